@@ -1,44 +1,512 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
-	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/gofiber/fiber/v2/middleware/recover"
 
+	"github.com/redhat-data-and-ai/naysayer/internal/archive"
+	"github.com/redhat-data-and-ai/naysayer/internal/audit"
+	"github.com/redhat-data-and-ai/naysayer/internal/cli"
+	"github.com/redhat-data-and-ai/naysayer/internal/commenttmpl"
 	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/digest"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/governance"
+	"github.com/redhat-data-and-ai/naysayer/internal/history"
+	"github.com/redhat-data-and-ai/naysayer/internal/idempotency"
+	"github.com/redhat-data-and-ai/naysayer/internal/instances"
+	"github.com/redhat-data-and-ai/naysayer/internal/jobs"
 	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/notify"
+	"github.com/redhat-data-and-ai/naysayer/internal/prevalidate"
+	"github.com/redhat-data-and-ai/naysayer/internal/projectfilter"
+	"github.com/redhat-data-and-ai/naysayer/internal/registry"
+	"github.com/redhat-data-and-ai/naysayer/internal/replay"
+	"github.com/redhat-data-and-ai/naysayer/internal/repoindex"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules"
+	"github.com/redhat-data-and-ai/naysayer/internal/server"
+	"github.com/redhat-data-and-ai/naysayer/internal/slo"
+	"github.com/redhat-data-and-ai/naysayer/internal/snapshot"
+	"github.com/redhat-data-and-ai/naysayer/internal/stats"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+	"github.com/redhat-data-and-ai/naysayer/internal/tokenauth"
+	"github.com/redhat-data-and-ai/naysayer/internal/tracing"
 	"github.com/redhat-data-and-ai/naysayer/internal/webhook"
+	"go.uber.org/zap"
 )
 
-func setupRoutes(app *fiber.App, cfg *config.Config) {
-	// Core middleware
+// sloCheckInterval is how often time-to-decision SLOs are checked for burn alerts
+const sloCheckInterval = 5 * time.Minute
+
+// governanceCheckInterval is how often the previous month's governance report is checked for publishing
+const governanceCheckInterval = time.Hour
+
+// digestCheckInterval is how often the day's manual review digest is checked for sending
+const digestCheckInterval = 10 * time.Minute
+
+// overrideCheckInterval is how often expired approve-until overrides are re-reviewed
+const overrideCheckInterval = 5 * time.Minute
+
+// newApp creates a Fiber app with the shared error handler
+func newApp() *fiber.App {
+	return fiber.New(fiber.Config{
+		DisableStartupMessage: true,
+		ErrorHandler: func(c *fiber.Ctx, err error) error {
+			logging.Error("Fiber error", zap.Error(err))
+			return c.Status(500).JSON(fiber.Map{
+				"error": "Internal server error",
+			})
+		},
+	})
+}
+
+// setupMiddleware installs the core middleware on an app
+func setupMiddleware(app *fiber.App) {
 	app.Use(recover.New())
-	app.Use(logger.New(logger.Config{
-		Format: "${time} ${status} - ${method} ${path} - ${latency}\n",
-	}))
+	app.Use(logging.Middleware())
+	app.Use(tracing.Middleware())
 	app.Use(cors.New())
+}
+
+// setupRoutes registers the webhook routes on app and the health and /api/v1 routes on
+// admin. Both are the same app unless a separate admin listener is configured. Webhook
+// handlers run on queue when asynchronous processing is enabled (non-nil queue).
+func setupRoutes(app, admin *fiber.App, cfg *config.Config, stateStore store.Store, snapshots *snapshot.Store, decisions *history.Store, queue *jobs.Queue) {
+	// Core middleware
+	setupMiddleware(app)
+	if admin != app {
+		setupMiddleware(admin)
+	}
+
+	// Security headers (and HSTS over HTTPS) on admin and reporting endpoints
+	admin.Use("/api/v1", server.SecurityHeaders(cfg.Server))
 
 	// Create handlers
-	dataProductConfigMrReviewHandler := webhook.NewDataProductConfigMrReviewHandler(cfg)
+	commentStats := stats.NewRecorder(stateStore)
+	commentStats.SetObjectives(stats.ObjectivesFromConfig(cfg.SLO))
+	byInstance := newInstanceHandlers(cfg, stateStore, snapshots, commentStats)
 	healthHandler := webhook.NewHealthHandler(cfg)
-	autoRebaseHandler := webhook.NewAutoRebaseHandler(cfg)
-	staleMRCleanupHandler := webhook.NewStaleMRCleanupHandler(cfg)
+	accessReviewHandler := webhook.NewAccessReviewHandler(cfg)
+	commentStatsHandler := webhook.NewCommentStatsHandler(commentStats)
+	metricsHandler := webhook.NewMetricsHandler(commentStats, cfg.SLO)
+	snapshotHandler := webhook.NewSnapshotHandler(snapshots)
+	historyHandler := webhook.NewHistoryHandler(decisions)
+	explainHandler := webhook.NewExplainHandler(stateStore)
+	rulesCatalogHandler := webhook.NewRulesCatalogHandler()
+	logLevelHandler := webhook.NewLogLevelHandler(cfg)
+	dependencyGraphHandler := webhook.NewDependencyGraphHandler(cfg)
+	replayGuard := replay.NewGuardFromConfig(cfg, stateStore)
+	deliveries := idempotency.NewCacheFromConfig(cfg.Idempotency, stateStore)
+	payloadArchive := archive.NewArchiveFromConfig(cfg.Archive, stateStore)
+	projects := projectfilter.Default()
 
 	// Health and monitoring routes
-	app.Get("/health", healthHandler.HandleHealth)
-	app.Get("/ready", healthHandler.HandleReady)
+	admin.Get("/health", healthHandler.HandleHealth)
+	admin.Get("/ready", healthHandler.HandleReady)
+	admin.Get("/readyz", healthHandler.HandleReadyz)
+	admin.Get("/metrics", metricsHandler.HandleMetrics)
+
+	// Latest rule evaluation of an MR, for authors diagnosing a decision
+	admin.Get("/decisions/:project_id/:mr_iid", explainHandler.HandleExplain)
+
+	// Webhook routes; unauthenticated deliveries and garbage payloads are rejected before
+	// the handlers parse them, deliveries for projects the subsystem does not handle are
+	// skipped, and deliveries GitLab retries get the original result
+	reviewKinds := []string{"merge_request"}
+	if cfg.Override.Enabled || cfg.ChatOps.Enabled {
+		reviewKinds = append(reviewKinds, "note")
+	}
+	app.Post("/dataverse-product-config-review",
+		byInstance.verifyToken(cfg, config.EndpointReview),
+		payloadArchive.Middleware(config.EndpointReview),
+		prevalidate.RulesFromConfig(cfg.Webhook, reviewKinds...).Middleware(),
+		projects.Middleware(config.EndpointReview),
+		deliveries.Middleware(config.EndpointReview),
+		queue.Async(config.EndpointReview, byInstance.review()))
+
+	// Auto-rebase route (generic, reusable), protected against replayed deliveries
+	app.Post("/auto-rebase",
+		byInstance.verifyToken(cfg, config.EndpointAutoRebase),
+		payloadArchive.Middleware(config.EndpointAutoRebase),
+		prevalidate.RulesFromConfig(cfg.Webhook, "push").Middleware(),
+		projects.Middleware(config.EndpointAutoRebase),
+		deliveries.Middleware(config.EndpointAutoRebase),
+		replayGuard.Middleware(), queue.Async(config.EndpointAutoRebase, byInstance.autoRebase()))
+
+	// Stale MR cleanup route; cleanup jobs post the same payload on every run, so its
+	// deliveries are not deduplicated
+	app.Post("/stale-mr-cleanup",
+		byInstance.verifyToken(cfg, config.EndpointStaleMRCleanup),
+		payloadArchive.Middleware(config.EndpointStaleMRCleanup),
+		prevalidate.RulesFromConfig(cfg.Webhook).Middleware(),
+		projects.Middleware(config.EndpointStaleMRCleanup),
+		replayGuard.Middleware(), queue.Async(config.EndpointStaleMRCleanup, byInstance.staleMRCleanup()))
+
+	// GitLab system hook route: onboards projects matching the configured path patterns and
+	// reviews their MRs without a webhook per project
+	if cfg.SystemHook.Enabled {
+		if len(cfg.SystemHook.ProjectPatterns) == 0 {
+			logging.Warn("System hooks enabled without SYSTEM_HOOK_PROJECT_PATTERNS; no project will be onboarded")
+		}
+		systemHookHandler := webhook.NewSystemHookHandler(
+			registry.NewRegistry(stateStore, cfg.SystemHook.ProjectPatterns), byInstance.review())
+		app.Post("/system-hook",
+			byInstance.verifyToken(cfg, config.EndpointSystemHook),
+			payloadArchive.Middleware(config.EndpointSystemHook),
+			projects.Middleware(config.EndpointReview),
+			deliveries.Middleware(config.EndpointSystemHook),
+			queue.Async(config.EndpointSystemHook, systemHookHandler.HandleWebhook))
+		admin.Get("/api/v1/projects", systemHookHandler.HandleListProjects)
+	}
+
+	// GitHub (Enterprise) pull request review and auto-rebase, verified by X-Hub-Signature-256
+	if cfg.GitHub.Enabled() {
+		githubHandler, err := webhook.NewGitHubHandler(cfg)
+		if err != nil {
+			logging.Error("GitHub webhooks disabled", zap.Error(err))
+		} else {
+			githubHandler.ReviewHandler().SetStateStore(stateStore)
+			githubHandler.ReviewHandler().SetStatsRecorder(commentStats)
+			githubHandler.RebaseHandler().SetStateStore(stateStore)
+			githubHandler.RebaseHandler().SetStatsRecorder(commentStats)
+			app.Post("/github/dataverse-product-config-review", githubHandler.HandleReview)
+			app.Post("/github/auto-rebase", githubHandler.HandleAutoRebase)
+			logging.Info("GitHub webhooks enabled", zap.String("base_url", cfg.GitHub.BaseURL))
+		}
+	}
+
+	// Status of webhook deliveries processed asynchronously
+	if queue != nil {
+		admin.Get("/jobs/:id", queue.HandleGet)
+	}
+
+	// Scrubbed webhook payloads for debugging
+	if payloadArchive != nil {
+		admin.Get("/api/v1/payloads/:id", payloadArchive.HandleGet)
+	}
+
+	// Access review export of UNMASKED grants
+	admin.Get("/api/v1/access-review/unmasked", accessReviewHandler.HandleUnmaskedGrants)
+
+	// Data product dependency graph from product.yaml consumers
+	admin.Get("/api/v1/dependency-graph", dependencyGraphHandler.HandleDependencyGraph)
+
+	// Bot comment and decision statistics
+	admin.Get("/api/v1/stats/comments", commentStatsHandler.HandleCommentStats)
+
+	// Evaluation snapshots for disputes and incident reviews
+	admin.Get("/api/v1/snapshots", snapshotHandler.HandleList)
+	admin.Get("/api/v1/snapshots/:id", snapshotHandler.HandleGet)
+	admin.Post("/api/v1/snapshots/:id/replay", snapshotHandler.HandleReplay)
+
+	// Persistent decision history for dashboards and idempotency checks
+	admin.Get("/api/v1/decisions", historyHandler.HandleListDecisions)
+	admin.Get("/api/v1/decisions/:id", historyHandler.HandleGetDecision)
+	admin.Get("/api/v1/actions", historyHandler.HandleListActions)
+
+	// Rules catalog with the activation status of scheduled rules
+	admin.Get("/api/v1/rules", rulesCatalogHandler.HandleCatalog)
+
+	// Runtime log level, reset to LOG_LEVEL on restart; changes require the dashboard token
+	admin.Get("/api/v1/log-level", logLevelHandler.HandleGet)
+	admin.Put("/api/v1/log-level", logLevelHandler.HandlePut)
+
+	// Read-only HTML dashboard
+	if cfg.Dashboard.Enabled {
+		if cfg.Dashboard.Token == "" {
+			logging.Error("Admin dashboard disabled: DASHBOARD_TOKEN is required")
+		} else {
+			admin.Get("/dashboard", server.SecurityHeaders(cfg.Server), webhook.NewDashboardHandler(cfg, decisions).HandleDashboard)
+			logging.Info("Admin dashboard enabled on /dashboard")
+		}
+	}
+}
+
+// gitlabHandlers are the webhook handlers talking to one GitLab instance
+type gitlabHandlers struct {
+	review         *webhook.DataProductConfigMrReviewHandler
+	autoRebase     *webhook.AutoRebaseHandler
+	staleMRCleanup *webhook.StaleMRCleanupHandler
+}
+
+// newGitLabHandlers creates the webhook handlers for the GitLab instance of cfg
+func newGitLabHandlers(cfg *config.Config, stateStore store.Store, snapshots *snapshot.Store, commentStats *stats.Recorder) gitlabHandlers {
+	review := webhook.NewDataProductConfigMrReviewHandler(cfg)
+	review.SetStateStore(stateStore)
+	review.SetStatsRecorder(commentStats)
+	review.SetSnapshotStore(snapshots)
+	autoRebase := webhook.NewAutoRebaseHandler(cfg)
+	autoRebase.SetStateStore(stateStore)
+	autoRebase.SetStatsRecorder(commentStats)
+	staleMRCleanup := webhook.NewStaleMRCleanupHandler(cfg)
+	staleMRCleanup.SetStatsRecorder(commentStats)
+	return gitlabHandlers{review: review, autoRebase: autoRebase, staleMRCleanup: staleMRCleanup}
+}
+
+// instanceHandlers routes webhook deliveries to the handlers of the GitLab instance they
+// came from
+type instanceHandlers struct {
+	router   *instances.Router
+	handlers map[string]gitlabHandlers
+}
+
+// newInstanceHandlers creates the handlers of the default GitLab instance and of every
+// additional instance. Additional instances keep their state below instances/<name>/ in
+// the state store, as their project IDs overlap with those of the default instance.
+func newInstanceHandlers(cfg *config.Config, stateStore store.Store, snapshots *snapshot.Store, commentStats *stats.Recorder) *instanceHandlers {
+	h := &instanceHandlers{
+		router:   instances.NewRouterFromConfig(cfg),
+		handlers: map[string]gitlabHandlers{instances.Default: newGitLabHandlers(cfg, stateStore, snapshots, commentStats)},
+	}
+	for _, instance := range cfg.GitLab.Instances {
+		instanceCfg, _ := cfg.ForGitLabInstance(instance.Name)
+		instanceStore := store.WithPrefix(stateStore, "instances/"+instance.Name+"/")
+		h.handlers[instance.Name] = newGitLabHandlers(instanceCfg, instanceStore, snapshots, commentStats)
+		logging.Info("GitLab instance enabled", zap.String("instance", instance.Name), zap.String("base_url", instance.BaseURL))
+	}
+	return h
+}
+
+// dispatch returns a handler calling the handler of the delivery's GitLab instance
+func (h *instanceHandlers) dispatch(handler func(gitlabHandlers) fiber.Handler) fiber.Handler {
+	routes := make(map[string]fiber.Handler, len(h.handlers))
+	for name, handlers := range h.handlers {
+		routes[name] = handler(handlers)
+	}
+	return h.router.Dispatch(routes)
+}
+
+// verifyToken returns the middleware checking the X-Gitlab-Token of deliveries to endpoint
+// against the webhook secret of the instance they are routed to
+func (h *instanceHandlers) verifyToken(cfg *config.Config, endpoint string) fiber.Handler {
+	if h.router == nil {
+		return tokenauth.NewVerifierFromConfig(cfg.Webhook, endpoint).Middleware()
+	}
+	verifiers := map[string]*tokenauth.Verifier{instances.Default: tokenauth.NewVerifierFromConfig(cfg.Webhook, endpoint)}
+	for _, instance := range cfg.GitLab.Instances {
+		instanceCfg, _ := cfg.ForGitLabInstance(instance.Name)
+		verifiers[instance.Name] = tokenauth.NewVerifierFromConfig(instanceCfg.Webhook, endpoint)
+	}
+	return tokenauth.InstanceMiddleware(func(c *fiber.Ctx) string {
+		return h.router.Resolve(c.Get(instances.Header), c.Body())
+	}, verifiers)
+}
+
+func (h *instanceHandlers) review() fiber.Handler {
+	return h.dispatch(func(handlers gitlabHandlers) fiber.Handler { return handlers.review.HandleWebhook })
+}
+
+func (h *instanceHandlers) autoRebase() fiber.Handler {
+	return h.dispatch(func(handlers gitlabHandlers) fiber.Handler { return handlers.autoRebase.HandleWebhook })
+}
+
+func (h *instanceHandlers) staleMRCleanup() fiber.Handler {
+	return h.dispatch(func(handlers gitlabHandlers) fiber.Handler { return handlers.staleMRCleanup.HandleWebhook })
+}
+
+// startBackgroundJobs starts periodic jobs and returns a function that stops them
+func startBackgroundJobs(cfg *config.Config, stateStore store.Store) func() {
+	var stops []func()
+
+	// Repository index snapshots for path-existence checks, one per GitLab instance as their
+	// project IDs overlap
+	if cfg.RepoIndex.Enabled {
+		interval := time.Duration(cfg.RepoIndex.RefreshIntervalMinutes) * time.Minute
+		idx := repoindex.NewIndex(gitlab.NewClientWithConfig(cfg), stateStore)
+		repoindex.SetDefault(idx)
+		idx.Start(interval)
+		stops = append(stops, idx.Stop)
+		for _, instance := range cfg.GitLab.Instances {
+			instanceCfg, _ := cfg.ForGitLabInstance(instance.Name)
+			instanceStore := store.WithPrefix(stateStore, "instances/"+instance.Name+"/")
+			instanceIdx := repoindex.NewIndex(gitlab.NewClientWithConfig(instanceCfg), instanceStore)
+			repoindex.SetForInstance(instance.Name, instanceIdx)
+			instanceIdx.Start(interval)
+			stops = append(stops, instanceIdx.Stop)
+		}
+		logging.Info("Repository index enabled", zap.Int("refresh_interval_minutes", cfg.RepoIndex.RefreshIntervalMinutes))
+	}
+
+	// Catch up on push events missed during downtime
+	if cfg.AutoRebase.Enabled && len(cfg.AutoRebase.CatchUpProjects) > 0 {
+		targets, err := webhook.ParseRebaseTargets(cfg.AutoRebase.CatchUpProjects)
+		if err != nil {
+			logging.Error("Invalid AUTO_REBASE_CATCHUP_PROJECTS", zap.Error(err))
+		} else {
+			processor := webhook.NewBacklogProcessor(webhook.NewAutoRebaseHandler(cfg), stateStore, targets)
+			processor.SetNotificationSink(notify.NewSinkFromConfig(cfg))
+			processor.Start(time.Duration(cfg.AutoRebase.CatchUpIntervalMinutes) * time.Minute)
+			stops = append(stops, processor.Stop)
+			logging.Info("Auto-rebase catch-up enabled", zap.Int("branches", len(targets)))
+		}
+	}
+
+	// Scheduled rebase passes and stale MR cleanups for projects without webhooks
+	if scheduler := newMaintenanceScheduler(cfg, stateStore); scheduler != nil {
+		scheduler.Start()
+		stops = append(stops, scheduler.Stop)
+	}
+
+	// Time-to-decision SLO burn alerts
+	recorder := stats.NewRecorder(stateStore)
+	recorder.SetObjectives(stats.ObjectivesFromConfig(cfg.SLO))
+	if monitor := slo.NewMonitorFromConfig(cfg, recorder); monitor != nil {
+		monitor.Start(sloCheckInterval)
+		stops = append(stops, monitor.Stop)
+		logging.Info("Time-to-decision SLO alerts enabled",
+			zap.Float64("alert_burn_rate", cfg.SLO.AlertBurnRate),
+			zap.Int("window_minutes", cfg.SLO.WindowMinutes))
+	}
 
-	// Webhook routes
-	app.Post("/dataverse-product-config-review", dataProductConfigMrReviewHandler.HandleWebhook)
+	// Monthly governance report publishing
+	if job := governance.NewJobFromConfig(cfg, recorder); job != nil {
+		job.Start(governanceCheckInterval)
+		stops = append(stops, job.Stop)
+		logging.Info("Governance reports enabled",
+			zap.Int("project_id", cfg.Governance.ProjectID),
+			zap.String("wiki_page", cfg.Governance.WikiPage),
+			zap.Int("snippet_id", cfg.Governance.SnippetID))
+	}
 
-	// Auto-rebase route (generic, reusable)
-	app.Post("/auto-rebase", autoRebaseHandler.HandleWebhook)
+	// Daily email digest of MRs waiting for manual review
+	if job, err := digest.NewJobFromConfig(cfg, stateStore, webhook.PendingManualReviews(stateStore)); err != nil {
+		logging.Error("Manual review digest disabled", zap.Error(err))
+	} else if job != nil {
+		job.Start(digestCheckInterval)
+		stops = append(stops, job.Stop)
+		logging.Info("Manual review digest enabled",
+			zap.Int("recipients", len(cfg.Digest.Recipients)),
+			zap.Int("hour", cfg.Digest.Hour))
+	}
 
-	// Stale MR cleanup route
-	app.Post("/stale-mr-cleanup", staleMRCleanupHandler.HandleWebhook)
+	// Re-review MRs whose approve-until override expired
+	if cfg.Override.Enabled {
+		reviewHandler := webhook.NewDataProductConfigMrReviewHandler(cfg)
+		reviewHandler.SetStatsRecorder(recorder)
+		reviewHandler.SetStateStore(stateStore)
+		if expirer := webhook.NewOverrideExpirer(reviewHandler); expirer != nil {
+			expirer.Start(overrideCheckInterval)
+			stops = append(stops, expirer.Stop)
+			logging.Info("Decision overrides enabled", zap.Int("max_days", cfg.Override.MaxDays))
+			if cfg.Override.Dir == "" {
+				logging.Warn("OVERRIDE_DIR is not set: overrides are lost on restart and will not expire")
+			}
+		}
+	}
+
+	return func() {
+		for _, stop := range stops {
+			stop()
+		}
+	}
+}
+
+// newMaintenanceScheduler creates the scheduler for AUTO_REBASE_SCHEDULES and
+// STALE_MR_SCHEDULES, or returns nil when nothing is scheduled
+func newMaintenanceScheduler(cfg *config.Config, stateStore store.Store) *webhook.Scheduler {
+	var tasks []webhook.ScheduledTask
+	var rebaseHandler *webhook.AutoRebaseHandler
+	var cleanupHandler *webhook.StaleMRCleanupHandler
+	recorder := stats.NewRecorder(stateStore)
+
+	if cfg.AutoRebase.Enabled && len(cfg.AutoRebase.Schedules) > 0 {
+		rebaseTasks, err := webhook.ParseScheduledTasks(webhook.TaskAutoRebase, cfg.AutoRebase.Schedules)
+		if err != nil {
+			logging.Error("Invalid AUTO_REBASE_SCHEDULES", zap.Error(err))
+		} else {
+			rebaseHandler = webhook.NewAutoRebaseHandler(cfg)
+			rebaseHandler.SetStateStore(stateStore)
+			rebaseHandler.SetStatsRecorder(recorder)
+			tasks = append(tasks, rebaseTasks...)
+		}
+	}
+	if len(cfg.StaleMR.Schedules) > 0 {
+		cleanupTasks, err := webhook.ParseScheduledTasks(webhook.TaskStaleMRCleanup, cfg.StaleMR.Schedules)
+		if err != nil {
+			logging.Error("Invalid STALE_MR_SCHEDULES", zap.Error(err))
+		} else {
+			cleanupHandler = webhook.NewStaleMRCleanupHandler(cfg)
+			cleanupHandler.SetStatsRecorder(recorder)
+			tasks = append(tasks, cleanupTasks...)
+		}
+	}
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	logging.Info("Scheduled maintenance enabled", zap.Int("tasks", len(tasks)))
+	return webhook.NewScheduler(rebaseHandler, cleanupHandler, tasks)
+}
+
+// validateInstanceWebhookSecrets requires a webhook secret for every additional GitLab
+// instance when deliveries of the default instance are verified, as a delivery routed to an
+// instance without one would not be verified at all
+func validateInstanceWebhookSecrets(cfg *config.Config) error {
+	if !cfg.Webhook.Verified() {
+		return nil
+	}
+	for _, instance := range cfg.GitLab.Instances {
+		if instance.WebhookSecret == "" {
+			return fmt.Errorf("GitLab instance %q has no webhook secret (GITLAB_INSTANCE_%s_WEBHOOK_SECRET) while WEBHOOK_SECRET is set",
+				instance.Name, strings.ToUpper(strings.ReplaceAll(instance.Name, "-", "_")))
+		}
+	}
+	return nil
+}
+
+// verifyBotIdentities checks at startup that the token of every naysayer function acts
+// as a configured bot identity. A mismatch is a misconfiguration that would break
+// recognising naysayer's own comments; an unreachable GitLab only logs a warning.
+func verifyBotIdentities(ctx context.Context, cfg *config.Config) error {
+	if !cfg.GitLab.HasBotIdentities() || !cfg.HasGitLabToken() {
+		return nil
+	}
+	verified := make(map[string]bool)
+	for _, function := range []string{config.EndpointReview, config.EndpointAutoRebase, config.EndpointStaleMRCleanup} {
+		gitlabConfig := cfg.GitLabFor(function)
+		token := gitlabConfig.Token + "\x00" + gitlabConfig.TokenFile + "\x00" + gitlabConfig.TokenSource + "\x00" + gitlabConfig.OAuth.ClientID
+		if verified[token] {
+			continue
+		}
+		verified[token] = true
+
+		user, err := gitlab.NewClient(gitlabConfig).VerifyBotIdentity(ctx)
+		switch {
+		case errors.Is(err, gitlab.ErrBotIdentityMismatch):
+			return fmt.Errorf("%s token: %w", function, err)
+		case err != nil:
+			logging.Warn("Could not verify the bot identity of a token", zap.String("token", function), zap.Error(err))
+		default:
+			logging.Info("Token acts as bot",
+				zap.String("token", function),
+				zap.String("bot", user.Username),
+				zap.Int("bot_id", user.ID))
+		}
+	}
+	return nil
+}
+
+// logDeprecations warns about legacy configuration keys still in use
+func logDeprecations(deprecations []config.Deprecation) {
+	for _, d := range deprecations {
+		if d.Ignored {
+			logging.Warn("Deprecated configuration key is ignored because its replacement is set; remove it",
+				zap.String("key", d.Old),
+				zap.String("replacement", d.New))
+			continue
+		}
+		logging.Warn("Deprecated configuration key is mapped to its replacement; rename it, e.g. with `naysayer config migrate`",
+			zap.String("key", d.Old),
+			zap.String("replacement", d.New))
+	}
 }
 
 func main() {
@@ -51,34 +519,175 @@ func main() {
 		logLevel = "info"
 	}
 	logging.InitLogger(logLevel, "NAYSAYER")
+	logDeprecations(cfg.Deprecations)
+
+	// One-shot subcommands (e.g. access-review) run instead of the server
+	if len(os.Args) > 1 && cli.IsCommand(os.Args[1]) {
+		os.Exit(cli.Run(os.Args[1:], cfg, os.Stdout, os.Stderr))
+	}
+
+	// Validate listener and TLS configuration before starting background jobs
+	if err := server.Validate(cfg.Server); err != nil {
+		logging.Error("Invalid server configuration", zap.Error(err))
+		os.Exit(1)
+	}
 
 	// Validate GitLab configuration
+	if err := gitlab.ValidateConfig(cfg.GitLab); err != nil {
+		logging.Error("Invalid GitLab configuration", zap.Error(err))
+		os.Exit(1)
+	}
+	for _, instance := range cfg.GitLab.Instances {
+		instanceCfg, _ := cfg.ForGitLabInstance(instance.Name)
+		if err := gitlab.ValidateConfig(instanceCfg.GitLab); err != nil {
+			logging.Error("Invalid configuration of GitLab instance", zap.String("instance", instance.Name), zap.Error(err))
+			os.Exit(1)
+		}
+	}
+	if err := validateInstanceWebhookSecrets(cfg); err != nil {
+		logging.Error("Invalid configuration of GitLab instance", zap.Error(err))
+		os.Exit(1)
+	}
+
+	// Fail fast on an invalid rules.yaml instead of silently skipping its rules per MR
+	if problems := rules.ValidateRuleConfigFile(rules.RulesConfigPath, rules.GetGlobalRegistry()); len(problems) > 0 {
+		for _, problem := range problems {
+			logging.Error("Invalid rule configuration", zap.String("path", rules.RulesConfigPath), zap.Error(problem))
+		}
+		os.Exit(1)
+	}
+
 	if !cfg.HasGitLabToken() {
 		logging.Warn("GITLAB_TOKEN not set - file analysis will be limited")
 	}
+	if err := verifyBotIdentities(context.Background(), cfg); err != nil {
+		logging.Error("Invalid bot identity configuration", zap.Error(err))
+		os.Exit(1)
+	}
 
-	// Create Fiber app
-	app := fiber.New(fiber.Config{
-		DisableStartupMessage: true,
-		ErrorHandler: func(c *fiber.Ctx, err error) error {
-			logging.Error("Fiber error: %v", err)
-			return c.Status(500).JSON(fiber.Map{
-				"error": "Internal server error",
-			})
-		},
-	})
+	// Optional allowlist, denylist and per-project subsystems for group webhooks
+	projects, err := projectfilter.NewFilterFromConfig(cfg)
+	if err != nil {
+		logging.Error("Invalid project filter configuration", zap.Error(err))
+		os.Exit(1)
+	}
+	projectfilter.SetDefault(projects)
+
+	// Optional OpenTelemetry traces of webhooks, rule evaluation and GitLab calls
+	if tracer := tracing.NewTracerFromConfig(cfg.Tracing); tracer != nil {
+		tracing.SetDefault(tracer)
+		defer func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			if err := tracer.Shutdown(ctx); err != nil {
+				logging.Warn("Failed to export remaining trace spans", zap.Error(err))
+			}
+		}()
+		logging.Info("Tracing enabled",
+			zap.String("otlp_endpoint", cfg.Tracing.Endpoint),
+			zap.Float64("sample_ratio", cfg.Tracing.SampleRatio))
+	}
+
+	// Shared state for background jobs
+	stateStore := store.NewMemoryStore()
+	stopJobs := startBackgroundJobs(cfg, stateStore)
+	defer stopJobs()
+
+	// Optional evaluation snapshots, pruned hourly
+	snapshots, err := snapshot.NewStoreFromConfig(cfg, stateStore)
+	if err != nil {
+		logging.Error("Invalid evaluation snapshot configuration", zap.Error(err))
+		os.Exit(1)
+	}
+	if snapshots != nil {
+		snapshots.Start(time.Hour)
+		defer snapshots.Stop()
+		logging.Info("Evaluation snapshots enabled", zap.Bool("encrypted", snapshots.Encrypted()))
+	}
+
+	// Optional structured audit log of decisions, rebases and closures
+	auditLogger, err := audit.NewLoggerFromConfig(cfg.Audit)
+	if err != nil {
+		logging.Error("Invalid audit log configuration", zap.Error(err))
+		os.Exit(1)
+	}
+	var recorders []audit.Recorder
+	if auditLogger != nil {
+		recorders = append(recorders, auditLogger)
+		defer func() { _ = auditLogger.Close() }()
+		logging.Info("Audit log enabled", zap.String("sink", cfg.Audit.Sink))
+	}
+
+	// Optional persistent history of decisions and actions, fed by the same audit events
+	decisions, err := history.NewStoreFromConfig(cfg.History)
+	if err != nil {
+		logging.Error("Invalid decision history configuration", zap.Error(err))
+		os.Exit(1)
+	}
+	if decisions != nil {
+		recorders = append(recorders, decisions)
+		defer func() { _ = decisions.Close() }()
+		logging.Info("Decision history enabled", zap.String("dialect", decisions.Dialect()))
+	}
+	audit.SetDefault(audit.Multi(recorders...))
+
+	// Operator-provided comment templates
+	if cfg.Comments.TemplatesDir != "" {
+		templates, err := commenttmpl.Load(cfg.Comments.TemplatesDir)
+		if err != nil {
+			logging.Error("Comment templates disabled, using built-in comments", zap.Error(err))
+		} else {
+			commenttmpl.SetDefault(templates)
+			logging.Info("Loaded comment templates",
+				zap.Int("templates", templates.Len()),
+				zap.String("dir", cfg.Comments.TemplatesDir))
+		}
+	}
+
+	// In-process cache of GitLab file contents shared by all clients
+	if cfg.GitLab.FileCacheSize > 0 {
+		gitlab.SetDefaultFileCache(gitlab.NewFileCache(cfg.GitLab.FileCacheSize, time.Duration(cfg.GitLab.FileCacheTTLSeconds)*time.Second))
+	}
+
+	// Optional asynchronous webhook processing; queued deliveries finish before shutdown
+	queue := jobs.NewQueueFromConfig(cfg.Jobs, stateStore)
+	if queue != nil {
+		queue.Start()
+		defer queue.Stop()
+		logging.Info("Asynchronous webhook processing enabled",
+			zap.Int("workers", cfg.Jobs.Workers),
+			zap.Int("queue_size", cfg.Jobs.QueueSize))
+	}
+
+	// Create Fiber apps; admin endpoints share the webhook app unless ADMIN_PORT is set
+	app := newApp()
+	admin := app
+	if cfg.Server.HasAdminListener() {
+		admin = newApp()
+	}
 
 	// Add routes
-	setupRoutes(app, cfg)
+	setupRoutes(app, admin, cfg, stateStore, snapshots, decisions, queue)
+
+	if admin != app {
+		logging.Info("Admin endpoints listening", zap.String("address", server.AdminAddress(cfg.Server)))
+		go func() {
+			if err := server.ServeAdmin(admin, cfg.Server); err != nil {
+				logging.Error("Failed to start admin server", zap.Error(err))
+				os.Exit(1)
+			}
+		}()
+	}
 
 	// Start server
-	port := cfg.Server.Port
-	logging.Info("NAYSAYER Webhook starting on port %s", port)
-	logging.Info("Analysis mode: %s", cfg.AnalysisMode())
-	logging.Info("Webhook security: %s", cfg.WebhookSecurityMode())
+	logging.Info("NAYSAYER Webhook starting",
+		zap.String("address", server.Address(cfg.Server)),
+		zap.String("scheme", server.Scheme(cfg.Server)))
+	logging.Info("Analysis mode", zap.String("mode", cfg.AnalysisMode()))
+	logging.Info("Webhook security", zap.String("mode", cfg.WebhookSecurityMode()))
 
-	if err := app.Listen(":" + port); err != nil {
-		logging.Error("Failed to start server: %v", err)
+	if err := server.Serve(app, cfg.Server); err != nil {
+		logging.Error("Failed to start server", zap.Error(err))
 		os.Exit(1)
 	}
 }
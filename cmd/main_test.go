@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -8,6 +9,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
@@ -16,6 +18,12 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/idempotency"
+	"github.com/redhat-data-and-ai/naysayer/internal/instances"
+	"github.com/redhat-data-and-ai/naysayer/internal/jobs"
+	"github.com/redhat-data-and-ai/naysayer/internal/projectfilter"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+	"github.com/redhat-data-and-ai/naysayer/internal/tokenauth"
 	"github.com/redhat-data-and-ai/naysayer/internal/webhook"
 )
 
@@ -428,3 +436,328 @@ func TestApplication_Middleware_Integration(t *testing.T) {
 	_ = json.Unmarshal(body, &health)
 	assert.Equal(t, "healthy", health["status"])
 }
+
+func TestSetupRoutes_SeparateAdminListener(t *testing.T) {
+	setupTestRulesFile()
+	t.Cleanup(cleanupTestRulesFile)
+
+	cfg := &config.Config{
+		GitLab: config.GitLabConfig{BaseURL: "https://gitlab.example.com", Token: "test-token"},
+		Server: config.ServerConfig{Port: "3000", AdminPort: "9090"},
+	}
+	app, admin := newApp(), newApp()
+	setupRoutes(app, admin, cfg, store.NewMemoryStore(), nil, nil, nil)
+
+	routes := func(a *fiber.App) map[string]bool {
+		found := make(map[string]bool)
+		for _, route := range a.GetRoutes(true) {
+			found[route.Method+" "+route.Path] = true
+		}
+		return found
+	}
+
+	public := routes(app)
+	assert.True(t, public["POST /dataverse-product-config-review"])
+	assert.True(t, public["POST /auto-rebase"])
+	assert.False(t, public["GET /health"])
+	assert.False(t, public["GET /api/v1/stats/comments"])
+
+	internal := routes(admin)
+	assert.True(t, internal["GET /health"])
+	assert.True(t, internal["GET /ready"])
+	assert.True(t, internal["GET /api/v1/snapshots"])
+	assert.True(t, internal["GET /api/v1/decisions"])
+	assert.True(t, internal["GET /decisions/:project_id/:mr_iid"])
+	assert.False(t, public["GET /api/v1/decisions"])
+	assert.False(t, internal["POST /dataverse-product-config-review"])
+
+	resp, err := admin.Test(httptest.NewRequest("GET", "/api/v1/stats/comments", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, "nosniff", resp.Header.Get("X-Content-Type-Options"))
+}
+
+func TestSetupRoutes_AsyncJobQueue(t *testing.T) {
+	setupTestRulesFile()
+	t.Cleanup(cleanupTestRulesFile)
+
+	cfg := &config.Config{
+		GitLab: config.GitLabConfig{BaseURL: "https://gitlab.example.com", Token: "test-token"},
+		Server: config.ServerConfig{Port: "3000"},
+	}
+	stateStore := store.NewMemoryStore()
+	queue := jobs.NewQueue(stateStore, 1, 10, time.Hour)
+	queue.Start()
+	defer queue.Stop()
+
+	app := newApp()
+	setupRoutes(app, app, cfg, stateStore, nil, nil, queue)
+
+	req := httptest.NewRequest("POST", "/stale-mr-cleanup", strings.NewReader(`{"project_id": 1}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusAccepted, resp.StatusCode)
+
+	var accepted map[string]interface{}
+	body, _ := io.ReadAll(resp.Body)
+	assert.NoError(t, json.Unmarshal(body, &accepted))
+	statusURL, _ := accepted["status_url"].(string)
+	assert.Equal(t, statusURL, resp.Header.Get("Location"))
+
+	resp, err = app.Test(httptest.NewRequest("GET", statusURL, nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/jobs/unknown", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
+
+func TestSetupRoutes_PayloadArchive(t *testing.T) {
+	setupTestRulesFile()
+	t.Cleanup(cleanupTestRulesFile)
+
+	cfg := &config.Config{
+		GitLab:  config.GitLabConfig{BaseURL: "https://gitlab.example.com", Token: "test-token"},
+		Server:  config.ServerConfig{Port: "3000"},
+		Archive: config.ArchiveConfig{Enabled: true, RetentionDays: 1, ScrubKeys: []string{"token"}, ScrubEmails: true},
+	}
+	app := newApp()
+	setupRoutes(app, app, cfg, store.NewMemoryStore(), nil, nil, nil)
+
+	req := httptest.NewRequest("POST", "/stale-mr-cleanup", strings.NewReader(`{"project_id": 1, "user_email": "me@example.com"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Gitlab-Event-UUID", "cleanup-1")
+	_, err := app.Test(req)
+	assert.NoError(t, err)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/payloads/cleanup-1", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	body, _ := io.ReadAll(resp.Body)
+	assert.Contains(t, string(body), `"user_email":"[EMAIL]"`)
+	assert.NotContains(t, string(body), "me@example.com")
+}
+
+func TestSetupRoutes_ProjectFilter(t *testing.T) {
+	setupTestRulesFile()
+	t.Cleanup(cleanupTestRulesFile)
+
+	filter, err := projectfilter.NewFilter(config.ProjectFilterConfig{Deny: []string{"^data/archive/"}}, nil)
+	assert.NoError(t, err)
+	projectfilter.SetDefault(filter)
+	defer projectfilter.SetDefault(nil)
+
+	cfg := &config.Config{
+		GitLab: config.GitLabConfig{BaseURL: "https://gitlab.example.com", Token: "test-token"},
+		Server: config.ServerConfig{Port: "3000"},
+	}
+	app := newApp()
+	setupRoutes(app, app, cfg, store.NewMemoryStore(), nil, nil, nil)
+
+	req := httptest.NewRequest("POST", "/auto-rebase",
+		strings.NewReader(`{"object_kind":"push","ref":"refs/heads/main","project":{"id":5,"path_with_namespace":"data/archive/old"}}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	body, _ := io.ReadAll(resp.Body)
+	assert.Contains(t, string(body), projectfilter.ReasonDenied)
+}
+
+func TestSetupRoutes_GitLabInstances(t *testing.T) {
+	setupTestRulesFile()
+	t.Cleanup(cleanupTestRulesFile)
+
+	gitlabServer := func(hits *int, tokens *[]string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			*hits++
+			*tokens = append(*tokens, r.Header.Get("Authorization"))
+			_, _ = w.Write([]byte(`[]`))
+		}))
+	}
+	var defaultHits, onpremHits int
+	var defaultTokens, onpremTokens []string
+	defaultServer := gitlabServer(&defaultHits, &defaultTokens)
+	defer defaultServer.Close()
+	onpremServer := gitlabServer(&onpremHits, &onpremTokens)
+	defer onpremServer.Close()
+
+	cfg := &config.Config{
+		GitLab: config.GitLabConfig{
+			BaseURL:   defaultServer.URL,
+			Token:     "default-token",
+			Instances: []config.GitLabInstance{{Name: "onprem", BaseURL: onpremServer.URL, Token: "onprem-token"}},
+		},
+		Server:  config.ServerConfig{Port: "3000"},
+		StaleMR: config.StaleMRConfig{ClosureDays: 30},
+	}
+	app := newApp()
+	setupRoutes(app, app, cfg, store.NewMemoryStore(), nil, nil, nil)
+
+	cleanup := func(instance string) {
+		req := httptest.NewRequest("POST", "/stale-mr-cleanup", strings.NewReader(`{"project_id": 1}`))
+		req.Header.Set("Content-Type", "application/json")
+		if instance != "" {
+			req.Header.Set(instances.Header, instance)
+		}
+		_, err := app.Test(req)
+		assert.NoError(t, err)
+	}
+
+	cleanup(onpremServer.URL)
+	assert.Zero(t, defaultHits)
+	assert.Positive(t, onpremHits)
+	assert.Contains(t, onpremTokens, "Bearer onprem-token")
+
+	cleanup("")
+	assert.Positive(t, defaultHits)
+	assert.NotContains(t, defaultTokens, "Bearer onprem-token")
+}
+
+func TestSetupRoutes_InstanceWebhookSecrets(t *testing.T) {
+	setupTestRulesFile()
+	t.Cleanup(cleanupTestRulesFile)
+
+	cfg := &config.Config{
+		GitLab: config.GitLabConfig{
+			BaseURL:   "http://127.0.0.1:1",
+			Token:     "default-token",
+			Instances: []config.GitLabInstance{{Name: "onprem", BaseURL: "https://onprem.example.com", Token: "onprem-token", WebhookSecret: "onprem-secret"}},
+		},
+		Server:  config.ServerConfig{Port: "3000"},
+		Webhook: config.WebhookConfig{Secret: "default-secret"},
+		StaleMR: config.StaleMRConfig{ClosureDays: 30},
+	}
+	app := newApp()
+	setupRoutes(app, app, cfg, store.NewMemoryStore(), nil, nil, nil)
+
+	cleanup := func(instance, token string) int {
+		req := httptest.NewRequest("POST", "/stale-mr-cleanup", strings.NewReader(`{"project_id": 1}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(tokenauth.TokenHeader, token)
+		if instance != "" {
+			req.Header.Set(instances.Header, instance)
+		}
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		return resp.StatusCode
+	}
+
+	assert.Equal(t, fiber.StatusUnauthorized, cleanup("onprem", "default-secret"), "the default secret is not valid for another instance")
+	assert.Equal(t, fiber.StatusUnauthorized, cleanup("", "onprem-secret"))
+	assert.NotEqual(t, fiber.StatusUnauthorized, cleanup("", "default-secret"))
+}
+
+func TestValidateInstanceWebhookSecrets(t *testing.T) {
+	cfg := &config.Config{GitLab: config.GitLabConfig{Instances: []config.GitLabInstance{{Name: "lab-two"}}}}
+	assert.NoError(t, validateInstanceWebhookSecrets(cfg), "deliveries are not verified at all")
+
+	cfg.Webhook.EndpointSecrets = map[string]string{config.EndpointReview: "review-secret"}
+	err := validateInstanceWebhookSecrets(cfg)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "GITLAB_INSTANCE_LAB_TWO_WEBHOOK_SECRET")
+
+	cfg.GitLab.Instances[0].WebhookSecret = "lab-secret"
+	assert.NoError(t, validateInstanceWebhookSecrets(cfg))
+}
+
+func TestSetupRoutes_Idempotency(t *testing.T) {
+	setupTestRulesFile()
+	t.Cleanup(cleanupTestRulesFile)
+
+	cfg := &config.Config{
+		GitLab:      config.GitLabConfig{BaseURL: "https://gitlab.example.com", Token: "test-token"},
+		Server:      config.ServerConfig{Port: "3000"},
+		Idempotency: config.IdempotencyConfig{Enabled: true, CacheSize: 10, TTLMinutes: 60},
+	}
+	stateStore := store.NewMemoryStore()
+	queue := jobs.NewQueue(stateStore, 1, 10, time.Hour)
+	queue.Start()
+	defer queue.Stop()
+
+	app := newApp()
+	setupRoutes(app, app, cfg, stateStore, nil, nil, queue)
+
+	deliver := func(path, body, uuid string) *http.Response {
+		req := httptest.NewRequest("POST", path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if uuid != "" {
+			req.Header.Set("X-Gitlab-Event-UUID", uuid)
+		}
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		return resp
+	}
+
+	// The retry gets the job of the first delivery instead of queueing another one
+	push := `{"object_kind": "push", "ref": "refs/heads/main", "project": {"id": 1}}`
+	first := deliver("/auto-rebase", push, "rebase-retry")
+	assert.Equal(t, fiber.StatusAccepted, first.StatusCode)
+	assert.Empty(t, first.Header.Get(idempotency.ReplayedHeader))
+	retry := deliver("/auto-rebase", push, "rebase-retry")
+	assert.Equal(t, fiber.StatusAccepted, retry.StatusCode)
+	assert.Equal(t, "true", retry.Header.Get(idempotency.ReplayedHeader))
+	assert.Equal(t, first.Header.Get("Location"), retry.Header.Get("Location"))
+
+	// Cleanup jobs post the same payload on every run; each run is queued
+	first = deliver("/stale-mr-cleanup", `{"project_id": 1}`, "")
+	again := deliver("/stale-mr-cleanup", `{"project_id": 1}`, "")
+	assert.Equal(t, fiber.StatusAccepted, first.StatusCode)
+	assert.Equal(t, fiber.StatusAccepted, again.StatusCode)
+	assert.Empty(t, again.Header.Get(idempotency.ReplayedHeader))
+	assert.NotEqual(t, first.Header.Get("Location"), again.Header.Get("Location"))
+}
+
+func TestSetupRoutes_SystemHook(t *testing.T) {
+	setupTestRulesFile()
+	t.Cleanup(cleanupTestRulesFile)
+
+	cfg := &config.Config{
+		GitLab:     config.GitLabConfig{BaseURL: "https://gitlab.example.com", Token: "test-token"},
+		Server:     config.ServerConfig{Port: "3000", AdminPort: "9090"},
+		SystemHook: config.SystemHookConfig{Enabled: true, ProjectPatterns: []string{"dataverse/*"}},
+	}
+	app, admin := newApp(), newApp()
+	setupRoutes(app, admin, cfg, store.NewMemoryStore(), nil, nil, nil)
+
+	req := httptest.NewRequest("POST", "/system-hook", strings.NewReader(`{"event_name": "project_create", "project_id": 74, "path_with_namespace": "dataverse/dataverse-marts"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	resp, err = admin.Test(httptest.NewRequest("GET", "/api/v1/projects", nil))
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	assert.Contains(t, string(body), `"path":"dataverse/dataverse-marts"`)
+}
+
+func TestVerifyBotIdentities(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Header.Get("Authorization") {
+		case "Bearer review-token":
+			_, _ = w.Write([]byte(`{"id": 101, "username": "naysayer-review"}`))
+		case "Bearer rebase-token":
+			_, _ = w.Write([]byte(`{"id": 102, "username": "naysayer-rebase"}`))
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{GitLab: config.GitLabConfig{BaseURL: server.URL, Token: "review-token"}}
+	assert.NoError(t, verifyBotIdentities(context.Background(), cfg), "nothing to verify without configured identities")
+
+	cfg.GitLab.BotUsernames = []string{"naysayer-review", "naysayer-rebase"}
+	cfg.AutoRebase.RepositoryToken = "rebase-token"
+	assert.NoError(t, verifyBotIdentities(context.Background(), cfg))
+
+	cfg.GitLab.GitlabStaleMRToken = "unreachable-token"
+	assert.NoError(t, verifyBotIdentities(context.Background(), cfg), "API errors only log a warning")
+
+	cfg.GitLab.BotUsernames = []string{"naysayer-review"}
+	err := verifyBotIdentities(context.Background(), cfg)
+	assert.ErrorContains(t, err, "auto-rebase token")
+	assert.ErrorContains(t, err, "naysayer-rebase")
+}
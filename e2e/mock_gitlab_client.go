@@ -1,10 +1,12 @@
 package e2e
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
@@ -26,7 +28,9 @@ type MockGitLabClient struct {
 	// File changes (generated from before/after comparison)
 	fileChanges []gitlab.FileChange
 
-	// Captured interactions for validation
+	// Captured interactions for validation, guarded by mu since rule evaluation fetches
+	// files from several goroutines
+	mu                sync.Mutex
 	CapturedComments  []CapturedComment
 	CapturedApprovals []CapturedApproval
 	FetchedFiles      []string
@@ -85,11 +89,13 @@ func (m *MockGitLabClient) SetFileChanges(changes []gitlab.FileChange) {
 // GetFileContent reads file content from the appropriate directory based on the ref (branch)
 func (m *MockGitLabClient) GetFileContent(projectID int, filePath, ref string) (string, error) {
 	// Track which files were fetched
+	m.mu.Lock()
 	m.FetchedFiles = append(m.FetchedFiles, filePath)
+	m.mu.Unlock()
 
 	// Fork MR: source branch exists only on ForkSourceProjectID (GitLab returns 404 for target project + source ref).
 	if m.ForkSourceProjectID != 0 && ref == m.sourceBranch && projectID != m.ForkSourceProjectID {
-		return "", fmt.Errorf("file not found: %s (ref: %s)", filePath, ref)
+		return "", fmt.Errorf("file not found: %s (ref: %s): %w", filePath, ref, gitlab.ErrNotFound)
 	}
 
 	// Determine which directory to read from based on branch
@@ -105,14 +111,14 @@ func (m *MockGitLabClient) GetFileContent(projectID int, filePath, ref string) (
 	fullPath := filepath.Join(baseDir, filePath)
 	content, err := os.ReadFile(fullPath) // #nosec G304 - reading test fixture files
 	if err != nil {
-		return "", fmt.Errorf("file not found: %s (ref: %s)", filePath, ref)
+		return "", fmt.Errorf("file not found: %s (ref: %s): %w", filePath, ref, gitlab.ErrNotFound)
 	}
 
 	return string(content), nil
 }
 
 // FetchMRChanges returns the file changes set via SetFileChanges
-func (m *MockGitLabClient) FetchMRChanges(projectID, mrID int) ([]gitlab.FileChange, error) {
+func (m *MockGitLabClient) FetchMRChanges(ctx context.Context, projectID, mrID int) ([]gitlab.FileChange, error) {
 	if m.fileChanges == nil {
 		return []gitlab.FileChange{}, nil
 	}
@@ -120,7 +126,9 @@ func (m *MockGitLabClient) FetchMRChanges(projectID, mrID int) ([]gitlab.FileCha
 }
 
 // AddMRComment captures the comment instead of posting to GitLab
-func (m *MockGitLabClient) AddMRComment(projectID, mrID int, comment string) error {
+func (m *MockGitLabClient) AddMRComment(ctx context.Context, projectID, mrID int, comment string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.CapturedComments = append(m.CapturedComments, CapturedComment{
 		ProjectID: projectID,
 		MRIID:     mrID,
@@ -131,7 +139,9 @@ func (m *MockGitLabClient) AddMRComment(projectID, mrID int, comment string) err
 }
 
 // AddOrUpdateMRComment captures the comment with a tag
-func (m *MockGitLabClient) AddOrUpdateMRComment(projectID, mrID int, comment string, tag string) error {
+func (m *MockGitLabClient) AddOrUpdateMRComment(ctx context.Context, projectID, mrID int, comment string, tag string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.CapturedComments = append(m.CapturedComments, CapturedComment{
 		ProjectID: projectID,
 		MRIID:     mrID,
@@ -142,7 +152,9 @@ func (m *MockGitLabClient) AddOrUpdateMRComment(projectID, mrID int, comment str
 }
 
 // ApproveMR captures the approval request
-func (m *MockGitLabClient) ApproveMR(projectID, mrID int) error {
+func (m *MockGitLabClient) ApproveMR(ctx context.Context, projectID, mrID int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.CapturedApprovals = append(m.CapturedApprovals, CapturedApproval{
 		ProjectID: projectID,
 		MRIID:     mrID,
@@ -152,7 +164,9 @@ func (m *MockGitLabClient) ApproveMR(projectID, mrID int) error {
 }
 
 // ApproveMRWithMessage captures the approval with a message
-func (m *MockGitLabClient) ApproveMRWithMessage(projectID, mrID int, message string) error {
+func (m *MockGitLabClient) ApproveMRWithMessage(ctx context.Context, projectID, mrID int, message string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.CapturedApprovals = append(m.CapturedApprovals, CapturedApproval{
 		ProjectID: projectID,
 		MRIID:     mrID,
@@ -162,7 +176,7 @@ func (m *MockGitLabClient) ApproveMRWithMessage(projectID, mrID int, message str
 }
 
 // ResetNaysayerApproval is a no-op for mock client
-func (m *MockGitLabClient) ResetNaysayerApproval(projectID, mrID int) error {
+func (m *MockGitLabClient) ResetNaysayerApproval(ctx context.Context, projectID, mrID int) error {
 	// In tests, we don't need to reset approvals
 	// Just return success
 	return nil
@@ -170,6 +184,8 @@ func (m *MockGitLabClient) ResetNaysayerApproval(projectID, mrID int) error {
 
 // GetLatestCommentByTag retrieves the most recent comment with a specific tag
 func (m *MockGitLabClient) GetLatestCommentByTag(tag string) (string, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	// Search in reverse to get the latest
 	for i := len(m.CapturedComments) - 1; i >= 0; i-- {
 		if m.CapturedComments[i].Tag == tag {
@@ -181,6 +197,8 @@ func (m *MockGitLabClient) GetLatestCommentByTag(tag string) (string, bool) {
 
 // GetAllComments returns all captured comments
 func (m *MockGitLabClient) GetAllComments() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	comments := make([]string, len(m.CapturedComments))
 	for i, captured := range m.CapturedComments {
 		comments[i] = captured.Comment
@@ -190,11 +208,15 @@ func (m *MockGitLabClient) GetAllComments() []string {
 
 // WasApproved returns true if ApproveMR was called
 func (m *MockGitLabClient) WasApproved() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	return len(m.CapturedApprovals) > 0
 }
 
 // GetApprovalMessage returns the approval message if approved
 func (m *MockGitLabClient) GetApprovalMessage() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	if len(m.CapturedApprovals) > 0 {
 		return m.CapturedApprovals[0].Message
 	}
@@ -203,6 +225,8 @@ func (m *MockGitLabClient) GetApprovalMessage() string {
 
 // Reset clears all captured data
 func (m *MockGitLabClient) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.CapturedComments = []CapturedComment{}
 	m.CapturedApprovals = []CapturedApproval{}
 	m.FetchedFiles = []string{}
@@ -210,6 +234,8 @@ func (m *MockGitLabClient) Reset() {
 
 // ValidateFileWasFetched checks if a specific file was fetched
 func (m *MockGitLabClient) ValidateFileWasFetched(filePath string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	for _, fetched := range m.FetchedFiles {
 		if fetched == filePath {
 			return true
@@ -220,16 +246,22 @@ func (m *MockGitLabClient) ValidateFileWasFetched(filePath string) bool {
 
 // GetCommentCount returns the number of captured comments
 func (m *MockGitLabClient) GetCommentCount() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	return len(m.CapturedComments)
 }
 
 // GetApprovalCount returns the number of captured approvals
 func (m *MockGitLabClient) GetApprovalCount() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	return len(m.CapturedApprovals)
 }
 
 // ContainsCommentPhrase checks if any comment contains a specific phrase
 func (m *MockGitLabClient) ContainsCommentPhrase(phrase string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	for _, comment := range m.CapturedComments {
 		if strings.Contains(comment.Comment, phrase) {
 			return true
@@ -239,7 +271,7 @@ func (m *MockGitLabClient) ContainsCommentPhrase(phrase string) bool {
 }
 
 // FetchFileContent reads file content and returns FileContent struct
-func (m *MockGitLabClient) FetchFileContent(projectID int, filePath, ref string) (*gitlab.FileContent, error) {
+func (m *MockGitLabClient) FetchFileContent(ctx context.Context, projectID int, filePath, ref string) (*gitlab.FileContent, error) {
 	content, err := m.GetFileContent(projectID, filePath, ref)
 	if err != nil {
 		return nil, err
@@ -254,13 +286,13 @@ func (m *MockGitLabClient) FetchFileContent(projectID int, filePath, ref string)
 }
 
 // GetMRTargetBranch returns the target branch
-func (m *MockGitLabClient) GetMRTargetBranch(projectID, mrIID int) (string, error) {
+func (m *MockGitLabClient) GetMRTargetBranch(ctx context.Context, projectID, mrIID int) (string, error) {
 	return m.targetBranch, nil
 }
 
 // GetMRDetails returns MR details (minimal implementation for tests).
 // For autorebase eligibility, returns recent CreatedAt and success Pipeline when used with OpenMRsForAutoRebase.
-func (m *MockGitLabClient) GetMRDetails(projectID, mrIID int) (*gitlab.MRDetails, error) {
+func (m *MockGitLabClient) GetMRDetails(ctx context.Context, projectID, mrIID int) (*gitlab.MRDetails, error) {
 	createdAt := time.Now().Add(-24 * time.Hour).Format(time.RFC3339) // 1 day ago
 	details := &gitlab.MRDetails{
 		IID:                  mrIID,
@@ -282,7 +314,7 @@ func (m *MockGitLabClient) GetMRDetails(projectID, mrIID int) (*gitlab.MRDetails
 }
 
 // ListMRComments returns captured comments as MRComment structs
-func (m *MockGitLabClient) ListMRComments(projectID, mrIID int) ([]gitlab.MRComment, error) {
+func (m *MockGitLabClient) ListMRComments(ctx context.Context, projectID, mrIID int) ([]gitlab.MRComment, error) {
 	var comments []gitlab.MRComment
 	for i, captured := range m.CapturedComments {
 		comments = append(comments, gitlab.MRComment{
@@ -297,13 +329,13 @@ func (m *MockGitLabClient) ListMRComments(projectID, mrIID int) ([]gitlab.MRComm
 }
 
 // UpdateMRComment captures comment updates
-func (m *MockGitLabClient) UpdateMRComment(projectID, mrIID, commentID int, newBody string) error {
+func (m *MockGitLabClient) UpdateMRComment(ctx context.Context, projectID, mrIID, commentID int, newBody string) error {
 	// In tests, just add as a new comment
-	return m.AddMRComment(projectID, mrIID, newBody)
+	return m.AddMRComment(ctx, projectID, mrIID, newBody)
 }
 
 // FindLatestNaysayerComment finds the latest comment by type
-func (m *MockGitLabClient) FindLatestNaysayerComment(projectID, mrIID int, commentType ...string) (*gitlab.MRComment, error) {
+func (m *MockGitLabClient) FindLatestNaysayerComment(ctx context.Context, projectID, mrIID int, commentType ...string) (*gitlab.MRComment, error) {
 	// Search in reverse for latest comment
 	for i := len(m.CapturedComments) - 1; i >= 0; i-- {
 		if len(commentType) > 0 && m.CapturedComments[i].Tag == commentType[0] {
@@ -320,12 +352,12 @@ func (m *MockGitLabClient) FindLatestNaysayerComment(projectID, mrIID int, comme
 }
 
 // GetCurrentBotUsername returns the bot username
-func (m *MockGitLabClient) GetCurrentBotUsername() (string, error) {
+func (m *MockGitLabClient) GetCurrentBotUsername(ctx context.Context) (string, error) {
 	return "naysayer-bot", nil
 }
 
 // IsNaysayerBotAuthor checks if author is the naysayer bot
-func (m *MockGitLabClient) IsNaysayerBotAuthor(author map[string]interface{}) bool {
+func (m *MockGitLabClient) IsNaysayerBotAuthor(ctx context.Context, author map[string]interface{}) bool {
 	if username, ok := author["username"].(string); ok {
 		return username == "naysayer-bot"
 	}
@@ -333,7 +365,7 @@ func (m *MockGitLabClient) IsNaysayerBotAuthor(author map[string]interface{}) bo
 }
 
 // CompareBranches returns commits that target has but source doesn't (same-project only in real API).
-func (m *MockGitLabClient) CompareBranches(sourceProjectID int, sourceBranch string, targetProjectID int, targetBranch string) (*gitlab.CompareResult, error) {
+func (m *MockGitLabClient) CompareBranches(ctx context.Context, sourceProjectID int, sourceBranch string, targetProjectID int, targetBranch string) (*gitlab.CompareResult, error) {
 	count := 0
 	if m.AutoRebaseBehindCount >= 0 {
 		count = m.AutoRebaseBehindCount
@@ -350,12 +382,12 @@ func (m *MockGitLabClient) CompareBranches(sourceProjectID int, sourceBranch str
 }
 
 // GetBranchCommit returns a dummy SHA for E2E.
-func (m *MockGitLabClient) GetBranchCommit(projectID int, branch string) (string, error) {
+func (m *MockGitLabClient) GetBranchCommit(ctx context.Context, projectID int, branch string) (string, error) {
 	return "e2e-main-sha", nil
 }
 
 // CompareCommits returns behind count for E2E (fork MR path).
-func (m *MockGitLabClient) CompareCommits(projectID int, fromSHA, toSHA string) (*gitlab.CompareResult, error) {
+func (m *MockGitLabClient) CompareCommits(ctx context.Context, projectID int, fromSHA, toSHA string) (*gitlab.CompareResult, error) {
 	count := 0
 	if m.AutoRebaseBehindCount >= 0 {
 		count = m.AutoRebaseBehindCount
@@ -372,12 +404,12 @@ func (m *MockGitLabClient) CompareCommits(projectID int, fromSHA, toSHA string)
 }
 
 // RebaseMR simulates rebase for auto-rebase E2E. Returns success so the handler can post the automated comment.
-func (m *MockGitLabClient) RebaseMR(projectID, mrIID int) (bool, error) {
+func (m *MockGitLabClient) RebaseMR(ctx context.Context, projectID, mrIID int) (bool, error) {
 	return true, nil
 }
 
 // ListOpenMRs returns open MR IIDs. For auto-rebase E2E, set OpenMRsForAutoRebase to return specific MRs.
-func (m *MockGitLabClient) ListOpenMRs(projectID int) ([]int, error) {
+func (m *MockGitLabClient) ListOpenMRs(ctx context.Context, projectID int) ([]int, error) {
 	if len(m.OpenMRsForAutoRebase) > 0 {
 		return m.OpenMRsForAutoRebase, nil
 	}
@@ -385,17 +417,17 @@ func (m *MockGitLabClient) ListOpenMRs(projectID int) ([]int, error) {
 }
 
 // ListOpenMRsWithDetails is a stub for mock client
-func (m *MockGitLabClient) ListOpenMRsWithDetails(projectID int) ([]gitlab.MRDetails, error) {
+func (m *MockGitLabClient) ListOpenMRsWithDetails(ctx context.Context, projectID int) ([]gitlab.MRDetails, error) {
 	// Simulate the new behavior: call GetMRDetails for each open MR
 	// This mimics the real implementation's N+1 query pattern
-	openMRs, err := m.ListOpenMRs(projectID)
+	openMRs, err := m.ListOpenMRs(ctx, projectID)
 	if err != nil {
 		return nil, err
 	}
 
 	details := make([]gitlab.MRDetails, 0, len(openMRs))
 	for _, mrIID := range openMRs {
-		mrDetail, err := m.GetMRDetails(projectID, mrIID)
+		mrDetail, err := m.GetMRDetails(ctx, projectID, mrIID)
 		if err != nil {
 			// Skip MRs that fail to fetch
 			continue
@@ -407,19 +439,19 @@ func (m *MockGitLabClient) ListOpenMRsWithDetails(projectID int) ([]gitlab.MRDet
 }
 
 // ListAllOpenMRsWithDetails lists all open merge requests (mock implementation)
-func (m *MockGitLabClient) ListAllOpenMRsWithDetails(projectID int) ([]gitlab.MRDetails, error) {
+func (m *MockGitLabClient) ListAllOpenMRsWithDetails(ctx context.Context, projectID int) ([]gitlab.MRDetails, error) {
 	// For mock, return same as ListOpenMRsWithDetails
-	return m.ListOpenMRsWithDetails(projectID)
+	return m.ListOpenMRsWithDetails(ctx, projectID)
 }
 
 // CloseMR closes a merge request (mock implementation)
-func (m *MockGitLabClient) CloseMR(projectID, mrIID int) error {
+func (m *MockGitLabClient) CloseMR(ctx context.Context, projectID, mrIID int) error {
 	// Mock implementation - just log the action
 	return nil
 }
 
 // FindCommentByPattern checks if a comment with the pattern exists (mock implementation)
-func (m *MockGitLabClient) FindCommentByPattern(projectID, mrIID int, pattern string) (bool, error) {
+func (m *MockGitLabClient) FindCommentByPattern(ctx context.Context, projectID, mrIID int, pattern string) (bool, error) {
 	// Mock implementation - check captured comments
 	for _, comment := range m.CapturedComments {
 		if comment.ProjectID == projectID && comment.MRIID == mrIID {
@@ -432,31 +464,31 @@ func (m *MockGitLabClient) FindCommentByPattern(projectID, mrIID int, pattern st
 }
 
 // GetPipelineJobs is a stub for mock client
-func (m *MockGitLabClient) GetPipelineJobs(projectID, pipelineID int) ([]gitlab.PipelineJob, error) {
+func (m *MockGitLabClient) GetPipelineJobs(ctx context.Context, projectID, pipelineID int) ([]gitlab.PipelineJob, error) {
 	// Return empty jobs for e2e tests
 	return []gitlab.PipelineJob{}, nil
 }
 
 // GetJobTrace is a stub for mock client
-func (m *MockGitLabClient) GetJobTrace(projectID, jobID int) (string, error) {
+func (m *MockGitLabClient) GetJobTrace(ctx context.Context, projectID, jobID int) (string, error) {
 	// Return empty trace for e2e tests
 	return "", nil
 }
 
 // FindLatestAtlantisComment is a stub for mock client
-func (m *MockGitLabClient) FindLatestAtlantisComment(projectID, mrIID int) (*gitlab.MRComment, error) {
+func (m *MockGitLabClient) FindLatestAtlantisComment(ctx context.Context, projectID, mrIID int) (*gitlab.MRComment, error) {
 	// Return nil for e2e tests (no atlantis comments)
 	return nil, nil
 }
 
 // AreAllPipelineJobsSucceeded is a stub for mock client
-func (m *MockGitLabClient) AreAllPipelineJobsSucceeded(projectID, pipelineID int) (bool, error) {
+func (m *MockGitLabClient) AreAllPipelineJobsSucceeded(ctx context.Context, projectID, pipelineID int) (bool, error) {
 	// Return true for e2e tests (all jobs succeeded)
 	return true, nil
 }
 
 // CheckAtlantisCommentForPlanFailures is a stub for mock client
-func (m *MockGitLabClient) CheckAtlantisCommentForPlanFailures(projectID, mrIID int) (bool, string) {
+func (m *MockGitLabClient) CheckAtlantisCommentForPlanFailures(ctx context.Context, projectID, mrIID int) (bool, string) {
 	// Return false for e2e tests (no plan failures, allow rebase)
 	return false, ""
 }
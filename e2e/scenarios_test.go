@@ -109,7 +109,7 @@ func runScenario(t *testing.T, scenario ScenarioConfig) {
 }
 
 // createTestConfig creates a test configuration
-func createTestConfig(t *testing.T) *config.Config {
+func createTestConfig(t testing.TB) *config.Config {
 	return &config.Config{
 		GitLab: config.GitLabConfig{
 			BaseURL: "https://gitlab.example.com",
@@ -130,7 +130,7 @@ func createTestConfig(t *testing.T) *config.Config {
 }
 
 // createWebhookHandler creates a webhook handler with mock GitLab client
-func createWebhookHandler(t *testing.T, cfg *config.Config, mockGitLab *MockGitLabClient) *webhook.DataProductConfigMrReviewHandler {
+func createWebhookHandler(t testing.TB, cfg *config.Config, mockGitLab *MockGitLabClient) *webhook.DataProductConfigMrReviewHandler {
 	// Use the new constructor that accepts a GitLab client
 	handler := webhook.NewDataProductConfigMrReviewHandlerWithClient(cfg, mockGitLab)
 	if handler == nil {
@@ -163,7 +163,7 @@ func createWebhookPayload(scenario ScenarioConfig, changes []gitlab.FileChange)
 }
 
 // callWebhookEndpoint calls the webhook endpoint and returns the response
-func callWebhookEndpoint(t *testing.T, handler *webhook.DataProductConfigMrReviewHandler, payload map[string]interface{}) map[string]interface{} {
+func callWebhookEndpoint(t testing.TB, handler *webhook.DataProductConfigMrReviewHandler, payload map[string]interface{}) map[string]interface{} {
 	// Create Fiber app
 	app := fiber.New(fiber.Config{
 		DisableStartupMessage: true,
@@ -0,0 +1,60 @@
+package e2e
+
+import (
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/redhat-data-and-ai/naysayer/internal/synthrepo"
+	"github.com/stretchr/testify/require"
+)
+
+// syntheticScenario writes a generated repository as before/ and the same repository with
+// invalid files as after/, so the MR changes exactly the invalid files
+func syntheticScenario(tb testing.TB, products int, invalidRatio float64) (ScenarioConfig, *synthrepo.Repo) {
+	before, err := synthrepo.Generate(synthrepo.Options{Products: products, Seed: 7})
+	require.NoError(tb, err)
+	after, err := synthrepo.Generate(synthrepo.Options{Products: products, InvalidRatio: invalidRatio, Seed: 7})
+	require.NoError(tb, err)
+
+	scenario := ScenarioConfig{
+		Name:      "synthetic_repo",
+		BeforeDir: tb.TempDir(),
+		AfterDir:  tb.TempDir(),
+		Expected:  ExpectedResults{Decision: shared.ManualReview, Approved: false},
+		MRMetadata: MRMetadata{
+			Title:        "Update masking policies and service accounts",
+			Author:       "developer",
+			SourceBranch: "feature/synthetic",
+			TargetBranch: "main",
+		},
+	}
+	require.NoError(tb, before.Write(scenario.BeforeDir))
+	require.NoError(tb, after.Write(scenario.AfterDir))
+	return scenario, after
+}
+
+// TestE2E_SyntheticRepo_InvalidFilesRequireReview checks that every kind of invalid file
+// the generator produces keeps an MR from being auto-approved
+func TestE2E_SyntheticRepo_InvalidFilesRequireReview(t *testing.T) {
+	scenario, after := syntheticScenario(t, 6, 0.5)
+	require.Len(t, after.Invalid, 3)
+
+	scenario.Expected.CommentContains = []string{"Masking policy validation failed"}
+	runScenario(t, scenario)
+}
+
+// BenchmarkE2E_SyntheticRepo reviews an MR touching one file in each of 30 data products
+func BenchmarkE2E_SyntheticRepo(b *testing.B) {
+	scenario, _ := syntheticScenario(b, 30, 1)
+	changes, err := CompareFolders(scenario.BeforeDir, scenario.AfterDir)
+	require.NoError(b, err)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		mockGitLab := NewMockGitLabClient(scenario.BeforeDir, scenario.AfterDir)
+		mockGitLab.SetMRBranches(scenario.MRMetadata.SourceBranch, scenario.MRMetadata.TargetBranch)
+		mockGitLab.SetFileChanges(changes)
+		handler := createWebhookHandler(b, createTestConfig(b), mockGitLab)
+		callWebhookEndpoint(b, handler, createWebhookPayload(scenario, changes))
+	}
+}
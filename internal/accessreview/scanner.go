@@ -0,0 +1,133 @@
+package accessreview
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/repoindex"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/masking"
+	"go.uber.org/zap"
+)
+
+// Grant is a single UNMASKED grant found in a masking policy
+type Grant struct {
+	DataProduct  string `json:"data_product"`
+	Environment  string `json:"environment"`
+	ConsumerKind string `json:"consumer_kind"`
+	Consumer     string `json:"consumer"`
+	DataType     string `json:"datatype"`
+	Policy       string `json:"policy"`
+	FilePath     string `json:"file_path"`
+}
+
+// FileFetcher defines the GitLab operation needed to read masking policies
+type FileFetcher interface {
+	FetchFileContent(ctx context.Context, projectID int, filePath, ref string) (*gitlab.FileContent, error)
+}
+
+// errStopScan ends a scan early without reporting an error
+var errStopScan = errors.New("stop scan")
+
+// Scanner finds UNMASKED grants in the masking policies of a repository ref
+type Scanner struct {
+	index   *repoindex.Index
+	fetcher FileFetcher
+}
+
+// NewScanner creates a scanner that lists masking files from the repository index
+func NewScanner(index *repoindex.Index, fetcher FileFetcher) *Scanner {
+	return &Scanner{
+		index:   index,
+		fetcher: fetcher,
+	}
+}
+
+// Scan streams every UNMASKED grant at ref to emit, one masking file at a time.
+// Files are visited in path order so the output is stable between runs.
+func (s *Scanner) Scan(ctx context.Context, projectID int, ref string, emit func(Grant) error) error {
+	paths, err := s.index.Paths(ctx, projectID, ref, masking.IsMaskingFile)
+	if err != nil {
+		return fmt.Errorf("failed to list masking policies: %w", err)
+	}
+
+	for _, path := range paths {
+		content, err := s.fetcher.FetchFileContent(ctx, projectID, path, ref)
+		if err != nil {
+			return fmt.Errorf("failed to fetch %s: %w", path, err)
+		}
+		if content == nil {
+			return fmt.Errorf("empty response when fetching %s", path)
+		}
+
+		policy, err := masking.ParseMaskingPolicy(content.Content)
+		if err != nil {
+			logging.Warn("Skipping unparsable masking policy", zap.String("path", path), zap.Error(err))
+			continue
+		}
+		if !strings.EqualFold(policy.Kind, masking.MaskingPolicyKind) {
+			continue
+		}
+
+		for _, grant := range UnmaskedGrants(path, policy) {
+			if err := emit(grant); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Page returns up to limit grants after skipping offset grants, and whether more grants follow
+func (s *Scanner) Page(ctx context.Context, projectID int, ref string, offset, limit int) ([]Grant, bool, error) {
+	grants := make([]Grant, 0, limit)
+	seen := 0
+	hasMore := false
+
+	err := s.Scan(ctx, projectID, ref, func(g Grant) error {
+		seen++
+		if seen <= offset {
+			return nil
+		}
+		if len(grants) == limit {
+			hasMore = true
+			return errStopScan
+		}
+		grants = append(grants, g)
+		return nil
+	})
+	if err != nil && !errors.Is(err, errStopScan) {
+		return nil, false, err
+	}
+	return grants, hasMore, nil
+}
+
+// UnmaskedGrants extracts the UNMASKED consumers of a policy stored at path
+func UnmaskedGrants(path string, policy *masking.MaskingPolicy) []Grant {
+	dataProduct, environment := masking.ExtractPathInfo(path)
+	if dataProduct == "" {
+		dataProduct = policy.DataProduct
+	}
+
+	var grants []Grant
+	for _, c := range policy.Cases {
+		if !strings.EqualFold(c.Strategy, masking.StrategyUnmasked) {
+			continue
+		}
+		for _, consumer := range c.Consumers {
+			grants = append(grants, Grant{
+				DataProduct:  dataProduct,
+				Environment:  environment,
+				ConsumerKind: consumer.Kind,
+				Consumer:     consumer.Name,
+				DataType:     policy.DataType,
+				Policy:       policy.Name,
+				FilePath:     path,
+			})
+		}
+	}
+	return grants
+}
@@ -0,0 +1,117 @@
+package accessreview
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/repoindex"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+)
+
+// mockRepository serves a fixed repository tree and file contents
+type mockRepository struct {
+	files map[string]string
+}
+
+func (m *mockRepository) ListRepositoryTree(ctx context.Context, projectID int, ref string) ([]gitlab.TreeEntry, error) {
+	entries := make([]gitlab.TreeEntry, 0, len(m.files))
+	for path := range m.files {
+		entries = append(entries, gitlab.TreeEntry{Type: "blob", Path: path})
+	}
+	return entries, nil
+}
+
+func (m *mockRepository) FetchFileContent(ctx context.Context, projectID int, filePath, ref string) (*gitlab.FileContent, error) {
+	content, ok := m.files[filePath]
+	if !ok {
+		return nil, fmt.Errorf("file not found: %s", filePath)
+	}
+	return &gitlab.FileContent{FilePath: filePath, Content: content}, nil
+}
+
+func newTestRepository() *mockRepository {
+	return &mockRepository{files: map[string]string{
+		"dataproducts/source/analytics/prod/pii_masking.yaml": `kind: MaskingPolicy
+name: analytics_pii_string_policy
+data_product: analytics
+datatype: string
+mask: "==MASKED=="
+cases:
+  - strategy: UNMASKED
+    consumers:
+      - kind: consumer_group
+        name: dataverse-source-analytics
+      - kind: service_account
+        name: analytics_astro_prod_appuser
+  - strategy: HASH_SHA1
+    consumers:
+      - kind: consumer_group
+        name: dataverse-consumer-hashed
+`,
+		"dataproducts/aggregate/sales/dev/restricted_float_masking.yaml": `kind: MaskingPolicy
+name: sales_restricted_float_policy
+data_product: sales
+datatype: float
+mask: "-9.0"
+cases:
+  - strategy: UNMASKED
+    consumers:
+      - kind: consumer_group
+        name: dataverse-aggregate-sales
+`,
+		"dataproducts/aggregate/sales/dev/pii_tag_masking.yaml": "kind: Tag\nname: pii\n",
+		"dataproducts/aggregate/sales/dev/product.yaml":         "name: sales\n",
+	}}
+}
+
+func newTestScanner(repo *mockRepository) *Scanner {
+	return NewScanner(repoindex.NewIndex(repo, store.NewMemoryStore()), repo)
+}
+
+func TestScanner_Scan(t *testing.T) {
+	var grants []Grant
+	err := newTestScanner(newTestRepository()).Scan(context.Background(), 1, "main", func(g Grant) error {
+		grants = append(grants, g)
+		return nil
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []Grant{
+		{DataProduct: "sales", Environment: "dev", ConsumerKind: "consumer_group", Consumer: "dataverse-aggregate-sales", DataType: "float", Policy: "sales_restricted_float_policy", FilePath: "dataproducts/aggregate/sales/dev/restricted_float_masking.yaml"},
+		{DataProduct: "analytics", Environment: "prod", ConsumerKind: "consumer_group", Consumer: "dataverse-source-analytics", DataType: "string", Policy: "analytics_pii_string_policy", FilePath: "dataproducts/source/analytics/prod/pii_masking.yaml"},
+		{DataProduct: "analytics", Environment: "prod", ConsumerKind: "service_account", Consumer: "analytics_astro_prod_appuser", DataType: "string", Policy: "analytics_pii_string_policy", FilePath: "dataproducts/source/analytics/prod/pii_masking.yaml"},
+	}, grants)
+}
+
+func TestScanner_SkipsUnparsablePolicies(t *testing.T) {
+	repo := newTestRepository()
+	repo.files["dataproducts/source/broken/prod/pii_masking.yaml"] = "kind: [unclosed"
+
+	count := 0
+	err := newTestScanner(repo).Scan(context.Background(), 1, "main", func(g Grant) error {
+		count++
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+}
+
+func TestScanner_Page(t *testing.T) {
+	ctx := context.Background()
+	scanner := newTestScanner(newTestRepository())
+
+	grants, hasMore, err := scanner.Page(ctx, 1, "main", 0, 2)
+	assert.NoError(t, err)
+	assert.Len(t, grants, 2)
+	assert.True(t, hasMore)
+
+	grants, hasMore, err = scanner.Page(ctx, 1, "main", 2, 2)
+	assert.NoError(t, err)
+	assert.Len(t, grants, 1)
+	assert.Equal(t, "analytics_astro_prod_appuser", grants[0].Consumer)
+	assert.False(t, hasMore)
+}
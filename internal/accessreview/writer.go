@@ -0,0 +1,110 @@
+package accessreview
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Supported report formats
+const (
+	FormatCSV  = "csv"
+	FormatJSON = "json"
+)
+
+// csvHeader is the column order of CSV reports
+var csvHeader = []string{"data_product", "environment", "consumer_kind", "consumer", "datatype", "policy", "file_path"}
+
+// Writer streams grants to an output format
+type Writer interface {
+	Write(grant Grant) error
+	Close() error
+}
+
+// ContentType returns the HTTP content type of a report format
+func ContentType(format string) string {
+	if format == FormatCSV {
+		return "text/csv"
+	}
+	return "application/json"
+}
+
+// NewWriter creates a streaming writer for the given format
+func NewWriter(format string, w io.Writer) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return &csvWriter{w: csv.NewWriter(w)}, nil
+	case FormatJSON:
+		return &jsonWriter{w: w}, nil
+	default:
+		return nil, fmt.Errorf("unsupported format %q: expected %s or %s", format, FormatCSV, FormatJSON)
+	}
+}
+
+// csvWriter writes a header row followed by one row per grant
+type csvWriter struct {
+	w             *csv.Writer
+	headerWritten bool
+}
+
+func (c *csvWriter) writeHeader() error {
+	if c.headerWritten {
+		return nil
+	}
+	c.headerWritten = true
+	return c.w.Write(csvHeader)
+}
+
+func (c *csvWriter) Write(g Grant) error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+	if err := c.w.Write([]string{g.DataProduct, g.Environment, g.ConsumerKind, g.Consumer, g.DataType, g.Policy, g.FilePath}); err != nil {
+		return err
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func (c *csvWriter) Close() error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// jsonWriter writes a JSON array one element at a time
+type jsonWriter struct {
+	w     io.Writer
+	count int
+}
+
+func (j *jsonWriter) Write(g Grant) error {
+	prefix := ",\n"
+	if j.count == 0 {
+		prefix = "[\n"
+	}
+	data, err := json.Marshal(g)
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(j.w, prefix); err != nil {
+		return err
+	}
+	if _, err := j.w.Write(data); err != nil {
+		return err
+	}
+	j.count++
+	return nil
+}
+
+func (j *jsonWriter) Close() error {
+	closing := "\n]\n"
+	if j.count == 0 {
+		closing = "[]\n"
+	}
+	_, err := io.WriteString(j.w, closing)
+	return err
+}
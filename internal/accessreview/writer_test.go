@@ -0,0 +1,62 @@
+package accessreview
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testGrant = Grant{
+	DataProduct:  "analytics",
+	Environment:  "prod",
+	ConsumerKind: "consumer_group",
+	Consumer:     "dataverse-source-analytics",
+	DataType:     "string",
+	Policy:       "analytics_pii_string_policy",
+	FilePath:     "dataproducts/source/analytics/prod/pii_masking.yaml",
+}
+
+func TestCSVWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(FormatCSV, &buf)
+	assert.NoError(t, err)
+	assert.NoError(t, w.Write(testGrant))
+	assert.NoError(t, w.Close())
+
+	assert.Equal(t, "data_product,environment,consumer_kind,consumer,datatype,policy,file_path\n"+
+		"analytics,prod,consumer_group,dataverse-source-analytics,string,analytics_pii_string_policy,dataproducts/source/analytics/prod/pii_masking.yaml\n",
+		buf.String())
+
+	// Empty reports still carry the header
+	buf.Reset()
+	w, _ = NewWriter(FormatCSV, &buf)
+	assert.NoError(t, w.Close())
+	assert.Equal(t, "data_product,environment,consumer_kind,consumer,datatype,policy,file_path\n", buf.String())
+}
+
+func TestJSONWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(FormatJSON, &buf)
+	assert.NoError(t, err)
+	assert.NoError(t, w.Write(testGrant))
+	assert.NoError(t, w.Write(testGrant))
+	assert.NoError(t, w.Close())
+
+	var grants []Grant
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &grants))
+	assert.Equal(t, []Grant{testGrant, testGrant}, grants)
+
+	buf.Reset()
+	w, _ = NewWriter(FormatJSON, &buf)
+	assert.NoError(t, w.Close())
+	assert.Equal(t, "[]\n", buf.String())
+}
+
+func TestNewWriter_UnsupportedFormat(t *testing.T) {
+	_, err := NewWriter("xml", &bytes.Buffer{})
+	assert.Error(t, err)
+	assert.Equal(t, "text/csv", ContentType(FormatCSV))
+	assert.Equal(t, "application/json", ContentType(FormatJSON))
+}
@@ -0,0 +1,173 @@
+package archive
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/replay"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+	"go.uber.org/zap"
+)
+
+// keyPrefix is the state store namespace for archived payloads
+const keyPrefix = "payloads/"
+
+// EventHeader is the header GitLab sets to the kind of webhook event
+const EventHeader = "X-Gitlab-Event"
+
+// pruneInterval limits how often expired payloads are removed
+const pruneInterval = time.Minute
+
+// deliveryIDPattern accepts the UUIDs GitLab sends and keeps IDs safe as store keys
+var deliveryIDPattern = regexp.MustCompile(`^[A-Za-z0-9_\-]{1,64}$`)
+
+// Record is an archived webhook delivery with its scrubbed payload
+type Record struct {
+	DeliveryID string          `json:"delivery_id"`
+	Endpoint   string          `json:"endpoint"`
+	Event      string          `json:"event,omitempty"`
+	ReceivedAt time.Time       `json:"received_at"`
+	Payload    json.RawMessage `json:"payload"`
+}
+
+// Archive keeps scrubbed webhook payloads for debugging
+type Archive struct {
+	store     store.Store
+	scrubber  *Scrubber
+	retention time.Duration
+	now       func() time.Time
+
+	mu         sync.Mutex
+	lastPruned time.Time
+}
+
+// NewArchive creates an archive keeping payloads scrubbed by scrubber in st for retention
+func NewArchive(st store.Store, scrubber *Scrubber, retention time.Duration) *Archive {
+	return &Archive{
+		store:     st,
+		scrubber:  scrubber,
+		retention: retention,
+		now:       time.Now,
+	}
+}
+
+// NewArchiveFromConfig returns a payload archive, or nil when archiving is disabled
+func NewArchiveFromConfig(cfg config.ArchiveConfig, st store.Store) *Archive {
+	if !cfg.Enabled {
+		return nil
+	}
+	return NewArchive(st, NewScrubberFromConfig(cfg), time.Duration(cfg.RetentionDays)*24*time.Hour)
+}
+
+// Save scrubs and stores a payload and returns its delivery ID. Deliveries without a
+// usable ID get a generated one.
+func (a *Archive) Save(deliveryID, endpoint, event string, body []byte) (string, error) {
+	if !deliveryIDPattern.MatchString(deliveryID) {
+		generated, err := newID()
+		if err != nil {
+			return "", err
+		}
+		deliveryID = generated
+	}
+
+	payload := a.scrubber.Scrub(body)
+	if !json.Valid(payload) {
+		// Non-JSON payloads are kept as a JSON string
+		encoded, err := json.Marshal(string(payload))
+		if err != nil {
+			return "", err
+		}
+		payload = encoded
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := a.now()
+	a.pruneLocked(now)
+	record := Record{DeliveryID: deliveryID, Endpoint: endpoint, Event: event, ReceivedAt: now, Payload: payload}
+	return deliveryID, store.PutJSON(a.store, keyPrefix+deliveryID, record)
+}
+
+// Get returns an archived payload and whether it was found
+func (a *Archive) Get(deliveryID string) (*Record, bool, error) {
+	if !deliveryIDPattern.MatchString(deliveryID) {
+		return nil, false, nil
+	}
+	var record Record
+	found, err := store.GetJSON(a.store, keyPrefix+deliveryID, &record)
+	if err != nil || !found {
+		return nil, false, err
+	}
+	if a.now().Sub(record.ReceivedAt) > a.retention {
+		return nil, false, nil
+	}
+	return &record, true, nil
+}
+
+// pruneLocked removes payloads older than the retention, at most once per pruneInterval
+func (a *Archive) pruneLocked(now time.Time) {
+	if now.Sub(a.lastPruned) < pruneInterval {
+		return
+	}
+	a.lastPruned = now
+
+	keys, err := a.store.Keys(keyPrefix)
+	if err != nil {
+		logging.Warn("Failed to list archived payloads", zap.Error(err))
+		return
+	}
+	for _, key := range keys {
+		var record Record
+		if found, err := store.GetJSON(a.store, key, &record); err == nil && found && now.Sub(record.ReceivedAt) > a.retention {
+			_ = a.store.Delete(key)
+		}
+	}
+}
+
+// Middleware archives the payload of each delivery before passing it on. Archive
+// failures are logged and never reject a delivery. A nil archive lets every request through.
+func (a *Archive) Middleware(endpoint string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if a == nil {
+			return c.Next()
+		}
+		deliveryID, err := a.Save(c.Get(replay.EventUUIDHeader), endpoint, c.Get(EventHeader), c.Body())
+		if err != nil {
+			logging.Error("Failed to archive webhook payload", zap.String("path", c.Path()), zap.Error(err))
+		} else {
+			logging.Info("Archived webhook payload", zap.String("path", c.Path()), zap.String("delivery_id", deliveryID))
+		}
+		return c.Next()
+	}
+}
+
+// HandleGet answers GET /api/v1/payloads/:id with the scrubbed payload of a delivery
+func (a *Archive) HandleGet(c *fiber.Ctx) error {
+	record, found, err := a.Get(c.Params("id"))
+	if err != nil {
+		logging.Error("Failed to load archived payload", zap.String("payload_id", c.Params("id")), zap.Error(err))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to load archived payload"})
+	}
+	if !found {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Payload not found"})
+	}
+	return c.JSON(record)
+}
+
+func newID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", fmt.Errorf("failed to generate delivery ID: %w", err)
+	}
+	return hex.EncodeToString(id), nil
+}
@@ -0,0 +1,98 @@
+package archive
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+)
+
+func TestNewArchiveFromConfig(t *testing.T) {
+	assert.Nil(t, NewArchiveFromConfig(config.ArchiveConfig{}, store.NewMemoryStore()))
+	a := NewArchiveFromConfig(config.ArchiveConfig{Enabled: true, RetentionDays: 2}, store.NewMemoryStore())
+	if assert.NotNil(t, a) {
+		assert.Equal(t, 48*time.Hour, a.retention)
+	}
+}
+
+func TestArchive_SaveGetAndExpire(t *testing.T) {
+	st := store.NewMemoryStore()
+	a := NewArchive(st, NewScrubber([]string{"token"}, true, true), 24*time.Hour)
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	a.now = func() time.Time { return now }
+
+	id, err := a.Save("3f1c9a52-2b7d-4c8e-9f10-6a2b3c4d5e6f", "review", "Merge Request Hook", []byte(`{"user": {"email": "a@example.com"}, "private_token": "x"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "3f1c9a52-2b7d-4c8e-9f10-6a2b3c4d5e6f", id)
+
+	record, found, err := a.Get(id)
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, "review", record.Endpoint)
+	assert.Equal(t, "Merge Request Hook", record.Event)
+	assert.JSONEq(t, `{"user": {"email": "[EMAIL]"}, "private_token": "[REDACTED]"}`, string(record.Payload))
+
+	generated, err := a.Save("../escape", "review", "", []byte("plain text"))
+	assert.NoError(t, err)
+	assert.Len(t, generated, 32, "unusable delivery IDs are replaced")
+	record, found, _ = a.Get(generated)
+	assert.True(t, found)
+	assert.Equal(t, `"plain text"`, string(record.Payload))
+
+	now = now.Add(25 * time.Hour)
+	_, found, _ = a.Get(id)
+	assert.False(t, found, "expired payloads are not served")
+
+	_, err = a.Save("next", "review", "", []byte(`{}`))
+	assert.NoError(t, err)
+	keys, _ := st.Keys(keyPrefix)
+	assert.Equal(t, []string{keyPrefix + "next"}, keys, "expired payloads are pruned")
+}
+
+func TestArchive_MiddlewareAndHandleGet(t *testing.T) {
+	a := NewArchive(store.NewMemoryStore(), NewScrubber(nil, true, true), time.Hour)
+
+	app := fiber.New()
+	app.Post("/hook", a.Middleware("review"), func(c *fiber.Ctx) error {
+		return c.SendString(string(c.Body()))
+	})
+	app.Get("/api/v1/payloads/:id", a.HandleGet)
+
+	req := httptest.NewRequest("POST", "/hook", strings.NewReader(`{"author": "me@example.com"}`))
+	req.Header.Set("X-Gitlab-Event-UUID", "delivery-1")
+	req.Header.Set(EventHeader, "Merge Request Hook")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, `{"author": "me@example.com"}`, string(body), "handlers receive the original payload")
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/api/v1/payloads/delivery-1", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	var record Record
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&record))
+	assert.Equal(t, "delivery-1", record.DeliveryID)
+	assert.JSONEq(t, `{"author": "[EMAIL]"}`, string(record.Payload))
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/api/v1/payloads/unknown", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
+
+func TestArchive_NilMiddlewarePassesThrough(t *testing.T) {
+	var a *Archive
+	app := fiber.New()
+	app.Post("/hook", a.Middleware("review"), func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+
+	resp, err := app.Test(httptest.NewRequest("POST", "/hook", strings.NewReader(`{}`)))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+}
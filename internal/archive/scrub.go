@@ -0,0 +1,117 @@
+package archive
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+)
+
+// Replacement values of scrubbed data
+const (
+	redacted      = "[REDACTED]"
+	redactedEmail = "[EMAIL]"
+)
+
+var (
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	// tokenPattern matches GitLab (glpat-, gldt-, ...), GitHub and Slack tokens wherever they appear
+	tokenPattern = regexp.MustCompile(`\b(gl[a-z]{2,6}-[A-Za-z0-9_\-]{16,}|gh[pousr]_[A-Za-z0-9]{30,}|xox[abprs]-[A-Za-z0-9\-]{10,})`)
+)
+
+// commitKeys are the keys holding commit objects (push events) or a commit object (MR events)
+var commitKeys = map[string]bool{"commits": true, "last_commit": true}
+
+// Scrubber removes personal data and credentials from webhook payloads
+type Scrubber struct {
+	keys         []string
+	emails       bool
+	commitBodies bool
+}
+
+// NewScrubber creates a scrubber redacting the values of keys (matched case-insensitively,
+// also as a "_key" suffix), email addresses when emails is set and commit message bodies
+// when commitBodies is set. Known token formats are always redacted.
+func NewScrubber(keys []string, emails, commitBodies bool) *Scrubber {
+	lower := make([]string, 0, len(keys))
+	for _, key := range keys {
+		lower = append(lower, strings.ToLower(key))
+	}
+	return &Scrubber{keys: lower, emails: emails, commitBodies: commitBodies}
+}
+
+// NewScrubberFromConfig creates the scrubber configured for the payload archive
+func NewScrubberFromConfig(cfg config.ArchiveConfig) *Scrubber {
+	return NewScrubber(cfg.ScrubKeys, cfg.ScrubEmails, cfg.ScrubCommitBodies)
+}
+
+// Scrub returns the scrubbed payload. JSON payloads are scrubbed value by value and
+// re-encoded; anything else is scrubbed as text.
+func (s *Scrubber) Scrub(payload []byte) []byte {
+	var value any
+	if err := json.Unmarshal(payload, &value); err != nil {
+		return []byte(s.scrubString(string(payload)))
+	}
+	scrubbed, err := json.Marshal(s.scrubValue(value, ""))
+	if err != nil {
+		return []byte(redacted)
+	}
+	return scrubbed
+}
+
+// scrubValue scrubs a decoded JSON value found under key
+func (s *Scrubber) scrubValue(value any, key string) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for k, child := range v {
+			switch {
+			case s.sensitiveKey(k):
+				v[k] = redacted
+			case s.commitBodies && commitKeys[key] && k == "message":
+				if message, ok := child.(string); ok {
+					v[k] = s.scrubString(subjectLine(message))
+				}
+			default:
+				v[k] = s.scrubValue(child, k)
+			}
+		}
+		return v
+	case []any:
+		for i, child := range v {
+			// Elements of an array belong to the key of the array
+			v[i] = s.scrubValue(child, key)
+		}
+		return v
+	case string:
+		return s.scrubString(v)
+	default:
+		return v
+	}
+}
+
+func (s *Scrubber) sensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, sensitive := range s.keys {
+		if key == sensitive || strings.HasSuffix(key, "_"+sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Scrubber) scrubString(value string) string {
+	value = tokenPattern.ReplaceAllString(value, redacted)
+	if s.emails {
+		value = emailPattern.ReplaceAllString(value, redactedEmail)
+	}
+	return value
+}
+
+// subjectLine returns the first line of a commit message
+func subjectLine(message string) string {
+	if i := strings.IndexByte(message, '\n'); i >= 0 {
+		return strings.TrimRight(message[:i], "\r")
+	}
+	return message
+}
@@ -0,0 +1,217 @@
+package audit
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"go.uber.org/zap"
+)
+
+// Audit sinks
+const (
+	SinkStdout = "stdout"
+	SinkFile   = "file"
+)
+
+// Event kinds
+const (
+	KindDecision   = "decision"    // Review decision, with the approval or manual review applied
+	KindRebase     = "rebase"      // Auto-rebase of an MR
+	KindStaleClose = "stale_close" // Closure of a stale MR
+	KindOverride   = "override"    // Manual review overridden by an override approver
+	KindMerge      = "merge"       // Auto-merge of an approved MR
+)
+
+// Event outcomes
+const (
+	OutcomeSucceeded = "succeeded"
+	OutcomeFailed    = "failed"
+)
+
+// RuleResult is the decision of one rule for one file
+type RuleResult struct {
+	File     string `json:"file"`
+	Rule     string `json:"rule"`
+	Decision string `json:"decision"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// Event is one audit record, written as a single JSON line
+type Event struct {
+	ID           string       `json:"id"`
+	Timestamp    time.Time    `json:"timestamp"`
+	Kind         string       `json:"kind"`
+	ProjectID    int          `json:"project_id"`
+	MRIID        int          `json:"mr_iid"`
+	Title        string       `json:"title,omitempty"`
+	Author       string       `json:"author,omitempty"`
+	SourceBranch string       `json:"source_branch,omitempty"`
+	TargetBranch string       `json:"target_branch,omitempty"`
+	Rules        []RuleResult `json:"rules,omitempty"`
+	Decision     string       `json:"decision,omitempty"`
+	Reason       string       `json:"reason,omitempty"`
+	SnapshotID   string       `json:"snapshot_id,omitempty"` // Evaluation snapshot for replay
+	Approved     bool         `json:"approved,omitempty"`    // Naysayer approved the MR
+	Outcome      string       `json:"outcome,omitempty"`
+	Error        string       `json:"error,omitempty"`
+}
+
+// Logger writes audit events as JSON lines, separately from the application log.
+// A nil Logger discards events.
+type Logger struct {
+	mu     sync.Mutex
+	w      io.Writer
+	closer io.Closer
+}
+
+// NewLogger creates a logger writing to w
+func NewLogger(w io.Writer) *Logger {
+	return &Logger{w: w}
+}
+
+// NewLoggerFromConfig creates the configured logger, or returns nil when auditing is disabled
+func NewLoggerFromConfig(cfg config.AuditConfig) (*Logger, error) {
+	switch cfg.Sink {
+	case "":
+		return nil, nil
+	case SinkStdout:
+		return NewLogger(os.Stdout), nil
+	case SinkFile:
+		if cfg.FilePath == "" {
+			return nil, fmt.Errorf("AUDIT_LOG_FILE is required for the file sink")
+		}
+		file, err := OpenRotatingFile(cfg.FilePath, int64(cfg.MaxSizeMB)*1024*1024, cfg.MaxBackups)
+		if err != nil {
+			return nil, err
+		}
+		return &Logger{w: file, closer: file}, nil
+	default:
+		return nil, fmt.Errorf("unknown audit sink %q (expected %s or %s)", cfg.Sink, SinkStdout, SinkFile)
+	}
+}
+
+// Record writes an event, filling in its ID and timestamp when missing
+func (l *Logger) Record(event Event) error {
+	if l == nil {
+		return nil
+	}
+	if event.ID == "" {
+		event.ID = NewID()
+	}
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now().UTC()
+	}
+	line, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit event: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, err := l.w.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit event: %w", err)
+	}
+	return nil
+}
+
+// Close closes the underlying file, if any
+func (l *Logger) Close() error {
+	if l == nil || l.closer == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.closer.Close()
+}
+
+// NewID returns a random identifier for a decision or action
+func NewID() string {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return fmt.Sprintf("%016x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(id)
+}
+
+// Recorder receives audit events. Logger implements it; other recorders (e.g. a decision
+// history database) can be installed next to it.
+type Recorder interface {
+	Record(event Event) error
+}
+
+// multiRecorder fans events out to several recorders
+type multiRecorder []Recorder
+
+// Record passes the event to every recorder, all sharing its ID and timestamp, and
+// returns the first failure
+func (m multiRecorder) Record(event Event) error {
+	if event.ID == "" {
+		event.ID = NewID()
+	}
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now().UTC()
+	}
+	var firstErr error
+	for _, recorder := range m {
+		if err := recorder.Record(event); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// Multi combines recorders, or returns nil when none is given
+func Multi(recorders ...Recorder) Recorder {
+	switch len(recorders) {
+	case 0:
+		return nil
+	case 1:
+		return recorders[0]
+	default:
+		return multiRecorder(recorders)
+	}
+}
+
+var (
+	defaultMu       sync.RWMutex
+	defaultRecorder Recorder
+)
+
+// SetDefault installs the process-wide audit recorder used by webhook handlers
+func SetDefault(r Recorder) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultRecorder = r
+}
+
+// Default returns the process-wide audit recorder, or nil when auditing is disabled
+func Default() Recorder {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultRecorder
+}
+
+// Enabled reports whether a process-wide audit recorder is installed
+func Enabled() bool {
+	return Default() != nil
+}
+
+// Record writes an event to the process-wide recorder; failures are logged, never
+// returned, so auditing cannot block decisions
+func Record(event Event) {
+	recorder := Default()
+	if recorder == nil {
+		return
+	}
+	if err := recorder.Record(event); err != nil {
+		logging.Error("Audit record failed", zap.Int("mr_iid", event.MRIID), zap.String("kind", event.Kind), zap.Error(err))
+	}
+}
@@ -0,0 +1,84 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+)
+
+func TestLogger_Record(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLogger(&buf)
+
+	assert.NoError(t, logger.Record(Event{Kind: KindDecision, ProjectID: 1, MRIID: 2, Decision: "approve", Approved: true}))
+	assert.NoError(t, logger.Record(Event{ID: "fixed", Kind: KindRebase, ProjectID: 1, MRIID: 3}))
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	assert.Len(t, lines, 2)
+
+	var first, second Event
+	assert.NoError(t, json.Unmarshal(lines[0], &first))
+	assert.NoError(t, json.Unmarshal(lines[1], &second))
+	assert.Len(t, first.ID, 16)
+	assert.False(t, first.Timestamp.IsZero())
+	assert.True(t, first.Approved)
+	assert.Equal(t, "fixed", second.ID)
+	assert.Equal(t, KindRebase, second.Kind)
+}
+
+func TestLogger_NilDiscards(t *testing.T) {
+	var logger *Logger
+	assert.NoError(t, logger.Record(Event{Kind: KindDecision}))
+	assert.NoError(t, logger.Close())
+
+	SetDefault(nil)
+	assert.False(t, Enabled())
+	Record(Event{Kind: KindDecision})
+}
+
+func TestNewLoggerFromConfig(t *testing.T) {
+	logger, err := NewLoggerFromConfig(config.AuditConfig{})
+	assert.NoError(t, err)
+	assert.Nil(t, logger)
+
+	logger, err = NewLoggerFromConfig(config.AuditConfig{Sink: SinkStdout})
+	assert.NoError(t, err)
+	assert.NotNil(t, logger)
+
+	path := filepath.Join(t.TempDir(), "audit", "decisions.log")
+	logger, err = NewLoggerFromConfig(config.AuditConfig{Sink: SinkFile, FilePath: path, MaxSizeMB: 1, MaxBackups: 1})
+	assert.NoError(t, err)
+	assert.NoError(t, logger.Record(Event{Kind: KindStaleClose}))
+	assert.NoError(t, logger.Close())
+	assert.FileExists(t, path)
+
+	_, err = NewLoggerFromConfig(config.AuditConfig{Sink: SinkFile})
+	assert.Error(t, err)
+	_, err = NewLoggerFromConfig(config.AuditConfig{Sink: "syslog"})
+	assert.Error(t, err)
+}
+
+func TestNewID(t *testing.T) {
+	assert.Len(t, NewID(), 16)
+	assert.NotEqual(t, NewID(), NewID())
+}
+
+func TestMulti(t *testing.T) {
+	assert.Nil(t, Multi())
+
+	var first, second bytes.Buffer
+	recorder := Multi(NewLogger(&first), NewLogger(&second))
+	assert.NoError(t, recorder.Record(Event{Kind: KindDecision, MRIID: 1}))
+
+	var a, b Event
+	assert.NoError(t, json.Unmarshal(first.Bytes(), &a))
+	assert.NoError(t, json.Unmarshal(second.Bytes(), &b))
+	assert.NotEmpty(t, a.ID)
+	assert.Equal(t, a.ID, b.ID, "recorders share the event ID")
+	assert.Equal(t, a.Timestamp, b.Timestamp)
+}
@@ -0,0 +1,58 @@
+package audit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRotatingFile_RotatesBySize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	file, err := OpenRotatingFile(path, 10, 2)
+	assert.NoError(t, err)
+
+	for _, line := range []string{"first\n", "second\n", "third\n", "fourth\n"} {
+		_, err := file.Write([]byte(line))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, file.Close())
+
+	read := func(p string) string {
+		content, err := os.ReadFile(p)
+		assert.NoError(t, err)
+		return string(content)
+	}
+	assert.Equal(t, "fourth\n", read(path))
+	assert.Equal(t, "third\n", read(path+".1"))
+	assert.Equal(t, "second\n", read(path+".2"))
+	assert.NoFileExists(t, path+".3", "backups beyond the limit are removed")
+}
+
+func TestRotatingFile_AppendsToExistingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	assert.NoError(t, os.WriteFile(path, []byte("12345\n"), 0o600))
+
+	file, err := OpenRotatingFile(path, 10, 1)
+	assert.NoError(t, err)
+	_, err = file.Write([]byte("67890\n"))
+	assert.NoError(t, err)
+	assert.NoError(t, file.Close())
+
+	content, _ := os.ReadFile(path + ".1")
+	assert.Equal(t, "12345\n", string(content), "existing content counts towards the size limit")
+}
+
+func TestRotatingFile_NoBackups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	file, err := OpenRotatingFile(path, 8, 0)
+	assert.NoError(t, err)
+	_, _ = file.Write([]byte("first\n"))
+	_, _ = file.Write([]byte("second\n"))
+	assert.NoError(t, file.Close())
+
+	content, _ := os.ReadFile(path)
+	assert.Equal(t, "second\n", string(content))
+	assert.NoFileExists(t, path+".1")
+}
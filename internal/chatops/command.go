@@ -0,0 +1,64 @@
+package chatops
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Commands that can be commented on a merge request
+const (
+	Recheck  = "recheck"  // Evaluate the rules again and update the approval
+	Rebase   = "rebase"   // Rebase the source branch onto the target branch
+	Explain  = "explain"  // Reply with the rule breakdown of the latest decision
+	Override = "override" // Approve an MR that needs manual review, with a reason
+)
+
+// Names lists the supported commands in the order they are documented
+var Names = []string{Recheck, Rebase, Explain, Override}
+
+// commandPattern matches `/naysayer <command> [arguments]` on its own line
+var commandPattern = regexp.MustCompile(`(?m)^[ \t]*/naysayer[ \t]+(\S+)(.*)$`)
+
+// ErrUnknownCommand is returned for /naysayer commands naysayer does not support
+var ErrUnknownCommand = errors.New("unknown naysayer command")
+
+// ErrMissingReason is returned for override commands without a reason
+var ErrMissingReason = errors.New("a reason is required")
+
+// Command is a parsed `/naysayer <command>` request
+type Command struct {
+	Name   string
+	Reason string // Override reason; other commands ignore their arguments
+}
+
+// ParseCommand finds a recheck, rebase, explain or override command in a comment. It
+// returns nil without an error when the comment has no command or holds an approve-until
+// command, which the override package parses, and an error wrapping ErrUnknownCommand or
+// ErrMissingReason otherwise.
+func ParseCommand(body string) (*Command, error) {
+	match := commandPattern.FindStringSubmatch(body)
+	if match == nil {
+		return nil, nil
+	}
+
+	name := strings.ToLower(match[1])
+	for _, known := range Names {
+		if name != known {
+			continue
+		}
+		cmd := &Command{Name: name}
+		if name == Override {
+			cmd.Reason = strings.Trim(strings.TrimSpace(match[2]), `"`)
+			if cmd.Reason == "" {
+				return nil, fmt.Errorf("%w, e.g. /naysayer override vendor contract approved by finance", ErrMissingReason)
+			}
+		}
+		return cmd, nil
+	}
+	if name == "approve-until" {
+		return nil, nil
+	}
+	return nil, fmt.Errorf("%w %q, expected one of: %s", ErrUnknownCommand, match[1], strings.Join(Names, ", "))
+}
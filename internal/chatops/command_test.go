@@ -0,0 +1,48 @@
+package chatops
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCommand(t *testing.T) {
+	for body, name := range map[string]string{
+		"/naysayer recheck":                   Recheck,
+		"Pushed a fix.\n  /naysayer REBASE  ": Rebase,
+		"/naysayer explain\nthanks":           Explain,
+	} {
+		cmd, err := ParseCommand(body)
+		assert.NoError(t, err, body)
+		assert.Equal(t, name, cmd.Name, body)
+	}
+
+	for _, body := range []string{
+		"please /naysayer recheck",
+		"/naysayer approve-until 2024-07-01",
+		"LGTM",
+	} {
+		cmd, err := ParseCommand(body)
+		assert.NoError(t, err, body)
+		assert.Nil(t, cmd, body)
+	}
+}
+
+func TestParseCommand_Unknown(t *testing.T) {
+	cmd, err := ParseCommand("/naysayer merge")
+	assert.Nil(t, cmd)
+	assert.True(t, errors.Is(err, ErrUnknownCommand))
+	assert.Contains(t, err.Error(), "recheck, rebase, explain")
+}
+
+func TestParseCommand_Override(t *testing.T) {
+	cmd, err := ParseCommand("Signed off in the vendor review.\n/naysayer override \"contract approved by finance\"")
+	assert.NoError(t, err)
+	assert.Equal(t, Override, cmd.Name)
+	assert.Equal(t, "contract approved by finance", cmd.Reason)
+
+	cmd, err = ParseCommand("/naysayer override   ")
+	assert.Nil(t, cmd)
+	assert.True(t, errors.Is(err, ErrMissingReason))
+}
@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/accessreview"
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/repoindex"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+)
+
+// repositoryClient is the GitLab access needed by the access review export
+type repositoryClient interface {
+	repoindex.TreeLister
+	accessreview.FileFetcher
+}
+
+// newRepositoryClient creates the GitLab client used by the export (replaced in tests)
+var newRepositoryClient = func(cfg *config.Config) repositoryClient {
+	return gitlab.NewClientWithConfig(cfg)
+}
+
+func init() {
+	register(&Command{
+		Name:        "access-review",
+		Description: "Export every UNMASKED masking policy grant on a branch (CSV/JSON)",
+		Run:         runAccessReview,
+	})
+}
+
+// runAccessReview writes the UNMASKED grant report to stdout or a file
+func runAccessReview(args []string, cfg *config.Config, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("access-review", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	projectID := flags.Int("project", 0, "GitLab project ID of the dataproduct config repository (required)")
+	ref := flags.String("ref", "main", "Branch to scan")
+	format := flags.String("format", accessreview.FormatCSV, "Output format: csv or json")
+	output := flags.String("output", "", "Write the report to this file instead of stdout")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *projectID <= 0 {
+		fmt.Fprintln(stderr, "access-review: -project is required")
+		flags.Usage()
+		return 2
+	}
+
+	out := stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			fmt.Fprintf(stderr, "access-review: %v\n", err)
+			return 1
+		}
+		defer file.Close()
+		out = file
+	}
+
+	writer, err := accessreview.NewWriter(*format, out)
+	if err != nil {
+		fmt.Fprintf(stderr, "access-review: %v\n", err)
+		return 2
+	}
+
+	client := newRepositoryClient(cfg)
+	scanner := accessreview.NewScanner(repoindex.NewIndex(client, store.NewMemoryStore()), client)
+
+	count := 0
+	err = scanner.Scan(context.Background(), *projectID, *ref, func(g accessreview.Grant) error {
+		count++
+		return writer.Write(g)
+	})
+	if closeErr := writer.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "access-review: %v\n", err)
+		return 1
+	}
+
+	fmt.Fprintf(stderr, "access-review: %d UNMASKED grants exported from project %d ref %s\n", count, *projectID, *ref)
+	return 0
+}
@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+)
+
+// mockRepositoryClient serves a repository tree and file contents
+type mockRepositoryClient struct {
+	files map[string]string
+}
+
+func (m *mockRepositoryClient) ListRepositoryTree(ctx context.Context, projectID int, ref string) ([]gitlab.TreeEntry, error) {
+	entries := make([]gitlab.TreeEntry, 0, len(m.files))
+	for path := range m.files {
+		entries = append(entries, gitlab.TreeEntry{Type: "blob", Path: path})
+	}
+	return entries, nil
+}
+
+func (m *mockRepositoryClient) FetchFileContent(ctx context.Context, projectID int, filePath, ref string) (*gitlab.FileContent, error) {
+	content, ok := m.files[filePath]
+	if !ok {
+		return nil, fmt.Errorf("file not found: %s", filePath)
+	}
+	return &gitlab.FileContent{FilePath: filePath, Content: content}, nil
+}
+
+func useMockRepository(t *testing.T) {
+	original := newRepositoryClient
+	newRepositoryClient = func(cfg *config.Config) repositoryClient {
+		return &mockRepositoryClient{files: map[string]string{
+			"dataproducts/source/analytics/prod/pii_masking.yaml": "kind: MaskingPolicy\nname: analytics_pii_string_policy\ndatatype: string\ncases:\n  - strategy: UNMASKED\n    consumers:\n      - kind: consumer_group\n        name: dataverse-source-analytics\n",
+		}}
+	}
+	t.Cleanup(func() { newRepositoryClient = original })
+}
+
+func TestRunAccessReview_CSVToStdout(t *testing.T) {
+	useMockRepository(t)
+
+	var stdout, stderr bytes.Buffer
+	code := Run([]string{"access-review", "-project", "42"}, &config.Config{}, &stdout, &stderr)
+	assert.Equal(t, 0, code, stderr.String())
+	assert.Contains(t, stdout.String(), "data_product,environment,consumer_kind,consumer,datatype,policy,file_path\n")
+	assert.Contains(t, stdout.String(), "analytics,prod,consumer_group,dataverse-source-analytics,string")
+	assert.Contains(t, stderr.String(), "1 UNMASKED grants exported")
+}
+
+func TestRunAccessReview_JSONToFile(t *testing.T) {
+	useMockRepository(t)
+	output := filepath.Join(t.TempDir(), "report.json")
+
+	var stdout, stderr bytes.Buffer
+	code := Run([]string{"access-review", "-project", "42", "-format", "json", "-output", output}, &config.Config{}, &stdout, &stderr)
+	assert.Equal(t, 0, code, stderr.String())
+	assert.Empty(t, stdout.String())
+
+	data, err := os.ReadFile(output)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"consumer":"dataverse-source-analytics"`)
+}
+
+func TestRunAccessReview_InvalidFlags(t *testing.T) {
+	useMockRepository(t)
+
+	var stdout, stderr bytes.Buffer
+	assert.Equal(t, 2, Run([]string{"access-review"}, &config.Config{}, &stdout, &stderr))
+	assert.Equal(t, 2, Run([]string{"access-review", "-project", "1", "-format", "xml"}, &config.Config{}, &stdout, &stderr))
+}
+
+func TestRun_UnknownCommandAndHelp(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	assert.Equal(t, 2, Run([]string{"nope"}, &config.Config{}, &stdout, &stderr))
+	assert.Contains(t, stderr.String(), "unknown command")
+
+	stdout.Reset()
+	assert.Equal(t, 0, Run([]string{"help"}, &config.Config{}, &stdout, &stderr))
+	assert.Contains(t, stdout.String(), "access-review")
+	assert.True(t, IsCommand("access-review"))
+	assert.False(t, IsCommand("-port"))
+}
@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+)
+
+// Command is a one-shot naysayer subcommand
+type Command struct {
+	Name        string
+	Description string
+	Run         func(args []string, cfg *config.Config, stdout, stderr io.Writer) int
+}
+
+// commands holds the available subcommands by name
+var commands = map[string]*Command{}
+
+// register adds a subcommand
+func register(cmd *Command) {
+	commands[cmd.Name] = cmd
+}
+
+// IsCommand reports whether name is a known subcommand
+func IsCommand(name string) bool {
+	_, ok := commands[name]
+	return ok || name == "help"
+}
+
+// Run executes the subcommand named by args[0] and returns the process exit code
+func Run(args []string, cfg *config.Config, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "help" {
+		usage(stdout)
+		return 0
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "unknown command %q\n\n", args[0])
+		usage(stderr)
+		return 2
+	}
+	return cmd.Run(args[1:], cfg, stdout, stderr)
+}
+
+// usage prints the list of subcommands
+func usage(w io.Writer) {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var sb strings.Builder
+	sb.WriteString("Usage: naysayer [command] [flags]\n\n")
+	sb.WriteString("Without a command the webhook server is started.\n\nCommands:\n")
+	for _, name := range names {
+		sb.WriteString(fmt.Sprintf("  %-16s %s\n", name, commands[name].Description))
+	}
+	fmt.Fprint(w, sb.String())
+}
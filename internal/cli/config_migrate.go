@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+)
+
+func init() {
+	register(&Command{
+		Name:        "config",
+		Description: "Configuration tools: `config migrate` rewrites legacy keys in config files",
+		Run:         runConfig,
+	})
+}
+
+// runConfig dispatches the config subcommands
+func runConfig(args []string, cfg *config.Config, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] != "migrate" {
+		fmt.Fprintln(stderr, "Usage: naysayer config migrate [-write] [-check] FILE...")
+		return 2
+	}
+	return runConfigMigrate(args[1:], stdout, stderr)
+}
+
+// runConfigMigrate reports legacy keys in env files and manifests and rewrites them with -write
+func runConfigMigrate(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("config migrate", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	write := flags.Bool("write", false, "Rewrite the files in place instead of only reporting")
+	check := flags.Bool("check", false, "Exit with status 1 when legacy keys are found (for CI)")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() == 0 {
+		fmt.Fprintln(stderr, "config migrate: at least one file is required")
+		flags.Usage()
+		return 2
+	}
+
+	found, failed := false, false
+	for _, path := range flags.Args() {
+		info, err := os.Stat(path)
+		if err != nil {
+			fmt.Fprintf(stderr, "config migrate: %v\n", err)
+			failed = true
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			fmt.Fprintf(stderr, "config migrate: %v\n", err)
+			failed = true
+			continue
+		}
+
+		migrated, changes := config.MigrateText(string(data))
+		if len(changes) == 0 {
+			fmt.Fprintf(stdout, "%s: no legacy keys\n", path)
+			continue
+		}
+		found = true
+		for _, change := range changes {
+			if change.Conflict {
+				fmt.Fprintf(stdout, "%s: %s (%d occurrences) not rewritten, %s is already used; remove the legacy key manually\n",
+					path, change.Old, change.Occurrences, change.New)
+				continue
+			}
+			fmt.Fprintf(stdout, "%s: %s -> %s (%d occurrences)\n", path, change.Old, change.New, change.Occurrences)
+		}
+
+		if *write && migrated != string(data) {
+			if err := os.WriteFile(path, []byte(migrated), info.Mode().Perm()); err != nil {
+				fmt.Fprintf(stderr, "config migrate: %v\n", err)
+				failed = true
+				continue
+			}
+			fmt.Fprintf(stdout, "%s: rewritten\n", path)
+		}
+	}
+
+	switch {
+	case failed:
+		return 1
+	case found && !*write:
+		fmt.Fprintln(stdout, "Run with -write to rewrite the files. Rename keys in Secrets and the manifests referencing them together.")
+		if *check {
+			return 1
+		}
+	}
+	return 0
+}
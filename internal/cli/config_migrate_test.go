@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+)
+
+func writeLegacyEnvFile(t *testing.T) string {
+	path := filepath.Join(t.TempDir(), "naysayer.env")
+	assert.NoError(t, os.WriteFile(path, []byte("GITLAB_TOKEN=main\nGITLAB_TOKEN_FIVETRAN=repo\n"), 0600))
+	return path
+}
+
+func TestRunConfigMigrate_ReportsWithoutWriting(t *testing.T) {
+	path := writeLegacyEnvFile(t)
+
+	var stdout, stderr bytes.Buffer
+	code := Run([]string{"config", "migrate", path}, &config.Config{}, &stdout, &stderr)
+
+	assert.Equal(t, 0, code, stderr.String())
+	assert.Contains(t, stdout.String(), "GITLAB_TOKEN_FIVETRAN -> AUTO_REBASE_REPOSITORY_TOKEN (1 occurrences)")
+	data, _ := os.ReadFile(path)
+	assert.Contains(t, string(data), "GITLAB_TOKEN_FIVETRAN=repo")
+}
+
+func TestRunConfigMigrate_Check(t *testing.T) {
+	path := writeLegacyEnvFile(t)
+
+	var stdout, stderr bytes.Buffer
+	assert.Equal(t, 1, Run([]string{"config", "migrate", "-check", path}, &config.Config{}, &stdout, &stderr))
+}
+
+func TestRunConfigMigrate_Write(t *testing.T) {
+	path := writeLegacyEnvFile(t)
+
+	var stdout, stderr bytes.Buffer
+	code := Run([]string{"config", "migrate", "-write", "-check", path}, &config.Config{}, &stdout, &stderr)
+
+	assert.Equal(t, 0, code, stderr.String())
+	data, _ := os.ReadFile(path)
+	assert.Equal(t, "GITLAB_TOKEN=main\nAUTO_REBASE_REPOSITORY_TOKEN=repo\n", string(data))
+	info, _ := os.Stat(path)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+func TestRunConfig_Usage(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	assert.Equal(t, 2, Run([]string{"config"}, &config.Config{}, &stdout, &stderr))
+	assert.Equal(t, 2, Run([]string{"config", "migrate"}, &config.Config{}, &stdout, &stderr))
+	assert.Equal(t, 1, Run([]string{"config", "migrate", "/nonexistent/naysayer.env"}, &config.Config{}, &stdout, &stderr))
+}
@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/localeval"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+)
+
+// Output formats of the evaluate command
+const (
+	evaluateFormatTable = "table"
+	evaluateFormatJSON  = "json"
+)
+
+// newEvaluateClient creates the GitLab client reading MRs to evaluate (replaced in tests)
+var newEvaluateClient = func(cfg *config.Config) gitlab.GitLabClient {
+	return gitlab.NewClientWithConfig(cfg)
+}
+
+func init() {
+	register(&Command{
+		Name:        "evaluate",
+		Description: "Run the rules on an MR or a local before/after directory and print the decision",
+		Run:         runEvaluate,
+	})
+}
+
+// runEvaluate evaluates an MR, or the changes between the before/ and after/ folders of a
+// directory, with the section rules and prints the decision. It exits 1 unless approved.
+func runEvaluate(args []string, cfg *config.Config, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("evaluate", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	projectID := flags.Int("project", 0, "GitLab project ID of the MR, or of the repository the directory belongs to")
+	mrIID := flags.Int("mr", 0, "IID of the MR to evaluate")
+	dir := flags.String("dir", "", "Directory with before/ and after/ folders to evaluate instead of an MR")
+	rulesPath := flags.String("rules", rules.RulesConfigPath, "Rule configuration file")
+	format := flags.String("format", evaluateFormatTable, "Output format: table or json")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if (*dir == "") == (*mrIID == 0) {
+		fmt.Fprintln(stderr, "evaluate: either -dir or -project with -mr is required")
+		flags.Usage()
+		return 2
+	}
+	if *dir == "" && *projectID <= 0 {
+		fmt.Fprintln(stderr, "evaluate: -project is required with -mr")
+		flags.Usage()
+		return 2
+	}
+	if *format != evaluateFormatTable && *format != evaluateFormatJSON {
+		fmt.Fprintf(stderr, "evaluate: unknown format %q, use table or json\n", *format)
+		return 2
+	}
+
+	ctx := context.Background()
+	var result *shared.RuleEvaluation
+	var err error
+	if *dir != "" {
+		result, err = localeval.EvaluateDir(ctx, *dir, *projectID, *rulesPath)
+	} else {
+		result, err = localeval.EvaluateMR(ctx, newEvaluateClient(cfg), *projectID, *mrIID, *rulesPath)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "evaluate: %v\n", err)
+		return 1
+	}
+
+	if *format == evaluateFormatJSON {
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(result); err != nil {
+			fmt.Fprintf(stderr, "evaluate: %v\n", err)
+			return 1
+		}
+	} else {
+		writeEvaluationTable(stdout, result)
+	}
+
+	if result.FinalDecision.Type != shared.Approve {
+		return 1
+	}
+	return 0
+}
+
+// writeEvaluationTable prints the decision and one row per rule result of each file
+func writeEvaluationTable(w io.Writer, result *shared.RuleEvaluation) {
+	fmt.Fprintf(w, "Decision: %s (%s)\n", result.FinalDecision.Type, result.FinalDecision.Summary)
+	fmt.Fprintf(w, "Reason:   %s\n\n", result.FinalDecision.Reason)
+
+	paths := make([]string, 0, len(result.FileValidations))
+	for path := range result.FileValidations {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	table := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(table, "FILE\tRULE\tDECISION\tREASON")
+	for _, path := range paths {
+		validation := result.FileValidations[path]
+		if len(validation.RuleResults) == 0 {
+			fmt.Fprintf(table, "%s\t-\t%s\t\n", path, validation.FileDecision)
+			continue
+		}
+		for _, ruleResult := range validation.RuleResults {
+			fmt.Fprintf(table, "%s\t%s\t%s\t%s\n", path, ruleResult.RuleName, ruleResult.Decision, strings.ReplaceAll(ruleResult.Reason, "\n", " "))
+		}
+	}
+	_ = table.Flush()
+}
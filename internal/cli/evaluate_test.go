@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/localeval"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+)
+
+const (
+	evaluateRules   = "../../e2e/rules.yaml"
+	approveScenario = "../../e2e/testdata/scenarios/05_metadata_readme"
+	reviewScenario  = "../../e2e/testdata/scenarios/02_warehouse_increase"
+)
+
+func TestRunEvaluate_DirTable(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := Run([]string{"evaluate", "-dir", reviewScenario, "-rules", evaluateRules}, &config.Config{}, &stdout, &stderr)
+
+	assert.Equal(t, 1, code, "manual reviews exit with 1")
+	assert.Contains(t, stdout.String(), "Decision: manual_review (⚠️ Manual review required)\n")
+	assert.Contains(t, stdout.String(), "Reason:   Warehouse changes require manual review\n")
+	assert.Regexp(t, `dataproducts/marketing/prod/product.yaml\s+warehouse_rule\s+manual_review\s+Warehouse size increase detected`, stdout.String())
+}
+
+func TestRunEvaluate_DirJSON(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := Run([]string{"evaluate", "-dir", approveScenario, "-rules", evaluateRules, "-format", "json"}, &config.Config{}, &stdout, &stderr)
+
+	assert.Equal(t, 0, code, stderr.String())
+	var result shared.RuleEvaluation
+	assert.NoError(t, json.Unmarshal(stdout.Bytes(), &result))
+	assert.Equal(t, shared.Approve, result.FinalDecision.Type)
+	assert.Contains(t, result.FileValidations, "dataproducts/analytics/README.md")
+}
+
+func TestRunEvaluate_MR(t *testing.T) {
+	original := newEvaluateClient
+	newEvaluateClient = func(cfg *config.Config) gitlab.GitLabClient {
+		return localeval.NewDirClient(approveScenario+"/before", approveScenario+"/after")
+	}
+	t.Cleanup(func() { newEvaluateClient = original })
+
+	var stdout, stderr bytes.Buffer
+	code := Run([]string{"evaluate", "-project", "42", "-mr", "7", "-rules", evaluateRules}, &config.Config{}, &stdout, &stderr)
+
+	assert.Equal(t, 0, code, stderr.String())
+	assert.Contains(t, stdout.String(), "Decision: approve (✅ Auto-approved)\n")
+}
+
+func TestRunEvaluate_Usage(t *testing.T) {
+	tests := [][]string{
+		{"evaluate"},
+		{"evaluate", "-mr", "7"},
+		{"evaluate", "-dir", approveScenario, "-mr", "7", "-project", "42"},
+		{"evaluate", "-dir", approveScenario, "-format", "yaml"},
+	}
+	for _, args := range tests {
+		var stdout, stderr bytes.Buffer
+		assert.Equal(t, 2, Run(args, &config.Config{}, &stdout, &stderr), args)
+	}
+
+	var stdout, stderr bytes.Buffer
+	code := Run([]string{"evaluate", "-dir", t.TempDir(), "-rules", evaluateRules}, &config.Config{}, &stdout, &stderr)
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr.String(), "evaluate: ")
+}
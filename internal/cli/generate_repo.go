@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/synthrepo"
+)
+
+func init() {
+	register(&Command{
+		Name:        "generate-repo",
+		Description: "Write a synthetic dataverse-config repository for tests and benchmarks",
+		Run:         runGenerateRepo,
+	})
+}
+
+// runGenerateRepo writes a generated repository to a directory and lists its invalid files
+func runGenerateRepo(args []string, cfg *config.Config, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("generate-repo", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	output := flags.String("output", "", "Directory to write the repository to (required)")
+	products := flags.Int("products", 10, "Number of data products")
+	envs := flags.String("envs", strings.Join(synthrepo.DefaultEnvironments, ","), "Comma-separated environments per data product")
+	invalidRatio := flags.Float64("invalid-ratio", 0.1, "Share of data products with one invalid file, 0 to 1")
+	seed := flags.Int64("seed", 1, "Seed of the generator; equal flags generate equal repositories")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *output == "" {
+		fmt.Fprintln(stderr, "generate-repo: -output is required")
+		flags.Usage()
+		return 2
+	}
+
+	var environments []string
+	for _, env := range strings.Split(*envs, ",") {
+		if env = strings.TrimSpace(env); env != "" {
+			environments = append(environments, env)
+		}
+	}
+	repo, err := synthrepo.Generate(synthrepo.Options{
+		Products:     *products,
+		Environments: environments,
+		InvalidRatio: *invalidRatio,
+		Seed:         *seed,
+	})
+	if err != nil {
+		fmt.Fprintf(stderr, "generate-repo: %v\n", err)
+		return 2
+	}
+	if err := repo.Write(*output); err != nil {
+		fmt.Fprintf(stderr, "generate-repo: %v\n", err)
+		return 1
+	}
+
+	for _, invalid := range repo.Invalid {
+		fmt.Fprintf(stdout, "%s\t%s\n", invalid.Path, invalid.Kind)
+	}
+	fmt.Fprintf(stderr, "generate-repo: %d files for %d data products written to %s (%d invalid)\n",
+		len(repo.Files), *products, *output, len(repo.Invalid))
+	return 0
+}
@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+)
+
+func TestRunGenerateRepo(t *testing.T) {
+	dir := t.TempDir()
+
+	var stdout, stderr bytes.Buffer
+	code := Run([]string{"generate-repo", "-output", dir, "-products", "3", "-envs", "dev,prod", "-invalid-ratio", "0.34"}, &config.Config{}, &stdout, &stderr)
+	assert.Equal(t, 0, code, stderr.String())
+	assert.Contains(t, stderr.String(), "24 files for 3 data products")
+	assert.Contains(t, stderr.String(), "(1 invalid)")
+
+	invalid := strings.Split(strings.TrimSpace(stdout.String()), "\t")
+	if assert.Len(t, invalid, 2) {
+		_, err := os.Stat(filepath.Join(dir, filepath.FromSlash(invalid[0])))
+		assert.NoError(t, err)
+	}
+	_, err := os.Stat(filepath.Join(dir, "dataproducts", "aggregate", "product001", "prod", "product.yaml"))
+	assert.NoError(t, err)
+}
+
+func TestRunGenerateRepo_RequiresOutput(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	assert.Equal(t, 2, Run([]string{"generate-repo"}, &config.Config{}, &stdout, &stderr))
+	assert.Contains(t, stderr.String(), "-output is required")
+
+	stderr.Reset()
+	assert.Equal(t, 2, Run([]string{"generate-repo", "-output", t.TempDir(), "-invalid-ratio", "2"}, &config.Config{}, &stdout, &stderr))
+	assert.Contains(t, stderr.String(), "invalid ratio must be between 0 and 1")
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/codequality"
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/localeval"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+)
+
+func init() {
+	register(&Command{
+		Name:        "lint",
+		Description: "Run the rules on the commits of a git checkout and write a GitLab Code Quality report",
+		Run:         runLint,
+	})
+}
+
+// runLint evaluates the changes between two commits of a local repository and writes the
+// findings as a GitLab Code Quality report. The CI variables of merge request pipelines
+// provide the defaults.
+func runLint(args []string, cfg *config.Config, stdout, stderr io.Writer) int {
+	defaultProject, _ := strconv.Atoi(os.Getenv("CI_PROJECT_ID"))
+	flags := flag.NewFlagSet("lint", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	repoDir := flags.String("repo", ".", "Git repository to read, a checkout or a bare repository")
+	base := flags.String("base", os.Getenv("CI_MERGE_REQUEST_DIFF_BASE_SHA"), "Commit the changes are compared with (default $CI_MERGE_REQUEST_DIFF_BASE_SHA)")
+	head := flags.String("head", "HEAD", "Commit with the changes")
+	projectID := flags.Int("project", defaultProject, "GitLab project ID whose project overrides apply (default $CI_PROJECT_ID)")
+	rulesPath := flags.String("rules", rules.RulesConfigPath, "Rule configuration file")
+	output := flags.String("output", "", "Write the report to this file (e.g. gl-code-quality-report.json) instead of stdout")
+	check := flags.Bool("check", false, "Exit with status 1 when the changes need manual review (for CI and pre-receive hooks)")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *base == "" {
+		fmt.Fprintln(stderr, "lint: -base is required outside of merge request pipelines")
+		flags.Usage()
+		return 2
+	}
+
+	result, err := localeval.EvaluateGit(context.Background(), *repoDir, *base, *head, *projectID, *rulesPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "lint: %v\n", err)
+		return 1
+	}
+	issues := codequality.FromEvaluation(result)
+
+	out := stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			fmt.Fprintf(stderr, "lint: %v\n", err)
+			return 1
+		}
+		defer file.Close()
+		out = file
+	}
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(issues); err != nil {
+		fmt.Fprintf(stderr, "lint: %v\n", err)
+		return 1
+	}
+
+	fmt.Fprintf(stderr, "lint: %s, %d findings in %d changed files\n", result.FinalDecision.Type, len(issues), result.TotalFiles)
+	if *check && result.FinalDecision.Type != shared.Approve {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/codequality"
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+)
+
+// lintRepo commits the before/ and then the after/ folder of an e2e scenario and returns
+// the repository with both commits
+func lintRepo(t *testing.T, scenario string) (repo, base, head string) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	repo = t.TempDir()
+	git := func(args ...string) string {
+		output, err := exec.Command("git", append([]string{"-C", repo, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...).CombinedOutput()
+		assert.NoError(t, err, string(output))
+		return strings.TrimSpace(string(output))
+	}
+	git("init", "-q")
+	for _, folder := range []string{"before", "after"} {
+		git("rm", "-rq", "--ignore-unmatch", ".")
+		assert.NoError(t, os.CopyFS(repo, os.DirFS(filepath.Join(scenario, folder))))
+		git("add", "-A")
+		git("commit", "-q", "--allow-empty", "-m", folder)
+		if folder == "before" {
+			base = git("rev-parse", "HEAD")
+		}
+	}
+	return repo, base, git("rev-parse", "HEAD")
+}
+
+func TestRunLint_ReportsFindings(t *testing.T) {
+	repo, base, head := lintRepo(t, reviewScenario)
+	output := filepath.Join(t.TempDir(), "gl-code-quality-report.json")
+
+	var stdout, stderr bytes.Buffer
+	code := Run([]string{"lint", "-repo", repo, "-base", base, "-head", head, "-rules", evaluateRules, "-output", output}, &config.Config{}, &stdout, &stderr)
+	assert.Equal(t, 0, code, stderr.String())
+	assert.Contains(t, stderr.String(), "lint: manual_review, 1 findings in 1 changed files")
+
+	data, err := os.ReadFile(output)
+	assert.NoError(t, err)
+	var issues []codequality.Issue
+	assert.NoError(t, json.Unmarshal(data, &issues))
+	if assert.Len(t, issues, 1) {
+		assert.Equal(t, "warehouse_rule", issues[0].CheckName)
+		assert.Equal(t, "dataproducts/marketing/prod/product.yaml", issues[0].Location.Path)
+		assert.Equal(t, codequality.SeverityMajor, issues[0].Severity)
+	}
+
+	code = Run([]string{"lint", "-repo", repo, "-base", base, "-head", head, "-rules", evaluateRules, "-check"}, &config.Config{}, &stdout, &stderr)
+	assert.Equal(t, 1, code, "-check fails on manual reviews")
+}
+
+func TestRunLint_Approved(t *testing.T) {
+	repo, base, head := lintRepo(t, approveScenario)
+	t.Setenv("CI_MERGE_REQUEST_DIFF_BASE_SHA", base)
+
+	var stdout, stderr bytes.Buffer
+	code := Run([]string{"lint", "-repo", repo, "-head", head, "-rules", evaluateRules, "-check"}, &config.Config{}, &stdout, &stderr)
+	assert.Equal(t, 0, code, stderr.String())
+	assert.Equal(t, "[]\n", stdout.String())
+}
+
+func TestRunLint_Usage(t *testing.T) {
+	t.Setenv("CI_MERGE_REQUEST_DIFF_BASE_SHA", "")
+
+	var stdout, stderr bytes.Buffer
+	assert.Equal(t, 2, Run([]string{"lint"}, &config.Config{}, &stdout, &stderr))
+	assert.Contains(t, stderr.String(), "-base is required")
+}
@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/selftest"
+	"github.com/redhat-data-and-ai/naysayer/internal/webhook"
+)
+
+// selfTestRunner runs self-test cases (implemented by *selftest.Runner)
+type selfTestRunner interface {
+	Run(ctx context.Context, runID string, cases []selftest.Case) []selftest.CaseResult
+}
+
+// newSelfTestRunner wires the self-test MR author, the review bot and the review trigger
+// (replaced in tests)
+var newSelfTestRunner = func(ctx context.Context, cfg *config.Config, stCfg config.SelfTestConfig) (selfTestRunner, error) {
+	authorConfig := cfg.GitLab
+	if stCfg.Token != "" {
+		authorConfig.Token = stCfg.Token
+		authorConfig.TokenFile = ""
+	}
+	authorClient := gitlab.NewClient(authorConfig)
+	author, err := authorClient.GetCurrentBotUsername(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up the self-test MR author: %w", err)
+	}
+
+	reviewClient := gitlab.NewClientForFunction(cfg, config.EndpointReview)
+	reviewer, err := reviewClient.GetCurrentBotUsername(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up the review bot: %w", err)
+	}
+
+	var trigger selftest.Trigger
+	if stCfg.WebhookURL != "" {
+		trigger = selftest.WebhookTrigger(stCfg.WebhookURL, cfg.Webhook.SecretFor(config.EndpointReview), author)
+	} else {
+		handler := webhook.NewDataProductConfigMrReviewHandlerWithClient(cfg, reviewClient)
+		trigger = selftest.HandlerTrigger(handler.HandleWebhook, author)
+	}
+	return selftest.NewRunner(authorClient, trigger, stCfg, reviewer), nil
+}
+
+func init() {
+	register(&Command{
+		Name:        "selftest",
+		Description: "Open disposable MRs in a sandbox project and verify naysayer reviews them",
+		Run:         runSelfTest,
+	})
+}
+
+// runSelfTest reviews the built-in known-good and known-bad fixture MRs and reports each outcome
+func runSelfTest(args []string, cfg *config.Config, stdout, stderr io.Writer) int {
+	stCfg := cfg.SelfTest
+	flags := flag.NewFlagSet("selftest", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.IntVar(&stCfg.ProjectID, "project", stCfg.ProjectID, "GitLab project ID of the sandbox project (default: SELFTEST_PROJECT_ID)")
+	flags.StringVar(&stCfg.TargetBranch, "target", stCfg.TargetBranch, "Branch the self-test MRs target")
+	flags.StringVar(&stCfg.WebhookURL, "webhook-url", stCfg.WebhookURL, "Review endpoint of a deployed instance; empty reviews in-process")
+	flags.IntVar(&stCfg.TimeoutSeconds, "timeout", stCfg.TimeoutSeconds, "Seconds to wait for the decision of each MR")
+	runID := flags.String("run-id", "", "Identifier of the run in branch names (default: current UTC time)")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if stCfg.ProjectID <= 0 {
+		fmt.Fprintln(stderr, "selftest: -project or SELFTEST_PROJECT_ID is required")
+		flags.Usage()
+		return 2
+	}
+	if stCfg.TargetBranch == "" || stCfg.TimeoutSeconds <= 0 {
+		fmt.Fprintln(stderr, "selftest: -target must not be empty and -timeout must be positive")
+		return 2
+	}
+	if stCfg.WebhookURL == "" && !cfg.Comments.EnableMRComments {
+		fmt.Fprintln(stderr, "selftest: in-process reviews need ENABLE_MR_COMMENTS=true to verify decisions")
+		return 2
+	}
+	if *runID == "" {
+		*runID = time.Now().UTC().Format("20060102-150405")
+	}
+
+	ctx := context.Background()
+	runner, err := newSelfTestRunner(ctx, cfg, stCfg)
+	if err != nil {
+		fmt.Fprintf(stderr, "selftest: %v\n", err)
+		return 1
+	}
+
+	passed := 0
+	results := runner.Run(ctx, *runID, selftest.DefaultCases(*runID))
+	for _, result := range results {
+		if result.Passed() {
+			passed++
+			fmt.Fprintf(stdout, "PASS\t%s\t!%d\n", result.Name, result.MRIID)
+		} else {
+			fmt.Fprintf(stdout, "FAIL\t%s\t!%d\t%v\n", result.Name, result.MRIID, result.Err)
+		}
+		if result.Notes != "" {
+			fmt.Fprintf(stderr, "selftest: %s cleanup: %s\n", result.Name, result.Notes)
+		}
+	}
+
+	fmt.Fprintf(stderr, "selftest: %d/%d cases passed in project %d\n", passed, len(results), stCfg.ProjectID)
+	if passed != len(results) {
+		return 1
+	}
+	return 0
+}
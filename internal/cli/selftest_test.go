@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/selftest"
+)
+
+// stubSelfTestRunner returns canned results and records the run
+type stubSelfTestRunner struct {
+	results []selftest.CaseResult
+	runID   string
+	cases   []selftest.Case
+}
+
+func (s *stubSelfTestRunner) Run(ctx context.Context, runID string, cases []selftest.Case) []selftest.CaseResult {
+	s.runID = runID
+	s.cases = cases
+	return s.results
+}
+
+func useStubSelfTestRunner(t *testing.T, runner *stubSelfTestRunner) *config.SelfTestConfig {
+	var used config.SelfTestConfig
+	original := newSelfTestRunner
+	newSelfTestRunner = func(ctx context.Context, cfg *config.Config, stCfg config.SelfTestConfig) (selfTestRunner, error) {
+		used = stCfg
+		return runner, nil
+	}
+	t.Cleanup(func() { newSelfTestRunner = original })
+	return &used
+}
+
+func selfTestConfig() *config.Config {
+	return &config.Config{
+		Comments: config.CommentsConfig{EnableMRComments: true},
+		SelfTest: config.SelfTestConfig{ProjectID: 42, TargetBranch: "main", TimeoutSeconds: 120},
+	}
+}
+
+func TestRunSelfTest_Passes(t *testing.T) {
+	runner := &stubSelfTestRunner{results: []selftest.CaseResult{
+		{Name: "known-good", MRIID: 12},
+		{Name: "known-bad", MRIID: 13},
+	}}
+	used := useStubSelfTestRunner(t, runner)
+
+	var stdout, stderr bytes.Buffer
+	code := Run([]string{"selftest", "-run-id", "deploy-7", "-timeout", "30"}, selfTestConfig(), &stdout, &stderr)
+
+	assert.Equal(t, 0, code, stderr.String())
+	assert.Equal(t, "PASS\tknown-good\t!12\nPASS\tknown-bad\t!13\n", stdout.String())
+	assert.Contains(t, stderr.String(), "selftest: 2/2 cases passed in project 42")
+	assert.Equal(t, "deploy-7", runner.runID)
+	assert.Len(t, runner.cases, 2)
+	assert.Equal(t, 30, used.TimeoutSeconds)
+}
+
+func TestRunSelfTest_Failure(t *testing.T) {
+	useStubSelfTestRunner(t, &stubSelfTestRunner{results: []selftest.CaseResult{
+		{Name: "known-good", MRIID: 12, Notes: "failed to delete branch"},
+		{Name: "known-bad", MRIID: 13, Err: errors.New("MR !13: no manual-review comment by naysayer-bot after 120s")},
+	}})
+
+	var stdout, stderr bytes.Buffer
+	code := Run([]string{"selftest"}, selfTestConfig(), &stdout, &stderr)
+
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stdout.String(), "FAIL\tknown-bad\t!13\tMR !13: no manual-review comment")
+	assert.Contains(t, stderr.String(), "known-good cleanup: failed to delete branch")
+	assert.Contains(t, stderr.String(), "1/2 cases passed")
+}
+
+func TestRunSelfTest_UsageErrors(t *testing.T) {
+	useStubSelfTestRunner(t, &stubSelfTestRunner{})
+	var stdout, stderr bytes.Buffer
+
+	cfg := selfTestConfig()
+	cfg.SelfTest.ProjectID = 0
+	assert.Equal(t, 2, Run([]string{"selftest"}, cfg, &stdout, &stderr))
+	assert.Contains(t, stderr.String(), "-project or SELFTEST_PROJECT_ID is required")
+
+	assert.Equal(t, 2, Run([]string{"selftest", "-timeout", "0"}, selfTestConfig(), &stdout, &stderr))
+
+	cfg = selfTestConfig()
+	cfg.Comments.EnableMRComments = false
+	assert.Equal(t, 2, Run([]string{"selftest"}, cfg, &stdout, &stderr))
+	assert.Equal(t, 0, Run([]string{"selftest", "-webhook-url", "https://naysayer.example.com/dataverse-product-config-review"}, cfg, &stdout, &stderr),
+		"a deployed instance posts comments according to its own configuration")
+}
+
+func TestRunSelfTest_SetupError(t *testing.T) {
+	original := newSelfTestRunner
+	newSelfTestRunner = func(ctx context.Context, cfg *config.Config, stCfg config.SelfTestConfig) (selfTestRunner, error) {
+		return nil, errors.New("failed to look up the review bot: 401 Unauthorized")
+	}
+	t.Cleanup(func() { newSelfTestRunner = original })
+
+	var stdout, stderr bytes.Buffer
+	assert.Equal(t, 1, Run([]string{"selftest"}, selfTestConfig(), &stdout, &stderr))
+	assert.Contains(t, stderr.String(), "401 Unauthorized")
+}
@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules"
+)
+
+func init() {
+	register(&Command{
+		Name:        "validate-config",
+		Description: "Strictly validate rules.yaml and print line:column errors",
+		Run:         runValidateConfig,
+	})
+}
+
+// runValidateConfig validates the rule configuration files (rules.yaml by default) against
+// the schema and the rule registry
+func runValidateConfig(args []string, cfg *config.Config, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("validate-config", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: naysayer validate-config [FILE...]")
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	paths := flags.Args()
+	if len(paths) == 0 {
+		paths = []string{rules.RulesConfigPath}
+	}
+
+	failed := false
+	for _, path := range paths {
+		problems := rules.ValidateRuleConfigFile(path, rules.GetGlobalRegistry())
+		if len(problems) == 0 {
+			fmt.Fprintf(stdout, "%s: OK\n", path)
+			continue
+		}
+		failed = true
+		for _, problem := range problems {
+			if problem.Line == 0 {
+				fmt.Fprintf(stdout, "%s: %s\n", path, problem.Message)
+				continue
+			}
+			fmt.Fprintf(stdout, "%s:%s\n", path, problem.Error())
+		}
+	}
+	if failed {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,34 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+)
+
+func TestRunValidateConfig(t *testing.T) {
+	valid := filepath.Join("..", "..", "rules.yaml")
+	invalid := filepath.Join(t.TempDir(), "rules.yaml")
+	assert.NoError(t, os.WriteFile(invalid, []byte("enabled: true\nfiles:\n  - name: docs\n    path: \"**/\"\n    filename: \"*.md\"\n    parser_type: yaml\n    sectons: []\n"), 0600))
+
+	var stdout, stderr bytes.Buffer
+	code := Run([]string{"validate-config", valid}, &config.Config{}, &stdout, &stderr)
+	assert.Equal(t, 0, code, stdout.String())
+	assert.Equal(t, valid+": OK\n", stdout.String())
+
+	stdout.Reset()
+	code = Run([]string{"validate-config", valid, invalid}, &config.Config{}, &stdout, &stderr)
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stdout.String(), valid+": OK\n")
+	assert.Contains(t, stdout.String(), invalid+`:7:5: unknown key "sectons" in files[0] (did you mean "sections"?)`)
+
+	stdout.Reset()
+	code = Run([]string{"validate-config", filepath.Join(t.TempDir(), "missing.yaml")}, &config.Config{}, &stdout, &stderr)
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stdout.String(), "missing.yaml: failed to read rule config")
+}
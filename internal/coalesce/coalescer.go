@@ -0,0 +1,134 @@
+package coalesce
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+)
+
+// Counters of the events handled by all coalescers since startup
+var (
+	evaluated  atomic.Int64
+	coalesced  atomic.Int64
+	serialized atomic.Int64
+)
+
+// Stats reports the events evaluated and coalesced since startup, and how many of them
+// waited for a running evaluation of the same MR
+func Stats() (evaluatedEvents, coalescedEvents, serializedEvents int64) {
+	return evaluated.Load(), coalesced.Load(), serialized.Load()
+}
+
+// Key identifies the MR whose events are coalesced
+func Key(projectID, mrIID int) string {
+	return fmt.Sprintf("%d/%d", projectID, mrIID)
+}
+
+// entry tracks the calls of one key
+type entry struct {
+	latest  uint64        // Sequence number of the newest call
+	calls   int           // Calls in progress, the entry is dropped at zero
+	running chan struct{} // Holds a token while an evaluation runs
+}
+
+// Coalescer runs one evaluation per key at a time. A call waits for the debounce window
+// and for the running evaluation of its key; when a newer call of the same key arrived
+// meanwhile it is skipped, so the latest event wins.
+type Coalescer struct {
+	window time.Duration
+
+	mu   sync.Mutex
+	keys map[string]*entry
+}
+
+// New creates a coalescer waiting window for further calls before evaluating
+func New(window time.Duration) *Coalescer {
+	return &Coalescer{window: window, keys: make(map[string]*entry)}
+}
+
+// NewFromConfig returns a coalescer, or nil when coalescing is disabled
+func NewFromConfig(cfg config.CoalesceConfig) *Coalescer {
+	if !cfg.Enabled {
+		return nil
+	}
+	return New(time.Duration(cfg.DebounceMs) * time.Millisecond)
+}
+
+// Run calls fn unless a newer call of key supersedes it or ctx ends first, and reports
+// whether fn ran. A nil coalescer always calls fn.
+func (c *Coalescer) Run(ctx context.Context, key string, fn func()) bool {
+	if c == nil {
+		fn()
+		return true
+	}
+
+	e, seq := c.enter(key)
+	defer c.leave(key, e)
+
+	if c.window > 0 {
+		timer := time.NewTimer(c.window)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return false
+		}
+	}
+	if c.superseded(e, seq) {
+		coalesced.Add(1)
+		return false
+	}
+
+	select {
+	case e.running <- struct{}{}:
+	default:
+		serialized.Add(1)
+		select {
+		case e.running <- struct{}{}:
+		case <-ctx.Done():
+			return false
+		}
+	}
+	defer func() { <-e.running }()
+
+	// A newer call arriving while this one waited evaluates the MR after it
+	if c.superseded(e, seq) {
+		coalesced.Add(1)
+		return false
+	}
+	evaluated.Add(1)
+	fn()
+	return true
+}
+
+func (c *Coalescer) enter(key string) (*entry, uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e := c.keys[key]
+	if e == nil {
+		e = &entry{running: make(chan struct{}, 1)}
+		c.keys[key] = e
+	}
+	e.latest++
+	e.calls++
+	return e, e.latest
+}
+
+func (c *Coalescer) leave(key string, e *entry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e.calls--
+	if e.calls == 0 {
+		delete(c.keys, key)
+	}
+}
+
+func (c *Coalescer) superseded(e *entry, seq uint64) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return e.latest != seq
+}
@@ -0,0 +1,112 @@
+package coalesce
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+)
+
+// waitForCalls blocks until key has n calls in progress
+func waitForCalls(t *testing.T, c *Coalescer, key string, n int) {
+	t.Helper()
+	assert.Eventually(t, func() bool {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		e := c.keys[key]
+		return e != nil && e.calls == n
+	}, time.Second, time.Millisecond)
+}
+
+func TestNewFromConfig(t *testing.T) {
+	assert.Nil(t, NewFromConfig(config.CoalesceConfig{Enabled: false, DebounceMs: 500}))
+	assert.Equal(t, 500*time.Millisecond, NewFromConfig(config.CoalesceConfig{Enabled: true, DebounceMs: 500}).window)
+
+	var c *Coalescer
+	ran := false
+	assert.True(t, c.Run(context.Background(), "1/1", func() { ran = true }), "a nil coalescer always runs")
+	assert.True(t, ran)
+}
+
+func TestCoalescer_DebounceLatestWins(t *testing.T) {
+	c := New(100 * time.Millisecond)
+	evaluatedBefore, coalescedBefore, _ := Stats()
+
+	var ran []int
+	var mu sync.Mutex
+	results := make([]bool, 3)
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = c.Run(context.Background(), Key(1, 2), func() {
+				mu.Lock()
+				ran = append(ran, i)
+				mu.Unlock()
+			})
+		}(i)
+		waitForCalls(t, c, Key(1, 2), i+1)
+	}
+
+	// Another MR is not affected
+	assert.True(t, c.Run(context.Background(), Key(1, 3), func() {}))
+	wg.Wait()
+
+	assert.Equal(t, []bool{false, false, true}, results)
+	assert.Equal(t, []int{2}, ran)
+	evaluatedAfter, coalescedAfter, _ := Stats()
+	assert.Equal(t, int64(2), evaluatedAfter-evaluatedBefore)
+	assert.Equal(t, int64(2), coalescedAfter-coalescedBefore)
+	assert.Empty(t, c.keys, "finished keys are dropped")
+}
+
+func TestCoalescer_OneEvaluationAtATime(t *testing.T) {
+	c := New(0)
+	_, _, serializedBefore := Stats()
+
+	release := make(chan struct{})
+	var running, maxRunning atomic.Int32
+	fn := func() {
+		if n := running.Add(1); n > maxRunning.Load() {
+			maxRunning.Store(n)
+		}
+		<-release
+		running.Add(-1)
+	}
+
+	results := make([]bool, 3)
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = c.Run(context.Background(), Key(4, 5), fn)
+		}(i)
+		waitForCalls(t, c, Key(4, 5), i+1)
+		if i == 0 {
+			assert.Eventually(t, func() bool { return running.Load() == 1 }, time.Second, time.Millisecond)
+		}
+	}
+	close(release)
+	wg.Wait()
+
+	// The running evaluation completes, the queued event is superseded by the newest one
+	assert.Equal(t, []bool{true, false, true}, results)
+	assert.Equal(t, int32(1), maxRunning.Load())
+	_, _, serializedAfter := Stats()
+	assert.Equal(t, int64(2), serializedAfter-serializedBefore)
+}
+
+func TestCoalescer_ContextCanceled(t *testing.T) {
+	c := New(time.Minute)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.False(t, c.Run(ctx, Key(1, 1), func() { t.Error("canceled call ran") }))
+	assert.Empty(t, c.keys)
+}
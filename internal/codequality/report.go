@@ -0,0 +1,106 @@
+// Package codequality converts rule evaluations into GitLab Code Quality reports, so rule
+// findings of a CI job are shown in the MR diff.
+package codequality
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+)
+
+// Severities of the GitLab Code Quality format used for rule findings
+const (
+	SeverityMajor = "major" // Manual review required
+	SeverityMinor = "minor" // Warning that does not block approval
+)
+
+// UncoveredCheckName is the check of changed lines no rule covers
+const UncoveredCheckName = "uncovered_lines"
+
+// Issue is one finding of a GitLab Code Quality report
+type Issue struct {
+	Description string   `json:"description"`
+	CheckName   string   `json:"check_name"`
+	Fingerprint string   `json:"fingerprint"`
+	Severity    string   `json:"severity"`
+	Location    Location `json:"location"`
+}
+
+// Location is the file and first line of a finding
+type Location struct {
+	Path  string `json:"path"`
+	Lines Lines  `json:"lines"`
+}
+
+// Lines holds the first line of a finding
+type Lines struct {
+	Begin int `json:"begin"`
+}
+
+// FromEvaluation returns an issue for each rule result needing manual review or carrying a
+// warning, and for each changed line range of a manual review file that no rule covers,
+// ordered by file. Approved results produce no issues, so an approved evaluation yields an
+// empty report.
+func FromEvaluation(result *shared.RuleEvaluation) []Issue {
+	paths := make([]string, 0, len(result.FileValidations))
+	for path := range result.FileValidations {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	issues := []Issue{}
+	for _, path := range paths {
+		validation := result.FileValidations[path]
+		for _, ruleResult := range validation.RuleResults {
+			var severity string
+			switch ruleResult.Decision {
+			case shared.ManualReview:
+				severity = SeverityMajor
+			case shared.Warn:
+				severity = SeverityMinor
+			default:
+				continue
+			}
+			issues = append(issues, newIssue(path, ruleResult.RuleName, ruleResult.Reason, severity, firstLine(ruleResult.LineRanges)))
+		}
+
+		if validation.FileDecision != shared.ManualReview {
+			continue
+		}
+		for _, uncovered := range validation.UncoveredLines {
+			description := fmt.Sprintf("Changed lines %d-%d are not covered by any rule and require manual review", uncovered.StartLine, uncovered.EndLine)
+			issues = append(issues, newIssue(path, UncoveredCheckName, description, SeverityMajor, firstLine([]shared.LineRange{uncovered})))
+		}
+	}
+	return issues
+}
+
+// newIssue builds an issue whose fingerprint stays the same while the finding does, so
+// GitLab can tell new findings from resolved ones between pipelines
+func newIssue(path, checkName, description, severity string, line int) Issue {
+	sum := sha256.Sum256([]byte(path + "\x00" + checkName + "\x00" + description))
+	return Issue{
+		Description: description,
+		CheckName:   checkName,
+		Fingerprint: hex.EncodeToString(sum[:16]),
+		Severity:    severity,
+		Location:    Location{Path: path, Lines: Lines{Begin: line}},
+	}
+}
+
+// firstLine returns the first line of the ranges, 1 when there are none
+func firstLine(ranges []shared.LineRange) int {
+	line := 0
+	for _, lineRange := range ranges {
+		if lineRange.StartLine > 0 && (line == 0 || lineRange.StartLine < line) {
+			line = lineRange.StartLine
+		}
+	}
+	if line == 0 {
+		return 1
+	}
+	return line
+}
@@ -0,0 +1,63 @@
+package codequality
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+)
+
+func TestFromEvaluation(t *testing.T) {
+	result := &shared.RuleEvaluation{FileValidations: map[string]*shared.FileValidationSummary{
+		"dataproducts/source/orders/prod/product.yaml": {
+			FileDecision: shared.ManualReview,
+			RuleResults: []shared.LineValidationResult{
+				{RuleName: "metadata_rule", Decision: shared.Approve, Reason: "metadata valid", LineRanges: []shared.LineRange{{StartLine: 1, EndLine: 3}}},
+				{RuleName: "warehouse_rule", Decision: shared.ManualReview, Reason: "Warehouse size increase detected", LineRanges: []shared.LineRange{{StartLine: 14, EndLine: 16}, {StartLine: 9, EndLine: 9}}},
+			},
+			UncoveredLines: []shared.LineRange{{StartLine: 30, EndLine: 31}},
+		},
+		"dataproducts/source/orders/dev/product.yaml": {
+			FileDecision: shared.Approve,
+			RuleResults: []shared.LineValidationResult{
+				{RuleName: "naming_rule", Decision: shared.Warn, Reason: "naming style deviation"},
+			},
+		},
+	}}
+
+	issues := FromEvaluation(result)
+	if assert.Len(t, issues, 3) {
+		assert.Equal(t, Issue{
+			Description: "naming style deviation",
+			CheckName:   "naming_rule",
+			Fingerprint: issues[0].Fingerprint,
+			Severity:    SeverityMinor,
+			Location:    Location{Path: "dataproducts/source/orders/dev/product.yaml", Lines: Lines{Begin: 1}},
+		}, issues[0])
+		assert.Equal(t, "warehouse_rule", issues[1].CheckName)
+		assert.Equal(t, SeverityMajor, issues[1].Severity)
+		assert.Equal(t, 9, issues[1].Location.Lines.Begin)
+		assert.Equal(t, UncoveredCheckName, issues[2].CheckName)
+		assert.Equal(t, "Changed lines 30-31 are not covered by any rule and require manual review", issues[2].Description)
+		assert.Equal(t, 30, issues[2].Location.Lines.Begin)
+	}
+	assert.Len(t, issues[0].Fingerprint, 32)
+	assert.Equal(t, issues[1].Fingerprint, FromEvaluation(result)[1].Fingerprint, "fingerprints are stable")
+	assert.NotEqual(t, issues[1].Fingerprint, issues[2].Fingerprint)
+
+	data, err := json.Marshal(issues[1])
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"description":"Warehouse size increase detected","check_name":"warehouse_rule","fingerprint":"`+issues[1].Fingerprint+`",
+		"severity":"major","location":{"path":"dataproducts/source/orders/prod/product.yaml","lines":{"begin":9}}}`, string(data))
+}
+
+func TestFromEvaluation_Approved(t *testing.T) {
+	issues := FromEvaluation(&shared.RuleEvaluation{FileValidations: map[string]*shared.FileValidationSummary{
+		"README.md": {FileDecision: shared.Approve, RuleResults: []shared.LineValidationResult{{RuleName: "metadata_rule", Decision: shared.Approve}}},
+	}})
+	data, err := json.Marshal(issues)
+	assert.NoError(t, err)
+	assert.Equal(t, "[]", string(data), "GitLab expects an empty array without findings")
+}
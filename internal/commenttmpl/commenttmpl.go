@@ -0,0 +1,191 @@
+package commenttmpl
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"go.uber.org/zap"
+)
+
+// Comment templates; each is loaded from <name>.md.tmpl in the templates directory or a
+// <locale> subdirectory and replaces the built-in comment when present
+const (
+	Approval     = "approval"      // Auto-approval comment
+	ManualReview = "manual_review" // Manual review comment
+	Rebase       = "rebase"        // Comment after an automated rebase
+	StaleClosure = "stale_closure" // Comment closing a stale MR
+)
+
+// Names lists the comment templates that can be customized
+var Names = []string{Approval, ManualReview, Rebase, StaleClosure}
+
+// fileSuffix is appended to a template name to get its file name
+const fileSuffix = ".md.tmpl"
+
+var funcs = template.FuncMap{
+	"join":  strings.Join,
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+	"trim":  strings.TrimSpace,
+}
+
+//go:embed catalogs
+var catalogFS embed.FS
+
+// catalogs holds the built-in translations of the comments, by locale
+var catalogs = mustLoadCatalogs()
+
+func mustLoadCatalogs() map[string]map[string]*template.Template {
+	root, err := fs.Sub(catalogFS, "catalogs")
+	if err != nil {
+		panic(err)
+	}
+	templates, err := load(root)
+	if err != nil {
+		panic(err)
+	}
+	return templates
+}
+
+// Set holds the customized comment templates
+type Set struct {
+	templates map[string]map[string]*template.Template // Locale ("" for every locale) -> name -> template
+}
+
+// Load parses the templates of dir, which apply to every locale, and of its <locale>
+// subdirectories; templates without a file keep the built-in comment
+func Load(dir string) (*Set, error) {
+	templates, err := load(os.DirFS(dir))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", dir, err)
+	}
+	return &Set{templates: templates}, nil
+}
+
+// load parses the templates at the root of fsys and in its locale subdirectories
+func load(fsys fs.FS) (map[string]map[string]*template.Template, error) {
+	templates := make(map[string]map[string]*template.Template)
+	dirs := []string{"."}
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read comment templates: %w", err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			dirs = append(dirs, entry.Name())
+		}
+	}
+
+	for _, dir := range dirs {
+		locale := strings.ToLower(dir)
+		if dir == "." {
+			locale = ""
+		}
+		for _, name := range Names {
+			path := filepath.ToSlash(filepath.Join(dir, name+fileSuffix))
+			data, err := fs.ReadFile(fsys, path)
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to read comment template %s: %w", path, err)
+			}
+			tmpl, err := template.New(name).Funcs(funcs).Parse(string(data))
+			if err != nil {
+				return nil, fmt.Errorf("invalid comment template %s: %w", path, err)
+			}
+			if templates[locale] == nil {
+				templates[locale] = make(map[string]*template.Template)
+			}
+			templates[locale][name] = tmpl
+		}
+	}
+	return templates, nil
+}
+
+// Len returns the number of customized templates
+func (s *Set) Len() int {
+	if s == nil {
+		return 0
+	}
+	count := 0
+	for _, templates := range s.templates {
+		count += len(templates)
+	}
+	return count
+}
+
+// Locales lists the languages with a built-in catalog
+func Locales() []string {
+	locales := make([]string, 0, len(catalogs))
+	for locale := range catalogs {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// Render executes the named template for the locale (e.g. de or pt-br, falling back to
+// pt). Customized templates of the locale come first, then customized templates for every
+// locale, then the built-in catalog. ok is false when none exists or rendering fails, so
+// the caller falls back to the built-in English comment.
+func (s *Set) Render(locale, name string, data interface{}) (comment string, ok bool) {
+	tmpl := s.lookup(locale, name)
+	if tmpl == nil {
+		return "", false
+	}
+	var out strings.Builder
+	if err := tmpl.Execute(&out, data); err != nil {
+		logging.Warn("Failed to render comment template", zap.String("name", name), zap.String("locale", locale), zap.Error(err))
+		return "", false
+	}
+	return out.String(), true
+}
+
+func (s *Set) lookup(locale, name string) *template.Template {
+	locales := []string{locale}
+	if language, _, found := strings.Cut(locale, "-"); found {
+		locales = append(locales, language)
+	}
+	if s != nil {
+		for _, candidate := range append(locales, "") {
+			if tmpl := s.templates[candidate][name]; tmpl != nil {
+				return tmpl
+			}
+		}
+	}
+	for _, candidate := range locales {
+		if tmpl := catalogs[candidate][name]; tmpl != nil {
+			return tmpl
+		}
+	}
+	return nil
+}
+
+var (
+	defaultMu  sync.RWMutex
+	defaultSet *Set
+)
+
+// SetDefault installs the process-wide comment templates
+func SetDefault(set *Set) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultSet = set
+}
+
+// Default returns the process-wide comment templates, or nil when none are configured
+func Default() *Set {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultSet
+}
@@ -0,0 +1,86 @@
+package commenttmpl
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "approval.md.tmpl"), []byte("Approved !{{.MRIID}} by {{upper .Bot}}"), 0o600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "unknown.md.tmpl"), []byte("ignored"), 0o600))
+
+	set, err := Load(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, set.Len())
+
+	comment, ok := set.Render("en", Approval, map[string]interface{}{"MRIID": 4, "Bot": "naysayer"})
+	assert.True(t, ok)
+	assert.Equal(t, "Approved !4 by NAYSAYER", comment)
+
+	_, ok = set.Render("en", ManualReview, nil)
+	assert.False(t, ok, "templates without a file keep the built-in comment")
+
+	_, ok = set.Render("en", Approval, struct{}{})
+	assert.False(t, ok, "failing templates fall back to the built-in comment")
+}
+
+func TestLoad_InvalidTemplate(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "rebase.md.tmpl"), []byte("{{.Broken"), 0o600))
+
+	_, err := Load(dir)
+	assert.ErrorContains(t, err, "invalid comment template")
+
+	_, err = Load(filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+}
+
+func TestRender_Locales(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, "NL"), 0o700))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "NL", "rebase.md.tmpl"), []byte("Gerebased op {{.TargetBranch}}"), 0o600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "stale_closure.md.tmpl"), []byte("Closed"), 0o600))
+	set, err := Load(dir)
+	assert.NoError(t, err)
+	data := map[string]interface{}{"TargetBranch": "main", "DaysInactive": 40}
+
+	comment, _ := set.Render("nl", Rebase, data)
+	assert.Equal(t, "Gerebased op main", comment, "customized templates of the locale")
+	comment, _ = set.Render("nl-be", Rebase, data)
+	assert.Equal(t, "Gerebased op main", comment, "regional locales fall back to the language")
+
+	comment, _ = set.Render("de", Rebase, data)
+	assert.Contains(t, comment, "Automatischer Rebase", "built-in catalog")
+	comment, _ = set.Render("de", StaleClosure, data)
+	assert.Equal(t, "Closed", comment, "customized templates for every locale come before the catalog")
+
+	var none *Set
+	comment, ok := none.Render("fr-ca", StaleClosure, data)
+	assert.True(t, ok, "catalogs work without customized templates")
+	assert.Contains(t, comment, "40 jours sans mise à jour")
+	_, ok = none.Render("en", Rebase, data)
+	assert.False(t, ok, "English uses the built-in comments")
+}
+
+func TestCatalogs(t *testing.T) {
+	assert.Equal(t, []string{"de", "es", "fr"}, Locales())
+	for _, locale := range Locales() {
+		for _, name := range Names {
+			assert.NotNil(t, catalogs[locale][name], "%s/%s", locale, name)
+		}
+	}
+}
+
+func TestDefault(t *testing.T) {
+	var empty *Set
+	assert.Equal(t, 0, empty.Len())
+
+	set := &Set{}
+	SetDefault(set)
+	defer SetDefault(nil)
+	assert.Same(t, set, Default())
+}
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"net/url"
 	"os"
 	"strconv"
 	"strings"
@@ -8,42 +9,271 @@ import (
 
 // Config holds application configuration
 type Config struct {
-	GitLab     GitLabConfig
-	Server     ServerConfig
-	Webhook    WebhookConfig
-	Comments   CommentsConfig
-	Rules      RulesConfig
-	Approval   ApprovalConfig
-	AutoRebase AutoRebaseConfig
-	StaleMR    StaleMRConfig
+	GitLab       GitLabConfig
+	GitHub       GitHubConfig
+	Server       ServerConfig
+	Webhook      WebhookConfig
+	Comments     CommentsConfig
+	Rules        RulesConfig
+	Approval     ApprovalConfig
+	AutoRebase   AutoRebaseConfig
+	StaleMR      StaleMRConfig
+	RepoIndex    RepoIndexConfig
+	Notify       NotifyConfig
+	Flapping     FlappingConfig
+	Snapshot     SnapshotConfig
+	Revert       RevertConfig
+	MergePolicy  MergePolicyConfig
+	Replay       ReplayConfig
+	Idempotency  IdempotencyConfig
+	SLO          SLOConfig
+	Governance   GovernanceConfig
+	Override     OverrideConfig
+	Onboarding   OnboardingConfig
+	Owners       OwnersConfig
+	Jobs         JobsConfig
+	Coalesce     CoalesceConfig
+	Archive      ArchiveConfig
+	SelfTest     SelfTestConfig
+	Audit        AuditConfig
+	History      HistoryConfig
+	ChatOps      ChatOpsConfig
+	CommitStatus CommitStatusConfig
+	AutoMerge    AutoMergeConfig
+	SystemHook   SystemHookConfig
+	Projects     ProjectFilterConfig
+	Dashboard    DashboardConfig
+	SMTP         SMTPConfig
+	Digest       DigestConfig
+	Tracing      TracingConfig
+
+	Deprecations []Deprecation // Legacy configuration keys found in the environment
 }
 
 // GitLabConfig holds GitLab API configuration
 type GitLabConfig struct {
-	BaseURL                       string
+	BaseURL                       string // Instance URL, optionally with a sub-path, e.g. https://example.com/gitlab
+	APIVersion                    string // REST API version appended as /api/<version> (default: v4)
 	Token                         string
-	GitlabFivetranRepositoryToken string // Optional: separate token for fivetran_terraform rebase
-	GitlabStaleMRToken            string // Optional: dedicated token for stale MR cleanup
-	InsecureTLS                   bool   // Skip TLS certificate verification
-	CACertPath                    string // Path to custom CA certificate file
+	TokenFile                     string            // Optional: file holding a short-lived token, re-read when GitLab answers 401
+	TokenSource                   string            // Optional: secret manager reference of the token, e.g. vault:secret/data/naysayer#gitlab_token
+	TokenReloadSeconds            int               // How often TokenFile and TokenSource are re-read for a rotated token, 0 only on 401 (default: 60)
+	TokenExpiryWarningDays        int               // Tokens expiring within this many days are reported by /readyz (default: 7)
+	Vault                         VaultConfig       // Vault server TokenSource references are read from
+	OAuth                         GitLabOAuthConfig // OAuth application the token is obtained from instead of a personal access token
+	GitlabFivetranRepositoryToken string            // Deprecated: legacy GITLAB_TOKEN_FIVETRAN, use AutoRebaseConfig.RepositoryToken
+	GitlabStaleMRToken            string            // Optional: dedicated token for stale MR cleanup
+	ReviewToken                   string            // Optional: dedicated token for MR review comments and approvals
+	BotUsernames                  []string          // Usernames of the naysayer bot identities, recognised as bot authors
+	BotIdentities                 []BotIdentity     // Bot users of project and group access tokens, per project
+	InsecureTLS                   bool              // Skip TLS certificate verification
+	CACertPath                    string            // Path to custom CA certificate file
+	MaxRetries                    int               // Retries of rate-limited (429) and transient (5xx, network) failures (default: 3)
+	RetryBackoffMs                int               // Initial retry delay, doubled per attempt (default: 500)
+	RateLimit                     float64           // Client-side requests per second, 0 disables the limiter (default: 10)
+	DetailConcurrency             int               // Concurrent MR detail requests when listing open MRs (default: 8)
+	MaxMRFiles                    int               // Changed files above which an MR is too large to analyze, 0 disables (default: 2000)
+	MaxMRDiffBytes                int               // Total diff size in bytes above which an MR is too large to analyze, 0 disables (default: 50 MiB)
+	FileCacheSize                 int               // Files kept in the in-process file content cache, 0 disables (default: 1000)
+	FileCacheTTLSeconds           int               // Lifetime of cached file contents (default: 300)
+	ETagCacheSize                 int               // GET responses kept per client for If-None-Match revalidation, 0 disables (default: 500)
+	ConnectTimeoutSeconds         int               // TCP connect and TLS handshake timeout, 0 disables (default: 10)
+	RequestTimeoutSeconds         int               // Timeout of a whole request including reading the body, 0 disables (default: 60)
+	CircuitBreakerThreshold       int               // Consecutive failed requests that open the circuit breaker, 0 disables (default: 5)
+	CircuitBreakerCooldownSeconds int               // How long an open circuit fails fast before a trial request (default: 30)
+	RebaseVerifyTimeoutSeconds    int               // How long a rebase is polled for its result (default: 60)
+	RebaseVerifyIntervalSeconds   int               // Delay between polls of a rebase (default: 2)
+	Instance                      string            // Name of the additional instance this config talks to, empty for the default instance
+	Instances                     []GitLabInstance  // Additional GitLab instances whose webhooks are served by this deployment
+}
+
+// VaultConfig holds the Vault server GitLab tokens can be read from
+type VaultConfig struct {
+	Addr      string // Vault server URL, e.g. https://vault.example.com:8200
+	Token     string // Vault token
+	TokenFile string // File holding the Vault token, e.g. a Vault agent sink; read on every fetch
+	Namespace string // Vault Enterprise namespace, empty for the root namespace
+}
+
+// GitLabOAuthConfig holds the GitLab OAuth application naysayer obtains short-lived access
+// tokens from, refreshed before they expire
+type GitLabOAuthConfig struct {
+	ClientID         string   // Application ID
+	ClientSecret     string   // Application secret
+	GrantType        string   // refresh_token or client_credentials (default: refresh_token when a refresh token is set)
+	RefreshToken     string   // Refresh token of the authorization the application was granted
+	RefreshTokenFile string   // File the refresh token is read from and each rotated refresh token is written to
+	Scopes           []string // Scopes requested by the client_credentials grant (default: api)
+	TokenURL         string   // Token endpoint (default: <GITLAB_BASE_URL>/oauth/token)
+}
+
+// Configured reports whether tokens are obtained from an OAuth application
+func (o GitLabOAuthConfig) Configured() bool {
+	return o.ClientID != ""
+}
+
+// GitLabInstance is an additional GitLab instance, e.g. a self-managed instance next to
+// gitlab.com. Settings not listed here are shared with the default instance.
+type GitLabInstance struct {
+	Name          string
+	BaseURL       string
+	Token         string
+	TokenFile     string
+	TokenSource   string
+	InsecureTLS   bool
+	CACertPath    string
+	WebhookSecret string // Secret deliveries routed to the instance must carry in X-Gitlab-Token
+}
+
+// BotIdentity is the GitLab user a naysayer token acts as
+type BotIdentity struct {
+	ProjectID int    // Project the bot acts in, 0 for every project
+	Username  string // Bot username, e.g. "project_123_bot_4f2a" for a project access token
+	UserID    int    // Bot user ID, 0 when only the username is known
+}
+
+// HasBotIdentities reports whether the naysayer bot users are configured explicitly
+func (g GitLabConfig) HasBotIdentities() bool {
+	return len(g.BotUsernames) > 0 || len(g.BotIdentities) > 0
+}
+
+// GitHubConfig holds GitHub (Enterprise) API configuration for reviewing pull requests
+type GitHubConfig struct {
+	BaseURL       string // REST API root, e.g. https://github.example.com/api/v3 (default: https://api.github.com)
+	Token         string // Token of the naysayer GitHub user or app installation; GitHub endpoints are disabled without it
+	WebhookSecret string // Optional: verify X-Hub-Signature-256 of GitHub deliveries
+}
+
+// Enabled reports whether the GitHub webhook endpoints are served
+func (g GitHubConfig) Enabled() bool {
+	return g.Token != ""
 }
 
 // ServerConfig holds server configuration
 type ServerConfig struct {
-	Port string
+	Port           string
+	UnixSocket     string   // Optional: listen on this unix socket instead of the TCP port
+	UnixSocketMode string   // Octal permissions of the unix socket (default: 0660)
+	TLSCertFile    string   // Optional: PEM certificate for serving HTTPS
+	TLSKeyFile     string   // Optional: PEM private key for TLSCertFile
+	ACMEDomains    []string // Optional: obtain certificates for these domains via ACME (TLS-ALPN-01)
+	ACMEEmail      string   // Optional: contact address for the ACME account
+	ACMECacheDir   string   // Directory caching ACME certificates (default: acme-cache)
+	ACMEDirectory  string   // Optional: ACME directory URL (default: Let's Encrypt)
+	HSTSMaxAge     int      // Strict-Transport-Security max-age in seconds on HTTPS admin endpoints (default: 1 year, 0 disables)
+	AdminPort      string   // Optional: serve health and /api/v1 endpoints on this port instead of the webhook listener
+	AdminHost      string   // Optional: interface the admin port binds to (default: all interfaces)
+}
+
+// HasAdminListener returns true if admin endpoints are served on a separate listener
+func (s ServerConfig) HasAdminListener() bool {
+	return s.AdminPort != ""
+}
+
+// TLSEnabled returns true if the server terminates TLS itself
+func (s ServerConfig) TLSEnabled() bool {
+	return s.TLSCertFile != "" || s.TLSKeyFile != "" || len(s.ACMEDomains) > 0
 }
 
 // WebhookConfig holds webhook security configuration
 type WebhookConfig struct {
-	Secret     string   // GitLab webhook secret token
-	AllowedIPs []string // Optional: restrict webhook calls to specific IPs
+	Secret          string            // GitLab webhook secret token, verified against X-Gitlab-Token
+	EndpointSecrets map[string]string // Optional: per-endpoint secrets overriding Secret
+	AllowedIPs      []string          // Optional: restrict webhook calls to specific IPs
+	AllowedProjects []int             // Optional: reject webhooks for other projects before parsing
+	MaxBodyBytes    int               // Reject larger webhook bodies before parsing (0 disables the limit)
+}
+
+// ProjectFilterConfig selects the projects naysayer handles, e.g. from a group webhook
+// delivering events for every project of a group. Entries are project IDs or regular
+// expressions matched against the project path (path_with_namespace).
+type ProjectFilterConfig struct {
+	Allow      []string // Projects handled; empty allows every project not denied
+	Deny       []string // Projects never handled, even when allowed
+	Subsystems []string // <project>=<subsystem>[,<subsystem>] entries; the first matching entry lists the subsystems enabled for a project
+}
+
+// Configured returns true if any project filter is set
+func (p ProjectFilterConfig) Configured() bool {
+	return len(p.Allow) > 0 || len(p.Deny) > 0 || len(p.Subsystems) > 0
+}
+
+// Webhook endpoints with their own secret
+const (
+	EndpointReview         = "review"           // /dataverse-product-config-review
+	EndpointAutoRebase     = "auto-rebase"      // /auto-rebase
+	EndpointStaleMRCleanup = "stale-mr-cleanup" // /stale-mr-cleanup
+	EndpointSystemHook     = "system-hook"      // /system-hook
+)
+
+// SecretFor returns the secret deliveries to an endpoint must carry (empty when not verified)
+func (w WebhookConfig) SecretFor(endpoint string) string {
+	if secret, ok := w.EndpointSecrets[endpoint]; ok {
+		return secret
+	}
+	return w.Secret
+}
+
+// Verified returns true if deliveries to any endpoint must carry a secret
+func (w WebhookConfig) Verified() bool {
+	if w.Secret != "" {
+		return true
+	}
+	for _, secret := range w.EndpointSecrets {
+		if secret != "" {
+			return true
+		}
+	}
+	return false
 }
 
 // CommentsConfig holds MR comments and messages configuration
 type CommentsConfig struct {
-	EnableMRComments       bool   // Enable/disable MR commenting
-	CommentVerbosity       string // Comment verbosity level (basic, detailed, debug)
-	UpdateExistingComments bool   // Update existing comments instead of creating new ones
+	EnableMRComments       bool           // Enable/disable MR commenting
+	CommentVerbosity       string         // Comment verbosity level (basic, detailed, debug)
+	UpdateExistingComments bool           // Update existing comments instead of creating new ones
+	CoverageReport         bool           // Append a collapsed per-file line coverage breakdown to decision comments
+	InlineDiscussions      bool           // Open a discussion on the diff line of every failed rule, resolved once fixed
+	TemplatesDir           string         // Optional: directory of Go templates replacing built-in comments
+	Locale                 string         // Language of bot comments, e.g. de or pt-BR (default: en)
+	ProjectLocales         map[int]string // Per-project comment language overrides
+
+	UpdateStrategy          string         // How existing comments are updated: edit, reply or on-decision-change
+	ProjectUpdateStrategies map[int]string // Per-project update strategy overrides
+}
+
+// Comment update strategies
+const (
+	CommentStrategyEdit             = "edit"               // Edit the existing comment in place
+	CommentStrategyReply            = "reply"              // Reply in the existing comment's thread
+	CommentStrategyOnDecisionChange = "on-decision-change" // Replace the comment only when the decision changes
+)
+
+// UpdateStrategyFor returns the comment update strategy for a project. Unknown strategies
+// fall back to editing in place.
+func (c CommentsConfig) UpdateStrategyFor(projectID int) string {
+	strategy := c.UpdateStrategy
+	if override, ok := c.ProjectUpdateStrategies[projectID]; ok {
+		strategy = override
+	}
+	switch strategy {
+	case CommentStrategyReply, CommentStrategyOnDecisionChange:
+		return strategy
+	default:
+		return CommentStrategyEdit
+	}
+}
+
+// LocaleFor returns the comment language of a project, lowercased
+func (c CommentsConfig) LocaleFor(projectID int) string {
+	locale := c.Locale
+	if override, ok := c.ProjectLocales[projectID]; ok {
+		locale = override
+	}
+	if locale == "" {
+		return "en"
+	}
+	return strings.ToLower(locale)
 }
 
 // RulesConfig holds rule-specific configuration
@@ -51,9 +281,11 @@ type RulesConfig struct {
 	EnabledRules            []string                      // List of enabled rule names
 	DisabledRules           []string                      // List of disabled rule names
 	DataProductConsumerRule DataProductConsumerRuleConfig // Consumer access rule configuration
+	GroupMembershipRule     GroupMembershipRuleConfig     // Group membership rule configuration
 	MigrationsRule          MigrationsRuleConfig          // Migrations validation configuration
 	NamingRule              NamingRuleConfig              // Naming conventions configuration
 	ServiceAccountRule      ServiceAccountRuleConfig      // Service account rule configuration
+	SourceBindingRule       SourceBindingRuleConfig       // Source binding rule configuration
 	TOCApprovalRule         TOCApprovalRuleConfig         // TOC approval rule configuration
 	WarehouseRule           WarehouseRuleConfig           // Warehouse rule configuration
 }
@@ -63,6 +295,18 @@ type WarehouseRuleConfig struct {
 	AllowTOCBypass       bool     // Allow bypassing TOC approval for specific cases
 	PlatformEnvironments []string // Environments requiring platform approval
 	AutoApproveEnvs      []string // Environments allowing auto-approval
+
+	CreditsPerHour      map[string]float64 // Credits per running hour by size, overriding Snowflake's standard table
+	HoursPerMonth       float64            // Assumed running hours per month for cost estimates
+	CreditPrice         float64            // Price of one credit
+	CostCurrency        string             // Currency of CreditPrice in cost estimates
+	CostReviewThreshold float64            // Estimated monthly cost increase forcing manual review (0 disables)
+
+	MaxSizes     map[string]string // Largest allowed warehouse size by lowercased environment
+	MaxSizeSteps int               // Size steps a single change may jump before it is a policy violation (0 disables)
+
+	ResizePolicy   string            // Resize policy of files no entry of ResizePolicies matches
+	ResizePolicies map[string]string // Resize policy by lowercased environment or "tier:<tier>" of the data product
 }
 
 // ServiceAccountRuleConfig holds service account validation configuration
@@ -72,6 +316,12 @@ type ServiceAccountRuleConfig struct {
 	AllowedDomains           []string // Allowed email domains
 	AstroEnvironmentsOnly    []string // Environments where Astro service accounts are allowed
 	EnforceNamingConventions bool     // Enforce naming conventions
+	ElevatedRoles            []string // Roles whose grant to a serviceaccounts/ definition requires manual review
+}
+
+// SourceBindingRuleConfig holds source binding validation configuration
+type SourceBindingRuleConfig struct {
+	AllowedPrivileges []string // Privileges a sourcebinding.yaml may request on its sources
 }
 
 // TOCApprovalRuleConfig holds TOC approval rule configuration
@@ -84,6 +334,11 @@ type DataProductConsumerRuleConfig struct {
 	AllowedEnvironments []string // Environments where consumer access is allowed (preprod, prod)
 }
 
+// GroupMembershipRuleConfig holds group membership rule configuration
+type GroupMembershipRuleConfig struct {
+	ElevatedRoles []string // Roles whose addition requires manual review (approver, member)
+}
+
 // MigrationsRuleConfig holds migrations validation configuration
 type MigrationsRuleConfig struct {
 	RequirePlatformApproval bool     // Always require platform approval
@@ -103,42 +358,383 @@ type ApprovalConfig struct {
 	EnablePlatformWorkflow bool   // Enable platform approval workflow
 	TOCGroupID             string // GitLab group ID for TOC team
 	PlatformGroupID        string // GitLab group ID for platform team
+	ReviewDrafts           bool   // Evaluate and comment on draft MRs without approving them (default: skip drafts)
 }
 
 // AutoRebaseConfig holds auto-rebase configuration
 type AutoRebaseConfig struct {
-	Enabled               bool   // Enable/disable auto-rebase feature
-	CheckAtlantisComments bool   // Check atlantis comments for plan failures (default: false)
-	RepositoryToken       string // Optional: repository-specific token (for backward compat with Fivetran)
+	Enabled                bool     // Enable/disable auto-rebase feature
+	CheckAtlantisComments  bool     // Check atlantis comments for plan failures (default: false)
+	RepositoryToken        string   // Optional: repository-specific token (for backward compat with Fivetran)
+	CatchUpProjects        []string // Project branches to check for missed pushes ("<project_id>[:<branch>]")
+	CatchUpIntervalMinutes int      // Minutes between missed-push checks (0 disables the periodic check)
+	SkipLabels             []string // MR labels that skip rebasing ("<label>[=<reason>]")
+	EligibilityHooks       []string // HTTP eligibility checks ("<name>=<url>")
+	EligibilityPlugins     []string // Go plugin (.so) files exporting CheckEligibility
+	EligibilityHookTimeout int      // Seconds an HTTP eligibility check may take (default: 5)
+	Schedules              []string // Scheduled rebase passes ("<project_id>[:<branch>]=<cron>")
+	SkipDrafts             bool     // Do not rebase draft MRs
+	OptOutLabel            string   // MR label (or "[<label>]" description marker) excluding an MR from rebasing
+	MaxRebasesPerPush      int      // Rebases triggered per pass; further MRs wait for the next pass (0: unlimited)
+	Order                  string   // Order MRs are rebased in: oldest_first, closest_to_merge or label_priority
+	PriorityLabels         []string // Labels rebased first with label_priority, highest priority first
+	CooldownMinutes        int      // Minutes before a rebased MR is rebased again (0 disables)
+}
+
+// Auto-rebase orders
+const (
+	RebaseOrderOldestFirst    = "oldest_first"     // Oldest MRs first
+	RebaseOrderClosestToMerge = "closest_to_merge" // MRs set to merge, with green pipelines and mergeable first
+	RebaseOrderLabelPriority  = "label_priority"   // MRs carrying the earliest priority label first
+)
+
+// RebaseOrder returns the configured auto-rebase order. Unknown orders fall back to oldest_first.
+func (c AutoRebaseConfig) RebaseOrder() string {
+	switch c.Order {
+	case RebaseOrderClosestToMerge, RebaseOrderLabelPriority:
+		return c.Order
+	default:
+		return RebaseOrderOldestFirst
+	}
 }
 
 // StaleMRConfig holds stale MR cleanup configuration
 type StaleMRConfig struct {
-	ClosureDays int // Days before closure (default: 30)
+	ClosureDays       int      // Days before closure (default: 30)
+	BranchDays        int      // Days since the last commit before a branch without open MR is stale (default: 90)
+	BranchDelete      bool     // Delete stale branches instead of only reporting them
+	ProtectedBranches []string // Branch name patterns never reported or deleted (path.Match syntax)
+	Schedules         []string // Scheduled stale MR cleanups ("<project_id>=<cron>")
+	ExemptLabels      []string // MR labels that keep a stale MR open (case-insensitive)
+	DraftClosureDays  int      // Days before closure of draft MRs (0 uses ClosureDays)
+}
+
+// RepoIndexConfig holds repository tree snapshot configuration
+type RepoIndexConfig struct {
+	Enabled                bool // Answer path-existence checks from local tree snapshots
+	RefreshIntervalMinutes int  // Minutes between full background refreshes (default: 60)
+}
+
+// NotifyConfig holds operator notification configuration
+type NotifyConfig struct {
+	WebhookURL string                   // Optional: POST notifications as JSON to this URL (default: log only)
+	Channels   map[string]NotifyChannel // Named Slack, Google Chat or webhook destinations
+	Routes     map[string][]string      // Event type ("*" for any other event) -> channel names
+	Templates  map[string]string        // Event type -> Go template of the message text
+}
+
+// Notification channel kinds
+const (
+	NotifyChannelSlack      = "slack"
+	NotifyChannelGoogleChat = "googlechat"
+	NotifyChannelWebhook    = "webhook"
+)
+
+// NotifyChannel is a destination notifications can be routed to
+type NotifyChannel struct {
+	Kind string // slack, googlechat or webhook
+	URL  string
+}
+
+// Routed reports whether notifications of the event type are routed to a channel
+func (c NotifyConfig) Routed(event string) bool {
+	return len(c.Routes[event]) > 0 || len(c.Routes["*"]) > 0
+}
+
+// FlappingConfig holds decision flapping detection configuration
+type FlappingConfig struct {
+	Threshold          int  // Decision flips within the window that count as flapping (0 disables detection)
+	WindowMinutes      int  // Sliding window for counting flips (default: 60)
+	FreezeAutoApproval bool // Stop auto-approving a flapping MR until a human reviews it
+}
+
+// SnapshotConfig holds MR evaluation snapshot configuration
+type SnapshotConfig struct {
+	Enabled       bool   // Persist the inputs of every evaluation for later replay
+	Dir           string // Optional: keep snapshots as files below this directory instead of the state store
+	EncryptionKey string // Optional: base64 AES-256 key encrypting snapshots at rest
+	RetentionDays int    // Days snapshots are kept (default: 90)
+	MaxPerMR      int    // Snapshots kept per MR, oldest dropped first (default: 20)
+}
+
+// RevertConfig holds revert MR handling configuration
+type RevertConfig struct {
+	FastPathEnabled bool // Auto-approve MRs that exactly revert a merged MR without rule evaluation
+}
+
+// MergePolicyConfig holds MR merge settings policy configuration
+type MergePolicyConfig struct {
+	Enabled                   bool // Check squash, delete-source-branch and merge method settings of every MR
+	RequireSquash             bool // Commits must be squashed on merge (default: true)
+	RequireDeleteSourceBranch bool // Source branch must be deleted on merge (default: true)
+	ForbidMergeCommits        bool // Project must use fast-forward merges (default: true)
+	BlockApproval             bool // Require manual review until settings comply instead of only commenting
+}
+
+// ReplayConfig holds webhook replay protection configuration
+type ReplayConfig struct {
+	Enabled            bool // Reject repeated delivery UUIDs and stale event timestamps on the rebase/cleanup endpoints
+	WindowMinutes      int  // Maximum age of an event timestamp (default: 15)
+	UUIDRetentionHours int  // Hours delivery UUIDs are remembered (default: 168)
+}
+
+// IdempotencyConfig holds webhook delivery deduplication configuration
+type IdempotencyConfig struct {
+	Enabled    bool // Answer repeated deliveries with the result of the first one instead of processing them again
+	CacheSize  int  // Delivery results kept in memory (default: 1000)
+	TTLMinutes int  // Minutes a delivery result is remembered (default: 1440)
+}
+
+// SLOConfig holds time-to-decision service level objectives
+type SLOConfig struct {
+	DecisionLatencySeconds int     // Webhook receipt to decision posted (default: 30)
+	FirstDecisionSeconds   int     // MR opened to first naysayer decision (default: 300)
+	TargetPercent          float64 // Share of decisions that must meet the thresholds (default: 95)
+	WindowMinutes          int     // Window for compliance in metrics and burn alerts (default: 60)
+	AlertBurnRate          float64 // Error budget burn rate that triggers an alert (0 disables alerts)
+	AlertMinEvents         int     // Decisions needed in the window before alerting (default: 10)
+}
+
+// GovernanceConfig holds monthly governance report publishing configuration
+type GovernanceConfig struct {
+	ProjectID        int    // Dataproduct config repository compared between month boundaries (0 disables reports)
+	Ref              string // Branch compared (default: main)
+	PublishProjectID int    // Project owning the wiki page or snippet (default: ProjectID)
+	WikiPage         string // Wiki page slug prefix; one page per month is written below it
+	SnippetID        int    // Project snippet overwritten with the latest report (0 disables)
+}
+
+// Enabled reports whether monthly reports are generated and published somewhere
+func (g GovernanceConfig) Enabled() bool {
+	return g.ProjectID > 0 && (g.WikiPage != "" || g.SnippetID > 0)
+}
+
+// OverrideConfig holds `/naysayer approve-until` decision override configuration
+type OverrideConfig struct {
+	Enabled        bool   // Accept approve-until commands from note webhooks on the review endpoint
+	MaxDays        int    // Longest override a command may request (default: 30)
+	MinAccessLevel int    // GitLab access level the commenter needs on the project (default: 40, Maintainer)
+	Dir            string // Optional: keep overrides as files below this directory so they survive restarts
+}
+
+// OnboardingConfig holds the onboarding checklist for MRs creating new data products
+type OnboardingConfig struct {
+	Enabled       bool     // Comment an onboarding checklist on MRs that create a new data product directory
+	TemplatesFile string   // Optional: YAML checklist templates per product kind (default: built-in template)
+	Environments  []string // Environments checked on the target branch to tell new products from new environments
+	BlockApproval bool     // Require manual review while checklist items are missing (default: true)
+}
+
+// OwnersConfig routes manual reviews to the owners of the changed paths
+type OwnersConfig struct {
+	Enabled         bool     // Mention the owners of changed paths in manual review comments
+	Files           []string // Owners files on the target branch, the first existing one is used (default: OWNERS,owners.yaml)
+	AssignReviewers bool     // Also add owning users as MR reviewers (groups are only mentioned)
+}
+
+// JobsConfig holds the queue processing webhook deliveries asynchronously
+type JobsConfig struct {
+	Enabled          bool // Answer webhooks with 202 and process them on a worker pool
+	Workers          int  // Deliveries processed concurrently (default: 4)
+	QueueSize        int  // Deliveries waiting for a worker before new ones get 503 (default: 100)
+	RetentionMinutes int  // Minutes finished job results stay queryable on /jobs/{id} (default: 60)
+}
+
+// CoalesceConfig holds the deduplication of concurrent evaluations of the same MR
+type CoalesceConfig struct {
+	Enabled    bool // Evaluate one merge request event per MR at a time; events superseded by a newer one are skipped
+	DebounceMs int  // Wait for further events of the MR before evaluating, 0 only serializes (default: 500)
+}
+
+// ArchiveConfig holds webhook payload archiving configuration
+type ArchiveConfig struct {
+	Enabled           bool     // Store scrubbed webhook payloads for debugging
+	RetentionDays     int      // Days archived payloads are kept (default: 7)
+	ScrubKeys         []string // JSON keys whose values are redacted, also matched as a "_key" suffix (default: token,secret,password,authorization,private_key)
+	ScrubEmails       bool     // Redact email addresses (default: true)
+	ScrubCommitBodies bool     // Keep only the subject line of commit messages (default: true)
+}
+
+// SelfTestConfig holds the sandbox project used by the selftest command
+type SelfTestConfig struct {
+	ProjectID      int    // Sandbox project receiving disposable self-test MRs
+	TargetBranch   string // Branch the self-test MRs target (default: main)
+	WebhookURL     string // Optional: review endpoint of a deployed instance; empty evaluates in-process
+	Token          string // Optional: token of the self-test MR author (default: GITLAB_TOKEN)
+	TimeoutSeconds int    // Seconds to wait for the expected comment and approval per MR (default: 120)
+}
+
+// AuditConfig holds the structured audit log of decisions and actions
+type AuditConfig struct {
+	Sink       string // Where audit events are written: stdout or file (default: disabled)
+	FilePath   string // Audit log file of the file sink (default: naysayer-audit.log)
+	MaxSizeMB  int    // Size at which the audit log file is rotated, 0 disables rotation (default: 100)
+	MaxBackups int    // Rotated audit log files kept (default: 5)
+}
+
+// HistoryConfig holds the persistent decision history
+type HistoryConfig struct {
+	Enabled bool   // Persist decisions, rule outcomes and rebase/cleanup actions
+	DSN     string // SQLite database file, or a postgres:// URL (default: naysayer-history.db)
+}
+
+// ChatOpsConfig holds the `/naysayer recheck|rebase|explain|override` comment commands
+type ChatOpsConfig struct {
+	Enabled        bool     // Accept comment commands from note webhooks on the review endpoint
+	AllowAuthor    bool     // Let the MR author run commands on their own MR (default: true)
+	AllowedUsers   []string // GitLab usernames that may run commands on any MR
+	MinAccessLevel int      // GitLab access level that may run commands on any MR (default: 30, Developer; 0 disables)
+
+	OverrideApprovers []string // GitLab usernames that may run `/naysayer override` (default: none, command disabled)
+}
+
+// CommitStatusConfig holds the commit status naysayer reports on the MR head
+type CommitStatusConfig struct {
+	Enabled bool   // Report pending, success or failed on the head commit of reviewed MRs
+	Name    string // Status name shown next to the MR pipeline (default: naysayer/review)
+}
+
+// AutoMergeConfig holds the auto-merge configuration for MRs naysayer approves
+type AutoMergeConfig struct {
+	Enabled bool   // Merge approved MRs carrying the label once their pipeline succeeds
+	Label   string // Label that opts an MR into auto-merge (default: automerge)
+}
+
+// SystemHookConfig holds the GitLab system hook configuration for onboarding projects
+type SystemHookConfig struct {
+	Enabled         bool     // Accept instance-level system hook events on /system-hook
+	ProjectPatterns []string // Project paths reviewed automatically (path.Match syntax, e.g. dataverse/*)
+}
+
+// DashboardConfig holds the read-only admin dashboard served on /dashboard
+type DashboardConfig struct {
+	Enabled bool   // Serve the HTML dashboard on the admin listener
+	Token   string // Bearer token required to view the dashboard (required when enabled)
+}
+
+// SMTPConfig holds the mail server used for email digests
+type SMTPConfig struct {
+	Host     string // Mail server host (required for email)
+	Port     int    // Mail server port; STARTTLS is used when the server offers it (default: 587)
+	Username string // Optional: PLAIN authentication user
+	Password string // Optional: PLAIN authentication password
+	From     string // Sender address (required for email)
+}
+
+// Enabled reports whether email can be sent
+func (s SMTPConfig) Enabled() bool {
+	return s.Host != "" && s.From != ""
+}
+
+// DigestConfig holds the daily email digest of MRs waiting for manual review
+type DigestConfig struct {
+	Recipients   []string // Addresses of the reviewer groups receiving the digest (empty disables it)
+	Hour         int      // UTC hour from which the day's digest is sent (default: 8)
+	TemplateFile string   // Optional: Go template replacing the built-in email body
+}
+
+// TracingConfig holds the OpenTelemetry trace export, configured with the standard OTEL_*
+// environment variables
+type TracingConfig struct {
+	Endpoint    string            // OTLP/HTTP traces URL, e.g. http://otel-collector:4318/v1/traces (empty disables tracing)
+	Headers     map[string]string // Headers sent with every export, e.g. collector credentials
+	ServiceName string            // service.name resource attribute (default: naysayer)
+	SampleRatio float64           // Fraction of new traces recorded; requests continuing a trace follow its decision (default: 1)
+}
+
+// Enabled reports whether spans are exported
+func (t TracingConfig) Enabled() bool {
+	return t.Endpoint != ""
 }
 
 // Load loads configuration from environment variables
 func Load() *Config {
 	return &Config{
 		GitLab: GitLabConfig{
-			BaseURL:                       getEnv("GITLAB_BASE_URL", "https://gitlab.com"),
-			Token:                         getEnv("GITLAB_TOKEN", ""),
+			BaseURL:                getEnv("GITLAB_BASE_URL", "https://gitlab.com"),
+			APIVersion:             getEnv("GITLAB_API_VERSION", "v4"),
+			Token:                  getEnv("GITLAB_TOKEN", ""),
+			TokenFile:              getEnv("GITLAB_TOKEN_FILE", ""),
+			TokenSource:            getEnv("GITLAB_TOKEN_SOURCE", ""),
+			TokenReloadSeconds:     getEnvInt("GITLAB_TOKEN_RELOAD_SECONDS", 60),
+			TokenExpiryWarningDays: getEnvInt("GITLAB_TOKEN_EXPIRY_WARNING_DAYS", 7),
+			OAuth: GitLabOAuthConfig{
+				ClientID:         getEnv("GITLAB_OAUTH_CLIENT_ID", ""),
+				ClientSecret:     getEnv("GITLAB_OAUTH_CLIENT_SECRET", ""),
+				GrantType:        getEnv("GITLAB_OAUTH_GRANT_TYPE", ""),
+				RefreshToken:     getEnv("GITLAB_OAUTH_REFRESH_TOKEN", ""),
+				RefreshTokenFile: getEnv("GITLAB_OAUTH_REFRESH_TOKEN_FILE", ""),
+				Scopes:           parseStringList(getEnv("GITLAB_OAUTH_SCOPES", "api")),
+				TokenURL:         getEnv("GITLAB_OAUTH_TOKEN_URL", ""),
+			},
+			Vault: VaultConfig{
+				Addr:      getEnv("VAULT_ADDR", ""),
+				Token:     getEnv("VAULT_TOKEN", ""),
+				TokenFile: getEnv("VAULT_TOKEN_FILE", ""),
+				Namespace: getEnv("VAULT_NAMESPACE", ""),
+			},
 			GitlabFivetranRepositoryToken: getEnv("GITLAB_TOKEN_FIVETRAN", ""), // Dedicated token for fivetran_terraform rebase
 			GitlabStaleMRToken:            getEnv("GITLAB_TOKEN_STALE_MR", ""), // Dedicated token for stale MR cleanup
+			ReviewToken:                   getEnv("GITLAB_TOKEN_REVIEW", ""),   // Dedicated token for MR review
+			BotUsernames:                  parseStringList(getEnv("GITLAB_BOT_USERNAMES", "")),
+			BotIdentities:                 parseBotIdentities(getEnv("GITLAB_BOT_IDENTITIES", "")),
 			InsecureTLS:                   getEnv("GITLAB_INSECURE_TLS", "false") == "true",
 			CACertPath:                    getEnv("GITLAB_CA_CERT_PATH", ""),
+			MaxRetries:                    getEnvInt("GITLAB_MAX_RETRIES", 3),
+			RetryBackoffMs:                getEnvInt("GITLAB_RETRY_BACKOFF_MS", 500),
+			RateLimit:                     getEnvFloat("GITLAB_RATE_LIMIT", 10),
+			DetailConcurrency:             getEnvInt("GITLAB_DETAIL_CONCURRENCY", 8),
+			MaxMRFiles:                    getEnvInt("GITLAB_MAX_MR_FILES", 2000),
+			MaxMRDiffBytes:                getEnvInt("GITLAB_MAX_MR_DIFF_BYTES", 50*1024*1024),
+			FileCacheSize:                 getEnvInt("GITLAB_FILE_CACHE_SIZE", 1000),
+			FileCacheTTLSeconds:           getEnvInt("GITLAB_FILE_CACHE_TTL_SECONDS", 300),
+			ETagCacheSize:                 getEnvInt("GITLAB_ETAG_CACHE_SIZE", 500),
+			ConnectTimeoutSeconds:         getEnvInt("GITLAB_CONNECT_TIMEOUT_SECONDS", 10),
+			RequestTimeoutSeconds:         getEnvInt("GITLAB_REQUEST_TIMEOUT_SECONDS", 60),
+			CircuitBreakerThreshold:       getEnvInt("GITLAB_CIRCUIT_BREAKER_THRESHOLD", 5),
+			CircuitBreakerCooldownSeconds: getEnvInt("GITLAB_CIRCUIT_BREAKER_COOLDOWN_SECONDS", 30),
+			RebaseVerifyTimeoutSeconds:    getEnvInt("AUTO_REBASE_VERIFY_TIMEOUT_SECONDS", 60),
+			RebaseVerifyIntervalSeconds:   getEnvInt("AUTO_REBASE_VERIFY_INTERVAL_SECONDS", 2),
+			Instances:                     parseGitLabInstances(getEnv("GITLAB_INSTANCES", "")),
+		},
+		GitHub: GitHubConfig{
+			BaseURL:       getEnv("GITHUB_API_URL", "https://api.github.com"),
+			Token:         getEnv("GITHUB_TOKEN", ""),
+			WebhookSecret: getEnv("GITHUB_WEBHOOK_SECRET", ""),
 		},
 		Server: ServerConfig{
-			Port: getEnv("PORT", "3000"),
+			Port:           getEnv("PORT", "3000"),
+			UnixSocket:     getEnv("SERVER_UNIX_SOCKET", ""),
+			UnixSocketMode: getEnv("SERVER_UNIX_SOCKET_MODE", "0660"),
+			TLSCertFile:    getEnv("TLS_CERT_FILE", ""),
+			TLSKeyFile:     getEnv("TLS_KEY_FILE", ""),
+			ACMEDomains:    parseStringList(getEnv("TLS_ACME_DOMAINS", "")),
+			ACMEEmail:      getEnv("TLS_ACME_EMAIL", ""),
+			ACMECacheDir:   getEnv("TLS_ACME_CACHE_DIR", "acme-cache"),
+			ACMEDirectory:  getEnv("TLS_ACME_DIRECTORY_URL", ""),
+			HSTSMaxAge:     getEnvInt("SERVER_HSTS_MAX_AGE", 31536000),
+			AdminPort:      getEnv("ADMIN_PORT", ""),
+			AdminHost:      getEnv("ADMIN_HOST", ""),
 		},
 		Webhook: WebhookConfig{
-			Secret:     getEnv("WEBHOOK_SECRET", ""),
-			AllowedIPs: parseIPList(getEnv("WEBHOOK_ALLOWED_IPS", "")),
+			Secret:          getEnv("WEBHOOK_SECRET", ""),
+			EndpointSecrets: parseEndpointSecrets(),
+			AllowedIPs:      parseIPList(getEnv("WEBHOOK_ALLOWED_IPS", "")),
+			AllowedProjects: parseIntList(getEnv("WEBHOOK_ALLOWED_PROJECTS", "")),
+			MaxBodyBytes:    getEnvInt("WEBHOOK_MAX_BODY_BYTES", 1<<20),
 		},
 		Comments: CommentsConfig{
 			EnableMRComments:       getEnv("ENABLE_MR_COMMENTS", "true") == "true",
 			CommentVerbosity:       getEnv("COMMENT_VERBOSITY", "detailed"),
 			UpdateExistingComments: getEnv("UPDATE_EXISTING_COMMENTS", "true") == "true",
+			CoverageReport:         getEnv("COMMENT_COVERAGE_REPORT", "false") == "true",
+			InlineDiscussions:      getEnv("COMMENT_INLINE_DISCUSSIONS", "false") == "true",
+			TemplatesDir:           getEnv("COMMENT_TEMPLATES_DIR", ""),
+			Locale:                 getEnv("COMMENT_LOCALE", "en"),
+			ProjectLocales:         parseProjectStrategies(getEnv("COMMENT_LOCALE_PROJECTS", "")),
+
+			UpdateStrategy:          getEnv("COMMENT_UPDATE_STRATEGY", CommentStrategyEdit),
+			ProjectUpdateStrategies: parseProjectStrategies(getEnv("COMMENT_UPDATE_STRATEGY_PROJECTS", "")),
 		},
 		Rules: RulesConfig{
 			EnabledRules:  parseStringList(getEnv("ENABLED_RULES", "")),
@@ -146,6 +742,9 @@ func Load() *Config {
 			DataProductConsumerRule: DataProductConsumerRuleConfig{
 				AllowedEnvironments: parseStringList(getEnv("DATAPRODUCT_CONSUMER_ENVS", "preprod,prod")),
 			},
+			GroupMembershipRule: GroupMembershipRuleConfig{
+				ElevatedRoles: parseStringList(getEnv("GROUP_ELEVATED_ROLES", "approver")),
+			},
 			MigrationsRule: MigrationsRuleConfig{
 				RequirePlatformApproval: getEnv("MIGRATIONS_REQUIRE_PLATFORM", "true") == "true",
 				AllowSelfServicePaths:   parseStringList(getEnv("MIGRATIONS_SELF_SERVICE_PATHS", "")),
@@ -160,6 +759,10 @@ func Load() *Config {
 				AllowedDomains:           parseStringList(getEnv("SA_ALLOWED_DOMAINS", "redhat.com")),
 				AstroEnvironmentsOnly:    parseStringList(getEnv("SA_ASTRO_ENVS", "preprod,prod")),
 				EnforceNamingConventions: getEnv("SA_ENFORCE_NAMING", "true") == "true",
+				ElevatedRoles:            parseStringList(getEnv("SA_ELEVATED_ROLES", "accountadmin,orgadmin,securityadmin,sysadmin,useradmin")),
+			},
+			SourceBindingRule: SourceBindingRuleConfig{
+				AllowedPrivileges: parseStringList(getEnv("SOURCEBINDING_ALLOWED_PRIVILEGES", "select,references,usage")),
 			},
 			TOCApprovalRule: TOCApprovalRuleConfig{
 				CriticalEnvironments: parseStringList(getEnv("TOC_APPROVAL_ENVS", "preprod,prod")),
@@ -168,6 +771,15 @@ func Load() *Config {
 				AllowTOCBypass:       getEnv("WAREHOUSE_ALLOW_TOC_BYPASS", "false") == "true",
 				PlatformEnvironments: parseStringList(getEnv("WAREHOUSE_PLATFORM_ENVS", "preprod,prod")),
 				AutoApproveEnvs:      parseStringList(getEnv("WAREHOUSE_AUTO_APPROVE_ENVS", "dev,sandbox")),
+				CreditsPerHour:       parseSizeCredits(getEnv("WAREHOUSE_CREDITS_PER_HOUR", "")),
+				HoursPerMonth:        getEnvFloat("WAREHOUSE_HOURS_PER_MONTH", 730),
+				CreditPrice:          getEnvFloat("WAREHOUSE_CREDIT_PRICE", 3),
+				CostCurrency:         getEnv("WAREHOUSE_COST_CURRENCY", "USD"),
+				CostReviewThreshold:  getEnvFloat("WAREHOUSE_COST_REVIEW_THRESHOLD", 0),
+				MaxSizes:             parseEnvironmentSizes(getEnv("WAREHOUSE_MAX_SIZES", "")),
+				MaxSizeSteps:         getEnvInt("WAREHOUSE_MAX_SIZE_STEPS", 2),
+				ResizePolicy:         strings.ToLower(getEnv("WAREHOUSE_RESIZE_POLICY", "review_all")),
+				ResizePolicies:       parseEnvironmentPolicies(getEnv("WAREHOUSE_RESIZE_POLICIES", "")),
 			},
 		},
 		Approval: ApprovalConfig{
@@ -176,22 +788,252 @@ func Load() *Config {
 			EnablePlatformWorkflow: getEnv("ENABLE_PLATFORM_WORKFLOW", "true") == "true",
 			TOCGroupID:             getEnv("TOC_GROUP_ID", ""),
 			PlatformGroupID:        getEnv("PLATFORM_GROUP_ID", ""),
+			ReviewDrafts:           getEnv("REVIEW_DRAFT_MRS", "false") == "true",
 		},
 		AutoRebase: AutoRebaseConfig{
 			Enabled:               getEnv("AUTO_REBASE_ENABLED", "true") == "true",
 			CheckAtlantisComments: getEnv("AUTO_REBASE_CHECK_ATLANTIS_COMMENTS", "true") == "true",
-			// Support both new and old env var names for backward compatibility
-			RepositoryToken: getEnv("AUTO_REBASE_REPOSITORY_TOKEN", getEnv("GITLAB_TOKEN_FIVETRAN", "")),
+			// Falls back to the legacy GITLAB_TOKEN_FIVETRAN (see LegacyKeys)
+			RepositoryToken:        getEnvMigrated("AUTO_REBASE_REPOSITORY_TOKEN", ""),
+			CatchUpProjects:        parseStringList(getEnv("AUTO_REBASE_CATCHUP_PROJECTS", "")),
+			CatchUpIntervalMinutes: getEnvInt("AUTO_REBASE_CATCHUP_MINUTES", 15),
+			SkipLabels:             parseStringList(getEnv("AUTO_REBASE_SKIP_LABELS", "")),
+			EligibilityHooks:       parseStringList(getEnv("AUTO_REBASE_ELIGIBILITY_HOOKS", "")),
+			EligibilityPlugins:     parseStringList(getEnv("AUTO_REBASE_ELIGIBILITY_PLUGINS", "")),
+			EligibilityHookTimeout: getEnvInt("AUTO_REBASE_ELIGIBILITY_HOOK_TIMEOUT", 5),
+			Schedules:              parseScheduleList(getEnv("AUTO_REBASE_SCHEDULES", "")),
+			SkipDrafts:             getEnv("AUTO_REBASE_SKIP_DRAFTS", "false") == "true",
+			OptOutLabel:            strings.TrimSpace(getEnv("AUTO_REBASE_OPT_OUT_LABEL", "no-auto-rebase")),
+			MaxRebasesPerPush:      getEnvInt("AUTO_REBASE_MAX_PER_PUSH", 0),
+			Order:                  getEnv("AUTO_REBASE_ORDER", RebaseOrderOldestFirst),
+			PriorityLabels:         parseStringList(getEnv("AUTO_REBASE_PRIORITY_LABELS", "")),
+			CooldownMinutes:        getEnvInt("AUTO_REBASE_COOLDOWN_MINUTES", 0),
 		},
 		StaleMR: StaleMRConfig{
-			ClosureDays: getEnvInt("STALE_MR_CLOSURE_DAYS", 30),
+			ClosureDays:       getEnvInt("STALE_MR_CLOSURE_DAYS", 30),
+			BranchDays:        getEnvInt("STALE_BRANCH_DAYS", 90),
+			BranchDelete:      getEnv("STALE_BRANCH_DELETE", "false") == "true",
+			ProtectedBranches: parseStringList(getEnv("STALE_BRANCH_PROTECTED", "main,master,release/*")),
+			Schedules:         parseScheduleList(getEnv("STALE_MR_SCHEDULES", "")),
+			ExemptLabels:      parseStringList(getEnv("STALE_MR_EXEMPT_LABELS", "keep-open,pinned")),
+			DraftClosureDays:  getEnvInt("STALE_MR_DRAFT_CLOSURE_DAYS", 0),
+		},
+		RepoIndex: RepoIndexConfig{
+			Enabled:                getEnv("REPO_INDEX_ENABLED", "false") == "true",
+			RefreshIntervalMinutes: getEnvInt("REPO_INDEX_REFRESH_MINUTES", 60),
+		},
+		Notify: NotifyConfig{
+			WebhookURL: getEnv("NOTIFY_WEBHOOK_URL", ""),
+			Channels:   parseNotifyChannels(getEnv("NOTIFY_CHANNELS", "")),
+			Routes:     parseNotifyRoutes(getEnv("NOTIFY_ROUTES", "")),
+			Templates:  parseNotifyTemplates(),
+		},
+		Flapping: FlappingConfig{
+			Threshold:          getEnvInt("DECISION_FLAP_THRESHOLD", 3),
+			WindowMinutes:      getEnvInt("DECISION_FLAP_WINDOW_MINUTES", 60),
+			FreezeAutoApproval: getEnv("DECISION_FLAP_FREEZE", "false") == "true",
+		},
+		Snapshot: SnapshotConfig{
+			Enabled:       getEnv("MR_SNAPSHOT_ENABLED", "false") == "true",
+			Dir:           getEnv("MR_SNAPSHOT_DIR", ""),
+			EncryptionKey: getEnv("MR_SNAPSHOT_ENCRYPTION_KEY", ""),
+			RetentionDays: getEnvInt("MR_SNAPSHOT_RETENTION_DAYS", 90),
+			MaxPerMR:      getEnvInt("MR_SNAPSHOT_MAX_PER_MR", 20),
+		},
+		Revert: RevertConfig{
+			FastPathEnabled: getEnv("REVERT_FAST_PATH_ENABLED", "true") == "true",
+		},
+		MergePolicy: MergePolicyConfig{
+			Enabled:                   getEnv("MERGE_POLICY_ENABLED", "false") == "true",
+			RequireSquash:             getEnv("MERGE_POLICY_REQUIRE_SQUASH", "true") == "true",
+			RequireDeleteSourceBranch: getEnv("MERGE_POLICY_REQUIRE_DELETE_SOURCE_BRANCH", "true") == "true",
+			ForbidMergeCommits:        getEnv("MERGE_POLICY_FORBID_MERGE_COMMITS", "true") == "true",
+			BlockApproval:             getEnv("MERGE_POLICY_BLOCK_APPROVAL", "false") == "true",
+		},
+		Replay: ReplayConfig{
+			Enabled:            getEnv("REPLAY_PROTECTION_ENABLED", "false") == "true",
+			WindowMinutes:      getEnvInt("REPLAY_WINDOW_MINUTES", 15),
+			UUIDRetentionHours: getEnvInt("REPLAY_UUID_RETENTION_HOURS", 168),
+		},
+		Idempotency: IdempotencyConfig{
+			Enabled:    getEnv("WEBHOOK_IDEMPOTENCY_ENABLED", "true") == "true",
+			CacheSize:  getEnvInt("WEBHOOK_IDEMPOTENCY_CACHE_SIZE", 1000),
+			TTLMinutes: getEnvInt("WEBHOOK_IDEMPOTENCY_TTL_MINUTES", 1440),
+		},
+		SLO: SLOConfig{
+			DecisionLatencySeconds: getEnvInt("SLO_DECISION_LATENCY_SECONDS", 30),
+			FirstDecisionSeconds:   getEnvInt("SLO_FIRST_DECISION_SECONDS", 300),
+			TargetPercent:          getEnvFloat("SLO_TARGET_PERCENT", 95),
+			WindowMinutes:          getEnvInt("SLO_WINDOW_MINUTES", 60),
+			AlertBurnRate:          getEnvFloat("SLO_ALERT_BURN_RATE", 2),
+			AlertMinEvents:         getEnvInt("SLO_ALERT_MIN_EVENTS", 10),
+		},
+		Governance: GovernanceConfig{
+			ProjectID:        getEnvInt("GOVERNANCE_REPORT_PROJECT", 0),
+			Ref:              getEnv("GOVERNANCE_REPORT_REF", "main"),
+			PublishProjectID: getEnvInt("GOVERNANCE_REPORT_PUBLISH_PROJECT", getEnvInt("GOVERNANCE_REPORT_PROJECT", 0)),
+			WikiPage:         getEnv("GOVERNANCE_REPORT_WIKI_PAGE", ""),
+			SnippetID:        getEnvInt("GOVERNANCE_REPORT_SNIPPET_ID", 0),
+		},
+		Override: OverrideConfig{
+			Enabled:        getEnv("OVERRIDE_ENABLED", "false") == "true",
+			MaxDays:        getEnvInt("OVERRIDE_MAX_DAYS", 30),
+			MinAccessLevel: getEnvInt("OVERRIDE_MIN_ACCESS_LEVEL", 40),
+			Dir:            getEnv("OVERRIDE_DIR", ""),
+		},
+		Onboarding: OnboardingConfig{
+			Enabled:       getEnv("ONBOARDING_CHECKLIST_ENABLED", "false") == "true",
+			TemplatesFile: getEnv("ONBOARDING_TEMPLATES_FILE", ""),
+			Environments:  parseStringList(getEnv("ONBOARDING_ENVIRONMENTS", "dev,sandbox,preprod,prod")),
+			BlockApproval: getEnv("ONBOARDING_BLOCK_APPROVAL", "true") == "true",
+		},
+		Owners: OwnersConfig{
+			Enabled:         getEnv("OWNERS_ENABLED", "false") == "true",
+			Files:           parseStringList(getEnv("OWNERS_FILES", "OWNERS,owners.yaml")),
+			AssignReviewers: getEnv("OWNERS_ASSIGN_REVIEWERS", "false") == "true",
+		},
+		Jobs: JobsConfig{
+			Enabled:          getEnv("JOB_QUEUE_ENABLED", "false") == "true",
+			Workers:          getEnvInt("JOB_QUEUE_WORKERS", 4),
+			QueueSize:        getEnvInt("JOB_QUEUE_SIZE", 100),
+			RetentionMinutes: getEnvInt("JOB_RETENTION_MINUTES", 60),
+		},
+		Coalesce: CoalesceConfig{
+			Enabled:    getEnv("EVALUATION_COALESCING_ENABLED", "true") == "true",
+			DebounceMs: getEnvInt("EVALUATION_DEBOUNCE_MS", 500),
+		},
+		Archive: ArchiveConfig{
+			Enabled:           getEnv("PAYLOAD_ARCHIVE_ENABLED", "false") == "true",
+			RetentionDays:     getEnvInt("PAYLOAD_ARCHIVE_RETENTION_DAYS", 7),
+			ScrubKeys:         parseStringList(getEnv("PAYLOAD_ARCHIVE_SCRUB_KEYS", "token,secret,password,authorization,private_key")),
+			ScrubEmails:       getEnv("PAYLOAD_ARCHIVE_SCRUB_EMAILS", "true") == "true",
+			ScrubCommitBodies: getEnv("PAYLOAD_ARCHIVE_SCRUB_COMMIT_BODIES", "true") == "true",
+		},
+		SelfTest: SelfTestConfig{
+			ProjectID:      getEnvInt("SELFTEST_PROJECT_ID", 0),
+			TargetBranch:   getEnv("SELFTEST_TARGET_BRANCH", "main"),
+			WebhookURL:     getEnv("SELFTEST_WEBHOOK_URL", ""),
+			Token:          getEnv("SELFTEST_GITLAB_TOKEN", ""),
+			TimeoutSeconds: getEnvInt("SELFTEST_TIMEOUT_SECONDS", 120),
+		},
+		Audit: AuditConfig{
+			Sink:       getEnv("AUDIT_LOG_SINK", ""),
+			FilePath:   getEnv("AUDIT_LOG_FILE", "naysayer-audit.log"),
+			MaxSizeMB:  getEnvInt("AUDIT_LOG_MAX_SIZE_MB", 100),
+			MaxBackups: getEnvInt("AUDIT_LOG_MAX_BACKUPS", 5),
+		},
+		History: HistoryConfig{
+			Enabled: getEnv("HISTORY_ENABLED", "false") == "true",
+			DSN:     getEnv("HISTORY_DSN", "naysayer-history.db"),
+		},
+		ChatOps: ChatOpsConfig{
+			Enabled:        getEnv("CHATOPS_ENABLED", "false") == "true",
+			AllowAuthor:    getEnv("CHATOPS_ALLOW_AUTHOR", "true") == "true",
+			AllowedUsers:   parseStringList(getEnv("CHATOPS_ALLOWED_USERS", "")),
+			MinAccessLevel: getEnvInt("CHATOPS_MIN_ACCESS_LEVEL", 30),
+
+			OverrideApprovers: parseStringList(getEnv("CHATOPS_OVERRIDE_APPROVERS", "")),
+		},
+		CommitStatus: CommitStatusConfig{
+			Enabled: getEnv("COMMIT_STATUS_ENABLED", "false") == "true",
+			Name:    getEnv("COMMIT_STATUS_NAME", "naysayer/review"),
+		},
+		AutoMerge: AutoMergeConfig{
+			Enabled: getEnv("AUTO_MERGE_ENABLED", "false") == "true",
+			Label:   getEnv("AUTO_MERGE_LABEL", "automerge"),
+		},
+		SystemHook: SystemHookConfig{
+			Enabled:         getEnv("SYSTEM_HOOK_ENABLED", "false") == "true",
+			ProjectPatterns: parseStringList(getEnv("SYSTEM_HOOK_PROJECT_PATTERNS", "")),
+		},
+		Projects: ProjectFilterConfig{
+			Allow:      parseScheduleList(getEnv("PROJECT_ALLOWLIST", "")),
+			Deny:       parseScheduleList(getEnv("PROJECT_DENYLIST", "")),
+			Subsystems: parseScheduleList(getEnv("PROJECT_SUBSYSTEMS", "")),
+		},
+		Dashboard: DashboardConfig{
+			Enabled: getEnv("DASHBOARD_ENABLED", "false") == "true",
+			Token:   getEnv("DASHBOARD_TOKEN", ""),
+		},
+		SMTP: SMTPConfig{
+			Host:     getEnv("SMTP_HOST", ""),
+			Port:     getEnvInt("SMTP_PORT", 587),
+			Username: getEnv("SMTP_USERNAME", ""),
+			Password: getEnv("SMTP_PASSWORD", ""),
+			From:     getEnv("SMTP_FROM", ""),
+		},
+		Digest: DigestConfig{
+			Recipients:   parseStringList(getEnv("DIGEST_RECIPIENTS", "")),
+			Hour:         getEnvInt("DIGEST_HOUR", 8),
+			TemplateFile: getEnv("DIGEST_TEMPLATE_FILE", ""),
+		},
+		Tracing: TracingConfig{
+			Endpoint:    tracesEndpoint(),
+			Headers:     parseHeaderList(getEnv("OTEL_EXPORTER_OTLP_TRACES_HEADERS", getEnv("OTEL_EXPORTER_OTLP_HEADERS", ""))),
+			ServiceName: getEnv("OTEL_SERVICE_NAME", "naysayer"),
+			SampleRatio: getEnvFloat("OTEL_TRACES_SAMPLER_ARG", 1),
 		},
+		Deprecations: Deprecations(),
 	}
 }
 
+// GitLabFor returns the GitLab configuration of a naysayer function (EndpointReview,
+// EndpointAutoRebase or EndpointStaleMRCleanup), using the function's dedicated token
+// when one is configured so its actions appear under its own bot user
+func (c *Config) GitLabFor(function string) GitLabConfig {
+	gitlabConfig := c.GitLab
+	var token string
+	switch function {
+	case EndpointReview:
+		token = c.GitLab.ReviewToken
+	case EndpointAutoRebase:
+		token = c.AutoRebase.RepositoryToken
+	case EndpointStaleMRCleanup:
+		token = c.GitLab.GitlabStaleMRToken
+	}
+	if token != "" {
+		gitlabConfig.Token = token
+		gitlabConfig.TokenFile = ""
+		gitlabConfig.TokenSource = ""
+		gitlabConfig.OAuth = GitLabOAuthConfig{}
+	}
+	return gitlabConfig
+}
+
+// ForGitLabInstance returns a copy of the config talking to the named additional GitLab
+// instance, and false when no instance has that name. Function-specific tokens, webhook
+// secrets and bot identities belong to the default instance and are not used for other
+// instances, whose deliveries are verified with their own webhook secret.
+func (c *Config) ForGitLabInstance(name string) (*Config, bool) {
+	for _, instance := range c.GitLab.Instances {
+		if instance.Name != name {
+			continue
+		}
+		copied := *c
+		copied.GitLab.Instance = instance.Name
+		copied.GitLab.Instances = nil
+		copied.GitLab.BaseURL = instance.BaseURL
+		copied.GitLab.Token = instance.Token
+		copied.GitLab.TokenFile = instance.TokenFile
+		copied.GitLab.TokenSource = instance.TokenSource
+		copied.GitLab.OAuth = GitLabOAuthConfig{}
+		copied.GitLab.InsecureTLS = instance.InsecureTLS
+		copied.GitLab.CACertPath = instance.CACertPath
+		copied.GitLab.ReviewToken = ""
+		copied.GitLab.GitlabStaleMRToken = ""
+		copied.GitLab.GitlabFivetranRepositoryToken = ""
+		copied.GitLab.BotIdentities = nil
+		copied.AutoRebase.RepositoryToken = ""
+		copied.Webhook.Secret = instance.WebhookSecret
+		copied.Webhook.EndpointSecrets = nil
+		return &copied, true
+	}
+	return nil, false
+}
+
 // HasGitLabToken returns true if GitLab token is configured
 func (c *Config) HasGitLabToken() bool {
-	return c.GitLab.Token != ""
+	return c.GitLab.Token != "" || c.GitLab.TokenFile != "" || c.GitLab.TokenSource != "" || c.GitLab.OAuth.Configured()
 }
 
 // AnalysisMode returns a description of the current analysis mode
@@ -202,9 +1044,9 @@ func (c *Config) AnalysisMode() string {
 	return "Limited (no GitLab token)"
 }
 
-// HasWebhookSecret returns true if webhook secret is configured
+// HasWebhookSecret returns true if a webhook secret is configured for any endpoint
 func (c *Config) HasWebhookSecret() bool {
-	return c.Webhook.Secret != ""
+	return c.Webhook.Secret != "" || len(c.Webhook.EndpointSecrets) > 0
 }
 
 // WebhookSecurityMode returns a description of the current webhook security mode
@@ -231,6 +1073,15 @@ func getEnvInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+func getEnvFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
+
 // parseIPList parses a comma-separated list of IP addresses
 func parseIPList(ipString string) []string {
 	if ipString == "" {
@@ -260,3 +1111,251 @@ func parseStringList(s string) []string {
 	}
 	return result
 }
+
+// parseScheduleList parses a semicolon-separated list of entries using commas themselves,
+// such as cron expressions and path regexes
+func parseScheduleList(s string) []string {
+	result := make([]string, 0)
+	for _, item := range strings.Split(s, ";") {
+		if trimmed := strings.TrimSpace(item); trimmed != "" {
+			result = append(result, trimmed)
+		}
+	}
+	return result
+}
+
+// parseIntList parses a comma-separated list of integers, skipping invalid entries
+func parseIntList(s string) []int {
+	result := make([]int, 0)
+	for _, item := range parseStringList(s) {
+		if value, err := strconv.Atoi(item); err == nil {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+// parseSizeCredits parses comma-separated <SIZE>=<credits> entries, skipping malformed ones
+func parseSizeCredits(s string) map[string]float64 {
+	result := make(map[string]float64)
+	for _, entry := range parseStringList(s) {
+		size, value, ok := strings.Cut(entry, "=")
+		if !ok {
+			continue
+		}
+		credits, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || credits < 0 {
+			continue
+		}
+		result[strings.ToUpper(strings.TrimSpace(size))] = credits
+	}
+	return result
+}
+
+// parseEnvironmentSizes parses comma-separated <environment>=<size> entries into sizes by
+// lowercased environment, skipping malformed ones
+func parseEnvironmentSizes(s string) map[string]string {
+	result := make(map[string]string)
+	for _, entry := range parseStringList(s) {
+		environment, size, ok := strings.Cut(entry, "=")
+		environment, size = strings.ToLower(strings.TrimSpace(environment)), strings.ToUpper(strings.TrimSpace(size))
+		if !ok || environment == "" || size == "" {
+			continue
+		}
+		result[environment] = size
+	}
+	return result
+}
+
+// tracesEndpoint returns OTEL_EXPORTER_OTLP_TRACES_ENDPOINT, else the traces path of the
+// OTEL_EXPORTER_OTLP_ENDPOINT collector, else "" when OTEL_TRACES_EXPORTER is not otlp
+func tracesEndpoint() string {
+	if exporter := getEnv("OTEL_TRACES_EXPORTER", "otlp"); exporter != "otlp" {
+		return ""
+	}
+	if endpoint := getEnv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", ""); endpoint != "" {
+		return endpoint
+	}
+	if endpoint := getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", ""); endpoint != "" {
+		return strings.TrimRight(endpoint, "/") + "/v1/traces"
+	}
+	return ""
+}
+
+// parseHeaderList parses comma-separated <name>=<value> headers, skipping malformed ones;
+// values may be URL-encoded as the OpenTelemetry specification allows
+func parseHeaderList(s string) map[string]string {
+	result := make(map[string]string)
+	for _, entry := range parseStringList(s) {
+		name, value, ok := strings.Cut(entry, "=")
+		name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+		if !ok || name == "" {
+			continue
+		}
+		if decoded, err := url.QueryUnescape(value); err == nil {
+			value = decoded
+		}
+		result[name] = value
+	}
+	return result
+}
+
+// parseEnvironmentPolicies parses comma-separated <selector>=<policy> entries into lowercased
+// policies by lowercased selector, skipping malformed ones
+func parseEnvironmentPolicies(s string) map[string]string {
+	result := make(map[string]string)
+	for _, entry := range parseStringList(s) {
+		selector, policy, ok := strings.Cut(entry, "=")
+		selector, policy = strings.ToLower(strings.TrimSpace(selector)), strings.ToLower(strings.TrimSpace(policy))
+		if !ok || selector == "" || policy == "" {
+			continue
+		}
+		result[selector] = policy
+	}
+	return result
+}
+
+// parseGitLabInstances reads the additional GitLab instances named in a comma-separated list
+// from GITLAB_INSTANCE_<NAME>_URL, _TOKEN, _TOKEN_FILE, _TOKEN_SOURCE, _INSECURE_TLS, _CA_CERT_PATH
+// and _WEBHOOK_SECRET, where
+// <NAME> is the upper-cased name with - replaced by _. Duplicate names and "default", the
+// name of the GITLAB_BASE_URL instance, are skipped.
+func parseGitLabInstances(s string) []GitLabInstance {
+	result := make([]GitLabInstance, 0)
+	seen := map[string]bool{"default": true}
+	for _, name := range parseStringList(s) {
+		name = strings.ToLower(name)
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		prefix := "GITLAB_INSTANCE_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+		result = append(result, GitLabInstance{
+			Name:          name,
+			BaseURL:       getEnv(prefix+"URL", ""),
+			Token:         getEnv(prefix+"TOKEN", ""),
+			TokenFile:     getEnv(prefix+"TOKEN_FILE", ""),
+			TokenSource:   getEnv(prefix+"TOKEN_SOURCE", ""),
+			InsecureTLS:   getEnv(prefix+"INSECURE_TLS", "false") == "true",
+			CACertPath:    getEnv(prefix+"CA_CERT_PATH", ""),
+			WebhookSecret: getEnv(prefix+"WEBHOOK_SECRET", ""),
+		})
+	}
+	return result
+}
+
+// parseBotIdentities parses comma-separated <project_id|*>:<username>[:<user_id>] entries,
+// skipping malformed ones
+func parseBotIdentities(s string) []BotIdentity {
+	result := make([]BotIdentity, 0)
+	for _, entry := range parseStringList(s) {
+		parts := strings.Split(entry, ":")
+		if len(parts) < 2 || len(parts) > 3 {
+			continue
+		}
+		identity := BotIdentity{Username: strings.TrimSpace(parts[1])}
+		if project := strings.TrimSpace(parts[0]); project != "*" {
+			projectID, err := strconv.Atoi(project)
+			if err != nil || projectID <= 0 {
+				continue
+			}
+			identity.ProjectID = projectID
+		}
+		if len(parts) == 3 {
+			userID, err := strconv.Atoi(strings.TrimSpace(parts[2]))
+			if err != nil || userID <= 0 {
+				continue
+			}
+			identity.UserID = userID
+		}
+		if identity.Username == "" {
+			continue
+		}
+		result = append(result, identity)
+	}
+	return result
+}
+
+// parseProjectStrategies parses comma-separated <project_id>:<value> pairs (comment update
+// strategies, comment locales), skipping malformed entries
+func parseProjectStrategies(s string) map[int]string {
+	result := make(map[int]string)
+	for _, entry := range parseStringList(s) {
+		idPart, strategy, found := strings.Cut(entry, ":")
+		projectID, err := strconv.Atoi(strings.TrimSpace(idPart))
+		if !found || err != nil || projectID <= 0 || strings.TrimSpace(strategy) == "" {
+			continue
+		}
+		result[projectID] = strings.TrimSpace(strategy)
+	}
+	return result
+}
+
+// parseNotifyChannels parses semicolon-separated <name>=<kind>:<url> entries, skipping
+// malformed entries and unknown kinds
+func parseNotifyChannels(s string) map[string]NotifyChannel {
+	result := make(map[string]NotifyChannel)
+	for _, entry := range parseScheduleList(s) {
+		name, target, _ := strings.Cut(entry, "=")
+		kind, url, found := strings.Cut(strings.TrimSpace(target), ":")
+		name, kind, url = strings.TrimSpace(name), strings.ToLower(strings.TrimSpace(kind)), strings.TrimSpace(url)
+		if !found || name == "" || url == "" {
+			continue
+		}
+		switch kind {
+		case NotifyChannelSlack, NotifyChannelGoogleChat, NotifyChannelWebhook:
+			result[name] = NotifyChannel{Kind: kind, URL: url}
+		}
+	}
+	return result
+}
+
+// parseNotifyRoutes parses semicolon-separated <event>=<channel>[,<channel>] entries,
+// skipping malformed ones
+func parseNotifyRoutes(s string) map[string][]string {
+	result := make(map[string][]string)
+	for _, entry := range parseScheduleList(s) {
+		event, channels, found := strings.Cut(entry, "=")
+		event = strings.TrimSpace(event)
+		if names := parseStringList(channels); found && event != "" && len(names) > 0 {
+			result[event] = append(result[event], names...)
+		}
+	}
+	return result
+}
+
+// notifyTemplatePrefix prefixes the variables holding the message template of an event
+// type, e.g. NOTIFY_TEMPLATE_MANUAL_REVIEW for manual_review
+const notifyTemplatePrefix = "NOTIFY_TEMPLATE_"
+
+// parseNotifyTemplates reads the message templates of the NOTIFY_TEMPLATE_<EVENT> variables
+func parseNotifyTemplates() map[string]string {
+	result := make(map[string]string)
+	for _, variable := range os.Environ() {
+		key, value, _ := strings.Cut(variable, "=")
+		event, found := strings.CutPrefix(key, notifyTemplatePrefix)
+		if found && event != "" && strings.TrimSpace(value) != "" {
+			result[strings.ToLower(event)] = value
+		}
+	}
+	return result
+}
+
+// endpointSecretEnv maps webhook endpoints to the variables holding their own secret
+var endpointSecretEnv = map[string]string{
+	EndpointReview:         "WEBHOOK_SECRET_REVIEW",
+	EndpointAutoRebase:     "WEBHOOK_SECRET_AUTO_REBASE",
+	EndpointStaleMRCleanup: "WEBHOOK_SECRET_STALE_MR_CLEANUP",
+	EndpointSystemHook:     "WEBHOOK_SECRET_SYSTEM_HOOK",
+}
+
+// parseEndpointSecrets reads the per-endpoint webhook secrets that are set
+func parseEndpointSecrets() map[string]string {
+	result := make(map[string]string)
+	for endpoint, key := range endpointSecretEnv {
+		if secret := getEnv(key, ""); secret != "" {
+			result[endpoint] = secret
+		}
+	}
+	return result
+}
@@ -36,8 +36,12 @@ func TestLoad_DefaultValues(t *testing.T) {
 	assert.Equal(t, "https://gitlab.com", config.GitLab.BaseURL)
 	assert.Equal(t, "", config.GitLab.Token)
 	assert.Equal(t, "3000", config.Server.Port)
+	assert.Equal(t, "0660", config.Server.UnixSocketMode)
+	assert.False(t, config.Server.TLSEnabled())
 	assert.Equal(t, "", config.Webhook.Secret)
 	assert.Empty(t, config.Webhook.AllowedIPs)
+	assert.Empty(t, config.Webhook.AllowedProjects)
+	assert.Equal(t, 1<<20, config.Webhook.MaxBodyBytes)
 }
 
 func TestLoad_EnvironmentOverrides(t *testing.T) {
@@ -344,6 +348,290 @@ func TestParseIPList(t *testing.T) {
 	}
 }
 
+func TestParseProjectStrategies(t *testing.T) {
+	result := parseProjectStrategies(" 123:reply , 456:on-decision-change,bad:reply,789,0:edit")
+	assert.Equal(t, map[int]string{123: "reply", 456: "on-decision-change"}, result)
+	assert.Empty(t, parseProjectStrategies(""))
+}
+
+func TestCommentsConfig_UpdateStrategyFor(t *testing.T) {
+	comments := CommentsConfig{
+		UpdateStrategy:          CommentStrategyReply,
+		ProjectUpdateStrategies: map[int]string{1: CommentStrategyOnDecisionChange, 2: "unknown"},
+	}
+
+	assert.Equal(t, CommentStrategyOnDecisionChange, comments.UpdateStrategyFor(1))
+	assert.Equal(t, CommentStrategyEdit, comments.UpdateStrategyFor(2))
+	assert.Equal(t, CommentStrategyReply, comments.UpdateStrategyFor(3))
+	assert.Equal(t, CommentStrategyEdit, CommentsConfig{}.UpdateStrategyFor(3))
+}
+
+func TestCommentsConfig_LocaleFor(t *testing.T) {
+	assert.Equal(t, "en", Load().Comments.LocaleFor(1))
+
+	t.Setenv("COMMENT_LOCALE", "de")
+	t.Setenv("COMMENT_LOCALE_PROJECTS", "7:pt-BR, bad, 9:fr")
+	comments := Load().Comments
+	assert.Equal(t, "de", comments.LocaleFor(1))
+	assert.Equal(t, "pt-br", comments.LocaleFor(7))
+	assert.Equal(t, "fr", comments.LocaleFor(9))
+	assert.Equal(t, "en", CommentsConfig{}.LocaleFor(1))
+}
+
+func TestGovernanceConfig(t *testing.T) {
+	t.Setenv("GOVERNANCE_REPORT_PROJECT", "42")
+	t.Setenv("GOVERNANCE_REPORT_WIKI_PAGE", "governance")
+
+	cfg := Load()
+	assert.Equal(t, 42, cfg.Governance.ProjectID)
+	assert.Equal(t, 42, cfg.Governance.PublishProjectID, "publishes to the scanned project by default")
+	assert.Equal(t, "main", cfg.Governance.Ref)
+	assert.True(t, cfg.Governance.Enabled())
+
+	assert.False(t, GovernanceConfig{ProjectID: 42}.Enabled(), "needs a wiki page or snippet")
+	assert.False(t, GovernanceConfig{SnippetID: 7}.Enabled(), "needs a project")
+}
+
+func TestOverrideConfigDefaults(t *testing.T) {
+	cfg := Load()
+	assert.False(t, cfg.Override.Enabled)
+	assert.Equal(t, 30, cfg.Override.MaxDays)
+	assert.Equal(t, 40, cfg.Override.MinAccessLevel)
+}
+
+func TestAutoRebaseEligibilityConfig(t *testing.T) {
+	t.Setenv("AUTO_REBASE_SKIP_LABELS", "needs-design-review=design_review_pending, wip")
+	t.Setenv("AUTO_REBASE_ELIGIBILITY_HOOKS", "freeze=https://freeze.example.com/check")
+
+	cfg := Load()
+	assert.Equal(t, []string{"needs-design-review=design_review_pending", "wip"}, cfg.AutoRebase.SkipLabels)
+	assert.Equal(t, []string{"freeze=https://freeze.example.com/check"}, cfg.AutoRebase.EligibilityHooks)
+	assert.Empty(t, cfg.AutoRebase.EligibilityPlugins)
+	assert.Equal(t, 5, cfg.AutoRebase.EligibilityHookTimeout)
+}
+
+func TestGitHubConfig(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "")
+	cfg := Load()
+	assert.Equal(t, "https://api.github.com", cfg.GitHub.BaseURL)
+	assert.False(t, cfg.GitHub.Enabled())
+
+	t.Setenv("GITHUB_API_URL", "https://github.example.com/api/v3")
+	t.Setenv("GITHUB_TOKEN", "ghp_test")
+	cfg = Load()
+	assert.Equal(t, "https://github.example.com/api/v3", cfg.GitHub.BaseURL)
+	assert.True(t, cfg.GitHub.Enabled())
+}
+
+func TestStaleBranchConfig(t *testing.T) {
+	cfg := Load()
+	assert.Equal(t, 90, cfg.StaleMR.BranchDays)
+	assert.False(t, cfg.StaleMR.BranchDelete)
+	assert.Equal(t, []string{"main", "master", "release/*"}, cfg.StaleMR.ProtectedBranches)
+
+	t.Setenv("STALE_BRANCH_DAYS", "30")
+	t.Setenv("STALE_BRANCH_DELETE", "true")
+	t.Setenv("STALE_BRANCH_PROTECTED", "main,env/*")
+	cfg = Load()
+	assert.Equal(t, 30, cfg.StaleMR.BranchDays)
+	assert.True(t, cfg.StaleMR.BranchDelete)
+	assert.Equal(t, []string{"main", "env/*"}, cfg.StaleMR.ProtectedBranches)
+}
+
+func TestOnboardingConfig(t *testing.T) {
+	cfg := Load()
+	assert.False(t, cfg.Onboarding.Enabled)
+	assert.Empty(t, cfg.Onboarding.TemplatesFile)
+	assert.Equal(t, []string{"dev", "sandbox", "preprod", "prod"}, cfg.Onboarding.Environments)
+	assert.True(t, cfg.Onboarding.BlockApproval)
+
+	t.Setenv("ONBOARDING_CHECKLIST_ENABLED", "true")
+	t.Setenv("ONBOARDING_ENVIRONMENTS", "preprod,prod")
+	t.Setenv("ONBOARDING_BLOCK_APPROVAL", "false")
+	cfg = Load()
+	assert.True(t, cfg.Onboarding.Enabled)
+	assert.Equal(t, []string{"preprod", "prod"}, cfg.Onboarding.Environments)
+	assert.False(t, cfg.Onboarding.BlockApproval)
+}
+
+func TestJobsConfig(t *testing.T) {
+	cfg := Load()
+	assert.False(t, cfg.Jobs.Enabled)
+	assert.Equal(t, 4, cfg.Jobs.Workers)
+	assert.Equal(t, 100, cfg.Jobs.QueueSize)
+	assert.Equal(t, 60, cfg.Jobs.RetentionMinutes)
+
+	t.Setenv("JOB_QUEUE_ENABLED", "true")
+	t.Setenv("JOB_QUEUE_WORKERS", "8")
+	t.Setenv("JOB_QUEUE_SIZE", "500")
+	t.Setenv("JOB_RETENTION_MINUTES", "15")
+	cfg = Load()
+	assert.True(t, cfg.Jobs.Enabled)
+	assert.Equal(t, 8, cfg.Jobs.Workers)
+	assert.Equal(t, 500, cfg.Jobs.QueueSize)
+	assert.Equal(t, 15, cfg.Jobs.RetentionMinutes)
+}
+
+func TestArchiveConfig(t *testing.T) {
+	cfg := Load()
+	assert.False(t, cfg.Archive.Enabled)
+	assert.Equal(t, 7, cfg.Archive.RetentionDays)
+	assert.Equal(t, []string{"token", "secret", "password", "authorization", "private_key"}, cfg.Archive.ScrubKeys)
+	assert.True(t, cfg.Archive.ScrubEmails)
+	assert.True(t, cfg.Archive.ScrubCommitBodies)
+
+	t.Setenv("PAYLOAD_ARCHIVE_ENABLED", "true")
+	t.Setenv("PAYLOAD_ARCHIVE_RETENTION_DAYS", "3")
+	t.Setenv("PAYLOAD_ARCHIVE_SCRUB_KEYS", "token, ssn")
+	t.Setenv("PAYLOAD_ARCHIVE_SCRUB_EMAILS", "false")
+	t.Setenv("PAYLOAD_ARCHIVE_SCRUB_COMMIT_BODIES", "false")
+	cfg = Load()
+	assert.True(t, cfg.Archive.Enabled)
+	assert.Equal(t, 3, cfg.Archive.RetentionDays)
+	assert.Equal(t, []string{"token", "ssn"}, cfg.Archive.ScrubKeys)
+	assert.False(t, cfg.Archive.ScrubEmails)
+	assert.False(t, cfg.Archive.ScrubCommitBodies)
+}
+
+func TestSelfTestConfig(t *testing.T) {
+	cfg := Load()
+	assert.Equal(t, 0, cfg.SelfTest.ProjectID)
+	assert.Equal(t, "main", cfg.SelfTest.TargetBranch)
+	assert.Empty(t, cfg.SelfTest.WebhookURL)
+	assert.Empty(t, cfg.SelfTest.Token)
+	assert.Equal(t, 120, cfg.SelfTest.TimeoutSeconds)
+
+	t.Setenv("SELFTEST_PROJECT_ID", "321")
+	t.Setenv("SELFTEST_TARGET_BRANCH", "develop")
+	t.Setenv("SELFTEST_WEBHOOK_URL", "https://naysayer.example.com/dataverse-product-config-review")
+	t.Setenv("SELFTEST_GITLAB_TOKEN", "author-token")
+	t.Setenv("SELFTEST_TIMEOUT_SECONDS", "30")
+	cfg = Load()
+	assert.Equal(t, 321, cfg.SelfTest.ProjectID)
+	assert.Equal(t, "develop", cfg.SelfTest.TargetBranch)
+	assert.Equal(t, "https://naysayer.example.com/dataverse-product-config-review", cfg.SelfTest.WebhookURL)
+	assert.Equal(t, "author-token", cfg.SelfTest.Token)
+	assert.Equal(t, 30, cfg.SelfTest.TimeoutSeconds)
+}
+
+func TestServiceAccountElevatedRoles(t *testing.T) {
+	cfg := Load()
+	assert.Equal(t, []string{"accountadmin", "orgadmin", "securityadmin", "sysadmin", "useradmin"}, cfg.Rules.ServiceAccountRule.ElevatedRoles)
+
+	t.Setenv("SA_ELEVATED_ROLES", "sysadmin, owner")
+	cfg = Load()
+	assert.Equal(t, []string{"sysadmin", "owner"}, cfg.Rules.ServiceAccountRule.ElevatedRoles)
+}
+
+func TestSourceBindingRuleConfig(t *testing.T) {
+	cfg := Load()
+	assert.Equal(t, []string{"select", "references", "usage"}, cfg.Rules.SourceBindingRule.AllowedPrivileges)
+
+	t.Setenv("SOURCEBINDING_ALLOWED_PRIVILEGES", "select")
+	cfg = Load()
+	assert.Equal(t, []string{"select"}, cfg.Rules.SourceBindingRule.AllowedPrivileges)
+}
+
+func TestWarehouseCostConfig(t *testing.T) {
+	cfg := Load()
+	assert.Empty(t, cfg.Rules.WarehouseRule.CreditsPerHour)
+	assert.Equal(t, 730.0, cfg.Rules.WarehouseRule.HoursPerMonth)
+	assert.Equal(t, 3.0, cfg.Rules.WarehouseRule.CreditPrice)
+	assert.Equal(t, "USD", cfg.Rules.WarehouseRule.CostCurrency)
+	assert.Equal(t, 0.0, cfg.Rules.WarehouseRule.CostReviewThreshold)
+
+	t.Setenv("WAREHOUSE_CREDITS_PER_HOUR", "xsmall=1.5, LARGE=10, MEDIUM, SMALL=abc")
+	t.Setenv("WAREHOUSE_HOURS_PER_MONTH", "200")
+	t.Setenv("WAREHOUSE_CREDIT_PRICE", "2.5")
+	t.Setenv("WAREHOUSE_COST_CURRENCY", "EUR")
+	t.Setenv("WAREHOUSE_COST_REVIEW_THRESHOLD", "5000")
+	cfg = Load()
+	assert.Equal(t, map[string]float64{"XSMALL": 1.5, "LARGE": 10}, cfg.Rules.WarehouseRule.CreditsPerHour)
+	assert.Equal(t, 200.0, cfg.Rules.WarehouseRule.HoursPerMonth)
+	assert.Equal(t, 2.5, cfg.Rules.WarehouseRule.CreditPrice)
+	assert.Equal(t, "EUR", cfg.Rules.WarehouseRule.CostCurrency)
+	assert.Equal(t, 5000.0, cfg.Rules.WarehouseRule.CostReviewThreshold)
+}
+
+func TestWarehouseSizePolicyConfig(t *testing.T) {
+	cfg := Load()
+	assert.Empty(t, cfg.Rules.WarehouseRule.MaxSizes)
+	assert.Equal(t, 2, cfg.Rules.WarehouseRule.MaxSizeSteps)
+
+	t.Setenv("WAREHOUSE_MAX_SIZES", "Sandbox=small, dev = MEDIUM, preprod, =LARGE")
+	t.Setenv("WAREHOUSE_MAX_SIZE_STEPS", "0")
+	cfg = Load()
+	assert.Equal(t, map[string]string{"sandbox": "SMALL", "dev": "MEDIUM"}, cfg.Rules.WarehouseRule.MaxSizes)
+	assert.Equal(t, 0, cfg.Rules.WarehouseRule.MaxSizeSteps)
+}
+
+func TestWarehouseResizePolicyConfig(t *testing.T) {
+	cfg := Load()
+	assert.Equal(t, "review_all", cfg.Rules.WarehouseRule.ResizePolicy)
+	assert.Empty(t, cfg.Rules.WarehouseRule.ResizePolicies)
+
+	t.Setenv("WAREHOUSE_RESIZE_POLICY", "Approve_Decreases")
+	t.Setenv("WAREHOUSE_RESIZE_POLICIES", "prod=review_all, Tier:Test = APPROVE_DECREASES, dev, =review_all")
+	cfg = Load()
+	assert.Equal(t, "approve_decreases", cfg.Rules.WarehouseRule.ResizePolicy)
+	assert.Equal(t, map[string]string{"prod": "review_all", "tier:test": "approve_decreases"}, cfg.Rules.WarehouseRule.ResizePolicies)
+}
+
+func TestOwnersConfig(t *testing.T) {
+	cfg := Load()
+	assert.False(t, cfg.Owners.Enabled)
+	assert.Equal(t, []string{"OWNERS", "owners.yaml"}, cfg.Owners.Files)
+	assert.False(t, cfg.Owners.AssignReviewers)
+
+	t.Setenv("OWNERS_ENABLED", "true")
+	t.Setenv("OWNERS_FILES", ".gitlab/owners.yaml")
+	t.Setenv("OWNERS_ASSIGN_REVIEWERS", "true")
+	cfg = Load()
+	assert.True(t, cfg.Owners.Enabled)
+	assert.Equal(t, []string{".gitlab/owners.yaml"}, cfg.Owners.Files)
+	assert.True(t, cfg.Owners.AssignReviewers)
+}
+
+func TestMaintenanceConfig(t *testing.T) {
+	cfg := Load()
+	assert.Empty(t, cfg.AutoRebase.Schedules)
+	assert.Empty(t, cfg.StaleMR.Schedules)
+
+	assert.Equal(t, []string{"keep-open", "pinned"}, cfg.StaleMR.ExemptLabels)
+	assert.Equal(t, 0, cfg.StaleMR.DraftClosureDays)
+	assert.False(t, cfg.AutoRebase.SkipDrafts)
+	assert.Equal(t, "no-auto-rebase", cfg.AutoRebase.OptOutLabel)
+	assert.Equal(t, 0, cfg.AutoRebase.MaxRebasesPerPush)
+	assert.Equal(t, RebaseOrderOldestFirst, cfg.AutoRebase.RebaseOrder())
+	assert.Equal(t, 0, cfg.AutoRebase.CooldownMinutes)
+	assert.False(t, cfg.Approval.ReviewDrafts)
+
+	t.Setenv("STALE_MR_DRAFT_CLOSURE_DAYS", "90")
+	t.Setenv("AUTO_REBASE_SKIP_DRAFTS", "true")
+	t.Setenv("AUTO_REBASE_OPT_OUT_LABEL", " keep-branch ")
+	t.Setenv("AUTO_REBASE_MAX_PER_PUSH", "5")
+	t.Setenv("AUTO_REBASE_ORDER", "label_priority")
+	t.Setenv("AUTO_REBASE_PRIORITY_LABELS", "release,hotfix")
+	t.Setenv("AUTO_REBASE_COOLDOWN_MINUTES", "30")
+	t.Setenv("REVIEW_DRAFT_MRS", "true")
+
+	t.Setenv("STALE_MR_EXEMPT_LABELS", "do-not-close")
+	t.Setenv("AUTO_REBASE_SCHEDULES", "123=0,30 * * * *; 456:master=@hourly;")
+	t.Setenv("STALE_MR_SCHEDULES", "123=0 3 * * 1-5")
+	cfg = Load()
+	assert.Equal(t, []string{"123=0,30 * * * *", "456:master=@hourly"}, cfg.AutoRebase.Schedules)
+	assert.Equal(t, []string{"123=0 3 * * 1-5"}, cfg.StaleMR.Schedules)
+	assert.Equal(t, []string{"do-not-close"}, cfg.StaleMR.ExemptLabels)
+	assert.Equal(t, 90, cfg.StaleMR.DraftClosureDays)
+	assert.True(t, cfg.AutoRebase.SkipDrafts)
+	assert.Equal(t, "keep-branch", cfg.AutoRebase.OptOutLabel)
+	assert.Equal(t, 5, cfg.AutoRebase.MaxRebasesPerPush)
+	assert.Equal(t, RebaseOrderLabelPriority, cfg.AutoRebase.RebaseOrder())
+	assert.Equal(t, []string{"release", "hotfix"}, cfg.AutoRebase.PriorityLabels)
+	assert.Equal(t, 30, cfg.AutoRebase.CooldownMinutes)
+	assert.True(t, cfg.Approval.ReviewDrafts)
+}
+
 func TestConfigStructs_FieldsExist(t *testing.T) {
 	// Test that all expected fields exist and have correct types
 	config := &Config{}
@@ -463,3 +751,436 @@ func TestConfigIntegration_RealWorldScenarios(t *testing.T) {
 		})
 	}
 }
+
+func TestWebhookEndpointSecrets(t *testing.T) {
+	t.Setenv("WEBHOOK_SECRET", "shared-secret")
+	t.Setenv("WEBHOOK_SECRET_AUTO_REBASE", "rebase-secret")
+	t.Setenv("WEBHOOK_SECRET_REVIEW", "")
+
+	config := Load()
+	assert.Equal(t, map[string]string{EndpointAutoRebase: "rebase-secret"}, config.Webhook.EndpointSecrets)
+	assert.Equal(t, "rebase-secret", config.Webhook.SecretFor(EndpointAutoRebase))
+	assert.Equal(t, "shared-secret", config.Webhook.SecretFor(EndpointReview), "endpoints without an override use WEBHOOK_SECRET")
+	assert.Equal(t, "shared-secret", config.Webhook.SecretFor(EndpointStaleMRCleanup))
+
+	t.Setenv("WEBHOOK_SECRET", "")
+	config = Load()
+	assert.True(t, config.HasWebhookSecret(), "a single endpoint secret enables verification")
+	assert.Equal(t, "", config.Webhook.SecretFor(EndpointReview))
+}
+
+func TestGitLabRetryConfig(t *testing.T) {
+	cfg := Load()
+	assert.Equal(t, 3, cfg.GitLab.MaxRetries)
+	assert.Equal(t, 500, cfg.GitLab.RetryBackoffMs)
+	assert.Equal(t, 10.0, cfg.GitLab.RateLimit)
+
+	t.Setenv("GITLAB_MAX_RETRIES", "0")
+	t.Setenv("GITLAB_RETRY_BACKOFF_MS", "250")
+	t.Setenv("GITLAB_RATE_LIMIT", "2.5")
+	cfg = Load()
+	assert.Equal(t, 0, cfg.GitLab.MaxRetries)
+	assert.Equal(t, 250, cfg.GitLab.RetryBackoffMs)
+	assert.Equal(t, 2.5, cfg.GitLab.RateLimit)
+}
+
+func TestGitLabFor(t *testing.T) {
+	t.Setenv("GITLAB_TOKEN", "main-token")
+	t.Setenv("GITLAB_TOKEN_FILE", "/var/run/secrets/gitlab/token")
+	cfg := Load()
+	for _, function := range []string{EndpointReview, EndpointAutoRebase, EndpointStaleMRCleanup} {
+		gitlabConfig := cfg.GitLabFor(function)
+		assert.Equal(t, "main-token", gitlabConfig.Token, function)
+		assert.Equal(t, "/var/run/secrets/gitlab/token", gitlabConfig.TokenFile, function)
+	}
+
+	t.Setenv("GITLAB_TOKEN_REVIEW", "review-token")
+	t.Setenv("AUTO_REBASE_REPOSITORY_TOKEN", "rebase-token")
+	t.Setenv("GITLAB_TOKEN_STALE_MR", "cleanup-token")
+	t.Setenv("GITLAB_BOT_USERNAMES", "naysayer-review, naysayer-rebase")
+	cfg = Load()
+	assert.Equal(t, []string{"naysayer-review", "naysayer-rebase"}, cfg.GitLab.BotUsernames)
+	for function, token := range map[string]string{
+		EndpointReview:         "review-token",
+		EndpointAutoRebase:     "rebase-token",
+		EndpointStaleMRCleanup: "cleanup-token",
+		"unknown":              "main-token",
+	} {
+		gitlabConfig := cfg.GitLabFor(function)
+		assert.Equal(t, token, gitlabConfig.Token, function)
+		assert.Equal(t, cfg.GitLab.BotUsernames, gitlabConfig.BotUsernames, function)
+	}
+	assert.Empty(t, cfg.GitLabFor(EndpointReview).TokenFile, "dedicated tokens replace the token file")
+}
+
+func TestParseBotIdentities(t *testing.T) {
+	identities := parseBotIdentities("*:naysayer-review:101, 123:project_123_bot_4f2a:456, 789:project_789_bot_9c1d, bad, 0:x, 5::1, 6:y:z, *:a:b:c")
+	assert.Equal(t, []BotIdentity{
+		{Username: "naysayer-review", UserID: 101},
+		{ProjectID: 123, Username: "project_123_bot_4f2a", UserID: 456},
+		{ProjectID: 789, Username: "project_789_bot_9c1d"},
+	}, identities)
+
+	assert.False(t, GitLabConfig{}.HasBotIdentities())
+	assert.True(t, GitLabConfig{BotIdentities: identities}.HasBotIdentities())
+
+	t.Setenv("GITLAB_BOT_IDENTITIES", "123:project_123_bot_4f2a:456")
+	assert.Equal(t, []BotIdentity{{ProjectID: 123, Username: "project_123_bot_4f2a", UserID: 456}}, Load().GitLab.BotIdentities)
+}
+
+func TestGitLabDetailConcurrencyConfig(t *testing.T) {
+	cfg := Load()
+	assert.Equal(t, 8, cfg.GitLab.DetailConcurrency)
+
+	t.Setenv("GITLAB_DETAIL_CONCURRENCY", "2")
+	cfg = Load()
+	assert.Equal(t, 2, cfg.GitLab.DetailConcurrency)
+}
+
+func TestGitLabMRSizeLimitConfig(t *testing.T) {
+	cfg := Load()
+	assert.Equal(t, 2000, cfg.GitLab.MaxMRFiles)
+	assert.Equal(t, 50*1024*1024, cfg.GitLab.MaxMRDiffBytes)
+
+	t.Setenv("GITLAB_MAX_MR_FILES", "10")
+	t.Setenv("GITLAB_MAX_MR_DIFF_BYTES", "0")
+	cfg = Load()
+	assert.Equal(t, 10, cfg.GitLab.MaxMRFiles)
+	assert.Equal(t, 0, cfg.GitLab.MaxMRDiffBytes)
+}
+
+func TestGitLabFileCacheConfig(t *testing.T) {
+	cfg := Load()
+	assert.Equal(t, 1000, cfg.GitLab.FileCacheSize)
+	assert.Equal(t, 300, cfg.GitLab.FileCacheTTLSeconds)
+
+	t.Setenv("GITLAB_FILE_CACHE_SIZE", "0")
+	t.Setenv("GITLAB_FILE_CACHE_TTL_SECONDS", "60")
+	cfg = Load()
+	assert.Equal(t, 0, cfg.GitLab.FileCacheSize)
+	assert.Equal(t, 60, cfg.GitLab.FileCacheTTLSeconds)
+}
+
+func TestGitLabETagCacheConfig(t *testing.T) {
+	assert.Equal(t, 500, Load().GitLab.ETagCacheSize)
+
+	t.Setenv("GITLAB_ETAG_CACHE_SIZE", "0")
+	assert.Equal(t, 0, Load().GitLab.ETagCacheSize)
+}
+
+func TestGitLabTimeoutAndCircuitBreakerConfig(t *testing.T) {
+	cfg := Load()
+	assert.Equal(t, 10, cfg.GitLab.ConnectTimeoutSeconds)
+	assert.Equal(t, 60, cfg.GitLab.RequestTimeoutSeconds)
+	assert.Equal(t, 5, cfg.GitLab.CircuitBreakerThreshold)
+	assert.Equal(t, 30, cfg.GitLab.CircuitBreakerCooldownSeconds)
+
+	t.Setenv("GITLAB_CONNECT_TIMEOUT_SECONDS", "2")
+	t.Setenv("GITLAB_REQUEST_TIMEOUT_SECONDS", "15")
+	t.Setenv("GITLAB_CIRCUIT_BREAKER_THRESHOLD", "0")
+	t.Setenv("GITLAB_CIRCUIT_BREAKER_COOLDOWN_SECONDS", "120")
+	cfg = Load()
+	assert.Equal(t, 2, cfg.GitLab.ConnectTimeoutSeconds)
+	assert.Equal(t, 15, cfg.GitLab.RequestTimeoutSeconds)
+	assert.Equal(t, 0, cfg.GitLab.CircuitBreakerThreshold)
+	assert.Equal(t, 120, cfg.GitLab.CircuitBreakerCooldownSeconds)
+}
+
+func TestProjectFilterConfig(t *testing.T) {
+	cfg := Load()
+	assert.False(t, cfg.Projects.Configured())
+
+	t.Setenv("PROJECT_ALLOWLIST", "^data/(config|etl)-[a-z]{2,4}$; 42")
+	t.Setenv("PROJECT_DENYLIST", "^data/archive/")
+	t.Setenv("PROJECT_SUBSYSTEMS", "^data/etl-=review,auto-rebase;42=none")
+	cfg = Load()
+	assert.True(t, cfg.Projects.Configured())
+	assert.Equal(t, []string{"^data/(config|etl)-[a-z]{2,4}$", "42"}, cfg.Projects.Allow)
+	assert.Equal(t, []string{"^data/archive/"}, cfg.Projects.Deny)
+	assert.Equal(t, []string{"^data/etl-=review,auto-rebase", "42=none"}, cfg.Projects.Subsystems)
+}
+
+func TestGitLabInstancesConfig(t *testing.T) {
+	assert.Empty(t, Load().GitLab.Instances)
+
+	t.Setenv("GITLAB_INSTANCES", "onprem, Lab-Two, onprem, default")
+	t.Setenv("GITLAB_INSTANCE_ONPREM_URL", "https://gitlab.example.com")
+	t.Setenv("GITLAB_INSTANCE_ONPREM_TOKEN", "onprem-token")
+	t.Setenv("GITLAB_INSTANCE_ONPREM_INSECURE_TLS", "true")
+	t.Setenv("GITLAB_INSTANCE_ONPREM_WEBHOOK_SECRET", "onprem-secret")
+	t.Setenv("WEBHOOK_SECRET", "default-secret")
+	t.Setenv("WEBHOOK_SECRET_REVIEW", "default-review-secret")
+	t.Setenv("GITLAB_INSTANCE_LAB_TWO_URL", "https://lab.example.com/gitlab")
+	t.Setenv("GITLAB_INSTANCE_LAB_TWO_TOKEN_FILE", "/var/run/secrets/lab-token")
+	t.Setenv("GITLAB_INSTANCE_LAB_TWO_CA_CERT_PATH", "/etc/ssl/lab.pem")
+	t.Setenv("GITLAB_TOKEN_REVIEW", "review-token")
+	cfg := Load()
+	assert.Equal(t, []GitLabInstance{
+		{Name: "onprem", BaseURL: "https://gitlab.example.com", Token: "onprem-token", InsecureTLS: true, WebhookSecret: "onprem-secret"},
+		{Name: "lab-two", BaseURL: "https://lab.example.com/gitlab", TokenFile: "/var/run/secrets/lab-token", CACertPath: "/etc/ssl/lab.pem"},
+	}, cfg.GitLab.Instances)
+
+	instanceCfg, ok := cfg.ForGitLabInstance("onprem")
+	assert.True(t, ok)
+	assert.Equal(t, "onprem", instanceCfg.GitLab.Instance)
+	assert.Equal(t, "https://gitlab.example.com", instanceCfg.GitLab.BaseURL)
+	assert.Equal(t, "onprem-token", instanceCfg.GitLabFor(EndpointReview).Token)
+	assert.Empty(t, instanceCfg.GitLab.Instances)
+	assert.Equal(t, "onprem-secret", instanceCfg.Webhook.SecretFor(EndpointReview), "instances verify deliveries with their own secret")
+	assert.Equal(t, "review-token", cfg.GitLabFor(EndpointReview).Token, "the default config is unchanged")
+	assert.Equal(t, "default-review-secret", cfg.Webhook.SecretFor(EndpointReview))
+
+	_, ok = cfg.ForGitLabInstance("unknown")
+	assert.False(t, ok)
+}
+
+func TestTokenSourceConfig(t *testing.T) {
+	cfg := Load()
+	assert.Equal(t, 60, cfg.GitLab.TokenReloadSeconds)
+	assert.Equal(t, 7, cfg.GitLab.TokenExpiryWarningDays)
+	assert.Empty(t, cfg.GitLab.TokenSource)
+
+	t.Setenv("GITLAB_TOKEN", "")
+	t.Setenv("GITLAB_TOKEN_SOURCE", "vault:secret/data/naysayer#gitlab_token")
+	t.Setenv("GITLAB_TOKEN_RELOAD_SECONDS", "0")
+	t.Setenv("GITLAB_TOKEN_EXPIRY_WARNING_DAYS", "14")
+	t.Setenv("VAULT_ADDR", "https://vault.example.com")
+	t.Setenv("VAULT_TOKEN_FILE", "/vault/agent/token")
+	t.Setenv("VAULT_NAMESPACE", "data-team")
+	t.Setenv("GITLAB_TOKEN_REVIEW", "review-token")
+	t.Setenv("GITLAB_INSTANCES", "onprem")
+	t.Setenv("GITLAB_INSTANCE_ONPREM_TOKEN_SOURCE", "command:onprem-token")
+	cfg = Load()
+	assert.True(t, cfg.HasGitLabToken())
+	assert.Equal(t, 0, cfg.GitLab.TokenReloadSeconds)
+	assert.Equal(t, 14, cfg.GitLab.TokenExpiryWarningDays)
+	assert.Equal(t, VaultConfig{Addr: "https://vault.example.com", TokenFile: "/vault/agent/token", Namespace: "data-team"}, cfg.GitLab.Vault)
+	assert.Equal(t, "vault:secret/data/naysayer#gitlab_token", cfg.GitLabFor(EndpointStaleMRCleanup).TokenSource)
+	assert.Empty(t, cfg.GitLabFor(EndpointReview).TokenSource, "dedicated tokens replace the token source")
+
+	instanceCfg, ok := cfg.ForGitLabInstance("onprem")
+	assert.True(t, ok)
+	assert.Equal(t, "command:onprem-token", instanceCfg.GitLab.TokenSource)
+}
+
+func TestGitLabOAuthConfig(t *testing.T) {
+	cfg := Load()
+	assert.False(t, cfg.GitLab.OAuth.Configured())
+	assert.Equal(t, []string{"api"}, cfg.GitLab.OAuth.Scopes)
+
+	t.Setenv("GITLAB_TOKEN", "")
+	t.Setenv("GITLAB_OAUTH_CLIENT_ID", "app")
+	t.Setenv("GITLAB_OAUTH_CLIENT_SECRET", "secret")
+	t.Setenv("GITLAB_OAUTH_GRANT_TYPE", "client_credentials")
+	t.Setenv("GITLAB_OAUTH_REFRESH_TOKEN_FILE", "/var/lib/naysayer/refresh-token")
+	t.Setenv("GITLAB_OAUTH_SCOPES", "api,read_repository")
+	t.Setenv("GITLAB_OAUTH_TOKEN_URL", "https://sso.example.com/oauth/token")
+	t.Setenv("GITLAB_TOKEN_STALE_MR", "stale-token")
+	t.Setenv("GITLAB_INSTANCES", "onprem")
+	cfg = Load()
+	assert.True(t, cfg.HasGitLabToken())
+	assert.Equal(t, GitLabOAuthConfig{
+		ClientID:         "app",
+		ClientSecret:     "secret",
+		GrantType:        "client_credentials",
+		RefreshTokenFile: "/var/lib/naysayer/refresh-token",
+		Scopes:           []string{"api", "read_repository"},
+		TokenURL:         "https://sso.example.com/oauth/token",
+	}, cfg.GitLab.OAuth)
+	assert.True(t, cfg.GitLabFor(EndpointReview).OAuth.Configured())
+	assert.False(t, cfg.GitLabFor(EndpointStaleMRCleanup).OAuth.Configured(), "dedicated tokens replace the OAuth application")
+
+	instanceCfg, ok := cfg.ForGitLabInstance("onprem")
+	assert.True(t, ok)
+	assert.False(t, instanceCfg.GitLab.OAuth.Configured(), "the OAuth application belongs to the default instance")
+}
+
+func TestRebaseVerifyConfig(t *testing.T) {
+	cfg := Load()
+	assert.Equal(t, 60, cfg.GitLab.RebaseVerifyTimeoutSeconds)
+	assert.Equal(t, 2, cfg.GitLab.RebaseVerifyIntervalSeconds)
+
+	t.Setenv("AUTO_REBASE_VERIFY_TIMEOUT_SECONDS", "300")
+	t.Setenv("AUTO_REBASE_VERIFY_INTERVAL_SECONDS", "5")
+	cfg = Load()
+	assert.Equal(t, 300, cfg.GitLab.RebaseVerifyTimeoutSeconds)
+	assert.Equal(t, 5, cfg.GitLab.RebaseVerifyIntervalSeconds)
+}
+
+func TestGitLabAPIVersionConfig(t *testing.T) {
+	assert.Equal(t, "v4", Load().GitLab.APIVersion)
+
+	t.Setenv("GITLAB_API_VERSION", "v5")
+	assert.Equal(t, "v5", Load().GitLab.APIVersion)
+}
+
+func TestAuditConfig(t *testing.T) {
+	cfg := Load()
+	assert.Equal(t, "", cfg.Audit.Sink)
+	assert.Equal(t, "naysayer-audit.log", cfg.Audit.FilePath)
+	assert.Equal(t, 100, cfg.Audit.MaxSizeMB)
+	assert.Equal(t, 5, cfg.Audit.MaxBackups)
+
+	t.Setenv("AUDIT_LOG_SINK", "file")
+	t.Setenv("AUDIT_LOG_FILE", "/var/log/naysayer/audit.log")
+	t.Setenv("AUDIT_LOG_MAX_SIZE_MB", "10")
+	t.Setenv("AUDIT_LOG_MAX_BACKUPS", "2")
+	cfg = Load()
+	assert.Equal(t, "file", cfg.Audit.Sink)
+	assert.Equal(t, "/var/log/naysayer/audit.log", cfg.Audit.FilePath)
+	assert.Equal(t, 10, cfg.Audit.MaxSizeMB)
+	assert.Equal(t, 2, cfg.Audit.MaxBackups)
+}
+
+func TestHistoryConfig(t *testing.T) {
+	cfg := Load()
+	assert.False(t, cfg.History.Enabled)
+	assert.Equal(t, "naysayer-history.db", cfg.History.DSN)
+
+	t.Setenv("HISTORY_ENABLED", "true")
+	t.Setenv("HISTORY_DSN", "postgres://naysayer@db:5432/naysayer")
+	cfg = Load()
+	assert.True(t, cfg.History.Enabled)
+	assert.Equal(t, "postgres://naysayer@db:5432/naysayer", cfg.History.DSN)
+}
+
+func TestChatOpsConfig(t *testing.T) {
+	cfg := Load()
+	assert.False(t, cfg.ChatOps.Enabled)
+	assert.True(t, cfg.ChatOps.AllowAuthor)
+	assert.Empty(t, cfg.ChatOps.AllowedUsers)
+	assert.Equal(t, 30, cfg.ChatOps.MinAccessLevel)
+	assert.Empty(t, cfg.ChatOps.OverrideApprovers)
+
+	t.Setenv("CHATOPS_ENABLED", "true")
+	t.Setenv("CHATOPS_ALLOW_AUTHOR", "false")
+	t.Setenv("CHATOPS_ALLOWED_USERS", "alice, bob")
+	t.Setenv("CHATOPS_MIN_ACCESS_LEVEL", "40")
+	t.Setenv("CHATOPS_OVERRIDE_APPROVERS", "carol")
+	cfg = Load()
+	assert.True(t, cfg.ChatOps.Enabled)
+	assert.False(t, cfg.ChatOps.AllowAuthor)
+	assert.Equal(t, []string{"alice", "bob"}, cfg.ChatOps.AllowedUsers)
+	assert.Equal(t, 40, cfg.ChatOps.MinAccessLevel)
+	assert.Equal(t, []string{"carol"}, cfg.ChatOps.OverrideApprovers)
+}
+
+func TestCommitStatusConfig(t *testing.T) {
+	cfg := Load()
+	assert.False(t, cfg.CommitStatus.Enabled)
+	assert.Equal(t, "naysayer/review", cfg.CommitStatus.Name)
+
+	t.Setenv("COMMIT_STATUS_ENABLED", "true")
+	t.Setenv("COMMIT_STATUS_NAME", "policy/naysayer")
+	cfg = Load()
+	assert.True(t, cfg.CommitStatus.Enabled)
+	assert.Equal(t, "policy/naysayer", cfg.CommitStatus.Name)
+}
+
+func TestAutoMergeConfig(t *testing.T) {
+	cfg := Load()
+	assert.False(t, cfg.AutoMerge.Enabled)
+	assert.Equal(t, "automerge", cfg.AutoMerge.Label)
+
+	t.Setenv("AUTO_MERGE_ENABLED", "true")
+	t.Setenv("AUTO_MERGE_LABEL", "ship-it")
+	cfg = Load()
+	assert.True(t, cfg.AutoMerge.Enabled)
+	assert.Equal(t, "ship-it", cfg.AutoMerge.Label)
+}
+
+func TestSystemHookConfig(t *testing.T) {
+	cfg := Load()
+	assert.False(t, cfg.SystemHook.Enabled)
+	assert.Empty(t, cfg.SystemHook.ProjectPatterns)
+
+	t.Setenv("SYSTEM_HOOK_ENABLED", "true")
+	t.Setenv("SYSTEM_HOOK_PROJECT_PATTERNS", "dataverse/*, platform/dataverse-*")
+	t.Setenv("WEBHOOK_SECRET_SYSTEM_HOOK", "system-secret")
+	cfg = Load()
+	assert.True(t, cfg.SystemHook.Enabled)
+	assert.Equal(t, []string{"dataverse/*", "platform/dataverse-*"}, cfg.SystemHook.ProjectPatterns)
+	assert.Equal(t, "system-secret", cfg.Webhook.SecretFor(EndpointSystemHook))
+}
+
+func TestDashboardConfig(t *testing.T) {
+	cfg := Load()
+	assert.False(t, cfg.Dashboard.Enabled)
+	assert.Empty(t, cfg.Dashboard.Token)
+
+	t.Setenv("DASHBOARD_ENABLED", "true")
+	t.Setenv("DASHBOARD_TOKEN", "dashboard-secret")
+	cfg = Load()
+	assert.True(t, cfg.Dashboard.Enabled)
+	assert.Equal(t, "dashboard-secret", cfg.Dashboard.Token)
+}
+
+func TestNotifyRoutingConfig(t *testing.T) {
+	cfg := Load()
+	assert.Empty(t, cfg.Notify.Channels)
+	assert.Empty(t, cfg.Notify.Routes)
+	assert.False(t, cfg.Notify.Routed("manual_review"))
+
+	t.Setenv("NOTIFY_CHANNELS", "team=slack:https://hooks.slack.com/services/T/B/x; ops = GoogleChat:https://chat.googleapis.com/v1/spaces/S/messages?key=k&token=t;bad=email:x;nourl=slack:")
+	t.Setenv("NOTIFY_ROUTES", "manual_review=team,ops; stale_mr_closed=ops;broken")
+	t.Setenv("NOTIFY_TEMPLATE_MANUAL_REVIEW", "MR !{{.MRIID}} needs review")
+	cfg = Load()
+	assert.Equal(t, map[string]NotifyChannel{
+		"team": {Kind: NotifyChannelSlack, URL: "https://hooks.slack.com/services/T/B/x"},
+		"ops":  {Kind: NotifyChannelGoogleChat, URL: "https://chat.googleapis.com/v1/spaces/S/messages?key=k&token=t"},
+	}, cfg.Notify.Channels)
+	assert.Equal(t, map[string][]string{"manual_review": {"team", "ops"}, "stale_mr_closed": {"ops"}}, cfg.Notify.Routes)
+	assert.Equal(t, "MR !{{.MRIID}} needs review", cfg.Notify.Templates["manual_review"])
+	assert.True(t, cfg.Notify.Routed("manual_review"))
+	assert.False(t, cfg.Notify.Routed("rebase_conflict"))
+
+	t.Setenv("NOTIFY_ROUTES", "*=ops")
+	assert.True(t, Load().Notify.Routed("rebase_conflict"))
+}
+
+func TestDigestConfig(t *testing.T) {
+	cfg := Load()
+	assert.False(t, cfg.SMTP.Enabled())
+	assert.Equal(t, 587, cfg.SMTP.Port)
+	assert.Empty(t, cfg.Digest.Recipients)
+	assert.Equal(t, 8, cfg.Digest.Hour)
+
+	t.Setenv("SMTP_HOST", "smtp.example.com")
+	t.Setenv("SMTP_PORT", "25")
+	t.Setenv("SMTP_FROM", "naysayer@example.com")
+	t.Setenv("DIGEST_RECIPIENTS", "data-reviewers@example.com, platform@example.com")
+	t.Setenv("DIGEST_HOUR", "6")
+	cfg = Load()
+	assert.True(t, cfg.SMTP.Enabled())
+	assert.Equal(t, 25, cfg.SMTP.Port)
+	assert.Equal(t, []string{"data-reviewers@example.com", "platform@example.com"}, cfg.Digest.Recipients)
+	assert.Equal(t, 6, cfg.Digest.Hour)
+}
+
+func TestTracingConfig(t *testing.T) {
+	cfg := Load()
+	assert.False(t, cfg.Tracing.Enabled())
+	assert.Equal(t, "naysayer", cfg.Tracing.ServiceName)
+	assert.Equal(t, 1.0, cfg.Tracing.SampleRatio)
+
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://otel-collector:4318/")
+	t.Setenv("OTEL_EXPORTER_OTLP_HEADERS", "Authorization=Bearer%20collector-token, X-Scope-OrgID=data ,malformed")
+	t.Setenv("OTEL_SERVICE_NAME", "naysayer-prod")
+	t.Setenv("OTEL_TRACES_SAMPLER_ARG", "0.25")
+	cfg = Load()
+	assert.Equal(t, TracingConfig{
+		Endpoint:    "http://otel-collector:4318/v1/traces",
+		Headers:     map[string]string{"Authorization": "Bearer collector-token", "X-Scope-OrgID": "data"},
+		ServiceName: "naysayer-prod",
+		SampleRatio: 0.25,
+	}, cfg.Tracing)
+
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "https://traces.example.com/otlp/v1/traces")
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_HEADERS", "Authorization=Basic dXNlcg==")
+	cfg = Load()
+	assert.Equal(t, "https://traces.example.com/otlp/v1/traces", cfg.Tracing.Endpoint)
+	assert.Equal(t, map[string]string{"Authorization": "Basic dXNlcg=="}, cfg.Tracing.Headers)
+
+	t.Setenv("OTEL_TRACES_EXPORTER", "none")
+	assert.False(t, Load().Tracing.Enabled())
+}
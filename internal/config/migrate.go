@@ -0,0 +1,80 @@
+package config
+
+import (
+	"os"
+	"regexp"
+)
+
+// KeyMigration maps a legacy configuration key to the key replacing it
+type KeyMigration struct {
+	Old string `json:"old"`
+	New string `json:"new"`
+}
+
+// LegacyKeys are renamed configuration keys. The old names are still read, with a
+// deprecation warning, until deployments are migrated with `naysayer config migrate`.
+var LegacyKeys = []KeyMigration{
+	{Old: "GITLAB_TOKEN_FIVETRAN", New: "AUTO_REBASE_REPOSITORY_TOKEN"},
+}
+
+// Deprecation is a legacy key found in the environment
+type Deprecation struct {
+	KeyMigration
+	Ignored bool `json:"ignored"` // The new key is set as well and takes precedence
+}
+
+// Deprecations returns the legacy keys set in the environment
+func Deprecations() []Deprecation {
+	var found []Deprecation
+	for _, key := range LegacyKeys {
+		if os.Getenv(key.Old) == "" {
+			continue
+		}
+		found = append(found, Deprecation{KeyMigration: key, Ignored: os.Getenv(key.New) != ""})
+	}
+	return found
+}
+
+// getEnvMigrated reads key, falling back to the legacy key it replaces
+func getEnvMigrated(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	for _, legacy := range LegacyKeys {
+		if legacy.New == key {
+			if value := os.Getenv(legacy.Old); value != "" {
+				return value
+			}
+		}
+	}
+	return defaultValue
+}
+
+// KeyChange is a legacy key rewritten (or left alone) in a config file
+type KeyChange struct {
+	KeyMigration
+	Occurrences int  `json:"occurrences"`
+	Conflict    bool `json:"conflict"` // The file already uses the new key; left for manual review
+}
+
+// MigrateText replaces legacy keys in the contents of an env file, Kubernetes manifest or
+// similar config file. Keys whose replacement already appears in the text are not rewritten,
+// since that would define the setting twice.
+func MigrateText(text string) (string, []KeyChange) {
+	var changes []KeyChange
+	for _, key := range LegacyKeys {
+		pattern := regexp.MustCompile(`\b` + regexp.QuoteMeta(key.Old) + `\b`)
+		occurrences := len(pattern.FindAllStringIndex(text, -1))
+		if occurrences == 0 {
+			continue
+		}
+		change := KeyChange{KeyMigration: key, Occurrences: occurrences}
+		if regexp.MustCompile(`\b` + regexp.QuoteMeta(key.New) + `\b`).MatchString(text) {
+			change.Conflict = true
+		} else {
+			text = pattern.ReplaceAllLiteralString(text, key.New)
+		}
+		changes = append(changes, change)
+	}
+	return text, changes
+}
@@ -0,0 +1,53 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrateText(t *testing.T) {
+	manifest := `env:
+  - name: GITLAB_TOKEN_FIVETRAN
+    valueFrom:
+      secretKeyRef:
+        name: naysayer-secrets
+        key: GITLAB_TOKEN_FIVETRAN
+  - name: GITLAB_TOKEN_FIVETRAN_BACKUP
+`
+	migrated, changes := MigrateText(manifest)
+
+	assert.Equal(t, []KeyChange{{KeyMigration: LegacyKeys[0], Occurrences: 2}}, changes)
+	assert.Contains(t, migrated, "- name: AUTO_REBASE_REPOSITORY_TOKEN\n")
+	assert.Contains(t, migrated, "key: AUTO_REBASE_REPOSITORY_TOKEN\n")
+	assert.Contains(t, migrated, "GITLAB_TOKEN_FIVETRAN_BACKUP", "longer keys are left alone")
+}
+
+func TestMigrateText_Conflict(t *testing.T) {
+	env := "GITLAB_TOKEN_FIVETRAN=old\nAUTO_REBASE_REPOSITORY_TOKEN=new\n"
+	migrated, changes := MigrateText(env)
+
+	assert.Equal(t, env, migrated)
+	assert.Len(t, changes, 1)
+	assert.True(t, changes[0].Conflict)
+}
+
+func TestMigrateText_NoLegacyKeys(t *testing.T) {
+	migrated, changes := MigrateText("AUTO_REBASE_REPOSITORY_TOKEN=new\n")
+	assert.Equal(t, "AUTO_REBASE_REPOSITORY_TOKEN=new\n", migrated)
+	assert.Empty(t, changes)
+}
+
+func TestLegacyKeys_LoadAndDeprecations(t *testing.T) {
+	t.Setenv("AUTO_REBASE_REPOSITORY_TOKEN", "")
+	t.Setenv("GITLAB_TOKEN_FIVETRAN", "legacy-token")
+
+	cfg := Load()
+	assert.Equal(t, "legacy-token", cfg.AutoRebase.RepositoryToken)
+	assert.Equal(t, []Deprecation{{KeyMigration: LegacyKeys[0]}}, cfg.Deprecations)
+
+	t.Setenv("AUTO_REBASE_REPOSITORY_TOKEN", "new-token")
+	cfg = Load()
+	assert.Equal(t, "new-token", cfg.AutoRebase.RepositoryToken)
+	assert.True(t, cfg.Deprecations[0].Ignored)
+}
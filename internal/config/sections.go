@@ -3,22 +3,46 @@ package config
 import (
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
 
+	"github.com/redhat-data-and-ai/naysayer/internal/cron"
 	"github.com/redhat-data-and-ai/naysayer/internal/utils"
 	"gopkg.in/yaml.v3"
 )
 
 // RuleConfig defines a rule with its enabled state
 type RuleConfig struct {
-	Name    string `yaml:"name"`    // Rule name (e.g., "warehouse_rule")
-	Enabled bool   `yaml:"enabled"` // Whether this rule should be executed
+	Name         string            `yaml:"name"`            // Rule name (e.g., "warehouse_rule")
+	Enabled      bool              `yaml:"enabled"`         // Whether this rule should be executed
+	Environments map[string]string `yaml:"environments"`    // Optional: behavior per file environment (approve, warn or manual_review)
+	Timeout      int               `yaml:"timeout_seconds"` // Optional: seconds one validation may take, overriding rule_timeout_seconds
+	Optional     bool              `yaml:"optional"`        // Optional: manual reviews only warn, so they cannot veto an auto_approve section
+}
+
+// EnvironmentBehavior returns the configured behavior of the rule for an environment, empty
+// when the rule decides on its own
+func (r RuleConfig) EnvironmentBehavior(environment string) string {
+	if environment == "" {
+		return ""
+	}
+	for env, behavior := range r.Environments {
+		if strings.EqualFold(env, environment) {
+			return behavior
+		}
+	}
+	return ""
 }
 
 // SectionDefinition defines how to identify and parse a section within a file
 type SectionDefinition struct {
 	Name        string       `yaml:"name"`         // Section identifier (e.g., "warehouse", "consumers")
-	YAMLPath    string       `yaml:"yaml_path"`    // YAML path to section (e.g., "spec.warehouse")
+	YAMLPath    string       `yaml:"yaml_path"`    // YAML path to section (e.g., "spec.warehouse"), dotted keys for json and toml
+	Pattern     string       `yaml:"pattern"`      // Text parser: regular expression matching the first line of the section
+	EndPattern  string       `yaml:"end_pattern"`  // Text parser: regular expression matching the last line (default: last line matching pattern)
 	Required    bool         `yaml:"required"`     // Is this section required in the file?
 	RuleConfigs []RuleConfig `yaml:"rule_configs"` // Rules with enable/disable control
 	AutoApprove bool         `yaml:"auto_approve"` // Auto-approve this section if rules pass (or no rules)
@@ -30,23 +54,211 @@ type FileRuleConfig struct {
 	Name          string              `yaml:"name"`           // Unique identifier for this file type
 	Path          string              `yaml:"path"`           // Directory path pattern (e.g., "**/" or "serviceaccounts/**/")
 	Filename      string              `yaml:"filename"`       // Filename pattern (e.g., "product.{yaml,yml}")
-	ParserType    string              `yaml:"parser_type"`    // Parser to use (yaml, json, etc.)
+	ParserType    string              `yaml:"parser_type"`    // Parser to use (yaml, json, toml or text)
 	Description   string              `yaml:"description"`    // Description of this file type
 	Enabled       bool                `yaml:"enabled"`        // Enable/disable this file type
 	DefaultAction string              `yaml:"default_action"` // Default action for unconfigured sections (manual_review, auto_approve)
 	Sections      []SectionDefinition `yaml:"sections"`       // Sections within this file type
 }
 
+// DeletionPolicy defines how deletions of files matching a pattern are handled
+type DeletionPolicy struct {
+	Name      string   `yaml:"name"`      // Unique identifier for this policy
+	Path      string   `yaml:"path"`      // Directory path pattern (e.g., "dataproducts/**/")
+	Filename  string   `yaml:"filename"`  // Filename pattern (e.g., "*masking.{yaml,yml}")
+	Action    string   `yaml:"action"`    // auto_approve, manual_review or block
+	Reviewers []string `yaml:"reviewers"` // Reviewers to involve (e.g., "@platform-team"), listed in the decision
+	Reason    string   `yaml:"reason"`    // Explanation shown in the MR comment
+}
+
+// PolicyCondition matches the result of a rule on any file of the MR
+type PolicyCondition struct {
+	Rule           string `yaml:"rule"`            // Rule name (e.g., "warehouse_rule")
+	Decision       string `yaml:"decision"`        // Optional: approve, warn or manual_review
+	ReasonContains string `yaml:"reason_contains"` // Optional: case-insensitive substring of the rule reason
+	ReasonMatches  string `yaml:"reason_matches"`  // Optional: regular expression matching the rule reason
+	Path           string `yaml:"path"`            // Optional: file pattern (e.g., "dataproducts/**/prod/product.{yaml,yml}")
+}
+
+// DecisionPolicy escalates the final decision when rule results combine in a risky way
+type DecisionPolicy struct {
+	Name      string            `yaml:"name"`      // Unique identifier for this policy
+	When      []PolicyCondition `yaml:"when"`      // All conditions must match for the policy to apply
+	Action    string            `yaml:"action"`    // manual_review or block
+	Reviewers []string          `yaml:"reviewers"` // Reviewers to involve (e.g., "@security-team"), listed in the decision
+	Reason    string            `yaml:"reason"`    // Explanation shown in the MR comment
+}
+
+// ApprovalPolicy requires human approvals before naysayer approves an MR whose rule results
+// match. Manual reviews matched by its conditions are satisfied by the approvals.
+type ApprovalPolicy struct {
+	Name      string            `yaml:"name"`      // Unique identifier for this policy
+	When      []PolicyCondition `yaml:"when"`      // All conditions must match for the policy to apply
+	Approvals int               `yaml:"approvals"` // Number of human approvals required
+	Approvers []string          `yaml:"approvers"` // GitLab usernames or group paths whose members' approvals count; empty counts any human approval
+	Reason    string            `yaml:"reason"`    // Explanation shown in the MR comment
+}
+
+// RuleSeverity sets whether the manual reviews of a rule block the MR or only warn. A warning
+// keeps the MR approved and lists the finding in the comment. The first matching entry decides.
+type RuleSeverity struct {
+	Rule           string   `yaml:"rule"`            // Rule name (e.g., "naming_rule")
+	Severity       string   `yaml:"severity"`        // warning or blocking
+	Environments   []string `yaml:"environments"`    // Optional: file environments (e.g., "dev"); empty matches every file
+	ReasonContains string   `yaml:"reason_contains"` // Optional: case-insensitive substring of the rule reason
+	ReasonMatches  string   `yaml:"reason_matches"`  // Optional: regular expression matching the rule reason
+	Path           string   `yaml:"path"`            // Optional: file pattern (e.g., "dataproducts/**/product.{yaml,yml}")
+}
+
+// AggregationPolicy decides how the decisions of the changed files combine into the decision
+// of the MR
+type AggregationPolicy struct {
+	Policy        string `yaml:"policy"`         // require_all_approve (default) or quorum
+	QuorumPercent int    `yaml:"quorum_percent"` // quorum: percentage of covered files that must approve (1-100)
+}
+
+// Name returns the policy, require_all_approve when none is configured
+func (a AggregationPolicy) Name() string {
+	if a.Policy == "" {
+		return utils.AggregationRequireAllApprove
+	}
+	return a.Policy
+}
+
+// ScheduleWindow is a period during which a scheduled rule is active
+type ScheduleWindow struct {
+	From string `yaml:"from"` // First day (2006-01-02) or start time (RFC 3339)
+	To   string `yaml:"to"`   // Last day, inclusive (2006-01-02), or end time, exclusive (RFC 3339)
+}
+
+// RuleSchedule activates a rule only during date windows or cron-matched minutes. Outside
+// them the rule is skipped as if it were disabled in every section.
+type RuleSchedule struct {
+	Rule     string           `yaml:"rule"`     // Rule name (e.g., "warehouse_rule")
+	Windows  []ScheduleWindow `yaml:"windows"`  // Active during any of these periods
+	Cron     []string         `yaml:"cron"`     // Active during minutes matching any of these expressions (e.g., "* * 1-14 12 *")
+	Timezone string           `yaml:"timezone"` // IANA time zone of dates and cron expressions (default: UTC)
+}
+
+// Location returns the time zone of the schedule
+func (s RuleSchedule) Location() (*time.Location, error) {
+	if s.Timezone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(s.Timezone)
+}
+
+// Bounds returns the window as the half-open interval [from, to) in loc
+func (w ScheduleWindow) Bounds(loc *time.Location) (time.Time, time.Time, error) {
+	from, _, err := parseScheduleTime(w.From, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid window start %q: %w", w.From, err)
+	}
+	to, toDate, err := parseScheduleTime(w.To, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid window end %q: %w", w.To, err)
+	}
+	if toDate {
+		to = to.AddDate(0, 0, 1)
+	}
+	if !from.Before(to) {
+		return time.Time{}, time.Time{}, fmt.Errorf("window %s - %s ends before it starts", w.From, w.To)
+	}
+	return from, to, nil
+}
+
+// parseScheduleTime parses a date or an RFC 3339 time and reports whether it was a date
+func parseScheduleTime(value string, loc *time.Location) (time.Time, bool, error) {
+	if t, err := time.ParseInLocation("2006-01-02", value, loc); err == nil {
+		return t, true, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	return t, false, err
+}
+
+// ProjectRuleConfig overrides the rule configuration for matching GitLab projects
+type ProjectRuleConfig struct {
+	Name                string       `yaml:"name"`                 // Unique identifier for this override
+	ProjectIDs          []int        `yaml:"project_ids"`          // Projects matched by ID
+	Paths               []string     `yaml:"paths"`                // Projects matched by path glob (e.g., "data/analytics-*")
+	Rules               []RuleConfig `yaml:"rules"`                // Enable or disable rules, and replace their environment behaviors and timeouts, in every section that configures them
+	AllowedEnvironments []string     `yaml:"allowed_environments"` // Optional: environments data product files may target
+	AutoApprove         *bool        `yaml:"auto_approve"`         // Optional: false sends every MR of the project to manual review
+}
+
+// AutoApproveDisabled reports whether the override turns off auto-approval
+func (p ProjectRuleConfig) AutoApproveDisabled() bool {
+	return p.AutoApprove != nil && !*p.AutoApprove
+}
+
+// ForProject returns a copy of the configuration with the rule overrides of project
+// applied. Project overrides do not nest, so the copy has none.
+func (config *GlobalRuleConfig) ForProject(project ProjectRuleConfig) *GlobalRuleConfig {
+	overrides := make(map[string]RuleConfig, len(project.Rules))
+	for _, rule := range project.Rules {
+		overrides[rule.Name] = rule
+	}
+
+	derived := *config
+	derived.Projects = nil
+	derived.Files = make([]FileRuleConfig, len(config.Files))
+	for i, fileConfig := range config.Files {
+		fileConfig.Sections = make([]SectionDefinition, len(config.Files[i].Sections))
+		for j, section := range config.Files[i].Sections {
+			section.RuleConfigs = make([]RuleConfig, len(section.RuleConfigs))
+			for k, ruleConfig := range config.Files[i].Sections[j].RuleConfigs {
+				if override, ok := overrides[ruleConfig.Name]; ok {
+					ruleConfig.Enabled = override.Enabled
+					if len(override.Environments) > 0 {
+						ruleConfig.Environments = override.Environments
+					}
+					if override.Timeout > 0 {
+						ruleConfig.Timeout = override.Timeout
+					}
+				}
+				section.RuleConfigs[k] = ruleConfig
+			}
+			fileConfig.Sections[j] = section
+		}
+		derived.Files[i] = fileConfig
+	}
+	return &derived
+}
+
 // GlobalRuleConfig holds the complete rule configuration for all file types
 type GlobalRuleConfig struct {
-	Enabled bool             `yaml:"enabled"`
-	Files   []FileRuleConfig `yaml:"files"` // Array of file configurations
+	Enabled          bool                `yaml:"enabled"`
+	Files            []FileRuleConfig    `yaml:"files"`             // Array of file configurations
+	DeletionPolicies []DeletionPolicy    `yaml:"deletion_policies"` // First matching policy decides file deletions
+	DecisionPolicies []DecisionPolicy    `yaml:"decision_policies"` // Every matching policy escalates the final decision
+	ApprovalPolicies []ApprovalPolicy    `yaml:"approval_policies"` // Every matching policy requires human approvals
+	RuleSchedules    []RuleSchedule      `yaml:"rule_schedules"`    // Rules active only during date windows or cron minutes
+	RuleSeverities   []RuleSeverity      `yaml:"rule_severities"`   // First matching entry downgrades manual reviews to warnings
+	Projects         []ProjectRuleConfig `yaml:"projects"`          // First matching override adapts the rules to a project
+
+	FullFileValidation bool `yaml:"full_file_validation"` // Validate every section of a changed file, not only the sections the MR changed
+	RuleTimeout        int  `yaml:"rule_timeout_seconds"` // Seconds one rule validation may take before it requires manual review (default: 30)
+	EvaluationWorkers  int  `yaml:"evaluation_workers"`   // Changed files of one MR validated concurrently (default: 8, 1 validates files one at a time)
+
+	Aggregation AggregationPolicy `yaml:"aggregation"` // How file decisions combine into the MR decision
 }
 
 // RuleBasedConfig is the external YAML format for rule configuration
 type RuleBasedConfig struct {
-	Enabled bool             `yaml:"enabled"`
-	Files   []FileRuleConfig `yaml:"files"` // Array of file configurations
+	Enabled          bool                `yaml:"enabled"`
+	Files            []FileRuleConfig    `yaml:"files"`             // Array of file configurations
+	DeletionPolicies []DeletionPolicy    `yaml:"deletion_policies"` // First matching policy decides file deletions
+	DecisionPolicies []DecisionPolicy    `yaml:"decision_policies"` // Every matching policy escalates the final decision
+	ApprovalPolicies []ApprovalPolicy    `yaml:"approval_policies"` // Every matching policy requires human approvals
+	RuleSchedules    []RuleSchedule      `yaml:"rule_schedules"`    // Rules active only during date windows or cron minutes
+	RuleSeverities   []RuleSeverity      `yaml:"rule_severities"`   // First matching entry downgrades manual reviews to warnings
+	Projects         []ProjectRuleConfig `yaml:"projects"`          // First matching override adapts the rules to a project
+
+	FullFileValidation bool `yaml:"full_file_validation"` // Validate every section of a changed file, not only the sections the MR changed
+	RuleTimeout        int  `yaml:"rule_timeout_seconds"` // Seconds one rule validation may take before it requires manual review (default: 30)
+	EvaluationWorkers  int  `yaml:"evaluation_workers"`   // Changed files of one MR validated concurrently (default: 8, 1 validates files one at a time)
+
+	Aggregation AggregationPolicy `yaml:"aggregation"` // How file decisions combine into the MR decision
 }
 
 // LoadRuleConfig loads rule-based validation configuration from YAML
@@ -77,8 +289,20 @@ func LoadRuleConfig(configPath string) (*GlobalRuleConfig, error) {
 
 	// Convert YAML config to internal format
 	config := &GlobalRuleConfig{
-		Enabled: yamlConfig.Enabled,
-		Files:   yamlConfig.Files,
+		Enabled:          yamlConfig.Enabled,
+		Files:            yamlConfig.Files,
+		DeletionPolicies: yamlConfig.DeletionPolicies,
+		DecisionPolicies: yamlConfig.DecisionPolicies,
+		ApprovalPolicies: yamlConfig.ApprovalPolicies,
+		RuleSchedules:    yamlConfig.RuleSchedules,
+		RuleSeverities:   yamlConfig.RuleSeverities,
+		Projects:         yamlConfig.Projects,
+
+		FullFileValidation: yamlConfig.FullFileValidation,
+		RuleTimeout:        yamlConfig.RuleTimeout,
+		EvaluationWorkers:  yamlConfig.EvaluationWorkers,
+
+		Aggregation: yamlConfig.Aggregation,
 	}
 
 	// Validate the configuration
@@ -93,8 +317,20 @@ func LoadRuleConfig(configPath string) (*GlobalRuleConfig, error) {
 func SaveRuleConfig(config *GlobalRuleConfig, configPath string) error {
 	// Convert internal config to external format
 	externalConfig := RuleBasedConfig{
-		Enabled: config.Enabled,
-		Files:   config.Files,
+		Enabled:          config.Enabled,
+		Files:            config.Files,
+		DeletionPolicies: config.DeletionPolicies,
+		DecisionPolicies: config.DecisionPolicies,
+		ApprovalPolicies: config.ApprovalPolicies,
+		RuleSchedules:    config.RuleSchedules,
+		RuleSeverities:   config.RuleSeverities,
+		Projects:         config.Projects,
+
+		FullFileValidation: config.FullFileValidation,
+		RuleTimeout:        config.RuleTimeout,
+		EvaluationWorkers:  config.EvaluationWorkers,
+
+		Aggregation: config.Aggregation,
 	}
 
 	// Marshal to YAML
@@ -142,6 +378,12 @@ func ValidateRuleConfig(config *GlobalRuleConfig) error {
 		if fileConfig.ParserType == "" {
 			return fmt.Errorf("file configuration %s missing parser type", fileConfig.Name)
 		}
+		switch fileConfig.ParserType {
+		case utils.ParserTypeYAML, utils.ParserTypeJSON, utils.ParserTypeTOML, utils.ParserTypeText:
+		default:
+			return fmt.Errorf("invalid parser_type '%s' for file config '%s'. Must be '%s', '%s', '%s' or '%s'",
+				fileConfig.ParserType, fileConfig.Name, utils.ParserTypeYAML, utils.ParserTypeJSON, utils.ParserTypeTOML, utils.ParserTypeText)
+		}
 
 		// Validate default_action if specified
 		if fileConfig.DefaultAction != "" &&
@@ -159,8 +401,8 @@ func ValidateRuleConfig(config *GlobalRuleConfig) error {
 			if section.Name == "" {
 				return fmt.Errorf("section definition missing name in file configuration %s", fileConfig.Name)
 			}
-			if section.YAMLPath == "" {
-				return fmt.Errorf("section %s missing YAML path in file configuration %s", section.Name, fileConfig.Name)
+			if err := validateSectionSelector(fileConfig.ParserType, section); err != nil {
+				return fmt.Errorf("%w in file configuration %s", err, fileConfig.Name)
 			}
 
 			// Validate rule configs
@@ -168,6 +410,15 @@ func ValidateRuleConfig(config *GlobalRuleConfig) error {
 				if ruleConfig.Name == "" {
 					return fmt.Errorf("rule config missing name in section %s of file configuration %s", section.Name, fileConfig.Name)
 				}
+				if err := validateEnvironmentBehaviors(ruleConfig); err != nil {
+					return fmt.Errorf("%w in section %s of file configuration %s", err, section.Name, fileConfig.Name)
+				}
+				if ruleConfig.Timeout < 0 {
+					return fmt.Errorf("rule %s has a negative timeout_seconds in section %s of file configuration %s", ruleConfig.Name, section.Name, fileConfig.Name)
+				}
+				if ruleConfig.Optional && !section.AutoApprove {
+					return fmt.Errorf("rule %s is optional in section %s of file configuration %s, which is not auto_approve", ruleConfig.Name, section.Name, fileConfig.Name)
+				}
 			}
 
 			// Auto-approve sections can have no rules, but warn if auto_approve is set with no rules
@@ -177,6 +428,267 @@ func ValidateRuleConfig(config *GlobalRuleConfig) error {
 		}
 	}
 
+	if config.RuleTimeout < 0 {
+		return fmt.Errorf("rule_timeout_seconds must not be negative")
+	}
+	if config.EvaluationWorkers < 0 {
+		return fmt.Errorf("evaluation_workers must not be negative")
+	}
+	if err := validateAggregationPolicy(config.Aggregation); err != nil {
+		return err
+	}
+	if err := validateDeletionPolicies(config.DeletionPolicies); err != nil {
+		return err
+	}
+	if err := validateDecisionPolicies(config.DecisionPolicies); err != nil {
+		return err
+	}
+	if err := validateApprovalPolicies(config.ApprovalPolicies); err != nil {
+		return err
+	}
+	if err := validateRuleSchedules(config.RuleSchedules); err != nil {
+		return err
+	}
+	if err := validateRuleSeverities(config.RuleSeverities); err != nil {
+		return err
+	}
+	return validateProjectRuleConfigs(config.Projects)
+}
+
+// validateSectionSelector validates how a section is selected: by yaml_path, or for the text
+// parser by pattern or yaml_path "." for the whole file
+func validateSectionSelector(parserType string, section SectionDefinition) error {
+	if parserType != utils.ParserTypeText {
+		if section.YAMLPath == "" {
+			return fmt.Errorf("section %s missing YAML path", section.Name)
+		}
+		return nil
+	}
+
+	if section.Pattern == "" {
+		if section.YAMLPath != "." {
+			return fmt.Errorf("text section %s needs a pattern or yaml_path \".\"", section.Name)
+		}
+		return nil
+	}
+	for _, pattern := range []string{section.Pattern, section.EndPattern} {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid pattern %q of section %s: %v", pattern, section.Name, err)
+		}
+	}
+	return nil
+}
+
+// validateEnvironmentBehaviors validates the behaviors a rule config sets per environment
+func validateEnvironmentBehaviors(ruleConfig RuleConfig) error {
+	for env, behavior := range ruleConfig.Environments {
+		if env == "" {
+			return fmt.Errorf("empty environment for rule %s", ruleConfig.Name)
+		}
+		switch behavior {
+		case utils.DecisionApprove, utils.DecisionWarn, utils.DefaultActionManualReview:
+		default:
+			return fmt.Errorf("invalid behavior '%s' for environment '%s' of rule %s. Must be '%s', '%s' or '%s'",
+				behavior, env, ruleConfig.Name, utils.DecisionApprove, utils.DecisionWarn, utils.DefaultActionManualReview)
+		}
+	}
+	return nil
+}
+
+// validateDeletionPolicies validates deletion policy definitions
+func validateDeletionPolicies(policies []DeletionPolicy) error {
+	for i, policy := range policies {
+		if policy.Name == "" {
+			return fmt.Errorf("deletion policy at index %d missing name", i)
+		}
+		if policy.Path == "" || policy.Filename == "" {
+			return fmt.Errorf("deletion policy %s must define path and filename", policy.Name)
+		}
+		switch policy.Action {
+		case utils.DefaultActionAutoApprove, utils.DefaultActionManualReview, utils.DeletionActionBlock:
+		default:
+			return fmt.Errorf("invalid action '%s' for deletion policy '%s'. Must be '%s', '%s' or '%s'",
+				policy.Action, policy.Name, utils.DefaultActionAutoApprove, utils.DefaultActionManualReview, utils.DeletionActionBlock)
+		}
+	}
+	return nil
+}
+
+// validateAggregationPolicy validates the aggregation policy
+func validateAggregationPolicy(aggregation AggregationPolicy) error {
+	switch aggregation.Name() {
+	case utils.AggregationRequireAllApprove:
+		if aggregation.QuorumPercent != 0 {
+			return fmt.Errorf("aggregation quorum_percent is only used by the '%s' policy", utils.AggregationQuorum)
+		}
+	case utils.AggregationQuorum:
+		if aggregation.QuorumPercent < 1 || aggregation.QuorumPercent > 100 {
+			return fmt.Errorf("aggregation quorum_percent must be between 1 and 100, got %d", aggregation.QuorumPercent)
+		}
+	default:
+		return fmt.Errorf("invalid aggregation policy '%s'. Must be '%s' or '%s'",
+			aggregation.Policy, utils.AggregationRequireAllApprove, utils.AggregationQuorum)
+	}
+	return nil
+}
+
+// validateDecisionPolicies validates decision policy definitions
+func validateDecisionPolicies(policies []DecisionPolicy) error {
+	for i, policy := range policies {
+		if policy.Name == "" {
+			return fmt.Errorf("decision policy at index %d missing name", i)
+		}
+		if len(policy.When) == 0 {
+			return fmt.Errorf("decision policy %s has no conditions", policy.Name)
+		}
+		if err := validatePolicyConditions("decision", policy.Name, policy.When); err != nil {
+			return err
+		}
+		switch policy.Action {
+		case utils.DefaultActionManualReview, utils.DeletionActionBlock:
+		default:
+			return fmt.Errorf("invalid action '%s' for decision policy '%s'. Must be '%s' or '%s'",
+				policy.Action, policy.Name, utils.DefaultActionManualReview, utils.DeletionActionBlock)
+		}
+	}
+	return nil
+}
+
+// validateApprovalPolicies validates approval policy definitions
+func validateApprovalPolicies(policies []ApprovalPolicy) error {
+	for i, policy := range policies {
+		if policy.Name == "" {
+			return fmt.Errorf("approval policy at index %d missing name", i)
+		}
+		if len(policy.When) == 0 {
+			return fmt.Errorf("approval policy %s has no conditions", policy.Name)
+		}
+		if err := validatePolicyConditions("approval", policy.Name, policy.When); err != nil {
+			return err
+		}
+		if policy.Approvals < 1 {
+			return fmt.Errorf("approval policy %s must require at least one approval", policy.Name)
+		}
+		if len(policy.Approvers) > 0 && len(policy.Approvers) < policy.Approvals {
+			return fmt.Errorf("approval policy %s requires %d approvals but lists only %d approvers", policy.Name, policy.Approvals, len(policy.Approvers))
+		}
+	}
+	return nil
+}
+
+// validatePolicyConditions validates the conditions of a decision or approval policy
+func validatePolicyConditions(kind, policyName string, conditions []PolicyCondition) error {
+	for j, condition := range conditions {
+		if condition.Rule == "" {
+			return fmt.Errorf("condition %d of %s policy %s missing rule", j, kind, policyName)
+		}
+		switch condition.Decision {
+		case "", utils.DecisionApprove, utils.DecisionWarn, utils.DefaultActionManualReview:
+		default:
+			return fmt.Errorf("invalid decision '%s' in condition %d of %s policy '%s'. Must be '%s', '%s' or '%s'",
+				condition.Decision, j, kind, policyName, utils.DecisionApprove, utils.DecisionWarn, utils.DefaultActionManualReview)
+		}
+		if condition.ReasonMatches != "" {
+			if _, err := regexp.Compile(condition.ReasonMatches); err != nil {
+				return fmt.Errorf("invalid reason_matches in condition %d of %s policy %s: %w", j, kind, policyName, err)
+			}
+		}
+	}
+	return nil
+}
+
+// validateRuleSchedules validates rule schedule definitions
+func validateRuleSchedules(schedules []RuleSchedule) error {
+	seen := make(map[string]bool)
+	for i, schedule := range schedules {
+		if schedule.Rule == "" {
+			return fmt.Errorf("rule schedule at index %d missing rule", i)
+		}
+		if seen[schedule.Rule] {
+			return fmt.Errorf("rule %s has more than one schedule", schedule.Rule)
+		}
+		seen[schedule.Rule] = true
+		if len(schedule.Windows) == 0 && len(schedule.Cron) == 0 {
+			return fmt.Errorf("schedule of rule %s has no windows or cron expressions", schedule.Rule)
+		}
+
+		loc, err := schedule.Location()
+		if err != nil {
+			return fmt.Errorf("invalid timezone '%s' in schedule of rule %s: %w", schedule.Timezone, schedule.Rule, err)
+		}
+		for _, window := range schedule.Windows {
+			if _, _, err := window.Bounds(loc); err != nil {
+				return fmt.Errorf("schedule of rule %s: %w", schedule.Rule, err)
+			}
+		}
+		for _, expr := range schedule.Cron {
+			if _, err := cron.Parse(expr); err != nil {
+				return fmt.Errorf("schedule of rule %s: %w", schedule.Rule, err)
+			}
+		}
+	}
+	return nil
+}
+
+// validateRuleSeverities validates rule severity definitions
+func validateRuleSeverities(severities []RuleSeverity) error {
+	for i, severity := range severities {
+		if severity.Rule == "" {
+			return fmt.Errorf("rule severity at index %d missing rule", i)
+		}
+		switch severity.Severity {
+		case utils.SeverityWarning, utils.SeverityBlocking:
+		default:
+			return fmt.Errorf("invalid severity '%s' for rule %s. Must be '%s' or '%s'",
+				severity.Severity, severity.Rule, utils.SeverityWarning, utils.SeverityBlocking)
+		}
+		for _, env := range severity.Environments {
+			if env == "" || strings.Contains(env, "/") {
+				return fmt.Errorf("invalid environment '%s' in severity of rule %s", env, severity.Rule)
+			}
+		}
+		if severity.ReasonMatches != "" {
+			if _, err := regexp.Compile(severity.ReasonMatches); err != nil {
+				return fmt.Errorf("invalid reason_matches in severity of rule %s: %w", severity.Rule, err)
+			}
+		}
+	}
+	return nil
+}
+
+// validateProjectRuleConfigs validates project override definitions
+func validateProjectRuleConfigs(projects []ProjectRuleConfig) error {
+	seen := make(map[string]bool)
+	for i, project := range projects {
+		if project.Name == "" {
+			return fmt.Errorf("project override at index %d missing name", i)
+		}
+		if seen[project.Name] {
+			return fmt.Errorf("project override %s is defined more than once", project.Name)
+		}
+		seen[project.Name] = true
+		if len(project.ProjectIDs) == 0 && len(project.Paths) == 0 {
+			return fmt.Errorf("project override %s must define project_ids or paths", project.Name)
+		}
+		for _, id := range project.ProjectIDs {
+			if id <= 0 {
+				return fmt.Errorf("invalid project ID %d in project override %s", id, project.Name)
+			}
+		}
+		for _, pattern := range project.Paths {
+			if _, err := path.Match(pattern, ""); err != nil || pattern == "" {
+				return fmt.Errorf("invalid path pattern '%s' in project override %s", pattern, project.Name)
+			}
+		}
+		for j, rule := range project.Rules {
+			if rule.Name == "" {
+				return fmt.Errorf("rule %d of project override %s missing name", j, project.Name)
+			}
+			if err := validateEnvironmentBehaviors(rule); err != nil {
+				return fmt.Errorf("%w in project override %s", err, project.Name)
+			}
+		}
+	}
 	return nil
 }
 
@@ -0,0 +1,88 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func at(value string) time.Time {
+	t, err := time.Parse("2006-01-02 15:04", value)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+		"@fortnightly",
+	} {
+		_, err := Parse(expr)
+		assert.Error(t, err, expr)
+	}
+}
+
+func TestSchedule_Matches(t *testing.T) {
+	tests := []struct {
+		expr    string
+		time    string
+		matches bool
+	}{
+		{"* * * * *", "2026-03-01 12:34", true},
+		{"*/15 * * * *", "2026-03-01 12:45", true},
+		{"*/15 * * * *", "2026-03-01 12:46", false},
+		{"0 9-17 * * 1-5", "2026-03-02 09:00", true},  // Monday
+		{"0 9-17 * * 1-5", "2026-03-01 09:00", false}, // Sunday
+		{"0 0 * * 7", "2026-03-01 00:00", true},       // 7 is Sunday
+		{"* * 1-14 12 *", "2026-12-14 23:59", true},
+		{"* * 1-14 12 *", "2026-12-15 00:00", false},
+		{"0 0 1 * 1", "2026-03-02 00:00", true}, // day of month OR day of week
+		{"0 0 1 * 1", "2026-03-03 00:00", false},
+		{"5,35 * * * *", "2026-03-01 10:35", true},
+		{"10-30/10 * * * *", "2026-03-01 10:20", true},
+		{"10-30/10 * * * *", "2026-03-01 10:40", false},
+		{"@daily", "2026-03-01 00:00", true},
+		{"@hourly", "2026-03-01 07:01", false},
+	}
+	for _, tt := range tests {
+		s, err := Parse(tt.expr)
+		assert.NoError(t, err, tt.expr)
+		assert.Equal(t, tt.matches, s.Matches(at(tt.time)), "%s at %s", tt.expr, tt.time)
+	}
+}
+
+func TestSchedule_Next(t *testing.T) {
+	tests := []struct {
+		expr string
+		from string
+		next string
+	}{
+		{"*/15 * * * *", "2026-03-01 12:34", "2026-03-01 12:45"},
+		{"0 2 * * *", "2026-03-01 02:00", "2026-03-02 02:00"},
+		{"30 6 * * 1", "2026-03-01 12:00", "2026-03-02 06:30"},
+		{"0 0 1 1 *", "2026-03-01 12:00", "2027-01-01 00:00"},
+		{"0 0 29 2 *", "2026-03-01 12:00", "2028-02-29 00:00"},
+	}
+	for _, tt := range tests {
+		s, err := Parse(tt.expr)
+		assert.NoError(t, err)
+		assert.Equal(t, at(tt.next), s.Next(at(tt.from)), tt.expr)
+	}
+
+	never, err := Parse("0 0 30 2 *")
+	assert.NoError(t, err)
+	assert.True(t, never.Next(at("2026-03-01 12:00")).IsZero())
+	assert.Equal(t, "0 0 30 2 *", never.String())
+}
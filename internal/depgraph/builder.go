@@ -0,0 +1,57 @@
+package depgraph
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/repoindex"
+	"go.uber.org/zap"
+)
+
+// FileFetcher defines the GitLab operation needed to read product definitions
+type FileFetcher interface {
+	FetchFileContent(ctx context.Context, projectID int, filePath, ref string) (*gitlab.FileContent, error)
+}
+
+// Builder builds dependency graphs from the product.yaml files of a repository ref
+type Builder struct {
+	index   *repoindex.Index
+	fetcher FileFetcher
+}
+
+// NewBuilder creates a builder that lists product files from the repository index
+func NewBuilder(index *repoindex.Index, fetcher FileFetcher) *Builder {
+	return &Builder{
+		index:   index,
+		fetcher: fetcher,
+	}
+}
+
+// Build parses every product.yaml at ref into a graph. Unparsable files are skipped.
+func (b *Builder) Build(ctx context.Context, projectID int, ref string) (*Graph, error) {
+	paths, err := b.index.Paths(ctx, projectID, ref, IsProductFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list product files: %w", err)
+	}
+
+	graph := New()
+	for _, path := range paths {
+		content, err := b.fetcher.FetchFileContent(ctx, projectID, path, ref)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch %s: %w", path, err)
+		}
+		if content == nil {
+			return nil, fmt.Errorf("empty response when fetching %s", path)
+		}
+
+		product, err := ParseProduct(path, content.Content)
+		if err != nil {
+			logging.Warn("Skipping product file in dependency graph", zap.Error(err))
+			continue
+		}
+		graph.AddProduct(product)
+	}
+	return graph, nil
+}
@@ -0,0 +1,44 @@
+package depgraph
+
+import "sort"
+
+// CyclePath returns the dependency cycle closed by edge e, as the product names
+// consumer, producer, ..., consumer, or nil when the edge does not close a cycle.
+// Only edges of e's environment are followed; e itself need not be in the graph.
+func (g *Graph) CyclePath(e Edge) []string {
+	if e.Consumer == e.Producer {
+		return []string{e.Consumer, e.Consumer}
+	}
+
+	// Breadth-first search from the producer along its dependencies back to the consumer
+	deps := make(map[string][]string)
+	for edge := range g.edges {
+		if edge.Environment == e.Environment {
+			deps[edge.Consumer] = append(deps[edge.Consumer], edge.Producer)
+		}
+	}
+	for _, producers := range deps {
+		sort.Strings(producers)
+	}
+
+	parent := map[string]string{e.Producer: ""}
+	queue := []string{e.Producer}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		if node == e.Consumer {
+			path := []string{}
+			for n := node; n != ""; n = parent[n] {
+				path = append([]string{n}, path...)
+			}
+			return append([]string{e.Consumer}, path...)
+		}
+		for _, next := range deps[node] {
+			if _, seen := parent[next]; !seen {
+				parent[next] = node
+				queue = append(queue, next)
+			}
+		}
+	}
+	return nil
+}
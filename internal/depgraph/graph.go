@@ -0,0 +1,143 @@
+package depgraph
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Edge says Consumer consumes (depends on) Producer, as declared in the producer's product.yaml
+type Edge struct {
+	Consumer    string `json:"consumer"`
+	Producer    string `json:"producer"`
+	Environment string `json:"environment,omitempty"`
+	FilePath    string `json:"file_path"`
+}
+
+// Graph is the data product dependency graph. Nodes are data product names; edges
+// point from a consumer to the product it consumes.
+type Graph struct {
+	nodes map[string]bool
+	edges map[Edge]bool
+}
+
+// New creates an empty graph
+func New() *Graph {
+	return &Graph{
+		nodes: make(map[string]bool),
+		edges: make(map[Edge]bool),
+	}
+}
+
+// AddProduct adds a product and one edge per data product consumer
+func (g *Graph) AddProduct(p *Product) {
+	g.nodes[p.Name] = true
+	for _, consumer := range p.Consumers {
+		g.AddEdge(Edge{Consumer: consumer, Producer: p.Name, Environment: p.Environment, FilePath: p.FilePath})
+	}
+}
+
+// AddEdge adds an edge and both of its nodes
+func (g *Graph) AddEdge(e Edge) {
+	g.nodes[e.Consumer] = true
+	g.nodes[e.Producer] = true
+	g.edges[e] = true
+}
+
+// RemoveFile drops the edges declared in filePath, e.g. before adding the MR version of the file
+func (g *Graph) RemoveFile(filePath string) {
+	for e := range g.edges {
+		if e.FilePath == filePath {
+			delete(g.edges, e)
+		}
+	}
+}
+
+// Nodes returns the product names in sorted order
+func (g *Graph) Nodes() []string {
+	nodes := make([]string, 0, len(g.nodes))
+	for node := range g.nodes {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+	return nodes
+}
+
+// Edges returns the edges sorted by consumer, producer and environment
+func (g *Graph) Edges() []Edge {
+	edges := make([]Edge, 0, len(g.edges))
+	for e := range g.edges {
+		edges = append(edges, e)
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		a, b := edges[i], edges[j]
+		if a.Consumer != b.Consumer {
+			return a.Consumer < b.Consumer
+		}
+		if a.Producer != b.Producer {
+			return a.Producer < b.Producer
+		}
+		if a.Environment != b.Environment {
+			return a.Environment < b.Environment
+		}
+		return a.FilePath < b.FilePath
+	})
+	return edges
+}
+
+// Dependencies returns the products consumer consumes, in sorted order
+func (g *Graph) Dependencies(consumer string) []string {
+	seen := make(map[string]bool)
+	for e := range g.edges {
+		if e.Consumer == consumer {
+			seen[e.Producer] = true
+		}
+	}
+	deps := make([]string, 0, len(seen))
+	for dep := range seen {
+		deps = append(deps, dep)
+	}
+	sort.Strings(deps)
+	return deps
+}
+
+// Environment returns the subgraph of edges declared for env; all products are kept
+func (g *Graph) Environment(env string) *Graph {
+	sub := New()
+	for node := range g.nodes {
+		sub.nodes[node] = true
+	}
+	for e := range g.edges {
+		if e.Environment == env {
+			sub.edges[e] = true
+		}
+	}
+	return sub
+}
+
+// MarshalJSON encodes the graph as {"nodes": [...], "edges": [...]}
+func (g *Graph) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Nodes []string `json:"nodes"`
+		Edges []Edge   `json:"edges"`
+	}{g.Nodes(), g.Edges()})
+}
+
+// DOT renders the graph in Graphviz DOT format, labelling edges with their environment
+func (g *Graph) DOT() string {
+	var sb strings.Builder
+	sb.WriteString("digraph dataproducts {\n  rankdir=LR;\n")
+	for _, node := range g.Nodes() {
+		sb.WriteString(fmt.Sprintf("  %q;\n", node))
+	}
+	for _, e := range g.Edges() {
+		if e.Environment != "" {
+			sb.WriteString(fmt.Sprintf("  %q -> %q [label=%q];\n", e.Consumer, e.Producer, e.Environment))
+		} else {
+			sb.WriteString(fmt.Sprintf("  %q -> %q;\n", e.Consumer, e.Producer))
+		}
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
@@ -0,0 +1,142 @@
+package depgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/repoindex"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+	"github.com/stretchr/testify/assert"
+)
+
+const ordersProduct = `name: orders
+data_product_db:
+  - database: orders_db
+    presentation_schemas:
+      - name: marts
+        consumers:
+          - name: analytics
+            kind: data_product
+          - name: finance
+            kind: data_product
+          - name: orders-readers
+            kind: consumer_group
+      - name: raw
+        consumers:
+          - name: analytics
+            kind: data_product
+`
+
+const analyticsProduct = `name: analytics
+data_product_db:
+  presentation_schemas:
+    - name: reporting
+      consumers:
+        - name: finance
+          kind: data_product
+`
+
+func TestParseProduct(t *testing.T) {
+	product, err := ParseProduct("dataproducts/source/orders/prod/product.yaml", ordersProduct)
+	assert.NoError(t, err)
+	assert.Equal(t, "orders", product.Name)
+	assert.Equal(t, "prod", product.Environment)
+	assert.Equal(t, []string{"analytics", "finance"}, product.Consumers)
+
+	// data_product_db as a single database; name falls back to the directory
+	product, err = ParseProduct("dataproducts/aggregate/reports/dev/product.yaml", "data_product_db:\n  presentation_schemas:\n    - consumers:\n        - name: x\n          kind: data_product\n")
+	assert.NoError(t, err)
+	assert.Equal(t, "reports", product.Name)
+	assert.Equal(t, "dev", product.Environment)
+	assert.Equal(t, []string{"x"}, product.Consumers)
+
+	_, err = ParseProduct("dataproducts/source/orders/prod/product.yaml", "name: [")
+	assert.Error(t, err)
+}
+
+func TestIsProductFile(t *testing.T) {
+	assert.True(t, IsProductFile("dataproducts/source/orders/prod/product.yaml"))
+	assert.True(t, IsProductFile("dataproducts/sales/prod/product.yml"))
+	assert.False(t, IsProductFile("dataproducts/source/orders/prod/warehouse.yaml"))
+	assert.False(t, IsProductFile("docs/product.yaml"))
+}
+
+func TestGraph(t *testing.T) {
+	g := New()
+	orders, _ := ParseProduct("dataproducts/source/orders/prod/product.yaml", ordersProduct)
+	analytics, _ := ParseProduct("dataproducts/aggregate/analytics/prod/product.yaml", analyticsProduct)
+	g.AddProduct(orders)
+	g.AddProduct(analytics)
+	g.AddProduct(&Product{Name: "orders", Environment: "dev", FilePath: "dataproducts/source/orders/dev/product.yaml", Consumers: []string{"analytics"}})
+
+	assert.Equal(t, []string{"analytics", "finance", "orders"}, g.Nodes())
+	assert.Len(t, g.Edges(), 4)
+	assert.Equal(t, []string{"analytics", "orders"}, g.Dependencies("finance"))
+	assert.Len(t, g.Environment("dev").Edges(), 1)
+
+	dot := g.DOT()
+	assert.Contains(t, dot, `"finance" -> "analytics" [label="prod"];`)
+	assert.Contains(t, dot, `"analytics";`)
+
+	data, err := json.Marshal(g)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"nodes":["analytics","finance","orders"]`)
+
+	g.RemoveFile("dataproducts/source/orders/prod/product.yaml")
+	assert.Equal(t, []string{"analytics"}, g.Dependencies("finance"))
+}
+
+// testRepository serves a repository tree and file contents
+type testRepository struct {
+	files map[string]string
+}
+
+func (r *testRepository) ListRepositoryTree(ctx context.Context, projectID int, ref string) ([]gitlab.TreeEntry, error) {
+	entries := make([]gitlab.TreeEntry, 0, len(r.files))
+	for path := range r.files {
+		entries = append(entries, gitlab.TreeEntry{Type: "blob", Path: path})
+	}
+	return entries, nil
+}
+
+func (r *testRepository) FetchFileContent(ctx context.Context, projectID int, filePath, ref string) (*gitlab.FileContent, error) {
+	content, ok := r.files[filePath]
+	if !ok {
+		return nil, fmt.Errorf("file not found: %s", filePath)
+	}
+	return &gitlab.FileContent{FilePath: filePath, Content: content}, nil
+}
+
+func TestBuilder_Build(t *testing.T) {
+	ctx := context.Background()
+	repo := &testRepository{files: map[string]string{
+		"dataproducts/source/orders/prod/product.yaml":        ordersProduct,
+		"dataproducts/aggregate/analytics/prod/product.yaml":  analyticsProduct,
+		"dataproducts/aggregate/broken/prod/product.yaml":     "name: [",
+		"dataproducts/source/orders/prod/pii_masking.yaml":    "kind: MaskingPolicy",
+		"dataproducts/aggregate/analytics/prod/warehouse.yml": "x: y",
+	}}
+	builder := NewBuilder(repoindex.NewIndex(repo, store.NewMemoryStore()), repo)
+
+	g, err := builder.Build(ctx, 1, "main")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"analytics", "finance", "orders"}, g.Nodes())
+	assert.Len(t, g.Edges(), 3)
+}
+
+func TestGraph_CyclePath(t *testing.T) {
+	g := New()
+	g.AddEdge(Edge{Consumer: "b", Producer: "c", Environment: "prod", FilePath: "c"})
+	g.AddEdge(Edge{Consumer: "c", Producer: "a", Environment: "prod", FilePath: "a"})
+	g.AddEdge(Edge{Consumer: "a", Producer: "d", Environment: "dev", FilePath: "d"})
+
+	// a consumes b while b -> c -> a
+	assert.Equal(t, []string{"a", "b", "c", "a"}, g.CyclePath(Edge{Consumer: "a", Producer: "b", Environment: "prod"}))
+	// Edges of other environments are not followed
+	assert.Nil(t, g.CyclePath(Edge{Consumer: "a", Producer: "b", Environment: "dev"}))
+	assert.Nil(t, g.CyclePath(Edge{Consumer: "d", Producer: "b", Environment: "prod"}))
+	assert.Equal(t, []string{"a", "a"}, g.CyclePath(Edge{Consumer: "a", Producer: "a"}))
+}
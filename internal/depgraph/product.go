@@ -0,0 +1,100 @@
+package depgraph
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// dataProductKind is the consumer kind that makes one data product depend on another
+const dataProductKind = "data_product"
+
+// Product is the dependency information of one product.yaml file
+type Product struct {
+	Name        string
+	Environment string
+	FilePath    string
+	// Consumers are the data products declared as consumers of this product
+	Consumers []string
+}
+
+// IsProductFile reports whether path is a data product definition (dataproducts/**/product.yaml)
+func IsProductFile(filePath string) bool {
+	lower := strings.ToLower(filePath)
+	if !strings.HasPrefix(lower, "dataproducts/") && !strings.Contains(lower, "/dataproducts/") {
+		return false
+	}
+	base := path.Base(lower)
+	return base == "product.yaml" || base == "product.yml"
+}
+
+// pathInfo derives the product name and environment from
+// dataproducts/[<type>/]<product>/<env>/product.yaml
+func pathInfo(filePath string) (string, string) {
+	dir := path.Dir(strings.ReplaceAll(filePath, "\\", "/"))
+	env := path.Base(dir)
+	name := path.Base(path.Dir(dir))
+	if name == "dataproducts" || name == "." || name == "/" {
+		return "", env
+	}
+	return name, env
+}
+
+// ParseProduct extracts the product name and its data product consumers. The name comes
+// from the YAML name field, falling back to the directory name. data_product_db may be
+// a list of databases or a single database.
+func ParseProduct(filePath, content string) (*Product, error) {
+	var parsed map[string]interface{}
+	if err := yaml.Unmarshal([]byte(content), &parsed); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filePath, err)
+	}
+
+	name, env := pathInfo(filePath)
+	product := &Product{Name: name, Environment: env, FilePath: filePath}
+	if yamlName, ok := parsed["name"].(string); ok && yamlName != "" {
+		product.Name = yamlName
+	}
+	if product.Name == "" {
+		return nil, fmt.Errorf("cannot determine data product name of %s", filePath)
+	}
+
+	var databases []interface{}
+	switch db := parsed["data_product_db"].(type) {
+	case []interface{}:
+		databases = db
+	case map[string]interface{}:
+		databases = []interface{}{db}
+	}
+
+	seen := make(map[string]bool)
+	for _, db := range databases {
+		dbMap, ok := db.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		schemas, _ := dbMap["presentation_schemas"].([]interface{})
+		for _, schema := range schemas {
+			schemaMap, ok := schema.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			consumers, _ := schemaMap["consumers"].([]interface{})
+			for _, consumer := range consumers {
+				consumerMap, ok := consumer.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				consumerName, _ := consumerMap["name"].(string)
+				kind, _ := consumerMap["kind"].(string)
+				if consumerName == "" || kind != dataProductKind || seen[consumerName] {
+					continue
+				}
+				seen[consumerName] = true
+				product.Consumers = append(product.Consumers, consumerName)
+			}
+		}
+	}
+	return product, nil
+}
@@ -0,0 +1,126 @@
+package digest
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// otherFiles groups MRs that change no data product
+const otherFiles = "Other files"
+
+//go:embed templates/digest.txt
+var defaultTemplate string
+
+// PendingReview is an open MR whose latest evaluation requires manual review
+type PendingReview struct {
+	ProjectID   int
+	MRIID       int
+	Title       string
+	Author      string
+	Reason      string
+	EvaluatedAt time.Time
+	Files       []string // Changed files of the evaluation
+}
+
+// Source lists the MRs currently waiting for manual review
+type Source func() ([]PendingReview, error)
+
+// Group lists the pending reviews touching one data product
+type Group struct {
+	DataProduct string // <type>/<name>, e.g. source/analytics
+	Reviews     []PendingReview
+}
+
+// Digest is the summary of pending manual reviews rendered into the email
+type Digest struct {
+	GeneratedAt time.Time
+	Total       int
+	Groups      []Group
+}
+
+// Build groups pending reviews by the data products they change; an MR changing several
+// data products is listed under each of them. Groups are sorted by name and reviews by
+// waiting time, oldest first.
+func Build(reviews []PendingReview, now time.Time) *Digest {
+	byProduct := make(map[string][]PendingReview)
+	for _, review := range reviews {
+		products := make(map[string]bool)
+		for _, file := range review.Files {
+			if product := DataProduct(file); product != "" {
+				products[product] = true
+			}
+		}
+		if len(products) == 0 {
+			products[otherFiles] = true
+		}
+		for product := range products {
+			byProduct[product] = append(byProduct[product], review)
+		}
+	}
+
+	digest := &Digest{GeneratedAt: now, Total: len(reviews)}
+	for product, reviews := range byProduct {
+		sort.Slice(reviews, func(i, j int) bool { return reviews[i].EvaluatedAt.Before(reviews[j].EvaluatedAt) })
+		digest.Groups = append(digest.Groups, Group{DataProduct: product, Reviews: reviews})
+	}
+	sort.Slice(digest.Groups, func(i, j int) bool {
+		// Other files come last
+		if (digest.Groups[i].DataProduct == otherFiles) != (digest.Groups[j].DataProduct == otherFiles) {
+			return digest.Groups[j].DataProduct == otherFiles
+		}
+		return digest.Groups[i].DataProduct < digest.Groups[j].DataProduct
+	})
+	return digest
+}
+
+// DataProduct returns <type>/<name> of a file below dataproducts/<type>/<name>/, or ""
+func DataProduct(path string) string {
+	parts := strings.Split(path, "/")
+	if len(parts) < 4 || parts[0] != "dataproducts" {
+		return ""
+	}
+	return parts[1] + "/" + parts[2]
+}
+
+// Subject is the email subject of the digest
+func (d *Digest) Subject() string {
+	return fmt.Sprintf("naysayer: %d MRs waiting for manual review (%s)", d.Total, d.GeneratedAt.UTC().Format("2006-01-02"))
+}
+
+// Renderer renders the email body of a digest
+type Renderer struct {
+	tmpl *template.Template
+}
+
+// NewRenderer parses the template file, or the built-in template when path is empty
+func NewRenderer(path string) (*Renderer, error) {
+	text := defaultTemplate
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read digest template: %w", err)
+		}
+		text = string(data)
+	}
+	tmpl, err := template.New("digest").Funcs(template.FuncMap{
+		"time": func(t time.Time) string { return t.UTC().Format("2006-01-02 15:04 UTC") },
+	}).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid digest template: %w", err)
+	}
+	return &Renderer{tmpl: tmpl}, nil
+}
+
+// Render renders the email body of the digest
+func (r *Renderer) Render(digest *Digest) (string, error) {
+	var body strings.Builder
+	if err := r.tmpl.Execute(&body, digest); err != nil {
+		return "", fmt.Errorf("failed to render digest: %w", err)
+	}
+	return body.String(), nil
+}
@@ -0,0 +1,65 @@
+package digest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func pendingReviews() []PendingReview {
+	base := time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC)
+	return []PendingReview{
+		{ProjectID: 5, MRIID: 3, Title: "Grow warehouse", Author: "alice", Reason: "warehouse size increased", EvaluatedAt: base.Add(2 * time.Hour),
+			Files: []string{"dataproducts/source/analytics/prod/product.yaml"}},
+		{ProjectID: 5, MRIID: 1, Title: "Share bookings", Reason: "new consumer", EvaluatedAt: base,
+			Files: []string{"dataproducts/aggregate/bookings/prod/product.yaml", "dataproducts/source/analytics/prod/product.yaml"}},
+		{ProjectID: 6, MRIID: 2, Title: "Tweak CI", Reason: "uncovered changes", EvaluatedAt: base,
+			Files: []string{".gitlab-ci.yml"}},
+	}
+}
+
+func TestBuild_GroupsByDataProduct(t *testing.T) {
+	now := time.Date(2026, 10, 15, 8, 0, 0, 0, time.UTC)
+	digest := Build(pendingReviews(), now)
+
+	assert.Equal(t, 3, digest.Total)
+	assert.Len(t, digest.Groups, 3)
+	assert.Equal(t, "aggregate/bookings", digest.Groups[0].DataProduct)
+	assert.Equal(t, "source/analytics", digest.Groups[1].DataProduct)
+	assert.Equal(t, otherFiles, digest.Groups[2].DataProduct, "MRs without data products come last")
+	assert.Equal(t, []int{1, 3}, []int{digest.Groups[1].Reviews[0].MRIID, digest.Groups[1].Reviews[1].MRIID}, "oldest first")
+	assert.Equal(t, "naysayer: 3 MRs waiting for manual review (2026-10-15)", digest.Subject())
+}
+
+func TestDataProduct(t *testing.T) {
+	assert.Equal(t, "source/analytics", DataProduct("dataproducts/source/analytics/prod/product.yaml"))
+	assert.Equal(t, "", DataProduct("dataproducts/source/README.md"))
+	assert.Equal(t, "", DataProduct("serviceaccounts/prod/bot.yaml"))
+}
+
+func TestRenderer(t *testing.T) {
+	renderer, err := NewRenderer("")
+	assert.NoError(t, err)
+	body, err := renderer.Render(Build(pendingReviews(), time.Now()))
+	assert.NoError(t, err)
+	assert.Contains(t, body, "3 merge requests are waiting for manual review.")
+	assert.Contains(t, body, "== source/analytics ==")
+	assert.Contains(t, body, "- Project 5 !3: Grow warehouse (@alice)\n  warehouse size increased\n  Waiting since 2026-10-14 11:00 UTC")
+
+	path := filepath.Join(t.TempDir(), "digest.tmpl")
+	assert.NoError(t, os.WriteFile(path, []byte("{{range .Groups}}{{.DataProduct}}:{{len .Reviews}} {{end}}"), 0o600))
+	renderer, err = NewRenderer(path)
+	assert.NoError(t, err)
+	body, err = renderer.Render(Build(pendingReviews(), time.Now()))
+	assert.NoError(t, err)
+	assert.Equal(t, "aggregate/bookings:1 source/analytics:2 Other files:1 ", body)
+
+	assert.NoError(t, os.WriteFile(path, []byte("{{.Broken"), 0o600))
+	_, err = NewRenderer(path)
+	assert.ErrorContains(t, err, "invalid digest template")
+	_, err = NewRenderer(filepath.Join(t.TempDir(), "missing.tmpl"))
+	assert.ErrorContains(t, err, "failed to read digest template")
+}
@@ -0,0 +1,140 @@
+package digest
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+	"go.uber.org/zap"
+)
+
+// lastSentKey holds the UTC day of the last digest sent, so restarts do not send it twice
+const lastSentKey = "digest/last_sent"
+
+// Job emails the digest of pending manual reviews once a day
+type Job struct {
+	source   Source
+	renderer *Renderer
+	mailer   Mailer
+	store    store.Store
+	cfg      config.DigestConfig
+	now      func() time.Time
+
+	mu   sync.Mutex
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewJob creates a daily digest job
+func NewJob(source Source, renderer *Renderer, mailer Mailer, st store.Store, cfg config.DigestConfig) *Job {
+	return &Job{source: source, renderer: renderer, mailer: mailer, store: st, cfg: cfg, now: time.Now}
+}
+
+// NewJobFromConfig returns a daily digest job, or nil when no recipients are configured
+func NewJobFromConfig(cfg *config.Config, st store.Store, source Source) (*Job, error) {
+	if len(cfg.Digest.Recipients) == 0 {
+		return nil, nil
+	}
+	if !cfg.SMTP.Enabled() {
+		return nil, fmt.Errorf("DIGEST_RECIPIENTS requires SMTP_HOST and SMTP_FROM")
+	}
+	renderer, err := NewRenderer(cfg.Digest.TemplateFile)
+	if err != nil {
+		return nil, err
+	}
+	return NewJob(source, renderer, NewSMTPMailer(cfg.SMTP), st, cfg.Digest), nil
+}
+
+// Check sends today's digest once the configured hour has passed, unless it was already
+// sent. Days without pending reviews send no email.
+func (j *Job) Check() {
+	now := j.now().UTC()
+	if now.Hour() < j.cfg.Hour {
+		return
+	}
+	day := now.Format("2006-01-02")
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	lastSent, _, err := j.store.Get(lastSentKey)
+	if err != nil {
+		logging.Warn("Failed to check manual review digest", zap.String("day", day), zap.Error(err))
+		return
+	}
+	if string(lastSent) == day {
+		return
+	}
+
+	reviews, err := j.source()
+	if err != nil {
+		logging.Error("Failed to list pending manual reviews for digest", zap.String("day", day), zap.Error(err))
+		return
+	}
+	if len(reviews) > 0 {
+		digest := Build(reviews, now)
+		body, err := j.renderer.Render(digest)
+		if err != nil {
+			logging.Error("Failed to render manual review digest", zap.String("day", day), zap.Error(err))
+			return
+		}
+		if err := j.mailer.Send(j.cfg.Recipients, digest.Subject(), body); err != nil {
+			logging.Error("Failed to send manual review digest", zap.String("day", day), zap.Error(err))
+			return
+		}
+		logging.Info("Sent manual review digest",
+			zap.String("day", day),
+			zap.Int("mrs", digest.Total),
+			zap.Int("groups", len(digest.Groups)),
+			zap.Int("recipients", len(j.cfg.Recipients)))
+	}
+	if err := j.store.Put(lastSentKey, []byte(day)); err != nil {
+		logging.Warn("Failed to record manual review digest", zap.String("day", day), zap.Error(err))
+	}
+}
+
+// Start checks now and then every interval until Stop is called
+func (j *Job) Start(interval time.Duration) {
+	j.mu.Lock()
+	if j.stop != nil {
+		j.mu.Unlock()
+		return
+	}
+	j.stop = make(chan struct{})
+	stop := j.stop
+	j.mu.Unlock()
+
+	j.wg.Add(1)
+	go func() {
+		defer j.wg.Done()
+		j.Check()
+		if interval <= 0 {
+			return
+		}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				j.Check()
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop ends periodic checks and waits for a running check to finish
+func (j *Job) Stop() {
+	j.mu.Lock()
+	stop := j.stop
+	j.stop = nil
+	j.mu.Unlock()
+
+	if stop != nil {
+		close(stop)
+		j.wg.Wait()
+	}
+}
@@ -0,0 +1,94 @@
+package digest
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+)
+
+type sentMail struct {
+	to      []string
+	subject string
+	body    string
+}
+
+type mockMailer struct {
+	sent []sentMail
+	err  error
+}
+
+func (m *mockMailer) Send(to []string, subject, body string) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.sent = append(m.sent, sentMail{to: to, subject: subject, body: body})
+	return nil
+}
+
+func newTestJob(t *testing.T, st store.Store, mailer Mailer, reviews []PendingReview, now time.Time) *Job {
+	renderer, err := NewRenderer("")
+	assert.NoError(t, err)
+	cfg := config.DigestConfig{Recipients: []string{"reviewers@example.com"}, Hour: 8}
+	job := NewJob(func() ([]PendingReview, error) { return reviews, nil }, renderer, mailer, st, cfg)
+	job.now = func() time.Time { return now }
+	return job
+}
+
+func TestJob_Check_SendsOncePerDay(t *testing.T) {
+	st := store.NewMemoryStore()
+	mailer := &mockMailer{}
+
+	newTestJob(t, st, mailer, pendingReviews(), time.Date(2026, 10, 15, 7, 59, 0, 0, time.UTC)).Check()
+	assert.Empty(t, mailer.sent, "not before the configured hour")
+
+	job := newTestJob(t, st, mailer, pendingReviews(), time.Date(2026, 10, 15, 8, 0, 0, 0, time.UTC))
+	job.Check()
+	assert.Len(t, mailer.sent, 1)
+	assert.Equal(t, []string{"reviewers@example.com"}, mailer.sent[0].to)
+	assert.Contains(t, mailer.sent[0].subject, "3 MRs")
+	assert.Contains(t, mailer.sent[0].body, "== aggregate/bookings ==")
+
+	job.Check()
+	newTestJob(t, st, mailer, pendingReviews(), time.Date(2026, 10, 15, 20, 0, 0, 0, time.UTC)).Check()
+	assert.Len(t, mailer.sent, 1, "a restarted job does not send the day's digest again")
+
+	newTestJob(t, st, mailer, pendingReviews(), time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)).Check()
+	assert.Len(t, mailer.sent, 2)
+}
+
+func TestJob_Check_NothingPending(t *testing.T) {
+	mailer := &mockMailer{}
+	newTestJob(t, store.NewMemoryStore(), mailer, nil, time.Date(2026, 10, 15, 9, 0, 0, 0, time.UTC)).Check()
+	assert.Empty(t, mailer.sent)
+}
+
+func TestJob_Check_RetriesFailedSend(t *testing.T) {
+	st := store.NewMemoryStore()
+	mailer := &mockMailer{err: errors.New("connection refused")}
+	job := newTestJob(t, st, mailer, pendingReviews(), time.Date(2026, 10, 15, 9, 0, 0, 0, time.UTC))
+
+	job.Check()
+	mailer.err = nil
+	job.Check()
+	assert.Len(t, mailer.sent, 1, "a failed digest is sent on the next check")
+}
+
+func TestNewJobFromConfig(t *testing.T) {
+	job, err := NewJobFromConfig(&config.Config{}, store.NewMemoryStore(), nil)
+	assert.NoError(t, err)
+	assert.Nil(t, job)
+
+	cfg := &config.Config{Digest: config.DigestConfig{Recipients: []string{"reviewers@example.com"}}}
+	_, err = NewJobFromConfig(cfg, store.NewMemoryStore(), nil)
+	assert.ErrorContains(t, err, "SMTP_HOST")
+
+	cfg.SMTP = config.SMTPConfig{Host: "smtp.example.com", Port: 587, From: "naysayer@example.com"}
+	job, err = NewJobFromConfig(cfg, store.NewMemoryStore(), nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, job)
+}
@@ -0,0 +1,63 @@
+package digest
+
+import (
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+)
+
+// Mailer sends plain-text emails
+type Mailer interface {
+	Send(to []string, subject, body string) error
+}
+
+// SMTPMailer sends emails through the configured mail server
+type SMTPMailer struct {
+	cfg      config.SMTPConfig
+	now      func() time.Time
+	sendMail func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// NewSMTPMailer creates a mailer for the SMTP server
+func NewSMTPMailer(cfg config.SMTPConfig) *SMTPMailer {
+	return &SMTPMailer{cfg: cfg, now: time.Now, sendMail: smtp.SendMail}
+}
+
+// Send sends a plain-text email to all recipients at once
+func (m *SMTPMailer) Send(to []string, subject, body string) error {
+	var auth smtp.Auth
+	if m.cfg.Username != "" {
+		auth = smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, m.cfg.Host)
+	}
+	addr := net.JoinHostPort(m.cfg.Host, strconv.Itoa(m.cfg.Port))
+	if err := m.sendMail(addr, auth, m.cfg.From, to, m.message(to, subject, body)); err != nil {
+		return fmt.Errorf("failed to send email via %s: %w", addr, err)
+	}
+	return nil
+}
+
+// message builds the RFC 5322 message with CRLF line endings
+func (m *SMTPMailer) message(to []string, subject, body string) []byte {
+	var msg strings.Builder
+	headers := [][2]string{
+		{"From", m.cfg.From},
+		{"To", strings.Join(to, ", ")},
+		{"Subject", mime.QEncoding.Encode("utf-8", subject)},
+		{"Date", m.now().Format(time.RFC1123Z)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", "text/plain; charset=utf-8"},
+		{"Content-Transfer-Encoding", "8bit"},
+	}
+	for _, header := range headers {
+		fmt.Fprintf(&msg, "%s: %s\r\n", header[0], header[1])
+	}
+	msg.WriteString("\r\n")
+	msg.WriteString(strings.ReplaceAll(strings.ReplaceAll(body, "\r\n", "\n"), "\n", "\r\n"))
+	return []byte(msg.String())
+}
@@ -0,0 +1,48 @@
+package digest
+
+import (
+	"errors"
+	"net/smtp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+)
+
+func TestSMTPMailer_Send(t *testing.T) {
+	mailer := NewSMTPMailer(config.SMTPConfig{Host: "smtp.example.com", Port: 587, Username: "bot", Password: "secret", From: "naysayer@example.com"})
+	mailer.now = func() time.Time { return time.Date(2026, 10, 15, 8, 0, 0, 0, time.UTC) }
+	var addr, from string
+	var to []string
+	var msg []byte
+	var auth smtp.Auth
+	mailer.sendMail = func(a string, au smtp.Auth, f string, t []string, m []byte) error {
+		addr, auth, from, to, msg = a, au, f, t, m
+		return nil
+	}
+
+	err := mailer.Send([]string{"a@example.com", "b@example.com"}, "Pending reviews ✓", "line one\nline two\n")
+	assert.NoError(t, err)
+	assert.Equal(t, "smtp.example.com:587", addr)
+	assert.NotNil(t, auth)
+	assert.Equal(t, "naysayer@example.com", from)
+	assert.Equal(t, []string{"a@example.com", "b@example.com"}, to)
+
+	headers, body, _ := strings.Cut(string(msg), "\r\n\r\n")
+	assert.Contains(t, headers, "To: a@example.com, b@example.com\r\n")
+	assert.Contains(t, headers, "Subject: =?utf-8?q?Pending_reviews_=E2=9C=93?=\r\n")
+	assert.Contains(t, headers, "Date: Thu, 15 Oct 2026 08:00:00 +0000")
+	assert.Equal(t, "line one\r\nline two\r\n", body)
+}
+
+func TestSMTPMailer_SendError(t *testing.T) {
+	mailer := NewSMTPMailer(config.SMTPConfig{Host: "smtp.example.com", Port: 25, From: "naysayer@example.com"})
+	mailer.sendMail = func(_ string, auth smtp.Auth, _ string, _ []string, _ []byte) error {
+		assert.Nil(t, auth, "no authentication without a username")
+		return errors.New("connection refused")
+	}
+	assert.ErrorContains(t, mailer.Send([]string{"a@example.com"}, "s", "b"), "smtp.example.com:25")
+}
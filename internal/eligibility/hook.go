@@ -0,0 +1,92 @@
+package eligibility
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+)
+
+// Request describes the MR an eligibility hook decides on. HTTP hooks receive it as the
+// JSON body; plugins receive its JSON fields as a map.
+type Request struct {
+	ProjectID    int      `json:"project_id"`
+	MRIID        int      `json:"mr_iid"`
+	Title        string   `json:"title"`
+	SourceBranch string   `json:"source_branch"`
+	TargetBranch string   `json:"target_branch"`
+	SHA          string   `json:"sha"`
+	Labels       []string `json:"labels"`
+	Author       string   `json:"author,omitempty"`
+}
+
+// NewRequest builds the request for an MR of a project
+func NewRequest(projectID int, mr gitlab.MRDetails) Request {
+	req := Request{
+		ProjectID:    projectID,
+		MRIID:        mr.IID,
+		Title:        mr.Title,
+		SourceBranch: mr.SourceBranch,
+		TargetBranch: mr.TargetBranch,
+		SHA:          mr.Sha,
+		Labels:       mr.Labels,
+	}
+	if req.Labels == nil {
+		req.Labels = []string{}
+	}
+	if mr.Author != nil {
+		req.Author = mr.Author.Username
+	}
+	return req
+}
+
+// Hook is an additional auto-rebase eligibility check. It returns a non-empty skip
+// reason when the MR must not be rebased.
+type Hook interface {
+	Name() string
+	Check(req Request) (reason string, err error)
+}
+
+// Check runs the hooks in order and returns the reason of the first one skipping the
+// MR. A failing hook skips the MR with reason "<name>_error".
+func Check(hooks []Hook, req Request) (reason string, err error) {
+	for _, hook := range hooks {
+		reason, err := hook.Check(req)
+		if err != nil {
+			return hook.Name() + "_error", fmt.Errorf("eligibility hook %s: %w", hook.Name(), err)
+		}
+		if reason != "" {
+			return reason, nil
+		}
+	}
+	return "", nil
+}
+
+// HooksFromConfig builds the label, HTTP and plugin hooks configured for auto-rebase
+func HooksFromConfig(cfg config.AutoRebaseConfig) ([]Hook, error) {
+	hooks := make([]Hook, 0)
+	if len(cfg.SkipLabels) > 0 {
+		hooks = append(hooks, NewLabelHook(cfg.SkipLabels))
+	}
+
+	timeout := time.Duration(cfg.EligibilityHookTimeout) * time.Second
+	for _, entry := range cfg.EligibilityHooks {
+		name, url, found := strings.Cut(entry, "=")
+		name, url = strings.TrimSpace(name), strings.TrimSpace(url)
+		if !found || name == "" || url == "" {
+			return nil, fmt.Errorf("invalid eligibility hook %q: expected <name>=<url>", entry)
+		}
+		hooks = append(hooks, NewHTTPHook(name, url, timeout))
+	}
+
+	for _, path := range cfg.EligibilityPlugins {
+		hook, err := LoadPlugin(path)
+		if err != nil {
+			return nil, err
+		}
+		hooks = append(hooks, hook)
+	}
+	return hooks, nil
+}
@@ -0,0 +1,57 @@
+package eligibility
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+)
+
+func TestNewRequest(t *testing.T) {
+	req := NewRequest(42, gitlab.MRDetails{
+		IID: 7, Title: "Bump warehouse", SourceBranch: "feature", TargetBranch: "main", Sha: "abc",
+		Author: &gitlab.MRUser{Username: "dev"},
+	})
+	assert.Equal(t, Request{
+		ProjectID: 42, MRIID: 7, Title: "Bump warehouse", SourceBranch: "feature", TargetBranch: "main",
+		SHA: "abc", Labels: []string{}, Author: "dev",
+	}, req)
+}
+
+func TestCheck_FirstSkipWins(t *testing.T) {
+	allow := NewPluginHook("allow", func(map[string]interface{}) (bool, string, error) { return false, "", nil })
+	freeze := NewPluginHook("freeze", func(map[string]interface{}) (bool, string, error) { return true, "change_freeze", nil })
+	broken := NewPluginHook("broken", func(map[string]interface{}) (bool, string, error) { return false, "", errors.New("boom") })
+
+	reason, err := Check([]Hook{allow, freeze, broken}, Request{})
+	assert.NoError(t, err)
+	assert.Equal(t, "change_freeze", reason)
+
+	reason, err = Check([]Hook{allow, broken, freeze}, Request{})
+	assert.Error(t, err)
+	assert.Equal(t, "broken_error", reason, "failing hooks skip the MR")
+
+	reason, err = Check(nil, Request{})
+	assert.NoError(t, err)
+	assert.Empty(t, reason)
+}
+
+func TestHooksFromConfig(t *testing.T) {
+	hooks, err := HooksFromConfig(config.AutoRebaseConfig{
+		SkipLabels:       []string{"needs-design-review"},
+		EligibilityHooks: []string{"freeze=https://freeze.example.com/check"},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, hooks, 2)
+	assert.Equal(t, "skip_labels", hooks[0].Name())
+	assert.Equal(t, "freeze", hooks[1].Name())
+
+	_, err = HooksFromConfig(config.AutoRebaseConfig{EligibilityHooks: []string{"https://freeze.example.com/check"}})
+	assert.Error(t, err)
+
+	_, err = HooksFromConfig(config.AutoRebaseConfig{EligibilityPlugins: []string{"/nonexistent/hook.so"}})
+	assert.Error(t, err)
+}
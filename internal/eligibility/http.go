@@ -0,0 +1,70 @@
+package eligibility
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// HTTPResponse is the answer of an HTTP eligibility check
+type HTTPResponse struct {
+	Eligible bool   `json:"eligible"`
+	Reason   string `json:"reason"`
+}
+
+// HTTPHook asks an external service, e.g. a change freeze API, whether an MR may be rebased
+type HTTPHook struct {
+	name   string
+	url    string
+	client *http.Client
+}
+
+// NewHTTPHook creates a hook posting requests to url
+func NewHTTPHook(name, url string, timeout time.Duration) *HTTPHook {
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	return &HTTPHook{
+		name:   name,
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Name identifies the hook in skip reasons and logs
+func (h *HTTPHook) Name() string {
+	return h.name
+}
+
+// Check posts the request and returns the service's reason when it answers
+// {"eligible": false}. Without a reason the MR is skipped with "hook_<name>".
+func (h *HTTPHook) Check(req Request) (string, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	resp, err := h.client.Post(h.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode >= 300 {
+		return "", fmt.Errorf("returned status %d", resp.StatusCode)
+	}
+
+	var answer HTTPResponse
+	if err := json.NewDecoder(resp.Body).Decode(&answer); err != nil {
+		return "", fmt.Errorf("invalid response: %w", err)
+	}
+	if answer.Eligible {
+		return "", nil
+	}
+	if answer.Reason == "" {
+		return "hook_" + h.name, nil
+	}
+	return answer.Reason, nil
+}
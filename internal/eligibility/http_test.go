@@ -0,0 +1,57 @@
+package eligibility
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPHook_Check(t *testing.T) {
+	var received Request
+	answer := `{"eligible": false, "reason": "change_freeze"}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		_ = json.NewDecoder(r.Body).Decode(&received)
+		_, _ = w.Write([]byte(answer))
+	}))
+	defer server.Close()
+
+	hook := NewHTTPHook("freeze", server.URL, time.Second)
+	reason, err := hook.Check(Request{ProjectID: 42, MRIID: 7, Labels: []string{"backend"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "change_freeze", reason)
+	assert.Equal(t, 7, received.MRIID)
+	assert.Equal(t, []string{"backend"}, received.Labels)
+
+	answer = `{"eligible": false}`
+	reason, err = hook.Check(Request{})
+	assert.NoError(t, err)
+	assert.Equal(t, "hook_freeze", reason)
+
+	answer = `{"eligible": true}`
+	reason, err = hook.Check(Request{})
+	assert.NoError(t, err)
+	assert.Empty(t, reason)
+}
+
+func TestHTTPHook_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	_, err := NewHTTPHook("freeze", server.URL, time.Second).Check(Request{})
+	assert.ErrorContains(t, err, "status 503")
+
+	invalid := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("not json"))
+	}))
+	defer invalid.Close()
+
+	_, err = NewHTTPHook("freeze", invalid.URL, time.Second).Check(Request{})
+	assert.ErrorContains(t, err, "invalid response")
+}
@@ -0,0 +1,43 @@
+package eligibility
+
+import (
+	"strings"
+)
+
+// LabelHook skips MRs carrying one of the configured labels
+type LabelHook struct {
+	reasons map[string]string // lower-cased label -> skip reason
+}
+
+// NewLabelHook creates a hook from "<label>[=<reason>]" entries. Without a reason the MR
+// is skipped with "label_<label>".
+func NewLabelHook(entries []string) *LabelHook {
+	hook := &LabelHook{reasons: make(map[string]string)}
+	for _, entry := range entries {
+		label, reason, _ := strings.Cut(entry, "=")
+		label, reason = strings.TrimSpace(label), strings.TrimSpace(reason)
+		if label == "" {
+			continue
+		}
+		if reason == "" {
+			reason = "label_" + label
+		}
+		hook.reasons[strings.ToLower(label)] = reason
+	}
+	return hook
+}
+
+// Name identifies the hook in skip reasons and logs
+func (h *LabelHook) Name() string {
+	return "skip_labels"
+}
+
+// Check returns the reason of the first configured label on the MR
+func (h *LabelHook) Check(req Request) (string, error) {
+	for _, label := range req.Labels {
+		if reason, ok := h.reasons[strings.ToLower(label)]; ok {
+			return reason, nil
+		}
+	}
+	return "", nil
+}
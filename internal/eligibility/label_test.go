@@ -0,0 +1,21 @@
+package eligibility
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLabelHook_Check(t *testing.T) {
+	hook := NewLabelHook([]string{"needs-design-review=design_review_pending", " WIP ", ""})
+
+	reason, err := hook.Check(Request{Labels: []string{"backend", "Needs-Design-Review"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "design_review_pending", reason)
+
+	reason, _ = hook.Check(Request{Labels: []string{"wip"}})
+	assert.Equal(t, "label_WIP", reason)
+
+	reason, _ = hook.Check(Request{Labels: []string{"backend"}})
+	assert.Empty(t, reason)
+}
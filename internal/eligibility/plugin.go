@@ -0,0 +1,72 @@
+package eligibility
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"plugin"
+	"strings"
+)
+
+// PluginSymbol is the function a Go plugin exports to act as an eligibility hook. It
+// receives the request fields (see Request) and returns whether to skip the MR and why.
+const PluginSymbol = "CheckEligibility"
+
+// PluginFunc is the signature of PluginSymbol
+type PluginFunc = func(request map[string]interface{}) (skip bool, reason string, err error)
+
+// PluginHook runs an eligibility check compiled as a Go plugin
+type PluginHook struct {
+	name  string
+	check PluginFunc
+}
+
+// NewPluginHook wraps a plugin function; used by LoadPlugin and tests
+func NewPluginHook(name string, check PluginFunc) *PluginHook {
+	return &PluginHook{name: name, check: check}
+}
+
+// LoadPlugin opens a Go plugin (.so) and looks up its CheckEligibility function. The hook
+// is named after the file.
+func LoadPlugin(path string) (*PluginHook, error) {
+	p, err := plugin.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open eligibility plugin %s: %w", path, err)
+	}
+	symbol, err := p.Lookup(PluginSymbol)
+	if err != nil {
+		return nil, fmt.Errorf("eligibility plugin %s: %w", path, err)
+	}
+	check, ok := symbol.(PluginFunc)
+	if !ok {
+		return nil, fmt.Errorf("eligibility plugin %s: %s has type %T, expected %T", path, PluginSymbol, symbol, PluginFunc(nil))
+	}
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	return NewPluginHook(name, check), nil
+}
+
+// Name identifies the hook in skip reasons and logs
+func (h *PluginHook) Name() string {
+	return h.name
+}
+
+// Check calls the plugin. Without a reason a skipped MR gets "plugin_<name>".
+func (h *PluginHook) Check(req Request) (string, error) {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return "", err
+	}
+
+	skip, reason, err := h.check(fields)
+	if err != nil || !skip {
+		return "", err
+	}
+	if reason == "" {
+		return "plugin_" + h.name, nil
+	}
+	return reason, nil
+}
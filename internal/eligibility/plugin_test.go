@@ -0,0 +1,29 @@
+package eligibility
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPluginHook_Check(t *testing.T) {
+	var received map[string]interface{}
+	hook := NewPluginHook("design", func(request map[string]interface{}) (bool, string, error) {
+		received = request
+		return request["target_branch"] == "release", "", nil
+	})
+
+	reason, err := hook.Check(Request{MRIID: 7, TargetBranch: "release"})
+	assert.NoError(t, err)
+	assert.Equal(t, "plugin_design", reason)
+	assert.Equal(t, float64(7), received["mr_iid"])
+
+	reason, err = hook.Check(Request{TargetBranch: "main"})
+	assert.NoError(t, err)
+	assert.Empty(t, reason)
+}
+
+func TestLoadPlugin_Missing(t *testing.T) {
+	_, err := LoadPlugin("/nonexistent/design.so")
+	assert.ErrorContains(t, err, "failed to open eligibility plugin")
+}
@@ -1,9 +1,11 @@
 package errors
 
 import (
+	stderrors "errors"
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
 	"github.com/redhat-data-and-ai/naysayer/internal/logging"
 	"go.uber.org/zap"
 )
@@ -82,6 +84,11 @@ func (h *Handler) toAppError(err error) *AppError {
 		return appErr
 	}
 
+	// Typed GitLab errors carry their semantics; prefer them over message matching
+	if code, message, ok := classifyGitLabError(err); ok {
+		return NewErrorWithCause(code, message, err)
+	}
+
 	// Check for common error patterns and classify them
 	errStr := strings.ToLower(err.Error())
 
@@ -196,13 +203,13 @@ func (h *Handler) logError(appErr *AppError, requestID string, c *fiber.Ctx) {
 	// Log with appropriate level based on severity
 	switch appErr.Severity {
 	case SeverityLow:
-		logging.Info(appErr.Message, toInterfaceSlice(fields)...)
+		logging.Info(appErr.Message, fields...)
 	case SeverityMedium:
-		logging.Warn(appErr.Message, toInterfaceSlice(fields)...)
+		logging.Warn(appErr.Message, fields...)
 	case SeverityHigh, SeverityCritical:
-		logging.Error(appErr.Message, toInterfaceSlice(fields)...)
+		logging.Error(appErr.Message, fields...)
 	default:
-		logging.Error(appErr.Message, toInterfaceSlice(fields)...)
+		logging.Error(appErr.Message, fields...)
 	}
 }
 
@@ -227,20 +234,11 @@ func (h *Handler) LogAndWrap(err error, code ErrorCode, message string, context
 		zap.Error(err),
 	}, context...)
 
-	logging.Error(message, toInterfaceSlice(fields)...)
+	logging.Error(message, fields...)
 
 	return appErr
 }
 
-// toInterfaceSlice converts zap.Field slice to interface{} slice
-func toInterfaceSlice(fields []zap.Field) []interface{} {
-	result := make([]interface{}, len(fields))
-	for i, field := range fields {
-		result[i] = field
-	}
-	return result
-}
-
 // RecoverAndHandle handles panics by converting them to errors
 func (h *Handler) RecoverAndHandle(c *fiber.Ctx) {
 	if r := recover(); r != nil {
@@ -267,3 +265,19 @@ func (h *Handler) RecoverAndHandle(c *fiber.Ctx) {
 		_ = h.HandleError(c, err)
 	}
 }
+
+// classifyGitLabError maps typed GitLab client errors to error codes
+func classifyGitLabError(err error) (ErrorCode, string, bool) {
+	var apiErr *gitlab.APIError
+	switch {
+	case stderrors.Is(err, gitlab.ErrPermission):
+		return ErrGitLabAuth, "Authentication failed", true
+	case stderrors.Is(err, gitlab.ErrNotFound):
+		return ErrGitLabNotFound, "Resource not found", true
+	case stderrors.As(err, new(*gitlab.RateLimitedError)):
+		return ErrGitLabRateLimit, "GitLab rate limit exceeded", true
+	case stderrors.As(err, &apiErr) && apiErr.StatusCode >= 500:
+		return ErrGitLabAPIFailed, "GitLab API request failed", true
+	}
+	return "", "", false
+}
@@ -78,6 +78,10 @@ func GitLabRetryCondition(err error) bool {
 		}
 	}
 
+	if code, _, ok := classifyGitLabError(err); ok {
+		return code == ErrGitLabRateLimit || code == ErrGitLabAPIFailed
+	}
+
 	return IsTemporaryError(err)
 }
 
@@ -0,0 +1,169 @@
+package flapping
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/notify"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+	"go.uber.org/zap"
+)
+
+// keyPrefix is the state store namespace for per-MR decision history
+const keyPrefix = "flapping/"
+
+// EventDecisionFlapping is the notification event sent when an MR starts flapping
+const EventDecisionFlapping = "decision_flapping"
+
+// mrState is the persisted decision history of one MR
+type mrState struct {
+	LastDecision shared.DecisionType `json:"last_decision"`
+	Flips        []time.Time         `json:"flips"`   // Times the decision changed, within the window
+	Alerted      bool                `json:"alerted"` // Notification sent for the current flapping episode
+	Frozen       bool                `json:"frozen"`  // Auto-approval frozen pending human review
+}
+
+// Status describes an MR's flapping state after recording a decision
+type Status struct {
+	Flips    int  // Decision changes within the window
+	Flapping bool // Flips exceeded the threshold
+	Frozen   bool // Auto-approval is frozen for this MR
+}
+
+// Detector tracks approve/manual-review flips per MR and alerts when they exceed a threshold
+type Detector struct {
+	store     store.Store
+	sink      notify.Sink
+	threshold int
+	window    time.Duration
+	freeze    bool
+	now       func() time.Time
+
+	mu sync.Mutex
+}
+
+// NewDetector creates a flapping detector persisting history in st
+func NewDetector(st store.Store, sink notify.Sink, cfg config.FlappingConfig) *Detector {
+	window := time.Duration(cfg.WindowMinutes) * time.Minute
+	if window <= 0 {
+		window = time.Hour
+	}
+	return &Detector{
+		store:     st,
+		sink:      sink,
+		threshold: cfg.Threshold,
+		window:    window,
+		freeze:    cfg.FreezeAutoApproval,
+		now:       time.Now,
+	}
+}
+
+// stateKey returns the state store key for an MR
+func stateKey(projectID, mrIID int) string {
+	return fmt.Sprintf("%s%d/%d", keyPrefix, projectID, mrIID)
+}
+
+// Record adds an evaluated decision to the MR's history. It notifies the sink when the
+// number of flips within the window exceeds the threshold and freezes auto-approval if configured.
+func (d *Detector) Record(projectID, mrIID int, decision shared.DecisionType) (Status, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	key := stateKey(projectID, mrIID)
+	var state mrState
+	if _, err := store.GetJSON(d.store, key, &state); err != nil {
+		return Status{}, err
+	}
+
+	now := d.now()
+	if state.LastDecision != "" && state.LastDecision != decision {
+		state.Flips = append(state.Flips, now)
+	}
+	state.LastDecision = decision
+
+	cutoff := now.Add(-d.window)
+	recent := state.Flips[:0]
+	for _, flip := range state.Flips {
+		if flip.After(cutoff) {
+			recent = append(recent, flip)
+		}
+	}
+	state.Flips = recent
+
+	flapping := d.threshold > 0 && len(state.Flips) > d.threshold
+	if flapping && !state.Alerted {
+		state.Alerted = true
+		if d.freeze {
+			state.Frozen = true
+		}
+		d.alert(projectID, mrIID, len(state.Flips), state.Frozen)
+	} else if !flapping {
+		state.Alerted = false
+	}
+
+	if err := store.PutJSON(d.store, key, &state); err != nil {
+		return Status{}, err
+	}
+	return Status{Flips: len(state.Flips), Flapping: flapping, Frozen: state.Frozen}, nil
+}
+
+// alert sends the flapping notification
+func (d *Detector) alert(projectID, mrIID, flips int, frozen bool) {
+	message := fmt.Sprintf("Decision flipped %d times between approve and manual review within %s. This usually indicates force pushes or flaky external checks.", flips, d.window)
+	if frozen {
+		message += " Auto-approval is frozen for this MR pending human review."
+	}
+
+	err := d.sink.Notify(notify.Notification{
+		Event:     EventDecisionFlapping,
+		Severity:  notify.SeverityWarning,
+		Title:     "MR decision flapping",
+		Message:   message,
+		ProjectID: projectID,
+		MRIID:     mrIID,
+		Fields: map[string]string{
+			"flips":  fmt.Sprintf("%d", flips),
+			"window": d.window.String(),
+			"frozen": fmt.Sprintf("%t", frozen),
+		},
+		Timestamp: d.now().UTC(),
+	})
+	if err != nil {
+		logging.Warn("Failed to send flapping notification",
+			zap.Int("project_id", projectID),
+			zap.Int("mr_iid", mrIID),
+			zap.Error(err))
+	}
+}
+
+// IsFrozen reports whether auto-approval is frozen for an MR
+func (d *Detector) IsFrozen(projectID, mrIID int) bool {
+	var state mrState
+	found, err := store.GetJSON(d.store, stateKey(projectID, mrIID), &state)
+	return err == nil && found && state.Frozen
+}
+
+// Unfreeze lifts an auto-approval freeze after human review and resets the flip history
+func (d *Detector) Unfreeze(projectID, mrIID int) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	key := stateKey(projectID, mrIID)
+	var state mrState
+	if _, err := store.GetJSON(d.store, key, &state); err != nil {
+		return err
+	}
+	state.Frozen = false
+	state.Alerted = false
+	state.Flips = nil
+	return store.PutJSON(d.store, key, &state)
+}
+
+// Clear drops an MR's history, e.g. once it is merged or closed
+func (d *Detector) Clear(projectID, mrIID int) error {
+	return d.store.Delete(stateKey(projectID, mrIID))
+}
@@ -0,0 +1,104 @@
+package flapping
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/notify"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+)
+
+// recordingSink captures notifications
+type recordingSink struct {
+	notifications []notify.Notification
+}
+
+func (s *recordingSink) Notify(n notify.Notification) error {
+	s.notifications = append(s.notifications, n)
+	return nil
+}
+
+func newTestDetector(freeze bool) (*Detector, *recordingSink, *time.Time) {
+	sink := &recordingSink{}
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	d := NewDetector(store.NewMemoryStore(), sink, config.FlappingConfig{Threshold: 2, WindowMinutes: 60, FreezeAutoApproval: freeze})
+	d.now = func() time.Time { return now }
+	return d, sink, &now
+}
+
+func TestDetector_AlertsWhenFlipsExceedThreshold(t *testing.T) {
+	d, sink, now := newTestDetector(false)
+
+	decisions := []shared.DecisionType{shared.Approve, shared.ManualReview, shared.Approve}
+	for _, decision := range decisions {
+		status, err := d.Record(1, 10, decision)
+		assert.NoError(t, err)
+		assert.False(t, status.Flapping)
+		*now = now.Add(time.Minute)
+	}
+	assert.Empty(t, sink.notifications)
+
+	// Third flip exceeds the threshold of 2
+	status, err := d.Record(1, 10, shared.ManualReview)
+	assert.NoError(t, err)
+	assert.True(t, status.Flapping)
+	assert.Equal(t, 3, status.Flips)
+	assert.False(t, status.Frozen)
+	assert.Len(t, sink.notifications, 1)
+	assert.Equal(t, EventDecisionFlapping, sink.notifications[0].Event)
+	assert.Equal(t, 10, sink.notifications[0].MRIID)
+
+	// Still flapping: no duplicate alert
+	_, _ = d.Record(1, 10, shared.Approve)
+	assert.Len(t, sink.notifications, 1)
+}
+
+func TestDetector_RepeatedSameDecisionIsNotAFlip(t *testing.T) {
+	d, sink, _ := newTestDetector(false)
+	for i := 0; i < 5; i++ {
+		status, err := d.Record(1, 10, shared.Approve)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, status.Flips)
+	}
+	assert.Empty(t, sink.notifications)
+}
+
+func TestDetector_FlipsOutsideWindowExpire(t *testing.T) {
+	d, _, now := newTestDetector(false)
+	_, _ = d.Record(1, 10, shared.Approve)
+	_, _ = d.Record(1, 10, shared.ManualReview)
+	_, _ = d.Record(1, 10, shared.Approve)
+
+	*now = now.Add(2 * time.Hour)
+	status, err := d.Record(1, 10, shared.ManualReview)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, status.Flips)
+	assert.False(t, status.Flapping)
+}
+
+func TestDetector_FreezeAndUnfreeze(t *testing.T) {
+	d, sink, _ := newTestDetector(true)
+	for _, decision := range []shared.DecisionType{shared.Approve, shared.ManualReview, shared.Approve, shared.ManualReview} {
+		_, _ = d.Record(1, 10, decision)
+	}
+
+	assert.True(t, d.IsFrozen(1, 10))
+	assert.Contains(t, sink.notifications[0].Message, "frozen")
+	assert.False(t, d.IsFrozen(1, 11))
+
+	// Freeze outlives the flapping episode until a human unfreezes it
+	status, _ := d.Record(1, 10, shared.ManualReview)
+	assert.True(t, status.Frozen)
+
+	assert.NoError(t, d.Unfreeze(1, 10))
+	assert.False(t, d.IsFrozen(1, 10))
+
+	_, _ = d.Record(1, 10, shared.Approve)
+	assert.NoError(t, d.Clear(1, 10))
+	status, _ = d.Record(1, 10, shared.ManualReview)
+	assert.Equal(t, 0, status.Flips)
+}
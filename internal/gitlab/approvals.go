@@ -0,0 +1,64 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// MRApprovals is the approval state of a merge request
+type MRApprovals struct {
+	ApprovedBy    []Approver // Users who approved the MR, including naysayer
+	ApprovalsLeft int        // Approvals still required by the project approval rules
+}
+
+// Approver is a user who approved a merge request
+type Approver struct {
+	ID       int
+	Username string
+}
+
+// GetMRApprovals returns the users who approved a merge request
+// GET /projects/:id/merge_requests/:merge_request_iid/approvals
+func (c *Client) GetMRApprovals(ctx context.Context, projectID, mrIID int) (*MRApprovals, error) {
+	apiURL := c.apiURL("/projects/%d/merge_requests/%d/approvals", projectID, mrIID)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create approvals request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get MR approvals: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, newAPIError(resp, "get MR approvals failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var state struct {
+		ApprovedBy []struct {
+			User struct {
+				ID       int    `json:"id"`
+				Username string `json:"username"`
+			} `json:"user"`
+		} `json:"approved_by"`
+		ApprovalsLeft int `json:"approvals_left"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&state); err != nil {
+		return nil, fmt.Errorf("failed to decode MR approvals response: %w", err)
+	}
+
+	approvals := &MRApprovals{ApprovedBy: make([]Approver, 0, len(state.ApprovedBy)), ApprovalsLeft: state.ApprovalsLeft}
+	for _, approval := range state.ApprovedBy {
+		approvals.ApprovedBy = append(approvals.ApprovedBy, Approver{ID: approval.User.ID, Username: approval.User.Username})
+	}
+	return approvals, nil
+}
@@ -0,0 +1,35 @@
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_GetMRApprovals(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v4/projects/42/merge_requests/7/approvals" {
+			_, _ = w.Write([]byte(`{"approvals_left": 1, "approved_by": [
+				{"user": {"id": 1, "username": "naysayer-bot"}},
+				{"user": {"id": 5, "username": "alice"}}
+			]}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+	approvals, err := client.GetMRApprovals(context.Background(), 42, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, []Approver{{ID: 1, Username: "naysayer-bot"}, {ID: 5, Username: "alice"}}, approvals.ApprovedBy)
+	assert.Equal(t, 1, approvals.ApprovalsLeft)
+
+	_, err = client.GetMRApprovals(context.Background(), 42, 8)
+	assert.True(t, errors.Is(err, ErrNotFound))
+}
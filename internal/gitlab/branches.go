@@ -0,0 +1,131 @@
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"go.uber.org/zap"
+)
+
+// Branch represents a repository branch from the branches API
+type Branch struct {
+	Name      string       `json:"name"`
+	Merged    bool         `json:"merged"`    // Merged into the default branch
+	Protected bool         `json:"protected"` // Covered by a protected branch rule
+	Default   bool         `json:"default"`   // The project's default branch
+	Commit    BranchCommit `json:"commit"`
+}
+
+// BranchCommit is the head commit of a branch
+type BranchCommit struct {
+	ID            string `json:"id"`
+	CommittedDate string `json:"committed_date"`
+	AuthorName    string `json:"author_name"`
+}
+
+// ListBranches returns every branch of a project (all pages).
+// GET /projects/:id/repository/branches
+func (c *Client) ListBranches(ctx context.Context, projectID int) ([]Branch, error) {
+	var branches []Branch
+	apiURL := c.apiURL("/projects/%d/repository/branches?per_page=100", projectID)
+
+	for apiURL != "" {
+		req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create branches request: %w", err)
+		}
+
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := c.do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list branches: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(resp.Body)
+			_ = resp.Body.Close()
+			return nil, newAPIError(resp, "list branches failed with status %d: %s", resp.StatusCode, string(body))
+		}
+
+		var page []Branch
+		err = json.NewDecoder(resp.Body).Decode(&page)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode branches response: %w", err)
+		}
+
+		branches = append(branches, page...)
+		apiURL = parseNextLink(resp.Header.Get("Link"))
+	}
+
+	return branches, nil
+}
+
+// DeleteBranch deletes a branch. GitLab refuses to delete protected and default branches.
+// DELETE /projects/:id/repository/branches/:branch
+func (c *Client) DeleteBranch(ctx context.Context, projectID int, branch string) error {
+	apiURL := c.apiURL("/projects/%d/repository/branches/%s", projectID, url.PathEscape(branch))
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", apiURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create delete branch request: %w", err)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to delete branch: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusNoContent {
+		body, _ := io.ReadAll(resp.Body)
+		return newAPIError(resp, "delete branch failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	logging.Info("Deleted branch", zap.String("branch", branch), zap.Int("project_id", projectID))
+	return nil
+}
+
+// CreateBranch creates a branch from ref (a branch name or commit SHA).
+// POST /projects/:id/repository/branches
+func (c *Client) CreateBranch(ctx context.Context, projectID int, branch, ref string) (*Branch, error) {
+	apiURL := c.apiURL("/projects/%d/repository/branches", projectID)
+
+	jsonPayload, err := json.Marshal(map[string]string{"branch": branch, "ref": ref})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal create branch payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", apiURL, bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create branch request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create branch: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, newAPIError(resp, "create branch failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var created Branch
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		return nil, fmt.Errorf("failed to decode create branch response: %w", err)
+	}
+
+	logging.Info("Created branch", zap.String("branch", branch), zap.String("ref", ref), zap.Int("project_id", projectID))
+	return &created, nil
+}
@@ -0,0 +1,91 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_ListBranches_Pagination(t *testing.T) {
+	var serverURL string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/42/repository/branches", r.URL.Path)
+		assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("page") == "2" {
+			_, _ = w.Write([]byte(`[{"name": "feature/old", "merged": true, "commit": {"id": "c2", "committed_date": "2026-01-02T10:00:00Z"}}]`))
+			return
+		}
+		w.Header().Set("Link", fmt.Sprintf(`<%s/api/v4/projects/42/repository/branches?per_page=100&page=2>; rel="next"`, serverURL))
+		_ = json.NewEncoder(w).Encode([]Branch{
+			{Name: "main", Default: true, Protected: true, Commit: BranchCommit{ID: "c1"}},
+		})
+	}))
+	defer server.Close()
+	serverURL = server.URL
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+	branches, err := client.ListBranches(context.Background(), 42)
+
+	assert.NoError(t, err)
+	assert.Len(t, branches, 2)
+	assert.True(t, branches[0].Default)
+	assert.Equal(t, "feature/old", branches[1].Name)
+	assert.True(t, branches[1].Merged)
+	assert.Equal(t, "2026-01-02T10:00:00Z", branches[1].Commit.CommittedDate)
+}
+
+func TestClient_DeleteBranch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "DELETE", r.Method)
+		if r.URL.EscapedPath() == "/api/v4/projects/42/repository/branches/feature%2Fold" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message":"403 Forbidden"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+	assert.NoError(t, client.DeleteBranch(context.Background(), 42, "feature/old"))
+
+	err := client.DeleteBranch(context.Background(), 42, "main")
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, ErrPermission))
+}
+
+func TestClient_CreateBranch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/api/v4/projects/42/repository/branches", r.URL.Path)
+		var payload map[string]string
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		if payload["branch"] == "main" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"Branch already exists"}`))
+			return
+		}
+		assert.Equal(t, map[string]string{"branch": "selftest", "ref": "main"}, payload)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"name": "selftest", "commit": {"id": "c1"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+	branch, err := client.CreateBranch(context.Background(), 42, "selftest", "main")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "c1", branch.Commit.ID)
+
+	_, err = client.CreateBranch(context.Background(), 42, "main", "main")
+	assert.Error(t, err)
+}
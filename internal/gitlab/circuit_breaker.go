@@ -0,0 +1,84 @@
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"go.uber.org/zap"
+)
+
+// circuitBreaker fast-fails requests while GitLab is unhealthy. After threshold consecutive
+// failed requests (network errors, timeouts or 5xx answers once retries are exhausted) the
+// circuit opens for the cooldown; then a single trial request is let through, closing the
+// circuit when it succeeds and reopening it when it fails.
+type circuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	openUntil time.Time
+	trial     bool // A trial request is in flight after the cooldown
+	now       func() time.Time
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	if threshold <= 0 {
+		return nil
+	}
+	return &circuitBreaker{threshold: threshold, cooldown: cooldown, now: time.Now}
+}
+
+// allow returns ErrUnavailable while the circuit is open
+func (b *circuitBreaker) allow() error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.threshold {
+		return nil
+	}
+	if b.trial || b.now().Before(b.openUntil) {
+		return ErrUnavailable
+	}
+	b.trial = true
+	return nil
+}
+
+// record counts the outcome of a request let through by allow
+func (b *circuitBreaker) record(resp *http.Response, err error) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.trial = false
+	// Requests cancelled by the caller say nothing about GitLab's health
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+
+	if err == nil && resp.StatusCode < http.StatusInternalServerError {
+		if b.failures >= b.threshold {
+			logging.Info("GitLab API recovered, closing circuit breaker")
+		}
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.failures >= b.threshold {
+		b.openUntil = b.now().Add(b.cooldown)
+		if b.failures == b.threshold {
+			logging.Warn("GitLab API failed consecutive requests, failing fast",
+				zap.Int("failures", b.failures),
+				zap.Duration("cooldown", b.cooldown))
+		}
+	}
+}
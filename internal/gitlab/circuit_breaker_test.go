@@ -0,0 +1,123 @@
+package gitlab
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+)
+
+func TestCircuitBreaker_OpensAndRecovers(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	breaker := newCircuitBreaker(2, time.Minute)
+	breaker.now = func() time.Time { return now }
+	failed := &http.Response{StatusCode: http.StatusBadGateway}
+	ok := &http.Response{StatusCode: http.StatusNotFound}
+
+	breaker.record(failed, nil)
+	assert.NoError(t, breaker.allow())
+	breaker.record(nil, context.DeadlineExceeded)
+	assert.ErrorIs(t, breaker.allow(), ErrUnavailable)
+
+	// One trial request after the cooldown; a failure reopens the circuit
+	now = now.Add(time.Minute)
+	assert.NoError(t, breaker.allow())
+	assert.ErrorIs(t, breaker.allow(), ErrUnavailable)
+	breaker.record(failed, nil)
+	assert.ErrorIs(t, breaker.allow(), ErrUnavailable)
+
+	now = now.Add(time.Minute)
+	assert.NoError(t, breaker.allow())
+	breaker.record(ok, nil)
+	assert.NoError(t, breaker.allow())
+	assert.NoError(t, breaker.allow())
+}
+
+func TestCircuitBreaker_IgnoresCancelledRequests(t *testing.T) {
+	breaker := newCircuitBreaker(1, time.Minute)
+	breaker.record(nil, context.Canceled)
+	assert.NoError(t, breaker.allow())
+
+	assert.Nil(t, newCircuitBreaker(0, time.Minute))
+	assert.NoError(t, (*circuitBreaker)(nil).allow())
+}
+
+func TestClient_CircuitBreakerFailsFast(t *testing.T) {
+	ctx := context.Background()
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token", CircuitBreakerThreshold: 2, CircuitBreakerCooldownSeconds: 60})
+	for i := 0; i < 2; i++ {
+		_, err := client.GetMRDetails(ctx, 1, 2)
+		assert.Error(t, err)
+	}
+
+	_, err := client.GetMRDetails(ctx, 1, 2)
+	assert.ErrorIs(t, err, ErrUnavailable)
+	_, err = client.FetchMRChanges(ctx, 1, 2)
+	assert.ErrorIs(t, err, ErrUnavailable)
+	assert.Equal(t, 2, requests)
+}
+
+func TestClient_ContextCancelsRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"iid": 2}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+	ctx, cancel := context.WithCancel(context.Background())
+
+	details, err := client.GetMRDetails(ctx, 1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, details.IID)
+
+	cancel()
+	_, err = client.GetMRDetails(ctx, 1, 2)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	// Other callers of the client are not affected by the cancelled context
+	_, err = client.GetMRDetails(context.Background(), 1, 2)
+	assert.NoError(t, err)
+}
+
+func TestClient_ContextDeadline(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	_, err := client.GetMRDetails(ctx, 1, 2)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestClient_RequestTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token", RequestTimeoutSeconds: 1})
+	start := time.Now()
+	_, err := client.GetMRDetails(context.Background(), 1, 2)
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
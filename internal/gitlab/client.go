@@ -2,16 +2,21 @@ package gitlab
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
+	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/redhat-data-and-ai/naysayer/internal/config"
@@ -21,13 +26,25 @@ import (
 
 // Client handles GitLab API operations
 type Client struct {
-	config config.GitLabConfig
-	http   *http.Client
+	config  config.GitLabConfig
+	http    *http.Client
+	apiRoot string // e.g. https://gitlab.com/api/v4
+
+	auth    *credentials    // Token state
+	breaker *circuitBreaker // nil when GITLAB_CIRCUIT_BREAKER_THRESHOLD is 0
+
+	limiter   *rateLimiter                                     // nil when GITLAB_RATE_LIMIT is 0
+	etags     *etagCache                                       // nil when GITLAB_ETAG_CACHE_SIZE is 0
+	sleepFunc func(ctx context.Context, d time.Duration) error // Replaces retry sleeps in tests
 }
 
 // createHTTPClient creates an HTTP client with custom TLS configuration
 func createHTTPClient(cfg config.GitLabConfig) (*http.Client, error) {
-	transport := &http.Transport{}
+	connectTimeout := time.Duration(cfg.ConnectTimeoutSeconds) * time.Second
+	transport := &http.Transport{
+		DialContext:         (&net.Dialer{Timeout: connectTimeout}).DialContext,
+		TLSHandshakeTimeout: connectTimeout,
+	}
 
 	// Configure TLS settings
 	tlsConfig := &tls.Config{
@@ -58,6 +75,7 @@ func createHTTPClient(cfg config.GitLabConfig) (*http.Client, error) {
 
 	return &http.Client{
 		Transport: transport,
+		Timeout:   time.Duration(cfg.RequestTimeoutSeconds) * time.Second,
 	}, nil
 }
 
@@ -66,80 +84,149 @@ func NewClient(cfg config.GitLabConfig) *Client {
 	httpClient, err := createHTTPClient(cfg)
 	if err != nil {
 		// Fallback to default client if TLS configuration fails
-		httpClient = &http.Client{}
+		httpClient = &http.Client{Timeout: time.Duration(cfg.RequestTimeoutSeconds) * time.Second}
+	}
+
+	client := &Client{
+		config:  cfg,
+		http:    httpClient,
+		apiRoot: apiRoot(cfg),
+		auth:    &credentials{token: cfg.Token},
+		breaker: newCircuitBreaker(cfg.CircuitBreakerThreshold, time.Duration(cfg.CircuitBreakerCooldownSeconds)*time.Second),
+		limiter: newRateLimiter(cfg.RateLimit),
+	}
+	if cfg.ETagCacheSize > 0 {
+		client.etags = newETagCache(cfg.ETagCacheSize)
 	}
 
-	return &Client{
-		config: cfg,
-		http:   httpClient,
+	// Short-lived tokens mounted from a secret or kept in a secret manager are re-read every
+	// reload interval and when GitLab rejects the current one. OAuth access tokens are
+	// refreshed before they expire.
+	refresh, err := tokenRefresher(cfg)
+	if err != nil {
+		logging.Warn("Invalid GitLab token source", zap.Error(err))
+	}
+	if refresh != nil {
+		if client.auth.token == "" || cfg.OAuth.Configured() {
+			if token, err := refresh(); err == nil {
+				client.auth.token = token
+			} else {
+				logging.Warn("Failed to load initial GitLab token", zap.Error(err))
+			}
+		}
+		client.auth.refresh = refresh
+		client.auth.reloadEvery = time.Duration(cfg.TokenReloadSeconds) * time.Second
+		client.auth.loadedAt = time.Now()
+	}
+	if cfg.OAuth.Configured() {
+		if source, err := sharedOAuthSource(cfg); err == nil {
+			client.auth.invalidate = source.invalidate
+			client.auth.reloadEvery = oauthCheckInterval
+		}
 	}
+
+	return client
 }
 
 // NewClientWithConfig creates a new GitLab API client with full config
 func NewClientWithConfig(cfg *config.Config) *Client {
-	httpClient, err := createHTTPClient(cfg.GitLab)
-	if err != nil {
-		// Fallback to default client if TLS configuration fails
-		httpClient = &http.Client{}
-	}
+	return NewClient(cfg.GitLab)
+}
 
-	return &Client{
-		config: cfg.GitLab,
-		http:   httpClient,
-	}
+// NewClientForFunction creates a GitLab API client acting as the bot identity of a naysayer
+// function (config.EndpointReview, config.EndpointAutoRebase or config.EndpointStaleMRCleanup)
+func NewClientForFunction(cfg *config.Config, function string) *Client {
+	return NewClient(cfg.GitLabFor(function))
 }
 
-// FetchMRChanges fetches merge request changes from GitLab API
-func (c *Client) FetchMRChanges(projectID, mrIID int) ([]FileChange, error) {
-	url := fmt.Sprintf("%s/api/v4/projects/%d/merge_requests/%d/changes",
-		strings.TrimRight(c.config.BaseURL, "/"), projectID, mrIID)
+// GitLabInstance returns the name of the additional GitLab instance the client talks to,
+// empty for the default instance
+func (c *Client) GitLabInstance() string {
+	return c.config.Instance
+}
 
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, err
-	}
+// FetchMRChanges fetches merge request changes from GitLab API. The diffs are paged and
+// decoded one file at a time so that MRs over the configured MaxMRFiles or MaxMRDiffBytes
+// fail with a *TooLargeError before they are fully loaded into memory.
+func (c *Client) FetchMRChanges(ctx context.Context, projectID, mrIID int) ([]FileChange, error) {
+	var fileChanges []FileChange
+	diffBytes := 0
+	apiURL := c.apiURL("/projects/%d/merge_requests/%d/diffs?per_page=100", projectID, mrIID)
 
-	req.Header.Set("Authorization", "Bearer "+c.config.Token)
-	req.Header.Set("Content-Type", "application/json")
+	for apiURL != "" {
+		req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+		if err != nil {
+			return nil, err
+		}
 
-	resp, err := c.http.Do(req)
-	if err != nil {
-		return nil, err
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := c.do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(resp.Body)
+			_ = resp.Body.Close()
+			return nil, newAPIError(resp, "GitLab API error %d: %s", resp.StatusCode, string(body))
+		}
+
+		fileChanges, diffBytes, err = c.decodeDiffPage(resp.Body, fileChanges, diffBytes)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		apiURL = parseNextLink(resp.Header.Get("Link"))
 	}
-	defer func() { _ = resp.Body.Close() }()
 
-	if resp.StatusCode != 200 {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("GitLab API error %d: %s", resp.StatusCode, string(body))
+	if fileChanges == nil {
+		fileChanges = []FileChange{}
 	}
+	return fileChanges, nil
+}
 
-	var response MRChanges
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return nil, err
+// decodeDiffPage stream-decodes one page of MR diffs, appending to changes and enforcing
+// the size limits as each file is read
+func (c *Client) decodeDiffPage(body io.Reader, changes []FileChange, diffBytes int) ([]FileChange, int, error) {
+	decoder := json.NewDecoder(body)
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to decode MR diffs: %w", err)
+	}
+	if token != json.Delim('[') {
+		return nil, 0, fmt.Errorf("failed to decode MR diffs: expected an array, got %v", token)
 	}
 
-	// Convert to FileChange slice
-	fileChanges := make([]FileChange, len(response.Changes))
-	for i, change := range response.Changes {
-		fileChanges[i] = FileChange{
-			OldPath:     change.OldPath,
-			NewPath:     change.NewPath,
-			AMode:       change.AMode,
-			BMode:       change.BMode,
-			NewFile:     change.NewFile,
-			RenamedFile: change.RenamedFile,
-			DeletedFile: change.DeletedFile,
-			Diff:        change.Diff,
+	for decoder.More() {
+		var change FileChange
+		if err := decoder.Decode(&change); err != nil {
+			return nil, 0, fmt.Errorf("failed to decode MR diffs: %w", err)
+		}
+
+		changes = append(changes, change)
+		diffBytes += len(change.Diff)
+
+		if maxFiles := c.config.MaxMRFiles; maxFiles > 0 && len(changes) > maxFiles {
+			return nil, 0, &TooLargeError{Reason: fmt.Sprintf("more than %d changed files", maxFiles)}
+		}
+		if maxBytes := c.config.MaxMRDiffBytes; maxBytes > 0 && diffBytes > maxBytes {
+			return nil, 0, &TooLargeError{Reason: fmt.Sprintf("diff larger than %d bytes", maxBytes)}
 		}
 	}
 
-	return fileChanges, nil
+	if _, err := decoder.Token(); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode MR diffs: %w", err)
+	}
+	return changes, diffBytes, nil
 }
 
 // ExtractMRInfo extracts merge request information from webhook payload
 func ExtractMRInfo(payload map[string]interface{}) (*MRInfo, error) {
 	var projectID, mrIID int
-	var title, author, sourceBranch, targetBranch, state string
+	var title, description, author, sourceBranch, targetBranch, state, createdAt, headSHA, oldRev string
+	var draft bool
 
 	// Extract from object_attributes
 	if objectAttrs, ok := payload["object_attributes"].(map[string]interface{}); ok {
@@ -158,6 +245,10 @@ func ExtractMRInfo(payload map[string]interface{}) (*MRInfo, error) {
 			title = titleVal
 		}
 
+		if descriptionVal, ok := objectAttrs["description"].(string); ok {
+			description = descriptionVal
+		}
+
 		if sourceVal, ok := objectAttrs["source_branch"].(string); ok {
 			sourceBranch = sourceVal
 		}
@@ -169,6 +260,22 @@ func ExtractMRInfo(payload map[string]interface{}) (*MRInfo, error) {
 		if stateVal, ok := objectAttrs["state"].(string); ok {
 			state = stateVal
 		}
+
+		if createdVal, ok := objectAttrs["created_at"].(string); ok {
+			createdAt = createdVal
+		}
+
+		if lastCommit, ok := objectAttrs["last_commit"].(map[string]interface{}); ok {
+			headSHA, _ = lastCommit["id"].(string)
+		}
+
+		// GitLab sets oldrev only on update events that push to the source branch
+		oldRev, _ = objectAttrs["oldrev"].(string)
+
+		// work_in_progress is the deprecated name of draft
+		draftVal, _ := objectAttrs["draft"].(bool)
+		wipVal, _ := objectAttrs["work_in_progress"].(bool)
+		draft = draftVal || wipVal
 	}
 
 	// Extract project ID
@@ -200,20 +307,24 @@ func ExtractMRInfo(payload map[string]interface{}) (*MRInfo, error) {
 		ProjectID:    projectID,
 		MRIID:        mrIID,
 		Title:        title,
+		Description:  description,
 		Author:       author,
 		SourceBranch: sourceBranch,
 		TargetBranch: targetBranch,
 		State:        state,
+		CreatedAt:    createdAt,
+		Draft:        draft,
+		HeadSHA:      headSHA,
+		OldRev:       oldRev,
 	}, nil
 }
 
 // AddMRComment adds a comment to a merge request
-func (c *Client) AddMRComment(projectID, mrIID int, comment string) error {
-	url := fmt.Sprintf("%s/api/v4/projects/%d/merge_requests/%d/notes",
-		strings.TrimRight(c.config.BaseURL, "/"), projectID, mrIID)
+func (c *Client) AddMRComment(ctx context.Context, projectID, mrIID int, comment string) error {
+	url := c.apiURL("/projects/%d/merge_requests/%d/notes", projectID, mrIID)
 
 	payload := map[string]string{
-		"body": comment,
+		"body": withCorrelationID(ctx, comment),
 	}
 
 	jsonPayload, err := json.Marshal(payload)
@@ -221,15 +332,14 @@ func (c *Client) AddMRComment(projectID, mrIID int, comment string) error {
 		return fmt.Errorf("failed to marshal comment payload: %w", err)
 	}
 
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonPayload))
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonPayload))
 	if err != nil {
 		return fmt.Errorf("failed to create comment request: %w", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.config.Token)
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.http.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("failed to add comment: %w", err)
 	}
@@ -239,24 +349,23 @@ func (c *Client) AddMRComment(projectID, mrIID int, comment string) error {
 	case 201:
 		return nil // Success
 	case 401:
-		return fmt.Errorf("comment failed: insufficient permissions")
+		return newAPIError(resp, "comment failed: insufficient permissions")
 	case 404:
-		return fmt.Errorf("comment failed: MR not found")
+		return newAPIError(resp, "comment failed: MR not found")
 	default:
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("comment failed with status %d: %s", resp.StatusCode, string(body))
+		return newAPIError(resp, "comment failed with status %d: %s", resp.StatusCode, string(body))
 	}
 }
 
 // ApproveMR approves a merge request (simple approval without message)
-func (c *Client) ApproveMR(projectID, mrIID int) error {
-	return c.ApproveMRWithMessage(projectID, mrIID, "")
+func (c *Client) ApproveMR(ctx context.Context, projectID, mrIID int) error {
+	return c.ApproveMRWithMessage(ctx, projectID, mrIID, "")
 }
 
 // ApproveMRWithMessage approves a merge request with a custom approval message
-func (c *Client) ApproveMRWithMessage(projectID, mrIID int, message string) error {
-	url := fmt.Sprintf("%s/api/v4/projects/%d/merge_requests/%d/approve",
-		strings.TrimRight(c.config.BaseURL, "/"), projectID, mrIID)
+func (c *Client) ApproveMRWithMessage(ctx context.Context, projectID, mrIID int, message string) error {
+	url := c.apiURL("/projects/%d/merge_requests/%d/approve", projectID, mrIID)
 
 	var jsonPayload []byte
 	var err error
@@ -274,15 +383,14 @@ func (c *Client) ApproveMRWithMessage(projectID, mrIID int, message string) erro
 		return fmt.Errorf("failed to marshal approval payload: %w", err)
 	}
 
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonPayload))
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonPayload))
 	if err != nil {
 		return fmt.Errorf("failed to create approval request: %w", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.config.Token)
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.http.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("failed to approve MR: %w", err)
 	}
@@ -292,32 +400,30 @@ func (c *Client) ApproveMRWithMessage(projectID, mrIID int, message string) erro
 	case 201:
 		return nil // Success
 	case 401:
-		return fmt.Errorf("approval failed: insufficient permissions")
+		return newAPIError(resp, "approval failed: insufficient permissions")
 	case 404:
-		return fmt.Errorf("approval failed: MR not found")
+		return newAPIError(resp, "approval failed: MR not found")
 	case 405:
-		return fmt.Errorf("approval failed: MR already approved or cannot be approved")
+		return newAPIError(resp, "approval failed: MR already approved or cannot be approved")
 	default:
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("approval failed with status %d: %s", resp.StatusCode, string(body))
+		return newAPIError(resp, "approval failed with status %d: %s", resp.StatusCode, string(body))
 	}
 }
 
 // ResetNaysayerApproval revokes naysayer's approval for a merge request
 // This is called when naysayer changes its decision from approve to manual review
-func (c *Client) ResetNaysayerApproval(projectID, mrIID int) error {
-	url := fmt.Sprintf("%s/api/v4/projects/%d/merge_requests/%d/unapprove",
-		strings.TrimRight(c.config.BaseURL, "/"), projectID, mrIID)
+func (c *Client) ResetNaysayerApproval(ctx context.Context, projectID, mrIID int) error {
+	url := c.apiURL("/projects/%d/merge_requests/%d/unapprove", projectID, mrIID)
 
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer([]byte("{}")))
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer([]byte("{}")))
 	if err != nil {
 		return fmt.Errorf("failed to create reset approval request: %w", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.config.Token)
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.http.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("failed to reset naysayer approval: %w", err)
 	}
@@ -327,14 +433,14 @@ func (c *Client) ResetNaysayerApproval(projectID, mrIID int) error {
 	case 201:
 		return nil // Success
 	case 401:
-		return fmt.Errorf("reset approval failed: insufficient permissions")
+		return newAPIError(resp, "reset approval failed: insufficient permissions")
 	case 404:
-		return fmt.Errorf("reset approval failed: MR not found")
+		return newAPIError(resp, "reset approval failed: MR not found")
 	case 405:
-		return fmt.Errorf("reset approval failed: MR not approved or cannot be reset")
+		return newAPIError(resp, "reset approval failed: MR not approved or cannot be reset")
 	default:
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("reset approval failed with status %d: %s", resp.StatusCode, string(body))
+		return newAPIError(resp, "reset approval failed with status %d: %s", resp.StatusCode, string(body))
 	}
 }
 
@@ -371,6 +477,12 @@ func parseNextLink(linkHeader string) string {
 	return ""
 }
 
+// BotUser is the GitLab user a token acts as
+type BotUser struct {
+	ID       int    `json:"id"`
+	Username string `json:"username"`
+}
+
 // MRComment represents a GitLab merge request comment
 type MRComment struct {
 	ID        int                    `json:"id"`
@@ -381,14 +493,13 @@ type MRComment struct {
 }
 
 // ListMRComments retrieves all comments for a merge request with pagination support
-func (c *Client) ListMRComments(projectID, mrIID int) ([]MRComment, error) {
+func (c *Client) ListMRComments(ctx context.Context, projectID, mrIID int) ([]MRComment, error) {
 	const maxPages = 20 // Safety limit to prevent infinite loops (20 pages = 2000 comments)
 
 	allComments := make([]MRComment, 0, 200) // Pre-allocate for typical case
 
 	// Initial URL with pagination params
-	nextURL := fmt.Sprintf("%s/api/v4/projects/%d/merge_requests/%d/notes?sort=desc&order_by=created_at&per_page=100",
-		strings.TrimRight(c.config.BaseURL, "/"), projectID, mrIID)
+	nextURL := c.apiURL("/projects/%d/merge_requests/%d/notes?sort=desc&order_by=created_at&per_page=100", projectID, mrIID)
 
 	pageCount := 0
 
@@ -396,22 +507,23 @@ func (c *Client) ListMRComments(projectID, mrIID int) ([]MRComment, error) {
 		pageCount++
 
 		// Create request
-		req, err := http.NewRequest("GET", nextURL, nil)
+		req, err := http.NewRequestWithContext(ctx, "GET", nextURL, nil)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create list comments request (page %d): %w", pageCount, err)
 		}
 
-		req.Header.Set("Authorization", "Bearer "+c.config.Token)
-
 		// Execute request
-		resp, err := c.http.Do(req)
+		resp, err := c.do(req)
 		if err != nil {
 			// First page failure is fatal
 			if pageCount == 1 {
 				return nil, fmt.Errorf("failed to list comments: %w", err)
 			}
 			// Subsequent page failures - log and return what we have
-			logging.Warn("Failed to fetch comment page %d for MR %d, returning %d comments", pageCount, mrIID, len(allComments))
+			logging.FromContext(ctx).Warn("Failed to fetch comment page",
+				zap.Int("page", pageCount),
+				zap.Int("mr_iid", mrIID),
+				zap.Int("comments", len(allComments)))
 			return allComments, nil
 		}
 
@@ -425,7 +537,10 @@ func (c *Client) ListMRComments(projectID, mrIID int) ([]MRComment, error) {
 				if pageCount == 1 {
 					return nil, fmt.Errorf("failed to decode comments response: %w", err)
 				}
-				logging.Warn("Failed to decode comment page %d for MR %d, returning %d comments", pageCount, mrIID, len(allComments))
+				logging.FromContext(ctx).Warn("Failed to decode comment page",
+					zap.Int("page", pageCount),
+					zap.Int("mr_iid", mrIID),
+					zap.Int("comments", len(allComments)))
 				return allComments, nil
 			}
 
@@ -436,38 +551,44 @@ func (c *Client) ListMRComments(projectID, mrIID int) ([]MRComment, error) {
 
 		case 401:
 			_ = resp.Body.Close()
-			return nil, fmt.Errorf("list comments failed: insufficient permissions")
+			return nil, newAPIError(resp, "list comments failed: insufficient permissions")
 		case 404:
 			_ = resp.Body.Close()
-			return nil, fmt.Errorf("list comments failed: MR not found")
+			return nil, newAPIError(resp, "list comments failed: MR not found")
 		default:
 			// For first page, return error. For subsequent pages, gracefully degrade
 			if pageCount == 1 {
 				body, _ := io.ReadAll(resp.Body)
 				_ = resp.Body.Close()
-				return nil, fmt.Errorf("list comments failed with status %d: %s", resp.StatusCode, string(body))
+				return nil, newAPIError(resp, "list comments failed with status %d: %s", resp.StatusCode, string(body))
 			}
 			_ = resp.Body.Close()
-			logging.Warn("Comment page %d failed with status %d for MR %d, returning %d comments", pageCount, resp.StatusCode, mrIID, len(allComments))
+			logging.FromContext(ctx).Warn("Comment page failed",
+				zap.Int("page", pageCount),
+				zap.Int("status", resp.StatusCode),
+				zap.Int("mr_iid", mrIID),
+				zap.Int("comments", len(allComments)))
 			return allComments, nil
 		}
 	}
 
 	// Check if we hit the page limit
 	if pageCount >= maxPages && nextURL != "" {
-		logging.Warn("Reached max page limit (%d) for MR %d, returning %d comments", maxPages, mrIID, len(allComments))
+		logging.FromContext(ctx).Warn("Reached max page limit",
+			zap.Int("max_pages", maxPages),
+			zap.Int("mr_iid", mrIID),
+			zap.Int("comments", len(allComments)))
 	}
 
 	return allComments, nil
 }
 
 // UpdateMRComment updates an existing comment on a merge request
-func (c *Client) UpdateMRComment(projectID, mrIID, commentID int, newBody string) error {
-	url := fmt.Sprintf("%s/api/v4/projects/%d/merge_requests/%d/notes/%d",
-		strings.TrimRight(c.config.BaseURL, "/"), projectID, mrIID, commentID)
+func (c *Client) UpdateMRComment(ctx context.Context, projectID, mrIID, commentID int, newBody string) error {
+	url := c.apiURL("/projects/%d/merge_requests/%d/notes/%d", projectID, mrIID, commentID)
 
 	payload := map[string]string{
-		"body": newBody,
+		"body": withCorrelationID(ctx, newBody),
 	}
 
 	jsonPayload, err := json.Marshal(payload)
@@ -475,15 +596,14 @@ func (c *Client) UpdateMRComment(projectID, mrIID, commentID int, newBody string
 		return fmt.Errorf("failed to marshal update comment payload: %w", err)
 	}
 
-	req, err := http.NewRequest("PUT", url, bytes.NewBuffer(jsonPayload))
+	req, err := http.NewRequestWithContext(ctx, "PUT", url, bytes.NewBuffer(jsonPayload))
 	if err != nil {
 		return fmt.Errorf("failed to create update comment request: %w", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.config.Token)
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.http.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("failed to update comment: %w", err)
 	}
@@ -493,27 +613,27 @@ func (c *Client) UpdateMRComment(projectID, mrIID, commentID int, newBody string
 	case 200:
 		return nil // Success
 	case 401:
-		return fmt.Errorf("update comment failed: insufficient permissions")
+		return newAPIError(resp, "update comment failed: insufficient permissions")
 	case 404:
-		return fmt.Errorf("update comment failed: comment or MR not found")
+		return newAPIError(resp, "update comment failed: comment or MR not found")
 	case 403:
-		return fmt.Errorf("update comment failed: cannot edit this comment")
+		return newAPIError(resp, "update comment failed: cannot edit this comment")
 	default:
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("update comment failed with status %d: %s", resp.StatusCode, string(body))
+		return newAPIError(resp, "update comment failed with status %d: %s", resp.StatusCode, string(body))
 	}
 }
 
 // FindLatestNaysayerComment searches for the most recent comment from the current naysayer bot instance
 // If commentType is provided, only returns comments of that type. If empty, returns any naysayer comment.
-func (c *Client) FindLatestNaysayerComment(projectID, mrIID int, commentType ...string) (*MRComment, error) {
-	comments, err := c.ListMRComments(projectID, mrIID)
+func (c *Client) FindLatestNaysayerComment(ctx context.Context, projectID, mrIID int, commentType ...string) (*MRComment, error) {
+	comments, err := c.ListMRComments(ctx, projectID, mrIID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list comments: %w", err)
 	}
 
 	// Get current bot username (fallback to any naysayer bot if fails)
-	currentBotUsername, _ := c.GetCurrentBotUsername()
+	currentBotUsername, _ := c.GetCurrentBotUsername(ctx)
 
 	// Determine if we need to filter by comment type
 	filterByType := len(commentType) > 0 && commentType[0] != ""
@@ -521,7 +641,7 @@ func (c *Client) FindLatestNaysayerComment(projectID, mrIID int, commentType ...
 	// Find the latest matching comment (comments are sorted by created_at desc)
 	for _, comment := range comments {
 		// Check if comment is from our bot and matches type (if specified)
-		if c.isOurBotComment(comment.Author, currentBotUsername) &&
+		if c.isOurBotComment(ctx, projectID, comment.Author, currentBotUsername) &&
 			(!filterByType || c.matchesCommentType(comment.Body, commentType[0])) {
 			return &comment, nil
 		}
@@ -530,21 +650,59 @@ func (c *Client) FindLatestNaysayerComment(projectID, mrIID int, commentType ...
 	return nil, nil // No matching comment found
 }
 
-// isOurBotComment checks if a comment is from our bot instance
-func (c *Client) isOurBotComment(author map[string]interface{}, currentBotUsername string) bool {
+// isOurBotComment checks if a comment is from our bot instance. Without the current
+// username, configured bot identities of the project are tried before username patterns.
+func (c *Client) isOurBotComment(ctx context.Context, projectID int, author map[string]interface{}, currentBotUsername string) bool {
 	if currentBotUsername != "" {
 		return author["username"] == currentBotUsername
 	}
-	return c.IsNaysayerBotAuthor(author)
+	if c.config.HasBotIdentities() {
+		return c.isConfiguredBot(projectID, author)
+	}
+	return c.IsNaysayerBotAuthor(ctx, author)
 }
 
 // matchesCommentType checks if a comment body matches the expected comment type
 func (c *Client) matchesCommentType(body, commentType string) bool {
+	return MatchesCommentType(body, commentType)
+}
+
+// correlationIDComment matches the hidden correlation ID of the request that last posted or
+// updated a comment
+var correlationIDComment = regexp.MustCompile("\n?<!-- naysayer-correlation-id: [A-Za-z0-9._-]+ -->")
+
+// withCorrelationID stamps a comment body with the correlation ID of ctx in hidden metadata,
+// replacing the one of an earlier request, so a comment can be traced to the logs of the
+// webhook delivery that wrote it
+func withCorrelationID(ctx context.Context, body string) string {
+	body = StripCorrelationID(body)
+	id := logging.CorrelationID(ctx)
+	if id == "" {
+		return body
+	}
+	return body + "\n<!-- naysayer-correlation-id: " + id + " -->"
+}
+
+// StripCorrelationID removes the hidden correlation ID from a comment body, for comparing
+// comments written by different requests
+func StripCorrelationID(body string) string {
+	return correlationIDComment.ReplaceAllString(body, "")
+}
+
+// MatchesCommentType checks if a comment body carries the naysayer marker of commentType.
+// Shared with other SCM providers storing the same comment bodies.
+func MatchesCommentType(body, commentType string) bool {
 	switch commentType {
 	case "approval":
 		return strings.Contains(body, "<!-- naysayer-comment-id: approval -->")
 	case "manual-review":
 		return strings.Contains(body, "<!-- naysayer-comment-id: manual-review -->")
+	case "group-membership":
+		return strings.Contains(body, "<!-- naysayer-comment-id: group-membership -->")
+	case "merge-settings":
+		return strings.Contains(body, "<!-- naysayer-comment-id: merge-settings -->")
+	case "auto-merge":
+		return strings.Contains(body, "<!-- naysayer-comment-id: auto-merge -->")
 	default:
 		// For unknown types, match any naysayer comment
 		return strings.Contains(body, "<!-- naysayer-comment-id:")
@@ -552,41 +710,70 @@ func (c *Client) matchesCommentType(body, commentType string) bool {
 }
 
 // GetCurrentBotUsername identifies the current bot's username by calling GitLab API
-func (c *Client) GetCurrentBotUsername() (string, error) {
-	url := fmt.Sprintf("%s/api/v4/user", strings.TrimRight(c.config.BaseURL, "/"))
-
-	req, err := http.NewRequest("GET", url, nil)
+func (c *Client) GetCurrentBotUsername(ctx context.Context) (string, error) {
+	user, err := c.CurrentUser(ctx)
 	if err != nil {
-		return "", fmt.Errorf("failed to create user info request: %w", err)
+		return "", err
 	}
+	return user.Username, nil
+}
 
-	req.Header.Set("Authorization", "Bearer "+c.config.Token)
+// CurrentUser returns the GitLab user the client's token acts as
+func (c *Client) CurrentUser(ctx context.Context) (*BotUser, error) {
+	url := c.apiURL("/user")
 
-	resp, err := c.http.Do(req)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
-		return "", fmt.Errorf("failed to get user info: %w", err)
+		return nil, fmt.Errorf("failed to create user info request: %w", err)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user info: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("user info request failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, newAPIError(resp, "user info request failed with status %d: %s", resp.StatusCode, string(body))
 	}
 
-	var userInfo map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&userInfo); err != nil {
-		return "", fmt.Errorf("failed to decode user info response: %w", err)
+	var user BotUser
+	if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
+		return nil, fmt.Errorf("failed to decode user info response: %w", err)
 	}
-
-	if username, ok := userInfo["username"].(string); ok {
-		return username, nil
+	if user.Username == "" {
+		return nil, fmt.Errorf("username not found in user info response")
 	}
 
-	return "", fmt.Errorf("username not found in user info response")
+	return &user, nil
 }
 
-// IsNaysayerBotAuthor checks if the comment author is a naysayer bot
-func (c *Client) IsNaysayerBotAuthor(author map[string]interface{}) bool {
+// VerifyBotIdentity checks that the token acts as one of the configured bot identities
+// and returns its user. Without configured identities any user is accepted.
+func (c *Client) VerifyBotIdentity(ctx context.Context) (*BotUser, error) {
+	user, err := c.CurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !c.config.HasBotIdentities() {
+		return user, nil
+	}
+	if !c.isConfiguredBot(0, map[string]interface{}{"id": float64(user.ID), "username": user.Username}) {
+		return user, fmt.Errorf("%w: token acts as %s (ID %d)", ErrBotIdentityMismatch, user.Username, user.ID)
+	}
+	return user, nil
+}
+
+// IsNaysayerBotAuthor checks if the comment author is a naysayer bot. Configured bot
+// identities are authoritative; username patterns are only a fallback when none are
+// configured. Every configured identity counts, so the review bot recognises comments
+// of the rebase bot and vice versa.
+func (c *Client) IsNaysayerBotAuthor(ctx context.Context, author map[string]interface{}) bool {
+	if c.config.HasBotIdentities() {
+		return c.isConfiguredBot(0, author)
+	}
+
 	// Check username patterns
 	if username, ok := author["username"].(string); ok {
 		return (strings.HasPrefix(username, "project_") && strings.Contains(username, "_bot_")) ||
@@ -601,21 +788,52 @@ func (c *Client) IsNaysayerBotAuthor(author map[string]interface{}) bool {
 	return false
 }
 
+// isConfiguredBot checks an author against the configured bot usernames and the bot
+// identities of projectID (0 matches identities of every project). Identities with a
+// user ID match by ID, so renamed bot users are still recognised.
+func (c *Client) isConfiguredBot(projectID int, author map[string]interface{}) bool {
+	username, _ := author["username"].(string)
+	userID := 0
+	if id, ok := author["id"].(float64); ok {
+		userID = int(id)
+	}
+
+	for _, botUsername := range c.config.BotUsernames {
+		if username != "" && username == botUsername {
+			return true
+		}
+	}
+	for _, identity := range c.config.BotIdentities {
+		if projectID != 0 && identity.ProjectID != 0 && identity.ProjectID != projectID {
+			continue
+		}
+		if identity.UserID != 0 && userID != 0 {
+			if identity.UserID == userID {
+				return true
+			}
+			continue
+		}
+		if username != "" && identity.Username == username {
+			return true
+		}
+	}
+	return false
+}
+
 // AddOrUpdateMRComment adds a new comment or updates the latest existing naysayer comment of the same type
-func (c *Client) AddOrUpdateMRComment(projectID, mrIID int, commentBody, commentType string) error {
+func (c *Client) AddOrUpdateMRComment(ctx context.Context, projectID, mrIID int, commentBody, commentType string) error {
 	// Find the latest naysayer comment of the same type
-	existingComment, err := c.FindLatestNaysayerComment(projectID, mrIID, commentType)
+	existingComment, err := c.FindLatestNaysayerComment(ctx, projectID, mrIID, commentType)
 	if err != nil {
 		return fmt.Errorf("failed to search for existing comment: %w", err)
 	}
 
 	// Update existing comment or create new one
 	if existingComment != nil {
-		if err := c.UpdateMRComment(projectID, mrIID, existingComment.ID, commentBody); err != nil {
+		if err := c.UpdateMRComment(ctx, projectID, mrIID, existingComment.ID, commentBody); err != nil {
 			// If update fails due to permissions, fallback to creating new comment
-			if strings.Contains(err.Error(), "cannot edit this comment") ||
-				strings.Contains(err.Error(), "insufficient permissions") {
-				return c.AddMRComment(projectID, mrIID, commentBody)
+			if errors.Is(err, ErrPermission) {
+				return c.AddMRComment(ctx, projectID, mrIID, commentBody)
 			}
 			return err
 		}
@@ -623,13 +841,14 @@ func (c *Client) AddOrUpdateMRComment(projectID, mrIID int, commentBody, comment
 	}
 
 	// No existing comment found, create new one
-	return c.AddMRComment(projectID, mrIID, commentBody)
+	return c.AddMRComment(ctx, projectID, mrIID, commentBody)
 }
 
-// RebaseMR triggers a rebase for a merge request and verifies it completed successfully.
+// RebaseMR triggers a rebase for a merge request and polls until GitLab finished it. Rebases
+// failing in the background return a *RebaseError, see verifyRebaseCompleted.
 // Caller should use CompareBranches() to decide if rebase is needed before calling this.
-func (c *Client) RebaseMR(projectID, mrIID int) (bool, error) {
-	mrDetails, err := c.GetMRDetails(projectID, mrIID)
+func (c *Client) RebaseMR(ctx context.Context, projectID, mrIID int) (bool, error) {
+	mrDetails, err := c.GetMRDetails(ctx, projectID, mrIID)
 	if err != nil {
 		return false, fmt.Errorf("failed to get MR details before rebase: %w", err)
 	}
@@ -637,16 +856,14 @@ func (c *Client) RebaseMR(projectID, mrIID int) (bool, error) {
 		return false, fmt.Errorf("rebase already in progress for MR %d", mrIID)
 	}
 
-	url := fmt.Sprintf("%s/api/v4/projects/%d/merge_requests/%d/rebase",
-		strings.TrimRight(c.config.BaseURL, "/"), projectID, mrIID)
-	req, err := http.NewRequest("PUT", url, bytes.NewBuffer([]byte("{}")))
+	url := c.apiURL("/projects/%d/merge_requests/%d/rebase", projectID, mrIID)
+	req, err := http.NewRequestWithContext(ctx, "PUT", url, bytes.NewBuffer([]byte("{}")))
 	if err != nil {
 		return false, fmt.Errorf("failed to create rebase request: %w", err)
 	}
-	req.Header.Set("Authorization", "Bearer "+c.config.Token)
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.http.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return false, fmt.Errorf("failed to rebase MR: %w", err)
 	}
@@ -657,59 +874,49 @@ func (c *Client) RebaseMR(projectID, mrIID int) (bool, error) {
 
 	switch resp.StatusCode {
 	case 202:
-		actuallyRebased, verifyErr := c.verifyRebaseCompleted(projectID, mrIID)
-		if verifyErr != nil {
-			return false, fmt.Errorf("rebase verification failed: %w", verifyErr)
-		}
-		if !actuallyRebased {
-			return false, fmt.Errorf("rebase failed: conflicts detected or rebase could not complete")
-		}
-		return true, nil
+		return c.verifyRebaseCompleted(ctx, projectID, mrIID)
 	case 403:
-		return false, fmt.Errorf("rebase failed: insufficient permissions or rebase not allowed: %s", bodyStr)
+		return false, newAPIError(resp, "rebase failed: insufficient permissions or rebase not allowed: %s", bodyStr)
 	case 404:
-		return false, fmt.Errorf("rebase failed: MR not found")
+		return false, newAPIError(resp, "rebase failed: MR not found")
 	case 409:
-		return false, fmt.Errorf("rebase failed: rebase already in progress or conflicts detected: %s", bodyStr)
+		return false, newAPIError(resp, "rebase failed: rebase already in progress or conflicts detected: %s", bodyStr)
 	default:
-		return false, fmt.Errorf("rebase failed with status %d: %s", resp.StatusCode, bodyStr)
+		return false, newAPIError(resp, "rebase failed with status %d: %s", resp.StatusCode, bodyStr)
 	}
 }
 
 // CompareBranches compares two branches in the same project using GitLab Compare API.
 // Use only for same-project MRs. For fork MRs use CompareCommits with MR.Sha (see auto_rebase).
 // Direction: from=sourceBranch, to=targetBranch (commits in target that source doesn't have).
-func (c *Client) CompareBranches(sourceProjectID int, sourceBranch string, targetProjectID int, targetBranch string) (*CompareResult, error) {
+func (c *Client) CompareBranches(ctx context.Context, sourceProjectID int, sourceBranch string, targetProjectID int, targetBranch string) (*CompareResult, error) {
 	if sourceProjectID != targetProjectID {
 		return nil, fmt.Errorf("CompareBranches does not support cross-project: use CompareCommits with MR.Sha for fork MRs")
 	}
 	encodedFrom := url.QueryEscape(sourceBranch)
 	encodedTo := url.QueryEscape(targetBranch)
-	apiURL := fmt.Sprintf("%s/api/v4/projects/%d/repository/compare?from=%s&to=%s",
-		strings.TrimRight(c.config.BaseURL, "/"), targetProjectID, encodedFrom, encodedTo)
-	return c.doCompare(apiURL)
+	apiURL := c.apiURL("/projects/%d/repository/compare?from=%s&to=%s", targetProjectID, encodedFrom, encodedTo)
+	return c.doCompare(ctx, apiURL)
 }
 
 // GetBranchCommit returns the commit SHA of the branch HEAD.
 // GET /projects/:id/repository/branches/:branch
-func (c *Client) GetBranchCommit(projectID int, branch string) (string, error) {
+func (c *Client) GetBranchCommit(ctx context.Context, projectID int, branch string) (string, error) {
 	encodedBranch := url.QueryEscape(branch)
-	apiURL := fmt.Sprintf("%s/api/v4/projects/%d/repository/branches/%s",
-		strings.TrimRight(c.config.BaseURL, "/"), projectID, encodedBranch)
-	req, err := http.NewRequest("GET", apiURL, nil)
+	apiURL := c.apiURL("/projects/%d/repository/branches/%s", projectID, encodedBranch)
+	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
 	if err != nil {
 		return "", fmt.Errorf("failed to create get branch request: %w", err)
 	}
-	req.Header.Set("Authorization", "Bearer "+c.config.Token)
 	req.Header.Set("Content-Type", "application/json")
-	resp, err := c.http.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return "", fmt.Errorf("failed to get branch: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("get branch failed with status %d: %s", resp.StatusCode, string(body))
+		return "", newAPIError(resp, "get branch failed with status %d: %s", resp.StatusCode, string(body))
 	}
 	var branchInfo struct {
 		Commit struct {
@@ -725,29 +932,27 @@ func (c *Client) GetBranchCommit(projectID int, branch string) (string, error) {
 // CompareCommits compares two commits by SHA in one project.
 // Used for fork MRs: GitLab cannot compare across projects by branch; use MR.Sha (source HEAD) and target branch SHA.
 // GET /projects/:id/repository/compare?from=<source_sha>&to=<target_sha>
-func (c *Client) CompareCommits(projectID int, fromSHA, toSHA string) (*CompareResult, error) {
+func (c *Client) CompareCommits(ctx context.Context, projectID int, fromSHA, toSHA string) (*CompareResult, error) {
 	encodedFrom := url.QueryEscape(fromSHA)
 	encodedTo := url.QueryEscape(toSHA)
-	apiURL := fmt.Sprintf("%s/api/v4/projects/%d/repository/compare?from=%s&to=%s",
-		strings.TrimRight(c.config.BaseURL, "/"), projectID, encodedFrom, encodedTo)
-	return c.doCompare(apiURL)
+	apiURL := c.apiURL("/projects/%d/repository/compare?from=%s&to=%s", projectID, encodedFrom, encodedTo)
+	return c.doCompare(ctx, apiURL)
 }
 
-func (c *Client) doCompare(apiURL string) (*CompareResult, error) {
-	req, err := http.NewRequest("GET", apiURL, nil)
+func (c *Client) doCompare(ctx context.Context, apiURL string) (*CompareResult, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create compare request: %w", err)
 	}
-	req.Header.Set("Authorization", "Bearer "+c.config.Token)
 	req.Header.Set("Content-Type", "application/json")
-	resp, err := c.http.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to compare: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("compare failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, newAPIError(resp, "compare failed with status %d: %s", resp.StatusCode, string(body))
 	}
 	var result CompareResult
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
@@ -756,42 +961,57 @@ func (c *Client) doCompare(apiURL string) (*CompareResult, error) {
 	return &result, nil
 }
 
-// verifyRebaseCompleted polls the MR to verify rebase completed successfully.
-// Returns (completedSuccessfully bool, error).
-func (c *Client) verifyRebaseCompleted(projectID, mrIID int) (bool, error) {
-	maxAttempts := 30        // 30 attempts
-	delay := 2 * time.Second // 2 seconds between attempts = max 60 seconds wait
+// verifyRebaseCompleted polls the MR until GitLab finished the rebase it enqueued, for at most
+// RebaseVerifyTimeoutSeconds. A rebase GitLab reports an error for returns a *RebaseError,
+// one leaving conflicts wraps ErrConflict and one still running at the deadline wraps
+// ErrRebaseTimeout.
+func (c *Client) verifyRebaseCompleted(ctx context.Context, projectID, mrIID int) (bool, error) {
+	timeout := time.Duration(c.config.RebaseVerifyTimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = 60 * time.Second
+	}
+	delay := time.Duration(c.config.RebaseVerifyIntervalSeconds) * time.Second
+	if delay <= 0 {
+		delay = 2 * time.Second
+	}
+	maxAttempts := max(int(timeout/delay), 1)
 
 	for attempt := 0; attempt < maxAttempts; attempt++ {
-		time.Sleep(delay)
+		if err := c.sleep(ctx, delay); err != nil {
+			return false, fmt.Errorf("rebase verification interrupted: %w", err)
+		}
 
-		mrDetails, err := c.GetMRDetails(projectID, mrIID)
+		mrDetails, err := c.GetMRDetails(ctx, projectID, mrIID)
 		if err != nil {
-			logging.MRWarn(mrIID, "Failed to get MR details during rebase verification, retrying",
+			logging.FromContext(ctx).MRWarn(mrIID, "Failed to get MR details during rebase verification, retrying",
 				zap.Int("attempt", attempt+1), zap.Error(err))
 			continue
 		}
 
 		if mrDetails.RebaseInProgress {
-			logging.MRInfo(mrIID, "Rebase still in progress, waiting", zap.Int("attempt", attempt+1))
+			logging.FromContext(ctx).MRInfo(mrIID, "Rebase still in progress, waiting", zap.Int("attempt", attempt+1))
 			continue
 		}
 
+		if mrDetails.MergeError != "" {
+			return false, &RebaseError{MergeError: mrDetails.MergeError}
+		}
+
 		if mrDetails.HasConflicts || mrDetails.MergeStatus == "cannot_be_merged" {
-			return false, fmt.Errorf("rebase completed but conflicts were introduced (merge_status: %s)", mrDetails.MergeStatus)
+			return false, fmt.Errorf("rebase completed but conflicts were introduced (merge_status: %s): %w", mrDetails.MergeStatus, ErrConflict)
 		}
 
-		logging.MRInfo(mrIID, "Rebase completed successfully")
+		logging.FromContext(ctx).MRInfo(mrIID, "Rebase completed successfully")
 		return true, nil
 	}
 
 	// Timeout reached - rebase still in progress or verification incomplete
-	return false, fmt.Errorf("rebase verification timeout: max %d seconds exceeded", maxAttempts*int(delay.Seconds()))
+	return false, fmt.Errorf("rebase verification timeout: max %d seconds exceeded: %w", int(timeout.Seconds()), ErrRebaseTimeout)
 }
 
 // ListOpenMRs returns a list of open MR IIDs for a project
-func (c *Client) ListOpenMRs(projectID int) ([]int, error) {
-	mrDetails, err := c.ListOpenMRsWithDetails(projectID)
+func (c *Client) ListOpenMRs(ctx context.Context, projectID int) ([]int, error) {
+	mrDetails, err := c.ListOpenMRsWithDetails(ctx, projectID)
 	if err != nil {
 		return nil, err
 	}
@@ -810,23 +1030,21 @@ func (c *Client) ListOpenMRs(projectID int) ([]int, error) {
 // fetch each MR individually. This results in N+1 API calls but ensures accurate
 // pipeline status for filtering.
 // Only fetches MRs created within the last 7 days to reduce API load.
-func (c *Client) ListOpenMRsWithDetails(projectID int) ([]MRDetails, error) {
+func (c *Client) ListOpenMRsWithDetails(ctx context.Context, projectID int) ([]MRDetails, error) {
 	// Calculate created_after date (7 days ago) in ISO 8601 format
 	sevenDaysAgo := time.Now().AddDate(0, 0, -7).Format(time.RFC3339)
 
 	// Step 1: Get list of open MR IIDs created in last 7 days (fast, no pipeline data)
-	url := fmt.Sprintf("%s/api/v4/projects/%d/merge_requests?state=opened&per_page=100&created_after=%s",
-		strings.TrimRight(c.config.BaseURL, "/"), projectID, sevenDaysAgo)
+	url := c.apiURL("/projects/%d/merge_requests?state=opened&per_page=100&created_after=%s", projectID, sevenDaysAgo)
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create list MRs request: %w", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.config.Token)
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.http.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list MRs: %w", err)
 	}
@@ -834,7 +1052,7 @@ func (c *Client) ListOpenMRsWithDetails(projectID int) ([]MRDetails, error) {
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("list MRs failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, newAPIError(resp, "list MRs failed with status %d: %s", resp.StatusCode, string(body))
 	}
 
 	// Parse basic MR list (just need IIDs)
@@ -850,39 +1068,75 @@ func (c *Client) ListOpenMRsWithDetails(projectID int) ([]MRDetails, error) {
 	}
 
 	// Step 2: Fetch each MR individually to get complete details including pipeline
-	detailedMRs := make([]MRDetails, 0, len(basicMRs))
+	iids := make([]int, len(basicMRs))
+	for i, basicMR := range basicMRs {
+		iids[i] = basicMR.IID
+	}
+	return c.getMRDetailsConcurrently(ctx, projectID, iids), nil
+}
 
-	for _, basicMR := range basicMRs {
-		mrDetails, err := c.GetMRDetails(projectID, basicMR.IID)
-		if err != nil {
-			// Log error but continue with other MRs
-			// Don't fail entire operation if one MR fetch fails
-			logging.Warn("Failed to get details for MR %d in project %d, skipping: %v", basicMR.IID, projectID, err)
-			continue
-		}
-		detailedMRs = append(detailedMRs, *mrDetails)
+// getMRDetailsConcurrently fetches the details of MRs on a pool of at most
+// DetailConcurrency workers and returns them in the order of iids. MRs whose details
+// cannot be fetched are logged and skipped rather than failing the whole listing.
+func (c *Client) getMRDetailsConcurrently(ctx context.Context, projectID int, iids []int) []MRDetails {
+	workers := c.config.DetailConcurrency
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(iids) {
+		workers = len(iids)
+	}
+
+	results := make([]*MRDetails, len(iids))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				mrDetails, err := c.GetMRDetails(ctx, projectID, iids[i])
+				if err != nil {
+					logging.FromContext(ctx).Warn("Failed to get MR details, skipping",
+						zap.Int("mr_iid", iids[i]),
+						zap.Int("project_id", projectID),
+						zap.Error(err))
+					continue
+				}
+				results[i] = mrDetails
+			}
+		}()
 	}
+	for i := range iids {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
 
-	return detailedMRs, nil
+	detailedMRs := make([]MRDetails, 0, len(iids))
+	for _, mrDetails := range results {
+		if mrDetails != nil {
+			detailedMRs = append(detailedMRs, *mrDetails)
+		}
+	}
+	return detailedMRs
 }
 
 // ListAllOpenMRsWithDetails lists all open merge requests for a project (no date filter)
 // This is used by the stale MR cleanup feature to find MRs that are 27-30+ days old
-func (c *Client) ListAllOpenMRsWithDetails(projectID int) ([]MRDetails, error) {
+func (c *Client) ListAllOpenMRsWithDetails(ctx context.Context, projectID int) ([]MRDetails, error) {
 	var allMRs []MRDetails
-	url := fmt.Sprintf("%s/api/v4/projects/%d/merge_requests?state=opened&per_page=100",
-		strings.TrimRight(c.config.BaseURL, "/"), projectID)
+	url := c.apiURL("/projects/%d/merge_requests?state=opened&per_page=100", projectID)
 
 	for url != "" {
-		req, err := http.NewRequest("GET", url, nil)
+		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create list MRs request: %w", err)
 		}
 
-		req.Header.Set("Authorization", "Bearer "+c.config.Token)
 		req.Header.Set("Content-Type", "application/json")
 
-		resp, err := c.http.Do(req)
+		resp, err := c.do(req)
 		if err != nil {
 			return nil, fmt.Errorf("failed to list MRs: %w", err)
 		}
@@ -890,7 +1144,7 @@ func (c *Client) ListAllOpenMRsWithDetails(projectID int) ([]MRDetails, error) {
 		if resp.StatusCode != http.StatusOK {
 			body, _ := io.ReadAll(resp.Body)
 			_ = resp.Body.Close()
-			return nil, fmt.Errorf("list MRs failed with status %d: %s", resp.StatusCode, string(body))
+			return nil, newAPIError(resp, "list MRs failed with status %d: %s", resp.StatusCode, string(body))
 		}
 
 		var mrs []MRDetails
@@ -910,9 +1164,8 @@ func (c *Client) ListAllOpenMRsWithDetails(projectID int) ([]MRDetails, error) {
 }
 
 // CloseMR closes a merge request
-func (c *Client) CloseMR(projectID, mrIID int) error {
-	url := fmt.Sprintf("%s/api/v4/projects/%d/merge_requests/%d",
-		strings.TrimRight(c.config.BaseURL, "/"), projectID, mrIID)
+func (c *Client) CloseMR(ctx context.Context, projectID, mrIID int) error {
+	url := c.apiURL("/projects/%d/merge_requests/%d", projectID, mrIID)
 
 	payload := map[string]string{
 		"state_event": "close",
@@ -923,15 +1176,14 @@ func (c *Client) CloseMR(projectID, mrIID int) error {
 		return fmt.Errorf("failed to marshal close MR payload: %w", err)
 	}
 
-	req, err := http.NewRequest("PUT", url, bytes.NewBuffer(payloadBytes))
+	req, err := http.NewRequestWithContext(ctx, "PUT", url, bytes.NewBuffer(payloadBytes))
 	if err != nil {
 		return fmt.Errorf("failed to create close MR request: %w", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.config.Token)
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.http.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("failed to close MR: %w", err)
 	}
@@ -939,27 +1191,25 @@ func (c *Client) CloseMR(projectID, mrIID int) error {
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("close MR failed with status %d: %s", resp.StatusCode, string(body))
+		return newAPIError(resp, "close MR failed with status %d: %s", resp.StatusCode, string(body))
 	}
 
-	logging.Info("Successfully closed MR !%d in project %d", mrIID, projectID)
+	logging.FromContext(ctx).Info("Successfully closed MR", zap.Int("mr_iid", mrIID), zap.Int("project_id", projectID))
 	return nil
 }
 
 // GetPipelineJobs retrieves all jobs for a pipeline
-func (c *Client) GetPipelineJobs(projectID, pipelineID int) ([]PipelineJob, error) {
-	url := fmt.Sprintf("%s/api/v4/projects/%d/pipelines/%d/jobs",
-		strings.TrimRight(c.config.BaseURL, "/"), projectID, pipelineID)
+func (c *Client) GetPipelineJobs(ctx context.Context, projectID, pipelineID int) ([]PipelineJob, error) {
+	url := c.apiURL("/projects/%d/pipelines/%d/jobs", projectID, pipelineID)
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create pipeline jobs request: %w", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.config.Token)
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.http.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get pipeline jobs: %w", err)
 	}
@@ -967,7 +1217,7 @@ func (c *Client) GetPipelineJobs(projectID, pipelineID int) ([]PipelineJob, erro
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("get pipeline jobs failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, newAPIError(resp, "get pipeline jobs failed with status %d: %s", resp.StatusCode, string(body))
 	}
 
 	var jobs []PipelineJob
@@ -984,19 +1234,17 @@ type JobTrace struct {
 }
 
 // GetJobTrace retrieves the trace/logs for a specific job
-func (c *Client) GetJobTrace(projectID, jobID int) (string, error) {
-	url := fmt.Sprintf("%s/api/v4/projects/%d/jobs/%d/trace",
-		strings.TrimRight(c.config.BaseURL, "/"), projectID, jobID)
+func (c *Client) GetJobTrace(ctx context.Context, projectID, jobID int) (string, error) {
+	url := c.apiURL("/projects/%d/jobs/%d/trace", projectID, jobID)
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return "", fmt.Errorf("failed to create job trace request: %w", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.config.Token)
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.http.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return "", fmt.Errorf("failed to get job trace: %w", err)
 	}
@@ -1004,7 +1252,7 @@ func (c *Client) GetJobTrace(projectID, jobID int) (string, error) {
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("get job trace failed with status %d: %s", resp.StatusCode, string(body))
+		return "", newAPIError(resp, "get job trace failed with status %d: %s", resp.StatusCode, string(body))
 	}
 
 	var trace JobTrace
@@ -1016,14 +1264,14 @@ func (c *Client) GetJobTrace(projectID, jobID int) (string, error) {
 }
 
 // FindLatestAtlantisComment finds the latest comment from atlantis-bot
-func (c *Client) FindLatestAtlantisComment(projectID, mrIID int) (*MRComment, error) {
-	comments, err := c.ListMRComments(projectID, mrIID)
+func (c *Client) FindLatestAtlantisComment(ctx context.Context, projectID, mrIID int) (*MRComment, error) {
+	comments, err := c.ListMRComments(ctx, projectID, mrIID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list comments: %w", err)
 	}
 
 	// Log all comments for debugging
-	logging.Info("Found %d comments for MR %d", len(comments), mrIID)
+	logging.FromContext(ctx).Info("Found comments", zap.Int("comments", len(comments)), zap.Int("mr_iid", mrIID))
 	for i, comment := range comments {
 		if i < 5 { // Log first 5 comments for debugging
 			authorUsername := "unknown"
@@ -1036,8 +1284,13 @@ func (c *Client) FindLatestAtlantisComment(projectID, mrIID int) (*MRComment, er
 			}
 			isAtlantis := c.isAtlantisBotComment(comment.Author)
 			bodyPreview := truncateString(comment.Body, 100)
-			logging.Info("Comment %d: author=%s/%s, is_atlantis=%v, preview=%s (MR %d)",
-				i, authorUsername, authorName, isAtlantis, bodyPreview, mrIID)
+			logging.FromContext(ctx).Info("Comment",
+				zap.Int("index", i),
+				zap.String("author_username", authorUsername),
+				zap.String("author_name", authorName),
+				zap.Bool("is_atlantis", isAtlantis),
+				zap.String("preview", bodyPreview),
+				zap.Int("mr_iid", mrIID))
 		}
 	}
 
@@ -1046,12 +1299,12 @@ func (c *Client) FindLatestAtlantisComment(projectID, mrIID int) (*MRComment, er
 	for _, comment := range comments {
 		// Check if comment is from atlantis-bot
 		if c.isAtlantisBotComment(comment.Author) {
-			logging.Info("Found atlantis comment for MR %d", mrIID)
+			logging.FromContext(ctx).Info("Found atlantis comment", zap.Int("mr_iid", mrIID))
 			return &comment, nil
 		}
 	}
 
-	logging.Info("No atlantis comment found in %d comments for MR %d", len(comments), mrIID)
+	logging.FromContext(ctx).Info("No atlantis comment found", zap.Int("comments", len(comments)), zap.Int("mr_iid", mrIID))
 	return nil, nil // No atlantis comment found
 }
 
@@ -1065,6 +1318,11 @@ func truncateString(s string, maxLen int) string {
 
 // isAtlantisBotComment checks if a comment is from atlantis-bot
 func (c *Client) isAtlantisBotComment(author map[string]interface{}) bool {
+	return IsAtlantisBotAuthor(author)
+}
+
+// IsAtlantisBotAuthor checks if a comment author is atlantis-bot
+func IsAtlantisBotAuthor(author map[string]interface{}) bool {
 	// Common atlantis bot username patterns
 	atlantisPatterns := []string{
 		"atlantis",
@@ -1111,8 +1369,8 @@ func (c *Client) isAtlantisBotComment(author map[string]interface{}) bool {
 }
 
 // AreAllPipelineJobsSucceeded checks if all non-allow-failure jobs in a pipeline succeeded
-func (c *Client) AreAllPipelineJobsSucceeded(projectID, pipelineID int) (bool, error) {
-	jobs, err := c.GetPipelineJobs(projectID, pipelineID)
+func (c *Client) AreAllPipelineJobsSucceeded(ctx context.Context, projectID, pipelineID int) (bool, error) {
+	jobs, err := c.GetPipelineJobs(ctx, projectID, pipelineID)
 	if err != nil {
 		return false, fmt.Errorf("failed to get pipeline jobs: %w", err)
 	}
@@ -1144,13 +1402,19 @@ func (c *Client) AreAllPipelineJobsSucceeded(projectID, pipelineID int) (bool, e
 // - shouldSkip=true means rebase should be skipped
 // - reason explains why (e.g., "atlantis_plan_failed", "atlantis_plan_locked")
 // - If shouldSkip=false, reason will be empty or "atlantis_plan_locked"
-func (c *Client) CheckAtlantisCommentForPlanFailures(projectID, mrIID int) (bool, string) {
-	atlantisComment, err := c.FindLatestAtlantisComment(projectID, mrIID)
+func (c *Client) CheckAtlantisCommentForPlanFailures(ctx context.Context, projectID, mrIID int) (bool, string) {
+	atlantisComment, err := c.FindLatestAtlantisComment(ctx, projectID, mrIID)
 	if err != nil {
 		// If we can't find the comment, don't skip (safer to allow rebase)
 		return false, ""
 	}
 
+	return AtlantisPlanFailure(atlantisComment)
+}
+
+// AtlantisPlanFailure classifies the latest atlantis comment of an MR (nil when there is
+// none) like CheckAtlantisCommentForPlanFailures
+func AtlantisPlanFailure(atlantisComment *MRComment) (bool, string) {
 	if atlantisComment == nil {
 		// No atlantis comment found - if pipeline is failed, skip rebase to be safe
 		// (We can't determine if it's a state lock or real error without the comment)
@@ -1174,8 +1438,8 @@ func (c *Client) CheckAtlantisCommentForPlanFailures(projectID, mrIID int) (bool
 }
 
 // FindCommentByPattern checks if a comment containing the specified pattern exists on an MR
-func (c *Client) FindCommentByPattern(projectID, mrIID int, pattern string) (bool, error) {
-	comments, err := c.ListMRComments(projectID, mrIID)
+func (c *Client) FindCommentByPattern(ctx context.Context, projectID, mrIID int, pattern string) (bool, error) {
+	comments, err := c.ListMRComments(ctx, projectID, mrIID)
 	if err != nil {
 		return false, fmt.Errorf("failed to list comments: %w", err)
 	}
@@ -1,6 +1,7 @@
 package gitlab
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -9,6 +10,8 @@ import (
 	"testing"
 
 	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/tracing"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -27,7 +30,74 @@ func TestNewClientWithConfig(t *testing.T) {
 	assert.Equal(t, cfg.GitLab.Token, client.config.Token)
 }
 
+func TestNewClientForFunction(t *testing.T) {
+	cfg := &config.Config{
+		GitLab: config.GitLabConfig{
+			BaseURL:     "https://gitlab.example.com",
+			Token:       "main-token",
+			ReviewToken: "review-token",
+		},
+	}
+
+	assert.Equal(t, "review-token", NewClientForFunction(cfg, config.EndpointReview).config.Token)
+	assert.Equal(t, "main-token", NewClientForFunction(cfg, config.EndpointAutoRebase).config.Token)
+}
+
+func TestIsNaysayerBotAuthor_ConfiguredBotUsernames(t *testing.T) {
+	ctx := context.Background()
+	client := NewClient(config.GitLabConfig{BotUsernames: []string{"naysayer-review", "naysayer-rebase"}})
+
+	assert.True(t, client.IsNaysayerBotAuthor(ctx, map[string]interface{}{"username": "naysayer-review"}))
+	assert.True(t, client.IsNaysayerBotAuthor(ctx, map[string]interface{}{"username": "naysayer-rebase"}))
+	assert.False(t, client.IsNaysayerBotAuthor(ctx, map[string]interface{}{"username": "project_1_bot_abc"}), "patterns are not used once bots are configured")
+	assert.False(t, client.IsNaysayerBotAuthor(ctx, map[string]interface{}{"username": "naysayer-cleanup"}))
+	assert.False(t, client.IsNaysayerBotAuthor(ctx, map[string]interface{}{"username": "developer"}))
+
+	unconfigured := NewClient(config.GitLabConfig{})
+	assert.True(t, unconfigured.IsNaysayerBotAuthor(ctx, map[string]interface{}{"username": "project_1_bot_abc"}))
+	assert.True(t, unconfigured.IsNaysayerBotAuthor(ctx, map[string]interface{}{"username": "naysayer-bot"}))
+}
+
+func TestIsOurBotComment_ProjectBotIdentities(t *testing.T) {
+	ctx := context.Background()
+	client := NewClient(config.GitLabConfig{BotIdentities: []config.BotIdentity{
+		{ProjectID: 123, Username: "project_123_bot_4f2a", UserID: 456},
+		{ProjectID: 789, Username: "project_789_bot_9c1d"},
+	}})
+	renamed := map[string]interface{}{"id": float64(456), "username": "project_123_bot_renamed"}
+	impostor := map[string]interface{}{"id": float64(999), "username": "project_123_bot_4f2a"}
+	other := map[string]interface{}{"id": float64(321), "username": "project_789_bot_9c1d"}
+
+	assert.True(t, client.isOurBotComment(ctx, 123, renamed, ""), "identities with a user ID match by ID")
+	assert.False(t, client.isOurBotComment(ctx, 123, impostor, ""))
+	assert.False(t, client.isOurBotComment(ctx, 123, other, ""), "bots of other projects are not ours")
+	assert.True(t, client.isOurBotComment(ctx, 789, other, ""))
+	assert.True(t, client.IsNaysayerBotAuthor(ctx, other), "any project matches without a project")
+	assert.False(t, client.isOurBotComment(ctx, 789, other, "naysayer-review"), "the current username wins")
+}
+
+func TestVerifyBotIdentity(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/user", r.URL.Path)
+		_, _ = w.Write([]byte(`{"id": 456, "username": "project_123_bot_4f2a"}`))
+	}))
+	defer server.Close()
+
+	user, err := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "t"}).VerifyBotIdentity(context.Background())
+	assert.NoError(t, err, "any user is accepted without configured identities")
+	assert.Equal(t, &BotUser{ID: 456, Username: "project_123_bot_4f2a"}, user)
+
+	_, err = NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "t",
+		BotIdentities: []config.BotIdentity{{ProjectID: 123, Username: "project_123_bot_4f2a", UserID: 456}}}).VerifyBotIdentity(context.Background())
+	assert.NoError(t, err)
+
+	_, err = NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "t", BotUsernames: []string{"naysayer-review"}}).VerifyBotIdentity(context.Background())
+	assert.ErrorIs(t, err, ErrBotIdentityMismatch)
+	assert.Contains(t, err.Error(), "project_123_bot_4f2a (ID 456)")
+}
+
 func TestAddMRComment_Success(t *testing.T) {
+	ctx := context.Background()
 	// Create test server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Verify request
@@ -57,12 +127,94 @@ func TestAddMRComment_Success(t *testing.T) {
 
 	client := NewClientWithConfig(cfg)
 
-	err := client.AddMRComment(123, 456, "Test comment")
+	err := client.AddMRComment(ctx, 123, 456, "Test comment")
 
 	assert.NoError(t, err)
 }
 
+func TestAddMRComment_CarriesCorrelationID(t *testing.T) {
+	ctx := logging.WithCorrelationID(context.Background(), "delivery-7")
+	var requestID, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID = r.Header.Get("X-Request-Id")
+		var payload map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		body = payload["body"]
+		w.WriteHeader(201)
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+	earlier := "Test comment\n<!-- naysayer-correlation-id: delivery-1 -->"
+	assert.NoError(t, client.AddMRComment(ctx, 123, 456, earlier))
+
+	assert.Equal(t, "delivery-7", requestID)
+	assert.Equal(t, "Test comment\n<!-- naysayer-correlation-id: delivery-7 -->", body, "the ID replaces the one of an earlier request")
+	assert.Equal(t, "Test comment", StripCorrelationID(body))
+	assert.Equal(t, "Test comment", withCorrelationID(context.Background(), earlier), "requests without an ID leave none behind")
+}
+
+func TestAddMRComment_Traced(t *testing.T) {
+	var exported []byte
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		exported, _ = io.ReadAll(r.Body)
+	}))
+	defer collector.Close()
+	tracer := tracing.NewTracerFromConfig(config.TracingConfig{Endpoint: collector.URL, SampleRatio: 1})
+	tracing.SetDefault(tracer)
+	defer tracing.SetDefault(nil)
+
+	var traceParent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceParent = r.Header.Get("traceparent")
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	ctx, parent := tracing.Start(context.Background(), "rules.EvaluateAll", tracing.KindInternal)
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+	assert.Error(t, client.AddMRComment(ctx, 123, 456, "Test comment"))
+	parent.End()
+	assert.NoError(t, tracer.Shutdown(context.Background()))
+
+	sc, ok := tracing.ParseTraceParent(traceParent)
+	assert.True(t, ok, "GitLab receives the trace context")
+	assert.Equal(t, parent.SpanContext().TraceID, sc.TraceID)
+	assert.NotEqual(t, parent.SpanContext().SpanID, sc.SpanID, "GitLab's parent is the client span")
+
+	var request struct {
+		ResourceSpans []struct {
+			ScopeSpans []struct {
+				Spans []struct {
+					Name         string `json:"name"`
+					ParentSpanID string `json:"parentSpanId"`
+					Kind         int    `json:"kind"`
+					Attributes   []struct {
+						Key   string            `json:"key"`
+						Value map[string]string `json:"value"`
+					} `json:"attributes"`
+					Status struct {
+						Code int `json:"code"`
+					} `json:"status"`
+				} `json:"spans"`
+			} `json:"scopeSpans"`
+		} `json:"resourceSpans"`
+	}
+	assert.NoError(t, json.Unmarshal(exported, &request))
+	span := request.ResourceSpans[0].ScopeSpans[0].Spans[0]
+	assert.Equal(t, "GitLab POST", span.Name)
+	assert.Equal(t, int(tracing.KindClient), span.Kind)
+	assert.Equal(t, 2, span.Status.Code)
+	attributes := make(map[string]map[string]string)
+	for _, attr := range span.Attributes {
+		attributes[attr.Key] = attr.Value
+	}
+	assert.Equal(t, "403", attributes["http.response.status_code"]["intValue"])
+	assert.Equal(t, "/api/v4/projects/123/merge_requests/456/notes", attributes["url.path"]["stringValue"])
+}
+
 func TestAddMRComment_UnauthorizedError(t *testing.T) {
+	ctx := context.Background()
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(401)
 		_, _ = w.Write([]byte(`{"message": "401 Unauthorized"}`))
@@ -78,13 +230,14 @@ func TestAddMRComment_UnauthorizedError(t *testing.T) {
 
 	client := NewClientWithConfig(cfg)
 
-	err := client.AddMRComment(123, 456, "Test comment")
+	err := client.AddMRComment(ctx, 123, 456, "Test comment")
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "comment failed: insufficient permissions")
 }
 
 func TestAddMRComment_NotFoundError(t *testing.T) {
+	ctx := context.Background()
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(404)
 		_, _ = w.Write([]byte(`{"message": "404 Not Found"}`))
@@ -100,13 +253,14 @@ func TestAddMRComment_NotFoundError(t *testing.T) {
 
 	client := NewClientWithConfig(cfg)
 
-	err := client.AddMRComment(123, 456, "Test comment")
+	err := client.AddMRComment(ctx, 123, 456, "Test comment")
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "comment failed: MR not found")
 }
 
 func TestAddMRComment_GenericError(t *testing.T) {
+	ctx := context.Background()
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(500)
 		_, _ = w.Write([]byte(`{"message": "Internal Server Error"}`))
@@ -122,7 +276,7 @@ func TestAddMRComment_GenericError(t *testing.T) {
 
 	client := NewClientWithConfig(cfg)
 
-	err := client.AddMRComment(123, 456, "Test comment")
+	err := client.AddMRComment(ctx, 123, 456, "Test comment")
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "comment failed with status 500")
@@ -130,6 +284,7 @@ func TestAddMRComment_GenericError(t *testing.T) {
 }
 
 func TestApproveMR_Success(t *testing.T) {
+	ctx := context.Background()
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Verify request
 		assert.Equal(t, "POST", r.Method)
@@ -155,12 +310,13 @@ func TestApproveMR_Success(t *testing.T) {
 
 	client := NewClientWithConfig(cfg)
 
-	err := client.ApproveMR(123, 456)
+	err := client.ApproveMR(ctx, 123, 456)
 
 	assert.NoError(t, err)
 }
 
 func TestApproveMRWithMessage_Success(t *testing.T) {
+	ctx := context.Background()
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Verify request
 		assert.Equal(t, "POST", r.Method)
@@ -188,12 +344,13 @@ func TestApproveMRWithMessage_Success(t *testing.T) {
 
 	client := NewClientWithConfig(cfg)
 
-	err := client.ApproveMRWithMessage(123, 456, "Auto-approved: Safe changes")
+	err := client.ApproveMRWithMessage(ctx, 123, 456, "Auto-approved: Safe changes")
 
 	assert.NoError(t, err)
 }
 
 func TestApproveMRWithMessage_EmptyMessage(t *testing.T) {
+	ctx := context.Background()
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Verify request body is empty JSON object for empty message
 		body, _ := io.ReadAll(r.Body)
@@ -213,12 +370,13 @@ func TestApproveMRWithMessage_EmptyMessage(t *testing.T) {
 
 	client := NewClientWithConfig(cfg)
 
-	err := client.ApproveMRWithMessage(123, 456, "")
+	err := client.ApproveMRWithMessage(ctx, 123, 456, "")
 
 	assert.NoError(t, err)
 }
 
 func TestApproveMRWithMessage_UnauthorizedError(t *testing.T) {
+	ctx := context.Background()
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(401)
 		_, _ = w.Write([]byte(`{"message": "401 Unauthorized"}`))
@@ -234,13 +392,14 @@ func TestApproveMRWithMessage_UnauthorizedError(t *testing.T) {
 
 	client := NewClientWithConfig(cfg)
 
-	err := client.ApproveMRWithMessage(123, 456, "Test approval")
+	err := client.ApproveMRWithMessage(ctx, 123, 456, "Test approval")
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "approval failed: insufficient permissions")
 }
 
 func TestApproveMRWithMessage_NotFoundError(t *testing.T) {
+	ctx := context.Background()
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(404)
 		_, _ = w.Write([]byte(`{"message": "404 Not Found"}`))
@@ -256,13 +415,14 @@ func TestApproveMRWithMessage_NotFoundError(t *testing.T) {
 
 	client := NewClientWithConfig(cfg)
 
-	err := client.ApproveMRWithMessage(123, 456, "Test approval")
+	err := client.ApproveMRWithMessage(ctx, 123, 456, "Test approval")
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "approval failed: MR not found")
 }
 
 func TestApproveMRWithMessage_AlreadyApprovedError(t *testing.T) {
+	ctx := context.Background()
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(405)
 		_, _ = w.Write([]byte(`{"message": "Method Not Allowed"}`))
@@ -278,13 +438,14 @@ func TestApproveMRWithMessage_AlreadyApprovedError(t *testing.T) {
 
 	client := NewClientWithConfig(cfg)
 
-	err := client.ApproveMRWithMessage(123, 456, "Test approval")
+	err := client.ApproveMRWithMessage(ctx, 123, 456, "Test approval")
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "approval failed: MR already approved or cannot be approved")
 }
 
 func TestApproveMRWithMessage_GenericError(t *testing.T) {
+	ctx := context.Background()
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(500)
 		_, _ = w.Write([]byte(`{"message": "Internal Server Error"}`))
@@ -300,7 +461,7 @@ func TestApproveMRWithMessage_GenericError(t *testing.T) {
 
 	client := NewClientWithConfig(cfg)
 
-	err := client.ApproveMRWithMessage(123, 456, "Test approval")
+	err := client.ApproveMRWithMessage(ctx, 123, 456, "Test approval")
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "approval failed with status 500")
@@ -308,6 +469,7 @@ func TestApproveMRWithMessage_GenericError(t *testing.T) {
 }
 
 func TestAddMRComment_NetworkError(t *testing.T) {
+	ctx := context.Background()
 	cfg := &config.Config{
 		GitLab: config.GitLabConfig{
 			BaseURL: "http://non-existent-server.invalid",
@@ -317,13 +479,14 @@ func TestAddMRComment_NetworkError(t *testing.T) {
 
 	client := NewClientWithConfig(cfg)
 
-	err := client.AddMRComment(123, 456, "Test comment")
+	err := client.AddMRComment(ctx, 123, 456, "Test comment")
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to add comment")
 }
 
 func TestApproveMRWithMessage_NetworkError(t *testing.T) {
+	ctx := context.Background()
 	cfg := &config.Config{
 		GitLab: config.GitLabConfig{
 			BaseURL: "http://non-existent-server.invalid",
@@ -333,13 +496,14 @@ func TestApproveMRWithMessage_NetworkError(t *testing.T) {
 
 	client := NewClientWithConfig(cfg)
 
-	err := client.ApproveMRWithMessage(123, 456, "Test approval")
+	err := client.ApproveMRWithMessage(ctx, 123, 456, "Test approval")
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to approve MR")
 }
 
 func TestApproveMR_CallsApproveMRWithMessage(t *testing.T) {
+	ctx := context.Background()
 	// Test that ApproveMR correctly calls ApproveMRWithMessage with empty message
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Verify that empty message results in empty JSON object
@@ -360,13 +524,14 @@ func TestApproveMR_CallsApproveMRWithMessage(t *testing.T) {
 
 	client := NewClientWithConfig(cfg)
 
-	err := client.ApproveMR(123, 456)
+	err := client.ApproveMR(ctx, 123, 456)
 
 	assert.NoError(t, err)
 }
 
 // TestListMRComments_Pagination tests basic pagination functionality
 func TestListMRComments_Pagination(t *testing.T) {
+	ctx := context.Background()
 	requestCount := 0
 	var serverURL string
 
@@ -396,7 +561,7 @@ func TestListMRComments_Pagination(t *testing.T) {
 	serverURL = server.URL
 
 	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
-	comments, err := client.ListMRComments(123, 456)
+	comments, err := client.ListMRComments(ctx, 123, 456)
 
 	assert.NoError(t, err)
 	assert.Len(t, comments, 150)
@@ -1,52 +1,56 @@
 package gitlab
 
+import "context"
+
 // GitLabClient is an interface for GitLab API operations
 // This interface allows for easy mocking in tests
+// Implementations must be safe for concurrent use: the rule manager validates the files of
+// an MR on up to evaluation_workers goroutines sharing one client
 type GitLabClient interface {
 	// File operations
-	FetchFileContent(projectID int, filePath, ref string) (*FileContent, error)
-	GetMRTargetBranch(projectID, mrIID int) (string, error)
-	GetMRDetails(projectID, mrIID int) (*MRDetails, error)
+	FetchFileContent(ctx context.Context, projectID int, filePath, ref string) (*FileContent, error)
+	GetMRTargetBranch(ctx context.Context, projectID, mrIID int) (string, error)
+	GetMRDetails(ctx context.Context, projectID, mrIID int) (*MRDetails, error)
 
 	// MR changes
-	FetchMRChanges(projectID, mrIID int) ([]FileChange, error)
+	FetchMRChanges(ctx context.Context, projectID, mrIID int) ([]FileChange, error)
 
 	// Comments
-	AddMRComment(projectID, mrIID int, comment string) error
-	AddOrUpdateMRComment(projectID, mrIID int, commentBody, commentType string) error
-	ListMRComments(projectID, mrIID int) ([]MRComment, error)
-	UpdateMRComment(projectID, mrIID, commentID int, newBody string) error
-	FindLatestNaysayerComment(projectID, mrIID int, commentType ...string) (*MRComment, error)
+	AddMRComment(ctx context.Context, projectID, mrIID int, comment string) error
+	AddOrUpdateMRComment(ctx context.Context, projectID, mrIID int, commentBody, commentType string) error
+	ListMRComments(ctx context.Context, projectID, mrIID int) ([]MRComment, error)
+	UpdateMRComment(ctx context.Context, projectID, mrIID, commentID int, newBody string) error
+	FindLatestNaysayerComment(ctx context.Context, projectID, mrIID int, commentType ...string) (*MRComment, error)
 
 	// Approvals
-	ApproveMR(projectID, mrIID int) error
-	ApproveMRWithMessage(projectID, mrIID int, message string) error
-	ResetNaysayerApproval(projectID, mrIID int) error
+	ApproveMR(ctx context.Context, projectID, mrIID int) error
+	ApproveMRWithMessage(ctx context.Context, projectID, mrIID int, message string) error
+	ResetNaysayerApproval(ctx context.Context, projectID, mrIID int) error
 
 	// Bot identity
-	GetCurrentBotUsername() (string, error)
-	IsNaysayerBotAuthor(author map[string]interface{}) bool
+	GetCurrentBotUsername(ctx context.Context) (string, error)
+	IsNaysayerBotAuthor(ctx context.Context, author map[string]interface{}) bool
 
 	// Rebase operations
-	RebaseMR(projectID, mrIID int) (bool, error) // Returns (success, error)
-	CompareBranches(sourceProjectID int, sourceBranch string, targetProjectID int, targetBranch string) (*CompareResult, error)
+	RebaseMR(ctx context.Context, projectID, mrIID int) (bool, error) // Returns (success, error)
+	CompareBranches(ctx context.Context, sourceProjectID int, sourceBranch string, targetProjectID int, targetBranch string) (*CompareResult, error)
 	// GetBranchCommit returns the commit SHA of the branch HEAD (for fork MR SHA-based compare)
-	GetBranchCommit(projectID int, branch string) (string, error)
+	GetBranchCommit(ctx context.Context, projectID int, branch string) (string, error)
 	// CompareCommits compares two commits by SHA in one project (used for fork MRs; GitLab cannot compare across projects by branch)
-	CompareCommits(projectID int, fromSHA, toSHA string) (*CompareResult, error)
-	ListOpenMRs(projectID int) ([]int, error)
-	ListOpenMRsWithDetails(projectID int) ([]MRDetails, error)
+	CompareCommits(ctx context.Context, projectID int, fromSHA, toSHA string) (*CompareResult, error)
+	ListOpenMRs(ctx context.Context, projectID int) ([]int, error)
+	ListOpenMRsWithDetails(ctx context.Context, projectID int) ([]MRDetails, error)
 
 	// Pipeline and job operations
-	GetPipelineJobs(projectID, pipelineID int) ([]PipelineJob, error)
-	GetJobTrace(projectID, jobID int) (string, error)
-	FindLatestAtlantisComment(projectID, mrIID int) (*MRComment, error)
-	AreAllPipelineJobsSucceeded(projectID, pipelineID int) (bool, error)
-	CheckAtlantisCommentForPlanFailures(projectID, mrIID int) (bool, string)
+	GetPipelineJobs(ctx context.Context, projectID, pipelineID int) ([]PipelineJob, error)
+	GetJobTrace(ctx context.Context, projectID, jobID int) (string, error)
+	FindLatestAtlantisComment(ctx context.Context, projectID, mrIID int) (*MRComment, error)
+	AreAllPipelineJobsSucceeded(ctx context.Context, projectID, pipelineID int) (bool, error)
+	CheckAtlantisCommentForPlanFailures(ctx context.Context, projectID, mrIID int) (bool, string)
 	// Stale MR cleanup operations
-	ListAllOpenMRsWithDetails(projectID int) ([]MRDetails, error)
-	CloseMR(projectID, mrIID int) error
-	FindCommentByPattern(projectID, mrIID int, pattern string) (bool, error)
+	ListAllOpenMRsWithDetails(ctx context.Context, projectID int) ([]MRDetails, error)
+	CloseMR(ctx context.Context, projectID, mrIID int) error
+	FindCommentByPattern(ctx context.Context, projectID, mrIID int, pattern string) (bool, error)
 }
 
 // Verify that Client implements GitLabClient interface
@@ -1,10 +1,15 @@
 package gitlab
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/redhat-data-and-ai/naysayer/internal/config"
 	"github.com/stretchr/testify/assert"
@@ -25,11 +30,12 @@ func TestNewClient(t *testing.T) {
 }
 
 func TestClient_FetchMRChanges_Success(t *testing.T) {
+	ctx := context.Background()
 	// Create test server that returns mock GitLab response
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Verify request
 		assert.Equal(t, "GET", r.Method)
-		assert.Contains(t, r.URL.Path, "/api/v4/projects/123/merge_requests/456/changes")
+		assert.Contains(t, r.URL.Path, "/api/v4/projects/123/merge_requests/456/diffs")
 		assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
 		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
 
@@ -69,7 +75,7 @@ func TestClient_FetchMRChanges_Success(t *testing.T) {
 		}
 
 		w.Header().Set("Content-Type", "application/json")
-		_ = json.NewEncoder(w).Encode(mockResponse)
+		_ = json.NewEncoder(w).Encode(mockResponse.Changes)
 	}))
 	defer server.Close()
 
@@ -79,7 +85,7 @@ func TestClient_FetchMRChanges_Success(t *testing.T) {
 	}
 	client := NewClient(cfg)
 
-	changes, err := client.FetchMRChanges(123, 456)
+	changes, err := client.FetchMRChanges(ctx, 123, 456)
 
 	assert.NoError(t, err)
 	assert.Len(t, changes, 2)
@@ -98,7 +104,57 @@ func TestClient_FetchMRChanges_Success(t *testing.T) {
 	assert.False(t, changes[1].DeletedFile)
 }
 
+func TestClient_FetchMRChanges_FollowsPages(t *testing.T) {
+	ctx := context.Background()
+	var serverURL string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "100", r.URL.Query().Get("per_page"))
+		if r.URL.Query().Get("page") == "2" {
+			_, _ = w.Write([]byte(`[{"new_path": "b.yaml", "diff": "+b"}]`))
+			return
+		}
+		w.Header().Set("Link", fmt.Sprintf(`<%s/api/v4/projects/123/merge_requests/456/diffs?per_page=100&page=2>; rel="next"`, serverURL))
+		_, _ = w.Write([]byte(`[{"new_path": "a.yaml", "diff": "+a"}]`))
+	}))
+	defer server.Close()
+	serverURL = server.URL
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+	changes, err := client.FetchMRChanges(ctx, 123, 456)
+
+	assert.NoError(t, err)
+	assert.Len(t, changes, 2)
+	assert.Equal(t, "a.yaml", changes[0].NewPath)
+	assert.Equal(t, "b.yaml", changes[1].NewPath)
+}
+
+func TestClient_FetchMRChanges_TooLarge(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"new_path": "a.yaml", "diff": "+aaaa"}, {"new_path": "b.yaml", "diff": "+bbbb"}, {"new_path": "c.yaml", "diff": "+c"}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token", MaxMRFiles: 2})
+	changes, err := client.FetchMRChanges(ctx, 123, 456)
+	assert.Nil(t, changes)
+	assert.ErrorIs(t, err, ErrMRTooLarge)
+	assert.EqualError(t, err, "merge request too large to analyze: more than 2 changed files")
+
+	client = NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token", MaxMRDiffBytes: 8})
+	_, err = client.FetchMRChanges(ctx, 123, 456)
+	var tooLarge *TooLargeError
+	assert.ErrorAs(t, err, &tooLarge)
+	assert.Equal(t, "diff larger than 8 bytes", tooLarge.Reason)
+
+	client = NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token", MaxMRFiles: 3, MaxMRDiffBytes: 12})
+	changes, err = client.FetchMRChanges(ctx, 123, 456)
+	assert.NoError(t, err)
+	assert.Len(t, changes, 3)
+}
+
 func TestClient_FetchMRChanges_HTTPErrors(t *testing.T) {
+	ctx := context.Background()
 	tests := []struct {
 		name          string
 		statusCode    int
@@ -145,7 +201,7 @@ func TestClient_FetchMRChanges_HTTPErrors(t *testing.T) {
 			}
 			client := NewClient(cfg)
 
-			changes, err := client.FetchMRChanges(123, 456)
+			changes, err := client.FetchMRChanges(ctx, 123, 456)
 
 			assert.Error(t, err)
 			assert.Nil(t, changes)
@@ -155,9 +211,10 @@ func TestClient_FetchMRChanges_HTTPErrors(t *testing.T) {
 }
 
 func TestClient_FetchMRChanges_InvalidJSON(t *testing.T) {
+	ctx := context.Background()
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
-		_, _ = w.Write([]byte(`{"invalid": json}`)) // Invalid JSON
+		_, _ = w.Write([]byte(`[{"invalid": json}]`)) // Invalid JSON
 	}))
 	defer server.Close()
 
@@ -167,7 +224,7 @@ func TestClient_FetchMRChanges_InvalidJSON(t *testing.T) {
 	}
 	client := NewClient(cfg)
 
-	changes, err := client.FetchMRChanges(123, 456)
+	changes, err := client.FetchMRChanges(ctx, 123, 456)
 
 	assert.Error(t, err)
 	assert.Nil(t, changes)
@@ -175,13 +232,14 @@ func TestClient_FetchMRChanges_InvalidJSON(t *testing.T) {
 }
 
 func TestClient_FetchMRChanges_NetworkError(t *testing.T) {
+	ctx := context.Background()
 	cfg := config.GitLabConfig{
 		BaseURL: "http://localhost:99999", // Non-existent server
 		Token:   "test-token",
 	}
 	client := NewClient(cfg)
 
-	changes, err := client.FetchMRChanges(123, 456)
+	changes, err := client.FetchMRChanges(ctx, 123, 456)
 
 	assert.Error(t, err)
 	assert.Nil(t, changes)
@@ -190,6 +248,7 @@ func TestClient_FetchMRChanges_NetworkError(t *testing.T) {
 }
 
 func TestClient_FetchMRChanges_URLConstruction(t *testing.T) {
+	ctx := context.Background()
 	tests := []struct {
 		name        string
 		baseURL     string
@@ -202,21 +261,21 @@ func TestClient_FetchMRChanges_URLConstruction(t *testing.T) {
 			baseURL:     "https://gitlab.com",
 			projectID:   123,
 			mrIID:       456,
-			expectedURL: "/api/v4/projects/123/merge_requests/456/changes",
+			expectedURL: "/api/v4/projects/123/merge_requests/456/diffs",
 		},
 		{
 			name:        "URL with trailing slash",
 			baseURL:     "https://gitlab.example.com/",
 			projectID:   789,
 			mrIID:       101,
-			expectedURL: "/api/v4/projects/789/merge_requests/101/changes",
+			expectedURL: "/api/v4/projects/789/merge_requests/101/diffs",
 		},
 		{
 			name:        "custom GitLab instance",
 			baseURL:     "https://git.company.com",
 			projectID:   999,
 			mrIID:       888,
-			expectedURL: "/api/v4/projects/999/merge_requests/888/changes",
+			expectedURL: "/api/v4/projects/999/merge_requests/888/diffs",
 		},
 	}
 
@@ -226,16 +285,7 @@ func TestClient_FetchMRChanges_URLConstruction(t *testing.T) {
 			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				requestURL = r.URL.Path
 				w.Header().Set("Content-Type", "application/json")
-				_ = json.NewEncoder(w).Encode(MRChanges{Changes: []struct {
-					OldPath     string `json:"old_path"`
-					NewPath     string `json:"new_path"`
-					AMode       string `json:"a_mode"`
-					BMode       string `json:"b_mode"`
-					NewFile     bool   `json:"new_file"`
-					RenamedFile bool   `json:"renamed_file"`
-					DeletedFile bool   `json:"deleted_file"`
-					Diff        string `json:"diff"`
-				}{}})
+				_, _ = w.Write([]byte(`[]`))
 			}))
 			defer server.Close()
 
@@ -245,7 +295,7 @@ func TestClient_FetchMRChanges_URLConstruction(t *testing.T) {
 			}
 			client := NewClient(cfg)
 
-			_, err := client.FetchMRChanges(tt.projectID, tt.mrIID)
+			_, err := client.FetchMRChanges(ctx, tt.projectID, tt.mrIID)
 
 			assert.NoError(t, err)
 			assert.Equal(t, tt.expectedURL, requestURL)
@@ -265,6 +315,7 @@ func TestExtractMRInfo_Success(t *testing.T) {
 				"object_attributes": map[string]interface{}{
 					"iid":           float64(123),
 					"title":         "Update warehouse configuration",
+					"description":   "Raise the warehouse size",
 					"source_branch": "feature/update-warehouse",
 					"target_branch": "main",
 				},
@@ -279,6 +330,7 @@ func TestExtractMRInfo_Success(t *testing.T) {
 				ProjectID:    456,
 				MRIID:        123,
 				Title:        "Update warehouse configuration",
+				Description:  "Raise the warehouse size",
 				Author:       "developer1",
 				SourceBranch: "feature/update-warehouse",
 				TargetBranch: "main",
@@ -524,19 +576,30 @@ func TestExtractMRInfo_PartialData(t *testing.T) {
 	assert.Equal(t, "", result.TargetBranch)
 }
 
+func TestExtractMRInfo_Draft(t *testing.T) {
+	for _, attr := range []string{"draft", "work_in_progress"} {
+		payload := map[string]interface{}{
+			"object_attributes": map[string]interface{}{"iid": float64(1), "title": "Add warehouse", attr: true},
+			"project":           map[string]interface{}{"id": float64(2)},
+		}
+		result, err := ExtractMRInfo(payload)
+		assert.NoError(t, err)
+		assert.True(t, result.Draft, attr)
+	}
+
+	assert.True(t, (&MRDetails{Draft: true}).IsDraft())
+	assert.True(t, (&MRDetails{WorkInProgress: true}).IsDraft())
+	assert.False(t, (&MRDetails{}).IsDraft())
+
+	assert.True(t, (&MRDetails{Labels: []string{"backend", "No-Auto-Rebase"}}).HasLabel("no-auto-rebase"))
+	assert.False(t, (&MRDetails{Labels: []string{"backend"}}).HasLabel("no-auto-rebase"))
+}
+
 func TestClient_FetchMRChanges_EmptyResponse(t *testing.T) {
+	ctx := context.Background()
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
-		_ = json.NewEncoder(w).Encode(MRChanges{Changes: []struct {
-			OldPath     string `json:"old_path"`
-			NewPath     string `json:"new_path"`
-			AMode       string `json:"a_mode"`
-			BMode       string `json:"b_mode"`
-			NewFile     bool   `json:"new_file"`
-			RenamedFile bool   `json:"renamed_file"`
-			DeletedFile bool   `json:"deleted_file"`
-			Diff        string `json:"diff"`
-		}{}}) // Empty changes array
+		_, _ = w.Write([]byte(`[]`)) // Empty changes array
 	}))
 	defer server.Close()
 
@@ -546,27 +609,19 @@ func TestClient_FetchMRChanges_EmptyResponse(t *testing.T) {
 	}
 	client := NewClient(cfg)
 
-	changes, err := client.FetchMRChanges(123, 456)
+	changes, err := client.FetchMRChanges(ctx, 123, 456)
 
 	assert.NoError(t, err)
 	assert.Empty(t, changes)
 }
 
 func TestClient_RequestHeaders(t *testing.T) {
+	ctx := context.Background()
 	var capturedHeaders http.Header
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		capturedHeaders = r.Header
 		w.Header().Set("Content-Type", "application/json")
-		_ = json.NewEncoder(w).Encode(MRChanges{Changes: []struct {
-			OldPath     string `json:"old_path"`
-			NewPath     string `json:"new_path"`
-			AMode       string `json:"a_mode"`
-			BMode       string `json:"b_mode"`
-			NewFile     bool   `json:"new_file"`
-			RenamedFile bool   `json:"renamed_file"`
-			DeletedFile bool   `json:"deleted_file"`
-			Diff        string `json:"diff"`
-		}{}})
+		_, _ = w.Write([]byte(`[]`))
 	}))
 	defer server.Close()
 
@@ -576,9 +631,105 @@ func TestClient_RequestHeaders(t *testing.T) {
 	}
 	client := NewClient(cfg)
 
-	_, err := client.FetchMRChanges(123, 456)
+	_, err := client.FetchMRChanges(ctx, 123, 456)
 
 	assert.NoError(t, err)
 	assert.Equal(t, "Bearer test-token-xyz", capturedHeaders.Get("Authorization"))
 	assert.Equal(t, "application/json", capturedHeaders.Get("Content-Type"))
 }
+
+func TestClient_ListOpenMRsWithDetails_FetchesConcurrentlyInOrder(t *testing.T) {
+	ctx := context.Background()
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/merge_requests") {
+			_, _ = w.Write([]byte(`[{"iid": 1}, {"iid": 2}, {"iid": 3}, {"iid": 4}, {"iid": 5}, {"iid": 6}]`))
+			return
+		}
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			current := atomic.LoadInt32(&maxInFlight)
+			if n <= current || atomic.CompareAndSwapInt32(&maxInFlight, current, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+
+		var iid int
+		_, _ = fmt.Sscanf(r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:], "%d", &iid)
+		if iid == 4 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"iid": %d, "title": "MR %d"}`, iid, iid)
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "token", DetailConcurrency: 3})
+	mrs, err := client.ListOpenMRsWithDetails(ctx, 42)
+	assert.NoError(t, err)
+
+	var iids []int
+	for _, mr := range mrs {
+		iids = append(iids, mr.IID)
+	}
+	assert.Equal(t, []int{1, 2, 3, 5, 6}, iids, "order is preserved and failed MRs are skipped")
+	assert.Equal(t, int32(3), atomic.LoadInt32(&maxInFlight), "at most DetailConcurrency requests are in flight")
+}
+
+func TestClient_ListOpenMRsWithDetails_SerialWithoutConcurrency(t *testing.T) {
+	ctx := context.Background()
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/merge_requests") {
+			_, _ = w.Write([]byte(`[{"iid": 1}, {"iid": 2}, {"iid": 3}]`))
+			return
+		}
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		if n > atomic.LoadInt32(&maxInFlight) {
+			atomic.StoreInt32(&maxInFlight, n)
+		}
+		_, _ = w.Write([]byte(`{"iid": 1}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "token"})
+	mrs, err := client.ListOpenMRsWithDetails(ctx, 42)
+	assert.NoError(t, err)
+	assert.Len(t, mrs, 3)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&maxInFlight), "unset concurrency fetches serially")
+}
+
+func TestExtractMRInfo_HeadSHA(t *testing.T) {
+	payload := map[string]interface{}{
+		"object_attributes": map[string]interface{}{
+			"iid":         float64(1),
+			"last_commit": map[string]interface{}{"id": "abc123", "message": "Add warehouse"},
+		},
+		"project": map[string]interface{}{"id": float64(2)},
+	}
+	result, err := ExtractMRInfo(payload)
+	assert.NoError(t, err)
+	assert.Equal(t, "abc123", result.HeadSHA)
+}
+
+func TestExtractMRInfo_OldRev(t *testing.T) {
+	payload := map[string]interface{}{
+		"object_attributes": map[string]interface{}{
+			"iid":    float64(1),
+			"action": "update",
+			"oldrev": "def456",
+		},
+		"project": map[string]interface{}{"id": float64(2)},
+	}
+	result, err := ExtractMRInfo(payload)
+	assert.NoError(t, err)
+	assert.Equal(t, "def456", result.OldRev)
+
+	delete(payload["object_attributes"].(map[string]interface{}), "oldrev")
+	result, err = ExtractMRInfo(payload)
+	assert.NoError(t, err)
+	assert.Empty(t, result.OldRev, "only pushes set oldrev")
+}
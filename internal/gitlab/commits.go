@@ -0,0 +1,188 @@
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Commit actions of CreateCommit
+const (
+	CommitActionCreate = "create"
+	CommitActionUpdate = "update"
+	CommitActionDelete = "delete"
+)
+
+// CommitAction is one file change of a commit created through the API
+type CommitAction struct {
+	Action   string `json:"action"` // CommitActionCreate, CommitActionUpdate or CommitActionDelete
+	FilePath string `json:"file_path"`
+	Content  string `json:"content,omitempty"`
+}
+
+// CreateCommit commits actions to an existing branch and returns the new commit SHA.
+// POST /projects/:id/repository/commits
+func (c *Client) CreateCommit(ctx context.Context, projectID int, branch, message string, actions []CommitAction) (string, error) {
+	apiURL := c.apiURL("/projects/%d/repository/commits", projectID)
+
+	jsonPayload, err := json.Marshal(map[string]interface{}{
+		"branch":         branch,
+		"commit_message": message,
+		"actions":        actions,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal commit payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", apiURL, bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return "", fmt.Errorf("failed to create commit request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to create commit: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return "", newAPIError(resp, "create commit failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var commit struct {
+		ID string `json:"id"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&commit); err != nil {
+		return "", fmt.Errorf("failed to decode commit response: %w", err)
+	}
+	return commit.ID, nil
+}
+
+// ListCommitMRs returns the merge requests that introduced a commit.
+// GET /projects/:id/repository/commits/:sha/merge_requests
+func (c *Client) ListCommitMRs(ctx context.Context, projectID int, sha string) ([]MRDetails, error) {
+	apiURL := c.apiURL("/projects/%d/repository/commits/%s/merge_requests", projectID, url.PathEscape(sha))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create commit merge requests request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commit merge requests: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, newAPIError(resp, "list commit merge requests failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var mrs []MRDetails
+	if err := json.NewDecoder(resp.Body).Decode(&mrs); err != nil {
+		return nil, fmt.Errorf("failed to decode commit merge requests response: %w", err)
+	}
+
+	return mrs, nil
+}
+
+// GetCommitBefore returns the SHA of the latest commit on ref created before until, or ""
+// when the ref has no commit that old.
+// GET /projects/:id/repository/commits?ref_name=:ref&until=:until&per_page=1
+func (c *Client) GetCommitBefore(ctx context.Context, projectID int, ref string, until time.Time) (string, error) {
+	query := url.Values{}
+	query.Set("ref_name", ref)
+	query.Set("until", until.UTC().Format(time.RFC3339))
+	query.Set("per_page", "1")
+	apiURL := c.apiURL("/projects/%d/repository/commits?%s", projectID, query.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create commits request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to list commits: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return "", newAPIError(resp, "list commits failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var commits []struct {
+		ID string `json:"id"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&commits); err != nil {
+		return "", fmt.Errorf("failed to decode commits response: %w", err)
+	}
+	if len(commits) == 0 {
+		return "", nil
+	}
+	return commits[0].ID, nil
+}
+
+// Commit status states of SetCommitStatus
+const (
+	CommitStatusPending = "pending"
+	CommitStatusSuccess = "success"
+	CommitStatusFailed  = "failed"
+)
+
+// CommitStatus is an external status reported on a commit, shown next to the MR pipeline
+type CommitStatus struct {
+	State       string `json:"state"` // CommitStatusPending, CommitStatusSuccess or CommitStatusFailed
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	TargetURL   string `json:"target_url,omitempty"`
+}
+
+// SetCommitStatus reports a status on a commit. Reposting the current state of a status
+// is not an error.
+// POST /projects/:id/statuses/:sha
+func (c *Client) SetCommitStatus(ctx context.Context, projectID int, sha string, status CommitStatus) error {
+	apiURL := c.apiURL("/projects/%d/statuses/%s", projectID, url.PathEscape(sha))
+
+	jsonPayload, err := json.Marshal(status)
+	if err != nil {
+		return fmt.Errorf("failed to marshal commit status payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", apiURL, bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return fmt.Errorf("failed to create commit status request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to set commit status: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		// GitLab refuses to move a status to the state it already has
+		if resp.StatusCode == http.StatusBadRequest && bytes.Contains(body, []byte("Cannot transition status")) {
+			return nil
+		}
+		return newAPIError(resp, "set commit status failed with status %d: %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
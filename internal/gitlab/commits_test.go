@@ -0,0 +1,153 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_ListCommitMRs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/42/repository/commits/abc123/merge_requests", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"iid": 7, "title": "Add orders consumer", "state": "merged"}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+	mrs, err := client.ListCommitMRs(context.Background(), 42, "abc123")
+
+	assert.NoError(t, err)
+	assert.Len(t, mrs, 1)
+	assert.Equal(t, 7, mrs[0].IID)
+	assert.Equal(t, "merged", mrs[0].State)
+}
+
+func TestClient_ListCommitMRs_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"404 Commit Not Found"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+	_, err := client.ListCommitMRs(context.Background(), 42, "abc123")
+
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, ErrNotFound))
+}
+
+func TestClient_GetCommitBefore(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/42/repository/commits", r.URL.Path)
+		assert.Equal(t, "main", r.URL.Query().Get("ref_name"))
+		assert.Equal(t, "2026-09-01T00:00:00Z", r.URL.Query().Get("until"))
+		assert.Equal(t, "1", r.URL.Query().Get("per_page"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"id": "abc123"}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+	sha, err := client.GetCommitBefore(context.Background(), 42, "main", time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "abc123", sha)
+}
+
+func TestClient_GetCommitBefore_NoCommits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+	sha, err := client.GetCommitBefore(context.Background(), 42, "main", time.Now())
+
+	assert.NoError(t, err)
+	assert.Empty(t, sha)
+}
+
+func TestClient_CreateCommit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/api/v4/projects/42/repository/commits", r.URL.Path)
+		var payload struct {
+			Branch  string         `json:"branch"`
+			Message string         `json:"commit_message"`
+			Actions []CommitAction `json:"actions"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Equal(t, "selftest", payload.Branch)
+		assert.Equal(t, "Add fixture", payload.Message)
+		assert.Equal(t, []CommitAction{{Action: CommitActionCreate, FilePath: "docs/README.md", Content: "# Test\n"}}, payload.Actions)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id": "def456"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+	sha, err := client.CreateCommit(context.Background(), 42, "selftest", "Add fixture", []CommitAction{
+		{Action: CommitActionCreate, FilePath: "docs/README.md", Content: "# Test\n"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "def456", sha)
+}
+
+func TestClient_CreateCommit_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"message":"A file with this name already exists"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+	_, err := client.CreateCommit(context.Background(), 42, "selftest", "Add fixture", []CommitAction{{Action: CommitActionCreate, FilePath: "README.md"}})
+
+	assert.Error(t, err)
+}
+
+func TestClient_SetCommitStatus(t *testing.T) {
+	var payload CommitStatus
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/api/v4/projects/42/statuses/abc123", r.URL.Path)
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id": 1, "status": "success"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+	err := client.SetCommitStatus(context.Background(), 42, "abc123", CommitStatus{State: CommitStatusSuccess, Name: "naysayer/review", Description: "All rules passed"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, CommitStatus{State: "success", Name: "naysayer/review", Description: "All rules passed"}, payload)
+}
+
+func TestClient_SetCommitStatus_Errors(t *testing.T) {
+	status := http.StatusBadRequest
+	body := `{"message": "Cannot transition status via :run from :running"}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+
+	assert.NoError(t, client.SetCommitStatus(context.Background(), 42, "abc123", CommitStatus{State: CommitStatusPending, Name: "naysayer/review"}),
+		"reposting the current state is not an error")
+
+	status, body = http.StatusForbidden, `{"message": "403 Forbidden"}`
+	err := client.SetCommitStatus(context.Background(), 42, "abc123", CommitStatus{State: CommitStatusPending, Name: "naysayer/review"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "status 403")
+}
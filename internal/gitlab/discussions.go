@@ -0,0 +1,190 @@
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Discussion is a merge request thread with its notes
+type Discussion struct {
+	ID    string      `json:"id"`
+	Notes []MRComment `json:"notes"`
+}
+
+// DiffRefs identifies the latest diff version of a merge request
+type DiffRefs struct {
+	BaseSHA  string `json:"base_sha"`
+	HeadSHA  string `json:"head_sha"`
+	StartSHA string `json:"start_sha"`
+}
+
+// DiffPosition anchors a discussion to a line of the merge request diff
+type DiffPosition struct {
+	DiffRefs
+	OldPath string
+	NewPath string
+	NewLine int // Line in the new version of the file
+}
+
+// FindCommentDiscussion returns the ID of the discussion containing a note, or "" when
+// the note is not found.
+// GET /projects/:id/merge_requests/:merge_request_iid/discussions
+func (c *Client) FindCommentDiscussion(ctx context.Context, projectID, mrIID, noteID int) (string, error) {
+	const maxPages = 20 // Same safety limit as ListMRComments
+
+	nextURL := c.apiURL("/projects/%d/merge_requests/%d/discussions?per_page=100", projectID, mrIID)
+
+	for page := 1; nextURL != "" && page <= maxPages; page++ {
+		req, err := http.NewRequestWithContext(ctx, "GET", nextURL, nil)
+		if err != nil {
+			return "", fmt.Errorf("failed to create list discussions request (page %d): %w", page, err)
+		}
+
+		resp, err := c.do(req)
+		if err != nil {
+			return "", fmt.Errorf("failed to list discussions: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(resp.Body)
+			_ = resp.Body.Close()
+			return "", newAPIError(resp, "list discussions failed with status %d: %s", resp.StatusCode, string(body))
+		}
+
+		var discussions []Discussion
+		err = json.NewDecoder(resp.Body).Decode(&discussions)
+		_ = resp.Body.Close()
+		if err != nil {
+			return "", fmt.Errorf("failed to decode discussions response: %w", err)
+		}
+
+		for _, discussion := range discussions {
+			for _, note := range discussion.Notes {
+				if note.ID == noteID {
+					return discussion.ID, nil
+				}
+			}
+		}
+
+		nextURL = parseNextLink(resp.Header.Get("Link"))
+	}
+
+	return "", nil
+}
+
+// ReplyToDiscussion adds a note to an existing merge request discussion
+// POST /projects/:id/merge_requests/:merge_request_iid/discussions/:discussion_id/notes
+func (c *Client) ReplyToDiscussion(ctx context.Context, projectID, mrIID int, discussionID, body string) error {
+	url := c.apiURL("/projects/%d/merge_requests/%d/discussions/%s/notes", projectID, mrIID, discussionID)
+
+	jsonPayload, err := json.Marshal(map[string]string{"body": body})
+	if err != nil {
+		return fmt.Errorf("failed to marshal reply payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return fmt.Errorf("failed to create reply request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reply to discussion: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusCreated {
+		respBody, _ := io.ReadAll(resp.Body)
+		return newAPIError(resp, "reply to discussion failed with status %d: %s", resp.StatusCode, string(respBody))
+	}
+	return nil
+}
+
+// ReplyToMRComment replies in the thread of an existing comment. GitLab turns a standalone
+// comment into a thread on its first reply.
+func (c *Client) ReplyToMRComment(ctx context.Context, projectID, mrIID, commentID int, body string) error {
+	discussionID, err := c.FindCommentDiscussion(ctx, projectID, mrIID, commentID)
+	if err != nil {
+		return err
+	}
+	if discussionID == "" {
+		return fmt.Errorf("no discussion found for comment %d", commentID)
+	}
+	return c.ReplyToDiscussion(ctx, projectID, mrIID, discussionID, body)
+}
+
+// CreateMRDiscussion starts a discussion on a line of the merge request diff and returns
+// its ID. GitLab rejects positions on lines that are not part of the diff.
+// POST /projects/:id/merge_requests/:merge_request_iid/discussions
+func (c *Client) CreateMRDiscussion(ctx context.Context, projectID, mrIID int, body string, position DiffPosition) (string, error) {
+	url := c.apiURL("/projects/%d/merge_requests/%d/discussions", projectID, mrIID)
+
+	jsonPayload, err := json.Marshal(map[string]interface{}{
+		"body": body,
+		"position": map[string]interface{}{
+			"position_type": "text",
+			"base_sha":      position.BaseSHA,
+			"head_sha":      position.HeadSHA,
+			"start_sha":     position.StartSHA,
+			"old_path":      position.OldPath,
+			"new_path":      position.NewPath,
+			"new_line":      position.NewLine,
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal discussion payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return "", fmt.Errorf("failed to create discussion request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to create discussion: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusCreated {
+		respBody, _ := io.ReadAll(resp.Body)
+		return "", newAPIError(resp, "create discussion failed with status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	var discussion Discussion
+	if err := json.NewDecoder(resp.Body).Decode(&discussion); err != nil {
+		return "", fmt.Errorf("failed to decode discussion response: %w", err)
+	}
+	return discussion.ID, nil
+}
+
+// ResolveMRDiscussion resolves or reopens a merge request discussion
+// PUT /projects/:id/merge_requests/:merge_request_iid/discussions/:discussion_id
+func (c *Client) ResolveMRDiscussion(ctx context.Context, projectID, mrIID int, discussionID string, resolved bool) error {
+	url := c.apiURL("/projects/%d/merge_requests/%d/discussions/%s?resolved=%t", projectID, mrIID, discussionID, resolved)
+
+	req, err := http.NewRequestWithContext(ctx, "PUT", url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create resolve discussion request: %w", err)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to resolve discussion: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return newAPIError(resp, "resolve discussion failed with status %d: %s", resp.StatusCode, string(respBody))
+	}
+	return nil
+}
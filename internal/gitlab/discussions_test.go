@@ -0,0 +1,126 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_ReplyToMRComment(t *testing.T) {
+	var replyBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/v4/projects/42/merge_requests/7/discussions":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`[
+				{"id": "aaa", "notes": [{"id": 1, "body": "first"}]},
+				{"id": "bbb", "notes": [{"id": 2, "body": "naysayer"}, {"id": 3, "body": "reply"}]}
+			]`))
+		case r.Method == "POST" && r.URL.Path == "/api/v4/projects/42/merge_requests/7/discussions/bbb/notes":
+			var payload map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&payload)
+			replyBody = payload["body"]
+			w.WriteHeader(http.StatusCreated)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+	err := client.ReplyToMRComment(context.Background(), 42, 7, 2, "updated")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "updated", replyBody)
+}
+
+func TestClient_ReplyToMRComment_CommentNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"id": "aaa", "notes": [{"id": 1, "body": "first"}]}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+	err := client.ReplyToMRComment(context.Background(), 42, 7, 99, "updated")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no discussion found")
+}
+
+func TestClient_ReplyToDiscussion_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message":"403 Forbidden"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+	err := client.ReplyToDiscussion(context.Background(), 42, 7, "bbb", "updated")
+
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, ErrPermission))
+}
+
+func TestClient_CreateMRDiscussion(t *testing.T) {
+	var payload map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/api/v4/projects/42/merge_requests/7/discussions", r.URL.Path)
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id": "d1", "notes": [{"id": 5, "body": "fix this"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+	id, err := client.CreateMRDiscussion(context.Background(), 42, 7, "fix this", DiffPosition{
+		DiffRefs: DiffRefs{BaseSHA: "base", HeadSHA: "head", StartSHA: "start"},
+		OldPath:  "product.yaml", NewPath: "product.yaml", NewLine: 3,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "d1", id)
+	assert.Equal(t, "fix this", payload["body"])
+	position := payload["position"].(map[string]interface{})
+	assert.Equal(t, "text", position["position_type"])
+	assert.Equal(t, "head", position["head_sha"])
+	assert.Equal(t, "product.yaml", position["new_path"])
+	assert.Equal(t, float64(3), position["new_line"])
+}
+
+func TestClient_CreateMRDiscussion_LineNotInDiff(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"message": "400 Bad request - Note {:line_code=>[\"can't be blank\"]}"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+	_, err := client.CreateMRDiscussion(context.Background(), 42, 7, "fix this", DiffPosition{NewPath: "product.yaml", NewLine: 300})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "status 400")
+}
+
+func TestClient_ResolveMRDiscussion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		assert.Equal(t, "/api/v4/projects/42/merge_requests/7/discussions/d1", r.URL.Path)
+		assert.Equal(t, "true", r.URL.Query().Get("resolved"))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id": "d1"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+	assert.NoError(t, client.ResolveMRDiscussion(context.Background(), 42, 7, "d1", true))
+}
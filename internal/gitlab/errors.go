@@ -0,0 +1,144 @@
+package gitlab
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Sentinel errors for GitLab API failures; match them with errors.Is
+var (
+	// ErrNotFound is returned when the requested project, MR, file or comment does not exist (404)
+	ErrNotFound = errors.New("gitlab: not found")
+	// ErrPermission is returned when the token is missing access or may not perform the action (401/403)
+	ErrPermission = errors.New("gitlab: insufficient permissions")
+	// ErrConflict is returned when the request conflicts with the resource state (409)
+	ErrConflict = errors.New("gitlab: conflict")
+	// ErrArchived is returned by callers refusing to act on an archived (read-only) project
+	ErrArchived = errors.New("gitlab: project is archived")
+	// ErrBotIdentityMismatch is returned when a token acts as a user that is not a configured bot identity
+	ErrBotIdentityMismatch = errors.New("gitlab: token does not act as a configured bot identity")
+	// ErrUnavailable is returned without contacting GitLab while the circuit breaker is open
+	ErrUnavailable = errors.New("gitlab: API unavailable, circuit breaker open")
+	// ErrMRTooLarge is returned when an MR exceeds the configured file or diff size limits
+	ErrMRTooLarge = errors.New("gitlab: merge request too large to analyze")
+	// ErrRebaseFailed matches a *RebaseError, a rebase GitLab reported an error for
+	ErrRebaseFailed = errors.New("gitlab: rebase failed")
+	// ErrRebaseTimeout is returned when a rebase did not finish within the verification time
+	ErrRebaseTimeout = errors.New("gitlab: rebase did not finish in time")
+)
+
+// APIError is a non-success GitLab API response
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+// Error returns the operation-specific error message
+func (e *APIError) Error() string {
+	return e.Message
+}
+
+// Is reports whether the status code corresponds to one of the sentinel errors
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrPermission:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	}
+	return false
+}
+
+// RebaseError is returned when GitLab accepted a rebase but reported an error running it in
+// the background. It matches ErrRebaseFailed, and ErrConflict for conflicting rebases.
+type RebaseError struct {
+	MergeError string // merge_error GitLab reported for the MR
+}
+
+// Error returns the error GitLab reported
+func (e *RebaseError) Error() string {
+	return "rebase failed: " + e.MergeError
+}
+
+// Is reports whether the rebase failed, and whether it failed on conflicts
+func (e *RebaseError) Is(target error) bool {
+	switch target {
+	case ErrRebaseFailed:
+		return true
+	case ErrConflict:
+		return strings.Contains(strings.ToLower(e.MergeError), "conflict")
+	}
+	return false
+}
+
+// TooLargeError is returned when an MR's diffs exceed MaxMRFiles or MaxMRDiffBytes
+type TooLargeError struct {
+	Reason string // Limit that was exceeded, e.g. "more than 2000 changed files"
+}
+
+// Error returns the exceeded limit
+func (e *TooLargeError) Error() string {
+	return fmt.Sprintf("merge request too large to analyze: %s", e.Reason)
+}
+
+// Is matches ErrMRTooLarge
+func (e *TooLargeError) Is(target error) bool {
+	return target == ErrMRTooLarge
+}
+
+// RateLimitedError is returned when GitLab rejects a request with 429 Too Many Requests.
+// RetryAfter is zero when the response carried no usable Retry-After header.
+type RateLimitedError struct {
+	RetryAfter time.Duration
+	Message    string
+}
+
+// Error returns the operation-specific error message
+func (e *RateLimitedError) Error() string {
+	return e.Message
+}
+
+// newAPIError builds a typed error for a non-success response, keeping the formatted message
+func newAPIError(resp *http.Response, format string, args ...interface{}) error {
+	message := fmt.Sprintf(format, args...)
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return &RateLimitedError{
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+			Message:    message,
+		}
+	}
+	return &APIError{StatusCode: resp.StatusCode, Message: message}
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil && at.After(now) {
+		return at.Sub(now)
+	}
+	return 0
+}
+
+// IsRateLimited reports whether err is a rate limit error and returns the suggested wait
+func IsRateLimited(err error) (time.Duration, bool) {
+	var rateLimited *RateLimitedError
+	if errors.As(err, &rateLimited) {
+		return rateLimited.RetryAfter, true
+	}
+	return 0, false
+}
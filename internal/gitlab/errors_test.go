@@ -0,0 +1,179 @@
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestClient(serverURL string) *Client {
+	return NewClientWithConfig(&config.Config{
+		GitLab: config.GitLabConfig{BaseURL: serverURL, Token: "test-token"},
+	})
+}
+
+func TestAPIError_IsSentinels(t *testing.T) {
+	tests := []struct {
+		status   int
+		expected error
+	}{
+		{http.StatusNotFound, ErrNotFound},
+		{http.StatusUnauthorized, ErrPermission},
+		{http.StatusForbidden, ErrPermission},
+		{http.StatusConflict, ErrConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("status %d", tt.status), func(t *testing.T) {
+			err := fmt.Errorf("wrapped: %w", &APIError{StatusCode: tt.status, Message: "failed"})
+			assert.ErrorIs(t, err, tt.expected)
+		})
+	}
+
+	err := &APIError{StatusCode: http.StatusInternalServerError, Message: "boom"}
+	assert.NotErrorIs(t, err, ErrNotFound)
+	assert.NotErrorIs(t, err, ErrPermission)
+	assert.NotErrorIs(t, err, ErrConflict)
+	assert.Equal(t, "boom", err.Error())
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 30*time.Second, parseRetryAfter("30", now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("", now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("-5", now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("soon", now))
+	assert.Equal(t, 2*time.Minute, parseRetryAfter(now.Add(2*time.Minute).Format(http.TimeFormat), now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter(now.Add(-time.Minute).Format(http.TimeFormat), now))
+}
+
+func TestClient_ReturnsTypedErrors(t *testing.T) {
+	ctx := context.Background()
+	status := http.StatusNotFound
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if status == http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", "12")
+		}
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+	client := newTestClient(server.URL)
+
+	_, err := client.FetchFileContent(ctx, 1, "missing.yaml", "main")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Contains(t, err.Error(), "file not found")
+
+	status = http.StatusForbidden
+	err = client.AddMRComment(ctx, 1, 2, "body")
+	assert.ErrorIs(t, err, ErrPermission)
+
+	status = http.StatusConflict
+	_, err = client.RebaseMR(ctx, 1, 2)
+	assert.ErrorIs(t, err, ErrConflict)
+
+	status = http.StatusTooManyRequests
+	_, err = client.ListOpenMRs(ctx, 1)
+	retryAfter, limited := IsRateLimited(err)
+	assert.True(t, limited)
+	assert.Equal(t, 12*time.Second, retryAfter)
+
+	_, limited = IsRateLimited(errors.New("other"))
+	assert.False(t, limited)
+}
+
+func TestAddOrUpdateMRComment_FallsBackOnPermissionError(t *testing.T) {
+	ctx := context.Background()
+	var updated, posted bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v4/user":
+			_, _ = w.Write([]byte(`{"username": "naysayer-bot"}`))
+		case r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`[{"id": 7, "body": "<!-- naysayer-comment-id: approval -->\nold", "author": {"username": "naysayer-bot"}}]`))
+		case r.Method == http.MethodPut:
+			updated = true
+			w.WriteHeader(http.StatusForbidden)
+		case r.Method == http.MethodPost:
+			posted = true
+			w.WriteHeader(http.StatusCreated)
+		}
+	}))
+	defer server.Close()
+
+	err := newTestClient(server.URL).AddOrUpdateMRComment(ctx, 1, 2, "new body", "approval")
+	assert.NoError(t, err)
+	assert.True(t, updated)
+	assert.True(t, posted)
+}
+
+func TestRebaseError_Is(t *testing.T) {
+	err := fmt.Errorf("wrapped: %w", &RebaseError{MergeError: "Rebase failed: Rebase locally, resolve all conflicts, then push the branch."})
+	assert.ErrorIs(t, err, ErrRebaseFailed)
+	assert.ErrorIs(t, err, ErrConflict)
+
+	err = &RebaseError{MergeError: "Failed to push to source branch"}
+	assert.ErrorIs(t, err, ErrRebaseFailed)
+	assert.NotErrorIs(t, err, ErrConflict)
+	assert.Equal(t, "rebase failed: Failed to push to source branch", err.Error())
+}
+
+func TestRebaseMR_VerifiesResult(t *testing.T) {
+	tests := []struct {
+		name     string
+		polls    []string // MR details returned after the rebase was enqueued
+		expected error
+	}{
+		{"completes", []string{`{"rebase_in_progress": true}`, `{"merge_status": "can_be_merged"}`}, nil},
+		{"fails in the background", []string{`{"rebase_in_progress": true}`, `{"merge_error": "Failed to push to source branch"}`}, ErrRebaseFailed},
+		{"leaves conflicts", []string{`{"has_conflicts": true}`}, ErrConflict},
+		{"times out", nil, ErrRebaseTimeout},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enqueued := false
+			polls := tt.polls
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodPut:
+					enqueued = true
+					w.WriteHeader(http.StatusAccepted)
+				case !enqueued:
+					_, _ = w.Write([]byte(`{"iid": 2}`))
+				case len(polls) > 0:
+					_, _ = w.Write([]byte(polls[0]))
+					polls = polls[1:]
+				default:
+					_, _ = w.Write([]byte(`{"rebase_in_progress": true}`))
+				}
+			}))
+			defer server.Close()
+
+			client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "token", RebaseVerifyTimeoutSeconds: 10, RebaseVerifyIntervalSeconds: 2})
+			sleeps := 0
+			client.sleepFunc = func(ctx context.Context, d time.Duration) error {
+				sleeps++
+				return nil
+			}
+
+			rebased, err := client.RebaseMR(context.Background(), 1, 2)
+			if tt.expected == nil {
+				assert.NoError(t, err)
+				assert.True(t, rebased)
+				return
+			}
+			assert.ErrorIs(t, err, tt.expected)
+			assert.False(t, rebased)
+			if tt.expected == ErrRebaseTimeout {
+				assert.Equal(t, 5, sleeps)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package gitlab
+
+import (
+	"bytes"
+	"container/list"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+)
+
+// maxETagBodyBytes is the largest response body kept for revalidation; larger responses
+// (e.g. big diff pages) are streamed through without being stored
+const maxETagBodyBytes = 1 << 20
+
+// etagCache keeps the last 200 OK response of GET requests that carried an ETag so that
+// repeated requests are revalidated with If-None-Match and a 304 is answered from the
+// stored body. Entries are keyed on the request URL and evicted least recently used first.
+type etagCache struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	order   *list.List // Most recently used first
+}
+
+type etagEntry struct {
+	url    string
+	etag   string
+	header http.Header
+	body   []byte
+}
+
+func newETagCache(size int) *etagCache {
+	return &etagCache{
+		size:    size,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+}
+
+func (c *etagCache) get(url string) (*etagEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[url]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	return element.Value.(*etagEntry), true
+}
+
+func (c *etagCache) put(entry *etagEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[entry.url]; ok {
+		element.Value = entry
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[entry.url] = c.order.PushFront(entry)
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*etagEntry).url)
+	}
+}
+
+func (c *etagCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// response rebuilds the stored 200 OK response for req
+func (e *etagEntry) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+		Request:       req,
+	}
+}
+
+// doConditional sends a GET request revalidating the cached response of its URL, answering a
+// 304 Not Modified from the cache and storing new 200 OK responses that carry an ETag
+func (c *Client) doConditional(req *http.Request) (*http.Response, error) {
+	url := req.URL.String()
+	cached, ok := c.etags.get(url)
+	if ok {
+		req.Header.Set("If-None-Match", cached.etag)
+	}
+
+	resp, err := c.doAuthorized(req)
+	if err != nil {
+		return resp, err
+	}
+
+	if ok && resp.StatusCode == http.StatusNotModified {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+		return cached.response(req), nil
+	}
+
+	etag := resp.Header.Get("ETag")
+	if resp.StatusCode != http.StatusOK || etag == "" || resp.ContentLength > maxETagBodyBytes {
+		return resp, nil
+	}
+
+	header := resp.Header.Clone()
+	header.Del("Content-Length")
+	resp.Body = &capturingBody{
+		ReadCloser: resp.Body,
+		onComplete: func(body []byte) {
+			header.Set("Content-Length", strconv.Itoa(len(body)))
+			c.etags.put(&etagEntry{url: url, etag: etag, header: header, body: body})
+		},
+	}
+	return resp, nil
+}
+
+// capturingBody copies a response body while it is read and hands the copy to onComplete
+// once the body was read to the end without exceeding maxETagBodyBytes
+type capturingBody struct {
+	io.ReadCloser
+	buf        bytes.Buffer
+	overflow   bool
+	done       bool
+	onComplete func(body []byte)
+}
+
+func (b *capturingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if !b.overflow {
+		if b.buf.Len()+n > maxETagBodyBytes {
+			b.overflow = true
+			b.buf = bytes.Buffer{}
+		} else {
+			b.buf.Write(p[:n])
+		}
+	}
+	if err == io.EOF && !b.overflow && !b.done {
+		b.done = true
+		b.onComplete(b.buf.Bytes())
+	}
+	return n, err
+}
+
+// Close finishes reading a body the caller stopped short of its end (json.Decoder stops after
+// the value) so the response can still be stored
+func (b *capturingBody) Close() error {
+	if !b.done && !b.overflow {
+		_, _ = io.CopyN(io.Discard, b, maxETagBodyBytes+1)
+	}
+	return b.ReadCloser.Close()
+}
@@ -0,0 +1,101 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+)
+
+func TestClient_ConditionalGetServesNotModifiedFromCache(t *testing.T) {
+	ctx := context.Background()
+	var ifNoneMatch []string
+	version := "v1"
+	var serverURL string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ifNoneMatch = append(ifNoneMatch, r.Header.Get("If-None-Match"))
+		etag := `"` + version + `"`
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		if r.URL.Query().Get("page") == "" {
+			w.Header().Set("Link", fmt.Sprintf(`<%s/api/v4/projects/1/merge_requests/2/diffs?per_page=100&page=2>; rel="next"`, serverURL))
+		}
+		_, _ = fmt.Fprintf(w, `[{"new_path": "%s-%s.yaml", "diff": "+x"}]`, version, r.URL.Query().Get("page"))
+	}))
+	defer server.Close()
+	serverURL = server.URL
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token", ETagCacheSize: 10})
+
+	changes, err := client.FetchMRChanges(ctx, 1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"v1-.yaml", "v1-2.yaml"}, []string{changes[0].NewPath, changes[1].NewPath})
+
+	// Unchanged pages are revalidated and served, including the Link header, from the cache
+	changes, err = client.FetchMRChanges(ctx, 1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"v1-.yaml", "v1-2.yaml"}, []string{changes[0].NewPath, changes[1].NewPath})
+	assert.Equal(t, []string{"", "", `"v1"`, `"v1"`}, ifNoneMatch)
+
+	version = "v2"
+	changes, err = client.FetchMRChanges(ctx, 1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, "v2-.yaml", changes[0].NewPath)
+	assert.Equal(t, 2, client.etags.len())
+}
+
+func TestClient_ConditionalGetSkipsUncacheableResponses(t *testing.T) {
+	ctx := context.Background()
+	var ifNoneMatch []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ifNoneMatch = append(ifNoneMatch, r.Header.Get("If-None-Match"))
+		switch r.URL.Path {
+		case "/api/v4/projects/1/merge_requests/2/diffs":
+			w.Header().Set("ETag", `"large"`)
+			_, _ = fmt.Fprintf(w, `[{"new_path": "a.yaml", "diff": "%s"}]`, strings.Repeat("x", maxETagBodyBytes))
+		case "/api/v4/projects/1/merge_requests/3/diffs":
+			_, _ = w.Write([]byte(`[]`))
+		default:
+			w.Header().Set("ETag", `"note"`)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id": 1}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token", ETagCacheSize: 10})
+
+	for i := 0; i < 2; i++ {
+		_, err := client.FetchMRChanges(ctx, 1, 2)
+		assert.NoError(t, err)
+		_, err = client.FetchMRChanges(ctx, 1, 3)
+		assert.NoError(t, err)
+		assert.NoError(t, client.AddMRComment(ctx, 1, 2, "hello"))
+	}
+
+	assert.Equal(t, []string{"", "", "", "", "", ""}, ifNoneMatch)
+	assert.Equal(t, 0, client.etags.len())
+}
+
+func TestETagCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache := newETagCache(2)
+	cache.put(&etagEntry{url: "a", etag: "1"})
+	cache.put(&etagEntry{url: "b", etag: "1"})
+	_, _ = cache.get("a")
+	cache.put(&etagEntry{url: "c", etag: "1"})
+
+	_, ok := cache.get("b")
+	assert.False(t, ok)
+	_, ok = cache.get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 2, cache.len())
+}
@@ -0,0 +1,141 @@
+package gitlab
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// FileCache is a bounded in-process cache of FetchFileContent results keyed on project, ref
+// and path. Entries expire after the TTL, the least recently used entry is evicted when the
+// cache is full, and all entries of a ref are dropped when the ref receives a push.
+type FileCache struct {
+	mu      sync.Mutex
+	size    int
+	ttl     time.Duration
+	entries map[fileCacheKey]*list.Element
+	order   *list.List // Most recently used first
+	now     func() time.Time
+
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+type fileCacheKey struct {
+	projectID int
+	ref       string
+	path      string
+}
+
+type fileCacheEntry struct {
+	key     fileCacheKey
+	content FileContent
+	expires time.Time
+}
+
+var (
+	defaultFileCacheMu sync.RWMutex
+	defaultFileCache   *FileCache
+)
+
+// SetDefaultFileCache installs the process-wide cache consulted by FetchFileContent
+func SetDefaultFileCache(cache *FileCache) {
+	defaultFileCacheMu.Lock()
+	defer defaultFileCacheMu.Unlock()
+	defaultFileCache = cache
+}
+
+// DefaultFileCache returns the process-wide file cache, or nil when caching is disabled
+func DefaultFileCache() *FileCache {
+	defaultFileCacheMu.RLock()
+	defer defaultFileCacheMu.RUnlock()
+	return defaultFileCache
+}
+
+// NewFileCache creates a cache holding at most size files for ttl each
+func NewFileCache(size int, ttl time.Duration) *FileCache {
+	return &FileCache{
+		size:    size,
+		ttl:     ttl,
+		entries: make(map[fileCacheKey]*list.Element),
+		order:   list.New(),
+		now:     time.Now,
+	}
+}
+
+// Get returns a copy of the cached file, counting the lookup as a hit or miss
+func (c *FileCache) Get(projectID int, ref, path string) (*FileContent, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := fileCacheKey{projectID: projectID, ref: ref, path: path}
+	element, ok := c.entries[key]
+	if ok {
+		entry := element.Value.(*fileCacheEntry)
+		if c.now().Before(entry.expires) {
+			c.order.MoveToFront(element)
+			c.hits.Add(1)
+			content := entry.content
+			return &content, true
+		}
+		c.remove(element)
+	}
+	c.misses.Add(1)
+	return nil, false
+}
+
+// Put stores a copy of the file, evicting the least recently used entry when full
+func (c *FileCache) Put(projectID int, ref, path string, content *FileContent) {
+	if c.size <= 0 || content == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := fileCacheKey{projectID: projectID, ref: ref, path: path}
+	entry := &fileCacheEntry{key: key, content: *content, expires: c.now().Add(c.ttl)}
+	if element, ok := c.entries[key]; ok {
+		element.Value = entry
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(entry)
+	for c.order.Len() > c.size {
+		c.remove(c.order.Back())
+	}
+}
+
+// Invalidate drops every cached file of the ref and returns how many were dropped
+func (c *FileCache) Invalidate(projectID int, ref string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	dropped := 0
+	for key, element := range c.entries {
+		if key.projectID == projectID && key.ref == ref {
+			c.remove(element)
+			dropped++
+		}
+	}
+	return dropped
+}
+
+// Len returns the number of cached files, including expired ones not yet looked up
+func (c *FileCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// Stats returns the cache hits and misses since the cache was created
+func (c *FileCache) Stats() (hits, misses int64) {
+	return c.hits.Load(), c.misses.Load()
+}
+
+func (c *FileCache) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*fileCacheEntry).key)
+}
@@ -0,0 +1,136 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+)
+
+func TestFileCache_GetPutAndExpiry(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	cache := NewFileCache(10, time.Minute)
+	cache.now = func() time.Time { return now }
+
+	_, ok := cache.Get(1, "main", "a.yaml")
+	assert.False(t, ok)
+
+	cache.Put(1, "main", "a.yaml", &FileContent{Content: "a"})
+	content, ok := cache.Get(1, "main", "a.yaml")
+	assert.True(t, ok)
+	assert.Equal(t, "a", content.Content)
+
+	// Callers get a copy
+	content.Content = "changed"
+	content, _ = cache.Get(1, "main", "a.yaml")
+	assert.Equal(t, "a", content.Content)
+
+	_, ok = cache.Get(1, "feature", "a.yaml")
+	assert.False(t, ok)
+
+	now = now.Add(time.Minute)
+	_, ok = cache.Get(1, "main", "a.yaml")
+	assert.False(t, ok)
+	assert.Equal(t, 0, cache.Len())
+
+	hits, misses := cache.Stats()
+	assert.Equal(t, int64(2), hits)
+	assert.Equal(t, int64(3), misses)
+}
+
+func TestClient_FetchFileContent_AdditionalInstanceBypassesCache(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = fmt.Fprintf(w, `{"file_path": "a.yaml", "encoding": "base64", "content": %q}`, base64.StdEncoding.EncodeToString([]byte("name: onprem")))
+	}))
+	defer server.Close()
+
+	cache := NewFileCache(10, time.Hour)
+	cache.Put(1, "main", "a.yaml", &FileContent{Content: "name: default"})
+	SetDefaultFileCache(cache)
+	defer SetDefaultFileCache(nil)
+
+	// Project 1 of another instance is a different project
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token", Instance: "onprem"})
+	content, err := client.FetchFileContent(context.Background(), 1, "a.yaml", "main")
+	assert.NoError(t, err)
+	assert.Equal(t, "name: onprem", content.Content)
+	assert.Equal(t, 1, requests)
+	assert.Equal(t, 1, cache.Len())
+}
+
+func TestFileCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewFileCache(2, time.Hour)
+	cache.Put(1, "main", "a.yaml", &FileContent{Content: "a"})
+	cache.Put(1, "main", "b.yaml", &FileContent{Content: "b"})
+	_, _ = cache.Get(1, "main", "a.yaml")
+	cache.Put(1, "main", "c.yaml", &FileContent{Content: "c"})
+
+	assert.Equal(t, 2, cache.Len())
+	_, ok := cache.Get(1, "main", "b.yaml")
+	assert.False(t, ok)
+	_, ok = cache.Get(1, "main", "a.yaml")
+	assert.True(t, ok)
+	_, ok = cache.Get(1, "main", "c.yaml")
+	assert.True(t, ok)
+}
+
+func TestFileCache_Invalidate(t *testing.T) {
+	cache := NewFileCache(10, time.Hour)
+	cache.Put(1, "main", "a.yaml", &FileContent{})
+	cache.Put(1, "main", "b.yaml", &FileContent{})
+	cache.Put(1, "feature", "a.yaml", &FileContent{})
+	cache.Put(2, "main", "a.yaml", &FileContent{})
+
+	assert.Equal(t, 2, cache.Invalidate(1, "main"))
+	assert.Equal(t, 2, cache.Len())
+	_, ok := cache.Get(1, "feature", "a.yaml")
+	assert.True(t, ok)
+	_, ok = cache.Get(2, "main", "a.yaml")
+	assert.True(t, ok)
+}
+
+func TestClient_FetchFileContent_UsesDefaultCache(t *testing.T) {
+	ctx := context.Background()
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Query().Get("ref") == "missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"file_path": "a.yaml", "encoding": "base64", "content": %q}`, base64.StdEncoding.EncodeToString([]byte("name: a")))
+	}))
+	defer server.Close()
+
+	cache := NewFileCache(10, time.Hour)
+	SetDefaultFileCache(cache)
+	defer SetDefaultFileCache(nil)
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+	for i := 0; i < 3; i++ {
+		content, err := client.FetchFileContent(ctx, 1, "a.yaml", "main")
+		assert.NoError(t, err)
+		assert.Equal(t, "name: a", content.Content)
+	}
+	assert.Equal(t, 1, requests)
+
+	// Failures are not cached
+	for i := 0; i < 2; i++ {
+		_, err := client.FetchFileContent(ctx, 1, "a.yaml", "missing")
+		assert.ErrorIs(t, err, ErrNotFound)
+	}
+	assert.Equal(t, 3, requests)
+
+	hits, misses := cache.Stats()
+	assert.Equal(t, int64(2), hits)
+	assert.Equal(t, int64(3), misses)
+}
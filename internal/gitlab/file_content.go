@@ -1,6 +1,7 @@
 package gitlab
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -24,35 +25,53 @@ type FileContent struct {
 	LastCommitID string `json:"last_commit_id"`
 }
 
-// FetchFileContent fetches file content from a specific commit/branch
-func (c *Client) FetchFileContent(projectID int, filePath, ref string) (*FileContent, error) {
+// FetchFileContent fetches file content from a specific commit/branch, served from the
+// default FileCache when one is installed. The cache is keyed on project IDs of the default
+// instance, so clients of additional instances bypass it.
+func (c *Client) FetchFileContent(ctx context.Context, projectID int, filePath, ref string) (*FileContent, error) {
+	cache := DefaultFileCache()
+	if cache == nil || c.config.Instance != "" {
+		return c.fetchFileContent(ctx, projectID, filePath, ref)
+	}
+	if content, ok := cache.Get(projectID, ref, filePath); ok {
+		return content, nil
+	}
+
+	content, err := c.fetchFileContent(ctx, projectID, filePath, ref)
+	if err != nil {
+		return nil, err
+	}
+	cache.Put(projectID, ref, filePath, content)
+	return content, nil
+}
+
+// fetchFileContent fetches file content from the GitLab API
+func (c *Client) fetchFileContent(ctx context.Context, projectID int, filePath, ref string) (*FileContent, error) {
 	// URL encode the file path
 	encodedPath := url.QueryEscape(filePath)
 
-	url := fmt.Sprintf("%s/api/v4/projects/%d/repository/files/%s?ref=%s",
-		strings.TrimRight(c.config.BaseURL, "/"), projectID, encodedPath, ref)
+	url := c.apiURL("/projects/%d/repository/files/%s?ref=%s", projectID, encodedPath, ref)
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.config.Token)
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.http.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode == 404 {
-		return nil, fmt.Errorf("file not found: %s", filePath)
+		return nil, newAPIError(resp, "file not found: %s", filePath)
 	}
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("GitLab API error %d: %s", resp.StatusCode, string(body))
+		return nil, newAPIError(resp, "GitLab API error %d: %s", resp.StatusCode, string(body))
 	}
 
 	var fileContent FileContent
@@ -73,19 +92,17 @@ func (c *Client) FetchFileContent(projectID int, filePath, ref string) (*FileCon
 }
 
 // GetMRTargetBranch fetches the target branch of a merge request
-func (c *Client) GetMRTargetBranch(projectID, mrIID int) (string, error) {
-	url := fmt.Sprintf("%s/api/v4/projects/%d/merge_requests/%d",
-		strings.TrimRight(c.config.BaseURL, "/"), projectID, mrIID)
+func (c *Client) GetMRTargetBranch(ctx context.Context, projectID, mrIID int) (string, error) {
+	url := c.apiURL("/projects/%d/merge_requests/%d", projectID, mrIID)
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return "", err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.config.Token)
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.http.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return "", err
 	}
@@ -93,7 +110,7 @@ func (c *Client) GetMRTargetBranch(projectID, mrIID int) (string, error) {
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("GitLab API error %d: %s", resp.StatusCode, string(body))
+		return "", newAPIError(resp, "GitLab API error %d: %s", resp.StatusCode, string(body))
 	}
 
 	var mr struct {
@@ -110,21 +127,55 @@ func (c *Client) GetMRTargetBranch(projectID, mrIID int) (string, error) {
 
 // MRDetails represents merge request details
 type MRDetails struct {
-	TargetBranch         string      `json:"target_branch"`
-	SourceBranch         string      `json:"source_branch"`
-	Sha                  string      `json:"sha"` // HEAD of source branch (used for fork MR compare)
-	IID                  int         `json:"iid"`
-	ProjectID            int         `json:"project_id"`             // Target project ID
-	SourceProjectID      int         `json:"source_project_id"`      // Source project ID (for cross-fork MRs)
-	TargetProjectID      int         `json:"target_project_id"`      // Target project ID (same as ProjectID)
-	CreatedAt            string      `json:"created_at"`             // ISO 8601 format timestamp
-	UpdatedAt            string      `json:"updated_at"`             // ISO 8601 format timestamp of last activity
-	Pipeline             *MRPipeline `json:"pipeline"`               // Pipeline info (can be nil if no pipeline)
-	BehindCommitsCount   int         `json:"behind_commits_count"`   // Number of commits behind target branch
-	DivergedCommitsCount int         `json:"diverged_commits_count"` // Number of diverged commits
-	MergeStatus          string      `json:"merge_status"`           // "can_be_merged", "cannot_be_merged", "checking", "unchecked"
-	RebaseInProgress     bool        `json:"rebase_in_progress"`     // True if rebase is currently in progress
-	HasConflicts         bool        `json:"has_conflicts"`          // True if MR has merge conflicts
+	TargetBranch              string      `json:"target_branch"`
+	SourceBranch              string      `json:"source_branch"`
+	Sha                       string      `json:"sha"` // HEAD of source branch (used for fork MR compare)
+	IID                       int         `json:"iid"`
+	Title                     string      `json:"title"`
+	Description               string      `json:"description"`
+	State                     string      `json:"state"`                        // "opened", "closed", "locked", "merged"
+	ProjectID                 int         `json:"project_id"`                   // Target project ID
+	SourceProjectID           int         `json:"source_project_id"`            // Source project ID (for cross-fork MRs)
+	TargetProjectID           int         `json:"target_project_id"`            // Target project ID (same as ProjectID)
+	CreatedAt                 string      `json:"created_at"`                   // ISO 8601 format timestamp
+	UpdatedAt                 string      `json:"updated_at"`                   // ISO 8601 format timestamp of last activity
+	Pipeline                  *MRPipeline `json:"pipeline"`                     // Pipeline info (can be nil if no pipeline)
+	BehindCommitsCount        int         `json:"behind_commits_count"`         // Number of commits behind target branch
+	DivergedCommitsCount      int         `json:"diverged_commits_count"`       // Number of diverged commits
+	MergeStatus               string      `json:"merge_status"`                 // "can_be_merged", "cannot_be_merged", "checking", "unchecked"
+	MergeError                string      `json:"merge_error"`                  // Error of the last rebase or merge GitLab ran in the background
+	RebaseInProgress          bool        `json:"rebase_in_progress"`           // True if rebase is currently in progress
+	HasConflicts              bool        `json:"has_conflicts"`                // True if MR has merge conflicts
+	Squash                    bool        `json:"squash"`                       // "Squash commits" toggle of the MR
+	RemoveSourceBranch        bool        `json:"force_remove_source_branch"`   // "Delete source branch" toggle of the MR
+	Author                    *MRUser     `json:"author"`                       // MR author (can be nil in older API responses)
+	Labels                    []string    `json:"labels"`                       // Label names
+	Reviewers                 []MRUser    `json:"reviewers"`                    // Users asked to review the MR
+	Draft                     bool        `json:"draft"`                        // MR is marked as draft
+	WorkInProgress            bool        `json:"work_in_progress"`             // Deprecated name of draft in older GitLab versions
+	DiffRefs                  *DiffRefs   `json:"diff_refs"`                    // Commits of the latest diff version, used to position diff discussions
+	MergeWhenPipelineSucceeds bool        `json:"merge_when_pipeline_succeeds"` // MR is set to merge once its pipeline succeeds
+}
+
+// IsDraft reports whether the MR is marked as draft
+func (d *MRDetails) IsDraft() bool {
+	return d.Draft || d.WorkInProgress
+}
+
+// HasLabel reports whether the MR carries the label, ignoring case
+func (d *MRDetails) HasLabel(name string) bool {
+	for _, label := range d.Labels {
+		if strings.EqualFold(strings.TrimSpace(label), name) {
+			return true
+		}
+	}
+	return false
+}
+
+// MRUser is a GitLab user referenced by an MR
+type MRUser struct {
+	ID       int    `json:"id"`
+	Username string `json:"username"`
 }
 
 // MRPipeline represents pipeline information for an MR
@@ -136,6 +187,7 @@ type MRPipeline struct {
 // CompareResult represents the result of comparing two branches
 type CompareResult struct {
 	Commits []CompareCommit `json:"commits"`
+	Diffs   []FileChange    `json:"diffs"` // Files changed between the two refs
 }
 
 // CompareCommit represents a commit in a compare result
@@ -150,19 +202,17 @@ type CompareCommit struct {
 }
 
 // GetMRDetails fetches merge request details
-func (c *Client) GetMRDetails(projectID, mrIID int) (*MRDetails, error) {
-	url := fmt.Sprintf("%s/api/v4/projects/%d/merge_requests/%d",
-		strings.TrimRight(c.config.BaseURL, "/"), projectID, mrIID)
+func (c *Client) GetMRDetails(ctx context.Context, projectID, mrIID int) (*MRDetails, error) {
+	url := c.apiURL("/projects/%d/merge_requests/%d", projectID, mrIID)
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.config.Token)
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.http.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -170,7 +220,7 @@ func (c *Client) GetMRDetails(projectID, mrIID int) (*MRDetails, error) {
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("GitLab API error %d: %s", resp.StatusCode, string(body))
+		return nil, newAPIError(resp, "GitLab API error %d: %s", resp.StatusCode, string(body))
 	}
 
 	var mrDetails MRDetails
@@ -1,6 +1,7 @@
 package gitlab
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"net/http"
@@ -12,6 +13,7 @@ import (
 )
 
 func TestClient_FetchFileContent_Success(t *testing.T) {
+	ctx := context.Background()
 	yamlContent := `name: test-product
 rover_group: test
 warehouses:
@@ -53,7 +55,7 @@ tags:
 	}
 	client := NewClient(cfg)
 
-	content, err := client.FetchFileContent(123, "dataproducts/agg/test/product.yaml", "main")
+	content, err := client.FetchFileContent(ctx, 123, "dataproducts/agg/test/product.yaml", "main")
 
 	assert.NoError(t, err)
 	assert.NotNil(t, content)
@@ -65,6 +67,7 @@ tags:
 }
 
 func TestClient_FetchFileContent_Base64Decoding(t *testing.T) {
+	ctx := context.Background()
 	originalContent := `name: base64-test
 rover_group: test
 warehouses:
@@ -94,7 +97,7 @@ warehouses:
 	}
 	client := NewClient(cfg)
 
-	content, err := client.FetchFileContent(123, "dataproducts/agg/test/product.yaml", "main")
+	content, err := client.FetchFileContent(ctx, 123, "dataproducts/agg/test/product.yaml", "main")
 
 	assert.NoError(t, err)
 	assert.Equal(t, originalContent, content.Content)
@@ -102,6 +105,7 @@ warehouses:
 }
 
 func TestClient_FetchFileContent_InvalidBase64(t *testing.T) {
+	ctx := context.Background()
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		response := FileContent{
 			FileName: "product.yaml",
@@ -122,7 +126,7 @@ func TestClient_FetchFileContent_InvalidBase64(t *testing.T) {
 	}
 	client := NewClient(cfg)
 
-	content, err := client.FetchFileContent(123, "dataproducts/agg/test/product.yaml", "main")
+	content, err := client.FetchFileContent(ctx, 123, "dataproducts/agg/test/product.yaml", "main")
 
 	assert.Error(t, err)
 	assert.Nil(t, content)
@@ -130,6 +134,7 @@ func TestClient_FetchFileContent_InvalidBase64(t *testing.T) {
 }
 
 func TestClient_FetchFileContent_FileNotFound(t *testing.T) {
+	ctx := context.Background()
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(404)
 		_, _ = w.Write([]byte(`{"message": "404 File Not Found"}`))
@@ -142,7 +147,7 @@ func TestClient_FetchFileContent_FileNotFound(t *testing.T) {
 	}
 	client := NewClient(cfg)
 
-	content, err := client.FetchFileContent(123, "nonexistent/file.yaml", "main")
+	content, err := client.FetchFileContent(ctx, 123, "nonexistent/file.yaml", "main")
 
 	assert.Error(t, err)
 	assert.Nil(t, content)
@@ -150,6 +155,7 @@ func TestClient_FetchFileContent_FileNotFound(t *testing.T) {
 }
 
 func TestClient_FetchFileContent_HTTPErrors(t *testing.T) {
+	ctx := context.Background()
 	tests := []struct {
 		name          string
 		statusCode    int
@@ -190,7 +196,7 @@ func TestClient_FetchFileContent_HTTPErrors(t *testing.T) {
 			}
 			client := NewClient(cfg)
 
-			content, err := client.FetchFileContent(123, "test/file.yaml", "main")
+			content, err := client.FetchFileContent(ctx, 123, "test/file.yaml", "main")
 
 			assert.Error(t, err)
 			assert.Nil(t, content)
@@ -200,6 +206,7 @@ func TestClient_FetchFileContent_HTTPErrors(t *testing.T) {
 }
 
 func TestClient_FetchFileContent_URLEncoding(t *testing.T) {
+	ctx := context.Background()
 	// Test that special characters in file paths are properly handled
 	filePath := "data products/test+file@domain.yaml"
 
@@ -222,7 +229,7 @@ func TestClient_FetchFileContent_URLEncoding(t *testing.T) {
 	}
 	client := NewClient(cfg)
 
-	_, err := client.FetchFileContent(123, filePath, "main")
+	_, err := client.FetchFileContent(ctx, 123, filePath, "main")
 
 	assert.NoError(t, err)
 	// Just verify the URL contains encoded characters and the ref parameter
@@ -232,6 +239,7 @@ func TestClient_FetchFileContent_URLEncoding(t *testing.T) {
 }
 
 func TestClient_GetMRTargetBranch_Success(t *testing.T) {
+	ctx := context.Background()
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Verify request
 		assert.Equal(t, "GET", r.Method)
@@ -255,13 +263,14 @@ func TestClient_GetMRTargetBranch_Success(t *testing.T) {
 	}
 	client := NewClient(cfg)
 
-	targetBranch, err := client.GetMRTargetBranch(123, 456)
+	targetBranch, err := client.GetMRTargetBranch(ctx, 123, 456)
 
 	assert.NoError(t, err)
 	assert.Equal(t, "main", targetBranch)
 }
 
 func TestClient_GetMRTargetBranch_HTTPError(t *testing.T) {
+	ctx := context.Background()
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(404)
 		_, _ = w.Write([]byte(`{"message": "404 Merge Request Not Found"}`))
@@ -274,7 +283,7 @@ func TestClient_GetMRTargetBranch_HTTPError(t *testing.T) {
 	}
 	client := NewClient(cfg)
 
-	targetBranch, err := client.GetMRTargetBranch(123, 999)
+	targetBranch, err := client.GetMRTargetBranch(ctx, 123, 999)
 
 	assert.Error(t, err)
 	assert.Empty(t, targetBranch)
@@ -282,6 +291,7 @@ func TestClient_GetMRTargetBranch_HTTPError(t *testing.T) {
 }
 
 func TestClient_GetMRTargetBranch_InvalidJSON(t *testing.T) {
+	ctx := context.Background()
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		_, _ = w.Write([]byte(`{"invalid": json content}`))
@@ -294,13 +304,14 @@ func TestClient_GetMRTargetBranch_InvalidJSON(t *testing.T) {
 	}
 	client := NewClient(cfg)
 
-	targetBranch, err := client.GetMRTargetBranch(123, 456)
+	targetBranch, err := client.GetMRTargetBranch(ctx, 123, 456)
 
 	assert.Error(t, err)
 	assert.Empty(t, targetBranch)
 }
 
 func TestClient_GetMRDetails_Success(t *testing.T) {
+	ctx := context.Background()
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Verify request
 		assert.Equal(t, "GET", r.Method)
@@ -327,7 +338,7 @@ func TestClient_GetMRDetails_Success(t *testing.T) {
 	}
 	client := NewClient(cfg)
 
-	details, err := client.GetMRDetails(123, 456)
+	details, err := client.GetMRDetails(ctx, 123, 456)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, details)
@@ -340,6 +351,7 @@ func TestClient_GetMRDetails_Success(t *testing.T) {
 }
 
 func TestClient_GetMRDetails_HTTPError(t *testing.T) {
+	ctx := context.Background()
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(403)
 		_, _ = w.Write([]byte(`{"message": "403 Forbidden"}`))
@@ -352,7 +364,7 @@ func TestClient_GetMRDetails_HTTPError(t *testing.T) {
 	}
 	client := NewClient(cfg)
 
-	details, err := client.GetMRDetails(123, 456)
+	details, err := client.GetMRDetails(ctx, 123, 456)
 
 	assert.Error(t, err)
 	assert.Nil(t, details)
@@ -360,6 +372,7 @@ func TestClient_GetMRDetails_HTTPError(t *testing.T) {
 }
 
 func TestClient_GetMRDetails_InvalidJSON(t *testing.T) {
+	ctx := context.Background()
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		_, _ = w.Write([]byte(`{"incomplete": json`))
@@ -372,20 +385,21 @@ func TestClient_GetMRDetails_InvalidJSON(t *testing.T) {
 	}
 	client := NewClient(cfg)
 
-	details, err := client.GetMRDetails(123, 456)
+	details, err := client.GetMRDetails(ctx, 123, 456)
 
 	assert.Error(t, err)
 	assert.Nil(t, details)
 }
 
 func TestClient_FetchFileContent_NetworkError(t *testing.T) {
+	ctx := context.Background()
 	cfg := config.GitLabConfig{
 		BaseURL: "http://localhost:99999", // Non-existent server
 		Token:   "test-token",
 	}
 	client := NewClient(cfg)
 
-	content, err := client.FetchFileContent(123, "test/file.yaml", "main")
+	content, err := client.FetchFileContent(ctx, 123, "test/file.yaml", "main")
 
 	assert.Error(t, err)
 	assert.Nil(t, content)
@@ -393,13 +407,14 @@ func TestClient_FetchFileContent_NetworkError(t *testing.T) {
 }
 
 func TestClient_GetMRTargetBranch_NetworkError(t *testing.T) {
+	ctx := context.Background()
 	cfg := config.GitLabConfig{
 		BaseURL: "http://localhost:99999", // Non-existent server
 		Token:   "test-token",
 	}
 	client := NewClient(cfg)
 
-	targetBranch, err := client.GetMRTargetBranch(123, 456)
+	targetBranch, err := client.GetMRTargetBranch(ctx, 123, 456)
 
 	assert.Error(t, err)
 	assert.Empty(t, targetBranch)
@@ -407,13 +422,14 @@ func TestClient_GetMRTargetBranch_NetworkError(t *testing.T) {
 }
 
 func TestClient_GetMRDetails_NetworkError(t *testing.T) {
+	ctx := context.Background()
 	cfg := config.GitLabConfig{
 		BaseURL: "http://localhost:99999", // Non-existent server
 		Token:   "test-token",
 	}
 	client := NewClient(cfg)
 
-	details, err := client.GetMRDetails(123, 456)
+	details, err := client.GetMRDetails(ctx, 123, 456)
 
 	assert.Error(t, err)
 	assert.Nil(t, details)
@@ -421,6 +437,7 @@ func TestClient_GetMRDetails_NetworkError(t *testing.T) {
 }
 
 func TestClient_FetchFileContent_QueryParameters(t *testing.T) {
+	ctx := context.Background()
 	var capturedQuery string
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		capturedQuery = r.URL.RawQuery
@@ -440,13 +457,14 @@ func TestClient_FetchFileContent_QueryParameters(t *testing.T) {
 	}
 	client := NewClient(cfg)
 
-	_, err := client.FetchFileContent(123, "test/file.yaml", "feature-branch")
+	_, err := client.FetchFileContent(ctx, 123, "test/file.yaml", "feature-branch")
 
 	assert.NoError(t, err)
 	assert.Equal(t, "ref=feature-branch", capturedQuery)
 }
 
 func TestClient_FetchFileContent_EmptyResponse(t *testing.T) {
+	ctx := context.Background()
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		response := FileContent{
 			FileName: "empty.yaml",
@@ -464,7 +482,7 @@ func TestClient_FetchFileContent_EmptyResponse(t *testing.T) {
 	}
 	client := NewClient(cfg)
 
-	content, err := client.FetchFileContent(123, "empty.yaml", "main")
+	content, err := client.FetchFileContent(ctx, 123, "empty.yaml", "main")
 
 	assert.NoError(t, err)
 	assert.NotNil(t, content)
@@ -0,0 +1,91 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// GitLab access levels
+const (
+	AccessLevelDeveloper  = 30
+	AccessLevelMaintainer = 40
+	AccessLevelOwner      = 50
+)
+
+// GetMemberAccessLevel returns a user's effective access level on a project, including
+// access inherited from groups. Non-members return an error matching ErrNotFound.
+// GET /projects/:id/members/all/:user_id
+func (c *Client) GetMemberAccessLevel(ctx context.Context, projectID, userID int) (int, error) {
+	apiURL := c.apiURL("/projects/%d/members/all/%d", projectID, userID)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create member request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get project member: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return 0, newAPIError(resp, "get project member failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var member struct {
+		AccessLevel int `json:"access_level"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&member); err != nil {
+		return 0, fmt.Errorf("failed to decode project member response: %w", err)
+	}
+	return member.AccessLevel, nil
+}
+
+// ListGroupMembers returns the members of a group, including members inherited from parent
+// groups (all pages). group is the full path of the group, e.g. "data/platform-team".
+// Unknown groups return an error matching ErrNotFound.
+// GET /groups/:id/members/all
+func (c *Client) ListGroupMembers(ctx context.Context, group string) ([]MRUser, error) {
+	var members []MRUser
+	apiURL := c.apiURL("/groups/%s/members/all?per_page=100", url.PathEscape(group))
+
+	for apiURL != "" {
+		req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create group members request: %w", err)
+		}
+
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := c.do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list group members: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(resp.Body)
+			_ = resp.Body.Close()
+			return nil, newAPIError(resp, "list group members failed with status %d: %s", resp.StatusCode, string(body))
+		}
+
+		var page []MRUser
+		err = json.NewDecoder(resp.Body).Decode(&page)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode group members response: %w", err)
+		}
+
+		members = append(members, page...)
+		apiURL = parseNextLink(resp.Header.Get("Link"))
+	}
+
+	return members, nil
+}
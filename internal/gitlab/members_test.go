@@ -0,0 +1,56 @@
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_GetMemberAccessLevel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v4/projects/42/members/all/7" {
+			_, _ = w.Write([]byte(`{"id": 7, "username": "maintainer", "access_level": 40}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+	level, err := client.GetMemberAccessLevel(context.Background(), 42, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, AccessLevelMaintainer, level)
+
+	_, err = client.GetMemberAccessLevel(context.Background(), 42, 8)
+	assert.True(t, errors.Is(err, ErrNotFound))
+}
+
+func TestClient_ListGroupMembers(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/api/v4/groups/data%2Fplatform-team/members/all" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.URL.Query().Get("page") == "2" {
+			_, _ = w.Write([]byte(`[{"id": 8, "username": "bob"}]`))
+			return
+		}
+		w.Header().Set("Link", "<"+server.URL+"/api/v4/groups/data%2Fplatform-team/members/all?per_page=100&page=2>; rel=\"next\"")
+		_, _ = w.Write([]byte(`[{"id": 7, "username": "alice"}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+	members, err := client.ListGroupMembers(context.Background(), "data/platform-team")
+	assert.NoError(t, err)
+	assert.Equal(t, []MRUser{{ID: 7, Username: "alice"}, {ID: 8, Username: "bob"}}, members)
+
+	_, err = client.ListGroupMembers(context.Background(), "alice")
+	assert.True(t, errors.Is(err, ErrNotFound))
+}
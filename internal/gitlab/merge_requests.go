@@ -0,0 +1,147 @@
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"go.uber.org/zap"
+)
+
+// CreateMR opens a merge request from sourceBranch into targetBranch of the same project.
+// POST /projects/:id/merge_requests
+func (c *Client) CreateMR(ctx context.Context, projectID int, sourceBranch, targetBranch, title, description string) (*MRDetails, error) {
+	apiURL := c.apiURL("/projects/%d/merge_requests", projectID)
+
+	jsonPayload, err := json.Marshal(map[string]string{
+		"source_branch": sourceBranch,
+		"target_branch": targetBranch,
+		"title":         title,
+		"description":   description,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal create MR payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", apiURL, bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create MR request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create MR: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, newAPIError(resp, "create MR failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var mr MRDetails
+	if err := json.NewDecoder(resp.Body).Decode(&mr); err != nil {
+		return nil, fmt.Errorf("failed to decode create MR response: %w", err)
+	}
+
+	logging.Info("Created MR",
+		zap.Int("mr_iid", mr.IID),
+		zap.String("source_branch", sourceBranch),
+		zap.String("target_branch", targetBranch),
+		zap.Int("project_id", projectID))
+	return &mr, nil
+}
+
+// ListMRApprovers returns the usernames of the users who approved a merge request.
+// GET /projects/:id/merge_requests/:iid/approvals
+func (c *Client) ListMRApprovers(ctx context.Context, projectID, mrIID int) ([]string, error) {
+	apiURL := c.apiURL("/projects/%d/merge_requests/%d/approvals", projectID, mrIID)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create MR approvals request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get MR approvals: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, newAPIError(resp, "get MR approvals failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var approvals struct {
+		ApprovedBy []struct {
+			User MRUser `json:"user"`
+		} `json:"approved_by"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&approvals); err != nil {
+		return nil, fmt.Errorf("failed to decode MR approvals response: %w", err)
+	}
+
+	usernames := make([]string, 0, len(approvals.ApprovedBy))
+	for _, approval := range approvals.ApprovedBy {
+		usernames = append(usernames, approval.User.Username)
+	}
+	return usernames, nil
+}
+
+// MergeOptions controls how MergeMR merges a merge request
+type MergeOptions struct {
+	SHA                       string // Merge only if the source branch HEAD still matches
+	MergeWhenPipelineSucceeds bool   // Let GitLab merge once the running pipeline succeeds
+}
+
+// MergeMR merges a merge request, or sets it to merge when its pipeline succeeds.
+// GitLab refuses MRs that are not mergeable (405), cannot be merged (406 or 422) or whose
+// HEAD moved past opts.SHA (409).
+// PUT /projects/:id/merge_requests/:merge_request_iid/merge
+func (c *Client) MergeMR(ctx context.Context, projectID, mrIID int, opts MergeOptions) error {
+	apiURL := c.apiURL("/projects/%d/merge_requests/%d/merge", projectID, mrIID)
+
+	payload := map[string]interface{}{
+		"merge_when_pipeline_succeeds": opts.MergeWhenPipelineSucceeds,
+	}
+	if opts.SHA != "" {
+		payload["sha"] = opts.SHA
+	}
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal merge payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "PUT", apiURL, bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return fmt.Errorf("failed to create merge request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to merge MR: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return newAPIError(resp, "merge MR failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	logging.Info("Merged MR",
+		zap.Int("mr_iid", mrIID),
+		zap.Int("project_id", projectID),
+		zap.Bool("merge_when_pipeline_succeeds", opts.MergeWhenPipelineSucceeds))
+	return nil
+}
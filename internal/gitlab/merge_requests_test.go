@@ -0,0 +1,109 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_CreateMR(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/api/v4/projects/42/merge_requests", r.URL.Path)
+		var payload map[string]string
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Equal(t, "selftest", payload["source_branch"])
+		assert.Equal(t, "main", payload["target_branch"])
+		assert.Equal(t, "Self-test", payload["title"])
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"iid": 12, "project_id": 42, "source_branch": "selftest", "target_branch": "main", "state": "opened"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+	mr, err := client.CreateMR(context.Background(), 42, "selftest", "main", "Self-test", "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 12, mr.IID)
+	assert.Equal(t, "opened", mr.State)
+}
+
+func TestClient_CreateMR_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(`{"message":["Another open merge request already exists for this source branch"]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+	_, err := client.CreateMR(context.Background(), 42, "selftest", "main", "Self-test", "")
+
+	assert.Error(t, err)
+}
+
+func TestClient_ListMRApprovers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/42/merge_requests/12/approvals", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"approved": true, "approved_by": [{"user": {"id": 7, "username": "naysayer-bot"}}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+	approvers, err := client.ListMRApprovers(context.Background(), 42, 12)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"naysayer-bot"}, approvers)
+}
+
+func TestClient_ListMRApprovers_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"404 Not found"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+	_, err := client.ListMRApprovers(context.Background(), 42, 12)
+
+	assert.True(t, errors.Is(err, ErrNotFound))
+}
+
+func TestClient_MergeMR(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		assert.Equal(t, "/api/v4/projects/42/merge_requests/12/merge", r.URL.Path)
+		var payload map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Equal(t, "abc123", payload["sha"])
+		assert.Equal(t, true, payload["merge_when_pipeline_succeeds"])
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"iid": 12, "state": "opened", "merge_when_pipeline_succeeds": true}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+	err := client.MergeMR(context.Background(), 42, 12, MergeOptions{SHA: "abc123", MergeWhenPipelineSucceeds: true})
+
+	assert.NoError(t, err)
+}
+
+func TestClient_MergeMR_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(`{"message":"SHA does not match HEAD of source branch"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+	err := client.MergeMR(context.Background(), 42, 12, MergeOptions{SHA: "abc123"})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "SHA does not match")
+}
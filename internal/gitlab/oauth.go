@@ -0,0 +1,229 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"go.uber.org/zap"
+)
+
+// OAuth grant types naysayer obtains access tokens with
+const (
+	GrantRefreshToken      = "refresh_token"
+	GrantClientCredentials = "client_credentials"
+)
+
+// oauthRefreshMargin is how long before expiry access tokens are refreshed; tokens living
+// shorter than twice the margin are refreshed halfway through their lifetime
+const oauthRefreshMargin = 5 * time.Minute
+
+// oauthCheckInterval is how often clients ask the OAuth source whether their access token
+// is due for refresh; asking is cheap, only due tokens are refreshed
+const oauthCheckInterval = 15 * time.Second
+
+// oauthSource obtains access tokens from a GitLab OAuth application. It is shared by every
+// client of the application: GitLab revokes a refresh token once it is used, so clients
+// refreshing on their own would invalidate each other's refresh token.
+type oauthSource struct {
+	cfg      config.GitLabOAuthConfig
+	grant    string
+	tokenURL string
+	http     *http.Client
+
+	mu           sync.Mutex
+	access       string
+	refreshAt    time.Time // Zero for access tokens that do not expire
+	refreshToken string    // Latest refresh token, rotated by GitLab on every refresh
+}
+
+var (
+	oauthSourcesMu sync.Mutex
+	oauthSources   = make(map[string]*oauthSource)
+)
+
+// sharedOAuthSource returns the source of the OAuth application of cfg, creating it on first
+// use. The configuration is checked without contacting GitLab.
+func sharedOAuthSource(cfg config.GitLabConfig) (*oauthSource, error) {
+	oauth := cfg.OAuth
+	grant := oauth.GrantType
+	if grant == "" {
+		grant = GrantClientCredentials
+		if oauth.RefreshToken != "" || oauth.RefreshTokenFile != "" {
+			grant = GrantRefreshToken
+		}
+	}
+	switch grant {
+	case GrantRefreshToken:
+		if oauth.RefreshToken == "" && oauth.RefreshTokenFile == "" {
+			return nil, fmt.Errorf("OAuth %s grant requires GITLAB_OAUTH_REFRESH_TOKEN or GITLAB_OAUTH_REFRESH_TOKEN_FILE", grant)
+		}
+	case GrantClientCredentials:
+		if oauth.ClientSecret == "" {
+			return nil, fmt.Errorf("OAuth %s grant requires GITLAB_OAUTH_CLIENT_SECRET", grant)
+		}
+	default:
+		return nil, fmt.Errorf("OAuth grant type %q must be %s or %s", grant, GrantRefreshToken, GrantClientCredentials)
+	}
+
+	tokenURL := oauth.TokenURL
+	if tokenURL == "" {
+		base, err := NormalizeBaseURL(cfg.BaseURL)
+		if err != nil {
+			return nil, err
+		}
+		tokenURL = base + "/oauth/token"
+	}
+
+	key := strings.Join([]string{tokenURL, oauth.ClientID, grant, oauth.RefreshToken, oauth.RefreshTokenFile, strings.Join(oauth.Scopes, " ")}, "\x00")
+	oauthSourcesMu.Lock()
+	defer oauthSourcesMu.Unlock()
+	if source, ok := oauthSources[key]; ok {
+		return source, nil
+	}
+
+	httpClient, err := createHTTPClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	source := &oauthSource{cfg: oauth, grant: grant, tokenURL: tokenURL, http: httpClient}
+	oauthSources[key] = source
+	return source, nil
+}
+
+// Token returns the current access token, obtaining a new one when none was obtained yet,
+// the token is due for refresh or GitLab rejected it
+func (s *oauthSource) Token() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.access != "" && (s.refreshAt.IsZero() || time.Now().Before(s.refreshAt)) {
+		return s.access, nil
+	}
+	return s.obtain()
+}
+
+// invalidate drops an access token GitLab rejected, unless another client already replaced it
+func (s *oauthSource) invalidate(rejected string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.access == rejected {
+		s.access = ""
+	}
+}
+
+// oauthTokenResponse is the response of the GitLab token endpoint
+type oauthTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+}
+
+// obtain requests a new access token with the configured grant; s.mu must be held
+func (s *oauthSource) obtain() (string, error) {
+	form := url.Values{"grant_type": {s.grant}, "client_id": {s.cfg.ClientID}}
+	if s.cfg.ClientSecret != "" {
+		form.Set("client_secret", s.cfg.ClientSecret)
+	}
+	if s.grant == GrantRefreshToken {
+		refreshToken, err := s.currentRefreshToken()
+		if err != nil {
+			return "", err
+		}
+		form.Set("refresh_token", refreshToken)
+	} else if len(s.cfg.Scopes) > 0 {
+		form.Set("scope", strings.Join(s.cfg.Scopes, " "))
+	}
+
+	resp, err := s.http.PostForm(s.tokenURL, form)
+	if err != nil {
+		return "", fmt.Errorf("failed to request OAuth access token: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", fmt.Errorf("OAuth token request failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var token oauthTokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", fmt.Errorf("failed to decode OAuth token response: %w", err)
+	}
+	if token.AccessToken == "" {
+		return "", fmt.Errorf("OAuth token response carries no access token")
+	}
+
+	if token.RefreshToken != "" && token.RefreshToken != s.refreshToken {
+		s.refreshToken = token.RefreshToken
+		if s.cfg.RefreshTokenFile != "" {
+			if err := writeTokenFile(s.cfg.RefreshTokenFile, token.RefreshToken); err != nil {
+				logging.Warn("Failed to save the rotated OAuth refresh token, a restart will need a new one", zap.Error(err))
+			}
+		}
+	}
+
+	s.access = token.AccessToken
+	s.refreshAt = time.Time{}
+	if token.ExpiresIn > 0 {
+		lifetime := time.Duration(token.ExpiresIn) * time.Second
+		margin := oauthRefreshMargin
+		if lifetime < 2*margin {
+			margin = lifetime / 2
+		}
+		s.refreshAt = time.Now().Add(lifetime - margin)
+	}
+	logging.Info("Obtained GitLab OAuth access token",
+		zap.String("grant", s.grant),
+		zap.Int("expires_in_seconds", token.ExpiresIn))
+	return s.access, nil
+}
+
+// currentRefreshToken returns the latest refresh token: the one GitLab returned last, else
+// the saved one from the refresh token file, else the configured one
+func (s *oauthSource) currentRefreshToken() (string, error) {
+	if s.refreshToken != "" {
+		return s.refreshToken, nil
+	}
+	if s.cfg.RefreshTokenFile != "" {
+		token, err := ReadTokenFile(s.cfg.RefreshTokenFile)
+		if err == nil {
+			s.refreshToken = token
+			return token, nil
+		}
+		if s.cfg.RefreshToken == "" {
+			return "", err
+		}
+	}
+	s.refreshToken = s.cfg.RefreshToken
+	return s.refreshToken, nil
+}
+
+// writeTokenFile replaces the content of a token file, writing a temporary file first so a
+// crash never leaves a truncated token behind
+func writeTokenFile(path, token string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".token-*")
+	if err != nil {
+		return fmt.Errorf("failed to write token file %s: %w", path, err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.WriteString(token + "\n"); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write token file %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write token file %s: %w", path, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write token file %s: %w", path, err)
+	}
+	return nil
+}
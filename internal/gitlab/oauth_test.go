@@ -0,0 +1,175 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+)
+
+// oauthServer is a GitLab issuing access tokens from rotating refresh tokens; API requests
+// are accepted only with the latest access token
+type oauthServer struct {
+	*httptest.Server
+	refreshToken  string // The only refresh token accepted
+	accessToken   string // The only access token accepted
+	expiresIn     int
+	tokenRequests int
+	forms         []map[string]string
+}
+
+func newOAuthServer(t *testing.T, refreshToken string) *oauthServer {
+	s := &oauthServer{refreshToken: refreshToken, expiresIn: 7200}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/oauth/token" {
+			assert.NoError(t, r.ParseForm())
+			form := make(map[string]string)
+			for key := range r.PostForm {
+				form[key] = r.PostForm.Get(key)
+			}
+			s.forms = append(s.forms, form)
+			s.tokenRequests++
+
+			if form["grant_type"] == GrantRefreshToken && form["refresh_token"] != s.refreshToken {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"error":"invalid_grant"}`))
+				return
+			}
+			s.accessToken = fmt.Sprintf("access-%d", s.tokenRequests)
+			s.refreshToken = fmt.Sprintf("refresh-%d", s.tokenRequests)
+			_, _ = fmt.Fprintf(w, `{"access_token":%q,"token_type":"Bearer","expires_in":%d,"refresh_token":%q}`,
+				s.accessToken, s.expiresIn, s.refreshToken)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer "+s.accessToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path == "/api/v4/user" {
+			_, _ = w.Write([]byte(`{"id":7,"username":"naysayer-oauth"}`))
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	return s
+}
+
+func TestSharedOAuthSource_RejectsInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		oauth config.GitLabOAuthConfig
+	}{
+		{"unknown grant", config.GitLabOAuthConfig{ClientID: "app", GrantType: "password"}},
+		{"client credentials without secret", config.GitLabOAuthConfig{ClientID: "app"}},
+		{"refresh grant without refresh token", config.GitLabOAuthConfig{ClientID: "app", GrantType: GrantRefreshToken}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := sharedOAuthSource(config.GitLabConfig{BaseURL: "https://gitlab.example.com", OAuth: tt.oauth})
+			assert.Error(t, err)
+			assert.Error(t, ValidateConfig(config.GitLabConfig{BaseURL: "https://gitlab.example.com", OAuth: tt.oauth}))
+		})
+	}
+}
+
+func TestClient_OAuthRefreshToken(t *testing.T) {
+	ctx := context.Background()
+	server := newOAuthServer(t, "initial-refresh")
+	defer server.Close()
+
+	refreshPath := filepath.Join(t.TempDir(), "refresh-token")
+	assert.NoError(t, os.WriteFile(refreshPath, []byte("initial-refresh\n"), 0600))
+	cfg := config.GitLabConfig{BaseURL: server.URL, OAuth: config.GitLabOAuthConfig{
+		ClientID:         "app",
+		ClientSecret:     "secret",
+		RefreshTokenFile: refreshPath,
+	}}
+
+	review := NewClient(cfg)
+	cleanup := NewClient(cfg)
+	assert.Equal(t, "access-1", review.currentToken())
+	assert.Equal(t, "access-1", cleanup.currentToken())
+	assert.Equal(t, 1, server.tokenRequests, "clients of one application share its access token")
+	assert.Equal(t, map[string]string{
+		"grant_type": GrantRefreshToken, "client_id": "app", "client_secret": "secret", "refresh_token": "initial-refresh",
+	}, server.forms[0])
+	saved, _ := ReadTokenFile(refreshPath)
+	assert.Equal(t, "refresh-1", saved, "the rotated refresh token survives restarts")
+
+	// GitLab revokes the access token early: one refresh serves both clients
+	server.accessToken = "revoked"
+	assert.NoError(t, review.AddMRComment(ctx, 1, 2, "hello"))
+	assert.NoError(t, cleanup.AddMRComment(ctx, 1, 2, "hello"))
+	assert.Equal(t, 2, server.tokenRequests)
+	assert.Equal(t, "refresh-1", server.forms[1]["refresh_token"])
+	assert.Equal(t, "access-2", cleanup.currentToken())
+
+	info, err := review.TokenInfo(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, &TokenInfo{Name: "naysayer-oauth", Active: true}, info)
+}
+
+func TestClient_OAuthRefreshesBeforeExpiry(t *testing.T) {
+	server := newOAuthServer(t, "initial-refresh")
+	defer server.Close()
+	server.expiresIn = 600
+
+	cfg := config.GitLabConfig{BaseURL: server.URL, OAuth: config.GitLabOAuthConfig{
+		ClientID:     "app",
+		RefreshToken: "initial-refresh",
+	}}
+	client := NewClient(cfg)
+	assert.Equal(t, "access-1", client.currentToken())
+
+	source, err := sharedOAuthSource(cfg)
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(5*time.Minute), source.refreshAt, 5*time.Second)
+
+	// Not due yet
+	client.auth.loadedAt = time.Now().Add(-time.Minute)
+	assert.Equal(t, "access-1", client.currentToken())
+
+	source.refreshAt = time.Now().Add(-time.Second)
+	client.auth.loadedAt = time.Now().Add(-time.Minute)
+	assert.Equal(t, "access-2", client.currentToken())
+	assert.Equal(t, 2, server.tokenRequests)
+	_, hasSecret := server.forms[1]["client_secret"]
+	assert.False(t, hasSecret, "public applications send no secret")
+}
+
+func TestClient_OAuthClientCredentials(t *testing.T) {
+	server := newOAuthServer(t, "")
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, OAuth: config.GitLabOAuthConfig{
+		ClientID:     "service-app",
+		ClientSecret: "secret",
+		Scopes:       []string{"api", "read_repository"},
+		TokenURL:     server.URL + "/oauth/token",
+	}})
+	assert.Equal(t, "access-1", client.currentToken())
+	assert.Equal(t, map[string]string{
+		"grant_type": GrantClientCredentials, "client_id": "service-app", "client_secret": "secret", "scope": "api read_repository",
+	}, server.forms[0])
+}
+
+func TestWriteTokenFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	assert.NoError(t, writeTokenFile(path, "rotated"))
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "rotated\n", string(data))
+
+	err = writeTokenFile(filepath.Join(t.TempDir(), "missing", "token"), "rotated")
+	assert.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), "failed to write token file"))
+}
@@ -0,0 +1,50 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Project represents the GitLab project settings naysayer inspects
+type Project struct {
+	ID                           int    `json:"id"`
+	PathWithNamespace            string `json:"path_with_namespace"`
+	MergeMethod                  string `json:"merge_method"`                     // "merge", "rebase_merge" or "ff"
+	SquashOption                 string `json:"squash_option"`                    // "never", "always", "default_on" or "default_off"
+	RemoveSourceBranchAfterMerge bool   `json:"remove_source_branch_after_merge"` // Default of "Delete source branch" for new MRs
+	Archived                     bool   `json:"archived"`                         // Archived projects are read-only; writes fail with 403
+}
+
+// GetProject returns the settings of a project.
+// GET /projects/:id
+func (c *Client) GetProject(ctx context.Context, projectID int) (*Project, error) {
+	apiURL := c.apiURL("/projects/%d", projectID)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create project request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, newAPIError(resp, "get project failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var project Project
+	if err := json.NewDecoder(resp.Body).Decode(&project); err != nil {
+		return nil, fmt.Errorf("failed to decode project response: %w", err)
+	}
+
+	return &project, nil
+}
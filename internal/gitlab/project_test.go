@@ -0,0 +1,56 @@
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_GetProject(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/42", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": 42, "path_with_namespace": "data/dataverse-config", "merge_method": "ff", "squash_option": "always"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+	project, err := client.GetProject(context.Background(), 42)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "data/dataverse-config", project.PathWithNamespace)
+	assert.Equal(t, "ff", project.MergeMethod)
+	assert.Equal(t, "always", project.SquashOption)
+	assert.False(t, project.Archived)
+}
+
+func TestClient_GetProject_Archived(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": 42, "path_with_namespace": "data/legacy-config", "archived": true}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+	project, err := client.GetProject(context.Background(), 42)
+
+	assert.NoError(t, err)
+	assert.True(t, project.Archived)
+}
+
+func TestClient_GetProject_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+	_, err := client.GetProject(context.Background(), 42)
+
+	assert.True(t, errors.Is(err, ErrPermission))
+}
@@ -0,0 +1,58 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// TreeEntry represents a single entry from the repository tree API
+type TreeEntry struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"` // "blob" or "tree"
+	Path string `json:"path"`
+	Mode string `json:"mode"`
+}
+
+// ListRepositoryTree returns every entry of the repository tree at ref (recursive, all pages).
+// GET /projects/:id/repository/tree?recursive=true&ref=<ref>
+func (c *Client) ListRepositoryTree(ctx context.Context, projectID int, ref string) ([]TreeEntry, error) {
+	var entries []TreeEntry
+	apiURL := c.apiURL("/projects/%d/repository/tree?recursive=true&per_page=100&ref=%s", projectID, url.QueryEscape(ref))
+
+	for apiURL != "" {
+		req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create repository tree request: %w", err)
+		}
+
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := c.do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list repository tree: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(resp.Body)
+			_ = resp.Body.Close()
+			return nil, newAPIError(resp, "list repository tree failed with status %d: %s", resp.StatusCode, string(body))
+		}
+
+		var page []TreeEntry
+		err = json.NewDecoder(resp.Body).Decode(&page)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode repository tree response: %w", err)
+		}
+
+		entries = append(entries, page...)
+		apiURL = parseNextLink(resp.Header.Get("Link"))
+	}
+
+	return entries, nil
+}
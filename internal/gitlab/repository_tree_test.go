@@ -0,0 +1,61 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_ListRepositoryTree_Pagination(t *testing.T) {
+	var serverURL string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/42/repository/tree", r.URL.Path)
+		assert.Equal(t, "true", r.URL.Query().Get("recursive"))
+		assert.Equal(t, "release/1.0", r.URL.Query().Get("ref"))
+		assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("page") == "2" {
+			_ = json.NewEncoder(w).Encode([]TreeEntry{
+				{Name: "b.yaml", Type: "blob", Path: "dir/b.yaml"},
+			})
+			return
+		}
+		w.Header().Set("Link", fmt.Sprintf(`<%s/api/v4/projects/42/repository/tree?recursive=true&ref=release%%2F1.0&page=2>; rel="next"`, serverURL))
+		_ = json.NewEncoder(w).Encode([]TreeEntry{
+			{Name: "dir", Type: "tree", Path: "dir"},
+			{Name: "a.yaml", Type: "blob", Path: "dir/a.yaml"},
+		})
+	}))
+	defer server.Close()
+	serverURL = server.URL
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+	entries, err := client.ListRepositoryTree(context.Background(), 42, "release/1.0")
+
+	assert.NoError(t, err)
+	assert.Len(t, entries, 3)
+	assert.Equal(t, "dir/a.yaml", entries[1].Path)
+	assert.Equal(t, "dir/b.yaml", entries[2].Path)
+}
+
+func TestClient_ListRepositoryTree_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"404 Tree Not Found"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+	entries, err := client.ListRepositoryTree(context.Background(), 42, "missing")
+
+	assert.Error(t, err)
+	assert.Nil(t, entries)
+	assert.Contains(t, err.Error(), "status 404")
+}
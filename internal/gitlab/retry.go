@@ -0,0 +1,197 @@
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"go.uber.org/zap"
+)
+
+// maxRetryDelay caps the backoff and the Retry-After delays honoured by the client
+const maxRetryDelay = 30 * time.Second
+
+// rateLimiter is a token bucket allowing rate requests per second with bursts of up to
+// one second worth of requests
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	burst    float64
+	tokens   float64
+	last     time.Time
+	now      func() time.Time
+}
+
+// newRateLimiter returns a limiter, or nil when rate is not positive
+func newRateLimiter(rate float64) *rateLimiter {
+	if rate <= 0 {
+		return nil
+	}
+	burst := math.Max(1, math.Ceil(rate))
+	return &rateLimiter{
+		interval: time.Duration(float64(time.Second) / rate),
+		burst:    burst,
+		tokens:   burst,
+		now:      time.Now,
+	}
+}
+
+// reserve takes a token and returns how long the caller has to wait for it
+func (l *rateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if !l.last.IsZero() {
+		l.tokens = math.Min(l.burst, l.tokens+float64(now.Sub(l.last))/float64(l.interval))
+	}
+	l.last = now
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens * float64(l.interval))
+}
+
+// wait blocks until a request may be sent. A nil limiter never blocks.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	return sleepContext(ctx, l.reserve())
+}
+
+// send issues req through the rate limiter, retrying 429 answers and, for idempotent
+// methods, transient 5xx answers and network errors with exponential backoff
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if err := c.limiter.wait(req.Context()); err != nil {
+			return nil, err
+		}
+
+		resp, err := c.http.Do(req)
+		if attempt >= c.config.MaxRetries || !shouldRetry(req, resp, err) {
+			return resp, err
+		}
+		retry, ok := rewind(req)
+		if !ok {
+			return resp, err
+		}
+
+		delay := c.backoff(attempt)
+		if resp != nil {
+			if after := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); after > 0 {
+				delay = min(after, maxRetryDelay)
+			}
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+			logging.FromContext(req.Context()).Warn("GitLab API request answered with a retryable status, retrying",
+				zap.String("method", req.Method),
+				zap.String("path", req.URL.Path),
+				zap.Int("status", resp.StatusCode),
+				zap.Duration("delay", delay),
+				zap.Int("attempt", attempt+1),
+				zap.Int("max_retries", c.config.MaxRetries))
+		} else {
+			logging.FromContext(req.Context()).Warn("GitLab API request failed, retrying",
+				zap.String("method", req.Method),
+				zap.String("path", req.URL.Path),
+				zap.Error(err),
+				zap.Duration("delay", delay),
+				zap.Int("attempt", attempt+1),
+				zap.Int("max_retries", c.config.MaxRetries))
+		}
+
+		if err := c.sleep(req.Context(), delay); err != nil {
+			return nil, err
+		}
+		req = retry
+	}
+}
+
+// backoff returns the delay before retry attempt+1: the base delay doubled per attempt,
+// with up to 20% jitter so concurrent callers do not retry in lockstep
+func (c *Client) backoff(attempt int) time.Duration {
+	base := time.Duration(c.config.RetryBackoffMs) * time.Millisecond
+	if base <= 0 {
+		return 0
+	}
+	delay := base << uint(attempt)
+	if delay <= 0 || delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	jitter := time.Duration(rand.Int63n(int64(delay)/5 + 1)) // #nosec G404 - jitter needs no cryptographic randomness
+	return delay - jitter
+}
+
+// sleep waits for d unless the request context ends first
+func (c *Client) sleep(ctx context.Context, d time.Duration) error {
+	if c.sleepFunc != nil {
+		return c.sleepFunc(ctx, d)
+	}
+	return sleepContext(ctx, d)
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// shouldRetry reports whether a failed request may be sent again. Rate-limited requests
+// were not processed, so they are retried for every method; 5xx answers and network
+// errors only for methods that are safe to repeat.
+func shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return false
+		}
+		return idempotent(req.Method)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return idempotent(req.Method)
+	}
+	return false
+}
+
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// rewind returns a copy of req with a fresh body, or false when the body cannot be re-read
+func rewind(req *http.Request) (*http.Request, bool) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return req.Clone(req.Context()), true
+	}
+	if req.GetBody == nil {
+		return nil, false
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, false
+	}
+	retry := req.Clone(req.Context())
+	retry.Body = body
+	return retry, true
+}
@@ -0,0 +1,159 @@
+package gitlab
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// flakyServer answers the first failures requests with status, then 200/201
+func flakyServer(status, failures int, header http.Header, bodies *[]string) (*httptest.Server, *int32) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		if bodies != nil {
+			body, _ := io.ReadAll(r.Body)
+			*bodies = append(*bodies, string(body))
+		}
+		if int(n) <= failures {
+			for key, values := range header {
+				w.Header()[key] = values
+			}
+			w.WriteHeader(status)
+			return
+		}
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		_, _ = w.Write([]byte(`{"iid": 2, "title": "MR"}`))
+	}))
+	return server, &calls
+}
+
+// newRetryClient records retry delays instead of sleeping
+func newRetryClient(baseURL string, maxRetries int, delays *[]time.Duration) *Client {
+	client := NewClient(config.GitLabConfig{BaseURL: baseURL, Token: "token", MaxRetries: maxRetries, RetryBackoffMs: 100})
+	client.sleepFunc = func(ctx context.Context, d time.Duration) error {
+		*delays = append(*delays, d)
+		return nil
+	}
+	return client
+}
+
+func TestClient_RetriesRateLimitedRequestsHonouringRetryAfter(t *testing.T) {
+	ctx := context.Background()
+	var bodies []string
+	server, calls := flakyServer(http.StatusTooManyRequests, 2, http.Header{"Retry-After": {"7"}}, &bodies)
+	defer server.Close()
+
+	var delays []time.Duration
+	client := newRetryClient(server.URL, 3, &delays)
+
+	assert.NoError(t, client.AddMRComment(ctx, 1, 2, "hello"), "rate-limited POSTs are retried")
+	assert.Equal(t, int32(3), atomic.LoadInt32(calls))
+	assert.Equal(t, []time.Duration{7 * time.Second, 7 * time.Second}, delays)
+	for _, body := range bodies {
+		assert.Contains(t, body, "hello", "the body is re-sent on every attempt")
+	}
+}
+
+func TestClient_RetriesTransientErrorsWithBackoff(t *testing.T) {
+	ctx := context.Background()
+	server, calls := flakyServer(http.StatusBadGateway, 2, nil, nil)
+	defer server.Close()
+
+	var delays []time.Duration
+	client := newRetryClient(server.URL, 3, &delays)
+
+	details, err := client.GetMRDetails(ctx, 1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, details.IID)
+	assert.Equal(t, int32(3), atomic.LoadInt32(calls))
+	assert.Len(t, delays, 2)
+	assert.InDelta(t, 90*time.Millisecond, delays[0], float64(10*time.Millisecond), "base delay with up to 20% jitter")
+	assert.InDelta(t, 180*time.Millisecond, delays[1], float64(20*time.Millisecond), "doubled per attempt")
+}
+
+func TestClient_DoesNotRetryTransientErrorsOnPost(t *testing.T) {
+	ctx := context.Background()
+	server, calls := flakyServer(http.StatusBadGateway, 1, nil, nil)
+	defer server.Close()
+
+	var delays []time.Duration
+	client := newRetryClient(server.URL, 3, &delays)
+
+	assert.Error(t, client.AddMRComment(ctx, 1, 2, "hello"), "a 502 POST may have been processed")
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+	assert.Empty(t, delays)
+}
+
+func TestClient_GivesUpAfterMaxRetries(t *testing.T) {
+	ctx := context.Background()
+	server, calls := flakyServer(http.StatusServiceUnavailable, 10, nil, nil)
+	defer server.Close()
+
+	var delays []time.Duration
+	client := newRetryClient(server.URL, 2, &delays)
+
+	_, err := client.GetMRDetails(ctx, 1, 2)
+	assert.Error(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(calls))
+	assert.Len(t, delays, 2)
+
+	// Retries are disabled without MaxRetries
+	atomic.StoreInt32(calls, 0)
+	_, err = NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "token"}).GetMRDetails(ctx, 1, 2)
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+}
+
+func TestClient_BackoffIsCapped(t *testing.T) {
+	client := NewClient(config.GitLabConfig{RetryBackoffMs: 1000})
+	delay := client.backoff(10)
+	assert.LessOrEqual(t, delay, maxRetryDelay)
+	assert.GreaterOrEqual(t, delay, maxRetryDelay*4/5)
+
+	assert.Equal(t, time.Duration(0), NewClient(config.GitLabConfig{}).backoff(3))
+}
+
+func TestClient_CapsRetryAfter(t *testing.T) {
+	ctx := context.Background()
+	server, _ := flakyServer(http.StatusTooManyRequests, 1, http.Header{"Retry-After": {"3600"}}, nil)
+	defer server.Close()
+
+	var delays []time.Duration
+	_, err := newRetryClient(server.URL, 1, &delays).GetMRDetails(ctx, 1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Duration{maxRetryDelay}, delays)
+}
+
+func TestRateLimiter(t *testing.T) {
+	assert.Nil(t, newRateLimiter(0))
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	limiter := newRateLimiter(2)
+	limiter.now = func() time.Time { return now }
+
+	// A burst of one second worth of requests passes, then requests are spaced
+	assert.Equal(t, time.Duration(0), limiter.reserve())
+	assert.Equal(t, time.Duration(0), limiter.reserve())
+	assert.Equal(t, 500*time.Millisecond, limiter.reserve())
+	assert.Equal(t, time.Second, limiter.reserve())
+
+	// Tokens refill over time
+	now = now.Add(3 * time.Second)
+	assert.Equal(t, time.Duration(0), limiter.reserve())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	limiter.now = func() time.Time { return now }
+	assert.Error(t, limiter.wait(ctx), "a canceled request stops waiting")
+}
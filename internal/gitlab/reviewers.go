@@ -0,0 +1,75 @@
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// GetUserByUsername looks up a user by username. Unknown usernames (e.g. group paths)
+// return an error matching ErrNotFound.
+// GET /users?username=:username
+func (c *Client) GetUserByUsername(ctx context.Context, username string) (*MRUser, error) {
+	apiURL := c.apiURL("/users?username=%s", url.QueryEscape(username))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, newAPIError(resp, "get user failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var users []MRUser
+	if err := json.NewDecoder(resp.Body).Decode(&users); err != nil {
+		return nil, fmt.Errorf("failed to decode users response: %w", err)
+	}
+	if len(users) == 0 {
+		return nil, fmt.Errorf("user %s: %w", username, ErrNotFound)
+	}
+	return &users[0], nil
+}
+
+// SetMRReviewers replaces the reviewers of a merge request
+// PUT /projects/:id/merge_requests/:merge_request_iid
+func (c *Client) SetMRReviewers(ctx context.Context, projectID, mrIID int, reviewerIDs []int) error {
+	apiURL := c.apiURL("/projects/%d/merge_requests/%d", projectID, mrIID)
+
+	jsonPayload, err := json.Marshal(map[string][]int{"reviewer_ids": reviewerIDs})
+	if err != nil {
+		return fmt.Errorf("failed to marshal reviewers payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "PUT", apiURL, bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return fmt.Errorf("failed to create reviewers request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to set MR reviewers: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return newAPIError(resp, "set MR reviewers failed with status %d: %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_GetUserByUsername(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/users", r.URL.Path)
+		if r.URL.Query().Get("username") == "alice" {
+			_, _ = w.Write([]byte(`[{"id": 5, "username": "alice"}]`))
+			return
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+	user, err := client.GetUserByUsername(context.Background(), "alice")
+	assert.NoError(t, err)
+	assert.Equal(t, &MRUser{ID: 5, Username: "alice"}, user)
+
+	_, err = client.GetUserByUsername(context.Background(), "data-platform")
+	assert.True(t, errors.Is(err, ErrNotFound))
+}
+
+func TestClient_SetMRReviewers(t *testing.T) {
+	var payload map[string][]int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" && r.URL.Path == "/api/v4/projects/42/merge_requests/7" {
+			_ = json.NewDecoder(r.Body).Decode(&payload)
+			_, _ = w.Write([]byte(`{"iid": 7}`))
+			return
+		}
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+	assert.NoError(t, client.SetMRReviewers(context.Background(), 42, 7, []int{5, 6}))
+	assert.Equal(t, map[string][]int{"reviewer_ids": {5, 6}}, payload)
+
+	err := client.SetMRReviewers(context.Background(), 42, 8, []int{5})
+	assert.True(t, errors.Is(err, ErrPermission))
+}
@@ -0,0 +1,89 @@
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Snippet is a project snippet
+type Snippet struct {
+	ID       int    `json:"id"`
+	Title    string `json:"title"`
+	FileName string `json:"file_name"`
+	WebURL   string `json:"web_url"`
+}
+
+// GetProjectSnippet returns a project snippet.
+// GET /projects/:id/snippets/:snippet_id
+func (c *Client) GetProjectSnippet(ctx context.Context, projectID, snippetID int) (*Snippet, error) {
+	apiURL := c.apiURL("/projects/%d/snippets/%d", projectID, snippetID)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create snippet request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get snippet: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, newAPIError(resp, "get snippet failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var snippet Snippet
+	if err := json.NewDecoder(resp.Body).Decode(&snippet); err != nil {
+		return nil, fmt.Errorf("failed to decode snippet response: %w", err)
+	}
+	return &snippet, nil
+}
+
+// snippetFile is a file action in a snippet update
+type snippetFile struct {
+	Action   string `json:"action"`
+	FilePath string `json:"file_path"`
+	Content  string `json:"content"`
+}
+
+// UpdateProjectSnippet replaces the title and the content of one file of a project snippet.
+// The file must already exist in the snippet.
+// PUT /projects/:id/snippets/:snippet_id
+func (c *Client) UpdateProjectSnippet(ctx context.Context, projectID, snippetID int, title, filePath, content string) error {
+	apiURL := c.apiURL("/projects/%d/snippets/%d", projectID, snippetID)
+
+	jsonPayload, err := json.Marshal(map[string]interface{}{
+		"title": title,
+		"files": []snippetFile{{Action: "update", FilePath: filePath, Content: content}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal snippet payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "PUT", apiURL, bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return fmt.Errorf("failed to create snippet request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to update snippet: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return newAPIError(resp, "update snippet failed with status %d: %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
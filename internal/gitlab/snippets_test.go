@@ -0,0 +1,61 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_UpdateProjectSnippet(t *testing.T) {
+	var payload struct {
+		Title string        `json:"title"`
+		Files []snippetFile `json:"files"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		assert.Equal(t, "/api/v4/projects/42/snippets/9", r.URL.Path)
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		_, _ = w.Write([]byte(`{"id": 9}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+	err := client.UpdateProjectSnippet(context.Background(), 42, 9, "Governance report 2026-09", "governance-report.md", "# Report")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Governance report 2026-09", payload.Title)
+	assert.Equal(t, []snippetFile{{Action: "update", FilePath: "governance-report.md", Content: "# Report"}}, payload.Files)
+}
+
+func TestClient_UpdateProjectSnippet_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+	err := client.UpdateProjectSnippet(context.Background(), 42, 9, "title", "governance-report.md", "# Report")
+
+	assert.True(t, errors.Is(err, ErrNotFound))
+}
+
+func TestClient_GetProjectSnippet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/42/snippets/9", r.URL.Path)
+		_, _ = w.Write([]byte(`{"id": 9, "title": "Governance report 2026-09", "file_name": "governance-report.md"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+	snippet, err := client.GetProjectSnippet(context.Background(), 42, 9)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Governance report 2026-09", snippet.Title)
+	assert.Equal(t, "governance-report.md", snippet.FileName)
+}
@@ -0,0 +1,307 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/tracing"
+	"go.uber.org/zap"
+)
+
+// TokenRefresher obtains a fresh GitLab token after the current one was rejected,
+// e.g. by re-reading a mounted secret or performing an OIDC token exchange
+type TokenRefresher func() (string, error)
+
+// FileTokenRefresher returns a refresher that re-reads the token from a file
+func FileTokenRefresher(path string) TokenRefresher {
+	return func() (string, error) {
+		return ReadTokenFile(path)
+	}
+}
+
+// ReadTokenFile reads a token from a file, trimming surrounding whitespace
+func ReadTokenFile(path string) (string, error) {
+	data, err := os.ReadFile(path) // #nosec G304 - path comes from operator configuration
+	if err != nil {
+		return "", fmt.Errorf("failed to read token file %s: %w", path, err)
+	}
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", fmt.Errorf("token file %s is empty", path)
+	}
+	return token, nil
+}
+
+// credentials is the token state of a client, refreshed when GitLab rejects the token and,
+// for token files and sources, every reload interval so rotated tokens are picked up early
+type credentials struct {
+	mu          sync.RWMutex
+	token       string
+	refresh     TokenRefresher
+	invalidate  func(rejected string) // Tells a shared token source that GitLab rejected its token
+	reloadEvery time.Duration         // 0 refreshes only after 401
+	loadedAt    time.Time             // Last time refresh was called
+}
+
+// reloadDue reports whether the reload interval elapsed since the token was last loaded
+func (a *credentials) reloadDue(now time.Time) bool {
+	return a.refresh != nil && a.reloadEvery > 0 && now.Sub(a.loadedAt) >= a.reloadEvery
+}
+
+// tokenReloads counts the rotated tokens picked up since startup
+var tokenReloads atomic.Int64
+
+// TokenReloads returns the number of times a client replaced its token with a rotated one
+// since startup, after a 401 or on a periodic reload
+func TokenReloads() int64 {
+	return tokenReloads.Load()
+}
+
+// tokenRefresher returns the refresher of the OAuth application, token source or token file
+// of cfg, or nil when the token is static
+func tokenRefresher(cfg config.GitLabConfig) (TokenRefresher, error) {
+	switch {
+	case cfg.OAuth.Configured():
+		source, err := sharedOAuthSource(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return source.Token, nil
+	case cfg.TokenSource != "":
+		return NewTokenSource(cfg.TokenSource, cfg.Vault)
+	case cfg.TokenFile != "":
+		return FileTokenRefresher(cfg.TokenFile), nil
+	}
+	return nil, nil
+}
+
+// SetTokenRefresher installs the callback used to refresh the token when GitLab answers 401
+func (c *Client) SetTokenRefresher(refresh TokenRefresher) {
+	c.auth.mu.Lock()
+	defer c.auth.mu.Unlock()
+	c.auth.refresh = refresh
+}
+
+// currentToken returns the token used for new requests, reloading it first when the reload
+// interval elapsed
+func (c *Client) currentToken() string {
+	c.auth.mu.RLock()
+	token, due := c.auth.token, c.auth.reloadDue(time.Now())
+	c.auth.mu.RUnlock()
+	if !due {
+		return token
+	}
+	return c.reloadToken()
+}
+
+// reloadToken re-reads the token file or source, keeping the current token when it fails
+func (c *Client) reloadToken() string {
+	c.auth.mu.Lock()
+	defer c.auth.mu.Unlock()
+
+	now := time.Now()
+	if !c.auth.reloadDue(now) {
+		return c.auth.token
+	}
+	c.auth.loadedAt = now
+
+	token, err := c.auth.refresh()
+	if err != nil {
+		logging.Warn("GitLab token reload failed, keeping the current token", zap.Error(err))
+		return c.auth.token
+	}
+	if token != c.auth.token {
+		c.auth.token = token
+		tokenReloads.Add(1)
+		logging.Info("Reloaded rotated GitLab token")
+	}
+	return c.auth.token
+}
+
+// requestIDHeader carries the correlation ID of the webhook request a GitLab API call is
+// made for; GitLab can record it as the correlation ID of the call in its own logs
+const requestIDHeader = "X-Request-Id"
+
+// do sends an authenticated request, revalidating GET requests with ETags when the response
+// cache is enabled. While the circuit breaker is open requests fail fast with ErrUnavailable.
+// Each call is traced as a client span covering its retries.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	ctx, span := tracing.Start(req.Context(), "GitLab "+req.Method, tracing.KindClient,
+		tracing.String("http.request.method", req.Method),
+		tracing.String("server.address", req.URL.Host),
+		tracing.String("url.path", req.URL.Path))
+	defer span.End()
+	if span != nil {
+		req = req.WithContext(ctx)
+		tracing.Inject(ctx, req.Header)
+	}
+
+	if err := c.breaker.allow(); err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	if id := logging.CorrelationID(req.Context()); id != "" {
+		req.Header.Set(requestIDHeader, id)
+	}
+
+	var resp *http.Response
+	var err error
+	if c.etags != nil && req.Method == http.MethodGet {
+		resp, err = c.doConditional(req)
+	} else {
+		resp, err = c.doAuthorized(req)
+	}
+	c.breaker.record(resp, err)
+	switch {
+	case err != nil:
+		span.SetError(err)
+	case resp.StatusCode >= http.StatusBadRequest:
+		span.SetAttributes(tracing.Int("http.response.status_code", resp.StatusCode))
+		span.SetFailed(http.StatusText(resp.StatusCode))
+	default:
+		span.SetAttributes(tracing.Int("http.response.status_code", resp.StatusCode))
+	}
+	return resp, err
+}
+
+// doAuthorized sends an authenticated request, rate limited and retried on 429 and transient
+// errors. When GitLab rejects the token with 401 and a refresher is configured, the token is
+// refreshed once and the request retried.
+func (c *Client) doAuthorized(req *http.Request) (*http.Response, error) {
+	token := c.currentToken()
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := c.send(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	retry, ok := c.retryRequest(req, token)
+	if !ok {
+		return resp, nil
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+
+	return c.send(retry)
+}
+
+// retryRequest refreshes the token rejected for req and returns a copy of req carrying the new one
+func (c *Client) retryRequest(req *http.Request, rejected string) (*http.Request, bool) {
+	if req.Body != nil && req.GetBody == nil {
+		return nil, false
+	}
+
+	token, ok := c.refreshAfterUnauthorized(rejected)
+	if !ok {
+		return nil, false
+	}
+
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, false
+		}
+		retry.Body = body
+	}
+	retry.Header.Set("Authorization", "Bearer "+token)
+	return retry, true
+}
+
+// refreshAfterUnauthorized swaps in a fresh token. Concurrent requests rejected with the same
+// token share one refresh; a token already replaced by another request is reused.
+func (c *Client) refreshAfterUnauthorized(rejected string) (string, bool) {
+	c.auth.mu.Lock()
+	defer c.auth.mu.Unlock()
+
+	if c.auth.refresh == nil {
+		return "", false
+	}
+	if c.auth.token != rejected {
+		return c.auth.token, true
+	}
+
+	if c.auth.invalidate != nil {
+		c.auth.invalidate(rejected)
+	}
+	token, err := c.auth.refresh()
+	c.auth.loadedAt = time.Now()
+	if err != nil {
+		logging.Warn("GitLab token refresh failed", zap.Error(err))
+		return "", false
+	}
+	if token == rejected {
+		logging.Warn("GitLab token refresh returned the rejected token, not retrying")
+		return "", false
+	}
+
+	c.auth.token = token
+	tokenReloads.Add(1)
+	logging.Info("Refreshed GitLab token after 401 response")
+	return token, true
+}
+
+// TokenInfo describes the token of a client as reported by GitLab
+type TokenInfo struct {
+	Name      string   `json:"name"`
+	Active    bool     `json:"active"`
+	Revoked   bool     `json:"revoked"`
+	ExpiresAt string   `json:"expires_at"` // YYYY-MM-DD, empty for tokens that do not expire
+	Scopes    []string `json:"scopes"`
+}
+
+// TokenInfo returns the details of the client's token, which also covers project and group
+// access tokens. GitLab versions without /personal_access_tokens/self (before 15.5) and
+// OAuth access tokens, which are refreshed before they expire, are checked with /user,
+// which only tells that the token is accepted. A rejected token returns an error matching
+// ErrPermission.
+func (c *Client) TokenInfo(ctx context.Context) (*TokenInfo, error) {
+	if c.config.OAuth.Configured() {
+		return c.userTokenInfo(ctx)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", c.apiURL("/personal_access_tokens/self"), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token info request: %w", err)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token info: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return c.userTokenInfo(ctx)
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, newAPIError(resp, "token info request failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var info TokenInfo
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return nil, fmt.Errorf("failed to decode token info response: %w", err)
+	}
+	return &info, nil
+}
+
+// userTokenInfo reports a token /user accepts as active
+func (c *Client) userTokenInfo(ctx context.Context) (*TokenInfo, error) {
+	user, err := c.CurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenInfo{Name: user.Username, Active: true}, nil
+}
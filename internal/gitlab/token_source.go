@@ -0,0 +1,143 @@
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+)
+
+// Schemes of GITLAB_TOKEN_SOURCE references
+const (
+	tokenSourceFile    = "file:"
+	tokenSourceVault   = "vault:"
+	tokenSourceCommand = "command:"
+)
+
+// defaultVaultField is the secret field holding the token when the reference names none
+const defaultVaultField = "token"
+
+// tokenSourceTimeout bounds a single fetch from Vault or a secret manager command
+const tokenSourceTimeout = 30 * time.Second
+
+// NewTokenSource returns a refresher loading the token from a secret manager reference:
+// file:<path>, vault:<secret path>[#<field>] or command:<program> [args...]. The reference
+// is checked without contacting the backend.
+func NewTokenSource(ref string, vault config.VaultConfig) (TokenRefresher, error) {
+	ref = strings.TrimSpace(ref)
+	switch {
+	case strings.HasPrefix(ref, tokenSourceFile):
+		path := strings.TrimPrefix(ref, tokenSourceFile)
+		if path == "" {
+			return nil, fmt.Errorf("token source %q names no file", ref)
+		}
+		return FileTokenRefresher(path), nil
+	case strings.HasPrefix(ref, tokenSourceVault):
+		return vaultTokenSource(strings.TrimPrefix(ref, tokenSourceVault), vault)
+	case strings.HasPrefix(ref, tokenSourceCommand):
+		args := strings.Fields(strings.TrimPrefix(ref, tokenSourceCommand))
+		if len(args) == 0 {
+			return nil, fmt.Errorf("token source %q names no command", ref)
+		}
+		return commandTokenSource(args), nil
+	default:
+		return nil, fmt.Errorf("token source %q must start with %s, %s or %s",
+			ref, tokenSourceFile, tokenSourceVault, tokenSourceCommand)
+	}
+}
+
+// vaultTokenSource reads the token from a field of a Vault KV secret, e.g.
+// secret/data/naysayer#gitlab_token for a KV v2 engine mounted at secret/
+func vaultTokenSource(ref string, vault config.VaultConfig) (TokenRefresher, error) {
+	path, field, _ := strings.Cut(ref, "#")
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return nil, fmt.Errorf("vault token source %q names no secret path", ref)
+	}
+	if field == "" {
+		field = defaultVaultField
+	}
+	if vault.Addr == "" {
+		return nil, fmt.Errorf("vault token source %q requires VAULT_ADDR", ref)
+	}
+	if vault.Token == "" && vault.TokenFile == "" {
+		return nil, fmt.Errorf("vault token source %q requires VAULT_TOKEN or VAULT_TOKEN_FILE", ref)
+	}
+
+	secretURL := strings.TrimRight(vault.Addr, "/") + "/v1/" + path
+	client := &http.Client{Timeout: tokenSourceTimeout}
+	return func() (string, error) {
+		vaultToken := vault.Token
+		if vault.TokenFile != "" {
+			var err error
+			if vaultToken, err = ReadTokenFile(vault.TokenFile); err != nil {
+				return "", err
+			}
+		}
+
+		req, err := http.NewRequest("GET", secretURL, nil)
+		if err != nil {
+			return "", fmt.Errorf("failed to create vault request: %w", err)
+		}
+		req.Header.Set("X-Vault-Token", vaultToken)
+		if vault.Namespace != "" {
+			req.Header.Set("X-Vault-Namespace", vault.Namespace)
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return "", fmt.Errorf("failed to read vault secret %s: %w", path, err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		if resp.StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+			return "", fmt.Errorf("vault secret %s request failed with status %d: %s", path, resp.StatusCode, strings.TrimSpace(string(body)))
+		}
+
+		var secret struct {
+			Data map[string]interface{} `json:"data"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&secret); err != nil {
+			return "", fmt.Errorf("failed to decode vault secret %s: %w", path, err)
+		}
+		// KV v2 nests the secret under data.data, KV v1 returns it as data
+		fields := secret.Data
+		if nested, ok := secret.Data["data"].(map[string]interface{}); ok {
+			fields = nested
+		}
+		token, _ := fields[field].(string)
+		if token = strings.TrimSpace(token); token == "" {
+			return "", fmt.Errorf("vault secret %s has no field %q", path, field)
+		}
+		return token, nil
+	}, nil
+}
+
+// commandTokenSource runs a secret manager CLI, e.g. "gcloud secrets versions access latest
+// --secret=naysayer-gitlab-token", and uses its trimmed standard output as the token
+func commandTokenSource(args []string) TokenRefresher {
+	return func() (string, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), tokenSourceTimeout)
+		defer cancel()
+
+		var stdout, stderr bytes.Buffer
+		cmd := exec.CommandContext(ctx, args[0], args[1:]...) // #nosec G204 - command comes from operator configuration
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			return "", fmt.Errorf("token command %s failed: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+		}
+		token := strings.TrimSpace(stdout.String())
+		if token == "" {
+			return "", fmt.Errorf("token command %s printed no token", args[0])
+		}
+		return token, nil
+	}
+}
@@ -0,0 +1,117 @@
+package gitlab
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+)
+
+func TestNewTokenSource_RejectsInvalidReferences(t *testing.T) {
+	vault := config.VaultConfig{Addr: "https://vault.example.com", Token: "vault-token"}
+	tests := []struct {
+		name  string
+		ref   string
+		vault config.VaultConfig
+	}{
+		{"unknown scheme", "aws:naysayer", vault},
+		{"empty file", "file:", vault},
+		{"empty command", "command:  ", vault},
+		{"empty vault path", "vault:#token", vault},
+		{"missing vault address", "vault:secret/data/naysayer", config.VaultConfig{Token: "vault-token"}},
+		{"missing vault token", "vault:secret/data/naysayer", config.VaultConfig{Addr: "https://vault.example.com"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewTokenSource(tt.ref, tt.vault)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestNewTokenSource_File(t *testing.T) {
+	tokenPath := filepath.Join(t.TempDir(), "token")
+	assert.NoError(t, os.WriteFile(tokenPath, []byte("file-token\n"), 0600))
+
+	source, err := NewTokenSource("file:"+tokenPath, config.VaultConfig{})
+	assert.NoError(t, err)
+	token, err := source()
+	assert.NoError(t, err)
+	assert.Equal(t, "file-token", token)
+}
+
+func TestNewTokenSource_Vault(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "vault-token" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		assert.Equal(t, "data-team", r.Header.Get("X-Vault-Namespace"))
+		switch r.URL.Path {
+		case "/v1/secret/data/naysayer":
+			_, _ = w.Write([]byte(`{"data":{"data":{"gitlab_token":"kv2-token"},"metadata":{"version":3}}}`))
+		case "/v1/kv/naysayer":
+			_, _ = w.Write([]byte(`{"data":{"token":"kv1-token"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	vault := config.VaultConfig{Addr: server.URL + "/", Token: "vault-token", Namespace: "data-team"}
+	fetch := func(ref string, vault config.VaultConfig) (string, error) {
+		source, err := NewTokenSource(ref, vault)
+		assert.NoError(t, err)
+		return source()
+	}
+
+	token, err := fetch("vault:secret/data/naysayer#gitlab_token", vault)
+	assert.NoError(t, err)
+	assert.Equal(t, "kv2-token", token)
+
+	token, err = fetch("vault:/kv/naysayer", vault)
+	assert.NoError(t, err)
+	assert.Equal(t, "kv1-token", token, "KV v1 secrets and the default field are supported")
+
+	_, err = fetch("vault:secret/data/naysayer#missing", vault)
+	assert.Error(t, err)
+	_, err = fetch("vault:secret/data/other", vault)
+	assert.Error(t, err)
+
+	// The Vault token is re-read from its file on every fetch
+	vaultTokenPath := filepath.Join(t.TempDir(), "vault-token")
+	assert.NoError(t, os.WriteFile(vaultTokenPath, []byte("stale-vault-token"), 0600))
+	fileVault := config.VaultConfig{Addr: server.URL, TokenFile: vaultTokenPath, Namespace: "data-team"}
+	source, err := NewTokenSource("vault:secret/data/naysayer#gitlab_token", fileVault)
+	assert.NoError(t, err)
+	_, err = source()
+	assert.Error(t, err)
+	assert.NoError(t, os.WriteFile(vaultTokenPath, []byte("vault-token"), 0600))
+	token, err = source()
+	assert.NoError(t, err)
+	assert.Equal(t, "kv2-token", token)
+}
+
+func TestNewTokenSource_Command(t *testing.T) {
+	source, err := NewTokenSource("command:echo command-token", config.VaultConfig{})
+	assert.NoError(t, err)
+	token, err := source()
+	assert.NoError(t, err)
+	assert.Equal(t, "command-token", token)
+
+	source, err = NewTokenSource("command:false", config.VaultConfig{})
+	assert.NoError(t, err)
+	_, err = source()
+	assert.Error(t, err)
+
+	source, err = NewTokenSource("command:true", config.VaultConfig{})
+	assert.NoError(t, err)
+	_, err = source()
+	assert.Error(t, err, "empty output is not a token")
+}
@@ -0,0 +1,178 @@
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// tokenServer accepts only requests authenticated with the valid token
+func tokenServer(valid string, bodies *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+valid {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if bodies != nil {
+			body, _ := io.ReadAll(r.Body)
+			*bodies = append(*bodies, string(body))
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+}
+
+func TestClient_RefreshesTokenOnceOn401(t *testing.T) {
+	ctx := context.Background()
+	var bodies []string
+	server := tokenServer("fresh-token", &bodies)
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "expired-token"})
+	refreshes := 0
+	client.SetTokenRefresher(func() (string, error) {
+		refreshes++
+		return "fresh-token", nil
+	})
+
+	err := client.AddMRComment(ctx, 1, 2, "hello")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, refreshes)
+	assert.Equal(t, "fresh-token", client.currentToken())
+	assert.Len(t, bodies, 1)
+	assert.Contains(t, bodies[0], "hello")
+
+	// Subsequent calls use the refreshed token without refreshing again
+	assert.NoError(t, client.AddMRComment(ctx, 1, 2, "again"))
+	assert.Equal(t, 1, refreshes)
+}
+
+func TestClient_DoesNotRetryWhenRefreshFails(t *testing.T) {
+	ctx := context.Background()
+	server := tokenServer("fresh-token", nil)
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "expired-token"})
+	client.SetTokenRefresher(func() (string, error) {
+		return "", errors.New("exchange failed")
+	})
+
+	err := client.AddMRComment(ctx, 1, 2, "hello")
+	assert.ErrorIs(t, err, ErrPermission)
+}
+
+func TestClient_RetriesOnlyOnce(t *testing.T) {
+	ctx := context.Background()
+	server := tokenServer("never-valid", nil)
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "expired-token"})
+	refreshes := 0
+	client.SetTokenRefresher(func() (string, error) {
+		refreshes++
+		return "still-wrong", nil
+	})
+
+	err := client.AddMRComment(ctx, 1, 2, "hello")
+	assert.ErrorIs(t, err, ErrPermission)
+	assert.Equal(t, 1, refreshes)
+}
+
+func TestClient_TokenFile(t *testing.T) {
+	ctx := context.Background()
+	tokenPath := filepath.Join(t.TempDir(), "token")
+	assert.NoError(t, os.WriteFile(tokenPath, []byte("initial-token\n"), 0600))
+
+	server := tokenServer("rotated-token", nil)
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, TokenFile: tokenPath})
+	assert.Equal(t, "initial-token", client.currentToken())
+
+	// The secret is rotated on disk; the 401 triggers a re-read
+	assert.NoError(t, os.WriteFile(tokenPath, []byte("rotated-token"), 0600))
+	assert.NoError(t, client.AddMRComment(ctx, 1, 2, "hello"))
+	assert.Equal(t, "rotated-token", client.currentToken())
+}
+
+func TestReadTokenFile(t *testing.T) {
+	dir := t.TempDir()
+	empty := filepath.Join(dir, "empty")
+	assert.NoError(t, os.WriteFile(empty, []byte("  \n"), 0600))
+
+	_, err := ReadTokenFile(empty)
+	assert.Error(t, err)
+	_, err = ReadTokenFile(filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+}
+
+func TestClient_ReloadsRotatedToken(t *testing.T) {
+	tokenPath := filepath.Join(t.TempDir(), "token")
+	assert.NoError(t, os.WriteFile(tokenPath, []byte("initial-token"), 0600))
+
+	client := NewClient(config.GitLabConfig{BaseURL: "https://gitlab.example.com", TokenFile: tokenPath, TokenReloadSeconds: 60})
+	assert.NoError(t, os.WriteFile(tokenPath, []byte("rotated-token"), 0600))
+	assert.Equal(t, "initial-token", client.currentToken(), "the file is not re-read before the reload interval")
+
+	reloads := TokenReloads()
+	client.auth.loadedAt = time.Now().Add(-time.Minute)
+	assert.Equal(t, "rotated-token", client.currentToken())
+	assert.Equal(t, reloads+1, TokenReloads())
+
+	// A failing reload keeps the current token
+	assert.NoError(t, os.Remove(tokenPath))
+	client.auth.loadedAt = time.Now().Add(-time.Minute)
+	assert.Equal(t, "rotated-token", client.currentToken())
+	assert.Equal(t, reloads+1, TokenReloads())
+
+	// Without a reload interval the file is only re-read after 401
+	assert.NoError(t, os.WriteFile(tokenPath, []byte("initial-token"), 0600))
+	client = NewClient(config.GitLabConfig{BaseURL: "https://gitlab.example.com", TokenFile: tokenPath})
+	assert.NoError(t, os.WriteFile(tokenPath, []byte("rotated-token"), 0600))
+	client.auth.loadedAt = time.Now().Add(-time.Hour)
+	assert.Equal(t, "initial-token", client.currentToken())
+}
+
+func TestClient_TokenSource(t *testing.T) {
+	client := NewClient(config.GitLabConfig{BaseURL: "https://gitlab.example.com", TokenSource: "command:echo source-token"})
+	assert.Equal(t, "source-token", client.currentToken())
+}
+
+func TestClient_TokenInfo(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Header.Get("Authorization") {
+		case "Bearer pat":
+			assert.Equal(t, "/api/v4/personal_access_tokens/self", r.URL.Path)
+			_, _ = w.Write([]byte(`{"name":"naysayer","active":true,"revoked":false,"expires_at":"2026-12-01","scopes":["api"]}`))
+		case "Bearer old-gitlab":
+			if r.URL.Path == "/api/v4/user" {
+				_, _ = w.Write([]byte(`{"id":7,"username":"naysayer-bot"}`))
+				return
+			}
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+
+	info, err := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "pat"}).TokenInfo(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, &TokenInfo{Name: "naysayer", Active: true, ExpiresAt: "2026-12-01", Scopes: []string{"api"}}, info)
+
+	info, err = NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "old-gitlab"}).TokenInfo(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, &TokenInfo{Name: "naysayer-bot", Active: true}, info)
+
+	_, err = NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "revoked"}).TokenInfo(ctx)
+	assert.ErrorIs(t, err, ErrPermission)
+}
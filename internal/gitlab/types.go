@@ -1,5 +1,7 @@
 package gitlab
 
+import "time"
+
 // MRChanges represents the structure of GitLab MR changes API response
 type MRChanges struct {
 	Changes []struct {
@@ -31,10 +33,16 @@ type MRInfo struct {
 	ProjectID    int
 	MRIID        int
 	Title        string
+	Description  string
 	Author       string
 	SourceBranch string
 	TargetBranch string
 	State        string
+	CreatedAt    string    // MR creation timestamp from the webhook payload
+	Draft        bool      // MR is marked as draft
+	HeadSHA      string    // Latest commit of the source branch
+	OldRev       string    // Previous head of the source branch when the event reports new commits
+	ReceivedAt   time.Time // When the webhook was received, for decision latency
 }
 
 // PipelineJob represents a GitLab CI job
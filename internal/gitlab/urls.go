@@ -0,0 +1,93 @@
+package gitlab
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+)
+
+// DefaultAPIVersion is the REST API version used unless GITLAB_API_VERSION is set
+const DefaultAPIVersion = "v4"
+
+var (
+	apiVersionPattern = regexp.MustCompile(`^v[0-9]+$`)
+	apiPathSuffix     = regexp.MustCompile(`/api/v[0-9]+$`)
+)
+
+// NormalizeBaseURL validates a GitLab base URL and returns it without a trailing slash.
+// Instances hosted under a sub-path (https://example.com/gitlab) keep the sub-path, and a
+// trailing API path copied from the API docs (https://gitlab.com/api/v4) is removed.
+func NormalizeBaseURL(raw string) (string, error) {
+	trimmed := strings.TrimSpace(raw)
+	if trimmed == "" {
+		return "", fmt.Errorf("GitLab base URL is empty")
+	}
+	u, err := url.Parse(trimmed)
+	if err != nil {
+		return "", fmt.Errorf("GitLab base URL %q is invalid: %w", raw, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("GitLab base URL %q must start with http:// or https://", raw)
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("GitLab base URL %q has no host", raw)
+	}
+	if u.User != nil {
+		return "", fmt.Errorf("GitLab base URL %q must not contain credentials, use GITLAB_TOKEN", u.Redacted())
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf("GitLab base URL %q must not contain a query or fragment", raw)
+	}
+
+	path := strings.TrimRight(u.Path, "/")
+	path = apiPathSuffix.ReplaceAllString(path, "")
+	return u.Scheme + "://" + u.Host + path, nil
+}
+
+// normalizeAPIVersion returns the configured API version, or DefaultAPIVersion when unset
+func normalizeAPIVersion(version string) (string, error) {
+	version = strings.Trim(strings.TrimSpace(version), "/")
+	if version == "" {
+		return DefaultAPIVersion, nil
+	}
+	if !apiVersionPattern.MatchString(version) {
+		return "", fmt.Errorf("GitLab API version %q must look like %q", version, DefaultAPIVersion)
+	}
+	return version, nil
+}
+
+// ValidateConfig reports a base URL, API version or token source that would make every
+// GitLab API call fail, so a typo is caught at startup instead of as a 404 during evaluation
+func ValidateConfig(cfg config.GitLabConfig) error {
+	if _, err := NormalizeBaseURL(cfg.BaseURL); err != nil {
+		return err
+	}
+	if _, err := normalizeAPIVersion(cfg.APIVersion); err != nil {
+		return err
+	}
+	_, err := tokenRefresher(cfg)
+	return err
+}
+
+// apiRoot returns the API root of cfg, e.g. https://example.com/gitlab/api/v4. Invalid
+// values are used as given; ValidateConfig rejects them at startup.
+func apiRoot(cfg config.GitLabConfig) string {
+	base, err := NormalizeBaseURL(cfg.BaseURL)
+	if err != nil {
+		base = strings.TrimRight(cfg.BaseURL, "/")
+	}
+	version, err := normalizeAPIVersion(cfg.APIVersion)
+	if err != nil {
+		version = DefaultAPIVersion
+	}
+	return base + "/api/" + version
+}
+
+// apiURL returns the URL of an API path such as "/projects/%d", formatted with args.
+// Path segments and query values must already be escaped.
+func (c *Client) apiURL(format string, args ...interface{}) string {
+	return c.apiRoot + fmt.Sprintf(format, args...)
+}
@@ -0,0 +1,97 @@
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// WikiPage is a project wiki page
+type WikiPage struct {
+	Slug    string `json:"slug"`
+	Title   string `json:"title"`
+	Content string `json:"content"`
+	Format  string `json:"format"`
+}
+
+// GetWikiPage returns a wiki page; a missing page returns an error matching ErrNotFound.
+// GET /projects/:id/wikis/:slug
+func (c *Client) GetWikiPage(ctx context.Context, projectID int, slug string) (*WikiPage, error) {
+	apiURL := c.apiURL("/projects/%d/wikis/%s", projectID, url.PathEscape(slug))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create wiki page request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wiki page: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, newAPIError(resp, "get wiki page failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var page WikiPage
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return nil, fmt.Errorf("failed to decode wiki page response: %w", err)
+	}
+	return &page, nil
+}
+
+// PublishWikiPage creates or overwrites the Markdown wiki page at slug. GitLab derives slugs
+// from titles, so the slug is sent as the title to keep the page in place; the wiki shows the
+// last slug segment as the page title.
+// PUT /projects/:id/wikis/:slug, falling back to POST /projects/:id/wikis for new pages
+func (c *Client) PublishWikiPage(ctx context.Context, projectID int, slug, content string) (*WikiPage, error) {
+	baseURL := c.apiURL("/projects/%d/wikis", projectID)
+	page := WikiPage{Title: slug, Content: content, Format: "markdown"}
+
+	updated, err := c.sendWikiPage(ctx, "PUT", baseURL+"/"+url.PathEscape(slug), http.StatusOK, page)
+	if !errors.Is(err, ErrNotFound) {
+		return updated, err
+	}
+	return c.sendWikiPage(ctx, "POST", baseURL, http.StatusCreated, page)
+}
+
+// sendWikiPage writes a wiki page and decodes the response
+func (c *Client) sendWikiPage(ctx context.Context, method, apiURL string, expectedStatus int, page WikiPage) (*WikiPage, error) {
+	jsonPayload, err := json.Marshal(page)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal wiki page: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, apiURL, bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create wiki page request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write wiki page: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != expectedStatus {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, newAPIError(resp, "write wiki page failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var written WikiPage
+	if err := json.NewDecoder(resp.Body).Decode(&written); err != nil {
+		return nil, fmt.Errorf("failed to decode wiki page response: %w", err)
+	}
+	return &written, nil
+}
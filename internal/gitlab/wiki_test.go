@@ -0,0 +1,88 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_PublishWikiPage_UpdatesExistingPage(t *testing.T) {
+	var payload WikiPage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		assert.Equal(t, "/api/v4/projects/42/wikis/governance%2F2026-09", r.URL.EscapedPath())
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"slug": "governance/2026-09", "title": "2026-09", "format": "markdown"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+	page, err := client.PublishWikiPage(context.Background(), 42, "governance/2026-09", "# Report")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "governance/2026-09", page.Slug)
+	assert.Equal(t, "governance/2026-09", payload.Title)
+	assert.Equal(t, "# Report", payload.Content)
+	assert.Equal(t, "markdown", payload.Format)
+}
+
+func TestClient_PublishWikiPage_CreatesMissingPage(t *testing.T) {
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		switch r.Method {
+		case "PUT":
+			w.WriteHeader(http.StatusNotFound)
+		case "POST":
+			assert.Equal(t, "/api/v4/projects/42/wikis", r.URL.Path)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"slug": "governance/2026-09", "title": "2026-09"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+	page, err := client.PublishWikiPage(context.Background(), 42, "governance/2026-09", "# Report")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "governance/2026-09", page.Slug)
+	assert.Equal(t, []string{"PUT", "POST"}, methods)
+}
+
+func TestClient_PublishWikiPage_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+	_, err := client.PublishWikiPage(context.Background(), 42, "governance/2026-09", "# Report")
+
+	assert.True(t, errors.Is(err, ErrPermission))
+}
+
+func TestClient_GetWikiPage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() == "/api/v4/projects/42/wikis/governance%2F2026-09" {
+			_, _ = w.Write([]byte(`{"slug": "governance/2026-09", "title": "2026-09", "content": "# Report"}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+	page, err := client.GetWikiPage(context.Background(), 42, "governance/2026-09")
+	assert.NoError(t, err)
+	assert.Equal(t, "# Report", page.Content)
+
+	_, err = client.GetWikiPage(context.Background(), 42, "governance/2026-10")
+	assert.True(t, errors.Is(err, ErrNotFound))
+}
@@ -0,0 +1,124 @@
+package governance
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/stats"
+	"go.uber.org/zap"
+)
+
+// Job publishes the report of the previous month once it has ended
+type Job struct {
+	generator *Generator
+	publisher *Publisher
+	cfg       config.GovernanceConfig
+	now       func() time.Time
+
+	mu        sync.Mutex
+	published string // Last month published by this process
+	stop      chan struct{}
+	wg        sync.WaitGroup
+}
+
+// NewJob creates a monthly publishing job
+func NewJob(generator *Generator, publisher *Publisher, cfg config.GovernanceConfig) *Job {
+	return &Job{generator: generator, publisher: publisher, cfg: cfg, now: time.Now}
+}
+
+// NewJobFromConfig returns a monthly publishing job, or nil when reports are not configured
+func NewJobFromConfig(cfg *config.Config, recorder *stats.Recorder) *Job {
+	if !cfg.Governance.Enabled() {
+		return nil
+	}
+	client := gitlab.NewClientWithConfig(cfg)
+	return NewJob(NewGenerator(client, recorder), NewPublisher(client, cfg.Governance), cfg.Governance)
+}
+
+// Check publishes the previous month's report unless it is already published. Published
+// reports are not overwritten after a restart, which resets the in-memory activity counts.
+func (j *Job) Check(ctx context.Context) {
+	month := MonthStart(j.now()).AddDate(0, -1, 0)
+	name := month.Format("2006-01")
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.published == name {
+		return
+	}
+
+	published, err := j.publisher.Published(ctx, name)
+	if err != nil {
+		logging.Warn("Failed to check governance report", zap.String("report", name), zap.Error(err))
+		return
+	}
+	if published {
+		j.published = name
+		return
+	}
+
+	report, err := j.generator.Generate(ctx, j.cfg.ProjectID, j.cfg.Ref, month)
+	if err != nil {
+		logging.Error("Failed to generate governance report", zap.String("report", name), zap.Error(err))
+		return
+	}
+	if err := j.publisher.Publish(ctx, report); err != nil {
+		logging.Error("Failed to publish governance report", zap.String("report", name), zap.Error(err))
+		return
+	}
+	j.published = name
+	logging.Info("Published governance report",
+		zap.String("report", name),
+		zap.Int("unmasked_grants_added", len(report.UnmaskedGrantsAdded)),
+		zap.Int("warehouse_growth", report.WarehouseGrowth),
+		zap.Float64("auto_approval_rate_percent", report.Activity.AutoApprovalRate*100))
+}
+
+// Start checks now and then every interval until Stop is called
+func (j *Job) Start(interval time.Duration) {
+	j.mu.Lock()
+	if j.stop != nil {
+		j.mu.Unlock()
+		return
+	}
+	j.stop = make(chan struct{})
+	stop := j.stop
+	j.mu.Unlock()
+
+	j.wg.Add(1)
+	go func() {
+		defer j.wg.Done()
+		ctx := context.Background()
+		j.Check(ctx)
+		if interval <= 0 {
+			return
+		}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				j.Check(ctx)
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop ends periodic checks and waits for a running check to finish
+func (j *Job) Stop() {
+	j.mu.Lock()
+	stop := j.stop
+	j.stop = nil
+	j.mu.Unlock()
+
+	if stop != nil {
+		close(stop)
+		j.wg.Wait()
+	}
+}
@@ -0,0 +1,33 @@
+package governance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+)
+
+func TestJob_Check_PublishesPreviousMonthOnce(t *testing.T) {
+	ctx := context.Background()
+	repo := newMockRepository()
+	cfg := config.GovernanceConfig{ProjectID: 42, Ref: "main", PublishProjectID: 42, WikiPage: "governance"}
+	job := NewJob(NewGenerator(repo, nil), NewPublisher(repo, cfg), cfg)
+	job.now = func() time.Time { return time.Date(2026, 10, 1, 3, 0, 0, 0, time.UTC) }
+
+	job.Check(ctx)
+	assert.Contains(t, repo.wikiPages["governance/2026-09"], "| UNMASKED grants added | 2 |")
+
+	// Already published: a restarted job leaves the page alone
+	repo.wikiPages["governance/2026-09"] = "edited"
+	restarted := NewJob(NewGenerator(repo, nil), NewPublisher(repo, cfg), cfg)
+	restarted.now = job.now
+	restarted.Check(ctx)
+	assert.Equal(t, "edited", repo.wikiPages["governance/2026-09"])
+}
+
+func TestNewJobFromConfig_Disabled(t *testing.T) {
+	assert.Nil(t, NewJobFromConfig(&config.Config{}, nil))
+}
@@ -0,0 +1,79 @@
+package governance
+
+import (
+	"fmt"
+	"strings"
+)
+
+// shortSHA abbreviates a commit SHA for display
+func shortSHA(sha string) string {
+	if len(sha) > 8 {
+		return sha[:8]
+	}
+	return sha
+}
+
+// cell escapes a value for a Markdown table cell
+func cell(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return strings.ReplaceAll(value, "|", `\|`)
+}
+
+// Markdown renders the report as a wiki page
+func (r *Report) Markdown() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Governance report %s\n\n", r.Month)
+	fmt.Fprintf(&b, "Generated by naysayer on %s for project %d, branch `%s`", r.GeneratedAt.Format("2006-01-02 15:04 MST"), r.ProjectID, r.Ref)
+	if r.FromCommit != "" && r.ToCommit != "" {
+		fmt.Fprintf(&b, " (`%s..%s`)", shortSHA(r.FromCommit), shortSHA(r.ToCommit))
+	}
+	b.WriteString(".\n\n")
+
+	b.WriteString("## Summary\n\n")
+	b.WriteString("| Metric | Value |\n|---|---|\n")
+	fmt.Fprintf(&b, "| MRs decided | %d |\n", r.Activity.MRsDecided)
+	fmt.Fprintf(&b, "| Auto-approval rate | %.1f%% |\n", r.Activity.AutoApprovalRate*100)
+	fmt.Fprintf(&b, "| Approvals | %d |\n", r.Activity.Approvals)
+	fmt.Fprintf(&b, "| Manual reviews | %d |\n", r.Activity.ManualReviews)
+	fmt.Fprintf(&b, "| Stale MRs closed | %d |\n", r.Activity.StaleComments)
+	fmt.Fprintf(&b, "| UNMASKED grants added | %d |\n", len(r.UnmaskedGrantsAdded))
+	fmt.Fprintf(&b, "| UNMASKED grants removed | %d |\n", r.UnmaskedGrantsRemoved)
+	fmt.Fprintf(&b, "| Warehouse growth (size steps) | %+d |\n\n", r.WarehouseGrowth)
+
+	if len(r.Projects) > 0 {
+		b.WriteString("## Activity by project\n\n")
+		b.WriteString("| Project | MRs decided | Auto-approval rate | Approvals | Manual reviews | Stale MRs closed |\n|---|---|---|---|---|---|\n")
+		for _, p := range r.Projects {
+			fmt.Fprintf(&b, "| %d | %d | %.1f%% | %d | %d | %d |\n",
+				p.ProjectID, p.MRsDecided, p.AutoApprovalRate*100, p.Approvals, p.ManualReviews, p.StaleComments)
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("## UNMASKED grants added\n\n")
+	if len(r.UnmaskedGrantsAdded) == 0 {
+		b.WriteString("None.\n\n")
+	} else {
+		b.WriteString("| Data product | Environment | Consumer | Kind | Data type | Policy | File |\n|---|---|---|---|---|---|---|\n")
+		for _, g := range r.UnmaskedGrantsAdded {
+			fmt.Fprintf(&b, "| %s | %s | %s | %s | %s | %s | `%s` |\n",
+				cell(g.DataProduct), cell(g.Environment), cell(g.Consumer), cell(g.ConsumerKind), cell(g.DataType), cell(g.Policy), g.FilePath)
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("## Warehouse changes\n\n")
+	if len(r.WarehouseChanges) == 0 {
+		b.WriteString("None.\n")
+	} else {
+		b.WriteString("| Warehouse | From | To |\n|---|---|---|\n")
+		for _, c := range r.WarehouseChanges {
+			fmt.Fprintf(&b, "| %s | %s | %s |\n", cell(c.FilePath), cell(c.FromSize), cell(c.ToSize))
+		}
+	}
+
+	return b.String()
+}
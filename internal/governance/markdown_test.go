@@ -0,0 +1,49 @@
+package governance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/accessreview"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/warehouse"
+	"github.com/redhat-data-and-ai/naysayer/internal/stats"
+)
+
+func TestReport_Markdown(t *testing.T) {
+	report := &Report{
+		Month:       "2026-09",
+		ProjectID:   42,
+		Ref:         "main",
+		FromCommit:  "0123456789abcdef",
+		ToCommit:    "fedcba9876543210",
+		GeneratedAt: time.Date(2026, 10, 1, 1, 0, 0, 0, time.UTC),
+		Activity:    stats.ProjectSummary{MRsDecided: 4, AutoApprovalRate: 0.75, Approvals: 3, ManualReviews: 1, StaleComments: 2},
+		Projects:    []stats.ProjectSummary{{ProjectID: 42, MRsDecided: 4, AutoApprovalRate: 0.75, Approvals: 3, ManualReviews: 1}},
+		UnmaskedGrantsAdded: []accessreview.Grant{
+			{DataProduct: "analytics", Environment: "prod", ConsumerKind: "consumer_group", Consumer: "marketing", DataType: "string", Policy: "analytics_pii", FilePath: policyPath},
+		},
+		UnmaskedGrantsRemoved: 1,
+		WarehouseChanges:      []warehouse.WarehouseChange{{FilePath: "product.yaml (type: user)", FromSize: "XSMALL", ToSize: "MEDIUM"}},
+		WarehouseGrowth:       2,
+	}
+
+	markdown := report.Markdown()
+	assert.Contains(t, markdown, "# Governance report 2026-09")
+	assert.Contains(t, markdown, "(`01234567..fedcba98`)")
+	assert.Contains(t, markdown, "| Auto-approval rate | 75.0% |")
+	assert.Contains(t, markdown, "| Stale MRs closed | 2 |")
+	assert.Contains(t, markdown, "| UNMASKED grants added | 1 |")
+	assert.Contains(t, markdown, "| Warehouse growth (size steps) | +2 |")
+	assert.Contains(t, markdown, "| 42 | 4 | 75.0% | 3 | 1 | 0 |")
+	assert.Contains(t, markdown, "| analytics | prod | marketing | consumer_group | string | analytics_pii | `"+policyPath+"` |")
+	assert.Contains(t, markdown, "| product.yaml (type: user) | XSMALL | MEDIUM |")
+}
+
+func TestReport_Markdown_Empty(t *testing.T) {
+	markdown := (&Report{Month: "2026-09", Ref: "main"}).Markdown()
+	assert.Contains(t, markdown, "## UNMASKED grants added\n\nNone.")
+	assert.Contains(t, markdown, "## Warehouse changes\n\nNone.")
+	assert.NotContains(t, markdown, "## Activity by project")
+}
@@ -0,0 +1,83 @@
+package governance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+)
+
+// SnippetFile is the snippet file overwritten with the latest report
+const SnippetFile = "governance-report.md"
+
+// PublishClient is the GitLab access needed to publish reports
+type PublishClient interface {
+	GetWikiPage(ctx context.Context, projectID int, slug string) (*gitlab.WikiPage, error)
+	PublishWikiPage(ctx context.Context, projectID int, slug, content string) (*gitlab.WikiPage, error)
+	GetProjectSnippet(ctx context.Context, projectID, snippetID int) (*gitlab.Snippet, error)
+	UpdateProjectSnippet(ctx context.Context, projectID, snippetID int, title, filePath, content string) error
+}
+
+// Publisher writes reports to a wiki page per month and/or a snippet holding the latest report
+type Publisher struct {
+	client PublishClient
+	cfg    config.GovernanceConfig
+}
+
+// NewPublisher creates a publisher for the configured wiki page prefix and snippet
+func NewPublisher(client PublishClient, cfg config.GovernanceConfig) *Publisher {
+	return &Publisher{client: client, cfg: cfg}
+}
+
+// wikiSlug returns the wiki page of a month
+func (p *Publisher) wikiSlug(month string) string {
+	return strings.Trim(p.cfg.WikiPage, "/") + "/" + month
+}
+
+// snippetTitle returns the snippet title of a month
+func snippetTitle(month string) string {
+	return "Governance report " + month
+}
+
+// Published reports whether the report of month is already on every configured destination
+func (p *Publisher) Published(ctx context.Context, month string) (bool, error) {
+	if p.cfg.WikiPage != "" {
+		_, err := p.client.GetWikiPage(ctx, p.cfg.PublishProjectID, p.wikiSlug(month))
+		if errors.Is(err, gitlab.ErrNotFound) {
+			return false, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed to check wiki page: %w", err)
+		}
+	}
+	if p.cfg.SnippetID > 0 {
+		snippet, err := p.client.GetProjectSnippet(ctx, p.cfg.PublishProjectID, p.cfg.SnippetID)
+		if err != nil {
+			return false, fmt.Errorf("failed to check snippet %d: %w", p.cfg.SnippetID, err)
+		}
+		if snippet.Title != snippetTitle(month) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// Publish writes the report to every configured destination
+func (p *Publisher) Publish(ctx context.Context, report *Report) error {
+	content := report.Markdown()
+	var errs []error
+	if p.cfg.WikiPage != "" {
+		if _, err := p.client.PublishWikiPage(ctx, p.cfg.PublishProjectID, p.wikiSlug(report.Month), content); err != nil {
+			errs = append(errs, fmt.Errorf("failed to publish wiki page: %w", err))
+		}
+	}
+	if p.cfg.SnippetID > 0 {
+		if err := p.client.UpdateProjectSnippet(ctx, p.cfg.PublishProjectID, p.cfg.SnippetID, snippetTitle(report.Month), SnippetFile, content); err != nil {
+			errs = append(errs, fmt.Errorf("failed to publish snippet %d: %w", p.cfg.SnippetID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,44 @@
+package governance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+)
+
+func TestPublisher_Publish(t *testing.T) {
+	repo := newMockRepository()
+	publisher := NewPublisher(repo, config.GovernanceConfig{PublishProjectID: 42, WikiPage: "/governance/", SnippetID: 9})
+
+	published, err := publisher.Published(context.Background(), "2026-09")
+	assert.NoError(t, err)
+	assert.False(t, published)
+
+	err = publisher.Publish(context.Background(), &Report{Month: "2026-09", Ref: "main"})
+	assert.NoError(t, err)
+	assert.Contains(t, repo.wikiPages["governance/2026-09"], "# Governance report 2026-09")
+	assert.Equal(t, "Governance report 2026-09", repo.snippetTitle)
+	assert.Equal(t, repo.wikiPages["governance/2026-09"], repo.snippetBody)
+
+	published, err = publisher.Published(context.Background(), "2026-09")
+	assert.NoError(t, err)
+	assert.True(t, published)
+}
+
+func TestPublisher_Published_RequiresEveryDestination(t *testing.T) {
+	repo := newMockRepository()
+	repo.wikiPages["governance/2026-09"] = "# Governance report 2026-09"
+	publisher := NewPublisher(repo, config.GovernanceConfig{PublishProjectID: 42, WikiPage: "governance", SnippetID: 9})
+
+	published, err := publisher.Published(context.Background(), "2026-09")
+	assert.NoError(t, err)
+	assert.False(t, published, "the snippet still shows an older report")
+
+	wikiOnly := NewPublisher(repo, config.GovernanceConfig{PublishProjectID: 42, WikiPage: "governance"})
+	published, err = wikiOnly.Published(context.Background(), "2026-09")
+	assert.NoError(t, err)
+	assert.True(t, published)
+}
@@ -0,0 +1,220 @@
+package governance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/accessreview"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/masking"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/warehouse"
+	"github.com/redhat-data-and-ai/naysayer/internal/stats"
+	"go.uber.org/zap"
+)
+
+// RepositoryClient is the GitLab access needed to compare the repository between month boundaries
+type RepositoryClient interface {
+	GetCommitBefore(ctx context.Context, projectID int, ref string, until time.Time) (string, error)
+	CompareCommits(ctx context.Context, projectID int, fromSHA, toSHA string) (*gitlab.CompareResult, error)
+	FetchFileContent(ctx context.Context, projectID int, filePath, ref string) (*gitlab.FileContent, error)
+}
+
+// Report is the governance summary of one calendar month (UTC)
+type Report struct {
+	Month       string    `json:"month"` // YYYY-MM
+	From        time.Time `json:"from"`
+	To          time.Time `json:"to"`
+	ProjectID   int       `json:"project_id"`
+	Ref         string    `json:"ref"`
+	FromCommit  string    `json:"from_commit,omitempty"` // Head of Ref when the month started
+	ToCommit    string    `json:"to_commit,omitempty"`   // Head of Ref when the month ended
+	GeneratedAt time.Time `json:"generated_at"`
+
+	// Naysayer activity across all projects; StaleComments counts stale MRs closed
+	Activity stats.ProjectSummary   `json:"activity"`
+	Projects []stats.ProjectSummary `json:"projects"`
+
+	UnmaskedGrantsAdded   []accessreview.Grant        `json:"unmasked_grants_added"`
+	UnmaskedGrantsRemoved int                         `json:"unmasked_grants_removed"`
+	WarehouseChanges      []warehouse.WarehouseChange `json:"warehouse_changes"`
+	// WarehouseGrowth is the net change in warehouse size steps (XSMALL=1 ... X6LARGE=10)
+	WarehouseGrowth int `json:"warehouse_growth"`
+}
+
+// Generator compiles monthly governance reports
+type Generator struct {
+	client   RepositoryClient
+	recorder *stats.Recorder // Optional: naysayer activity
+	now      func() time.Time
+}
+
+// NewGenerator creates a report generator; recorder may be nil to report repository changes only
+func NewGenerator(client RepositoryClient, recorder *stats.Recorder) *Generator {
+	return &Generator{client: client, recorder: recorder, now: time.Now}
+}
+
+// MonthStart returns the first instant of the UTC month containing t
+func MonthStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// Generate reports the month containing month for the repository ref of projectID
+func (g *Generator) Generate(ctx context.Context, projectID int, ref string, month time.Time) (*Report, error) {
+	from := MonthStart(month)
+	to := from.AddDate(0, 1, 0)
+	report := &Report{
+		Month:               from.Format("2006-01"),
+		From:                from,
+		To:                  to,
+		ProjectID:           projectID,
+		Ref:                 ref,
+		GeneratedAt:         g.now().UTC(),
+		Projects:            []stats.ProjectSummary{},
+		UnmaskedGrantsAdded: []accessreview.Grant{},
+		WarehouseChanges:    []warehouse.WarehouseChange{},
+	}
+
+	if g.recorder != nil {
+		activity, err := g.recorder.Summarize(0, from, to)
+		if err != nil {
+			return nil, fmt.Errorf("failed to summarize naysayer activity: %w", err)
+		}
+		report.Activity = activity.Total
+		report.Projects = activity.Projects
+	}
+
+	fromSHA, err := g.client.GetCommitBefore(ctx, projectID, ref, from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the head of %s at %s: %w", ref, from.Format(time.RFC3339), err)
+	}
+	toSHA, err := g.client.GetCommitBefore(ctx, projectID, ref, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the head of %s at %s: %w", ref, to.Format(time.RFC3339), err)
+	}
+	report.FromCommit, report.ToCommit = fromSHA, toSHA
+	if fromSHA == "" || toSHA == "" || fromSHA == toSHA {
+		return report, nil
+	}
+
+	comparison, err := g.client.CompareCommits(ctx, projectID, fromSHA, toSHA)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare %s..%s: %w", fromSHA, toSHA, err)
+	}
+
+	var grantsBefore, grantsAfter []accessreview.Grant
+	for _, change := range comparison.Diffs {
+		switch {
+		case masking.IsMaskingFile(change.OldPath) || masking.IsMaskingFile(change.NewPath):
+			before, after, err := g.versions(ctx, projectID, change, fromSHA, toSHA)
+			if err != nil {
+				return nil, err
+			}
+			grantsBefore = append(grantsBefore, policyGrants(change.OldPath, before)...)
+			grantsAfter = append(grantsAfter, policyGrants(change.NewPath, after)...)
+		case shared.IsDataProductFile(change.OldPath) || shared.IsDataProductFile(change.NewPath):
+			before, after, err := g.versions(ctx, projectID, change, fromSHA, toSHA)
+			if err != nil {
+				return nil, err
+			}
+			changes, err := warehouse.CompareDataProducts(change.NewPath, before, after)
+			if err != nil {
+				logging.Warn("Skipping unparsable dataproduct in governance report",
+					zap.String("path", change.NewPath),
+					zap.Error(err))
+				continue
+			}
+			report.WarehouseChanges = append(report.WarehouseChanges, changes...)
+		}
+	}
+
+	report.UnmaskedGrantsAdded, report.UnmaskedGrantsRemoved = diffGrants(grantsBefore, grantsAfter)
+	sort.Slice(report.WarehouseChanges, func(i, j int) bool {
+		return report.WarehouseChanges[i].FilePath < report.WarehouseChanges[j].FilePath
+	})
+	for _, change := range report.WarehouseChanges {
+		report.WarehouseGrowth += warehouse.WarehouseSizes[change.ToSize] - warehouse.WarehouseSizes[change.FromSize]
+	}
+	return report, nil
+}
+
+// versions fetches a changed file at both month boundaries; missing versions are empty
+func (g *Generator) versions(ctx context.Context, projectID int, change gitlab.FileChange, fromSHA, toSHA string) (string, string, error) {
+	var before, after string
+	var err error
+	if !change.NewFile {
+		if before, err = g.fetch(ctx, projectID, change.OldPath, fromSHA); err != nil {
+			return "", "", err
+		}
+	}
+	if !change.DeletedFile {
+		if after, err = g.fetch(ctx, projectID, change.NewPath, toSHA); err != nil {
+			return "", "", err
+		}
+	}
+	return before, after, nil
+}
+
+// fetch returns the content of a file at a commit, or "" when it does not exist
+func (g *Generator) fetch(ctx context.Context, projectID int, path, sha string) (string, error) {
+	content, err := g.client.FetchFileContent(ctx, projectID, path, sha)
+	if errors.Is(err, gitlab.ErrNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch %s at %s: %w", path, sha, err)
+	}
+	if content == nil {
+		return "", nil
+	}
+	return content.Content, nil
+}
+
+// policyGrants returns the UNMASKED grants of a masking policy file's content
+func policyGrants(path, content string) []accessreview.Grant {
+	if content == "" {
+		return nil
+	}
+	policy, err := masking.ParseMaskingPolicy(content)
+	if err != nil {
+		logging.Warn("Skipping unparsable masking policy in governance report", zap.String("path", path), zap.Error(err))
+		return nil
+	}
+	if !strings.EqualFold(policy.Kind, masking.MaskingPolicyKind) {
+		return nil
+	}
+	return accessreview.UnmaskedGrants(path, policy)
+}
+
+// diffGrants returns the grants added and the number removed. Grants are compared without
+// their file path, so moving a policy file does not count as a new grant.
+func diffGrants(before, after []accessreview.Grant) ([]accessreview.Grant, int) {
+	remaining := make(map[accessreview.Grant]int)
+	for _, grant := range before {
+		grant.FilePath = ""
+		remaining[grant]++
+	}
+
+	added := []accessreview.Grant{}
+	for _, grant := range after {
+		key := grant
+		key.FilePath = ""
+		if remaining[key] > 0 {
+			remaining[key]--
+			continue
+		}
+		added = append(added, grant)
+	}
+
+	removed := 0
+	for _, count := range remaining {
+		removed += count
+	}
+	return added, removed
+}
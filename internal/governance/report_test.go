@@ -0,0 +1,181 @@
+package governance
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/accessreview"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/warehouse"
+	"github.com/redhat-data-and-ai/naysayer/internal/stats"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+)
+
+const (
+	policyPath  = "dataproducts/source/analytics/prod/pii_masking.yaml"
+	productPath = "dataproducts/source/analytics/product.yaml"
+)
+
+func maskingPolicy(consumers ...string) string {
+	policy := "kind: MaskingPolicy\nname: analytics_pii\ndata_product: analytics\ndatatype: string\ncases:\n  - strategy: UNMASKED\n    consumers:\n"
+	for _, consumer := range consumers {
+		policy += fmt.Sprintf("      - kind: consumer_group\n        name: %s\n", consumer)
+	}
+	return policy
+}
+
+// mockRepository serves files per commit and records published reports
+type mockRepository struct {
+	heads map[time.Time]string         // Month boundary -> head commit
+	files map[string]map[string]string // Commit -> path -> content
+	diffs []gitlab.FileChange
+
+	wikiPages    map[string]string
+	snippetTitle string
+	snippetBody  string
+}
+
+func newMockRepository() *mockRepository {
+	return &mockRepository{
+		heads: map[time.Time]string{
+			time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC):  "sha-start",
+			time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC): "sha-end",
+		},
+		files: map[string]map[string]string{
+			"sha-start": {
+				policyPath:  maskingPolicy("analytics-readers", "finance"),
+				productPath: "name: analytics\nwarehouses:\n- type: user\n  size: XSMALL\n",
+			},
+			"sha-end": {
+				policyPath:  maskingPolicy("analytics-readers", "marketing", "sales"),
+				productPath: "name: analytics\nwarehouses:\n- type: user\n  size: MEDIUM\n- type: service_account\n  size: SMALL\n",
+			},
+		},
+		diffs: []gitlab.FileChange{
+			{OldPath: policyPath, NewPath: policyPath},
+			{OldPath: productPath, NewPath: productPath},
+			{OldPath: "README.md", NewPath: "README.md"},
+		},
+		wikiPages: map[string]string{},
+	}
+}
+
+func (m *mockRepository) GetCommitBefore(ctx context.Context, projectID int, ref string, until time.Time) (string, error) {
+	return m.heads[until], nil
+}
+
+func (m *mockRepository) CompareCommits(ctx context.Context, projectID int, fromSHA, toSHA string) (*gitlab.CompareResult, error) {
+	return &gitlab.CompareResult{Diffs: m.diffs}, nil
+}
+
+func (m *mockRepository) FetchFileContent(ctx context.Context, projectID int, filePath, ref string) (*gitlab.FileContent, error) {
+	content, ok := m.files[ref][filePath]
+	if !ok {
+		return nil, &gitlab.APIError{StatusCode: 404, Message: "not found"}
+	}
+	return &gitlab.FileContent{FilePath: filePath, Content: content}, nil
+}
+
+func (m *mockRepository) GetWikiPage(ctx context.Context, projectID int, slug string) (*gitlab.WikiPage, error) {
+	content, ok := m.wikiPages[slug]
+	if !ok {
+		return nil, &gitlab.APIError{StatusCode: 404, Message: "not found"}
+	}
+	return &gitlab.WikiPage{Slug: slug, Content: content}, nil
+}
+
+func (m *mockRepository) PublishWikiPage(ctx context.Context, projectID int, slug, content string) (*gitlab.WikiPage, error) {
+	m.wikiPages[slug] = content
+	return &gitlab.WikiPage{Slug: slug, Content: content}, nil
+}
+
+func (m *mockRepository) GetProjectSnippet(ctx context.Context, projectID, snippetID int) (*gitlab.Snippet, error) {
+	return &gitlab.Snippet{ID: snippetID, Title: m.snippetTitle}, nil
+}
+
+func (m *mockRepository) UpdateProjectSnippet(ctx context.Context, projectID, snippetID int, title, filePath, content string) error {
+	m.snippetTitle, m.snippetBody = title, content
+	return nil
+}
+
+func TestMonthStart(t *testing.T) {
+	assert.Equal(t, time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC), MonthStart(time.Date(2026, 9, 30, 23, 59, 0, 0, time.UTC)))
+}
+
+func TestGenerator_Generate(t *testing.T) {
+	ctx := context.Background()
+	recorder := stats.NewRecorder(store.NewMemoryStore())
+	recorder.RecordDecision(stats.KindApproval, 1, 10, "")
+	recorder.RecordDecision(stats.KindManualReview, 1, 11, "")
+	recorder.Record(stats.KindStale, 2, 12)
+
+	generator := NewGenerator(newMockRepository(), recorder)
+	month := time.Date(2026, 9, 15, 0, 0, 0, 0, time.UTC)
+	report, err := generator.Generate(ctx, 42, "main", month)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2026-09", report.Month)
+	assert.Equal(t, "sha-start", report.FromCommit)
+	assert.Equal(t, "sha-end", report.ToCommit)
+
+	// Recorded now, outside September 2026
+	assert.Equal(t, 0, report.Activity.MRsDecided)
+
+	assert.Len(t, report.UnmaskedGrantsAdded, 2)
+	assert.ElementsMatch(t, []string{"marketing", "sales"}, []string{report.UnmaskedGrantsAdded[0].Consumer, report.UnmaskedGrantsAdded[1].Consumer})
+	assert.Equal(t, 1, report.UnmaskedGrantsRemoved)
+
+	assert.Equal(t, []warehouse.WarehouseChange{
+		{FilePath: productPath + " (type: service_account)", ToSize: "SMALL"},
+		{FilePath: productPath + " (type: user)", FromSize: "XSMALL", ToSize: "MEDIUM"},
+	}, report.WarehouseChanges)
+	assert.Equal(t, 4, report.WarehouseGrowth)
+}
+
+func TestGenerator_Generate_IncludesActivityOfTheMonth(t *testing.T) {
+	ctx := context.Background()
+	recorder := stats.NewRecorder(store.NewMemoryStore())
+	recorder.RecordDecision(stats.KindApproval, 1, 10, "")
+	recorder.RecordDecision(stats.KindManualReview, 1, 11, "")
+	recorder.Record(stats.KindStale, 2, 12)
+
+	report, err := NewGenerator(newMockRepository(), recorder).Generate(ctx, 42, "main", time.Now())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, report.Activity.MRsDecided)
+	assert.Equal(t, 0.5, report.Activity.AutoApprovalRate)
+	assert.Equal(t, 1, report.Activity.StaleComments)
+	assert.Len(t, report.Projects, 2)
+}
+
+func TestGenerator_Generate_NewFilesAndUnchangedRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := newMockRepository()
+	repo.files["sha-start"] = map[string]string{}
+	repo.diffs = []gitlab.FileChange{{NewPath: policyPath, NewFile: true}}
+
+	report, err := NewGenerator(repo, nil).Generate(ctx, 42, "main", time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.Len(t, report.UnmaskedGrantsAdded, 3)
+	assert.Equal(t, 0, report.UnmaskedGrantsRemoved)
+
+	repo.heads[time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)] = "sha-start"
+	report, err = NewGenerator(repo, nil).Generate(ctx, 42, "main", time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.Empty(t, report.UnmaskedGrantsAdded)
+	assert.Empty(t, report.WarehouseChanges)
+}
+
+func TestDiffGrants_IgnoresMovedPolicies(t *testing.T) {
+	grant := accessreview.Grant{DataProduct: "analytics", Consumer: "finance", FilePath: "old/pii_masking.yaml"}
+	moved := grant
+	moved.FilePath = "new/pii_masking.yaml"
+
+	added, removed := diffGrants([]accessreview.Grant{grant}, []accessreview.Grant{moved})
+	assert.Empty(t, added)
+	assert.Equal(t, 0, removed)
+}
@@ -0,0 +1,430 @@
+package history
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	// Database drivers: SQLite (pure Go) by default, Postgres for postgres:// DSNs
+	_ "github.com/jackc/pgx/v5/stdlib"
+	_ "modernc.org/sqlite"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/audit"
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+)
+
+// Database dialects
+const (
+	DialectSQLite   = "sqlite"
+	DialectPostgres = "postgres"
+)
+
+// ErrNotFound is returned when a decision does not exist
+var ErrNotFound = errors.New("decision not found")
+
+// DefaultLimit and MaxLimit bound the rows returned by a query
+const (
+	DefaultLimit = 50
+	MaxLimit     = 500
+)
+
+// schema is valid for both SQLite and Postgres. Timestamps are unix nanoseconds so they
+// sort the same in both.
+var schema = []string{
+	`CREATE TABLE IF NOT EXISTS decisions (
+		id TEXT PRIMARY KEY,
+		created_at BIGINT NOT NULL,
+		project_id INTEGER NOT NULL,
+		mr_iid INTEGER NOT NULL,
+		title TEXT NOT NULL DEFAULT '',
+		author TEXT NOT NULL DEFAULT '',
+		source_branch TEXT NOT NULL DEFAULT '',
+		target_branch TEXT NOT NULL DEFAULT '',
+		decision TEXT NOT NULL,
+		reason TEXT NOT NULL DEFAULT '',
+		snapshot_id TEXT NOT NULL DEFAULT '',
+		approved INTEGER NOT NULL DEFAULT 0,
+		outcome TEXT NOT NULL DEFAULT '',
+		error TEXT NOT NULL DEFAULT ''
+	)`,
+	`CREATE INDEX IF NOT EXISTS decisions_mr ON decisions (project_id, mr_iid, created_at)`,
+	`CREATE TABLE IF NOT EXISTS rule_outcomes (
+		decision_id TEXT NOT NULL,
+		position INTEGER NOT NULL,
+		file TEXT NOT NULL,
+		rule TEXT NOT NULL,
+		decision TEXT NOT NULL,
+		reason TEXT NOT NULL DEFAULT '',
+		PRIMARY KEY (decision_id, position)
+	)`,
+	`CREATE TABLE IF NOT EXISTS actions (
+		id TEXT PRIMARY KEY,
+		created_at BIGINT NOT NULL,
+		kind TEXT NOT NULL,
+		project_id INTEGER NOT NULL,
+		mr_iid INTEGER NOT NULL,
+		title TEXT NOT NULL DEFAULT '',
+		author TEXT NOT NULL DEFAULT '',
+		reason TEXT NOT NULL DEFAULT '',
+		outcome TEXT NOT NULL DEFAULT '',
+		error TEXT NOT NULL DEFAULT ''
+	)`,
+	`CREATE INDEX IF NOT EXISTS actions_mr ON actions (project_id, mr_iid, kind, created_at)`,
+}
+
+// Decision is a stored review decision with its rule outcomes
+type Decision struct {
+	ID           string             `json:"id"`
+	CreatedAt    time.Time          `json:"created_at"`
+	ProjectID    int                `json:"project_id"`
+	MRIID        int                `json:"mr_iid"`
+	Title        string             `json:"title,omitempty"`
+	Author       string             `json:"author,omitempty"`
+	SourceBranch string             `json:"source_branch,omitempty"`
+	TargetBranch string             `json:"target_branch,omitempty"`
+	Decision     string             `json:"decision"`
+	Reason       string             `json:"reason,omitempty"`
+	SnapshotID   string             `json:"snapshot_id,omitempty"`
+	Approved     bool               `json:"approved"`
+	Outcome      string             `json:"outcome,omitempty"`
+	Error        string             `json:"error,omitempty"`
+	Rules        []audit.RuleResult `json:"rules,omitempty"` // Only loaded for single decisions
+}
+
+// Action is a stored auto-rebase or stale MR closure
+type Action struct {
+	ID        string    `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	Kind      string    `json:"kind"`
+	ProjectID int       `json:"project_id"`
+	MRIID     int       `json:"mr_iid"`
+	Title     string    `json:"title,omitempty"`
+	Author    string    `json:"author,omitempty"`
+	Reason    string    `json:"reason,omitempty"`
+	Outcome   string    `json:"outcome,omitempty"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// RuleCount counts the outcomes of one rule over stored decisions
+type RuleCount struct {
+	Rule   string `json:"rule"`
+	Passed int    `json:"passed"` // Files the rule approved
+	Warned int    `json:"warned"` // Files the rule approved with a warning
+	Failed int    `json:"failed"` // Files the rule sent to manual review
+}
+
+// Filter selects decisions or actions, newest first. Zero fields do not filter.
+type Filter struct {
+	ProjectID int
+	MRIID     int
+	Kind      string    // Action kind (actions only)
+	Since     time.Time // Only rows created at or after Since
+	Limit     int       // Rows returned (default: DefaultLimit, at most MaxLimit)
+}
+
+// Store persists decisions, rule outcomes and actions in SQLite or Postgres, so history
+// survives redeploys. It records audit events, see audit.Recorder.
+type Store struct {
+	db      *sql.DB
+	dialect string
+}
+
+// Verify that Store records audit events
+var _ audit.Recorder = (*Store)(nil)
+
+// NewStoreFromConfig opens the configured database, or returns nil when the decision
+// history is disabled
+func NewStoreFromConfig(cfg config.HistoryConfig) (*Store, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	return Open(cfg.DSN)
+}
+
+// Open opens the database of dsn and creates missing tables. postgres:// and
+// postgresql:// URLs select Postgres; any other DSN is an SQLite database file.
+func Open(dsn string) (*Store, error) {
+	if dsn == "" {
+		return nil, fmt.Errorf("decision history DSN cannot be empty")
+	}
+	dialect, driver := DialectSQLite, "sqlite"
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		dialect, driver = DialectPostgres, "pgx"
+	}
+
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open decision history: %w", err)
+	}
+	if dialect == DialectSQLite {
+		// SQLite allows a single writer; serializing avoids "database is locked" errors
+		db.SetMaxOpenConns(1)
+	}
+
+	s := &Store{db: db, dialect: dialect}
+	if err := s.migrate(); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// Dialect returns the database dialect, sqlite or postgres
+func (s *Store) Dialect() string {
+	return s.dialect
+}
+
+// Close closes the database
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+func (s *Store) migrate() error {
+	for _, statement := range schema {
+		if _, err := s.db.Exec(statement); err != nil {
+			return fmt.Errorf("failed to create decision history schema: %w", err)
+		}
+	}
+	return nil
+}
+
+// Record stores a decision with its rule outcomes, or a rebase or closure action
+func (s *Store) Record(event audit.Event) error {
+	if event.ID == "" {
+		event.ID = audit.NewID()
+	}
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now().UTC()
+	}
+	if event.Kind == audit.KindDecision {
+		return s.recordDecision(event)
+	}
+	_, err := s.db.Exec(s.rebind(`INSERT INTO actions
+		(id, created_at, kind, project_id, mr_iid, title, author, reason, outcome, error)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`),
+		event.ID, event.Timestamp.UnixNano(), event.Kind, event.ProjectID, event.MRIID,
+		event.Title, event.Author, event.Reason, event.Outcome, event.Error)
+	if err != nil {
+		return fmt.Errorf("failed to store %s action: %w", event.Kind, err)
+	}
+	return nil
+}
+
+func (s *Store) recordDecision(event audit.Event) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to store decision: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	approved := 0
+	if event.Approved {
+		approved = 1
+	}
+	if _, err := tx.Exec(s.rebind(`INSERT INTO decisions
+		(id, created_at, project_id, mr_iid, title, author, source_branch, target_branch,
+		 decision, reason, snapshot_id, approved, outcome, error)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`),
+		event.ID, event.Timestamp.UnixNano(), event.ProjectID, event.MRIID, event.Title, event.Author,
+		event.SourceBranch, event.TargetBranch, event.Decision, event.Reason, event.SnapshotID,
+		approved, event.Outcome, event.Error); err != nil {
+		return fmt.Errorf("failed to store decision: %w", err)
+	}
+	for i, rule := range event.Rules {
+		if _, err := tx.Exec(s.rebind(`INSERT INTO rule_outcomes
+			(decision_id, position, file, rule, decision, reason) VALUES (?, ?, ?, ?, ?, ?)`),
+			event.ID, i, rule.File, rule.Rule, rule.Decision, rule.Reason); err != nil {
+			return fmt.Errorf("failed to store rule outcome: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to store decision: %w", err)
+	}
+	return nil
+}
+
+// Decisions lists decisions matching the filter, newest first, without rule outcomes
+func (s *Store) Decisions(filter Filter) ([]Decision, error) {
+	where, args := filter.where(false)
+	rows, err := s.db.Query(s.rebind(`SELECT id, created_at, project_id, mr_iid, title, author,
+		source_branch, target_branch, decision, reason, snapshot_id, approved, outcome, error
+		FROM decisions`+where+` ORDER BY created_at DESC LIMIT `+strconv.Itoa(filter.limit())), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list decisions: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	decisions := []Decision{}
+	for rows.Next() {
+		decision, err := scanDecision(rows)
+		if err != nil {
+			return nil, err
+		}
+		decisions = append(decisions, *decision)
+	}
+	return decisions, rows.Err()
+}
+
+// Decision returns one decision with its rule outcomes
+func (s *Store) Decision(id string) (*Decision, error) {
+	row := s.db.QueryRow(s.rebind(`SELECT id, created_at, project_id, mr_iid, title, author,
+		source_branch, target_branch, decision, reason, snapshot_id, approved, outcome, error
+		FROM decisions WHERE id = ?`), id)
+	decision, err := scanDecision(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(s.rebind(`SELECT file, rule, decision, reason FROM rule_outcomes
+		WHERE decision_id = ? ORDER BY position`), id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load rule outcomes: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	for rows.Next() {
+		var rule audit.RuleResult
+		if err := rows.Scan(&rule.File, &rule.Rule, &rule.Decision, &rule.Reason); err != nil {
+			return nil, fmt.Errorf("failed to load rule outcomes: %w", err)
+		}
+		decision.Rules = append(decision.Rules, rule)
+	}
+	return decision, rows.Err()
+}
+
+// LatestDecision returns the newest decision of an MR with its rule outcomes, or nil
+func (s *Store) LatestDecision(projectID, mrIID int) (*Decision, error) {
+	decisions, err := s.Decisions(Filter{ProjectID: projectID, MRIID: mrIID, Limit: 1})
+	if err != nil || len(decisions) == 0 {
+		return nil, err
+	}
+	return s.Decision(decisions[0].ID)
+}
+
+// Actions lists rebase and closure actions matching the filter, newest first
+func (s *Store) Actions(filter Filter) ([]Action, error) {
+	where, args := filter.where(true)
+	rows, err := s.db.Query(s.rebind(`SELECT id, created_at, kind, project_id, mr_iid, title, author,
+		reason, outcome, error FROM actions`+where+` ORDER BY created_at DESC LIMIT `+strconv.Itoa(filter.limit())), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list actions: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	actions := []Action{}
+	for rows.Next() {
+		var action Action
+		var createdAt int64
+		if err := rows.Scan(&action.ID, &createdAt, &action.Kind, &action.ProjectID, &action.MRIID,
+			&action.Title, &action.Author, &action.Reason, &action.Outcome, &action.Error); err != nil {
+			return nil, fmt.Errorf("failed to list actions: %w", err)
+		}
+		action.CreatedAt = time.Unix(0, createdAt).UTC()
+		actions = append(actions, action)
+	}
+	return actions, rows.Err()
+}
+
+// RuleCounts counts the approve, warn and manual review outcomes of each rule in decisions created
+// at or after since (zero counts all decisions), sorted by rule
+func (s *Store) RuleCounts(since time.Time) ([]RuleCount, error) {
+	where, args := Filter{Since: since}.where(false)
+	rows, err := s.db.Query(s.rebind(`SELECT rule_outcomes.rule,
+		SUM(CASE WHEN rule_outcomes.decision = 'approve' THEN 1 ELSE 0 END),
+		SUM(CASE WHEN rule_outcomes.decision = 'warn' THEN 1 ELSE 0 END),
+		SUM(CASE WHEN rule_outcomes.decision IN ('approve', 'warn') THEN 0 ELSE 1 END)
+		FROM rule_outcomes JOIN decisions ON decisions.id = rule_outcomes.decision_id`+where+`
+		GROUP BY rule_outcomes.rule ORDER BY rule_outcomes.rule`), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count rule outcomes: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	counts := []RuleCount{}
+	for rows.Next() {
+		var count RuleCount
+		if err := rows.Scan(&count.Rule, &count.Passed, &count.Warned, &count.Failed); err != nil {
+			return nil, fmt.Errorf("failed to count rule outcomes: %w", err)
+		}
+		counts = append(counts, count)
+	}
+	return counts, rows.Err()
+}
+
+// rowScanner is satisfied by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanDecision(row rowScanner) (*Decision, error) {
+	var decision Decision
+	var createdAt int64
+	var approved int
+	if err := row.Scan(&decision.ID, &createdAt, &decision.ProjectID, &decision.MRIID, &decision.Title,
+		&decision.Author, &decision.SourceBranch, &decision.TargetBranch, &decision.Decision,
+		&decision.Reason, &decision.SnapshotID, &approved, &decision.Outcome, &decision.Error); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to load decision: %w", err)
+	}
+	decision.CreatedAt = time.Unix(0, createdAt).UTC()
+	decision.Approved = approved != 0
+	return &decision, nil
+}
+
+// where builds the WHERE clause of the filter with ? placeholders
+func (f Filter) where(actions bool) (string, []any) {
+	var conditions []string
+	var args []any
+	if f.ProjectID > 0 {
+		conditions, args = append(conditions, "project_id = ?"), append(args, f.ProjectID)
+	}
+	if f.MRIID > 0 {
+		conditions, args = append(conditions, "mr_iid = ?"), append(args, f.MRIID)
+	}
+	if actions && f.Kind != "" {
+		conditions, args = append(conditions, "kind = ?"), append(args, f.Kind)
+	}
+	if !f.Since.IsZero() {
+		conditions, args = append(conditions, "created_at >= ?"), append(args, f.Since.UnixNano())
+	}
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+func (f Filter) limit() int {
+	switch {
+	case f.Limit <= 0:
+		return DefaultLimit
+	case f.Limit > MaxLimit:
+		return MaxLimit
+	default:
+		return f.Limit
+	}
+}
+
+// rebind converts ? placeholders to Postgres $n placeholders
+func (s *Store) rebind(query string) string {
+	if s.dialect != DialectPostgres {
+		return query
+	}
+	var b strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
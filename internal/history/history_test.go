@@ -0,0 +1,166 @@
+package history
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/audit"
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+)
+
+func openTestStore(t *testing.T) *Store {
+	s, err := Open(filepath.Join(t.TempDir(), "history.db"))
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = s.Close() })
+	return s
+}
+
+func TestStore_RecordAndQueryDecisions(t *testing.T) {
+	s := openTestStore(t)
+	assert.Equal(t, DialectSQLite, s.Dialect())
+	base := time.Date(2024, 5, 6, 10, 0, 0, 0, time.UTC)
+
+	assert.NoError(t, s.Record(audit.Event{
+		ID: "first", Timestamp: base, Kind: audit.KindDecision, ProjectID: 1, MRIID: 10,
+		Decision: "manual_review", Reason: "Warehouse increase",
+	}))
+	assert.NoError(t, s.Record(audit.Event{
+		ID: "second", Timestamp: base.Add(time.Hour), Kind: audit.KindDecision, ProjectID: 1, MRIID: 10,
+		Title: "Shrink warehouse", Author: "alice", Decision: "approve", Reason: "All rules passed",
+		SnapshotID: "snap-1", Approved: true, Outcome: audit.OutcomeSucceeded,
+		Rules: []audit.RuleResult{
+			{File: "product.yaml", Rule: "warehouse_rule", Decision: "approve", Reason: "Warehouse decrease"},
+			{File: "README.md", Rule: "documentation_rule", Decision: "approve"},
+		},
+	}))
+	assert.NoError(t, s.Record(audit.Event{ID: "other", Timestamp: base, Kind: audit.KindDecision, ProjectID: 2, MRIID: 10, Decision: "approve"}))
+
+	decisions, err := s.Decisions(Filter{ProjectID: 1, MRIID: 10})
+	assert.NoError(t, err)
+	assert.Len(t, decisions, 2)
+	assert.Equal(t, "second", decisions[0].ID, "newest first")
+	assert.True(t, decisions[0].Approved)
+	assert.Equal(t, base.Add(time.Hour), decisions[0].CreatedAt)
+	assert.Empty(t, decisions[0].Rules, "listings omit rule outcomes")
+
+	all, err := s.Decisions(Filter{Limit: 1})
+	assert.NoError(t, err)
+	assert.Len(t, all, 1)
+
+	since, err := s.Decisions(Filter{Since: base.Add(time.Minute)})
+	assert.NoError(t, err)
+	assert.Len(t, since, 1)
+
+	decision, err := s.Decision("second")
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", decision.Author)
+	assert.Equal(t, "snap-1", decision.SnapshotID)
+	assert.Len(t, decision.Rules, 2)
+	assert.Equal(t, "warehouse_rule", decision.Rules[0].Rule)
+
+	latest, err := s.LatestDecision(1, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, "second", latest.ID)
+	assert.Len(t, latest.Rules, 2)
+
+	latest, err = s.LatestDecision(1, 99)
+	assert.NoError(t, err)
+	assert.Nil(t, latest)
+
+	_, err = s.Decision("missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestStore_RecordAndQueryActions(t *testing.T) {
+	s := openTestStore(t)
+	assert.NoError(t, s.Record(audit.Event{Kind: audit.KindRebase, ProjectID: 1, MRIID: 3, Reason: "behind main by 2 commits", Outcome: audit.OutcomeSucceeded}))
+	assert.NoError(t, s.Record(audit.Event{Kind: audit.KindStaleClose, ProjectID: 1, MRIID: 4, Outcome: audit.OutcomeFailed, Error: "403"}))
+
+	actions, err := s.Actions(Filter{ProjectID: 1})
+	assert.NoError(t, err)
+	assert.Len(t, actions, 2)
+
+	closures, err := s.Actions(Filter{ProjectID: 1, Kind: audit.KindStaleClose})
+	assert.NoError(t, err)
+	assert.Len(t, closures, 1)
+	assert.Equal(t, 4, closures[0].MRIID)
+	assert.Equal(t, "403", closures[0].Error)
+	assert.NotEmpty(t, closures[0].ID)
+
+	decisions, err := s.Decisions(Filter{})
+	assert.NoError(t, err)
+	assert.Empty(t, decisions, "actions are not decisions")
+}
+
+func TestStore_RuleCounts(t *testing.T) {
+	s := openTestStore(t)
+	base := time.Date(2024, 5, 6, 10, 0, 0, 0, time.UTC)
+
+	assert.NoError(t, s.Record(audit.Event{
+		ID: "old", Timestamp: base, Kind: audit.KindDecision, ProjectID: 1, MRIID: 10, Decision: "manual_review",
+		Rules: []audit.RuleResult{{File: "product.yaml", Rule: "warehouse_rule", Decision: "manual_review"}},
+	}))
+	assert.NoError(t, s.Record(audit.Event{
+		ID: "new", Timestamp: base.Add(time.Hour), Kind: audit.KindDecision, ProjectID: 1, MRIID: 11, Decision: "manual_review",
+		Rules: []audit.RuleResult{
+			{File: "product.yaml", Rule: "warehouse_rule", Decision: "approve"},
+			{File: "dev/product.yaml", Rule: "warehouse_rule", Decision: "manual_review"},
+			{File: "README.md", Rule: "documentation_rule", Decision: "approve"},
+			{File: "sandbox/product.yaml", Rule: "warehouse_rule", Decision: "warn"},
+		},
+	}))
+
+	counts, err := s.RuleCounts(time.Time{})
+	assert.NoError(t, err)
+	assert.Equal(t, []RuleCount{
+		{Rule: "documentation_rule", Passed: 1},
+		{Rule: "warehouse_rule", Passed: 1, Warned: 1, Failed: 2},
+	}, counts)
+
+	counts, err = s.RuleCounts(base.Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, []RuleCount{
+		{Rule: "documentation_rule", Passed: 1},
+		{Rule: "warehouse_rule", Passed: 1, Warned: 1, Failed: 1},
+	}, counts)
+}
+
+func TestStore_PersistsAcrossReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.db")
+	s, err := Open(path)
+	assert.NoError(t, err)
+	assert.NoError(t, s.Record(audit.Event{ID: "kept", Kind: audit.KindDecision, ProjectID: 1, MRIID: 1, Decision: "approve"}))
+	assert.NoError(t, s.Close())
+
+	s, err = Open(path)
+	assert.NoError(t, err)
+	defer func() { _ = s.Close() }()
+	decision, err := s.Decision("kept")
+	assert.NoError(t, err)
+	assert.Equal(t, "approve", decision.Decision)
+}
+
+func TestNewStoreFromConfig(t *testing.T) {
+	s, err := NewStoreFromConfig(config.HistoryConfig{DSN: "unused.db"})
+	assert.NoError(t, err)
+	assert.Nil(t, s)
+
+	_, err = NewStoreFromConfig(config.HistoryConfig{Enabled: true})
+	assert.Error(t, err)
+}
+
+func TestRebind(t *testing.T) {
+	query := "SELECT id FROM decisions WHERE project_id = ? AND mr_iid = ?"
+	assert.Equal(t, query, (&Store{dialect: DialectSQLite}).rebind(query))
+	assert.Equal(t, "SELECT id FROM decisions WHERE project_id = $1 AND mr_iid = $2",
+		(&Store{dialect: DialectPostgres}).rebind(query))
+}
+
+func TestFilterLimit(t *testing.T) {
+	assert.Equal(t, DefaultLimit, Filter{}.limit())
+	assert.Equal(t, 5, Filter{Limit: 5}.limit())
+	assert.Equal(t, MaxLimit, Filter{Limit: 10000}.limit())
+}
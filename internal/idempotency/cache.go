@@ -0,0 +1,270 @@
+package idempotency
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/replay"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+	"go.uber.org/zap"
+)
+
+// keyPrefix is the state store namespace for the results of processed deliveries
+const keyPrefix = "idempotency/"
+
+// ReplayedHeader marks responses repeating the result of an earlier delivery
+const ReplayedHeader = "X-Naysayer-Idempotent-Replay"
+
+// pruneInterval limits how often expired results are removed from the state store
+const pruneInterval = time.Minute
+
+// Counters of the deliveries answered with an earlier result since startup
+var (
+	duplicates atomic.Int64
+	inFlight   atomic.Int64
+)
+
+// Stats reports the duplicate deliveries answered with a stored result, and those that
+// arrived while the original delivery was still being processed, since startup
+func Stats() (duplicateDeliveries, inFlightDeliveries int64) {
+	return duplicates.Load(), inFlight.Load()
+}
+
+// Result is the response of a processed delivery
+type Result struct {
+	Status      int       `json:"status"`
+	ContentType string    `json:"content_type,omitempty"`
+	Location    string    `json:"location,omitempty"` // Status URL of queued deliveries
+	Body        []byte    `json:"body"`
+	StoredAt    time.Time `json:"stored_at"`
+}
+
+type cacheEntry struct {
+	key    string
+	result Result
+}
+
+// Cache remembers the responses of processed webhook deliveries so that deliveries GitLab
+// retries after a timeout are answered with the original result instead of being processed
+// again. The most recent results are kept in a bounded LRU in front of the state store.
+type Cache struct {
+	store store.Store
+	size  int
+	ttl   time.Duration
+	now   func() time.Time
+
+	mu         sync.Mutex
+	entries    map[string]*list.Element
+	order      *list.List // Most recently used first
+	pending    map[string]bool
+	lastPruned time.Time
+}
+
+// NewCache creates a cache holding at most size results in memory and every result in st
+// for ttl. A nil store keeps results in memory only.
+func NewCache(st store.Store, size int, ttl time.Duration) *Cache {
+	return &Cache{
+		store:   st,
+		size:    size,
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+		pending: make(map[string]bool),
+	}
+}
+
+// NewCacheFromConfig returns a delivery cache, or nil when idempotency is disabled
+func NewCacheFromConfig(cfg config.IdempotencyConfig, st store.Store) *Cache {
+	if !cfg.Enabled {
+		return nil
+	}
+	return NewCache(st, cfg.CacheSize, time.Duration(cfg.TTLMinutes)*time.Minute)
+}
+
+// Key identifies a delivery to endpoint by its X-Gitlab-Event-UUID
+func Key(endpoint, uuid string) string {
+	return endpoint + "/uuid/" + uuid
+}
+
+// Begin looks up the result of a delivery. When none is stored and the delivery is not
+// being processed it is marked as pending, and the caller must call Finish or Abort.
+func (c *Cache) Begin(key string) (result *Result, pending bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if result, ok := c.getLocked(key); ok {
+		return result, false
+	}
+	if c.pending[key] {
+		return nil, true
+	}
+	c.pending[key] = true
+	return nil, false
+}
+
+// Finish stores the result of a pending delivery
+func (c *Cache) Finish(key string, result Result) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.pending, key)
+	result.StoredAt = c.now()
+	c.putLocked(key, result)
+	if c.store != nil {
+		if err := store.PutJSON(c.store, keyPrefix+key, result); err != nil {
+			logging.Warn("Failed to persist result of webhook delivery", zap.String("key", key), zap.Error(err))
+		}
+		c.pruneLocked(result.StoredAt)
+	}
+}
+
+// Abort releases a pending delivery without storing a result, so a retry is processed
+func (c *Cache) Abort(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.pending, key)
+}
+
+// Len returns the number of results held in memory
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+func (c *Cache) getLocked(key string) (*Result, bool) {
+	if element, ok := c.entries[key]; ok {
+		entry := element.Value.(*cacheEntry)
+		if c.fresh(entry.result) {
+			c.order.MoveToFront(element)
+			result := entry.result
+			return &result, true
+		}
+		c.remove(element)
+	}
+
+	if c.store == nil {
+		return nil, false
+	}
+	var result Result
+	found, err := store.GetJSON(c.store, keyPrefix+key, &result)
+	if err != nil {
+		logging.Warn("Failed to load result of webhook delivery", zap.String("key", key), zap.Error(err))
+		return nil, false
+	}
+	if !found || !c.fresh(result) {
+		return nil, false
+	}
+	c.putLocked(key, result)
+	return &result, true
+}
+
+func (c *Cache) putLocked(key string, result Result) {
+	if c.size <= 0 {
+		return
+	}
+	entry := &cacheEntry{key: key, result: result}
+	if element, ok := c.entries[key]; ok {
+		element.Value = entry
+		c.order.MoveToFront(element)
+		return
+	}
+	c.entries[key] = c.order.PushFront(entry)
+	for c.order.Len() > c.size {
+		c.remove(c.order.Back())
+	}
+}
+
+func (c *Cache) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*cacheEntry).key)
+}
+
+func (c *Cache) fresh(result Result) bool {
+	return c.now().Sub(result.StoredAt) <= c.ttl
+}
+
+// pruneLocked removes expired results from the state store, at most once per pruneInterval
+func (c *Cache) pruneLocked(now time.Time) {
+	if now.Sub(c.lastPruned) < pruneInterval {
+		return
+	}
+	c.lastPruned = now
+
+	keys, err := c.store.Keys(keyPrefix)
+	if err != nil {
+		logging.Warn("Failed to list webhook delivery results", zap.Error(err))
+		return
+	}
+	for _, key := range keys {
+		var result Result
+		if found, err := store.GetJSON(c.store, key, &result); err == nil && found && !c.fresh(result) {
+			_ = c.store.Delete(key)
+		}
+	}
+}
+
+// Middleware answers repeated deliveries to endpoint with the response of the first one.
+// Deliveries arriving while the first is still processed get 202 without being processed;
+// server errors are not stored so that GitLab's retry is processed. Only GitLab deliveries
+// are deduplicated: requests without an X-Gitlab-Event-UUID, such as scheduled jobs posting
+// the same payload every run, are always processed. (X-Gitlab-Webhook-UUID names the webhook
+// rather than the delivery, so it cannot tell retries from new events.) A nil cache lets
+// every request through.
+func (c *Cache) Middleware(endpoint string) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		uuid := ctx.Get(replay.EventUUIDHeader)
+		if c == nil || uuid == "" {
+			return ctx.Next()
+		}
+
+		key := Key(endpoint, uuid)
+		result, pending := c.Begin(key)
+		switch {
+		case result != nil:
+			duplicates.Add(1)
+			logging.Info("Answered repeated webhook delivery", zap.String("path", ctx.Path()))
+			ctx.Set(ReplayedHeader, "true")
+			if result.ContentType != "" {
+				ctx.Set(fiber.HeaderContentType, result.ContentType)
+			}
+			if result.Location != "" {
+				ctx.Set(fiber.HeaderLocation, result.Location)
+			}
+			return ctx.Status(result.Status).Send(result.Body)
+		case pending:
+			inFlight.Add(1)
+			logging.Info("Skipped webhook delivery", zap.String("path", ctx.Path()))
+			ctx.Set(ReplayedHeader, "true")
+			return ctx.Status(fiber.StatusAccepted).JSON(fiber.Map{
+				"status":  "processing",
+				"message": "This delivery is already being processed",
+			})
+		}
+
+		if err := ctx.Next(); err != nil {
+			c.Abort(key)
+			return err
+		}
+
+		status := ctx.Response().StatusCode()
+		if status >= fiber.StatusInternalServerError {
+			c.Abort(key)
+			return nil
+		}
+		c.Finish(key, Result{
+			Status:      status,
+			ContentType: string(ctx.Response().Header.ContentType()),
+			Location:    string(ctx.Response().Header.Peek(fiber.HeaderLocation)),
+			Body:        append([]byte(nil), ctx.Response().Body()...),
+		})
+		return nil
+	}
+}
@@ -0,0 +1,204 @@
+package idempotency
+
+import (
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+)
+
+func TestNewCacheFromConfig(t *testing.T) {
+	assert.Nil(t, NewCacheFromConfig(config.IdempotencyConfig{}, store.NewMemoryStore()))
+	cache := NewCacheFromConfig(config.IdempotencyConfig{Enabled: true, CacheSize: 10, TTLMinutes: 5}, nil)
+	assert.NotNil(t, cache)
+	assert.Equal(t, 5*time.Minute, cache.ttl)
+}
+
+func TestKey(t *testing.T) {
+	assert.Equal(t, "review/uuid/abc", Key("review", "abc"))
+	assert.NotEqual(t, Key("review", "abc"), Key("rebase", "abc"))
+}
+
+func TestCache_BeginFinish(t *testing.T) {
+	cache := NewCache(nil, 10, time.Hour)
+
+	result, pending := cache.Begin("k")
+	assert.Nil(t, result)
+	assert.False(t, pending)
+
+	// A second delivery waits for the first to finish
+	result, pending = cache.Begin("k")
+	assert.Nil(t, result)
+	assert.True(t, pending)
+
+	cache.Finish("k", Result{Status: 200, Body: []byte("ok")})
+	result, pending = cache.Begin("k")
+	assert.False(t, pending)
+	if assert.NotNil(t, result) {
+		assert.Equal(t, 200, result.Status)
+		assert.Equal(t, "ok", string(result.Body))
+	}
+
+	// Aborted deliveries are processed again
+	cache.Begin("failed")
+	cache.Abort("failed")
+	result, pending = cache.Begin("failed")
+	assert.Nil(t, result)
+	assert.False(t, pending)
+}
+
+func TestCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewCache(nil, 2, time.Hour)
+	for _, key := range []string{"a", "b"} {
+		cache.Begin(key)
+		cache.Finish(key, Result{Status: 200})
+	}
+	cache.Begin("a") // a becomes the most recently used
+	cache.Begin("c")
+	cache.Finish("c", Result{Status: 200})
+
+	assert.Equal(t, 2, cache.Len())
+	result, _ := cache.Begin("a")
+	assert.NotNil(t, result)
+	result, pending := cache.Begin("b")
+	assert.Nil(t, result)
+	assert.False(t, pending)
+}
+
+func TestCache_PersistsAndExpires(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	st := store.NewMemoryStore()
+	cache := NewCache(st, 1, time.Hour)
+	cache.now = func() time.Time { return now }
+
+	cache.Begin("a")
+	cache.Finish("a", Result{Status: 200, Body: []byte("first")})
+	cache.Begin("b")
+	cache.Finish("b", Result{Status: 202})
+
+	// a was evicted from memory but is still found in the state store
+	result, _ := cache.Begin("a")
+	if assert.NotNil(t, result) {
+		assert.Equal(t, "first", string(result.Body))
+	}
+
+	// A restarted process finds the results in the state store
+	restarted := NewCache(st, 10, time.Hour)
+	restarted.now = cache.now
+	result, _ = restarted.Begin("b")
+	assert.NotNil(t, result)
+
+	cache.now = func() time.Time { return now.Add(2 * time.Hour) }
+	result, pending := cache.Begin("a")
+	assert.Nil(t, result)
+	assert.False(t, pending)
+	cache.Finish("a", Result{Status: 200})
+	keys, _ := st.Keys(keyPrefix)
+	assert.Equal(t, []string{keyPrefix + "a"}, keys)
+}
+
+func TestCache_Middleware(t *testing.T) {
+	cache := NewCache(store.NewMemoryStore(), 10, time.Hour)
+	calls := 0
+	app := fiber.New()
+	app.Post("/hook", cache.Middleware("review"), func(c *fiber.Ctx) error {
+		calls++
+		if strings.Contains(string(c.Body()), "broken") {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed"})
+		}
+		return c.JSON(fiber.Map{"call": calls})
+	})
+
+	post := func(uuid, body string) (int, string, string) {
+		req := httptest.NewRequest("POST", "/hook", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if uuid != "" {
+			req.Header.Set("X-Gitlab-Event-UUID", uuid)
+		}
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		respBody, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(respBody), resp.Header.Get(ReplayedHeader)
+	}
+
+	status, body, replayed := post("uuid-1", `{"object_kind":"merge_request"}`)
+	assert.Equal(t, 200, status)
+	assert.Equal(t, `{"call":1}`, body)
+	assert.Empty(t, replayed)
+
+	// GitLab retries the delivery with the same UUID
+	status, body, replayed = post("uuid-1", `{"object_kind":"merge_request"}`)
+	assert.Equal(t, 200, status)
+	assert.Equal(t, `{"call":1}`, body)
+	assert.Equal(t, "true", replayed)
+
+	// Server errors are not remembered so that the retry is processed
+	status, _, _ = post("uuid-2", `{"broken":true}`)
+	assert.Equal(t, 500, status)
+	post("uuid-2", `{"broken":true}`)
+	assert.Equal(t, 3, calls)
+
+	duplicates, _ := Stats()
+	assert.GreaterOrEqual(t, duplicates, int64(1))
+}
+
+func TestCache_MiddlewareInFlight(t *testing.T) {
+	cache := NewCache(nil, 10, time.Hour)
+	key := Key("review", "uuid-1")
+	cache.Begin(key)
+
+	app := fiber.New()
+	app.Post("/hook", cache.Middleware("review"), func(c *fiber.Ctx) error {
+		t.Error("delivery being processed must not be processed again")
+		return nil
+	})
+	req := httptest.NewRequest("POST", "/hook", nil)
+	req.Header.Set("X-Gitlab-Event-UUID", "uuid-1")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusAccepted, resp.StatusCode)
+	assert.Equal(t, "true", resp.Header.Get(ReplayedHeader))
+}
+
+func TestCache_MiddlewareWithoutUUID(t *testing.T) {
+	cache := NewCache(store.NewMemoryStore(), 10, time.Hour)
+	calls := 0
+	app := fiber.New()
+	app.Post("/stale-mr-cleanup", cache.Middleware(config.EndpointStaleMRCleanup), func(c *fiber.Ctx) error {
+		calls++
+		return c.JSON(fiber.Map{"call": calls})
+	})
+
+	// A scheduled cleanup job posts the same payload on every run
+	for run := 1; run <= 2; run++ {
+		req := httptest.NewRequest("POST", "/stale-mr-cleanup", strings.NewReader(`{"project_id":42}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Empty(t, resp.Header.Get(ReplayedHeader))
+		assert.Equal(t, fmt.Sprintf(`{"call":%d}`, run), string(body))
+	}
+	assert.Equal(t, 2, calls, "requests without a delivery UUID are always processed")
+	assert.Equal(t, 0, cache.Len())
+}
+
+func TestCache_NilMiddleware(t *testing.T) {
+	var cache *Cache
+	app := fiber.New()
+	app.Post("/hook", cache.Middleware("review"), func(c *fiber.Ctx) error {
+		return c.SendStatus(204)
+	})
+	resp, err := app.Test(httptest.NewRequest("POST", "/hook", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 204, resp.StatusCode)
+}
@@ -0,0 +1,178 @@
+package instances
+
+import (
+	"encoding/json"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"go.uber.org/zap"
+)
+
+// Default is the name of the instance configured with GITLAB_BASE_URL
+const Default = "default"
+
+// Header is set by GitLab to the URL of the instance sending a webhook delivery. Callers
+// such as scheduled cleanup jobs may set it to an instance name instead.
+const Header = "X-Gitlab-Instance"
+
+// instance is an additional GitLab instance matched by the URL it serves projects under
+type instance struct {
+	name string
+	host string
+	path string // Sub-path of instances not served from the root, without trailing slash
+}
+
+func (i instance) matches(u *url.URL) bool {
+	if !strings.EqualFold(u.Host, i.host) {
+		return false
+	}
+	return i.path == "" || u.Path == i.path || strings.HasPrefix(u.Path, i.path+"/")
+}
+
+// Router picks the GitLab instance a webhook delivery came from, so that one deployment can
+// serve gitlab.com and self-managed instances with a client and token per instance
+type Router struct {
+	instances []instance // Longest sub-path first, so nested installations win
+}
+
+// NewRouter creates a router for the additional instances; deliveries matching none of
+// them belong to the default instance. Instances with an invalid base URL are skipped.
+func NewRouter(additional []config.GitLabInstance) *Router {
+	r := &Router{}
+	for _, cfg := range additional {
+		base, err := url.Parse(strings.TrimSpace(cfg.BaseURL))
+		if err != nil || base.Host == "" {
+			logging.Warn("Ignoring GitLab instance", zap.String("instance", cfg.Name), zap.String("base_url", cfg.BaseURL))
+			continue
+		}
+		r.instances = append(r.instances, instance{
+			name: cfg.Name,
+			host: base.Host,
+			path: strings.TrimRight(base.Path, "/"),
+		})
+	}
+	sort.SliceStable(r.instances, func(i, j int) bool { return len(r.instances[i].path) > len(r.instances[j].path) })
+	return r
+}
+
+// NewRouterFromConfig returns the instance router, or nil when no additional GitLab
+// instance is configured
+func NewRouterFromConfig(cfg *config.Config) *Router {
+	if len(cfg.GitLab.Instances) == 0 {
+		return nil
+	}
+	return NewRouter(cfg.GitLab.Instances)
+}
+
+// payloadURLs are the URLs of GitLab payloads pointing into the sending instance
+type payloadURLs struct {
+	Project struct {
+		WebURL string `json:"web_url"`
+	} `json:"project"`
+	Repository struct {
+		Homepage string `json:"homepage"`
+	} `json:"repository"`
+	ObjectAttributes struct {
+		URL string `json:"url"`
+	} `json:"object_attributes"`
+}
+
+// Resolve returns the name of the instance a delivery came from: the instance named or
+// addressed by the X-Gitlab-Instance header, else the instance serving the project URLs
+// of the payload, else Default
+func (r *Router) Resolve(header string, body []byte) string {
+	if r == nil {
+		return Default
+	}
+
+	if header = strings.TrimSpace(header); header != "" {
+		for _, inst := range r.instances {
+			if strings.EqualFold(inst.name, header) {
+				return inst.name
+			}
+		}
+		if name, ok := r.match(header); ok {
+			return name
+		}
+	}
+
+	var urls payloadURLs
+	if err := json.Unmarshal(body, &urls); err == nil {
+		for _, raw := range []string{urls.Project.WebURL, urls.Repository.Homepage, urls.ObjectAttributes.URL} {
+			if name, ok := r.match(raw); ok {
+				return name
+			}
+		}
+	}
+	return Default
+}
+
+// match returns the additional instance serving raw
+func (r *Router) match(raw string) (string, bool) {
+	if raw == "" {
+		return "", false
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return "", false
+	}
+	for _, inst := range r.instances {
+		if inst.matches(u) {
+			return inst.name, true
+		}
+	}
+	return "", false
+}
+
+// Dispatch returns a handler calling the handler of the instance each delivery came from.
+// handlers must hold a handler for Default. A nil router always calls the default handler.
+func (r *Router) Dispatch(handlers map[string]fiber.Handler) fiber.Handler {
+	if r == nil {
+		return handlers[Default]
+	}
+	return func(c *fiber.Ctx) error {
+		name := r.Resolve(c.Get(Header), c.Body())
+		handler, ok := handlers[name]
+		if !ok {
+			name, handler = Default, handlers[Default]
+		}
+		recordDelivery(name)
+		return handler(c)
+	}
+}
+
+// DeliveryCount is the number of webhook deliveries routed to an instance since startup
+type DeliveryCount struct {
+	Instance string
+	Count    int64
+}
+
+var (
+	deliveriesMu sync.Mutex
+	deliveries   = make(map[string]int64)
+)
+
+func recordDelivery(name string) {
+	deliveriesMu.Lock()
+	defer deliveriesMu.Unlock()
+	deliveries[name]++
+}
+
+// Deliveries returns the deliveries routed per instance since startup, sorted by instance
+func Deliveries() []DeliveryCount {
+	deliveriesMu.Lock()
+	defer deliveriesMu.Unlock()
+
+	result := make([]DeliveryCount, 0, len(deliveries))
+	for name, count := range deliveries {
+		result = append(result, DeliveryCount{Instance: name, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Instance < result[j].Instance })
+	return result
+}
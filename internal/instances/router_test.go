@@ -0,0 +1,99 @@
+package instances
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+)
+
+func newTestRouter() *Router {
+	return NewRouter([]config.GitLabInstance{
+		{Name: "onprem", BaseURL: "https://gitlab.example.com"},
+		{Name: "legacy", BaseURL: "https://tools.example.com/gitlab/"},
+		{Name: "broken", BaseURL: "not a url"},
+	})
+}
+
+func TestNewRouterFromConfig(t *testing.T) {
+	assert.Nil(t, NewRouterFromConfig(&config.Config{}))
+	router := NewRouterFromConfig(&config.Config{GitLab: config.GitLabConfig{
+		Instances: []config.GitLabInstance{{Name: "onprem", BaseURL: "https://gitlab.example.com"}},
+	}})
+	assert.NotNil(t, router)
+}
+
+func TestRouter_Resolve(t *testing.T) {
+	router := newTestRouter()
+
+	tests := []struct {
+		name     string
+		header   string
+		body     string
+		expected string
+	}{
+		{"instance header URL", "https://gitlab.example.com", `{}`, "onprem"},
+		{"instance header name", "Legacy", `{}`, "legacy"},
+		{"unknown header falls back to the payload", "https://gitlab.com", `{"project":{"web_url":"https://gitlab.example.com/data/config"}}`, "onprem"},
+		{"project web_url", "", `{"project":{"web_url":"https://GITLAB.example.com/data/config"}}`, "onprem"},
+		{"sub-path instance", "", `{"repository":{"homepage":"https://tools.example.com/gitlab/data/config"}}`, "legacy"},
+		{"sibling path is not the sub-path instance", "", `{"project":{"web_url":"https://tools.example.com/gitlab-old/data/config"}}`, Default},
+		{"MR url", "", `{"object_attributes":{"url":"https://gitlab.example.com/data/config/-/merge_requests/1"}}`, "onprem"},
+		{"gitlab.com", "https://gitlab.com", `{"project":{"web_url":"https://gitlab.com/data/config"}}`, Default},
+		{"payload without URLs", "", `{"project_id":12}`, Default},
+		{"invalid payload", "", `not json`, Default},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, router.Resolve(tt.header, []byte(tt.body)))
+		})
+	}
+
+	var disabled *Router
+	assert.Equal(t, Default, disabled.Resolve("https://gitlab.example.com", nil))
+}
+
+func TestRouter_Dispatch(t *testing.T) {
+	handler := func(name string) fiber.Handler {
+		return func(c *fiber.Ctx) error { return c.SendString(name) }
+	}
+	handlers := map[string]fiber.Handler{Default: handler(Default), "onprem": handler("onprem")}
+
+	app := fiber.New()
+	app.Post("/hook", newTestRouter().Dispatch(handlers))
+	post := func(header, body string) string {
+		req := httptest.NewRequest("POST", "/hook", strings.NewReader(body))
+		if header != "" {
+			req.Header.Set(Header, header)
+		}
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		respBody, _ := io.ReadAll(resp.Body)
+		return string(respBody)
+	}
+
+	assert.Equal(t, "onprem", post("https://gitlab.example.com", `{}`))
+	assert.Equal(t, Default, post("", `{"project":{"web_url":"https://gitlab.com/a/b"}}`))
+	// Instances without handlers are served by the default handlers
+	assert.Equal(t, Default, post("legacy", `{}`))
+
+	routed := make(map[string]int64)
+	for _, count := range Deliveries() {
+		routed[count.Instance] = count.Count
+	}
+	assert.GreaterOrEqual(t, routed["onprem"], int64(1))
+	assert.GreaterOrEqual(t, routed[Default], int64(2))
+
+	var disabled *Router
+	app = fiber.New()
+	app.Post("/hook", disabled.Dispatch(handlers))
+	resp, err := app.Test(httptest.NewRequest("POST", "/hook", nil))
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, Default, string(body))
+}
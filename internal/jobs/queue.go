@@ -0,0 +1,317 @@
+package jobs
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+	"github.com/redhat-data-and-ai/naysayer/internal/tracing"
+	"go.uber.org/zap"
+)
+
+// keyPrefix is the state store namespace for job records
+const keyPrefix = "jobs/"
+
+// pruneInterval limits how often expired job records are removed
+const pruneInterval = time.Minute
+
+// Status is the processing state of a job
+type Status string
+
+// Job states
+const (
+	StatusQueued    Status = "queued"
+	StatusRunning   Status = "running"
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+)
+
+var (
+	// ErrQueueFull is returned when every queue slot is taken
+	ErrQueueFull = errors.New("job queue is full")
+	// ErrStopped is returned for deliveries arriving while the queue shuts down
+	ErrStopped = errors.New("job queue is stopped")
+)
+
+// Job is the record of one webhook delivery processed in the background. StatusCode
+// and Result are the response the handler would have sent synchronously.
+type Job struct {
+	ID            string          `json:"id"`
+	Endpoint      string          `json:"endpoint"`
+	CorrelationID string          `json:"correlation_id,omitempty"` // Correlation ID of the delivery, see logging.Middleware
+	Status        Status          `json:"status"`
+	StatusCode    int             `json:"status_code,omitempty"`
+	Result        json.RawMessage `json:"result,omitempty"`
+	Error         string          `json:"error,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+	StartedAt     *time.Time      `json:"started_at,omitempty"`
+	FinishedAt    *time.Time      `json:"finished_at,omitempty"`
+}
+
+// Finished reports whether the job succeeded or failed
+func (j *Job) Finished() bool {
+	return j.Status == StatusSucceeded || j.Status == StatusFailed
+}
+
+// task is a queued delivery with a private copy of its request
+type task struct {
+	id       string
+	endpoint string
+	app      *fiber.App
+	handler  fiber.Handler
+	request  *fasthttp.Request
+}
+
+// Queue runs webhook handlers on a bounded worker pool so deliveries can be answered
+// before GitLab's webhook timeout
+type Queue struct {
+	store     store.Store
+	workers   int
+	retention time.Duration
+	tasks     chan task
+	now       func() time.Time
+	wg        sync.WaitGroup
+
+	mu         sync.Mutex
+	stopped    bool
+	lastPruned time.Time
+}
+
+// NewQueue creates a queue with workers processing at most size waiting deliveries.
+// Job records are kept in st for retention after they finish.
+func NewQueue(st store.Store, workers, size int, retention time.Duration) *Queue {
+	if workers < 1 {
+		workers = 1
+	}
+	if size < 0 {
+		size = 0
+	}
+	return &Queue{
+		store:     st,
+		workers:   workers,
+		retention: retention,
+		tasks:     make(chan task, size),
+		now:       time.Now,
+	}
+}
+
+// NewQueueFromConfig returns a job queue, or nil when asynchronous processing is disabled
+func NewQueueFromConfig(cfg config.JobsConfig, st store.Store) *Queue {
+	if !cfg.Enabled {
+		return nil
+	}
+	return NewQueue(st, cfg.Workers, cfg.QueueSize, time.Duration(cfg.RetentionMinutes)*time.Minute)
+}
+
+// Start launches the workers
+func (q *Queue) Start() {
+	for i := 0; i < q.workers; i++ {
+		q.wg.Add(1)
+		go func() {
+			defer q.wg.Done()
+			for t := range q.tasks {
+				q.run(t)
+			}
+		}()
+	}
+}
+
+// Stop rejects new deliveries and waits for the queued ones to finish
+func (q *Queue) Stop() {
+	q.mu.Lock()
+	if q.stopped {
+		q.mu.Unlock()
+		return
+	}
+	q.stopped = true
+	close(q.tasks)
+	q.mu.Unlock()
+
+	q.wg.Wait()
+}
+
+// Enqueue queues a copy of the request of c for handler and returns the job record
+func (q *Queue) Enqueue(c *fiber.Ctx, endpoint string, handler fiber.Handler) (*Job, error) {
+	id, err := newID()
+	if err != nil {
+		return nil, err
+	}
+	request := &fasthttp.Request{}
+	c.Request().CopyTo(request)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.stopped {
+		return nil, ErrStopped
+	}
+	q.pruneLocked(q.now())
+
+	job := &Job{ID: id, Endpoint: endpoint, CorrelationID: logging.CorrelationID(logging.RequestContext(c)), Status: StatusQueued, CreatedAt: q.now()}
+	if err := q.save(job); err != nil {
+		return nil, err
+	}
+	select {
+	case q.tasks <- task{id: id, endpoint: endpoint, app: c.App(), handler: handler, request: request}:
+		return job, nil
+	default:
+		_ = q.store.Delete(keyPrefix + id)
+		return nil, ErrQueueFull
+	}
+}
+
+// Get returns a job record and whether it was found
+func (q *Queue) Get(id string) (*Job, bool, error) {
+	var job Job
+	found, err := store.GetJSON(q.store, keyPrefix+id, &job)
+	if err != nil || !found {
+		return nil, false, err
+	}
+	return &job, true, nil
+}
+
+// run calls the handler with the copied request and records its response
+func (q *Queue) run(t task) {
+	job, found, err := q.Get(t.id)
+	if err != nil || !found {
+		logging.Error("Job disappeared before it ran", zap.String("job_id", t.id), zap.Error(err))
+		return
+	}
+	started := q.now()
+	job.Status = StatusRunning
+	job.StartedAt = &started
+	q.saveLogged(job)
+
+	statusCode, body, err := q.call(t)
+	finished := q.now()
+	job.FinishedAt = &finished
+	job.StatusCode = statusCode
+	if json.Valid(body) {
+		job.Result = body
+	}
+	switch {
+	case err != nil:
+		job.Status = StatusFailed
+		job.Error = err.Error()
+	case statusCode >= fiber.StatusBadRequest:
+		job.Status = StatusFailed
+	default:
+		job.Status = StatusSucceeded
+	}
+	q.saveLogged(job)
+	logging.FromContext(logging.WithCorrelationID(context.Background(), job.CorrelationID)).Info("Job finished",
+		zap.String("job_id", job.ID),
+		zap.String("endpoint", job.Endpoint),
+		zap.String("status", string(job.Status)),
+		zap.Int("status_code", job.StatusCode),
+		zap.Duration("duration", finished.Sub(started).Round(time.Millisecond)))
+}
+
+// call runs the handler on a fresh context so a panic or error only fails the job
+func (q *Queue) call(t task) (statusCode int, body []byte, err error) {
+	fctx := &fasthttp.RequestCtx{}
+	t.request.CopyTo(&fctx.Request)
+	c := t.app.AcquireCtx(fctx)
+	defer t.app.ReleaseCtx(c)
+	ctx, span := tracing.Start(tracing.RequestContext(logging.RequestContext(c), c), "job "+t.endpoint, tracing.KindInternal,
+		tracing.String("naysayer.job_id", t.id))
+	defer span.End()
+	c.SetUserContext(ctx)
+
+	defer func() {
+		if r := recover(); r != nil {
+			statusCode, body, err = fiber.StatusInternalServerError, nil, fmt.Errorf("handler panicked: %v", r)
+			span.SetError(err)
+		}
+	}()
+
+	if err := t.handler(c); err != nil {
+		span.SetError(err)
+		return fiber.StatusInternalServerError, nil, err
+	}
+	span.SetAttributes(tracing.Int("http.response.status_code", fctx.Response.StatusCode()))
+	return fctx.Response.StatusCode(), append([]byte(nil), fctx.Response.Body()...), nil
+}
+
+func (q *Queue) save(job *Job) error {
+	return store.PutJSON(q.store, keyPrefix+job.ID, job)
+}
+
+func (q *Queue) saveLogged(job *Job) {
+	if err := q.save(job); err != nil {
+		logging.Error("Failed to save job", zap.String("job_id", job.ID), zap.Error(err))
+	}
+}
+
+// pruneLocked removes jobs finished longer than the retention ago, at most once per pruneInterval
+func (q *Queue) pruneLocked(now time.Time) {
+	if now.Sub(q.lastPruned) < pruneInterval {
+		return
+	}
+	q.lastPruned = now
+
+	keys, err := q.store.Keys(keyPrefix)
+	if err != nil {
+		logging.Warn("Failed to list jobs", zap.Error(err))
+		return
+	}
+	for _, key := range keys {
+		var job Job
+		if found, err := store.GetJSON(q.store, key, &job); err == nil && found && job.Finished() && now.Sub(*job.FinishedAt) > q.retention {
+			_ = q.store.Delete(key)
+		}
+	}
+}
+
+// Async wraps a webhook handler so deliveries are answered with 202 and processed by the
+// queue. A nil queue returns the handler unchanged.
+func (q *Queue) Async(endpoint string, handler fiber.Handler) fiber.Handler {
+	if q == nil {
+		return handler
+	}
+	return func(c *fiber.Ctx) error {
+		job, err := q.Enqueue(c, endpoint, handler)
+		if err != nil {
+			logging.Warn("Rejected webhook delivery", zap.String("path", c.Path()), zap.Error(err))
+			return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": err.Error()})
+		}
+		statusURL := "/jobs/" + job.ID
+		c.Set(fiber.HeaderLocation, statusURL)
+		return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+			"job_id":     job.ID,
+			"status":     job.Status,
+			"status_url": statusURL,
+		})
+	}
+}
+
+// HandleGet answers GET /jobs/:id with the job record
+func (q *Queue) HandleGet(c *fiber.Ctx) error {
+	job, found, err := q.Get(c.Params("id"))
+	if err != nil {
+		logging.Error("Failed to load job", zap.String("job_id", c.Params("id")), zap.Error(err))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to load job"})
+	}
+	if !found {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Job not found"})
+	}
+	return c.JSON(job)
+}
+
+func newID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", fmt.Errorf("failed to generate job ID: %w", err)
+	}
+	return hex.EncodeToString(id), nil
+}
@@ -0,0 +1,205 @@
+package jobs
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+)
+
+// newTestApp serves handler asynchronously on POST /webhook and job status on GET /jobs/:id
+func newTestApp(q *Queue, handler fiber.Handler) *fiber.App {
+	app := fiber.New()
+	app.Post("/webhook", q.Async("review", handler))
+	app.Get("/jobs/:id", q.HandleGet)
+	return app
+}
+
+func post(t *testing.T, app *fiber.App, body string) (int, map[string]interface{}) {
+	req := httptest.NewRequest("POST", "/webhook", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	var decoded map[string]interface{}
+	data, _ := io.ReadAll(resp.Body)
+	_ = json.Unmarshal(data, &decoded)
+	return resp.StatusCode, decoded
+}
+
+func getJob(t *testing.T, app *fiber.App, id string) (int, *Job) {
+	resp, err := app.Test(httptest.NewRequest("GET", "/jobs/"+id, nil))
+	assert.NoError(t, err)
+	var job Job
+	data, _ := io.ReadAll(resp.Body)
+	_ = json.Unmarshal(data, &job)
+	return resp.StatusCode, &job
+}
+
+func waitFinished(t *testing.T, q *Queue, id string) *Job {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if job, found, _ := q.Get(id); found && job.Finished() {
+			return job
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("job %s did not finish", id)
+	return nil
+}
+
+func TestQueue_ProcessesDeliveryInBackground(t *testing.T) {
+	q := NewQueue(store.NewMemoryStore(), 2, 10, time.Hour)
+	q.Start()
+	defer q.Stop()
+
+	app := newTestApp(q, func(c *fiber.Ctx) error {
+		var payload map[string]interface{}
+		if err := c.BodyParser(&payload); err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "Invalid JSON payload"})
+		}
+		return c.JSON(fiber.Map{"decision": "approve", "mr": payload["mr"]})
+	})
+
+	status, body := post(t, app, `{"mr": 7}`)
+	assert.Equal(t, fiber.StatusAccepted, status)
+	assert.Equal(t, "queued", body["status"])
+	id := body["job_id"].(string)
+	assert.Equal(t, "/jobs/"+id, body["status_url"])
+
+	job := waitFinished(t, q, id)
+	assert.Equal(t, StatusSucceeded, job.Status)
+
+	status, fetched := getJob(t, app, id)
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, "review", fetched.Endpoint)
+	assert.Equal(t, 200, fetched.StatusCode)
+	assert.JSONEq(t, `{"decision":"approve","mr":7}`, string(fetched.Result))
+	assert.NotNil(t, fetched.StartedAt)
+	assert.NotNil(t, fetched.FinishedAt)
+
+	// The handler's error responses fail the job
+	status, body = post(t, app, `not json`)
+	assert.Equal(t, fiber.StatusAccepted, status)
+	job = waitFinished(t, q, body["job_id"].(string))
+	assert.Equal(t, StatusFailed, job.Status)
+	assert.Equal(t, 400, job.StatusCode)
+	assert.JSONEq(t, `{"error":"Invalid JSON payload"}`, string(job.Result))
+}
+
+func TestQueue_HandlerErrorsAndPanicsFailTheJob(t *testing.T) {
+	q := NewQueue(store.NewMemoryStore(), 1, 10, time.Hour)
+	q.Start()
+	defer q.Stop()
+
+	fail := newTestApp(q, func(c *fiber.Ctx) error { return fiber.ErrBadGateway })
+	_, body := post(t, fail, `{}`)
+	job := waitFinished(t, q, body["job_id"].(string))
+	assert.Equal(t, StatusFailed, job.Status)
+	assert.Equal(t, 500, job.StatusCode)
+	assert.Equal(t, "Bad Gateway", job.Error)
+
+	crash := newTestApp(q, func(c *fiber.Ctx) error { panic("boom") })
+	_, body = post(t, crash, `{}`)
+	job = waitFinished(t, q, body["job_id"].(string))
+	assert.Equal(t, StatusFailed, job.Status)
+	assert.Contains(t, job.Error, "boom")
+}
+
+func TestQueue_FullQueueAnswers503(t *testing.T) {
+	q := NewQueue(store.NewMemoryStore(), 1, 1, time.Hour)
+	// Workers are not started, so the single slot stays taken
+	app := newTestApp(q, func(c *fiber.Ctx) error { return c.SendStatus(200) })
+
+	status, _ := post(t, app, `{}`)
+	assert.Equal(t, fiber.StatusAccepted, status)
+	status, body := post(t, app, `{}`)
+	assert.Equal(t, fiber.StatusServiceUnavailable, status)
+	assert.Equal(t, ErrQueueFull.Error(), body["error"])
+
+	keys, _ := q.store.Keys(keyPrefix)
+	assert.Len(t, keys, 1, "rejected deliveries leave no job record")
+
+	// Stop drains the queued delivery
+	q.Start()
+	q.Stop()
+	job, found, _ := q.Get(strings.TrimPrefix(keys[0], keyPrefix))
+	assert.True(t, found)
+	assert.Equal(t, StatusSucceeded, job.Status)
+
+	status, _ = post(t, app, `{}`)
+	assert.Equal(t, fiber.StatusServiceUnavailable, status, "a stopped queue rejects deliveries")
+}
+
+func TestQueue_PrunesFinishedJobs(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	st := store.NewMemoryStore()
+	q := NewQueue(st, 1, 10, time.Hour)
+	q.now = func() time.Time { return now }
+
+	finished := now.Add(-2 * time.Hour)
+	assert.NoError(t, q.save(&Job{ID: "old", Status: StatusSucceeded, FinishedAt: &finished}))
+	assert.NoError(t, q.save(&Job{ID: "waiting", Status: StatusQueued, CreatedAt: finished}))
+
+	q.pruneLocked(now)
+	keys, _ := st.Keys(keyPrefix)
+	assert.Equal(t, []string{keyPrefix + "waiting"}, keys)
+}
+
+func TestQueue_GetUnknownJob(t *testing.T) {
+	q := NewQueue(store.NewMemoryStore(), 1, 1, time.Hour)
+	status, _ := getJob(t, newTestApp(q, nil), "missing")
+	assert.Equal(t, fiber.StatusNotFound, status)
+}
+
+func TestNilQueueRunsHandlerInline(t *testing.T) {
+	var q *Queue
+	app := fiber.New()
+	app.Post("/webhook", q.Async("review", func(c *fiber.Ctx) error { return c.SendStatus(200) }))
+
+	status, _ := post(t, app, `{}`)
+	assert.Equal(t, 200, status)
+}
+
+func TestNewQueueFromConfig(t *testing.T) {
+	assert.Nil(t, NewQueueFromConfig(config.JobsConfig{}, store.NewMemoryStore()))
+
+	q := NewQueueFromConfig(config.JobsConfig{Enabled: true, Workers: 0, QueueSize: 5, RetentionMinutes: 30}, store.NewMemoryStore())
+	assert.NotNil(t, q)
+	assert.Equal(t, 1, q.workers)
+	assert.Equal(t, 5, cap(q.tasks))
+	assert.Equal(t, 30*time.Minute, q.retention)
+}
+
+func TestQueue_PropagatesCorrelationID(t *testing.T) {
+	q := NewQueue(store.NewMemoryStore(), 1, 10, time.Hour)
+	q.Start()
+	defer q.Stop()
+
+	seen := make(chan string, 1)
+	app := fiber.New()
+	app.Use(logging.Middleware())
+	app.Post("/webhook", q.Async("review", func(c *fiber.Ctx) error {
+		seen <- logging.CorrelationID(c.UserContext())
+		return c.SendStatus(200)
+	}))
+
+	req := httptest.NewRequest("POST", "/webhook", strings.NewReader(`{}`))
+	req.Header.Set(logging.CorrelationHeader, "delivery-1")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	var body map[string]interface{}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+
+	job := waitFinished(t, q, body["job_id"].(string))
+	assert.Equal(t, "delivery-1", job.CorrelationID)
+	assert.Equal(t, "delivery-1", <-seen, "the background handler runs with the request's correlation ID")
+}
@@ -0,0 +1,126 @@
+package localeval
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"path"
+	"strings"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/redhat-data-and-ai/naysayer/internal/utils"
+)
+
+// EvaluateGit evaluates the changes from baseRef to headRef of the git repository in repoDir
+// (a CI checkout or the bare repository of a pre-receive hook) as an MR of projectID
+func EvaluateGit(ctx context.Context, repoDir, baseRef, headRef string, projectID int, rulesPath string) (*shared.RuleEvaluation, error) {
+	client := &gitClient{repoDir: repoDir, baseRef: baseRef, headRef: headRef}
+	for _, ref := range []string{baseRef, headRef} {
+		if _, err := client.git(ctx, "rev-parse", "--verify", "--quiet", ref+"^{commit}"); err != nil {
+			return nil, fmt.Errorf("unknown commit %q in %s", ref, repoDir)
+		}
+	}
+	return EvaluateMR(ctx, client, projectID, 1, rulesPath)
+}
+
+// gitClient answers the reads of an evaluation from two commits of a git repository: baseRef
+// as the target branch and headRef as the source branch. Only MR and file reads are
+// implemented; any other call panics and is reported by EvaluateMR.
+type gitClient struct {
+	gitlab.GitLabClient
+
+	repoDir string
+	baseRef string
+	headRef string
+}
+
+// git runs a git command in the repository and returns its standard output
+func (c *gitClient) git(ctx context.Context, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", c.repoDir}, args...)...) // #nosec G204 - fixed git subcommands
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return output, nil
+}
+
+func (c *gitClient) FetchFileContent(ctx context.Context, projectID int, filePath, ref string) (*gitlab.FileContent, error) {
+	commit := c.headRef
+	if ref == TargetBranch {
+		commit = c.baseRef
+	}
+	content, err := c.git(ctx, "show", commit+":"+filePath)
+	if err != nil {
+		return nil, &gitlab.APIError{StatusCode: http.StatusNotFound, Message: fmt.Sprintf("file %s not found at %s", filePath, ref)}
+	}
+	return &gitlab.FileContent{
+		FileName: path.Base(filePath),
+		FilePath: filePath,
+		Size:     len(content),
+		Content:  string(content),
+		Ref:      ref,
+		CommitID: commit,
+	}, nil
+}
+
+func (c *gitClient) GetMRDetails(ctx context.Context, projectID, mrIID int) (*gitlab.MRDetails, error) {
+	return &gitlab.MRDetails{
+		IID:             mrIID,
+		Title:           "Local changes",
+		State:           utils.MRStateOpened,
+		ProjectID:       projectID,
+		SourceProjectID: projectID,
+		TargetProjectID: projectID,
+		SourceBranch:    SourceBranch,
+		TargetBranch:    TargetBranch,
+		Sha:             c.headRef,
+	}, nil
+}
+
+func (c *gitClient) GetMRTargetBranch(ctx context.Context, projectID, mrIID int) (string, error) {
+	return TargetBranch, nil
+}
+
+// FetchMRChanges diffs the files changed between the commits in path order, renames counting
+// as a deletion and an addition
+func (c *gitClient) FetchMRChanges(ctx context.Context, projectID, mrIID int) ([]gitlab.FileChange, error) {
+	output, err := c.git(ctx, "diff", "--name-status", "--no-renames", "-z", c.baseRef, c.headRef)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := strings.Split(strings.TrimSuffix(string(output), "\x00"), "\x00")
+	var changes []gitlab.FileChange
+	for i := 0; i+1 < len(fields); i += 2 {
+		status, filePath := fields[i], fields[i+1]
+		oldContent, newContent := c.content(ctx, c.baseRef, filePath), c.content(ctx, c.headRef, filePath)
+		switch status {
+		case "A":
+			changes = append(changes, gitlab.FileChange{NewPath: filePath, NewFile: true, Diff: unifiedDiff("", newContent)})
+		case "D":
+			changes = append(changes, gitlab.FileChange{OldPath: filePath, DeletedFile: true, Diff: unifiedDiff(oldContent, "")})
+		default:
+			changes = append(changes, gitlab.FileChange{OldPath: filePath, NewPath: filePath, Diff: unifiedDiff(oldContent, newContent)})
+		}
+	}
+	return changes, nil
+}
+
+func (c *gitClient) GetCurrentBotUsername(ctx context.Context) (string, error) {
+	return "", errors.New("no bot user in a local evaluation")
+}
+
+// content returns a file at a commit, empty when it does not exist there
+func (c *gitClient) content(ctx context.Context, commit, filePath string) string {
+	content, err := c.git(ctx, "show", commit+":"+filePath)
+	if err != nil {
+		return ""
+	}
+	return string(content)
+}
@@ -0,0 +1,79 @@
+package localeval
+
+import (
+	"context"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+)
+
+// commitFiles writes files to the repository and commits them, returning the commit
+func commitFiles(t *testing.T, repo string, files map[string]string, removed ...string) string {
+	for path, content := range files {
+		writeFile(t, filepath.Join(repo, path), content)
+	}
+	run := func(args ...string) string {
+		output, err := exec.Command("git", append([]string{"-C", repo, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...).CombinedOutput()
+		assert.NoError(t, err, string(output))
+		return string(output)
+	}
+	if len(removed) > 0 {
+		run(append([]string{"rm", "-q"}, removed...)...)
+	}
+	run("add", "-A")
+	run("commit", "-q", "--allow-empty", "-m", "change")
+	rev := run("rev-parse", "HEAD")
+	return rev[:len(rev)-1]
+}
+
+func initRepo(t *testing.T) string {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	repo := t.TempDir()
+	output, err := exec.Command("git", "init", "-q", repo).CombinedOutput()
+	assert.NoError(t, err, string(output))
+	return repo
+}
+
+func TestGitClient_FetchMRChanges(t *testing.T) {
+	repo := initRepo(t)
+	base := commitFiles(t, repo, map[string]string{"docs/README.md": "a\nb\n", "docs/old.md": "gone\n"})
+	head := commitFiles(t, repo, map[string]string{"docs/README.md": "a\nc\n", "docs/new.md": "new\n"}, "docs/old.md")
+
+	client := &gitClient{repoDir: repo, baseRef: base, headRef: head}
+	changes, err := client.FetchMRChanges(context.Background(), 1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []gitlab.FileChange{
+		{OldPath: "docs/README.md", NewPath: "docs/README.md", Diff: "@@ -1,3 +1,3 @@\n a\n-b\n+c\n "},
+		{NewPath: "docs/new.md", NewFile: true, Diff: "@@ -1 +1,2 @@\n+new\n "},
+		{OldPath: "docs/old.md", DeletedFile: true, Diff: "@@ -1,2 +1 @@\n-gone\n "},
+	}, changes)
+
+	content, err := client.FetchFileContent(context.Background(), 1, "docs/README.md", TargetBranch)
+	assert.NoError(t, err)
+	assert.Equal(t, "a\nb\n", content.Content)
+	content, err = client.FetchFileContent(context.Background(), 1, "docs/README.md", SourceBranch)
+	assert.NoError(t, err)
+	assert.Equal(t, "a\nc\n", content.Content)
+	_, err = client.FetchFileContent(context.Background(), 1, "docs/old.md", SourceBranch)
+	assert.ErrorIs(t, err, gitlab.ErrNotFound)
+}
+
+func TestEvaluateGit(t *testing.T) {
+	repo := initRepo(t)
+	base := commitFiles(t, repo, map[string]string{"dataproducts/analytics/README.md": "# Analytics\n"})
+	head := commitFiles(t, repo, map[string]string{"dataproducts/analytics/README.md": "# Analytics\n\nOrders and revenue.\n"})
+
+	result, err := EvaluateGit(context.Background(), repo, base, head, 0, e2eRules)
+	assert.NoError(t, err)
+	assert.Equal(t, shared.Approve, result.FinalDecision.Type)
+
+	_, err = EvaluateGit(context.Background(), repo, "does-not-exist", head, 0, e2eRules)
+	assert.ErrorContains(t, err, `unknown commit "does-not-exist"`)
+}
@@ -0,0 +1,229 @@
+// Package localeval runs the section rule manager outside of the webhook server, on an MR
+// fetched from GitLab or on a local before/after directory pair, for the evaluate command.
+package localeval
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pmezard/go-difflib/difflib"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/redhat-data-and-ai/naysayer/internal/utils"
+)
+
+// Branches of a directory evaluation: before/ is the target branch and after/ the source branch
+const (
+	TargetBranch = "main"
+	SourceBranch = "local-changes"
+)
+
+// EvaluateMR evaluates MR mrIID of projectID with the rules of rulesPath, reading the MR and
+// its files through client. GitLab calls the client cannot answer are returned as errors.
+func EvaluateMR(ctx context.Context, client gitlab.GitLabClient, projectID, mrIID int, rulesPath string) (result *shared.RuleEvaluation, err error) {
+	details, err := client.GetMRDetails(ctx, projectID, mrIID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch MR !%d: %w", mrIID, err)
+	}
+	changes, err := client.FetchMRChanges(ctx, projectID, mrIID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch changes of MR !%d: %w", mrIID, err)
+	}
+	if len(changes) == 0 {
+		return nil, fmt.Errorf("MR !%d has no file changes", mrIID)
+	}
+
+	manager, err := rules.GetGlobalRegistry().CreateSectionBasedRuleManager(client, rulesPath)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			result = nil
+			err = fmt.Errorf("evaluation made a GitLab call that is not available locally: %v", r)
+		}
+	}()
+
+	mrInfo := &gitlab.MRInfo{
+		ProjectID:    projectID,
+		MRIID:        mrIID,
+		Title:        details.Title,
+		SourceBranch: details.SourceBranch,
+		TargetBranch: details.TargetBranch,
+		State:        details.State,
+		CreatedAt:    details.CreatedAt,
+		Draft:        details.IsDraft(),
+		HeadSHA:      details.Sha,
+		ReceivedAt:   time.Now(),
+	}
+	if details.Author != nil {
+		mrInfo.Author = details.Author.Username
+	}
+	return manager.EvaluateAll(&shared.MRContext{
+		ProjectID: projectID,
+		MRIID:     mrIID,
+		Changes:   changes,
+		MRInfo:    mrInfo,
+		Ctx:       ctx,
+	}), nil
+}
+
+// EvaluateDir evaluates the changes from dir/before to dir/after, laid out like the e2e
+// scenarios, as an MR of projectID (zero when the project overrides do not matter)
+func EvaluateDir(ctx context.Context, dir string, projectID int, rulesPath string) (*shared.RuleEvaluation, error) {
+	afterDir := filepath.Join(dir, "after")
+	if _, err := os.Stat(afterDir); err != nil {
+		return nil, fmt.Errorf("%s must contain an after/ directory with the changed files: %w", dir, err)
+	}
+	return EvaluateMR(ctx, NewDirClient(filepath.Join(dir, "before"), afterDir), projectID, 1, rulesPath)
+}
+
+// dirClient answers the reads of an evaluation from a before/ and an after/ directory
+// instead of GitLab. Only MR and file reads are implemented; any other call panics and is
+// reported by EvaluateMR.
+type dirClient struct {
+	gitlab.GitLabClient
+
+	beforeDir string
+	afterDir  string
+}
+
+// NewDirClient returns a GitLab client serving beforeDir as the target branch and afterDir
+// as the source branch of a single MR. A missing beforeDir means every file is new.
+func NewDirClient(beforeDir, afterDir string) gitlab.GitLabClient {
+	return &dirClient{beforeDir: beforeDir, afterDir: afterDir}
+}
+
+func (c *dirClient) FetchFileContent(ctx context.Context, projectID int, filePath, ref string) (*gitlab.FileContent, error) {
+	dir := c.afterDir
+	if ref == TargetBranch {
+		dir = c.beforeDir
+	}
+	content, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(filePath))) // #nosec G304 - reading the directories given on the command line
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, &gitlab.APIError{StatusCode: http.StatusNotFound, Message: fmt.Sprintf("file %s not found at %s", filePath, ref)}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &gitlab.FileContent{
+		FileName: filepath.Base(filePath),
+		FilePath: filePath,
+		Size:     len(content),
+		Content:  string(content),
+		Ref:      ref,
+	}, nil
+}
+
+func (c *dirClient) GetMRDetails(ctx context.Context, projectID, mrIID int) (*gitlab.MRDetails, error) {
+	return &gitlab.MRDetails{
+		IID:             mrIID,
+		Title:           "Local changes",
+		State:           utils.MRStateOpened,
+		ProjectID:       projectID,
+		SourceProjectID: projectID,
+		TargetProjectID: projectID,
+		SourceBranch:    SourceBranch,
+		TargetBranch:    TargetBranch,
+	}, nil
+}
+
+func (c *dirClient) GetMRTargetBranch(ctx context.Context, projectID, mrIID int) (string, error) {
+	return TargetBranch, nil
+}
+
+func (c *dirClient) FetchMRChanges(ctx context.Context, projectID, mrIID int) ([]gitlab.FileChange, error) {
+	return CompareDirs(c.beforeDir, c.afterDir)
+}
+
+func (c *dirClient) GetCurrentBotUsername(ctx context.Context) (string, error) {
+	return "", errors.New("no bot user in a local evaluation")
+}
+
+// CompareDirs returns the added, modified and deleted files from beforeDir to afterDir as
+// MR changes with unified diffs, sorted by path. A missing beforeDir is empty.
+func CompareDirs(beforeDir, afterDir string) ([]gitlab.FileChange, error) {
+	before, err := readTree(beforeDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", beforeDir, err)
+	}
+	after, err := readTree(afterDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", afterDir, err)
+	}
+
+	paths := make([]string, 0, len(before)+len(after))
+	for path := range after {
+		paths = append(paths, path)
+	}
+	for path := range before {
+		if _, ok := after[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	var changes []gitlab.FileChange
+	for _, path := range paths {
+		oldContent, existed := before[path]
+		newContent, exists := after[path]
+		switch {
+		case !existed:
+			changes = append(changes, gitlab.FileChange{NewPath: path, NewFile: true, Diff: unifiedDiff("", newContent)})
+		case !exists:
+			changes = append(changes, gitlab.FileChange{OldPath: path, DeletedFile: true, Diff: unifiedDiff(oldContent, "")})
+		case oldContent != newContent:
+			changes = append(changes, gitlab.FileChange{OldPath: path, NewPath: path, Diff: unifiedDiff(oldContent, newContent)})
+		}
+	}
+	return changes, nil
+}
+
+// readTree maps the slash-separated paths of the files below root to their content
+func readTree(root string) (map[string]string, error) {
+	files := make(map[string]string)
+	if _, err := os.Stat(root); errors.Is(err, fs.ErrNotExist) {
+		return files, nil
+	}
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		content, err := os.ReadFile(path) // #nosec G304 - walking the directories given on the command line
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(relPath)] = string(content)
+		return nil
+	})
+	return files, err
+}
+
+// unifiedDiff returns the hunks of a git diff from oldContent to newContent, without the
+// file headers GitLab reports separately
+func unifiedDiff(oldContent, newContent string) string {
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:       difflib.SplitLines(oldContent),
+		B:       difflib.SplitLines(newContent),
+		Context: 3,
+	})
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSuffix(diff, "\n")
+}
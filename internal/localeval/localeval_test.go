@@ -0,0 +1,100 @@
+package localeval
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+)
+
+const e2eRules = "../../e2e/rules.yaml"
+
+func writeFile(t *testing.T, path, content string) {
+	assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestCompareDirs(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "before", "docs", "README.md"), "a\nb\n")
+	writeFile(t, filepath.Join(dir, "before", "docs", "old.md"), "gone\n")
+	writeFile(t, filepath.Join(dir, "before", "docs", "same.md"), "same\n")
+	writeFile(t, filepath.Join(dir, "after", "docs", "README.md"), "a\nc\n")
+	writeFile(t, filepath.Join(dir, "after", "docs", "new.md"), "new\n")
+	writeFile(t, filepath.Join(dir, "after", "docs", "same.md"), "same\n")
+
+	changes, err := CompareDirs(filepath.Join(dir, "before"), filepath.Join(dir, "after"))
+	assert.NoError(t, err)
+	if assert.Len(t, changes, 3) {
+		assert.Equal(t, gitlab.FileChange{OldPath: "docs/README.md", NewPath: "docs/README.md", Diff: "@@ -1,3 +1,3 @@\n a\n-b\n+c\n "}, changes[0])
+		assert.Equal(t, "docs/new.md", changes[1].NewPath)
+		assert.True(t, changes[1].NewFile)
+		assert.Equal(t, "docs/old.md", changes[2].OldPath)
+		assert.True(t, changes[2].DeletedFile)
+	}
+
+	changes, err = CompareDirs(filepath.Join(dir, "missing"), filepath.Join(dir, "after"))
+	assert.NoError(t, err)
+	assert.Len(t, changes, 3, "a missing before directory makes every file new")
+}
+
+func TestDirClient_FetchFileContent(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "before", "product.yaml"), "before")
+	writeFile(t, filepath.Join(dir, "after", "product.yaml"), "after")
+	client := NewDirClient(filepath.Join(dir, "before"), filepath.Join(dir, "after"))
+
+	content, err := client.FetchFileContent(context.Background(), 1, "product.yaml", TargetBranch)
+	assert.NoError(t, err)
+	assert.Equal(t, "before", content.Content)
+	content, err = client.FetchFileContent(context.Background(), 1, "product.yaml", SourceBranch)
+	assert.NoError(t, err)
+	assert.Equal(t, "after", content.Content)
+
+	_, err = client.FetchFileContent(context.Background(), 1, "missing.yaml", SourceBranch)
+	assert.ErrorIs(t, err, gitlab.ErrNotFound)
+}
+
+func TestEvaluateDir(t *testing.T) {
+	result, err := EvaluateDir(context.Background(), "../../e2e/testdata/scenarios/05_metadata_readme", 0, e2eRules)
+	assert.NoError(t, err)
+	assert.Equal(t, shared.Approve, result.FinalDecision.Type)
+	assert.Contains(t, result.FileValidations, "dataproducts/analytics/README.md")
+
+	result, err = EvaluateDir(context.Background(), "../../e2e/testdata/scenarios/02_warehouse_increase", 0, e2eRules)
+	assert.NoError(t, err)
+	assert.Equal(t, shared.ManualReview, result.FinalDecision.Type)
+	assert.Equal(t, "Warehouse changes require manual review", result.FinalDecision.Reason)
+
+	_, err = EvaluateDir(context.Background(), t.TempDir(), 0, e2eRules)
+	assert.ErrorContains(t, err, "after/ directory")
+
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "before", "README.md"), "same\n")
+	writeFile(t, filepath.Join(dir, "after", "README.md"), "same\n")
+	_, err = EvaluateDir(context.Background(), dir, 0, e2eRules)
+	assert.ErrorContains(t, err, "no file changes")
+}
+
+// unsupportedClient answers MR reads but nothing else, like a client missing a GitLab call
+type unsupportedClient struct {
+	gitlab.GitLabClient
+}
+
+func (c *unsupportedClient) GetMRDetails(ctx context.Context, projectID, mrIID int) (*gitlab.MRDetails, error) {
+	return &gitlab.MRDetails{IID: mrIID, SourceBranch: SourceBranch, TargetBranch: TargetBranch}, nil
+}
+
+func (c *unsupportedClient) FetchMRChanges(ctx context.Context, projectID, mrIID int) ([]gitlab.FileChange, error) {
+	return []gitlab.FileChange{{NewPath: "dataproducts/analytics/README.md", Diff: "@@ -1 +1 @@\n-a\n+b"}}, nil
+}
+
+func TestEvaluateMR_ReportsUnavailableCalls(t *testing.T) {
+	_, err := EvaluateMR(context.Background(), &unsupportedClient{}, 5, 9, e2eRules)
+	assert.ErrorContains(t, err, "not available locally")
+}
@@ -0,0 +1,103 @@
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"regexp"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// CorrelationHeader carries the correlation ID of a webhook request. Callers may set it to
+// tie naysayer's logs to their own; responses and GitLab API requests carry it.
+const CorrelationHeader = "X-Correlation-ID"
+
+// CorrelationField is the log field holding the correlation ID
+const CorrelationField = "correlation_id"
+
+// validCorrelationID bounds correlation IDs accepted from callers, so they are safe to log
+// and to embed in MR comments
+var validCorrelationID = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+type correlationKey struct{}
+
+// NewCorrelationID returns a random correlation ID
+func NewCorrelationID() string {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return time.Now().UTC().Format("20060102150405.000000000")
+	}
+	return hex.EncodeToString(id)
+}
+
+// WithCorrelationID returns a context carrying the correlation ID; an empty ID returns ctx
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	if id == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, correlationKey{}, id)
+}
+
+// CorrelationID returns the correlation ID of ctx, or "" when it carries none
+func CorrelationID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(correlationKey{}).(string)
+	return id
+}
+
+// FromContext returns the global logger adding the correlation ID of ctx to every entry
+func FromContext(ctx context.Context) *Logger {
+	if defaultLogger == nil {
+		return nopLogger
+	}
+	if id := CorrelationID(ctx); id != "" {
+		return defaultLogger.With(zap.String(CorrelationField, id))
+	}
+	return defaultLogger
+}
+
+// nopLogger is returned by FromContext before the global logger is initialized
+var nopLogger = &Logger{zap: zap.NewNop(), atom: zap.NewAtomicLevel()}
+
+// Middleware assigns every request a correlation ID, reusing a valid X-Correlation-ID sent
+// by the caller. The ID is set on the request header, so handlers running the request
+// later on the job queue see it too, on the response header and on the user context, and
+// each request is logged with it.
+func Middleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id := c.Get(CorrelationHeader)
+		if !validCorrelationID.MatchString(id) {
+			id = NewCorrelationID()
+		}
+		c.Request().Header.Set(CorrelationHeader, id)
+		c.Set(CorrelationHeader, id)
+		c.SetUserContext(WithCorrelationID(c.UserContext(), id))
+
+		started := time.Now()
+		err := c.Next()
+		FromContext(c.UserContext()).Info("HTTP request",
+			zap.String("method", c.Method()),
+			zap.String("path", c.Path()),
+			zap.Int("status", c.Response().StatusCode()),
+			zap.Duration("latency", time.Since(started)))
+		return err
+	}
+}
+
+// RequestContext returns the user context of c carrying the correlation ID of its
+// X-Correlation-ID header, for handlers running requests outside the middleware chain
+func RequestContext(c *fiber.Ctx) context.Context {
+	ctx := c.UserContext()
+	if CorrelationID(ctx) != "" {
+		return ctx
+	}
+	if id := c.Get(CorrelationHeader); validCorrelationID.MatchString(id) {
+		return WithCorrelationID(ctx, id)
+	}
+	return ctx
+}
@@ -0,0 +1,79 @@
+package logging
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddleware_AssignsCorrelationID(t *testing.T) {
+	logger, logs := observedLogger(INFO)
+	useDefault(t, logger)
+
+	var seen string
+	app := fiber.New()
+	app.Use(Middleware())
+	app.Post("/webhook", func(c *fiber.Ctx) error {
+		seen = CorrelationID(c.UserContext())
+		FromContext(c.UserContext()).Info("Handling webhook")
+		return c.SendStatus(200)
+	})
+
+	// A valid caller ID is kept
+	req := httptest.NewRequest("POST", "/webhook", nil)
+	req.Header.Set(CorrelationHeader, "deploy-42.a")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, "deploy-42.a", seen)
+	assert.Equal(t, "deploy-42.a", resp.Header.Get(CorrelationHeader))
+
+	entries := logs.AllUntimed()
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "deploy-42.a", entries[0].ContextMap()[CorrelationField])
+	assert.Equal(t, "HTTP request", entries[1].Message)
+	assert.Equal(t, "deploy-42.a", entries[1].ContextMap()[CorrelationField])
+	assert.Equal(t, int64(200), entries[1].ContextMap()["status"])
+
+	// Missing and unsafe IDs are replaced
+	for _, id := range []string{"", "<script>", "a b"} {
+		req := httptest.NewRequest("POST", "/webhook", nil)
+		req.Header.Set(CorrelationHeader, id)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Regexp(t, `^[0-9a-f]{16}$`, seen)
+		assert.Equal(t, seen, resp.Header.Get(CorrelationHeader))
+	}
+}
+
+func TestRequestContext(t *testing.T) {
+	app := fiber.New()
+	var ids []string
+	app.Get("/", func(c *fiber.Ctx) error {
+		ids = append(ids, CorrelationID(RequestContext(c)))
+		c.SetUserContext(WithCorrelationID(c.UserContext(), "from-context"))
+		ids = append(ids, CorrelationID(RequestContext(c)))
+		return nil
+	})
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set(CorrelationHeader, "from-header")
+	_, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"from-header", "from-context"}, ids)
+}
+
+func TestFromContext(t *testing.T) {
+	useDefault(t, nil)
+	assert.NotNil(t, FromContext(context.Background()), "logging before initialization is a no-op")
+
+	logger, logs := observedLogger(INFO)
+	useDefault(t, logger)
+	FromContext(context.Background()).Info("no ID")
+	FromContext(WithCorrelationID(context.Background(), "")).Info("empty ID")
+	for _, entry := range logs.AllUntimed() {
+		assert.NotContains(t, entry.ContextMap(), CorrelationField)
+	}
+}
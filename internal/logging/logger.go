@@ -1,6 +1,7 @@
 package logging
 
 import (
+	"fmt"
 	"os"
 	"strings"
 
@@ -22,13 +23,15 @@ const (
 type Logger struct {
 	zap   *zap.Logger
 	level LogLevel
+	atom  zap.AtomicLevel // Shared by loggers derived with With, changed at runtime by SetLevel
 }
 
 // NewLogger creates a new Zap-based logger
 func NewLogger(level LogLevel, component string) *Logger {
 	// Configure Zap
+	atom := zap.NewAtomicLevelAt(logLevelToZap(level))
 	config := zap.NewProductionConfig()
-	config.Level = zap.NewAtomicLevelAt(logLevelToZap(level))
+	config.Level = atom
 	config.Development = false
 	config.Encoding = "json"
 
@@ -38,35 +41,56 @@ func NewLogger(level LogLevel, component string) *Logger {
 		"service":   "naysayer",
 	}
 
-	// Build the logger
-	zapLogger, err := config.Build()
+	// Build the logger; callers are reported past the Logger method and log helper
+	zapLogger, err := config.Build(zap.AddCallerSkip(2))
 	if err != nil {
 		// Fallback to development logger if production config fails
-		zapLogger, _ = zap.NewDevelopment()
+		zapLogger, _ = zap.NewDevelopment(zap.AddCallerSkip(2), zap.IncreaseLevel(atom))
 	}
 
 	return &Logger{
 		zap:   zapLogger,
 		level: level,
+		atom:  atom,
 	}
 }
 
-// GetLogLevel parses a log level string
-func GetLogLevel(level string) LogLevel {
-	switch strings.ToLower(level) {
+// ParseLogLevel parses a log level string, rejecting unknown levels
+func ParseLogLevel(level string) (LogLevel, error) {
+	switch strings.ToLower(strings.TrimSpace(level)) {
 	case "debug":
-		return DEBUG
+		return DEBUG, nil
 	case "info":
-		return INFO
+		return INFO, nil
 	case "warn", "warning":
-		return WARN
+		return WARN, nil
 	case "error":
-		return ERROR
+		return ERROR, nil
 	default:
-		return INFO
+		return INFO, fmt.Errorf("unknown log level %q (expected debug, info, warn or error)", level)
+	}
+}
+
+// String returns the name of the level
+func (l LogLevel) String() string {
+	switch l {
+	case DEBUG:
+		return "debug"
+	case WARN:
+		return "warn"
+	case ERROR:
+		return "error"
+	default:
+		return "info"
 	}
 }
 
+// GetLogLevel parses a log level string, defaulting to INFO for unknown levels
+func GetLogLevel(level string) LogLevel {
+	parsed, _ := ParseLogLevel(level)
+	return parsed
+}
+
 // logLevelToZap converts our LogLevel to zap level
 func logLevelToZap(level LogLevel) zapcore.Level {
 	switch level {
@@ -83,50 +107,77 @@ func logLevelToZap(level LogLevel) zapcore.Level {
 	}
 }
 
-// Info logs info messages
-func (l *Logger) Info(message string, args ...interface{}) {
-	if len(args) == 0 {
-		l.zap.Info(message)
-	} else {
-		l.zap.Sugar().Infof(message, args...)
+// log writes message at level with structured fields
+func (l *Logger) log(level zapcore.Level, message string, fields []zap.Field) {
+	if ce := l.zap.Check(level, message); ce != nil {
+		ce.Write(fields...)
 	}
 }
 
+// With returns a logger adding fields to every entry
+func (l *Logger) With(fields ...zap.Field) *Logger {
+	return &Logger{zap: l.zap.With(fields...), level: l.level, atom: l.atom}
+}
+
+// Debug logs debug messages
+func (l *Logger) Debug(message string, fields ...zap.Field) {
+	l.log(zapcore.DebugLevel, message, fields)
+}
+
+// Info logs info messages
+func (l *Logger) Info(message string, fields ...zap.Field) {
+	l.log(zapcore.InfoLevel, message, fields)
+}
+
 // Warn logs warning messages
-func (l *Logger) Warn(message string, args ...interface{}) {
-	if len(args) == 0 {
-		l.zap.Warn(message)
-	} else {
-		l.zap.Sugar().Warnf(message, args...)
-	}
+func (l *Logger) Warn(message string, fields ...zap.Field) {
+	l.log(zapcore.WarnLevel, message, fields)
 }
 
 // Error logs error messages
-func (l *Logger) Error(message string, args ...interface{}) {
-	if len(args) == 0 {
-		l.zap.Error(message)
-	} else {
-		l.zap.Sugar().Errorf(message, args...)
-	}
+func (l *Logger) Error(message string, fields ...zap.Field) {
+	l.log(zapcore.ErrorLevel, message, fields)
 }
 
 // MR-specific logging helpers for better traceability
 func (l *Logger) MRInfo(mrID int, message string, fields ...zap.Field) {
-	allFields := append([]zap.Field{zap.Int("mr_id", mrID)}, fields...)
-	l.zap.Info(message, allFields...)
+	l.log(zapcore.InfoLevel, message, mrFields(mrID, nil, fields))
 }
 
 func (l *Logger) MRError(mrID int, message string, err error, fields ...zap.Field) {
-	allFields := append([]zap.Field{
-		zap.Int("mr_id", mrID),
-		zap.Error(err),
-	}, fields...)
-	l.zap.Error(message, allFields...)
+	l.log(zapcore.ErrorLevel, message, mrFields(mrID, err, fields))
 }
 
 func (l *Logger) MRWarn(mrID int, message string, fields ...zap.Field) {
-	allFields := append([]zap.Field{zap.Int("mr_id", mrID)}, fields...)
-	l.zap.Warn(message, allFields...)
+	l.log(zapcore.WarnLevel, message, mrFields(mrID, nil, fields))
+}
+
+// mrFields returns the fields of an MR log entry
+func mrFields(mrID int, err error, fields []zap.Field) []zap.Field {
+	all := append(make([]zap.Field, 0, len(fields)+2), zap.Int("mr_id", mrID))
+	if err != nil {
+		all = append(all, zap.Error(err))
+	}
+	return append(all, fields...)
+}
+
+// Level returns the level entries are currently logged at
+func (l *Logger) Level() LogLevel {
+	switch l.atom.Level() {
+	case zapcore.DebugLevel:
+		return DEBUG
+	case zapcore.WarnLevel:
+		return WARN
+	case zapcore.ErrorLevel:
+		return ERROR
+	default:
+		return INFO
+	}
+}
+
+// SetLevel changes the level of the logger and of every logger derived from it
+func (l *Logger) SetLevel(level LogLevel) {
+	l.atom.SetLevel(logLevelToZap(level))
 }
 
 // Sync flushes any buffered log entries
@@ -143,42 +194,68 @@ func InitLogger(level string, component string) {
 	defaultLogger = NewLogger(logLevel, component)
 }
 
-// Global logging functions (only the ones actually used)
-func Info(message string, args ...interface{}) {
+// Global logging functions logging structured fields
+func Debug(message string, fields ...zap.Field) {
 	if defaultLogger != nil {
-		defaultLogger.Info(message, args...)
+		defaultLogger.log(zapcore.DebugLevel, message, fields)
 	}
 }
 
-func Warn(message string, args ...interface{}) {
+func Info(message string, fields ...zap.Field) {
 	if defaultLogger != nil {
-		defaultLogger.Warn(message, args...)
+		defaultLogger.log(zapcore.InfoLevel, message, fields)
 	}
 }
 
-func Error(message string, args ...interface{}) {
+func Warn(message string, fields ...zap.Field) {
 	if defaultLogger != nil {
-		defaultLogger.Error(message, args...)
+		defaultLogger.log(zapcore.WarnLevel, message, fields)
+	}
+}
+
+func Error(message string, fields ...zap.Field) {
+	if defaultLogger != nil {
+		defaultLogger.log(zapcore.ErrorLevel, message, fields)
 	}
 }
 
 // MR-specific global helpers
 func MRInfo(mrID int, message string, fields ...zap.Field) {
 	if defaultLogger != nil {
-		defaultLogger.MRInfo(mrID, message, fields...)
+		defaultLogger.log(zapcore.InfoLevel, message, mrFields(mrID, nil, fields))
 	}
 }
 
 func MRError(mrID int, message string, err error, fields ...zap.Field) {
 	if defaultLogger != nil {
-		defaultLogger.MRError(mrID, message, err, fields...)
+		defaultLogger.log(zapcore.ErrorLevel, message, mrFields(mrID, err, fields))
 	}
 }
 
 func MRWarn(mrID int, message string, fields ...zap.Field) {
 	if defaultLogger != nil {
-		defaultLogger.MRWarn(mrID, message, fields...)
+		defaultLogger.log(zapcore.WarnLevel, message, mrFields(mrID, nil, fields))
+	}
+}
+
+// SetLevel changes the level of the global logger at runtime
+func SetLevel(level string) error {
+	parsed, err := ParseLogLevel(level)
+	if err != nil {
+		return err
+	}
+	if defaultLogger != nil {
+		defaultLogger.SetLevel(parsed)
+	}
+	return nil
+}
+
+// CurrentLevel returns the level of the global logger
+func CurrentLevel() LogLevel {
+	if defaultLogger == nil {
+		return INFO
 	}
+	return defaultLogger.Level()
 }
 
 // GetLogger returns the default logger instance
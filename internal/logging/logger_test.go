@@ -0,0 +1,70 @@
+package logging
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// observedLogger returns a logger at level recording its entries
+func observedLogger(level LogLevel) (*Logger, *observer.ObservedLogs) {
+	atom := zap.NewAtomicLevelAt(logLevelToZap(level))
+	core, logs := observer.New(atom)
+	return &Logger{zap: zap.New(core), level: level, atom: atom}, logs
+}
+
+// useDefault replaces the global logger for the duration of the test
+func useDefault(t *testing.T, logger *Logger) {
+	previous := defaultLogger
+	defaultLogger = logger
+	t.Cleanup(func() { defaultLogger = previous })
+}
+
+func TestLogger_LogsStructuredFields(t *testing.T) {
+	logger, logs := observedLogger(DEBUG)
+
+	logger.Info("Processed MR", zap.Int("mr_iid", 42), zap.String("project", "group/project"))
+	logger.Warn("No fields with 100% literal text")
+	logger.MRError(7, "Rule failed", assert.AnError, zap.String("rule", "warehouse_rule"))
+
+	entries := logs.AllUntimed()
+	assert.Len(t, entries, 3)
+	assert.Equal(t, "Processed MR", entries[0].Message)
+	assert.Equal(t, map[string]interface{}{"mr_iid": int64(42), "project": "group/project"}, entries[0].ContextMap())
+	assert.Equal(t, "No fields with 100% literal text", entries[1].Message)
+	assert.Equal(t, "Rule failed", entries[2].Message)
+	assert.Equal(t, int64(7), entries[2].ContextMap()["mr_id"])
+	assert.Equal(t, "warehouse_rule", entries[2].ContextMap()["rule"])
+	assert.Equal(t, assert.AnError.Error(), entries[2].ContextMap()["error"])
+}
+
+func TestParseLogLevel(t *testing.T) {
+	for input, want := range map[string]LogLevel{"debug": DEBUG, " INFO ": INFO, "warning": WARN, "warn": WARN, "error": ERROR} {
+		level, err := ParseLogLevel(input)
+		assert.NoError(t, err, input)
+		assert.Equal(t, want, level, input)
+	}
+	_, err := ParseLogLevel("verbose")
+	assert.Error(t, err)
+	assert.Equal(t, INFO, GetLogLevel("verbose"))
+}
+
+func TestSetLevel_AppliesToDerivedLoggers(t *testing.T) {
+	logger, logs := observedLogger(INFO)
+	useDefault(t, logger)
+	derived := FromContext(WithCorrelationID(context.Background(), "abc"))
+
+	derived.Debug("hidden")
+	assert.NoError(t, SetLevel("debug"))
+	assert.Equal(t, DEBUG, CurrentLevel())
+	derived.Debug("shown")
+	assert.Error(t, SetLevel("verbose"))
+	assert.Equal(t, DEBUG, CurrentLevel())
+
+	entries := logs.AllUntimed()
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "shown", entries[0].Message)
+}
@@ -0,0 +1,87 @@
+package mergepolicy
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+)
+
+// Client is the subset of the GitLab client needed to read MR merge settings
+type Client interface {
+	GetMRDetails(ctx context.Context, projectID, mrIID int) (*gitlab.MRDetails, error)
+}
+
+// ProjectGetter reads project settings. The GitLab client implements it; without it the
+// project-level settings (squash enforcement, merge method) cannot be checked.
+type ProjectGetter interface {
+	GetProject(ctx context.Context, projectID int) (*gitlab.Project, error)
+}
+
+// Violation is a merge setting that does not comply with the policy
+type Violation struct {
+	Setting string // Short name of the setting, e.g. "squash"
+	Fix     string // Toggle change that makes the MR compliant (markdown)
+}
+
+// Checker validates MR merge settings against the configured policy
+type Checker struct {
+	client Client
+	policy config.MergePolicyConfig
+}
+
+// NewChecker creates a merge settings checker
+func NewChecker(client Client, policy config.MergePolicyConfig) *Checker {
+	return &Checker{client: client, policy: policy}
+}
+
+// Check returns the merge settings of the MR that violate the policy
+func (c *Checker) Check(ctx context.Context, projectID, mrIID int) ([]Violation, error) {
+	details, err := c.client.GetMRDetails(ctx, projectID, mrIID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get MR details: %w", err)
+	}
+	if details == nil {
+		return nil, fmt.Errorf("empty MR details response")
+	}
+
+	var project *gitlab.Project
+	if getter, ok := c.client.(ProjectGetter); ok && (c.policy.RequireSquash || c.policy.ForbidMergeCommits) {
+		if project, err = getter.GetProject(ctx, projectID); err != nil {
+			return nil, fmt.Errorf("failed to get project settings: %w", err)
+		}
+	}
+
+	var violations []Violation
+	if c.policy.RequireSquash && !details.Squash && (project == nil || project.SquashOption != "always") {
+		fix := "Enable **Squash commits when merge request is accepted** on this MR"
+		if project != nil && project.SquashOption == "never" {
+			fix = "Allow squashing in the project settings (**Settings → Merge requests → Squash commits when merging**), then enable **Squash commits when merge request is accepted** on this MR"
+		}
+		violations = append(violations, Violation{Setting: "squash", Fix: fix})
+	}
+	if c.policy.RequireDeleteSourceBranch && !details.RemoveSourceBranch {
+		violations = append(violations, Violation{
+			Setting: "delete source branch",
+			Fix:     "Enable **Delete source branch when merge request is accepted** on this MR",
+		})
+	}
+	if c.policy.ForbidMergeCommits && project != nil && project.MergeMethod != "ff" {
+		violations = append(violations, Violation{
+			Setting: "merge commits",
+			Fix:     fmt.Sprintf("Set the project merge method to **Fast-forward merge** (**Settings → Merge requests → Merge method**), currently `%s`", project.MergeMethod),
+		})
+	}
+	return violations, nil
+}
+
+// Reason summarizes violations for a manual review decision
+func Reason(violations []Violation) string {
+	settings := make([]string, 0, len(violations))
+	for _, v := range violations {
+		settings = append(settings, v.Setting)
+	}
+	return "Merge settings do not comply with project policy (" + strings.Join(settings, ", ") + ") - update them to allow approval"
+}
@@ -0,0 +1,93 @@
+package mergepolicy
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeClient returns fixed MR details
+type fakeClient struct {
+	details *gitlab.MRDetails
+	err     error
+}
+
+func (c *fakeClient) GetMRDetails(ctx context.Context, projectID, mrIID int) (*gitlab.MRDetails, error) {
+	return c.details, c.err
+}
+
+// fakeProjectClient also returns project settings
+type fakeProjectClient struct {
+	fakeClient
+	project *gitlab.Project
+}
+
+func (c *fakeProjectClient) GetProject(ctx context.Context, projectID int) (*gitlab.Project, error) {
+	return c.project, nil
+}
+
+var strictPolicy = config.MergePolicyConfig{RequireSquash: true, RequireDeleteSourceBranch: true, ForbidMergeCommits: true}
+
+func settings(violations []Violation) []string {
+	out := make([]string, 0, len(violations))
+	for _, v := range violations {
+		out = append(out, v.Setting)
+	}
+	return out
+}
+
+func TestChecker_Check(t *testing.T) {
+	ctx := context.Background()
+	client := &fakeProjectClient{
+		fakeClient: fakeClient{details: &gitlab.MRDetails{}},
+		project:    &gitlab.Project{MergeMethod: "merge", SquashOption: "default_off"},
+	}
+
+	violations, err := NewChecker(client, strictPolicy).Check(ctx, 1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"squash", "delete source branch", "merge commits"}, settings(violations))
+	assert.Contains(t, violations[2].Fix, "currently `merge`")
+
+	// Toggled on the MR, fast-forward project
+	client.details = &gitlab.MRDetails{Squash: true, RemoveSourceBranch: true}
+	client.project = &gitlab.Project{MergeMethod: "ff"}
+	violations, err = NewChecker(client, strictPolicy).Check(ctx, 1, 2)
+	assert.NoError(t, err)
+	assert.Empty(t, violations)
+
+	// Squashing enforced by the project
+	client.details = &gitlab.MRDetails{RemoveSourceBranch: true}
+	client.project = &gitlab.Project{MergeMethod: "ff", SquashOption: "always"}
+	violations, _ = NewChecker(client, strictPolicy).Check(ctx, 1, 2)
+	assert.Empty(t, violations)
+
+	// Squashing disabled by the project needs a settings change first
+	client.project = &gitlab.Project{MergeMethod: "ff", SquashOption: "never"}
+	violations, _ = NewChecker(client, strictPolicy).Check(ctx, 1, 2)
+	assert.Equal(t, []string{"squash"}, settings(violations))
+	assert.Contains(t, violations[0].Fix, "Allow squashing")
+
+	// Only configured settings are checked
+	violations, _ = NewChecker(client, config.MergePolicyConfig{RequireDeleteSourceBranch: true}).Check(ctx, 1, 2)
+	assert.Empty(t, violations)
+}
+
+func TestChecker_Check_WithoutProjectSettings(t *testing.T) {
+	ctx := context.Background()
+	// Without project access the merge method cannot be checked
+	violations, err := NewChecker(&fakeClient{details: &gitlab.MRDetails{}}, strictPolicy).Check(ctx, 1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"squash", "delete source branch"}, settings(violations))
+
+	_, err = NewChecker(&fakeClient{err: fmt.Errorf("boom")}, strictPolicy).Check(ctx, 1, 2)
+	assert.Error(t, err)
+}
+
+func TestReason(t *testing.T) {
+	reason := Reason([]Violation{{Setting: "squash"}, {Setting: "merge commits"}})
+	assert.Equal(t, "Merge settings do not comply with project policy (squash, merge commits) - update them to allow approval", reason)
+}
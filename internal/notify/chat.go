@@ -0,0 +1,59 @@
+package notify
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// SlackSink posts notifications as messages to a Slack incoming webhook
+type SlackSink struct {
+	url    string
+	client *http.Client
+}
+
+// NewSlackSink creates a sink posting to the Slack incoming webhook url
+func NewSlackSink(url string) *SlackSink {
+	return &SlackSink{url: url, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// Notify posts the notification text
+func (s *SlackSink) Notify(n Notification) error {
+	return postJSON(s.client, s.url, map[string]string{"text": chatText(n)})
+}
+
+// GoogleChatSink posts notifications as messages to a Google Chat space webhook
+type GoogleChatSink struct {
+	url    string
+	client *http.Client
+}
+
+// NewGoogleChatSink creates a sink posting to the Google Chat webhook url
+func NewGoogleChatSink(url string) *GoogleChatSink {
+	return &GoogleChatSink{url: url, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// Notify posts the notification text
+func (s *GoogleChatSink) Notify(n Notification) error {
+	return postJSON(s.client, s.url, map[string]string{"text": chatText(n)})
+}
+
+// chatText is the rendered template text of the notification, or its title, message and MR
+// in the bold-title markup Slack and Google Chat share
+func chatText(n Notification) string {
+	if n.Text != "" {
+		return n.Text
+	}
+	var text strings.Builder
+	fmt.Fprintf(&text, "*%s*", n.Title)
+	if n.Message != "" {
+		fmt.Fprintf(&text, "\n%s", n.Message)
+	}
+	if n.MRIID > 0 {
+		fmt.Fprintf(&text, "\nProject %d, MR !%d", n.ProjectID, n.MRIID)
+	} else if n.ProjectID > 0 {
+		fmt.Fprintf(&text, "\nProject %d", n.ProjectID)
+	}
+	return text.String()
+}
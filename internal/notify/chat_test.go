@@ -0,0 +1,37 @@
+package notify
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func chatServer(t *testing.T, received *map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		_ = json.NewDecoder(r.Body).Decode(received)
+	}))
+}
+
+func TestSlackSink_Notify(t *testing.T) {
+	var received map[string]string
+	server := chatServer(t, &received)
+	defer server.Close()
+
+	err := NewSlackSink(server.URL).Notify(Notification{Event: EventManualReview, Title: "Manual review required", Message: "warehouse size increased", ProjectID: 5, MRIID: 9})
+	assert.NoError(t, err)
+	assert.Equal(t, "*Manual review required*\nwarehouse size increased\nProject 5, MR !9", received["text"])
+}
+
+func TestGoogleChatSink_Notify(t *testing.T) {
+	var received map[string]string
+	server := chatServer(t, &received)
+	defer server.Close()
+
+	err := NewGoogleChatSink(server.URL).Notify(Notification{Event: EventStaleMRClosed, Title: "Closed", Text: "2 stale MRs closed"})
+	assert.NoError(t, err)
+	assert.Equal(t, "2 stale MRs closed", received["text"], "rendered template text is sent as is")
+}
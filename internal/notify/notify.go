@@ -0,0 +1,122 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"go.uber.org/zap"
+)
+
+// Severity levels for notifications
+const (
+	SeverityInfo    = "info"
+	SeverityWarning = "warning"
+)
+
+// Events about MRs that need attention; they are only sent when NOTIFY_ROUTES routes them
+const (
+	EventManualReview   = "manual_review"
+	EventRebaseConflict = "rebase_conflict"
+	EventStaleMRClosed  = "stale_mr_closed"
+)
+
+// Notification is an operator-facing alert about an MR or background job
+type Notification struct {
+	Event     string            `json:"event"`
+	Severity  string            `json:"severity"`
+	Title     string            `json:"title"`
+	Message   string            `json:"message"`
+	ProjectID int               `json:"project_id,omitempty"`
+	MRIID     int               `json:"mr_iid,omitempty"`
+	Fields    map[string]string `json:"fields,omitempty"`
+	Timestamp time.Time         `json:"timestamp"`
+	Text      string            `json:"text,omitempty"` // Message rendered from the event's NOTIFY_TEMPLATE_<EVENT>
+}
+
+// Sink delivers notifications
+type Sink interface {
+	Notify(n Notification) error
+}
+
+// NewSinkFromConfig returns a webhook sink when NOTIFY_WEBHOOK_URL is set, otherwise a log
+// sink. With NOTIFY_ROUTES or message templates, notifications go through a router sending
+// routed events to their channels and all others to that sink.
+func NewSinkFromConfig(cfg *config.Config) Sink {
+	var fallback Sink = LogSink{}
+	if cfg.Notify.WebhookURL != "" {
+		fallback = NewWebhookSink(cfg.Notify.WebhookURL)
+	}
+	if len(cfg.Notify.Routes) == 0 && len(cfg.Notify.Templates) == 0 {
+		return fallback
+	}
+	return NewRouter(cfg.Notify, fallback)
+}
+
+// NewRoutedSinkFromConfig returns the sink for an event type that is only sent when
+// NOTIFY_ROUTES routes it, or nil when it is not routed
+func NewRoutedSinkFromConfig(cfg *config.Config, event string) Sink {
+	if !cfg.Notify.Routed(event) {
+		return nil
+	}
+	return NewSinkFromConfig(cfg)
+}
+
+// LogSink writes notifications to the application log
+type LogSink struct{}
+
+// Notify logs the notification
+func (LogSink) Notify(n Notification) error {
+	logging.Warn("Notification",
+		zap.String("event", n.Event),
+		zap.String("title", n.Title),
+		zap.String("message", n.Message),
+		zap.Int("project_id", n.ProjectID),
+		zap.Int("mr_iid", n.MRIID))
+	return nil
+}
+
+// WebhookSink posts notifications as JSON to an HTTP endpoint
+type WebhookSink struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookSink creates a sink posting to url
+func NewWebhookSink(url string) *WebhookSink {
+	return &WebhookSink{
+		url:    url,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Notify posts the notification
+func (s *WebhookSink) Notify(n Notification) error {
+	if n.Timestamp.IsZero() {
+		n.Timestamp = time.Now().UTC()
+	}
+	return postJSON(s.client, s.url, n)
+}
+
+// postJSON posts payload as JSON to url
+func postJSON(client *http.Client, url string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+
+	resp, err := client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to send notification: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("notification webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package notify
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+)
+
+func TestWebhookSink_Notify(t *testing.T) {
+	var received Notification
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		_ = json.NewDecoder(r.Body).Decode(&received)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	sink := NewWebhookSink(server.URL)
+	err := sink.Notify(Notification{Event: "decision_flapping", Severity: SeverityWarning, Title: "Flapping", ProjectID: 1, MRIID: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, "decision_flapping", received.Event)
+	assert.Equal(t, 2, received.MRIID)
+	assert.False(t, received.Timestamp.IsZero())
+}
+
+func TestWebhookSink_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	err := NewWebhookSink(server.URL).Notify(Notification{Event: "x"})
+	assert.Error(t, err)
+}
+
+func TestNewSinkFromConfig(t *testing.T) {
+	assert.IsType(t, LogSink{}, NewSinkFromConfig(&config.Config{}))
+	assert.IsType(t, &WebhookSink{}, NewSinkFromConfig(&config.Config{Notify: config.NotifyConfig{WebhookURL: "http://example"}}))
+	assert.NoError(t, LogSink{}.Notify(Notification{Event: "x"}))
+
+	routed := &config.Config{Notify: config.NotifyConfig{Routes: map[string][]string{EventManualReview: {"team"}}}}
+	assert.IsType(t, &Router{}, NewSinkFromConfig(routed))
+	assert.NotNil(t, NewRoutedSinkFromConfig(routed, EventManualReview))
+	assert.Nil(t, NewRoutedSinkFromConfig(routed, EventRebaseConflict))
+	assert.Nil(t, NewRoutedSinkFromConfig(&config.Config{}, EventManualReview))
+}
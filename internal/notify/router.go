@@ -0,0 +1,90 @@
+package notify
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"go.uber.org/zap"
+)
+
+// Router renders the message template of a notification's event type and delivers it to
+// the channels the event is routed to, or to the fallback sink when it is not routed
+type Router struct {
+	routes    map[string][]Sink
+	templates map[string]*template.Template
+	fallback  Sink
+}
+
+// NewRouter builds a router from the configured channels, routes and templates. Routes to
+// unknown channels and invalid templates are logged and skipped.
+func NewRouter(cfg config.NotifyConfig, fallback Sink) *Router {
+	channels := make(map[string]Sink)
+	for name, channel := range cfg.Channels {
+		switch channel.Kind {
+		case config.NotifyChannelSlack:
+			channels[name] = NewSlackSink(channel.URL)
+		case config.NotifyChannelGoogleChat:
+			channels[name] = NewGoogleChatSink(channel.URL)
+		case config.NotifyChannelWebhook:
+			channels[name] = NewWebhookSink(channel.URL)
+		}
+	}
+
+	router := &Router{
+		routes:    make(map[string][]Sink),
+		templates: make(map[string]*template.Template),
+		fallback:  fallback,
+	}
+	for event, names := range cfg.Routes {
+		for _, name := range names {
+			sink, ok := channels[name]
+			if !ok {
+				logging.Warn("Notification route refers to unknown channel, skipping",
+					zap.String("event", event),
+					zap.String("channel", name))
+				continue
+			}
+			router.routes[event] = append(router.routes[event], sink)
+		}
+	}
+	for event, text := range cfg.Templates {
+		tmpl, err := template.New(event).Parse(text)
+		if err != nil {
+			logging.Warn("Invalid notification template", zap.String("event", event), zap.Error(err))
+			continue
+		}
+		router.templates[event] = tmpl
+	}
+	return router
+}
+
+// Notify delivers the notification to every channel of its event type
+func (r *Router) Notify(n Notification) error {
+	if tmpl, ok := r.templates[n.Event]; ok {
+		var text strings.Builder
+		if err := tmpl.Execute(&text, n); err != nil {
+			logging.Warn("Failed to render notification template", zap.String("event", n.Event), zap.Error(err))
+		} else {
+			n.Text = text.String()
+		}
+	}
+
+	sinks, ok := r.routes[n.Event]
+	if !ok {
+		sinks, ok = r.routes["*"]
+	}
+	if !ok {
+		return r.fallback.Notify(n)
+	}
+	var errs []error
+	for _, sink := range sinks {
+		if err := sink.Notify(n); err != nil {
+			errs = append(errs, fmt.Errorf("%T: %w", sink, err))
+		}
+	}
+	return errors.Join(errs...)
+}
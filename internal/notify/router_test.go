@@ -0,0 +1,69 @@
+package notify
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+)
+
+type recordingSink struct {
+	received []Notification
+	err      error
+}
+
+func (s *recordingSink) Notify(n Notification) error {
+	s.received = append(s.received, n)
+	return s.err
+}
+
+func TestRouter_Notify(t *testing.T) {
+	fallback := &recordingSink{}
+	router := NewRouter(config.NotifyConfig{
+		Routes:    map[string][]string{EventManualReview: {"team", "missing"}},
+		Templates: map[string]string{EventManualReview: "MR !{{.MRIID}}: {{.Message}}", "broken": "{{.Nope"},
+	}, fallback)
+	team := &recordingSink{}
+	router.routes[EventManualReview] = []Sink{team}
+
+	assert.NoError(t, router.Notify(Notification{Event: EventManualReview, MRIID: 9, Message: "needs review"}))
+	assert.Len(t, team.received, 1)
+	assert.Equal(t, "MR !9: needs review", team.received[0].Text)
+	assert.Empty(t, fallback.received, "routed events skip the fallback")
+
+	assert.NoError(t, router.Notify(Notification{Event: "decision_flapping"}))
+	assert.Len(t, fallback.received, 1, "unrouted events go to the fallback")
+	assert.Empty(t, fallback.received[0].Text)
+
+	team.err = errors.New("boom")
+	assert.ErrorContains(t, router.Notify(Notification{Event: EventManualReview}), "boom")
+}
+
+func TestRouter_Wildcard(t *testing.T) {
+	fallback, ops := &recordingSink{}, &recordingSink{}
+	router := NewRouter(config.NotifyConfig{}, fallback)
+	router.routes["*"] = []Sink{ops}
+
+	assert.NoError(t, router.Notify(Notification{Event: EventRebaseConflict}))
+	assert.Len(t, ops.received, 1)
+	assert.Empty(t, fallback.received)
+}
+
+func TestNewRouter_Channels(t *testing.T) {
+	router := NewRouter(config.NotifyConfig{
+		Channels: map[string]config.NotifyChannel{
+			"team": {Kind: config.NotifyChannelSlack, URL: "http://slack"},
+			"ops":  {Kind: config.NotifyChannelGoogleChat, URL: "http://chat"},
+			"hook": {Kind: config.NotifyChannelWebhook, URL: "http://hook"},
+		},
+		Routes: map[string][]string{EventStaleMRClosed: {"team", "ops", "hook"}},
+	}, LogSink{})
+
+	sinks := router.routes[EventStaleMRClosed]
+	assert.Len(t, sinks, 3)
+	assert.IsType(t, &SlackSink{}, sinks[0])
+	assert.IsType(t, &GoogleChatSink{}, sinks[1])
+	assert.IsType(t, &WebhookSink{}, sinks[2])
+}
@@ -0,0 +1,219 @@
+package onboarding
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/repoindex"
+)
+
+// Client reads files on the target branch to tell new products from new environments
+type Client interface {
+	FetchFileContent(ctx context.Context, projectID int, filePath, ref string) (*gitlab.FileContent, error)
+}
+
+// Product is a data product directory created by an MR
+type Product struct {
+	Kind         string   // Type directory, e.g. "source"
+	Name         string   // Product directory name
+	Dir          string   // dataproducts/<kind>/<name>
+	Environments []string // Environments the MR adds a product.yaml for
+}
+
+// ItemStatus is a checklist item expanded for a product
+type ItemStatus struct {
+	Name string // e.g. "Masking policy (prod)"
+	Path string // Glob relative to the repository root
+	Done bool   // A file added by the MR matches Path
+}
+
+// Checklist is the onboarding checklist of one new data product
+type Checklist struct {
+	Product Product
+	Items   []ItemStatus
+}
+
+// Missing returns the items without a matching file
+func (c Checklist) Missing() []ItemStatus {
+	var missing []ItemStatus
+	for _, item := range c.Items {
+		if !item.Done {
+			missing = append(missing, item)
+		}
+	}
+	return missing
+}
+
+// Complete reports whether every item is done
+func (c Checklist) Complete() bool {
+	return len(c.Missing()) == 0
+}
+
+// Checker builds onboarding checklists for MRs that create data products
+type Checker struct {
+	client       Client
+	templates    Templates
+	environments []string
+}
+
+// NewChecker creates a checker. environments are looked up on the target branch to
+// confirm that a product directory does not exist yet.
+func NewChecker(client Client, templates Templates, environments []string) *Checker {
+	return &Checker{client: client, templates: templates, environments: environments}
+}
+
+// NewCheckerFromConfig returns nil when the onboarding checklist is disabled
+func NewCheckerFromConfig(client Client, cfg config.OnboardingConfig) (*Checker, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	templates := DefaultTemplates()
+	if cfg.TemplatesFile != "" {
+		loaded, err := LoadTemplates(cfg.TemplatesFile)
+		if err != nil {
+			return nil, err
+		}
+		templates = loaded
+	}
+	return NewChecker(client, templates, cfg.Environments), nil
+}
+
+// Check returns a checklist for every data product directory the MR creates. The
+// checklists are rebuilt from the MR changes on every push, so completed items are
+// ticked off as the missing files are added.
+func (c *Checker) Check(ctx context.Context, projectID int, targetBranch string, changes []gitlab.FileChange) []Checklist {
+	var checklists []Checklist
+	for _, product := range newProductCandidates(changes) {
+		if c.existsOnTarget(ctx, projectID, targetBranch, product) {
+			continue
+		}
+		checklists = append(checklists, c.checklist(product, changes))
+	}
+	return checklists
+}
+
+// checklist expands the template of the product kind and ticks off items with a matching file
+func (c *Checker) checklist(product Product, changes []gitlab.FileChange) Checklist {
+	var added []string
+	for _, change := range changes {
+		if !change.DeletedFile && strings.HasPrefix(change.NewPath, product.Dir+"/") {
+			added = append(added, strings.TrimPrefix(change.NewPath, product.Dir+"/"))
+		}
+	}
+
+	checklist := Checklist{Product: product}
+	for _, item := range c.templates.For(product.Kind) {
+		if !strings.Contains(item.Path, envPlaceholder) {
+			checklist.Items = append(checklist.Items, itemStatus(product, item.Name, item.Path, added))
+			continue
+		}
+		for _, env := range product.Environments {
+			name := fmt.Sprintf("%s (%s)", item.Name, env)
+			checklist.Items = append(checklist.Items, itemStatus(product, name, strings.ReplaceAll(item.Path, envPlaceholder, env), added))
+		}
+	}
+	return checklist
+}
+
+func itemStatus(product Product, name, pattern string, added []string) ItemStatus {
+	status := ItemStatus{Name: name, Path: product.Dir + "/" + pattern}
+	for _, file := range added {
+		if matched, _ := path.Match(pattern, file); matched {
+			status.Done = true
+			break
+		}
+	}
+	return status
+}
+
+// existsOnTarget reports whether the product directory already exists on the target
+// branch, e.g. when the MR only adds a new environment of an existing product
+func (c *Checker) existsOnTarget(ctx context.Context, projectID int, targetBranch string, product Product) bool {
+	if idx := repoindex.ForClient(c.client); idx != nil {
+		paths, err := idx.Paths(ctx, projectID, targetBranch, func(p string) bool {
+			return strings.HasPrefix(p, product.Dir+"/")
+		})
+		if err == nil {
+			return len(paths) > 0
+		}
+	}
+
+	added := make(map[string]bool)
+	for _, env := range product.Environments {
+		added[env] = true
+	}
+	for _, env := range c.environments {
+		if added[env] {
+			continue
+		}
+		file, err := c.client.FetchFileContent(ctx, projectID, product.Dir+"/"+env+"/product.yaml", targetBranch)
+		if err == nil && file != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// newProductCandidates returns the product directories whose files are all added by the
+// MR and that contain at least one product.yaml
+func newProductCandidates(changes []gitlab.FileChange) []Product {
+	products := make(map[string]*Product)
+	existing := make(map[string]bool)
+	for _, change := range changes {
+		kind, name, rest, ok := splitProductPath(change.NewPath)
+		if !ok {
+			continue
+		}
+		dir := "dataproducts/" + kind + "/" + name
+		if !change.NewFile {
+			existing[dir] = true
+			continue
+		}
+
+		product, ok := products[dir]
+		if !ok {
+			product = &Product{Kind: kind, Name: name, Dir: dir}
+			products[dir] = product
+		}
+		if env, file := path.Split(rest); path.Clean(env) != "." && !strings.Contains(path.Clean(env), "/") && file == "product.yaml" {
+			product.Environments = append(product.Environments, path.Clean(env))
+		}
+	}
+
+	var candidates []Product
+	for dir, product := range products {
+		if existing[dir] || len(product.Environments) == 0 {
+			continue
+		}
+		sort.Strings(product.Environments)
+		candidates = append(candidates, *product)
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].Dir < candidates[j].Dir })
+	return candidates
+}
+
+// splitProductPath splits dataproducts/<kind>/<name>/<rest>
+func splitProductPath(filePath string) (kind, name, rest string, ok bool) {
+	parts := strings.SplitN(filePath, "/", 4)
+	if len(parts) < 4 || parts[0] != "dataproducts" || parts[1] == "" || parts[2] == "" {
+		return "", "", "", false
+	}
+	return parts[1], parts[2], parts[3], true
+}
+
+// Reason explains a manual review decision caused by incomplete checklists
+func Reason(checklists []Checklist) string {
+	var missing []string
+	for _, checklist := range checklists {
+		for _, item := range checklist.Missing() {
+			missing = append(missing, checklist.Product.Name+": "+item.Name)
+		}
+	}
+	return "New data product is missing onboarding artifacts (" + strings.Join(missing, ", ") + ") - add them to allow approval"
+}
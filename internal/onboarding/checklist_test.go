@@ -0,0 +1,113 @@
+package onboarding
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+)
+
+// mockClient serves files that exist on the target branch
+type mockClient struct {
+	files   map[string]bool
+	fetched []string
+}
+
+func (m *mockClient) FetchFileContent(ctx context.Context, projectID int, filePath, ref string) (*gitlab.FileContent, error) {
+	m.fetched = append(m.fetched, filePath)
+	if m.files[filePath] {
+		return &gitlab.FileContent{FilePath: filePath}, nil
+	}
+	return nil, errors.New("file not found")
+}
+
+func added(paths ...string) []gitlab.FileChange {
+	changes := make([]gitlab.FileChange, 0, len(paths))
+	for _, p := range paths {
+		changes = append(changes, gitlab.FileChange{NewPath: p, NewFile: true, Diff: "+x"})
+	}
+	return changes
+}
+
+func TestNewProductCandidates(t *testing.T) {
+	changes := append(added(
+		"dataproducts/source/analytics/prod/product.yaml",
+		"dataproducts/source/analytics/dev/product.yaml",
+		"dataproducts/source/analytics/groups/analytics.yaml",
+		"dataproducts/aggregate/sales/groups/sales.yaml", // no product.yaml
+		"dataproducts/platform/billing/prod/product.yaml",
+		"README.md",
+	), gitlab.FileChange{NewPath: "dataproducts/platform/billing/prod/masking.yaml", OldPath: "dataproducts/platform/billing/prod/masking.yaml"})
+
+	candidates := newProductCandidates(changes)
+	assert.Len(t, candidates, 1, "products with modified files and without product.yaml are not new")
+	assert.Equal(t, Product{
+		Kind:         "source",
+		Name:         "analytics",
+		Dir:          "dataproducts/source/analytics",
+		Environments: []string{"dev", "prod"},
+	}, candidates[0])
+}
+
+func TestChecker_Check(t *testing.T) {
+	client := &mockClient{}
+	checker := NewChecker(client, DefaultTemplates(), []string{"dev", "preprod", "prod"})
+
+	checklists := checker.Check(context.Background(), 1, "main", added(
+		"dataproducts/source/analytics/prod/product.yaml",
+		"dataproducts/source/analytics/prod/pii_masking.yaml",
+		"dataproducts/source/analytics/dev/product.yaml",
+	))
+
+	assert.Len(t, checklists, 1)
+	assert.ElementsMatch(t, []string{
+		"dataproducts/source/analytics/preprod/product.yaml",
+	}, client.fetched, "only environments not added by the MR are looked up")
+
+	checklist := checklists[0]
+	assert.False(t, checklist.Complete())
+	assert.Equal(t, []ItemStatus{
+		{Name: "Product definition (dev)", Path: "dataproducts/source/analytics/dev/product.yaml", Done: true},
+		{Name: "Product definition (prod)", Path: "dataproducts/source/analytics/prod/product.yaml", Done: true},
+		{Name: "Masking policy (dev)", Path: "dataproducts/source/analytics/dev/*masking.yaml"},
+		{Name: "Masking policy (prod)", Path: "dataproducts/source/analytics/prod/*masking.yaml", Done: true},
+		{Name: "Consumer group", Path: "dataproducts/source/analytics/groups/*.yaml"},
+	}, checklist.Items)
+	assert.Len(t, checklist.Missing(), 2)
+	assert.Equal(t, "New data product is missing onboarding artifacts (analytics: Masking policy (dev), analytics: Consumer group) - add them to allow approval", Reason(checklists))
+
+	// A later push adds the missing files
+	checklists = checker.Check(context.Background(), 1, "main", added(
+		"dataproducts/source/analytics/prod/product.yaml",
+		"dataproducts/source/analytics/prod/pii_masking.yaml",
+		"dataproducts/source/analytics/dev/product.yaml",
+		"dataproducts/source/analytics/dev/pii_masking.yaml",
+		"dataproducts/source/analytics/groups/dataverse-source-analytics.yaml",
+	))
+	assert.True(t, checklists[0].Complete())
+}
+
+func TestChecker_Check_NewEnvironmentOfExistingProduct(t *testing.T) {
+	client := &mockClient{files: map[string]bool{"dataproducts/source/analytics/dev/product.yaml": true}}
+	checker := NewChecker(client, DefaultTemplates(), []string{"dev", "prod"})
+
+	checklists := checker.Check(context.Background(), 1, "main", added("dataproducts/source/analytics/prod/product.yaml"))
+	assert.Empty(t, checklists)
+}
+
+func TestNewCheckerFromConfig(t *testing.T) {
+	checker, err := NewCheckerFromConfig(&mockClient{}, config.OnboardingConfig{})
+	assert.NoError(t, err)
+	assert.Nil(t, checker)
+
+	checker, err = NewCheckerFromConfig(&mockClient{}, config.OnboardingConfig{Enabled: true})
+	assert.NoError(t, err)
+	assert.NotNil(t, checker)
+
+	_, err = NewCheckerFromConfig(&mockClient{}, config.OnboardingConfig{Enabled: true, TemplatesFile: "/nonexistent/onboarding.yaml"})
+	assert.Error(t, err)
+}
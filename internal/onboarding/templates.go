@@ -0,0 +1,79 @@
+package onboarding
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultKind is the template used for product kinds without their own template
+const DefaultKind = "default"
+
+// envPlaceholder in an item path is expanded to every environment of the product
+const envPlaceholder = "{env}"
+
+// Item is one artifact a new data product must provide
+type Item struct {
+	Name string `yaml:"name"` // Checklist text, e.g. "Masking policy"
+	Path string `yaml:"path"` // Glob relative to the product directory, e.g. "{env}/*masking.yaml"
+}
+
+// Templates are the checklist items per product kind (source, aggregate, platform, ...)
+type Templates map[string][]Item
+
+// DefaultTemplates requires a product definition and masking policy per environment and
+// at least one consumer group for every product kind
+func DefaultTemplates() Templates {
+	return Templates{
+		DefaultKind: {
+			{Name: "Product definition", Path: "{env}/product.yaml"},
+			{Name: "Masking policy", Path: "{env}/*masking.yaml"},
+			{Name: "Consumer group", Path: "groups/*.yaml"},
+		},
+	}
+}
+
+// LoadTemplates reads checklist templates from a YAML file mapping product kinds to items:
+//
+//	default:
+//	  - name: Product definition
+//	    path: "{env}/product.yaml"
+//	platform:
+//	  - name: Product definition
+//	    path: "{env}/product.yaml"
+func LoadTemplates(path string) (Templates, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read onboarding templates %s: %w", path, err)
+	}
+
+	var templates Templates
+	if err := yaml.Unmarshal(data, &templates); err != nil {
+		return nil, fmt.Errorf("failed to parse onboarding templates %s: %w", path, err)
+	}
+	if len(templates) == 0 {
+		return nil, fmt.Errorf("onboarding templates %s define no product kinds", path)
+	}
+
+	for kind, items := range templates {
+		for i, item := range items {
+			if item.Path == "" {
+				return nil, fmt.Errorf("onboarding template %s item %d has no path", kind, i+1)
+			}
+			if item.Name == "" {
+				templates[kind][i].Name = item.Path
+			}
+		}
+	}
+	return templates, nil
+}
+
+// For returns the items of a product kind, falling back to the default template
+func (t Templates) For(kind string) []Item {
+	if items, ok := t[strings.ToLower(kind)]; ok {
+		return items
+	}
+	return t[DefaultKind]
+}
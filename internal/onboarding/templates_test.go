@@ -0,0 +1,54 @@
+package onboarding
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeTemplates(t *testing.T, content string) string {
+	file := filepath.Join(t.TempDir(), "onboarding.yaml")
+	assert.NoError(t, os.WriteFile(file, []byte(content), 0o600))
+	return file
+}
+
+func TestLoadTemplates(t *testing.T) {
+	file := writeTemplates(t, `
+default:
+  - name: Product definition
+    path: "{env}/product.yaml"
+platform:
+  - path: "{env}/product.yaml"
+  - name: Runbook
+    path: RUNBOOK.md
+`)
+
+	templates, err := LoadTemplates(file)
+	assert.NoError(t, err)
+	assert.Len(t, templates.For("platform"), 2)
+	assert.Equal(t, "{env}/product.yaml", templates.For("platform")[0].Name, "name defaults to the path")
+	assert.Equal(t, "Runbook", templates.For("Platform")[1].Name)
+	assert.Equal(t, "Product definition", templates.For("source")[0].Name, "unknown kinds use the default template")
+}
+
+func TestLoadTemplates_Invalid(t *testing.T) {
+	_, err := LoadTemplates(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err)
+
+	_, err = LoadTemplates(writeTemplates(t, "default: [unclosed"))
+	assert.Error(t, err)
+
+	_, err = LoadTemplates(writeTemplates(t, ""))
+	assert.ErrorContains(t, err, "no product kinds")
+
+	_, err = LoadTemplates(writeTemplates(t, "default:\n  - name: Missing path\n"))
+	assert.ErrorContains(t, err, "has no path")
+}
+
+func TestDefaultTemplates(t *testing.T) {
+	items := DefaultTemplates().For("aggregate")
+	assert.Len(t, items, 3)
+	assert.Equal(t, "groups/*.yaml", items[2].Path)
+}
@@ -0,0 +1,64 @@
+package override
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// commandPattern matches `/naysayer approve-until <date> reason:"<text>"` on its own line
+var commandPattern = regexp.MustCompile(`(?m)^\s*/naysayer\s+approve-until\b(.*)$`)
+
+// argsPattern splits the command arguments into the expiry and the quoted reason
+var argsPattern = regexp.MustCompile(`^\s*(\S+)?\s*(?:reason:"([^"]*)")?\s*$`)
+
+// ErrInvalidCommand is returned for approve-until commands that cannot be applied
+var ErrInvalidCommand = errors.New("invalid approve-until command")
+
+// Command is a parsed `/naysayer approve-until` request
+type Command struct {
+	Until  time.Time
+	Reason string
+}
+
+// parseExpiry accepts a date (expiring at 00:00 UTC that day) or an RFC 3339 timestamp
+func parseExpiry(value string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// ParseCommand finds an approve-until command in a comment. It returns nil without an error
+// when the comment has no command, and an error wrapping ErrInvalidCommand when the command
+// is malformed, lacks a reason, or expires in the past or later than maxDays from now.
+func ParseCommand(body string, now time.Time, maxDays int) (*Command, error) {
+	match := commandPattern.FindStringSubmatch(body)
+	if match == nil {
+		return nil, nil
+	}
+
+	args := argsPattern.FindStringSubmatch(match[1])
+	if args == nil || args[1] == "" {
+		return nil, fmt.Errorf(`%w: expected /naysayer approve-until YYYY-MM-DD reason:"..."`, ErrInvalidCommand)
+	}
+
+	until, err := parseExpiry(args[1])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q is not a date (YYYY-MM-DD) or RFC 3339 timestamp", ErrInvalidCommand, args[1])
+	}
+	reason := strings.TrimSpace(args[2])
+	if reason == "" {
+		return nil, fmt.Errorf(`%w: a reason is required, e.g. reason:"migration window"`, ErrInvalidCommand)
+	}
+	if !until.After(now) {
+		return nil, fmt.Errorf("%w: %s is in the past", ErrInvalidCommand, until.UTC().Format(time.RFC3339))
+	}
+	if maxDays > 0 && until.After(now.AddDate(0, 0, maxDays)) {
+		return nil, fmt.Errorf("%w: overrides may last at most %d days", ErrInvalidCommand, maxDays)
+	}
+
+	return &Command{Until: until.UTC(), Reason: reason}, nil
+}
@@ -0,0 +1,46 @@
+package override
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCommand(t *testing.T) {
+	now := time.Date(2024, 6, 20, 12, 0, 0, 0, time.UTC)
+
+	cmd, err := ParseCommand("Looks fine for now.\n/naysayer approve-until 2024-07-01 reason:\"migration window\"", now, 30)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), cmd.Until)
+	assert.Equal(t, "migration window", cmd.Reason)
+
+	cmd, err = ParseCommand(`/naysayer approve-until 2024-06-21T18:00:00+02:00 reason:"hotfix"`, now, 30)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 6, 21, 16, 0, 0, 0, time.UTC), cmd.Until)
+
+	cmd, err = ParseCommand("please approve until 2024-07-01", now, 30)
+	assert.NoError(t, err)
+	assert.Nil(t, cmd, "comments without the command are ignored")
+}
+
+func TestParseCommand_Invalid(t *testing.T) {
+	now := time.Date(2024, 6, 20, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]string{
+		"missing date":   `/naysayer approve-until`,
+		"bad date":       `/naysayer approve-until next-week reason:"later"`,
+		"missing reason": `/naysayer approve-until 2024-07-01`,
+		"empty reason":   `/naysayer approve-until 2024-07-01 reason:" "`,
+		"past":           `/naysayer approve-until 2024-06-01 reason:"too late"`,
+		"too long":       `/naysayer approve-until 2024-09-01 reason:"summer"`,
+	}
+	for name, body := range tests {
+		t.Run(name, func(t *testing.T) {
+			cmd, err := ParseCommand(body, now, 30)
+			assert.Nil(t, cmd)
+			assert.True(t, errors.Is(err, ErrInvalidCommand), err)
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package override
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+)
+
+// keyPrefix is the state store namespace for active overrides
+const keyPrefix = "override/"
+
+// Override is an approval granted by a maintainer until an expiry time, or by an override
+// approver until new commits are pushed
+type Override struct {
+	ProjectID int       `json:"project_id"`
+	MRIID     int       `json:"mr_iid"`
+	Actor     string    `json:"actor"`
+	Reason    string    `json:"reason"`
+	Until     time.Time `json:"until"` // Zero for overrides without expiry
+	CreatedAt time.Time `json:"created_at"`
+	NoteID    int       `json:"note_id"` // Comment carrying the command
+	SHA       string    `json:"sha"`     // MR head the override was granted for
+}
+
+// Expired reports whether the override has ended at now
+func (o *Override) Expired(now time.Time) bool {
+	return !o.Until.IsZero() && !now.Before(o.Until)
+}
+
+// Store persists active overrides in the state store
+type Store struct {
+	st store.Store
+}
+
+// NewStore creates an override store backed by st
+func NewStore(st store.Store) *Store {
+	return &Store{st: st}
+}
+
+// NewStoreFromConfig creates the override store configured by cfg. Overrides go to
+// OVERRIDE_DIR when set, so that time-boxed overrides still expire after a restart, otherwise
+// to st. Overrides of an additional GitLab instance are kept below instances/<name>/ in the
+// directory, as project IDs of different instances overlap.
+func NewStoreFromConfig(cfg *config.Config, st store.Store) (*Store, error) {
+	if cfg.Override.Dir == "" {
+		return NewStore(st), nil
+	}
+	dir, err := store.NewDirStore(cfg.Override.Dir)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.GitLab.Instance != "" {
+		return NewStore(store.WithPrefix(dir, "instances/"+cfg.GitLab.Instance+"/")), nil
+	}
+	return NewStore(dir), nil
+}
+
+// key returns the state store key of an MR's override
+func key(projectID, mrIID int) string {
+	return fmt.Sprintf("%s%d/%d", keyPrefix, projectID, mrIID)
+}
+
+// Save stores an override, replacing an earlier one for the same MR
+func (s *Store) Save(o Override) error {
+	return store.PutJSON(s.st, key(o.ProjectID, o.MRIID), o)
+}
+
+// Get returns the override of an MR, or nil when there is none
+func (s *Store) Get(projectID, mrIID int) (*Override, error) {
+	var o Override
+	found, err := store.GetJSON(s.st, key(projectID, mrIID), &o)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &o, nil
+}
+
+// Delete removes the override of an MR
+func (s *Store) Delete(projectID, mrIID int) error {
+	return s.st.Delete(key(projectID, mrIID))
+}
+
+// Expired returns every override that has ended at now
+func (s *Store) Expired(now time.Time) ([]Override, error) {
+	keys, err := s.st.Keys(keyPrefix)
+	if err != nil {
+		return nil, err
+	}
+	var expired []Override
+	for _, k := range keys {
+		var o Override
+		if found, err := store.GetJSON(s.st, k, &o); err != nil || !found {
+			continue
+		}
+		if o.Expired(now) {
+			expired = append(expired, o)
+		}
+	}
+	return expired, nil
+}
@@ -0,0 +1,87 @@
+package override
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+)
+
+func TestStore(t *testing.T) {
+	s := NewStore(store.NewMemoryStore())
+	now := time.Date(2024, 6, 20, 12, 0, 0, 0, time.UTC)
+
+	missing, err := s.Get(1, 2)
+	assert.NoError(t, err)
+	assert.Nil(t, missing)
+
+	assert.NoError(t, s.Save(Override{ProjectID: 1, MRIID: 2, Actor: "alice", Reason: "migration", Until: now.Add(time.Hour)}))
+	assert.NoError(t, s.Save(Override{ProjectID: 1, MRIID: 3, Actor: "bob", Reason: "hotfix", Until: now.Add(-time.Minute)}))
+
+	active, err := s.Get(1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", active.Actor)
+	assert.False(t, active.Expired(now))
+	assert.True(t, active.Expired(now.Add(time.Hour)))
+
+	expired, err := s.Expired(now)
+	assert.NoError(t, err)
+	if assert.Len(t, expired, 1) {
+		assert.Equal(t, 3, expired[0].MRIID)
+	}
+
+	// Overrides without expiry last until they are deleted
+	assert.NoError(t, s.Save(Override{ProjectID: 1, MRIID: 4, Actor: "carol", Reason: "vendor freeze"}))
+	unlimited, err := s.Get(1, 4)
+	assert.NoError(t, err)
+	assert.False(t, unlimited.Expired(now.AddDate(10, 0, 0)))
+
+	assert.NoError(t, s.Delete(1, 3))
+	expired, err = s.Expired(now)
+	assert.NoError(t, err)
+	assert.Empty(t, expired)
+}
+
+func TestNewStoreFromConfig(t *testing.T) {
+	now := time.Date(2024, 6, 20, 12, 0, 0, 0, time.UTC)
+	state := store.NewMemoryStore()
+
+	cfg := &config.Config{Override: config.OverrideConfig{Enabled: true}}
+	s, err := NewStoreFromConfig(cfg, state)
+	assert.NoError(t, err)
+	assert.NoError(t, s.Save(Override{ProjectID: 1, MRIID: 2, Until: now}))
+	keys, _ := state.Keys(keyPrefix)
+	assert.Len(t, keys, 1, "without a directory overrides are kept in the state store")
+
+	cfg.Override.Dir = t.TempDir()
+	s, err = NewStoreFromConfig(cfg, state)
+	assert.NoError(t, err)
+	assert.NoError(t, s.Save(Override{ProjectID: 1, MRIID: 3, Until: now}))
+
+	// A store opened after a restart still finds the override
+	restarted, err := NewStoreFromConfig(cfg, store.NewMemoryStore())
+	assert.NoError(t, err)
+	expired, err := restarted.Expired(now)
+	assert.NoError(t, err)
+	if assert.Len(t, expired, 1) {
+		assert.Equal(t, 3, expired[0].MRIID)
+	}
+
+	// Additional GitLab instances keep their overrides apart
+	instanceCfg := *cfg
+	instanceCfg.GitLab.Instance = "onprem"
+	onprem, err := NewStoreFromConfig(&instanceCfg, state)
+	assert.NoError(t, err)
+	missing, err := onprem.Get(1, 3)
+	assert.NoError(t, err)
+	assert.Nil(t, missing)
+	assert.NoError(t, onprem.Save(Override{ProjectID: 1, MRIID: 3, Actor: "onprem-maintainer", Until: now}))
+	active, _ := restarted.Get(1, 3)
+	assert.Empty(t, active.Actor)
+
+	_, err = NewStoreFromConfig(&config.Config{Override: config.OverrideConfig{Dir: "/dev/null/overrides"}}, state)
+	assert.Error(t, err)
+}
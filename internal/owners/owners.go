@@ -0,0 +1,128 @@
+package owners
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"gopkg.in/yaml.v3"
+)
+
+// Rule maps a path pattern to the users and groups owning it
+type Rule struct {
+	Path   string   `yaml:"path"`   // Pattern relative to the repository root, e.g. dataproducts/source/analytics/**
+	Owners []string `yaml:"owners"` // GitLab usernames or group paths, with or without a leading @
+}
+
+// File is the format of the owners file:
+//
+//	owners:
+//	  - path: "dataproducts/source/analytics/**"
+//	    owners: ["@alice", "@data-platform/analytics"]
+type File struct {
+	Owners []Rule `yaml:"owners"`
+}
+
+// Parse parses and validates an owners file
+func Parse(content string) (*File, error) {
+	var file File
+	if err := yaml.Unmarshal([]byte(content), &file); err != nil {
+		return nil, fmt.Errorf("YAML parsing error: %w", err)
+	}
+	for i, rule := range file.Owners {
+		if rule.Path == "" {
+			return nil, fmt.Errorf("owners rule at index %d missing path", i)
+		}
+		if len(rule.Owners) == 0 {
+			return nil, fmt.Errorf("owners rule for %s lists no owners", rule.Path)
+		}
+	}
+	return &file, nil
+}
+
+// OwnersOf returns the owners of a file. Like CODEOWNERS, the last matching rule wins.
+func (f *File) OwnersOf(filePath string) []string {
+	for i := len(f.Owners) - 1; i >= 0; i-- {
+		if shared.MatchesPattern(filePath, f.Owners[i].Path) {
+			return f.Owners[i].Owners
+		}
+	}
+	return nil
+}
+
+// Reviewers returns the owners of the files without leading @, deduplicated in order of appearance
+func (f *File) Reviewers(filePaths []string) []string {
+	seen := make(map[string]bool)
+	var reviewers []string
+	for _, filePath := range filePaths {
+		for _, owner := range f.OwnersOf(filePath) {
+			name := strings.TrimPrefix(strings.TrimSpace(owner), "@")
+			if name == "" || seen[strings.ToLower(name)] {
+				continue
+			}
+			seen[strings.ToLower(name)] = true
+			reviewers = append(reviewers, name)
+		}
+	}
+	return reviewers
+}
+
+// Client reads the owners file on the target branch
+type Client interface {
+	FetchFileContent(ctx context.Context, projectID int, filePath, ref string) (*gitlab.FileContent, error)
+}
+
+// Resolver finds the owners of changed files from the owners file of the target branch,
+// so an MR cannot choose its own reviewers
+type Resolver struct {
+	client Client
+	files  []string
+}
+
+// NewResolver creates a resolver reading the first of files that exists
+func NewResolver(client Client, files []string) *Resolver {
+	return &Resolver{client: client, files: files}
+}
+
+// NewResolverFromConfig returns nil when reviewer routing is disabled
+func NewResolverFromConfig(client Client, cfg config.OwnersConfig) *Resolver {
+	if !cfg.Enabled {
+		return nil
+	}
+	return NewResolver(client, cfg.Files)
+}
+
+// Load returns the first owners file found on ref, or nil when none exists
+func (r *Resolver) Load(ctx context.Context, projectID int, ref string) (*File, error) {
+	for _, filePath := range r.files {
+		content, err := r.client.FetchFileContent(ctx, projectID, filePath, ref)
+		if errors.Is(err, gitlab.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch %s: %w", filePath, err)
+		}
+		if content == nil {
+			continue
+		}
+		file, err := Parse(content.Content)
+		if err != nil {
+			return nil, fmt.Errorf("invalid owners file %s: %w", filePath, err)
+		}
+		return file, nil
+	}
+	return nil, nil
+}
+
+// Reviewers returns the owners of filePaths according to the owners file on ref
+func (r *Resolver) Reviewers(ctx context.Context, projectID int, ref string, filePaths []string) ([]string, error) {
+	file, err := r.Load(ctx, projectID, ref)
+	if err != nil || file == nil {
+		return nil, err
+	}
+	return file.Reviewers(filePaths), nil
+}
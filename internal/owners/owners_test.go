@@ -0,0 +1,99 @@
+package owners
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+)
+
+const ownersYAML = `owners:
+  - path: "dataproducts/**"
+    owners: ["@data-platform"]
+  - path: "dataproducts/source/analytics/**"
+    owners: ["@alice", "analytics-team/reviewers"]
+  - path: "serviceaccounts/**"
+    owners: ["@bob", "@Alice"]
+`
+
+// mockClient serves files on the target branch
+type mockClient struct {
+	files map[string]string
+	err   error
+}
+
+func (m *mockClient) FetchFileContent(ctx context.Context, projectID int, filePath, ref string) (*gitlab.FileContent, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	content, ok := m.files[ref+":"+filePath]
+	if !ok {
+		return nil, gitlab.ErrNotFound
+	}
+	return &gitlab.FileContent{Content: content}, nil
+}
+
+func TestFile_OwnersOf(t *testing.T) {
+	file, err := Parse(ownersYAML)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"@alice", "analytics-team/reviewers"}, file.OwnersOf("dataproducts/source/analytics/prod/product.yaml"), "last matching rule wins")
+	assert.Equal(t, []string{"@data-platform"}, file.OwnersOf("dataproducts/source/billing/prod/product.yaml"))
+	assert.Nil(t, file.OwnersOf("README.md"))
+}
+
+func TestFile_Reviewers(t *testing.T) {
+	file, err := Parse(ownersYAML)
+	assert.NoError(t, err)
+
+	reviewers := file.Reviewers([]string{
+		"dataproducts/source/analytics/prod/product.yaml",
+		"serviceaccounts/prod/analytics_astro_prod_appuser.yaml",
+		"dataproducts/source/billing/dev/product.yaml",
+		"README.md",
+	})
+	assert.Equal(t, []string{"alice", "analytics-team/reviewers", "bob", "data-platform"}, reviewers)
+}
+
+func TestParse_Invalid(t *testing.T) {
+	_, err := Parse("owners:\n  - owners: [alice]\n")
+	assert.Error(t, err)
+	_, err = Parse("owners:\n  - path: docs/**\n")
+	assert.Error(t, err)
+	_, err = Parse("owners: [")
+	assert.Error(t, err)
+}
+
+func TestResolver_Reviewers(t *testing.T) {
+	ctx := context.Background()
+	client := &mockClient{files: map[string]string{"main:owners.yaml": ownersYAML}}
+	resolver := NewResolver(client, []string{"OWNERS", "owners.yaml"})
+
+	reviewers, err := resolver.Reviewers(ctx, 1, "main", []string{"serviceaccounts/prod/x.yaml"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"bob", "Alice"}, reviewers)
+
+	// The first existing file wins
+	client.files["main:OWNERS"] = "owners:\n  - path: \"**\"\n    owners: [carol]\n"
+	reviewers, err = resolver.Reviewers(ctx, 1, "main", []string{"serviceaccounts/prod/x.yaml"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"carol"}, reviewers)
+
+	// No owners file on the branch
+	reviewers, err = resolver.Reviewers(ctx, 1, "release", []string{"serviceaccounts/prod/x.yaml"})
+	assert.NoError(t, err)
+	assert.Empty(t, reviewers)
+
+	client.err = errors.New("500 Internal Server Error")
+	_, err = resolver.Reviewers(ctx, 1, "main", []string{"serviceaccounts/prod/x.yaml"})
+	assert.Error(t, err)
+}
+
+func TestNewResolverFromConfig(t *testing.T) {
+	assert.Nil(t, NewResolverFromConfig(&mockClient{}, config.OwnersConfig{}))
+	assert.NotNil(t, NewResolverFromConfig(&mockClient{}, config.OwnersConfig{Enabled: true, Files: []string{"OWNERS"}}))
+}
@@ -0,0 +1,135 @@
+package prevalidate
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrMalformed is returned for bodies that are not a JSON object with a project ID
+	ErrMalformed = errors.New("malformed webhook payload")
+	// ErrEventType is returned for events the endpoint does not handle
+	ErrEventType = errors.New("unsupported event type")
+	// ErrProject is returned for projects outside the allowlist
+	ErrProject = errors.New("project is not allowed")
+	// ErrTooLarge is returned for bodies above the size limit
+	ErrTooLarge = errors.New("webhook payload too large")
+)
+
+// Rules are the cheap checks applied to a webhook endpoint before its handler parses the payload
+type Rules struct {
+	EventTypes   []string // Accepted object_kind values; empty accepts payloads without object_kind
+	Projects     []int    // Accepted project IDs; empty accepts all projects
+	MaxBodyBytes int      // Maximum body size (0 disables the limit)
+}
+
+// RulesFromConfig returns the rules for an endpoint accepting eventTypes
+func RulesFromConfig(cfg config.WebhookConfig, eventTypes ...string) Rules {
+	return Rules{
+		EventTypes:   eventTypes,
+		Projects:     cfg.AllowedProjects,
+		MaxBodyBytes: cfg.MaxBodyBytes,
+	}
+}
+
+// Envelope is the part of a webhook payload needed to route or reject it
+type Envelope struct {
+	ObjectKind  string
+	ProjectID   int
+	ProjectPath string // path_with_namespace, empty when the payload does not carry it
+}
+
+// envelope decodes only object_kind and the project ID and path; all other fields are
+// skipped without being materialized
+type envelope struct {
+	ObjectKind  string     `json:"object_kind"`
+	ProjectID   flexibleID `json:"project_id"`
+	ProjectPath string     `json:"path_with_namespace"`
+	Project     struct {
+		ID                flexibleID `json:"id"`
+		PathWithNamespace string     `json:"path_with_namespace"`
+	} `json:"project"`
+}
+
+// flexibleID accepts numeric and string IDs, as GitLab payloads use both
+type flexibleID int
+
+func (f *flexibleID) UnmarshalJSON(data []byte) error {
+	value, err := strconv.Atoi(string(bytes.Trim(data, `"`)))
+	if err != nil {
+		return nil // Left zero and rejected as a missing project ID
+	}
+	*f = flexibleID(value)
+	return nil
+}
+
+// Check validates a webhook body against the rules and returns its envelope
+func (r Rules) Check(body []byte) (*Envelope, error) {
+	if r.MaxBodyBytes > 0 && len(body) > r.MaxBodyBytes {
+		return nil, fmt.Errorf("%w: %d bytes exceeds %d", ErrTooLarge, len(body), r.MaxBodyBytes)
+	}
+
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 || trimmed[0] != '{' {
+		return nil, fmt.Errorf("%w: expected a JSON object", ErrMalformed)
+	}
+	var env envelope
+	if err := json.Unmarshal(trimmed, &env); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+
+	result := &Envelope{ObjectKind: env.ObjectKind, ProjectID: int(env.Project.ID), ProjectPath: env.Project.PathWithNamespace}
+	if result.ProjectID == 0 {
+		result.ProjectID = int(env.ProjectID)
+	}
+	if result.ProjectPath == "" {
+		result.ProjectPath = env.ProjectPath
+	}
+
+	if len(r.EventTypes) > 0 && !slices.Contains(r.EventTypes, result.ObjectKind) {
+		return nil, fmt.Errorf("%w: %q", ErrEventType, result.ObjectKind)
+	}
+	if result.ProjectID <= 0 {
+		return nil, fmt.Errorf("%w: missing project id", ErrMalformed)
+	}
+	if len(r.Projects) > 0 && !slices.Contains(r.Projects, result.ProjectID) {
+		return nil, fmt.Errorf("%w: %d", ErrProject, result.ProjectID)
+	}
+	return result, nil
+}
+
+// Middleware rejects payloads failing the rules before the handler unmarshals them
+func (r Rules) Middleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if !c.Is("json") {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Content-Type must be application/json",
+			})
+		}
+
+		_, err := r.Check(c.Body())
+		switch {
+		case err == nil:
+			return c.Next()
+		case errors.Is(err, ErrTooLarge):
+			logging.Warn("Rejected webhook", zap.String("path", c.Path()), zap.Error(err))
+			return c.Status(fiber.StatusRequestEntityTooLarge).JSON(fiber.Map{"error": err.Error()})
+		case errors.Is(err, ErrProject):
+			logging.Warn("Rejected webhook", zap.String("path", c.Path()), zap.Error(err))
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": err.Error()})
+		default:
+			logging.Warn("Rejected webhook", zap.String("path", c.Path()), zap.Error(err))
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+	}
+}
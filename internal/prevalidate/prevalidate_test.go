@@ -0,0 +1,94 @@
+package prevalidate
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+)
+
+func TestRules_Check(t *testing.T) {
+	rules := Rules{EventTypes: []string{"merge_request"}, Projects: []int{42}, MaxBodyBytes: 200}
+
+	tests := []struct {
+		name    string
+		body    string
+		wantErr error
+	}{
+		{"valid merge request", `{"object_kind":"merge_request","project":{"id":42},"object_attributes":{"iid":1}}`, nil},
+		{"string project id", `{"object_kind":"merge_request","project":{"id":"42"}}`, nil},
+		{"top-level project_id", `{"object_kind":"merge_request","project_id":42}`, nil},
+		{"too large", `{"object_kind":"merge_request","project":{"id":42},"description":"` + strings.Repeat("x", 200) + `"}`, ErrTooLarge},
+		{"empty body", ``, ErrMalformed},
+		{"not an object", `[1,2,3]`, ErrMalformed},
+		{"truncated json", `{"object_kind":"merge_request","project":{"id":42}`, ErrMalformed},
+		{"wrong event type", `{"object_kind":"push","project":{"id":42}}`, ErrEventType},
+		{"missing project", `{"object_kind":"merge_request"}`, ErrMalformed},
+		{"project not allowed", `{"object_kind":"merge_request","project":{"id":7}}`, ErrProject},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := rules.Check([]byte(tt.body))
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, errors.Is(err, tt.wantErr), "got %v", err)
+			}
+		})
+	}
+}
+
+func TestRules_CheckReturnsEnvelope(t *testing.T) {
+	env, err := Rules{}.Check([]byte(`{"project_id":9,"closure_days":30}`))
+	assert.NoError(t, err)
+	assert.Equal(t, &Envelope{ProjectID: 9}, env)
+
+	env, err = Rules{}.Check([]byte(`{"object_kind":"push","project":{"id":9,"path_with_namespace":"data/config"}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, &Envelope{ObjectKind: "push", ProjectID: 9, ProjectPath: "data/config"}, env)
+
+	env, err = Rules{}.Check([]byte(`{"event_name":"project_create","project_id":9,"path_with_namespace":"data/new"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "data/new", env.ProjectPath)
+}
+
+func TestRulesFromConfig(t *testing.T) {
+	rules := RulesFromConfig(config.WebhookConfig{AllowedProjects: []int{1}, MaxBodyBytes: 10}, "push")
+	assert.Equal(t, Rules{EventTypes: []string{"push"}, Projects: []int{1}, MaxBodyBytes: 10}, rules)
+}
+
+func TestMiddleware(t *testing.T) {
+	app := fiber.New()
+	app.Post("/hook", Rules{EventTypes: []string{"push"}, Projects: []int{42}, MaxBodyBytes: 100}.Middleware(),
+		func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+
+	tests := []struct {
+		name        string
+		body        string
+		contentType string
+		status      int
+	}{
+		{"accepted", `{"object_kind":"push","project":{"id":42}}`, "application/json", 200},
+		{"wrong content type", `{"object_kind":"push","project":{"id":42}}`, "text/plain", 400},
+		{"garbage", `not json`, "application/json", 400},
+		{"wrong event", `{"object_kind":"note","project":{"id":42}}`, "application/json", 400},
+		{"project not allowed", `{"object_kind":"push","project":{"id":1}}`, "application/json", 403},
+		{"too large", `{"object_kind":"push","project":{"id":42},"x":"` + strings.Repeat("x", 100) + `"}`, "application/json", 413},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/hook", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.status, resp.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,403 @@
+package projectfilter
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/instances"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/prevalidate"
+	"go.uber.org/zap"
+)
+
+// Subsystems that can be enabled per project; the names match the webhook endpoints
+var Subsystems = []string{config.EndpointReview, config.EndpointAutoRebase, config.EndpointStaleMRCleanup}
+
+// Reasons a project is not handled by a subsystem
+const (
+	ReasonDenied            = "project_denied"          // The project matches the denylist
+	ReasonNotAllowed        = "project_not_allowed"     // An allowlist is set and the project does not match it
+	ReasonSubsystemDisabled = "subsystem_disabled"      // The subsystem is not enabled for the project
+	ReasonPathUnresolved    = "project_path_unresolved" // A path regex decides and the project path is unknown
+)
+
+// noneSubsystems disables every subsystem for the projects of a subsystem entry
+const noneSubsystems = "none"
+
+// PathResolver returns the path (path_with_namespace) of a project of the named GitLab
+// instance whose events do not carry it
+type PathResolver func(ctx context.Context, instance string, projectID int) (string, error)
+
+const (
+	// pathLookupTimeout bounds one project path lookup, so a slow GitLab does not hold up
+	// deliveries for long
+	pathLookupTimeout = 5 * time.Second
+	// failedLookupTTL is how long a failed path lookup is cached before the project is
+	// looked up again
+	failedLookupTTL = 30 * time.Second
+)
+
+// projectKey identifies a project across GitLab instances, whose project IDs overlap
+type projectKey struct {
+	instance  string
+	projectID int
+}
+
+// resolvedPath is a cached path lookup; failed lookups have an empty path and expire
+type resolvedPath struct {
+	path    string
+	expires time.Time // Zero for resolved paths
+}
+
+// selector matches a project by ID or by a regular expression on its path
+type selector struct {
+	id      int
+	pattern *regexp.Regexp
+}
+
+func parseSelector(entry string) (selector, error) {
+	entry = strings.TrimSpace(entry)
+	if id, err := strconv.Atoi(entry); err == nil {
+		if id <= 0 {
+			return selector{}, fmt.Errorf("invalid project ID %d", id)
+		}
+		return selector{id: id}, nil
+	}
+	pattern, err := regexp.Compile(entry)
+	if err != nil {
+		return selector{}, fmt.Errorf("invalid project path regex %q: %w", entry, err)
+	}
+	return selector{pattern: pattern}, nil
+}
+
+// matches reports whether the selector matches the project; known is false when the selector
+// matches on the path and the path is unknown
+func (s selector) matches(projectID int, path string) (matched, known bool) {
+	if s.pattern == nil {
+		return s.id == projectID, true
+	}
+	if path == "" {
+		return false, false
+	}
+	return s.pattern.MatchString(path), true
+}
+
+// subsystemRule enables the listed subsystems for the projects its selector matches
+type subsystemRule struct {
+	selector
+	enabled map[string]bool
+}
+
+// Filter decides which projects naysayer handles and which subsystems are enabled for
+// each, so that one group webhook can serve projects with different needs
+type Filter struct {
+	allow   []selector
+	deny    []selector
+	rules   []subsystemRule
+	resolve PathResolver
+	router  *instances.Router // Picks the instance of webhook deliveries, nil for one instance
+
+	now   func() time.Time
+	mu    sync.Mutex
+	paths map[projectKey]resolvedPath
+}
+
+// NewFilter creates a filter from cfg; resolve looks up the path of projects whose events
+// carry only their ID and may be nil
+func NewFilter(cfg config.ProjectFilterConfig, resolve PathResolver) (*Filter, error) {
+	f := &Filter{resolve: resolve, now: time.Now, paths: make(map[projectKey]resolvedPath)}
+	for _, entry := range cfg.Allow {
+		sel, err := parseSelector(entry)
+		if err != nil {
+			return nil, fmt.Errorf("PROJECT_ALLOWLIST: %w", err)
+		}
+		f.allow = append(f.allow, sel)
+	}
+	for _, entry := range cfg.Deny {
+		sel, err := parseSelector(entry)
+		if err != nil {
+			return nil, fmt.Errorf("PROJECT_DENYLIST: %w", err)
+		}
+		f.deny = append(f.deny, sel)
+	}
+	for _, entry := range cfg.Subsystems {
+		rule, err := parseSubsystemRule(entry)
+		if err != nil {
+			return nil, fmt.Errorf("PROJECT_SUBSYSTEMS: %w", err)
+		}
+		f.rules = append(f.rules, rule)
+	}
+	return f, nil
+}
+
+// parseSubsystemRule parses <project>=<subsystem>[,<subsystem>]; the last = separates the
+// subsystems so that path regexes may contain =
+func parseSubsystemRule(entry string) (subsystemRule, error) {
+	index := strings.LastIndex(entry, "=")
+	if index < 0 {
+		return subsystemRule{}, fmt.Errorf("entry %q is not <project>=<subsystems>", entry)
+	}
+	sel, err := parseSelector(entry[:index])
+	if err != nil {
+		return subsystemRule{}, err
+	}
+
+	rule := subsystemRule{selector: sel, enabled: make(map[string]bool)}
+	for _, name := range strings.Split(entry[index+1:], ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch {
+		case name == noneSubsystems:
+		case isSubsystem(name):
+			rule.enabled[name] = true
+		default:
+			return subsystemRule{}, fmt.Errorf("unknown subsystem %q in %q (expected %s or %s)",
+				name, entry, strings.Join(Subsystems, ", "), noneSubsystems)
+		}
+	}
+	return rule, nil
+}
+
+func isSubsystem(name string) bool {
+	for _, subsystem := range Subsystems {
+		if subsystem == name {
+			return true
+		}
+	}
+	return false
+}
+
+// NewFilterFromConfig returns the project filter, resolving project paths with the GitLab
+// API of the instance each project belongs to, or nil when no filter is configured
+func NewFilterFromConfig(cfg *config.Config) (*Filter, error) {
+	if !cfg.Projects.Configured() {
+		return nil, nil
+	}
+	clients := map[string]*gitlab.Client{instances.Default: gitlab.NewClientWithConfig(cfg)}
+	for _, instance := range cfg.GitLab.Instances {
+		instanceCfg, _ := cfg.ForGitLabInstance(instance.Name)
+		clients[instance.Name] = gitlab.NewClientWithConfig(instanceCfg)
+	}
+	f, err := NewFilter(cfg.Projects, func(ctx context.Context, instance string, projectID int) (string, error) {
+		client, ok := clients[instance]
+		if !ok {
+			return "", fmt.Errorf("unknown GitLab instance %q", instance)
+		}
+		project, err := client.GetProject(ctx, projectID)
+		if err != nil {
+			return "", err
+		}
+		return project.PathWithNamespace, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	f.router = instances.NewRouterFromConfig(cfg)
+	return f, nil
+}
+
+// usesPaths reports whether any entry matches on the project path
+func (f *Filter) usesPaths() bool {
+	for _, selectors := range [][]selector{f.allow, f.deny} {
+		for _, sel := range selectors {
+			if sel.pattern != nil {
+				return true
+			}
+		}
+	}
+	for _, rule := range f.rules {
+		if rule.pattern != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// projectPath returns path, or the resolved path of the project when the event did not
+// carry it, or "" when it cannot be resolved. Lookups run outside the lock, so concurrent
+// deliveries of other projects are not held up by a slow GitLab.
+func (f *Filter) projectPath(ctx context.Context, instance string, projectID int, path string) string {
+	if path != "" || f.resolve == nil || !f.usesPaths() {
+		return path
+	}
+
+	key := projectKey{instance: instance, projectID: projectID}
+	f.mu.Lock()
+	cached, ok := f.paths[key]
+	f.mu.Unlock()
+	if ok && (cached.expires.IsZero() || f.now().Before(cached.expires)) {
+		return cached.path
+	}
+
+	lookupCtx, cancel := context.WithTimeout(ctx, pathLookupTimeout)
+	defer cancel()
+	resolved, err := f.resolve(lookupCtx, instance, projectID)
+	entry := resolvedPath{path: resolved}
+	if err != nil {
+		logging.Warn("Failed to resolve the path of project for the project filter",
+			zap.String("instance", instance),
+			zap.Int("project_id", projectID),
+			zap.Error(err))
+		entry = resolvedPath{expires: f.now().Add(failedLookupTTL)}
+	}
+
+	f.mu.Lock()
+	f.paths[key] = entry
+	f.mu.Unlock()
+	return entry.path
+}
+
+// Allows reports whether subsystem handles the project, and the reason when it does not.
+// path may be empty when the event does not carry it. Projects whose path cannot be resolved
+// match no allowlist regex and are not handled when a denylist or subsystem regex could match
+// them. instance names the GitLab instance of the project, empty for the default instance,
+// and ctx bounds the path lookup. A nil filter allows every project.
+func (f *Filter) Allows(ctx context.Context, instance, subsystem string, projectID int, path string) (bool, string) {
+	if f == nil {
+		return true, ""
+	}
+	if instance == "" {
+		instance = instances.Default
+	}
+	path = f.projectPath(ctx, instance, projectID, path)
+
+	unresolved := false
+	for _, sel := range f.deny {
+		matched, known := sel.matches(projectID, path)
+		if matched {
+			return false, ReasonDenied
+		}
+		unresolved = unresolved || !known
+	}
+	if unresolved {
+		return false, ReasonPathUnresolved
+	}
+	if len(f.allow) > 0 {
+		allowed := false
+		for _, sel := range f.allow {
+			if matched, _ := sel.matches(projectID, path); matched {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return false, ReasonNotAllowed
+		}
+	}
+	for _, rule := range f.rules {
+		matched, known := rule.matches(projectID, path)
+		if !known {
+			return false, ReasonPathUnresolved
+		}
+		if matched {
+			if !rule.enabled[subsystem] {
+				return false, ReasonSubsystemDisabled
+			}
+			break
+		}
+	}
+	return true, ""
+}
+
+// Check is Allows recording skipped projects for the metrics endpoint
+func (f *Filter) Check(ctx context.Context, instance, subsystem string, projectID int, path string) (bool, string) {
+	allowed, reason := f.Allows(ctx, instance, subsystem, projectID, path)
+	if !allowed {
+		recordSkip(subsystem, reason)
+		logging.Info("Project is not handled by subsystem",
+			zap.Int("project_id", projectID),
+			zap.String("subsystem", subsystem),
+			zap.String("reason", reason))
+	}
+	return allowed, reason
+}
+
+// Middleware answers deliveries for projects subsystem does not handle with 200 and a
+// skipped status, so that group webhooks are not disabled by GitLab for failing. Payloads
+// without a project ID are left to the handler. Project paths are resolved with the GitLab
+// instance the delivery came from. A nil filter lets every request through.
+func (f *Filter) Middleware(subsystem string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if f == nil {
+			return c.Next()
+		}
+		env, err := prevalidate.Rules{}.Check(c.Body())
+		if err != nil {
+			return c.Next()
+		}
+		instance := f.router.Resolve(c.Get(instances.Header), c.Body())
+		if allowed, reason := f.Check(c.UserContext(), instance, subsystem, env.ProjectID, env.ProjectPath); !allowed {
+			return c.JSON(fiber.Map{
+				"webhook_response": "processed",
+				"status":           "skipped",
+				"reason":           reason,
+				"project_id":       env.ProjectID,
+			})
+		}
+		return c.Next()
+	}
+}
+
+var (
+	defaultMu     sync.RWMutex
+	defaultFilter *Filter
+)
+
+// SetDefault installs the process-wide filter used by webhook routes and background jobs
+func SetDefault(f *Filter) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultFilter = f
+}
+
+// Default returns the process-wide filter, or nil when no filter is configured
+func Default() *Filter {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultFilter
+}
+
+// SkipCount is the number of times a subsystem skipped projects for a reason since startup
+type SkipCount struct {
+	Subsystem string
+	Reason    string
+	Count     int64
+}
+
+var (
+	skipsMu sync.Mutex
+	skips   = make(map[[2]string]int64)
+)
+
+func recordSkip(subsystem, reason string) {
+	skipsMu.Lock()
+	defer skipsMu.Unlock()
+	skips[[2]string{subsystem, reason}]++
+}
+
+// Skips returns the projects skipped since startup, sorted by subsystem and reason
+func Skips() []SkipCount {
+	skipsMu.Lock()
+	defer skipsMu.Unlock()
+
+	result := make([]SkipCount, 0, len(skips))
+	for key, count := range skips {
+		result = append(result, SkipCount{Subsystem: key[0], Reason: key[1], Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Subsystem != result[j].Subsystem {
+			return result[i].Subsystem < result[j].Subsystem
+		}
+		return result[i].Reason < result[j].Reason
+	})
+	return result
+}
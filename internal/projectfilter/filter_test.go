@@ -0,0 +1,248 @@
+package projectfilter
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/instances"
+)
+
+func TestNewFilter_RejectsInvalidEntries(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.ProjectFilterConfig
+	}{
+		{"invalid regex", config.ProjectFilterConfig{Allow: []string{"data/("}}},
+		{"negative ID", config.ProjectFilterConfig{Deny: []string{"-4"}}},
+		{"missing subsystems", config.ProjectFilterConfig{Subsystems: []string{"123"}}},
+		{"unknown subsystem", config.ProjectFilterConfig{Subsystems: []string{"123=review,deploy"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewFilter(tt.cfg, nil)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestNewFilterFromConfig(t *testing.T) {
+	filter, err := NewFilterFromConfig(&config.Config{})
+	assert.NoError(t, err)
+	assert.Nil(t, filter)
+
+	filter, err = NewFilterFromConfig(&config.Config{Projects: config.ProjectFilterConfig{Allow: []string{"42"}}})
+	assert.NoError(t, err)
+	assert.NotNil(t, filter)
+}
+
+func TestFilter_Allows(t *testing.T) {
+	filter, err := NewFilter(config.ProjectFilterConfig{
+		Allow: []string{"^data/", "7"},
+		Deny:  []string{"^data/archive/", "13"},
+		Subsystems: []string{
+			"^data/legacy/=review",
+			"^data/sandbox/=none",
+			"7=review, auto-rebase",
+		},
+	}, nil)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		subsystem string
+		projectID int
+		path      string
+		allowed   bool
+		reason    string
+	}{
+		{"allowed by path", config.EndpointAutoRebase, 1, "data/config", true, ""},
+		{"allowed by ID", config.EndpointReview, 7, "other/tools", true, ""},
+		{"not allowed", config.EndpointReview, 2, "other/tools", false, ReasonNotAllowed},
+		{"denied by path", config.EndpointReview, 3, "data/archive/old", false, ReasonDenied},
+		{"denied by ID", config.EndpointReview, 13, "data/config", false, ReasonDenied},
+		{"subsystem enabled", config.EndpointReview, 4, "data/legacy/x", true, ""},
+		{"subsystem disabled", config.EndpointStaleMRCleanup, 4, "data/legacy/x", false, ReasonSubsystemDisabled},
+		{"every subsystem disabled", config.EndpointReview, 5, "data/sandbox/y", false, ReasonSubsystemDisabled},
+		{"subsystem disabled by ID", config.EndpointStaleMRCleanup, 7, "other/tools", false, ReasonSubsystemDisabled},
+		{"denied by ID without path", config.EndpointReview, 13, "", false, ReasonDenied},
+		{"path unknown", config.EndpointReview, 8, "", false, ReasonPathUnresolved},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allowed, reason := filter.Allows(context.Background(), "", tt.subsystem, tt.projectID, tt.path)
+			assert.Equal(t, tt.allowed, allowed)
+			assert.Equal(t, tt.reason, reason)
+		})
+	}
+
+	var disabled *Filter
+	allowed, _ := disabled.Allows(context.Background(), "", config.EndpointReview, 2, "")
+	assert.True(t, allowed)
+}
+
+func TestFilter_UnresolvedPathsFailClosed(t *testing.T) {
+	allowlist, err := NewFilter(config.ProjectFilterConfig{Allow: []string{"^data/", "7"}}, nil)
+	assert.NoError(t, err)
+	allowed, reason := allowlist.Allows(context.Background(), "", config.EndpointReview, 8, "")
+	assert.False(t, allowed)
+	assert.Equal(t, ReasonNotAllowed, reason)
+	allowed, _ = allowlist.Allows(context.Background(), "", config.EndpointReview, 7, "")
+	assert.True(t, allowed, "ID entries do not need the path")
+
+	subsystems, err := NewFilter(config.ProjectFilterConfig{Subsystems: []string{"7=review", "^data/legacy/=review"}}, nil)
+	assert.NoError(t, err)
+	allowed, reason = subsystems.Allows(context.Background(), "", config.EndpointAutoRebase, 8, "")
+	assert.False(t, allowed, "a subsystem regex might disable the subsystem")
+	assert.Equal(t, ReasonPathUnresolved, reason)
+	allowed, reason = subsystems.Allows(context.Background(), "", config.EndpointAutoRebase, 7, "")
+	assert.False(t, allowed)
+	assert.Equal(t, ReasonSubsystemDisabled, reason, "the ID entry decides before the regex")
+}
+
+func TestFilter_ResolvesMissingPaths(t *testing.T) {
+	lookups := 0
+	filter, err := NewFilter(config.ProjectFilterConfig{Deny: []string{"^data/archive/"}}, func(ctx context.Context, instance string, projectID int) (string, error) {
+		lookups++
+		if projectID == 9 {
+			return "", errors.New("unavailable")
+		}
+		return "data/archive/old", nil
+	})
+	assert.NoError(t, err)
+
+	allowed, reason := filter.Allows(context.Background(), "", config.EndpointStaleMRCleanup, 3, "")
+	assert.False(t, allowed)
+	assert.Equal(t, ReasonDenied, reason)
+	filter.Allows(context.Background(), "", config.EndpointStaleMRCleanup, 3, "")
+	assert.Equal(t, 1, lookups, "resolved paths are cached")
+
+	// Paths carried by the event are not resolved
+	allowed, _ = filter.Allows(context.Background(), "", config.EndpointReview, 4, "data/config")
+	assert.True(t, allowed)
+	assert.Equal(t, 1, lookups)
+
+	// Unresolvable projects might match the denylist regex
+	allowed, reason = filter.Allows(context.Background(), "", config.EndpointReview, 9, "")
+	assert.False(t, allowed)
+	assert.Equal(t, ReasonPathUnresolved, reason)
+
+	// Failed lookups are cached briefly
+	now := time.Now()
+	filter.now = func() time.Time { return now }
+	filter.Allows(context.Background(), "", config.EndpointReview, 9, "")
+	assert.Equal(t, 2, lookups, "failed lookups are cached")
+	now = now.Add(failedLookupTTL + time.Second)
+	filter.Allows(context.Background(), "", config.EndpointReview, 9, "")
+	assert.Equal(t, 3, lookups, "failed lookups are retried once expired")
+}
+
+func TestFilter_ResolvesPathsPerInstance(t *testing.T) {
+	paths := map[string]string{instances.Default: "data/archive/old", "self-managed": "data/config"}
+	filter, err := NewFilter(config.ProjectFilterConfig{Deny: []string{"^data/archive/"}}, func(ctx context.Context, instance string, projectID int) (string, error) {
+		return paths[instance], nil
+	})
+	assert.NoError(t, err)
+	filter.router = instances.NewRouter([]config.GitLabInstance{{Name: "self-managed", BaseURL: "https://git.example.com"}})
+
+	allowed, reason := filter.Allows(context.Background(), "", config.EndpointStaleMRCleanup, 3, "")
+	assert.False(t, allowed)
+	assert.Equal(t, ReasonDenied, reason)
+	allowed, _ = filter.Allows(context.Background(), "self-managed", config.EndpointStaleMRCleanup, 3, "")
+	assert.True(t, allowed, "project 3 of another instance has its own path")
+
+	// Deliveries are resolved with the instance the router picks
+	app := fiber.New()
+	app.Post("/stale-mr-cleanup", filter.Middleware(config.EndpointStaleMRCleanup), func(c *fiber.Ctx) error {
+		return c.SendString("handled")
+	})
+	post := func(instance string) string {
+		req := httptest.NewRequest("POST", "/stale-mr-cleanup", strings.NewReader(`{"project_id":3}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(instances.Header, instance)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		body, _ := io.ReadAll(resp.Body)
+		return string(body)
+	}
+	assert.Contains(t, post(""), ReasonDenied)
+	assert.Equal(t, "handled", post("https://git.example.com"))
+}
+
+func TestFilter_PathLookupsDoNotBlockOtherProjects(t *testing.T) {
+	release := make(chan struct{})
+	filter, err := NewFilter(config.ProjectFilterConfig{Deny: []string{"^data/archive/"}}, func(ctx context.Context, instance string, projectID int) (string, error) {
+		if _, ok := ctx.Deadline(); !ok {
+			return "", errors.New("lookup without deadline")
+		}
+		if projectID == 1 {
+			<-release
+		}
+		return "data/config", nil
+	})
+	assert.NoError(t, err)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		filter.Allows(context.Background(), "", config.EndpointReview, 1, "")
+	}()
+
+	allowed, reason := filter.Allows(context.Background(), "", config.EndpointReview, 2, "")
+	assert.True(t, allowed, reason)
+	close(release)
+	<-done
+}
+
+func TestFilter_Middleware(t *testing.T) {
+	filter, err := NewFilter(config.ProjectFilterConfig{Subsystems: []string{"^data/legacy/=review"}}, nil)
+	assert.NoError(t, err)
+
+	app := fiber.New()
+	app.Post("/auto-rebase", filter.Middleware(config.EndpointAutoRebase), func(c *fiber.Ctx) error {
+		return c.SendString("handled")
+	})
+	post := func(body string) (int, string) {
+		req := httptest.NewRequest("POST", "/auto-rebase", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		respBody, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(respBody)
+	}
+
+	status, body := post(`{"object_kind":"push","project":{"id":5,"path_with_namespace":"data/legacy/etl"}}`)
+	assert.Equal(t, 200, status)
+	assert.Contains(t, body, `"status":"skipped"`)
+	assert.Contains(t, body, ReasonSubsystemDisabled)
+
+	_, body = post(`{"object_kind":"push","project":{"id":6,"path_with_namespace":"data/config"}}`)
+	assert.Equal(t, "handled", body)
+
+	// Payloads without a project are left to the handler
+	_, body = post(`{"object_kind":"push"}`)
+	assert.Equal(t, "handled", body)
+
+	found := false
+	for _, skip := range Skips() {
+		if skip.Subsystem == config.EndpointAutoRebase && skip.Reason == ReasonSubsystemDisabled {
+			found = skip.Count > 0
+		}
+	}
+	assert.True(t, found)
+
+	var disabled *Filter
+	app = fiber.New()
+	app.Post("/hook", disabled.Middleware(config.EndpointReview), func(c *fiber.Ctx) error { return c.SendStatus(204) })
+	resp, err := app.Test(httptest.NewRequest("POST", "/hook", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 204, resp.StatusCode)
+}
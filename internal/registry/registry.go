@@ -0,0 +1,101 @@
+package registry
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+)
+
+// keyPrefix is the state store namespace for onboarded projects
+const keyPrefix = "projects/"
+
+// Project is a GitLab project naysayer reviews
+type Project struct {
+	ID           int       `json:"id"`
+	Path         string    `json:"path"` // path_with_namespace, e.g. dataverse/dataverse-config
+	RegisteredAt time.Time `json:"registered_at"`
+}
+
+// Registry keeps the projects onboarded through GitLab system hooks: every project whose
+// path matches one of the patterns (path.Match syntax) is reviewed
+type Registry struct {
+	st       store.Store
+	patterns []string
+}
+
+// NewRegistry creates a registry backed by st for projects matching patterns
+func NewRegistry(st store.Store, patterns []string) *Registry {
+	return &Registry{st: st, patterns: patterns}
+}
+
+// key returns the state store key of a project
+func key(projectID int) string {
+	return fmt.Sprintf("%s%d", keyPrefix, projectID)
+}
+
+// Matches reports whether a project path matches one of the patterns
+func (r *Registry) Matches(projectPath string) bool {
+	for _, pattern := range r.patterns {
+		if matched, _ := path.Match(pattern, projectPath); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// Sync registers a project whose path matches the patterns and removes one that no longer
+// does, e.g. after a rename or transfer. It reports whether the project is registered.
+func (r *Registry) Sync(projectID int, projectPath string, now time.Time) (bool, error) {
+	if !r.Matches(projectPath) {
+		return false, r.Remove(projectID)
+	}
+
+	existing, err := r.Get(projectID)
+	if err != nil {
+		return false, err
+	}
+	if existing != nil && existing.Path == projectPath {
+		return true, nil
+	}
+	project := Project{ID: projectID, Path: projectPath, RegisteredAt: now}
+	if existing != nil {
+		project.RegisteredAt = existing.RegisteredAt
+	}
+	return true, store.PutJSON(r.st, key(projectID), project)
+}
+
+// Get returns a registered project, or nil when it is not registered
+func (r *Registry) Get(projectID int) (*Project, error) {
+	var p Project
+	found, err := store.GetJSON(r.st, key(projectID), &p)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// Remove forgets a project
+func (r *Registry) Remove(projectID int) error {
+	return r.st.Delete(key(projectID))
+}
+
+// List returns the registered projects sorted by path
+func (r *Registry) List() ([]Project, error) {
+	keys, err := r.st.Keys(keyPrefix)
+	if err != nil {
+		return nil, err
+	}
+	projects := make([]Project, 0, len(keys))
+	for _, k := range keys {
+		var p Project
+		if found, err := store.GetJSON(r.st, k, &p); err != nil || !found {
+			continue
+		}
+		projects = append(projects, p)
+	}
+	sort.Slice(projects, func(i, j int) bool { return projects[i].Path < projects[j].Path })
+	return projects, nil
+}
@@ -0,0 +1,56 @@
+package registry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+)
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry(store.NewMemoryStore(), []string{"dataverse/*", "platform/dataverse-*"})
+	now := time.Date(2024, 6, 20, 12, 0, 0, 0, time.UTC)
+
+	assert.True(t, r.Matches("dataverse/dataverse-config"))
+	assert.True(t, r.Matches("platform/dataverse-marts"))
+	assert.False(t, r.Matches("dataverse/sub/group"))
+	assert.False(t, r.Matches("platform/ci-templates"))
+
+	registered, err := r.Sync(10, "dataverse/dataverse-config", now)
+	assert.NoError(t, err)
+	assert.True(t, registered)
+	registered, err = r.Sync(11, "platform/ci-templates", now)
+	assert.NoError(t, err)
+	assert.False(t, registered)
+	_, err = r.Sync(12, "platform/dataverse-marts", now)
+	assert.NoError(t, err)
+
+	projects, err := r.List()
+	assert.NoError(t, err)
+	assert.Equal(t, []Project{
+		{ID: 10, Path: "dataverse/dataverse-config", RegisteredAt: now},
+		{ID: 12, Path: "platform/dataverse-marts", RegisteredAt: now},
+	}, projects)
+
+	// Renames within the patterns keep the registration date
+	_, err = r.Sync(10, "dataverse/config", now.Add(time.Hour))
+	assert.NoError(t, err)
+	renamed, err := r.Get(10)
+	assert.NoError(t, err)
+	assert.Equal(t, &Project{ID: 10, Path: "dataverse/config", RegisteredAt: now}, renamed)
+
+	// Transfers out of the patterns remove the project
+	registered, err = r.Sync(12, "archive/dataverse-marts", now)
+	assert.NoError(t, err)
+	assert.False(t, registered)
+	missing, err := r.Get(12)
+	assert.NoError(t, err)
+	assert.Nil(t, missing)
+
+	assert.NoError(t, r.Remove(10))
+	projects, err = r.List()
+	assert.NoError(t, err)
+	assert.Empty(t, projects)
+}
@@ -0,0 +1,188 @@
+package replay
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+	"go.uber.org/zap"
+)
+
+// keyPrefix is the state store namespace for seen delivery UUIDs
+const keyPrefix = "replay/"
+
+// EventUUIDHeader is the header GitLab sets to a unique ID per webhook delivery
+const EventUUIDHeader = "X-Gitlab-Event-UUID"
+
+// pruneInterval limits how often expired delivery UUIDs are removed
+const pruneInterval = time.Minute
+
+var (
+	// ErrReplayed is returned for a delivery UUID that was already accepted
+	ErrReplayed = errors.New("webhook delivery was already received")
+	// ErrOutsideWindow is returned for an event timestamp outside the accepted window
+	ErrOutsideWindow = errors.New("webhook event timestamp is outside the accepted window")
+)
+
+// timeFormats are the timestamp layouts found in GitLab webhook payloads
+var timeFormats = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05 MST",
+	"2006-01-02 15:04:05 -0700",
+}
+
+// Guard rejects webhook deliveries whose UUID was seen before or whose event timestamp
+// is older (or further in the future) than the window
+type Guard struct {
+	store     store.Store
+	window    time.Duration
+	retention time.Duration
+	now       func() time.Time
+
+	mu         sync.Mutex
+	lastPruned time.Time
+}
+
+// NewGuard creates a replay guard remembering delivery UUIDs in st for retention
+func NewGuard(st store.Store, window, retention time.Duration) *Guard {
+	if retention < window {
+		// A UUID must be remembered at least as long as its timestamp is accepted
+		retention = window
+	}
+	return &Guard{
+		store:     st,
+		window:    window,
+		retention: retention,
+		now:       time.Now,
+	}
+}
+
+// NewGuardFromConfig returns a replay guard, or nil when replay protection is disabled
+func NewGuardFromConfig(cfg *config.Config, st store.Store) *Guard {
+	if !cfg.Replay.Enabled {
+		return nil
+	}
+	return NewGuard(st,
+		time.Duration(cfg.Replay.WindowMinutes)*time.Minute,
+		time.Duration(cfg.Replay.UUIDRetentionHours)*time.Hour)
+}
+
+// Check validates a delivery. Deliveries without a UUID are only checked by timestamp,
+// payloads without a recognizable timestamp only by UUID. Accepted UUIDs are remembered.
+func (g *Guard) Check(uuid string, body []byte) error {
+	now := g.now()
+	if eventTime, ok := EventTime(body); ok && g.window > 0 {
+		if age := now.Sub(eventTime); age > g.window || age < -g.window {
+			return fmt.Errorf("%w: event at %s", ErrOutsideWindow, eventTime.UTC().Format(time.RFC3339))
+		}
+	}
+
+	if uuid == "" {
+		return nil
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.pruneLocked(now)
+
+	key := keyPrefix + uuid
+	var seen time.Time
+	found, err := store.GetJSON(g.store, key, &seen)
+	if err != nil {
+		return err
+	}
+	if found && now.Sub(seen) <= g.retention {
+		return fmt.Errorf("%w: %s", ErrReplayed, uuid)
+	}
+	return store.PutJSON(g.store, key, now)
+}
+
+// pruneLocked removes delivery UUIDs older than the retention, at most once per pruneInterval
+func (g *Guard) pruneLocked(now time.Time) {
+	if now.Sub(g.lastPruned) < pruneInterval {
+		return
+	}
+	g.lastPruned = now
+
+	keys, err := g.store.Keys(keyPrefix)
+	if err != nil {
+		logging.Warn("Failed to list webhook delivery UUIDs", zap.Error(err))
+		return
+	}
+	for _, key := range keys {
+		var seen time.Time
+		if found, err := store.GetJSON(g.store, key, &seen); err == nil && found && now.Sub(seen) > g.retention {
+			_ = g.store.Delete(key)
+		}
+	}
+}
+
+// Middleware rejects replayed deliveries before they reach the handler. A nil guard
+// lets every request through.
+func (g *Guard) Middleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if g == nil {
+			return c.Next()
+		}
+
+		err := g.Check(c.Get(EventUUIDHeader), c.Body())
+		switch {
+		case err == nil:
+			return c.Next()
+		case errors.Is(err, ErrReplayed):
+			logging.Warn("Rejected replayed webhook delivery", zap.String("path", c.Path()), zap.Error(err))
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+		case errors.Is(err, ErrOutsideWindow):
+			logging.Warn("Rejected stale webhook delivery", zap.String("path", c.Path()), zap.Error(err))
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": err.Error()})
+		default:
+			// Failing closed on store errors would drop legitimate deliveries
+			logging.Error("Replay check failed", zap.String("path", c.Path()), zap.Error(err))
+			return c.Next()
+		}
+	}
+}
+
+// EventTime returns the event timestamp of a webhook payload: object_attributes.updated_at
+// for merge request events, or a top-level timestamp (e.g. sent by scheduled cleanup jobs).
+// Push events carry no event timestamp; commit dates are not used as they can be old.
+func EventTime(body []byte) (time.Time, bool) {
+	var payload struct {
+		Timestamp        string `json:"timestamp"`
+		ObjectAttributes struct {
+			UpdatedAt string `json:"updated_at"`
+		} `json:"object_attributes"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return time.Time{}, false
+	}
+
+	for _, value := range []string{payload.ObjectAttributes.UpdatedAt, payload.Timestamp} {
+		if t, ok := parseTime(value); ok {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func parseTime(value string) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, false
+	}
+	for _, layout := range timeFormats {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,106 @@
+package replay
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+)
+
+func newTestGuard(now time.Time) (*Guard, *store.MemoryStore) {
+	st := store.NewMemoryStore()
+	guard := NewGuard(st, 15*time.Minute, time.Hour)
+	guard.now = func() time.Time { return now }
+	return guard, st
+}
+
+func TestGuard_DuplicateUUID(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	guard, st := newTestGuard(now)
+	push := []byte(`{"object_kind":"push","ref":"refs/heads/main"}`)
+
+	assert.NoError(t, guard.Check("uuid-1", push))
+	assert.True(t, errors.Is(guard.Check("uuid-1", push), ErrReplayed))
+	assert.NoError(t, guard.Check("uuid-2", push))
+
+	// UUIDs are forgotten after the retention
+	guard.now = func() time.Time { return now.Add(2 * time.Hour) }
+	assert.NoError(t, guard.Check("uuid-3", push))
+	keys, _ := st.Keys(keyPrefix)
+	assert.Equal(t, []string{keyPrefix + "uuid-3"}, keys)
+	assert.NoError(t, guard.Check("uuid-1", push))
+}
+
+func TestGuard_TimestampWindow(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	guard, _ := newTestGuard(now)
+
+	fresh := []byte(`{"object_kind":"merge_request","object_attributes":{"updated_at":"2026-03-01 11:50:00 UTC"}}`)
+	stale := []byte(`{"object_kind":"merge_request","object_attributes":{"updated_at":"2026-03-01T11:30:00Z"}}`)
+	future := []byte(`{"project_id":1,"timestamp":"2026-03-01T13:00:00+01:00"}`)
+	skewed := []byte(`{"project_id":1,"timestamp":"2026-03-01T14:00:00+01:00"}`)
+
+	assert.NoError(t, guard.Check("", fresh))
+	assert.True(t, errors.Is(guard.Check("", stale), ErrOutsideWindow))
+	assert.NoError(t, guard.Check("", future))
+	assert.True(t, errors.Is(guard.Check("", skewed), ErrOutsideWindow))
+
+	// A rejected stale delivery does not burn its UUID
+	assert.Error(t, guard.Check("uuid-1", stale))
+	assert.NoError(t, guard.Check("uuid-1", fresh))
+}
+
+func TestEventTime(t *testing.T) {
+	eventTime, ok := EventTime([]byte(`{"object_attributes":{"updated_at":"2026-03-01 11:50:00 +0100"}}`))
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2026, 3, 1, 10, 50, 0, 0, time.UTC), eventTime.UTC())
+
+	for _, body := range []string{`{"object_kind":"push","commits":[{"timestamp":"2020-01-01T00:00:00Z"}]}`, `{"timestamp":"yesterday"}`, `not json`} {
+		_, ok = EventTime([]byte(body))
+		assert.False(t, ok, body)
+	}
+}
+
+func TestGuard_Middleware(t *testing.T) {
+	guard, _ := newTestGuard(time.Now())
+	app := fiber.New()
+	app.Post("/auto-rebase", guard.Middleware(), func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+
+	send := func(uuid, body string) int {
+		req := httptest.NewRequest("POST", "/auto-rebase", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if uuid != "" {
+			req.Header.Set(EventUUIDHeader, uuid)
+		}
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		return resp.StatusCode
+	}
+
+	assert.Equal(t, fiber.StatusOK, send("uuid-1", `{"object_kind":"push"}`))
+	assert.Equal(t, fiber.StatusConflict, send("uuid-1", `{"object_kind":"push"}`))
+	assert.Equal(t, fiber.StatusForbidden, send("uuid-2", `{"project_id":1,"timestamp":"2020-01-01T00:00:00Z"}`))
+	assert.Equal(t, fiber.StatusOK, send("", `{"project_id":1}`))
+
+	// Disabled protection lets everything through
+	var disabled *Guard
+	app = fiber.New()
+	app.Post("/auto-rebase", disabled.Middleware(), func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+	assert.Equal(t, fiber.StatusOK, send("uuid-1", `{"object_kind":"push"}`))
+	assert.Equal(t, fiber.StatusOK, send("uuid-1", `{"object_kind":"push"}`))
+}
+
+func TestNewGuardFromConfig(t *testing.T) {
+	assert.Nil(t, NewGuardFromConfig(&config.Config{}, store.NewMemoryStore()))
+
+	guard := NewGuardFromConfig(&config.Config{Replay: config.ReplayConfig{Enabled: true, WindowMinutes: 30, UUIDRetentionHours: 0}}, store.NewMemoryStore())
+	assert.Equal(t, 30*time.Minute, guard.window)
+	assert.Equal(t, 30*time.Minute, guard.retention)
+}
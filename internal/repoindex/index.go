@@ -0,0 +1,339 @@
+package repoindex
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+	"go.uber.org/zap"
+)
+
+// keyPrefix is the state store namespace for repository tree snapshots
+const keyPrefix = "repoindex/"
+
+// TreeLister defines the GitLab operation needed to build a snapshot
+type TreeLister interface {
+	ListRepositoryTree(ctx context.Context, projectID int, ref string) ([]gitlab.TreeEntry, error)
+}
+
+// Snapshot is the persisted set of file paths for one project ref
+type Snapshot struct {
+	ProjectID   int       `json:"project_id"`
+	Ref         string    `json:"ref"`
+	Paths       []string  `json:"paths"`
+	RefreshedAt time.Time `json:"refreshed_at"`
+}
+
+// Index answers path-existence queries from local tree snapshots instead of live API calls
+type Index struct {
+	lister TreeLister
+	store  store.Store
+
+	// updateMu serializes Refresh and ApplyChanges from listing or loading a snapshot to
+	// saving it, so a push applied during a refresh is not lost
+	updateMu sync.Mutex
+
+	mu      sync.RWMutex
+	cache   map[string]map[string]struct{} // store key -> path set
+	tracked map[string]trackedRef          // refs to refresh periodically
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+type trackedRef struct {
+	projectID int
+	ref       string
+}
+
+// NewIndex creates a new repository index backed by the given state store
+func NewIndex(lister TreeLister, st store.Store) *Index {
+	return &Index{
+		lister:  lister,
+		store:   st,
+		cache:   make(map[string]map[string]struct{}),
+		tracked: make(map[string]trackedRef),
+	}
+}
+
+// snapshotKey returns the state store key for a project ref
+func snapshotKey(projectID int, ref string) string {
+	return fmt.Sprintf("%s%d/%s", keyPrefix, projectID, ref)
+}
+
+// Track registers a project ref for periodic refresh
+func (i *Index) Track(projectID int, ref string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.tracked[snapshotKey(projectID, ref)] = trackedRef{projectID: projectID, ref: ref}
+}
+
+// Refresh takes a full snapshot of the repository tree at ref (paths only)
+func (i *Index) Refresh(ctx context.Context, projectID int, ref string) (*Snapshot, error) {
+	i.updateMu.Lock()
+	defer i.updateMu.Unlock()
+	return i.refresh(ctx, projectID, ref)
+}
+
+// refresh is Refresh for callers holding updateMu
+func (i *Index) refresh(ctx context.Context, projectID int, ref string) (*Snapshot, error) {
+	entries, err := i.lister.ListRepositoryTree(ctx, projectID, ref)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repository tree for project %d ref %s: %w", projectID, ref, err)
+	}
+
+	paths := make(map[string]struct{}, len(entries))
+	for _, entry := range entries {
+		if entry.Type == "blob" {
+			paths[entry.Path] = struct{}{}
+		}
+	}
+
+	snapshot, err := i.save(projectID, ref, paths)
+	if err != nil {
+		return nil, err
+	}
+
+	i.Track(projectID, ref)
+	logging.Info("Repository index snapshot refreshed",
+		zap.Int("project_id", projectID),
+		zap.String("ref", ref),
+		zap.Int("paths", len(snapshot.Paths)))
+	return snapshot, nil
+}
+
+// ApplyChanges incrementally updates an existing snapshot with added and removed paths.
+// If no snapshot exists yet for the ref, a full refresh is taken instead.
+func (i *Index) ApplyChanges(ctx context.Context, projectID int, ref string, added, removed []string) error {
+	i.updateMu.Lock()
+	defer i.updateMu.Unlock()
+
+	paths, ok, err := i.load(projectID, ref)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		_, err := i.refresh(ctx, projectID, ref)
+		return err
+	}
+
+	paths = copyPaths(paths)
+	for _, path := range removed {
+		delete(paths, path)
+	}
+	for _, path := range added {
+		paths[path] = struct{}{}
+	}
+
+	_, err = i.save(projectID, ref, paths)
+	return err
+}
+
+// PathExists reports whether path exists at ref. The second return value is false when
+// the ref has not been snapshotted yet, in which case callers should fall back to the API.
+func (i *Index) PathExists(projectID int, ref, path string) (bool, bool) {
+	paths, ok, err := i.load(projectID, ref)
+	if err != nil || !ok {
+		// Make sure the next periodic refresh picks this ref up
+		i.Track(projectID, ref)
+		return false, false
+	}
+	_, exists := paths[path]
+	return exists, true
+}
+
+// Paths returns the sorted paths at ref accepted by match (all paths when match is nil).
+// A snapshot is taken first if the ref has not been indexed yet.
+func (i *Index) Paths(ctx context.Context, projectID int, ref string, match func(path string) bool) ([]string, error) {
+	paths, ok, err := i.load(projectID, ref)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		if _, err := i.Refresh(ctx, projectID, ref); err != nil {
+			return nil, err
+		}
+		if paths, _, err = i.load(projectID, ref); err != nil {
+			return nil, err
+		}
+	}
+
+	result := make([]string, 0)
+	for path := range paths {
+		if match == nil || match(path) {
+			result = append(result, path)
+		}
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+// Start launches the periodic background refresh of all tracked refs
+func (i *Index) Start(interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	i.mu.Lock()
+	if i.stop != nil {
+		i.mu.Unlock()
+		return
+	}
+	i.stop = make(chan struct{})
+	stop := i.stop
+	i.mu.Unlock()
+
+	i.wg.Add(1)
+	go func() {
+		defer i.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				i.RefreshAll(context.Background())
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop halts the background refresh loop
+func (i *Index) Stop() {
+	i.mu.Lock()
+	stop := i.stop
+	i.stop = nil
+	i.mu.Unlock()
+
+	if stop != nil {
+		close(stop)
+		i.wg.Wait()
+	}
+}
+
+// RefreshAll takes a fresh snapshot of every tracked ref, including refs persisted by a previous run
+func (i *Index) RefreshAll(ctx context.Context) {
+	for _, t := range i.trackedRefs() {
+		if _, err := i.Refresh(ctx, t.projectID, t.ref); err != nil {
+			logging.Warn("Repository index refresh failed",
+				zap.Int("project_id", t.projectID),
+				zap.String("ref", t.ref),
+				zap.Error(err))
+		}
+	}
+}
+
+// trackedRefs returns tracked refs merged with refs already present in the store
+func (i *Index) trackedRefs() []trackedRef {
+	i.mu.RLock()
+	refs := make(map[string]trackedRef, len(i.tracked))
+	for key, t := range i.tracked {
+		refs[key] = t
+	}
+	i.mu.RUnlock()
+
+	if keys, err := i.store.Keys(keyPrefix); err == nil {
+		for _, key := range keys {
+			if t, ok := parseSnapshotKey(key); ok {
+				refs[key] = t
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(refs))
+	for key := range refs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	result := make([]trackedRef, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, refs[key])
+	}
+	return result
+}
+
+// parseSnapshotKey converts "repoindex/<project>/<ref>" back into a tracked ref
+func parseSnapshotKey(key string) (trackedRef, bool) {
+	rest := strings.TrimPrefix(key, keyPrefix)
+	parts := strings.SplitN(rest, "/", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return trackedRef{}, false
+	}
+	projectID, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return trackedRef{}, false
+	}
+	return trackedRef{projectID: projectID, ref: parts[1]}, true
+}
+
+// load returns the path set for a ref, reading through the in-memory cache.
+// The returned set is shared and must not be modified.
+func (i *Index) load(projectID int, ref string) (map[string]struct{}, bool, error) {
+	key := snapshotKey(projectID, ref)
+
+	i.mu.RLock()
+	paths, ok := i.cache[key]
+	i.mu.RUnlock()
+	if ok {
+		return paths, true, nil
+	}
+
+	var snapshot Snapshot
+	found, err := store.GetJSON(i.store, key, &snapshot)
+	if err != nil || !found {
+		return nil, false, err
+	}
+
+	paths = make(map[string]struct{}, len(snapshot.Paths))
+	for _, path := range snapshot.Paths {
+		paths[path] = struct{}{}
+	}
+
+	i.mu.Lock()
+	i.cache[key] = paths
+	i.mu.Unlock()
+	return paths, true, nil
+}
+
+// save persists a path set and updates the cache
+func (i *Index) save(projectID int, ref string, paths map[string]struct{}) (*Snapshot, error) {
+	sorted := make([]string, 0, len(paths))
+	for path := range paths {
+		sorted = append(sorted, path)
+	}
+	sort.Strings(sorted)
+
+	snapshot := &Snapshot{
+		ProjectID:   projectID,
+		Ref:         ref,
+		Paths:       sorted,
+		RefreshedAt: time.Now().UTC(),
+	}
+
+	key := snapshotKey(projectID, ref)
+	if err := store.PutJSON(i.store, key, snapshot); err != nil {
+		return nil, err
+	}
+
+	i.mu.Lock()
+	i.cache[key] = paths
+	i.mu.Unlock()
+	return snapshot, nil
+}
+
+func copyPaths(paths map[string]struct{}) map[string]struct{} {
+	out := make(map[string]struct{}, len(paths))
+	for path := range paths {
+		out[path] = struct{}{}
+	}
+	return out
+}
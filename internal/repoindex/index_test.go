@@ -0,0 +1,200 @@
+package repoindex
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+)
+
+type mockTreeLister struct {
+	entries map[string][]gitlab.TreeEntry // ref -> entries
+	calls   int
+	err     error
+}
+
+func (m *mockTreeLister) ListRepositoryTree(ctx context.Context, projectID int, ref string) ([]gitlab.TreeEntry, error) {
+	m.calls++
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.entries[ref], nil
+}
+
+func newLister() *mockTreeLister {
+	return &mockTreeLister{
+		entries: map[string][]gitlab.TreeEntry{
+			"main": {
+				{Type: "tree", Path: "serviceaccounts"},
+				{Type: "blob", Path: "serviceaccounts/prod/a_appuser.yaml"},
+				{Type: "blob", Path: "dataproducts/source/x/product.yaml"},
+			},
+		},
+	}
+}
+
+func TestIndex_RefreshAndPathExists(t *testing.T) {
+	lister := newLister()
+	idx := NewIndex(lister, store.NewMemoryStore())
+
+	exists, indexed := idx.PathExists(1, "main", "serviceaccounts/prod/a_appuser.yaml")
+	assert.False(t, indexed, "ref should not be indexed before the first snapshot")
+	assert.False(t, exists)
+
+	snapshot, err := idx.Refresh(context.Background(), 1, "main")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"dataproducts/source/x/product.yaml", "serviceaccounts/prod/a_appuser.yaml"}, snapshot.Paths)
+
+	exists, indexed = idx.PathExists(1, "main", "serviceaccounts/prod/a_appuser.yaml")
+	assert.True(t, indexed)
+	assert.True(t, exists)
+
+	// Directories are not indexed as paths
+	exists, _ = idx.PathExists(1, "main", "serviceaccounts")
+	assert.False(t, exists)
+
+	// Refs are tracked per project
+	_, indexed = idx.PathExists(2, "main", "serviceaccounts/prod/a_appuser.yaml")
+	assert.False(t, indexed)
+}
+
+func TestIndex_RefreshError(t *testing.T) {
+	lister := &mockTreeLister{err: errors.New("boom")}
+	idx := NewIndex(lister, store.NewMemoryStore())
+
+	_, err := idx.Refresh(context.Background(), 1, "main")
+	assert.Error(t, err)
+	_, indexed := idx.PathExists(1, "main", "a")
+	assert.False(t, indexed)
+}
+
+func TestIndex_ApplyChanges(t *testing.T) {
+	lister := newLister()
+	idx := NewIndex(lister, store.NewMemoryStore())
+
+	// First apply without a snapshot takes a full refresh
+	assert.NoError(t, idx.ApplyChanges(context.Background(), 1, "main", nil, nil))
+	assert.Equal(t, 1, lister.calls)
+
+	assert.NoError(t, idx.ApplyChanges(context.Background(), 1, "main",
+		[]string{"serviceaccounts/prod/b_appuser.yaml"},
+		[]string{"serviceaccounts/prod/a_appuser.yaml"}))
+	assert.Equal(t, 1, lister.calls, "incremental update must not call the API")
+
+	exists, _ := idx.PathExists(1, "main", "serviceaccounts/prod/b_appuser.yaml")
+	assert.True(t, exists)
+	exists, _ = idx.PathExists(1, "main", "serviceaccounts/prod/a_appuser.yaml")
+	assert.False(t, exists)
+}
+
+// blockingTreeLister lists the tree as it was before a push, after being released
+type blockingTreeLister struct {
+	*mockTreeLister
+	listing chan struct{}
+	release chan struct{}
+}
+
+func (m *blockingTreeLister) ListRepositoryTree(ctx context.Context, projectID int, ref string) ([]gitlab.TreeEntry, error) {
+	close(m.listing)
+	<-m.release
+	return m.mockTreeLister.ListRepositoryTree(ctx, projectID, ref)
+}
+
+func TestIndex_ApplyChangesDuringRefresh(t *testing.T) {
+	st := store.NewMemoryStore()
+	_, err := NewIndex(newLister(), st).Refresh(context.Background(), 1, "main")
+	assert.NoError(t, err)
+
+	lister := &blockingTreeLister{mockTreeLister: newLister(), listing: make(chan struct{}), release: make(chan struct{})}
+	idx := NewIndex(lister, st)
+	refreshed := make(chan error)
+	go func() {
+		_, err := idx.Refresh(context.Background(), 1, "main")
+		refreshed <- err
+	}()
+	<-lister.listing
+
+	// A push lands while the refresh still holds the old tree
+	applied := make(chan error)
+	go func() {
+		applied <- idx.ApplyChanges(context.Background(), 1, "main", []string{"serviceaccounts/prod/b_appuser.yaml"}, nil)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	close(lister.release)
+	assert.NoError(t, <-refreshed)
+	assert.NoError(t, <-applied)
+
+	exists, indexed := idx.PathExists(1, "main", "serviceaccounts/prod/b_appuser.yaml")
+	assert.True(t, indexed)
+	assert.True(t, exists, "the refresh must not overwrite the pushed path")
+}
+
+func TestIndex_PersistsAcrossInstances(t *testing.T) {
+	st := store.NewMemoryStore()
+	_, err := NewIndex(newLister(), st).Refresh(context.Background(), 1, "main")
+	assert.NoError(t, err)
+
+	lister := newLister()
+	idx := NewIndex(lister, st)
+	exists, indexed := idx.PathExists(1, "main", "dataproducts/source/x/product.yaml")
+	assert.True(t, indexed)
+	assert.True(t, exists)
+	assert.Equal(t, 0, lister.calls)
+
+	// Persisted refs are refreshed by RefreshAll
+	idx.RefreshAll(context.Background())
+	assert.Equal(t, 1, lister.calls)
+}
+
+func TestIndex_StartStop(t *testing.T) {
+	lister := newLister()
+	idx := NewIndex(lister, store.NewMemoryStore())
+	idx.Track(1, "main")
+
+	idx.Start(10 * time.Millisecond)
+	assert.Eventually(t, func() bool {
+		_, indexed := idx.PathExists(1, "main", "x")
+		return indexed
+	}, time.Second, 10*time.Millisecond)
+	idx.Stop()
+
+	// Stop is idempotent
+	idx.Stop()
+}
+
+func TestParseSnapshotKey(t *testing.T) {
+	ref, ok := parseSnapshotKey("repoindex/12/release/1.0")
+	assert.True(t, ok)
+	assert.Equal(t, trackedRef{projectID: 12, ref: "release/1.0"}, ref)
+
+	_, ok = parseSnapshotKey("repoindex/abc/main")
+	assert.False(t, ok)
+	_, ok = parseSnapshotKey("repoindex/12")
+	assert.False(t, ok)
+}
+
+func TestIndex_Paths(t *testing.T) {
+	lister := newLister()
+	idx := NewIndex(lister, store.NewMemoryStore())
+
+	// Not indexed yet: takes a snapshot first
+	paths, err := idx.Paths(context.Background(), 1, "main", func(path string) bool { return strings.HasSuffix(path, ".yaml") })
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"dataproducts/source/x/product.yaml", "serviceaccounts/prod/a_appuser.yaml"}, paths)
+	assert.Equal(t, 1, lister.calls)
+
+	paths, err = idx.Paths(context.Background(), 1, "main", nil)
+	assert.NoError(t, err)
+	assert.Len(t, paths, 2)
+	assert.Equal(t, 1, lister.calls)
+
+	lister.err = errors.New("boom")
+	_, err = idx.Paths(context.Background(), 1, "other", nil)
+	assert.Error(t, err)
+}
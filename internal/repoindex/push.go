@@ -0,0 +1,121 @@
+package repoindex
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+var (
+	defaultMu sync.RWMutex
+	indexes   = make(map[string]*Index)
+)
+
+// SetDefault installs the index of the default GitLab instance used by rules and webhook handlers
+func SetDefault(idx *Index) {
+	SetForInstance("", idx)
+}
+
+// Default returns the index of the default GitLab instance, or nil when indexing is disabled
+func Default() *Index {
+	return ForInstance("")
+}
+
+// SetForInstance installs the index of the named additional GitLab instance, or of the
+// default instance when name is empty. Project IDs of different instances overlap, so every
+// instance needs its own index.
+func SetForInstance(name string, idx *Index) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	if idx == nil {
+		delete(indexes, name)
+		return
+	}
+	indexes[name] = idx
+}
+
+// ForInstance returns the index of the named GitLab instance, "" for the default instance,
+// or nil when indexing is disabled
+func ForInstance(name string) *Index {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return indexes[name]
+}
+
+// instanceClient is implemented by GitLab clients naming the instance they talk to
+type instanceClient interface {
+	GitLabInstance() string
+}
+
+// ForClient returns the index of the GitLab instance client talks to. Clients that do not
+// name their instance, e.g. local evaluation clients, use the default instance's index.
+func ForClient(client interface{}) *Index {
+	if named, ok := client.(instanceClient); ok {
+		return ForInstance(named.GitLabInstance())
+	}
+	return Default()
+}
+
+// ChangedPathsFromPush extracts the net added and removed paths from a GitLab push payload.
+// Commits are applied oldest to newest so a path added and later removed counts as removed.
+// complete is false when GitLab truncated the commit list (it only sends the first 20 commits).
+func ChangedPathsFromPush(payload map[string]interface{}) (added, removed []string, complete bool) {
+	commits, _ := payload["commits"].([]interface{})
+
+	complete = true
+	if total, ok := payload["total_commits_count"].(float64); ok && int(total) > len(commits) {
+		complete = false
+	}
+
+	present := make(map[string]bool)
+	for _, c := range commits {
+		commit, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, path := range stringList(commit["added"]) {
+			present[path] = true
+		}
+		for _, path := range stringList(commit["removed"]) {
+			present[path] = false
+		}
+	}
+
+	added = make([]string, 0)
+	removed = make([]string, 0)
+	for path, exists := range present {
+		if exists {
+			added = append(added, path)
+		} else {
+			removed = append(removed, path)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed, complete
+}
+
+// ApplyPush incrementally refreshes the snapshot for ref from a push webhook payload.
+// Falls back to a full refresh when the payload does not list every commit.
+func (i *Index) ApplyPush(ctx context.Context, projectID int, ref string, payload map[string]interface{}) error {
+	added, removed, complete := ChangedPathsFromPush(payload)
+	if !complete {
+		_, err := i.Refresh(ctx, projectID, ref)
+		return err
+	}
+	return i.ApplyChanges(ctx, projectID, ref, added, removed)
+}
+
+func stringList(v interface{}) []string {
+	items, ok := v.([]interface{})
+	if !ok {
+		return nil
+	}
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		if s, ok := item.(string); ok && s != "" {
+			result = append(result, s)
+		}
+	}
+	return result
+}
@@ -0,0 +1,120 @@
+package repoindex
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+)
+
+func TestChangedPathsFromPush(t *testing.T) {
+	payload := map[string]interface{}{
+		"total_commits_count": float64(2),
+		"commits": []interface{}{
+			map[string]interface{}{
+				"added":    []interface{}{"a.yaml", "tmp.yaml"},
+				"modified": []interface{}{"c.yaml"},
+				"removed":  []interface{}{"old.yaml"},
+			},
+			map[string]interface{}{
+				"added":   []interface{}{"b.yaml"},
+				"removed": []interface{}{"tmp.yaml"},
+			},
+		},
+	}
+
+	added, removed, complete := ChangedPathsFromPush(payload)
+	assert.True(t, complete)
+	assert.Equal(t, []string{"a.yaml", "b.yaml"}, added)
+	assert.Equal(t, []string{"old.yaml", "tmp.yaml"}, removed)
+}
+
+func TestChangedPathsFromPush_Truncated(t *testing.T) {
+	payload := map[string]interface{}{
+		"total_commits_count": float64(25),
+		"commits":             []interface{}{map[string]interface{}{"added": []interface{}{"a.yaml"}}},
+	}
+
+	_, _, complete := ChangedPathsFromPush(payload)
+	assert.False(t, complete)
+}
+
+func TestIndex_ApplyPush(t *testing.T) {
+	lister := newLister()
+	idx := NewIndex(lister, store.NewMemoryStore())
+	_, _ = idx.Refresh(context.Background(), 1, "main")
+
+	err := idx.ApplyPush(context.Background(), 1, "main", map[string]interface{}{
+		"total_commits_count": float64(1),
+		"commits": []interface{}{
+			map[string]interface{}{"added": []interface{}{"new.yaml"}},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, lister.calls)
+	exists, _ := idx.PathExists(1, "main", "new.yaml")
+	assert.True(t, exists)
+
+	// Truncated commit list forces a full refresh
+	err = idx.ApplyPush(context.Background(), 1, "main", map[string]interface{}{"total_commits_count": float64(30)})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, lister.calls)
+	exists, _ = idx.PathExists(1, "main", "new.yaml")
+	assert.False(t, exists)
+}
+
+func TestDefaultIndex(t *testing.T) {
+	assert.Nil(t, Default())
+	idx := NewIndex(newLister(), store.NewMemoryStore())
+	SetDefault(idx)
+	defer SetDefault(nil)
+	assert.Same(t, idx, Default())
+}
+
+type instanceLister struct {
+	mockTreeLister
+	instance string
+}
+
+func (l *instanceLister) GitLabInstance() string {
+	return l.instance
+}
+
+func TestForInstance_KeepsInstancesApart(t *testing.T) {
+	defaultIdx := NewIndex(newLister(), store.NewMemoryStore())
+	onpremLister := &mockTreeLister{entries: map[string][]gitlab.TreeEntry{
+		"main": {{Type: "blob", Path: "dataproducts/source/onprem/product.yaml"}},
+	}}
+	onpremIdx := NewIndex(onpremLister, store.WithPrefix(store.NewMemoryStore(), "instances/onprem/"))
+	SetDefault(defaultIdx)
+	SetForInstance("onprem", onpremIdx)
+	defer SetDefault(nil)
+	defer SetForInstance("onprem", nil)
+
+	assert.Same(t, defaultIdx, Default())
+	assert.Same(t, defaultIdx, ForInstance(""))
+	assert.Same(t, onpremIdx, ForInstance("onprem"))
+	assert.Nil(t, ForInstance("unknown"), "instances without an index are not served the default index")
+
+	assert.Same(t, onpremIdx, ForClient(&instanceLister{instance: "onprem"}))
+	assert.Same(t, defaultIdx, ForClient(&instanceLister{}))
+	assert.Same(t, defaultIdx, ForClient(newLister()), "clients not naming an instance use the default index")
+
+	// The same project ID is a different repository on each instance
+	_, err := Default().Refresh(context.Background(), 1, "main")
+	assert.NoError(t, err)
+	_, err = ForInstance("onprem").Refresh(context.Background(), 1, "main")
+	assert.NoError(t, err)
+	exists, indexed := ForInstance("onprem").PathExists(1, "main", "serviceaccounts/prod/a_appuser.yaml")
+	assert.True(t, indexed)
+	assert.False(t, exists)
+	exists, _ = Default().PathExists(1, "main", "dataproducts/source/onprem/product.yaml")
+	assert.False(t, exists)
+
+	SetForInstance("onprem", nil)
+	assert.Nil(t, ForInstance("onprem"))
+	assert.Same(t, defaultIdx, Default())
+}
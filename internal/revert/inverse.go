@@ -0,0 +1,63 @@
+package revert
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+)
+
+// IsInverse reports whether revert undoes exactly the changes of original: the same
+// files are touched, creations and deletions are swapped, renames point back, and every
+// line added by original is removed by revert and vice versa. When it returns false the
+// string explains the first mismatch found.
+func IsInverse(original, revert []gitlab.FileChange) (bool, string) {
+	if len(original) != len(revert) {
+		return false, fmt.Sprintf("revert touches %d files, the original MR touched %d", len(revert), len(original))
+	}
+
+	// Index the revert by the path it starts from, which is where the original ended
+	byOldPath := make(map[string]gitlab.FileChange, len(revert))
+	for _, change := range revert {
+		byOldPath[change.OldPath] = change
+	}
+
+	for _, o := range original {
+		r, ok := byOldPath[o.NewPath]
+		if !ok {
+			return false, fmt.Sprintf("%s is not reverted", o.NewPath)
+		}
+		if r.NewPath != o.OldPath {
+			return false, fmt.Sprintf("%s is reverted to %s instead of %s", o.NewPath, r.NewPath, o.OldPath)
+		}
+		if o.NewFile != r.DeletedFile || o.DeletedFile != r.NewFile {
+			return false, fmt.Sprintf("%s is not created or deleted inversely", o.NewPath)
+		}
+		if o.Diff == "" && r.Diff == "" && !o.RenamedFile {
+			// Diffs are omitted for binary and oversized files; nothing to compare
+			return false, fmt.Sprintf("diff of %s is not available", o.NewPath)
+		}
+
+		added, removed := changedLines(o.Diff)
+		revertAdded, revertRemoved := changedLines(r.Diff)
+		if !slices.Equal(added, revertRemoved) || !slices.Equal(removed, revertAdded) {
+			return false, fmt.Sprintf("%s is not reverted exactly", o.NewPath)
+		}
+	}
+
+	return true, ""
+}
+
+// changedLines returns the lines a GitLab diff (hunks without file headers) adds and removes, in order
+func changedLines(diff string) (added, removed []string) {
+	for _, line := range strings.Split(diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "+"):
+			added = append(added, line[1:])
+		case strings.HasPrefix(line, "-"):
+			removed = append(removed, line[1:])
+		}
+	}
+	return added, removed
+}
@@ -0,0 +1,79 @@
+package revert
+
+import (
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsInverse(t *testing.T) {
+	ok, reason := IsInverse(addConsumer, removeConsumer)
+	assert.True(t, ok, reason)
+
+	// Created files are deleted, renames point back
+	created := []gitlab.FileChange{
+		{OldPath: "a.yaml", NewPath: "a.yaml", NewFile: true, Diff: "@@ -0,0 +1 @@\n+x: 1\n"},
+		{OldPath: "old.yaml", NewPath: "new.yaml", RenamedFile: true},
+	}
+	deleted := []gitlab.FileChange{
+		{OldPath: "a.yaml", NewPath: "a.yaml", DeletedFile: true, Diff: "@@ -1 +0,0 @@\n-x: 1\n"},
+		{OldPath: "new.yaml", NewPath: "old.yaml", RenamedFile: true},
+	}
+	ok, reason = IsInverse(created, deleted)
+	assert.True(t, ok, reason)
+}
+
+func TestIsInverse_Mismatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		original []gitlab.FileChange
+		revert   []gitlab.FileChange
+		reason   string
+	}{
+		{
+			name:     "extra file",
+			original: addConsumer,
+			revert:   append([]gitlab.FileChange{{OldPath: "b.yaml", NewPath: "b.yaml", Diff: "+y"}}, removeConsumer...),
+			reason:   "revert touches 2 files, the original MR touched 1",
+		},
+		{
+			name:     "different file",
+			original: addConsumer,
+			revert:   []gitlab.FileChange{{OldPath: "b.yaml", NewPath: "b.yaml", Diff: "-  - name: analytics"}},
+			reason:   consumerPath + " is not reverted",
+		},
+		{
+			name:     "same change applied again",
+			original: addConsumer,
+			revert:   addConsumer,
+			reason:   consumerPath + " is not reverted exactly",
+		},
+		{
+			name:     "created file edited instead of deleted",
+			original: []gitlab.FileChange{{OldPath: "a.yaml", NewPath: "a.yaml", NewFile: true, Diff: "+x: 1"}},
+			revert:   []gitlab.FileChange{{OldPath: "a.yaml", NewPath: "a.yaml", Diff: "-x: 1"}},
+			reason:   "a.yaml is not created or deleted inversely",
+		},
+		{
+			name:     "rename not reverted",
+			original: []gitlab.FileChange{{OldPath: "old.yaml", NewPath: "new.yaml", RenamedFile: true}},
+			revert:   []gitlab.FileChange{{OldPath: "new.yaml", NewPath: "other.yaml", RenamedFile: true}},
+			reason:   "new.yaml is reverted to other.yaml instead of old.yaml",
+		},
+		{
+			name:     "diff unavailable",
+			original: []gitlab.FileChange{{OldPath: "img.png", NewPath: "img.png"}},
+			revert:   []gitlab.FileChange{{OldPath: "img.png", NewPath: "img.png"}},
+			reason:   "diff of img.png is not available",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, reason := IsInverse(tt.original, tt.revert)
+			assert.False(t, ok)
+			assert.Equal(t, tt.reason, reason)
+		})
+	}
+}
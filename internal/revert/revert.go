@@ -0,0 +1,151 @@
+package revert
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+)
+
+var (
+	// GitLab's "Revert" button titles the MR `Revert "<original title>"`
+	titlePattern = regexp.MustCompile(`^Revert "(.+)"$`)
+	// Description written by GitLab's "Revert" button
+	mergeRequestPattern = regexp.MustCompile(`(?i)this reverts merge request !(\d+)`)
+	// Commit message written by `git revert`, often copied into the MR description
+	commitPattern = regexp.MustCompile(`(?i)this reverts commit ([0-9a-f]{7,40})`)
+)
+
+// summaryPrefix marks fast-path revert decisions
+const summaryPrefix = "↩️ Revert of !"
+
+// Reference identifies what a revert MR claims to revert
+type Reference struct {
+	MRIID         int    // Reverted merge request, when named in the description
+	CommitSHA     string // Reverted commit, when named in the description
+	OriginalTitle string // Title of the reverted MR, from a `Revert "..."` title
+}
+
+// Detect recognizes a revert MR from its title and description
+func Detect(title, description string) (*Reference, bool) {
+	ref := &Reference{}
+	if m := titlePattern.FindStringSubmatch(strings.TrimSpace(title)); m != nil {
+		ref.OriginalTitle = m[1]
+	}
+	if m := mergeRequestPattern.FindStringSubmatch(description); m != nil {
+		ref.MRIID, _ = strconv.Atoi(m[1])
+	}
+	if m := commitPattern.FindStringSubmatch(description); m != nil {
+		ref.CommitSHA = m[1]
+	}
+	if ref.OriginalTitle == "" && ref.MRIID == 0 && ref.CommitSHA == "" {
+		return nil, false
+	}
+	return ref, true
+}
+
+// Client is the subset of the GitLab client needed to compare a revert with its original
+type Client interface {
+	FetchMRChanges(ctx context.Context, projectID, mrIID int) ([]gitlab.FileChange, error)
+	GetMRDetails(ctx context.Context, projectID, mrIID int) (*gitlab.MRDetails, error)
+}
+
+// CommitMRLister resolves the merge requests that introduced a commit. The GitLab client
+// implements it; reverts naming only a commit are not fast-pathed without it.
+type CommitMRLister interface {
+	ListCommitMRs(ctx context.Context, projectID int, sha string) ([]gitlab.MRDetails, error)
+}
+
+// Result is the outcome of checking a revert MR
+type Result struct {
+	RevertedIID int    // Merge request being reverted (0 when it could not be identified)
+	Exact       bool   // The MR exactly inverts the merged changes of RevertedIID
+	Reason      string // Why the revert is not exact
+}
+
+// Checker verifies that revert MRs exactly undo a previously merged MR
+type Checker struct {
+	client Client
+}
+
+// NewChecker creates a revert checker
+func NewChecker(client Client) *Checker {
+	return &Checker{client: client}
+}
+
+// Check returns nil when the MR is not a revert. Otherwise it resolves the reverted MR,
+// which must be merged, and compares its changes with the inverse of the revert's changes.
+func (c *Checker) Check(ctx context.Context, mrInfo *gitlab.MRInfo, changes []gitlab.FileChange) (*Result, error) {
+	ref, ok := Detect(mrInfo.Title, mrInfo.Description)
+	if !ok {
+		return nil, nil
+	}
+
+	iid, err := c.revertedIID(ctx, mrInfo.ProjectID, ref)
+	if err != nil {
+		return nil, err
+	}
+	if iid == 0 {
+		return &Result{Reason: "reverted merge request could not be identified"}, nil
+	}
+
+	result := &Result{RevertedIID: iid}
+	details, err := c.client.GetMRDetails(ctx, mrInfo.ProjectID, iid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get details of !%d: %w", iid, err)
+	}
+	if details == nil || details.State != "merged" {
+		result.Reason = fmt.Sprintf("!%d is not merged", iid)
+		return result, nil
+	}
+
+	original, err := c.client.FetchMRChanges(ctx, mrInfo.ProjectID, iid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch changes of !%d: %w", iid, err)
+	}
+	result.Exact, result.Reason = IsInverse(original, changes)
+	return result, nil
+}
+
+// revertedIID resolves the reverted MR from the description's MR or commit reference
+func (c *Checker) revertedIID(ctx context.Context, projectID int, ref *Reference) (int, error) {
+	if ref.MRIID != 0 {
+		return ref.MRIID, nil
+	}
+	if ref.CommitSHA == "" {
+		return 0, nil
+	}
+	lister, ok := c.client.(CommitMRLister)
+	if !ok {
+		return 0, nil
+	}
+	mrs, err := lister.ListCommitMRs(ctx, projectID, ref.CommitSHA)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find the merge request of commit %s: %w", ref.CommitSHA, err)
+	}
+	for _, mr := range mrs {
+		if mr.State == "merged" {
+			return mr.IID, nil
+		}
+	}
+	return 0, nil
+}
+
+// Decision is the fast-path approval of an exact revert of !revertedIID
+func Decision(revertedIID int) shared.Decision {
+	return shared.Decision{
+		Type:    shared.Approve,
+		Reason:  fmt.Sprintf("Revert of !%d - exactly reverts its merged changes", revertedIID),
+		Summary: fmt.Sprintf("%s%d", summaryPrefix, revertedIID),
+		Details: "Exact reverts are approved without rule evaluation so they can unblock incident recovery quickly",
+	}
+}
+
+// IsDecision reports whether d is a fast-path revert approval
+func IsDecision(d shared.Decision) bool {
+	return d.Type == shared.Approve && strings.HasPrefix(d.Summary, summaryPrefix)
+}
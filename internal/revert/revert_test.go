@@ -0,0 +1,114 @@
+package revert
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetect(t *testing.T) {
+	ref, ok := Detect(`Revert "Add orders consumer"`, "This reverts merge request !42")
+	assert.True(t, ok)
+	assert.Equal(t, &Reference{MRIID: 42, OriginalTitle: "Add orders consumer"}, ref)
+
+	ref, ok = Detect("Roll back consumer change", "This reverts commit 0a1b2c3d4e5f.")
+	assert.True(t, ok)
+	assert.Equal(t, "0a1b2c3d4e5f", ref.CommitSHA)
+
+	ref, ok = Detect(`Revert "Add orders consumer"`, "")
+	assert.True(t, ok)
+	assert.Zero(t, ref.MRIID)
+
+	_, ok = Detect("Revert warehouse to SMALL", "Reverting last week's size bump")
+	assert.False(t, ok)
+}
+
+// fakeClient serves MR details and changes per IID
+type fakeClient struct {
+	states  map[int]string
+	changes map[int][]gitlab.FileChange
+}
+
+func (c *fakeClient) FetchMRChanges(ctx context.Context, projectID, mrIID int) ([]gitlab.FileChange, error) {
+	changes, ok := c.changes[mrIID]
+	if !ok {
+		return nil, fmt.Errorf("MR !%d: %w", mrIID, gitlab.ErrNotFound)
+	}
+	return changes, nil
+}
+
+func (c *fakeClient) GetMRDetails(ctx context.Context, projectID, mrIID int) (*gitlab.MRDetails, error) {
+	return &gitlab.MRDetails{IID: mrIID, State: c.states[mrIID]}, nil
+}
+
+// fakeCommitClient also resolves commits to merge requests
+type fakeCommitClient struct {
+	fakeClient
+}
+
+func (c *fakeCommitClient) ListCommitMRs(ctx context.Context, projectID int, sha string) ([]gitlab.MRDetails, error) {
+	return []gitlab.MRDetails{{IID: 3, State: "closed"}, {IID: 7, State: "merged"}}, nil
+}
+
+const consumerPath = "dataproducts/source/orders/prod/product.yaml"
+
+var (
+	addConsumer    = []gitlab.FileChange{{OldPath: consumerPath, NewPath: consumerPath, Diff: "@@ -5,2 +5,4 @@\n consumers:\n+  - name: analytics\n+    kind: data_product\n   - name: readers\n"}}
+	removeConsumer = []gitlab.FileChange{{OldPath: consumerPath, NewPath: consumerPath, Diff: "@@ -5,4 +5,2 @@\n consumers:\n-  - name: analytics\n-    kind: data_product\n   - name: readers\n"}}
+)
+
+func TestChecker_Check(t *testing.T) {
+	ctx := context.Background()
+	client := &fakeCommitClient{fakeClient{
+		states:  map[int]string{7: "merged", 8: "opened", 9: "merged"},
+		changes: map[int][]gitlab.FileChange{7: addConsumer, 8: addConsumer},
+	}}
+	checker := NewChecker(client)
+
+	result, err := checker.Check(ctx, &gitlab.MRInfo{Title: `Revert "Add analytics consumer"`, Description: "This reverts merge request !7"}, removeConsumer)
+	assert.NoError(t, err)
+	assert.Equal(t, &Result{RevertedIID: 7, Exact: true}, result)
+
+	// Commit references are resolved to the merged MR that introduced them
+	result, err = checker.Check(ctx, &gitlab.MRInfo{Title: "Roll back", Description: "This reverts commit 0a1b2c3."}, removeConsumer)
+	assert.NoError(t, err)
+	assert.Equal(t, 7, result.RevertedIID)
+	assert.True(t, result.Exact)
+
+	// Not a revert
+	result, err = checker.Check(ctx, &gitlab.MRInfo{Title: "Remove analytics consumer"}, removeConsumer)
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+
+	// The reverted MR must be merged
+	result, err = checker.Check(ctx, &gitlab.MRInfo{Description: "This reverts merge request !8"}, removeConsumer)
+	assert.NoError(t, err)
+	assert.False(t, result.Exact)
+	assert.Equal(t, "!8 is not merged", result.Reason)
+
+	// A title alone does not identify the reverted MR
+	result, err = checker.Check(ctx, &gitlab.MRInfo{Title: `Revert "Add analytics consumer"`}, removeConsumer)
+	assert.NoError(t, err)
+	assert.False(t, result.Exact)
+
+	// Without commit lookup a commit reference cannot be resolved
+	result, err = NewChecker(&client.fakeClient).Check(ctx, &gitlab.MRInfo{Description: "This reverts commit 0a1b2c3."}, removeConsumer)
+	assert.NoError(t, err)
+	assert.Zero(t, result.RevertedIID)
+
+	// Changes of the reverted MR cannot be fetched
+	_, err = checker.Check(ctx, &gitlab.MRInfo{Description: "This reverts merge request !9"}, removeConsumer)
+	assert.Error(t, err)
+}
+
+func TestDecision(t *testing.T) {
+	decision := Decision(7)
+	assert.Equal(t, shared.Approve, decision.Type)
+	assert.Contains(t, decision.Reason, "Revert of !7")
+	assert.True(t, IsDecision(decision))
+	assert.False(t, IsDecision(shared.Decision{Type: shared.Approve, Summary: "✅ Auto-approved"}))
+}
@@ -0,0 +1,102 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/redhat-data-and-ai/naysayer/internal/utils"
+	"go.uber.org/zap"
+)
+
+// aggregationDetails describes the aggregation policy of the manager for decision details
+func (srm *SectionRuleManager) aggregationDetails() string {
+	aggregation := srm.config.Aggregation
+	if aggregation.Name() == utils.AggregationQuorum {
+		return fmt.Sprintf("Aggregation policy: %s (%d%% of covered files)", utils.AggregationQuorum, aggregation.QuorumPercent)
+	}
+	return "Aggregation policy: " + aggregation.Name()
+}
+
+// quorumVeto returns why a file requiring manual review cannot be outvoted by a quorum, or ""
+// when it can. Only manual reviews of rules that opt in (see shared.OutvotableRule) can be
+// outvoted; files with changes no rule covers, enforced findings, internal rule errors, results
+// of rules the manager does not know, such as deletion policies, and reviews no rule result
+// explains always require a review.
+func (srm *SectionRuleManager) quorumVeto(fileValidation *shared.FileValidationSummary) string {
+	if len(fileValidation.UncoveredLines) > 0 {
+		return "uncovered changes"
+	}
+	outvotable := false
+	for _, result := range fileValidation.RuleResults {
+		if result.Decision != shared.ManualReview {
+			continue
+		}
+		if strings.HasPrefix(result.Reason, InternalRuleErrorReason) {
+			return InternalRuleErrorReason
+		}
+		rule := srm.ruleRegistry[result.RuleName]
+		if enforced, ok := rule.(shared.EnforcedRule); ok && enforced.IsEnforced(result.Reason) {
+			return result.RuleName
+		}
+		if voter, ok := rule.(shared.OutvotableRule); !ok || !voter.Outvotable(result.Reason) {
+			return result.RuleName
+		}
+		outvotable = true
+	}
+	if !outvotable {
+		// Reviews no rule result explains, e.g. of sections without applicable rules
+		return "section review"
+	}
+	return ""
+}
+
+// applyQuorum approves an MR whose covered files approve at least at the quorum percentage
+// and whose manual reviews can all be outvoted, else returns decision. filePaths are sorted.
+func (srm *SectionRuleManager) applyQuorum(filePaths []string, fileValidations map[string]*shared.FileValidationSummary, decision shared.Decision) shared.Decision {
+	quorum := srm.config.Aggregation.QuorumPercent
+	covered, approved := 0, 0
+	var outvoted, vetoes []string
+	for _, filePath := range filePaths {
+		fileValidation := fileValidations[filePath]
+		if fileValidation == nil {
+			continue
+		}
+		if fileValidation.FileDecision != shared.ManualReview {
+			covered++
+			approved++
+			continue
+		}
+		if veto := srm.quorumVeto(fileValidation); veto != "" {
+			vetoes = append(vetoes, fmt.Sprintf("%s (%s)", filePath, veto))
+			continue
+		}
+		covered++
+		outvoted = append(outvoted, filePath)
+	}
+
+	if len(vetoes) > 0 {
+		decision.Details += fmt.Sprintf(". Not outvoted by the quorum: %s", strings.Join(vetoes, ", "))
+		return decision
+	}
+	percent := approved * 100 / covered
+	if approved*100 < quorum*covered {
+		decision.Details += fmt.Sprintf(". Quorum not met: %d of %d covered files approved (%d%%, %d%% required)", approved, covered, percent, quorum)
+		return decision
+	}
+
+	logging.Info("MR approved by quorum",
+		zap.Int("approved", approved),
+		zap.Int("covered", covered),
+		zap.Int("percent", percent),
+		zap.Int("quorum_percent", quorum),
+		zap.Strings("outvoted", outvoted))
+	return shared.Decision{
+		Type:    shared.Approve,
+		Reason:  fmt.Sprintf("Quorum met: %d of %d covered files approved", approved, covered),
+		Summary: "✅ Auto-approved by quorum",
+		Details: fmt.Sprintf("%d of %d covered files approved (%d%%, %d%% required). Files outvoted by the quorum: %s",
+			approved, covered, percent, quorum, strings.Join(outvoted, ", ")),
+	}
+}
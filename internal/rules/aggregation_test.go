@@ -0,0 +1,291 @@
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/codeowners"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/common"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/consumer_cycle"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/dataproduct_consumer"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/group_file"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/group_membership"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/masking"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/repo_settings"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/serviceaccount"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/sourcebinding"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/tag"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/toc_approval"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/warehouse"
+)
+
+func aggregationTestValidations(reviews map[string]shared.LineValidationResult, approved ...string) map[string]*shared.FileValidationSummary {
+	validations := make(map[string]*shared.FileValidationSummary)
+	for _, filePath := range approved {
+		validations[filePath] = &shared.FileValidationSummary{FilePath: filePath, FileDecision: shared.Approve}
+	}
+	for filePath, result := range reviews {
+		result.WasEvaluated = true
+		validations[filePath] = &shared.FileValidationSummary{
+			FilePath:     filePath,
+			RuleResults:  []shared.LineValidationResult{result},
+			FileDecision: shared.ManualReview,
+		}
+	}
+	return validations
+}
+
+// outvotableRule opts in to having its manual reviews outvoted by a quorum
+type outvotableRule struct {
+	findingRule
+}
+
+func (r *outvotableRule) Outvotable(reason string) bool { return true }
+
+// outvotableEnforcedRule can be outvoted except for its enforced findings
+type outvotableEnforcedRule struct {
+	enforcedRule
+}
+
+func (r *outvotableEnforcedRule) Outvotable(reason string) bool { return true }
+
+func quorumManager(percent int) *SectionRuleManager {
+	manager := NewSectionRuleManager(&config.GlobalRuleConfig{
+		Aggregation: config.AggregationPolicy{Policy: "quorum", QuorumPercent: percent},
+	}, nil)
+	manager.AddRule(&outvotableRule{findingRule{name: "naming_rule"}})
+	manager.AddRule(&outvotableEnforcedRule{enforcedRule{findingRule{name: "policy_rule"}}})
+	manager.AddRule(&findingRule{name: "sensitive_rule"})
+	manager.AddRule(repo_settings.NewRule(nil))
+	return manager
+}
+
+func TestDetermineOverallDecision_RequireAllApprove(t *testing.T) {
+	manager := NewSectionRuleManager(&config.GlobalRuleConfig{}, nil)
+	validations := aggregationTestValidations(map[string]shared.LineValidationResult{
+		"e.yaml": {RuleName: "naming_rule", Decision: shared.ManualReview, Reason: "naming style deviation"},
+	}, "a.yaml", "b.yaml", "c.yaml", "d.yaml")
+
+	decision := manager.determineOverallDecision(validations)
+	assert.Equal(t, shared.ManualReview, decision.Type)
+	assert.Equal(t, "Files requiring manual review: e.yaml. Files auto-approved: a.yaml, b.yaml, c.yaml, d.yaml. Aggregation policy: require_all_approve", decision.Details)
+
+	delete(validations, "e.yaml")
+	decision = manager.determineOverallDecision(validations)
+	assert.Equal(t, shared.Approve, decision.Type)
+	assert.Equal(t, "All 4 files passed section-based validation with complete coverage. Aggregation policy: require_all_approve", decision.Details)
+}
+
+func TestDetermineOverallDecision_Quorum(t *testing.T) {
+	validations := aggregationTestValidations(map[string]shared.LineValidationResult{
+		"e.yaml": {RuleName: "naming_rule", Decision: shared.ManualReview, Reason: "naming style deviation"},
+	}, "a.yaml", "b.yaml", "c.yaml", "d.yaml")
+
+	decision := quorumManager(80).determineOverallDecision(validations)
+	assert.Equal(t, shared.Approve, decision.Type)
+	assert.Equal(t, "Quorum met: 4 of 5 covered files approved", decision.Reason)
+	assert.Equal(t, "✅ Auto-approved by quorum", decision.Summary)
+	assert.Equal(t, "4 of 5 covered files approved (80%, 80% required). Files outvoted by the quorum: e.yaml. Aggregation policy: quorum (80% of covered files)", decision.Details)
+
+	decision = quorumManager(90).determineOverallDecision(validations)
+	assert.Equal(t, shared.ManualReview, decision.Type)
+	assert.Equal(t, "Files requiring manual review: e.yaml. Files auto-approved: a.yaml, b.yaml, c.yaml, d.yaml. Quorum not met: 4 of 5 covered files approved (80%, 90% required). Aggregation policy: quorum (90% of covered files)", decision.Details)
+}
+
+func TestDetermineOverallDecision_QuorumVetoes(t *testing.T) {
+	tests := []struct {
+		name   string
+		result shared.LineValidationResult
+		veto   string
+	}{
+		{"deletion", shared.LineValidationResult{RuleName: DeletionPolicyRuleName, Decision: shared.ManualReview, Reason: "Deletion blocked"}, DeletionPolicyRuleName},
+		{"warehouse safeguard", shared.LineValidationResult{RuleName: "warehouse_rule", Decision: shared.ManualReview, Reason: "Warehouses section changed - manual review required"}, "warehouse_rule"},
+		{"internal error", shared.LineValidationResult{RuleName: "naming_rule", Decision: shared.ManualReview, Reason: "internal rule error: naming_rule panicked while validating this file"}, InternalRuleErrorReason},
+		{"repository settings", shared.LineValidationResult{RuleName: repo_settings.RuleName, Decision: shared.ManualReview, Reason: "CODEOWNERS no longer requires review of dataproducts/"}, repo_settings.RuleName},
+		{"enforced finding", shared.LineValidationResult{RuleName: "policy_rule", Decision: shared.ManualReview, Reason: "blocking: masking policy missing"}, "policy_rule"},
+		{"rule not opting in", shared.LineValidationResult{RuleName: "sensitive_rule", Decision: shared.ManualReview, Reason: "sensitive change"}, "sensitive_rule"},
+		{"unknown rule", shared.LineValidationResult{RuleName: "retired_rule", Decision: shared.ManualReview, Reason: "retired"}, "retired_rule"},
+		{"section review", shared.LineValidationResult{RuleName: "naming_rule", Decision: shared.Approve, Reason: "naming valid"}, "section review"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validations := aggregationTestValidations(map[string]shared.LineValidationResult{"z.yaml": tt.result},
+				"a.yaml", "b.yaml", "c.yaml", "d.yaml", "e.yaml", "f.yaml", "g.yaml", "h.yaml", "i.yaml")
+
+			decision := quorumManager(50).determineOverallDecision(validations)
+			assert.Equal(t, shared.ManualReview, decision.Type)
+			assert.Contains(t, decision.Details, "Not outvoted by the quorum: z.yaml ("+tt.veto+")")
+		})
+	}
+
+	validations := aggregationTestValidations(nil, "a.yaml", "b.yaml", "c.yaml")
+	validations["docs/notes.txt"] = NewSectionRuleManager(&config.GlobalRuleConfig{}, nil).createManualReviewValidation("docs/notes.txt", 4, "No section-based validation configuration found for this file type")
+	decision := quorumManager(50).determineOverallDecision(validations)
+	assert.Equal(t, shared.ManualReview, decision.Type)
+	assert.Contains(t, decision.Details, "Not outvoted by the quorum: docs/notes.txt (uncovered changes)")
+}
+
+func TestQuorum_BuiltInRulesCannotBeOutvoted(t *testing.T) {
+	for _, rule := range []shared.Rule{
+		NewServiceAccountRule(nil),
+		NewDocumentationAutoApprovalRule(),
+		common.NewMetadataRule(),
+		codeowners.NewCODEOWNERSSyncRule(nil),
+		consumer_cycle.NewRule(nil),
+		dataproduct_consumer.NewDataProductConsumerRule(nil),
+		group_file.NewRule(nil),
+		group_membership.NewRule(nil, nil),
+		masking.NewRule(nil),
+		repo_settings.NewRule(nil),
+		serviceaccount.NewRule(nil, nil),
+		sourcebinding.NewRule(nil, nil),
+		tag.NewRule(nil),
+		toc_approval.NewTOCApprovalRule(nil),
+		warehouse.NewRule(nil, nil),
+	} {
+		t.Run(rule.Name(), func(t *testing.T) {
+			_, outvotable := rule.(shared.OutvotableRule)
+			assert.False(t, outvotable, "built-in rules do not opt in to being outvoted")
+
+			manager := quorumManager(10)
+			manager.AddRule(rule)
+			validations := aggregationTestValidations(map[string]shared.LineValidationResult{
+				"z.yaml": {RuleName: rule.Name(), Decision: shared.ManualReview, Reason: "finding"},
+			}, "a.yaml", "b.yaml", "c.yaml", "d.yaml", "e.yaml", "f.yaml", "g.yaml", "h.yaml", "i.yaml", "j.yaml")
+
+			decision := manager.determineOverallDecision(validations)
+			assert.Equal(t, shared.ManualReview, decision.Type)
+			assert.Contains(t, decision.Details, "Not outvoted by the quorum: z.yaml ("+rule.Name()+")")
+		})
+	}
+}
+
+func TestAdjustRules_OptionalRules(t *testing.T) {
+	cfg := severityTestConfig()
+	cfg.RuleSeverities = append(cfg.RuleSeverities, config.RuleSeverity{Rule: "style_rule", Severity: "blocking", ReasonContains: "secret"})
+	manager := NewSectionRuleManager(cfg, nil)
+	ruleConfigs := []config.RuleConfig{
+		{Name: "style_rule", Enabled: true, Optional: true},
+		{Name: "owner_rule", Enabled: true, Optional: true, Environments: map[string]string{"prod": "manual_review"}},
+		{Name: "policy_rule", Enabled: true, Optional: true},
+		{Name: "warehouse_rule", Enabled: true},
+	}
+	style := &findingRule{name: "style_rule", decision: shared.ManualReview, reason: "trailing whitespace"}
+	owner := &findingRule{name: "owner_rule", decision: shared.Approve, reason: "owner valid"}
+	policy := &enforcedRule{findingRule{name: "policy_rule", decision: shared.ManualReview, reason: "blocking: masking policy missing"}}
+	warehouse := &findingRule{name: "warehouse_rule", decision: shared.ManualReview, reason: "warehouse size increased"}
+
+	rules := manager.adjustRules(ruleConfigs, []shared.Rule{style, owner, policy, warehouse}, "prod")
+	path := "dataproducts/source/orders/prod/product.yaml"
+
+	decision, reason := rules[0].ValidateLines(path, "", nil)
+	assert.Equal(t, shared.Warn, decision, "optional rules only warn")
+	assert.Equal(t, "trailing whitespace", reason)
+
+	decision, _ = rules[1].ValidateLines(path, "", nil)
+	assert.Equal(t, shared.ManualReview, decision, "environment behaviors take precedence")
+
+	decision, _ = rules[2].ValidateLines(path, "", nil)
+	assert.Equal(t, shared.ManualReview, decision, "enforced findings are kept")
+
+	decision, _ = rules[3].ValidateLines(path, "", nil)
+	assert.Equal(t, shared.ManualReview, decision, "rules not marked optional still veto")
+
+	style.reason = "secret in description"
+	decision, _ = rules[0].ValidateLines(path, "", nil)
+	assert.Equal(t, shared.ManualReview, decision, "blocking severities take precedence")
+}
+
+func TestValidateSection_OptionalRuleCannotVetoAutoApprove(t *testing.T) {
+	manager := NewSectionRuleManager(&config.GlobalRuleConfig{}, nil)
+	ruleConfigs := []config.RuleConfig{{Name: "style_rule", Enabled: true, Optional: true}}
+	rules := manager.adjustRules(ruleConfigs, []shared.Rule{
+		&findingRule{name: "style_rule", decision: shared.ManualReview, reason: "trailing whitespace"},
+	}, "dev")
+	section := shared.Section{Name: "description", StartLine: 1, EndLine: 2, FilePath: "dataproducts/source/orders/dev/product.yaml", AutoApprove: true, RuleConfigs: ruleConfigs}
+
+	result := NewYAMLSectionParser(nil).ValidateSection(&section, rules)
+	assert.Equal(t, shared.Approve, result.Decision)
+	if assert.Len(t, result.RuleResults, 1) {
+		assert.Equal(t, shared.Warn, result.RuleResults[0].Decision)
+	}
+}
+
+func TestValidateRuleConfig_Aggregation(t *testing.T) {
+	base := func(aggregation config.AggregationPolicy, optional, autoApprove bool) *config.GlobalRuleConfig {
+		return &config.GlobalRuleConfig{
+			Aggregation: aggregation,
+			Files: []config.FileRuleConfig{{
+				Name: "products", Path: "dataproducts/**/", Filename: "product.yaml", ParserType: "yaml",
+				Sections: []config.SectionDefinition{{Name: "description", YAMLPath: "description", AutoApprove: autoApprove, RuleConfigs: []config.RuleConfig{
+					{Name: "metadata_rule", Enabled: true, Optional: optional},
+				}}},
+			}},
+		}
+	}
+
+	assert.NoError(t, config.ValidateRuleConfig(base(config.AggregationPolicy{}, false, false)))
+	assert.NoError(t, config.ValidateRuleConfig(base(config.AggregationPolicy{Policy: "require_all_approve"}, true, true)))
+	assert.NoError(t, config.ValidateRuleConfig(base(config.AggregationPolicy{Policy: "quorum", QuorumPercent: 75}, false, false)))
+
+	tests := []struct {
+		name   string
+		config *config.GlobalRuleConfig
+		errMsg string
+	}{
+		{"unknown policy", base(config.AggregationPolicy{Policy: "majority"}, false, false), "invalid aggregation policy 'majority'"},
+		{"quorum without percent", base(config.AggregationPolicy{Policy: "quorum"}, false, false), "between 1 and 100"},
+		{"quorum above 100", base(config.AggregationPolicy{Policy: "quorum", QuorumPercent: 120}, false, false), "between 1 and 100"},
+		{"percent without quorum", base(config.AggregationPolicy{QuorumPercent: 80}, false, false), "only used by the 'quorum' policy"},
+		{"optional outside auto_approve", base(config.AggregationPolicy{}, true, false), "rule metadata_rule is optional in section description"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := config.ValidateRuleConfig(tt.config)
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.errMsg)
+			}
+		})
+	}
+}
+
+func TestLoadRuleConfig_Aggregation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(`enabled: true
+aggregation:
+  policy: quorum
+  quorum_percent: 90
+files:
+  - name: products
+    path: "dataproducts/**/"
+    filename: "product.yaml"
+    parser_type: yaml
+    enabled: true
+    sections:
+      - name: description
+        yaml_path: description
+        auto_approve: true
+        rule_configs:
+          - name: metadata_rule
+            enabled: true
+            optional: true
+`), 0o600))
+
+	assert.Empty(t, ValidateRuleConfigFile(path, NewRuleRegistry()))
+	cfg, err := config.LoadRuleConfig(path)
+	assert.NoError(t, err)
+	assert.Equal(t, config.AggregationPolicy{Policy: "quorum", QuorumPercent: 90}, cfg.Aggregation)
+	assert.True(t, cfg.Files[0].Sections[0].RuleConfigs[0].Optional)
+
+	saved := filepath.Join(t.TempDir(), "saved.yaml")
+	assert.NoError(t, config.SaveRuleConfig(cfg, saved))
+	data, err := os.ReadFile(saved)
+	assert.NoError(t, err)
+	assert.True(t, strings.Contains(string(data), "quorum_percent: 90"))
+}
@@ -0,0 +1,88 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"go.uber.org/zap"
+)
+
+// applyApprovalPolicies returns the approval requirements of every approval policy whose
+// conditions all match the rule results of the MR. When every manual review of the MR is
+// matched by a condition of these policies, the decision becomes an approval that the
+// webhook handler holds until the required approvals are given.
+func (srm *SectionRuleManager) applyApprovalPolicies(fileValidations map[string]*shared.FileValidationSummary, decision shared.Decision) (shared.Decision, []shared.ApprovalRequirement) {
+	var matched []config.ApprovalPolicy
+	var requirements []shared.ApprovalRequirement
+	for _, policy := range srm.config.ApprovalPolicies {
+		if !policyConditionsMatch(policy.When, fileValidations) {
+			continue
+		}
+		logging.Info("Approval policy matched", zap.String("policy", policy.Name), zap.Int("approvals", policy.Approvals))
+		matched = append(matched, policy)
+		requirements = append(requirements, shared.ApprovalRequirement{
+			Policy:    policy.Name,
+			Approvals: policy.Approvals,
+			Approvers: policy.Approvers,
+			Reason:    policy.Reason,
+		})
+	}
+	if len(matched) == 0 || decision.Type != shared.ManualReview || !reviewsCoveredByPolicies(matched, fileValidations) {
+		return decision, requirements
+	}
+
+	names := make([]string, 0, len(matched))
+	for _, policy := range matched {
+		names = append(names, policy.Name)
+	}
+	details := decision.Reason
+	if decision.Details != "" {
+		details += ". " + decision.Details
+	}
+	return shared.Decision{
+		Type:    shared.Approve,
+		Reason:  fmt.Sprintf("Changes requiring review are covered by approval policies: %s", strings.Join(names, ", ")),
+		Summary: "✅ Approved with required approvals",
+		Details: details,
+	}, requirements
+}
+
+// reviewsCoveredByPolicies reports whether every manual review of the MR comes from a rule
+// result matched by a condition of the policies. Uncovered lines and blocked deletions always
+// need a review.
+func reviewsCoveredByPolicies(policies []config.ApprovalPolicy, fileValidations map[string]*shared.FileValidationSummary) bool {
+	for filePath, fileValidation := range fileValidations {
+		if fileValidation == nil || fileValidation.FileDecision != shared.ManualReview {
+			continue
+		}
+		if len(fileValidation.UncoveredLines) > 0 {
+			return false
+		}
+		for _, result := range fileValidation.RuleResults {
+			if result.Decision != shared.ManualReview || !result.WasEvaluated {
+				continue
+			}
+			if isBlockedDeletion(result) || !resultCoveredByPolicies(policies, filePath, result) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func resultCoveredByPolicies(policies []config.ApprovalPolicy, filePath string, result shared.LineValidationResult) bool {
+	for _, policy := range policies {
+		for _, condition := range policy.When {
+			if condition.Path != "" && !shared.MatchesPattern(filePath, condition.Path) {
+				continue
+			}
+			if resultMatches(condition, result) {
+				return true
+			}
+		}
+	}
+	return false
+}
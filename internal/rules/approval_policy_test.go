@@ -0,0 +1,142 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func approvalPolicyTestConfig() *config.GlobalRuleConfig {
+	return &config.GlobalRuleConfig{
+		Enabled: true,
+		Files:   []config.FileRuleConfig{},
+		ApprovalPolicies: []config.ApprovalPolicy{{
+			Name: "large_warehouse_increase",
+			When: []config.PolicyCondition{
+				{Rule: "warehouse_rule", Decision: "manual_review", ReasonMatches: `size increase.*→ (XXLARGE|X[3-6]LARGE)`},
+			},
+			Approvals: 2,
+			Approvers: []string{"@alice", "@bob"},
+			Reason:    "large warehouses need budget owner approval",
+		}},
+	}
+}
+
+func TestApplyApprovalPolicies_ApprovesCoveredReviews(t *testing.T) {
+	manager := NewSectionRuleManager(approvalPolicyTestConfig(), nil)
+	base := shared.Decision{Type: shared.ManualReview, Reason: "Warehouse changes require manual review", Details: "Files requiring manual review: a"}
+
+	validations := map[string]*shared.FileValidationSummary{
+		"dataproducts/source/a/prod/product.yaml": fileValidation("dataproducts/source/a/prod/product.yaml",
+			ruleResult("warehouse_rule", shared.ManualReview, "Warehouse size increase detected: user warehouse: LARGE → X3LARGE")),
+		"dataproducts/source/a/prod/pii_masking.yaml": fileValidation("dataproducts/source/a/prod/pii_masking.yaml",
+			ruleResult("masking_policy_rule", shared.Approve, "ok")),
+	}
+
+	decision, requirements := manager.applyApprovalPolicies(validations, base)
+	assert.Equal(t, shared.Approve, decision.Type)
+	assert.Equal(t, "Changes requiring review are covered by approval policies: large_warehouse_increase", decision.Reason)
+	assert.Equal(t, "Warehouse changes require manual review. Files requiring manual review: a", decision.Details)
+	assert.Equal(t, []shared.ApprovalRequirement{{
+		Policy:    "large_warehouse_increase",
+		Approvals: 2,
+		Approvers: []string{"@alice", "@bob"},
+		Reason:    "large warehouses need budget owner approval",
+	}}, requirements)
+}
+
+func TestApplyApprovalPolicies_OtherReviewsRemain(t *testing.T) {
+	manager := NewSectionRuleManager(approvalPolicyTestConfig(), nil)
+	base := shared.Decision{Type: shared.ManualReview, Reason: "One or more files require manual review"}
+	increase := ruleResult("warehouse_rule", shared.ManualReview, "Warehouse size increase detected: user warehouse: LARGE → XXLARGE")
+
+	tests := []struct {
+		name        string
+		validations map[string]*shared.FileValidationSummary
+	}{
+		{
+			name: "another rule requires review",
+			validations: map[string]*shared.FileValidationSummary{
+				"dataproducts/source/a/prod/product.yaml": fileValidation("dataproducts/source/a/prod/product.yaml", increase),
+				"dataproducts/source/a/prod/pii_masking.yaml": fileValidation("dataproducts/source/a/prod/pii_masking.yaml",
+					ruleResult("masking_policy_rule", shared.ManualReview, "invalid policy")),
+			},
+		},
+		{
+			name: "uncovered lines",
+			validations: map[string]*shared.FileValidationSummary{
+				"dataproducts/source/a/prod/product.yaml": {
+					FilePath:       "dataproducts/source/a/prod/product.yaml",
+					RuleResults:    []shared.LineValidationResult{increase},
+					UncoveredLines: []shared.LineRange{{StartLine: 3, EndLine: 4}},
+					FileDecision:   shared.ManualReview,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision, requirements := manager.applyApprovalPolicies(tt.validations, base)
+			assert.Equal(t, base, decision)
+			assert.Len(t, requirements, 1, "requirements are reported even when a review remains")
+		})
+	}
+}
+
+func TestApplyApprovalPolicies_NoMatch(t *testing.T) {
+	manager := NewSectionRuleManager(approvalPolicyTestConfig(), nil)
+	base := shared.Decision{Type: shared.ManualReview, Reason: "Warehouse changes require manual review"}
+
+	validations := map[string]*shared.FileValidationSummary{
+		"dataproducts/source/a/prod/product.yaml": fileValidation("dataproducts/source/a/prod/product.yaml",
+			ruleResult("warehouse_rule", shared.ManualReview, "Warehouse size increase detected: user warehouse: SMALL → XLARGE")),
+	}
+
+	decision, requirements := manager.applyApprovalPolicies(validations, base)
+	assert.Equal(t, base, decision)
+	assert.Empty(t, requirements)
+}
+
+func TestApplyApprovalPolicies_ApprovedMRKeepsRequirements(t *testing.T) {
+	cfg := approvalPolicyTestConfig()
+	cfg.ApprovalPolicies = []config.ApprovalPolicy{{
+		Name:      "prod_consumers",
+		When:      []config.PolicyCondition{{Rule: "dataproduct_consumer_rule", Path: "dataproducts/**/prod/product.{yaml,yml}"}},
+		Approvals: 1,
+	}}
+	manager := NewSectionRuleManager(cfg, nil)
+	base := shared.Decision{Type: shared.Approve, Reason: "All files passed validation"}
+
+	validations := map[string]*shared.FileValidationSummary{
+		"dataproducts/source/a/prod/product.yaml": fileValidation("dataproducts/source/a/prod/product.yaml",
+			ruleResult("dataproduct_consumer_rule", shared.Approve, "Consumer access changes in prod environment")),
+	}
+
+	decision, requirements := manager.applyApprovalPolicies(validations, base)
+	assert.Equal(t, base, decision)
+	assert.Equal(t, []shared.ApprovalRequirement{{Policy: "prod_consumers", Approvals: 1}}, requirements)
+}
+
+func TestValidateRuleConfig_ApprovalPolicies(t *testing.T) {
+	base := func(policies ...config.ApprovalPolicy) *config.GlobalRuleConfig {
+		return &config.GlobalRuleConfig{
+			Files: []config.FileRuleConfig{{
+				Name: "docs", Path: "**/", Filename: "*.md", ParserType: "yaml",
+				Sections: []config.SectionDefinition{{Name: "full", YAMLPath: ".", AutoApprove: true}},
+			}},
+			ApprovalPolicies: policies,
+		}
+	}
+	when := []config.PolicyCondition{{Rule: "warehouse_rule", Decision: "manual_review"}}
+
+	assert.NoError(t, config.ValidateRuleConfig(base(config.ApprovalPolicy{Name: "a", When: when, Approvals: 1})))
+	assert.NoError(t, config.ValidateRuleConfig(base(config.ApprovalPolicy{Name: "a", When: when, Approvals: 2, Approvers: []string{"alice", "bob"}})))
+	assert.Error(t, config.ValidateRuleConfig(base(config.ApprovalPolicy{When: when, Approvals: 1})))
+	assert.Error(t, config.ValidateRuleConfig(base(config.ApprovalPolicy{Name: "a", Approvals: 1})))
+	assert.Error(t, config.ValidateRuleConfig(base(config.ApprovalPolicy{Name: "a", When: when})))
+	assert.Error(t, config.ValidateRuleConfig(base(config.ApprovalPolicy{Name: "a", When: when, Approvals: 2, Approvers: []string{"alice"}})), "not enough approvers")
+	assert.Error(t, config.ValidateRuleConfig(base(config.ApprovalPolicy{Name: "a", When: []config.PolicyCondition{{Rule: "x", ReasonMatches: "("}}, Approvals: 1})))
+}
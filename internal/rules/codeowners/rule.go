@@ -8,6 +8,7 @@ import (
 	"github.com/redhat-data-and-ai/naysayer/internal/logging"
 	"github.com/redhat-data-and-ai/naysayer/internal/rules/common"
 	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"go.uber.org/zap"
 	"gopkg.in/yaml.v3"
 )
 
@@ -182,15 +183,15 @@ func (r *CODEOWNERSSyncRule) fetchOwners(mrCtx *shared.MRContext, filePath strin
 		return nil
 	}
 
-	content, err := r.client.FetchFileContent(mrCtx.ProjectID, filePath, mrCtx.MRInfo.SourceBranch)
+	content, err := r.client.FetchFileContent(mrCtx.Context(), mrCtx.ProjectID, filePath, mrCtx.MRInfo.SourceBranch)
 	if err != nil {
-		logging.Warn("Failed to fetch developers.yaml: %v", err)
+		logging.Warn("Failed to fetch developers.yaml", zap.Error(err))
 		return nil
 	}
 
 	var data DevelopersYAML
 	if err := yaml.Unmarshal([]byte(content.Content), &data); err != nil {
-		logging.Warn("Failed to parse developers.yaml: %v", err)
+		logging.Warn("Failed to parse developers.yaml", zap.Error(err))
 		return nil
 	}
 	return data.Group.Owners
@@ -202,15 +203,15 @@ func (r *CODEOWNERSSyncRule) fetchGroupInfo(mrCtx *shared.MRContext, filePath st
 		return nil
 	}
 
-	content, err := r.client.FetchFileContent(mrCtx.ProjectID, filePath, mrCtx.MRInfo.SourceBranch)
+	content, err := r.client.FetchFileContent(mrCtx.Context(), mrCtx.ProjectID, filePath, mrCtx.MRInfo.SourceBranch)
 	if err != nil {
-		logging.Warn("Failed to fetch group YAML: %v", err)
+		logging.Warn("Failed to fetch group YAML", zap.Error(err))
 		return nil
 	}
 
 	var data GroupYAML
 	if err := yaml.Unmarshal([]byte(content.Content), &data); err != nil {
-		logging.Warn("Failed to parse group YAML: %v", err)
+		logging.Warn("Failed to parse group YAML", zap.Error(err))
 		return nil
 	}
 	return &data
@@ -221,7 +222,7 @@ func (r *CODEOWNERSSyncRule) dataProductExists(mrCtx *shared.MRContext, dp DataP
 	if r.client == nil || mrCtx.MRInfo == nil {
 		return false
 	}
-	_, err := r.client.FetchFileContent(mrCtx.ProjectID, dp.Path+"/developers.yaml", mrCtx.MRInfo.TargetBranch)
+	_, err := r.client.FetchFileContent(mrCtx.Context(), mrCtx.ProjectID, dp.Path+"/developers.yaml", mrCtx.MRInfo.TargetBranch)
 	return err == nil
 }
 
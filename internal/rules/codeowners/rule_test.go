@@ -1,6 +1,7 @@
 package codeowners
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -18,7 +19,7 @@ func NewMockGitLabClient() *MockGitLabClient {
 	return &MockGitLabClient{fileContents: make(map[string]*gitlab.FileContent)}
 }
 
-func (m *MockGitLabClient) FetchFileContent(projectID int, filePath, ref string) (*gitlab.FileContent, error) {
+func (m *MockGitLabClient) FetchFileContent(ctx context.Context, projectID int, filePath, ref string) (*gitlab.FileContent, error) {
 	key := ref + ":" + filePath
 	if content, exists := m.fileContents[key]; exists {
 		return content, nil
@@ -33,67 +34,79 @@ func (m *MockGitLabClient) SetFileContent(ref, filePath, content string) {
 }
 
 // Stub interface methods
-func (m *MockGitLabClient) FetchMRChanges(projectID, mrIID int) ([]gitlab.FileChange, error) {
+func (m *MockGitLabClient) FetchMRChanges(ctx context.Context, projectID, mrIID int) ([]gitlab.FileChange, error) {
 	return nil, nil
 }
-func (m *MockGitLabClient) AddMRComment(projectID, mrIID int, comment string) error { return nil }
-func (m *MockGitLabClient) ApproveMR(projectID, mrIID int) error                    { return nil }
-func (m *MockGitLabClient) ApproveMRWithMessage(projectID, mrIID int, message string) error {
+func (m *MockGitLabClient) AddMRComment(ctx context.Context, projectID, mrIID int, comment string) error {
 	return nil
 }
-func (m *MockGitLabClient) ResetNaysayerApproval(projectID, mrIID int) error { return nil }
-func (m *MockGitLabClient) GetMRTargetBranch(projectID, mrIID int) (string, error) {
+func (m *MockGitLabClient) ApproveMR(ctx context.Context, projectID, mrIID int) error { return nil }
+func (m *MockGitLabClient) ApproveMRWithMessage(ctx context.Context, projectID, mrIID int, message string) error {
+	return nil
+}
+func (m *MockGitLabClient) ResetNaysayerApproval(ctx context.Context, projectID, mrIID int) error {
+	return nil
+}
+func (m *MockGitLabClient) GetMRTargetBranch(ctx context.Context, projectID, mrIID int) (string, error) {
 	return "main", nil
 }
-func (m *MockGitLabClient) GetMRDetails(projectID, mrIID int) (*gitlab.MRDetails, error) {
+func (m *MockGitLabClient) GetMRDetails(ctx context.Context, projectID, mrIID int) (*gitlab.MRDetails, error) {
 	return nil, nil
 }
-func (m *MockGitLabClient) ListMRComments(projectID, mrIID int) ([]gitlab.MRComment, error) {
+func (m *MockGitLabClient) ListMRComments(ctx context.Context, projectID, mrIID int) ([]gitlab.MRComment, error) {
 	return nil, nil
 }
-func (m *MockGitLabClient) UpdateMRComment(projectID, mrIID, commentID int, newBody string) error {
+func (m *MockGitLabClient) UpdateMRComment(ctx context.Context, projectID, mrIID, commentID int, newBody string) error {
 	return nil
 }
-func (m *MockGitLabClient) AddOrUpdateMRComment(projectID, mrIID int, commentBody, commentType string) error {
+func (m *MockGitLabClient) AddOrUpdateMRComment(ctx context.Context, projectID, mrIID int, commentBody, commentType string) error {
 	return nil
 }
-func (m *MockGitLabClient) FindLatestNaysayerComment(projectID, mrIID int, commentType ...string) (*gitlab.MRComment, error) {
+func (m *MockGitLabClient) FindLatestNaysayerComment(ctx context.Context, projectID, mrIID int, commentType ...string) (*gitlab.MRComment, error) {
 	return nil, nil
 }
-func (m *MockGitLabClient) GetCurrentBotUsername() (string, error)                 { return "", nil }
-func (m *MockGitLabClient) IsNaysayerBotAuthor(author map[string]interface{}) bool { return false }
-func (m *MockGitLabClient) RebaseMR(projectID, mrIID int) (bool, error)            { return false, nil }
-func (m *MockGitLabClient) CompareBranches(sourceProjectID int, sourceBranch string, targetProjectID int, targetBranch string) (*gitlab.CompareResult, error) {
+func (m *MockGitLabClient) GetCurrentBotUsername(ctx context.Context) (string, error) { return "", nil }
+func (m *MockGitLabClient) IsNaysayerBotAuthor(ctx context.Context, author map[string]interface{}) bool {
+	return false
+}
+func (m *MockGitLabClient) RebaseMR(ctx context.Context, projectID, mrIID int) (bool, error) {
+	return false, nil
+}
+func (m *MockGitLabClient) CompareBranches(ctx context.Context, sourceProjectID int, sourceBranch string, targetProjectID int, targetBranch string) (*gitlab.CompareResult, error) {
 	return nil, nil
 }
-func (m *MockGitLabClient) GetBranchCommit(projectID int, branch string) (string, error) {
+func (m *MockGitLabClient) GetBranchCommit(ctx context.Context, projectID int, branch string) (string, error) {
 	return "", nil
 }
-func (m *MockGitLabClient) CompareCommits(projectID int, fromSHA, toSHA string) (*gitlab.CompareResult, error) {
+func (m *MockGitLabClient) CompareCommits(ctx context.Context, projectID int, fromSHA, toSHA string) (*gitlab.CompareResult, error) {
+	return nil, nil
+}
+func (m *MockGitLabClient) ListOpenMRs(ctx context.Context, projectID int) ([]int, error) {
 	return nil, nil
 }
-func (m *MockGitLabClient) ListOpenMRs(projectID int) ([]int, error) { return nil, nil }
-func (m *MockGitLabClient) ListOpenMRsWithDetails(projectID int) ([]gitlab.MRDetails, error) {
+func (m *MockGitLabClient) ListOpenMRsWithDetails(ctx context.Context, projectID int) ([]gitlab.MRDetails, error) {
 	return nil, nil
 }
-func (m *MockGitLabClient) ListAllOpenMRsWithDetails(projectID int) ([]gitlab.MRDetails, error) {
+func (m *MockGitLabClient) ListAllOpenMRsWithDetails(ctx context.Context, projectID int) ([]gitlab.MRDetails, error) {
 	return nil, nil
 }
-func (m *MockGitLabClient) CloseMR(projectID, mrIID int) error { return nil }
-func (m *MockGitLabClient) GetPipelineJobs(projectID, pipelineID int) ([]gitlab.PipelineJob, error) {
+func (m *MockGitLabClient) CloseMR(ctx context.Context, projectID, mrIID int) error { return nil }
+func (m *MockGitLabClient) GetPipelineJobs(ctx context.Context, projectID, pipelineID int) ([]gitlab.PipelineJob, error) {
 	return nil, nil
 }
-func (m *MockGitLabClient) GetJobTrace(projectID, jobID int) (string, error) { return "", nil }
-func (m *MockGitLabClient) FindLatestAtlantisComment(projectID, mrIID int) (*gitlab.MRComment, error) {
+func (m *MockGitLabClient) GetJobTrace(ctx context.Context, projectID, jobID int) (string, error) {
+	return "", nil
+}
+func (m *MockGitLabClient) FindLatestAtlantisComment(ctx context.Context, projectID, mrIID int) (*gitlab.MRComment, error) {
 	return nil, nil
 }
-func (m *MockGitLabClient) AreAllPipelineJobsSucceeded(projectID, pipelineID int) (bool, error) {
+func (m *MockGitLabClient) AreAllPipelineJobsSucceeded(ctx context.Context, projectID, pipelineID int) (bool, error) {
 	return false, nil
 }
-func (m *MockGitLabClient) CheckAtlantisCommentForPlanFailures(projectID, mrIID int) (bool, string) {
+func (m *MockGitLabClient) CheckAtlantisCommentForPlanFailures(ctx context.Context, projectID, mrIID int) (bool, string) {
 	return false, ""
 }
-func (m *MockGitLabClient) FindCommentByPattern(projectID, mrIID int, pattern string) (bool, error) {
+func (m *MockGitLabClient) FindCommentByPattern(ctx context.Context, projectID, mrIID int, pattern string) (bool, error) {
 	return false, nil
 }
 
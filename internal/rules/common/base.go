@@ -41,6 +41,12 @@ func (b *BaseRule) GetMRContext() *shared.MRContext {
 	return b.mrContext
 }
 
+// Environment returns the environment a file targets as resolved in the MR context, empty
+// for files that are not environment-specific
+func (b *BaseRule) Environment(filePath string) string {
+	return b.mrContext.EnvironmentOf(filePath)
+}
+
 // GetFullFileCoverage returns line ranges covering the entire file
 func (b *BaseRule) GetFullFileCoverage(filePath, fileContent string) []shared.LineRange {
 	totalLines := shared.CountLines(fileContent)
@@ -0,0 +1,339 @@
+package rules
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+)
+
+// SchemaError is a problem of the rule configuration at a line and column of the file.
+// Line is zero for problems without a position.
+type SchemaError struct {
+	Line    int
+	Column  int
+	Message string
+}
+
+// Error formats the problem as line:column: message
+func (e SchemaError) Error() string {
+	if e.Line == 0 {
+		return e.Message
+	}
+	return fmt.Sprintf("%d:%d: %s", e.Line, e.Column, e.Message)
+}
+
+// requiredKeys lists the keys each configuration entry must set; "a|b" requires either key
+var requiredKeys = map[reflect.Type][]string{
+	reflect.TypeOf(config.FileRuleConfig{}):    {"name", "path", "filename", "parser_type"},
+	reflect.TypeOf(config.SectionDefinition{}): {"name", "yaml_path|pattern"},
+	reflect.TypeOf(config.RuleConfig{}):        {"name"},
+	reflect.TypeOf(config.DeletionPolicy{}):    {"name", "path", "filename", "action"},
+	reflect.TypeOf(config.DecisionPolicy{}):    {"name", "when", "action"},
+	reflect.TypeOf(config.ApprovalPolicy{}):    {"name", "when", "approvals"},
+	reflect.TypeOf(config.PolicyCondition{}):   {"rule"},
+	reflect.TypeOf(config.RuleSchedule{}):      {"rule"},
+	reflect.TypeOf(config.ScheduleWindow{}):    {"from", "to"},
+	reflect.TypeOf(config.RuleSeverity{}):      {"rule", "severity"},
+	reflect.TypeOf(config.ProjectRuleConfig{}): {"name"},
+}
+
+// entryChecks verify the values of configuration entries beyond their types: file patterns,
+// regular expressions and rule names
+var entryChecks = map[reflect.Type]func(w *schemaWalker, keys map[string]*yaml.Node){
+	reflect.TypeOf(config.FileRuleConfig{}): func(w *schemaWalker, keys map[string]*yaml.Node) {
+		w.checkPattern(keys["path"], keys["filename"])
+	},
+	reflect.TypeOf(config.SectionDefinition{}): func(w *schemaWalker, keys map[string]*yaml.Node) {
+		w.checkRegexp(keys["pattern"])
+		w.checkRegexp(keys["end_pattern"])
+	},
+	reflect.TypeOf(config.DeletionPolicy{}): func(w *schemaWalker, keys map[string]*yaml.Node) {
+		w.checkPattern(keys["path"], keys["filename"])
+	},
+	reflect.TypeOf(config.RuleConfig{}): func(w *schemaWalker, keys map[string]*yaml.Node) {
+		w.checkRuleName(keys["name"])
+	},
+	reflect.TypeOf(config.PolicyCondition{}): func(w *schemaWalker, keys map[string]*yaml.Node) {
+		w.checkRuleName(keys["rule"])
+		w.checkPattern(keys["path"])
+		w.checkRegexp(keys["reason_matches"])
+	},
+	reflect.TypeOf(config.RuleSchedule{}): func(w *schemaWalker, keys map[string]*yaml.Node) {
+		w.checkRuleName(keys["rule"])
+	},
+	reflect.TypeOf(config.RuleSeverity{}): func(w *schemaWalker, keys map[string]*yaml.Node) {
+		w.checkRuleName(keys["rule"])
+		w.checkPattern(keys["path"])
+		w.checkRegexp(keys["reason_matches"])
+	},
+}
+
+var yamlErrorLine = regexp.MustCompile(`line (\d+)`)
+
+// ValidateRuleConfigFile strictly validates a rule configuration file: its schema with
+// ValidateRuleConfigSchema and, when that passes, the checks applied when it is loaded
+func ValidateRuleConfigFile(configPath string, registry *RuleRegistry) []SchemaError {
+	data, err := os.ReadFile(filepath.Clean(configPath))
+	if err != nil {
+		return []SchemaError{{Message: fmt.Sprintf("failed to read rule config: %v", err)}}
+	}
+	if problems := ValidateRuleConfigSchema(data, registry); len(problems) > 0 {
+		return problems
+	}
+	if _, err := config.LoadRuleConfig(configPath); err != nil {
+		return []SchemaError{{Message: err.Error()}}
+	}
+	return nil
+}
+
+// ValidateRuleConfigSchema reports unknown keys, missing required keys, values of the wrong
+// type, malformed file patterns and regular expressions, and rule names the registry does not
+// know, with the position of each in data, in file order
+func ValidateRuleConfigSchema(data []byte, registry *RuleRegistry) []SchemaError {
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		problem := SchemaError{Message: err.Error()}
+		if match := yamlErrorLine.FindStringSubmatch(err.Error()); match != nil {
+			problem.Line, _ = strconv.Atoi(match[1])
+			problem.Column = 1
+		}
+		return []SchemaError{problem}
+	}
+	if len(document.Content) == 0 {
+		return []SchemaError{{Message: "rule config is empty"}}
+	}
+
+	w := &schemaWalker{ruleNames: []string{DeletionPolicyRuleName}}
+	for name := range registry.ListRules() {
+		w.ruleNames = append(w.ruleNames, name)
+	}
+	sort.Strings(w.ruleNames)
+	w.walk(document.Content[0], reflect.TypeOf(config.RuleBasedConfig{}), "")
+	sort.SliceStable(w.problems, func(i, j int) bool {
+		a, b := w.problems[i], w.problems[j]
+		return a.Line < b.Line || (a.Line == b.Line && a.Column < b.Column)
+	})
+	return w.problems
+}
+
+// schemaWalker compares a YAML node tree with the configuration types
+type schemaWalker struct {
+	ruleNames []string
+	problems  []SchemaError
+}
+
+func (w *schemaWalker) errorf(node *yaml.Node, format string, args ...interface{}) {
+	w.problems = append(w.problems, SchemaError{Line: node.Line, Column: node.Column, Message: fmt.Sprintf(format, args...)})
+}
+
+// walk validates node as a value of type t found at where
+func (w *schemaWalker) walk(node *yaml.Node, t reflect.Type, where string) {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	switch t.Kind() {
+	case reflect.Ptr:
+		w.walk(node, t.Elem(), where)
+	case reflect.Struct:
+		w.walkStruct(node, t, where)
+	case reflect.Slice:
+		if isNull(node) {
+			return
+		}
+		if node.Kind != yaml.SequenceNode {
+			w.errorf(node, "%s must be a list", describe(where))
+			return
+		}
+		for i, item := range node.Content {
+			w.walk(item, t.Elem(), fmt.Sprintf("%s[%d]", where, i))
+		}
+	case reflect.Map:
+		if isNull(node) {
+			return
+		}
+		if node.Kind != yaml.MappingNode {
+			w.errorf(node, "%s must be a mapping", describe(where))
+			return
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			w.walk(node.Content[i+1], t.Elem(), join(where, node.Content[i].Value))
+		}
+	default:
+		if node.Kind != yaml.ScalarNode {
+			w.errorf(node, "%s must be a %s", describe(where), typeName(t))
+			return
+		}
+		if err := node.Decode(reflect.New(t).Interface()); err != nil {
+			w.errorf(node, "%s must be a %s, got %q", describe(where), typeName(t), node.Value)
+		}
+	}
+}
+
+// walkStruct rejects unknown and duplicate keys, walks the known ones and checks that the
+// required keys are set
+func (w *schemaWalker) walkStruct(node *yaml.Node, t reflect.Type, where string) {
+	if node.Kind != yaml.MappingNode {
+		w.errorf(node, "%s must be a mapping", describe(where))
+		return
+	}
+
+	fields := make(map[string]reflect.Type)
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("yaml"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		fields[name] = t.Field(i).Type
+		names = append(names, name)
+	}
+
+	keys := make(map[string]*yaml.Node)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		fieldType, known := fields[key.Value]
+		switch {
+		case !known:
+			w.errorf(key, "unknown key %q in %s%s", key.Value, describe(where), suggest(key.Value, names))
+			continue
+		case keys[key.Value] != nil:
+			w.errorf(key, "duplicate key %q in %s", key.Value, describe(where))
+			continue
+		}
+		keys[key.Value] = value
+		w.walk(value, fieldType, join(where, key.Value))
+	}
+
+	for _, required := range requiredKeys[t] {
+		var names []string
+		set := false
+		for _, name := range strings.Split(required, "|") {
+			names = append(names, strconv.Quote(name))
+			if value := keys[name]; value != nil && !isNull(value) && (value.Kind != yaml.ScalarNode || value.Value != "") {
+				set = true
+			}
+		}
+		if !set {
+			w.errorf(node, "%s is missing required key %s", describe(where), strings.Join(names, " or "))
+		}
+	}
+	if check := entryChecks[t]; check != nil {
+		check(w, keys)
+	}
+}
+
+// checkPattern verifies that the concatenation of the scalar nodes is a supported file pattern
+func (w *schemaWalker) checkPattern(nodes ...*yaml.Node) {
+	var pattern string
+	var last *yaml.Node
+	for _, node := range nodes {
+		if node == nil || node.Kind != yaml.ScalarNode {
+			continue
+		}
+		pattern += node.Value
+		last = node
+	}
+	if last == nil || pattern == "" {
+		return
+	}
+	if err := shared.ValidatePattern(pattern); err != nil {
+		w.errorf(last, "%v", err)
+	}
+}
+
+// checkRegexp verifies that a scalar node is a valid regular expression
+func (w *schemaWalker) checkRegexp(node *yaml.Node) {
+	if node == nil || node.Kind != yaml.ScalarNode {
+		return
+	}
+	if _, err := regexp.Compile(node.Value); err != nil {
+		w.errorf(node, "invalid regular expression %q: %v", node.Value, err)
+	}
+}
+
+// checkRuleName verifies that a scalar node names a registered rule
+func (w *schemaWalker) checkRuleName(node *yaml.Node) {
+	if node == nil || node.Kind != yaml.ScalarNode || node.Value == "" {
+		return
+	}
+	for _, name := range w.ruleNames {
+		if name == node.Value {
+			return
+		}
+	}
+	w.errorf(node, "unknown rule %q%s", node.Value, suggest(node.Value, w.ruleNames))
+}
+
+func isNull(node *yaml.Node) bool {
+	return node.Kind == yaml.ScalarNode && node.Tag == "!!null"
+}
+
+func join(where, key string) string {
+	if where == "" {
+		return key
+	}
+	return where + "." + key
+}
+
+func describe(where string) string {
+	if where == "" {
+		return "the rule config"
+	}
+	return where
+}
+
+func typeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int64:
+		return "number"
+	default:
+		return t.Kind().String()
+	}
+}
+
+// suggest proposes the closest of names to a misspelled value
+func suggest(value string, names []string) string {
+	best, bestDistance := "", len(value)/2+1
+	for _, name := range names {
+		if distance := editDistance(value, name); distance < bestDistance {
+			best, bestDistance = name, distance
+		}
+	}
+	if best == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (did you mean %q?)", best)
+}
+
+// editDistance is the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+	return previous[len(b)]
+}
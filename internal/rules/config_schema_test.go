@@ -0,0 +1,133 @@
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const schemaTestConfig = `enabled: true
+files:
+  - name: products
+    path: "dataproducts/**/"
+    filename: "product.{yaml,yml}"
+    parser_type: yaml
+    sections:
+      - name: warehouses
+        yaml_path: warehouses
+        rule_configs:
+          - name: warehouse_rule
+            enabled: true
+            environments:
+              dev: warn
+decision_policies:
+  - name: prod_warehouses
+    when:
+      - rule: warehouse_rule
+        path: "dataproducts/**/prod/product.{yaml,yml}"
+        reason_matches: "^increase"
+    action: manual_review
+rule_severities:
+  - rule: deletion_policy
+    severity: warning
+`
+
+func schemaMessages(problems []SchemaError) []string {
+	messages := make([]string, 0, len(problems))
+	for _, problem := range problems {
+		messages = append(messages, problem.Error())
+	}
+	return messages
+}
+
+func TestValidateRuleConfigSchema_Valid(t *testing.T) {
+	assert.Empty(t, ValidateRuleConfigSchema([]byte(schemaTestConfig), NewRuleRegistry()))
+}
+
+func TestValidateRuleConfigSchema_Errors(t *testing.T) {
+	data := `enabled: yes please
+files:
+  - name: products
+    path: "dataproducts/**/critical/**/"
+    filename: "product.{yaml,yml}"
+    parser_type: yaml
+    sections:
+      - name: warehouses
+        yaml_paht: warehouses
+        rule_configs:
+          - name: warehouse_rul
+            enabled: true
+decision_policies:
+  - name: prod_warehouses
+    when:
+      - rule: warehouse_rule
+        reason_matches: "("
+    action: manual_review
+approval_policies:
+  - name: two_approvals
+    when: [{rule: warehouse_rule}]
+    approvals: two
+`
+	assert.Equal(t, []string{
+		`1:10: enabled must be a boolean, got "yes please"`,
+		`5:15: pattern "dataproducts/**/critical/**/product.{yaml,yml}" has more than one **, only one is supported`,
+		`8:9: files[0].sections[0] is missing required key "yaml_path" or "pattern"`,
+		`9:9: unknown key "yaml_paht" in files[0].sections[0] (did you mean "yaml_path"?)`,
+		`11:19: unknown rule "warehouse_rul" (did you mean "warehouse_rule"?)`,
+		"17:25: invalid regular expression \"(\": error parsing regexp: missing closing ): `(`",
+		`22:16: approval_policies[0].approvals must be a number, got "two"`,
+	}, schemaMessages(ValidateRuleConfigSchema([]byte(data), NewRuleRegistry())))
+}
+
+func TestValidateRuleConfigSchema_Syntax(t *testing.T) {
+	problems := ValidateRuleConfigSchema([]byte("files:\n  - name: products\n    sections: [a\n"), NewRuleRegistry())
+	if assert.Len(t, problems, 1) {
+		assert.Positive(t, problems[0].Line)
+		assert.Contains(t, problems[0].Message, "did not find expected ',' or ']'")
+	}
+
+	problems = ValidateRuleConfigSchema(nil, NewRuleRegistry())
+	assert.Equal(t, []string{"rule config is empty"}, schemaMessages(problems))
+}
+
+func TestValidateRuleConfigFile(t *testing.T) {
+	assert.Empty(t, ValidateRuleConfigFile(filepath.Join("..", "..", RulesConfigPath), NewRuleRegistry()),
+		"the shipped rule config is valid")
+
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(schemaTestConfig), 0o600))
+	assert.Empty(t, ValidateRuleConfigFile(path, NewRuleRegistry()))
+
+	// Schema-valid configs still get the checks applied when loading
+	invalid := schemaTestConfig + "    environments: [\"dev/eu\"]\n"
+	assert.NoError(t, os.WriteFile(path, []byte(invalid), 0o600))
+	problems := ValidateRuleConfigFile(path, NewRuleRegistry())
+	if assert.Len(t, problems, 1) {
+		assert.Zero(t, problems[0].Line)
+	}
+
+	problems = ValidateRuleConfigFile(filepath.Join(t.TempDir(), "missing.yaml"), NewRuleRegistry())
+	assert.Len(t, problems, 1)
+}
+
+func TestValidateRuleConfigSchema_TextSections(t *testing.T) {
+	data := `files:
+  - name: tfvars
+    path: "terraform/"
+    filename: "*.tfvars"
+    parser_type: text
+    sections:
+      - name: warehouse
+        pattern: "^warehouse_"
+        auto_approve: true
+      - name: tags
+        pattern: "^tags"
+        end_pattern: "^[}"
+        auto_approve: true
+`
+	assert.Equal(t, []string{
+		"12:22: invalid regular expression \"^[}\": error parsing regexp: missing closing ]: `[}`",
+	}, schemaMessages(ValidateRuleConfigSchema([]byte(data), NewRuleRegistry())))
+}
@@ -0,0 +1,187 @@
+package consumer_cycle
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/depgraph"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/repoindex"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/common"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+	"go.uber.org/zap"
+)
+
+// RuleName is the identifier of the consumer cycle rule
+const RuleName = "consumer_cycle_rule"
+
+// Client is the subset of the GitLab client needed to build the dependency graph
+type Client interface {
+	FetchFileContent(ctx context.Context, projectID int, filePath, ref string) (*gitlab.FileContent, error)
+	GetMRDetails(ctx context.Context, projectID, mrIID int) (*gitlab.MRDetails, error)
+}
+
+// Rule requires manual review when an MR adds a data product consumer that closes a
+// dependency cycle (A consumes B and B consumes A, possibly through other products).
+// The target-branch graph is overlaid with every product.yaml changed in the MR, so
+// cycles introduced across several files of one MR are found too.
+type Rule struct {
+	*common.BaseRule
+	client Client
+
+	mu       sync.Mutex
+	graphCtx *shared.MRContext // MR the cached graph was built for
+	graph    *depgraph.Graph
+	target   map[string][]depgraph.Edge // target-branch edges per changed file
+}
+
+// NewRule creates a new consumer cycle rule instance
+func NewRule(client Client) *Rule {
+	return &Rule{
+		BaseRule: common.NewBaseRule(RuleName, "Requires manual review when a new data product consumer creates a circular dependency"),
+		client:   client,
+	}
+}
+
+// GetCoveredLines returns line ranges this rule covers
+func (r *Rule) GetCoveredLines(filePath string, fileContent string) []shared.LineRange {
+	if !depgraph.IsProductFile(filePath) {
+		return []shared.LineRange{}
+	}
+	return r.GetFullFileCoverage(filePath, fileContent)
+}
+
+// ValidateLines checks the consumer edges the MR adds to filePath for cycles
+func (r *Rule) ValidateLines(filePath string, fileContent string, lineRanges []shared.LineRange) (shared.DecisionType, string) {
+	if !depgraph.IsProductFile(filePath) {
+		return shared.Approve, "Not a product.yaml file - consumer cycle rule does not apply"
+	}
+
+	graph, target, err := r.graphForMR()
+	if err != nil {
+		logging.Warn("Consumer cycle check skipped", zap.String("file_path", filePath), zap.Error(err))
+		return shared.Approve, "Consumer cycle check skipped: " + err.Error()
+	}
+
+	existing := make(map[[2]string]bool)
+	for _, e := range target[filePath] {
+		existing[[2]string{e.Consumer, e.Producer}] = true
+	}
+
+	var cycles []string
+	for _, e := range graph.Edges() {
+		if e.FilePath != filePath || existing[[2]string{e.Consumer, e.Producer}] {
+			continue
+		}
+		if path := graph.CyclePath(e); path != nil {
+			cycles = append(cycles, fmt.Sprintf("%s (%s)", strings.Join(path, " → "), e.Environment))
+		}
+	}
+
+	if len(cycles) > 0 {
+		return shared.ManualReview, "Circular data product dependency: " + strings.Join(cycles, "; ") + " - manual review required"
+	}
+	return shared.Approve, "No circular data product dependencies introduced"
+}
+
+// graphForMR builds the dependency graph of the target branch with the MR's product files
+// applied, once per MR evaluation. It also returns the target-branch edges of the changed files.
+func (r *Rule) graphForMR() (*depgraph.Graph, map[string][]depgraph.Edge, error) {
+	mrCtx := r.GetMRContext()
+	if mrCtx == nil || mrCtx.MRInfo == nil {
+		return nil, nil, fmt.Errorf("MR context not available")
+	}
+	if r.client == nil {
+		return nil, nil, fmt.Errorf("GitLab client not available")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.graphCtx == mrCtx {
+		return r.graph, r.target, nil
+	}
+
+	targetBranch := mrCtx.MRInfo.TargetBranch
+	graph, err := r.targetGraph(mrCtx.Context(), mrCtx.ProjectID, targetBranch)
+	if err != nil {
+		// Cycles within the MR's own files are still detected
+		logging.Warn("Dependency graph of target branch unavailable, checking MR files only",
+			zap.String("target_branch", targetBranch),
+			zap.Error(err))
+		graph = depgraph.New()
+	}
+
+	target := make(map[string][]depgraph.Edge)
+	for _, e := range graph.Edges() {
+		target[e.FilePath] = append(target[e.FilePath], e)
+	}
+
+	sourceProjectID := r.sourceProjectID(mrCtx)
+	for _, change := range mrCtx.Changes {
+		if !depgraph.IsProductFile(change.NewPath) && !depgraph.IsProductFile(change.OldPath) {
+			continue
+		}
+		graph.RemoveFile(change.OldPath)
+		graph.RemoveFile(change.NewPath)
+		if change.OldPath != "" && change.OldPath != change.NewPath {
+			// Compare a renamed file with the edges it had under its old path
+			target[change.NewPath] = retarget(target[change.OldPath], change.NewPath)
+		}
+		if change.DeletedFile || !depgraph.IsProductFile(change.NewPath) {
+			continue
+		}
+
+		content, err := r.client.FetchFileContent(mrCtx.Context(), sourceProjectID, change.NewPath, mrCtx.MRInfo.SourceBranch)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to fetch %s: %w", change.NewPath, err)
+		}
+		if content == nil {
+			return nil, nil, fmt.Errorf("empty response when fetching %s", change.NewPath)
+		}
+		product, err := depgraph.ParseProduct(change.NewPath, content.Content)
+		if err != nil {
+			return nil, nil, err
+		}
+		graph.AddProduct(product)
+	}
+
+	r.graphCtx, r.graph, r.target = mrCtx, graph, target
+	return graph, target, nil
+}
+
+// targetGraph builds the target-branch graph from the shared repository index, or a
+// one-off tree listing when the index is disabled and the client can list trees
+func (r *Rule) targetGraph(ctx context.Context, projectID int, ref string) (*depgraph.Graph, error) {
+	index := repoindex.ForClient(r.client)
+	if index == nil {
+		lister, ok := r.client.(repoindex.TreeLister)
+		if !ok {
+			return nil, fmt.Errorf("repository tree listing not available")
+		}
+		index = repoindex.NewIndex(lister, store.NewMemoryStore())
+	}
+	return depgraph.NewBuilder(index, r.client).Build(ctx, projectID, ref)
+}
+
+// sourceProjectID returns the project holding the MR source branch (the fork for fork MRs)
+func (r *Rule) sourceProjectID(mrCtx *shared.MRContext) int {
+	details, err := r.client.GetMRDetails(mrCtx.Context(), mrCtx.ProjectID, mrCtx.MRIID)
+	if err == nil && details != nil && details.SourceProjectID != 0 {
+		return details.SourceProjectID
+	}
+	return mrCtx.ProjectID
+}
+
+// retarget copies edges with a new file path
+func retarget(edges []depgraph.Edge, filePath string) []depgraph.Edge {
+	out := make([]depgraph.Edge, 0, len(edges))
+	for _, e := range edges {
+		e.FilePath = filePath
+		out = append(out, e)
+	}
+	return out
+}
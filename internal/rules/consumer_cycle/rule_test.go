@@ -0,0 +1,165 @@
+package consumer_cycle
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeClient serves files per ref; it does not list repository trees
+type fakeClient struct {
+	files map[string]map[string]string // ref -> path -> content
+}
+
+func (c *fakeClient) FetchFileContent(ctx context.Context, projectID int, filePath, ref string) (*gitlab.FileContent, error) {
+	content, ok := c.files[ref][filePath]
+	if !ok {
+		return nil, fmt.Errorf("file not found: %s: %w", filePath, gitlab.ErrNotFound)
+	}
+	return &gitlab.FileContent{FilePath: filePath, Content: content}, nil
+}
+
+func (c *fakeClient) GetMRDetails(ctx context.Context, projectID, mrIID int) (*gitlab.MRDetails, error) {
+	return &gitlab.MRDetails{SourceProjectID: projectID}, nil
+}
+
+// fakeTreeClient also lists the repository tree of a ref
+type fakeTreeClient struct {
+	fakeClient
+}
+
+func (c *fakeTreeClient) ListRepositoryTree(ctx context.Context, projectID int, ref string) ([]gitlab.TreeEntry, error) {
+	entries := make([]gitlab.TreeEntry, 0)
+	for path := range c.files[ref] {
+		entries = append(entries, gitlab.TreeEntry{Type: "blob", Path: path})
+	}
+	return entries, nil
+}
+
+const (
+	ordersPath    = "dataproducts/source/orders/prod/product.yaml"
+	analyticsPath = "dataproducts/aggregate/analytics/prod/product.yaml"
+)
+
+// product renders a product.yaml with the given data product consumers
+func product(name string, consumers ...string) string {
+	content := "name: " + name + "\ndata_product_db:\n  - presentation_schemas:\n      - name: marts\n        consumers:\n"
+	for _, consumer := range consumers {
+		content += "          - name: " + consumer + "\n            kind: data_product\n"
+	}
+	content += "          - name: readers\n            kind: consumer_group\n"
+	return content
+}
+
+func newMRContext(changes ...gitlab.FileChange) *shared.MRContext {
+	return &shared.MRContext{
+		ProjectID: 1,
+		MRIID:     5,
+		Changes:   changes,
+		MRInfo:    &gitlab.MRInfo{SourceBranch: "feature", TargetBranch: "main"},
+	}
+}
+
+func TestRule_DetectsTransitiveCycleAgainstTargetBranch(t *testing.T) {
+	client := &fakeTreeClient{fakeClient{files: map[string]map[string]string{
+		"main": {
+			ordersPath:    product("orders", "analytics"),
+			analyticsPath: product("analytics", "finance"),
+			"dataproducts/aggregate/finance/prod/product.yaml": product("finance"),
+		},
+		"feature": {
+			// finance consumes analytics, analytics consumes orders; orders now consumes finance
+			"dataproducts/aggregate/finance/prod/product.yaml": product("finance", "orders"),
+		},
+	}}}
+	rule := NewRule(client)
+	rule.SetMRContext(newMRContext(gitlab.FileChange{OldPath: "dataproducts/aggregate/finance/prod/product.yaml", NewPath: "dataproducts/aggregate/finance/prod/product.yaml"}))
+
+	decision, reason := rule.ValidateLines("dataproducts/aggregate/finance/prod/product.yaml", "", nil)
+	assert.Equal(t, shared.ManualReview, decision)
+	assert.Contains(t, reason, "orders → finance → analytics → orders (prod)")
+}
+
+func TestRule_ExistingEdgesAreNotReported(t *testing.T) {
+	client := &fakeTreeClient{fakeClient{files: map[string]map[string]string{
+		"main": {
+			ordersPath:    product("orders", "analytics"),
+			analyticsPath: product("analytics", "orders"),
+		},
+		"feature": {
+			ordersPath: product("orders", "analytics", "finance"),
+		},
+	}}}
+	rule := NewRule(client)
+	rule.SetMRContext(newMRContext(gitlab.FileChange{OldPath: ordersPath, NewPath: ordersPath}))
+
+	decision, reason := rule.ValidateLines(ordersPath, "", nil)
+	assert.Equal(t, shared.Approve, decision)
+	assert.Equal(t, "No circular data product dependencies introduced", reason)
+}
+
+func TestRule_CycleWithinOneMR(t *testing.T) {
+	// Without tree listing only the MR's own files are checked
+	client := &fakeClient{files: map[string]map[string]string{
+		"feature": {
+			ordersPath:    product("orders", "analytics"),
+			analyticsPath: product("analytics", "orders"),
+		},
+	}}
+	rule := NewRule(client)
+	rule.SetMRContext(newMRContext(
+		gitlab.FileChange{NewPath: ordersPath, NewFile: true},
+		gitlab.FileChange{NewPath: analyticsPath, NewFile: true},
+	))
+
+	decision, reason := rule.ValidateLines(ordersPath, "", nil)
+	assert.Equal(t, shared.ManualReview, decision)
+	assert.Contains(t, reason, "analytics → orders → analytics (prod)")
+
+	decision, _ = rule.ValidateLines(analyticsPath, "", nil)
+	assert.Equal(t, shared.ManualReview, decision)
+}
+
+func TestRule_SelfConsumerAndOtherEnvironments(t *testing.T) {
+	devPath := "dataproducts/source/orders/dev/product.yaml"
+	client := &fakeTreeClient{fakeClient{files: map[string]map[string]string{
+		"main": {
+			analyticsPath: product("analytics", "orders"),
+		},
+		"feature": {
+			ordersPath: product("orders", "orders"),
+			devPath:    product("orders", "analytics"),
+		},
+	}}}
+	rule := NewRule(client)
+	rule.SetMRContext(newMRContext(
+		gitlab.FileChange{NewPath: ordersPath, NewFile: true},
+		gitlab.FileChange{NewPath: devPath, NewFile: true},
+	))
+
+	decision, reason := rule.ValidateLines(ordersPath, "", nil)
+	assert.Equal(t, shared.ManualReview, decision)
+	assert.Contains(t, reason, "orders → orders (prod)")
+
+	// analytics consumes orders in prod only; the dev edge does not close a cycle
+	decision, _ = rule.ValidateLines(devPath, "", nil)
+	assert.Equal(t, shared.Approve, decision)
+}
+
+func TestRule_NotApplicable(t *testing.T) {
+	rule := NewRule(&fakeClient{})
+	assert.Empty(t, rule.GetCoveredLines("dataproducts/source/orders/prod/warehouse.yaml", "x: y"))
+	assert.NotEmpty(t, rule.GetCoveredLines(ordersPath, "name: orders"))
+
+	decision, _ := rule.ValidateLines("README.md", "", nil)
+	assert.Equal(t, shared.Approve, decision)
+
+	// Missing MR context skips the check
+	decision, reason := rule.ValidateLines(ordersPath, "", nil)
+	assert.Equal(t, shared.Approve, decision)
+	assert.Contains(t, reason, "skipped")
+}
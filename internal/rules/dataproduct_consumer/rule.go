@@ -68,11 +68,6 @@ func (r *DataProductConsumerRule) GetCoveredLines(filePath string, fileContent s
 		return []shared.LineRange{}
 	}
 
-	// Check if file has content
-	if len(strings.TrimSpace(fileContent)) == 0 {
-		return []shared.LineRange{}
-	}
-
 	// For section-based validation, we return a placeholder range to indicate
 	// this rule wants to participate in validation. The actual section content
 	// (data_product_db) will be provided by the section manager.
@@ -88,7 +83,7 @@ func (r *DataProductConsumerRule) GetCoveredLines(filePath string, fileContent s
 func (r *DataProductConsumerRule) analyzeFile(filePath string, fileContent string, lineRanges []shared.LineRange) *ConsumerContext {
 	context := &ConsumerContext{
 		FilePath:         filePath,
-		Environment:      r.extractEnvironmentFromPath(filePath),
+		Environment:      r.environmentOf(filePath),
 		HasConsumers:     false,
 		IsConsumerOnly:   false,
 		IsSelfConsumer:   false,
@@ -335,6 +330,15 @@ func (r *DataProductConsumerRule) isConsumerRelatedLine(line string) bool {
 	return false
 }
 
+// environmentOf returns the environment the file targets, preferring the environment resolved
+// in the MR context over matching the path
+func (r *DataProductConsumerRule) environmentOf(filePath string) string {
+	if env := r.Environment(filePath); env != "" {
+		return env
+	}
+	return r.extractEnvironmentFromPath(filePath)
+}
+
 // extractEnvironmentFromPath attempts to extract the environment name from the file path
 func (r *DataProductConsumerRule) extractEnvironmentFromPath(filePath string) string {
 	lowerPath := strings.ToLower(filePath)
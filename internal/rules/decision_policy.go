@@ -0,0 +1,114 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/redhat-data-and-ai/naysayer/internal/utils"
+	"go.uber.org/zap"
+)
+
+// applyDecisionPolicies escalates the overall decision for every decision policy whose
+// conditions all match the rule results of the MR. Policies only escalate: a matching
+// policy never turns a manual review into an approval.
+func (srm *SectionRuleManager) applyDecisionPolicies(fileValidations map[string]*shared.FileValidationSummary, decision shared.Decision) shared.Decision {
+	var reasons []string
+	blocked := false
+	for _, policy := range srm.config.DecisionPolicies {
+		if !policyConditionsMatch(policy.When, fileValidations) {
+			continue
+		}
+
+		var reason string
+		if policy.Action == utils.DeletionActionBlock {
+			blocked = true
+			reason = fmt.Sprintf("Blocked by decision policy '%s'", policy.Name)
+		} else {
+			reason = fmt.Sprintf("Escalated by decision policy '%s'", policy.Name)
+		}
+		if policy.Reason != "" {
+			reason += ": " + policy.Reason
+		}
+		if len(policy.Reviewers) > 0 {
+			reason += fmt.Sprintf(" (reviewers: %s)", strings.Join(policy.Reviewers, ", "))
+		}
+		logging.Info("Decision policy matched", zap.String("policy", policy.Name), zap.String("action", policy.Action))
+		reasons = append(reasons, reason)
+	}
+	if len(reasons) == 0 {
+		return decision
+	}
+
+	details := decision.Reason
+	if decision.Details != "" {
+		details += ". " + decision.Details
+	}
+	summary := "⚠️ Manual review required"
+	if blocked {
+		summary = "🚫 Blocked by decision policy"
+	}
+	return shared.Decision{
+		Type:    shared.ManualReview,
+		Reason:  strings.Join(reasons, "; "),
+		Summary: summary,
+		Details: details,
+	}
+}
+
+// policyConditionsMatch reports whether every condition matches an evaluated rule result.
+// Conditions may match results on different files.
+func policyConditionsMatch(conditions []config.PolicyCondition, fileValidations map[string]*shared.FileValidationSummary) bool {
+	for _, condition := range conditions {
+		matched := false
+		for filePath, fileValidation := range fileValidations {
+			if conditionMatches(condition, filePath, fileValidation) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+func conditionMatches(condition config.PolicyCondition, filePath string, fileValidation *shared.FileValidationSummary) bool {
+	if fileValidation == nil {
+		return false
+	}
+	if condition.Path != "" && !shared.MatchesPattern(filePath, condition.Path) {
+		return false
+	}
+	for _, result := range fileValidation.RuleResults {
+		if resultMatches(condition, result) {
+			return true
+		}
+	}
+	return false
+}
+
+// resultMatches reports whether an evaluated rule result satisfies the rule, decision and
+// reason of a condition
+func resultMatches(condition config.PolicyCondition, result shared.LineValidationResult) bool {
+	if !result.WasEvaluated || result.RuleName != condition.Rule {
+		return false
+	}
+	if condition.Decision != "" && string(result.Decision) != condition.Decision {
+		return false
+	}
+	if condition.ReasonContains != "" && !strings.Contains(strings.ToLower(result.Reason), strings.ToLower(condition.ReasonContains)) {
+		return false
+	}
+	if condition.ReasonMatches != "" {
+		// Patterns are validated when the configuration is loaded
+		if matched, err := regexp.MatchString(condition.ReasonMatches, result.Reason); err != nil || !matched {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,176 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func decisionPolicyTestConfig() *config.GlobalRuleConfig {
+	return &config.GlobalRuleConfig{
+		Enabled: true,
+		Files:   []config.FileRuleConfig{},
+		DecisionPolicies: []config.DecisionPolicy{
+			{
+				Name: "warehouse_increase_with_prod_consumer",
+				When: []config.PolicyCondition{
+					{Rule: "warehouse_rule", Decision: "manual_review", ReasonContains: "size increase"},
+					{Rule: "dataproduct_consumer_rule", Path: "dataproducts/**/prod/product.{yaml,yml}", ReasonContains: "consumer access changes"},
+				},
+				Action:    "manual_review",
+				Reviewers: []string{"@security-team"},
+				Reason:    "warehouse increase combined with production consumer access",
+			},
+			{
+				Name:   "service_account_with_masking",
+				When:   []config.PolicyCondition{{Rule: "service_account_rule"}, {Rule: "masking_policy_rule"}},
+				Action: "block",
+			},
+		},
+	}
+}
+
+func ruleResult(ruleName string, decision shared.DecisionType, reason string) shared.LineValidationResult {
+	return shared.LineValidationResult{RuleName: ruleName, Decision: decision, Reason: reason, WasEvaluated: true}
+}
+
+func fileValidation(filePath string, results ...shared.LineValidationResult) *shared.FileValidationSummary {
+	decision := shared.Approve
+	for _, r := range results {
+		if r.Decision == shared.ManualReview {
+			decision = shared.ManualReview
+		}
+	}
+	return &shared.FileValidationSummary{FilePath: filePath, RuleResults: results, FileDecision: decision}
+}
+
+func TestApplyDecisionPolicies_EscalatesCombination(t *testing.T) {
+	manager := NewSectionRuleManager(decisionPolicyTestConfig(), nil)
+	base := shared.Decision{Type: shared.ManualReview, Reason: "Warehouse changes require manual review", Details: "Files requiring manual review: a"}
+
+	validations := map[string]*shared.FileValidationSummary{
+		"dataproducts/source/a/preprod/product.yaml": fileValidation("dataproducts/source/a/preprod/product.yaml",
+			ruleResult("warehouse_rule", shared.ManualReview, "Warehouse size increase detected: XSMALL -> LARGE")),
+		"dataproducts/source/a/prod/product.yaml": fileValidation("dataproducts/source/a/prod/product.yaml",
+			ruleResult("dataproduct_consumer_rule", shared.Approve, "Consumer access changes in prod environment - data product owner approval sufficient")),
+	}
+
+	decision := manager.applyDecisionPolicies(validations, base)
+	assert.Equal(t, shared.ManualReview, decision.Type)
+	assert.Equal(t, "Escalated by decision policy 'warehouse_increase_with_prod_consumer': warehouse increase combined with production consumer access (reviewers: @security-team)", decision.Reason)
+	assert.Equal(t, "⚠️ Manual review required", decision.Summary)
+	assert.Equal(t, "Warehouse changes require manual review. Files requiring manual review: a", decision.Details)
+}
+
+func TestApplyDecisionPolicies_NoMatchKeepsDecision(t *testing.T) {
+	manager := NewSectionRuleManager(decisionPolicyTestConfig(), nil)
+	base := shared.Decision{Type: shared.Approve, Reason: "All files passed validation"}
+
+	tests := []struct {
+		name        string
+		validations map[string]*shared.FileValidationSummary
+	}{
+		{
+			name: "consumer change outside prod",
+			validations: map[string]*shared.FileValidationSummary{
+				"dataproducts/source/a/dev/product.yaml": fileValidation("dataproducts/source/a/dev/product.yaml",
+					ruleResult("warehouse_rule", shared.ManualReview, "Warehouse size increase detected"),
+					ruleResult("dataproduct_consumer_rule", shared.Approve, "Consumer access changes in dev environment")),
+			},
+		},
+		{
+			name: "warehouse decrease",
+			validations: map[string]*shared.FileValidationSummary{
+				"dataproducts/source/a/prod/product.yaml": fileValidation("dataproducts/source/a/prod/product.yaml",
+					ruleResult("warehouse_rule", shared.ManualReview, "Warehouse size decrease detected"),
+					ruleResult("dataproduct_consumer_rule", shared.Approve, "Consumer access changes in prod environment")),
+			},
+		},
+		{
+			name: "skipped rule results do not count",
+			validations: map[string]*shared.FileValidationSummary{
+				"serviceaccounts/prod/app.yaml": fileValidation("serviceaccounts/prod/app.yaml",
+					shared.LineValidationResult{RuleName: "service_account_rule", Decision: shared.Approve}),
+				"dataproducts/source/a/prod/pii_masking.yaml": fileValidation("dataproducts/source/a/prod/pii_masking.yaml",
+					ruleResult("masking_policy_rule", shared.Approve, "ok")),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, base, manager.applyDecisionPolicies(tt.validations, base))
+		})
+	}
+}
+
+func TestApplyDecisionPolicies_BlockAndMultipleMatches(t *testing.T) {
+	manager := NewSectionRuleManager(decisionPolicyTestConfig(), nil)
+	base := shared.Decision{Type: shared.Approve, Reason: "All files passed validation"}
+
+	validations := map[string]*shared.FileValidationSummary{
+		"serviceaccounts/prod/app.yaml": fileValidation("serviceaccounts/prod/app.yaml",
+			ruleResult("service_account_rule", shared.Approve, "Astro service account")),
+		"dataproducts/source/a/prod/pii_masking.yaml": fileValidation("dataproducts/source/a/prod/pii_masking.yaml",
+			ruleResult("masking_policy_rule", shared.Approve, "ok")),
+		"dataproducts/source/a/prod/product.yaml": fileValidation("dataproducts/source/a/prod/product.yaml",
+			ruleResult("warehouse_rule", shared.ManualReview, "Warehouse size increase detected"),
+			ruleResult("dataproduct_consumer_rule", shared.Approve, "Consumer access changes in prod environment")),
+	}
+
+	decision := manager.applyDecisionPolicies(validations, base)
+	assert.Equal(t, shared.ManualReview, decision.Type, "policies escalate approvals")
+	assert.Equal(t, "🚫 Blocked by decision policy", decision.Summary)
+	assert.Contains(t, decision.Reason, "Escalated by decision policy 'warehouse_increase_with_prod_consumer'")
+	assert.Contains(t, decision.Reason, "; Blocked by decision policy 'service_account_with_masking'")
+	assert.Equal(t, "All files passed validation", decision.Details)
+}
+
+func TestDecisionPolicy_EvaluateAll(t *testing.T) {
+	cfg := deletionPolicyTestConfig()
+	cfg.DecisionPolicies = []config.DecisionPolicy{{
+		Name:   "bulk_documentation_removal",
+		When:   []config.PolicyCondition{{Rule: DeletionPolicyRuleName, Decision: "approve", Path: "docs/**"}},
+		Action: "manual_review",
+		Reason: "documentation removals are reviewed by tech writers",
+	}}
+	manager := NewSectionRuleManager(cfg, nil)
+
+	mrCtx := &shared.MRContext{
+		ProjectID: 123,
+		MRIID:     456,
+		Changes: []gitlab.FileChange{
+			{OldPath: "docs/old.md", NewPath: "docs/old.md", DeletedFile: true},
+		},
+		MRInfo: &gitlab.MRInfo{Title: "Remove docs", Author: "developer", SourceBranch: "feature"},
+	}
+
+	result := manager.EvaluateAll(mrCtx)
+	assert.Equal(t, shared.ManualReview, result.FinalDecision.Type)
+	assert.Contains(t, result.FinalDecision.Reason, "bulk_documentation_removal")
+	assert.Equal(t, 1, result.ApprovedFiles, "file decisions are unchanged")
+}
+
+func TestValidateRuleConfig_DecisionPolicies(t *testing.T) {
+	base := func(policies ...config.DecisionPolicy) *config.GlobalRuleConfig {
+		return &config.GlobalRuleConfig{
+			Files: []config.FileRuleConfig{{
+				Name: "docs", Path: "**/", Filename: "*.md", ParserType: "yaml",
+				Sections: []config.SectionDefinition{{Name: "full", YAMLPath: ".", AutoApprove: true}},
+			}},
+			DecisionPolicies: policies,
+		}
+	}
+	when := []config.PolicyCondition{{Rule: "warehouse_rule", Decision: "manual_review"}}
+
+	assert.NoError(t, config.ValidateRuleConfig(base(config.DecisionPolicy{Name: "a", When: when, Action: "manual_review"})))
+	assert.NoError(t, config.ValidateRuleConfig(base(config.DecisionPolicy{Name: "a", When: when, Action: "block"})))
+	assert.Error(t, config.ValidateRuleConfig(base(config.DecisionPolicy{Name: "a", When: when, Action: "auto_approve"})), "policies only escalate")
+	assert.Error(t, config.ValidateRuleConfig(base(config.DecisionPolicy{When: when, Action: "block"})))
+	assert.Error(t, config.ValidateRuleConfig(base(config.DecisionPolicy{Name: "a", Action: "block"})))
+	assert.Error(t, config.ValidateRuleConfig(base(config.DecisionPolicy{Name: "a", When: []config.PolicyCondition{{Decision: "approve"}}, Action: "block"})))
+	assert.Error(t, config.ValidateRuleConfig(base(config.DecisionPolicy{Name: "a", When: []config.PolicyCondition{{Rule: "x", Decision: "reject"}}, Action: "block"})))
+}
@@ -0,0 +1,188 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/redhat-data-and-ai/naysayer/internal/utils"
+	"go.uber.org/zap"
+)
+
+// DeletionPolicyRuleName is the rule name reported for deletion policy decisions
+const DeletionPolicyRuleName = "deletion_policy"
+
+// DeletionBlockedReason starts the reason of deletions blocked by a deletion policy
+const DeletionBlockedReason = "Deletion blocked by policy"
+
+// getDeletedFilePaths returns paths removed by the MR (deleted files and the old side of renames)
+func (srm *SectionRuleManager) getDeletedFilePaths(changes []gitlab.FileChange) map[string]bool {
+	deleted := make(map[string]bool)
+	for _, change := range changes {
+		if change.DeletedFile {
+			path := change.OldPath
+			if path == "" {
+				path = change.NewPath
+			}
+			deleted[path] = true
+		} else if change.RenamedFile && change.OldPath != "" && change.OldPath != change.NewPath {
+			deleted[change.OldPath] = true
+		}
+	}
+	return deleted
+}
+
+// findDeletionPolicy returns the first deletion policy matching the file path
+func (srm *SectionRuleManager) findDeletionPolicy(filePath string) *config.DeletionPolicy {
+	for i := range srm.config.DeletionPolicies {
+		policy := &srm.config.DeletionPolicies[i]
+		if shared.MatchesPattern(filePath, policy.Path+policy.Filename) {
+			return policy
+		}
+	}
+	return nil
+}
+
+// validateDeletion applies the configured deletion policy to a removed file.
+// Files without a matching policy require manual review.
+func (srm *SectionRuleManager) validateDeletion(filePath string) *shared.FileValidationSummary {
+	policy := srm.findDeletionPolicy(filePath)
+
+	decision := shared.ManualReview
+	var reason string
+
+	if policy == nil {
+		reason = "File deletion requires manual review (no deletion policy configured for this file type)"
+	} else {
+		switch policy.Action {
+		case utils.DefaultActionAutoApprove:
+			decision = shared.Approve
+			reason = fmt.Sprintf("Deletion allowed by policy '%s'", policy.Name)
+		case utils.DeletionActionBlock:
+			reason = fmt.Sprintf("%s '%s' - this file must not be deleted without owner sign-off", DeletionBlockedReason, policy.Name)
+		default:
+			reason = fmt.Sprintf("Deletion requires manual review per policy '%s'", policy.Name)
+		}
+		if policy.Reason != "" {
+			reason += ": " + policy.Reason
+		}
+		if decision == shared.ManualReview && len(policy.Reviewers) > 0 {
+			reason += fmt.Sprintf(" (reviewers: %s)", strings.Join(policy.Reviewers, ", "))
+		}
+	}
+
+	logging.Info("Deletion policy decision",
+		zap.String("file_path", filePath),
+		zap.String("decision", string(decision)),
+		zap.String("reason", reason))
+
+	var reviewers []string
+	if policy != nil && decision == shared.ManualReview {
+		for _, reviewer := range policy.Reviewers {
+			reviewers = append(reviewers, strings.TrimPrefix(reviewer, "@"))
+		}
+	}
+
+	summary := &shared.FileValidationSummary{
+		FilePath:       filePath,
+		TotalLines:     0,
+		CoveredLines:   []shared.LineRange{},
+		UncoveredLines: []shared.LineRange{},
+		RuleResults: []shared.LineValidationResult{{
+			RuleName:     DeletionPolicyRuleName,
+			LineRanges:   []shared.LineRange{},
+			Decision:     decision,
+			Reason:       reason,
+			WasEvaluated: true,
+		}},
+		FileDecision: decision,
+		Reviewers:    reviewers,
+	}
+
+	// Rules configured for the file may escalate the deletion (e.g. a group still referenced)
+	for _, rule := range srm.deletionAwareRules(filePath) {
+		ruleDecision, ruleReason := rule.ValidateDeletion(filePath)
+		logging.Info("Deletion check",
+			zap.String("file_path", filePath),
+			zap.String("rule", rule.Name()),
+			zap.String("decision", string(ruleDecision)),
+			zap.String("reason", ruleReason))
+		summary.RuleResults = append(summary.RuleResults, shared.LineValidationResult{
+			RuleName:     rule.Name(),
+			LineRanges:   []shared.LineRange{},
+			Decision:     ruleDecision,
+			Reason:       ruleReason,
+			WasEvaluated: true,
+		})
+		if ruleDecision == shared.ManualReview {
+			summary.FileDecision = shared.ManualReview
+		}
+	}
+	return summary
+}
+
+// deletionAwareRules returns the enabled rules of the file configurations matching filePath
+// that check deletions, each once
+func (srm *SectionRuleManager) deletionAwareRules(filePath string) []shared.DeletionAwareRule {
+	var rules []shared.DeletionAwareRule
+	seen := make(map[string]bool)
+	for _, fileConfig := range srm.config.Files {
+		if !fileConfig.Enabled || !shared.MatchesPattern(filePath, fileConfig.Path+fileConfig.Filename) {
+			continue
+		}
+		for _, section := range fileConfig.Sections {
+			for _, rule := range srm.getEnabledRulesForSection(section.RuleConfigs) {
+				deletionRule, ok := rule.(shared.DeletionAwareRule)
+				if !ok || seen[rule.Name()] {
+					continue
+				}
+				seen[rule.Name()] = true
+				rules = append(rules, deletionRule)
+			}
+		}
+	}
+	return rules
+}
+
+// isBlockedDeletion reports whether a rule result is a deletion blocked by a deletion policy
+func isBlockedDeletion(result shared.LineValidationResult) bool {
+	return result.RuleName == DeletionPolicyRuleName && strings.HasPrefix(result.Reason, DeletionBlockedReason)
+}
+
+// applyDeletionBlocks turns the decision into a blocked manual review when a deletion policy
+// blocks the deletion of a file. Blocked deletions are never approved by a quorum, an approval
+// policy or a project setting; only a human can merge the MR.
+func (srm *SectionRuleManager) applyDeletionBlocks(fileValidations map[string]*shared.FileValidationSummary, decision shared.Decision) shared.Decision {
+	var blocked []string
+	for filePath, fileValidation := range fileValidations {
+		if fileValidation == nil {
+			continue
+		}
+		for _, result := range fileValidation.RuleResults {
+			if isBlockedDeletion(result) {
+				blocked = append(blocked, filePath)
+				break
+			}
+		}
+	}
+	if len(blocked) == 0 {
+		return decision
+	}
+	sort.Strings(blocked)
+
+	logging.Info("MR blocked by deletion policies", zap.Strings("files", blocked))
+	details := decision.Reason
+	if decision.Details != "" {
+		details += ". " + decision.Details
+	}
+	return shared.Decision{
+		Type:    shared.ManualReview,
+		Reason:  fmt.Sprintf("%s: %s", DeletionBlockedReason, strings.Join(blocked, ", ")),
+		Summary: "🚫 Blocked by deletion policy",
+		Details: details,
+	}
+}
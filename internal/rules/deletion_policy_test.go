@@ -0,0 +1,227 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func deletionPolicyTestConfig() *config.GlobalRuleConfig {
+	return &config.GlobalRuleConfig{
+		Enabled: true,
+		Files:   []config.FileRuleConfig{},
+		DeletionPolicies: []config.DeletionPolicy{
+			{
+				Name:      "masking_policies",
+				Path:      "dataproducts/**/",
+				Filename:  "*masking.{yaml,yml}",
+				Action:    "manual_review",
+				Reviewers: []string{"@data-governance"},
+				Reason:    "removes data protection",
+			},
+			{
+				Name:     "product_configs",
+				Path:     "dataproducts/**/",
+				Filename: "product.{yaml,yml}",
+				Action:   "block",
+			},
+			{
+				Name:     "documentation",
+				Path:     "**/",
+				Filename: "*.md",
+				Action:   "auto_approve",
+			},
+		},
+	}
+}
+
+func TestDeletionPolicy_ActionsPerFileKind(t *testing.T) {
+	manager := NewSectionRuleManager(deletionPolicyTestConfig(), nil)
+
+	tests := []struct {
+		name              string
+		filePath          string
+		expectedDecision  shared.DecisionType
+		reasonContains    []string
+		expectedReviewers []string
+	}{
+		{
+			name:             "documentation deletion auto-approved",
+			filePath:         "dataproducts/source/x/README.md",
+			expectedDecision: shared.Approve,
+			reasonContains:   []string{"documentation"},
+		},
+		{
+			name:              "masking deletion requires review with reviewers",
+			filePath:          "dataproducts/source/x/prod/pii_masking.yaml",
+			expectedDecision:  shared.ManualReview,
+			reasonContains:    []string{"masking_policies", "removes data protection", "@data-governance"},
+			expectedReviewers: []string{"data-governance"},
+		},
+		{
+			name:             "product deletion blocked",
+			filePath:         "dataproducts/source/x/prod/product.yaml",
+			expectedDecision: shared.ManualReview,
+			reasonContains:   []string{"blocked", "product_configs"},
+		},
+		{
+			name:             "no matching policy defaults to manual review",
+			filePath:         "serviceaccounts/prod/a_appuser.yaml",
+			expectedDecision: shared.ManualReview,
+			reasonContains:   []string{"no deletion policy"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summary := manager.validateDeletion(tt.filePath)
+			assert.Equal(t, tt.expectedDecision, summary.FileDecision)
+			assert.Len(t, summary.RuleResults, 1)
+			assert.Equal(t, DeletionPolicyRuleName, summary.RuleResults[0].RuleName)
+			for _, fragment := range tt.reasonContains {
+				assert.Contains(t, summary.RuleResults[0].Reason, fragment)
+			}
+			assert.Equal(t, tt.expectedReviewers, summary.Reviewers)
+		})
+	}
+}
+
+func TestDeletionPolicy_EvaluateAll(t *testing.T) {
+	manager := NewSectionRuleManager(deletionPolicyTestConfig(), nil)
+
+	mrCtx := &shared.MRContext{
+		ProjectID: 123,
+		MRIID:     456,
+		Changes: []gitlab.FileChange{
+			{OldPath: "docs/old.md", NewPath: "docs/old.md", DeletedFile: true},
+		},
+		MRInfo: &gitlab.MRInfo{Title: "Remove docs", Author: "developer", SourceBranch: "feature"},
+	}
+
+	result := manager.EvaluateAll(mrCtx)
+	assert.Equal(t, shared.Approve, result.FinalDecision.Type)
+	assert.Equal(t, 1, result.ApprovedFiles)
+
+	// Renaming a masking file removes the old path, which is subject to the masking deletion policy
+	mrCtx.Changes = []gitlab.FileChange{
+		{OldPath: "dataproducts/source/x/prod/pii_masking.yaml", NewPath: "dataproducts/source/x/prod/old_masking.txt", RenamedFile: true},
+	}
+	deleted := manager.getDeletedFilePaths(mrCtx.Changes)
+	assert.True(t, deleted["dataproducts/source/x/prod/pii_masking.yaml"])
+	assert.False(t, deleted["dataproducts/source/x/prod/old_masking.txt"])
+
+	result = manager.EvaluateAll(mrCtx)
+	assert.Equal(t, shared.ManualReview, result.FinalDecision.Type)
+	assert.Equal(t, shared.ManualReview, result.FileValidations["dataproducts/source/x/prod/pii_masking.yaml"].FileDecision)
+}
+
+func TestDeletionPolicy_BlockIsNotApprovable(t *testing.T) {
+	cfg := deletionPolicyTestConfig()
+	cfg.Files = []config.FileRuleConfig{{
+		Name: "docs", Path: "**/", Filename: "*.md", ParserType: "yaml", Enabled: true,
+		Sections: []config.SectionDefinition{{Name: "full", YAMLPath: ".", AutoApprove: true}},
+	}}
+	cfg.ApprovalPolicies = []config.ApprovalPolicy{{
+		Name:      "product_removal",
+		When:      []config.PolicyCondition{{Rule: DeletionPolicyRuleName, Decision: "manual_review"}},
+		Approvals: 1,
+	}}
+	cfg.Aggregation = config.AggregationPolicy{Policy: "quorum", QuorumPercent: 10}
+	manager := NewSectionRuleManager(cfg, nil)
+
+	mrCtx := &shared.MRContext{
+		ProjectID: 123,
+		MRIID:     456,
+		Changes: []gitlab.FileChange{
+			{OldPath: "dataproducts/source/x/prod/product.yaml", NewPath: "dataproducts/source/x/prod/product.yaml", DeletedFile: true},
+			{OldPath: "docs/a.md", NewPath: "docs/a.md", DeletedFile: true},
+			{OldPath: "docs/b.md", NewPath: "docs/b.md", DeletedFile: true},
+		},
+		MRInfo: &gitlab.MRInfo{Title: "Remove product", Author: "developer", SourceBranch: "feature"},
+	}
+
+	result := manager.EvaluateAll(mrCtx)
+	assert.Equal(t, shared.ManualReview, result.FinalDecision.Type, "neither the quorum nor the approval policy approves a blocked deletion")
+	assert.Equal(t, "🚫 Blocked by deletion policy", result.FinalDecision.Summary)
+	assert.Equal(t, "Deletion blocked by policy: dataproducts/source/x/prod/product.yaml", result.FinalDecision.Reason)
+	assert.Len(t, result.ApprovalRequirements, 1)
+
+	// A deletion requiring review per policy may still be approved by the approval policy
+	mrCtx.Changes[0] = gitlab.FileChange{OldPath: "dataproducts/source/x/prod/pii_masking.yaml", NewPath: "dataproducts/source/x/prod/pii_masking.yaml", DeletedFile: true}
+	cfg.Aggregation = config.AggregationPolicy{}
+	result = manager.EvaluateAll(mrCtx)
+	assert.Equal(t, shared.Approve, result.FinalDecision.Type)
+	assert.Equal(t, []string{"data-governance"}, result.FileValidations["dataproducts/source/x/prod/pii_masking.yaml"].Reviewers)
+}
+
+// deletionCheckRule escalates deletions of the paths it is given
+type deletionCheckRule struct {
+	MockRule
+	referenced map[string]bool
+	checked    []string
+}
+
+func (r *deletionCheckRule) ValidateDeletion(filePath string) (shared.DecisionType, string) {
+	r.checked = append(r.checked, filePath)
+	if r.referenced[filePath] {
+		return shared.ManualReview, "still referenced"
+	}
+	return shared.Approve, "not referenced"
+}
+
+func TestDeletionPolicy_DeletionAwareRules(t *testing.T) {
+	cfg := deletionPolicyTestConfig()
+	cfg.Files = []config.FileRuleConfig{{
+		Name: "groups", Path: "dataproducts/**/groups/", Filename: "*.yaml", ParserType: "yaml", Enabled: true,
+		Sections: []config.SectionDefinition{
+			{Name: "full", YAMLPath: ".", RuleConfigs: []config.RuleConfig{{Name: "group_check", Enabled: true}, {Name: "other", Enabled: true}}},
+			{Name: "again", YAMLPath: "group_name", RuleConfigs: []config.RuleConfig{{Name: "group_check", Enabled: true}}},
+		},
+	}}
+	cfg.DeletionPolicies = append(cfg.DeletionPolicies, config.DeletionPolicy{
+		Name: "groups", Path: "dataproducts/**/groups/", Filename: "*.yaml", Action: "auto_approve",
+	})
+
+	manager := NewSectionRuleManager(cfg, nil)
+	rule := &deletionCheckRule{
+		MockRule:   MockRule{name: "group_check"},
+		referenced: map[string]bool{"dataproducts/source/x/groups/used.yaml": true},
+	}
+	manager.AddRule(rule)
+	manager.AddRule(&MockRule{name: "other"})
+
+	summary := manager.validateDeletion("dataproducts/source/x/groups/unused.yaml")
+	assert.Equal(t, shared.Approve, summary.FileDecision)
+	assert.Len(t, summary.RuleResults, 2)
+
+	summary = manager.validateDeletion("dataproducts/source/x/groups/used.yaml")
+	assert.Equal(t, shared.ManualReview, summary.FileDecision)
+	assert.Equal(t, "group_check", summary.RuleResults[1].RuleName)
+	assert.Equal(t, "still referenced", summary.RuleResults[1].Reason)
+	assert.Len(t, rule.checked, 2, "rules are asked once per deleted file")
+
+	// Other file types are decided by their deletion policy alone
+	summary = manager.validateDeletion("dataproducts/source/x/README.md")
+	assert.Len(t, summary.RuleResults, 1)
+	assert.Len(t, rule.checked, 2)
+}
+
+func TestValidateRuleConfig_DeletionPolicies(t *testing.T) {
+	base := func(policies ...config.DeletionPolicy) *config.GlobalRuleConfig {
+		return &config.GlobalRuleConfig{
+			Files: []config.FileRuleConfig{{
+				Name: "docs", Path: "**/", Filename: "*.md", ParserType: "yaml",
+				Sections: []config.SectionDefinition{{Name: "full", YAMLPath: ".", AutoApprove: true}},
+			}},
+			DeletionPolicies: policies,
+		}
+	}
+
+	assert.NoError(t, config.ValidateRuleConfig(base(config.DeletionPolicy{Name: "a", Path: "**/", Filename: "*.md", Action: "block"})))
+	assert.Error(t, config.ValidateRuleConfig(base(config.DeletionPolicy{Name: "a", Path: "**/", Filename: "*.md", Action: "delete"})))
+	assert.Error(t, config.ValidateRuleConfig(base(config.DeletionPolicy{Path: "**/", Filename: "*.md", Action: "block"})))
+	assert.Error(t, config.ValidateRuleConfig(base(config.DeletionPolicy{Name: "a", Filename: "*.md", Action: "block"})))
+}
@@ -0,0 +1,45 @@
+package rules
+
+import "sync"
+
+// defaultEvaluationWorkers bounds the files of one MR validated concurrently when rules.yaml
+// sets no evaluation_workers
+const defaultEvaluationWorkers = 8
+
+// evaluationWorkers returns the number of workers validating files of an MR changing files files
+func (srm *SectionRuleManager) evaluationWorkers(files int) int {
+	workers := defaultEvaluationWorkers
+	if srm.config.EvaluationWorkers > 0 {
+		workers = srm.config.EvaluationWorkers
+	}
+	return max(1, min(workers, files))
+}
+
+// forEachFile calls validate for every index below files on up to workers goroutines and
+// returns once all calls returned. With one worker, files are validated in order on the
+// calling goroutine.
+func forEachFile(files, workers int, validate func(i int)) {
+	if workers <= 1 {
+		for i := 0; i < files; i++ {
+			validate(i)
+		}
+		return
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				validate(i)
+			}
+		}()
+	}
+	for i := 0; i < files; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
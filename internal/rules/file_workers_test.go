@@ -0,0 +1,154 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+)
+
+// slowFetchClient serves source-branch files after a short delay and records how many
+// fetches overlapped
+type slowFetchClient struct {
+	gitlab.GitLabClient
+
+	active    atomic.Int32
+	maxActive atomic.Int32
+}
+
+func (c *slowFetchClient) GetMRDetails(ctx context.Context, projectID, mrIID int) (*gitlab.MRDetails, error) {
+	return &gitlab.MRDetails{IID: mrIID, ProjectID: projectID, SourceProjectID: projectID}, nil
+}
+
+func (c *slowFetchClient) FetchFileContent(ctx context.Context, projectID int, filePath, ref string) (*gitlab.FileContent, error) {
+	active := c.active.Add(1)
+	defer c.active.Add(-1)
+	for {
+		seen := c.maxActive.Load()
+		if active <= seen || c.maxActive.CompareAndSwap(seen, active) {
+			break
+		}
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	content := "name: orders\nowner: data-team\n"
+	if strings.Contains(filePath, "review") {
+		content = "name: orders\nowner: needs-review\n"
+	}
+	return &gitlab.FileContent{FilePath: filePath, Content: content}, nil
+}
+
+// contentRule requires manual review of files whose content asks for it
+type contentRule struct {
+	findingRule
+}
+
+func (r *contentRule) ValidateLines(filePath string, fileContent string, lineRanges []shared.LineRange) (shared.DecisionType, string) {
+	if strings.Contains(fileContent, "needs-review") {
+		return shared.ManualReview, "owner needs review in " + filePath
+	}
+	return shared.Approve, "owner valid"
+}
+
+func fileWorkersTestManager(workers int, client gitlab.GitLabClient) *SectionRuleManager {
+	cfg := &config.GlobalRuleConfig{
+		Enabled:           true,
+		EvaluationWorkers: workers,
+		Files: []config.FileRuleConfig{{
+			Name: "products", Path: "dataproducts/**/", Filename: "product.yaml", ParserType: "yaml", Enabled: true,
+			Sections: []config.SectionDefinition{
+				{Name: "name", YAMLPath: "name", RuleConfigs: []config.RuleConfig{{Name: "owner_rule", Enabled: true}}},
+				{Name: "owner", YAMLPath: "owner", RuleConfigs: []config.RuleConfig{{Name: "owner_rule", Enabled: true}}},
+			},
+		}},
+	}
+	manager := NewSectionRuleManager(cfg, client)
+	manager.AddRule(&contentRule{findingRule: findingRule{name: "owner_rule"}})
+	return manager
+}
+
+func fileWorkersTestMR(files int) *shared.MRContext {
+	mrCtx := &shared.MRContext{
+		ProjectID: 1,
+		MRIID:     2,
+		MRInfo:    &gitlab.MRInfo{Author: "alice", SourceBranch: "feature", TargetBranch: "main"},
+	}
+	for i := 0; i < files; i++ {
+		path := fmt.Sprintf("dataproducts/source/product%03d/dev/product.yaml", i)
+		if i%7 == 3 {
+			path = fmt.Sprintf("dataproducts/source/review%03d/dev/product.yaml", i)
+		}
+		mrCtx.Changes = append(mrCtx.Changes, gitlab.FileChange{NewPath: path, OldPath: path, Diff: "@@ -1,2 +1,2 @@\n name: orders\n-owner: old\n+owner: new"})
+	}
+	return mrCtx
+}
+
+func TestForEachFile_CallsEveryIndexOnce(t *testing.T) {
+	for _, workers := range []int{1, 3, 16} {
+		var mu sync.Mutex
+		calls := make(map[int]int)
+		forEachFile(10, workers, func(i int) {
+			mu.Lock()
+			defer mu.Unlock()
+			calls[i]++
+		})
+		assert.Len(t, calls, 10, "workers=%d", workers)
+		for i := 0; i < 10; i++ {
+			assert.Equal(t, 1, calls[i], "workers=%d index=%d", workers, i)
+		}
+	}
+
+	var order []int
+	forEachFile(4, 1, func(i int) { order = append(order, i) })
+	assert.Equal(t, []int{0, 1, 2, 3}, order, "a single worker validates files in order")
+}
+
+func TestEvaluationWorkers(t *testing.T) {
+	manager := &SectionRuleManager{config: &config.GlobalRuleConfig{}}
+	assert.Equal(t, defaultEvaluationWorkers, manager.evaluationWorkers(100))
+	assert.Equal(t, 3, manager.evaluationWorkers(3), "no more workers than files")
+	assert.Equal(t, 1, manager.evaluationWorkers(0))
+
+	manager.config.EvaluationWorkers = 1
+	assert.Equal(t, 1, manager.evaluationWorkers(100))
+	manager.config.EvaluationWorkers = 32
+	assert.Equal(t, 32, manager.evaluationWorkers(100))
+}
+
+func TestEvaluateAll_ParallelMatchesSequential(t *testing.T) {
+	sequentialClient := &slowFetchClient{}
+	sequential := fileWorkersTestManager(1, sequentialClient).EvaluateAll(fileWorkersTestMR(40))
+	assert.Equal(t, int32(1), sequentialClient.maxActive.Load())
+
+	parallelClient := &slowFetchClient{}
+	for run := 0; run < 3; run++ {
+		parallel := fileWorkersTestManager(8, parallelClient).EvaluateAll(fileWorkersTestMR(40))
+		assert.Equal(t, sequential.FinalDecision, parallel.FinalDecision)
+		assert.Equal(t, sequential.FileValidations, parallel.FileValidations)
+		assert.Equal(t, sequential.ReviewFiles, parallel.ReviewFiles)
+	}
+	assert.Greater(t, parallelClient.maxActive.Load(), int32(1), "files are fetched concurrently")
+	assert.LessOrEqual(t, parallelClient.maxActive.Load(), int32(8), "at most evaluation_workers files at a time")
+
+	assert.Equal(t, shared.ManualReview, sequential.FinalDecision.Type)
+	assert.Equal(t, 6, sequential.ReviewFiles)
+	assert.True(t, strings.HasPrefix(sequential.FinalDecision.Details,
+		"Files requiring manual review: dataproducts/source/review003/dev/product.yaml, dataproducts/source/review010/dev/product.yaml"),
+		"files are listed in path order: %s", sequential.FinalDecision.Details)
+}
+
+func TestValidateRuleConfig_EvaluationWorkers(t *testing.T) {
+	cfg := fileWorkersTestManager(4, nil).config
+	assert.NoError(t, config.ValidateRuleConfig(cfg))
+	cfg.EvaluationWorkers = -2
+	assert.Error(t, config.ValidateRuleConfig(cfg))
+}
@@ -0,0 +1,306 @@
+package group_file
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/depgraph"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/repoindex"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/common"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/group_membership"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/masking"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+	"gopkg.in/yaml.v3"
+)
+
+// RuleName is the identifier of the group file rule
+const RuleName = "group_file_rule"
+
+// groupFilePattern matches consumer group files of a data product
+const groupFilePattern = "dataproducts/*/*/groups/*.{yaml,yml}"
+
+// consumerGroupKind is the consumer kind referencing a group in masking policies and product.yaml
+const consumerGroupKind = "consumer_group"
+
+// Group name patterns, the same the masking rule resolves consumer groups with
+var (
+	// dataverse-<source|aggregate|platform>-<dataproduct>
+	ProductGroupRegex = regexp.MustCompile(`^dataverse-(source|aggregate|platform)-([a-z0-9]+)$`)
+
+	// dataverse-consumer-<dataproduct>-<suffix>
+	ConsumerGroupRegex = regexp.MustCompile(`^dataverse-consumer-([a-z0-9]+)-([a-z0-9][a-z0-9-]*)$`)
+
+	// Member kinds under members:, e.g. users, serviceaccounts
+	memberKindRegex = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+)
+
+// Client is the subset of the GitLab client needed to find references to deleted groups
+type Client interface {
+	FetchFileContent(ctx context.Context, projectID int, filePath, ref string) (*gitlab.FileContent, error)
+	GetMRDetails(ctx context.Context, projectID, mrIID int) (*gitlab.MRDetails, error)
+}
+
+// Rule validates dataproducts/<type>/<dataproduct>/groups/*.yaml files: the group name
+// follows the naming patterns of the data product, membership lists are well-formed, and
+// deleted groups are not referenced by masking policies or product.yaml consumers
+type Rule struct {
+	*common.BaseRule
+	*common.ValidationHelper
+	client Client
+}
+
+// NewRule creates a new group file rule instance
+func NewRule(client Client) *Rule {
+	return &Rule{
+		BaseRule:         common.NewBaseRule(RuleName, "Validates group names and membership lists of groups/*.yaml files, requires manual review when a referenced group is deleted"),
+		ValidationHelper: common.NewValidationHelper(),
+		client:           client,
+	}
+}
+
+// IsGroupFile checks if the path is a consumer group file of a data product
+func IsGroupFile(filePath string) bool {
+	return shared.MatchesPattern(filePath, groupFilePattern)
+}
+
+// GetCoveredLines returns line ranges this rule covers
+func (r *Rule) GetCoveredLines(filePath string, fileContent string) []shared.LineRange {
+	if !IsGroupFile(filePath) || strings.TrimSpace(fileContent) == "" {
+		return []shared.LineRange{}
+	}
+	return r.GetFullFileCoverage(filePath, fileContent)
+}
+
+// ValidateLines validates the name and membership lists of a group file
+func (r *Rule) ValidateLines(filePath string, fileContent string, lineRanges []shared.LineRange) (shared.DecisionType, string) {
+	if !IsGroupFile(filePath) {
+		return r.CreateApprovalResult("Not a group file - rule does not apply")
+	}
+
+	var group group_membership.GroupYAML
+	if err := yaml.Unmarshal([]byte(fileContent), &group); err != nil {
+		return r.CreateManualReviewResult(fmt.Sprintf("Failed to parse group YAML: %v", err))
+	}
+
+	if problems := Validate(&group, filePath); len(problems) > 0 {
+		return r.CreateManualReviewResult("Group file validation failed: " + strings.Join(problems, "; "))
+	}
+	return r.CreateApprovalResult(fmt.Sprintf("Group '%s' follows naming conventions with well-formed membership lists", group.GroupName))
+}
+
+// Validate checks a group against its path dataproducts/<type>/<dataproduct>/groups/<name>.yaml
+// and returns the problems found
+func Validate(group *group_membership.GroupYAML, filePath string) []string {
+	var problems []string
+	if group.GroupName == "" {
+		return []string{"group_name: required field is missing"}
+	}
+
+	groupsDir := path.Dir(filePath)
+	dataProduct := path.Base(path.Dir(groupsDir))
+	productType := path.Base(path.Dir(path.Dir(groupsDir)))
+	fileName := strings.TrimSuffix(strings.TrimSuffix(path.Base(filePath), ".yaml"), ".yml")
+
+	if group.GroupName != fileName {
+		problems = append(problems, fmt.Sprintf("group_name: '%s' does not match file name '%s'", group.GroupName, fileName))
+	}
+	if m := ProductGroupRegex.FindStringSubmatch(group.GroupName); m != nil {
+		if m[1] != productType || m[2] != dataProduct {
+			problems = append(problems, fmt.Sprintf("group_name: '%s' must be dataverse-%s-%s for this data product", group.GroupName, productType, dataProduct))
+		}
+	} else if m := ConsumerGroupRegex.FindStringSubmatch(group.GroupName); m != nil {
+		if m[1] != dataProduct {
+			problems = append(problems, fmt.Sprintf("group_name: '%s' must start with dataverse-consumer-%s- for this data product", group.GroupName, dataProduct))
+		}
+	} else {
+		problems = append(problems, fmt.Sprintf("group_name: '%s' must follow dataverse-<type>-<dataproduct> or dataverse-consumer-<dataproduct>-<suffix>", group.GroupName))
+	}
+
+	problems = append(problems, validateNames("approvers", group.Approvers)...)
+	kinds := make([]string, 0, len(group.Members))
+	for kind := range group.Members {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	for _, kind := range kinds {
+		if !memberKindRegex.MatchString(kind) {
+			problems = append(problems, fmt.Sprintf("members: '%s' is not a valid member kind", kind))
+			continue
+		}
+		problems = append(problems, validateNames("members."+kind, group.Members[kind])...)
+	}
+	return problems
+}
+
+// validateNames reports empty, whitespace-containing and duplicate entries of a membership list
+func validateNames(field string, names []string) []string {
+	var problems []string
+	seen := make(map[string]bool, len(names))
+	for i, name := range names {
+		switch {
+		case name == "":
+			problems = append(problems, fmt.Sprintf("%s[%d]: empty entry", field, i))
+		case strings.ContainsAny(name, " \t"):
+			problems = append(problems, fmt.Sprintf("%s[%d]: '%s' must not contain whitespace", field, i, name))
+		case seen[name]:
+			problems = append(problems, fmt.Sprintf("%s[%d]: duplicate entry '%s'", field, i, name))
+		}
+		seen[name] = true
+	}
+	return problems
+}
+
+// ValidateDeletion requires manual review when the deleted group is still referenced by a
+// masking policy or product.yaml consumer after the MR
+func (r *Rule) ValidateDeletion(filePath string) (shared.DecisionType, string) {
+	if !IsGroupFile(filePath) {
+		return r.CreateApprovalResult("Not a group file - rule does not apply")
+	}
+	groupName := strings.TrimSuffix(strings.TrimSuffix(path.Base(filePath), ".yaml"), ".yml")
+
+	mrCtx := r.GetMRContext()
+	if r.client == nil || mrCtx == nil {
+		return r.CreateManualReviewResult(fmt.Sprintf("Could not check references to deleted group '%s': MR context not available", groupName))
+	}
+	refs, err := r.references(mrCtx, groupName)
+	if err != nil {
+		return r.CreateManualReviewResult(fmt.Sprintf("Could not check references to deleted group '%s': %v", groupName, err))
+	}
+	if len(refs) > 0 {
+		return r.CreateManualReviewResult(fmt.Sprintf("Group '%s' is deleted but still referenced by: %s - manual review required", groupName, strings.Join(refs, ", ")))
+	}
+	return r.CreateApprovalResult(fmt.Sprintf("Deleted group '%s' is not referenced by masking policies or product.yaml consumers", groupName))
+}
+
+// references returns the masking and product files referencing groupName after the MR:
+// files changed by the MR are read from the source branch, the others from the target branch
+func (r *Rule) references(mrCtx *shared.MRContext, groupName string) ([]string, error) {
+	replaced := make(map[string]bool)
+	var changed []string
+	for _, change := range mrCtx.Changes {
+		if change.OldPath != "" {
+			replaced[change.OldPath] = true
+		}
+		if !change.DeletedFile && isReferencingFile(change.NewPath) {
+			replaced[change.NewPath] = true
+			changed = append(changed, change.NewPath)
+		}
+	}
+
+	paths, err := r.targetPaths(mrCtx.Context(), mrCtx.ProjectID, targetBranch(mrCtx), isReferencingFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var refs []string
+	check := func(projectID int, filePath, ref string) error {
+		content, err := r.client.FetchFileContent(mrCtx.Context(), projectID, filePath, ref)
+		if err != nil {
+			return fmt.Errorf("failed to fetch %s: %w", filePath, err)
+		}
+		if content != nil && referencesGroup(content.Content, groupName) {
+			refs = append(refs, filePath)
+		}
+		return nil
+	}
+	for _, p := range paths {
+		if replaced[p] {
+			continue
+		}
+		if err := check(mrCtx.ProjectID, p, targetBranch(mrCtx)); err != nil {
+			return nil, err
+		}
+	}
+	if len(changed) > 0 {
+		sourceProjectID := r.sourceProjectID(mrCtx)
+		for _, p := range changed {
+			if err := check(sourceProjectID, p, sourceBranch(mrCtx)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	sort.Strings(refs)
+	return refs, nil
+}
+
+// isReferencingFile reports whether a file may reference consumer groups
+func isReferencingFile(filePath string) bool {
+	return masking.IsMaskingFile(filePath) || depgraph.IsProductFile(filePath)
+}
+
+// referencesGroup reports whether YAML content contains a consumer_group entry named
+// groupName. Unparsable content is searched for the name.
+func referencesGroup(content, groupName string) bool {
+	var parsed interface{}
+	if err := yaml.Unmarshal([]byte(content), &parsed); err != nil {
+		return strings.Contains(content, groupName)
+	}
+	return containsGroup(parsed, groupName)
+}
+
+func containsGroup(node interface{}, groupName string) bool {
+	switch v := node.(type) {
+	case map[string]interface{}:
+		if kind, _ := v["kind"].(string); kind == consumerGroupKind {
+			if name, _ := v["name"].(string); name == groupName {
+				return true
+			}
+		}
+		for _, child := range v {
+			if containsGroup(child, groupName) {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, child := range v {
+			if containsGroup(child, groupName) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// targetPaths lists target-branch paths from the shared repository index, or a one-off
+// tree listing when the index is disabled and the client can list trees
+func (r *Rule) targetPaths(ctx context.Context, projectID int, ref string, match func(path string) bool) ([]string, error) {
+	index := repoindex.ForClient(r.client)
+	if index == nil {
+		lister, ok := r.client.(repoindex.TreeLister)
+		if !ok {
+			return nil, fmt.Errorf("repository tree listing not available")
+		}
+		index = repoindex.NewIndex(lister, store.NewMemoryStore())
+	}
+	return index.Paths(ctx, projectID, ref, match)
+}
+
+// sourceProjectID returns the project holding the MR source branch (the fork for fork MRs)
+func (r *Rule) sourceProjectID(mrCtx *shared.MRContext) int {
+	details, err := r.client.GetMRDetails(mrCtx.Context(), mrCtx.ProjectID, mrCtx.MRIID)
+	if err == nil && details != nil && details.SourceProjectID != 0 {
+		return details.SourceProjectID
+	}
+	return mrCtx.ProjectID
+}
+
+func sourceBranch(mrCtx *shared.MRContext) string {
+	if mrCtx.MRInfo != nil {
+		return mrCtx.MRInfo.SourceBranch
+	}
+	return ""
+}
+
+func targetBranch(mrCtx *shared.MRContext) string {
+	if mrCtx.MRInfo != nil && mrCtx.MRInfo.TargetBranch != "" {
+		return mrCtx.MRInfo.TargetBranch
+	}
+	return masking.DefaultTargetBranch
+}
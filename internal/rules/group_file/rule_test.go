@@ -0,0 +1,243 @@
+package group_file
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/group_membership"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+const groupPath = "dataproducts/aggregate/analytics/groups/dataverse-consumer-analytics-team.yaml"
+
+const validGroup = `group_name: dataverse-consumer-analytics-team
+approvers:
+- alice
+members:
+  users:
+  - alice
+  - bob
+  serviceaccounts:
+  - analytics_astro_prod_appuser
+`
+
+const referencingMasking = `kind: MaskingPolicy
+name: analytics_pii_string_policy
+cases:
+- strategy: UNMASKED
+  consumers:
+  - kind: consumer_group
+    name: dataverse-consumer-analytics-team
+`
+
+const referencingProduct = `name: finance
+data_product_db:
+- presentation_schemas:
+  - name: marts
+    consumers:
+    - kind: consumer_group
+      name: dataverse-consumer-analytics-team
+`
+
+// mockClient serves files per ref and lists the target branch tree
+type mockClient struct {
+	files map[string]map[string]string // ref -> path -> content
+}
+
+func (m *mockClient) FetchFileContent(ctx context.Context, projectID int, filePath, ref string) (*gitlab.FileContent, error) {
+	content, ok := m.files[ref][filePath]
+	if !ok {
+		return nil, fmt.Errorf("file not found: %s: %w", filePath, gitlab.ErrNotFound)
+	}
+	return &gitlab.FileContent{FilePath: filePath, Content: content}, nil
+}
+
+func (m *mockClient) GetMRDetails(ctx context.Context, projectID, mrIID int) (*gitlab.MRDetails, error) {
+	return &gitlab.MRDetails{IID: mrIID, SourceProjectID: projectID}, nil
+}
+
+func (m *mockClient) ListRepositoryTree(ctx context.Context, projectID int, ref string) ([]gitlab.TreeEntry, error) {
+	var entries []gitlab.TreeEntry
+	for path := range m.files[ref] {
+		entries = append(entries, gitlab.TreeEntry{Type: "blob", Path: path})
+	}
+	return entries, nil
+}
+
+func newTestRule(client Client, changes ...gitlab.FileChange) *Rule {
+	rule := NewRule(client)
+	rule.SetMRContext(&shared.MRContext{
+		ProjectID: 1,
+		MRIID:     2,
+		Changes:   changes,
+		MRInfo:    &gitlab.MRInfo{SourceBranch: "feature", TargetBranch: "main"},
+	})
+	return rule
+}
+
+func TestIsGroupFile(t *testing.T) {
+	assert.True(t, IsGroupFile(groupPath))
+	assert.True(t, IsGroupFile("dataproducts/source/analytics/groups/dataverse-source-analytics.yml"))
+	assert.False(t, IsGroupFile("dataproducts/source/analytics/prod/groups.yaml"))
+	assert.False(t, IsGroupFile("dataproducts/analytics/groups/dataverse-source-analytics.yaml"))
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		group    group_membership.GroupYAML
+		path     string
+		problems []string
+	}{
+		{
+			name:  "product group",
+			group: group_membership.GroupYAML{GroupName: "dataverse-source-analytics"},
+			path:  "dataproducts/source/analytics/groups/dataverse-source-analytics.yaml",
+		},
+		{
+			name:  "consumer group",
+			group: group_membership.GroupYAML{GroupName: "dataverse-consumer-analytics-team", Approvers: []string{"alice"}},
+			path:  groupPath,
+		},
+		{
+			name:     "missing name",
+			group:    group_membership.GroupYAML{Approvers: []string{"alice"}},
+			path:     groupPath,
+			problems: []string{"group_name: required field is missing"},
+		},
+		{
+			name:  "unknown pattern",
+			group: group_membership.GroupYAML{GroupName: "analytics-team"},
+			path:  "dataproducts/aggregate/analytics/groups/analytics-team.yaml",
+			problems: []string{
+				"group_name: 'analytics-team' must follow dataverse-<type>-<dataproduct> or dataverse-consumer-<dataproduct>-<suffix>",
+			},
+		},
+		{
+			name:  "other data product",
+			group: group_membership.GroupYAML{GroupName: "dataverse-consumer-sales-team"},
+			path:  "dataproducts/aggregate/analytics/groups/dataverse-consumer-sales-team.yaml",
+			problems: []string{
+				"group_name: 'dataverse-consumer-sales-team' must start with dataverse-consumer-analytics- for this data product",
+			},
+		},
+		{
+			name:  "type differs from path",
+			group: group_membership.GroupYAML{GroupName: "dataverse-source-analytics"},
+			path:  "dataproducts/aggregate/analytics/groups/dataverse-source-analytics.yaml",
+			problems: []string{
+				"group_name: 'dataverse-source-analytics' must be dataverse-aggregate-analytics for this data product",
+			},
+		},
+		{
+			name:  "name differs from file name",
+			group: group_membership.GroupYAML{GroupName: "dataverse-consumer-analytics-ops"},
+			path:  groupPath,
+			problems: []string{
+				"group_name: 'dataverse-consumer-analytics-ops' does not match file name 'dataverse-consumer-analytics-team'",
+			},
+		},
+		{
+			name: "malformed membership lists",
+			group: group_membership.GroupYAML{
+				GroupName: "dataverse-consumer-analytics-team",
+				Approvers: []string{"alice", "alice"},
+				Members:   map[string][]string{"users": {"", "bob smith"}, "Service Accounts": {"x"}},
+			},
+			path: groupPath,
+			problems: []string{
+				"approvers[1]: duplicate entry 'alice'",
+				"members: 'Service Accounts' is not a valid member kind",
+				"members.users[0]: empty entry",
+				"members.users[1]: 'bob smith' must not contain whitespace",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.problems, Validate(&tt.group, tt.path))
+		})
+	}
+}
+
+func TestRule_ValidateLines(t *testing.T) {
+	rule := NewRule(nil)
+
+	assert.NotEmpty(t, rule.GetCoveredLines(groupPath, validGroup))
+	decision, reason := rule.ValidateLines(groupPath, validGroup, nil)
+	assert.Equal(t, shared.Approve, decision, reason)
+
+	decision, reason = rule.ValidateLines(groupPath, "group_name: dataverse-consumer-analytics-team\nmembers: alice\n", nil)
+	assert.Equal(t, shared.ManualReview, decision)
+	assert.Contains(t, reason, "Failed to parse group YAML")
+
+	decision, reason = rule.ValidateLines(groupPath, validGroup+"  - analytics_astro_prod_appuser\n", nil)
+	assert.Equal(t, shared.ManualReview, decision)
+	assert.Contains(t, reason, "Group file validation failed: members.serviceaccounts[1]: duplicate entry 'analytics_astro_prod_appuser'")
+}
+
+func TestRule_ValidateDeletion(t *testing.T) {
+	maskingPath := "dataproducts/aggregate/analytics/prod/pii_masking.yaml"
+	productPath := "dataproducts/aggregate/finance/prod/product.yaml"
+	target := map[string]string{
+		groupPath:   validGroup,
+		maskingPath: referencingMasking,
+		productPath: referencingProduct,
+		"dataproducts/aggregate/analytics/prod/product.yaml": "name: analytics\n",
+	}
+	deletion := gitlab.FileChange{OldPath: groupPath, NewPath: groupPath, DeletedFile: true}
+
+	t.Run("referenced group requires review", func(t *testing.T) {
+		rule := newTestRule(&mockClient{files: map[string]map[string]string{"main": target}}, deletion)
+
+		decision, reason := rule.ValidateDeletion(groupPath)
+		assert.Equal(t, shared.ManualReview, decision)
+		assert.Equal(t, "Group 'dataverse-consumer-analytics-team' is deleted but still referenced by: "+
+			maskingPath+", "+productPath+" - manual review required", reason)
+	})
+
+	t.Run("references removed in the same MR", func(t *testing.T) {
+		client := &mockClient{files: map[string]map[string]string{
+			"main":    target,
+			"feature": {productPath: "name: finance\n"},
+		}}
+		rule := newTestRule(client, deletion,
+			gitlab.FileChange{OldPath: maskingPath, NewPath: maskingPath, DeletedFile: true},
+			gitlab.FileChange{OldPath: productPath, NewPath: productPath},
+		)
+
+		decision, reason := rule.ValidateDeletion(groupPath)
+		assert.Equal(t, shared.Approve, decision, reason)
+	})
+
+	t.Run("reference added in the same MR", func(t *testing.T) {
+		newMasking := "dataproducts/aggregate/analytics/dev/pii_masking.yaml"
+		client := &mockClient{files: map[string]map[string]string{
+			"main":    {groupPath: validGroup},
+			"feature": {newMasking: referencingMasking},
+		}}
+		rule := newTestRule(client, deletion, gitlab.FileChange{NewPath: newMasking, NewFile: true})
+
+		decision, reason := rule.ValidateDeletion(groupPath)
+		assert.Equal(t, shared.ManualReview, decision)
+		assert.Contains(t, reason, newMasking)
+	})
+
+	t.Run("without MR context", func(t *testing.T) {
+		decision, reason := NewRule(nil).ValidateDeletion(groupPath)
+		assert.Equal(t, shared.ManualReview, decision)
+		assert.Contains(t, reason, "Could not check references to deleted group 'dataverse-consumer-analytics-team'")
+	})
+}
+
+func TestReferencesGroup(t *testing.T) {
+	assert.True(t, referencesGroup(referencingMasking, "dataverse-consumer-analytics-team"))
+	assert.True(t, referencesGroup(referencingProduct, "dataverse-consumer-analytics-team"))
+	assert.False(t, referencesGroup(referencingMasking, "dataverse-consumer-analytics"))
+	assert.False(t, referencesGroup("consumers:\n- kind: service_account\n  name: dataverse-consumer-analytics-team\n", "dataverse-consumer-analytics-team"))
+	assert.True(t, referencesGroup("consumers: [unclosed dataverse-consumer-analytics-team", "dataverse-consumer-analytics-team"), "unparsable files are searched for the name")
+}
@@ -0,0 +1,198 @@
+package group_membership
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ParseGroupYAML parses group file content; empty content yields an empty group
+func ParseGroupYAML(content string) (*GroupYAML, error) {
+	group := &GroupYAML{}
+	if strings.TrimSpace(content) == "" {
+		return group, nil
+	}
+	if err := yaml.Unmarshal([]byte(content), group); err != nil {
+		return nil, fmt.Errorf("failed to parse group YAML: %w", err)
+	}
+	return group, nil
+}
+
+// Roles returns each member's sorted roles keyed by member name
+func (g *GroupYAML) Roles() map[string][]string {
+	roleSets := make(map[string]map[string]bool)
+	add := func(name, role string) {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return
+		}
+		if roleSets[name] == nil {
+			roleSets[name] = make(map[string]bool)
+		}
+		roleSets[name][role] = true
+	}
+
+	for _, approver := range g.Approvers {
+		add(approver, RoleApprover)
+	}
+	for kind, names := range g.Members {
+		for _, name := range names {
+			add(name, memberRole(kind))
+		}
+	}
+
+	roles := make(map[string][]string, len(roleSets))
+	for name, set := range roleSets {
+		roles[name] = sortedKeys(set)
+	}
+	return roles
+}
+
+// memberRole returns the role label for a members.<kind> list
+func memberRole(kind string) string {
+	if kind == "users" {
+		return RoleMember
+	}
+	return fmt.Sprintf("%s (%s)", RoleMember, kind)
+}
+
+// ComputeDiff computes the membership diff between old and new group file content.
+// Empty old content is treated as a new group.
+func ComputeDiff(oldContent, newContent string) (*MembershipDiff, error) {
+	oldGroup, err := ParseGroupYAML(oldContent)
+	if err != nil {
+		return nil, fmt.Errorf("old version: %w", err)
+	}
+	newGroup, err := ParseGroupYAML(newContent)
+	if err != nil {
+		return nil, fmt.Errorf("new version: %w", err)
+	}
+
+	diff := &MembershipDiff{GroupName: newGroup.GroupName, NewGroup: strings.TrimSpace(oldContent) == ""}
+	if diff.GroupName == "" {
+		diff.GroupName = oldGroup.GroupName
+	}
+
+	oldRoles := oldGroup.Roles()
+	newRoles := newGroup.Roles()
+
+	for _, name := range sortedKeys(newRoles) {
+		previous, existed := oldRoles[name]
+		if !existed {
+			diff.Added = append(diff.Added, MemberChange{Name: name, Roles: newRoles[name]})
+		} else if strings.Join(previous, ",") != strings.Join(newRoles[name], ",") {
+			diff.RoleChanges = append(diff.RoleChanges, RoleChange{Name: name, OldRoles: previous, NewRoles: newRoles[name]})
+		}
+	}
+	for _, name := range sortedKeys(oldRoles) {
+		if _, stillPresent := newRoles[name]; !stillPresent {
+			diff.Removed = append(diff.Removed, MemberChange{Name: name, Roles: oldRoles[name]})
+		}
+	}
+
+	return diff, nil
+}
+
+// IsEmpty reports whether the diff contains no membership changes
+func (d *MembershipDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.RoleChanges) == 0
+}
+
+// ElevatedGrants returns "name (role)" entries for elevated roles the member did not hold before.
+// Initial members of a new group are not grants: new groups are reviewed through CODEOWNERS sync.
+func (d *MembershipDiff) ElevatedGrants(elevatedRoles []string) []string {
+	if d.NewGroup {
+		return nil
+	}
+
+	elevated := make(map[string]bool, len(elevatedRoles))
+	for _, role := range elevatedRoles {
+		elevated[role] = true
+	}
+
+	var grants []string
+	for _, added := range d.Added {
+		for _, role := range added.Roles {
+			if elevated[role] {
+				grants = append(grants, fmt.Sprintf("%s (%s)", added.Name, role))
+			}
+		}
+	}
+	for _, change := range d.RoleChanges {
+		previous := make(map[string]bool, len(change.OldRoles))
+		for _, role := range change.OldRoles {
+			previous[role] = true
+		}
+		for _, role := range change.NewRoles {
+			if elevated[role] && !previous[role] {
+				grants = append(grants, fmt.Sprintf("%s (%s)", change.Name, role))
+			}
+		}
+	}
+	return grants
+}
+
+// Summary returns a one-line count of membership changes
+func (d *MembershipDiff) Summary() string {
+	return fmt.Sprintf("%d added, %d removed, %d role changes", len(d.Added), len(d.Removed), len(d.RoleChanges))
+}
+
+// FormatMarkdown renders the diff as a markdown section for an MR comment
+func (d *MembershipDiff) FormatMarkdown(filePath string) string {
+	var sb strings.Builder
+
+	title := d.GroupName
+	if title == "" {
+		title = filePath
+	}
+	sb.WriteString(fmt.Sprintf("#### `%s`\n", title))
+	if title != filePath {
+		sb.WriteString(fmt.Sprintf("_File: `%s`_\n\n", filePath))
+	} else {
+		sb.WriteString("\n")
+	}
+
+	if d.IsEmpty() {
+		sb.WriteString("No membership changes.\n")
+		return sb.String()
+	}
+	if d.NewGroup {
+		sb.WriteString("_New group_\n\n")
+	}
+
+	if len(d.Added) > 0 {
+		sb.WriteString("**Added:**\n")
+		for _, m := range d.Added {
+			sb.WriteString(fmt.Sprintf("- ➕ `%s` — %s\n", m.Name, strings.Join(m.Roles, ", ")))
+		}
+		sb.WriteString("\n")
+	}
+	if len(d.Removed) > 0 {
+		sb.WriteString("**Removed:**\n")
+		for _, m := range d.Removed {
+			sb.WriteString(fmt.Sprintf("- ➖ `%s` — %s\n", m.Name, strings.Join(m.Roles, ", ")))
+		}
+		sb.WriteString("\n")
+	}
+	if len(d.RoleChanges) > 0 {
+		sb.WriteString("**Role changes:**\n")
+		for _, c := range d.RoleChanges {
+			sb.WriteString(fmt.Sprintf("- 🔄 `%s` — %s → %s\n", c.Name, strings.Join(c.OldRoles, ", "), strings.Join(c.NewRoles, ", ")))
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// sortedKeys returns the sorted keys of a map
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,79 @@
+package group_membership
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const oldGroupYAML = `group_name: dataverse-consumer-analytics
+approvers:
+- alice
+members:
+  users:
+  - alice
+  - bob
+  - carol
+`
+
+func TestComputeDiff(t *testing.T) {
+	newContent := `group_name: dataverse-consumer-analytics
+approvers:
+- alice
+- bob
+members:
+  users:
+  - alice
+  - bob
+  - dave
+  service_accounts:
+  - etl_appuser
+`
+	diff, err := ComputeDiff(oldGroupYAML, newContent)
+	assert.NoError(t, err)
+	assert.Equal(t, "dataverse-consumer-analytics", diff.GroupName)
+	assert.Equal(t, []MemberChange{
+		{Name: "dave", Roles: []string{"member"}},
+		{Name: "etl_appuser", Roles: []string{"member (service_accounts)"}},
+	}, diff.Added)
+	assert.Equal(t, []MemberChange{{Name: "carol", Roles: []string{"member"}}}, diff.Removed)
+	assert.Equal(t, []RoleChange{
+		{Name: "bob", OldRoles: []string{"member"}, NewRoles: []string{"approver", "member"}},
+	}, diff.RoleChanges)
+	assert.Equal(t, "2 added, 1 removed, 1 role changes", diff.Summary())
+	assert.Equal(t, []string{"bob (approver)"}, diff.ElevatedGrants([]string{RoleApprover}))
+}
+
+func TestComputeDiff_NewGroupAndNoChanges(t *testing.T) {
+	diff, err := ComputeDiff("", oldGroupYAML)
+	assert.NoError(t, err)
+	assert.True(t, diff.NewGroup)
+	assert.Len(t, diff.Added, 3)
+	assert.Empty(t, diff.ElevatedGrants([]string{RoleApprover}))
+
+	diff, err = ComputeDiff(oldGroupYAML, oldGroupYAML)
+	assert.NoError(t, err)
+	assert.True(t, diff.IsEmpty())
+	assert.Empty(t, diff.ElevatedGrants([]string{RoleApprover}))
+}
+
+func TestComputeDiff_InvalidYAML(t *testing.T) {
+	_, err := ComputeDiff(oldGroupYAML, "approvers: [unclosed")
+	assert.Error(t, err)
+}
+
+func TestMembershipDiff_FormatMarkdown(t *testing.T) {
+	diff := &MembershipDiff{
+		GroupName:   "analytics",
+		Added:       []MemberChange{{Name: "dave", Roles: []string{"member"}}},
+		Removed:     []MemberChange{{Name: "carol", Roles: []string{"member"}}},
+		RoleChanges: []RoleChange{{Name: "bob", OldRoles: []string{"member"}, NewRoles: []string{"approver", "member"}}},
+	}
+
+	markdown := diff.FormatMarkdown("dataproducts/source/x/groups/analytics.yaml")
+	assert.Contains(t, markdown, "#### `analytics`")
+	assert.Contains(t, markdown, "dataproducts/source/x/groups/analytics.yaml")
+	assert.Contains(t, markdown, "➕ `dave` — member")
+	assert.Contains(t, markdown, "➖ `carol` — member")
+	assert.Contains(t, markdown, "🔄 `bob` — member → approver, member")
+}
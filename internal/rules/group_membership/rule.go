@@ -0,0 +1,125 @@
+package group_membership
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/common"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"go.uber.org/zap"
+)
+
+// RuleName is the identifier of the group membership rule
+const RuleName = "group_membership_rule"
+
+// groupFilePattern matches consumer group files
+const groupFilePattern = "dataproducts/**/groups/*.{yaml,yml}"
+
+// FileFetcher is the subset of the GitLab client needed to load previous group file versions
+type FileFetcher interface {
+	FetchFileContent(ctx context.Context, projectID int, filePath, ref string) (*gitlab.FileContent, error)
+}
+
+// Rule compares old and new versions of groups/*.yaml files and requires
+// manual review when elevated roles are granted
+type Rule struct {
+	*common.BaseRule
+	*common.ValidationHelper
+	client FileFetcher
+	config *GroupMembershipConfig
+}
+
+// NewRule creates a new group membership rule instance
+func NewRule(client FileFetcher, elevatedRoles []string) *Rule {
+	config := DefaultGroupMembershipConfig()
+	if len(elevatedRoles) > 0 {
+		config.ElevatedRoles = elevatedRoles
+	}
+
+	return &Rule{
+		BaseRule:         common.NewBaseRule(RuleName, "Summarizes group membership changes and requires manual review when elevated roles are added"),
+		ValidationHelper: common.NewValidationHelper(),
+		client:           client,
+		config:           config,
+	}
+}
+
+// IsGroupFile checks if the path is a consumer group file
+func IsGroupFile(filePath string) bool {
+	return shared.MatchesPattern(filePath, groupFilePattern)
+}
+
+// GetCoveredLines returns line ranges this rule covers
+func (r *Rule) GetCoveredLines(filePath string, fileContent string) []shared.LineRange {
+	if !IsGroupFile(filePath) {
+		return []shared.LineRange{}
+	}
+	return r.GetFullFileCoverage(filePath, fileContent)
+}
+
+// ValidateLines validates membership changes in a group file
+func (r *Rule) ValidateLines(filePath string, fileContent string, lineRanges []shared.LineRange) (shared.DecisionType, string) {
+	if !IsGroupFile(filePath) {
+		return r.CreateApprovalResult("Not a group file - rule does not apply")
+	}
+
+	oldContent, err := r.previousContent(filePath)
+	if err != nil {
+		return r.CreateManualReviewResult(fmt.Sprintf("Could not load previous version of group file: %v", err))
+	}
+
+	diff, err := ComputeDiff(oldContent, fileContent)
+	if err != nil {
+		return r.CreateManualReviewResult(fmt.Sprintf("Could not compute membership changes: %v", err))
+	}
+
+	if grants := diff.ElevatedGrants(r.config.ElevatedRoles); len(grants) > 0 {
+		return r.CreateManualReviewResult(fmt.Sprintf("Elevated roles granted: %s - manual review required", strings.Join(grants, ", ")))
+	}
+
+	if diff.IsEmpty() {
+		return r.CreateApprovalResult("No group membership changes")
+	}
+	if diff.NewGroup {
+		return r.CreateApprovalResult("New group created (" + diff.Summary() + ")")
+	}
+	return r.CreateApprovalResult("Group membership changes without elevated roles (" + diff.Summary() + ")")
+}
+
+// previousContent fetches the group file from the target branch; new files have no previous content
+func (r *Rule) previousContent(filePath string) (string, error) {
+	mrCtx := r.GetMRContext()
+	if mrCtx == nil || mrCtx.MRInfo == nil {
+		return "", fmt.Errorf("MR context not available")
+	}
+
+	oldPath := filePath
+	for _, change := range mrCtx.Changes {
+		if change.NewPath != filePath {
+			continue
+		}
+		if change.NewFile {
+			return "", nil
+		}
+		if change.OldPath != "" {
+			oldPath = change.OldPath
+		}
+		break
+	}
+
+	if r.client == nil {
+		return "", fmt.Errorf("GitLab client not available")
+	}
+	content, err := r.client.FetchFileContent(mrCtx.Context(), mrCtx.ProjectID, oldPath, mrCtx.MRInfo.TargetBranch)
+	if err != nil {
+		logging.Warn("Failed to fetch previous version", zap.String("old_path", oldPath), zap.Error(err))
+		return "", err
+	}
+	if content == nil {
+		return "", fmt.Errorf("empty response when fetching %s", oldPath)
+	}
+	return content.Content, nil
+}
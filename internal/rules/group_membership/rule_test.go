@@ -0,0 +1,116 @@
+package group_membership
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+const groupPath = "dataproducts/source/analytics/groups/dataverse-consumer-analytics.yaml"
+
+// mockFileFetcher serves file contents keyed by "ref:path"
+type mockFileFetcher struct {
+	files map[string]string
+}
+
+func (m *mockFileFetcher) FetchFileContent(ctx context.Context, projectID int, filePath, ref string) (*gitlab.FileContent, error) {
+	content, ok := m.files[ref+":"+filePath]
+	if !ok {
+		return nil, fmt.Errorf("file not found: %s", filePath)
+	}
+	return &gitlab.FileContent{FilePath: filePath, Content: content, Ref: ref}, nil
+}
+
+func newTestRule(files map[string]string, change gitlab.FileChange) *Rule {
+	rule := NewRule(&mockFileFetcher{files: files}, nil)
+	rule.SetMRContext(&shared.MRContext{
+		ProjectID: 1,
+		MRIID:     2,
+		Changes:   []gitlab.FileChange{change},
+		MRInfo:    &gitlab.MRInfo{SourceBranch: "feature", TargetBranch: "main"},
+	})
+	return rule
+}
+
+func TestRule_GetCoveredLines(t *testing.T) {
+	rule := NewRule(nil, nil)
+	assert.NotEmpty(t, rule.GetCoveredLines(groupPath, oldGroupYAML))
+	assert.NotEmpty(t, rule.GetCoveredLines("dataproducts/aggregate/x/groups/g.yml", oldGroupYAML))
+	assert.Empty(t, rule.GetCoveredLines("dataproducts/source/analytics/developers.yaml", oldGroupYAML))
+}
+
+func TestRule_ValidateLines(t *testing.T) {
+	files := map[string]string{"main:" + groupPath: oldGroupYAML}
+	change := gitlab.FileChange{OldPath: groupPath, NewPath: groupPath}
+
+	tests := []struct {
+		name             string
+		newContent       string
+		change           gitlab.FileChange
+		expectedDecision shared.DecisionType
+		reasonContains   string
+	}{
+		{
+			name:             "adding regular member auto-approves",
+			newContent:       oldGroupYAML + "  - dave\n",
+			change:           change,
+			expectedDecision: shared.Approve,
+			reasonContains:   "1 added, 0 removed, 0 role changes",
+		},
+		{
+			name:             "removing members auto-approves",
+			newContent:       "group_name: dataverse-consumer-analytics\napprovers:\n- alice\nmembers:\n  users:\n  - alice\n",
+			change:           change,
+			expectedDecision: shared.Approve,
+			reasonContains:   "0 added, 2 removed",
+		},
+		{
+			name:             "promoting member to approver requires review",
+			newContent:       "group_name: dataverse-consumer-analytics\napprovers:\n- alice\n- bob\nmembers:\n  users:\n  - alice\n  - bob\n  - carol\n",
+			change:           change,
+			expectedDecision: shared.ManualReview,
+			reasonContains:   "bob (approver)",
+		},
+		{
+			name:             "new group auto-approves",
+			newContent:       oldGroupYAML,
+			change:           gitlab.FileChange{NewPath: groupPath, NewFile: true},
+			expectedDecision: shared.Approve,
+			reasonContains:   "New group created (3 added",
+		},
+		{
+			name:             "missing previous version requires review",
+			newContent:       oldGroupYAML,
+			change:           gitlab.FileChange{OldPath: "dataproducts/source/analytics/groups/missing.yaml", NewPath: groupPath, RenamedFile: true},
+			expectedDecision: shared.ManualReview,
+			reasonContains:   "Could not load previous version",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := newTestRule(files, tt.change)
+			decision, reason := rule.ValidateLines(groupPath, tt.newContent, nil)
+			assert.Equal(t, tt.expectedDecision, decision)
+			assert.Contains(t, reason, tt.reasonContains)
+		})
+	}
+}
+
+func TestRule_CustomElevatedRoles(t *testing.T) {
+	files := map[string]string{"main:" + groupPath: oldGroupYAML}
+	rule := NewRule(&mockFileFetcher{files: files}, []string{RoleApprover, RoleMember})
+	rule.SetMRContext(&shared.MRContext{
+		ProjectID: 1,
+		Changes:   []gitlab.FileChange{{OldPath: groupPath, NewPath: groupPath}},
+		MRInfo:    &gitlab.MRInfo{TargetBranch: "main"},
+	})
+
+	decision, reason := rule.ValidateLines(groupPath, oldGroupYAML+"  - dave\n", nil)
+	assert.Equal(t, shared.ManualReview, decision)
+	assert.Contains(t, reason, "dave (member)")
+}
@@ -0,0 +1,49 @@
+package group_membership
+
+// Role labels used in membership diffs
+const (
+	RoleApprover = "approver" // Listed under approvers
+	RoleMember   = "member"   // Listed under members.users
+)
+
+// GroupMembershipConfig holds configuration for group membership validation
+type GroupMembershipConfig struct {
+	// Roles that grant elevated access; adding them requires manual review
+	ElevatedRoles []string
+}
+
+// DefaultGroupMembershipConfig returns default configuration
+func DefaultGroupMembershipConfig() *GroupMembershipConfig {
+	return &GroupMembershipConfig{
+		ElevatedRoles: []string{RoleApprover},
+	}
+}
+
+// GroupYAML represents the membership-related fields of groups/*.yaml files
+type GroupYAML struct {
+	GroupName string              `yaml:"group_name"`
+	Approvers []string            `yaml:"approvers"`
+	Members   map[string][]string `yaml:"members"`
+}
+
+// MemberChange describes a member added to or removed from a group
+type MemberChange struct {
+	Name  string
+	Roles []string
+}
+
+// RoleChange describes a member whose roles changed
+type RoleChange struct {
+	Name     string
+	OldRoles []string
+	NewRoles []string
+}
+
+// MembershipDiff summarizes membership changes between two versions of a group file
+type MembershipDiff struct {
+	GroupName   string
+	NewGroup    bool // No previous version exists
+	Added       []MemberChange
+	Removed     []MemberChange
+	RoleChanges []RoleChange
+}
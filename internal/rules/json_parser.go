@@ -0,0 +1,140 @@
+package rules
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+)
+
+// JSONSectionParser parses JSON files into sections selected by dotted key paths
+type JSONSectionParser struct {
+	sectionParserBase
+}
+
+// NewJSONSectionParser creates a new JSON section parser
+func NewJSONSectionParser(definitions map[string]config.SectionDefinition) *JSONSectionParser {
+	return &JSONSectionParser{
+		sectionParserBase: sectionParserBase{sectionDefinitions: definitions},
+	}
+}
+
+// ParseSections extracts sections from JSON content based on definitions
+func (p *JSONSectionParser) ParseSections(filePath string, content string) ([]shared.Section, error) {
+	doc, err := parseJSONDocument(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+	return p.keyedSections(filePath, content, doc, shared.JSONSection)
+}
+
+// parseJSONDocument decodes JSON content and records the lines of each object key reachable
+// through objects only
+func parseJSONDocument(content string) (*keyedDocument, error) {
+	doc := &keyedDocument{spans: make(map[string]lineSpan)}
+	if err := json.Unmarshal([]byte(content), &doc.value); err != nil {
+		return nil, err
+	}
+
+	// The content is valid JSON from here on, so the scanner does not check the syntax
+	scanner := &jsonScanner{data: content, line: 1, spans: doc.spans}
+	scanner.skipSpace()
+	scanner.value("", true)
+	return doc, nil
+}
+
+// jsonScanner walks valid JSON content keeping track of the current line
+type jsonScanner struct {
+	data  string
+	pos   int
+	line  int
+	spans map[string]lineSpan
+}
+
+func (s *jsonScanner) skipSpace() {
+	for s.pos < len(s.data) {
+		switch s.data[s.pos] {
+		case '\n':
+			s.line++
+			s.pos++
+		case ' ', '\t', '\r':
+			s.pos++
+		default:
+			return
+		}
+	}
+}
+
+// value consumes the value at the current position. Object keys below path are recorded
+// when record is set, i.e. no array encloses the value.
+func (s *jsonScanner) value(path string, record bool) {
+	switch s.data[s.pos] {
+	case '{':
+		s.pos++
+		for {
+			s.skipSpace()
+			if s.data[s.pos] == '}' {
+				s.pos++
+				return
+			}
+			if s.data[s.pos] == ',' {
+				s.pos++
+				continue
+			}
+
+			start := s.line
+			key := s.string()
+			s.skipSpace()
+			s.pos++ // ':'
+			s.skipSpace()
+
+			keyPath := key
+			if path != "" {
+				keyPath = path + "." + key
+			}
+			s.value(keyPath, record)
+			if record {
+				s.spans[keyPath] = lineSpan{start: start, end: s.line}
+			}
+		}
+	case '[':
+		s.pos++
+		for {
+			s.skipSpace()
+			switch s.data[s.pos] {
+			case ']':
+				s.pos++
+				return
+			case ',':
+				s.pos++
+			default:
+				s.value("", false)
+			}
+		}
+	case '"':
+		s.string()
+	default:
+		for s.pos < len(s.data) && !strings.ContainsRune(",}] \t\r\n", rune(s.data[s.pos])) {
+			s.pos++
+		}
+	}
+}
+
+// string consumes a string and returns its decoded value
+func (s *jsonScanner) string() string {
+	start := s.pos
+	s.pos++
+	for s.data[s.pos] != '"' {
+		if s.data[s.pos] == '\\' {
+			s.pos++
+		}
+		s.pos++
+	}
+	s.pos++
+
+	var decoded string
+	_ = json.Unmarshal([]byte(s.data[start:s.pos]), &decoded)
+	return decoded
+}
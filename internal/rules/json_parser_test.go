@@ -0,0 +1,93 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+)
+
+const appConfigJSON = `{
+	"name": "orders-app",
+	"url": "https:\/\/example.com",
+	"database": {
+		"host": "db.example.com",
+		"pool": {
+			"size": 10
+		}
+	},
+	"features": [
+		{"name": "beta", "enabled": true},
+		"legacy"
+	]
+}
+`
+
+func TestJSONSectionParser_ParseSections(t *testing.T) {
+	parser := NewJSONSectionParser(map[string]config.SectionDefinition{
+		"database": {Name: "database", YAMLPath: "database", Required: true},
+		"pool":     {Name: "pool", YAMLPath: "database.pool"},
+		"url":      {Name: "url", YAMLPath: "url"},
+		"features": {Name: "features", YAMLPath: "features"},
+		"full":     {Name: "full", YAMLPath: "."},
+		"missing":  {Name: "missing", YAMLPath: "cache"},
+		"nested":   {Name: "nested", YAMLPath: "features.name"},
+	})
+
+	sections, err := parser.ParseSections("apps/orders/config.json", appConfigJSON)
+	assert.NoError(t, err)
+
+	byName := make(map[string]shared.Section)
+	for _, section := range sections {
+		byName[section.Name] = section
+	}
+	assert.Len(t, byName, 5, "missing keys and keys inside arrays are not sections")
+
+	database := byName["database"]
+	assert.Equal(t, 4, database.StartLine)
+	assert.Equal(t, 9, database.EndLine)
+	assert.Equal(t, shared.JSONSection, database.Type)
+	assert.Equal(t, "apps/orders/config.json", database.FilePath)
+	assert.Equal(t, "db.example.com", database.Fields["host"])
+	assert.Contains(t, database.Content, `"size": 10`)
+
+	assert.Equal(t, 6, byName["pool"].StartLine)
+	assert.Equal(t, 8, byName["pool"].EndLine)
+	assert.Equal(t, map[string]interface{}{"size": float64(10)}, byName["pool"].Fields)
+
+	assert.Equal(t, 3, byName["url"].StartLine)
+	assert.Equal(t, 3, byName["url"].EndLine)
+	assert.Equal(t, map[string]interface{}{"value": "https://example.com"}, byName["url"].Fields)
+
+	assert.Equal(t, 10, byName["features"].StartLine)
+	assert.Equal(t, 13, byName["features"].EndLine)
+
+	assert.Equal(t, 1, byName["full"].StartLine)
+	assert.Equal(t, 15, byName["full"].EndLine)
+	assert.Equal(t, "orders-app", byName["full"].Fields["name"])
+}
+
+func TestJSONSectionParser_ParseSections_Errors(t *testing.T) {
+	parser := NewJSONSectionParser(map[string]config.SectionDefinition{
+		"cache": {Name: "cache", YAMLPath: "cache", Required: true},
+	})
+
+	_, err := parser.ParseSections("config.json", `{"name": "x"}`)
+	assert.EqualError(t, err, "required section cache not found: section not found at path: cache")
+
+	_, err = parser.ParseSections("config.json", `{"name": }`)
+	assert.ErrorContains(t, err, "failed to parse JSON")
+}
+
+func TestParseJSONDocument_Compact(t *testing.T) {
+	doc, err := parseJSONDocument(`{"a":{"b":[1,{"c":"x\"}"}],"d":null},"e":-1.5e3}`)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]lineSpan{
+		"a":   {start: 1, end: 1},
+		"a.b": {start: 1, end: 1},
+		"a.d": {start: 1, end: 1},
+		"e":   {start: 1, end: 1},
+	}, doc.spans)
+}
@@ -1,15 +1,21 @@
 package rules
 
 import (
+	"context"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/redhat-data-and-ai/naysayer/internal/config"
 	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
 	"github.com/redhat-data-and-ai/naysayer/internal/logging"
 	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/redhat-data-and-ai/naysayer/internal/tracing"
+	"github.com/redhat-data-and-ai/naysayer/internal/utils"
+	"go.uber.org/zap"
 )
 
 // SectionRuleManager manages section-based validation
@@ -17,8 +23,14 @@ type SectionRuleManager struct {
 	rules          []shared.Rule
 	sectionParsers map[string]shared.SectionParser // File pattern -> parser
 	config         *config.GlobalRuleConfig
-	ruleRegistry   map[string]shared.Rule // Rule name -> rule instance
-	gitlabClient   gitlab.GitLabClient    // GitLab client for fetching file content
+	ruleRegistry   map[string]shared.Rule   // Rule name -> rule instance
+	gitlabClient   gitlab.GitLabClient      // GitLab client for fetching file content
+	schedules      map[string]*ruleSchedule // Rule name -> activation schedule
+	now            func() time.Time
+
+	project         *config.ProjectRuleConfig      // Project override this manager applies, nil for the base manager
+	projectMu       sync.Mutex                     // Guards projectManagers
+	projectManagers map[string]*SectionRuleManager // Project override name -> manager
 }
 
 // NewSectionRuleManager creates a new section-based rule manager
@@ -29,6 +41,10 @@ func NewSectionRuleManager(ruleConfig *config.GlobalRuleConfig, client gitlab.Gi
 		config:         ruleConfig,
 		ruleRegistry:   make(map[string]shared.Rule),
 		gitlabClient:   client,
+		schedules:      parseRuleSchedules(ruleConfig.RuleSchedules),
+		now:            time.Now,
+
+		projectManagers: make(map[string]*SectionRuleManager),
 	}
 
 	// Initialize parsers based on configuration
@@ -41,31 +57,38 @@ func NewSectionRuleManager(ruleConfig *config.GlobalRuleConfig, client gitlab.Gi
 func (srm *SectionRuleManager) initializeParsers() {
 	for _, fileConfig := range srm.config.Files {
 		if !fileConfig.Enabled {
-			logging.Info("Skipping disabled file configuration: %s", fileConfig.Name)
+			logging.Info("Skipping disabled file configuration", zap.String("file_configuration", fileConfig.Name))
 			continue
 		}
 
 		// Combine path and filename to create full pattern
 		fullPattern := fileConfig.Path + fileConfig.Filename
 
+		// Create section definitions map from the file's sections
+		definitionMap := make(map[string]config.SectionDefinition)
+		for _, section := range fileConfig.Sections {
+			definitionMap[section.Name] = section
+		}
+
 		switch fileConfig.ParserType {
-		case "yaml":
-			// Create section definitions map from the file's sections
-			definitionMap := make(map[string]config.SectionDefinition)
-			for _, section := range fileConfig.Sections {
-				definitionMap[section.Name] = section
-			}
+		case utils.ParserTypeYAML:
 			srm.sectionParsers[fullPattern] = NewYAMLSectionParser(definitionMap)
-			logging.Info("Initialized YAML parser for pattern: %s (%d sections)", fullPattern, len(definitionMap))
-		case "json":
-			// TODO: Implement JSON parser when needed
-			logging.Warn("JSON section parser not yet implemented for: %s", fileConfig.Name)
-		case "markdown":
-			// TODO: Implement Markdown parser when needed
-			logging.Warn("Markdown section parser not yet implemented for: %s", fileConfig.Name)
+		case utils.ParserTypeJSON:
+			srm.sectionParsers[fullPattern] = NewJSONSectionParser(definitionMap)
+		case utils.ParserTypeTOML:
+			srm.sectionParsers[fullPattern] = NewTOMLSectionParser(definitionMap)
+		case utils.ParserTypeText:
+			srm.sectionParsers[fullPattern] = NewTextSectionParser(definitionMap)
 		default:
-			logging.Warn("Unknown parser type %s for file configuration: %s", fileConfig.ParserType, fileConfig.Name)
+			logging.Warn("Unknown parser type for file configuration",
+				zap.String("parser_type", fileConfig.ParserType),
+				zap.String("file_configuration", fileConfig.Name))
+			continue
 		}
+		logging.Info("Initialized parser",
+			zap.String("parser_type", fileConfig.ParserType),
+			zap.String("pattern", fullPattern),
+			zap.Int("sections", len(definitionMap)))
 	}
 }
 
@@ -73,11 +96,35 @@ func (srm *SectionRuleManager) initializeParsers() {
 func (srm *SectionRuleManager) AddRule(rule shared.Rule) {
 	srm.rules = append(srm.rules, rule)
 	srm.ruleRegistry[rule.Name()] = rule
+
+	// Project managers are rebuilt with the new rule on next use
+	srm.projectMu.Lock()
+	srm.projectManagers = make(map[string]*SectionRuleManager)
+	srm.projectMu.Unlock()
 }
 
 // EvaluateAll runs section-based validation on all files
 func (srm *SectionRuleManager) EvaluateAll(mrCtx *shared.MRContext) *shared.RuleEvaluation {
+	// Projects with an override are evaluated with their own rule configuration
+	if override := srm.findProjectOverride(mrCtx.Context(), mrCtx.ProjectID); override != nil {
+		logging.FromContext(mrCtx.Context()).Info("Applying project rule override",
+			zap.String("override", override.Name),
+			zap.Int("project_id", mrCtx.ProjectID))
+		return srm.managerForProject(override).EvaluateAll(mrCtx)
+	}
+
 	start := time.Now()
+	ctx, span := tracing.Start(mrCtx.Context(), "rules.EvaluateAll", tracing.KindInternal,
+		tracing.Int("naysayer.project_id", mrCtx.ProjectID),
+		tracing.Int("naysayer.mr_iid", mrCtx.MRIID),
+		tracing.Int("naysayer.changes", len(mrCtx.Changes)))
+	defer span.End()
+	if span != nil {
+		// Rules' GitLab calls become children of the evaluation span
+		parent := mrCtx.Ctx
+		mrCtx.Ctx = ctx
+		defer func() { mrCtx.Ctx = parent }()
+	}
 
 	// Note: Draft MR filtering is now handled at the webhook level to avoid any processing
 
@@ -94,12 +141,22 @@ func (srm *SectionRuleManager) EvaluateAll(mrCtx *shared.MRContext) *shared.Rule
 		}
 	}
 
-	// Set MR context for context-aware rules
+	// Resolve the environment of every changed file once, then share it with context-aware rules
+	mrCtx.ResolveEnvironments()
 	srm.setMRContextForRules(mrCtx)
 
 	// Perform section-based validation
 	fileValidations, overallDecision := srm.validateFilesWithSections(mrCtx)
 
+	// Let human approvals satisfy the reviews configured as approval policies
+	overallDecision, approvalRequirements := srm.applyApprovalPolicies(fileValidations, overallDecision)
+
+	// Escalate combinations of rule results configured as decision policies
+	overallDecision = srm.applyDecisionPolicies(fileValidations, overallDecision)
+	overallDecision = srm.applyProjectAutoApprove(overallDecision)
+	overallDecision = srm.applyDeletionBlocks(fileValidations, overallDecision)
+	span.SetAttributes(tracing.String("naysayer.decision", string(overallDecision.Type)))
+
 	// Calculate summary statistics
 	totalFiles := len(fileValidations)
 	approvedFiles := 0
@@ -127,46 +184,31 @@ func (srm *SectionRuleManager) EvaluateAll(mrCtx *shared.MRContext) *shared.Rule
 		ApprovedFiles:   approvedFiles,
 		ReviewFiles:     reviewFiles,
 		UncoveredFiles:  uncoveredFiles,
+		RuleSchedules:   scheduleStatuses(srm.schedules, srm.now()),
+
+		ApprovalRequirements: approvalRequirements,
 	}
 }
 
-// validateFilesWithSections performs section-based validation for each file
+// validateFilesWithSections performs section-based validation for each file. Files are
+// validated concurrently by evaluationWorkers; the result does not depend on their order.
 func (srm *SectionRuleManager) validateFilesWithSections(mrCtx *shared.MRContext) (map[string]*shared.FileValidationSummary, shared.Decision) {
-	fileValidations := make(map[string]*shared.FileValidationSummary)
-
 	// Get unique file paths from changes
 	filePaths := srm.getUniqueFilePaths(mrCtx.Changes)
+	deletedFiles := srm.getDeletedFilePaths(mrCtx.Changes)
 
 	// Source branch files for fork MRs live on the fork project, not the target (same as warehouse analyzer).
 	sourceProjectID := srm.sourceProjectIDForMR(mrCtx)
 
-	for _, filePath := range filePaths {
-		// Get file content from source branch
-		fileContent, fetchErr := srm.getFileContent(filePath, mrCtx, sourceProjectID)
-		if fetchErr != nil {
-			logging.Warn("Cannot load source-branch file for validation (requiring manual review): %s: %v", filePath, fetchErr)
-			fileValidations[filePath] = srm.createManualReviewValidation(filePath, 0, fmt.Sprintf("Could not load file from source branch: %v", fetchErr))
-			continue
-		}
-		totalLines := shared.CountLines(fileContent)
-
-		// Extract changed lines from the diff for delta validation
-		changedLines := srm.getChangedLinesForFile(filePath, mrCtx)
-		diffText := srm.getDiffForFile(filePath, mrCtx)
+	// Each worker writes only the summary of its own file
+	summaries := make([]*shared.FileValidationSummary, len(filePaths))
+	forEachFile(len(filePaths), srm.evaluationWorkers(len(filePaths)), func(i int) {
+		summaries[i] = srm.validateFile(mrCtx, filePaths[i], deletedFiles[filePaths[i]], sourceProjectID)
+	})
 
-		// Check if this file has section-based validation
-		parser := srm.getParserForFile(filePath)
-		if parser != nil {
-			logging.Info("Using section-based validation for file: %s", filePath)
-			// Use section-based validation with delta approach
-			fileValidation := srm.validateFileWithSections(filePath, fileContent, totalLines, parser, changedLines, diffText)
-			fileValidations[filePath] = fileValidation
-		} else {
-			logging.Info("No parser found for file: %s - requiring manual review", filePath)
-			// No section configuration found - require manual review
-			fileValidation := srm.createManualReviewValidation(filePath, totalLines, "No section-based validation configuration found for this file type")
-			fileValidations[filePath] = fileValidation
-		}
+	fileValidations := make(map[string]*shared.FileValidationSummary, len(filePaths))
+	for i, filePath := range filePaths {
+		fileValidations[filePath] = summaries[i]
 	}
 
 	// Determine overall decision
@@ -174,6 +216,53 @@ func (srm *SectionRuleManager) validateFilesWithSections(mrCtx *shared.MRContext
 	return fileValidations, overallDecision
 }
 
+// validateFile validates one changed file of the MR
+func (srm *SectionRuleManager) validateFile(mrCtx *shared.MRContext, filePath string, deleted bool, sourceProjectID int) *shared.FileValidationSummary {
+	// Deleted files have no source-branch content; deletion policies decide them centrally
+	if deleted {
+		return srm.validateDeletion(filePath)
+	}
+
+	// Project overrides may restrict the environments data products are changed in
+	if !srm.environmentAllowed(filePath) {
+		return srm.createManualReviewValidation(filePath, 0, fmt.Sprintf(
+			"File targets an environment not allowed by project override '%s' (allowed: %s)",
+			srm.project.Name, strings.Join(srm.project.AllowedEnvironments, ", ")))
+	}
+
+	// Get file content from source branch
+	fileContent, fetchErr := srm.getFileContent(filePath, mrCtx, sourceProjectID)
+	if fetchErr != nil {
+		logging.FromContext(mrCtx.Context()).Warn("Cannot load source-branch file for validation (requiring manual review)",
+			zap.String("file_path", filePath),
+			zap.Error(fetchErr))
+		return srm.createManualReviewValidation(filePath, 0, fmt.Sprintf("Could not load file from source branch: %v", fetchErr))
+	}
+	totalLines := shared.CountLines(fileContent)
+
+	// Extract changed lines from the diff for delta validation
+	changedLines := srm.getChangedLinesForFile(filePath, mrCtx)
+	diffText := srm.getDiffForFile(filePath, mrCtx)
+
+	// Check if this file has section-based validation
+	parser := srm.getParserForFile(filePath)
+	if parser == nil {
+		logging.FromContext(mrCtx.Context()).Info("No parser found for file", zap.String("file_path", filePath))
+		// No section configuration found - require manual review
+		return srm.createManualReviewValidation(filePath, totalLines, "No section-based validation configuration found for this file type")
+	}
+
+	logging.FromContext(mrCtx.Context()).Info("Using section-based validation for file", zap.String("file_path", filePath))
+	// Use section-based validation with delta approach
+	ctx, span := tracing.Start(mrCtx.Context(), "rules.validateFile", tracing.KindInternal,
+		tracing.String("naysayer.file", filePath),
+		tracing.Int("naysayer.lines", totalLines))
+	defer span.End()
+	fileValidation := srm.validateFileWithSections(ctx, filePath, mrCtx.EnvironmentOf(filePath), fileContent, totalLines, parser, changedLines, diffText)
+	span.SetAttributes(tracing.String("naysayer.decision", string(fileValidation.FileDecision)))
+	return fileValidation
+}
+
 // getChangedLinesForFile extracts changed line ranges for a specific file from MR context
 func (srm *SectionRuleManager) getChangedLinesForFile(filePath string, mrCtx *shared.MRContext) []shared.LineRange {
 	for _, change := range mrCtx.Changes {
@@ -199,28 +288,40 @@ func (srm *SectionRuleManager) getDiffForFile(filePath string, mrCtx *shared.MRC
 }
 
 // validateFileWithSections validates a file using section-based approach with delta validation
-func (srm *SectionRuleManager) validateFileWithSections(filePath, fileContent string, totalLines int, parser shared.SectionParser, changedLines []shared.LineRange, diffText string) *shared.FileValidationSummary {
+func (srm *SectionRuleManager) validateFileWithSections(ctx context.Context, filePath, environment, fileContent string, totalLines int, parser shared.SectionParser, changedLines []shared.LineRange, diffText string) *shared.FileValidationSummary {
 	// Parse file into sections
 	sections, err := parser.ParseSections(filePath, fileContent)
 	if err != nil {
-		logging.Error("Failed to parse sections for %s: %v", filePath, err)
+		logging.Error("Failed to parse sections", zap.String("file_path", filePath), zap.Error(err))
 		// Section parsing failed - require manual review
 		return srm.createManualReviewValidation(filePath, totalLines, fmt.Sprintf("Failed to parse file sections: %v", err))
 	}
+	// Parsers return sections in no particular order; validate them top to bottom so rule
+	// results are listed the same way on every evaluation
+	sort.SliceStable(sections, func(i, j int) bool {
+		if sections[i].StartLine != sections[j].StartLine {
+			return sections[i].StartLine < sections[j].StartLine
+		}
+		return sections[i].Name < sections[j].Name
+	})
 
 	var allCoveredLines []shared.LineRange
 	var ruleResults []shared.LineValidationResult
 	var sectionResults []shared.SectionValidationResult
 
-	// Validate ALL sections to ensure all rules appear in comments
-	// Track which sections were actually affected for potential future optimizations
+	// Only sections the MR changed are validated, so issues elsewhere in the file do not block
+	// unrelated changes. Without changed lines, e.g. for renames, every section is validated.
+	fullValidation := srm.config.FullFileValidation || len(changedLines) == 0
 	affectedSections := make(map[string]bool)
 	if len(changedLines) > 0 {
 		affected := srm.getAffectedSections(sections, changedLines)
 		for _, section := range affected {
 			affectedSections[section.Name] = true
 		}
-		logging.Info("Delta validation for %s: %d affected sections out of %d total", filePath, len(affectedSections), len(sections))
+		logging.Info("Delta validation",
+			zap.String("file_path", filePath),
+			zap.Int("affected_sections", len(affectedSections)),
+			zap.Int("sections", len(sections)))
 	}
 
 	if len(affectedSections) > 0 {
@@ -229,18 +330,28 @@ func (srm *SectionRuleManager) validateFileWithSections(filePath, fileContent st
 			names = append(names, name)
 		}
 		sort.Strings(names)
-		logging.Info("Delta validation for %s affected sections: %s", filePath, strings.Join(names, ", "))
+		logging.Info("Delta validation affected sections", zap.String("file_path", filePath), zap.Strings("sections", names))
 	}
 
 	if !affectedSections["warehouses"] && diffMentionsWarehouses(diffText) {
 		affectedSections["warehouses"] = true
-		logging.Info("Delta validation for %s: warehouses section flagged as affected (diff heuristic)", filePath)
+		logging.Info("Delta validation: warehouses section flagged as affected (diff heuristic)",
+			zap.String("file_path", filePath))
 	}
 
-	// Validate all sections (not just affected ones) to show complete rule evaluation
 	for _, section := range sections {
+		// Rules cover the lines of their section, which the MR did not change
+		if !fullValidation && !affectedSections[section.Name] {
+			logging.Info("Delta validation: skipping unchanged section",
+				zap.String("file_path", filePath),
+				zap.String("section", section.Name))
+			continue
+		}
+
 		// Get enabled rules for this section
-		sectionRules := srm.getEnabledRulesForSection(section.RuleConfigs)
+		// Rule errors are isolated outside the adjustments, so environments and severities never accept them
+		sectionRules := srm.adjustRules(section.RuleConfigs, srm.getEnabledRulesForSection(section.RuleConfigs), environment)
+		sectionRules = traceRules(ctx, srm.guardRules(section.RuleConfigs, sectionRules))
 
 		// Validate the section
 		sectionResult := parser.ValidateSection(&section, sectionRules)
@@ -356,14 +467,18 @@ func (srm *SectionRuleManager) getEnabledRulesForSection(ruleConfigs []config.Ru
 
 	for _, ruleConfig := range ruleConfigs {
 		if !ruleConfig.Enabled {
-			logging.Info("Skipping disabled rule: %s", ruleConfig.Name)
+			logging.Info("Skipping disabled rule", zap.String("rule", ruleConfig.Name))
+			continue
+		}
+		if !srm.ruleActive(ruleConfig.Name) {
+			logging.Info("Skipping rule outside its schedule", zap.String("rule", ruleConfig.Name))
 			continue
 		}
 
 		if rule, exists := srm.ruleRegistry[ruleConfig.Name]; exists {
 			sectionRules = append(sectionRules, rule)
 		} else {
-			logging.Warn("Rule %s not found in registry", ruleConfig.Name)
+			logging.Warn("Rule not found in registry", zap.String("rule", ruleConfig.Name))
 		}
 	}
 
@@ -506,13 +621,17 @@ func (srm *SectionRuleManager) sourceProjectIDForMR(mrCtx *shared.MRContext) int
 	if srm.gitlabClient == nil {
 		return projectID
 	}
-	mrDetails, err := srm.gitlabClient.GetMRDetails(projectID, mrCtx.MRIID)
+	mrDetails, err := srm.gitlabClient.GetMRDetails(mrCtx.Context(), projectID, mrCtx.MRIID)
 	if err != nil {
-		logging.Warn("Failed to get MR details for source project resolution (MR %d): %v", mrCtx.MRIID, err)
+		logging.FromContext(mrCtx.Context()).Warn("Failed to get MR details for source project resolution",
+			zap.Int("mr_iid", mrCtx.MRIID),
+			zap.Error(err))
 		return projectID
 	}
 	if mrDetails != nil && mrDetails.SourceProjectID != 0 && mrDetails.SourceProjectID != projectID {
-		logging.Info("Fork MR: source-branch file fetches use project %d (target project %d)", mrDetails.SourceProjectID, projectID)
+		logging.FromContext(mrCtx.Context()).Info("Fork MR: source-branch file fetches use the source project",
+			zap.Int("source_project_id", mrDetails.SourceProjectID),
+			zap.Int("target_project_id", projectID))
 		return mrDetails.SourceProjectID
 	}
 	return projectID
@@ -520,16 +639,21 @@ func (srm *SectionRuleManager) sourceProjectIDForMR(mrCtx *shared.MRContext) int
 
 func (srm *SectionRuleManager) getFileContent(filePath string, mrCtx *shared.MRContext, sourceProjectID int) (string, error) {
 	if srm.gitlabClient == nil {
-		logging.Warn("GitLab client not available, cannot fetch file content for: %s", filePath)
+		logging.FromContext(mrCtx.Context()).Warn("GitLab client not available, cannot fetch file content",
+			zap.String("file_path", filePath))
 		return "", fmt.Errorf("GitLab client not available")
 	}
 	if mrCtx.MRInfo == nil || mrCtx.MRInfo.SourceBranch == "" {
 		return "", fmt.Errorf("source branch not available in MR context")
 	}
 	sourceBranch := mrCtx.MRInfo.SourceBranch
-	fileContent, err := srm.gitlabClient.FetchFileContent(sourceProjectID, filePath, sourceBranch)
+	fileContent, err := srm.gitlabClient.FetchFileContent(mrCtx.Context(), sourceProjectID, filePath, sourceBranch)
 	if err != nil {
-		logging.Warn("Failed to fetch file content for %s from project %d branch %s: %v", filePath, sourceProjectID, sourceBranch, err)
+		logging.FromContext(mrCtx.Context()).Warn("Failed to fetch file content",
+			zap.String("file_path", filePath),
+			zap.Int("project_id", sourceProjectID),
+			zap.String("branch", sourceBranch),
+			zap.Error(err))
 		return "", err
 	}
 	if fileContent == nil {
@@ -538,64 +662,67 @@ func (srm *SectionRuleManager) getFileContent(filePath string, mrCtx *shared.MRC
 	return fileContent.Content, nil
 }
 
-// extractChangedLinesFromDiff extracts the line ranges that were modified in a Git diff
+// extractChangedLinesFromDiff extracts the lines of the new file a Git diff adds. A removal
+// marks the lines around it, so sections that only lose lines count as changed. Context
+// lines of a hunk are not changes.
 func (srm *SectionRuleManager) extractChangedLinesFromDiff(diff string) []shared.LineRange {
 	var changedRanges []shared.LineRange
-	lines := strings.Split(diff, "\n")
+	mark := func(line int) {
+		if line < 1 {
+			return
+		}
+		if last := len(changedRanges) - 1; last >= 0 && changedRanges[last].EndLine >= line-1 {
+			changedRanges[last].EndLine = max(changedRanges[last].EndLine, line)
+			return
+		}
+		changedRanges = append(changedRanges, shared.LineRange{StartLine: line, EndLine: line})
+	}
 
-	for _, line := range lines {
+	newLine := 0 // Next line of the new file, 0 before the first hunk
+	for _, line := range strings.Split(diff, "\n") {
 		if strings.HasPrefix(line, "@@") {
 			// Parse hunk header like "@@ -1,4 +1,6 @@"
-			if lineRange := srm.parseHunkHeader(line); lineRange != nil {
-				changedRanges = append(changedRanges, *lineRange)
+			if start, ok := srm.parseHunkHeader(line); ok {
+				newLine = start
 			}
+			continue
+		}
+		if newLine == 0 || line == "" {
+			continue
+		}
+		switch line[0] {
+		case '+':
+			mark(newLine)
+			newLine++
+		case '-':
+			mark(newLine - 1)
+			mark(newLine)
+		case ' ':
+			newLine++
 		}
 	}
 
 	return changedRanges
 }
 
-// parseHunkHeader parses a Git diff hunk header to extract the new file line range
-func (srm *SectionRuleManager) parseHunkHeader(hunkHeader string) *shared.LineRange {
+// parseHunkHeader parses a Git diff hunk header and returns the first new file line of the
+// hunk. Hunks that only remove lines start after the line their header names.
+func (srm *SectionRuleManager) parseHunkHeader(hunkHeader string) (int, bool) {
 	// Format: @@ -old_start,old_count +new_start,new_count @@
 	parts := strings.Fields(hunkHeader)
-	if len(parts) < 3 {
-		return nil
-	}
-
-	newPart := parts[2] // +new_start,new_count
-	if !strings.HasPrefix(newPart, "+") {
-		return nil
-	}
-
-	newInfo := strings.TrimPrefix(newPart, "+")
-	rangeParts := strings.Split(newInfo, ",")
-
-	startLine := 0
-	count := 1
-
-	// Parse start line
-	if len(rangeParts) > 0 {
-		if n, err := fmt.Sscanf(rangeParts[0], "%d", &startLine); n != 1 || err != nil {
-			return nil
-		}
+	if len(parts) < 3 || !strings.HasPrefix(parts[2], "+") {
+		return 0, false
 	}
 
-	// Parse count if present
-	if len(rangeParts) > 1 {
-		if n, err := fmt.Sscanf(rangeParts[1], "%d", &count); n != 1 || err != nil {
-			count = 1
-		}
+	rangeParts := strings.Split(strings.TrimPrefix(parts[2], "+"), ",")
+	startLine, err := strconv.Atoi(rangeParts[0])
+	if err != nil || startLine < 0 {
+		return 0, false
 	}
-
-	if startLine <= 0 || count <= 0 {
-		return nil
-	}
-
-	return &shared.LineRange{
-		StartLine: startLine,
-		EndLine:   startLine + count - 1,
+	if len(rangeParts) > 1 && rangeParts[1] == "0" {
+		startLine++
 	}
+	return max(startLine, 1), true
 }
 
 // getAffectedSections returns only the sections that contain changed lines
@@ -639,8 +766,14 @@ func (srm *SectionRuleManager) determineOverallDecision(fileValidations map[stri
 	var warehouseManualReasons []string
 	var hasUncoveredLines bool
 
-	// Collect file results
-	for _, fileValidation := range fileValidations {
+	// Collect file results in path order, so the decision does not depend on map order
+	filePaths := make([]string, 0, len(fileValidations))
+	for filePath := range fileValidations {
+		filePaths = append(filePaths, filePath)
+	}
+	sort.Strings(filePaths)
+	for _, filePath := range filePaths {
+		fileValidation := fileValidations[filePath]
 		if fileValidation.FileDecision == shared.ManualReview {
 			manualReviewFiles = append(manualReviewFiles, fileValidation.FilePath)
 		} else {
@@ -686,22 +819,38 @@ func (srm *SectionRuleManager) determineOverallDecision(fileValidations map[stri
 			reason = "Uncovered changes require manual review"
 		}
 
-		logging.Info("MR requires manual review (files=%d, warehouse=%t, uncovered_lines=%t): %v",
-			len(manualReviewFiles), len(warehouseManualReasons) > 0, hasUncoveredLines, manualReviewFiles)
-
-		return shared.Decision{
+		decision := shared.Decision{
 			Type:    shared.ManualReview,
 			Reason:  reason,
 			Summary: "⚠️ Manual review required",
 			Details: details,
 		}
+		// A quorum of approving files may outvote the files requiring manual review
+		if srm.config.Aggregation.Name() == utils.AggregationQuorum {
+			decision = srm.applyQuorum(filePaths, fileValidations, decision)
+		}
+		if decision.Type == shared.ManualReview {
+			logging.Info("MR requires manual review",
+				zap.Int("files", len(manualReviewFiles)),
+				zap.Bool("warehouse", len(warehouseManualReasons) > 0),
+				zap.Bool("uncovered_lines", hasUncoveredLines),
+				zap.Strings("review_files", manualReviewFiles))
+		}
+		decision.Details += ". " + srm.aggregationDetails()
+		return decision
 	}
 
 	// All files approved - provide detailed summary
-	return shared.Decision{
+	decision := shared.Decision{
 		Type:    shared.Approve,
 		Reason:  "All files passed validation - all changes covered by approved rules",
 		Summary: "✅ Auto-approved",
 		Details: fmt.Sprintf("All %d files passed section-based validation with complete coverage", len(fileValidations)),
 	}
+	if warnings := (&shared.RuleEvaluation{FileValidations: fileValidations}).Warnings(); len(warnings) > 0 {
+		decision.Summary = "✅ Auto-approved with warnings"
+		decision.Details += fmt.Sprintf(". %d rule findings were downgraded to warnings", len(warnings))
+	}
+	decision.Details += ". " + srm.aggregationDetails()
+	return decision
 }
@@ -1,6 +1,7 @@
 package rules
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -32,7 +33,7 @@ type forkMRTestGitLabClient struct {
 
 var _ gitlab.GitLabClient = (*forkMRTestGitLabClient)(nil)
 
-func (m *forkMRTestGitLabClient) FetchFileContent(projectID int, filePath, ref string) (*gitlab.FileContent, error) {
+func (m *forkMRTestGitLabClient) FetchFileContent(ctx context.Context, projectID int, filePath, ref string) (*gitlab.FileContent, error) {
 	m.FetchFileContentCalls = append(m.FetchFileContentCalls, struct {
 		ProjectID int
 		FilePath  string
@@ -46,17 +47,17 @@ func (m *forkMRTestGitLabClient) FetchFileContent(projectID int, filePath, ref s
 		return &gitlab.FileContent{Content: m.afterYAML, FilePath: filePath}, nil
 	case projectID == m.targetProjectID && ref == m.sourceBranch:
 		// Simulates GitLab: source branch ref does not exist on target project
-		return nil, fmt.Errorf("file not found: %s", filePath)
+		return nil, fmt.Errorf("file not found: %s: %w", filePath, gitlab.ErrNotFound)
 	default:
-		return nil, fmt.Errorf("file not found: %s", filePath)
+		return nil, fmt.Errorf("file not found: %s: %w", filePath, gitlab.ErrNotFound)
 	}
 }
 
-func (m *forkMRTestGitLabClient) GetMRTargetBranch(projectID, mrIID int) (string, error) {
+func (m *forkMRTestGitLabClient) GetMRTargetBranch(ctx context.Context, projectID, mrIID int) (string, error) {
 	return m.targetBranch, nil
 }
 
-func (m *forkMRTestGitLabClient) GetMRDetails(projectID, mrIID int) (*gitlab.MRDetails, error) {
+func (m *forkMRTestGitLabClient) GetMRDetails(ctx context.Context, projectID, mrIID int) (*gitlab.MRDetails, error) {
 	return &gitlab.MRDetails{
 		IID:             mrIID,
 		ProjectID:       projectID,
@@ -66,7 +67,7 @@ func (m *forkMRTestGitLabClient) GetMRDetails(projectID, mrIID int) (*gitlab.MRD
 	}, nil
 }
 
-func (m *forkMRTestGitLabClient) FetchMRChanges(projectID, mrIID int) ([]gitlab.FileChange, error) {
+func (m *forkMRTestGitLabClient) FetchMRChanges(ctx context.Context, projectID, mrIID int) ([]gitlab.FileChange, error) {
 	return []gitlab.FileChange{{
 		NewPath: "dataproducts/marketing/prod/product.yaml",
 		Diff: `@@ -7,7 +7,7 @@
@@ -75,60 +76,74 @@ func (m *forkMRTestGitLabClient) FetchMRChanges(projectID, mrIID int) ([]gitlab.
 	}}, nil
 }
 
-func (m *forkMRTestGitLabClient) AddMRComment(projectID, mrIID int, comment string) error { return nil }
-func (m *forkMRTestGitLabClient) AddOrUpdateMRComment(projectID, mrIID int, commentBody, commentType string) error {
+func (m *forkMRTestGitLabClient) AddMRComment(ctx context.Context, projectID, mrIID int, comment string) error {
 	return nil
 }
-func (m *forkMRTestGitLabClient) ListMRComments(projectID, mrIID int) ([]gitlab.MRComment, error) {
+func (m *forkMRTestGitLabClient) AddOrUpdateMRComment(ctx context.Context, projectID, mrIID int, commentBody, commentType string) error {
+	return nil
+}
+func (m *forkMRTestGitLabClient) ListMRComments(ctx context.Context, projectID, mrIID int) ([]gitlab.MRComment, error) {
 	return nil, nil
 }
-func (m *forkMRTestGitLabClient) UpdateMRComment(projectID, mrIID, commentID int, newBody string) error {
+func (m *forkMRTestGitLabClient) UpdateMRComment(ctx context.Context, projectID, mrIID, commentID int, newBody string) error {
 	return nil
 }
-func (m *forkMRTestGitLabClient) FindLatestNaysayerComment(projectID, mrIID int, commentType ...string) (*gitlab.MRComment, error) {
+func (m *forkMRTestGitLabClient) FindLatestNaysayerComment(ctx context.Context, projectID, mrIID int, commentType ...string) (*gitlab.MRComment, error) {
 	return nil, nil
 }
-func (m *forkMRTestGitLabClient) ApproveMR(projectID, mrIID int) error { return nil }
-func (m *forkMRTestGitLabClient) ApproveMRWithMessage(projectID, mrIID int, message string) error {
+func (m *forkMRTestGitLabClient) ApproveMR(ctx context.Context, projectID, mrIID int) error {
+	return nil
+}
+func (m *forkMRTestGitLabClient) ApproveMRWithMessage(ctx context.Context, projectID, mrIID int, message string) error {
+	return nil
+}
+func (m *forkMRTestGitLabClient) ResetNaysayerApproval(ctx context.Context, projectID, mrIID int) error {
 	return nil
 }
-func (m *forkMRTestGitLabClient) ResetNaysayerApproval(projectID, mrIID int) error { return nil }
-func (m *forkMRTestGitLabClient) GetCurrentBotUsername() (string, error) {
+func (m *forkMRTestGitLabClient) GetCurrentBotUsername(ctx context.Context) (string, error) {
 	return "naysayer-bot", nil
 }
-func (m *forkMRTestGitLabClient) IsNaysayerBotAuthor(author map[string]interface{}) bool { return false }
-func (m *forkMRTestGitLabClient) CompareBranches(sourceProjectID int, sourceBranch string, targetProjectID int, targetBranch string) (*gitlab.CompareResult, error) {
+func (m *forkMRTestGitLabClient) IsNaysayerBotAuthor(ctx context.Context, author map[string]interface{}) bool {
+	return false
+}
+func (m *forkMRTestGitLabClient) CompareBranches(ctx context.Context, sourceProjectID int, sourceBranch string, targetProjectID int, targetBranch string) (*gitlab.CompareResult, error) {
 	return &gitlab.CompareResult{}, nil
 }
-func (m *forkMRTestGitLabClient) GetBranchCommit(projectID int, branch string) (string, error) {
+func (m *forkMRTestGitLabClient) GetBranchCommit(ctx context.Context, projectID int, branch string) (string, error) {
 	return "abc123", nil
 }
-func (m *forkMRTestGitLabClient) CompareCommits(projectID int, fromSHA, toSHA string) (*gitlab.CompareResult, error) {
+func (m *forkMRTestGitLabClient) CompareCommits(ctx context.Context, projectID int, fromSHA, toSHA string) (*gitlab.CompareResult, error) {
 	return &gitlab.CompareResult{}, nil
 }
-func (m *forkMRTestGitLabClient) RebaseMR(projectID, mrIID int) (bool, error) { return false, nil }
-func (m *forkMRTestGitLabClient) ListOpenMRs(projectID int) ([]int, error) { return nil, nil }
-func (m *forkMRTestGitLabClient) ListOpenMRsWithDetails(projectID int) ([]gitlab.MRDetails, error) {
+func (m *forkMRTestGitLabClient) RebaseMR(ctx context.Context, projectID, mrIID int) (bool, error) {
+	return false, nil
+}
+func (m *forkMRTestGitLabClient) ListOpenMRs(ctx context.Context, projectID int) ([]int, error) {
 	return nil, nil
 }
-func (m *forkMRTestGitLabClient) ListAllOpenMRsWithDetails(projectID int) ([]gitlab.MRDetails, error) {
+func (m *forkMRTestGitLabClient) ListOpenMRsWithDetails(ctx context.Context, projectID int) ([]gitlab.MRDetails, error) {
 	return nil, nil
 }
-func (m *forkMRTestGitLabClient) CloseMR(projectID, mrIID int) error { return nil }
-func (m *forkMRTestGitLabClient) FindCommentByPattern(projectID, mrIID int, pattern string) (bool, error) {
+func (m *forkMRTestGitLabClient) ListAllOpenMRsWithDetails(ctx context.Context, projectID int) ([]gitlab.MRDetails, error) {
+	return nil, nil
+}
+func (m *forkMRTestGitLabClient) CloseMR(ctx context.Context, projectID, mrIID int) error { return nil }
+func (m *forkMRTestGitLabClient) FindCommentByPattern(ctx context.Context, projectID, mrIID int, pattern string) (bool, error) {
 	return false, nil
 }
-func (m *forkMRTestGitLabClient) GetPipelineJobs(projectID, pipelineID int) ([]gitlab.PipelineJob, error) {
+func (m *forkMRTestGitLabClient) GetPipelineJobs(ctx context.Context, projectID, pipelineID int) ([]gitlab.PipelineJob, error) {
 	return nil, nil
 }
-func (m *forkMRTestGitLabClient) GetJobTrace(projectID, jobID int) (string, error) { return "", nil }
-func (m *forkMRTestGitLabClient) FindLatestAtlantisComment(projectID, mrIID int) (*gitlab.MRComment, error) {
+func (m *forkMRTestGitLabClient) GetJobTrace(ctx context.Context, projectID, jobID int) (string, error) {
+	return "", nil
+}
+func (m *forkMRTestGitLabClient) FindLatestAtlantisComment(ctx context.Context, projectID, mrIID int) (*gitlab.MRComment, error) {
 	return nil, nil
 }
-func (m *forkMRTestGitLabClient) AreAllPipelineJobsSucceeded(projectID, pipelineID int) (bool, error) {
+func (m *forkMRTestGitLabClient) AreAllPipelineJobsSucceeded(ctx context.Context, projectID, pipelineID int) (bool, error) {
 	return true, nil
 }
-func (m *forkMRTestGitLabClient) CheckAtlantisCommentForPlanFailures(projectID, mrIID int) (bool, string) {
+func (m *forkMRTestGitLabClient) CheckAtlantisCommentForPlanFailures(ctx context.Context, projectID, mrIID int) (bool, string) {
 	return false, ""
 }
 
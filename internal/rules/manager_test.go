@@ -1,6 +1,7 @@
 package rules
 
 import (
+	"context"
 	"testing"
 
 	"github.com/redhat-data-and-ai/naysayer/internal/config"
@@ -73,6 +74,49 @@ func TestSectionRuleManager_GetParserForFile(t *testing.T) {
 	assert.Nil(t, parser)
 }
 
+func TestSectionRuleManager_ParserTypes(t *testing.T) {
+	section := config.SectionDefinition{Name: "full_file", YAMLPath: ".", AutoApprove: true}
+	ruleConfig := &config.GlobalRuleConfig{
+		Files: []config.FileRuleConfig{
+			{Name: "json", Path: "apps/", Filename: "*.json", ParserType: "json", Enabled: true, Sections: []config.SectionDefinition{section}},
+			{Name: "toml", Path: "apps/", Filename: "*.toml", ParserType: "toml", Enabled: true, Sections: []config.SectionDefinition{section}},
+			{Name: "tfvars", Path: "terraform/", Filename: "*.tfvars", ParserType: "text", Enabled: true, Sections: []config.SectionDefinition{section}},
+			{Name: "docs", Path: "", Filename: "*.md", ParserType: "markdown", Enabled: true, Sections: []config.SectionDefinition{section}},
+		},
+	}
+
+	manager := NewSectionRuleManager(ruleConfig, nil)
+
+	assert.IsType(t, &JSONSectionParser{}, manager.getParserForFile("apps/config.json"))
+	assert.IsType(t, &TOMLSectionParser{}, manager.getParserForFile("apps/config.toml"))
+	assert.IsType(t, &TextSectionParser{}, manager.getParserForFile("terraform/orders.tfvars"))
+	assert.Nil(t, manager.getParserForFile("README.md"), "unknown parser types are skipped")
+}
+
+func TestValidateRuleConfig_SectionSelectors(t *testing.T) {
+	base := func(parserType string, section config.SectionDefinition) *config.GlobalRuleConfig {
+		section.Name = "s"
+		section.AutoApprove = true
+		return &config.GlobalRuleConfig{Files: []config.FileRuleConfig{
+			{Name: "f", Path: "**/", Filename: "*.tfvars", ParserType: parserType, Sections: []config.SectionDefinition{section}},
+		}}
+	}
+
+	assert.NoError(t, config.ValidateRuleConfig(base("json", config.SectionDefinition{YAMLPath: "database"})))
+	assert.NoError(t, config.ValidateRuleConfig(base("toml", config.SectionDefinition{YAMLPath: "."})))
+	assert.NoError(t, config.ValidateRuleConfig(base("text", config.SectionDefinition{YAMLPath: "."})))
+	assert.NoError(t, config.ValidateRuleConfig(base("text", config.SectionDefinition{Pattern: `^tags\s*=`, EndPattern: `^\}`})))
+
+	assert.EqualError(t, config.ValidateRuleConfig(base("markdown", config.SectionDefinition{YAMLPath: "."})),
+		"invalid parser_type 'markdown' for file config 'f'. Must be 'yaml', 'json', 'toml' or 'text'")
+	assert.EqualError(t, config.ValidateRuleConfig(base("json", config.SectionDefinition{Pattern: "^a"})),
+		"section s missing YAML path in file configuration f")
+	assert.EqualError(t, config.ValidateRuleConfig(base("text", config.SectionDefinition{YAMLPath: "tags"})),
+		`text section s needs a pattern or yaml_path "." in file configuration f`)
+	assert.ErrorContains(t, config.ValidateRuleConfig(base("text", config.SectionDefinition{Pattern: "^a", EndPattern: "("})),
+		`invalid pattern "(" of section s`)
+}
+
 func TestPatternMatching(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -139,3 +183,95 @@ func TestSectionRuleManager_DetermineOverallDecision_WithFiles(t *testing.T) {
 
 	assert.Equal(t, shared.ManualReview, decision.Type)
 }
+
+func TestSectionRuleManager_ExtractChangedLinesFromDiff(t *testing.T) {
+	manager := NewSectionRuleManager(&config.GlobalRuleConfig{}, nil)
+
+	tests := []struct {
+		name     string
+		diff     string
+		expected []shared.LineRange
+	}{
+		{
+			name:     "context lines are not changes",
+			diff:     "@@ -1,5 +1,6 @@\n a\n b\n+c\n d\n e\n f",
+			expected: []shared.LineRange{{StartLine: 3, EndLine: 3}},
+		},
+		{
+			name:     "replaced lines merge into one range",
+			diff:     "@@ -10,4 +10,4 @@\n a\n-b\n-c\n+B\n+C\n d",
+			expected: []shared.LineRange{{StartLine: 10, EndLine: 12}},
+		},
+		{
+			name: "hunks yield separate ranges",
+			diff: "@@ -2,3 +2,3 @@\n a\n-b\n+B\n c\n@@ -20,3 +20,4 @@\n x\n+y\n z",
+			expected: []shared.LineRange{
+				{StartLine: 2, EndLine: 3},
+				{StartLine: 21, EndLine: 21},
+			},
+		},
+		{
+			name:     "pure removal marks the surrounding lines",
+			diff:     "@@ -5,2 +4,0 @@\n-a\n-b",
+			expected: []shared.LineRange{{StartLine: 4, EndLine: 5}},
+		},
+		{
+			name:     "new file",
+			diff:     "@@ -0,0 +1,2 @@\n+a\n+b",
+			expected: []shared.LineRange{{StartLine: 1, EndLine: 2}},
+		},
+		{
+			name: "no hunks",
+			diff: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, manager.extractChangedLinesFromDiff(tt.diff))
+		})
+	}
+}
+
+func TestSectionRuleManager_DeltaValidation(t *testing.T) {
+	content := "name: orders\nowner: data-team\nspec:\n  warehouse: XSMALL\n"
+	newManager := func(fullFileValidation bool) (*SectionRuleManager, shared.SectionParser) {
+		cfg := &config.GlobalRuleConfig{
+			FullFileValidation: fullFileValidation,
+			Files: []config.FileRuleConfig{{
+				Name: "products", Path: "", Filename: "product.yaml", ParserType: "yaml", Enabled: true,
+				Sections: []config.SectionDefinition{
+					{Name: "metadata", YAMLPath: "owner", RuleConfigs: []config.RuleConfig{{Name: "metadata_rule", Enabled: true}}},
+					{Name: "spec", YAMLPath: "spec", RuleConfigs: []config.RuleConfig{{Name: "spec_rule", Enabled: true}}},
+				},
+			}},
+		}
+		manager := NewSectionRuleManager(cfg, nil)
+		manager.ruleRegistry["metadata_rule"] = &findingRule{name: "metadata_rule", decision: shared.ManualReview, reason: "owner not allowed"}
+		manager.ruleRegistry["spec_rule"] = &findingRule{name: "spec_rule", decision: shared.Approve, reason: "spec valid"}
+		return manager, manager.getParserForFile("product.yaml")
+	}
+	appliedRules := func(summary *shared.FileValidationSummary) []string {
+		var names []string
+		for _, result := range summary.RuleResults {
+			names = append(names, result.RuleName)
+		}
+		return names
+	}
+
+	diff := "@@ -4,1 +4,1 @@\n-  warehouse: SMALL\n+  warehouse: XSMALL"
+	changedLines := []shared.LineRange{{StartLine: 4, EndLine: 4}}
+
+	manager, parser := newManager(false)
+	summary := manager.validateFileWithSections(context.Background(), "product.yaml", "dev", content, 5, parser, changedLines, diff)
+	assert.Equal(t, []string{"spec_rule"}, appliedRules(summary), "unchanged sections are skipped")
+	assert.Equal(t, shared.Approve, summary.FileDecision)
+
+	summary = manager.validateFileWithSections(context.Background(), "product.yaml", "dev", content, 5, parser, nil, "")
+	assert.ElementsMatch(t, []string{"metadata_rule", "spec_rule"}, appliedRules(summary), "without changed lines every section is validated")
+
+	manager, parser = newManager(true)
+	summary = manager.validateFileWithSections(context.Background(), "product.yaml", "dev", content, 5, parser, changedLines, diff)
+	assert.ElementsMatch(t, []string{"metadata_rule", "spec_rule"}, appliedRules(summary))
+	assert.Equal(t, shared.ManualReview, summary.FileDecision)
+}
@@ -0,0 +1,67 @@
+package masking
+
+import (
+	"fmt"
+	"strings"
+)
+
+// protectionLevels ranks strategies by how much they protect the data. Consumers listed in
+// no case see the mask, the strongest protection.
+var protectionLevels = map[string]int{
+	StrategyUnmasked: 0,
+	StrategyHashSha1: 1,
+}
+
+const maskedLevel = 2
+
+// ProtectionWeakenings compares two versions of a masking policy and describes every
+// consumer that sees less protected data in the new version. Consumers losing access or
+// gaining protection are not weakenings.
+func ProtectionWeakenings(oldPolicy, newPolicy *MaskingPolicy) []string {
+	oldStrategies := consumerStrategies(oldPolicy)
+
+	var weakenings []string
+	seen := make(map[string]bool)
+	for _, c := range newPolicy.Cases {
+		for _, consumer := range c.Consumers {
+			key := consumerKey(consumer)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			oldStrategy, listed := oldStrategies[key]
+			if !listed {
+				oldStrategy = "masked"
+			}
+			if protectionLevel(c.Strategy) < protectionLevel(oldStrategy) {
+				weakenings = append(weakenings, fmt.Sprintf("%s '%s' changes from %s to %s", consumer.Kind, consumer.Name, oldStrategy, c.Strategy))
+			}
+		}
+	}
+	return weakenings
+}
+
+// consumerStrategies maps each consumer to the strategy of the first case listing it
+func consumerStrategies(policy *MaskingPolicy) map[string]string {
+	strategies := make(map[string]string)
+	for _, c := range policy.Cases {
+		for _, consumer := range c.Consumers {
+			if _, ok := strategies[consumerKey(consumer)]; !ok {
+				strategies[consumerKey(consumer)] = c.Strategy
+			}
+		}
+	}
+	return strategies
+}
+
+func consumerKey(consumer Consumer) string {
+	return strings.ToLower(consumer.Kind) + "/" + strings.ToLower(consumer.Name)
+}
+
+func protectionLevel(strategy string) int {
+	if level, ok := protectionLevels[strings.ToUpper(strategy)]; ok {
+		return level
+	}
+	return maskedLevel
+}
@@ -0,0 +1,60 @@
+package masking
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestProtectionWeakenings(t *testing.T) {
+	group := func(name string) Consumer { return Consumer{Kind: ConsumerKindGroup, Name: name} }
+	sa := Consumer{Kind: ConsumerKindServiceAccount, Name: "analytics_dbt_prod_appuser"}
+
+	tests := []struct {
+		name      string
+		oldCases  []Case
+		newCases  []Case
+		weakening []string
+	}{
+		{
+			name:     "unchanged",
+			oldCases: []Case{{Strategy: StrategyUnmasked, Consumers: []Consumer{group("a")}}},
+			newCases: []Case{{Strategy: StrategyUnmasked, Consumers: []Consumer{group("a")}}},
+		},
+		{
+			name:      "consumer gains unmasked access",
+			oldCases:  []Case{{Strategy: StrategyUnmasked, Consumers: []Consumer{group("a")}}},
+			newCases:  []Case{{Strategy: StrategyUnmasked, Consumers: []Consumer{group("a"), sa}}},
+			weakening: []string{"service_account 'analytics_dbt_prod_appuser' changes from masked to UNMASKED"},
+		},
+		{
+			name:      "consumer gains hashed access",
+			newCases:  []Case{{Strategy: StrategyHashSha1, Consumers: []Consumer{group("a")}}},
+			weakening: []string{"consumer_group 'a' changes from masked to HASH_SHA1"},
+		},
+		{
+			name:      "hashed consumer unmasked",
+			oldCases:  []Case{{Strategy: StrategyHashSha1, Consumers: []Consumer{group("a")}}},
+			newCases:  []Case{{Strategy: StrategyUnmasked, Consumers: []Consumer{group("A")}}},
+			weakening: []string{"consumer_group 'A' changes from HASH_SHA1 to UNMASKED"},
+		},
+		{
+			name:     "unmasked consumer hashed",
+			oldCases: []Case{{Strategy: StrategyUnmasked, Consumers: []Consumer{group("a")}}},
+			newCases: []Case{{Strategy: StrategyHashSha1, Consumers: []Consumer{group("a")}}},
+		},
+		{
+			name:     "consumer removed",
+			oldCases: []Case{{Strategy: StrategyUnmasked, Consumers: []Consumer{group("a"), group("b")}}},
+			newCases: []Case{{Strategy: StrategyUnmasked, Consumers: []Consumer{group("a")}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			weakenings := ProtectionWeakenings(&MaskingPolicy{Cases: tt.oldCases}, &MaskingPolicy{Cases: tt.newCases})
+			if !reflect.DeepEqual(weakenings, tt.weakening) {
+				t.Errorf("expected weakenings %v, got %v", tt.weakening, weakenings)
+			}
+		})
+	}
+}
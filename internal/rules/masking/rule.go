@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/repoindex"
 	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
 	"gopkg.in/yaml.v3"
 )
@@ -39,7 +40,7 @@ func (r *Rule) Name() string {
 
 // Description returns human-readable description
 func (r *Rule) Description() string {
-	return "Validates masking policy configurations in *masking.yaml files - auto-approves valid policies, requires manual review for invalid configurations"
+	return "Validates masking policy configurations in *masking.yaml files - auto-approves valid policies, requires manual review for invalid configurations or weakened protection"
 }
 
 // GetCoveredLines returns which line ranges this rule validates in a file
@@ -48,12 +49,6 @@ func (r *Rule) GetCoveredLines(filePath string, fileContent string) []shared.Lin
 		return nil
 	}
 
-	// For deleted files (empty content), still return a range so ValidateLines is called
-	// This ensures deleted masking files require manual review (security-sensitive operation)
-	if len(strings.TrimSpace(fileContent)) == 0 {
-		return []shared.LineRange{{StartLine: 1, EndLine: 1, FilePath: filePath}}
-	}
-
 	// For masking files, we validate the entire file
 	lineCount := strings.Count(fileContent, "\n") + 1
 	return []shared.LineRange{
@@ -65,15 +60,19 @@ func (r *Rule) GetCoveredLines(filePath string, fileContent string) []shared.Lin
 	}
 }
 
-// ValidateLines validates masking policy configuration
-func (r *Rule) ValidateLines(filePath string, fileContent string, lineRanges []shared.LineRange) (shared.DecisionType, string) {
+// ValidateDeletion requires manual review of deleted masking policies, as the deletion
+// removes data protection
+func (r *Rule) ValidateDeletion(filePath string) (shared.DecisionType, string) {
 	if !r.isMaskingFile(filePath) {
 		return shared.Approve, "Not a masking policy file"
 	}
+	return shared.ManualReview, "Masking policy deletion requires manual review - this removes data protection"
+}
 
-	// Deleted masking policy files require manual review (security-sensitive operation)
-	if len(strings.TrimSpace(fileContent)) == 0 {
-		return shared.ManualReview, "Masking policy deletion requires manual review - this removes data protection"
+// ValidateLines validates masking policy configuration
+func (r *Rule) ValidateLines(filePath string, fileContent string, lineRanges []shared.LineRange) (shared.DecisionType, string) {
+	if !r.isMaskingFile(filePath) {
+		return shared.Approve, "Not a masking policy file"
 	}
 
 	// Parse the YAML content
@@ -98,6 +97,15 @@ func (r *Rule) ValidateLines(filePath string, fileContent string, lineRanges []s
 		return shared.ManualReview, fmt.Sprintf("Masking policy validation failed: %s", strings.Join(errorMessages, "; "))
 	}
 
+	// Only changes weakening the protection of the previous version need a review
+	weakenings, err := r.protectionWeakenings(filePath, fileContent)
+	if err != nil {
+		return shared.ManualReview, fmt.Sprintf("Could not compare masking policy with its previous version: %v", err)
+	}
+	if len(weakenings) > 0 {
+		return shared.ManualReview, fmt.Sprintf("Masking policy weakens data protection: %s", strings.Join(weakenings, "; "))
+	}
+
 	// Check if all consumers exist in the repository
 	if r.client != nil && r.mrCtx != nil {
 		var missingConsumers []string
@@ -119,6 +127,11 @@ func (r *Rule) ValidateLines(filePath string, fileContent string, lineRanges []s
 
 // isMaskingFile checks if a file is a masking policy file (excludes tag files)
 func (r *Rule) isMaskingFile(path string) bool {
+	return IsMaskingFile(path)
+}
+
+// IsMaskingFile checks if a file is a masking policy file (excludes tag files)
+func IsMaskingFile(path string) bool {
 	if path == "" {
 		return false
 	}
@@ -140,6 +153,11 @@ func (r *Rule) isMaskingFile(path string) bool {
 
 // parseMaskingPolicy parses YAML content into a MaskingPolicy struct
 func (r *Rule) parseMaskingPolicy(content string) (*MaskingPolicy, error) {
+	return ParseMaskingPolicy(content)
+}
+
+// ParseMaskingPolicy parses YAML content into a MaskingPolicy struct
+func ParseMaskingPolicy(content string) (*MaskingPolicy, error) {
 	var policy MaskingPolicy
 	err := yaml.Unmarshal([]byte(content), &policy)
 	if err != nil {
@@ -148,11 +166,31 @@ func (r *Rule) parseMaskingPolicy(content string) (*MaskingPolicy, error) {
 	return &policy, nil
 }
 
+// protectionWeakenings compares the policy with its target branch version. New policies
+// and policies without MR context have nothing to weaken.
+func (r *Rule) protectionWeakenings(filePath, fileContent string) ([]string, error) {
+	versions, err := shared.LoadFileVersions(r.client, r.mrCtx, filePath, fileContent)
+	if err != nil || versions == nil || versions.IsNew {
+		return nil, err
+	}
+
+	oldPolicy, newPolicy, err := shared.ParseVersions(versions, ParseMaskingPolicy)
+	if err != nil {
+		return nil, err
+	}
+	return ProtectionWeakenings(oldPolicy, newPolicy), nil
+}
+
 // extractPathInfo extracts data product and environment from the file path.
 // Path format: dataproducts/<type>/<dataproduct>/<env>/<filename>
 // Where type is: source, aggregate, or platform
 // Example: dataproducts/source/hellosource/sandbox/pii_masking.yaml -> "hellosource", "sandbox"
 func (r *Rule) extractPathInfo(filePath string) (dataProduct, environment string) {
+	return ExtractPathInfo(filePath)
+}
+
+// ExtractPathInfo extracts data product and environment from a masking file path
+func ExtractPathInfo(filePath string) (dataProduct, environment string) {
 	parts := strings.Split(filePath, "/")
 
 	// Look for "dataproducts" in the path
@@ -245,7 +283,8 @@ func (r *Rule) extractDataProductFromGroupName(groupName string) string {
 	return ""
 }
 
-// fileExistsInRepo checks if a file exists in the repository using GitLab API
+// fileExistsInRepo checks if a file exists in the repository, using the local
+// repository index when the target branch is snapshotted and the GitLab API otherwise
 func (r *Rule) fileExistsInRepo(filePath string) bool {
 	if r.client == nil || r.mrCtx == nil {
 		return true // If no client/context, skip existence check (validation only)
@@ -257,17 +296,25 @@ func (r *Rule) fileExistsInRepo(filePath string) bool {
 		targetBranch = r.mrCtx.MRInfo.TargetBranch
 	}
 
-	// Try to fetch the file from the repository
-	_, err := r.client.FetchFileContent(r.mrCtx.ProjectID, filePath, targetBranch)
-	if err != nil {
-		// Check if file exists in the current MR changes (being added in same MR)
-		for _, change := range r.mrCtx.Changes {
-			if strings.EqualFold(change.NewPath, filePath) && !change.DeletedFile {
-				return true // File is being added in this MR
-			}
-		}
-		return false
+	exists := false
+	indexed := false
+	if idx := repoindex.ForClient(r.client); idx != nil {
+		exists, indexed = idx.PathExists(r.mrCtx.ProjectID, targetBranch, filePath)
+	}
+	if !indexed {
+		// Try to fetch the file from the repository
+		_, err := r.client.FetchFileContent(r.mrCtx.Context(), r.mrCtx.ProjectID, filePath, targetBranch)
+		exists = err == nil
+	}
+	if exists {
+		return true
 	}
 
-	return true
+	// Check if file exists in the current MR changes (being added in same MR)
+	for _, change := range r.mrCtx.Changes {
+		if strings.EqualFold(change.NewPath, filePath) && !change.DeletedFile {
+			return true // File is being added in this MR
+		}
+	}
+	return false
 }
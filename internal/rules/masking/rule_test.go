@@ -1,23 +1,28 @@
 package masking
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"testing"
 
 	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/repoindex"
 	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
 )
 
 // MockGitLabClient implements gitlab.GitLabClient for testing
 type MockGitLabClient struct {
-	existingFiles map[string]bool // map of file paths that exist
-	fetchError    error           // error to return for FetchFileContent
+	existingFiles map[string]bool   // map of file paths that exist
+	fileContents  map[string]string // content of files on the target branch
+	fetchError    error             // error to return for FetchFileContent
 }
 
 func NewMockGitLabClient() *MockGitLabClient {
 	return &MockGitLabClient{
 		existingFiles: make(map[string]bool),
+		fileContents:  make(map[string]string),
 	}
 }
 
@@ -25,10 +30,17 @@ func (m *MockGitLabClient) AddExistingFile(path string) {
 	m.existingFiles[strings.ToLower(path)] = true
 }
 
-func (m *MockGitLabClient) FetchFileContent(projectID int, filePath, ref string) (*gitlab.FileContent, error) {
+func (m *MockGitLabClient) AddFileContent(path, content string) {
+	m.fileContents[path] = content
+}
+
+func (m *MockGitLabClient) FetchFileContent(ctx context.Context, projectID int, filePath, ref string) (*gitlab.FileContent, error) {
 	if m.fetchError != nil {
 		return nil, m.fetchError
 	}
+	if content, ok := m.fileContents[filePath]; ok {
+		return &gitlab.FileContent{Content: content}, nil
+	}
 	if m.existingFiles[strings.ToLower(filePath)] {
 		return &gitlab.FileContent{Content: "content"}, nil
 	}
@@ -36,67 +48,81 @@ func (m *MockGitLabClient) FetchFileContent(projectID int, filePath, ref string)
 }
 
 // Stub implementations for interface compliance
-func (m *MockGitLabClient) GetMRTargetBranch(projectID, mrIID int) (string, error) {
+func (m *MockGitLabClient) GetMRTargetBranch(ctx context.Context, projectID, mrIID int) (string, error) {
 	return "main", nil
 }
-func (m *MockGitLabClient) GetMRDetails(projectID, mrIID int) (*gitlab.MRDetails, error) {
+func (m *MockGitLabClient) GetMRDetails(ctx context.Context, projectID, mrIID int) (*gitlab.MRDetails, error) {
 	return &gitlab.MRDetails{SourceBranch: "feature"}, nil
 }
-func (m *MockGitLabClient) FetchMRChanges(projectID, mrIID int) ([]gitlab.FileChange, error) {
+func (m *MockGitLabClient) FetchMRChanges(ctx context.Context, projectID, mrIID int) ([]gitlab.FileChange, error) {
 	return nil, nil
 }
-func (m *MockGitLabClient) AddMRComment(projectID, mrIID int, comment string) error { return nil }
-func (m *MockGitLabClient) AddOrUpdateMRComment(projectID, mrIID int, commentBody, commentType string) error {
+func (m *MockGitLabClient) AddMRComment(ctx context.Context, projectID, mrIID int, comment string) error {
 	return nil
 }
-func (m *MockGitLabClient) ListMRComments(projectID, mrIID int) ([]gitlab.MRComment, error) {
+func (m *MockGitLabClient) AddOrUpdateMRComment(ctx context.Context, projectID, mrIID int, commentBody, commentType string) error {
+	return nil
+}
+func (m *MockGitLabClient) ListMRComments(ctx context.Context, projectID, mrIID int) ([]gitlab.MRComment, error) {
 	return nil, nil
 }
-func (m *MockGitLabClient) UpdateMRComment(projectID, mrIID, commentID int, newBody string) error {
+func (m *MockGitLabClient) UpdateMRComment(ctx context.Context, projectID, mrIID, commentID int, newBody string) error {
 	return nil
 }
-func (m *MockGitLabClient) FindLatestNaysayerComment(projectID, mrIID int, commentType ...string) (*gitlab.MRComment, error) {
+func (m *MockGitLabClient) FindLatestNaysayerComment(ctx context.Context, projectID, mrIID int, commentType ...string) (*gitlab.MRComment, error) {
 	return nil, nil
 }
-func (m *MockGitLabClient) ApproveMR(projectID, mrIID int) error { return nil }
-func (m *MockGitLabClient) ApproveMRWithMessage(projectID, mrIID int, message string) error {
+func (m *MockGitLabClient) ApproveMR(ctx context.Context, projectID, mrIID int) error { return nil }
+func (m *MockGitLabClient) ApproveMRWithMessage(ctx context.Context, projectID, mrIID int, message string) error {
+	return nil
+}
+func (m *MockGitLabClient) ResetNaysayerApproval(ctx context.Context, projectID, mrIID int) error {
 	return nil
 }
-func (m *MockGitLabClient) ResetNaysayerApproval(projectID, mrIID int) error       { return nil }
-func (m *MockGitLabClient) GetCurrentBotUsername() (string, error)                 { return "bot", nil }
-func (m *MockGitLabClient) IsNaysayerBotAuthor(author map[string]interface{}) bool { return false }
-func (m *MockGitLabClient) RebaseMR(projectID, mrIID int) (bool, error)            { return false, nil }
-func (m *MockGitLabClient) CompareBranches(sourceProjectID int, sourceBranch string, targetProjectID int, targetBranch string) (*gitlab.CompareResult, error) {
+func (m *MockGitLabClient) GetCurrentBotUsername(ctx context.Context) (string, error) {
+	return "bot", nil
+}
+func (m *MockGitLabClient) IsNaysayerBotAuthor(ctx context.Context, author map[string]interface{}) bool {
+	return false
+}
+func (m *MockGitLabClient) RebaseMR(ctx context.Context, projectID, mrIID int) (bool, error) {
+	return false, nil
+}
+func (m *MockGitLabClient) CompareBranches(ctx context.Context, sourceProjectID int, sourceBranch string, targetProjectID int, targetBranch string) (*gitlab.CompareResult, error) {
 	return nil, nil
 }
-func (m *MockGitLabClient) GetBranchCommit(projectID int, branch string) (string, error) {
+func (m *MockGitLabClient) GetBranchCommit(ctx context.Context, projectID int, branch string) (string, error) {
 	return "", nil
 }
-func (m *MockGitLabClient) CompareCommits(projectID int, fromSHA, toSHA string) (*gitlab.CompareResult, error) {
+func (m *MockGitLabClient) CompareCommits(ctx context.Context, projectID int, fromSHA, toSHA string) (*gitlab.CompareResult, error) {
 	return nil, nil
 }
-func (m *MockGitLabClient) ListOpenMRs(projectID int) ([]int, error) { return nil, nil }
-func (m *MockGitLabClient) ListOpenMRsWithDetails(projectID int) ([]gitlab.MRDetails, error) {
+func (m *MockGitLabClient) ListOpenMRs(ctx context.Context, projectID int) ([]int, error) {
 	return nil, nil
 }
-func (m *MockGitLabClient) GetPipelineJobs(projectID, pipelineID int) ([]gitlab.PipelineJob, error) {
+func (m *MockGitLabClient) ListOpenMRsWithDetails(ctx context.Context, projectID int) ([]gitlab.MRDetails, error) {
 	return nil, nil
 }
-func (m *MockGitLabClient) GetJobTrace(projectID, jobID int) (string, error) { return "", nil }
-func (m *MockGitLabClient) FindLatestAtlantisComment(projectID, mrIID int) (*gitlab.MRComment, error) {
+func (m *MockGitLabClient) GetPipelineJobs(ctx context.Context, projectID, pipelineID int) ([]gitlab.PipelineJob, error) {
 	return nil, nil
 }
-func (m *MockGitLabClient) AreAllPipelineJobsSucceeded(projectID, pipelineID int) (bool, error) {
+func (m *MockGitLabClient) GetJobTrace(ctx context.Context, projectID, jobID int) (string, error) {
+	return "", nil
+}
+func (m *MockGitLabClient) FindLatestAtlantisComment(ctx context.Context, projectID, mrIID int) (*gitlab.MRComment, error) {
+	return nil, nil
+}
+func (m *MockGitLabClient) AreAllPipelineJobsSucceeded(ctx context.Context, projectID, pipelineID int) (bool, error) {
 	return true, nil
 }
-func (m *MockGitLabClient) CheckAtlantisCommentForPlanFailures(projectID, mrIID int) (bool, string) {
+func (m *MockGitLabClient) CheckAtlantisCommentForPlanFailures(ctx context.Context, projectID, mrIID int) (bool, string) {
 	return false, ""
 }
-func (m *MockGitLabClient) ListAllOpenMRsWithDetails(projectID int) ([]gitlab.MRDetails, error) {
+func (m *MockGitLabClient) ListAllOpenMRsWithDetails(ctx context.Context, projectID int) ([]gitlab.MRDetails, error) {
 	return nil, nil
 }
-func (m *MockGitLabClient) CloseMR(projectID, mrIID int) error { return nil }
-func (m *MockGitLabClient) FindCommentByPattern(projectID, mrIID int, pattern string) (bool, error) {
+func (m *MockGitLabClient) CloseMR(ctx context.Context, projectID, mrIID int) error { return nil }
+func (m *MockGitLabClient) FindCommentByPattern(ctx context.Context, projectID, mrIID int, pattern string) (bool, error) {
 	return false, nil
 }
 
@@ -128,7 +154,6 @@ func TestRule_GetCoveredLines(t *testing.T) {
 		{"masking yaml file", "dataproducts/source/analytics/sandbox/pii_masking.yaml", "kind: MaskingPolicy", true},
 		{"masking yml file", "dataproducts/source/analytics/sandbox/pii_masking.yml", "kind: MaskingPolicy", true},
 		{"non-masking file", "dataproducts/source/analytics/sandbox/product.yaml", "name: analytics", false},
-		{"empty/deleted masking file - still covered for manual review", "dataproducts/source/analytics/sandbox/pii_masking.yaml", "", true},
 		{"tag masking file - not covered", "dataproducts/source/analytics/sandbox/tag_pii_masking.yaml", "kind: Tag", false},
 	}
 
@@ -559,42 +584,22 @@ cases:
 	}
 }
 
-// TestRule_ValidateLines_DeletedMaskingFile verifies that deleted masking policy files
-// require manual review since deleting a masking policy removes data protection.
-func TestRule_ValidateLines_DeletedMaskingFile(t *testing.T) {
+// TestRule_ValidateDeletion verifies that deleted masking policy files require manual
+// review since deleting a masking policy removes data protection.
+func TestRule_ValidateDeletion(t *testing.T) {
 	rule := NewRule(nil)
 
-	filePath := "dataproducts/source/analytics/sandbox/pii_masking.yaml"
-	emptyContent := "" // Simulates deleted file (no content in source branch)
-
-	decision, reason := rule.ValidateLines(filePath, emptyContent, nil)
-
+	decision, reason := rule.ValidateDeletion("dataproducts/source/analytics/sandbox/pii_masking.yaml")
 	if decision != shared.ManualReview {
 		t.Errorf("expected ManualReview for deleted masking file, got %s: %s", decision, reason)
 	}
-
 	if !strings.Contains(reason, "deletion") {
 		t.Errorf("expected reason to mention deletion, got: %s", reason)
 	}
-}
-
-// TestRule_GetCoveredLines_DeletedMaskingFile verifies that deleted masking files
-// are still "covered" by this rule so that ValidateLines is called.
-func TestRule_GetCoveredLines_DeletedMaskingFile(t *testing.T) {
-	rule := NewRule(nil)
-
-	filePath := "dataproducts/source/analytics/sandbox/pii_masking.yaml"
-	emptyContent := "" // Simulates deleted file
-
-	lines := rule.GetCoveredLines(filePath, emptyContent)
 
-	if len(lines) == 0 {
-		t.Errorf("expected deleted masking file to be covered for manual review")
-	}
-
-	// Verify it returns a minimal range for the deleted file
-	if lines[0].StartLine != 1 || lines[0].EndLine != 1 {
-		t.Errorf("expected lines 1-1 for deleted file, got %d-%d", lines[0].StartLine, lines[0].EndLine)
+	decision, _ = rule.ValidateDeletion("dataproducts/source/analytics/sandbox/tag_pii_masking.yaml")
+	if decision != shared.Approve {
+		t.Errorf("expected tag files to be left to the tag rule, got %s", decision)
 	}
 }
 
@@ -928,3 +933,160 @@ func TestRule_SetMRContext(t *testing.T) {
 		t.Errorf("expected MR context to be set")
 	}
 }
+
+// staticTreeLister returns a fixed repository tree for index tests
+type staticTreeLister struct {
+	paths []string
+}
+
+func (s *staticTreeLister) ListRepositoryTree(ctx context.Context, projectID int, ref string) ([]gitlab.TreeEntry, error) {
+	entries := make([]gitlab.TreeEntry, 0, len(s.paths))
+	for _, p := range s.paths {
+		entries = append(entries, gitlab.TreeEntry{Type: "blob", Path: p})
+	}
+	return entries, nil
+}
+
+func TestRule_ValidateLines_UsesRepositoryIndex(t *testing.T) {
+	// The API reports every file as missing; only the index knows about the service account
+	mockClient := NewMockGitLabClient()
+	mockClient.fetchError = fmt.Errorf("API should not be called")
+
+	idx := repoindex.NewIndex(&staticTreeLister{
+		paths: []string{"serviceaccounts/sandbox/analytics_dbt_sandbox_appuser.yaml"},
+	}, store.NewMemoryStore())
+	if _, err := idx.Refresh(context.Background(), 123, "main"); err != nil {
+		t.Fatalf("failed to refresh index: %v", err)
+	}
+	repoindex.SetDefault(idx)
+	defer repoindex.SetDefault(nil)
+
+	rule := NewRule(mockClient)
+	rule.SetMRContext(&shared.MRContext{
+		ProjectID: 123,
+		MRInfo:    &gitlab.MRInfo{TargetBranch: "main"},
+	})
+
+	validYAML := `kind: MaskingPolicy
+name: analytics_pii_string_policy
+data_product: analytics
+datatype: string
+mask: "==MASKED=="
+cases:
+  - strategy: UNMASKED
+    consumers:
+      - kind: service_account
+        name: analytics_dbt_sandbox_appuser
+`
+
+	filePath := "dataproducts/source/analytics/sandbox/pii_masking.yaml"
+	decision, reason := rule.ValidateLines(filePath, validYAML, nil)
+	if decision != shared.Approve {
+		t.Errorf("expected Approve when index contains the service account, got %s: %s", decision, reason)
+	}
+
+	// A ref that has not been snapshotted falls back to the API
+	rule.SetMRContext(&shared.MRContext{
+		ProjectID: 123,
+		MRInfo:    &gitlab.MRInfo{TargetBranch: "release"},
+	})
+	decision, _ = rule.ValidateLines(filePath, validYAML, nil)
+	if decision != shared.ManualReview {
+		t.Errorf("expected ManualReview when falling back to a failing API, got %s", decision)
+	}
+}
+
+func TestRule_ValidateLines_ComparesWithPreviousVersion(t *testing.T) {
+	filePath := "dataproducts/source/analytics/sandbox/pii_masking.yaml"
+	policy := func(unmasked, hashed string) string {
+		content := `kind: MaskingPolicy
+name: analytics_pii_string_policy
+data_product: analytics
+datatype: string
+mask: "==MASKED=="
+cases:
+`
+		if unmasked != "" {
+			content += "  - strategy: UNMASKED\n    consumers:\n      - kind: consumer_group\n        name: " + unmasked + "\n"
+		}
+		if hashed != "" {
+			content += "  - strategy: HASH_SHA1\n    consumers:\n      - kind: consumer_group\n        name: " + hashed + "\n"
+		}
+		return content
+	}
+
+	tests := []struct {
+		name             string
+		oldContent       string
+		newContent       string
+		newFile          bool
+		expectedDecision shared.DecisionType
+		reasonContains   string
+	}{
+		{
+			name:             "unmasked consumer added",
+			oldContent:       policy("", "dataverse-source-analytics"),
+			newContent:       policy("dataverse-consumer-analytics-marts", "dataverse-source-analytics"),
+			expectedDecision: shared.ManualReview,
+			reasonContains:   "consumer_group 'dataverse-consumer-analytics-marts' changes from masked to UNMASKED",
+		},
+		{
+			name:             "hashed consumer unmasked",
+			oldContent:       policy("", "dataverse-source-analytics"),
+			newContent:       policy("dataverse-source-analytics", ""),
+			expectedDecision: shared.ManualReview,
+			reasonContains:   "changes from HASH_SHA1 to UNMASKED",
+		},
+		{
+			name:             "unmasked consumer removed",
+			oldContent:       policy("dataverse-consumer-analytics-marts", "dataverse-source-analytics"),
+			newContent:       policy("", "dataverse-source-analytics"),
+			expectedDecision: shared.Approve,
+		},
+		{
+			name:             "unmasked consumer hashed",
+			oldContent:       policy("dataverse-source-analytics", ""),
+			newContent:       policy("", "dataverse-source-analytics"),
+			expectedDecision: shared.Approve,
+		},
+		{
+			name:             "new policy",
+			newContent:       policy("dataverse-source-analytics", ""),
+			newFile:          true,
+			expectedDecision: shared.Approve,
+		},
+		{
+			name:             "unparsable previous version",
+			oldContent:       "cases: [",
+			newContent:       policy("dataverse-source-analytics", ""),
+			expectedDecision: shared.ManualReview,
+			reasonContains:   "Could not compare masking policy with its previous version",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := NewMockGitLabClient()
+			mockClient.AddExistingFile("dataproducts/source/analytics/groups/dataverse-source-analytics.yaml")
+			mockClient.AddExistingFile("dataproducts/source/analytics/groups/dataverse-consumer-analytics-marts.yaml")
+			if !tt.newFile {
+				mockClient.AddFileContent(filePath, tt.oldContent)
+			}
+
+			rule := NewRule(mockClient)
+			rule.SetMRContext(&shared.MRContext{
+				ProjectID: 123,
+				MRInfo:    &gitlab.MRInfo{TargetBranch: "main"},
+				Changes:   []gitlab.FileChange{{OldPath: filePath, NewPath: filePath, NewFile: tt.newFile}},
+			})
+
+			decision, reason := rule.ValidateLines(filePath, tt.newContent, nil)
+			if decision != tt.expectedDecision {
+				t.Errorf("expected %s, got %s: %s", tt.expectedDecision, decision, reason)
+			}
+			if !strings.Contains(reason, tt.reasonContains) {
+				t.Errorf("expected reason to contain '%s', got: %s", tt.reasonContains, reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"go.uber.org/zap"
+)
+
+// projectGetter is implemented by clients that can look up projects
+type projectGetter interface {
+	GetProject(ctx context.Context, projectID int) (*gitlab.Project, error)
+}
+
+// findProjectOverride returns the first project override matching the project of the MR,
+// or nil. Path globs are matched against the project path, looked up only when needed.
+func (srm *SectionRuleManager) findProjectOverride(ctx context.Context, projectID int) *config.ProjectRuleConfig {
+	var projectPath string
+	pathResolved := false
+	for i := range srm.config.Projects {
+		project := &srm.config.Projects[i]
+		for _, id := range project.ProjectIDs {
+			if id == projectID {
+				return project
+			}
+		}
+		if len(project.Paths) == 0 {
+			continue
+		}
+		if !pathResolved {
+			projectPath = srm.projectPath(ctx, projectID)
+			pathResolved = true
+		}
+		if projectPath != "" && shared.MatchesAnyPattern(projectPath, project.Paths) {
+			return project
+		}
+	}
+	return nil
+}
+
+// projectPath returns the path with namespace of a project, or "" when it cannot be looked up
+func (srm *SectionRuleManager) projectPath(ctx context.Context, projectID int) string {
+	getter, ok := srm.gitlabClient.(projectGetter)
+	if !ok {
+		return ""
+	}
+	project, err := getter.GetProject(ctx, projectID)
+	if err != nil {
+		logging.Warn("Failed to look up project for project rule overrides", zap.Int("project_id", projectID), zap.Error(err))
+		return ""
+	}
+	return project.PathWithNamespace
+}
+
+// managerForProject returns the manager evaluating MRs of projects matching override,
+// built on first use from the configuration with the override applied
+func (srm *SectionRuleManager) managerForProject(override *config.ProjectRuleConfig) *SectionRuleManager {
+	srm.projectMu.Lock()
+	defer srm.projectMu.Unlock()
+
+	if manager, ok := srm.projectManagers[override.Name]; ok {
+		return manager
+	}
+	manager := NewSectionRuleManager(srm.config.ForProject(*override), srm.gitlabClient)
+	manager.project = override
+	manager.schedules = srm.schedules
+	manager.now = srm.now
+	for _, rule := range srm.rules {
+		manager.AddRule(rule)
+	}
+	srm.projectManagers[override.Name] = manager
+	return manager
+}
+
+// environmentAllowed reports whether a data product file targets one of the environments
+// allowed by the project override. Files outside dataproducts/ are not environment-specific.
+func (srm *SectionRuleManager) environmentAllowed(filePath string) bool {
+	if srm.project == nil || len(srm.project.AllowedEnvironments) == 0 {
+		return true
+	}
+	segments := dataProductSegments(filePath)
+	return segments == nil || targetsEnvironment(segments, srm.project.AllowedEnvironments)
+}
+
+// dataProductSegments returns the lowercased directories of a file below dataproducts/, one
+// of which names its environment, or nil for files outside dataproducts/
+func dataProductSegments(filePath string) []string {
+	segments := strings.Split(strings.ToLower(filePath), "/")
+	for i, segment := range segments[:len(segments)-1] {
+		if segment == "dataproducts" {
+			return segments[i+1 : len(segments)-1]
+		}
+	}
+	return nil
+}
+
+// targetsEnvironment reports whether any directory names one of the environments
+func targetsEnvironment(segments, environments []string) bool {
+	for _, segment := range segments {
+		for _, env := range environments {
+			if segment == strings.ToLower(env) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// applyProjectAutoApprove turns an approval into a manual review when the project
+// override disables auto-approval
+func (srm *SectionRuleManager) applyProjectAutoApprove(decision shared.Decision) shared.Decision {
+	if srm.project == nil || !srm.project.AutoApproveDisabled() || decision.Type != shared.Approve {
+		return decision
+	}
+	details := decision.Reason
+	if decision.Details != "" {
+		details += ". " + decision.Details
+	}
+	return shared.Decision{
+		Type:    shared.ManualReview,
+		Reason:  fmt.Sprintf("Auto-approval is disabled for this project by project override '%s'", srm.project.Name),
+		Summary: "⚠️ Manual review required",
+		Details: details,
+	}
+}
@@ -0,0 +1,151 @@
+package rules
+
+import (
+	"context"
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+// projectPathTestClient resolves every project to the same path
+type projectPathTestClient struct {
+	*forkMRTestGitLabClient
+	path  string
+	calls int
+}
+
+func (c *projectPathTestClient) GetProject(ctx context.Context, projectID int) (*gitlab.Project, error) {
+	c.calls++
+	return &gitlab.Project{ID: projectID, PathWithNamespace: c.path}, nil
+}
+
+func projectOverrideTestConfig() *config.GlobalRuleConfig {
+	disabled := false
+	cfg := deletionPolicyTestConfig()
+	cfg.Files = []config.FileRuleConfig{{
+		Name: "product_configs", Path: "dataproducts/**/", Filename: "product.{yaml,yml}", ParserType: "yaml", Enabled: true,
+		Sections: []config.SectionDefinition{{
+			Name: "warehouses", YAMLPath: "warehouses",
+			RuleConfigs: []config.RuleConfig{{Name: "warehouse_rule", Enabled: true}, {Name: "metadata_rule", Enabled: false}},
+		}},
+	}}
+	cfg.Projects = []config.ProjectRuleConfig{
+		{
+			Name:       "sandbox",
+			ProjectIDs: []int{42},
+			Rules: []config.RuleConfig{
+				{Name: "warehouse_rule", Enabled: false},
+				{Name: "metadata_rule", Enabled: true, Environments: map[string]string{"prod": "warn"}},
+			},
+		},
+		{
+			Name:                "analytics",
+			Paths:               []string{"data/analytics-*"},
+			AllowedEnvironments: []string{"dev", "preprod"},
+			AutoApprove:         &disabled,
+		},
+	}
+	return cfg
+}
+
+func TestProjectOverride_MatchesByIDThenPath(t *testing.T) {
+	client := &projectPathTestClient{forkMRTestGitLabClient: &forkMRTestGitLabClient{}, path: "data/analytics-config"}
+	manager := NewSectionRuleManager(projectOverrideTestConfig(), client)
+
+	override := manager.findProjectOverride(context.Background(), 42)
+	if assert.NotNil(t, override) {
+		assert.Equal(t, "sandbox", override.Name)
+	}
+	assert.Equal(t, 0, client.calls, "ID matches need no project lookup")
+
+	override = manager.findProjectOverride(context.Background(), 7)
+	if assert.NotNil(t, override) {
+		assert.Equal(t, "analytics", override.Name)
+	}
+	assert.Equal(t, 1, client.calls)
+
+	client.path = "data/dataverse-config"
+	assert.Nil(t, manager.findProjectOverride(context.Background(), 7))
+	assert.Nil(t, NewSectionRuleManager(projectOverrideTestConfig(), nil).findProjectOverride(context.Background(), 7), "paths cannot match without a client")
+}
+
+func TestProjectOverride_RuleOverrides(t *testing.T) {
+	manager := NewSectionRuleManager(projectOverrideTestConfig(), nil)
+	manager.AddRule(&MockRule{name: "warehouse_rule"})
+	manager.AddRule(&MockRule{name: "metadata_rule"})
+
+	ruleConfigs := manager.config.Files[0].Sections[0].RuleConfigs
+	enabled := manager.getEnabledRulesForSection(ruleConfigs)
+	if assert.Len(t, enabled, 1) {
+		assert.Equal(t, "warehouse_rule", enabled[0].Name())
+	}
+
+	sandbox := manager.managerForProject(manager.findProjectOverride(context.Background(), 42))
+	enabled = sandbox.getEnabledRulesForSection(sandbox.config.Files[0].Sections[0].RuleConfigs)
+	if assert.Len(t, enabled, 1) {
+		assert.Equal(t, "metadata_rule", enabled[0].Name())
+	}
+	assert.Same(t, sandbox, manager.managerForProject(manager.findProjectOverride(context.Background(), 42)))
+	assert.Equal(t, "warn", sandbox.config.Files[0].Sections[0].RuleConfigs[1].EnvironmentBehavior("PROD"))
+	assert.True(t, manager.config.Files[0].Sections[0].RuleConfigs[0].Enabled, "base configuration is unchanged")
+	assert.Empty(t, manager.config.Files[0].Sections[0].RuleConfigs[1].EnvironmentBehavior("prod"))
+	assert.Empty(t, sandbox.config.Projects)
+}
+
+func TestProjectOverride_AllowedEnvironments(t *testing.T) {
+	manager := NewSectionRuleManager(projectOverrideTestConfig(), nil)
+	analytics := &manager.config.Projects[1]
+	project := manager.managerForProject(analytics)
+
+	assert.True(t, project.environmentAllowed("dataproducts/source/sales/dev/product.yaml"))
+	assert.True(t, project.environmentAllowed("dataproducts/source/sales/PreProd/product.yaml"))
+	assert.False(t, project.environmentAllowed("dataproducts/source/sales/prod/product.yaml"))
+	assert.True(t, project.environmentAllowed(".gitlab-ci.yml"), "files outside dataproducts/ have no environment")
+	assert.True(t, manager.environmentAllowed("dataproducts/source/sales/prod/product.yaml"), "the base manager allows every environment")
+}
+
+func TestProjectOverride_DisablesAutoApproval(t *testing.T) {
+	client := &projectPathTestClient{forkMRTestGitLabClient: &forkMRTestGitLabClient{}, path: "data/analytics-config"}
+	manager := NewSectionRuleManager(projectOverrideTestConfig(), client)
+	mrCtx := &shared.MRContext{
+		ProjectID: 7,
+		MRIID:     1,
+		Changes:   []gitlab.FileChange{{OldPath: "docs/old.md", NewPath: "docs/old.md", DeletedFile: true}},
+		MRInfo:    &gitlab.MRInfo{Title: "Remove docs", Author: "developer", SourceBranch: "feature"},
+	}
+
+	result := manager.EvaluateAll(mrCtx)
+	assert.Equal(t, shared.ManualReview, result.FinalDecision.Type)
+	assert.Equal(t, "Auto-approval is disabled for this project by project override 'analytics'", result.FinalDecision.Reason)
+
+	client.path = "data/dataverse-config"
+	result = manager.EvaluateAll(mrCtx)
+	assert.Equal(t, shared.Approve, result.FinalDecision.Type)
+}
+
+func TestValidateRuleConfig_Projects(t *testing.T) {
+	base := func(projects ...config.ProjectRuleConfig) *config.GlobalRuleConfig {
+		return &config.GlobalRuleConfig{
+			Files: []config.FileRuleConfig{{
+				Name: "docs", Path: "**/", Filename: "*.md", ParserType: "yaml",
+				Sections: []config.SectionDefinition{{Name: "full", YAMLPath: ".", AutoApprove: true}},
+			}},
+			Projects: projects,
+		}
+	}
+
+	assert.NoError(t, config.ValidateRuleConfig(base(config.ProjectRuleConfig{Name: "a", ProjectIDs: []int{1}})))
+	assert.NoError(t, config.ValidateRuleConfig(base(config.ProjectRuleConfig{Name: "a", Paths: []string{"data/**"}})))
+	assert.Error(t, config.ValidateRuleConfig(base(config.ProjectRuleConfig{ProjectIDs: []int{1}})))
+	assert.Error(t, config.ValidateRuleConfig(base(config.ProjectRuleConfig{Name: "a"})))
+	assert.Error(t, config.ValidateRuleConfig(base(config.ProjectRuleConfig{Name: "a", ProjectIDs: []int{0}})))
+	assert.Error(t, config.ValidateRuleConfig(base(config.ProjectRuleConfig{Name: "a", Paths: []string{"data/[a"}})))
+	assert.Error(t, config.ValidateRuleConfig(base(config.ProjectRuleConfig{Name: "a", ProjectIDs: []int{1}, Rules: []config.RuleConfig{{Enabled: true}}})))
+	assert.Error(t, config.ValidateRuleConfig(base(
+		config.ProjectRuleConfig{Name: "a", ProjectIDs: []int{1}},
+		config.ProjectRuleConfig{Name: "a", ProjectIDs: []int{2}},
+	)))
+}
@@ -8,11 +8,19 @@ import (
 	"github.com/redhat-data-and-ai/naysayer/internal/logging"
 	"github.com/redhat-data-and-ai/naysayer/internal/rules/codeowners"
 	"github.com/redhat-data-and-ai/naysayer/internal/rules/common"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/consumer_cycle"
 	"github.com/redhat-data-and-ai/naysayer/internal/rules/dataproduct_consumer"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/group_file"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/group_membership"
 	"github.com/redhat-data-and-ai/naysayer/internal/rules/masking"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/repo_settings"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/serviceaccount"
 	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/sourcebinding"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/tag"
 	"github.com/redhat-data-and-ai/naysayer/internal/rules/toc_approval"
 	"github.com/redhat-data-and-ai/naysayer/internal/rules/warehouse"
+	"go.uber.org/zap"
 )
 
 // RuleFactory is a function that creates a rule instance
@@ -56,7 +64,8 @@ func (r *RuleRegistry) registerBuiltInRules() {
 		Description: "Auto-approves MRs with only dataverse-safe files (warehouse/sourcebinding), requires manual review for warehouse increases",
 		Version:     "1.0.0",
 		Factory: func(client gitlab.GitLabClient) shared.Rule {
-			return warehouse.NewRule(client)
+			cfg := config.Load()
+			return warehouse.NewRule(client, warehouse.NewSizePolicy(cfg.Rules.WarehouseRule))
 		},
 		Enabled:  true,
 		Category: "warehouse",
@@ -85,6 +94,32 @@ func (r *RuleRegistry) registerBuiltInRules() {
 		Category: "service_account",
 	})
 
+	// Service account definition rule
+	_ = r.RegisterRule(&RuleInfo{
+		Name:        serviceaccount.RuleName,
+		Description: "Validates serviceaccounts/<env>/<name>.yaml naming, environment folder and required fields, requires manual review for elevated role grants",
+		Version:     "1.0.0",
+		Factory: func(client gitlab.GitLabClient) shared.Rule {
+			cfg := config.Load()
+			return serviceaccount.NewRule(client, cfg.Rules.ServiceAccountRule.ElevatedRoles)
+		},
+		Enabled:  true,
+		Category: "service_account",
+	})
+
+	// Source binding rule
+	_ = r.RegisterRule(&RuleInfo{
+		Name:        sourcebinding.RuleName,
+		Description: "Validates sourcebinding.yaml schemas, tables, environments and privileges, requires manual review for new bindings to other data products",
+		Version:     "1.0.0",
+		Factory: func(client gitlab.GitLabClient) shared.Rule {
+			cfg := config.Load()
+			return sourcebinding.NewRule(client, cfg.Rules.SourceBindingRule.AllowedPrivileges)
+		},
+		Enabled:  true,
+		Category: "source_binding",
+	})
+
 	_ = r.RegisterRule(&RuleInfo{
 		Name:        "toc_approval_rule",
 		Description: "Requires TOC approval for new product.yaml files in preprod/prod environments",
@@ -112,6 +147,18 @@ func (r *RuleRegistry) registerBuiltInRules() {
 		Category: "consumer_access",
 	})
 
+	// Consumer cycle rule
+	_ = r.RegisterRule(&RuleInfo{
+		Name:        consumer_cycle.RuleName,
+		Description: "Requires manual review when a new data product consumer creates a circular dependency",
+		Version:     "1.0.0",
+		Factory: func(client gitlab.GitLabClient) shared.Rule {
+			return consumer_cycle.NewRule(client)
+		},
+		Enabled:  true,
+		Category: "consumer_access",
+	})
+
 	// CODEOWNERS sync rule
 	_ = r.RegisterRule(&RuleInfo{
 		Name:        "codeowners_sync_rule",
@@ -124,10 +171,47 @@ func (r *RuleRegistry) registerBuiltInRules() {
 		Category: "codeowners",
 	})
 
+	// Repository settings rule
+	_ = r.RegisterRule(&RuleInfo{
+		Name:        repo_settings.RuleName,
+		Description: "Requires manual review for CODEOWNERS, approval rules and .gitlab-ci.yml changes that weaken review requirements",
+		Version:     "1.0.0",
+		Factory: func(client gitlab.GitLabClient) shared.Rule {
+			return repo_settings.NewRule(client)
+		},
+		Enabled:  true,
+		Category: "security",
+	})
+
+	// Group membership rule
+	_ = r.RegisterRule(&RuleInfo{
+		Name:        group_membership.RuleName,
+		Description: "Summarizes groups/*.yaml membership changes, requires manual review when elevated roles are added",
+		Version:     "1.0.0",
+		Factory: func(client gitlab.GitLabClient) shared.Rule {
+			cfg := config.Load()
+			return group_membership.NewRule(client, cfg.Rules.GroupMembershipRule.ElevatedRoles)
+		},
+		Enabled:  true,
+		Category: "access",
+	})
+
+	// Group file rule
+	_ = r.RegisterRule(&RuleInfo{
+		Name:        group_file.RuleName,
+		Description: "Validates group names and membership lists of groups/*.yaml files, requires manual review when a group still referenced by masking policies or product.yaml consumers is deleted",
+		Version:     "1.0.0",
+		Factory: func(client gitlab.GitLabClient) shared.Rule {
+			return group_file.NewRule(client)
+		},
+		Enabled:  true,
+		Category: "access",
+	})
+
 	// Masking policy rule
 	_ = r.RegisterRule(&RuleInfo{
 		Name:        "masking_policy_rule",
-		Description: "Validates masking policy configurations - auto-approves valid policies, requires manual review for invalid configurations",
+		Description: "Validates masking policy configurations - auto-approves valid policies, requires manual review for invalid configurations or weakened protection",
 		Version:     "1.0.0",
 		Factory: func(client gitlab.GitLabClient) shared.Rule {
 			return masking.NewRule(client)
@@ -136,6 +220,18 @@ func (r *RuleRegistry) registerBuiltInRules() {
 		Category: "masking",
 	})
 
+	// Tag rule
+	_ = r.RegisterRule(&RuleInfo{
+		Name:        tag.RuleName,
+		Description: "Validates Tag files (tag_*masking.yaml) - naming, allowed values, data product path and that referenced masking policies exist in the same data product",
+		Version:     "1.0.0",
+		Factory: func(client gitlab.GitLabClient) shared.Rule {
+			return tag.NewRule(client)
+		},
+		Enabled:  true,
+		Category: "masking",
+	})
+
 }
 
 // RegisterRule registers a new rule in the registry
@@ -153,7 +249,10 @@ func (r *RuleRegistry) RegisterRule(info *RuleInfo) error {
 	}
 
 	r.rules[info.Name] = info
-	logging.Info("Registered rule: %s (category: %s, enabled: %t)", info.Name, info.Category, info.Enabled)
+	logging.Info("Registered rule",
+		zap.String("rule", info.Name),
+		zap.String("category", info.Category),
+		zap.Bool("enabled", info.Enabled))
 
 	return nil
 }
@@ -201,7 +300,7 @@ func (r *RuleRegistry) CreateRuleManager(client gitlab.GitLabClient, ruleNames [
 	// Load default rule configuration for section-based validation
 	ruleConfig, err := config.LoadRuleConfig("rules.yaml")
 	if err != nil {
-		logging.Warn("Failed to load rule config, using minimal configuration: %v", err)
+		logging.Warn("Failed to load rule config, using minimal configuration", zap.Error(err))
 		// Create minimal config for fallback
 		ruleConfig = &config.GlobalRuleConfig{
 			Enabled: true,
@@ -216,7 +315,7 @@ func (r *RuleRegistry) CreateRuleManager(client gitlab.GitLabClient, ruleNames [
 		for _, info := range r.ListEnabledRules() {
 			rule := info.Factory(client)
 			manager.AddRule(rule)
-			logging.Info("Added enabled rule: %s", info.Name)
+			logging.Info("Added enabled rule", zap.String("rule", info.Name))
 		}
 	} else {
 		// Add only specified rules from the list
@@ -227,7 +326,7 @@ func (r *RuleRegistry) CreateRuleManager(client gitlab.GitLabClient, ruleNames [
 			}
 			rule := info.Factory(client)
 			manager.AddRule(rule)
-			logging.Info("Added requested rule: %s", info.Name)
+			logging.Info("Added requested rule", zap.String("rule", info.Name))
 		}
 	}
 
@@ -243,7 +342,7 @@ func (r *RuleRegistry) CreateDataverseRuleManager(client gitlab.GitLabClient) sh
 
 	manager, err := r.CreateRuleManager(client, dataverseRules)
 	if err != nil {
-		logging.Error("Error creating dataverse rule manager: %v", err)
+		logging.Error("Error creating dataverse rule manager", zap.Error(err))
 		// Fallback to empty section manager
 		ruleConfig := &config.GlobalRuleConfig{
 			Enabled: true,
@@ -275,10 +374,10 @@ func (r *RuleRegistry) CreateSectionBasedRuleManager(client gitlab.GitLabClient,
 	for _, info := range r.ListEnabledRules() {
 		rule := info.Factory(client)
 		sectionManager.AddRule(rule)
-		logging.Info("Added rule to section manager: %s", info.Name)
+		logging.Info("Added rule to section manager", zap.String("rule", info.Name))
 	}
 
-	logging.Info("Created section-based rule manager with %d file configurations", len(ruleConfig.Files))
+	logging.Info("Created section-based rule manager", zap.Int("file_configurations", len(ruleConfig.Files)))
 	return sectionManager, nil
 }
 
@@ -0,0 +1,103 @@
+package repo_settings
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ApprovalRule is one entry of approval_rules, mirroring GitLab's project approval rules
+type ApprovalRule struct {
+	Name              string   `yaml:"name"`
+	ApprovalsRequired int      `yaml:"approvals_required"`
+	Users             []string `yaml:"users"`
+	Groups            []string `yaml:"groups"`
+}
+
+// ApprovalSettings mirrors GitLab's project-level merge request approval settings
+type ApprovalSettings struct {
+	ApprovalsBeforeMerge int             `yaml:"approvals_before_merge"`
+	Rules                []ApprovalRule  `yaml:"approval_rules"`
+	Flags                map[string]bool `yaml:"-"`
+}
+
+// strictApprovalFlags maps approval setting flags to the value that keeps review strict
+var strictApprovalFlags = map[string]bool{
+	"reset_approvals_on_push":                        true,
+	"selective_code_owner_removals":                  true,
+	"disable_overriding_approvers_per_merge_request": true,
+	"merge_requests_disable_committers_approval":     true,
+	"require_password_to_approve":                    true,
+	"require_reauthentication_to_approve":            true,
+	"merge_requests_author_approval":                 false,
+}
+
+// ParseApprovalSettings parses an approval rules configuration file
+func ParseApprovalSettings(content string) (*ApprovalSettings, error) {
+	settings := &ApprovalSettings{}
+	if err := yaml.Unmarshal([]byte(content), settings); err != nil {
+		return nil, fmt.Errorf("failed to parse approval settings: %w", err)
+	}
+
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal([]byte(content), &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse approval settings: %w", err)
+	}
+	settings.Flags = make(map[string]bool)
+	for flag := range strictApprovalFlags {
+		if value, ok := raw[flag].(bool); ok {
+			settings.Flags[flag] = value
+		}
+	}
+
+	return settings, nil
+}
+
+// ApprovalRuleWeakenings lists changes that lower approval requirements: removed approval rules,
+// lowered approval counts and approval flags switched away from their strict value
+func ApprovalRuleWeakenings(oldContent, newContent string) ([]Weakening, error) {
+	oldSettings, err := ParseApprovalSettings(oldContent)
+	if err != nil {
+		return nil, err
+	}
+	newSettings, err := ParseApprovalSettings(newContent)
+	if err != nil {
+		return nil, err
+	}
+
+	var weakenings []Weakening
+	add := func(format string, args ...interface{}) {
+		weakenings = append(weakenings, Weakening{Kind: KindApprovalRules, Detail: fmt.Sprintf(format, args...)})
+	}
+
+	if newSettings.ApprovalsBeforeMerge < oldSettings.ApprovalsBeforeMerge {
+		add("approvals_before_merge lowered from %d to %d", oldSettings.ApprovalsBeforeMerge, newSettings.ApprovalsBeforeMerge)
+	}
+
+	newRules := make(map[string]ApprovalRule, len(newSettings.Rules))
+	for _, rule := range newSettings.Rules {
+		newRules[rule.Name] = rule
+	}
+	for _, oldRule := range oldSettings.Rules {
+		newRule, exists := newRules[oldRule.Name]
+		switch {
+		case !exists:
+			add("approval rule '%s' removed", oldRule.Name)
+		case newRule.ApprovalsRequired < oldRule.ApprovalsRequired:
+			add("approvals required by rule '%s' lowered from %d to %d", oldRule.Name, oldRule.ApprovalsRequired, newRule.ApprovalsRequired)
+		}
+	}
+
+	for _, flag := range sortedKeys(strictApprovalFlags) {
+		strict := strictApprovalFlags[flag]
+		oldValue, wasSet := oldSettings.Flags[flag]
+		if !wasSet || oldValue != strict {
+			continue
+		}
+		if newValue, isSet := newSettings.Flags[flag]; !isSet || newValue != strict {
+			add("%s no longer %t", flag, strict)
+		}
+	}
+
+	return weakenings, nil
+}
@@ -0,0 +1,82 @@
+package repo_settings
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const baseApprovalRules = `approvals_before_merge: 2
+reset_approvals_on_push: true
+merge_requests_author_approval: false
+approval_rules:
+  - name: Security
+    approvals_required: 2
+    groups: [security-team]
+  - name: Data Governance
+    approvals_required: 1
+    users: [alice]
+`
+
+func TestApprovalRuleWeakenings(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected []string
+	}{
+		{
+			name:     "unchanged",
+			content:  baseApprovalRules,
+			expected: nil,
+		},
+		{
+			name: "raising requirements is not a weakening",
+			content: `approvals_before_merge: 3
+reset_approvals_on_push: true
+merge_requests_author_approval: false
+approval_rules:
+  - name: Security
+    approvals_required: 3
+  - name: Data Governance
+    approvals_required: 1
+  - name: New Rule
+    approvals_required: 1
+`,
+			expected: nil,
+		},
+		{
+			name: "counts lowered, rule removed and flags relaxed",
+			content: `approvals_before_merge: 1
+merge_requests_author_approval: true
+approval_rules:
+  - name: Security
+    approvals_required: 1
+`,
+			expected: []string{
+				"approvals_before_merge lowered from 2 to 1",
+				"approvals required by rule 'Security' lowered from 2 to 1",
+				"approval rule 'Data Governance' removed",
+				"merge_requests_author_approval no longer false",
+				"reset_approvals_on_push no longer true",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			weakenings, err := ApprovalRuleWeakenings(baseApprovalRules, tt.content)
+			assert.NoError(t, err)
+			var details []string
+			for _, w := range weakenings {
+				assert.Equal(t, KindApprovalRules, w.Kind)
+				details = append(details, w.Detail)
+			}
+			assert.Equal(t, tt.expected, details)
+		})
+	}
+}
+
+func TestApprovalRuleWeakenings_InvalidYAML(t *testing.T) {
+	_, err := ApprovalRuleWeakenings(baseApprovalRules, "approval_rules: [")
+	assert.Error(t, err)
+}
@@ -0,0 +1,91 @@
+package repo_settings
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ciReservedKeys are top-level .gitlab-ci.yml keywords that are not jobs
+var ciReservedKeys = map[string]bool{
+	"stages":        true,
+	"variables":     true,
+	"include":       true,
+	"default":       true,
+	"workflow":      true,
+	"image":         true,
+	"services":      true,
+	"before_script": true,
+	"after_script":  true,
+	"cache":         true,
+	"types":         true,
+}
+
+// ParseCIJobs returns the jobs defined in .gitlab-ci.yml content, keyed by job name.
+// Hidden jobs (templates starting with ".") are not run and are skipped.
+func ParseCIJobs(content string) (map[string]map[string]interface{}, error) {
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal([]byte(content), &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse CI configuration: %w", err)
+	}
+
+	jobs := make(map[string]map[string]interface{})
+	for name, value := range raw {
+		if ciReservedKeys[name] || strings.HasPrefix(name, ".") {
+			continue
+		}
+		if job, ok := value.(map[string]interface{}); ok {
+			jobs[name] = job
+		}
+	}
+	return jobs, nil
+}
+
+// isNaysayerJob reports whether a CI job runs naysayer, by name or by any reference in its definition
+func isNaysayerJob(name string, job map[string]interface{}) bool {
+	if strings.Contains(strings.ToLower(name), "naysayer") {
+		return true
+	}
+	definition, err := yaml.Marshal(job)
+	return err == nil && strings.Contains(strings.ToLower(string(definition)), "naysayer")
+}
+
+// CIWeakenings lists changes that disable naysayer checks in CI: removed naysayer jobs and
+// naysayer jobs that are allowed to fail or no longer run automatically
+func CIWeakenings(oldContent, newContent string) ([]Weakening, error) {
+	oldJobs, err := ParseCIJobs(oldContent)
+	if err != nil {
+		return nil, err
+	}
+	newJobs, err := ParseCIJobs(newContent)
+	if err != nil {
+		return nil, err
+	}
+
+	var weakenings []Weakening
+	add := func(format string, args ...interface{}) {
+		weakenings = append(weakenings, Weakening{Kind: KindCI, Detail: fmt.Sprintf(format, args...)})
+	}
+
+	for _, name := range sortedKeys(oldJobs) {
+		oldJob := oldJobs[name]
+		if !isNaysayerJob(name, oldJob) {
+			continue
+		}
+
+		newJob, exists := newJobs[name]
+		if !exists {
+			add("naysayer CI job '%s' removed", name)
+			continue
+		}
+		if newJob["allow_failure"] == true && oldJob["allow_failure"] != true {
+			add("naysayer CI job '%s' allowed to fail", name)
+		}
+		if when, _ := newJob["when"].(string); (when == "manual" || when == "never") && oldJob["when"] != when {
+			add("naysayer CI job '%s' changed to when: %s", name, when)
+		}
+	}
+
+	return weakenings, nil
+}
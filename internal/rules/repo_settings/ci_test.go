@@ -0,0 +1,85 @@
+package repo_settings
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const baseCI = `stages: [validate, deploy]
+
+.template:
+  image: alpine
+
+naysayer-check:
+  stage: validate
+  script: ./scripts/notify.sh
+
+validate-dataproducts:
+  stage: validate
+  script: curl -X POST https://naysayer.example.com/dataverse-product-config-review
+
+deploy:
+  stage: deploy
+  script: ./deploy.sh
+`
+
+func TestParseCIJobs(t *testing.T) {
+	jobs, err := ParseCIJobs(baseCI)
+	assert.NoError(t, err)
+	assert.Len(t, jobs, 3)
+	assert.Contains(t, jobs, "naysayer-check")
+	assert.NotContains(t, jobs, "stages")
+	assert.NotContains(t, jobs, ".template")
+}
+
+func TestCIWeakenings(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected []string
+	}{
+		{
+			name:     "unchanged",
+			content:  baseCI,
+			expected: nil,
+		},
+		{
+			name: "removing unrelated job is not a weakening",
+			content: `naysayer-check:
+  script: ./scripts/notify.sh
+validate-dataproducts:
+  script: curl -X POST https://naysayer.example.com/dataverse-product-config-review
+`,
+			expected: nil,
+		},
+		{
+			name: "naysayer jobs removed or disabled",
+			content: `naysayer-check:
+  script: ./scripts/notify.sh
+  allow_failure: true
+  when: manual
+deploy:
+  script: ./deploy.sh
+`,
+			expected: []string{
+				"naysayer CI job 'naysayer-check' allowed to fail",
+				"naysayer CI job 'naysayer-check' changed to when: manual",
+				"naysayer CI job 'validate-dataproducts' removed",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			weakenings, err := CIWeakenings(baseCI, tt.content)
+			assert.NoError(t, err)
+			var details []string
+			for _, w := range weakenings {
+				assert.Equal(t, KindCI, w.Kind)
+				details = append(details, w.Detail)
+			}
+			assert.Equal(t, tt.expected, details)
+		})
+	}
+}
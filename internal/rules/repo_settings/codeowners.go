@@ -0,0 +1,146 @@
+package repo_settings
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// sectionHeaderPattern matches "[Name]", "^[Name]" and "[Name][N]" with optional default owners
+var sectionHeaderPattern = regexp.MustCompile(`^(\^)?\[([^\]]+)\](?:\[(\d+)\])?\s*(.*)$`)
+
+// ParseCODEOWNERS parses CODEOWNERS content into sections and path rules.
+// Entries without owners inherit the default owners of their section.
+func ParseCODEOWNERS(content string) *CODEOWNERSFile {
+	file := &CODEOWNERSFile{
+		Sections: make(map[string]CODEOWNERSSection),
+		Rules:    make(map[string]CODEOWNERSRule),
+	}
+
+	section := ""
+	var defaultOwners []string
+
+	for _, rawLine := range strings.Split(content, "\n") {
+		line := strings.TrimSpace(rawLine)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if match := sectionHeaderPattern.FindStringSubmatch(line); match != nil {
+			approvals := 1
+			if match[3] != "" {
+				if n, err := strconv.Atoi(match[3]); err == nil {
+					approvals = n
+				}
+			}
+			section = match[2]
+			defaultOwners = strings.Fields(match[4])
+			file.Sections[strings.ToLower(section)] = CODEOWNERSSection{
+				Name:      section,
+				Optional:  match[1] == "^",
+				Approvals: approvals,
+			}
+			continue
+		}
+
+		fields := strings.Fields(line)
+		owners := fields[1:]
+		if len(owners) == 0 {
+			owners = defaultOwners
+		}
+		file.Rules[ruleKey(section, fields[0])] = CODEOWNERSRule{
+			Section: section,
+			Pattern: fields[0],
+			Owners:  owners,
+		}
+	}
+
+	return file
+}
+
+// ruleKey identifies a path rule within its section; section names are case-insensitive
+func ruleKey(section, pattern string) string {
+	return strings.ToLower(section) + "\x00" + pattern
+}
+
+// CODEOWNERSWeakenings lists changes that lower CODEOWNERS review requirements: removed or
+// optional sections, lowered section approval counts, removed path rules and rules left with
+// fewer owners. Replacing an owner with another is not a weakening.
+func CODEOWNERSWeakenings(oldContent, newContent string) []Weakening {
+	oldFile := ParseCODEOWNERS(oldContent)
+	newFile := ParseCODEOWNERS(newContent)
+
+	var weakenings []Weakening
+	add := func(format string, args ...interface{}) {
+		weakenings = append(weakenings, Weakening{Kind: KindCODEOWNERS, Detail: fmt.Sprintf(format, args...)})
+	}
+
+	removedSections := make(map[string]bool)
+	for _, key := range sortedKeys(oldFile.Sections) {
+		oldSection := oldFile.Sections[key]
+		newSection, exists := newFile.Sections[key]
+		switch {
+		case !exists:
+			removedSections[key] = true
+			add("section [%s] removed", oldSection.Name)
+		case newSection.Optional && !oldSection.Optional:
+			add("section [%s] made optional", oldSection.Name)
+		case newSection.Approvals < oldSection.Approvals:
+			add("required approvals for section [%s] lowered from %d to %d", oldSection.Name, oldSection.Approvals, newSection.Approvals)
+		}
+	}
+
+	for _, key := range sortedKeys(oldFile.Rules) {
+		oldRule := oldFile.Rules[key]
+		if removedSections[strings.ToLower(oldRule.Section)] {
+			continue
+		}
+
+		newRule, exists := newFile.Rules[key]
+		if !exists {
+			add("ownership removed for %s%s", oldRule.Pattern, sectionSuffix(oldRule.Section))
+			continue
+		}
+		if len(newRule.Owners) < len(oldRule.Owners) {
+			add("owners removed from %s%s: %s", oldRule.Pattern, sectionSuffix(oldRule.Section),
+				strings.Join(missing(oldRule.Owners, newRule.Owners), ", "))
+		}
+	}
+
+	return weakenings
+}
+
+// sectionSuffix formats the section of a rule for messages
+func sectionSuffix(section string) string {
+	if section == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (section [%s])", section)
+}
+
+// missing returns the values of old that are not in current
+func missing(old, current []string) []string {
+	present := make(map[string]bool, len(current))
+	for _, value := range current {
+		present[value] = true
+	}
+	var result []string
+	for _, value := range old {
+		if !present[value] {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+// sortedKeys returns map keys in sorted order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
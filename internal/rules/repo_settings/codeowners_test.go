@@ -0,0 +1,106 @@
+package repo_settings
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const baseCODEOWNERS = `# Global owners
+* @platform-team
+
+[Data Products][2] @dataverse-admins
+/dataproducts/source/analytics/ @alice @bob
+/dataproducts/source/billing/
+
+^[Docs]
+/docs/ @writers
+`
+
+func TestParseCODEOWNERS(t *testing.T) {
+	file := ParseCODEOWNERS(baseCODEOWNERS)
+
+	assert.Equal(t, CODEOWNERSSection{Name: "Data Products", Approvals: 2}, file.Sections["data products"])
+	assert.Equal(t, CODEOWNERSSection{Name: "Docs", Optional: true, Approvals: 1}, file.Sections["docs"])
+
+	assert.Equal(t, []string{"@platform-team"}, file.Rules[ruleKey("", "*")].Owners)
+	assert.Equal(t, []string{"@alice", "@bob"}, file.Rules[ruleKey("Data Products", "/dataproducts/source/analytics/")].Owners)
+	// Entries without owners inherit the section default owners
+	assert.Equal(t, []string{"@dataverse-admins"}, file.Rules[ruleKey("Data Products", "/dataproducts/source/billing/")].Owners)
+}
+
+func TestCODEOWNERSWeakenings(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected []string
+	}{
+		{
+			name:     "unchanged",
+			content:  baseCODEOWNERS,
+			expected: nil,
+		},
+		{
+			name:     "adding owners is not a weakening",
+			content:  baseCODEOWNERS + "/dataproducts/source/new/ @carol\n",
+			expected: nil,
+		},
+		{
+			name: "replacing an owner is not a weakening",
+			content: `* @platform-team
+[Data Products][2] @dataverse-admins
+/dataproducts/source/analytics/ @alice @carol
+/dataproducts/source/billing/
+^[Docs]
+/docs/ @writers
+`,
+			expected: nil,
+		},
+		{
+			name: "owner removed and approvals lowered",
+			content: `* @platform-team
+[Data Products] @dataverse-admins
+/dataproducts/source/analytics/ @alice
+/dataproducts/source/billing/
+^[Docs]
+/docs/ @writers
+`,
+			expected: []string{
+				"required approvals for section [Data Products] lowered from 2 to 1",
+				"owners removed from /dataproducts/source/analytics/ (section [Data Products]): @bob",
+			},
+		},
+		{
+			name: "section made optional and rule removed",
+			content: `^[Data Products][2] @dataverse-admins
+/dataproducts/source/analytics/ @alice @bob
+/dataproducts/source/billing/
+^[Docs]
+/docs/ @writers
+`,
+			expected: []string{
+				"section [Data Products] made optional",
+				"ownership removed for *",
+			},
+		},
+		{
+			name: "section removed reports the section only",
+			content: `* @platform-team
+^[Docs]
+/docs/ @writers
+`,
+			expected: []string{"section [Data Products] removed"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var details []string
+			for _, w := range CODEOWNERSWeakenings(baseCODEOWNERS, tt.content) {
+				assert.Equal(t, KindCODEOWNERS, w.Kind)
+				details = append(details, w.Detail)
+			}
+			assert.Equal(t, tt.expected, details)
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package repo_settings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/common"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"go.uber.org/zap"
+)
+
+// RuleName is the identifier of the repository settings rule
+const RuleName = "repo_settings_rule"
+
+// FileFetcher is the subset of the GitLab client needed to load previous file versions
+type FileFetcher interface {
+	FetchFileContent(ctx context.Context, projectID int, filePath, ref string) (*gitlab.FileContent, error)
+}
+
+// Rule compares repository settings files (CODEOWNERS, approval rules, .gitlab-ci.yml) with
+// their target-branch version and requires manual review when review requirements are weakened.
+// CI and approval rule changes always require manual review; CODEOWNERS changes that do not
+// weaken ownership are left to the other rules configured for the file.
+type Rule struct {
+	*common.BaseRule
+	*common.ValidationHelper
+	client FileFetcher
+}
+
+// NewRule creates a new repository settings rule instance
+func NewRule(client FileFetcher) *Rule {
+	return &Rule{
+		BaseRule:         common.NewBaseRule(RuleName, "Requires manual review when CODEOWNERS, approval rules or CI changes weaken review requirements"),
+		ValidationHelper: common.NewValidationHelper(),
+		client:           client,
+	}
+}
+
+// FileKind classifies a repository settings file by name
+func FileKind(filePath string) string {
+	switch path.Base(filePath) {
+	case "CODEOWNERS":
+		return KindCODEOWNERS
+	case ".gitlab-ci.yml":
+		return KindCI
+	default:
+		return KindApprovalRules
+	}
+}
+
+// GetCoveredLines returns line ranges this rule covers
+func (r *Rule) GetCoveredLines(filePath string, fileContent string) []shared.LineRange {
+	return r.GetFullFileCoverage(filePath, fileContent)
+}
+
+// ValidateLines compares the file with its previous version and reports weakenings
+func (r *Rule) ValidateLines(filePath string, fileContent string, lineRanges []shared.LineRange) (shared.DecisionType, string) {
+	kind := FileKind(filePath)
+
+	oldContent, isNew, err := r.previousContent(filePath)
+	if err != nil {
+		return r.CreateManualReviewResult(fmt.Sprintf("Could not load previous version of %s: %v", filePath, err))
+	}
+
+	var weakenings []Weakening
+	if !isNew {
+		weakenings, err = Weakenings(kind, oldContent, fileContent)
+		if err != nil {
+			return r.CreateManualReviewResult(fmt.Sprintf("Could not compare %s with its previous version: %v", filePath, err))
+		}
+	}
+
+	if len(weakenings) > 0 {
+		details := make([]string, 0, len(weakenings))
+		for _, w := range weakenings {
+			details = append(details, w.Detail)
+		}
+		logging.Warn("Review requirements weakened", zap.String("file_path", filePath), zap.Strings("details", details))
+		return r.CreateManualReviewResult("Review requirements weakened: " + strings.Join(details, "; "))
+	}
+
+	if kind == KindCODEOWNERS {
+		return r.CreateApprovalResult("No weakening of code ownership")
+	}
+	if isNew {
+		return r.CreateManualReviewResult("New repository settings file - manual review required")
+	}
+	return r.CreateManualReviewResult("Repository settings change - manual review required (no weakening detected)")
+}
+
+// Weakenings compares two versions of a repository settings file of the given kind
+func Weakenings(kind, oldContent, newContent string) ([]Weakening, error) {
+	switch kind {
+	case KindCODEOWNERS:
+		return CODEOWNERSWeakenings(oldContent, newContent), nil
+	case KindCI:
+		return CIWeakenings(oldContent, newContent)
+	default:
+		return ApprovalRuleWeakenings(oldContent, newContent)
+	}
+}
+
+// previousContent fetches the file from the target branch; isNew is true for files added by the MR
+func (r *Rule) previousContent(filePath string) (content string, isNew bool, err error) {
+	mrCtx := r.GetMRContext()
+	if mrCtx == nil || mrCtx.MRInfo == nil {
+		return "", false, fmt.Errorf("MR context not available")
+	}
+
+	oldPath := filePath
+	for _, change := range mrCtx.Changes {
+		if change.NewPath != filePath {
+			continue
+		}
+		if change.NewFile {
+			return "", true, nil
+		}
+		if change.OldPath != "" {
+			oldPath = change.OldPath
+		}
+		break
+	}
+
+	if r.client == nil {
+		return "", false, fmt.Errorf("GitLab client not available")
+	}
+	fileContent, err := r.client.FetchFileContent(mrCtx.Context(), mrCtx.ProjectID, oldPath, mrCtx.MRInfo.TargetBranch)
+	if errors.Is(err, gitlab.ErrNotFound) {
+		return "", true, nil
+	}
+	if err != nil {
+		logging.Warn("Failed to fetch previous version", zap.String("old_path", oldPath), zap.Error(err))
+		return "", false, err
+	}
+	if fileContent == nil {
+		return "", false, fmt.Errorf("empty response when fetching %s", oldPath)
+	}
+	return fileContent.Content, false, nil
+}
@@ -0,0 +1,133 @@
+package repo_settings
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockFileFetcher serves file contents keyed by "ref:path"
+type mockFileFetcher struct {
+	files map[string]string
+	err   error
+}
+
+func (m *mockFileFetcher) FetchFileContent(ctx context.Context, projectID int, filePath, ref string) (*gitlab.FileContent, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	content, ok := m.files[ref+":"+filePath]
+	if !ok {
+		return nil, fmt.Errorf("file not found: %s: %w", filePath, gitlab.ErrNotFound)
+	}
+	return &gitlab.FileContent{FilePath: filePath, Content: content, Ref: ref}, nil
+}
+
+func newTestRule(fetcher *mockFileFetcher, change gitlab.FileChange) *Rule {
+	rule := NewRule(fetcher)
+	rule.SetMRContext(&shared.MRContext{
+		ProjectID: 1,
+		MRIID:     2,
+		Changes:   []gitlab.FileChange{change},
+		MRInfo:    &gitlab.MRInfo{SourceBranch: "feature", TargetBranch: "main"},
+	})
+	return rule
+}
+
+func TestFileKind(t *testing.T) {
+	assert.Equal(t, KindCODEOWNERS, FileKind(".gitlab/CODEOWNERS"))
+	assert.Equal(t, KindCODEOWNERS, FileKind("CODEOWNERS"))
+	assert.Equal(t, KindCI, FileKind(".gitlab-ci.yml"))
+	assert.Equal(t, KindApprovalRules, FileKind(".gitlab/approval_rules.yaml"))
+}
+
+func TestRule_ValidateLines(t *testing.T) {
+	files := map[string]string{
+		"main:.gitlab/CODEOWNERS":          baseCODEOWNERS,
+		"main:.gitlab-ci.yml":              baseCI,
+		"main:.gitlab/approval_rules.yaml": baseApprovalRules,
+	}
+
+	tests := []struct {
+		name             string
+		filePath         string
+		newContent       string
+		newFile          bool
+		fetchErr         error
+		expectedDecision shared.DecisionType
+		reasonContains   string
+	}{
+		{
+			name:             "CODEOWNERS without weakening passes",
+			filePath:         ".gitlab/CODEOWNERS",
+			newContent:       baseCODEOWNERS + "/dataproducts/source/new/ @carol\n",
+			expectedDecision: shared.Approve,
+			reasonContains:   "No weakening",
+		},
+		{
+			name:             "CODEOWNERS owner removal requires review",
+			filePath:         ".gitlab/CODEOWNERS",
+			newContent:       "[Data Products][2] @dataverse-admins\n/dataproducts/source/analytics/ @alice @bob\n/dataproducts/source/billing/\n^[Docs]\n/docs/ @writers\n",
+			expectedDecision: shared.ManualReview,
+			reasonContains:   "Review requirements weakened: ownership removed for *",
+		},
+		{
+			name:             "CODEOWNERS missing on target branch is treated as new",
+			filePath:         "docs/CODEOWNERS",
+			newContent:       "* @docs\n",
+			expectedDecision: shared.Approve,
+		},
+		{
+			name:             "CI change without weakening still requires review",
+			filePath:         ".gitlab-ci.yml",
+			newContent:       baseCI + "lint:\n  script: make lint\n",
+			expectedDecision: shared.ManualReview,
+			reasonContains:   "no weakening detected",
+		},
+		{
+			name:             "CI naysayer job removal is highlighted",
+			filePath:         ".gitlab-ci.yml",
+			newContent:       "deploy:\n  script: ./deploy.sh\n",
+			expectedDecision: shared.ManualReview,
+			reasonContains:   "naysayer CI job 'naysayer-check' removed",
+		},
+		{
+			name:             "approval count lowered is highlighted",
+			filePath:         ".gitlab/approval_rules.yaml",
+			newContent:       "approvals_before_merge: 0\n",
+			expectedDecision: shared.ManualReview,
+			reasonContains:   "approvals_before_merge lowered from 2 to 0",
+		},
+		{
+			name:             "new approval rules file requires review",
+			filePath:         ".gitlab/approval_rules.yml",
+			newContent:       baseApprovalRules,
+			newFile:          true,
+			expectedDecision: shared.ManualReview,
+			reasonContains:   "New repository settings file",
+		},
+		{
+			name:             "fetch failure requires review",
+			filePath:         ".gitlab/CODEOWNERS",
+			newContent:       baseCODEOWNERS,
+			fetchErr:         fmt.Errorf("connection refused"),
+			expectedDecision: shared.ManualReview,
+			reasonContains:   "Could not load previous version",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			change := gitlab.FileChange{OldPath: tt.filePath, NewPath: tt.filePath, NewFile: tt.newFile}
+			rule := newTestRule(&mockFileFetcher{files: files, err: tt.fetchErr}, change)
+
+			decision, reason := rule.ValidateLines(tt.filePath, tt.newContent, nil)
+			assert.Equal(t, tt.expectedDecision, decision)
+			assert.Contains(t, reason, tt.reasonContains)
+		})
+	}
+}
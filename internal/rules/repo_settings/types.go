@@ -0,0 +1,39 @@
+package repo_settings
+
+// Kinds of repository settings files handled by the rule
+const (
+	KindCODEOWNERS    = "codeowners"     // .gitlab/CODEOWNERS, CODEOWNERS, docs/CODEOWNERS
+	KindCI            = "ci"             // .gitlab-ci.yml
+	KindApprovalRules = "approval_rules" // Any other file routed to the rule (approval rule configuration)
+)
+
+// Weakening describes one change that lowers review requirements
+type Weakening struct {
+	Kind   string // File kind the weakening was found in
+	Detail string // Human-readable description, e.g. "owners removed from /docs/: @alice"
+}
+
+// String returns the weakening description
+func (w Weakening) String() string {
+	return w.Detail
+}
+
+// CODEOWNERSSection is a CODEOWNERS section header, e.g. "^[Docs][2] @docs-team"
+type CODEOWNERSSection struct {
+	Name      string
+	Optional  bool // Prefixed with ^ - approval not required
+	Approvals int  // Required approvals from [Section][N], 1 when omitted
+}
+
+// CODEOWNERSRule is one path entry of a CODEOWNERS file
+type CODEOWNERSRule struct {
+	Section string
+	Pattern string
+	Owners  []string
+}
+
+// CODEOWNERSFile is a parsed CODEOWNERS file
+type CODEOWNERSFile struct {
+	Sections map[string]CODEOWNERSSection
+	Rules    map[string]CODEOWNERSRule // Keyed by section and pattern
+}
@@ -0,0 +1,137 @@
+package rules
+
+import (
+	"fmt"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"go.uber.org/zap"
+)
+
+// defaultRuleTimeout bounds one rule validation when rules.yaml sets no rule_timeout_seconds
+const defaultRuleTimeout = 30 * time.Second
+
+// InternalRuleErrorReason starts the reason of validations that panicked or timed out
+const InternalRuleErrorReason = "internal rule error"
+
+// Rule failure kinds
+const (
+	RuleFailurePanic   = "panic"
+	RuleFailureTimeout = "timeout"
+)
+
+// guardedRule runs the validations of its rule on their own goroutine, so a rule that
+// panics or never returns fails only its own validation. Failures require manual review.
+// A timed-out validation keeps running in the background until the rule returns.
+type guardedRule struct {
+	shared.Rule
+	timeout time.Duration
+}
+
+// guardResult is the outcome of a guarded validation
+type guardResult struct {
+	decision shared.DecisionType
+	reason   string
+}
+
+// ValidateLines validates the lines with the wrapped rule, bounded by the rule timeout
+func (r *guardedRule) ValidateLines(filePath string, fileContent string, lineRanges []shared.LineRange) (shared.DecisionType, string) {
+	done := make(chan guardResult, 1)
+	go func() {
+		defer func() {
+			if p := recover(); p != nil {
+				recordRuleFailure(r.Name(), RuleFailurePanic)
+				logging.Error("Rule panicked",
+					zap.String("rule", r.Name()),
+					zap.String("file_path", filePath),
+					zap.Any("panic", p),
+					zap.ByteString("stack", debug.Stack()))
+				done <- guardResult{shared.ManualReview, fmt.Sprintf("%s: %s panicked while validating this file", InternalRuleErrorReason, r.Name())}
+			}
+		}()
+		decision, reason := r.Rule.ValidateLines(filePath, fileContent, lineRanges)
+		done <- guardResult{decision, reason}
+	}()
+
+	timer := time.NewTimer(r.timeout)
+	defer timer.Stop()
+	select {
+	case result := <-done:
+		return result.decision, result.reason
+	case <-timer.C:
+		recordRuleFailure(r.Name(), RuleFailureTimeout)
+		logging.Error("Rule did not validate the file in time",
+			zap.String("rule", r.Name()),
+			zap.String("file_path", filePath),
+			zap.Duration("timeout", r.timeout))
+		return shared.ManualReview, fmt.Sprintf("%s: %s did not finish validating this file within %s", InternalRuleErrorReason, r.Name(), r.timeout)
+	}
+}
+
+// guardRules wraps rules so their validations are bounded by the timeout of their rule
+// config, else rule_timeout_seconds, else defaultRuleTimeout
+func (srm *SectionRuleManager) guardRules(ruleConfigs []config.RuleConfig, rules []shared.Rule) []shared.Rule {
+	timeout := defaultRuleTimeout
+	if srm.config.RuleTimeout > 0 {
+		timeout = time.Duration(srm.config.RuleTimeout) * time.Second
+	}
+	timeouts := make(map[string]time.Duration)
+	for _, ruleConfig := range ruleConfigs {
+		if ruleConfig.Timeout > 0 {
+			timeouts[ruleConfig.Name] = time.Duration(ruleConfig.Timeout) * time.Second
+		}
+	}
+
+	guarded := make([]shared.Rule, len(rules))
+	for i, rule := range rules {
+		ruleTimeout := timeout
+		if override, ok := timeouts[rule.Name()]; ok {
+			ruleTimeout = override
+		}
+		guarded[i] = &guardedRule{Rule: rule, timeout: ruleTimeout}
+	}
+	return guarded
+}
+
+var (
+	ruleFailuresMu sync.Mutex
+	ruleFailures   = make(map[[2]string]int64) // [rule, kind] -> count
+)
+
+// recordRuleFailure counts a validation of rule that failed with kind
+func recordRuleFailure(rule, kind string) {
+	ruleFailuresMu.Lock()
+	defer ruleFailuresMu.Unlock()
+	ruleFailures[[2]string{rule, kind}]++
+}
+
+// RuleFailureCount is the number of validations of a rule that failed with a kind since startup
+type RuleFailureCount struct {
+	Rule  string
+	Kind  string
+	Count int64
+}
+
+// RuleFailures returns the rule validations that panicked or timed out since startup,
+// sorted by rule and kind
+func RuleFailures() []RuleFailureCount {
+	ruleFailuresMu.Lock()
+	defer ruleFailuresMu.Unlock()
+
+	result := make([]RuleFailureCount, 0, len(ruleFailures))
+	for key, count := range ruleFailures {
+		result = append(result, RuleFailureCount{Rule: key[0], Kind: key[1], Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Rule != result[j].Rule {
+			return result[i].Rule < result[j].Rule
+		}
+		return result[i].Kind < result[j].Kind
+	})
+	return result
+}
@@ -0,0 +1,143 @@
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+)
+
+// misbehavingRule panics on a poisoned file and never returns for a hanging one
+type misbehavingRule struct {
+	findingRule
+	release chan struct{}
+}
+
+func (r *misbehavingRule) ValidateLines(filePath string, fileContent string, lineRanges []shared.LineRange) (shared.DecisionType, string) {
+	switch {
+	case strings.Contains(fileContent, "poison"):
+		var warehouses map[string]int
+		warehouses["size"]++ // nil map write
+	case strings.Contains(fileContent, "hang"):
+		<-r.release
+	}
+	return r.findingRule.ValidateLines(filePath, fileContent, lineRanges)
+}
+
+func ruleFailureCount(rule, kind string) int64 {
+	for _, failure := range RuleFailures() {
+		if failure.Rule == rule && failure.Kind == kind {
+			return failure.Count
+		}
+	}
+	return 0
+}
+
+func TestGuardedRule_IsolatesPanicsAndTimeouts(t *testing.T) {
+	rule := &misbehavingRule{
+		findingRule: findingRule{name: "guard_test_rule", decision: shared.Approve, reason: "Looks fine"},
+		release:     make(chan struct{}),
+	}
+	defer close(rule.release)
+	guarded := &guardedRule{Rule: rule, timeout: 50 * time.Millisecond}
+
+	decision, reason := guarded.ValidateLines("product.yaml", "name: orders", nil)
+	assert.Equal(t, shared.Approve, decision)
+	assert.Equal(t, "Looks fine", reason)
+
+	panics := ruleFailureCount("guard_test_rule", RuleFailurePanic)
+	decision, reason = guarded.ValidateLines("product.yaml", "poison", nil)
+	assert.Equal(t, shared.ManualReview, decision)
+	assert.Equal(t, "internal rule error: guard_test_rule panicked while validating this file", reason)
+	assert.Equal(t, panics+1, ruleFailureCount("guard_test_rule", RuleFailurePanic))
+
+	timeouts := ruleFailureCount("guard_test_rule", RuleFailureTimeout)
+	started := time.Now()
+	decision, reason = guarded.ValidateLines("product.yaml", "hang", nil)
+	assert.Less(t, time.Since(started), 5*time.Second)
+	assert.Equal(t, shared.ManualReview, decision)
+	assert.Equal(t, "internal rule error: guard_test_rule did not finish validating this file within 50ms", reason)
+	assert.Equal(t, timeouts+1, ruleFailureCount("guard_test_rule", RuleFailureTimeout))
+}
+
+func TestGuardRules_Timeouts(t *testing.T) {
+	first := &findingRule{name: "first_rule"}
+	second := &findingRule{name: "second_rule"}
+	manager := &SectionRuleManager{config: &config.GlobalRuleConfig{}}
+
+	guarded := manager.guardRules(nil, []shared.Rule{first})
+	assert.Equal(t, defaultRuleTimeout, guarded[0].(*guardedRule).timeout)
+
+	manager.config.RuleTimeout = 5
+	guarded = manager.guardRules([]config.RuleConfig{{Name: "second_rule", Enabled: true, Timeout: 90}}, []shared.Rule{first, second})
+	assert.Equal(t, 5*time.Second, guarded[0].(*guardedRule).timeout)
+	assert.Equal(t, 90*time.Second, guarded[1].(*guardedRule).timeout)
+	assert.Equal(t, "second_rule", guarded[1].Name())
+}
+
+func TestGuardRules_ErrorsAreNotAcceptedByEnvironments(t *testing.T) {
+	rule := &misbehavingRule{findingRule: findingRule{name: "guard_env_rule", decision: shared.Approve}}
+	manager := &SectionRuleManager{config: &config.GlobalRuleConfig{}}
+	ruleConfigs := []config.RuleConfig{{Name: "guard_env_rule", Enabled: true, Environments: map[string]string{"dev": "approve"}}}
+
+	rules := manager.guardRules(ruleConfigs, manager.adjustRules(ruleConfigs, []shared.Rule{rule}, "dev"))
+	decision, reason := rules[0].ValidateLines("dataproducts/source/orders/dev/product.yaml", "poison", nil)
+	assert.Equal(t, shared.ManualReview, decision)
+	assert.True(t, strings.HasPrefix(reason, InternalRuleErrorReason))
+}
+
+func TestValidateRuleConfig_RuleTimeouts(t *testing.T) {
+	base := func(ruleTimeout, timeout int) *config.GlobalRuleConfig {
+		return &config.GlobalRuleConfig{
+			RuleTimeout: ruleTimeout,
+			Files: []config.FileRuleConfig{{
+				Name: "products", Path: "dataproducts/**/", Filename: "product.yaml", ParserType: "yaml",
+				Sections: []config.SectionDefinition{{Name: "full", YAMLPath: ".", RuleConfigs: []config.RuleConfig{
+					{Name: "metadata_rule", Enabled: true, Timeout: timeout},
+				}}},
+			}},
+		}
+	}
+
+	assert.NoError(t, config.ValidateRuleConfig(base(10, 60)))
+	assert.Error(t, config.ValidateRuleConfig(base(-1, 0)))
+	assert.Error(t, config.ValidateRuleConfig(base(0, -5)))
+
+	cfg := base(10, 0)
+	cfg.Projects = []config.ProjectRuleConfig{{Name: "slow", ProjectIDs: []int{1}, Rules: []config.RuleConfig{
+		{Name: "metadata_rule", Enabled: true, Timeout: 120},
+	}}}
+	assert.Equal(t, 120, cfg.ForProject(cfg.Projects[0]).Files[0].Sections[0].RuleConfigs[0].Timeout)
+}
+
+func TestLoadRuleConfig_RuleTimeouts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(`enabled: true
+rule_timeout_seconds: 15
+files:
+  - name: products
+    path: "dataproducts/**/"
+    filename: "product.yaml"
+    parser_type: yaml
+    enabled: true
+    sections:
+      - name: warehouses
+        yaml_path: warehouses
+        rule_configs:
+          - name: warehouse_rule
+            enabled: true
+            timeout_seconds: 45
+`), 0o600))
+
+	assert.Empty(t, ValidateRuleConfigFile(path, NewRuleRegistry()))
+	cfg, err := config.LoadRuleConfig(path)
+	assert.NoError(t, err)
+	assert.Equal(t, 15, cfg.RuleTimeout)
+	assert.Equal(t, 45, cfg.Files[0].Sections[0].RuleConfigs[0].Timeout)
+}
@@ -0,0 +1,142 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/redhat-data-and-ai/naysayer/internal/utils"
+	"go.uber.org/zap"
+)
+
+// adjustedRule applies the environment behavior of its rule config, or else the rule
+// severities, to the decisions of a rule on one file. Manual reviews of optional rules no
+// severity decides become warnings. Warnings keep the section approved and let the following
+// rules of the section run.
+type adjustedRule struct {
+	shared.Rule
+	environment string
+	behavior    string // Behavior the rule config sets for the environment, empty for none
+	severities  []config.RuleSeverity
+	optional    bool // The rule config is optional, so it cannot veto its auto_approve section
+}
+
+// ValidateLines validates the lines with the wrapped rule and adjusts its decision
+func (r *adjustedRule) ValidateLines(filePath string, fileContent string, lineRanges []shared.LineRange) (shared.DecisionType, string) {
+	decision, reason := r.Rule.ValidateLines(filePath, fileContent, lineRanges)
+	if enforced, ok := r.Rule.(shared.EnforcedRule); ok && decision == shared.ManualReview && enforced.IsEnforced(reason) {
+		return decision, reason
+	}
+	if r.behavior != "" {
+		return r.applyBehavior(filePath, decision, reason)
+	}
+	if decision != shared.ManualReview {
+		return decision, reason
+	}
+	for _, severity := range r.severities {
+		if !severityMatches(severity, filePath, r.environment, reason) {
+			continue
+		}
+		if severity.Severity == utils.SeverityWarning {
+			logging.Info("Rule downgraded to a warning",
+				zap.String("rule", r.Name()),
+				zap.String("file_path", filePath),
+				zap.String("reason", reason))
+			return shared.Warn, reason
+		}
+		return decision, reason
+	}
+	if r.optional {
+		logging.Info("Optional rule downgraded to a warning",
+			zap.String("rule", r.Name()),
+			zap.String("file_path", filePath),
+			zap.String("reason", reason))
+		return shared.Warn, reason
+	}
+	return decision, reason
+}
+
+// applyBehavior sets the decision configured for the environment: approve accepts manual
+// reviews, warn turns them into warnings and manual_review requires a review for every change
+func (r *adjustedRule) applyBehavior(filePath string, decision shared.DecisionType, reason string) (shared.DecisionType, string) {
+	switch {
+	case r.behavior == utils.DecisionApprove && decision != shared.Approve:
+		logging.Info("Rule approved in environment",
+			zap.String("rule", r.Name()),
+			zap.String("environment", r.environment),
+			zap.String("file_path", filePath),
+			zap.String("reason", reason))
+		return shared.Approve, fmt.Sprintf("%s (accepted in %s environment)", reason, r.environment)
+	case r.behavior == utils.DecisionWarn && decision == shared.ManualReview:
+		logging.Info("Rule downgraded to a warning in environment",
+			zap.String("rule", r.Name()),
+			zap.String("environment", r.environment),
+			zap.String("file_path", filePath),
+			zap.String("reason", reason))
+		return shared.Warn, reason
+	case r.behavior == utils.DefaultActionManualReview && decision != shared.ManualReview:
+		logging.Info("Rule requires manual review in environment",
+			zap.String("rule", r.Name()),
+			zap.String("environment", r.environment),
+			zap.String("file_path", filePath))
+		return shared.ManualReview, fmt.Sprintf("%s (manual review required in %s environment)", reason, r.environment)
+	}
+	return decision, reason
+}
+
+// adjustRules wraps the rules of a section whose decisions on a file in environment are
+// adjusted by an environment behavior, a rule severity or an optional rule config
+func (srm *SectionRuleManager) adjustRules(ruleConfigs []config.RuleConfig, rules []shared.Rule, environment string) []shared.Rule {
+	behaviors := make(map[string]string)
+	optional := make(map[string]bool)
+	for _, ruleConfig := range ruleConfigs {
+		if behavior := ruleConfig.EnvironmentBehavior(environment); behavior != "" {
+			behaviors[ruleConfig.Name] = behavior
+		}
+		if ruleConfig.Optional {
+			optional[ruleConfig.Name] = true
+		}
+	}
+	if len(behaviors) == 0 && len(optional) == 0 && len(srm.config.RuleSeverities) == 0 {
+		return rules
+	}
+
+	adjusted := make([]shared.Rule, len(rules))
+	for i, rule := range rules {
+		adjusted[i] = rule
+		var severities []config.RuleSeverity
+		for _, severity := range srm.config.RuleSeverities {
+			if severity.Rule == rule.Name() {
+				severities = append(severities, severity)
+			}
+		}
+		if behaviors[rule.Name()] != "" || len(severities) > 0 || optional[rule.Name()] {
+			adjusted[i] = &adjustedRule{Rule: rule, environment: environment, behavior: behaviors[rule.Name()], severities: severities, optional: optional[rule.Name()]}
+		}
+	}
+	return adjusted
+}
+
+// severityMatches reports whether a severity applies to a manual review of its rule on a
+// file in environment. Severities limited to environments only match files of those environments.
+func severityMatches(severity config.RuleSeverity, filePath, environment, reason string) bool {
+	if len(severity.Environments) > 0 && !targetsEnvironment([]string{environment}, severity.Environments) {
+		return false
+	}
+	if severity.Path != "" && !shared.MatchesPattern(filePath, severity.Path) {
+		return false
+	}
+	if severity.ReasonContains != "" && !strings.Contains(strings.ToLower(reason), strings.ToLower(severity.ReasonContains)) {
+		return false
+	}
+	if severity.ReasonMatches != "" {
+		// Patterns are validated when the configuration is loaded
+		if matched, err := regexp.MatchString(severity.ReasonMatches, reason); err != nil || !matched {
+			return false
+		}
+	}
+	return true
+}
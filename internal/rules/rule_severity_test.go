@@ -0,0 +1,238 @@
+package rules
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+// findingRule covers every section and returns a fixed decision
+type findingRule struct {
+	name     string
+	decision shared.DecisionType
+	reason   string
+}
+
+func (r *findingRule) Name() string        { return r.name }
+func (r *findingRule) Description() string { return "Rule with a fixed finding" }
+
+func (r *findingRule) GetCoveredLines(filePath string, fileContent string) []shared.LineRange {
+	return []shared.LineRange{{StartLine: 1, EndLine: 1, FilePath: filePath}}
+}
+
+func (r *findingRule) ValidateLines(filePath string, fileContent string, lineRanges []shared.LineRange) (shared.DecisionType, string) {
+	return r.decision, r.reason
+}
+
+// enforcedRule is a finding rule whose blocking findings cannot be adjusted
+type enforcedRule struct {
+	findingRule
+}
+
+func (r *enforcedRule) IsEnforced(reason string) bool {
+	return strings.HasPrefix(reason, "blocking:")
+}
+
+func severityTestConfig() *config.GlobalRuleConfig {
+	cfg := deletionPolicyTestConfig()
+	cfg.RuleSeverities = []config.RuleSeverity{
+		{Rule: "naming_rule", Severity: "blocking", Path: "dataproducts/critical/**/product.{yaml,yml}"},
+		{Rule: "naming_rule", Severity: "warning", Environments: []string{"dev", "Sandbox"}},
+		{Rule: "metadata_rule", Severity: "warning", ReasonContains: "Style deviation"},
+	}
+	return cfg
+}
+
+func TestRuleSeverity_DowngradesPerEnvironment(t *testing.T) {
+	manager := NewSectionRuleManager(severityTestConfig(), nil)
+	naming := &findingRule{name: "naming_rule", decision: shared.ManualReview, reason: "naming style deviation"}
+	rules := manager.adjustRules(nil, []shared.Rule{naming, &MockRule{name: "warehouse_rule"}}, "dev")
+	assert.IsType(t, &MockRule{}, rules[1], "rules without a severity are not wrapped")
+
+	tests := []struct {
+		path     string
+		expected shared.DecisionType
+	}{
+		{"dataproducts/source/orders/dev/product.yaml", shared.Warn},
+		{"dataproducts/source/orders/sandbox/product.yaml", shared.Warn},
+		{"dataproducts/source/orders/prod/product.yaml", shared.ManualReview},
+		{"dataproducts/critical/orders/dev/product.yaml", shared.ManualReview},
+		{"serviceaccounts/dev/reader.yaml", shared.Warn},
+		{"docs/dev/README.md", shared.ManualReview},
+	}
+	for _, tt := range tests {
+		rule := manager.adjustRules(nil, []shared.Rule{naming}, shared.EnvironmentFromPath(tt.path))[0]
+		decision, reason := rule.ValidateLines(tt.path, "", nil)
+		assert.Equal(t, tt.expected, decision, tt.path)
+		assert.Equal(t, "naming style deviation", reason)
+	}
+
+	naming.decision = shared.Approve
+	decision, _ := rules[0].ValidateLines("dataproducts/source/orders/dev/product.yaml", "", nil)
+	assert.Equal(t, shared.Approve, decision, "approvals are kept")
+}
+
+func TestRuleSeverity_MatchesReason(t *testing.T) {
+	manager := NewSectionRuleManager(severityTestConfig(), nil)
+	metadata := &findingRule{name: "metadata_rule", decision: shared.ManualReview, reason: "style deviation in description"}
+	rule := manager.adjustRules(nil, []shared.Rule{metadata}, "prod")[0]
+
+	decision, _ := rule.ValidateLines("dataproducts/source/orders/prod/product.yaml", "", nil)
+	assert.Equal(t, shared.Warn, decision, "severities without environments match every file")
+
+	metadata.reason = "missing owner"
+	decision, _ = rule.ValidateLines("dataproducts/source/orders/prod/product.yaml", "", nil)
+	assert.Equal(t, shared.ManualReview, decision)
+}
+
+func TestRuleSeverity_WarningKeepsSectionApproved(t *testing.T) {
+	manager := NewSectionRuleManager(severityTestConfig(), nil)
+	rules := manager.adjustRules(nil, []shared.Rule{
+		&findingRule{name: "naming_rule", decision: shared.ManualReview, reason: "naming style deviation"},
+		&findingRule{name: "warehouse_rule", decision: shared.Approve, reason: "warehouse unchanged"},
+	}, "dev")
+	section := shared.Section{Name: "metadata", StartLine: 1, EndLine: 3, FilePath: "dataproducts/source/orders/dev/product.yaml"}
+
+	result := NewYAMLSectionParser(nil).ValidateSection(&section, rules)
+	assert.Equal(t, shared.Approve, result.Decision)
+	if assert.Len(t, result.RuleResults, 2, "rules after a warning still run") {
+		assert.Equal(t, shared.Warn, result.RuleResults[0].Decision)
+		assert.Equal(t, shared.Approve, result.RuleResults[1].Decision)
+	}
+}
+
+func TestEnvironmentBehavior_Matrix(t *testing.T) {
+	manager := NewSectionRuleManager(severityTestConfig(), nil)
+	ruleConfigs := []config.RuleConfig{{
+		Name: "metadata_rule", Enabled: true,
+		Environments: map[string]string{"sandbox": "approve", "dev": "warn", "PROD": "manual_review"},
+	}}
+	finding := &findingRule{name: "metadata_rule", decision: shared.ManualReview, reason: "missing owner"}
+	passing := &findingRule{name: "metadata_rule", decision: shared.Approve, reason: "metadata valid"}
+
+	tests := []struct {
+		environment string
+		rule        *findingRule
+		expected    shared.DecisionType
+		reason      string
+	}{
+		{"sandbox", finding, shared.Approve, "missing owner (accepted in sandbox environment)"},
+		{"dev", finding, shared.Warn, "missing owner"},
+		{"prod", finding, shared.ManualReview, "missing owner"},
+		{"prod", passing, shared.ManualReview, "metadata valid (manual review required in prod environment)"},
+		{"sandbox", passing, shared.Approve, "metadata valid"},
+		{"preprod", finding, shared.ManualReview, "missing owner"},
+		{"preprod", passing, shared.Approve, "metadata valid"},
+	}
+	for _, tt := range tests {
+		rule := manager.adjustRules(ruleConfigs, []shared.Rule{tt.rule}, tt.environment)[0]
+		decision, reason := rule.ValidateLines("dataproducts/source/orders/"+tt.environment+"/product.yaml", "", nil)
+		assert.Equal(t, tt.expected, decision, tt.environment)
+		assert.Equal(t, tt.reason, reason, tt.environment)
+	}
+
+	// The environment behavior takes precedence over the rule severities
+	finding.reason = "style deviation in description"
+	rule := manager.adjustRules(ruleConfigs, []shared.Rule{finding}, "prod")[0]
+	decision, _ := rule.ValidateLines("dataproducts/source/orders/prod/product.yaml", "", nil)
+	assert.Equal(t, shared.ManualReview, decision)
+	rule = manager.adjustRules(ruleConfigs, []shared.Rule{finding}, "preprod")[0]
+	decision, _ = rule.ValidateLines("dataproducts/source/orders/preprod/product.yaml", "", nil)
+	assert.Equal(t, shared.Warn, decision)
+
+	assert.Same(t, passing, NewSectionRuleManager(deletionPolicyTestConfig(), nil).adjustRules(ruleConfigs, []shared.Rule{passing}, "preprod")[0],
+		"rules without a behavior or severity are not wrapped")
+}
+
+func TestEnforcedRule_IgnoresBehaviorsAndSeverities(t *testing.T) {
+	cfg := severityTestConfig()
+	cfg.RuleSeverities = append(cfg.RuleSeverities, config.RuleSeverity{Rule: "warehouse_rule", Severity: "warning"})
+	manager := NewSectionRuleManager(cfg, nil)
+	ruleConfigs := []config.RuleConfig{{
+		Name: "warehouse_rule", Enabled: true,
+		Environments: map[string]string{"sandbox": "approve", "dev": "warn"},
+	}}
+
+	tests := []struct {
+		environment string
+		reason      string
+		expected    shared.DecisionType
+	}{
+		{"sandbox", "blocking: size above the maximum", shared.ManualReview},
+		{"dev", "blocking: size above the maximum", shared.ManualReview},
+		{"prod", "blocking: size above the maximum", shared.ManualReview},
+		{"sandbox", "size increase", shared.Approve},
+		{"dev", "size increase", shared.Warn},
+		{"prod", "size increase", shared.Warn},
+	}
+	for _, tt := range tests {
+		rule := &enforcedRule{findingRule{name: "warehouse_rule", decision: shared.ManualReview, reason: tt.reason}}
+		adjusted := manager.adjustRules(ruleConfigs, []shared.Rule{rule}, tt.environment)[0]
+		decision, _ := adjusted.ValidateLines("dataproducts/source/orders/"+tt.environment+"/product.yaml", "", nil)
+		assert.Equal(t, tt.expected, decision, "%s: %s", tt.environment, tt.reason)
+	}
+}
+
+func TestValidateRuleConfig_EnvironmentBehaviors(t *testing.T) {
+	base := func(environments map[string]string) *config.GlobalRuleConfig {
+		return &config.GlobalRuleConfig{
+			Files: []config.FileRuleConfig{{
+				Name: "products", Path: "dataproducts/**/", Filename: "product.yaml", ParserType: "yaml",
+				Sections: []config.SectionDefinition{{Name: "full", YAMLPath: ".", RuleConfigs: []config.RuleConfig{
+					{Name: "metadata_rule", Enabled: true, Environments: environments},
+				}}},
+			}},
+		}
+	}
+
+	assert.NoError(t, config.ValidateRuleConfig(base(map[string]string{"sandbox": "approve", "dev": "warn", "prod": "manual_review"})))
+	assert.Error(t, config.ValidateRuleConfig(base(map[string]string{"prod": "block"})))
+	assert.Error(t, config.ValidateRuleConfig(base(map[string]string{"": "warn"})))
+
+	cfg := base(nil)
+	cfg.Projects = []config.ProjectRuleConfig{{Name: "lenient", ProjectIDs: []int{1}, Rules: []config.RuleConfig{
+		{Name: "metadata_rule", Enabled: true, Environments: map[string]string{"prod": "lenient"}},
+	}}}
+	assert.Error(t, config.ValidateRuleConfig(cfg))
+}
+
+func TestRuleEvaluation_Warnings(t *testing.T) {
+	validations := map[string]*shared.FileValidationSummary{
+		"b/product.yaml": fileValidation("b/product.yaml", ruleResult("naming_rule", shared.Warn, "naming style deviation")),
+		"a/product.yaml": fileValidation("a/product.yaml",
+			ruleResult("warehouse_rule", shared.Approve, "warehouse unchanged"),
+			ruleResult("naming_rule", shared.Warn, "naming style deviation")),
+	}
+	manager := NewSectionRuleManager(severityTestConfig(), nil)
+	decision := manager.determineOverallDecision(validations)
+	assert.Equal(t, shared.Approve, decision.Type)
+	assert.Equal(t, "✅ Auto-approved with warnings", decision.Summary)
+
+	warnings := (&shared.RuleEvaluation{FileValidations: validations}).Warnings()
+	assert.Equal(t, []shared.RuleWarning{
+		{FilePath: "a/product.yaml", RuleName: "naming_rule", Reason: "naming style deviation"},
+		{FilePath: "b/product.yaml", RuleName: "naming_rule", Reason: "naming style deviation"},
+	}, warnings)
+}
+
+func TestValidateRuleConfig_RuleSeverities(t *testing.T) {
+	base := func(severities ...config.RuleSeverity) *config.GlobalRuleConfig {
+		return &config.GlobalRuleConfig{
+			Files: []config.FileRuleConfig{{
+				Name: "docs", Path: "**/", Filename: "*.md", ParserType: "yaml",
+				Sections: []config.SectionDefinition{{Name: "full", YAMLPath: ".", AutoApprove: true}},
+			}},
+			RuleSeverities: severities,
+		}
+	}
+
+	assert.NoError(t, config.ValidateRuleConfig(base(config.RuleSeverity{Rule: "a", Severity: "warning", Environments: []string{"dev"}})))
+	assert.NoError(t, config.ValidateRuleConfig(base(config.RuleSeverity{Rule: "a", Severity: "blocking", ReasonMatches: "^naming"})))
+	assert.Error(t, config.ValidateRuleConfig(base(config.RuleSeverity{Severity: "warning"})))
+	assert.Error(t, config.ValidateRuleConfig(base(config.RuleSeverity{Rule: "a", Severity: "info"})))
+	assert.Error(t, config.ValidateRuleConfig(base(config.RuleSeverity{Rule: "a", Severity: "warning", Environments: []string{"dev/eu"}})))
+	assert.Error(t, config.ValidateRuleConfig(base(config.RuleSeverity{Rule: "a", Severity: "warning", ReasonMatches: "("})))
+}
@@ -0,0 +1,44 @@
+package rules
+
+import (
+	"context"
+	"strings"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/redhat-data-and-ai/naysayer/internal/tracing"
+)
+
+// tracedRule records a span for every ValidateLines call of its rule, as a child of the
+// span of the file being validated
+type tracedRule struct {
+	shared.Rule
+	ctx context.Context
+}
+
+// ValidateLines validates the lines with the wrapped rule inside a span
+func (r *tracedRule) ValidateLines(filePath string, fileContent string, lineRanges []shared.LineRange) (shared.DecisionType, string) {
+	_, span := tracing.Start(r.ctx, "rule "+r.Name(), tracing.KindInternal,
+		tracing.String("naysayer.rule", r.Name()),
+		tracing.String("naysayer.file", filePath))
+	defer span.End()
+
+	decision, reason := r.Rule.ValidateLines(filePath, fileContent, lineRanges)
+	span.SetAttributes(tracing.String("naysayer.decision", string(decision)))
+	if strings.HasPrefix(reason, InternalRuleErrorReason) {
+		span.SetFailed(reason)
+	}
+	return decision, reason
+}
+
+// traceRules wraps rules so their validations are traced; rules are returned unchanged
+// while tracing is disabled
+func traceRules(ctx context.Context, rules []shared.Rule) []shared.Rule {
+	if tracing.Default() == nil {
+		return rules
+	}
+	traced := make([]shared.Rule, len(rules))
+	for i, rule := range rules {
+		traced[i] = &tracedRule{Rule: rule, ctx: ctx}
+	}
+	return traced
+}
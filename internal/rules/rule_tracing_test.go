@@ -0,0 +1,73 @@
+package rules
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/redhat-data-and-ai/naysayer/internal/tracing"
+)
+
+// exportedSpan holds the exported span fields the tests check
+type exportedSpan struct {
+	SpanID       string `json:"spanId"`
+	ParentSpanID string `json:"parentSpanId"`
+	Name         string `json:"name"`
+	Attributes   []struct {
+		Key   string            `json:"key"`
+		Value map[string]string `json:"value"`
+	} `json:"attributes"`
+}
+
+func TestTraceRules(t *testing.T) {
+	rule := &findingRule{name: "warehouse_rule", decision: shared.ManualReview, reason: "Warehouse increase"}
+
+	tracing.SetDefault(nil)
+	untraced := traceRules(context.Background(), []shared.Rule{rule})
+	assert.Same(t, rule, untraced[0], "rules are not wrapped while tracing is disabled")
+
+	var spans []exportedSpan
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ResourceSpans []struct {
+				ScopeSpans []struct {
+					Spans []exportedSpan `json:"spans"`
+				} `json:"scopeSpans"`
+			} `json:"resourceSpans"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		spans = append(spans, req.ResourceSpans[0].ScopeSpans[0].Spans...)
+	}))
+	defer collector.Close()
+	tracer := tracing.NewTracerFromConfig(config.TracingConfig{Endpoint: collector.URL, SampleRatio: 1})
+	tracing.SetDefault(tracer)
+	defer tracing.SetDefault(nil)
+
+	ctx, file := tracing.Start(context.Background(), "rules.validateFile", tracing.KindInternal)
+	traced := traceRules(ctx, []shared.Rule{rule})
+	decision, reason := traced[0].ValidateLines("product.yaml", "", nil)
+	file.End()
+	assert.NoError(t, tracer.Shutdown(context.Background()))
+
+	assert.Equal(t, shared.ManualReview, decision)
+	assert.Equal(t, "Warehouse increase", reason)
+	assert.Equal(t, "warehouse_rule", traced[0].Name())
+	assert.Len(t, spans, 2)
+	assert.Equal(t, "rule warehouse_rule", spans[0].Name)
+	assert.Equal(t, spans[1].SpanID, spans[0].ParentSpanID)
+	attributes := make(map[string]string)
+	for _, attr := range spans[0].Attributes {
+		attributes[attr.Key] = attr.Value["stringValue"]
+	}
+	assert.Equal(t, map[string]string{
+		"naysayer.rule":     "warehouse_rule",
+		"naysayer.file":     "product.yaml",
+		"naysayer.decision": string(shared.ManualReview),
+	}, attributes)
+}
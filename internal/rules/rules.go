@@ -8,6 +8,9 @@ import (
 	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
 )
 
+// RulesConfigPath is the section rule configuration used by the dataverse manager
+const RulesConfigPath = "rules.yaml"
+
 // CreateDataverseRuleManager creates a rule manager with rules for dataverse product config
 // This function now uses the extensible rule registry system
 func CreateDataverseRuleManager(gitlabClient gitlab.GitLabClient) shared.RuleManager {
@@ -36,7 +39,7 @@ func CreateSectionBasedDataverseManager(client gitlab.GitLabClient) (shared.Rule
 	registry := GetGlobalRegistry()
 
 	// Create section-based manager - no fallback allowed
-	sectionManager, err := registry.CreateSectionBasedRuleManager(client, "rules.yaml")
+	sectionManager, err := registry.CreateSectionBasedRuleManager(client, RulesConfigPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create section-based rule manager: %w", err)
 	}
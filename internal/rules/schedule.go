@@ -0,0 +1,123 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/cron"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"go.uber.org/zap"
+)
+
+// ruleSchedule is a parsed rule schedule
+type ruleSchedule struct {
+	rule     string
+	location *time.Location
+	windows  [][2]time.Time
+	labels   []string
+	crons    []*cron.Schedule
+}
+
+// parseRuleSchedule parses the windows and cron expressions of a schedule
+func parseRuleSchedule(cfg config.RuleSchedule) (*ruleSchedule, error) {
+	loc, err := cfg.Location()
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone '%s': %w", cfg.Timezone, err)
+	}
+	schedule := &ruleSchedule{rule: cfg.Rule, location: loc}
+	for _, window := range cfg.Windows {
+		from, to, err := window.Bounds(loc)
+		if err != nil {
+			return nil, err
+		}
+		schedule.windows = append(schedule.windows, [2]time.Time{from, to})
+		schedule.labels = append(schedule.labels, fmt.Sprintf("%s - %s", window.From, window.To))
+	}
+	for _, expr := range cfg.Cron {
+		parsed, err := cron.Parse(expr)
+		if err != nil {
+			return nil, err
+		}
+		schedule.crons = append(schedule.crons, parsed)
+	}
+	return schedule, nil
+}
+
+// parseRuleSchedules parses the schedules of a rule configuration. A schedule that fails
+// to parse is dropped, which leaves its rule always active rather than silently disabled.
+func parseRuleSchedules(schedules []config.RuleSchedule) map[string]*ruleSchedule {
+	parsed := make(map[string]*ruleSchedule, len(schedules))
+	for _, cfg := range schedules {
+		schedule, err := parseRuleSchedule(cfg)
+		if err != nil {
+			logging.Error("Ignoring schedule of rule", zap.String("rule", cfg.Rule), zap.Error(err))
+			continue
+		}
+		parsed[cfg.Rule] = schedule
+	}
+	return parsed
+}
+
+// status returns whether the rule is active at now, why, and when it activates next
+func (s *ruleSchedule) status(now time.Time) shared.RuleScheduleStatus {
+	now = now.In(s.location)
+	status := shared.RuleScheduleStatus{Rule: s.rule}
+
+	var next time.Time
+	for i, window := range s.windows {
+		if !now.Before(window[0]) && now.Before(window[1]) {
+			status.Active = true
+			status.Reason = fmt.Sprintf("active during window %s", s.labels[i])
+			return status
+		}
+		if now.Before(window[0]) && (next.IsZero() || window[0].Before(next)) {
+			next = window[0]
+		}
+	}
+	for _, c := range s.crons {
+		if c.Matches(now) {
+			status.Active = true
+			status.Reason = fmt.Sprintf("active on cron schedule '%s'", c)
+			return status
+		}
+		if n := c.Next(now); !n.IsZero() && (next.IsZero() || n.Before(next)) {
+			next = n
+		}
+	}
+
+	status.Reason = "inactive outside its schedule"
+	if !next.IsZero() {
+		status.NextActivation = &next
+	}
+	return status
+}
+
+// RuleScheduleStatuses returns the activation status of every scheduled rule at now,
+// sorted by rule name
+func RuleScheduleStatuses(schedules []config.RuleSchedule, now time.Time) []shared.RuleScheduleStatus {
+	return scheduleStatuses(parseRuleSchedules(schedules), now)
+}
+
+func scheduleStatuses(schedules map[string]*ruleSchedule, now time.Time) []shared.RuleScheduleStatus {
+	if len(schedules) == 0 {
+		return nil
+	}
+	statuses := make([]shared.RuleScheduleStatus, 0, len(schedules))
+	for _, schedule := range schedules {
+		statuses = append(statuses, schedule.status(now))
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Rule < statuses[j].Rule })
+	return statuses
+}
+
+// ruleActive reports whether a rule is inside its schedule. Unscheduled rules are always active.
+func (srm *SectionRuleManager) ruleActive(ruleName string) bool {
+	schedule, ok := srm.schedules[ruleName]
+	if !ok {
+		return true
+	}
+	return schedule.status(srm.now()).Active
+}
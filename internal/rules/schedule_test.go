@@ -0,0 +1,132 @@
+package rules
+
+import (
+	"testing"
+	"time"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func scheduleTestConfig() *config.GlobalRuleConfig {
+	cfg := deletionPolicyTestConfig()
+	cfg.RuleSchedules = []config.RuleSchedule{
+		{
+			Rule:    "prod_freeze_rule",
+			Windows: []config.ScheduleWindow{{From: "2026-12-01", To: "2026-12-14"}},
+		},
+		{
+			Rule:     "business_hours_rule",
+			Cron:     []string{"* 9-17 * * 1-5"},
+			Timezone: "Europe/Berlin",
+		},
+	}
+	return cfg
+}
+
+func TestRuleSchedule_Windows(t *testing.T) {
+	manager := NewSectionRuleManager(scheduleTestConfig(), nil)
+
+	tests := []struct {
+		name   string
+		now    time.Time
+		active bool
+	}{
+		{"before window", time.Date(2026, 11, 30, 23, 59, 0, 0, time.UTC), false},
+		{"first day", time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC), true},
+		{"last day is inclusive", time.Date(2026, 12, 14, 23, 59, 0, 0, time.UTC), true},
+		{"after window", time.Date(2026, 12, 15, 0, 0, 0, 0, time.UTC), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager.now = func() time.Time { return tt.now }
+			assert.Equal(t, tt.active, manager.ruleActive("prod_freeze_rule"))
+		})
+	}
+
+	manager.now = func() time.Time { return time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC) }
+	assert.True(t, manager.ruleActive("warehouse_rule"), "unscheduled rules are always active")
+}
+
+func TestRuleSchedule_CronInTimezone(t *testing.T) {
+	schedules := scheduleTestConfig().RuleSchedules
+
+	// 08:30 UTC on a Thursday is 10:30 in Berlin
+	statuses := RuleScheduleStatuses(schedules, time.Date(2026, 10, 15, 8, 30, 0, 0, time.UTC))
+	assert.Len(t, statuses, 2)
+	assert.Equal(t, "business_hours_rule", statuses[0].Rule)
+	assert.True(t, statuses[0].Active)
+	assert.Equal(t, "active on cron schedule '* 9-17 * * 1-5'", statuses[0].Reason)
+	assert.Nil(t, statuses[0].NextActivation)
+
+	assert.Equal(t, "prod_freeze_rule", statuses[1].Rule)
+	assert.False(t, statuses[1].Active)
+	assert.Equal(t, "inactive outside its schedule", statuses[1].Reason)
+	if assert.NotNil(t, statuses[1].NextActivation) {
+		assert.True(t, statuses[1].NextActivation.Equal(time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC)))
+	}
+
+	// Saturday evening: next activation is Monday 09:00 in Berlin
+	statuses = RuleScheduleStatuses(schedules, time.Date(2026, 10, 17, 18, 0, 0, 0, time.UTC))
+	assert.False(t, statuses[0].Active)
+	if assert.NotNil(t, statuses[0].NextActivation) {
+		assert.True(t, statuses[0].NextActivation.Equal(time.Date(2026, 10, 19, 7, 0, 0, 0, time.UTC)))
+	}
+}
+
+func TestRuleSchedule_SkipsInactiveRules(t *testing.T) {
+	manager := NewSectionRuleManager(scheduleTestConfig(), nil)
+	manager.ruleRegistry["prod_freeze_rule"] = &MockRule{name: "prod_freeze_rule"}
+	manager.ruleRegistry["warehouse_rule"] = &MockRule{name: "warehouse_rule"}
+	ruleConfigs := []config.RuleConfig{{Name: "prod_freeze_rule", Enabled: true}, {Name: "warehouse_rule", Enabled: true}}
+
+	manager.now = func() time.Time { return time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC) }
+	enabled := manager.getEnabledRulesForSection(ruleConfigs)
+	assert.Len(t, enabled, 1)
+	assert.Equal(t, "warehouse_rule", enabled[0].Name())
+
+	manager.now = func() time.Time { return time.Date(2026, 12, 10, 12, 0, 0, 0, time.UTC) }
+	assert.Len(t, manager.getEnabledRulesForSection(ruleConfigs), 2)
+}
+
+func TestRuleSchedule_RecordedInEvaluation(t *testing.T) {
+	manager := NewSectionRuleManager(scheduleTestConfig(), nil)
+	manager.now = func() time.Time { return time.Date(2026, 12, 10, 12, 0, 0, 0, time.UTC) }
+
+	result := manager.EvaluateAll(&shared.MRContext{
+		ProjectID: 123,
+		MRIID:     456,
+		Changes:   []gitlab.FileChange{{OldPath: "docs/old.md", NewPath: "docs/old.md", DeletedFile: true}},
+		MRInfo:    &gitlab.MRInfo{Title: "Remove docs", Author: "developer", SourceBranch: "feature"},
+	})
+	assert.Len(t, result.RuleSchedules, 2)
+	assert.Equal(t, "prod_freeze_rule", result.RuleSchedules[1].Rule)
+	assert.True(t, result.RuleSchedules[1].Active)
+	assert.Equal(t, "active during window 2026-12-01 - 2026-12-14", result.RuleSchedules[1].Reason)
+}
+
+func TestValidateRuleConfig_RuleSchedules(t *testing.T) {
+	base := func(schedules ...config.RuleSchedule) *config.GlobalRuleConfig {
+		return &config.GlobalRuleConfig{
+			Files: []config.FileRuleConfig{{
+				Name: "docs", Path: "**/", Filename: "*.md", ParserType: "yaml",
+				Sections: []config.SectionDefinition{{Name: "full", YAMLPath: ".", AutoApprove: true}},
+			}},
+			RuleSchedules: schedules,
+		}
+	}
+	window := []config.ScheduleWindow{{From: "2026-12-01", To: "2026-12-14"}}
+
+	assert.NoError(t, config.ValidateRuleConfig(base(config.RuleSchedule{Rule: "a", Windows: window})))
+	assert.NoError(t, config.ValidateRuleConfig(base(config.RuleSchedule{Rule: "a", Windows: []config.ScheduleWindow{{From: "2026-12-01T18:00:00Z", To: "2026-12-02T06:00:00+02:00"}}})))
+	assert.NoError(t, config.ValidateRuleConfig(base(config.RuleSchedule{Rule: "a", Cron: []string{"@daily"}, Timezone: "America/New_York"})))
+	assert.Error(t, config.ValidateRuleConfig(base(config.RuleSchedule{Windows: window})))
+	assert.Error(t, config.ValidateRuleConfig(base(config.RuleSchedule{Rule: "a"})))
+	assert.Error(t, config.ValidateRuleConfig(base(config.RuleSchedule{Rule: "a", Windows: window}, config.RuleSchedule{Rule: "a", Cron: []string{"@daily"}})))
+	assert.Error(t, config.ValidateRuleConfig(base(config.RuleSchedule{Rule: "a", Windows: []config.ScheduleWindow{{From: "2026-12-14", To: "2026-12-01"}}})))
+	assert.Error(t, config.ValidateRuleConfig(base(config.RuleSchedule{Rule: "a", Windows: []config.ScheduleWindow{{From: "december", To: "2026-12-01"}}})))
+	assert.Error(t, config.ValidateRuleConfig(base(config.RuleSchedule{Rule: "a", Cron: []string{"* * *"}})))
+	assert.Error(t, config.ValidateRuleConfig(base(config.RuleSchedule{Rule: "a", Cron: []string{"@daily"}, Timezone: "Mars/Olympus"})))
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/redhat-data-and-ai/naysayer/internal/logging"
 	"github.com/redhat-data-and-ai/naysayer/internal/rules/common"
 	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"go.uber.org/zap"
 	"gopkg.in/yaml.v3"
 )
 
@@ -58,7 +59,7 @@ func (r *ServiceAccountRule) ValidateLines(filePath string, fileContent string,
 	}
 
 	// All non-Astro service accounts require manual review
-	logging.Info("Non-Astro service account file %s requires manual review", filePath)
+	logging.Info("Non-Astro service account file requires manual review", zap.String("file_path", filePath))
 	return shared.ManualReview, "Only Astro service account files (*_astro_*.yaml/yml) are auto-approved - other service account files require manual review"
 }
 
@@ -115,7 +116,7 @@ func (r *ServiceAccountRule) validateAstroServiceAccount(filePath string, fileCo
 	// Parse YAML content to extract the 'name' field
 	var yamlData map[string]interface{}
 	if err := yaml.Unmarshal([]byte(fileContent), &yamlData); err != nil {
-		logging.Warn("Failed to parse YAML content for %s: %v", filePath, err)
+		logging.Warn("Failed to parse YAML content", zap.String("file_path", filePath), zap.Error(err))
 		return shared.ManualReview, "Failed to parse YAML content"
 	}
 
@@ -142,7 +143,9 @@ func (r *ServiceAccountRule) validateAstroServiceAccount(filePath string, fileCo
 			"Name field value '" + nameValue + "' does not match expected filename-based name '" + expectedName + "'"
 	}
 
-	logging.Info("Astro service account file %s validated successfully: name field '%s' matches filename", filePath, nameValue)
+	logging.Info("Astro service account file validated successfully: name field matches filename",
+		zap.String("file_path", filePath),
+		zap.String("name", nameValue))
 	return shared.Approve, "Astro service account file follows naming convention and name field matches filename"
 }
 
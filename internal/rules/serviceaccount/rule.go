@@ -0,0 +1,195 @@
+package serviceaccount
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/common"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+// RuleName is the identifier of the service account definition rule
+const RuleName = "serviceaccount_rule"
+
+// serviceAccountFilePattern matches service account definitions
+const serviceAccountFilePattern = "serviceaccounts/*/*.{yaml,yml}"
+
+// FileFetcher is the subset of the GitLab client needed to load previous service account versions
+type FileFetcher interface {
+	FetchFileContent(ctx context.Context, projectID int, filePath, ref string) (*gitlab.FileContent, error)
+}
+
+// Rule validates serviceaccounts/<env>/<name>.yaml definitions: naming, environment folder,
+// required fields, and requires manual review when elevated roles are granted
+type Rule struct {
+	*common.BaseRule
+	*common.ValidationHelper
+	client FileFetcher
+	config *ServiceAccountConfig
+}
+
+// NewRule creates a new service account definition rule instance
+func NewRule(client FileFetcher, elevatedRoles []string) *Rule {
+	config := DefaultServiceAccountConfig()
+	if len(elevatedRoles) > 0 {
+		config.ElevatedRoles = elevatedRoles
+	}
+
+	return &Rule{
+		BaseRule:         common.NewBaseRule(RuleName, "Validates serviceaccounts/<env>/<name>.yaml definitions and requires manual review when elevated roles are granted"),
+		ValidationHelper: common.NewValidationHelper(),
+		client:           client,
+		config:           config,
+	}
+}
+
+// IsServiceAccountFile checks if the path is a service account definition
+func IsServiceAccountFile(filePath string) bool {
+	return shared.MatchesPattern(filePath, serviceAccountFilePattern)
+}
+
+// GetCoveredLines returns line ranges this rule covers
+func (r *Rule) GetCoveredLines(filePath string, fileContent string) []shared.LineRange {
+	if !IsServiceAccountFile(filePath) || strings.TrimSpace(fileContent) == "" {
+		return []shared.LineRange{}
+	}
+	return r.GetFullFileCoverage(filePath, fileContent)
+}
+
+// ValidateLines validates a service account definition
+func (r *Rule) ValidateLines(filePath string, fileContent string, lineRanges []shared.LineRange) (shared.DecisionType, string) {
+	if !IsServiceAccountFile(filePath) {
+		return r.CreateApprovalResult("Not a service account file - rule does not apply")
+	}
+
+	var sa ServiceAccountYAML
+	if err := yaml.Unmarshal([]byte(fileContent), &sa); err != nil {
+		return r.CreateManualReviewResult(fmt.Sprintf("Failed to parse service account YAML: %v", err))
+	}
+
+	if problems := Validate(&sa, filePath); len(problems) > 0 {
+		return r.CreateManualReviewResult("Service account validation failed: " + strings.Join(problems, "; "))
+	}
+
+	oldRoles, err := r.previousRoles(filePath)
+	if err != nil {
+		return r.CreateManualReviewResult(fmt.Sprintf("Could not load previous version of service account: %v", err))
+	}
+	if grants := r.elevatedGrants(oldRoles, sa.Roles); len(grants) > 0 {
+		return r.CreateManualReviewResult(fmt.Sprintf("Service account '%s' is granted elevated roles: %s - manual review required", sa.Name, strings.Join(grants, ", ")))
+	}
+
+	return r.CreateApprovalResult(fmt.Sprintf("Service account '%s' follows naming conventions without privilege escalation", sa.Name))
+}
+
+// Validate checks a service account definition against its path
+// serviceaccounts/<env>/<name>.yaml and returns the problems found
+func Validate(sa *ServiceAccountYAML, filePath string) []string {
+	var problems []string
+	required := []struct{ field, value string }{
+		{"name", sa.Name},
+		{"data_product", sa.DataProduct},
+		{"environment", sa.Environment},
+	}
+	for _, r := range required {
+		if r.value == "" {
+			problems = append(problems, r.field+": required field is missing")
+		}
+	}
+	if len(problems) > 0 {
+		return problems
+	}
+
+	envFolder := path.Base(path.Dir(filePath))
+	fileName := strings.TrimSuffix(strings.TrimSuffix(path.Base(filePath), ".yaml"), ".yml")
+
+	if sa.Name != fileName {
+		problems = append(problems, fmt.Sprintf("name: '%s' does not match file name '%s'", sa.Name, fileName))
+	}
+	parts, ok := ParseName(sa.Name)
+	if !ok {
+		problems = append(problems, fmt.Sprintf("name: '%s' must follow <dataproduct>_<tool>_<env>_appuser", sa.Name))
+	} else {
+		if parts.Environment != envFolder {
+			problems = append(problems, fmt.Sprintf("name: environment '%s' does not match folder serviceaccounts/%s/", parts.Environment, envFolder))
+		}
+		if parts.DataProduct != sa.DataProduct {
+			problems = append(problems, fmt.Sprintf("data_product: '%s' does not match name prefix '%s'", sa.DataProduct, parts.DataProduct))
+		}
+	}
+	if sa.Environment != envFolder {
+		problems = append(problems, fmt.Sprintf("environment: '%s' does not match folder serviceaccounts/%s/", sa.Environment, envFolder))
+	}
+	return problems
+}
+
+// elevatedGrants returns the elevated roles in newRoles that oldRoles did not have
+func (r *Rule) elevatedGrants(oldRoles, newRoles []string) []string {
+	had := make(map[string]bool, len(oldRoles))
+	for _, role := range oldRoles {
+		had[strings.ToLower(role)] = true
+	}
+	elevated := make(map[string]bool, len(r.config.ElevatedRoles))
+	for _, role := range r.config.ElevatedRoles {
+		elevated[strings.ToLower(role)] = true
+	}
+
+	var grants []string
+	for _, role := range newRoles {
+		lower := strings.ToLower(role)
+		if elevated[lower] && !had[lower] {
+			grants = append(grants, role)
+			had[lower] = true
+		}
+	}
+	return grants
+}
+
+// previousRoles returns the roles of the service account on the target branch. New files
+// have none; without MR context every role counts as new.
+func (r *Rule) previousRoles(filePath string) ([]string, error) {
+	mrCtx := r.GetMRContext()
+	if mrCtx == nil || mrCtx.MRInfo == nil || r.client == nil {
+		return nil, nil
+	}
+
+	oldPath := filePath
+	for _, change := range mrCtx.Changes {
+		if change.NewPath != filePath {
+			continue
+		}
+		if change.NewFile {
+			return nil, nil
+		}
+		if change.OldPath != "" {
+			oldPath = change.OldPath
+		}
+		break
+	}
+
+	content, err := r.client.FetchFileContent(mrCtx.Context(), mrCtx.ProjectID, oldPath, mrCtx.MRInfo.TargetBranch)
+	if errors.Is(err, gitlab.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		logging.Warn("Failed to fetch previous version", zap.String("old_path", oldPath), zap.Error(err))
+		return nil, err
+	}
+	if content == nil {
+		return nil, fmt.Errorf("empty response when fetching %s", oldPath)
+	}
+
+	var old ServiceAccountYAML
+	if err := yaml.Unmarshal([]byte(content.Content), &old); err != nil {
+		// Roles of an unparsable previous version cannot be trusted, so every role counts as new
+		return nil, nil
+	}
+	return old.Roles, nil
+}
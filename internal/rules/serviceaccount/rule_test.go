@@ -0,0 +1,199 @@
+package serviceaccount
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+const saPath = "serviceaccounts/prod/analytics_astro_prod_appuser.yaml"
+
+const validSA = `name: analytics_astro_prod_appuser
+data_product: analytics
+environment: prod
+roles:
+- analytics_reader
+`
+
+// mockFileFetcher serves file contents keyed by "ref:path"
+type mockFileFetcher struct {
+	files map[string]string
+}
+
+func (m *mockFileFetcher) FetchFileContent(ctx context.Context, projectID int, filePath, ref string) (*gitlab.FileContent, error) {
+	content, ok := m.files[ref+":"+filePath]
+	if !ok {
+		return nil, fmt.Errorf("file not found: %s: %w", filePath, gitlab.ErrNotFound)
+	}
+	return &gitlab.FileContent{FilePath: filePath, Content: content, Ref: ref}, nil
+}
+
+func newTestRule(files map[string]string, change gitlab.FileChange) *Rule {
+	rule := NewRule(&mockFileFetcher{files: files}, nil)
+	rule.SetMRContext(&shared.MRContext{
+		ProjectID: 1,
+		MRIID:     2,
+		Changes:   []gitlab.FileChange{change},
+		MRInfo:    &gitlab.MRInfo{SourceBranch: "feature", TargetBranch: "main"},
+	})
+	return rule
+}
+
+func TestParseName(t *testing.T) {
+	parts, ok := ParseName("analytics_astro_prod_appuser")
+	assert.True(t, ok)
+	assert.Equal(t, NameParts{DataProduct: "analytics", Tool: "astro", Environment: "prod"}, parts)
+
+	for _, name := range []string{"analytics_astro_prod_user", "analytics_prod_appuser", "Analytics_astro_prod_appuser", "analytics_astro__appuser"} {
+		_, ok := ParseName(name)
+		assert.False(t, ok, name)
+	}
+}
+
+func TestRule_GetCoveredLines(t *testing.T) {
+	rule := NewRule(nil, nil)
+	assert.NotEmpty(t, rule.GetCoveredLines(saPath, validSA))
+	assert.NotEmpty(t, rule.GetCoveredLines("serviceaccounts/dev/analytics_dbt_dev_appuser.yml", validSA))
+	assert.Empty(t, rule.GetCoveredLines(saPath, ""))
+	assert.Empty(t, rule.GetCoveredLines("serviceaccounts/analytics_astro_prod_appuser.yaml", validSA))
+	assert.Empty(t, rule.GetCoveredLines("dataproducts/source/analytics/prod/product.yaml", validSA))
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		sa       ServiceAccountYAML
+		path     string
+		problems []string
+	}{
+		{
+			name: "valid",
+			sa:   ServiceAccountYAML{Name: "analytics_astro_prod_appuser", DataProduct: "analytics", Environment: "prod"},
+			path: saPath,
+		},
+		{
+			name:     "missing fields",
+			sa:       ServiceAccountYAML{Name: "analytics_astro_prod_appuser"},
+			path:     saPath,
+			problems: []string{"data_product: required field is missing", "environment: required field is missing"},
+		},
+		{
+			name: "name differs from file name",
+			sa:   ServiceAccountYAML{Name: "analytics_astro_prod_user", DataProduct: "analytics", Environment: "prod"},
+			path: saPath,
+			problems: []string{
+				"name: 'analytics_astro_prod_user' does not match file name 'analytics_astro_prod_appuser'",
+				"name: 'analytics_astro_prod_user' must follow <dataproduct>_<tool>_<env>_appuser",
+			},
+		},
+		{
+			name: "wrong environment folder",
+			sa:   ServiceAccountYAML{Name: "analytics_astro_prod_appuser", DataProduct: "analytics", Environment: "prod"},
+			path: "serviceaccounts/dev/analytics_astro_prod_appuser.yaml",
+			problems: []string{
+				"name: environment 'prod' does not match folder serviceaccounts/dev/",
+				"environment: 'prod' does not match folder serviceaccounts/dev/",
+			},
+		},
+		{
+			name:     "data product differs from name",
+			sa:       ServiceAccountYAML{Name: "analytics_astro_prod_appuser", DataProduct: "sales", Environment: "prod"},
+			path:     saPath,
+			problems: []string{"data_product: 'sales' does not match name prefix 'analytics'"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.problems, Validate(&tt.sa, tt.path))
+		})
+	}
+}
+
+func TestRule_ValidateLines(t *testing.T) {
+	files := map[string]string{"main:" + saPath: validSA}
+	change := gitlab.FileChange{OldPath: saPath, NewPath: saPath}
+
+	tests := []struct {
+		name             string
+		content          string
+		change           gitlab.FileChange
+		expectedDecision shared.DecisionType
+		reasonContains   string
+	}{
+		{
+			name:             "unchanged roles auto-approve",
+			content:          validSA,
+			change:           change,
+			expectedDecision: shared.Approve,
+			reasonContains:   "without privilege escalation",
+		},
+		{
+			name:             "regular role auto-approves",
+			content:          validSA + "- analytics_writer\n",
+			change:           change,
+			expectedDecision: shared.Approve,
+		},
+		{
+			name:             "elevated role requires review",
+			content:          validSA + "- SYSADMIN\n",
+			change:           change,
+			expectedDecision: shared.ManualReview,
+			reasonContains:   "granted elevated roles: SYSADMIN",
+		},
+		{
+			name:             "new account with elevated role requires review",
+			content:          "name: analytics_dbt_prod_appuser\ndata_product: analytics\nenvironment: prod\nroles: [accountadmin]\n",
+			change:           gitlab.FileChange{NewPath: "serviceaccounts/prod/analytics_dbt_prod_appuser.yaml", NewFile: true},
+			expectedDecision: shared.ManualReview,
+			reasonContains:   "accountadmin",
+		},
+		{
+			name:             "invalid definition requires review",
+			content:          "name: analytics_astro_prod_appuser\nenvironment: prod\n",
+			change:           change,
+			expectedDecision: shared.ManualReview,
+			reasonContains:   "Service account validation failed: data_product: required field is missing",
+		},
+		{
+			name:             "unparsable YAML requires review",
+			content:          "name: [unclosed",
+			change:           change,
+			expectedDecision: shared.ManualReview,
+			reasonContains:   "Failed to parse service account YAML",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := tt.change.NewPath
+			rule := newTestRule(files, tt.change)
+			decision, reason := rule.ValidateLines(filePath, tt.content, rule.GetCoveredLines(filePath, tt.content))
+			assert.Equal(t, tt.expectedDecision, decision, reason)
+			assert.Contains(t, reason, tt.reasonContains)
+		})
+	}
+}
+
+func TestRule_ExistingElevatedRoleKept(t *testing.T) {
+	old := validSA + "- sysadmin\n"
+	rule := newTestRule(map[string]string{"main:" + saPath: old}, gitlab.FileChange{OldPath: saPath, NewPath: saPath})
+
+	decision, reason := rule.ValidateLines(saPath, old+"- analytics_writer\n", nil)
+	assert.Equal(t, shared.Approve, decision, reason)
+}
+
+func TestRule_WithoutMRContext(t *testing.T) {
+	rule := NewRule(nil, []string{"owner"})
+
+	decision, _ := rule.ValidateLines(saPath, validSA, nil)
+	assert.Equal(t, shared.Approve, decision)
+
+	decision, reason := rule.ValidateLines(saPath, validSA+"- owner\n", nil)
+	assert.Equal(t, shared.ManualReview, decision)
+	assert.Contains(t, reason, "owner")
+}
@@ -0,0 +1,46 @@
+package serviceaccount
+
+import "regexp"
+
+// Default roles that grant elevated privileges to a service account
+var DefaultElevatedRoles = []string{"accountadmin", "orgadmin", "securityadmin", "sysadmin", "useradmin"}
+
+// NameRegex matches service account names: <dataproduct>_<tool>_<env>_appuser
+var NameRegex = regexp.MustCompile(`^([a-z0-9]+)_([a-z0-9]+)_([a-z0-9-]+)_appuser$`)
+
+// ServiceAccountConfig holds configuration for service account validation
+type ServiceAccountConfig struct {
+	// Roles whose addition requires manual review
+	ElevatedRoles []string
+}
+
+// DefaultServiceAccountConfig returns default configuration
+func DefaultServiceAccountConfig() *ServiceAccountConfig {
+	return &ServiceAccountConfig{
+		ElevatedRoles: DefaultElevatedRoles,
+	}
+}
+
+// ServiceAccountYAML represents a serviceaccounts/<env>/<name>.yaml file
+type ServiceAccountYAML struct {
+	Name        string   `yaml:"name"`
+	DataProduct string   `yaml:"data_product"`
+	Environment string   `yaml:"environment"`
+	Roles       []string `yaml:"roles"`
+}
+
+// NameParts are the components of a service account name
+type NameParts struct {
+	DataProduct string
+	Tool        string
+	Environment string
+}
+
+// ParseName splits a service account name into its components
+func ParseName(name string) (NameParts, bool) {
+	m := NameRegex.FindStringSubmatch(name)
+	if m == nil {
+		return NameParts{}, false
+	}
+	return NameParts{DataProduct: m[1], Tool: m[2], Environment: m[3]}, true
+}
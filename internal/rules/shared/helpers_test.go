@@ -60,6 +60,13 @@ func TestIsDraftMR(t *testing.T) {
 			},
 			expected: true,
 		},
+		{
+			name: "marked as draft",
+			mrCtx: &MRContext{
+				MRInfo: &gitlab.MRInfo{Title: "Add new feature", Draft: true},
+			},
+			expected: true,
+		},
 		{
 			name: "normal title",
 			mrCtx: &MRContext{
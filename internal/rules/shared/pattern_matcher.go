@@ -1,6 +1,7 @@
 package shared
 
 import (
+	"fmt"
 	"path/filepath"
 	"strings"
 )
@@ -138,3 +139,29 @@ func MatchesPattern(filePath, pattern string) bool {
 func MatchesAnyPattern(filePath string, patterns []string) bool {
 	return GlobalPatternMatcher.MatchesAnyPattern(filePath, patterns)
 }
+
+// ValidatePattern reports patterns MatchesPattern cannot match as written: unbalanced or
+// repeated brace groups, more than one ** and malformed character classes
+func ValidatePattern(pattern string) error {
+	if strings.Count(pattern, "{") != strings.Count(pattern, "}") {
+		return fmt.Errorf("pattern %q has unbalanced braces", pattern)
+	}
+	if strings.Count(pattern, "{") > 1 {
+		return fmt.Errorf("pattern %q has more than one {a,b} group, only one is supported", pattern)
+	}
+	if strings.Index(pattern, "}") < strings.Index(pattern, "{") {
+		return fmt.Errorf("pattern %q closes a brace before opening it", pattern)
+	}
+
+	for _, expanded := range GlobalPatternMatcher.expandBracePattern(pattern) {
+		if strings.Count(expanded, "**") > 1 {
+			return fmt.Errorf("pattern %q has more than one **, only one is supported", pattern)
+		}
+		for _, part := range strings.Split(expanded, "**") {
+			if _, err := filepath.Match(part, ""); err != nil {
+				return fmt.Errorf("pattern %q is malformed: %w", pattern, err)
+			}
+		}
+	}
+	return nil
+}
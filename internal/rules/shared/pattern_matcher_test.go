@@ -191,3 +191,15 @@ func TestPatternMatcher_GlobstarPatterns(t *testing.T) {
 		})
 	}
 }
+
+func TestValidatePattern(t *testing.T) {
+	valid := []string{"**/", "dataproducts/**/", "product.{yaml,yml}", "dataproducts/**/prod/product.{yaml,yml}", "*masking.[yj]*"}
+	for _, pattern := range valid {
+		assert.NoError(t, ValidatePattern(pattern), pattern)
+	}
+
+	invalid := []string{"product.{yaml,yml", "product.yaml}{", "{a,b}/{c,d}", "dataproducts/**/critical/**", "product.[yaml", "dataproducts/**/[a-"}
+	for _, pattern := range invalid {
+		assert.Error(t, ValidatePattern(pattern), pattern)
+	}
+}
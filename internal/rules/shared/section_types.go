@@ -10,6 +10,7 @@ type SectionType string
 const (
 	YAMLSection     SectionType = "yaml"
 	JSONSection     SectionType = "json"
+	TOMLSection     SectionType = "toml"
 	TextSection     SectionType = "text"
 	MarkdownSection SectionType = "markdown"
 )
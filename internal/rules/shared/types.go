@@ -1,6 +1,7 @@
 package shared
 
 import (
+	"context"
 	"sort"
 	"strings"
 	"time"
@@ -14,6 +15,7 @@ type DecisionType string
 const (
 	Approve      DecisionType = "approve"       // Auto-approve the MR
 	ManualReview DecisionType = "manual_review" // Require manual approval
+	Warn         DecisionType = "warn"          // Approve, listing the finding as a warning in the comment
 )
 
 // Decision represents a simplified approval decision for a merge request
@@ -39,9 +41,69 @@ type MRContext struct {
 	MRIID       int                 `json:"mr_iid"`
 	Changes     []gitlab.FileChange `json:"changes"`
 	MRInfo      *gitlab.MRInfo      `json:"mr_info"`
-	Environment string              `json:"environment,omitempty"`
+	Environment string              `json:"environment,omitempty"` // Set when every environment-specific file targets the same environment
 	Labels      []string            `json:"labels,omitempty"`
 	Metadata    map[string]any      `json:"metadata,omitempty"`
+	// Environments maps changed files to the environment they target, see ResolveEnvironments
+	Environments map[string]string `json:"environments,omitempty"`
+	// Ctx bounds the GitLab calls rules make while evaluating the MR
+	Ctx context.Context `json:"-"`
+}
+
+// Context returns the context bounding the GitLab calls of the evaluation, or
+// context.Background when none was set
+func (m *MRContext) Context() context.Context {
+	if m == nil || m.Ctx == nil {
+		return context.Background()
+	}
+	return m.Ctx
+}
+
+// ResolveEnvironments records the environment of every changed file that targets one and
+// sets Environment, unless already set, when all of them target the same environment
+func (m *MRContext) ResolveEnvironments() {
+	m.Environments = make(map[string]string)
+	environments := make(map[string]bool)
+	for _, change := range m.Changes {
+		for _, filePath := range []string{change.OldPath, change.NewPath} {
+			if env := EnvironmentFromPath(filePath); env != "" {
+				m.Environments[filePath] = env
+				environments[env] = true
+			}
+		}
+	}
+	if m.Environment == "" && len(environments) == 1 {
+		for env := range environments {
+			m.Environment = env
+		}
+	}
+}
+
+// EnvironmentOf returns the environment a file targets, empty for files that are not
+// environment-specific. Files outside the resolved changes are resolved from their path.
+func (m *MRContext) EnvironmentOf(filePath string) string {
+	if m != nil {
+		if env, ok := m.Environments[filePath]; ok {
+			return env
+		}
+	}
+	return EnvironmentFromPath(filePath)
+}
+
+// EnvironmentFromPath returns the lowercased environment of a data product file, the directory
+// holding it in dataproducts/[<type>/]<name>/<env>/, or of a service account file in
+// serviceaccounts/<env>/. It is empty for other files.
+func EnvironmentFromPath(filePath string) string {
+	segments := strings.Split(strings.ToLower(filePath), "/")
+	for i, segment := range segments {
+		switch {
+		case segment == "dataproducts" && len(segments)-i >= 4:
+			return segments[len(segments)-2]
+		case segment == "serviceaccounts" && len(segments)-i >= 3:
+			return segments[len(segments)-2]
+		}
+	}
+	return ""
 }
 
 // Rule defines a simplified interface for all rules
@@ -67,6 +129,34 @@ type ContextAwareRule interface {
 	SetMRContext(mrCtx *MRContext)
 }
 
+// DeletionAwareRule is an optional interface for rules that check files removed by the MR.
+// Deletion policies decide removed files; these rules can only escalate to manual review.
+type DeletionAwareRule interface {
+	Rule
+
+	// ValidateDeletion checks a removed file; Approve keeps the deletion policy decision
+	ValidateDeletion(filePath string) (DecisionType, string)
+}
+
+// EnforcedRule is an optional interface for rules whose manual reviews must not be approved
+// or downgraded by the environment behaviors and severities of the rule configuration
+type EnforcedRule interface {
+	Rule
+
+	// IsEnforced reports whether the manual review with reason always stands
+	IsEnforced(reason string) bool
+}
+
+// OutvotableRule is an optional interface for rules whose manual reviews a quorum of approving
+// files may outvote. Manual reviews of rules not implementing it always need a human.
+type OutvotableRule interface {
+	Rule
+
+	// Outvotable reports whether the manual review with reason may be approved when enough
+	// other files of the MR approve
+	Outvotable(reason string) bool
+}
+
 // RuleManager manages and executes rules with simple logic
 type RuleManager interface {
 	// AddRule registers a rule
@@ -100,6 +190,7 @@ type FileValidationSummary struct {
 	UncoveredLines []LineRange            `json:"uncovered_lines"`
 	RuleResults    []LineValidationResult `json:"rule_results"`
 	FileDecision   DecisionType           `json:"file_decision"`
+	Reviewers      []string               `json:"reviewers,omitempty"` // Usernames a policy asks to review the file, without @
 }
 
 // RuleEvaluation contains the results of evaluating all rules
@@ -113,15 +204,91 @@ type RuleEvaluation struct {
 	ApprovedFiles  int `json:"approved_files"`
 	ReviewFiles    int `json:"review_files"`
 	UncoveredFiles int `json:"uncovered_files"`
+
+	// Activation status of the scheduled rules when the MR was evaluated
+	RuleSchedules []RuleScheduleStatus `json:"rule_schedules,omitempty"`
+
+	// Human approvals required by matching approval policies before naysayer approves
+	ApprovalRequirements []ApprovalRequirement `json:"approval_requirements,omitempty"`
+
+	// Set when new commits turned a previous approval into a manual review
+	ApprovalRevocation *ApprovalRevocation `json:"approval_revocation,omitempty"`
+
+	// Identify the decision in the audit log and the evaluation snapshot it was made from
+	DecisionID string `json:"decision_id,omitempty"`
+	SnapshotID string `json:"snapshot_id,omitempty"`
+}
+
+// RuleWarning is a rule finding downgraded to a warning by a rule severity
+type RuleWarning struct {
+	FilePath string `json:"file_path"`
+	RuleName string `json:"rule_name"`
+	Reason   string `json:"reason"`
+}
+
+// Warnings returns the warnings of the evaluated rules, sorted by file and rule
+func (e *RuleEvaluation) Warnings() []RuleWarning {
+	var warnings []RuleWarning
+	for filePath, fileValidation := range e.FileValidations {
+		if fileValidation == nil {
+			continue
+		}
+		for _, result := range fileValidation.RuleResults {
+			if result.WasEvaluated && result.Decision == Warn {
+				warnings = append(warnings, RuleWarning{FilePath: filePath, RuleName: result.RuleName, Reason: result.Reason})
+			}
+		}
+	}
+	sort.Slice(warnings, func(i, j int) bool {
+		if warnings[i].FilePath != warnings[j].FilePath {
+			return warnings[i].FilePath < warnings[j].FilePath
+		}
+		return warnings[i].RuleName < warnings[j].RuleName
+	})
+	return warnings
+}
+
+// ApprovalRevocation records a naysayer approval revoked because new commits changed the decision
+type ApprovalRevocation struct {
+	PreviousCommit string           `json:"previous_commit,omitempty"` // Head of the source branch before the push
+	Regressions    []RuleRegression `json:"regressions,omitempty"`
+}
+
+// RuleRegression is a rule result that no longer approves a file
+type RuleRegression struct {
+	FilePath string       `json:"file_path"`
+	RuleName string       `json:"rule_name"`
+	Previous DecisionType `json:"previous"` // Empty when the rule did not run on the file before
+	Current  DecisionType `json:"current"`
+	Reason   string       `json:"reason"`
+}
+
+// ApprovalRequirement is a number of human approvals an approval policy requires
+type ApprovalRequirement struct {
+	Policy    string   `json:"policy"`
+	Approvals int      `json:"approvals"`
+	Approvers []string `json:"approvers,omitempty"` // Usernames or group paths whose approvals count; empty counts any human approval
+	Reason    string   `json:"reason,omitempty"`
+}
+
+// RuleScheduleStatus tells whether a scheduled rule is active
+type RuleScheduleStatus struct {
+	Rule           string     `json:"rule"`
+	Active         bool       `json:"active"`
+	Reason         string     `json:"reason"`
+	NextActivation *time.Time `json:"next_activation,omitempty"` // Set for inactive rules that activate again
 }
 
 // Common helper functions for rule evaluation
 
-// IsDraftMR returns true if the MR is a draft/WIP
+// IsDraftMR returns true if the MR is marked as draft or has a draft/WIP title
 func IsDraftMR(mrCtx *MRContext) bool {
 	if mrCtx.MRInfo == nil {
 		return false
 	}
+	if mrCtx.MRInfo.Draft {
+		return true
+	}
 
 	title := strings.ToLower(mrCtx.MRInfo.Title)
 	return strings.Contains(title, "draft") ||
@@ -275,3 +275,46 @@ func BuildDataProductApprovalMessage(changeCount int) string {
 	}
 	return fmt.Sprintf("Auto-approving MR with only %d data product changes", changeCount)
 }
+
+func TestEnvironmentFromPath(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{"dataproducts/source/orders/prod/product.yaml", "prod"},
+		{"dataproducts/analytics/Sandbox/product.yaml", "sandbox"},
+		{"dataproducts/source/orders/dev/pii_masking.yaml", "dev"},
+		{"dataproducts/product.yaml", ""},
+		{"serviceaccounts/preprod/orders_appuser.yaml", "preprod"},
+		{"docs/dev/README.md", ""},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, EnvironmentFromPath(tt.path), tt.path)
+	}
+}
+
+func TestMRContext_ResolveEnvironments(t *testing.T) {
+	mrCtx := &MRContext{Changes: []gitlab.FileChange{
+		{OldPath: "dataproducts/source/orders/dev/product.yaml", NewPath: "dataproducts/source/orders/dev/product.yaml"},
+		{OldPath: "serviceaccounts/dev/orders.yaml", NewPath: "serviceaccounts/dev/orders.yaml"},
+		{OldPath: "README.md", NewPath: "README.md"},
+	}}
+	mrCtx.ResolveEnvironments()
+	assert.Equal(t, "dev", mrCtx.Environment)
+	assert.Equal(t, map[string]string{
+		"dataproducts/source/orders/dev/product.yaml": "dev",
+		"serviceaccounts/dev/orders.yaml":             "dev",
+	}, mrCtx.Environments)
+	assert.Equal(t, "dev", mrCtx.EnvironmentOf("serviceaccounts/dev/orders.yaml"))
+	assert.Equal(t, "prod", mrCtx.EnvironmentOf("dataproducts/source/orders/prod/product.yaml"), "other files are resolved from their path")
+	assert.Empty(t, mrCtx.EnvironmentOf("README.md"))
+
+	mrCtx.Changes = append(mrCtx.Changes, gitlab.FileChange{NewPath: "dataproducts/source/orders/prod/product.yaml", NewFile: true})
+	mrCtx.Environment = ""
+	mrCtx.ResolveEnvironments()
+	assert.Empty(t, mrCtx.Environment, "MRs spanning environments have no single environment")
+	assert.Equal(t, "prod", mrCtx.Environments["dataproducts/source/orders/prod/product.yaml"])
+
+	var empty *MRContext
+	assert.Equal(t, "prod", empty.EnvironmentOf("dataproducts/source/orders/prod/product.yaml"))
+}
@@ -0,0 +1,80 @@
+package shared
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+)
+
+// FileFetcher fetches file content from a GitLab project
+type FileFetcher interface {
+	FetchFileContent(ctx context.Context, projectID int, filePath, ref string) (*gitlab.FileContent, error)
+}
+
+// FileVersions holds the target branch and MR versions of a file the MR changes, so rules
+// can tell what a change adds from what it removes
+type FileVersions struct {
+	FilePath   string // Path of the file in the MR
+	OldPath    string // Path on the target branch, differs from FilePath for renames
+	OldContent string // Content on the target branch, empty for new files
+	NewContent string // Content in the MR
+	IsNew      bool   // The file does not exist on the target branch
+}
+
+// LoadFileVersions fetches the target branch version of a file the MR changes. It returns
+// nil without an error when the versions cannot be compared: without client or MR context,
+// or for files that are not among the MR changes.
+func LoadFileVersions(client FileFetcher, mrCtx *MRContext, filePath, newContent string) (*FileVersions, error) {
+	if client == nil || mrCtx == nil || mrCtx.MRInfo == nil {
+		return nil, nil
+	}
+
+	var change *gitlab.FileChange
+	for i := range mrCtx.Changes {
+		if mrCtx.Changes[i].NewPath == filePath {
+			change = &mrCtx.Changes[i]
+			break
+		}
+	}
+	if change == nil {
+		return nil, nil
+	}
+
+	versions := &FileVersions{FilePath: filePath, OldPath: filePath, NewContent: newContent}
+	if change.OldPath != "" {
+		versions.OldPath = change.OldPath
+	}
+	if change.NewFile {
+		versions.IsNew = true
+		return versions, nil
+	}
+
+	content, err := client.FetchFileContent(mrCtx.Context(), mrCtx.ProjectID, versions.OldPath, mrCtx.MRInfo.TargetBranch)
+	if errors.Is(err, gitlab.ErrNotFound) {
+		versions.IsNew = true
+		return versions, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch previous version of %s: %w", versions.OldPath, err)
+	}
+	if content == nil {
+		return nil, fmt.Errorf("empty response when fetching %s", versions.OldPath)
+	}
+	versions.OldContent = content.Content
+	return versions, nil
+}
+
+// ParseVersions parses both versions of a file with parse. before is nil for new files.
+func ParseVersions[T any](versions *FileVersions, parse func(content string) (*T, error)) (before, after *T, err error) {
+	if !versions.IsNew {
+		if before, err = parse(versions.OldContent); err != nil {
+			return nil, nil, fmt.Errorf("previous version of %s: %w", versions.OldPath, err)
+		}
+	}
+	if after, err = parse(versions.NewContent); err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", versions.FilePath, err)
+	}
+	return before, after, nil
+}
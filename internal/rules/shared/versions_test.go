@@ -0,0 +1,103 @@
+package shared
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/stretchr/testify/assert"
+)
+
+// versionsFetcher serves the target branch files it holds
+type versionsFetcher struct {
+	files map[string]string
+	err   error
+}
+
+func (f *versionsFetcher) FetchFileContent(ctx context.Context, projectID int, filePath, ref string) (*gitlab.FileContent, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	content, ok := f.files[ref+":"+filePath]
+	if !ok {
+		return nil, gitlab.ErrNotFound
+	}
+	return &gitlab.FileContent{Content: content}, nil
+}
+
+func TestLoadFileVersions(t *testing.T) {
+	fetcher := &versionsFetcher{files: map[string]string{
+		"main:a.yaml":   "old a",
+		"main:old.yaml": "old renamed",
+	}}
+	mrCtx := &MRContext{
+		MRInfo: &gitlab.MRInfo{TargetBranch: "main"},
+		Changes: []gitlab.FileChange{
+			{OldPath: "a.yaml", NewPath: "a.yaml"},
+			{OldPath: "old.yaml", NewPath: "new.yaml", RenamedFile: true},
+			{NewPath: "added.yaml", NewFile: true},
+			{OldPath: "gone.yaml", NewPath: "gone.yaml"},
+		},
+	}
+
+	versions, err := LoadFileVersions(fetcher, mrCtx, "a.yaml", "new a")
+	assert.NoError(t, err)
+	assert.Equal(t, &FileVersions{FilePath: "a.yaml", OldPath: "a.yaml", OldContent: "old a", NewContent: "new a"}, versions)
+
+	versions, err = LoadFileVersions(fetcher, mrCtx, "new.yaml", "new renamed")
+	assert.NoError(t, err)
+	assert.Equal(t, "old.yaml", versions.OldPath)
+	assert.Equal(t, "old renamed", versions.OldContent)
+
+	versions, err = LoadFileVersions(fetcher, mrCtx, "added.yaml", "added")
+	assert.NoError(t, err)
+	assert.True(t, versions.IsNew)
+
+	versions, err = LoadFileVersions(fetcher, mrCtx, "gone.yaml", "content")
+	assert.NoError(t, err)
+	assert.True(t, versions.IsNew, "files missing on the target branch are new")
+
+	versions, err = LoadFileVersions(fetcher, mrCtx, "unchanged.yaml", "content")
+	assert.NoError(t, err)
+	assert.Nil(t, versions, "files the MR does not change")
+
+	versions, err = LoadFileVersions(nil, mrCtx, "a.yaml", "new a")
+	assert.NoError(t, err)
+	assert.Nil(t, versions)
+
+	versions, err = LoadFileVersions(fetcher, nil, "a.yaml", "new a")
+	assert.NoError(t, err)
+	assert.Nil(t, versions)
+
+	_, err = LoadFileVersions(&versionsFetcher{err: errors.New("timeout")}, mrCtx, "a.yaml", "new a")
+	assert.EqualError(t, err, "failed to fetch previous version of a.yaml: timeout")
+}
+
+func TestParseVersions(t *testing.T) {
+	parse := func(content string) (*int, error) {
+		n, err := strconv.Atoi(content)
+		if err != nil {
+			return nil, fmt.Errorf("not a number")
+		}
+		return &n, nil
+	}
+
+	before, after, err := ParseVersions(&FileVersions{FilePath: "n", OldPath: "n", OldContent: "1", NewContent: "2"}, parse)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, *before)
+	assert.Equal(t, 2, *after)
+
+	before, after, err = ParseVersions(&FileVersions{FilePath: "n", NewContent: "2", IsNew: true}, parse)
+	assert.NoError(t, err)
+	assert.Nil(t, before)
+	assert.Equal(t, 2, *after)
+
+	_, _, err = ParseVersions(&FileVersions{FilePath: "n", OldPath: "m", OldContent: "x", NewContent: "2"}, parse)
+	assert.EqualError(t, err, "previous version of m: not a number")
+
+	_, _, err = ParseVersions(&FileVersions{FilePath: "n", OldPath: "n", OldContent: "1", NewContent: "x"}, parse)
+	assert.EqualError(t, err, "n: not a number")
+}
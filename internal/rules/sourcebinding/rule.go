@@ -0,0 +1,312 @@
+package sourcebinding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/repoindex"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/common"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+// RuleName is the identifier of the source binding rule
+const RuleName = "sourcebinding_rule"
+
+// sourceBindingFilePattern matches source binding definitions
+const sourceBindingFilePattern = "dataproducts/**/sourcebinding.{yaml,yml}"
+
+// productTypeDirs are the type directories a referenced data product may live in; the empty
+// one stands for products directly below dataproducts/
+var productTypeDirs = []string{"source", "aggregate", "platform", ""}
+
+// FileFetcher is the subset of the GitLab client needed to load product definitions and
+// previous source binding versions
+type FileFetcher interface {
+	FetchFileContent(ctx context.Context, projectID int, filePath, ref string) (*gitlab.FileContent, error)
+}
+
+// Rule validates dataproducts/**/sourcebinding.yaml files: well-formed schemas and tables,
+// environments matching the path, privileges within an allowlist and existing referenced
+// data products. Bindings the MR adds to another data product require manual review.
+type Rule struct {
+	*common.BaseRule
+	client            FileFetcher
+	allowedPrivileges []string
+}
+
+// NewRule creates a new source binding rule instance allowing allowedPrivileges
+// (DefaultAllowedPrivileges when empty)
+func NewRule(client FileFetcher, allowedPrivileges []string) *Rule {
+	if len(allowedPrivileges) == 0 {
+		allowedPrivileges = DefaultAllowedPrivileges
+	}
+	return &Rule{
+		BaseRule:          common.NewBaseRule(RuleName, "Validates sourcebinding.yaml files and requires manual review for new bindings to other data products"),
+		client:            client,
+		allowedPrivileges: allowedPrivileges,
+	}
+}
+
+// IsSourceBindingFile checks if the path is a source binding definition
+func IsSourceBindingFile(filePath string) bool {
+	return shared.MatchesPattern(filePath, sourceBindingFilePattern)
+}
+
+// GetCoveredLines returns line ranges this rule covers
+func (r *Rule) GetCoveredLines(filePath string, fileContent string) []shared.LineRange {
+	if !IsSourceBindingFile(filePath) || strings.TrimSpace(fileContent) == "" {
+		return []shared.LineRange{}
+	}
+	return r.GetFullFileCoverage(filePath, fileContent)
+}
+
+// ValidateLines validates a source binding definition
+func (r *Rule) ValidateLines(filePath string, fileContent string, lineRanges []shared.LineRange) (shared.DecisionType, string) {
+	if !IsSourceBindingFile(filePath) {
+		return shared.Approve, "Not a source binding file - rule does not apply"
+	}
+
+	bindings, err := ParseBindings(fileContent)
+	if err != nil {
+		return shared.ManualReview, fmt.Sprintf("Failed to parse source binding YAML: %v", err)
+	}
+	if problems := r.validate(bindings, filePath); len(problems) > 0 {
+		return shared.ManualReview, "Source binding validation failed: " + strings.Join(problems, "; ")
+	}
+
+	previous, err := r.previousBindings(filePath)
+	if err != nil {
+		return shared.ManualReview, fmt.Sprintf("Could not load previous version of source binding: %v", err)
+	}
+	if added := r.newCrossBindings(filePath, bindings, previous); len(added) > 0 {
+		return shared.ManualReview, fmt.Sprintf("New cross-data-product source bindings: %s - manual review required", strings.Join(added, ", "))
+	}
+	return shared.Approve, "Source bindings are valid and bind no new data products"
+}
+
+// validate checks the bindings of a file in dataproducts/[<type>/]<name>/<env>/ and returns
+// the problems found
+func (r *Rule) validate(bindings []Binding, filePath string) []string {
+	if len(bindings) == 0 {
+		return []string{"no sources declared - expected a source list or a database"}
+	}
+
+	var problems []string
+	environment := shared.EnvironmentFromPath(filePath)
+	if environment == "" {
+		problems = append(problems, "file must be in dataproducts/[<type>/]<name>/<env>/")
+	}
+	ownProduct := productDir(filePath)
+
+	for i, binding := range bindings {
+		prefix := fmt.Sprintf("source %d (%s): ", i+1, binding)
+		if binding.Database == "" && binding.DataProduct == "" {
+			problems = append(problems, prefix+"database or data_product is required")
+		}
+		if binding.Database != "" && !IdentifierRegex.MatchString(binding.Database) {
+			problems = append(problems, fmt.Sprintf("%sdatabase %q is not a valid identifier", prefix, binding.Database))
+		}
+		if binding.Schema == "" {
+			problems = append(problems, prefix+"schema is required")
+		} else if !IdentifierRegex.MatchString(binding.Schema) {
+			problems = append(problems, fmt.Sprintf("%sschema %q is not a valid identifier", prefix, binding.Schema))
+		}
+		for _, table := range binding.TableNames() {
+			if !IdentifierRegex.MatchString(table) {
+				problems = append(problems, fmt.Sprintf("%stable %q is not a valid identifier", prefix, table))
+			}
+		}
+		for _, privilege := range binding.Privileges {
+			if !r.privilegeAllowed(privilege) {
+				problems = append(problems, fmt.Sprintf("%sprivilege %q is not allowed (allowed: %s)", prefix, privilege, strings.Join(r.allowedPrivileges, ", ")))
+			}
+		}
+		if binding.Environment != "" && environment != "" && !strings.EqualFold(binding.Environment, environment) {
+			problems = append(problems, fmt.Sprintf("%senvironment %q does not match the %s folder", prefix, binding.Environment, environment))
+		}
+		if binding.DataProduct != "" && environment != "" && !strings.EqualFold(binding.DataProduct, ownProduct) && !r.productExists(binding.DataProduct, environment) {
+			problems = append(problems, fmt.Sprintf("%sdata product %q has no product.yaml in the %s environment", prefix, binding.DataProduct, environment))
+		}
+	}
+	return problems
+}
+
+func (r *Rule) privilegeAllowed(privilege string) bool {
+	for _, allowed := range r.allowedPrivileges {
+		if strings.EqualFold(allowed, privilege) {
+			return true
+		}
+	}
+	return false
+}
+
+// newCrossBindings returns the bindings to other data products that previous did not have.
+// A binding belongs to another data product when it names one, or when its database is not
+// a data_product_db of the product.yaml next to the file.
+func (r *Rule) newCrossBindings(filePath string, bindings, previous []Binding) []string {
+	had := make(map[string]bool, len(previous))
+	for _, binding := range previous {
+		had[binding.Key()] = true
+	}
+
+	names, databases := r.ownProduct(filePath)
+	var added []string
+	for _, binding := range bindings {
+		if had[binding.Key()] {
+			continue
+		}
+		had[binding.Key()] = true
+		own := databases[strings.ToLower(binding.Database)]
+		if binding.DataProduct != "" {
+			own = names[strings.ToLower(binding.DataProduct)]
+		}
+		if !own {
+			added = append(added, binding.String())
+		}
+	}
+	return added
+}
+
+// ownProduct returns the lowercased names and databases of the data product a source binding
+// belongs to, from its directory and the product.yaml next to it in the MR
+func (r *Rule) ownProduct(filePath string) (map[string]bool, map[string]bool) {
+	names := map[string]bool{strings.ToLower(productDir(filePath)): true}
+	databases := make(map[string]bool)
+
+	mrCtx := r.GetMRContext()
+	if mrCtx == nil || mrCtx.MRInfo == nil || r.client == nil {
+		return names, databases
+	}
+	for _, name := range []string{"product.yaml", "product.yml"} {
+		productPath := path.Join(path.Dir(filePath), name)
+		content, err := r.client.FetchFileContent(mrCtx.Context(), mrCtx.ProjectID, productPath, mrCtx.MRInfo.SourceBranch)
+		if err != nil || content == nil {
+			continue
+		}
+
+		var product struct {
+			Name          string    `yaml:"name"`
+			DataProductDB yaml.Node `yaml:"data_product_db"`
+		}
+		if err := yaml.Unmarshal([]byte(content.Content), &product); err != nil {
+			logging.Warn("Failed to parse data product for source binding ownership",
+				zap.String("path", productPath),
+				zap.Error(err))
+			return names, databases
+		}
+		if product.Name != "" {
+			names[strings.ToLower(product.Name)] = true
+		}
+
+		// data_product_db may be a list of databases or a single database
+		var dbs []productDatabase
+		switch product.DataProductDB.Kind {
+		case yaml.SequenceNode:
+			_ = product.DataProductDB.Decode(&dbs)
+		case yaml.MappingNode:
+			var db productDatabase
+			if product.DataProductDB.Decode(&db) == nil {
+				dbs = append(dbs, db)
+			}
+		}
+		for _, db := range dbs {
+			if db.Database != "" {
+				databases[strings.ToLower(db.Database)] = true
+			}
+		}
+		return names, databases
+	}
+	return names, databases
+}
+
+// previousBindings returns the bindings of the file on the target branch. New files have
+// none; without MR context every binding counts as new.
+func (r *Rule) previousBindings(filePath string) ([]Binding, error) {
+	mrCtx := r.GetMRContext()
+	if mrCtx == nil || mrCtx.MRInfo == nil || r.client == nil {
+		return nil, nil
+	}
+
+	oldPath := filePath
+	for _, change := range mrCtx.Changes {
+		if change.NewPath != filePath {
+			continue
+		}
+		if change.NewFile {
+			return nil, nil
+		}
+		if change.OldPath != "" {
+			oldPath = change.OldPath
+		}
+		break
+	}
+
+	content, err := r.client.FetchFileContent(mrCtx.Context(), mrCtx.ProjectID, oldPath, mrCtx.MRInfo.TargetBranch)
+	if errors.Is(err, gitlab.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		logging.Warn("Failed to fetch previous version", zap.String("old_path", oldPath), zap.Error(err))
+		return nil, err
+	}
+	if content == nil {
+		return nil, fmt.Errorf("empty response when fetching %s", oldPath)
+	}
+
+	bindings, err := ParseBindings(content.Content)
+	if err != nil {
+		// Bindings of an unparsable previous version cannot be trusted, so every binding counts as new
+		return nil, nil
+	}
+	return bindings, nil
+}
+
+// productExists reports whether dataProduct has a product.yaml in environment on the target
+// branch or in the MR. Without MR context the check is skipped.
+func (r *Rule) productExists(dataProduct, environment string) bool {
+	mrCtx := r.GetMRContext()
+	if mrCtx == nil || mrCtx.MRInfo == nil || r.client == nil {
+		return true
+	}
+
+	for _, typeDir := range productTypeDirs {
+		for _, name := range []string{"product.yaml", "product.yml"} {
+			productPath := path.Join("dataproducts", typeDir, dataProduct, environment, name)
+			for _, change := range mrCtx.Changes {
+				if strings.EqualFold(change.NewPath, productPath) && !change.DeletedFile {
+					return true
+				}
+			}
+
+			exists, indexed := false, false
+			if idx := repoindex.ForClient(r.client); idx != nil {
+				exists, indexed = idx.PathExists(mrCtx.ProjectID, mrCtx.MRInfo.TargetBranch, productPath)
+			}
+			if !indexed {
+				_, err := r.client.FetchFileContent(mrCtx.Context(), mrCtx.ProjectID, productPath, mrCtx.MRInfo.TargetBranch)
+				exists = err == nil
+			}
+			if exists {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// productDatabase is a data_product_db entry of a product.yaml
+type productDatabase struct {
+	Database string `yaml:"database"`
+}
+
+// productDir returns the data product directory of a file in dataproducts/[<type>/]<name>/<env>/
+func productDir(filePath string) string {
+	return path.Base(path.Dir(path.Dir(filePath)))
+}
@@ -0,0 +1,206 @@
+package sourcebinding
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+const bindingPath = "dataproducts/source/orders/prod/sourcebinding.yaml"
+
+const ordersProduct = `name: orders
+data_product_db:
+- database: orders_db
+  presentation_schemas: []
+`
+
+const ownBinding = `source:
+- database: orders_db
+  schema: public
+`
+
+// mockFileFetcher serves file contents keyed by "ref:path"
+type mockFileFetcher struct {
+	files map[string]string
+}
+
+func (m *mockFileFetcher) FetchFileContent(ctx context.Context, projectID int, filePath, ref string) (*gitlab.FileContent, error) {
+	content, ok := m.files[ref+":"+filePath]
+	if !ok {
+		return nil, fmt.Errorf("file not found: %s: %w", filePath, gitlab.ErrNotFound)
+	}
+	return &gitlab.FileContent{FilePath: filePath, Content: content, Ref: ref}, nil
+}
+
+func newTestRule(files map[string]string, change gitlab.FileChange) *Rule {
+	rule := NewRule(&mockFileFetcher{files: files}, nil)
+	rule.SetMRContext(&shared.MRContext{
+		ProjectID: 1,
+		MRIID:     2,
+		Changes:   []gitlab.FileChange{change},
+		MRInfo:    &gitlab.MRInfo{SourceBranch: "feature", TargetBranch: "main"},
+	})
+	return rule
+}
+
+func TestParseBindings(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected []Binding
+	}{
+		{
+			name:    "source list",
+			content: "environment: prod\nsource:\n- database: orders_db\n  schema: public\n- database: snowpipe_db\n  schema: orders\n  tables: [events]\n  environment: dev\n",
+			expected: []Binding{
+				{Database: "orders_db", Schema: "public", Environment: "prod"},
+				{Database: "snowpipe_db", Schema: "orders", Tables: []string{"events"}, Environment: "dev"},
+			},
+		},
+		{
+			name:     "source database",
+			content:  "source: orders_db\nschema: public\ntable: customers\n",
+			expected: []Binding{{Database: "orders_db", Schema: "public", Table: "customers"}},
+		},
+		{
+			name:     "top-level binding",
+			content:  "kind: SourceBinding\ndata_product: orders\ndatabase: fivetran_db\nschema: orders\nprivileges: [SELECT]\n",
+			expected: []Binding{{DataProduct: "orders", Database: "fivetran_db", Schema: "orders", Privileges: []string{"SELECT"}}},
+		},
+		{
+			name:    "no sources",
+			content: "source_type: snowflake\nconnection_name: orders_prod\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bindings, err := ParseBindings(tt.content)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, bindings)
+		})
+	}
+
+	_, err := ParseBindings("source:\n- schema: [a, b]\n")
+	assert.Error(t, err)
+}
+
+func TestRule_GetCoveredLines(t *testing.T) {
+	rule := NewRule(nil, nil)
+	assert.NotEmpty(t, rule.GetCoveredLines(bindingPath, ownBinding))
+	assert.NotEmpty(t, rule.GetCoveredLines("dataproducts/orders/dev/sourcebinding.yaml", ownBinding))
+	assert.Empty(t, rule.GetCoveredLines("dataproducts/source/orders/prod/product.yaml", ownBinding))
+	assert.Empty(t, rule.GetCoveredLines(bindingPath, ""))
+}
+
+func TestRule_ValidateLines(t *testing.T) {
+	files := map[string]string{
+		"feature:dataproducts/source/orders/prod/product.yaml":    ordersProduct,
+		"main:dataproducts/source/customers/prod/product.yaml":    "name: customers\n",
+		"main:dataproducts/source/orders/prod/sourcebinding.yaml": ownBinding + "- database: snowpipe_db\n  schema: orders\n",
+	}
+	modified := gitlab.FileChange{OldPath: bindingPath, NewPath: bindingPath}
+
+	tests := []struct {
+		name     string
+		content  string
+		change   gitlab.FileChange
+		expected shared.DecisionType
+		reason   string
+	}{
+		{
+			name:     "own database",
+			content:  ownBinding,
+			change:   gitlab.FileChange{NewPath: bindingPath, NewFile: true},
+			expected: shared.Approve,
+			reason:   "Source bindings are valid and bind no new data products",
+		},
+		{
+			name:     "existing cross binding",
+			content:  ownBinding + "- database: snowpipe_db\n  schema: orders\n  tables: [events, orders_v2]\n",
+			change:   modified,
+			expected: shared.Approve,
+			reason:   "Source bindings are valid and bind no new data products",
+		},
+		{
+			name:     "new cross binding",
+			content:  ownBinding + "- data_product: customers\n  database: customers_db\n  schema: marts\n  privileges: [select]\n- database: billing_db\n  schema: invoices\n",
+			change:   modified,
+			expected: shared.ManualReview,
+			reason:   "New cross-data-product source bindings: customers:customers_db.marts, billing_db.invoices - manual review required",
+		},
+		{
+			name:     "binding to the own data product by name",
+			content:  "data_product: orders\ndatabase: fivetran_db\nschema: orders\n",
+			change:   gitlab.FileChange{NewPath: bindingPath, NewFile: true},
+			expected: shared.Approve,
+			reason:   "Source bindings are valid and bind no new data products",
+		},
+		{
+			name: "malformed bindings",
+			content: "source:\n- database: orders-db\n  schema: public\n- data_product: payments\n  table: 'order items'\n" +
+				"- database: orders_db\n  schema: public\n  privileges: [ownership]\n  environment: dev\n",
+			change:   modified,
+			expected: shared.ManualReview,
+			reason: `Source binding validation failed: source 1 (orders-db.public): database "orders-db" is not a valid identifier; ` +
+				`source 2 (payments:): schema is required; source 2 (payments:): table "order items" is not a valid identifier; ` +
+				`source 2 (payments:): data product "payments" has no product.yaml in the prod environment; ` +
+				`source 3 (orders_db.public): privilege "ownership" is not allowed (allowed: select, references, usage); ` +
+				`source 3 (orders_db.public): environment "dev" does not match the prod folder`,
+		},
+		{
+			name:     "no sources",
+			content:  "source_type: snowflake\nconnection_name: orders_prod\n",
+			change:   modified,
+			expected: shared.ManualReview,
+			reason:   "Source binding validation failed: no sources declared - expected a source list or a database",
+		},
+		{
+			name:     "unparsable",
+			content:  "source: [",
+			change:   modified,
+			expected: shared.ManualReview,
+			reason:   "Failed to parse source binding YAML",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := newTestRule(files, tt.change)
+			decision, reason := rule.ValidateLines(bindingPath, tt.content, nil)
+			assert.Equal(t, tt.expected, decision)
+			assert.Contains(t, reason, tt.reason)
+		})
+	}
+}
+
+func TestRule_ValidateLines_ReferencedProductInMR(t *testing.T) {
+	rule := NewRule(&mockFileFetcher{files: map[string]string{}}, nil)
+	rule.SetMRContext(&shared.MRContext{
+		ProjectID: 1,
+		Changes: []gitlab.FileChange{
+			{NewPath: bindingPath, NewFile: true},
+			{NewPath: "dataproducts/aggregate/customers/prod/product.yaml", NewFile: true},
+		},
+		MRInfo: &gitlab.MRInfo{SourceBranch: "feature", TargetBranch: "main"},
+	})
+
+	decision, reason := rule.ValidateLines(bindingPath, "data_product: customers\ndatabase: customers_db\nschema: marts\n", nil)
+	assert.Equal(t, shared.ManualReview, decision)
+	assert.Equal(t, "New cross-data-product source bindings: customers:customers_db.marts - manual review required", reason)
+}
+
+func TestRule_ValidateLines_NoContext(t *testing.T) {
+	rule := NewRule(nil, []string{"select"})
+	decision, reason := rule.ValidateLines(bindingPath, ownBinding, nil)
+	assert.Equal(t, shared.ManualReview, decision, "without the product.yaml every database belongs to another data product")
+	assert.Equal(t, "New cross-data-product source bindings: orders_db.public - manual review required", reason)
+
+	decision, _ = rule.ValidateLines(bindingPath, "data_product: orders\ndatabase: orders_db\nschema: public\nprivileges: [usage]\n", nil)
+	assert.Equal(t, shared.ManualReview, decision)
+
+	decision, _ = rule.ValidateLines("dataproducts/source/orders/prod/product.yaml", ownBinding, nil)
+	assert.Equal(t, shared.Approve, decision)
+}
@@ -0,0 +1,97 @@
+package sourcebinding
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultAllowedPrivileges are the privileges a binding may request when none are configured
+var DefaultAllowedPrivileges = []string{"select", "references", "usage"}
+
+// IdentifierRegex matches Snowflake identifiers of databases, schemas and tables
+var IdentifierRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_$]*$`)
+
+// Binding is one source a data product reads from: a schema of a database, optionally of
+// another data product and limited to some tables
+type Binding struct {
+	DataProduct string   `yaml:"data_product"`
+	Database    string   `yaml:"database"`
+	Schema      string   `yaml:"schema"`
+	Table       string   `yaml:"table"`
+	Tables      []string `yaml:"tables"`
+	Privileges  []string `yaml:"privileges"`
+	Environment string   `yaml:"environment"`
+}
+
+// TableNames returns the tables of the binding, from table and tables
+func (b Binding) TableNames() []string {
+	if b.Table == "" {
+		return b.Tables
+	}
+	return append([]string{b.Table}, b.Tables...)
+}
+
+// Key identifies a binding when comparing two versions of a file
+func (b Binding) Key() string {
+	return strings.ToLower(b.DataProduct + "|" + b.Database + "|" + b.Schema)
+}
+
+// String returns the binding as [<data_product>:]<database>.<schema>
+func (b Binding) String() string {
+	name := b.Database
+	if b.Schema != "" {
+		name += "." + b.Schema
+	}
+	if b.DataProduct != "" {
+		return b.DataProduct + ":" + name
+	}
+	return name
+}
+
+// ParseBindings returns the bindings of a sourcebinding.yaml. Sources are either a source
+// list of bindings, a source database with the binding fields at the top level, or the
+// binding fields at the top level alone. Bindings without an environment take the one of
+// the file.
+func ParseBindings(content string) ([]Binding, error) {
+	var file struct {
+		Binding `yaml:",inline"`
+		Source  yaml.Node `yaml:"source"`
+	}
+	if err := yaml.Unmarshal([]byte(content), &file); err != nil {
+		return nil, fmt.Errorf("YAML parsing error: %w", err)
+	}
+
+	var bindings []Binding
+	switch file.Source.Kind {
+	case yaml.SequenceNode:
+		if err := file.Source.Decode(&bindings); err != nil {
+			return nil, fmt.Errorf("source: %w", err)
+		}
+	case yaml.MappingNode:
+		var binding Binding
+		if err := file.Source.Decode(&binding); err != nil {
+			return nil, fmt.Errorf("source: %w", err)
+		}
+		bindings = append(bindings, binding)
+	case yaml.ScalarNode:
+		binding := file.Binding
+		if binding.Database == "" {
+			binding.Database = file.Source.Value
+		}
+		bindings = append(bindings, binding)
+	default:
+		if file.DataProduct != "" || file.Database != "" {
+			bindings = append(bindings, file.Binding)
+		}
+	}
+
+	for i := range bindings {
+		if bindings[i].Environment == "" {
+			bindings[i].Environment = file.Environment
+		}
+	}
+	return bindings, nil
+}
@@ -0,0 +1,195 @@
+package tag
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/repoindex"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/common"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/masking"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+)
+
+// RuleName is the identifier of the tag rule
+const RuleName = "tag_rule"
+
+// Client is the subset of the GitLab client needed to look up masking policies
+type Client interface {
+	FetchFileContent(ctx context.Context, projectID int, filePath, ref string) (*gitlab.FileContent, error)
+	GetMRDetails(ctx context.Context, projectID, mrIID int) (*gitlab.MRDetails, error)
+}
+
+// Rule validates Tag kind YAMLs (tag_*masking.yaml): naming convention, allowed values,
+// data product consistency with the path, and that every referenced masking policy is
+// defined by a masking file in the same directory, on the target branch or in the MR
+type Rule struct {
+	*common.BaseRule
+	client Client
+}
+
+// NewRule creates a new tag rule instance
+func NewRule(client Client) *Rule {
+	return &Rule{
+		BaseRule: common.NewBaseRule(RuleName, "Validates Tag files (tag_*masking.yaml) - auto-approves valid tags, requires manual review for invalid tags or missing masking policies"),
+		client:   client,
+	}
+}
+
+// IsTagFile checks if a file is a tag file: a *masking.yaml/yml file with "tag" in its name
+func IsTagFile(filePath string) bool {
+	lowerPath := strings.ToLower(filePath)
+	if !strings.HasSuffix(lowerPath, "masking.yaml") && !strings.HasSuffix(lowerPath, "masking.yml") {
+		return false
+	}
+	return strings.Contains(path.Base(lowerPath), "tag")
+}
+
+// GetCoveredLines returns line ranges this rule covers
+func (r *Rule) GetCoveredLines(filePath string, fileContent string) []shared.LineRange {
+	if !IsTagFile(filePath) {
+		return []shared.LineRange{}
+	}
+	return r.GetFullFileCoverage(filePath, fileContent)
+}
+
+// ValidateDeletion requires manual review of deleted tags, as the masking policies
+// referencing them lose their tag
+func (r *Rule) ValidateDeletion(filePath string) (shared.DecisionType, string) {
+	if !IsTagFile(filePath) {
+		return shared.Approve, "Not a tag file - tag rule does not apply"
+	}
+	return shared.ManualReview, "Tag deletion requires manual review - masking policies lose their tag"
+}
+
+// ValidateLines validates the tag and the masking policies it references
+func (r *Rule) ValidateLines(filePath string, fileContent string, lineRanges []shared.LineRange) (shared.DecisionType, string) {
+	if !IsTagFile(filePath) {
+		return shared.Approve, "Not a tag file - tag rule does not apply"
+	}
+
+	tag, err := ParseTag(fileContent)
+	if err != nil {
+		return shared.ManualReview, fmt.Sprintf("Failed to parse tag YAML: %v", err)
+	}
+
+	dataProduct, _ := masking.ExtractPathInfo(filePath)
+	if result := Validate(tag, dataProduct); !result.IsValid {
+		return shared.ManualReview, fmt.Sprintf("Tag validation failed: %s", strings.Join(result.GetErrorMessages(), "; "))
+	}
+
+	mrCtx := r.GetMRContext()
+	if r.client == nil || mrCtx == nil {
+		return shared.Approve, "Tag validation passed - auto-approved"
+	}
+
+	defined, err := r.definedPolicies(mrCtx, path.Dir(filePath))
+	if err != nil {
+		return shared.ManualReview, fmt.Sprintf("Could not verify the masking policies of tag '%s': %v", tag.Name, err)
+	}
+	var missing []string
+	for _, ref := range tag.MaskingPolicies {
+		if !defined[ref.Name] {
+			missing = append(missing, ref.Name)
+		}
+	}
+	if len(missing) > 0 {
+		return shared.ManualReview, fmt.Sprintf("Tag '%s' references masking policies not defined in %s/: %s",
+			tag.Name, path.Dir(filePath), strings.Join(missing, ", "))
+	}
+
+	return shared.Approve, "Tag validation passed - auto-approved"
+}
+
+// definedPolicies returns the names of the masking policies defined in dir after the MR:
+// masking files changed by the MR are read from the source branch, the other masking
+// files of dir from the target branch
+func (r *Rule) definedPolicies(mrCtx *shared.MRContext, dir string) (map[string]bool, error) {
+	defined := make(map[string]bool)
+	changed := make(map[string]bool)
+
+	var sourceProjectID int
+	for _, change := range mrCtx.Changes {
+		if change.OldPath != "" {
+			changed[change.OldPath] = true
+		}
+		if change.DeletedFile || path.Dir(change.NewPath) != dir || !masking.IsMaskingFile(change.NewPath) {
+			continue
+		}
+		changed[change.NewPath] = true
+		if sourceProjectID == 0 {
+			sourceProjectID = r.sourceProjectID(mrCtx)
+		}
+		r.addPolicy(mrCtx.Context(), defined, sourceProjectID, change.NewPath, sourceBranch(mrCtx))
+	}
+
+	paths, err := r.targetPaths(mrCtx.Context(), mrCtx.ProjectID, targetBranch(mrCtx), func(p string) bool {
+		return path.Dir(p) == dir && masking.IsMaskingFile(p)
+	})
+	if err != nil {
+		// Without a tree listing only the policies changed in the MR can be found
+		if len(defined) > 0 {
+			return defined, nil
+		}
+		return nil, err
+	}
+	for _, p := range paths {
+		if !changed[p] {
+			r.addPolicy(mrCtx.Context(), defined, mrCtx.ProjectID, p, targetBranch(mrCtx))
+		}
+	}
+	return defined, nil
+}
+
+// addPolicy records the policy defined in a masking file; unreadable files define nothing
+func (r *Rule) addPolicy(ctx context.Context, defined map[string]bool, projectID int, filePath, ref string) {
+	content, err := r.client.FetchFileContent(ctx, projectID, filePath, ref)
+	if err != nil || content == nil {
+		return
+	}
+	policy, err := masking.ParseMaskingPolicy(content.Content)
+	if err != nil || !strings.EqualFold(policy.Kind, masking.MaskingPolicyKind) {
+		return
+	}
+	defined[policy.Name] = true
+}
+
+// targetPaths lists target-branch paths from the shared repository index, or a one-off
+// tree listing when the index is disabled and the client can list trees
+func (r *Rule) targetPaths(ctx context.Context, projectID int, ref string, match func(path string) bool) ([]string, error) {
+	index := repoindex.ForClient(r.client)
+	if index == nil {
+		lister, ok := r.client.(repoindex.TreeLister)
+		if !ok {
+			return nil, fmt.Errorf("repository tree listing not available")
+		}
+		index = repoindex.NewIndex(lister, store.NewMemoryStore())
+	}
+	return index.Paths(ctx, projectID, ref, match)
+}
+
+// sourceProjectID returns the project holding the MR source branch (the fork for fork MRs)
+func (r *Rule) sourceProjectID(mrCtx *shared.MRContext) int {
+	details, err := r.client.GetMRDetails(mrCtx.Context(), mrCtx.ProjectID, mrCtx.MRIID)
+	if err == nil && details != nil && details.SourceProjectID != 0 {
+		return details.SourceProjectID
+	}
+	return mrCtx.ProjectID
+}
+
+func sourceBranch(mrCtx *shared.MRContext) string {
+	if mrCtx.MRInfo != nil {
+		return mrCtx.MRInfo.SourceBranch
+	}
+	return ""
+}
+
+func targetBranch(mrCtx *shared.MRContext) string {
+	if mrCtx.MRInfo != nil && mrCtx.MRInfo.TargetBranch != "" {
+		return mrCtx.MRInfo.TargetBranch
+	}
+	return masking.DefaultTargetBranch
+}
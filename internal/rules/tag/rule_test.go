@@ -0,0 +1,159 @@
+package tag
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+const tagPath = "dataproducts/source/analytics/prod/tag_pii_masking.yaml"
+
+func policyFile(name string) string {
+	return fmt.Sprintf("kind: MaskingPolicy\nname: %s\ndata_product: analytics\n", name)
+}
+
+// mockClient serves files per ref and optionally lists the target branch tree
+type mockClient struct {
+	files map[string]map[string]string // ref -> path -> content
+}
+
+func (m *mockClient) FetchFileContent(ctx context.Context, projectID int, filePath, ref string) (*gitlab.FileContent, error) {
+	content, ok := m.files[ref][filePath]
+	if !ok {
+		return nil, fmt.Errorf("file not found: %s: %w", filePath, gitlab.ErrNotFound)
+	}
+	return &gitlab.FileContent{FilePath: filePath, Content: content}, nil
+}
+
+func (m *mockClient) GetMRDetails(ctx context.Context, projectID, mrIID int) (*gitlab.MRDetails, error) {
+	return &gitlab.MRDetails{IID: mrIID, SourceProjectID: projectID}, nil
+}
+
+// listingClient can also list the target branch tree
+type listingClient struct {
+	*mockClient
+}
+
+func (m *listingClient) ListRepositoryTree(ctx context.Context, projectID int, ref string) ([]gitlab.TreeEntry, error) {
+	var entries []gitlab.TreeEntry
+	for path := range m.files[ref] {
+		entries = append(entries, gitlab.TreeEntry{Type: "blob", Path: path})
+	}
+	return entries, nil
+}
+
+func newMRContext(changes ...gitlab.FileChange) *shared.MRContext {
+	return &shared.MRContext{
+		ProjectID: 1,
+		MRIID:     2,
+		Changes:   changes,
+		MRInfo:    &gitlab.MRInfo{SourceBranch: "feature", TargetBranch: "main"},
+	}
+}
+
+func TestIsTagFile(t *testing.T) {
+	assert.True(t, IsTagFile(tagPath))
+	assert.True(t, IsTagFile("dataproducts/source/analytics/prod/pii_tag_masking.yml"))
+	assert.False(t, IsTagFile("dataproducts/source/analytics/prod/pii_masking.yaml"))
+	assert.False(t, IsTagFile("dataproducts/source/tagging/prod/pii_masking.yaml"), "only the file name is checked")
+	assert.False(t, IsTagFile("dataproducts/source/analytics/prod/tags.yaml"))
+}
+
+func TestRule_Coverage(t *testing.T) {
+	rule := NewRule(nil)
+
+	assert.Equal(t, rule.GetFullFileCoverage(tagPath, validTag), rule.GetCoveredLines(tagPath, validTag))
+	assert.Empty(t, rule.GetCoveredLines("dataproducts/source/analytics/prod/pii_masking.yaml", "kind: MaskingPolicy\n"))
+}
+
+func TestRule_ValidateDeletion(t *testing.T) {
+	rule := NewRule(nil)
+
+	decision, reason := rule.ValidateDeletion(tagPath)
+	assert.Equal(t, shared.ManualReview, decision)
+	assert.Contains(t, reason, "Tag deletion requires manual review")
+
+	decision, _ = rule.ValidateDeletion("dataproducts/source/analytics/prod/pii_masking.yaml")
+	assert.Equal(t, shared.Approve, decision, "masking policies are left to the masking rule")
+}
+
+func TestRule_ValidateWithoutContext(t *testing.T) {
+	rule := NewRule(nil)
+
+	decision, reason := rule.ValidateLines(tagPath, validTag, nil)
+	assert.Equal(t, shared.Approve, decision, reason)
+
+	decision, reason = rule.ValidateLines("dataproducts/source/sales/prod/tag_pii_masking.yaml", validTag, nil)
+	assert.Equal(t, shared.ManualReview, decision)
+	assert.Contains(t, reason, "data_product: mismatch with file path")
+}
+
+func TestRule_PoliciesOnTargetBranchAndInMR(t *testing.T) {
+	client := &listingClient{&mockClient{files: map[string]map[string]string{
+		"main": {
+			"dataproducts/source/analytics/prod/pii_string_masking.yaml": policyFile("analytics_pii_string_policy"),
+			"dataproducts/source/analytics/dev/pii_number_masking.yaml":  policyFile("analytics_pii_number_policy"),
+			tagPath: validTag,
+		},
+		"feature": {
+			"dataproducts/source/analytics/prod/pii_number_masking.yaml": policyFile("analytics_pii_number_policy"),
+		},
+	}}}
+	rule := NewRule(client)
+
+	// The number policy only exists in another environment
+	rule.SetMRContext(newMRContext(gitlab.FileChange{NewPath: tagPath, OldPath: tagPath}))
+	decision, reason := rule.ValidateLines(tagPath, validTag, nil)
+	assert.Equal(t, shared.ManualReview, decision)
+	assert.Equal(t, "Tag 'analytics_pii' references masking policies not defined in dataproducts/source/analytics/prod/: analytics_pii_number_policy", reason)
+
+	// The MR adds it next to the tag
+	rule.SetMRContext(newMRContext(
+		gitlab.FileChange{NewPath: tagPath, OldPath: tagPath},
+		gitlab.FileChange{NewPath: "dataproducts/source/analytics/prod/pii_number_masking.yaml", NewFile: true},
+	))
+	decision, reason = rule.ValidateLines(tagPath, validTag, nil)
+	assert.Equal(t, shared.Approve, decision, reason)
+}
+
+func TestRule_DeletedPolicy(t *testing.T) {
+	stringPolicy := "dataproducts/source/analytics/prod/pii_string_masking.yaml"
+	client := &listingClient{&mockClient{files: map[string]map[string]string{
+		"main": {
+			stringPolicy: policyFile("analytics_pii_string_policy"),
+			"dataproducts/source/analytics/prod/pii_number_masking.yaml": policyFile("analytics_pii_number_policy"),
+		},
+	}}}
+	rule := NewRule(client)
+
+	rule.SetMRContext(newMRContext(gitlab.FileChange{OldPath: stringPolicy, NewPath: stringPolicy, DeletedFile: true}))
+	decision, reason := rule.ValidateLines(tagPath, validTag, nil)
+	assert.Equal(t, shared.ManualReview, decision)
+	assert.Contains(t, reason, "analytics_pii_string_policy")
+}
+
+func TestRule_WithoutTreeListing(t *testing.T) {
+	client := &mockClient{files: map[string]map[string]string{
+		"feature": {
+			"dataproducts/source/analytics/prod/pii_string_masking.yaml": policyFile("analytics_pii_string_policy"),
+			"dataproducts/source/analytics/prod/pii_number_masking.yaml": policyFile("analytics_pii_number_policy"),
+		},
+	}}
+	rule := NewRule(client)
+
+	rule.SetMRContext(newMRContext())
+	decision, reason := rule.ValidateLines(tagPath, validTag, nil)
+	assert.Equal(t, shared.ManualReview, decision)
+	assert.Contains(t, reason, "Could not verify the masking policies of tag 'analytics_pii': repository tree listing not available")
+
+	rule.SetMRContext(newMRContext(
+		gitlab.FileChange{NewPath: "dataproducts/source/analytics/prod/pii_string_masking.yaml", OldPath: "dataproducts/source/analytics/prod/pii_string_masking.yaml"},
+		gitlab.FileChange{NewPath: "dataproducts/source/analytics/prod/pii_number_masking.yaml", OldPath: "dataproducts/source/analytics/prod/pii_number_masking.yaml"},
+	))
+	decision, reason = rule.ValidateLines(tagPath, validTag, nil)
+	assert.Equal(t, shared.Approve, decision, reason)
+}
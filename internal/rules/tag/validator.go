@@ -0,0 +1,113 @@
+package tag
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/masking"
+	"gopkg.in/yaml.v3"
+)
+
+// TagKind is the kind of tag files
+const TagKind = "Tag"
+
+// Regex patterns for validation
+var (
+	// Tag name format: <dataproduct>_<classification>, e.g. analytics_pii
+	TagNameRegex = regexp.MustCompile(`^[a-z0-9]{3,30}_(pii|restricted|restrictedpii)$`)
+
+	// Allowed value format: lowercase identifier, e.g. default, custom_value
+	AllowedValueRegex = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+)
+
+// Tag represents the structure of a tag YAML
+type Tag struct {
+	Kind            string      `yaml:"kind"`
+	Name            string      `yaml:"name"`
+	Description     string      `yaml:"description"`
+	DataProduct     string      `yaml:"data_product"`
+	MaskingPolicies []PolicyRef `yaml:"masking_policies"`
+	AllowedValues   []string    `yaml:"allowed_values"`
+}
+
+// PolicyRef references a masking policy of the same data product
+type PolicyRef struct {
+	Name string `yaml:"name"`
+}
+
+// ParseTag parses YAML content into a Tag struct
+func ParseTag(content string) (*Tag, error) {
+	var tag Tag
+	if err := yaml.Unmarshal([]byte(content), &tag); err != nil {
+		return nil, fmt.Errorf("YAML parsing error: %w", err)
+	}
+	return &tag, nil
+}
+
+// Validate checks the fields of a tag. dataProductFromPath is the data product directory
+// of the tag file, empty when the path does not follow dataproducts/<type>/<product>/<env>/.
+func Validate(tag *Tag, dataProductFromPath string) *masking.ValidationResult {
+	result := masking.NewValidationResult()
+
+	if !strings.EqualFold(tag.Kind, TagKind) {
+		result.AddError("kind", fmt.Sprintf("must be '%s', found '%s'", TagKind, tag.Kind))
+		return result
+	}
+	if tag.Name == "" {
+		result.AddError("name", "required field is missing")
+	}
+	if tag.DataProduct == "" {
+		result.AddError("data_product", "required field is missing")
+	}
+	if len(tag.MaskingPolicies) == 0 {
+		result.AddError("masking_policies", "at least one masking policy must be referenced")
+	}
+	if !result.IsValid {
+		return result
+	}
+
+	// Naming convention: <data_product>_<classification>
+	if !TagNameRegex.MatchString(tag.Name) {
+		result.AddError("name", fmt.Sprintf("'%s' must follow <dataproduct>_<pii|restricted|restrictedpii>", tag.Name))
+	} else if !strings.HasPrefix(tag.Name, tag.DataProduct+"_") {
+		result.AddError("name", fmt.Sprintf("'%s' must start with the data product '%s_'", tag.Name, tag.DataProduct))
+	}
+
+	// Data product must match the file path
+	if dataProductFromPath != "" && !strings.EqualFold(tag.DataProduct, dataProductFromPath) {
+		result.AddError("data_product", fmt.Sprintf("mismatch with file path: expected '%s', found '%s'", dataProductFromPath, tag.DataProduct))
+	}
+
+	// Referenced policies belong to the tag: <tag name>_<datatype>_policy
+	seen := make(map[string]bool)
+	for i, ref := range tag.MaskingPolicies {
+		field := fmt.Sprintf("masking_policies[%d]", i)
+		switch {
+		case ref.Name == "":
+			result.AddError(field, "name is required")
+		case seen[ref.Name]:
+			result.AddError(field, fmt.Sprintf("duplicate masking policy '%s'", ref.Name))
+		case !masking.MaskingPolicyNameRegex.MatchString(ref.Name):
+			result.AddError(field, fmt.Sprintf("'%s' is not a valid masking policy name (<dataproduct>_<classification>_<datatype>_policy)", ref.Name))
+		case !strings.HasPrefix(ref.Name, tag.Name+"_"):
+			result.AddError(field, fmt.Sprintf("'%s' does not belong to tag '%s' (expected %s_<datatype>_policy)", ref.Name, tag.Name, tag.Name))
+		}
+		seen[ref.Name] = true
+	}
+
+	// Allowed values are unique lowercase identifiers
+	seenValues := make(map[string]bool)
+	for i, value := range tag.AllowedValues {
+		field := fmt.Sprintf("allowed_values[%d]", i)
+		switch {
+		case !AllowedValueRegex.MatchString(value):
+			result.AddError(field, fmt.Sprintf("'%s' must be a lowercase identifier (a-z, 0-9, _; at most 50 characters)", value))
+		case seenValues[value]:
+			result.AddError(field, fmt.Sprintf("duplicate allowed value '%s'", value))
+		}
+		seenValues[value] = true
+	}
+
+	return result
+}
@@ -0,0 +1,75 @@
+package tag
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const validTag = `kind: Tag
+name: analytics_pii
+description: String and number tag for pii data masking
+data_product: analytics
+masking_policies:
+- name: analytics_pii_string_policy
+- name: analytics_pii_number_policy
+allowed_values:
+- default
+- custom
+`
+
+func TestParseTag(t *testing.T) {
+	tag, err := ParseTag(validTag)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "analytics_pii", tag.Name)
+	assert.Equal(t, []PolicyRef{{Name: "analytics_pii_string_policy"}, {Name: "analytics_pii_number_policy"}}, tag.MaskingPolicies)
+	assert.Equal(t, []string{"default", "custom"}, tag.AllowedValues)
+
+	_, err = ParseTag("kind: [unclosed")
+	assert.Error(t, err)
+}
+
+func TestValidate_Valid(t *testing.T) {
+	tag, _ := ParseTag(validTag)
+
+	assert.True(t, Validate(tag, "analytics").IsValid)
+	assert.True(t, Validate(tag, "").IsValid, "paths without a data product directory are not checked")
+}
+
+func TestValidate_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		replace [2]string
+		path    string
+		want    string
+	}{
+		{"wrong kind", [2]string{"kind: Tag", "kind: MaskingPolicy"}, "analytics", "kind: must be 'Tag'"},
+		{"missing name", [2]string{"name: analytics_pii\n", ""}, "analytics", "name: required field is missing"},
+		{"no policies", [2]string{"masking_policies:\n- name: analytics_pii_string_policy\n- name: analytics_pii_number_policy\n", ""}, "analytics", "at least one masking policy"},
+		{"bad classification", [2]string{"name: analytics_pii\n", "name: analytics_secret\n"}, "analytics", "must follow <dataproduct>_<pii|restricted|restrictedpii>"},
+		{"other data product", [2]string{"name: analytics_pii\n", "name: sales_pii\n"}, "analytics", "must start with the data product 'analytics_'"},
+		{"path mismatch", [2]string{"", ""}, "sales", "data_product: mismatch with file path: expected 'sales', found 'analytics'"},
+		{"policy of other tag", [2]string{"analytics_pii_number_policy", "analytics_restricted_number_policy"}, "analytics", "does not belong to tag 'analytics_pii'"},
+		{"invalid policy name", [2]string{"analytics_pii_number_policy", "analytics_pii_numbers"}, "analytics", "is not a valid masking policy name"},
+		{"duplicate policy", [2]string{"analytics_pii_number_policy", "analytics_pii_string_policy"}, "analytics", "duplicate masking policy 'analytics_pii_string_policy'"},
+		{"invalid allowed value", [2]string{"- custom", "- Custom Value"}, "analytics", "allowed_values[1]: 'Custom Value' must be a lowercase identifier"},
+		{"duplicate allowed value", [2]string{"- custom", "- default"}, "analytics", "duplicate allowed value 'default'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := validTag
+			if tt.replace[0] != "" {
+				content = strings.Replace(content, tt.replace[0], tt.replace[1], 1)
+			}
+			tag, err := ParseTag(content)
+			assert.NoError(t, err)
+
+			result := Validate(tag, tt.path)
+			assert.False(t, result.IsValid)
+			assert.Contains(t, strings.Join(result.GetErrorMessages(), "; "), tt.want)
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+)
+
+// TextSectionParser parses text files such as Terraform tfvars into sections selected by
+// regular expressions. A section starts at the first line matching its pattern and ends at
+// the next line matching its end pattern, or at the last line matching its pattern when it
+// has none. Named groups of the first match become the section fields.
+type TextSectionParser struct {
+	sectionParserBase
+}
+
+// NewTextSectionParser creates a new text section parser
+func NewTextSectionParser(definitions map[string]config.SectionDefinition) *TextSectionParser {
+	return &TextSectionParser{
+		sectionParserBase: sectionParserBase{sectionDefinitions: definitions},
+	}
+}
+
+// ParseSections extracts sections from text content based on definitions
+func (p *TextSectionParser) ParseSections(filePath string, content string) ([]shared.Section, error) {
+	contentLines := strings.Split(content, "\n")
+
+	var sections []shared.Section
+	for _, definition := range p.sectionDefinitions {
+		section, err := p.extractSection(definition, filePath, contentLines)
+		if err != nil {
+			if definition.Required {
+				return nil, fmt.Errorf("required section %s not found: %w", definition.Name, err)
+			}
+			continue
+		}
+		sections = append(sections, *section)
+	}
+	return sections, nil
+}
+
+// extractSection extracts the lines of a section; without a pattern it covers the whole file
+func (p *TextSectionParser) extractSection(definition config.SectionDefinition, filePath string, contentLines []string) (*shared.Section, error) {
+	if definition.Pattern == "" {
+		if normalizeKeyPath(definition.YAMLPath) != "" {
+			return nil, fmt.Errorf("text sections are selected by pattern, not path %s", definition.YAMLPath)
+		}
+		section := p.newSection(definition, filePath, contentLines, lineSpan{start: 1, end: len(contentLines)}, shared.TextSection, map[string]interface{}{})
+		return &section, nil
+	}
+
+	pattern, err := regexp.Compile(definition.Pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern: %w", err)
+	}
+
+	var span lineSpan
+	fields := make(map[string]interface{})
+	for i, line := range contentLines {
+		match := pattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		if span.start == 0 {
+			span.start = i + 1
+			for j, name := range pattern.SubexpNames() {
+				if name != "" {
+					fields[name] = match[j]
+				}
+			}
+		}
+		span.end = i + 1
+	}
+	if span.start == 0 {
+		return nil, fmt.Errorf("no line matches pattern: %s", definition.Pattern)
+	}
+
+	if definition.EndPattern != "" {
+		endPattern, err := regexp.Compile(definition.EndPattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid end pattern: %w", err)
+		}
+		span.end = len(contentLines)
+		for i := span.start; i < len(contentLines); i++ {
+			if endPattern.MatchString(contentLines[i]) {
+				span.end = i + 1
+				break
+			}
+		}
+	}
+
+	section := p.newSection(definition, filePath, contentLines, span, shared.TextSection, fields)
+	return &section, nil
+}
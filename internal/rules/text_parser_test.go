@@ -0,0 +1,74 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+)
+
+const warehouseTfvars = `project = "orders"
+
+warehouse_size = "SMALL"
+warehouse_auto_suspend = 60
+
+tags = {
+  owner = "orders-team"
+  tier  = "gold"
+}
+`
+
+func TestTextSectionParser_ParseSections(t *testing.T) {
+	parser := NewTextSectionParser(map[string]config.SectionDefinition{
+		"warehouse": {Name: "warehouse", Pattern: `^warehouse_(?P<setting>\w+)\s*=\s*"?(?P<value>[^"]*)"?$`, Required: true},
+		"tags":      {Name: "tags", Pattern: `^tags\s*=\s*\{`, EndPattern: `^\}`},
+		"full":      {Name: "full", YAMLPath: "."},
+		"missing":   {Name: "missing", Pattern: `^region\s*=`},
+	})
+
+	sections, err := parser.ParseSections("terraform/orders.tfvars", warehouseTfvars)
+	assert.NoError(t, err)
+
+	byName := make(map[string]shared.Section)
+	for _, section := range sections {
+		byName[section.Name] = section
+	}
+	assert.Len(t, byName, 3)
+
+	warehouse := byName["warehouse"]
+	assert.Equal(t, 3, warehouse.StartLine)
+	assert.Equal(t, 4, warehouse.EndLine)
+	assert.Equal(t, shared.TextSection, warehouse.Type)
+	assert.Equal(t, map[string]interface{}{"setting": "size", "value": "SMALL"}, warehouse.Fields)
+	assert.Equal(t, "warehouse_size = \"SMALL\"\nwarehouse_auto_suspend = 60", warehouse.Content)
+
+	assert.Equal(t, 6, byName["tags"].StartLine)
+	assert.Equal(t, 9, byName["tags"].EndLine)
+
+	assert.Equal(t, 1, byName["full"].StartLine)
+	assert.Equal(t, 10, byName["full"].EndLine)
+}
+
+func TestTextSectionParser_ParseSections_Errors(t *testing.T) {
+	parser := NewTextSectionParser(map[string]config.SectionDefinition{
+		"region": {Name: "region", Pattern: `^region\s*=`, Required: true},
+	})
+	_, err := parser.ParseSections("orders.tfvars", warehouseTfvars)
+	assert.EqualError(t, err, `required section region not found: no line matches pattern: ^region\s*=`)
+
+	parser = NewTextSectionParser(map[string]config.SectionDefinition{
+		"tags": {Name: "tags", YAMLPath: "tags", Required: true},
+	})
+	_, err = parser.ParseSections("orders.tfvars", warehouseTfvars)
+	assert.ErrorContains(t, err, "text sections are selected by pattern")
+
+	// An unterminated block runs to the end of the file
+	parser = NewTextSectionParser(map[string]config.SectionDefinition{
+		"tags": {Name: "tags", Pattern: `^tags`, EndPattern: `^\)`},
+	})
+	sections, err := parser.ParseSections("orders.tfvars", warehouseTfvars)
+	assert.NoError(t, err)
+	assert.Equal(t, 10, sections[0].EndLine)
+}
@@ -77,7 +77,7 @@ func (r *TOCApprovalRule) analyzeFile(filePath string) *TOCApprovalContext {
 	context := &TOCApprovalContext{
 		FilePath:         filePath,
 		IsNewFile:        r.isNewFile(filePath),
-		Environment:      r.extractEnvironmentFromPath(filePath),
+		Environment:      r.environmentOf(filePath),
 		RequiresApproval: false,
 	}
 
@@ -127,6 +127,19 @@ func (r *TOCApprovalRule) isEnvironmentCritical(environment string) bool {
 	return false
 }
 
+// environmentOf returns the required environment the file targets, preferring the environment
+// resolved in the MR context over matching the path
+func (r *TOCApprovalRule) environmentOf(filePath string) string {
+	if env := r.Environment(filePath); env != "" {
+		for _, required := range r.config.RequiredEnvironments {
+			if strings.EqualFold(env, required) {
+				return required
+			}
+		}
+	}
+	return r.extractEnvironmentFromPath(filePath)
+}
+
 // extractEnvironmentFromPath attempts to extract the environment name from the file path
 func (r *TOCApprovalRule) extractEnvironmentFromPath(filePath string) string {
 	lowerPath := strings.ToLower(filePath)
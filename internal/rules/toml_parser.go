@@ -0,0 +1,477 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+)
+
+// TOMLSectionParser parses TOML files into sections selected by dotted key paths. A table
+// section spans from its header to its last key.
+type TOMLSectionParser struct {
+	sectionParserBase
+}
+
+// NewTOMLSectionParser creates a new TOML section parser
+func NewTOMLSectionParser(definitions map[string]config.SectionDefinition) *TOMLSectionParser {
+	return &TOMLSectionParser{
+		sectionParserBase: sectionParserBase{sectionDefinitions: definitions},
+	}
+}
+
+// ParseSections extracts sections from TOML content based on definitions
+func (p *TOMLSectionParser) ParseSections(filePath string, content string) ([]shared.Section, error) {
+	doc, err := parseTOMLDocument(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse TOML: %w", err)
+	}
+	return p.keyedSections(filePath, content, doc, shared.TOMLSection)
+}
+
+// tomlDateTime matches the start of TOML dates, times and date-times, which are kept as strings
+var tomlDateTime = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2}|\d{2}:\d{2}:\d{2})`)
+
+// parseTOMLDocument decodes TOML content and records the lines of each key and table.
+// Dates and times decode to their string form.
+func parseTOMLDocument(content string) (*keyedDocument, error) {
+	root := make(map[string]interface{})
+	d := &tomlDecoder{
+		data:  content,
+		doc:   &keyedDocument{value: root, spans: make(map[string]lineSpan)},
+		table: root,
+	}
+	for i, c := range content {
+		if c == '\n' {
+			d.lineStarts = append(d.lineStarts, i+1)
+		}
+	}
+
+	for {
+		d.skipBlank(true)
+		if d.eof() {
+			return d.doc, nil
+		}
+		var err error
+		if d.peek() == '[' {
+			err = d.header(root)
+		} else {
+			err = d.keyValue(d.table, d.tablePath, true)
+		}
+		if err != nil {
+			return nil, err
+		}
+		d.skipBlank(false)
+		if !d.eof() && d.peek() != '\n' {
+			return nil, d.errorf("expected a new line, found %q", d.peek())
+		}
+	}
+}
+
+// tomlDecoder decodes TOML content into nested maps
+type tomlDecoder struct {
+	data       string
+	pos        int
+	lineStarts []int // Offsets of the lines after the first one
+	doc        *keyedDocument
+
+	table     map[string]interface{} // Table of the last header
+	tablePath string
+}
+
+func (d *tomlDecoder) eof() bool  { return d.pos >= len(d.data) }
+func (d *tomlDecoder) peek() byte { return d.data[d.pos] }
+
+// line returns the 1-based line of an offset
+func (d *tomlDecoder) line(pos int) int {
+	return sort.Search(len(d.lineStarts), func(i int) bool { return d.lineStarts[i] > pos }) + 1
+}
+
+func (d *tomlDecoder) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("line %d: %s", d.line(d.pos), fmt.Sprintf(format, args...))
+}
+
+// skipBlank skips spaces and comments, and new lines too when newlines is set
+func (d *tomlDecoder) skipBlank(newlines bool) {
+	for !d.eof() {
+		switch d.peek() {
+		case ' ', '\t', '\r':
+			d.pos++
+		case '\n':
+			if !newlines {
+				return
+			}
+			d.pos++
+		case '#':
+			for !d.eof() && d.peek() != '\n' {
+				d.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+// header consumes a [table] or [[array of tables]] header and makes its table current
+func (d *tomlDecoder) header(root map[string]interface{}) error {
+	start := d.pos
+	arrayOfTables := strings.HasPrefix(d.data[d.pos:], "[[")
+	d.pos++
+	if arrayOfTables {
+		d.pos++
+	}
+	keys, err := d.key()
+	if err != nil {
+		return err
+	}
+	closing := "]"
+	if arrayOfTables {
+		closing = "]]"
+	}
+	if !strings.HasPrefix(d.data[d.pos:], closing) {
+		return d.errorf("unterminated table header")
+	}
+	d.pos += len(closing)
+
+	parent, err := d.subtable(root, keys[:len(keys)-1])
+	if err != nil {
+		return err
+	}
+	last := keys[len(keys)-1]
+	if arrayOfTables {
+		tables, ok := parent[last].([]interface{})
+		if _, exists := parent[last]; exists && !ok {
+			return d.errorf("key %s is not an array of tables", strings.Join(keys, "."))
+		}
+		d.table = make(map[string]interface{})
+		parent[last] = append(tables, d.table)
+	} else {
+		if d.table, err = d.subtable(parent, keys[len(keys)-1:]); err != nil {
+			return err
+		}
+	}
+	d.tablePath = strings.Join(keys, ".")
+	d.doc.extend(d.tablePath, d.line(start), d.line(start))
+	return nil
+}
+
+// subtable returns the table at keys below table, creating missing ones. Arrays of tables
+// resolve to their last table.
+func (d *tomlDecoder) subtable(table map[string]interface{}, keys []string) (map[string]interface{}, error) {
+	for _, key := range keys {
+		switch next := table[key].(type) {
+		case nil:
+			created := make(map[string]interface{})
+			table[key] = created
+			table = created
+		case map[string]interface{}:
+			table = next
+		case []interface{}:
+			last, ok := next[len(next)-1].(map[string]interface{})
+			if !ok {
+				return nil, d.errorf("key %s is not a table", key)
+			}
+			table = last
+		default:
+			return nil, d.errorf("key %s is not a table", key)
+		}
+	}
+	return table, nil
+}
+
+// keyValue consumes a key = value pair into table. Keys are recorded below tablePath when
+// record is set, i.e. outside inline tables.
+func (d *tomlDecoder) keyValue(table map[string]interface{}, tablePath string, record bool) error {
+	start := d.pos
+	keys, err := d.key()
+	if err != nil {
+		return err
+	}
+	if d.eof() || d.peek() != '=' {
+		return d.errorf("expected = after key %s", strings.Join(keys, "."))
+	}
+	d.pos++
+	d.skipBlank(false)
+
+	value, err := d.value()
+	if err != nil {
+		return err
+	}
+	parent, err := d.subtable(table, keys[:len(keys)-1])
+	if err != nil {
+		return err
+	}
+	last := keys[len(keys)-1]
+	if _, exists := parent[last]; exists {
+		return d.errorf("duplicate key %s", strings.Join(keys, "."))
+	}
+	parent[last] = value
+
+	if record {
+		keyPath := strings.Join(keys, ".")
+		if tablePath != "" {
+			keyPath = tablePath + "." + keyPath
+		}
+		d.doc.extend(keyPath, d.line(start), d.line(d.pos-1))
+	}
+	return nil
+}
+
+// key consumes a dotted key of bare and quoted parts
+func (d *tomlDecoder) key() ([]string, error) {
+	var keys []string
+	for {
+		d.skipBlank(false)
+		if d.eof() {
+			return nil, d.errorf("expected a key")
+		}
+		switch d.peek() {
+		case '"', '\'':
+			key, err := d.value()
+			if err != nil {
+				return nil, err
+			}
+			keys = append(keys, key.(string))
+		default:
+			start := d.pos
+			for !d.eof() && isBareKeyChar(d.peek()) {
+				d.pos++
+			}
+			if start == d.pos {
+				return nil, d.errorf("invalid character %q in key", d.peek())
+			}
+			keys = append(keys, d.data[start:d.pos])
+		}
+		d.skipBlank(false)
+		if d.eof() || d.peek() != '.' {
+			return keys, nil
+		}
+		d.pos++
+	}
+}
+
+func isBareKeyChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-'
+}
+
+// value consumes a string, number, boolean, date-time, array or inline table
+func (d *tomlDecoder) value() (interface{}, error) {
+	if d.eof() {
+		return nil, d.errorf("expected a value")
+	}
+	rest := d.data[d.pos:]
+	switch {
+	case strings.HasPrefix(rest, `"""`):
+		return d.multilineString(`"""`, true)
+	case strings.HasPrefix(rest, "'''"):
+		return d.multilineString("'''", false)
+	case rest[0] == '"':
+		return d.basicString()
+	case rest[0] == '\'':
+		end := strings.IndexAny(rest[1:], "'\n")
+		if end < 0 || rest[1+end] != '\'' {
+			return nil, d.errorf("unterminated string")
+		}
+		d.pos += end + 2
+		return rest[1 : end+1], nil
+	case rest[0] == '[':
+		return d.array()
+	case rest[0] == '{':
+		return d.inlineTable()
+	}
+
+	end := strings.IndexAny(rest, ",]}#\n \t\r")
+	if end < 0 {
+		end = len(rest)
+	}
+	// Date-times may separate the date and the time with a space
+	if match := tomlDateTime.FindString(rest); len(match) == 10 && end == 10 && len(rest) > 12 && rest[10] == ' ' && rest[11] >= '0' && rest[11] <= '9' {
+		if more := strings.IndexAny(rest[11:], ",]}#\n \t\r"); more < 0 {
+			end = len(rest)
+		} else {
+			end = 11 + more
+		}
+	}
+	token := rest[:end]
+	d.pos += end
+
+	switch {
+	case token == "true":
+		return true, nil
+	case token == "false":
+		return false, nil
+	case tomlDateTime.MatchString(token):
+		return token, nil
+	}
+	// TOML integers have no leading zeros, which base 0 would read as octal
+	if digits := strings.TrimLeft(token, "+-"); len(digits) < 2 || digits[0] != '0' || digits[1] < '0' || digits[1] > '9' {
+		if n, err := strconv.ParseInt(token, 0, 64); err == nil {
+			return n, nil
+		}
+	}
+	if f, err := strconv.ParseFloat(strings.ReplaceAll(token, "_", ""), 64); err == nil {
+		return f, nil
+	}
+	return nil, d.errorf("invalid value %q", token)
+}
+
+// basicString consumes a double-quoted string on one line
+func (d *tomlDecoder) basicString() (string, error) {
+	d.pos++
+	var b strings.Builder
+	for {
+		if d.eof() || d.peek() == '\n' {
+			return "", d.errorf("unterminated string")
+		}
+		c := d.peek()
+		if c == '"' {
+			d.pos++
+			return b.String(), nil
+		}
+		if c == '\\' {
+			if err := d.escape(&b); err != nil {
+				return "", err
+			}
+			continue
+		}
+		b.WriteByte(c)
+		d.pos++
+	}
+}
+
+// multilineString consumes a multi-line string closed by quotes; a new line right after
+// the opening quotes is dropped
+func (d *tomlDecoder) multilineString(quotes string, escapes bool) (string, error) {
+	d.pos += len(quotes)
+	if strings.HasPrefix(d.data[d.pos:], "\r\n") {
+		d.pos += 2
+	} else if !d.eof() && d.peek() == '\n' {
+		d.pos++
+	}
+
+	var b strings.Builder
+	for {
+		if d.eof() {
+			return "", d.errorf("unterminated multi-line string")
+		}
+		if strings.HasPrefix(d.data[d.pos:], quotes) {
+			// Up to two quotes may directly precede the closing ones
+			for extra := 0; extra < 2 && strings.HasPrefix(d.data[d.pos+1:], quotes); extra++ {
+				b.WriteByte(d.peek())
+				d.pos++
+			}
+			d.pos += len(quotes)
+			return b.String(), nil
+		}
+		if escapes && d.peek() == '\\' {
+			// A backslash ending a line trims the following whitespace and new lines
+			if trimmed := strings.TrimLeft(d.data[d.pos+1:], " \t\r"); strings.HasPrefix(trimmed, "\n") {
+				d.pos = len(d.data) - len(strings.TrimLeft(trimmed, " \t\r\n"))
+				continue
+			}
+			if err := d.escape(&b); err != nil {
+				return "", err
+			}
+			continue
+		}
+		b.WriteByte(d.peek())
+		d.pos++
+	}
+}
+
+// escape consumes an escape sequence of a basic string into b
+func (d *tomlDecoder) escape(b *strings.Builder) error {
+	if d.pos+1 >= len(d.data) {
+		return d.errorf("unterminated escape sequence")
+	}
+	c := d.data[d.pos+1]
+	d.pos += 2
+	switch c {
+	case 'b':
+		b.WriteByte('\b')
+	case 't':
+		b.WriteByte('\t')
+	case 'n':
+		b.WriteByte('\n')
+	case 'f':
+		b.WriteByte('\f')
+	case 'r':
+		b.WriteByte('\r')
+	case '"', '\\':
+		b.WriteByte(c)
+	case 'u', 'U':
+		size := 4
+		if c == 'U' {
+			size = 8
+		}
+		if d.pos+size > len(d.data) {
+			return d.errorf("invalid unicode escape")
+		}
+		code, err := strconv.ParseUint(d.data[d.pos:d.pos+size], 16, 32)
+		if err != nil || !utf8.ValidRune(rune(code)) {
+			return d.errorf("invalid unicode escape %q", d.data[d.pos:d.pos+size])
+		}
+		b.WriteRune(rune(code))
+		d.pos += size
+	default:
+		return d.errorf("invalid escape sequence \\%c", c)
+	}
+	return nil
+}
+
+// array consumes an array, which may span several lines
+func (d *tomlDecoder) array() ([]interface{}, error) {
+	d.pos++
+	values := make([]interface{}, 0)
+	for {
+		d.skipBlank(true)
+		if d.eof() {
+			return nil, d.errorf("unterminated array")
+		}
+		if d.peek() == ']' {
+			d.pos++
+			return values, nil
+		}
+		value, err := d.value()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+		d.skipBlank(true)
+		if !d.eof() && d.peek() == ',' {
+			d.pos++
+		} else if d.eof() || d.peek() != ']' {
+			return nil, d.errorf("expected , or ] in array")
+		}
+	}
+}
+
+// inlineTable consumes a { key = value, ... } table
+func (d *tomlDecoder) inlineTable() (map[string]interface{}, error) {
+	d.pos++
+	table := make(map[string]interface{})
+	for {
+		d.skipBlank(false)
+		if d.eof() {
+			return nil, d.errorf("unterminated inline table")
+		}
+		if d.peek() == '}' {
+			d.pos++
+			return table, nil
+		}
+		if err := d.keyValue(table, "", false); err != nil {
+			return nil, err
+		}
+		d.skipBlank(false)
+		if !d.eof() && d.peek() == ',' {
+			d.pos++
+		} else if d.eof() || d.peek() != '}' {
+			return nil, d.errorf("expected , or } in inline table")
+		}
+	}
+}
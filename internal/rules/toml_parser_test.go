@@ -0,0 +1,142 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+)
+
+const appConfigTOML = `# Orders app
+name = "orders-app"
+replicas = 3
+
+[database]
+host = "db.example.com"
+port = 5_432
+options = [
+  "sslmode=require",  # enforced in prod
+  "connect_timeout=10",
+]
+
+[database.pool]
+size = 10
+
+[[workers]]
+name = "ingest"
+
+[[workers]]
+name = "export"
+schedule = { cron = "0 * * * *", timezone = 'UTC' }
+`
+
+func TestTOMLSectionParser_ParseSections(t *testing.T) {
+	parser := NewTOMLSectionParser(map[string]config.SectionDefinition{
+		"database": {Name: "database", YAMLPath: "database", Required: true},
+		"pool":     {Name: "pool", YAMLPath: "database.pool"},
+		"options":  {Name: "options", YAMLPath: "database.options"},
+		"replicas": {Name: "replicas", YAMLPath: "replicas"},
+		"workers":  {Name: "workers", YAMLPath: "workers"},
+		"full":     {Name: "full", YAMLPath: "."},
+		"missing":  {Name: "missing", YAMLPath: "cache"},
+	})
+
+	sections, err := parser.ParseSections("apps/orders/config.toml", appConfigTOML)
+	assert.NoError(t, err)
+
+	byName := make(map[string]shared.Section)
+	for _, section := range sections {
+		byName[section.Name] = section
+	}
+	assert.Len(t, byName, 6)
+
+	database := byName["database"]
+	assert.Equal(t, 5, database.StartLine)
+	assert.Equal(t, 14, database.EndLine, "a table spans its subtables")
+	assert.Equal(t, shared.TOMLSection, database.Type)
+	assert.Equal(t, "db.example.com", database.Fields["host"])
+	assert.Equal(t, int64(5432), database.Fields["port"])
+
+	assert.Equal(t, 8, byName["options"].StartLine)
+	assert.Equal(t, 11, byName["options"].EndLine)
+	assert.Equal(t, []interface{}{"sslmode=require", "connect_timeout=10"}, byName["options"].Fields["value"])
+
+	assert.Equal(t, 13, byName["pool"].StartLine)
+	assert.Equal(t, 14, byName["pool"].EndLine)
+
+	assert.Equal(t, 3, byName["replicas"].StartLine)
+	assert.Equal(t, map[string]interface{}{"value": int64(3)}, byName["replicas"].Fields)
+
+	workers := byName["workers"]
+	assert.Equal(t, 16, workers.StartLine)
+	assert.Equal(t, 21, workers.EndLine)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"name": "ingest"},
+		map[string]interface{}{"name": "export", "schedule": map[string]interface{}{"cron": "0 * * * *", "timezone": "UTC"}},
+	}, workers.Fields["value"])
+
+	assert.Equal(t, 1, byName["full"].StartLine)
+	assert.Equal(t, 22, byName["full"].EndLine)
+	assert.Equal(t, "orders-app", byName["full"].Fields["name"])
+}
+
+func TestParseTOMLDocument_Values(t *testing.T) {
+	doc, err := parseTOMLDocument(`
+basic = "tab\tquote\" \u00e9"
+literal = 'C:\path'
+multiline = """
+first \
+  second"""
+raw = '''
+keep \n'''
+hex = 0xff
+negative = -17
+float = 6.626e-34
+special = inf
+enabled = true
+released = 1979-05-27 07:32:00Z
+day = 1979-05-27
+site."google.com".port = 443
+`)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"basic":     "tab\tquote\" é",
+		"literal":   `C:\path`,
+		"multiline": "first second",
+		"raw":       `keep \n`,
+		"hex":       int64(255),
+		"negative":  int64(-17),
+		"float":     6.626e-34,
+		"special":   doc.value.(map[string]interface{})["special"],
+		"enabled":   true,
+		"released":  "1979-05-27 07:32:00Z",
+		"day":       "1979-05-27",
+		"site":      map[string]interface{}{"google.com": map[string]interface{}{"port": int64(443)}},
+	}, doc.value)
+	assert.Greater(t, doc.value.(map[string]interface{})["special"], 1e308)
+	assert.Equal(t, lineSpan{start: 4, end: 6}, doc.spans["multiline"])
+	assert.Equal(t, lineSpan{start: 16, end: 16}, doc.spans["site"])
+}
+
+func TestParseTOMLDocument_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{name: "duplicate key", content: "a = 1\na = 2\n", expected: "line 2: duplicate key a"},
+		{name: "missing value", content: "a =\n", expected: "line 1: invalid value"},
+		{name: "unterminated string", content: "a = \"x\n", expected: "line 1: unterminated string"},
+		{name: "unterminated array", content: "a = [1,\n2\n", expected: "line 3: expected , or ] in array"},
+		{name: "two values on a line", content: "a = 1 b = 2\n", expected: "line 1: expected a new line"},
+		{name: "key redefined as table", content: "a = 1\n[a.b]\n", expected: "line 2: key a is not a table"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseTOMLDocument(tt.content)
+			assert.ErrorContains(t, err, tt.expected)
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package warehouse
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -27,18 +29,19 @@ type Warehouse struct {
 // Tags represents the tags section
 type Tags struct {
 	DataProduct string `yaml:"data_product"`
+	Tier        string `yaml:"tier,omitempty"`
 }
 
 // GitLabClientInterface defines the interface for GitLab API operations needed by the analyzer
 type GitLabClientInterface interface {
-	GetMRTargetBranch(projectID, mrIID int) (string, error)
-	FetchFileContent(projectID int, filePath, ref string) (*gitlab.FileContent, error)
-	GetMRDetails(projectID, mrIID int) (*gitlab.MRDetails, error)
+	GetMRTargetBranch(ctx context.Context, projectID, mrIID int) (string, error)
+	FetchFileContent(ctx context.Context, projectID int, filePath, ref string) (*gitlab.FileContent, error)
+	GetMRDetails(ctx context.Context, projectID, mrIID int) (*gitlab.MRDetails, error)
 }
 
 // AnalyzerInterface defines the interface for warehouse analyzers
 type AnalyzerInterface interface {
-	AnalyzeChanges(projectID, mrIID int, changes []gitlab.FileChange) ([]WarehouseChange, error)
+	AnalyzeChanges(ctx context.Context, projectID, mrIID int, changes []gitlab.FileChange) ([]WarehouseChange, error)
 }
 
 // Analyzer analyzes YAML files for warehouse changes
@@ -54,7 +57,7 @@ func NewAnalyzer(gitlabClient GitLabClientInterface) *Analyzer {
 }
 
 // AnalyzeChanges analyzes GitLab MR changes for warehouse modifications using proper YAML parsing
-func (a *Analyzer) AnalyzeChanges(projectID, mrIID int, changes []gitlab.FileChange) ([]WarehouseChange, error) {
+func (a *Analyzer) AnalyzeChanges(ctx context.Context, projectID, mrIID int, changes []gitlab.FileChange) ([]WarehouseChange, error) {
 	warehouseChanges := make([]WarehouseChange, 0)
 
 	for _, change := range changes {
@@ -69,9 +72,9 @@ func (a *Analyzer) AnalyzeChanges(projectID, mrIID int, changes []gitlab.FileCha
 		}
 
 		// Analyze this specific file for warehouse changes
-		fileChanges, err := a.analyzeFileChange(projectID, mrIID, change.NewPath)
+		fileChanges, err := a.analyzeFileChange(ctx, projectID, mrIID, change.NewPath)
 		if err != nil {
-			return nil, fmt.Errorf("failed to analyze file %s: %v", change.NewPath, err)
+			return nil, fmt.Errorf("failed to analyze file %s: %w", change.NewPath, err)
 		}
 
 		if fileChanges != nil {
@@ -83,40 +86,52 @@ func (a *Analyzer) AnalyzeChanges(projectID, mrIID int, changes []gitlab.FileCha
 }
 
 // analyzeFileChange fetches complete file content and compares YAML structures
-func (a *Analyzer) analyzeFileChange(projectID, mrIID int, filePath string) (*[]WarehouseChange, error) {
+func (a *Analyzer) analyzeFileChange(ctx context.Context, projectID, mrIID int, filePath string) (*[]WarehouseChange, error) {
 	// Get target branch
-	targetBranch, err := a.gitlabClient.GetMRTargetBranch(projectID, mrIID)
+	targetBranch, err := a.gitlabClient.GetMRTargetBranch(ctx, projectID, mrIID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get target branch: %v", err)
 	}
 
 	// Get the MR details to find source branch
-	mrDetails, err := a.gitlabClient.GetMRDetails(projectID, mrIID)
+	mrDetails, err := a.gitlabClient.GetMRDetails(ctx, projectID, mrIID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get MR details: %v", err)
 	}
+	if mrDetails == nil {
+		return nil, fmt.Errorf("failed to get MR details: empty response")
+	}
 
 	// Determine project IDs for target and source branches
 	targetProjectID := projectID // Always use the target project ID for target branch
 	sourceProjectID := projectID // Default to target project ID
 
-	// For cross-fork MRs, use the source project ID for source branch
+	sourceRef := mrDetails.SourceBranch
+
+	// For cross-fork MRs, use the source project ID for source branch, pinned to the MR head
+	// so the fork branch moving on does not change what is analyzed
 	if mrDetails.SourceProjectID != 0 && mrDetails.SourceProjectID != targetProjectID {
 		sourceProjectID = mrDetails.SourceProjectID
+		if mrDetails.Sha != "" {
+			sourceRef = mrDetails.Sha
+		}
 	}
 
 	// Fetch file content from target branch (before changes)
-	oldContent, err := a.gitlabClient.FetchFileContent(targetProjectID, filePath, targetBranch)
-	if err != nil && strings.Contains(err.Error(), "file not found") {
+	oldContent, err := a.gitlabClient.FetchFileContent(ctx, targetProjectID, filePath, targetBranch)
+	if errors.Is(err, gitlab.ErrNotFound) {
 		// File is new - doesn't exist in target branch
 		// Try to fetch from source branch to analyze the new file
-		newContent, err := a.gitlabClient.FetchFileContent(sourceProjectID, filePath, mrDetails.SourceBranch)
+		newContent, err := a.gitlabClient.FetchFileContent(ctx, sourceProjectID, filePath, sourceRef)
 		if err != nil {
-			if strings.Contains(err.Error(), "file not found") {
+			if visibilityErr := a.forkVisibilityError(ctx, sourceProjectID, targetProjectID, filePath, sourceRef, err); visibilityErr != nil {
+				return nil, visibilityErr
+			}
+			if errors.Is(err, gitlab.ErrNotFound) {
 				// File doesn't exist in either branch - this shouldn't happen for non-deleted files
 				return &[]WarehouseChange{}, nil
 			}
-			return nil, fmt.Errorf("failed to fetch new file content from source project %d, branch %s: %v", sourceProjectID, mrDetails.SourceBranch, err)
+			return nil, fmt.Errorf("failed to fetch new file content from source project %d, branch %s: %v", sourceProjectID, sourceRef, err)
 		}
 
 		// New file - compare empty state with new content
@@ -132,10 +147,13 @@ func (a *Analyzer) analyzeFileChange(projectID, mrIID int, filePath string) (*[]
 	}
 
 	// Fetch file content from source branch (after changes)
-	newContent, err := a.gitlabClient.FetchFileContent(sourceProjectID, filePath, mrDetails.SourceBranch)
+	newContent, err := a.gitlabClient.FetchFileContent(ctx, sourceProjectID, filePath, sourceRef)
 	if err != nil {
+		if visibilityErr := a.forkVisibilityError(ctx, sourceProjectID, targetProjectID, filePath, sourceRef, err); visibilityErr != nil {
+			return nil, visibilityErr
+		}
 		// File might be deleted in source branch
-		if strings.Contains(err.Error(), "file not found") {
+		if errors.Is(err, gitlab.ErrNotFound) {
 			// File was deleted in source branch - compare old content with empty state
 			newDP := &DataProduct{Warehouses: []Warehouse{}}
 			oldDP, err := a.parseDataProduct(oldContent.Content)
@@ -145,7 +163,7 @@ func (a *Analyzer) analyzeFileChange(projectID, mrIID int, filePath string) (*[]
 			changes := a.compareWarehouses(filePath, oldDP, newDP)
 			return &changes, nil
 		}
-		return nil, fmt.Errorf("failed to fetch new file content from source project %d, branch %s: %v", sourceProjectID, mrDetails.SourceBranch, err)
+		return nil, fmt.Errorf("failed to fetch new file content from source project %d, branch %s: %v", sourceProjectID, sourceRef, err)
 	}
 
 	// Parse both YAML contents
@@ -187,6 +205,25 @@ func (a *Analyzer) parseDataProduct(content string) (*DataProduct, error) {
 	return &dp, nil
 }
 
+// CompareDataProducts returns the warehouse changes between two versions of a dataproduct
+// file. Empty content stands for a file that does not exist in that version.
+func CompareDataProducts(filePath, oldContent, newContent string) ([]WarehouseChange, error) {
+	a := &Analyzer{}
+	versions := make([]*DataProduct, 0, 2)
+	for _, content := range []string{oldContent, newContent} {
+		if content == "" {
+			versions = append(versions, &DataProduct{Warehouses: []Warehouse{}})
+			continue
+		}
+		dp, err := a.parseDataProduct(content)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, dp)
+	}
+	return a.compareWarehouses(filePath, versions[0], versions[1]), nil
+}
+
 // compareWarehouses compares warehouse configurations between old and new
 func (a *Analyzer) compareWarehouses(filePath string, oldDP, newDP *DataProduct) []WarehouseChange {
 	changes := make([]WarehouseChange, 0)
@@ -203,33 +240,38 @@ func (a *Analyzer) compareWarehouses(filePath string, oldDP, newDP *DataProduct)
 		newWarehouses[wh.Type] = wh.Size
 	}
 
-	// Check for warehouse size changes and new warehouse creation
+	// The target branch tier decides, so an MR cannot pick its own resize policy
+	tier := oldDP.Tags.Tier
+	if tier == "" {
+		tier = newDP.Tags.Tier
+	}
+
+	// Check for warehouse size changes and new warehouse creation. Sizes outside
+	// WarehouseSizes are reported too, so the size policy can reject them.
 	for whType, newSize := range newWarehouses {
 		if oldSize, exists := oldWarehouses[whType]; exists {
 			if oldSize != newSize {
-				// Warehouse size changed
+				// Warehouse size changed; only known sizes can be ordered
 				oldValue, oldExists := WarehouseSizes[oldSize]
 				newValue, newExists := WarehouseSizes[newSize]
 
-				if oldExists && newExists {
-					changes = append(changes, WarehouseChange{
-						FilePath:   fmt.Sprintf("%s (type: %s)", filePath, whType),
-						FromSize:   oldSize,
-						ToSize:     newSize,
-						IsDecrease: oldValue > newValue,
-					})
-				}
-			}
-		} else {
-			// New warehouse created - treat as an increase
-			if _, newExists := WarehouseSizes[newSize]; newExists {
 				changes = append(changes, WarehouseChange{
 					FilePath:   fmt.Sprintf("%s (type: %s)", filePath, whType),
-					FromSize:   "", // Empty for new warehouses
+					FromSize:   oldSize,
 					ToSize:     newSize,
-					IsDecrease: false, // New warehouse creation is always an increase
+					IsDecrease: oldExists && newExists && oldValue > newValue,
+					Tier:       tier,
 				})
 			}
+		} else {
+			// New warehouse created - treat as an increase
+			changes = append(changes, WarehouseChange{
+				FilePath:   fmt.Sprintf("%s (type: %s)", filePath, whType),
+				FromSize:   "", // Empty for new warehouses
+				ToSize:     newSize,
+				IsDecrease: false, // New warehouse creation is always an increase
+				Tier:       tier,
+			})
 		}
 	}
 
@@ -237,14 +279,13 @@ func (a *Analyzer) compareWarehouses(filePath string, oldDP, newDP *DataProduct)
 	for whType, oldSize := range oldWarehouses {
 		if _, exists := newWarehouses[whType]; !exists {
 			// Warehouse was removed - treat as a decrease (requires manual review)
-			if _, oldExists := WarehouseSizes[oldSize]; oldExists {
-				changes = append(changes, WarehouseChange{
-					FilePath:   fmt.Sprintf("%s (type: %s)", filePath, whType),
-					FromSize:   oldSize,
-					ToSize:     "",   // Empty for removed warehouses
-					IsDecrease: true, // Removal is considered a decrease
-				})
-			}
+			changes = append(changes, WarehouseChange{
+				FilePath:   fmt.Sprintf("%s (type: %s)", filePath, whType),
+				FromSize:   oldSize,
+				ToSize:     "",   // Empty for removed warehouses
+				IsDecrease: true, // Removal is considered a decrease
+				Tier:       tier,
+			})
 		}
 	}
 
@@ -1,6 +1,7 @@
 package warehouse
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -249,7 +250,14 @@ func TestAnalyzer_compareWarehouses(t *testing.T) {
 					{Type: "snowflake", Size: "MEDIUM"},
 				},
 			},
-			expected: []WarehouseChange{},
+			expected: []WarehouseChange{
+				{
+					FilePath:   "dataproducts/agg/test/product.yaml (type: snowflake)",
+					FromSize:   "UNKNOWN_SIZE",
+					ToSize:     "MEDIUM",
+					IsDecrease: false,
+				},
+			},
 		},
 		{
 			name: "empty warehouses",
@@ -326,7 +334,37 @@ func TestAnalyzer_compareWarehouses(t *testing.T) {
 	}
 }
 
+func TestCompareDataProducts(t *testing.T) {
+	oldContent := "name: sales\nwarehouses:\n- type: user\n  size: XSMALL\n"
+	newContent := "name: sales\nwarehouses:\n- type: user\n  size: MEDIUM\n"
+
+	changes, err := CompareDataProducts("sales/product.yaml", oldContent, newContent)
+	assert.NoError(t, err)
+	assert.Equal(t, []WarehouseChange{{FilePath: "sales/product.yaml (type: user)", FromSize: "XSMALL", ToSize: "MEDIUM"}}, changes)
+
+	changes, err = CompareDataProducts("sales/product.yaml", "", newContent)
+	assert.NoError(t, err)
+	assert.Equal(t, []WarehouseChange{{FilePath: "sales/product.yaml (type: user)", ToSize: "MEDIUM"}}, changes)
+
+	changes, err = CompareDataProducts("sales/product.yaml", oldContent, "")
+	assert.NoError(t, err)
+	assert.Equal(t, []WarehouseChange{{FilePath: "sales/product.yaml (type: user)", FromSize: "XSMALL", IsDecrease: true}}, changes)
+
+	_, err = CompareDataProducts("sales/product.yaml", oldContent, "warehouses: [")
+	assert.Error(t, err)
+
+	// The tier of the target branch wins over a tier changed by the MR
+	changes, err = CompareDataProducts("sales/product.yaml", oldContent+"tags:\n  tier: production\n", "warehouses:\n- type: user\n  size: XSMALL\n- type: loader\n  size: SMALL\ntags:\n  tier: test\n")
+	assert.NoError(t, err)
+	assert.Equal(t, []WarehouseChange{{FilePath: "sales/product.yaml (type: loader)", ToSize: "SMALL", Tier: "production"}}, changes)
+
+	changes, err = CompareDataProducts("sales/product.yaml", "", newContent+"tags:\n  tier: test\n")
+	assert.NoError(t, err)
+	assert.Equal(t, "test", changes[0].Tier)
+}
+
 func TestAnalyzer_AnalyzeChanges_FilteringLogic(t *testing.T) {
+	ctx := context.Background()
 	// Create mock client that will return specific responses
 	var mockClient GitLabClientInterface = &MockGitLabClient{}
 	analyzer := NewAnalyzer(mockClient)
@@ -378,7 +416,7 @@ func TestAnalyzer_AnalyzeChanges_FilteringLogic(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := analyzer.AnalyzeChanges(123, 456, tt.changes)
+			result, err := analyzer.AnalyzeChanges(ctx, 123, 456, tt.changes)
 			assert.NoError(t, err, "AnalyzeChanges should not return error for filtering tests")
 			assert.Equal(t, tt.expected, result, "AnalyzeChanges filtering result mismatch")
 		})
@@ -386,6 +424,7 @@ func TestAnalyzer_AnalyzeChanges_FilteringLogic(t *testing.T) {
 }
 
 func TestAnalyzer_analyzeFileChange_ErrorHandling(t *testing.T) {
+	ctx := context.Background()
 	tests := []struct {
 		name           string
 		mockClient     *MockGitLabClient
@@ -414,7 +453,7 @@ func TestAnalyzer_analyzeFileChange_ErrorHandling(t *testing.T) {
 			name: "file not found - should be handled gracefully",
 			mockClient: &MockGitLabClient{
 				targetBranch:   "main",
-				oldFileError:   fmt.Errorf("file not found"),
+				oldFileError:   fmt.Errorf("file not found: %w", gitlab.ErrNotFound),
 				mrDetails:      &gitlab.MRDetails{SourceBranch: "feature", ProjectID: 123, SourceProjectID: 123, TargetProjectID: 123},
 				newFileContent: &gitlab.FileContent{Content: "name: test\nrover_group: test"},
 			},
@@ -486,7 +525,7 @@ func TestAnalyzer_analyzeFileChange_ErrorHandling(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			var mockClient GitLabClientInterface = tt.mockClient
 			analyzer := NewAnalyzer(mockClient)
-			result, err := analyzer.analyzeFileChange(123, 456, "dataproducts/agg/test/product.yaml")
+			result, err := analyzer.analyzeFileChange(ctx, 123, 456, "dataproducts/agg/test/product.yaml")
 
 			if tt.expectedError != "" {
 				assert.Error(t, err, "analyzeFileChange should return error")
@@ -514,14 +553,14 @@ type MockGitLabClient struct {
 	lastFetchBranch    string // Track which branch was used for last fetch
 }
 
-func (m *MockGitLabClient) GetMRTargetBranch(projectID, mrIID int) (string, error) {
+func (m *MockGitLabClient) GetMRTargetBranch(ctx context.Context, projectID, mrIID int) (string, error) {
 	if m.targetBranchError != nil {
 		return "", m.targetBranchError
 	}
 	return m.targetBranch, nil
 }
 
-func (m *MockGitLabClient) FetchFileContent(projectID int, filePath, branch string) (*gitlab.FileContent, error) {
+func (m *MockGitLabClient) FetchFileContent(ctx context.Context, projectID int, filePath, branch string) (*gitlab.FileContent, error) {
 	// Track the last fetch call
 	m.lastFetchProjectID = projectID
 	m.lastFetchBranch = branch
@@ -541,7 +580,7 @@ func (m *MockGitLabClient) FetchFileContent(projectID int, filePath, branch stri
 	return m.newFileContent, nil
 }
 
-func (m *MockGitLabClient) GetMRDetails(projectID, mrIID int) (*gitlab.MRDetails, error) {
+func (m *MockGitLabClient) GetMRDetails(ctx context.Context, projectID, mrIID int) (*gitlab.MRDetails, error) {
 	if m.mrDetailsError != nil {
 		return nil, m.mrDetailsError
 	}
@@ -549,55 +588,55 @@ func (m *MockGitLabClient) GetMRDetails(projectID, mrIID int) (*gitlab.MRDetails
 }
 
 // Stub implementations for interface compliance (not used in warehouse tests)
-func (m *MockGitLabClient) FetchMRChanges(projectID, mrIID int) ([]gitlab.FileChange, error) {
+func (m *MockGitLabClient) FetchMRChanges(ctx context.Context, projectID, mrIID int) ([]gitlab.FileChange, error) {
 	return []gitlab.FileChange{}, nil
 }
 
-func (m *MockGitLabClient) AddMRComment(projectID, mrIID int, comment string) error {
+func (m *MockGitLabClient) AddMRComment(ctx context.Context, projectID, mrIID int, comment string) error {
 	return nil
 }
 
-func (m *MockGitLabClient) AddOrUpdateMRComment(projectID, mrIID int, commentBody, commentType string) error {
+func (m *MockGitLabClient) AddOrUpdateMRComment(ctx context.Context, projectID, mrIID int, commentBody, commentType string) error {
 	return nil
 }
 
-func (m *MockGitLabClient) ListMRComments(projectID, mrIID int) ([]gitlab.MRComment, error) {
+func (m *MockGitLabClient) ListMRComments(ctx context.Context, projectID, mrIID int) ([]gitlab.MRComment, error) {
 	return []gitlab.MRComment{}, nil
 }
 
-func (m *MockGitLabClient) UpdateMRComment(projectID, mrIID, commentID int, newBody string) error {
+func (m *MockGitLabClient) UpdateMRComment(ctx context.Context, projectID, mrIID, commentID int, newBody string) error {
 	return nil
 }
 
-func (m *MockGitLabClient) FindLatestNaysayerComment(projectID, mrIID int, commentType ...string) (*gitlab.MRComment, error) {
+func (m *MockGitLabClient) FindLatestNaysayerComment(ctx context.Context, projectID, mrIID int, commentType ...string) (*gitlab.MRComment, error) {
 	return nil, nil
 }
 
-func (m *MockGitLabClient) ApproveMR(projectID, mrIID int) error {
+func (m *MockGitLabClient) ApproveMR(ctx context.Context, projectID, mrIID int) error {
 	return nil
 }
 
-func (m *MockGitLabClient) ApproveMRWithMessage(projectID, mrIID int, message string) error {
+func (m *MockGitLabClient) ApproveMRWithMessage(ctx context.Context, projectID, mrIID int, message string) error {
 	return nil
 }
 
-func (m *MockGitLabClient) ResetNaysayerApproval(projectID, mrIID int) error {
+func (m *MockGitLabClient) ResetNaysayerApproval(ctx context.Context, projectID, mrIID int) error {
 	return nil
 }
 
-func (m *MockGitLabClient) GetCurrentBotUsername() (string, error) {
+func (m *MockGitLabClient) GetCurrentBotUsername(ctx context.Context) (string, error) {
 	return "naysayer-bot", nil
 }
 
-func (m *MockGitLabClient) IsNaysayerBotAuthor(author map[string]interface{}) bool {
+func (m *MockGitLabClient) IsNaysayerBotAuthor(ctx context.Context, author map[string]interface{}) bool {
 	return false
 }
 
-func (m *MockGitLabClient) RebaseMR(projectID, mrIID int) (bool, error) {
+func (m *MockGitLabClient) RebaseMR(ctx context.Context, projectID, mrIID int) (bool, error) {
 	return true, nil
 }
 
-func (m *MockGitLabClient) ListOpenMRs(projectID int) ([]int, error) {
+func (m *MockGitLabClient) ListOpenMRs(ctx context.Context, projectID int) ([]int, error) {
 	return []int{}, nil
 }
 
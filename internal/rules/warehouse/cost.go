@@ -0,0 +1,128 @@
+package warehouse
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+)
+
+// DefaultCreditsPerHour are Snowflake's standard credits per running hour by warehouse size
+var DefaultCreditsPerHour = map[string]float64{
+	"XSMALL":  1,
+	"SMALL":   2,
+	"MEDIUM":  4,
+	"LARGE":   8,
+	"XLARGE":  16,
+	"XXLARGE": 32,
+	"X3LARGE": 64,
+	"X4LARGE": 128,
+	"X5LARGE": 256,
+	"X6LARGE": 512,
+}
+
+// CostModel estimates the monthly credits and cost of warehouse sizes
+type CostModel struct {
+	CreditsPerHour map[string]float64 // Credits per running hour by size
+	HoursPerMonth  float64            // Assumed running hours per month
+	CreditPrice    float64            // Price of one credit
+	Currency       string             // Currency of CreditPrice
+}
+
+// NewCostModel creates a cost model from the warehouse rule configuration; sizes missing
+// from the configured table fall back to DefaultCreditsPerHour
+func NewCostModel(cfg config.WarehouseRuleConfig) *CostModel {
+	credits := make(map[string]float64, len(DefaultCreditsPerHour))
+	for size, value := range DefaultCreditsPerHour {
+		credits[size] = value
+	}
+	for size, value := range cfg.CreditsPerHour {
+		credits[strings.ToUpper(size)] = value
+	}
+	return &CostModel{
+		CreditsPerHour: credits,
+		HoursPerMonth:  cfg.HoursPerMonth,
+		CreditPrice:    cfg.CreditPrice,
+		Currency:       cfg.CostCurrency,
+	}
+}
+
+// CostImpact is the estimated monthly impact of one warehouse change
+type CostImpact struct {
+	FilePath      string  // product.yaml of the warehouse
+	WarehouseType string  // e.g. user, service_account
+	FromSize      string  // Empty for added warehouses
+	ToSize        string  // Empty for removed warehouses
+	CreditsDelta  float64 // Monthly credits after minus before
+	CostDelta     float64 // CreditsDelta times the credit price
+	UnknownSize   bool    // A size has no cost entry; the deltas leave it out
+}
+
+// MonthlyCredits returns the credits a warehouse of size uses per month. Empty sizes use none.
+func (m *CostModel) MonthlyCredits(size string) (float64, bool) {
+	if size == "" || size == "N/A" {
+		return 0, true
+	}
+	perHour, ok := m.CreditsPerHour[strings.ToUpper(size)]
+	return perHour * m.HoursPerMonth, ok
+}
+
+// Estimate returns the cost impact of each warehouse size change, sorted by file and
+// warehouse type. Changes without sizes (non-warehouse changes) have no cost impact.
+func (m *CostModel) Estimate(changes []WarehouseChange) []CostImpact {
+	impacts := make([]CostImpact, 0, len(changes))
+	for _, change := range changes {
+		if normalizeSize(change.FromSize) == "" && normalizeSize(change.ToSize) == "" {
+			continue
+		}
+		filePath, warehouseType := splitChangePath(change.FilePath)
+		impact := CostImpact{
+			FilePath:      filePath,
+			WarehouseType: warehouseType,
+			FromSize:      normalizeSize(change.FromSize),
+			ToSize:        normalizeSize(change.ToSize),
+		}
+		before, okBefore := m.MonthlyCredits(impact.FromSize)
+		after, okAfter := m.MonthlyCredits(impact.ToSize)
+		if !okBefore || !okAfter {
+			impact.UnknownSize = true
+		} else {
+			impact.CreditsDelta = after - before
+			impact.CostDelta = impact.CreditsDelta * m.CreditPrice
+		}
+		impacts = append(impacts, impact)
+	}
+	sort.SliceStable(impacts, func(i, j int) bool {
+		if impacts[i].FilePath != impacts[j].FilePath {
+			return impacts[i].FilePath < impacts[j].FilePath
+		}
+		return impacts[i].WarehouseType < impacts[j].WarehouseType
+	})
+	return impacts
+}
+
+// TotalCostDelta sums the monthly cost deltas of impacts
+func TotalCostDelta(impacts []CostImpact) (credits, cost float64) {
+	for _, impact := range impacts {
+		credits += impact.CreditsDelta
+		cost += impact.CostDelta
+	}
+	return credits, cost
+}
+
+// splitChangePath splits a change path "dataproducts/.../product.yaml (type: user)" into
+// the file path and warehouse type
+func splitChangePath(changePath string) (string, string) {
+	idx := strings.Index(changePath, " (type: ")
+	if idx == -1 {
+		return changePath, "unknown"
+	}
+	return changePath[:idx], strings.TrimSuffix(changePath[idx+len(" (type: "):], ")")
+}
+
+func normalizeSize(size string) string {
+	if size == "N/A" {
+		return ""
+	}
+	return size
+}
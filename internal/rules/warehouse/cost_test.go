@@ -0,0 +1,71 @@
+package warehouse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+)
+
+func testCostModel() *CostModel {
+	return NewCostModel(config.WarehouseRuleConfig{HoursPerMonth: 100, CreditPrice: 2, CostCurrency: "USD"})
+}
+
+func TestNewCostModel_Overrides(t *testing.T) {
+	model := NewCostModel(config.WarehouseRuleConfig{
+		CreditsPerHour: map[string]float64{"large": 10},
+		HoursPerMonth:  730,
+	})
+	assert.Equal(t, 10.0, model.CreditsPerHour["LARGE"])
+	assert.Equal(t, 2.0, model.CreditsPerHour["SMALL"], "sizes without override keep the default")
+	assert.Equal(t, 8.0, DefaultCreditsPerHour["LARGE"], "defaults are not modified")
+}
+
+func TestCostModel_MonthlyCredits(t *testing.T) {
+	model := testCostModel()
+
+	credits, ok := model.MonthlyCredits("medium")
+	assert.True(t, ok)
+	assert.Equal(t, 400.0, credits)
+
+	credits, ok = model.MonthlyCredits("")
+	assert.True(t, ok)
+	assert.Equal(t, 0.0, credits)
+
+	_, ok = model.MonthlyCredits("HUGE")
+	assert.False(t, ok)
+}
+
+func TestCostModel_Estimate(t *testing.T) {
+	model := testCostModel()
+	impacts := model.Estimate([]WarehouseChange{
+		{FilePath: "dataproducts/source/b/prod/product.yaml (type: user)", FromSize: "SMALL", ToSize: "LARGE"},
+		{FilePath: "dataproducts/source/a/prod/product.yaml (type: service_account)", FromSize: "MEDIUM", ToSize: "", IsDecrease: true},
+		{FilePath: "dataproducts/source/a/prod/product.yaml (type: user)", FromSize: "", ToSize: "XSMALL"},
+		{FilePath: "dataproducts/source/a/prod/product.yaml (non-warehouse changes)", FromSize: "N/A", ToSize: "N/A"},
+	})
+
+	assert.Equal(t, []CostImpact{
+		{FilePath: "dataproducts/source/a/prod/product.yaml", WarehouseType: "service_account", FromSize: "MEDIUM", CreditsDelta: -400, CostDelta: -800},
+		{FilePath: "dataproducts/source/a/prod/product.yaml", WarehouseType: "user", ToSize: "XSMALL", CreditsDelta: 100, CostDelta: 200},
+		{FilePath: "dataproducts/source/b/prod/product.yaml", WarehouseType: "user", FromSize: "SMALL", ToSize: "LARGE", CreditsDelta: 600, CostDelta: 1200},
+	}, impacts)
+
+	credits, cost := TotalCostDelta(impacts)
+	assert.Equal(t, 300.0, credits)
+	assert.Equal(t, 600.0, cost)
+}
+
+func TestCostModel_EstimateUnknownSize(t *testing.T) {
+	model := testCostModel()
+	delete(model.CreditsPerHour, "X6LARGE")
+
+	impacts := model.Estimate([]WarehouseChange{
+		{FilePath: "dataproducts/source/a/prod/product.yaml (type: user)", FromSize: "X5LARGE", ToSize: "X6LARGE"},
+	})
+
+	assert.Len(t, impacts, 1)
+	assert.True(t, impacts[0].UnknownSize)
+	assert.Equal(t, 0.0, impacts[0].CostDelta)
+}
@@ -0,0 +1,82 @@
+package warehouse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+)
+
+// ErrForkVisibility is returned when the source fork of an MR cannot be read by the bot
+var ErrForkVisibility = errors.New("source fork is not visible to the naysayer bot")
+
+// forkVisibilityFailures counts fork MRs whose source project the bot could not read
+var forkVisibilityFailures atomic.Int64
+
+// ForkVisibilityFailures returns the fork visibility failures since startup
+func ForkVisibilityFailures() int64 {
+	return forkVisibilityFailures.Load()
+}
+
+// ForkVisibilityError describes a source file fetch of a fork MR that failed because the
+// bot has no access to the fork
+type ForkVisibilityError struct {
+	SourceProjectID int
+	TargetProjectID int
+	FilePath        string
+	Ref             string
+	Err             error
+}
+
+func (e *ForkVisibilityError) Error() string {
+	return fmt.Sprintf("%v: cannot read %s on %s of fork project %d: %v", ErrForkVisibility, e.FilePath, e.Ref, e.SourceProjectID, e.Err)
+}
+
+// Unwrap returns the GitLab error of the failed fetch
+func (e *ForkVisibilityError) Unwrap() error {
+	return e.Err
+}
+
+// Is makes errors.Is(err, ErrForkVisibility) match
+func (e *ForkVisibilityError) Is(target error) bool {
+	return target == ErrForkVisibility
+}
+
+// projectGetter is implemented by clients that can look up projects
+type projectGetter interface {
+	GetProject(ctx context.Context, projectID int) (*gitlab.Project, error)
+}
+
+// forkVisibilityError classifies a failed source file fetch of a fork MR. Permission
+// errors always mean the fork is hidden from the bot. GitLab answers 404 for projects the
+// bot cannot see, so a not-found file counts only when the fork itself cannot be read.
+// It returns nil for errors unrelated to visibility.
+func (a *Analyzer) forkVisibilityError(ctx context.Context, sourceProjectID, targetProjectID int, filePath, ref string, err error) error {
+	if sourceProjectID == targetProjectID {
+		return nil
+	}
+	switch {
+	case errors.Is(err, gitlab.ErrPermission):
+	case errors.Is(err, gitlab.ErrNotFound):
+		getter, ok := a.gitlabClient.(projectGetter)
+		if !ok {
+			return nil
+		}
+		if _, projectErr := getter.GetProject(ctx, sourceProjectID); !errors.Is(projectErr, gitlab.ErrNotFound) && !errors.Is(projectErr, gitlab.ErrPermission) {
+			return nil
+		}
+	default:
+		return nil
+	}
+
+	forkVisibilityFailures.Add(1)
+	return &ForkVisibilityError{
+		SourceProjectID: sourceProjectID,
+		TargetProjectID: targetProjectID,
+		FilePath:        filePath,
+		Ref:             ref,
+		Err:             err,
+	}
+}
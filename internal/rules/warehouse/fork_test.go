@@ -0,0 +1,120 @@
+package warehouse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+// forkMockGitLabClient adds project lookups to MockGitLabClient
+type forkMockGitLabClient struct {
+	MockGitLabClient
+	projectError error
+}
+
+func (m *forkMockGitLabClient) GetProject(ctx context.Context, projectID int) (*gitlab.Project, error) {
+	if m.projectError != nil {
+		return nil, m.projectError
+	}
+	return &gitlab.Project{ID: projectID}, nil
+}
+
+func forkMRDetails() *gitlab.MRDetails {
+	return &gitlab.MRDetails{SourceBranch: "feature", ProjectID: 123, SourceProjectID: 456, TargetProjectID: 123}
+}
+
+func TestAnalyzeFileChange_ForkVisibility(t *testing.T) {
+	ctx := context.Background()
+	oldContent := &gitlab.FileContent{Content: "name: test\nwarehouses:\n- type: user\n  size: XSMALL\n"}
+	notFound := fmt.Errorf("%w: file", gitlab.ErrNotFound)
+	forbidden := fmt.Errorf("%w: file", gitlab.ErrPermission)
+
+	tests := []struct {
+		name         string
+		client       GitLabClientInterface
+		visibilityOK bool
+	}{
+		{
+			name: "permission error on fork",
+			client: &MockGitLabClient{targetBranch: "main", oldFileContent: oldContent,
+				newFileError: forbidden, mrDetails: forkMRDetails()},
+		},
+		{
+			name: "not found on a fork hidden from the bot",
+			client: &forkMockGitLabClient{MockGitLabClient: MockGitLabClient{targetBranch: "main", oldFileContent: oldContent,
+				newFileError: notFound, mrDetails: forkMRDetails()}, projectError: notFound},
+		},
+		{
+			name: "new file on a fork hidden from the bot",
+			client: &forkMockGitLabClient{MockGitLabClient: MockGitLabClient{targetBranch: "main", oldFileError: notFound,
+				newFileError: notFound, mrDetails: forkMRDetails()}, projectError: notFound},
+		},
+		{
+			name: "file deleted on a visible fork",
+			client: &forkMockGitLabClient{MockGitLabClient: MockGitLabClient{targetBranch: "main", oldFileContent: oldContent,
+				newFileError: notFound, mrDetails: forkMRDetails()}},
+			visibilityOK: true,
+		},
+		{
+			name: "permission error in the same project",
+			client: &MockGitLabClient{targetBranch: "main", oldFileContent: oldContent, newFileError: forbidden,
+				mrDetails: &gitlab.MRDetails{SourceBranch: "feature", ProjectID: 123, SourceProjectID: 123}},
+			visibilityOK: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := ForkVisibilityFailures()
+			_, err := NewAnalyzer(tt.client).analyzeFileChange(ctx, 123, 1, "dataproducts/source/test/prod/product.yaml")
+
+			if tt.visibilityOK {
+				assert.False(t, errors.Is(err, ErrForkVisibility))
+				assert.Equal(t, before, ForkVisibilityFailures())
+				return
+			}
+			var visibilityErr *ForkVisibilityError
+			if assert.ErrorAs(t, err, &visibilityErr) {
+				assert.Equal(t, 456, visibilityErr.SourceProjectID)
+				assert.Equal(t, 123, visibilityErr.TargetProjectID)
+				assert.Equal(t, "feature", visibilityErr.Ref)
+			}
+			assert.ErrorIs(t, err, ErrForkVisibility)
+			assert.Equal(t, before+1, ForkVisibilityFailures())
+		})
+	}
+}
+
+func TestWarehouseRule_ForkVisibilityReason(t *testing.T) {
+	rule := NewRule(nil, nil)
+	rule.analyzer = &MockAnalyzer{err: fmt.Errorf("failed to analyze file x: %w", &ForkVisibilityError{
+		SourceProjectID: 456, TargetProjectID: 123, FilePath: "x", Ref: "feature", Err: gitlab.ErrPermission,
+	})}
+	rule.SetMRContext(&shared.MRContext{ProjectID: 123, MRIID: 1})
+
+	decision, reason := rule.ValidateLines("dataproducts/source/test/prod/product.yaml", "warehouses: []", nil)
+	assert.Equal(t, shared.ManualReview, decision)
+	assert.Equal(t, "Warehouse changes could not be analyzed: the source fork (project 456) is not visible to the naysayer bot. "+
+		"Grant the naysayer bot at least Reporter access to the fork (Manage > Members) and push again or re-run the review", reason)
+}
+
+func TestAnalyzeFileChange_ForkReadsMRHead(t *testing.T) {
+	ctx := context.Background()
+	details := forkMRDetails()
+	details.Sha = "abc123"
+	client := &MockGitLabClient{targetBranch: "main",
+		oldFileContent: &gitlab.FileContent{Content: "name: test\nwarehouses:\n- type: user\n  size: XSMALL\n"},
+		newFileContent: &gitlab.FileContent{Content: "name: test\nwarehouses:\n- type: user\n  size: SMALL\n"},
+		mrDetails:      details}
+
+	changes, err := NewAnalyzer(client).analyzeFileChange(ctx, 123, 1, "dataproducts/source/test/prod/product.yaml")
+	assert.NoError(t, err)
+	assert.Equal(t, "XSMALL", (*changes)[0].FromSize)
+	assert.Equal(t, 456, client.lastFetchProjectID)
+	assert.Equal(t, "abc123", client.lastFetchBranch)
+}
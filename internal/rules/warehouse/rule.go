@@ -1,6 +1,7 @@
 package warehouse
 
 import (
+	"errors"
 	"fmt"
 	"sort"
 	"strings"
@@ -14,10 +15,15 @@ type Rule struct {
 	client   gitlab.GitLabClient
 	analyzer AnalyzerInterface
 	mrCtx    *shared.MRContext // Store MR context for warehouse analysis
+	policy   *SizePolicy       // Size policy of the changes, nil for none
 }
 
-// NewRule creates a new warehouse validation rule
-func NewRule(client gitlab.GitLabClient) *Rule {
+// SizePolicyViolationPrefix starts the reason of a manual review required by the size policy
+const SizePolicyViolationPrefix = "Warehouse size policy violation: "
+
+// NewRule creates a new warehouse validation rule checking changes against policy (nil skips
+// the size policy)
+func NewRule(client gitlab.GitLabClient, policy *SizePolicy) *Rule {
 	var analyzer AnalyzerInterface
 	if client != nil {
 		analyzer = NewAnalyzer(client)
@@ -26,6 +32,7 @@ func NewRule(client gitlab.GitLabClient) *Rule {
 	return &Rule{
 		client:   client,
 		analyzer: analyzer,
+		policy:   policy,
 	}
 }
 
@@ -36,7 +43,7 @@ func (r *Rule) Name() string {
 
 // Description returns human-readable description
 func (r *Rule) Description() string {
-	return "Validates warehouse size changes in product.yaml files - all warehouse changes require manual review for cost control and governance."
+	return "Validates warehouse size changes in product.yaml files - warehouse changes require manual review for cost control and governance unless the resize policy approves size decreases."
 }
 
 // SetMRContext implements ContextAwareRule interface
@@ -44,17 +51,18 @@ func (r *Rule) SetMRContext(mrCtx *shared.MRContext) {
 	r.mrCtx = mrCtx
 }
 
+// IsEnforced implements shared.EnforcedRule: size policy violations always require manual
+// review, whatever the environment behaviors and severities of the rule
+func (r *Rule) IsEnforced(reason string) bool {
+	return strings.HasPrefix(reason, SizePolicyViolationPrefix)
+}
+
 // GetCoveredLines returns which line ranges this rule validates in a file
 func (r *Rule) GetCoveredLines(filePath string, fileContent string) []shared.LineRange {
 	if !r.isWarehouseFile(filePath) {
 		return nil // This rule doesn't apply to non-warehouse files
 	}
 
-	// Check if file has content
-	if len(strings.TrimSpace(fileContent)) == 0 {
-		return nil // No content to validate
-	}
-
 	// For section-based validation, we return a placeholder range to indicate
 	// this rule wants to participate in validation. The actual section content
 	// will be provided by the section manager.
@@ -69,7 +77,7 @@ func (r *Rule) GetCoveredLines(filePath string, fileContent string) []shared.Lin
 
 // ValidateLines validates warehouse configuration changes
 // When called by section-based validation, fileContent contains the warehouses section content
-// ALL warehouse changes require manual review - no auto-approval
+// Warehouse changes require manual review unless the resize policy approves size decreases
 func (r *Rule) ValidateLines(filePath string, fileContent string, lineRanges []shared.LineRange) (shared.DecisionType, string) {
 	if !r.isWarehouseFile(filePath) {
 		return shared.Approve, "Not a warehouse file"
@@ -82,8 +90,12 @@ func (r *Rule) ValidateLines(filePath string, fileContent string, lineRanges []s
 	}
 
 	// Use the analyzer to detect warehouse changes
-	changes, err := r.analyzer.AnalyzeChanges(r.mrCtx.ProjectID, r.mrCtx.MRIID, r.mrCtx.Changes)
+	changes, err := r.analyzer.AnalyzeChanges(r.mrCtx.Context(), r.mrCtx.ProjectID, r.mrCtx.MRIID, r.mrCtx.Changes)
 	if err != nil {
+		var visibilityErr *ForkVisibilityError
+		if errors.As(err, &visibilityErr) {
+			return shared.ManualReview, r.forkVisibilityReason(visibilityErr)
+		}
 		// If analysis fails, require manual review for safety
 		return shared.ManualReview, fmt.Sprintf("Warehouse analysis failed: %v", err)
 	}
@@ -94,10 +106,15 @@ func (r *Rule) ValidateLines(filePath string, fileContent string, lineRanges []s
 	var warehouseRemovals []WarehouseChange
 	var warehouseIncreases []WarehouseChange
 	var warehouseDecreases []WarehouseChange
+	var violations []string
 
 	for _, change := range changes {
 		// Check if this change affects the current file
 		if strings.Contains(change.FilePath, filePath) {
+			if r.policy != nil {
+				violations = append(violations, r.policy.Violations(change, r.mrCtx.EnvironmentOf(filePath))...)
+			}
+
 			// Categorize ALL warehouse changes (not just size changes to existing)
 			// Note: FromSize can be "N/A" or empty string "" for new warehouses
 			isNewWarehouse := (change.FromSize == "N/A" || change.FromSize == "") && change.ToSize != "N/A" && change.ToSize != ""
@@ -120,7 +137,25 @@ func (r *Rule) ValidateLines(filePath string, fileContent string, lineRanges []s
 		}
 	}
 
-	// ALL warehouse changes require manual review - no auto-approval
+	// Size policy violations require manual review regardless of the change direction
+	if len(violations) > 0 {
+		sort.Strings(violations)
+		return shared.ManualReview, SizePolicyViolationPrefix + strings.Join(violations, "; ")
+	}
+
+	// Within the size limits, the resize policy of the file may approve pure decreases
+	if r.policy != nil && len(warehouseDecreases) > 0 && len(warehouseAdditions)+len(warehouseRemovals)+len(warehouseIncreases) == 0 {
+		if policy := r.policy.Resize(r.mrCtx.EnvironmentOf(filePath), warehouseDecreases[0].Tier); policy == ResizeApproveDecreases {
+			var details []string
+			for _, change := range warehouseDecreases {
+				details = append(details, formatSizeChangeDetail(r.extractWarehouseType(change.FilePath), change.FromSize, change.ToSize, false, "decreased"))
+			}
+			sort.Strings(details)
+			return shared.Approve, fmt.Sprintf("Warehouse size decrease approved by the %s resize policy: %s", policy, strings.Join(details, ", "))
+		}
+	}
+
+	// Any other warehouse change requires manual review
 	allChanges := len(warehouseAdditions) + len(warehouseRemovals) + len(warehouseIncreases) + len(warehouseDecreases)
 	if allChanges > 0 {
 		var details []string
@@ -219,3 +254,15 @@ func formatSizeChangeDetail(warehouseType, from, to string, hasMixedChanges bool
 	}
 	return fmt.Sprintf("%s warehouse: %s → %s", warehouseType, from, to)
 }
+
+// forkVisibilityReason tells the MR author how to give the bot access to their fork
+func (r *Rule) forkVisibilityReason(err *ForkVisibilityError) string {
+	bot := "the naysayer bot"
+	if r.client != nil {
+		if username, usernameErr := r.client.GetCurrentBotUsername(r.mrCtx.Context()); usernameErr == nil && username != "" {
+			bot = "@" + username
+		}
+	}
+	return fmt.Sprintf("Warehouse changes could not be analyzed: the source fork (project %d) is not visible to %s. "+
+		"Grant %s at least Reporter access to the fork (Manage > Members) and push again or re-run the review", err.SourceProjectID, bot, bot)
+}
@@ -1,7 +1,9 @@
 package warehouse
 
 import (
+	"context"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
@@ -15,17 +17,17 @@ type MockAnalyzer struct {
 	err     error
 }
 
-func (m *MockAnalyzer) AnalyzeChanges(projectID int, mrIID int, changes []gitlab.FileChange) ([]WarehouseChange, error) {
+func (m *MockAnalyzer) AnalyzeChanges(ctx context.Context, projectID int, mrIID int, changes []gitlab.FileChange) ([]WarehouseChange, error) {
 	return m.changes, m.err
 }
 
 func TestWarehouseRule_Name(t *testing.T) {
-	rule := NewRule(nil)
+	rule := NewRule(nil, nil)
 	assert.Equal(t, "warehouse_rule", rule.Name())
 }
 
 func TestWarehouseRule_Description(t *testing.T) {
-	rule := NewRule(nil)
+	rule := NewRule(nil, nil)
 	description := rule.Description()
 	assert.Contains(t, description, "warehouse")
 	assert.Contains(t, description, "product.yaml")
@@ -33,7 +35,7 @@ func TestWarehouseRule_Description(t *testing.T) {
 }
 
 func TestWarehouseRule_isWarehouseFile(t *testing.T) {
-	rule := NewRule(nil)
+	rule := NewRule(nil, nil)
 
 	tests := []struct {
 		name     string
@@ -59,7 +61,7 @@ func TestWarehouseRule_isWarehouseFile(t *testing.T) {
 }
 
 func TestWarehouseRule_GetCoveredLines(t *testing.T) {
-	rule := NewRule(nil)
+	rule := NewRule(nil, nil)
 
 	tests := []struct {
 		name        string
@@ -70,8 +72,6 @@ func TestWarehouseRule_GetCoveredLines(t *testing.T) {
 		{"warehouse file with content", "dataproducts/analytics/product.yaml", "name: test\nwarehouses:\n- type: user\n  size: XSMALL\n", true},
 		{"warehouse file with minimal content", "product.yaml", "name: test", true},
 		{"non-warehouse file", "README.md", "# README\nThis is a readme file\n", false},
-		{"warehouse file with empty content", "product.yaml", "", false},
-		{"warehouse file with whitespace only", "product.yaml", "   \n  \t  \n", false},
 	}
 
 	for _, tt := range tests {
@@ -83,14 +83,14 @@ func TestWarehouseRule_GetCoveredLines(t *testing.T) {
 				assert.Equal(t, 1, lines[0].StartLine)
 				assert.Equal(t, 1, lines[0].EndLine)
 			} else {
-				assert.Len(t, lines, 0, "Should not cover lines for non-warehouse files or empty files")
+				assert.Len(t, lines, 0, "Should not cover lines for non-warehouse files")
 			}
 		})
 	}
 }
 
 func TestWarehouseRule_ValidateLines_NoContext(t *testing.T) {
-	rule := NewRule(nil)
+	rule := NewRule(nil, nil)
 
 	tests := []struct {
 		name           string
@@ -199,7 +199,7 @@ func TestWarehouseRule_ValidateLines_WithContext(t *testing.T) {
 				changes: tt.mockChanges,
 				err:     tt.mockError,
 			}
-			rule := NewRule(nil)
+			rule := NewRule(nil, nil)
 			rule.analyzer = mockAnalyzer
 
 			// Set MR context
@@ -224,7 +224,7 @@ func TestWarehouseRule_ValidateLines_WithContext(t *testing.T) {
 }
 
 func TestWarehouseRule_SetMRContext(t *testing.T) {
-	rule := NewRule(nil)
+	rule := NewRule(nil, nil)
 
 	mrCtx := &shared.MRContext{
 		ProjectID: 123,
@@ -239,7 +239,7 @@ func TestWarehouseRule_SetMRContext(t *testing.T) {
 }
 
 func TestWarehouseRule_extractWarehouseType(t *testing.T) {
-	rule := NewRule(nil)
+	rule := NewRule(nil, nil)
 
 	tests := []struct {
 		name         string
@@ -312,7 +312,7 @@ func TestWarehouseRule_SectionBasedScenarios(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Test coverage
-			rule := NewRule(nil)
+			rule := NewRule(nil, nil)
 			lines := rule.GetCoveredLines(tt.filePath, tt.fileContent)
 			if tt.expectCoverage {
 				assert.Len(t, lines, 1, "Should cover warehouse files")
@@ -325,7 +325,7 @@ func TestWarehouseRule_SectionBasedScenarios(t *testing.T) {
 				changes: tt.mockChanges,
 				err:     nil,
 			}
-			rule = NewRule(nil)
+			rule = NewRule(nil, nil)
 			rule.analyzer = mockAnalyzer
 
 			mrCtx := &shared.MRContext{
@@ -343,3 +343,148 @@ func TestWarehouseRule_SectionBasedScenarios(t *testing.T) {
 		})
 	}
 }
+
+func TestWarehouseRule_SizePolicy(t *testing.T) {
+	filePath := "dataproducts/source/orders/sandbox/product.yaml"
+	policy := &SizePolicy{MaxSizes: map[string]string{"sandbox": "SMALL"}, MaxSteps: 2}
+
+	tests := []struct {
+		name     string
+		changes  []WarehouseChange
+		expected shared.DecisionType
+		reason   string
+	}{
+		{
+			name:     "allowed increase",
+			changes:  []WarehouseChange{{FilePath: filePath + " (type: user)", FromSize: "XSMALL", ToSize: "SMALL"}},
+			expected: shared.ManualReview,
+			reason:   "Warehouse size increase detected: user warehouse: XSMALL → SMALL",
+		},
+		{
+			name: "size above the environment maximum and unknown size",
+			changes: []WarehouseChange{
+				{FilePath: filePath + " (type: user)", FromSize: "SMALL", ToSize: "MEDIUM"},
+				{FilePath: filePath + " (type: loader)", ToSize: "TINY"},
+			},
+			expected: shared.ManualReview,
+			reason: SizePolicyViolationPrefix + `loader warehouse size "TINY" is not one of XSMALL, SMALL, MEDIUM, LARGE, XLARGE, XXLARGE, X3LARGE, X4LARGE, X5LARGE, X6LARGE; ` +
+				"user warehouse size MEDIUM exceeds the SMALL maximum of the sandbox environment",
+		},
+		{
+			name:     "multi-step decrease",
+			changes:  []WarehouseChange{{FilePath: filePath + " (type: user)", FromSize: "X6LARGE", ToSize: "XSMALL", IsDecrease: true}},
+			expected: shared.ManualReview,
+			reason:   SizePolicyViolationPrefix + "user warehouse jumps 9 sizes from X6LARGE to XSMALL, more than the 2 allowed in one change",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := NewRule(nil, policy)
+			rule.analyzer = &MockAnalyzer{changes: tt.changes}
+			mrCtx := &shared.MRContext{ProjectID: 123, MRIID: 456, Changes: []gitlab.FileChange{{NewPath: filePath}}}
+			mrCtx.ResolveEnvironments()
+			rule.SetMRContext(mrCtx)
+
+			decision, reason := rule.ValidateLines(filePath, "warehouses:", nil)
+			assert.Equal(t, tt.expected, decision)
+			assert.Equal(t, tt.reason, reason)
+			assert.Equal(t, strings.HasPrefix(tt.reason, SizePolicyViolationPrefix), rule.IsEnforced(reason))
+		})
+	}
+}
+
+func TestWarehouseRule_ResizePolicy(t *testing.T) {
+	policy := &SizePolicy{
+		MaxSteps:       2,
+		ResizePolicy:   ResizeReviewAll,
+		ResizePolicies: map[string]string{"dev": ResizeApproveDecreases, "tier:test": ResizeApproveDecreases},
+	}
+	devPath := "dataproducts/source/orders/dev/product.yaml"
+	prodPath := "dataproducts/source/orders/prod/product.yaml"
+
+	tests := []struct {
+		name     string
+		filePath string
+		changes  []WarehouseChange
+		expected shared.DecisionType
+		reason   string
+	}{
+		{
+			name:     "decrease in an approve_decreases environment",
+			filePath: devPath,
+			changes: []WarehouseChange{
+				{FilePath: devPath + " (type: user)", FromSize: "MEDIUM", ToSize: "SMALL", IsDecrease: true},
+				{FilePath: devPath + " (type: loader)", FromSize: "LARGE", ToSize: "MEDIUM", IsDecrease: true},
+			},
+			expected: shared.Approve,
+			reason:   "Warehouse size decrease approved by the approve_decreases resize policy: loader warehouse: LARGE → MEDIUM, user warehouse: MEDIUM → SMALL",
+		},
+		{
+			name:     "no-op change",
+			filePath: devPath,
+			changes:  []WarehouseChange{{FilePath: devPath + " (non-warehouse changes)", FromSize: "N/A", ToSize: "N/A"}},
+			expected: shared.Approve,
+			reason:   "No warehouse size changes detected - approved",
+		},
+		{
+			name:     "increase in an approve_decreases environment",
+			filePath: devPath,
+			changes:  []WarehouseChange{{FilePath: devPath + " (type: user)", FromSize: "SMALL", ToSize: "MEDIUM"}},
+			expected: shared.ManualReview,
+			reason:   "Warehouse size increase detected: user warehouse: SMALL → MEDIUM",
+		},
+		{
+			name:     "decrease with an increase",
+			filePath: devPath,
+			changes: []WarehouseChange{
+				{FilePath: devPath + " (type: user)", FromSize: "MEDIUM", ToSize: "SMALL", IsDecrease: true},
+				{FilePath: devPath + " (type: loader)", FromSize: "SMALL", ToSize: "MEDIUM"},
+			},
+			expected: shared.ManualReview,
+			reason:   "Warehouse changes detected - manual review required: loader warehouse increased: SMALL → MEDIUM, user warehouse decreased: MEDIUM → SMALL",
+		},
+		{
+			name:     "removal in an approve_decreases environment",
+			filePath: devPath,
+			changes:  []WarehouseChange{{FilePath: devPath + " (type: user)", FromSize: "SMALL", IsDecrease: true}},
+			expected: shared.ManualReview,
+			reason:   "Warehouse removal detected: user warehouse removed: was SMALL",
+		},
+		{
+			name:     "multi-step decrease",
+			filePath: devPath,
+			changes:  []WarehouseChange{{FilePath: devPath + " (type: user)", FromSize: "X6LARGE", ToSize: "XSMALL", IsDecrease: true}},
+			expected: shared.ManualReview,
+			reason:   SizePolicyViolationPrefix + "user warehouse jumps 9 sizes from X6LARGE to XSMALL, more than the 2 allowed in one change",
+		},
+		{
+			name:     "decrease in a review_all environment",
+			filePath: prodPath,
+			changes:  []WarehouseChange{{FilePath: prodPath + " (type: user)", FromSize: "MEDIUM", ToSize: "SMALL", IsDecrease: true}},
+			expected: shared.ManualReview,
+			reason:   "Warehouse size decrease detected: user warehouse: MEDIUM → SMALL",
+		},
+		{
+			name:     "decrease of an approve_decreases tier",
+			filePath: prodPath,
+			changes:  []WarehouseChange{{FilePath: prodPath + " (type: user)", FromSize: "MEDIUM", ToSize: "SMALL", IsDecrease: true, Tier: "test"}},
+			expected: shared.Approve,
+			reason:   "Warehouse size decrease approved by the approve_decreases resize policy: user warehouse: MEDIUM → SMALL",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := NewRule(nil, policy)
+			rule.analyzer = &MockAnalyzer{changes: tt.changes}
+			mrCtx := &shared.MRContext{ProjectID: 123, MRIID: 456, Changes: []gitlab.FileChange{{NewPath: tt.filePath}}}
+			mrCtx.ResolveEnvironments()
+			rule.SetMRContext(mrCtx)
+
+			decision, reason := rule.ValidateLines(tt.filePath, "warehouses:", nil)
+			assert.Equal(t, tt.expected, decision)
+			assert.Equal(t, tt.reason, reason)
+		})
+	}
+}
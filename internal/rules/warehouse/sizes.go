@@ -0,0 +1,132 @@
+package warehouse
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"go.uber.org/zap"
+)
+
+// Resize policies deciding warehouse size changes that break no size limit
+const (
+	ResizeReviewAll        = "review_all"        // Every size change requires manual review
+	ResizeApproveDecreases = "approve_decreases" // Size decreases are approved, increases require manual review
+)
+
+// SizePolicy checks warehouse size changes against the allowed sizes, the largest size of
+// each environment and the number of size steps a single change may jump, and decides which
+// changes within those limits are approved
+type SizePolicy struct {
+	MaxSizes       map[string]string // Largest allowed size by lowercased environment
+	MaxSteps       int               // Size steps a change may jump in either direction (0 disables)
+	ResizePolicy   string            // Resize policy of files no entry of ResizePolicies matches
+	ResizePolicies map[string]string // Resize policy by lowercased environment or "tier:<tier>"
+}
+
+// NewSizePolicy creates a size policy from the warehouse rule configuration, skipping
+// environment maximums that are not warehouse sizes and unknown resize policies
+func NewSizePolicy(cfg config.WarehouseRuleConfig) *SizePolicy {
+	maxSizes := make(map[string]string, len(cfg.MaxSizes))
+	for environment, size := range cfg.MaxSizes {
+		if _, ok := WarehouseSizes[size]; !ok {
+			logging.Warn("Ignoring unknown maximum warehouse size of environment",
+				zap.String("size", size),
+				zap.String("environment", environment),
+				zap.Strings("sizes", SizeNames()))
+			continue
+		}
+		maxSizes[environment] = size
+	}
+
+	resizePolicy := cfg.ResizePolicy
+	if !isResizePolicy(resizePolicy) {
+		if resizePolicy != "" {
+			logging.Warn("Unknown warehouse resize policy, using the default",
+				zap.String("policy", resizePolicy),
+				zap.String("default", ResizeReviewAll))
+		}
+		resizePolicy = ResizeReviewAll
+	}
+	resizePolicies := make(map[string]string, len(cfg.ResizePolicies))
+	for selector, policy := range cfg.ResizePolicies {
+		if !isResizePolicy(policy) {
+			logging.Warn("Ignoring unknown warehouse resize policy",
+				zap.String("policy", policy),
+				zap.String("selector", selector))
+			continue
+		}
+		resizePolicies[selector] = policy
+	}
+
+	return &SizePolicy{MaxSizes: maxSizes, MaxSteps: cfg.MaxSizeSteps, ResizePolicy: resizePolicy, ResizePolicies: resizePolicies}
+}
+
+func isResizePolicy(policy string) bool {
+	return policy == ResizeReviewAll || policy == ResizeApproveDecreases
+}
+
+// Resize returns the resize policy of a data product file in environment whose product has
+// tier: the policy of its tier, else of its environment, else the default
+func (p *SizePolicy) Resize(environment, tier string) string {
+	if tier != "" {
+		if policy, ok := p.ResizePolicies["tier:"+strings.ToLower(tier)]; ok {
+			return policy
+		}
+	}
+	if environment != "" {
+		if policy, ok := p.ResizePolicies[strings.ToLower(environment)]; ok {
+			return policy
+		}
+	}
+	if p.ResizePolicy == "" {
+		return ResizeReviewAll
+	}
+	return p.ResizePolicy
+}
+
+// SizeNames returns the allowed warehouse sizes from smallest to largest
+func SizeNames() []string {
+	names := make([]string, 0, len(WarehouseSizes))
+	for name := range WarehouseSizes {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return WarehouseSizes[names[i]] < WarehouseSizes[names[j]]
+	})
+	return names
+}
+
+// Violations returns why a warehouse change of a file in environment breaks the policy:
+// a new size outside the allowed sizes, a new size above the environment maximum, or a
+// jump of more size steps than allowed. Removed warehouses and non-warehouse changes
+// never violate it.
+func (p *SizePolicy) Violations(change WarehouseChange, environment string) []string {
+	toSize := normalizeSize(change.ToSize)
+	if toSize == "" {
+		return nil
+	}
+	_, warehouseType := splitChangePath(change.FilePath)
+
+	toValue, ok := WarehouseSizes[toSize]
+	if !ok {
+		return []string{fmt.Sprintf("%s warehouse size %q is not one of %s", warehouseType, toSize, strings.Join(SizeNames(), ", "))}
+	}
+
+	var violations []string
+	if maxSize, ok := p.MaxSizes[strings.ToLower(environment)]; ok && toValue > WarehouseSizes[maxSize] {
+		violations = append(violations, fmt.Sprintf("%s warehouse size %s exceeds the %s maximum of the %s environment", warehouseType, toSize, maxSize, environment))
+	}
+	if fromValue, ok := WarehouseSizes[normalizeSize(change.FromSize)]; ok && p.MaxSteps > 0 {
+		steps := toValue - fromValue
+		if steps < 0 {
+			steps = -steps
+		}
+		if steps > p.MaxSteps {
+			violations = append(violations, fmt.Sprintf("%s warehouse jumps %d sizes from %s to %s, more than the %d allowed in one change", warehouseType, steps, change.FromSize, toSize, p.MaxSteps))
+		}
+	}
+	return violations
+}
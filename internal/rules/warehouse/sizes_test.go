@@ -0,0 +1,131 @@
+package warehouse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+)
+
+func TestSizeNames(t *testing.T) {
+	assert.Equal(t, []string{"XSMALL", "SMALL", "MEDIUM", "LARGE", "XLARGE", "XXLARGE", "X3LARGE", "X4LARGE", "X5LARGE", "X6LARGE"}, SizeNames())
+}
+
+func TestNewSizePolicy(t *testing.T) {
+	policy := NewSizePolicy(config.WarehouseRuleConfig{
+		MaxSizes:     map[string]string{"sandbox": "SMALL", "dev": "HUGE"},
+		MaxSizeSteps: 3,
+	})
+	assert.Equal(t, map[string]string{"sandbox": "SMALL"}, policy.MaxSizes, "unknown maximums are skipped")
+	assert.Equal(t, 3, policy.MaxSteps)
+	assert.Equal(t, ResizeReviewAll, policy.ResizePolicy)
+
+	policy = NewSizePolicy(config.WarehouseRuleConfig{
+		ResizePolicy:   "approve_all",
+		ResizePolicies: map[string]string{"sandbox": ResizeApproveDecreases, "tier:test": "approve"},
+	})
+	assert.Equal(t, ResizeReviewAll, policy.ResizePolicy, "unknown default falls back to review_all")
+	assert.Equal(t, map[string]string{"sandbox": ResizeApproveDecreases}, policy.ResizePolicies, "unknown policies are skipped")
+}
+
+func TestSizePolicy_Resize(t *testing.T) {
+	policy := &SizePolicy{
+		ResizePolicy: ResizeReviewAll,
+		ResizePolicies: map[string]string{
+			"dev":             ResizeApproveDecreases,
+			"prod":            ResizeReviewAll,
+			"tier:test":       ResizeApproveDecreases,
+			"tier:production": ResizeReviewAll,
+		},
+	}
+	assert.Equal(t, ResizeApproveDecreases, policy.Resize("dev", ""))
+	assert.Equal(t, ResizeApproveDecreases, policy.Resize("DEV", ""))
+	assert.Equal(t, ResizeReviewAll, policy.Resize("preprod", ""))
+	assert.Equal(t, ResizeApproveDecreases, policy.Resize("prod", "Test"), "the tier wins over the environment")
+	assert.Equal(t, ResizeReviewAll, policy.Resize("dev", "production"))
+	assert.Equal(t, ResizeApproveDecreases, policy.Resize("dev", "gold"), "unconfigured tiers use the environment")
+	assert.Equal(t, ResizeReviewAll, policy.Resize("", ""))
+	assert.Equal(t, ResizeReviewAll, (&SizePolicy{}).Resize("dev", ""))
+}
+
+func TestSizePolicy_Violations(t *testing.T) {
+	policy := &SizePolicy{MaxSizes: map[string]string{"sandbox": "SMALL"}, MaxSteps: 2}
+	path := "dataproducts/source/orders/sandbox/product.yaml (type: user)"
+
+	tests := []struct {
+		name        string
+		change      WarehouseChange
+		environment string
+		expected    []string
+	}{
+		{
+			name:        "one step within the maximum",
+			change:      WarehouseChange{FilePath: path, FromSize: "XSMALL", ToSize: "SMALL"},
+			environment: "sandbox",
+		},
+		{
+			name:        "unknown size",
+			change:      WarehouseChange{FilePath: path, FromSize: "XSMALL", ToSize: "HUGE"},
+			environment: "sandbox",
+			expected:    []string{`user warehouse size "HUGE" is not one of XSMALL, SMALL, MEDIUM, LARGE, XLARGE, XXLARGE, X3LARGE, X4LARGE, X5LARGE, X6LARGE`},
+		},
+		{
+			name:        "sizes are case-sensitive",
+			change:      WarehouseChange{FilePath: path, ToSize: "small"},
+			environment: "dev",
+			expected:    []string{`user warehouse size "small" is not one of XSMALL, SMALL, MEDIUM, LARGE, XLARGE, XXLARGE, X3LARGE, X4LARGE, X5LARGE, X6LARGE`},
+		},
+		{
+			name:        "fixing an unknown size",
+			change:      WarehouseChange{FilePath: path, FromSize: "HUGE", ToSize: "SMALL"},
+			environment: "sandbox",
+		},
+		{
+			name:        "new warehouse above the environment maximum",
+			change:      WarehouseChange{FilePath: path, ToSize: "LARGE"},
+			environment: "sandbox",
+			expected:    []string{"user warehouse size LARGE exceeds the SMALL maximum of the sandbox environment"},
+		},
+		{
+			name:        "environment without maximum",
+			change:      WarehouseChange{FilePath: path, ToSize: "X6LARGE"},
+			environment: "prod",
+		},
+		{
+			name:        "multi-step increase",
+			change:      WarehouseChange{FilePath: path, FromSize: "XSMALL", ToSize: "X6LARGE"},
+			environment: "prod",
+			expected:    []string{"user warehouse jumps 9 sizes from XSMALL to X6LARGE, more than the 2 allowed in one change"},
+		},
+		{
+			name:        "multi-step decrease",
+			change:      WarehouseChange{FilePath: path, FromSize: "XLARGE", ToSize: "SMALL", IsDecrease: true},
+			environment: "prod",
+			expected:    []string{"user warehouse jumps 3 sizes from XLARGE to SMALL, more than the 2 allowed in one change"},
+		},
+		{
+			name:        "multi-step increase above the maximum",
+			change:      WarehouseChange{FilePath: path, FromSize: "XSMALL", ToSize: "LARGE"},
+			environment: "sandbox",
+			expected: []string{
+				"user warehouse size LARGE exceeds the SMALL maximum of the sandbox environment",
+				"user warehouse jumps 3 sizes from XSMALL to LARGE, more than the 2 allowed in one change",
+			},
+		},
+		{
+			name:        "removed warehouse",
+			change:      WarehouseChange{FilePath: path, FromSize: "X6LARGE", IsDecrease: true},
+			environment: "sandbox",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, policy.Violations(tt.change, tt.environment))
+		})
+	}
+
+	unlimited := &SizePolicy{}
+	assert.Empty(t, unlimited.Violations(WarehouseChange{FilePath: path, FromSize: "XSMALL", ToSize: "X6LARGE"}, "sandbox"), "zero steps disables the jump check")
+}
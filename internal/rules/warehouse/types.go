@@ -6,6 +6,7 @@ type WarehouseChange struct {
 	FromSize   string
 	ToSize     string
 	IsDecrease bool
+	Tier       string // Tier tag of the data product on the target branch, of the new file otherwise
 }
 
 // ValidationResult represents warehouse validation outcome
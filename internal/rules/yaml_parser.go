@@ -5,28 +5,24 @@ import (
 	"strings"
 
 	"github.com/redhat-data-and-ai/naysayer/internal/config"
-	"github.com/redhat-data-and-ai/naysayer/internal/logging"
 	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
 	"gopkg.in/yaml.v3"
 )
 
 // YAMLSectionParser parses YAML files into logical sections
 type YAMLSectionParser struct {
-	sectionDefinitions map[string]config.SectionDefinition
-	filePath           string
+	sectionParserBase
 }
 
 // NewYAMLSectionParser creates a new YAML section parser
 func NewYAMLSectionParser(definitions map[string]config.SectionDefinition) *YAMLSectionParser {
 	return &YAMLSectionParser{
-		sectionDefinitions: definitions,
+		sectionParserBase: sectionParserBase{sectionDefinitions: definitions},
 	}
 }
 
 // ParseSections extracts sections from YAML content based on definitions
 func (p *YAMLSectionParser) ParseSections(filePath string, content string) ([]shared.Section, error) {
-	p.filePath = filePath
-
 	// Parse YAML with line number tracking
 	var yamlNode yaml.Node
 	if err := yaml.Unmarshal([]byte(content), &yamlNode); err != nil {
@@ -39,7 +35,7 @@ func (p *YAMLSectionParser) ParseSections(filePath string, content string) ([]sh
 	// Extract sections based on definitions
 	for _, definition := range p.sectionDefinitions {
 
-		section, err := p.extractSection(definition, &yamlNode, contentLines)
+		section, err := p.extractSection(definition, filePath, &yamlNode, contentLines)
 		if err != nil {
 			if definition.Required {
 				return nil, fmt.Errorf("required section %s not found: %w", definition.Name, err)
@@ -57,7 +53,7 @@ func (p *YAMLSectionParser) ParseSections(filePath string, content string) ([]sh
 }
 
 // extractSection extracts a specific section from the YAML node
-func (p *YAMLSectionParser) extractSection(definition config.SectionDefinition, rootNode *yaml.Node, contentLines []string) (*shared.Section, error) {
+func (p *YAMLSectionParser) extractSection(definition config.SectionDefinition, filePath string, rootNode *yaml.Node, contentLines []string) (*shared.Section, error) {
 	// Navigate to the YAML path
 	node, err := p.navigateYAMLPath(rootNode, definition.YAMLPath)
 	if err != nil {
@@ -87,7 +83,7 @@ func (p *YAMLSectionParser) extractSection(definition config.SectionDefinition,
 		Content:     sectionContent,
 		Type:        shared.YAMLSection,
 		Fields:      fields,
-		FilePath:    p.filePath,
+		FilePath:    filePath,
 		YAMLPath:    definition.YAMLPath,
 		Required:    definition.Required,
 		RuleConfigs: definition.RuleConfigs,
@@ -195,22 +191,6 @@ func (p *YAMLSectionParser) calculateEndLine(node *yaml.Node) int {
 	return maxLine
 }
 
-// extractSectionContent extracts the text content for a section
-func (p *YAMLSectionParser) extractSectionContent(contentLines []string, startLine, endLine int) string {
-	if startLine < 1 || endLine < startLine || startLine > len(contentLines) {
-		return ""
-	}
-
-	// Adjust for 0-based indexing
-	start := startLine - 1
-	end := endLine
-	if end > len(contentLines) {
-		end = len(contentLines)
-	}
-
-	return strings.Join(contentLines[start:end], "\n")
-}
-
 // parseNodeToMap converts a YAML node to a map[string]interface{}
 func (p *YAMLSectionParser) parseNodeToMap(node *yaml.Node) (map[string]interface{}, error) {
 	var result interface{}
@@ -228,122 +208,3 @@ func (p *YAMLSectionParser) parseNodeToMap(node *yaml.Node) (map[string]interfac
 		"value": result,
 	}, nil
 }
-
-// GetSectionAtLine returns the section that contains the given line number
-func (p *YAMLSectionParser) GetSectionAtLine(sections []shared.Section, lineNumber int) *shared.Section {
-	for i := range sections {
-		section := &sections[i]
-		if lineNumber >= section.StartLine && lineNumber <= section.EndLine {
-			return section
-		}
-	}
-	return nil
-}
-
-// ValidateSection validates a section using the specified rules
-func (p *YAMLSectionParser) ValidateSection(section *shared.Section, rules []shared.Rule) *shared.SectionValidationResult {
-	result := &shared.SectionValidationResult{
-		Section:      section,
-		AppliedRules: make([]string, 0),
-		Decision:     shared.Approve,
-		Reason:       "Section validation passed",
-		Violations:   make([]shared.SectionViolation, 0),
-		RuleResults:  make([]shared.LineValidationResult, 0),
-	}
-
-	// Create line ranges for this section
-	lineRanges := []shared.LineRange{
-		{
-			StartLine: section.StartLine,
-			EndLine:   section.EndLine,
-			FilePath:  section.FilePath,
-		},
-	}
-
-	// Step 1: Run any configured rules first
-	hasRules := len(rules) > 0
-	rulesPassed := true
-	var lastRuleReason string
-
-	if hasRules {
-		// Apply each rule to the section
-		for _, rule := range rules {
-			// Check if this rule applies to this section
-			coveredLines := rule.GetCoveredLines(section.FilePath, section.Content)
-			if len(coveredLines) == 0 {
-				continue // Rule doesn't apply
-			}
-
-			// Validate using the rule
-			decision, reason := rule.ValidateLines(section.FilePath, section.Content, lineRanges)
-
-			result.AppliedRules = append(result.AppliedRules, rule.Name())
-			result.RuleResults = append(result.RuleResults, shared.LineValidationResult{
-				RuleName:     rule.Name(),
-				LineRanges:   lineRanges,
-				Decision:     decision,
-				Reason:       reason,
-				WasEvaluated: true, // Mark that this rule actually executed
-			})
-
-			lastRuleReason = reason
-
-			// If any rule requires manual review, rules failed
-			if decision == shared.ManualReview {
-				rulesPassed = false
-				result.Decision = shared.ManualReview
-				result.Reason = fmt.Sprintf("Rule validation failed: %s", reason)
-				break // Stop on first rule failure
-			}
-		}
-	}
-
-	// Step 2: Apply decision logic - handle definitive cases first
-
-	// Case 1: Rules failed - always manual review regardless of auto-approve setting
-	if hasRules && !rulesPassed {
-		result.Decision = shared.ManualReview
-		// Reason already set above when rules failed
-		if section.AutoApprove {
-			logging.Info("AUTO_APPROVE_AUDIT: Section '%s' at %s:%d-%d failed auto-approve (rules failed: %s)",
-				section.Name, section.FilePath, section.StartLine, section.EndLine, result.Reason)
-		}
-		return result
-	}
-
-	// Case 2: Auto-approve enabled - approve if rules passed or no rules
-	if section.AutoApprove {
-		result.Decision = shared.Approve
-
-		if !hasRules {
-			result.Reason = fmt.Sprintf("Auto-approved: %s (no validation required)", section.Name)
-			logging.Info("AUTO_APPROVE_AUDIT: Section '%s' at %s:%d-%d auto-approved (no rules required)",
-				section.Name, section.FilePath, section.StartLine, section.EndLine)
-		} else if len(result.AppliedRules) == 0 {
-			result.Reason = fmt.Sprintf("Auto-approved: %s (no applicable rules)", section.Name)
-			logging.Info("AUTO_APPROVE_AUDIT: Section '%s' at %s:%d-%d auto-approved (no applicable rules)",
-				section.Name, section.FilePath, section.StartLine, section.EndLine)
-		} else {
-			result.Reason = fmt.Sprintf("Auto-approved: %s (validation passed)", lastRuleReason)
-			logging.Info("AUTO_APPROVE_AUDIT: Section '%s' at %s:%d-%d auto-approved (rules: %v passed)",
-				section.Name, section.FilePath, section.StartLine, section.EndLine, result.AppliedRules)
-		}
-		return result
-	}
-
-	// Case 3: Not auto-approve - normal approval process
-	if !hasRules || len(result.AppliedRules) == 0 {
-		result.Decision = shared.ManualReview
-		result.Reason = fmt.Sprintf("No validation rules configured for %s - manual review required", section.Name)
-	} else if rulesPassed {
-		result.Decision = shared.Approve
-		result.Reason = lastRuleReason
-	}
-
-	return result
-}
-
-// GetSectionDefinitions returns the section definitions for this parser
-func (p *YAMLSectionParser) GetSectionDefinitions() map[string]config.SectionDefinition {
-	return p.sectionDefinitions
-}
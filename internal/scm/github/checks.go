@@ -0,0 +1,75 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"go.uber.org/zap"
+)
+
+// pipeline summarizes the commit statuses and check runs of a commit as a GitLab
+// pipeline status: "running" while any is pending, "failed" when any failed, otherwise
+// "success". It returns nil when the commit has no CI results.
+func (c *Client) pipeline(ctx context.Context, repoID int, sha string) *gitlab.MRPipeline {
+	var statuses struct {
+		State      string `json:"state"` // success, pending, failure, error
+		TotalCount int    `json:"total_count"`
+	}
+	var checks struct {
+		TotalCount int `json:"total_count"`
+		CheckRuns  []struct {
+			Status     string `json:"status"`     // queued, in_progress, completed
+			Conclusion string `json:"conclusion"` // success, failure, neutral, cancelled, skipped, timed_out, action_required
+		} `json:"check_runs"`
+	}
+	if _, err := c.getJSON(ctx, c.repoURL(repoID, "/commits/%s/status", sha), &statuses); err != nil {
+		logging.Warn("Failed to get commit status", zap.String("sha", sha), zap.Error(err))
+		return nil
+	}
+	if _, err := c.getJSON(ctx, c.repoURL(repoID, "/commits/%s/check-runs?per_page=100", sha), &checks); err != nil {
+		logging.Warn("Failed to get check runs", zap.String("sha", sha), zap.Error(err))
+		return nil
+	}
+	if statuses.TotalCount == 0 && checks.TotalCount == 0 {
+		return nil
+	}
+
+	running := statuses.TotalCount > 0 && statuses.State == "pending"
+	failed := statuses.State == "failure" || statuses.State == "error"
+	for _, run := range checks.CheckRuns {
+		switch {
+		case run.Status != "completed":
+			running = true
+		case run.Conclusion != "success" && run.Conclusion != "neutral" && run.Conclusion != "skipped":
+			failed = true
+		}
+	}
+
+	switch {
+	case running:
+		return &gitlab.MRPipeline{Status: "running"}
+	case failed:
+		return &gitlab.MRPipeline{Status: "failed"}
+	default:
+		return &gitlab.MRPipeline{Status: "success"}
+	}
+}
+
+// GetPipelineJobs is not available on GitHub: pipeline statuses are derived from commit
+// statuses and check runs, which have no job listing
+func (c *Client) GetPipelineJobs(ctx context.Context, repoID, pipelineID int) ([]gitlab.PipelineJob, error) {
+	return []gitlab.PipelineJob{}, nil
+}
+
+// GetJobTrace is not available on GitHub
+func (c *Client) GetJobTrace(ctx context.Context, repoID, jobID int) (string, error) {
+	return "", fmt.Errorf("job traces are not available on GitHub")
+}
+
+// AreAllPipelineJobsSucceeded reports false: a failed GitHub pipeline always has a
+// failed status or check run
+func (c *Client) AreAllPipelineJobsSucceeded(ctx context.Context, repoID, pipelineID int) (bool, error) {
+	return false, nil
+}
@@ -0,0 +1,63 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+)
+
+func testConfig() config.GitHubConfig {
+	return config.GitHubConfig{BaseURL: "https://github.example.com/api/v3", Token: "test-token"}
+}
+
+func TestClient_Pipeline(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name     string
+		status   string
+		checks   string
+		expected string
+	}{
+		{"no CI", `{"state": "pending", "total_count": 0}`, `{"total_count": 0}`, ""},
+		{"pending status", `{"state": "pending", "total_count": 1}`, `{"total_count": 0}`, "running"},
+		{"queued check run", `{"state": "success", "total_count": 1}`, `{"total_count": 1, "check_runs": [{"status": "queued"}]}`, "running"},
+		{"failed check run", `{"state": "pending", "total_count": 0}`, `{"total_count": 2, "check_runs": [{"status": "completed", "conclusion": "success"}, {"status": "completed", "conclusion": "failure"}]}`, "failed"},
+		{"failed status", `{"state": "error", "total_count": 1}`, `{"total_count": 0}`, "failed"},
+		{"all passed", `{"state": "success", "total_count": 1}`, `{"total_count": 1, "check_runs": [{"status": "completed", "conclusion": "skipped"}]}`, "success"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, map[string]http.HandlerFunc{
+				"GET /repositories/1/commits/abc/status":     respond(http.StatusOK, tt.status),
+				"GET /repositories/1/commits/abc/check-runs": respond(http.StatusOK, tt.checks),
+			})
+
+			pipeline := client.pipeline(ctx, 1, "abc")
+			if tt.expected == "" {
+				assert.Nil(t, pipeline)
+				return
+			}
+			assert.Equal(t, tt.expected, pipeline.Status)
+		})
+	}
+}
+
+func TestClient_PipelineJobsUnavailable(t *testing.T) {
+	ctx := context.Background()
+	client := NewClient(testConfig())
+	jobs, err := client.GetPipelineJobs(ctx, 1, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, jobs)
+
+	succeeded, err := client.AreAllPipelineJobsSucceeded(ctx, 1, 0)
+	assert.NoError(t, err)
+	assert.False(t, succeeded)
+
+	_, err = client.GetJobTrace(ctx, 1, 0)
+	assert.Error(t, err)
+}
@@ -0,0 +1,143 @@
+// Package github implements the naysayer SCM client interface (gitlab.GitLabClient) on
+// top of the GitHub REST API, so GitHub (Enterprise) pull requests are reviewed and
+// rebased by the same handlers as GitLab merge requests.
+//
+// Repositories are addressed by their numeric ID (/repositories/:id), which plays the role
+// of the GitLab project ID; pull request numbers play the role of MR IIDs.
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+)
+
+// Verify that Client implements the SCM client interface
+var _ gitlab.GitLabClient = (*Client)(nil)
+
+// maxPages bounds paginated listings (100 items per page)
+const maxPages = 20
+
+// Client is a GitHub REST API client
+type Client struct {
+	config config.GitHubConfig
+	http   *http.Client
+
+	mu          sync.Mutex
+	botUsername string // Cached login of the token owner
+}
+
+// NewClient creates a GitHub API client
+func NewClient(cfg config.GitHubConfig) *Client {
+	return &Client{
+		config: cfg,
+		http:   &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// repoURL builds an API URL below a repository
+func (c *Client) repoURL(repoID int, format string, args ...interface{}) string {
+	return fmt.Sprintf("%s/repositories/%d", strings.TrimRight(c.config.BaseURL, "/"), repoID) +
+		fmt.Sprintf(format, args...)
+}
+
+// request sends an authenticated request with an optional JSON body
+func (c *Client) request(ctx context.Context, method, url string, body interface{}) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request: %w", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	req.Header.Set("Authorization", "Bearer "+c.config.Token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return c.http.Do(req)
+}
+
+// getJSON fetches url into out, returning an API error for non-200 responses
+func (c *Client) getJSON(ctx context.Context, url string, out interface{}) (*http.Response, error) {
+	resp, err := c.request(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return resp, newAPIError(resp, "GitHub API error %d: %s", resp.StatusCode, string(body))
+	}
+	return resp, json.NewDecoder(resp.Body).Decode(out)
+}
+
+// getPages fetches every page of a listing, calling add with each decoded page
+func (c *Client) getPages(ctx context.Context, url string, add func(page json.RawMessage) error) error {
+	if strings.Contains(url, "?") {
+		url += "&per_page=100"
+	} else {
+		url += "?per_page=100"
+	}
+	for pages := 0; url != "" && pages < maxPages; pages++ {
+		var page json.RawMessage
+		resp, err := c.getJSON(ctx, url, &page)
+		if err != nil {
+			return err
+		}
+		if err := add(page); err != nil {
+			return err
+		}
+		url = nextLink(resp.Header.Get("Link"))
+	}
+	return nil
+}
+
+// nextLink extracts the rel="next" URL of a Link header
+func nextLink(header string) string {
+	for _, link := range strings.Split(header, ",") {
+		link = strings.TrimSpace(link)
+		if !strings.Contains(link, `rel="next"`) {
+			continue
+		}
+		start, end := strings.Index(link, "<"), strings.Index(link, ">")
+		if start != -1 && end > start {
+			return link[start+1 : end]
+		}
+	}
+	return ""
+}
+
+// newAPIError builds a typed error matching the gitlab sentinel errors
+func newAPIError(resp *http.Response, format string, args ...interface{}) error {
+	return &gitlab.APIError{StatusCode: resp.StatusCode, Message: fmt.Sprintf(format, args...)}
+}
+
+// expectStatus turns responses with another status into API errors
+func expectStatus(resp *http.Response, operation string, statuses ...int) error {
+	defer func() { _ = resp.Body.Close() }()
+	for _, status := range statuses {
+		if resp.StatusCode == status {
+			return nil
+		}
+	}
+	body, _ := io.ReadAll(resp.Body)
+	return newAPIError(resp, "%s failed with status %d: %s", operation, resp.StatusCode, string(body))
+}
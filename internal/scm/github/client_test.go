@@ -0,0 +1,64 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+)
+
+// newTestClient serves GitHub API routes from handlers keyed by "METHOD path"
+func newTestClient(t *testing.T, routes map[string]http.HandlerFunc) *Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+		handler, ok := routes[r.Method+" "+r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+			return
+		}
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return NewClient(config.GitHubConfig{BaseURL: server.URL + "/", Token: "test-token"})
+}
+
+// respond writes a JSON body with a status
+func respond(status int, body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}
+}
+
+func TestNextLink(t *testing.T) {
+	header := `<https://api.github.com/repositories/1/pulls?page=2>; rel="next", <https://api.github.com/repositories/1/pulls?page=5>; rel="last"`
+	assert.Equal(t, "https://api.github.com/repositories/1/pulls?page=2", nextLink(header))
+	assert.Empty(t, nextLink(`<https://api.github.com/repositories/1/pulls?page=1>; rel="prev"`))
+	assert.Empty(t, nextLink(""))
+}
+
+func TestClient_GetPages(t *testing.T) {
+	ctx := context.Background()
+	var client *Client
+	client = newTestClient(t, map[string]http.HandlerFunc{
+		"GET /repositories/1/pulls": func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "100", r.URL.Query().Get("per_page"))
+			if r.URL.Query().Get("page") == "2" {
+				_, _ = w.Write([]byte(`[{"number": 2}]`))
+				return
+			}
+			w.Header().Set("Link", `<`+client.repoURL(1, "/pulls?state=open&per_page=100&page=2")+`>; rel="next"`)
+			_, _ = w.Write([]byte(`[{"number": 1}]`))
+		},
+	})
+
+	numbers, err := client.ListOpenMRs(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, numbers)
+}
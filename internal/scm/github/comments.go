@@ -0,0 +1,161 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+)
+
+// issueComment is a pull request conversation comment
+type issueComment struct {
+	ID        int    `json:"id"`
+	Body      string `json:"body"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+	User      User   `json:"user"`
+}
+
+// authorMap describes a GitHub user like a GitLab comment author
+func authorMap(user User) map[string]interface{} {
+	return map[string]interface{}{
+		"id":       user.ID,
+		"username": user.Login,
+		"name":     user.Login,
+		"bot":      user.Type == "Bot",
+	}
+}
+
+// ListMRComments lists the conversation comments of a pull request, newest first like
+// the GitLab client
+func (c *Client) ListMRComments(ctx context.Context, repoID, number int) ([]gitlab.MRComment, error) {
+	comments := make([]gitlab.MRComment, 0)
+	err := c.getPages(ctx, c.repoURL(repoID, "/issues/%d/comments", number), func(page json.RawMessage) error {
+		var batch []issueComment
+		if err := json.Unmarshal(page, &batch); err != nil {
+			return err
+		}
+		for _, comment := range batch {
+			comments = append(comments, gitlab.MRComment{
+				ID:        comment.ID,
+				Body:      comment.Body,
+				CreatedAt: comment.CreatedAt,
+				UpdatedAt: comment.UpdatedAt,
+				Author:    authorMap(comment.User),
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// GitHub lists comments oldest first
+	for i, j := 0, len(comments)-1; i < j; i, j = i+1, j-1 {
+		comments[i], comments[j] = comments[j], comments[i]
+	}
+	return comments, nil
+}
+
+// AddMRComment adds a conversation comment to a pull request
+func (c *Client) AddMRComment(ctx context.Context, repoID, number int, comment string) error {
+	resp, err := c.request(ctx, "POST", c.repoURL(repoID, "/issues/%d/comments", number), map[string]string{"body": comment})
+	if err != nil {
+		return fmt.Errorf("failed to add comment: %w", err)
+	}
+	return expectStatus(resp, "add comment", http.StatusCreated)
+}
+
+// UpdateMRComment edits a conversation comment
+func (c *Client) UpdateMRComment(ctx context.Context, repoID, number, commentID int, newBody string) error {
+	resp, err := c.request(ctx, "PATCH", c.repoURL(repoID, "/issues/comments/%d", commentID), map[string]string{"body": newBody})
+	if err != nil {
+		return fmt.Errorf("failed to update comment: %w", err)
+	}
+	return expectStatus(resp, "update comment", http.StatusOK)
+}
+
+// ReplyToMRComment quotes the comment in a new comment, as GitHub conversation comments
+// have no threads
+func (c *Client) ReplyToMRComment(ctx context.Context, repoID, number, commentID int, body string) error {
+	return c.AddMRComment(ctx, repoID, number, body)
+}
+
+// FindLatestNaysayerComment returns the newest comment of the naysayer bot, optionally of
+// one comment type
+func (c *Client) FindLatestNaysayerComment(ctx context.Context, repoID, number int, commentType ...string) (*gitlab.MRComment, error) {
+	comments, err := c.ListMRComments(ctx, repoID, number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list comments: %w", err)
+	}
+
+	currentBotUsername, _ := c.GetCurrentBotUsername(ctx)
+	filterByType := len(commentType) > 0 && commentType[0] != ""
+	for _, comment := range comments {
+		if c.isOurBot(ctx, comment.Author, currentBotUsername) &&
+			(!filterByType || gitlab.MatchesCommentType(comment.Body, commentType[0])) {
+			return &comment, nil
+		}
+	}
+	return nil, nil
+}
+
+// AddOrUpdateMRComment edits the newest naysayer comment of the type or adds a new one
+func (c *Client) AddOrUpdateMRComment(ctx context.Context, repoID, number int, commentBody, commentType string) error {
+	existing, err := c.FindLatestNaysayerComment(ctx, repoID, number, commentType)
+	if err != nil {
+		return fmt.Errorf("failed to search for existing comment: %w", err)
+	}
+	if existing == nil {
+		return c.AddMRComment(ctx, repoID, number, commentBody)
+	}
+	if err := c.UpdateMRComment(ctx, repoID, number, existing.ID, commentBody); err != nil {
+		if errors.Is(err, gitlab.ErrPermission) {
+			return c.AddMRComment(ctx, repoID, number, commentBody)
+		}
+		return err
+	}
+	return nil
+}
+
+// FindCommentByPattern checks if a comment containing pattern exists on a pull request
+func (c *Client) FindCommentByPattern(ctx context.Context, repoID, number int, pattern string) (bool, error) {
+	comments, err := c.ListMRComments(ctx, repoID, number)
+	if err != nil {
+		return false, fmt.Errorf("failed to list comments: %w", err)
+	}
+	for _, comment := range comments {
+		if strings.Contains(comment.Body, pattern) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// FindLatestAtlantisComment finds the newest comment from atlantis
+func (c *Client) FindLatestAtlantisComment(ctx context.Context, repoID, number int) (*gitlab.MRComment, error) {
+	comments, err := c.ListMRComments(ctx, repoID, number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list comments: %w", err)
+	}
+	for _, comment := range comments {
+		if gitlab.IsAtlantisBotAuthor(comment.Author) {
+			return &comment, nil
+		}
+	}
+	return nil, nil
+}
+
+// CheckAtlantisCommentForPlanFailures classifies the newest atlantis comment like the
+// GitLab client
+func (c *Client) CheckAtlantisCommentForPlanFailures(ctx context.Context, repoID, number int) (bool, string) {
+	comment, err := c.FindLatestAtlantisComment(ctx, repoID, number)
+	if err != nil {
+		return false, ""
+	}
+	return gitlab.AtlantisPlanFailure(comment)
+}
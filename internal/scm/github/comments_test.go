@@ -0,0 +1,82 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const commentsJSON = `[
+	{"id": 1, "body": "<!-- naysayer-comment-id: approval -->\nold", "user": {"login": "naysayer-bot"}},
+	{"id": 2, "body": "Error: Error acquiring the state lock", "user": {"login": "atlantis-bot", "type": "Bot"}},
+	{"id": 3, "body": "<!-- naysayer-comment-id: approval -->\nnew", "user": {"login": "naysayer-bot"}},
+	{"id": 4, "body": "lgtm", "user": {"login": "dev"}}
+]`
+
+func TestClient_ListMRComments_NewestFirst(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t, map[string]http.HandlerFunc{
+		"GET /repositories/1/issues/7/comments": respond(http.StatusOK, commentsJSON),
+	})
+
+	comments, err := client.ListMRComments(ctx, 1, 7)
+	assert.NoError(t, err)
+	assert.Len(t, comments, 4)
+	assert.Equal(t, 4, comments[0].ID)
+	assert.Equal(t, "dev", comments[0].Author["username"])
+	assert.Equal(t, true, comments[2].Author["bot"])
+}
+
+func TestClient_AddOrUpdateMRComment(t *testing.T) {
+	ctx := context.Background()
+	var updated map[string]string
+	client := newTestClient(t, map[string]http.HandlerFunc{
+		"GET /repositories/1/issues/7/comments": respond(http.StatusOK, commentsJSON),
+		"GET /user":                             respond(http.StatusOK, `{"login": "naysayer-bot"}`),
+		"PATCH /repositories/1/issues/comments/3": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewDecoder(r.Body).Decode(&updated)
+			respond(http.StatusOK, `{}`)(w, r)
+		},
+	})
+
+	err := client.AddOrUpdateMRComment(ctx, 1, 7, "<!-- naysayer-comment-id: approval -->\nupdated", "approval")
+	assert.NoError(t, err)
+	assert.Contains(t, updated["body"], "updated")
+}
+
+func TestClient_AddMRComment(t *testing.T) {
+	ctx := context.Background()
+	var added map[string]string
+	client := newTestClient(t, map[string]http.HandlerFunc{
+		"POST /repositories/1/issues/7/comments": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewDecoder(r.Body).Decode(&added)
+			respond(http.StatusCreated, `{"id": 5}`)(w, r)
+		},
+	})
+
+	assert.NoError(t, client.AddMRComment(ctx, 1, 7, "hello"))
+	assert.Equal(t, "hello", added["body"])
+	assert.Error(t, client.AddMRComment(ctx, 1, 8, "hello"))
+}
+
+func TestClient_AtlantisComments(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t, map[string]http.HandlerFunc{
+		"GET /repositories/1/issues/7/comments": respond(http.StatusOK, commentsJSON),
+	})
+
+	comment, err := client.FindLatestAtlantisComment(ctx, 1, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, comment.ID)
+
+	skip, reason := client.CheckAtlantisCommentForPlanFailures(ctx, 1, 7)
+	assert.False(t, skip)
+	assert.Equal(t, "atlantis_plan_locked", reason)
+
+	found, err := client.FindCommentByPattern(ctx, 1, 7, "lgtm")
+	assert.NoError(t, err)
+	assert.True(t, found)
+}
@@ -0,0 +1,90 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+)
+
+// comparison is the GitHub compare API response
+type comparison struct {
+	Commits []struct {
+		SHA    string `json:"sha"`
+		Commit struct {
+			Message string `json:"message"`
+			Author  struct {
+				Name  string `json:"name"`
+				Email string `json:"email"`
+				Date  string `json:"date"`
+			} `json:"author"`
+		} `json:"commit"`
+	} `json:"commits"`
+	Files []changedFile `json:"files"`
+}
+
+// compare returns the commits and files on head that base does not have
+func (c *Client) compare(ctx context.Context, repoID int, base, head string) (*gitlab.CompareResult, error) {
+	var cmp comparison
+	compareURL := c.repoURL(repoID, "/compare/%s...%s", url.PathEscape(base), url.PathEscape(head))
+	if _, err := c.getJSON(ctx, compareURL, &cmp); err != nil {
+		return nil, err
+	}
+
+	result := &gitlab.CompareResult{
+		Commits: make([]gitlab.CompareCommit, 0, len(cmp.Commits)),
+		Diffs:   make([]gitlab.FileChange, 0, len(cmp.Files)),
+	}
+	for _, commit := range cmp.Commits {
+		shortID := commit.SHA
+		if len(shortID) > 8 {
+			shortID = shortID[:8]
+		}
+		title, _, _ := strings.Cut(commit.Commit.Message, "\n")
+		result.Commits = append(result.Commits, gitlab.CompareCommit{
+			ID:            commit.SHA,
+			ShortID:       shortID,
+			Title:         title,
+			AuthorName:    commit.Commit.Author.Name,
+			AuthorEmail:   commit.Commit.Author.Email,
+			CommittedDate: commit.Commit.Author.Date,
+			Message:       commit.Commit.Message,
+		})
+	}
+	for _, file := range cmp.Files {
+		result.Diffs = append(result.Diffs, file.toFileChange())
+	}
+	return result, nil
+}
+
+// CompareBranches lists the commits on targetBranch missing from sourceBranch, like the
+// GitLab client's compare from=source to=target. Fork pull requests use CompareCommits.
+func (c *Client) CompareBranches(ctx context.Context, sourceRepoID int, sourceBranch string, targetRepoID int, targetBranch string) (*gitlab.CompareResult, error) {
+	if sourceRepoID != targetRepoID {
+		return nil, fmt.Errorf("CompareBranches does not support cross-repository: use CompareCommits with MR.Sha for fork pull requests")
+	}
+	return c.compare(ctx, targetRepoID, sourceBranch, targetBranch)
+}
+
+// CompareCommits lists the commits of toSHA missing from fromSHA
+func (c *Client) CompareCommits(ctx context.Context, repoID int, fromSHA, toSHA string) (*gitlab.CompareResult, error) {
+	return c.compare(ctx, repoID, fromSHA, toSHA)
+}
+
+// GetBranchCommit returns the SHA of a branch head
+func (c *Client) GetBranchCommit(ctx context.Context, repoID int, branch string) (string, error) {
+	var b struct {
+		Commit struct {
+			SHA string `json:"sha"`
+		} `json:"commit"`
+	}
+	if _, err := c.getJSON(ctx, c.repoURL(repoID, "/branches/%s", url.PathEscape(branch)), &b); err != nil {
+		return "", err
+	}
+	if b.Commit.SHA == "" {
+		return "", fmt.Errorf("branch %s has no commit", branch)
+	}
+	return b.Commit.SHA, nil
+}
@@ -0,0 +1,43 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_CompareBranches(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t, map[string]http.HandlerFunc{
+		"GET /repositories/1/compare/feature/x...main": respond(http.StatusOK, `{
+			"commits": [{"sha": "0123456789abcdef", "commit": {"message": "Fix warehouse\n\nDetails", "author": {"name": "Dev", "email": "dev@example.com"}}}],
+			"files": [{"filename": "product.yaml", "status": "modified"}]
+		}`),
+	})
+
+	result, err := client.CompareBranches(ctx, 1, "feature/x", 1, "main")
+	assert.NoError(t, err)
+	assert.Len(t, result.Commits, 1)
+	assert.Equal(t, "01234567", result.Commits[0].ShortID)
+	assert.Equal(t, "Fix warehouse", result.Commits[0].Title)
+	assert.Equal(t, "product.yaml", result.Diffs[0].NewPath)
+
+	_, err = client.CompareBranches(ctx, 2, "feature/x", 1, "main")
+	assert.Error(t, err, "cross-repository compares need CompareCommits")
+}
+
+func TestClient_GetBranchCommit(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t, map[string]http.HandlerFunc{
+		"GET /repositories/1/branches/main": respond(http.StatusOK, `{"name": "main", "commit": {"sha": "abc123"}}`),
+	})
+
+	sha, err := client.GetBranchCommit(ctx, 1, "main")
+	assert.NoError(t, err)
+	assert.Equal(t, "abc123", sha)
+
+	_, err = client.GetBranchCommit(ctx, 1, "missing")
+	assert.Error(t, err)
+}
@@ -0,0 +1,79 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+)
+
+// changedFile is an entry of the pull request files listing
+type changedFile struct {
+	Filename         string `json:"filename"`
+	PreviousFilename string `json:"previous_filename"`
+	Status           string `json:"status"` // added, removed, modified, renamed, copied, changed, unchanged
+	Patch            string `json:"patch"`
+}
+
+// toFileChange maps a changed file to a GitLab file change
+func (f changedFile) toFileChange() gitlab.FileChange {
+	oldPath := f.Filename
+	if f.PreviousFilename != "" {
+		oldPath = f.PreviousFilename
+	}
+	return gitlab.FileChange{
+		OldPath:     oldPath,
+		NewPath:     f.Filename,
+		NewFile:     f.Status == "added",
+		DeletedFile: f.Status == "removed",
+		RenamedFile: f.Status == "renamed",
+		Diff:        f.Patch,
+	}
+}
+
+// escapePath escapes each segment of a repository path
+func escapePath(path string) string {
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// FetchFileContent fetches a file at a branch or commit
+func (c *Client) FetchFileContent(ctx context.Context, repoID int, filePath, ref string) (*gitlab.FileContent, error) {
+	var file struct {
+		Name     string `json:"name"`
+		Path     string `json:"path"`
+		SHA      string `json:"sha"`
+		Size     int    `json:"size"`
+		Encoding string `json:"encoding"`
+		Content  string `json:"content"`
+	}
+	fileURL := c.repoURL(repoID, "/contents/%s?ref=%s", escapePath(filePath), url.QueryEscape(ref))
+	if _, err := c.getJSON(ctx, fileURL, &file); err != nil {
+		return nil, err
+	}
+
+	content := file.Content
+	if file.Encoding == "base64" {
+		decoded, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(content, "\n", ""))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode base64 content: %v", err)
+		}
+		content = string(decoded)
+	}
+
+	return &gitlab.FileContent{
+		FileName: file.Name,
+		FilePath: file.Path,
+		Size:     file.Size,
+		Encoding: file.Encoding,
+		Content:  content,
+		Ref:      ref,
+		BlobID:   file.SHA,
+	}, nil
+}
@@ -0,0 +1,30 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_FetchFileContent(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t, map[string]http.HandlerFunc{
+		"GET /repositories/1/contents/dataproducts/my product/product.yaml": func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "feature/x", r.URL.Query().Get("ref"))
+			respond(http.StatusOK, `{"name": "product.yaml", "path": "dataproducts/my product/product.yaml",
+				"sha": "blob1", "size": 12, "encoding": "base64", "content": "bmFtZTogYW5h\nbHl0aWNz\n"}`)(w, r)
+		},
+	})
+
+	file, err := client.FetchFileContent(ctx, 1, "dataproducts/my product/product.yaml", "feature/x")
+	assert.NoError(t, err)
+	assert.Equal(t, "name: analytics", file.Content)
+	assert.Equal(t, "product.yaml", file.FileName)
+	assert.Equal(t, "blob1", file.BlobID)
+	assert.Equal(t, "feature/x", file.Ref)
+
+	_, err = client.FetchFileContent(ctx, 1, "missing.yaml", "main")
+	assert.Error(t, err)
+}
@@ -0,0 +1,249 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/utils"
+)
+
+// rebaseWindow matches the GitLab client: only pull requests created in the last 7 days
+// are considered for rebasing
+const rebaseWindow = 7 * 24 * time.Hour
+
+// User is a GitHub account
+type User struct {
+	ID    int    `json:"id"`
+	Login string `json:"login"`
+	Type  string `json:"type"` // "User" or "Bot"
+}
+
+// Ref is the head or base of a pull request
+type Ref struct {
+	Ref  string `json:"ref"`
+	SHA  string `json:"sha"`
+	Repo *struct {
+		ID int `json:"id"`
+	} `json:"repo"` // nil when a fork was deleted
+}
+
+// PullRequest is a GitHub pull request
+type PullRequest struct {
+	Number         int     `json:"number"`
+	Title          string  `json:"title"`
+	Body           string  `json:"body"`
+	State          string  `json:"state"` // "open" or "closed"
+	Draft          bool    `json:"draft"`
+	MergedAt       *string `json:"merged_at"`
+	CreatedAt      string  `json:"created_at"`
+	UpdatedAt      string  `json:"updated_at"`
+	Head           Ref     `json:"head"`
+	Base           Ref     `json:"base"`
+	User           User    `json:"user"`
+	Mergeable      *bool   `json:"mergeable"`
+	MergeableState string  `json:"mergeable_state"` // "clean", "dirty" (conflicts), "behind", "blocked", ...
+	Labels         []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+}
+
+// MRState maps the pull request state to the GitLab MR state
+func (pr *PullRequest) MRState() string {
+	switch {
+	case pr.State == "open":
+		return utils.MRStateOpened
+	case pr.MergedAt != nil:
+		return "merged"
+	default:
+		return "closed"
+	}
+}
+
+// MRTitle returns the title with a "Draft:" prefix for draft pull requests, which
+// naysayer recognizes as drafts like GitLab draft MRs
+func (pr *PullRequest) MRTitle() string {
+	if pr.Draft {
+		return "Draft: " + pr.Title
+	}
+	return pr.Title
+}
+
+// toMRDetails maps a pull request to GitLab MR details
+func (pr *PullRequest) toMRDetails(repoID int) gitlab.MRDetails {
+	details := gitlab.MRDetails{
+		TargetBranch:    pr.Base.Ref,
+		SourceBranch:    pr.Head.Ref,
+		Sha:             pr.Head.SHA,
+		IID:             pr.Number,
+		Title:           pr.MRTitle(),
+		State:           pr.MRState(),
+		ProjectID:       repoID,
+		TargetProjectID: repoID,
+		CreatedAt:       pr.CreatedAt,
+		UpdatedAt:       pr.UpdatedAt,
+		HasConflicts:    pr.MergeableState == "dirty",
+		MergeStatus:     "checking",
+		Author:          &gitlab.MRUser{ID: pr.User.ID, Username: pr.User.Login},
+		Labels:          make([]string, 0, len(pr.Labels)),
+	}
+	if pr.Head.Repo != nil {
+		details.SourceProjectID = pr.Head.Repo.ID
+	}
+	if pr.Mergeable != nil {
+		details.MergeStatus = "cannot_be_merged"
+		if *pr.Mergeable {
+			details.MergeStatus = "can_be_merged"
+		}
+	}
+	for _, label := range pr.Labels {
+		details.Labels = append(details.Labels, label.Name)
+	}
+	return details
+}
+
+// getPullRequest fetches a pull request
+func (c *Client) getPullRequest(ctx context.Context, repoID, number int) (*PullRequest, error) {
+	var pr PullRequest
+	if _, err := c.getJSON(ctx, c.repoURL(repoID, "/pulls/%d", number), &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// listOpenPullRequests fetches every open pull request of a repository
+func (c *Client) listOpenPullRequests(ctx context.Context, repoID int) ([]PullRequest, error) {
+	prs := make([]PullRequest, 0)
+	err := c.getPages(ctx, c.repoURL(repoID, "/pulls?state=open"), func(page json.RawMessage) error {
+		var batch []PullRequest
+		if err := json.Unmarshal(page, &batch); err != nil {
+			return err
+		}
+		prs = append(prs, batch...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list open pull requests: %w", err)
+	}
+	return prs, nil
+}
+
+// GetMRDetails fetches pull request details
+func (c *Client) GetMRDetails(ctx context.Context, repoID, number int) (*gitlab.MRDetails, error) {
+	pr, err := c.getPullRequest(ctx, repoID, number)
+	if err != nil {
+		return nil, err
+	}
+	details := pr.toMRDetails(repoID)
+	return &details, nil
+}
+
+// GetMRTargetBranch fetches the base branch of a pull request
+func (c *Client) GetMRTargetBranch(ctx context.Context, repoID, number int) (string, error) {
+	pr, err := c.getPullRequest(ctx, repoID, number)
+	if err != nil {
+		return "", err
+	}
+	return pr.Base.Ref, nil
+}
+
+// FetchMRChanges fetches the files changed by a pull request
+func (c *Client) FetchMRChanges(ctx context.Context, repoID, number int) ([]gitlab.FileChange, error) {
+	changes := make([]gitlab.FileChange, 0)
+	err := c.getPages(ctx, c.repoURL(repoID, "/pulls/%d/files", number), func(page json.RawMessage) error {
+		var files []changedFile
+		if err := json.Unmarshal(page, &files); err != nil {
+			return err
+		}
+		for _, file := range files {
+			changes = append(changes, file.toFileChange())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// ListOpenMRs lists the numbers of open pull requests
+func (c *Client) ListOpenMRs(ctx context.Context, repoID int) ([]int, error) {
+	prs, err := c.listOpenPullRequests(ctx, repoID)
+	if err != nil {
+		return nil, err
+	}
+	numbers := make([]int, 0, len(prs))
+	for _, pr := range prs {
+		numbers = append(numbers, pr.Number)
+	}
+	return numbers, nil
+}
+
+// ListOpenMRsWithDetails lists open pull requests created in the last 7 days with their
+// CI status, for auto-rebase
+func (c *Client) ListOpenMRsWithDetails(ctx context.Context, repoID int) ([]gitlab.MRDetails, error) {
+	prs, err := c.listOpenPullRequests(ctx, repoID)
+	if err != nil {
+		return nil, err
+	}
+
+	cutoff := time.Now().Add(-rebaseWindow)
+	details := make([]gitlab.MRDetails, 0, len(prs))
+	for _, pr := range prs {
+		if created, err := time.Parse(time.RFC3339, pr.CreatedAt); err == nil && created.Before(cutoff) {
+			continue
+		}
+		mr := pr.toMRDetails(repoID)
+		mr.Pipeline = c.pipeline(ctx, repoID, pr.Head.SHA)
+		details = append(details, mr)
+	}
+	return details, nil
+}
+
+// ListAllOpenMRsWithDetails lists all open pull requests, for stale cleanup
+func (c *Client) ListAllOpenMRsWithDetails(ctx context.Context, repoID int) ([]gitlab.MRDetails, error) {
+	prs, err := c.listOpenPullRequests(ctx, repoID)
+	if err != nil {
+		return nil, err
+	}
+	details := make([]gitlab.MRDetails, 0, len(prs))
+	for _, pr := range prs {
+		details = append(details, pr.toMRDetails(repoID))
+	}
+	return details, nil
+}
+
+// CloseMR closes a pull request
+func (c *Client) CloseMR(ctx context.Context, repoID, number int) error {
+	resp, err := c.request(ctx, "PATCH", c.repoURL(repoID, "/pulls/%d", number), map[string]string{"state": "closed"})
+	if err != nil {
+		return fmt.Errorf("failed to close pull request: %w", err)
+	}
+	return expectStatus(resp, "close pull request", http.StatusOK)
+}
+
+// RebaseMR brings a pull request up to date with its base branch. GitHub merges the base
+// branch into the head branch; the update runs asynchronously once accepted.
+func (c *Client) RebaseMR(ctx context.Context, repoID, number int) (bool, error) {
+	pr, err := c.getPullRequest(ctx, repoID, number)
+	if err != nil {
+		return false, fmt.Errorf("failed to get pull request before update: %w", err)
+	}
+
+	resp, err := c.request(ctx, "PUT", c.repoURL(repoID, "/pulls/%d/update-branch", number),
+		map[string]string{"expected_head_sha": pr.Head.SHA})
+	if err != nil {
+		return false, fmt.Errorf("failed to update pull request branch: %w", err)
+	}
+	if resp.StatusCode == http.StatusUnprocessableEntity {
+		_ = resp.Body.Close()
+		return false, fmt.Errorf("update branch failed: conflicts or head changed: %w", gitlab.ErrConflict)
+	}
+	if err := expectStatus(resp, "update branch", http.StatusAccepted); err != nil {
+		return false, err
+	}
+	return true, nil
+}
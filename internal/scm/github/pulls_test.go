@@ -0,0 +1,135 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+)
+
+const pullRequestJSON = `{
+	"number": 7, "title": "Bump warehouse", "state": "open", "draft": true,
+	"created_at": "2026-10-01T10:00:00Z", "updated_at": "2026-10-02T10:00:00Z",
+	"head": {"ref": "feature", "sha": "abc123", "repo": {"id": 99}},
+	"base": {"ref": "main", "sha": "def456", "repo": {"id": 1}},
+	"user": {"id": 5, "login": "dev"},
+	"mergeable": true, "mergeable_state": "behind",
+	"labels": [{"name": "backend"}]
+}`
+
+func TestClient_GetMRDetails(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t, map[string]http.HandlerFunc{
+		"GET /repositories/1/pulls/7": respond(http.StatusOK, pullRequestJSON),
+	})
+
+	details, err := client.GetMRDetails(ctx, 1, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, 7, details.IID)
+	assert.Equal(t, "Draft: Bump warehouse", details.Title)
+	assert.Equal(t, "opened", details.State)
+	assert.Equal(t, "feature", details.SourceBranch)
+	assert.Equal(t, "main", details.TargetBranch)
+	assert.Equal(t, "abc123", details.Sha)
+	assert.Equal(t, 99, details.SourceProjectID)
+	assert.Equal(t, 1, details.TargetProjectID)
+	assert.Equal(t, "can_be_merged", details.MergeStatus)
+	assert.Equal(t, "dev", details.Author.Username)
+	assert.Equal(t, []string{"backend"}, details.Labels)
+
+	_, err = client.GetMRDetails(ctx, 1, 8)
+	assert.True(t, errors.Is(err, gitlab.ErrNotFound))
+}
+
+func TestPullRequest_MRState(t *testing.T) {
+	merged := "2026-10-03T10:00:00Z"
+	assert.Equal(t, "opened", (&PullRequest{State: "open"}).MRState())
+	assert.Equal(t, "merged", (&PullRequest{State: "closed", MergedAt: &merged}).MRState())
+	assert.Equal(t, "closed", (&PullRequest{State: "closed"}).MRState())
+}
+
+func TestClient_FetchMRChanges(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t, map[string]http.HandlerFunc{
+		"GET /repositories/1/pulls/7/files": respond(http.StatusOK, `[
+			{"filename": "dataproducts/a/product.yaml", "status": "modified", "patch": "@@ -1 +1 @@\n-a\n+b"},
+			{"filename": "dataproducts/b/product.yaml", "previous_filename": "dataproducts/c/product.yaml", "status": "renamed"},
+			{"filename": "dataproducts/d/product.yaml", "status": "added"}
+		]`),
+	})
+
+	changes, err := client.FetchMRChanges(ctx, 1, 7)
+	assert.NoError(t, err)
+	assert.Len(t, changes, 3)
+	assert.Equal(t, "@@ -1 +1 @@\n-a\n+b", changes[0].Diff)
+	assert.Equal(t, "dataproducts/c/product.yaml", changes[1].OldPath)
+	assert.True(t, changes[1].RenamedFile)
+	assert.True(t, changes[2].NewFile)
+}
+
+func TestClient_ListOpenMRsWithDetails(t *testing.T) {
+	ctx := context.Background()
+	recent := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	old := time.Now().Add(-10 * 24 * time.Hour).UTC().Format(time.RFC3339)
+	prs, _ := json.Marshal([]map[string]interface{}{
+		{"number": 1, "state": "open", "created_at": recent, "head": map[string]string{"ref": "a", "sha": "sha1"}},
+		{"number": 2, "state": "open", "created_at": old, "head": map[string]string{"ref": "b", "sha": "sha2"}},
+	})
+	client := newTestClient(t, map[string]http.HandlerFunc{
+		"GET /repositories/1/pulls":                   respond(http.StatusOK, string(prs)),
+		"GET /repositories/1/commits/sha1/status":     respond(http.StatusOK, `{"state": "success", "total_count": 1}`),
+		"GET /repositories/1/commits/sha1/check-runs": respond(http.StatusOK, `{"total_count": 1, "check_runs": [{"status": "in_progress"}]}`),
+	})
+
+	details, err := client.ListOpenMRsWithDetails(ctx, 1)
+	assert.NoError(t, err)
+	assert.Len(t, details, 1, "pull requests older than 7 days are not rebased")
+	assert.Equal(t, "running", details[0].Pipeline.Status)
+
+	all, err := client.ListAllOpenMRsWithDetails(ctx, 1)
+	assert.NoError(t, err)
+	assert.Len(t, all, 2)
+}
+
+func TestClient_CloseMR(t *testing.T) {
+	ctx := context.Background()
+	var body map[string]string
+	client := newTestClient(t, map[string]http.HandlerFunc{
+		"PATCH /repositories/1/pulls/7": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			respond(http.StatusOK, pullRequestJSON)(w, r)
+		},
+	})
+
+	assert.NoError(t, client.CloseMR(ctx, 1, 7))
+	assert.Equal(t, "closed", body["state"])
+}
+
+func TestClient_RebaseMR(t *testing.T) {
+	ctx := context.Background()
+	var body map[string]string
+	status := http.StatusAccepted
+	client := newTestClient(t, map[string]http.HandlerFunc{
+		"GET /repositories/1/pulls/7": respond(http.StatusOK, pullRequestJSON),
+		"PUT /repositories/1/pulls/7/update-branch": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			respond(status, `{"message": "Updating pull request branch."}`)(w, r)
+		},
+	})
+
+	rebased, err := client.RebaseMR(ctx, 1, 7)
+	assert.NoError(t, err)
+	assert.True(t, rebased)
+	assert.Equal(t, "abc123", body["expected_head_sha"])
+
+	status = http.StatusUnprocessableEntity
+	rebased, err = client.RebaseMR(ctx, 1, 7)
+	assert.False(t, rebased)
+	assert.True(t, errors.Is(err, gitlab.ErrConflict))
+}
@@ -0,0 +1,106 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// review is a pull request review
+type review struct {
+	ID    int    `json:"id"`
+	State string `json:"state"` // APPROVED, CHANGES_REQUESTED, COMMENTED, DISMISSED, PENDING
+	User  User   `json:"user"`
+}
+
+// ApproveMR approves a pull request
+func (c *Client) ApproveMR(ctx context.Context, repoID, number int) error {
+	return c.ApproveMRWithMessage(ctx, repoID, number, "")
+}
+
+// ApproveMRWithMessage submits an approving review with message as its body
+func (c *Client) ApproveMRWithMessage(ctx context.Context, repoID, number int, message string) error {
+	payload := map[string]string{"event": "APPROVE"}
+	if message != "" {
+		payload["body"] = message
+	}
+	resp, err := c.request(ctx, "POST", c.repoURL(repoID, "/pulls/%d/reviews", number), payload)
+	if err != nil {
+		return fmt.Errorf("failed to approve pull request: %w", err)
+	}
+	return expectStatus(resp, "approval", http.StatusOK)
+}
+
+// ResetNaysayerApproval dismisses the approving reviews of the naysayer bot
+func (c *Client) ResetNaysayerApproval(ctx context.Context, repoID, number int) error {
+	reviews := make([]review, 0)
+	err := c.getPages(ctx, c.repoURL(repoID, "/pulls/%d/reviews", number), func(page json.RawMessage) error {
+		var batch []review
+		if err := json.Unmarshal(page, &batch); err != nil {
+			return err
+		}
+		reviews = append(reviews, batch...)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list reviews: %w", err)
+	}
+
+	currentBotUsername, _ := c.GetCurrentBotUsername(ctx)
+	dismissed := 0
+	for _, r := range reviews {
+		if r.State != "APPROVED" || !c.isOurBot(ctx, authorMap(r.User), currentBotUsername) {
+			continue
+		}
+		resp, err := c.request(ctx, "PUT", c.repoURL(repoID, "/pulls/%d/reviews/%d/dismissals", number, r.ID),
+			map[string]string{"message": "Manual review required", "event": "DISMISS"})
+		if err != nil {
+			return fmt.Errorf("failed to dismiss review: %w", err)
+		}
+		if err := expectStatus(resp, "dismiss review", http.StatusOK); err != nil {
+			return err
+		}
+		dismissed++
+	}
+	if dismissed == 0 {
+		return fmt.Errorf("reset approval failed: pull request %d is not approved by naysayer", number)
+	}
+	return nil
+}
+
+// GetCurrentBotUsername returns the login of the token owner. GitHub App installation
+// tokens cannot read it; callers then fall back to IsNaysayerBotAuthor.
+func (c *Client) GetCurrentBotUsername(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.botUsername != "" {
+		return c.botUsername, nil
+	}
+
+	var user User
+	if _, err := c.getJSON(ctx, strings.TrimRight(c.config.BaseURL, "/")+"/user", &user); err != nil {
+		return "", fmt.Errorf("failed to get user info: %w", err)
+	}
+	if user.Login == "" {
+		return "", fmt.Errorf("login not found in user info response")
+	}
+	c.botUsername = user.Login
+	return user.Login, nil
+}
+
+// IsNaysayerBotAuthor checks if a comment author is a naysayer bot account or GitHub App
+func (c *Client) IsNaysayerBotAuthor(ctx context.Context, author map[string]interface{}) bool {
+	username, _ := author["username"].(string)
+	return strings.Contains(username, "naysayer-bot") ||
+		(strings.HasPrefix(username, "naysayer") && strings.HasSuffix(username, "[bot]"))
+}
+
+// isOurBot checks if an author is this naysayer instance
+func (c *Client) isOurBot(ctx context.Context, author map[string]interface{}, currentBotUsername string) bool {
+	if currentBotUsername != "" {
+		return author["username"] == currentBotUsername
+	}
+	return c.IsNaysayerBotAuthor(ctx, author)
+}
@@ -0,0 +1,55 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_ApproveMRWithMessage(t *testing.T) {
+	ctx := context.Background()
+	var body map[string]string
+	client := newTestClient(t, map[string]http.HandlerFunc{
+		"POST /repositories/1/pulls/7/reviews": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			respond(http.StatusOK, `{"id": 1, "state": "APPROVED"}`)(w, r)
+		},
+	})
+
+	assert.NoError(t, client.ApproveMRWithMessage(ctx, 1, 7, "Auto-approved"))
+	assert.Equal(t, map[string]string{"event": "APPROVE", "body": "Auto-approved"}, body)
+}
+
+func TestClient_ResetNaysayerApproval(t *testing.T) {
+	ctx := context.Background()
+	var dismissed []string
+	client := newTestClient(t, map[string]http.HandlerFunc{
+		"GET /user": respond(http.StatusOK, `{"login": "naysayer-bot"}`),
+		"GET /repositories/1/pulls/7/reviews": respond(http.StatusOK, `[
+			{"id": 10, "state": "APPROVED", "user": {"login": "naysayer-bot"}},
+			{"id": 11, "state": "APPROVED", "user": {"login": "maintainer"}},
+			{"id": 12, "state": "COMMENTED", "user": {"login": "naysayer-bot"}}
+		]`),
+		"PUT /repositories/1/pulls/7/reviews/10/dismissals": func(w http.ResponseWriter, r *http.Request) {
+			dismissed = append(dismissed, r.URL.Path)
+			respond(http.StatusOK, `{}`)(w, r)
+		},
+		"GET /repositories/1/pulls/8/reviews": respond(http.StatusOK, `[]`),
+	})
+
+	assert.NoError(t, client.ResetNaysayerApproval(ctx, 1, 7))
+	assert.Len(t, dismissed, 1)
+	assert.Error(t, client.ResetNaysayerApproval(ctx, 1, 8), "nothing to dismiss")
+}
+
+func TestClient_IsNaysayerBotAuthor(t *testing.T) {
+	ctx := context.Background()
+	client := NewClient(testConfig())
+	assert.True(t, client.IsNaysayerBotAuthor(ctx, map[string]interface{}{"username": "naysayer-bot"}))
+	assert.True(t, client.IsNaysayerBotAuthor(ctx, map[string]interface{}{"username": "naysayer-review[bot]"}))
+	assert.False(t, client.IsNaysayerBotAuthor(ctx, map[string]interface{}{"username": "dependabot[bot]"}))
+	assert.False(t, client.IsNaysayerBotAuthor(ctx, map[string]interface{}{}))
+}
@@ -0,0 +1,110 @@
+package github
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// Webhook event names (X-GitHub-Event)
+const (
+	EventPullRequest = "pull_request"
+	EventPush        = "push"
+	EventPing        = "ping"
+)
+
+// ReviewActions are the pull_request actions that change what naysayer reviews
+var ReviewActions = map[string]bool{
+	"opened":             true,
+	"reopened":           true,
+	"synchronize":        true,
+	"edited":             true,
+	"ready_for_review":   true,
+	"converted_to_draft": true,
+	"closed":             true,
+}
+
+// VerifySignature checks the X-Hub-Signature-256 header of a delivery
+func VerifySignature(secret string, body []byte, signature string) bool {
+	digest, ok := strings.CutPrefix(signature, "sha256=")
+	if !ok {
+		return false
+	}
+	expected, err := hex.DecodeString(digest)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+// MergeRequestPayload translates a pull_request event into the GitLab merge request
+// webhook payload the review handler understands
+func MergeRequestPayload(event map[string]interface{}) (map[string]interface{}, error) {
+	pr, ok := event["pull_request"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("missing pull_request")
+	}
+	repo, ok := event["repository"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("missing repository")
+	}
+
+	state := "closed"
+	switch {
+	case pr["state"] == "open":
+		state = "opened"
+	case pr["merged_at"] != nil || pr["merged"] == true:
+		state = "merged"
+	}
+	title, _ := pr["title"].(string)
+	if pr["draft"] == true {
+		title = "Draft: " + title
+	}
+
+	attrs := map[string]interface{}{
+		"iid":           pr["number"],
+		"title":         title,
+		"description":   pr["body"],
+		"state":         state,
+		"source_branch": nestedString(pr, "head", "ref"),
+		"target_branch": nestedString(pr, "base", "ref"),
+		"created_at":    pr["created_at"],
+		"last_commit":   map[string]interface{}{"id": nestedString(pr, "head", "sha")},
+	}
+	payload := map[string]interface{}{
+		"object_kind":       "merge_request",
+		"project":           map[string]interface{}{"id": repo["id"]},
+		"object_attributes": attrs,
+	}
+	if login := nestedString(pr, "user", "login"); login != "" {
+		payload["user"] = map[string]interface{}{"username": login}
+	}
+	return payload, nil
+}
+
+// PushPayload translates a push event into the GitLab push webhook payload the
+// auto-rebase handler understands
+func PushPayload(event map[string]interface{}) (map[string]interface{}, error) {
+	repo, ok := event["repository"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("missing repository")
+	}
+	return map[string]interface{}{
+		"object_kind": "push",
+		"ref":         event["ref"],
+		"before":      event["before"],
+		"after":       event["after"],
+		"project":     map[string]interface{}{"id": repo["id"]},
+	}, nil
+}
+
+// nestedString reads object[key][field] as a string
+func nestedString(object map[string]interface{}, key, field string) string {
+	nested, _ := object[key].(map[string]interface{})
+	value, _ := nested[field].(string)
+	return value
+}
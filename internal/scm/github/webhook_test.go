@@ -0,0 +1,71 @@
+package github
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifySignature(t *testing.T) {
+	body := []byte(`{"action": "opened"}`)
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(body)
+	signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	assert.True(t, VerifySignature("secret", body, signature))
+	assert.False(t, VerifySignature("other", body, signature))
+	assert.False(t, VerifySignature("secret", []byte(`{}`), signature))
+	assert.False(t, VerifySignature("secret", body, "sha1=abc"))
+	assert.False(t, VerifySignature("secret", body, "sha256=zz"))
+}
+
+func TestMergeRequestPayload(t *testing.T) {
+	payload, err := MergeRequestPayload(map[string]interface{}{
+		"action": "opened",
+		"pull_request": map[string]interface{}{
+			"number": float64(7), "title": "Bump warehouse", "body": "desc", "state": "open", "draft": true,
+			"created_at": "2026-10-01T10:00:00Z",
+			"head":       map[string]interface{}{"ref": "feature", "sha": "abc123"},
+			"base":       map[string]interface{}{"ref": "main"},
+			"user":       map[string]interface{}{"login": "dev"},
+		},
+		"repository": map[string]interface{}{"id": float64(42)},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "merge_request", payload["object_kind"])
+	assert.Equal(t, float64(42), payload["project"].(map[string]interface{})["id"])
+	assert.Equal(t, "dev", payload["user"].(map[string]interface{})["username"])
+
+	attrs := payload["object_attributes"].(map[string]interface{})
+	assert.Equal(t, float64(7), attrs["iid"])
+	assert.Equal(t, "Draft: Bump warehouse", attrs["title"])
+	assert.Equal(t, "opened", attrs["state"])
+	assert.Equal(t, "feature", attrs["source_branch"])
+	assert.Equal(t, "main", attrs["target_branch"])
+
+	merged, _ := MergeRequestPayload(map[string]interface{}{
+		"pull_request": map[string]interface{}{"number": float64(7), "state": "closed", "merged": true},
+		"repository":   map[string]interface{}{"id": float64(42)},
+	})
+	assert.Equal(t, "merged", merged["object_attributes"].(map[string]interface{})["state"])
+
+	_, err = MergeRequestPayload(map[string]interface{}{"repository": map[string]interface{}{}})
+	assert.Error(t, err)
+}
+
+func TestPushPayload(t *testing.T) {
+	payload, err := PushPayload(map[string]interface{}{
+		"ref": "refs/heads/main", "after": "abc123",
+		"repository": map[string]interface{}{"id": float64(42)},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "push", payload["object_kind"])
+	assert.Equal(t, "refs/heads/main", payload["ref"])
+	assert.Equal(t, "abc123", payload["after"])
+
+	_, err = PushPayload(map[string]interface{}{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,38 @@
+// Package scm selects the source code management provider naysayer talks to. GitLab is
+// the primary provider; GitHub (Enterprise) pull requests are served by a client
+// implementing the same interface.
+package scm
+
+import (
+	"fmt"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/scm/github"
+)
+
+// Provider identifies an SCM provider
+type Provider string
+
+// Supported providers
+const (
+	ProviderGitLab Provider = "gitlab"
+	ProviderGitHub Provider = "github"
+)
+
+// Client is the API surface the webhook handlers use; every provider implements it
+type Client = gitlab.GitLabClient
+
+// NewClient creates the API client of a provider
+func NewClient(cfg *config.Config, provider Provider) (Client, error) {
+	switch provider {
+	case ProviderGitLab:
+		return gitlab.NewClientWithConfig(cfg), nil
+	case ProviderGitHub:
+		if !cfg.GitHub.Enabled() {
+			return nil, fmt.Errorf("GitHub provider requires GITHUB_TOKEN")
+		}
+		return github.NewClient(cfg.GitHub), nil
+	}
+	return nil, fmt.Errorf("unknown SCM provider %q", provider)
+}
@@ -0,0 +1,30 @@
+package scm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/scm/github"
+)
+
+func TestNewClient(t *testing.T) {
+	cfg := &config.Config{GitLab: config.GitLabConfig{BaseURL: "https://gitlab.example.com"}}
+
+	client, err := NewClient(cfg, ProviderGitLab)
+	assert.NoError(t, err)
+	assert.IsType(t, &gitlab.Client{}, client)
+
+	_, err = NewClient(cfg, ProviderGitHub)
+	assert.Error(t, err, "GitHub needs a token")
+
+	cfg.GitHub = config.GitHubConfig{BaseURL: "https://github.example.com/api/v3", Token: "test-token"}
+	client, err = NewClient(cfg, ProviderGitHub)
+	assert.NoError(t, err)
+	assert.IsType(t, &github.Client{}, client)
+
+	_, err = NewClient(cfg, "bitbucket")
+	assert.Error(t, err)
+}
@@ -0,0 +1,226 @@
+package selftest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"go.uber.org/zap"
+)
+
+// pollInterval is how often the MR is checked for the expected comment and approval
+const pollInterval = 2 * time.Second
+
+// Client is the GitLab access needed to create, inspect and clean up self-test MRs
+type Client interface {
+	CreateBranch(ctx context.Context, projectID int, branch, ref string) (*gitlab.Branch, error)
+	CreateCommit(ctx context.Context, projectID int, branch, message string, actions []gitlab.CommitAction) (string, error)
+	CreateMR(ctx context.Context, projectID int, sourceBranch, targetBranch, title, description string) (*gitlab.MRDetails, error)
+	ListMRComments(ctx context.Context, projectID, mrIID int) ([]gitlab.MRComment, error)
+	ListMRApprovers(ctx context.Context, projectID, mrIID int) ([]string, error)
+	CloseMR(ctx context.Context, projectID, mrIID int) error
+	DeleteBranch(ctx context.Context, projectID int, branch string) error
+}
+
+// Trigger makes naysayer review a self-test MR, e.g. by delivering a merge request webhook
+type Trigger func(mr *gitlab.MRDetails) error
+
+// Case is a fixture change with the decision naysayer must reach on it
+type Case struct {
+	Name   string            // Short identifier, used in the branch name
+	Files  map[string]string // Repository path -> content, created on the MR branch
+	Expect shared.DecisionType
+}
+
+// DefaultCases returns the built-in fixtures, placed below naysayer-selftest/<runID>/ so
+// they never collide with real content: a README.md, approved by the documentation rules,
+// and a file no rule covers, which needs manual review
+func DefaultCases(runID string) []Case {
+	dir := "naysayer-selftest/" + runID
+	return []Case{
+		{
+			Name:   "known-good",
+			Files:  map[string]string{dir + "/README.md": "# Naysayer self-test\n\nDisposable documentation change, approved automatically.\n"},
+			Expect: shared.Approve,
+		},
+		{
+			Name:   "known-bad",
+			Files:  map[string]string{dir + "/uncovered.txt": "Disposable change without validation rules, needs manual review.\n"},
+			Expect: shared.ManualReview,
+		},
+	}
+}
+
+// CaseResult is the outcome of one self-test MR
+type CaseResult struct {
+	Name  string
+	MRIID int    // 0 when the MR could not be created
+	Err   error  // nil when the expected comment and approval state were observed
+	Notes string // Cleanup problems, which do not fail the case
+}
+
+// Passed reports whether naysayer reached the expected decision
+func (r CaseResult) Passed() bool {
+	return r.Err == nil
+}
+
+// Runner creates the self-test MRs, has them reviewed and verifies the outcome through the API
+type Runner struct {
+	client   Client
+	trigger  Trigger
+	cfg      config.SelfTestConfig
+	reviewer string // Username of the naysayer review bot
+
+	now   func() time.Time
+	sleep func(time.Duration)
+}
+
+// NewRunner creates a runner for the sandbox project of cfg. reviewer is the username
+// naysayer comments and approves as.
+func NewRunner(client Client, trigger Trigger, cfg config.SelfTestConfig, reviewer string) *Runner {
+	return &Runner{client: client, trigger: trigger, cfg: cfg, reviewer: reviewer, now: time.Now, sleep: time.Sleep}
+}
+
+// Run executes every case and always closes the MRs and deletes the branches it created
+func (r *Runner) Run(ctx context.Context, runID string, cases []Case) []CaseResult {
+	results := make([]CaseResult, 0, len(cases))
+	for _, c := range cases {
+		results = append(results, r.runCase(ctx, runID, c))
+	}
+	return results
+}
+
+// runCase creates the MR of one case, triggers the review and waits for the outcome
+func (r *Runner) runCase(ctx context.Context, runID string, c Case) (result CaseResult) {
+	result.Name = c.Name
+	projectID := r.cfg.ProjectID
+	branch := fmt.Sprintf("naysayer-selftest/%s/%s", runID, c.Name)
+
+	if _, err := r.client.CreateBranch(ctx, projectID, branch, r.cfg.TargetBranch); err != nil {
+		result.Err = fmt.Errorf("failed to create branch %s: %w", branch, err)
+		return result
+	}
+	defer func() {
+		if result.MRIID > 0 {
+			if err := r.client.CloseMR(ctx, projectID, result.MRIID); err != nil {
+				result.Notes = appendNote(result.Notes, fmt.Sprintf("failed to close MR !%d: %v", result.MRIID, err))
+			}
+		}
+		if err := r.client.DeleteBranch(ctx, projectID, branch); err != nil {
+			result.Notes = appendNote(result.Notes, fmt.Sprintf("failed to delete branch %s: %v", branch, err))
+		}
+	}()
+
+	if _, err := r.client.CreateCommit(ctx, projectID, branch, "naysayer self-test: "+c.Name, commitActions(c.Files)); err != nil {
+		result.Err = fmt.Errorf("failed to commit fixtures: %w", err)
+		return result
+	}
+
+	mr, err := r.client.CreateMR(ctx, projectID, branch, r.cfg.TargetBranch,
+		fmt.Sprintf("naysayer self-test %s (%s)", c.Name, runID),
+		"Disposable MR created by `naysayer selftest`; it is closed automatically.")
+	if err != nil {
+		result.Err = fmt.Errorf("failed to create MR: %w", err)
+		return result
+	}
+	result.MRIID = mr.IID
+	logging.Info("Self-test created MR", zap.String("check", c.Name), zap.Int("mr_iid", mr.IID), zap.Int("project_id", projectID))
+
+	if err := r.trigger(mr); err != nil {
+		result.Err = fmt.Errorf("failed to trigger review of MR !%d: %w", mr.IID, err)
+		return result
+	}
+	result.Err = r.awaitDecision(ctx, mr.IID, c.Expect)
+	return result
+}
+
+// awaitDecision polls the MR until the comment of the expected decision is posted by the
+// reviewer and the approval state matches, or the timeout passes
+func (r *Runner) awaitDecision(ctx context.Context, mrIID int, expect shared.DecisionType) error {
+	commentType := "manual-review"
+	if expect == shared.Approve {
+		commentType = "approval"
+	}
+	deadline := r.now().Add(time.Duration(r.cfg.TimeoutSeconds) * time.Second)
+
+	var lastErr error
+	for {
+		lastErr = r.checkDecision(ctx, mrIID, commentType, expect == shared.Approve)
+		if lastErr == nil {
+			return nil
+		}
+		if !r.now().Before(deadline) {
+			return fmt.Errorf("MR !%d: %w after %ds", mrIID, lastErr, r.cfg.TimeoutSeconds)
+		}
+		r.sleep(pollInterval)
+	}
+}
+
+// checkDecision reports what is still missing for the expected decision
+func (r *Runner) checkDecision(ctx context.Context, mrIID int, commentType string, approved bool) error {
+	comments, err := r.client.ListMRComments(ctx, r.cfg.ProjectID, mrIID)
+	if err != nil {
+		return fmt.Errorf("failed to list comments: %w", err)
+	}
+	if !r.hasComment(comments, commentType) {
+		return fmt.Errorf("no %s comment by %s", commentType, r.reviewer)
+	}
+
+	approvers, err := r.client.ListMRApprovers(ctx, r.cfg.ProjectID, mrIID)
+	if err != nil {
+		return fmt.Errorf("failed to list approvals: %w", err)
+	}
+	isApprover := false
+	for _, approver := range approvers {
+		if approver == r.reviewer {
+			isApprover = true
+			break
+		}
+	}
+	if isApprover != approved {
+		if approved {
+			return fmt.Errorf("not approved by %s", r.reviewer)
+		}
+		return fmt.Errorf("unexpectedly approved by %s", r.reviewer)
+	}
+	return nil
+}
+
+// hasComment reports whether the reviewer posted a comment of commentType
+func (r *Runner) hasComment(comments []gitlab.MRComment, commentType string) bool {
+	marker := "<!-- naysayer-comment-id: " + commentType + " -->"
+	for _, comment := range comments {
+		if author, _ := comment.Author["username"].(string); author == r.reviewer && strings.Contains(comment.Body, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// commitActions returns create actions for files in path order
+func commitActions(files map[string]string) []gitlab.CommitAction {
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	actions := make([]gitlab.CommitAction, 0, len(paths))
+	for _, path := range paths {
+		actions = append(actions, gitlab.CommitAction{Action: gitlab.CommitActionCreate, FilePath: path, Content: files[path]})
+	}
+	return actions
+}
+
+func appendNote(notes, note string) string {
+	if notes == "" {
+		return note
+	}
+	return notes + "; " + note
+}
@@ -0,0 +1,187 @@
+package selftest
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeClient keeps self-test MRs in memory
+type fakeClient struct {
+	branches  map[string]bool
+	commits   map[string][]gitlab.CommitAction
+	mrs       map[int]*gitlab.MRDetails
+	comments  map[int][]gitlab.MRComment
+	approvers map[int][]string
+	closed    []int
+
+	createBranchErr error
+	closeErr        error
+}
+
+func newFakeClient() *fakeClient {
+	return &fakeClient{
+		branches:  map[string]bool{},
+		commits:   map[string][]gitlab.CommitAction{},
+		mrs:       map[int]*gitlab.MRDetails{},
+		comments:  map[int][]gitlab.MRComment{},
+		approvers: map[int][]string{},
+	}
+}
+
+func (f *fakeClient) CreateBranch(ctx context.Context, projectID int, branch, ref string) (*gitlab.Branch, error) {
+	if f.createBranchErr != nil {
+		return nil, f.createBranchErr
+	}
+	f.branches[branch] = true
+	return &gitlab.Branch{Name: branch}, nil
+}
+
+func (f *fakeClient) CreateCommit(ctx context.Context, projectID int, branch, message string, actions []gitlab.CommitAction) (string, error) {
+	f.commits[branch] = append(f.commits[branch], actions...)
+	return "sha", nil
+}
+
+func (f *fakeClient) CreateMR(ctx context.Context, projectID int, sourceBranch, targetBranch, title, description string) (*gitlab.MRDetails, error) {
+	mr := &gitlab.MRDetails{IID: len(f.mrs) + 1, ProjectID: projectID, SourceBranch: sourceBranch, TargetBranch: targetBranch, Title: title}
+	f.mrs[mr.IID] = mr
+	return mr, nil
+}
+
+func (f *fakeClient) ListMRComments(ctx context.Context, projectID, mrIID int) ([]gitlab.MRComment, error) {
+	return f.comments[mrIID], nil
+}
+
+func (f *fakeClient) ListMRApprovers(ctx context.Context, projectID, mrIID int) ([]string, error) {
+	return f.approvers[mrIID], nil
+}
+
+func (f *fakeClient) CloseMR(ctx context.Context, projectID, mrIID int) error {
+	f.closed = append(f.closed, mrIID)
+	return f.closeErr
+}
+
+func (f *fakeClient) DeleteBranch(ctx context.Context, projectID int, branch string) error {
+	delete(f.branches, branch)
+	return nil
+}
+
+// review posts the comment of a decision as user, approving on Approve
+func (f *fakeClient) review(mrIID int, user string, decision shared.DecisionType) {
+	commentType := "manual-review"
+	if decision == shared.Approve {
+		commentType = "approval"
+		f.approvers[mrIID] = append(f.approvers[mrIID], user)
+	}
+	f.comments[mrIID] = append(f.comments[mrIID], gitlab.MRComment{
+		Body:   "<!-- naysayer-comment-id: " + commentType + " -->\nDecision",
+		Author: map[string]interface{}{"username": user},
+	})
+}
+
+// correctTrigger reviews the fixtures the way the default rules do
+func correctTrigger(client *fakeClient) Trigger {
+	return func(mr *gitlab.MRDetails) error {
+		for _, action := range client.commits[mr.SourceBranch] {
+			decision := shared.ManualReview
+			if strings.HasSuffix(action.FilePath, "README.md") {
+				decision = shared.Approve
+			}
+			client.review(mr.IID, "naysayer-bot", decision)
+		}
+		return nil
+	}
+}
+
+func newTestRunner(client *fakeClient, trigger Trigger) *Runner {
+	runner := NewRunner(client, trigger, config.SelfTestConfig{ProjectID: 42, TargetBranch: "main", TimeoutSeconds: 10}, "naysayer-bot")
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	runner.now = func() time.Time { return now }
+	runner.sleep = func(d time.Duration) { now = now.Add(d) }
+	return runner
+}
+
+func TestRunner_Passes(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeClient()
+	results := newTestRunner(client, correctTrigger(client)).Run(ctx, "run1", DefaultCases("run1"))
+
+	if assert.Len(t, results, 2) {
+		for _, result := range results {
+			assert.True(t, result.Passed(), "%s: %v", result.Name, result.Err)
+			assert.Empty(t, result.Notes)
+		}
+	}
+	assert.Equal(t, []int{1, 2}, client.closed)
+	assert.Empty(t, client.branches, "branches are deleted")
+	assert.Equal(t, "naysayer-selftest/run1/README.md", client.commits["naysayer-selftest/run1/known-good"][0].FilePath)
+}
+
+func TestRunner_WrongDecisionTimesOut(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeClient()
+	approveAll := func(mr *gitlab.MRDetails) error {
+		client.review(mr.IID, "naysayer-bot", shared.Approve)
+		return nil
+	}
+	results := newTestRunner(client, approveAll).Run(ctx, "run1", DefaultCases("run1"))
+
+	assert.True(t, results[0].Passed())
+	assert.False(t, results[1].Passed())
+	assert.Contains(t, results[1].Err.Error(), "no manual-review comment by naysayer-bot")
+	assert.Contains(t, results[1].Err.Error(), "after 10s")
+	assert.Equal(t, []int{1, 2}, client.closed, "failed MRs are cleaned up too")
+}
+
+func TestRunner_IgnoresOtherAuthors(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeClient()
+	otherBot := func(mr *gitlab.MRDetails) error {
+		client.review(mr.IID, "someone-else", shared.ManualReview)
+		return nil
+	}
+	results := newTestRunner(client, otherBot).Run(ctx, "run1", DefaultCases("run1")[1:])
+
+	assert.False(t, results[0].Passed())
+}
+
+func TestRunner_UnexpectedApproval(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeClient()
+	trigger := func(mr *gitlab.MRDetails) error {
+		client.review(mr.IID, "naysayer-bot", shared.ManualReview)
+		client.approvers[mr.IID] = []string{"naysayer-bot"}
+		return nil
+	}
+	results := newTestRunner(client, trigger).Run(ctx, "run1", DefaultCases("run1")[1:])
+
+	assert.Contains(t, results[0].Err.Error(), "unexpectedly approved by naysayer-bot")
+}
+
+func TestRunner_SetupAndCleanupFailures(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeClient()
+	client.createBranchErr = errors.New("403 Forbidden")
+	results := newTestRunner(client, correctTrigger(client)).Run(ctx, "run1", DefaultCases("run1")[:1])
+	assert.Contains(t, results[0].Err.Error(), "failed to create branch")
+	assert.Equal(t, 0, results[0].MRIID)
+
+	client = newFakeClient()
+	client.closeErr = errors.New("500")
+	results = newTestRunner(client, correctTrigger(client)).Run(ctx, "run1", DefaultCases("run1")[:1])
+	assert.True(t, results[0].Passed(), "cleanup problems do not fail the case")
+	assert.Contains(t, results[0].Notes, "failed to close MR !1")
+
+	client = newFakeClient()
+	failing := func(mr *gitlab.MRDetails) error { return errors.New("connection refused") }
+	results = newTestRunner(client, failing).Run(ctx, "run1", DefaultCases("run1")[:1])
+	assert.Contains(t, results[0].Err.Error(), "failed to trigger review of MR !1")
+	assert.Equal(t, []int{1}, client.closed)
+}
@@ -0,0 +1,101 @@
+package selftest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/tokenauth"
+)
+
+// webhookTimeout bounds a webhook delivery to a deployed instance
+const webhookTimeout = 60 * time.Second
+
+// MergeRequestPayload returns the merge request webhook payload GitLab sends when mr is opened
+func MergeRequestPayload(mr *gitlab.MRDetails, author string) map[string]interface{} {
+	return map[string]interface{}{
+		"object_kind": "merge_request",
+		"event_type":  "merge_request",
+		"object_attributes": map[string]interface{}{
+			"iid":              mr.IID,
+			"title":            mr.Title,
+			"source_branch":    mr.SourceBranch,
+			"target_branch":    mr.TargetBranch,
+			"state":            "opened",
+			"action":           "open",
+			"created_at":       mr.CreatedAt,
+			"work_in_progress": false,
+		},
+		"project": map[string]interface{}{
+			"id": mr.ProjectID,
+		},
+		"user": map[string]interface{}{
+			"username": author,
+		},
+	}
+}
+
+// HandlerTrigger reviews MRs in-process by passing the webhook payload to a review handler
+func HandlerTrigger(handler fiber.Handler, author string) Trigger {
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Post("/", handler)
+
+	return func(mr *gitlab.MRDetails) error {
+		body, err := json.Marshal(MergeRequestPayload(mr, author))
+		if err != nil {
+			return fmt.Errorf("failed to marshal webhook payload: %w", err)
+		}
+		req := httptest.NewRequest("POST", "/", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			return fmt.Errorf("review handler failed: %w", err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		if resp.StatusCode != http.StatusOK {
+			respBody, _ := io.ReadAll(resp.Body)
+			return fmt.Errorf("review handler answered %d: %s", resp.StatusCode, string(respBody))
+		}
+		return nil
+	}
+}
+
+// WebhookTrigger reviews MRs by delivering the webhook payload to the review endpoint of a
+// deployed instance, authenticated with the webhook secret when one is given
+func WebhookTrigger(url, secret, author string) Trigger {
+	client := &http.Client{Timeout: webhookTimeout}
+
+	return func(mr *gitlab.MRDetails) error {
+		body, err := json.Marshal(MergeRequestPayload(mr, author))
+		if err != nil {
+			return fmt.Errorf("failed to marshal webhook payload: %w", err)
+		}
+		req, err := http.NewRequest("POST", url, bytes.NewReader(body))
+		if err != nil {
+			return fmt.Errorf("failed to create webhook request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Gitlab-Event", "Merge Request Hook")
+		if secret != "" {
+			req.Header.Set(tokenauth.TokenHeader, secret)
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return fmt.Errorf("failed to deliver webhook: %w", err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			respBody, _ := io.ReadAll(resp.Body)
+			return fmt.Errorf("webhook answered %d: %s", resp.StatusCode, string(respBody))
+		}
+		return nil
+	}
+}
@@ -0,0 +1,65 @@
+package selftest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/stretchr/testify/assert"
+)
+
+var testMR = &gitlab.MRDetails{IID: 12, ProjectID: 42, Title: "naysayer self-test", SourceBranch: "naysayer-selftest/run1/known-good", TargetBranch: "main"}
+
+func TestMergeRequestPayload(t *testing.T) {
+	mrInfo, err := gitlab.ExtractMRInfo(MergeRequestPayload(testMR, "selftest-user"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 42, mrInfo.ProjectID)
+	assert.Equal(t, 12, mrInfo.MRIID)
+	assert.Equal(t, "opened", mrInfo.State)
+	assert.Equal(t, "naysayer-selftest/run1/known-good", mrInfo.SourceBranch)
+	assert.Equal(t, "selftest-user", mrInfo.Author)
+}
+
+func TestHandlerTrigger(t *testing.T) {
+	var received map[string]interface{}
+	trigger := HandlerTrigger(func(c *fiber.Ctx) error {
+		if err := c.BodyParser(&received); err != nil {
+			return err
+		}
+		return c.JSON(fiber.Map{"webhook_response": "processed"})
+	}, "selftest-user")
+
+	assert.NoError(t, trigger(testMR))
+	assert.Equal(t, "merge_request", received["object_kind"])
+
+	failing := HandlerTrigger(func(c *fiber.Ctx) error {
+		return c.Status(500).JSON(fiber.Map{"error": "Rule evaluation failed"})
+	}, "selftest-user")
+	err := failing(testMR)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Rule evaluation failed")
+}
+
+func TestWebhookTrigger(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Gitlab-Token") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var payload map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Equal(t, float64(12), payload["object_attributes"].(map[string]interface{})["iid"])
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	assert.NoError(t, WebhookTrigger(server.URL, "secret", "selftest-user")(testMR))
+
+	err := WebhookTrigger(server.URL, "wrong", "selftest-user")(testMR)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "401")
+}
@@ -0,0 +1,24 @@
+package server
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/helmet"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+)
+
+// SecurityHeaders returns middleware setting standard security headers for the JSON admin
+// endpoints. Strict-Transport-Security is only sent on HTTPS requests.
+func SecurityHeaders(cfg config.ServerConfig) fiber.Handler {
+	secure := helmet.New(helmet.Config{
+		ContentSecurityPolicy: "default-src 'none'; frame-ancestors 'none'",
+		XFrameOptions:         "DENY",
+		ReferrerPolicy:        "no-referrer",
+		HSTSMaxAge:            cfg.HSTSMaxAge,
+	})
+	return func(c *fiber.Ctx) error {
+		// Reports contain repository data; keep them out of shared caches
+		c.Set(fiber.HeaderCacheControl, "no-store")
+		return secure(c)
+	}
+}
@@ -0,0 +1,32 @@
+package server
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+)
+
+func TestSecurityHeaders(t *testing.T) {
+	app := fiber.New()
+	app.Use("/api/v1", SecurityHeaders(config.ServerConfig{HSTSMaxAge: 31536000}))
+	app.Get("/api/v1/stats/comments", func(c *fiber.Ctx) error { return c.JSON(fiber.Map{}) })
+	app.Get("/health", func(c *fiber.Ctx) error { return c.SendString("ok") })
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/stats/comments", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, "nosniff", resp.Header.Get("X-Content-Type-Options"))
+	assert.Equal(t, "DENY", resp.Header.Get("X-Frame-Options"))
+	assert.Equal(t, "no-referrer", resp.Header.Get("Referrer-Policy"))
+	assert.Equal(t, "default-src 'none'; frame-ancestors 'none'", resp.Header.Get("Content-Security-Policy"))
+	assert.Equal(t, "no-store", resp.Header.Get("Cache-Control"))
+	// HSTS is only sent over HTTPS
+	assert.Empty(t, resp.Header.Get("Strict-Transport-Security"))
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/health", nil))
+	assert.NoError(t, err)
+	assert.Empty(t, resp.Header.Get("Content-Security-Policy"))
+}
@@ -0,0 +1,188 @@
+package server
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+)
+
+// Validate checks the listener and TLS options for conflicts
+func Validate(cfg config.ServerConfig) error {
+	if (cfg.TLSCertFile == "") != (cfg.TLSKeyFile == "") {
+		return fmt.Errorf("TLS_CERT_FILE and TLS_KEY_FILE must be set together")
+	}
+	if cfg.TLSCertFile != "" && len(cfg.ACMEDomains) > 0 {
+		return fmt.Errorf("TLS_CERT_FILE and TLS_ACME_DOMAINS are mutually exclusive")
+	}
+	if cfg.UnixSocket != "" && len(cfg.ACMEDomains) > 0 {
+		return fmt.Errorf("ACME certificates need a public TCP listener, not a unix socket")
+	}
+	if cfg.UnixSocket != "" {
+		if _, err := socketMode(cfg.UnixSocketMode); err != nil {
+			return err
+		}
+	}
+	if cfg.HasAdminListener() && cfg.UnixSocket == "" && cfg.AdminPort == cfg.Port {
+		return fmt.Errorf("ADMIN_PORT must differ from PORT")
+	}
+	return nil
+}
+
+// Listener opens the configured listener: a unix socket or the TCP port, wrapped in TLS
+// when a certificate or ACME domains are configured. The returned cleanup removes the
+// unix socket file.
+func Listener(cfg config.ServerConfig) (net.Listener, func(), error) {
+	if err := Validate(cfg); err != nil {
+		return nil, nil, err
+	}
+
+	tlsCfg, err := tlsConfig(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cleanup := func() {}
+	var ln net.Listener
+	if cfg.UnixSocket != "" {
+		ln, err = listenUnix(cfg.UnixSocket, cfg.UnixSocketMode)
+		if err != nil {
+			return nil, nil, err
+		}
+		cleanup = func() { _ = os.Remove(cfg.UnixSocket) }
+	} else {
+		ln, err = net.Listen("tcp", ":"+cfg.Port)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to listen on port %s: %w", cfg.Port, err)
+		}
+	}
+
+	if tlsCfg != nil {
+		ln = tls.NewListener(ln, tlsCfg)
+	}
+	return ln, cleanup, nil
+}
+
+// Serve runs app on the configured listener until the app shuts down
+func Serve(app *fiber.App, cfg config.ServerConfig) error {
+	ln, cleanup, err := Listener(cfg)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	return app.Listener(ln)
+}
+
+// ServeAdmin runs the admin app on the admin port until the app shuts down. Admin
+// endpoints are meant to stay cluster-internal and are served over plain HTTP.
+func ServeAdmin(app *fiber.App, cfg config.ServerConfig) error {
+	ln, err := net.Listen("tcp", AdminAddress(cfg))
+	if err != nil {
+		return fmt.Errorf("failed to listen on admin port %s: %w", cfg.AdminPort, err)
+	}
+	return app.Listener(ln)
+}
+
+// AdminAddress returns the address of the admin listener
+func AdminAddress(cfg config.ServerConfig) string {
+	return net.JoinHostPort(cfg.AdminHost, cfg.AdminPort)
+}
+
+// Address describes where the server listens
+func Address(cfg config.ServerConfig) string {
+	if cfg.UnixSocket != "" {
+		return "unix:" + cfg.UnixSocket
+	}
+	return ":" + cfg.Port
+}
+
+// Scheme returns "https" when the server terminates TLS, "http" otherwise
+func Scheme(cfg config.ServerConfig) string {
+	if cfg.TLSEnabled() {
+		return "https"
+	}
+	return "http"
+}
+
+// tlsConfig returns the TLS configuration for a static certificate or ACME, or nil for plain HTTP
+func tlsConfig(cfg config.ServerConfig) (*tls.Config, error) {
+	switch {
+	case cfg.TLSCertFile != "":
+		cert, err := tls.LoadX509KeyPair(cfg.TLSCertFile, cfg.TLSKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load TLS certificate: %w", err)
+		}
+		return &tls.Config{
+			MinVersion:   tls.VersionTLS12,
+			Certificates: []tls.Certificate{cert},
+		}, nil
+	case len(cfg.ACMEDomains) > 0:
+		manager := &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			HostPolicy: autocert.HostWhitelist(cfg.ACMEDomains...),
+			Cache:      autocert.DirCache(cfg.ACMECacheDir),
+			Email:      cfg.ACMEEmail,
+		}
+		if cfg.ACMEDirectory != "" {
+			manager.Client = &acme.Client{DirectoryURL: cfg.ACMEDirectory}
+		}
+		// Answers TLS-ALPN-01 challenges on the HTTPS listener itself, no port 80 needed
+		acmeConfig := manager.TLSConfig()
+		acmeConfig.MinVersion = tls.VersionTLS12
+		return acmeConfig, nil
+	default:
+		return nil, nil
+	}
+}
+
+// listenUnix listens on a unix socket, replacing a stale socket file left by a previous run
+func listenUnix(path, mode string) (net.Listener, error) {
+	perm, err := socketMode(mode)
+	if err != nil {
+		return nil, err
+	}
+
+	if info, err := os.Lstat(path); err == nil {
+		if info.Mode()&fs.ModeSocket == 0 {
+			return nil, fmt.Errorf("%s exists and is not a socket", path)
+		}
+		if conn, err := net.Dial("unix", path); err == nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("unix socket %s is in use", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("failed to remove stale unix socket: %w", err)
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to inspect unix socket path: %w", err)
+	}
+
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on unix socket %s: %w", path, err)
+	}
+	if err := os.Chmod(path, perm); err != nil {
+		_ = ln.Close()
+		return nil, fmt.Errorf("failed to set unix socket permissions: %w", err)
+	}
+	return ln, nil
+}
+
+// socketMode parses octal unix socket permissions such as "0660"
+func socketMode(mode string) (fs.FileMode, error) {
+	perm, err := strconv.ParseUint(mode, 8, 32)
+	if err != nil || perm > 0o777 {
+		return 0, fmt.Errorf("invalid SERVER_UNIX_SOCKET_MODE %q: expected octal permissions such as 0660", mode)
+	}
+	return fs.FileMode(perm), nil
+}
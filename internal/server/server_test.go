@@ -0,0 +1,164 @@
+package server
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+)
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, Validate(config.ServerConfig{Port: "3000"}))
+	assert.NoError(t, Validate(config.ServerConfig{TLSCertFile: "c.pem", TLSKeyFile: "k.pem"}))
+	assert.NoError(t, Validate(config.ServerConfig{UnixSocket: "/run/naysayer.sock", UnixSocketMode: "0600"}))
+
+	for _, cfg := range []config.ServerConfig{
+		{TLSCertFile: "c.pem"},
+		{TLSCertFile: "c.pem", TLSKeyFile: "k.pem", ACMEDomains: []string{"naysayer.example.com"}},
+		{UnixSocket: "/run/naysayer.sock", UnixSocketMode: "0660", ACMEDomains: []string{"naysayer.example.com"}},
+		{UnixSocket: "/run/naysayer.sock", UnixSocketMode: "rw"},
+		{Port: "3000", AdminPort: "3000"},
+	} {
+		assert.Error(t, Validate(cfg), "%+v", cfg)
+	}
+}
+
+func TestAddressAndScheme(t *testing.T) {
+	assert.Equal(t, ":3000", Address(config.ServerConfig{Port: "3000"}))
+	assert.Equal(t, "unix:/run/naysayer.sock", Address(config.ServerConfig{UnixSocket: "/run/naysayer.sock"}))
+	assert.Equal(t, "http", Scheme(config.ServerConfig{}))
+	assert.Equal(t, "https", Scheme(config.ServerConfig{ACMEDomains: []string{"naysayer.example.com"}}))
+	assert.Equal(t, ":9090", AdminAddress(config.ServerConfig{AdminPort: "9090"}))
+	assert.Equal(t, "127.0.0.1:9090", AdminAddress(config.ServerConfig{AdminHost: "127.0.0.1", AdminPort: "9090"}))
+}
+
+func TestServeAdmin_PortInUse(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { _ = ln.Close() }()
+	_, port, _ := net.SplitHostPort(ln.Addr().String())
+
+	err = ServeAdmin(newTestApp(), config.ServerConfig{AdminHost: "127.0.0.1", AdminPort: port})
+	assert.ErrorContains(t, err, "failed to listen on admin port")
+}
+
+func newTestApp() *fiber.App {
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Get("/health", func(c *fiber.Ctx) error { return c.SendString("ok") })
+	return app
+}
+
+func TestServe_UnixSocket(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "naysayer.sock")
+	// A stale socket from a previous run is replaced
+	stale, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	_ = stale.Close()
+
+	cfg := config.ServerConfig{UnixSocket: socket, UnixSocketMode: "0600"}
+	app := newTestApp()
+	done := make(chan error, 1)
+	go func() { done <- Serve(app, cfg) }()
+
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", socket)
+		},
+	}}
+	require.Eventually(t, func() bool {
+		resp, err := client.Get("http://naysayer/health")
+		if err != nil {
+			return false
+		}
+		_ = resp.Body.Close()
+		return resp.StatusCode == http.StatusOK
+	}, 5*time.Second, 20*time.Millisecond)
+
+	info, err := os.Stat(socket)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	// A socket in use is not taken over
+	_, _, err = Listener(cfg)
+	assert.ErrorContains(t, err, "in use")
+
+	require.NoError(t, app.Shutdown())
+	assert.NoError(t, <-done)
+	_, err = os.Stat(socket)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestListener_RefusesRegularFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "naysayer.sock")
+	require.NoError(t, os.WriteFile(path, []byte("data"), 0o600))
+
+	_, _, err := Listener(config.ServerConfig{UnixSocket: path, UnixSocketMode: "0660"})
+	assert.ErrorContains(t, err, "not a socket")
+}
+
+// writeTestCertificate writes a self-signed certificate for localhost
+func writeTestCertificate(t *testing.T) (certFile, keyFile string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	certFile, keyFile = filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	return certFile, keyFile
+}
+
+func TestListener_TLS(t *testing.T) {
+	certFile, keyFile := writeTestCertificate(t)
+	ln, cleanup, err := Listener(config.ServerConfig{Port: "0", TLSCertFile: certFile, TLSKeyFile: keyFile})
+	require.NoError(t, err)
+	defer cleanup()
+
+	app := newTestApp()
+	app.Use("/api/v1", SecurityHeaders(config.ServerConfig{HSTSMaxAge: 3600}))
+	app.Get("/api/v1/report", func(c *fiber.Ctx) error { return c.JSON(fiber.Map{}) })
+	go func() { _ = app.Listener(ln) }()
+	defer func() { _ = app.Shutdown() }()
+
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}} // #nosec G402 -- self-signed test certificate
+	url := "https://" + ln.Addr().String() + "/api/v1/report"
+	resp, err := client.Get(url)
+	require.NoError(t, err)
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "max-age=3600; includeSubDomains", resp.Header.Get("Strict-Transport-Security"))
+
+	_, _, err = Listener(config.ServerConfig{Port: "0", TLSCertFile: certFile, TLSKeyFile: filepath.Join(t.TempDir(), "missing.key")})
+	assert.ErrorContains(t, err, "failed to load TLS certificate")
+}
@@ -0,0 +1,163 @@
+package slo
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/notify"
+	"github.com/redhat-data-and-ai/naysayer/internal/stats"
+	"go.uber.org/zap"
+)
+
+// EventSLOBurn is the notification event sent when a project burns its error budget too fast
+const EventSLOBurn = "slo_burn"
+
+// objectiveTitles are the human-readable names of the objectives
+var objectiveTitles = map[string]string{
+	stats.ObjectiveDecisionLatency: "Decision latency",
+	stats.ObjectiveFirstDecision:   "Time to first decision",
+}
+
+// Monitor periodically evaluates time-to-decision SLOs per project and alerts when the
+// error budget burn rate within the window reaches the threshold
+type Monitor struct {
+	recorder  *stats.Recorder
+	sink      notify.Sink
+	window    time.Duration
+	burnRate  float64
+	minEvents int
+	now       func() time.Time
+
+	mu      sync.Mutex
+	alerted map[string]bool // Project/objective pairs alerted for the current burn episode
+	stop    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewMonitor creates an SLO monitor. The recorder must have objectives set.
+func NewMonitor(recorder *stats.Recorder, sink notify.Sink, cfg config.SLOConfig) *Monitor {
+	window := time.Duration(cfg.WindowMinutes) * time.Minute
+	if window <= 0 {
+		window = time.Hour
+	}
+	return &Monitor{
+		recorder:  recorder,
+		sink:      sink,
+		window:    window,
+		burnRate:  cfg.AlertBurnRate,
+		minEvents: cfg.AlertMinEvents,
+		now:       time.Now,
+		alerted:   make(map[string]bool),
+	}
+}
+
+// NewMonitorFromConfig returns an SLO monitor, or nil when burn alerts are disabled
+func NewMonitorFromConfig(cfg *config.Config, recorder *stats.Recorder) *Monitor {
+	if cfg.SLO.AlertBurnRate <= 0 {
+		return nil
+	}
+	return NewMonitor(recorder, notify.NewSinkFromConfig(cfg), cfg.SLO)
+}
+
+// Check evaluates the SLOs of every project over the window and alerts once per burn
+// episode; the alert re-arms when the burn rate drops below the threshold
+func (m *Monitor) Check() {
+	now := m.now()
+	report, err := m.recorder.Summarize(0, now.Add(-m.window), now)
+	if err != nil {
+		logging.Warn("Failed to evaluate time-to-decision SLOs", zap.Error(err))
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	burning := make(map[string]bool)
+	for _, project := range report.Projects {
+		for objective, status := range map[string]*stats.SLOStatus{
+			stats.ObjectiveDecisionLatency: project.DecisionLatencySLO,
+			stats.ObjectiveFirstDecision:   project.FirstDecisionSLO,
+		} {
+			if status == nil || status.Events < m.minEvents || status.BurnRate < m.burnRate {
+				continue
+			}
+			key := fmt.Sprintf("%d/%s", project.ProjectID, objective)
+			burning[key] = true
+			if !m.alerted[key] {
+				m.alert(project.ProjectID, objective, status)
+			}
+		}
+	}
+	m.alerted = burning
+}
+
+// alert sends the burn notification
+func (m *Monitor) alert(projectID int, objective string, status *stats.SLOStatus) {
+	message := fmt.Sprintf("%.1f%% of %d decisions within %s met the %.0fs threshold (target %.1f%%), burning the error budget %.1fx too fast.",
+		status.CompliancePercent, status.Events, m.window, status.ThresholdSeconds, status.TargetPercent, status.BurnRate)
+	err := m.sink.Notify(notify.Notification{
+		Event:     EventSLOBurn,
+		Severity:  notify.SeverityWarning,
+		Title:     objectiveTitles[objective] + " SLO at risk",
+		Message:   message,
+		ProjectID: projectID,
+		Fields: map[string]string{
+			"objective":   objective,
+			"compliance":  fmt.Sprintf("%.1f", status.CompliancePercent),
+			"burn_rate":   fmt.Sprintf("%.2f", status.BurnRate),
+			"p95_seconds": fmt.Sprintf("%.1f", status.P95Seconds),
+			"window":      m.window.String(),
+		},
+		Timestamp: m.now().UTC(),
+	})
+	if err != nil {
+		logging.Warn("Failed to send SLO burn notification", zap.Int("project_id", projectID), zap.Error(err))
+	}
+}
+
+// Start checks the SLOs now and then every interval until Stop is called
+func (m *Monitor) Start(interval time.Duration) {
+	m.mu.Lock()
+	if m.stop != nil {
+		m.mu.Unlock()
+		return
+	}
+	m.stop = make(chan struct{})
+	stop := m.stop
+	m.mu.Unlock()
+
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		m.Check()
+		if interval <= 0 {
+			return
+		}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				m.Check()
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop ends periodic checks and waits for a running check to finish
+func (m *Monitor) Stop() {
+	m.mu.Lock()
+	stop := m.stop
+	m.stop = nil
+	m.mu.Unlock()
+
+	if stop != nil {
+		close(stop)
+		m.wg.Wait()
+	}
+}
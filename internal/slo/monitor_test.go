@@ -0,0 +1,85 @@
+package slo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/notify"
+	"github.com/redhat-data-and-ai/naysayer/internal/stats"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+)
+
+// recordingSink captures notifications
+type recordingSink struct {
+	notifications []notify.Notification
+}
+
+func (s *recordingSink) Notify(n notify.Notification) error {
+	s.notifications = append(s.notifications, n)
+	return nil
+}
+
+func newTestMonitor(now time.Time) (*Monitor, *stats.Recorder, *recordingSink) {
+	cfg := config.SLOConfig{DecisionLatencySeconds: 30, FirstDecisionSeconds: 300, TargetPercent: 90, WindowMinutes: 60, AlertBurnRate: 2, AlertMinEvents: 4}
+	recorder := stats.NewRecorder(store.NewMemoryStore())
+	recorder.SetObjectives(stats.ObjectivesFromConfig(cfg))
+	sink := &recordingSink{}
+	monitor := NewMonitor(recorder, sink, cfg)
+	// Checks run shortly after the decisions are recorded
+	monitor.now = func() time.Time { return now.Add(time.Minute) }
+	return monitor, recorder, sink
+}
+
+func TestMonitor_AlertsOncePerBurnEpisode(t *testing.T) {
+	now := time.Now()
+	monitor, recorder, sink := newTestMonitor(now)
+
+	// 2 of 4 decisions too slow: 50% compliance against a 90% target burns 5x
+	recorder.RecordDecisionLatency(1, 10, now.Add(-5*time.Second))
+	recorder.RecordDecisionLatency(1, 11, now.Add(-5*time.Second))
+	recorder.RecordDecisionLatency(1, 12, now.Add(-2*time.Minute))
+	recorder.RecordDecisionLatency(1, 13, now.Add(-2*time.Minute))
+
+	monitor.Check()
+	assert.Len(t, sink.notifications, 1)
+	n := sink.notifications[0]
+	assert.Equal(t, EventSLOBurn, n.Event)
+	assert.Equal(t, 1, n.ProjectID)
+	assert.Equal(t, stats.ObjectiveDecisionLatency, n.Fields["objective"])
+	assert.Equal(t, "5.00", n.Fields["burn_rate"])
+
+	monitor.Check()
+	assert.Len(t, sink.notifications, 1, "no repeated alert while still burning")
+}
+
+func TestMonitor_NeedsMinimumEvents(t *testing.T) {
+	now := time.Now()
+	monitor, recorder, sink := newTestMonitor(now)
+
+	recorder.RecordDecisionLatency(1, 10, now.Add(-2*time.Minute))
+	monitor.Check()
+	assert.Empty(t, sink.notifications)
+}
+
+func TestMonitor_RearmsAfterRecovery(t *testing.T) {
+	now := time.Now()
+	monitor, recorder, sink := newTestMonitor(now)
+	for i := 0; i < 4; i++ {
+		recorder.RecordDecisionLatency(1, 10+i, now.Add(-2*time.Minute))
+	}
+	monitor.Check()
+	assert.Len(t, sink.notifications, 1)
+
+	// The slow decisions leave the window
+	monitor.now = func() time.Time { return now.Add(2 * time.Hour) }
+	monitor.Check()
+	assert.Empty(t, monitor.alerted)
+}
+
+func TestNewMonitorFromConfig_Disabled(t *testing.T) {
+	cfg := &config.Config{SLO: config.SLOConfig{AlertBurnRate: 0}}
+	assert.Nil(t, NewMonitorFromConfig(cfg, stats.NewRecorder(store.NewMemoryStore())))
+}
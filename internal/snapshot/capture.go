@@ -0,0 +1,175 @@
+package snapshot
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+)
+
+// fileKey identifies one fetched file version
+func fileKey(projectID int, path, ref string) string {
+	return fmt.Sprintf("%d\x00%s\x00%s", projectID, path, ref)
+}
+
+// RulesConfigSHA256 returns the SHA-256 of the rule configuration file, or "" if it cannot be read
+func RulesConfigSHA256(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// statusCode returns the HTTP status of a GitLab API error, or 0
+func statusCode(err error) int {
+	var apiErr *gitlab.APIError
+	switch {
+	case errors.As(err, &apiErr):
+		return apiErr.StatusCode
+	case errors.Is(err, gitlab.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, gitlab.ErrPermission):
+		return http.StatusForbidden
+	case errors.Is(err, gitlab.ErrConflict):
+		return http.StatusConflict
+	}
+	return 0
+}
+
+// Capture is a GitLab client that records every read rules make during one evaluation.
+// Calls it does not record are passed through to the wrapped client.
+type Capture struct {
+	gitlab.GitLabClient
+
+	mu       sync.Mutex
+	files    []FileRecord
+	contents map[string][]byte
+	seen     map[string]bool
+	details  []DetailsRecord
+	branches []TargetBranchRecord
+}
+
+// NewCapture wraps client for recording one evaluation
+func NewCapture(client gitlab.GitLabClient) *Capture {
+	return &Capture{
+		GitLabClient: client,
+		contents:     make(map[string][]byte),
+		seen:         make(map[string]bool),
+	}
+}
+
+// GitLabInstance returns the GitLab instance of the wrapped client, so that rules keep using
+// that instance's repository index while being recorded
+func (c *Capture) GitLabInstance() string {
+	if named, ok := c.GitLabClient.(interface{ GitLabInstance() string }); ok {
+		return named.GitLabInstance()
+	}
+	return ""
+}
+
+// FetchFileContent fetches and records file content (or the failure)
+func (c *Capture) FetchFileContent(ctx context.Context, projectID int, filePath, ref string) (*gitlab.FileContent, error) {
+	content, err := c.GitLabClient.FetchFileContent(ctx, projectID, filePath, ref)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := fileKey(projectID, filePath, ref)
+	if c.seen[key] {
+		return content, err
+	}
+	c.seen[key] = true
+
+	record := FileRecord{ProjectID: projectID, Path: filePath, Ref: ref}
+	if err != nil {
+		record.Error = err.Error()
+		record.StatusCode = statusCode(err)
+	} else if content != nil {
+		metadata := *content
+		metadata.Content = ""
+		record.Metadata = &metadata
+		c.contents[key] = []byte(content.Content)
+	}
+	c.files = append(c.files, record)
+	return content, err
+}
+
+// GetMRDetails fetches and records MR details
+func (c *Capture) GetMRDetails(ctx context.Context, projectID, mrIID int) (*gitlab.MRDetails, error) {
+	details, err := c.GitLabClient.GetMRDetails(ctx, projectID, mrIID)
+	c.recordDetails(projectID, mrIID, details, err)
+	return details, err
+}
+
+// GetMRTargetBranch fetches and records the MR target branch
+func (c *Capture) GetMRTargetBranch(ctx context.Context, projectID, mrIID int) (string, error) {
+	branch, err := c.GitLabClient.GetMRTargetBranch(ctx, projectID, mrIID)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, existing := range c.branches {
+		if existing.ProjectID == projectID && existing.MRIID == mrIID {
+			return branch, err
+		}
+	}
+	record := TargetBranchRecord{ProjectID: projectID, MRIID: mrIID, Branch: branch}
+	if err != nil {
+		record.Error = err.Error()
+		record.StatusCode = statusCode(err)
+	}
+	c.branches = append(c.branches, record)
+	return branch, err
+}
+
+func (c *Capture) recordDetails(projectID, mrIID int, details *gitlab.MRDetails, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, existing := range c.details {
+		if existing.ProjectID == projectID && existing.MRIID == mrIID {
+			return
+		}
+	}
+	record := DetailsRecord{ProjectID: projectID, MRIID: mrIID, Details: details}
+	if err != nil {
+		record.Details = nil
+		record.Error = err.Error()
+		record.StatusCode = statusCode(err)
+	}
+	c.details = append(c.details, record)
+}
+
+// Snapshot builds the snapshot of the recorded evaluation and the contents it references.
+// rulesConfigPath is hashed so a replay can tell whether the rules changed since.
+func (c *Capture) Snapshot(mrCtx *shared.MRContext, decision shared.Decision, rulesConfigPath string) (*Snapshot, map[string][]byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	snap := &Snapshot{
+		ProjectID: mrCtx.ProjectID,
+		MRIID:     mrCtx.MRIID,
+		Changes:   mrCtx.Changes,
+		Files:     append([]FileRecord(nil), c.files...),
+		MRDetails: append([]DetailsRecord(nil), c.details...),
+		Decision:  decision,
+
+		TargetBranches: append([]TargetBranchRecord(nil), c.branches...),
+	}
+	if mrCtx.MRInfo != nil {
+		snap.MRInfo = *mrCtx.MRInfo
+	}
+	snap.RulesConfigSHA256 = RulesConfigSHA256(rulesConfigPath)
+
+	contents := make(map[string][]byte, len(c.contents))
+	for key, content := range c.contents {
+		contents[key] = content
+	}
+	return snap, contents
+}
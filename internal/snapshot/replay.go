@@ -0,0 +1,125 @@
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+)
+
+// ErrNotCaptured is returned for reads the original evaluation did not make
+var ErrNotCaptured = errors.New("not captured in snapshot")
+
+// replayClient answers rule reads from a snapshot instead of GitLab. Only the reads
+// recorded by Capture are implemented; any other call panics and is reported by Replay.
+type replayClient struct {
+	gitlab.GitLabClient
+
+	files    map[string]FileRecord
+	details  map[[2]int]DetailsRecord
+	branches map[[2]int]TargetBranchRecord
+	blob     func(addr string) ([]byte, error)
+}
+
+// replayError recreates a recorded failure, keeping its GitLab status for errors.Is checks
+func replayError(message string, status int) error {
+	if status != 0 {
+		return &gitlab.APIError{StatusCode: status, Message: message}
+	}
+	return errors.New(message)
+}
+
+func (r *replayClient) FetchFileContent(ctx context.Context, projectID int, filePath, ref string) (*gitlab.FileContent, error) {
+	record, ok := r.files[fileKey(projectID, filePath, ref)]
+	if !ok {
+		return nil, fmt.Errorf("file %s at %s in project %d: %w", filePath, ref, projectID, ErrNotCaptured)
+	}
+	if record.Error != "" {
+		return nil, replayError(record.Error, record.StatusCode)
+	}
+	if record.Blob == "" {
+		return nil, nil
+	}
+	content, err := r.blob(record.Blob)
+	if err != nil {
+		return nil, err
+	}
+	result := gitlab.FileContent{}
+	if record.Metadata != nil {
+		result = *record.Metadata
+	}
+	result.Content = string(content)
+	return &result, nil
+}
+
+func (r *replayClient) GetMRDetails(ctx context.Context, projectID, mrIID int) (*gitlab.MRDetails, error) {
+	record, ok := r.details[[2]int{projectID, mrIID}]
+	if !ok {
+		return nil, fmt.Errorf("details of MR !%d in project %d: %w", mrIID, projectID, ErrNotCaptured)
+	}
+	if record.Error != "" {
+		return nil, replayError(record.Error, record.StatusCode)
+	}
+	if record.Details == nil {
+		return nil, nil
+	}
+	details := *record.Details
+	return &details, nil
+}
+
+func (r *replayClient) GetMRTargetBranch(ctx context.Context, projectID, mrIID int) (string, error) {
+	record, ok := r.branches[[2]int{projectID, mrIID}]
+	if !ok {
+		return "", fmt.Errorf("target branch of MR !%d in project %d: %w", mrIID, projectID, ErrNotCaptured)
+	}
+	if record.Error != "" {
+		return "", replayError(record.Error, record.StatusCode)
+	}
+	return record.Branch, nil
+}
+
+// NewReplayClient returns a GitLab client serving the reads recorded in snap
+func (s *Store) NewReplayClient(snap *Snapshot) gitlab.GitLabClient {
+	client := &replayClient{
+		files:    make(map[string]FileRecord, len(snap.Files)),
+		details:  make(map[[2]int]DetailsRecord, len(snap.MRDetails)),
+		branches: make(map[[2]int]TargetBranchRecord, len(snap.TargetBranches)),
+		blob:     s.Blob,
+	}
+	for _, file := range snap.Files {
+		client.files[fileKey(file.ProjectID, file.Path, file.Ref)] = file
+	}
+	for _, details := range snap.MRDetails {
+		client.details[[2]int{details.ProjectID, details.MRIID}] = details
+	}
+	for _, branch := range snap.TargetBranches {
+		client.branches[[2]int{branch.ProjectID, branch.MRIID}] = branch
+	}
+	return client
+}
+
+// Replay re-runs an evaluation against the snapshot's inputs. newManager builds the rule
+// manager for the replay client, normally rules.CreateSectionBasedDataverseManager.
+func (s *Store) Replay(snap *Snapshot, newManager func(gitlab.GitLabClient) (shared.RuleManager, error)) (result *shared.RuleEvaluation, err error) {
+	manager, err := newManager(s.NewReplayClient(snap))
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			result = nil
+			err = fmt.Errorf("replay made a GitLab call that is not captured in the snapshot: %v", r)
+		}
+	}()
+
+	mrInfo := snap.MRInfo
+	return manager.EvaluateAll(&shared.MRContext{
+		ProjectID: snap.ProjectID,
+		MRIID:     snap.MRIID,
+		Changes:   snap.Changes,
+		MRInfo:    &mrInfo,
+	}), nil
+}
@@ -0,0 +1,123 @@
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/repoindex"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeGitLab serves files from a map; other calls are not implemented
+type fakeGitLab struct {
+	gitlab.GitLabClient
+	files map[string]string
+	calls int
+}
+
+func (f *fakeGitLab) FetchFileContent(ctx context.Context, projectID int, filePath, ref string) (*gitlab.FileContent, error) {
+	f.calls++
+	content, ok := f.files[filePath+"@"+ref]
+	if !ok {
+		return nil, fmt.Errorf("file not found: %s: %w", filePath, gitlab.ErrNotFound)
+	}
+	return &gitlab.FileContent{FilePath: filePath, Ref: ref, Content: content, BlobID: "blob-" + filePath}, nil
+}
+
+func (f *fakeGitLab) GetMRTargetBranch(ctx context.Context, projectID, mrIID int) (string, error) {
+	f.calls++
+	return "main", nil
+}
+
+// fakeManager approves when the source file says "approve" and the target file is missing
+type fakeManager struct {
+	client gitlab.GitLabClient
+}
+
+func (m *fakeManager) AddRule(rule shared.Rule) {}
+
+func (m *fakeManager) EvaluateAll(mrCtx *shared.MRContext) *shared.RuleEvaluation {
+	ctx := mrCtx.Context()
+	target, err := m.client.GetMRTargetBranch(ctx, mrCtx.ProjectID, mrCtx.MRIID)
+	if err != nil {
+		panic(err)
+	}
+	decision := shared.Decision{Type: shared.ManualReview, Reason: "changed"}
+	source, err := m.client.FetchFileContent(ctx, mrCtx.ProjectID, "product.yaml", mrCtx.MRInfo.SourceBranch)
+	_, targetErr := m.client.FetchFileContent(ctx, mrCtx.ProjectID, "product.yaml", target)
+	if err == nil && source.Content == "approve" && errors.Is(targetErr, gitlab.ErrNotFound) {
+		decision = shared.Decision{Type: shared.Approve, Reason: "new product " + source.BlobID}
+	}
+	return &shared.RuleEvaluation{FinalDecision: decision}
+}
+
+func newFakeManager(client gitlab.GitLabClient) (shared.RuleManager, error) {
+	return &fakeManager{client: client}, nil
+}
+
+func TestCaptureAndReplay(t *testing.T) {
+	live := &fakeGitLab{files: map[string]string{"product.yaml@feature": "approve"}}
+	capture := NewCapture(live)
+	manager, _ := newFakeManager(capture)
+
+	mrCtx := &shared.MRContext{
+		ProjectID: 1,
+		MRIID:     10,
+		Changes:   []gitlab.FileChange{{NewPath: "product.yaml", NewFile: true, Diff: "+approve"}},
+		MRInfo:    &gitlab.MRInfo{ProjectID: 1, MRIID: 10, SourceBranch: "feature"},
+	}
+	result := manager.EvaluateAll(mrCtx)
+	assert.Equal(t, shared.Approve, result.FinalDecision.Type)
+
+	snap, contents := capture.Snapshot(mrCtx, result.FinalDecision, "testdata/missing-rules.yaml")
+	assert.Len(t, snap.Files, 2)
+	assert.Equal(t, 404, snap.Files[1].StatusCode)
+	assert.Len(t, snap.TargetBranches, 1)
+	assert.Empty(t, snap.RulesConfigSHA256)
+
+	s, _ := NewStore(store.NewMemoryStore(), testKey, 0, 0)
+	assert.NoError(t, s.Save(snap, contents))
+
+	// The live repository changes afterwards; the replay still sees the captured inputs
+	live.files["product.yaml@feature"] = "something else"
+	calls := live.calls
+
+	loaded, err := s.Load(snap.ID)
+	assert.NoError(t, err)
+	replayed, err := s.Replay(loaded, newFakeManager)
+	assert.NoError(t, err)
+	assert.Equal(t, result.FinalDecision, replayed.FinalDecision)
+	assert.Equal(t, calls, live.calls)
+}
+
+func TestReplay_UncapturedCall(t *testing.T) {
+	ctx := context.Background()
+	s, _ := NewStore(store.NewMemoryStore(), "", 0, 0)
+	snap := &Snapshot{ProjectID: 1, MRIID: 10, MRInfo: gitlab.MRInfo{SourceBranch: "feature"}}
+
+	// GetMRTargetBranch was never recorded
+	_, err := s.Replay(snap, newFakeManager)
+	assert.Error(t, err)
+
+	client := s.NewReplayClient(snap)
+	_, err = client.FetchFileContent(ctx, 1, "product.yaml", "feature")
+	assert.ErrorIs(t, err, ErrNotCaptured)
+	assert.Panics(t, func() { _ = client.AddMRComment(ctx, 1, 10, "hi") })
+}
+
+func TestCapture_KeepsGitLabInstance(t *testing.T) {
+	onprem := NewCapture(gitlab.NewClient(config.GitLabConfig{BaseURL: "https://gitlab.example.com", Instance: "onprem"}))
+	assert.Equal(t, "onprem", onprem.GitLabInstance())
+	assert.Equal(t, "", NewCapture(&fakeGitLab{}).GitLabInstance())
+
+	idx := repoindex.NewIndex(nil, store.NewMemoryStore())
+	repoindex.SetForInstance("onprem", idx)
+	defer repoindex.SetForInstance("onprem", nil)
+	assert.Same(t, idx, repoindex.ForClient(onprem), "recorded rules use the index of the wrapped client's instance")
+}
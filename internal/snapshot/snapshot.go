@@ -0,0 +1,490 @@
+package snapshot
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+	"go.uber.org/zap"
+)
+
+// Backend namespaces for file content blobs and per-evaluation manifests
+const (
+	blobPrefix     = "snapshots/blobs/"
+	manifestPrefix = "snapshots/manifests/"
+)
+
+// FileRecord is one FetchFileContent call made during an evaluation
+type FileRecord struct {
+	ProjectID int    `json:"project_id"`
+	Path      string `json:"path"`
+	Ref       string `json:"ref"`
+	// Blob addresses the file content; empty when the fetch failed
+	Blob string `json:"blob,omitempty"`
+	// Metadata is the GitLab response without its content
+	Metadata *gitlab.FileContent `json:"metadata,omitempty"`
+	// Error and StatusCode reproduce a failed fetch (StatusCode is 0 for transport errors)
+	Error      string `json:"error,omitempty"`
+	StatusCode int    `json:"status_code,omitempty"`
+}
+
+// DetailsRecord is one GetMRDetails call made during an evaluation
+type DetailsRecord struct {
+	ProjectID  int               `json:"project_id"`
+	MRIID      int               `json:"mr_iid"`
+	Details    *gitlab.MRDetails `json:"details,omitempty"`
+	Error      string            `json:"error,omitempty"`
+	StatusCode int               `json:"status_code,omitempty"`
+}
+
+// TargetBranchRecord is one GetMRTargetBranch call made during an evaluation
+type TargetBranchRecord struct {
+	ProjectID  int    `json:"project_id"`
+	MRIID      int    `json:"mr_iid"`
+	Branch     string `json:"branch,omitempty"`
+	Error      string `json:"error,omitempty"`
+	StatusCode int    `json:"status_code,omitempty"`
+}
+
+// Snapshot holds everything an evaluation read, so it can be re-run later
+type Snapshot struct {
+	ID         string              `json:"id"`
+	ProjectID  int                 `json:"project_id"`
+	MRIID      int                 `json:"mr_iid"`
+	CapturedAt time.Time           `json:"captured_at"`
+	MRInfo     gitlab.MRInfo       `json:"mr_info"`
+	Changes    []gitlab.FileChange `json:"changes"`
+	Files      []FileRecord        `json:"files"`
+	MRDetails  []DetailsRecord     `json:"mr_details,omitempty"`
+	// TargetBranches are MR target branch lookups
+	TargetBranches []TargetBranchRecord `json:"target_branches,omitempty"`
+	// RulesConfigSHA256 identifies the rules.yaml the evaluation ran with
+	RulesConfigSHA256 string          `json:"rules_config_sha256,omitempty"`
+	Decision          shared.Decision `json:"decision"`
+}
+
+// Summary is the listing view of a snapshot
+type Summary struct {
+	ID         string              `json:"id"`
+	ProjectID  int                 `json:"project_id"`
+	MRIID      int                 `json:"mr_iid"`
+	CapturedAt time.Time           `json:"captured_at"`
+	Decision   shared.DecisionType `json:"decision"`
+}
+
+// Store persists snapshots in a store.Store backend. File contents are kept once
+// per distinct content (content-addressed) and shared between snapshots.
+// A nil Store ignores all snapshots.
+type Store struct {
+	backend   store.Store
+	aead      cipher.AEAD // nil when encryption at rest is disabled
+	key       []byte
+	retention time.Duration
+	maxPerMR  int
+	now       func() time.Time
+
+	mu   sync.Mutex
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewStore creates a snapshot store. encryptionKey is an optional base64 AES-256 key;
+// retention and maxPerMR of zero keep snapshots indefinitely.
+func NewStore(backend store.Store, encryptionKey string, retention time.Duration, maxPerMR int) (*Store, error) {
+	s := &Store{backend: backend, retention: retention, maxPerMR: maxPerMR, now: time.Now}
+	if encryptionKey == "" {
+		return s, nil
+	}
+
+	key, err := base64.StdEncoding.DecodeString(encryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid snapshot encryption key: %w", err)
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("invalid snapshot encryption key: expected 32 bytes, got %d", len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	s.aead, err = cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	s.key = key
+	return s, nil
+}
+
+// NewStoreFromConfig creates the snapshot store configured by cfg, or nil when snapshots are disabled.
+// Snapshots go to MR_SNAPSHOT_DIR when set, otherwise to the shared state store.
+func NewStoreFromConfig(cfg *config.Config, stateStore store.Store) (*Store, error) {
+	if !cfg.Snapshot.Enabled {
+		return nil, nil
+	}
+	backend := stateStore
+	if cfg.Snapshot.Dir != "" {
+		dir, err := store.NewDirStore(cfg.Snapshot.Dir)
+		if err != nil {
+			return nil, err
+		}
+		backend = dir
+	}
+	retention := time.Duration(cfg.Snapshot.RetentionDays) * 24 * time.Hour
+	return NewStore(backend, cfg.Snapshot.EncryptionKey, retention, cfg.Snapshot.MaxPerMR)
+}
+
+// Encrypted reports whether snapshots are encrypted at rest
+func (s *Store) Encrypted() bool {
+	return s != nil && s.aead != nil
+}
+
+// address returns the content address of data. With encryption enabled the address is
+// keyed so stored addresses do not reveal which well-known contents a snapshot holds.
+func (s *Store) address(data []byte) string {
+	if s.key != nil {
+		mac := hmac.New(sha256.New, s.key)
+		mac.Write(data)
+		return hex.EncodeToString(mac.Sum(nil))
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// seal encrypts data when encryption at rest is enabled (nonce || ciphertext)
+func (s *Store) seal(data []byte) ([]byte, error) {
+	if s.aead == nil {
+		return data, nil
+	}
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return s.aead.Seal(nonce, nonce, data, nil), nil
+}
+
+// open reverses seal
+func (s *Store) open(data []byte) ([]byte, error) {
+	if s.aead == nil {
+		return data, nil
+	}
+	if len(data) < s.aead.NonceSize() {
+		return nil, fmt.Errorf("encrypted snapshot data is truncated")
+	}
+	nonce, ciphertext := data[:s.aead.NonceSize()], data[s.aead.NonceSize():]
+	plain, err := s.aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt snapshot data: %w", err)
+	}
+	return plain, nil
+}
+
+// putBlob stores content once under its address and returns the address
+func (s *Store) putBlob(content []byte) (string, error) {
+	addr := s.address(content)
+	if _, found, err := s.backend.Get(blobPrefix + addr); err != nil {
+		return "", err
+	} else if found {
+		return addr, nil
+	}
+	sealed, err := s.seal(content)
+	if err != nil {
+		return "", err
+	}
+	return addr, s.backend.Put(blobPrefix+addr, sealed)
+}
+
+// Blob returns the content stored under addr
+func (s *Store) Blob(addr string) ([]byte, error) {
+	data, found, err := s.backend.Get(blobPrefix + addr)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("snapshot blob %s not found", addr)
+	}
+	content, err := s.open(data)
+	if err != nil {
+		return nil, err
+	}
+	if s.address(content) != addr {
+		return nil, fmt.Errorf("snapshot blob %s is corrupted", addr)
+	}
+	return content, nil
+}
+
+// manifestKey orders an MR's snapshots by capture time
+func manifestKey(projectID, mrIID int, at time.Time) string {
+	return fmt.Sprintf("%s%d/%d/%020d", manifestPrefix, projectID, mrIID, at.UnixNano())
+}
+
+// idFromKey returns the public identifier of the snapshot stored under a manifest key
+func idFromKey(key string) string {
+	return strings.ReplaceAll(strings.TrimPrefix(key, manifestPrefix), "/", "-")
+}
+
+// keyFromID reverses idFromKey, validating the identifier
+func keyFromID(id string) (string, error) {
+	parts := strings.Split(id, "-")
+	if len(parts) != 3 {
+		return "", fmt.Errorf("invalid snapshot id %q", id)
+	}
+	for _, part := range parts {
+		if _, err := strconv.ParseInt(part, 10, 64); err != nil {
+			return "", fmt.Errorf("invalid snapshot id %q", id)
+		}
+	}
+	return manifestPrefix + strings.Join(parts, "/"), nil
+}
+
+// Save stores the snapshot, assigning its ID and capture time, and drops the MR's
+// oldest snapshots beyond the per-MR limit
+func (s *Store) Save(snap *Snapshot, contents map[string][]byte) error {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range snap.Files {
+		content, ok := contents[fileKey(snap.Files[i].ProjectID, snap.Files[i].Path, snap.Files[i].Ref)]
+		if snap.Files[i].Error != "" || !ok {
+			continue
+		}
+		addr, err := s.putBlob(content)
+		if err != nil {
+			return fmt.Errorf("failed to store snapshot file %s: %w", snap.Files[i].Path, err)
+		}
+		snap.Files[i].Blob = addr
+	}
+
+	snap.CapturedAt = s.now().UTC()
+	key := manifestKey(snap.ProjectID, snap.MRIID, snap.CapturedAt)
+	snap.ID = idFromKey(key)
+
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return err
+	}
+	sealed, err := s.seal(data)
+	if err != nil {
+		return err
+	}
+	if err := s.backend.Put(key, sealed); err != nil {
+		return fmt.Errorf("failed to store snapshot manifest: %w", err)
+	}
+
+	if s.maxPerMR > 0 {
+		keys, err := s.backend.Keys(fmt.Sprintf("%s%d/%d/", manifestPrefix, snap.ProjectID, snap.MRIID))
+		if err != nil {
+			return err
+		}
+		for len(keys) > s.maxPerMR {
+			if err := s.backend.Delete(keys[0]); err != nil {
+				return err
+			}
+			keys = keys[1:]
+		}
+	}
+	return nil
+}
+
+// Load returns the snapshot with the given ID
+func (s *Store) Load(id string) (*Snapshot, error) {
+	key, err := keyFromID(id)
+	if err != nil {
+		return nil, err
+	}
+	data, found, err := s.backend.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("snapshot %s not found", id)
+	}
+	return s.decodeManifest(data)
+}
+
+// decodeManifest decrypts and parses a stored manifest
+func (s *Store) decodeManifest(data []byte) (*Snapshot, error) {
+	plain, err := s.open(data)
+	if err != nil {
+		return nil, err
+	}
+	var snap Snapshot
+	if err := json.Unmarshal(plain, &snap); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot manifest: %w", err)
+	}
+	return &snap, nil
+}
+
+// List returns snapshots of one project (all projects when projectID is 0), optionally
+// limited to one MR, newest first
+func (s *Store) List(projectID, mrIID int) ([]Summary, error) {
+	prefix := manifestPrefix
+	if projectID > 0 {
+		prefix += strconv.Itoa(projectID) + "/"
+		if mrIID > 0 {
+			prefix += strconv.Itoa(mrIID) + "/"
+		}
+	}
+	keys, err := s.backend.Keys(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make([]Summary, 0, len(keys))
+	for _, key := range keys {
+		data, found, err := s.backend.Get(key)
+		if err != nil || !found {
+			continue
+		}
+		snap, err := s.decodeManifest(data)
+		if err != nil {
+			logging.Warn("Skipping unreadable snapshot", zap.String("snapshot_id", idFromKey(key)), zap.Error(err))
+			continue
+		}
+		if mrIID > 0 && snap.MRIID != mrIID {
+			continue
+		}
+		summaries = append(summaries, Summary{
+			ID:         snap.ID,
+			ProjectID:  snap.ProjectID,
+			MRIID:      snap.MRIID,
+			CapturedAt: snap.CapturedAt,
+			Decision:   snap.Decision.Type,
+		})
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].CapturedAt.After(summaries[j].CapturedAt)
+	})
+	return summaries, nil
+}
+
+// Prune deletes snapshots older than the retention period and blobs no snapshot references.
+// It returns the number of deleted snapshots and blobs.
+func (s *Store) Prune() (int, int, error) {
+	if s == nil {
+		return 0, 0, nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	keys, err := s.backend.Keys(manifestPrefix)
+	if err != nil {
+		return 0, 0, err
+	}
+	cutoff := s.now().Add(-s.retention)
+	referenced := make(map[string]bool)
+	snapshots := 0
+	for _, key := range keys {
+		nanos, err := strconv.ParseInt(key[strings.LastIndex(key, "/")+1:], 10, 64)
+		if err == nil && s.retention > 0 && time.Unix(0, nanos).Before(cutoff) {
+			if err := s.backend.Delete(key); err != nil {
+				return snapshots, 0, err
+			}
+			snapshots++
+			continue
+		}
+
+		data, found, err := s.backend.Get(key)
+		if err != nil {
+			return snapshots, 0, err
+		}
+		if !found {
+			continue
+		}
+		snap, err := s.decodeManifest(data)
+		if err != nil {
+			// Keep every blob while a manifest cannot be read (e.g. during key rotation)
+			return snapshots, 0, fmt.Errorf("cannot prune blobs: %w", err)
+		}
+		for _, file := range snap.Files {
+			referenced[file.Blob] = true
+		}
+	}
+
+	blobKeys, err := s.backend.Keys(blobPrefix)
+	if err != nil {
+		return snapshots, 0, err
+	}
+	blobs := 0
+	for _, key := range blobKeys {
+		if referenced[strings.TrimPrefix(key, blobPrefix)] {
+			continue
+		}
+		if err := s.backend.Delete(key); err != nil {
+			return snapshots, blobs, err
+		}
+		blobs++
+	}
+	return snapshots, blobs, nil
+}
+
+// Start prunes expired snapshots every interval until Stop is called
+func (s *Store) Start(interval time.Duration) {
+	if s == nil || interval <= 0 {
+		return
+	}
+	s.mu.Lock()
+	if s.stop != nil {
+		s.mu.Unlock()
+		return
+	}
+	s.stop = make(chan struct{})
+	stop := s.stop
+	s.mu.Unlock()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				snapshots, blobs, err := s.Prune()
+				if err != nil {
+					logging.Warn("Snapshot pruning failed", zap.Error(err))
+				} else if snapshots > 0 || blobs > 0 {
+					logging.Info("Pruned expired snapshots and unreferenced blobs",
+						zap.Int("snapshots", snapshots),
+						zap.Int("blobs", blobs))
+				}
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the background pruning started by Start
+func (s *Store) Stop() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	stop := s.stop
+	s.stop = nil
+	s.mu.Unlock()
+	if stop != nil {
+		close(stop)
+		s.wg.Wait()
+	}
+}
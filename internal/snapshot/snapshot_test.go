@@ -0,0 +1,148 @@
+package snapshot
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+	"github.com/stretchr/testify/assert"
+)
+
+var testKey = base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+
+func newTestSnapshot(projectID, mrIID int, content string) (*Snapshot, map[string][]byte) {
+	snap := &Snapshot{
+		ProjectID: projectID,
+		MRIID:     mrIID,
+		Files:     []FileRecord{{ProjectID: projectID, Path: "product.yaml", Ref: "feature"}},
+		Decision:  shared.Decision{Type: shared.Approve, Reason: "ok"},
+	}
+	return snap, map[string][]byte{fileKey(projectID, "product.yaml", "feature"): []byte(content)}
+}
+
+func TestStore_SaveLoadList(t *testing.T) {
+	backend := store.NewMemoryStore()
+	s, err := NewStore(backend, "", 0, 0)
+	assert.NoError(t, err)
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+
+	first, contents := newTestSnapshot(1, 10, "name: a")
+	assert.NoError(t, s.Save(first, contents))
+	now = now.Add(time.Minute)
+	second, contents := newTestSnapshot(1, 10, "name: a")
+	assert.NoError(t, s.Save(second, contents))
+	now = now.Add(time.Minute)
+	other, contents := newTestSnapshot(2, 20, "name: b")
+	assert.NoError(t, s.Save(other, contents))
+
+	// Identical contents are stored once
+	blobs, _ := backend.Keys(blobPrefix)
+	assert.Len(t, blobs, 2)
+	assert.Equal(t, first.Files[0].Blob, second.Files[0].Blob)
+
+	loaded, err := s.Load(second.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 10, loaded.MRIID)
+	content, err := s.Blob(loaded.Files[0].Blob)
+	assert.NoError(t, err)
+	assert.Equal(t, "name: a", string(content))
+
+	summaries, err := s.List(1, 10)
+	assert.NoError(t, err)
+	assert.Len(t, summaries, 2)
+	assert.Equal(t, second.ID, summaries[0].ID)
+
+	summaries, err = s.List(0, 0)
+	assert.NoError(t, err)
+	assert.Len(t, summaries, 3)
+
+	_, err = s.Load("1-10")
+	assert.Error(t, err)
+	_, err = s.Load("1-10-99")
+	assert.Error(t, err)
+}
+
+func TestStore_Encryption(t *testing.T) {
+	backend := store.NewMemoryStore()
+	s, err := NewStore(backend, testKey, 0, 0)
+	assert.NoError(t, err)
+	assert.True(t, s.Encrypted())
+
+	snap, contents := newTestSnapshot(1, 10, "secret: value")
+	snap.MRInfo.Title = "Sensitive title"
+	assert.NoError(t, s.Save(snap, contents))
+
+	keys, _ := backend.Keys("snapshots/")
+	for _, key := range keys {
+		data, _, _ := backend.Get(key)
+		assert.NotContains(t, string(data), "secret: value")
+		assert.NotContains(t, string(data), "Sensitive title")
+	}
+
+	loaded, err := s.Load(snap.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Sensitive title", loaded.MRInfo.Title)
+	content, err := s.Blob(loaded.Files[0].Blob)
+	assert.NoError(t, err)
+	assert.Equal(t, "secret: value", string(content))
+
+	// A different key cannot read the snapshot
+	otherKey := base64.StdEncoding.EncodeToString([]byte(strings.Repeat("x", 32)))
+	other, err := NewStore(backend, otherKey, 0, 0)
+	assert.NoError(t, err)
+	_, err = other.Load(snap.ID)
+	assert.Error(t, err)
+}
+
+func TestNewStore_InvalidKey(t *testing.T) {
+	_, err := NewStore(store.NewMemoryStore(), "not base64!", 0, 0)
+	assert.Error(t, err)
+	_, err = NewStore(store.NewMemoryStore(), base64.StdEncoding.EncodeToString([]byte("short")), 0, 0)
+	assert.Error(t, err)
+}
+
+func TestStore_MaxPerMRAndPrune(t *testing.T) {
+	backend := store.NewMemoryStore()
+	s, err := NewStore(backend, "", 24*time.Hour, 2)
+	assert.NoError(t, err)
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+
+	for i, content := range []string{"v1", "v2", "v3"} {
+		now = now.Add(time.Duration(i+1) * time.Minute)
+		snap, contents := newTestSnapshot(1, 10, content)
+		assert.NoError(t, s.Save(snap, contents))
+	}
+	summaries, _ := s.List(1, 10)
+	assert.Len(t, summaries, 2)
+
+	// v1 is no longer referenced
+	snapshots, blobs, err := s.Prune()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, snapshots)
+	assert.Equal(t, 1, blobs)
+
+	// Everything expires after the retention period
+	now = now.Add(48 * time.Hour)
+	snapshots, blobs, err = s.Prune()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, snapshots)
+	assert.Equal(t, 2, blobs)
+	keys, _ := backend.Keys("snapshots/")
+	assert.Empty(t, keys)
+}
+
+func TestStore_NilIsNoop(t *testing.T) {
+	var s *Store
+	snap, contents := newTestSnapshot(1, 10, "v1")
+	assert.NoError(t, s.Save(snap, contents))
+	_, _, err := s.Prune()
+	assert.NoError(t, err)
+	assert.False(t, s.Encrypted())
+	s.Start(time.Minute)
+	s.Stop()
+}
@@ -0,0 +1,160 @@
+package stats
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+	"go.uber.org/zap"
+)
+
+// State store namespaces for comment events and first decisions
+const (
+	eventPrefix         = "stats/comments/"
+	firstDecisionPrefix = "stats/first_decision/"
+)
+
+// Kinds of naysayer activity counted in comment statistics
+const (
+	KindApproval     = "approval"      // MR auto-approved
+	KindManualReview = "manual_review" // Manual review comment posted
+	KindRebase       = "rebase"        // Auto-rebase comment posted
+	KindStale        = "stale"         // Stale MR closure comment posted
+)
+
+// Event is one recorded naysayer comment or decision
+type Event struct {
+	Kind      string    `json:"kind"`
+	ProjectID int       `json:"project_id"`
+	MRIID     int       `json:"mr_iid"`
+	At        time.Time `json:"at"`
+}
+
+// firstDecision records when an MR was opened and first decided on
+type firstDecision struct {
+	ProjectID int       `json:"project_id"`
+	MRIID     int       `json:"mr_iid"`
+	CreatedAt time.Time `json:"created_at"`
+	DecidedAt time.Time `json:"decided_at"`
+}
+
+// timestampLayouts are the formats GitLab uses for created_at in API responses and webhooks
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05 MST",
+	"2006-01-02 15:04:05 -0700",
+}
+
+// ParseTimestamp parses a GitLab timestamp; it returns the zero time for empty or unknown formats
+func ParseTimestamp(value string) time.Time {
+	for _, layout := range timestampLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// Recorder persists naysayer comment events for the comment statistics endpoint.
+// A nil Recorder ignores all events.
+type Recorder struct {
+	store store.Store
+	now   func() time.Time
+
+	mu         sync.Mutex
+	seq        int
+	objectives *Objectives // Optional: SLO compliance in reports
+}
+
+// NewRecorder creates a recorder persisting events in st
+func NewRecorder(st store.Store) *Recorder {
+	return &Recorder{store: st, now: time.Now}
+}
+
+// eventKey orders events by time within a project; seq keeps keys unique within one nanosecond
+func eventKey(projectID int, at time.Time, seq int, mrIID int, kind string) string {
+	return fmt.Sprintf("%s%d/%020d-%06d-%d-%s", eventPrefix, projectID, at.UnixNano(), seq%1000000, mrIID, kind)
+}
+
+// firstDecisionKey returns the state store key of an MR's first decision
+func firstDecisionKey(projectID, mrIID int) string {
+	return fmt.Sprintf("%s%d/%d", firstDecisionPrefix, projectID, mrIID)
+}
+
+// Record stores a comment event of the given kind
+func (r *Recorder) Record(kind string, projectID, mrIID int) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.record(kind, projectID, mrIID, r.now())
+}
+
+// RecordDecision stores an approval or manual review event. The first decision per MR is kept
+// with the MR creation time (GitLab created_at) to measure time to first decision.
+func (r *Recorder) RecordDecision(kind string, projectID, mrIID int, mrCreatedAt string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	r.record(kind, projectID, mrIID, now)
+
+	key := firstDecisionKey(projectID, mrIID)
+	var existing firstDecision
+	found, err := store.GetJSON(r.store, key, &existing)
+	if err != nil || found {
+		return
+	}
+	createdAt := ParseTimestamp(mrCreatedAt)
+	if createdAt.IsZero() {
+		return
+	}
+	if err := store.PutJSON(r.store, key, firstDecision{ProjectID: projectID, MRIID: mrIID, CreatedAt: createdAt, DecidedAt: now}); err != nil {
+		logging.Warn("Failed to record first decision",
+			zap.Int("mr_iid", mrIID),
+			zap.Int("project_id", projectID),
+			zap.Error(err))
+	}
+}
+
+// record writes one event; callers hold r.mu
+func (r *Recorder) record(kind string, projectID, mrIID int, at time.Time) {
+	r.seq++
+	event := Event{Kind: kind, ProjectID: projectID, MRIID: mrIID, At: at}
+	if err := store.PutJSON(r.store, eventKey(projectID, at, r.seq, mrIID, kind), event); err != nil {
+		logging.Warn("Failed to record comment event",
+			zap.String("kind", kind),
+			zap.Int("mr_iid", mrIID),
+			zap.Int("project_id", projectID),
+			zap.Error(err))
+	}
+}
+
+// projectPrefix returns the key prefix for one project, or all projects when projectID is 0
+func projectPrefix(prefix string, projectID int) string {
+	if projectID == 0 {
+		return prefix
+	}
+	return prefix + strconv.Itoa(projectID) + "/"
+}
+
+// keyTime extracts the event time from an event key without loading the event
+func keyTime(key string) (time.Time, bool) {
+	idx := strings.LastIndex(key, "/")
+	if idx < 0 {
+		return time.Time{}, false
+	}
+	nanos, err := strconv.ParseInt(strings.SplitN(key[idx+1:], "-", 2)[0], 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(0, nanos), true
+}
@@ -0,0 +1,90 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRecorder(now *time.Time) *Recorder {
+	recorder := NewRecorder(store.NewMemoryStore())
+	recorder.now = func() time.Time { return *now }
+	return recorder
+}
+
+func TestParseTimestamp(t *testing.T) {
+	expected := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	assert.True(t, expected.Equal(ParseTimestamp("2024-03-01T10:00:00Z")))
+	assert.True(t, expected.Equal(ParseTimestamp("2024-03-01T10:00:00.000Z")))
+	assert.True(t, expected.Equal(ParseTimestamp("2024-03-01 10:00:00 UTC")))
+	assert.True(t, ParseTimestamp("").IsZero())
+	assert.True(t, ParseTimestamp("yesterday").IsZero())
+}
+
+func TestRecorder_Summarize(t *testing.T) {
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	now := start
+	recorder := newTestRecorder(&now)
+
+	// Project 1: MR 10 opened 1h before approval, MR 11 opened 3h before manual review then approved
+	recorder.RecordDecision(KindApproval, 1, 10, "2024-03-01T11:00:00Z")
+	recorder.RecordDecision(KindManualReview, 1, 11, "2024-03-01 09:00:00 UTC")
+	now = start.Add(30 * time.Minute)
+	recorder.RecordDecision(KindApproval, 1, 11, "2024-03-01 09:00:00 UTC")
+	recorder.Record(KindRebase, 1, 10)
+
+	// Project 2: MR 20 needs manual review, MR opened 5h earlier; a stale closure
+	recorder.RecordDecision(KindManualReview, 2, 20, "2024-03-01T07:30:00Z")
+	recorder.Record(KindStale, 2, 21)
+
+	// Outside the range
+	now = start.Add(48 * time.Hour)
+	recorder.RecordDecision(KindManualReview, 1, 10, "2024-03-01T11:00:00Z")
+
+	report, err := recorder.Summarize(0, start, start.Add(24*time.Hour))
+	assert.NoError(t, err)
+	assert.Len(t, report.Projects, 2)
+
+	p1 := report.Projects[0]
+	assert.Equal(t, 1, p1.ProjectID)
+	assert.Equal(t, 2, p1.Approvals)
+	assert.Equal(t, 1, p1.ManualReviews)
+	assert.Equal(t, 1, p1.RebaseComments)
+	assert.Equal(t, 2, p1.MRsDecided)
+	assert.Equal(t, 1.0, p1.AutoApprovalRate)
+	assert.Equal(t, 2, p1.FirstDecisions)
+	assert.Equal(t, (2 * time.Hour).Seconds(), p1.MedianTimeToFirstDecisionSeconds)
+
+	p2 := report.Projects[1]
+	assert.Equal(t, 2, p2.ProjectID)
+	assert.Equal(t, 1, p2.StaleComments)
+	assert.Equal(t, 0.0, p2.AutoApprovalRate)
+
+	total := report.Total
+	assert.Equal(t, 2, total.Approvals)
+	assert.Equal(t, 2, total.ManualReviews)
+	assert.Equal(t, 3, total.MRsDecided)
+	assert.InDelta(t, 2.0/3.0, total.AutoApprovalRate, 0.0001)
+	assert.Equal(t, (3 * time.Hour).Seconds(), total.MedianTimeToFirstDecisionSeconds)
+
+	// Single project
+	report, err = recorder.Summarize(2, start, start.Add(24*time.Hour))
+	assert.NoError(t, err)
+	assert.Len(t, report.Projects, 1)
+	assert.Equal(t, 2, report.Total.ProjectID)
+	assert.Equal(t, 1, report.Total.ManualReviews)
+}
+
+func TestRecorder_NilIsNoop(t *testing.T) {
+	var recorder *Recorder
+	recorder.Record(KindRebase, 1, 2)
+	recorder.RecordDecision(KindApproval, 1, 2, "2024-03-01T11:00:00Z")
+}
+
+func TestMedian(t *testing.T) {
+	assert.Equal(t, time.Duration(0), median(nil))
+	assert.Equal(t, 2*time.Second, median([]time.Duration{3 * time.Second, time.Second, 2 * time.Second}))
+	assert.Equal(t, 2500*time.Millisecond, median([]time.Duration{time.Second, 2 * time.Second, 3 * time.Second, 4 * time.Second}))
+}
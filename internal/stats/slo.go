@@ -0,0 +1,140 @@
+package stats
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+	"go.uber.org/zap"
+)
+
+// latencyPrefix is the state store namespace for webhook-to-decision latencies
+const latencyPrefix = "stats/decision_latency/"
+
+// Names of the time-to-decision objectives
+const (
+	ObjectiveDecisionLatency = "decision_latency" // Webhook receipt to decision posted
+	ObjectiveFirstDecision   = "first_decision"   // MR opened to first naysayer decision
+)
+
+// Objective is a latency objective: at least Target of the events complete within Threshold
+type Objective struct {
+	Threshold time.Duration
+	Target    float64 // Fraction, e.g. 0.95
+}
+
+// Objectives are the time-to-decision objectives evaluated in reports
+type Objectives struct {
+	DecisionLatency Objective
+	FirstDecision   Objective
+}
+
+// ObjectivesFromConfig converts the SLO configuration into objectives
+func ObjectivesFromConfig(cfg config.SLOConfig) Objectives {
+	target := cfg.TargetPercent / 100
+	return Objectives{
+		DecisionLatency: Objective{Threshold: time.Duration(cfg.DecisionLatencySeconds) * time.Second, Target: target},
+		FirstDecision:   Objective{Threshold: time.Duration(cfg.FirstDecisionSeconds) * time.Second, Target: target},
+	}
+}
+
+// SLOStatus is the compliance of the events in a report range with an objective
+type SLOStatus struct {
+	ThresholdSeconds  float64 `json:"threshold_seconds"`
+	TargetPercent     float64 `json:"target_percent"`
+	Events            int     `json:"events"`
+	WithinThreshold   int     `json:"within_threshold"`
+	CompliancePercent float64 `json:"compliance_percent"`
+	P95Seconds        float64 `json:"p95_seconds"`
+	Met               bool    `json:"met"`
+	// BurnRate is how fast the error budget is spent: 1 spends exactly the budget over the
+	// range. With a 100% target there is no budget and it is the number of misses.
+	BurnRate float64 `json:"burn_rate"`
+}
+
+// Evaluate computes the compliance of latencies with the objective
+func (o Objective) Evaluate(latencies []time.Duration) SLOStatus {
+	status := SLOStatus{
+		ThresholdSeconds:  o.Threshold.Seconds(),
+		TargetPercent:     o.Target * 100,
+		Events:            len(latencies),
+		CompliancePercent: 100,
+		Met:               true,
+	}
+	if len(latencies) == 0 {
+		return status
+	}
+
+	for _, latency := range latencies {
+		if latency <= o.Threshold {
+			status.WithinThreshold++
+		}
+	}
+	compliance := float64(status.WithinThreshold) / float64(status.Events)
+	status.CompliancePercent = compliance * 100
+	status.Met = compliance >= o.Target
+	status.P95Seconds = percentile(latencies, 0.95).Seconds()
+
+	missed := status.Events - status.WithinThreshold
+	if budget := 1 - o.Target; budget > 0 {
+		status.BurnRate = (1 - compliance) / budget
+	} else {
+		status.BurnRate = float64(missed)
+	}
+	return status
+}
+
+// latencyEvent is one recorded webhook-to-decision latency
+type latencyEvent struct {
+	ProjectID int     `json:"project_id"`
+	MRIID     int     `json:"mr_iid"`
+	Seconds   float64 `json:"seconds"`
+}
+
+// SetObjectives enables SLO compliance in reports
+func (r *Recorder) SetObjectives(objectives Objectives) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.objectives = &objectives
+}
+
+// RecordDecisionLatency stores the time from webhook receipt to the decision being posted
+func (r *Recorder) RecordDecisionLatency(projectID, mrIID int, receivedAt time.Time) {
+	if r == nil || receivedAt.IsZero() {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	r.seq++
+	key := fmt.Sprintf("%s%d/%020d-%06d-%d", latencyPrefix, projectID, now.UnixNano(), r.seq%1000000, mrIID)
+	event := latencyEvent{ProjectID: projectID, MRIID: mrIID, Seconds: now.Sub(receivedAt).Seconds()}
+	if err := store.PutJSON(r.store, key, event); err != nil {
+		logging.Warn("Failed to record decision latency",
+			zap.Int("mr_iid", mrIID),
+			zap.Int("project_id", projectID),
+			zap.Error(err))
+	}
+}
+
+// percentile returns the p-th percentile (nearest rank) of values
+func percentile(values []time.Duration, p float64) time.Duration {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]time.Duration(nil), values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return sorted[rank]
+}
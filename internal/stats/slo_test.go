@@ -0,0 +1,83 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObjective_Evaluate(t *testing.T) {
+	objective := Objective{Threshold: 30 * time.Second, Target: 0.9}
+
+	empty := objective.Evaluate(nil)
+	assert.True(t, empty.Met)
+	assert.Equal(t, 100.0, empty.CompliancePercent)
+	assert.Equal(t, 0.0, empty.BurnRate)
+
+	latencies := []time.Duration{}
+	for i := 0; i < 8; i++ {
+		latencies = append(latencies, 5*time.Second)
+	}
+	latencies = append(latencies, 45*time.Second, 60*time.Second)
+
+	status := objective.Evaluate(latencies)
+	assert.Equal(t, 10, status.Events)
+	assert.Equal(t, 8, status.WithinThreshold)
+	assert.InDelta(t, 80.0, status.CompliancePercent, 0.001)
+	assert.False(t, status.Met)
+	assert.InDelta(t, 2.0, status.BurnRate, 0.001)
+	assert.Equal(t, 60.0, status.P95Seconds)
+
+	strict := Objective{Threshold: 30 * time.Second, Target: 1}.Evaluate(latencies)
+	assert.Equal(t, 2.0, strict.BurnRate)
+}
+
+func TestObjectivesFromConfig(t *testing.T) {
+	objectives := ObjectivesFromConfig(config.SLOConfig{DecisionLatencySeconds: 30, FirstDecisionSeconds: 300, TargetPercent: 95})
+	assert.Equal(t, 30*time.Second, objectives.DecisionLatency.Threshold)
+	assert.Equal(t, 5*time.Minute, objectives.FirstDecision.Threshold)
+	assert.InDelta(t, 0.95, objectives.DecisionLatency.Target, 0.0001)
+}
+
+func TestRecorder_SummarizeSLO(t *testing.T) {
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	now := start
+	recorder := newTestRecorder(&now)
+	recorder.SetObjectives(Objectives{
+		DecisionLatency: Objective{Threshold: 30 * time.Second, Target: 0.95},
+		FirstDecision:   Objective{Threshold: time.Hour, Target: 0.95},
+	})
+
+	recorder.RecordDecisionLatency(1, 10, start.Add(-10*time.Second))
+	recorder.RecordDecisionLatency(1, 11, start.Add(-50*time.Second))
+	recorder.RecordDecisionLatency(1, 12, time.Time{}) // not received via webhook, ignored
+	recorder.RecordDecision(KindApproval, 1, 10, "2024-03-01T11:30:00Z")
+
+	report, err := recorder.Summarize(0, start.Add(-time.Hour), start.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Len(t, report.Projects, 1)
+
+	latency := report.Projects[0].DecisionLatencySLO
+	assert.NotNil(t, latency)
+	assert.Equal(t, 2, latency.Events)
+	assert.Equal(t, 1, latency.WithinThreshold)
+	assert.False(t, latency.Met)
+	assert.Equal(t, 50.0, latency.P95Seconds)
+
+	first := report.Total.FirstDecisionSLO
+	assert.NotNil(t, first)
+	assert.Equal(t, 1, first.Events)
+	assert.True(t, first.Met)
+}
+
+func TestRecorder_SummarizeWithoutObjectives(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	recorder := newTestRecorder(&now)
+	recorder.RecordDecisionLatency(1, 10, now.Add(-time.Second))
+
+	report, err := recorder.Summarize(0, now.Add(-time.Hour), now.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Nil(t, report.Total.DecisionLatencySLO)
+}
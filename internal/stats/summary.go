@@ -0,0 +1,182 @@
+package stats
+
+import (
+	"sort"
+	"time"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+)
+
+// ProjectSummary aggregates naysayer activity for one project (or all projects in Report.Total)
+type ProjectSummary struct {
+	ProjectID      int `json:"project_id,omitempty"`
+	Approvals      int `json:"approvals"`
+	ManualReviews  int `json:"manual_reviews"`
+	RebaseComments int `json:"rebase_comments"`
+	StaleComments  int `json:"stale_comments"`
+	// MRsDecided counts distinct MRs with an approval or manual review decision in the range
+	MRsDecided int `json:"mrs_decided"`
+	// AutoApprovalRate is the share of decided MRs whose latest decision in the range was an approval
+	AutoApprovalRate float64 `json:"auto_approval_rate"`
+	// MedianTimeToFirstDecisionSeconds covers MRs first decided in the range
+	MedianTimeToFirstDecisionSeconds float64 `json:"median_time_to_first_decision_seconds"`
+	FirstDecisions                   int     `json:"first_decisions"`
+	// Time-to-decision SLO compliance, when objectives are configured
+	DecisionLatencySLO *SLOStatus `json:"decision_latency_slo,omitempty"`
+	FirstDecisionSLO   *SLOStatus `json:"first_decision_slo,omitempty"`
+}
+
+// Report summarizes naysayer comment activity for a time range
+type Report struct {
+	From     time.Time        `json:"from"`
+	To       time.Time        `json:"to"`
+	Projects []ProjectSummary `json:"projects"`
+	Total    ProjectSummary   `json:"total"`
+}
+
+// accumulator collects events of one project (or all projects) before summarizing
+type accumulator struct {
+	summary       ProjectSummary
+	lastDecision  map[[2]int]string // (project, MR) -> latest decision kind
+	decisionTimes []time.Duration
+	latencies     []time.Duration // Webhook receipt to decision posted
+}
+
+func newAccumulator(projectID int) *accumulator {
+	return &accumulator{
+		summary:      ProjectSummary{ProjectID: projectID},
+		lastDecision: make(map[[2]int]string),
+	}
+}
+
+func (a *accumulator) addEvent(event Event) {
+	switch event.Kind {
+	case KindApproval:
+		a.summary.Approvals++
+	case KindManualReview:
+		a.summary.ManualReviews++
+	case KindRebase:
+		a.summary.RebaseComments++
+	case KindStale:
+		a.summary.StaleComments++
+	}
+	if event.Kind == KindApproval || event.Kind == KindManualReview {
+		a.lastDecision[[2]int{event.ProjectID, event.MRIID}] = event.Kind
+	}
+}
+
+func (a *accumulator) finish(objectives *Objectives) ProjectSummary {
+	summary := a.summary
+	summary.MRsDecided = len(a.lastDecision)
+	if summary.MRsDecided > 0 {
+		approved := 0
+		for _, kind := range a.lastDecision {
+			if kind == KindApproval {
+				approved++
+			}
+		}
+		summary.AutoApprovalRate = float64(approved) / float64(summary.MRsDecided)
+	}
+	summary.FirstDecisions = len(a.decisionTimes)
+	summary.MedianTimeToFirstDecisionSeconds = median(a.decisionTimes).Seconds()
+	if objectives != nil {
+		decisionLatency := objectives.DecisionLatency.Evaluate(a.latencies)
+		firstDecision := objectives.FirstDecision.Evaluate(a.decisionTimes)
+		summary.DecisionLatencySLO = &decisionLatency
+		summary.FirstDecisionSLO = &firstDecision
+	}
+	return summary
+}
+
+// Summarize reports activity recorded in [from, to) for one project, or per project when projectID is 0
+func (r *Recorder) Summarize(projectID int, from, to time.Time) (*Report, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	projects := make(map[int]*accumulator)
+	total := newAccumulator(0)
+	project := func(id int) *accumulator {
+		if projects[id] == nil {
+			projects[id] = newAccumulator(id)
+		}
+		return projects[id]
+	}
+
+	keys, err := r.store.Keys(projectPrefix(eventPrefix, projectID))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		at, ok := keyTime(key)
+		if !ok || at.Before(from) || !at.Before(to) {
+			continue
+		}
+		var event Event
+		if found, err := store.GetJSON(r.store, key, &event); err != nil || !found {
+			continue
+		}
+		project(event.ProjectID).addEvent(event)
+		total.addEvent(event)
+	}
+
+	keys, err = r.store.Keys(projectPrefix(firstDecisionPrefix, projectID))
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		var decision firstDecision
+		if found, err := store.GetJSON(r.store, key, &decision); err != nil || !found {
+			continue
+		}
+		if decision.DecidedAt.Before(from) || !decision.DecidedAt.Before(to) {
+			continue
+		}
+		elapsed := decision.DecidedAt.Sub(decision.CreatedAt)
+		project(decision.ProjectID).decisionTimes = append(project(decision.ProjectID).decisionTimes, elapsed)
+		total.decisionTimes = append(total.decisionTimes, elapsed)
+	}
+
+	keys, err = r.store.Keys(projectPrefix(latencyPrefix, projectID))
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		at, ok := keyTime(key)
+		if !ok || at.Before(from) || !at.Before(to) {
+			continue
+		}
+		var event latencyEvent
+		if found, err := store.GetJSON(r.store, key, &event); err != nil || !found {
+			continue
+		}
+		latency := time.Duration(event.Seconds * float64(time.Second))
+		project(event.ProjectID).latencies = append(project(event.ProjectID).latencies, latency)
+		total.latencies = append(total.latencies, latency)
+	}
+
+	report := &Report{From: from, To: to, Projects: []ProjectSummary{}}
+	for _, acc := range projects {
+		report.Projects = append(report.Projects, acc.finish(r.objectives))
+	}
+	sort.Slice(report.Projects, func(i, j int) bool {
+		return report.Projects[i].ProjectID < report.Projects[j].ProjectID
+	})
+	report.Total = total.finish(r.objectives)
+	report.Total.ProjectID = projectID
+	return report, nil
+}
+
+// median returns the median duration, or zero for no values
+func median(values []time.Duration) time.Duration {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]time.Duration(nil), values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[mid]
+	}
+	return (sorted[mid-1] + sorted[mid]) / 2
+}
@@ -0,0 +1,46 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDirStore_PutGetDeleteKeys(t *testing.T) {
+	s, err := NewDirStore(t.TempDir())
+	assert.NoError(t, err)
+
+	_, ok, err := s.Get("snapshots/blobs/missing")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	assert.NoError(t, s.Put("snapshots/blobs/abc", []byte("one")))
+	assert.NoError(t, s.Put("snapshots/manifests/1/2/3", []byte("two")))
+	assert.NoError(t, s.Put("other", []byte("three")))
+
+	value, ok, err := s.Get("snapshots/blobs/abc")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "one", string(value))
+
+	keys, err := s.Keys("snapshots/")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"snapshots/blobs/abc", "snapshots/manifests/1/2/3"}, keys)
+
+	assert.NoError(t, s.Delete("snapshots/blobs/abc"))
+	assert.NoError(t, s.Delete("snapshots/blobs/abc"))
+	_, ok, _ = s.Get("snapshots/blobs/abc")
+	assert.False(t, ok)
+}
+
+func TestDirStore_InvalidKeys(t *testing.T) {
+	s, err := NewDirStore(t.TempDir())
+	assert.NoError(t, err)
+
+	for _, key := range []string{"", "../escape", "a//b", "a/./b"} {
+		assert.Error(t, s.Put(key, []byte("x")), key)
+	}
+
+	_, err = NewDirStore("")
+	assert.Error(t, err)
+}
@@ -0,0 +1,45 @@
+package store
+
+import "strings"
+
+// PrefixStore namespaces the keys of another Store, e.g. to keep the state of different
+// GitLab instances whose project IDs overlap apart
+type PrefixStore struct {
+	store  Store
+	prefix string
+}
+
+// Verify that PrefixStore implements Store interface
+var _ Store = (*PrefixStore)(nil)
+
+// WithPrefix returns a Store keeping its keys below prefix in st
+func WithPrefix(st Store, prefix string) *PrefixStore {
+	return &PrefixStore{store: st, prefix: prefix}
+}
+
+// Get returns the value stored under key and whether it was found
+func (s *PrefixStore) Get(key string) ([]byte, bool, error) {
+	return s.store.Get(s.prefix + key)
+}
+
+// Put stores value under key
+func (s *PrefixStore) Put(key string, value []byte) error {
+	return s.store.Put(s.prefix+key, value)
+}
+
+// Delete removes key
+func (s *PrefixStore) Delete(key string) error {
+	return s.store.Delete(s.prefix + key)
+}
+
+// Keys returns the keys starting with prefix, without the store's own prefix
+func (s *PrefixStore) Keys(prefix string) ([]string, error) {
+	keys, err := s.store.Keys(s.prefix + prefix)
+	if err != nil {
+		return nil, err
+	}
+	for i, key := range keys {
+		keys[i] = strings.TrimPrefix(key, s.prefix)
+	}
+	return keys, nil
+}
@@ -0,0 +1,33 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrefixStore(t *testing.T) {
+	backing := NewMemoryStore()
+	st := WithPrefix(backing, "instances/onprem/")
+
+	assert.NoError(t, st.Put("autorebase/1", []byte("a")))
+	assert.NoError(t, backing.Put("autorebase/1", []byte("default")))
+
+	value, found, err := st.Get("autorebase/1")
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, "a", string(value))
+
+	keys, err := st.Keys("autorebase/")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"autorebase/1"}, keys)
+
+	keys, _ = backing.Keys("")
+	assert.Equal(t, []string{"autorebase/1", "instances/onprem/autorebase/1"}, keys)
+
+	assert.NoError(t, st.Delete("autorebase/1"))
+	_, found, _ = st.Get("autorebase/1")
+	assert.False(t, found)
+	_, found, _ = backing.Get("autorebase/1")
+	assert.True(t, found)
+}
@@ -0,0 +1,109 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Store is a small key-value state store used by background jobs to keep
+// state between webhook deliveries (snapshots, cursors, counters)
+type Store interface {
+	Get(key string) ([]byte, bool, error)
+	Put(key string, value []byte) error
+	Delete(key string) error
+	Keys(prefix string) ([]string, error)
+}
+
+// MemoryStore is an in-process Store implementation
+type MemoryStore struct {
+	mu   sync.RWMutex
+	data map[string][]byte
+}
+
+// Verify that MemoryStore implements Store interface
+var _ Store = (*MemoryStore)(nil)
+
+// NewMemoryStore creates a new empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		data: make(map[string][]byte),
+	}
+}
+
+// Get returns the value stored under key and whether it was found
+func (s *MemoryStore) Get(key string) ([]byte, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	value, ok := s.data[key]
+	if !ok {
+		return nil, false, nil
+	}
+
+	// Return a copy so callers cannot mutate stored state
+	out := make([]byte, len(value))
+	copy(out, value)
+	return out, true, nil
+}
+
+// Put stores value under key, replacing any previous value
+func (s *MemoryStore) Put(key string, value []byte) error {
+	if key == "" {
+		return fmt.Errorf("store key cannot be empty")
+	}
+
+	stored := make([]byte, len(value))
+	copy(stored, value)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data[key] = stored
+	return nil
+}
+
+// Delete removes key from the store (no-op if missing)
+func (s *MemoryStore) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.data, key)
+	return nil
+}
+
+// Keys returns all keys with the given prefix in sorted order
+func (s *MemoryStore) Keys(prefix string) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	keys := make([]string, 0)
+	for key := range s.data {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// GetJSON loads the value under key into out. Returns false if the key is missing.
+func GetJSON(s Store, key string, out interface{}) (bool, error) {
+	data, ok, err := s.Get(key)
+	if err != nil || !ok {
+		return false, err
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return false, fmt.Errorf("failed to decode stored value for %s: %w", key, err)
+	}
+	return true, nil
+}
+
+// PutJSON stores value under key as JSON
+func PutJSON(s Store, key string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode value for %s: %w", key, err)
+	}
+	return s.Put(key, data)
+}
@@ -0,0 +1,71 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryStore_PutGetDelete(t *testing.T) {
+	s := NewMemoryStore()
+
+	_, ok, err := s.Get("missing")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	assert.NoError(t, s.Put("a", []byte("value")))
+	value, ok, err := s.Get("a")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "value", string(value))
+
+	// Mutating the returned slice must not change stored state
+	value[0] = 'X'
+	value, _, _ = s.Get("a")
+	assert.Equal(t, "value", string(value))
+
+	assert.NoError(t, s.Delete("a"))
+	_, ok, _ = s.Get("a")
+	assert.False(t, ok)
+}
+
+func TestMemoryStore_EmptyKey(t *testing.T) {
+	s := NewMemoryStore()
+	assert.Error(t, s.Put("", []byte("x")))
+}
+
+func TestMemoryStore_Keys(t *testing.T) {
+	s := NewMemoryStore()
+	_ = s.Put("repoindex/1/main", nil)
+	_ = s.Put("repoindex/2/main", nil)
+	_ = s.Put("other/1", nil)
+
+	keys, err := s.Keys("repoindex/")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"repoindex/1/main", "repoindex/2/main"}, keys)
+}
+
+func TestJSONHelpers(t *testing.T) {
+	s := NewMemoryStore()
+
+	type payload struct {
+		Name  string `json:"name"`
+		Count int    `json:"count"`
+	}
+
+	assert.NoError(t, PutJSON(s, "k", payload{Name: "n", Count: 3}))
+
+	var out payload
+	ok, err := GetJSON(s, "k", &out)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, payload{Name: "n", Count: 3}, out)
+
+	ok, err = GetJSON(s, "missing", &out)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	_ = s.Put("bad", []byte("{not json"))
+	_, err = GetJSON(s, "bad", &out)
+	assert.Error(t, err)
+}
@@ -0,0 +1,189 @@
+package synthrepo
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// DefaultEnvironments are the environments every data product is deployed to unless configured
+var DefaultEnvironments = []string{"dev", "preprod", "prod"}
+
+// warehouseSizes are the sizes drawn for generated warehouses
+var warehouseSizes = []string{"XSMALL", "SMALL", "MEDIUM", "LARGE"}
+
+// kinds are the data product kinds and the directories they live in
+var kinds = []struct {
+	dir  string
+	kind string
+}{
+	{"source", "source-aligned"},
+	{"aggregate", "aggregated"},
+}
+
+// Invalid file kinds
+const (
+	InvalidMaskingOrder    = "masking_strategy_order"        // HASH_SHA1 case before UNMASKED
+	InvalidMaskingDataType = "masking_datatype_mismatch"     // datatype differs from the policy name
+	InvalidServiceAccount  = "service_account_name_mismatch" // name differs from the file name
+)
+
+// invalidKinds are applied round-robin to the products selected as invalid
+var invalidKinds = []string{InvalidMaskingOrder, InvalidMaskingDataType, InvalidServiceAccount}
+
+// Options controls the size and shape of a generated repository
+type Options struct {
+	Products     int      // Number of data products
+	Environments []string // Environments per data product (default: DefaultEnvironments)
+	InvalidRatio float64  // Share of data products with one invalid file, 0 to 1
+	Seed         int64    // Seed of the pseudo-random choices; equal options generate equal repositories
+}
+
+// InvalidFile is a generated file that the naysayer rules should send to manual review
+type InvalidFile struct {
+	Path string
+	Kind string
+}
+
+// Repo is a generated dataverse-config repository layout
+type Repo struct {
+	Files   map[string]string // Repository path -> content
+	Invalid []InvalidFile     // Files generated invalid on purpose, sorted by path
+}
+
+// Generate builds a repository of opts.Products data products, each with a product.yaml,
+// masking policy and Astro service account per environment plus a consumer group and
+// developers file. Products are named product000, product001, ... and alternate between
+// source-aligned and aggregated. Service account files live under
+// serviceaccounts/<env>/.
+func Generate(opts Options) (*Repo, error) {
+	if opts.Products < 0 {
+		return nil, fmt.Errorf("number of data products must not be negative, got %d", opts.Products)
+	}
+	if opts.InvalidRatio < 0 || opts.InvalidRatio > 1 {
+		return nil, fmt.Errorf("invalid ratio must be between 0 and 1, got %g", opts.InvalidRatio)
+	}
+	envs := opts.Environments
+	if len(envs) == 0 {
+		envs = DefaultEnvironments
+	}
+	for _, env := range envs {
+		if env == "" || strings.ContainsAny(env, "/_ ") {
+			return nil, fmt.Errorf("invalid environment name %q", env)
+		}
+	}
+
+	rng := rand.New(rand.NewSource(opts.Seed)) // #nosec G404 - reproducible test data needs no cryptographic randomness
+	invalidCount := int(float64(opts.Products)*opts.InvalidRatio + 0.5)
+	invalidProducts := make(map[int]string, invalidCount)
+	for n, i := range rng.Perm(opts.Products)[:invalidCount] {
+		invalidProducts[i] = invalidKinds[n%len(invalidKinds)]
+	}
+
+	repo := &Repo{Files: make(map[string]string)}
+	for i := 0; i < opts.Products; i++ {
+		repo.addProduct(rng, i, envs, invalidProducts[i])
+	}
+	sort.Slice(repo.Invalid, func(i, j int) bool { return repo.Invalid[i].Path < repo.Invalid[j].Path })
+	return repo, nil
+}
+
+// addProduct adds the files of one data product. A non-empty invalid kind breaks one file
+// of the product in the first environment.
+func (r *Repo) addProduct(rng *rand.Rand, index int, envs []string, invalid string) {
+	name := fmt.Sprintf("product%03d", index)
+	kind := kinds[index%len(kinds)]
+	group := fmt.Sprintf("dataverse-%s-%s", kind.dir, name)
+	base := fmt.Sprintf("dataproducts/%s/%s", kind.dir, name)
+
+	r.Files[base+"/developers.yaml"] = fmt.Sprintf("group:\n  owners:\n    - %s-owner\n", name)
+	r.Files[fmt.Sprintf("%s/groups/%s.yaml", base, group)] = groupFile(group, name, envs)
+
+	for n, env := range envs {
+		broken := ""
+		if n == 0 {
+			broken = invalid
+		}
+		serviceAccount := fmt.Sprintf("%s_astro_%s_appuser", name, env)
+
+		productPath := fmt.Sprintf("%s/%s/product.yaml", base, env)
+		r.Files[productPath] = productFile(rng, name, kind.kind, group)
+
+		maskingPath := fmt.Sprintf("%s/%s/pii_masking.yaml", base, env)
+		r.Files[maskingPath] = maskingFile(name, group, serviceAccount, broken)
+
+		saName := serviceAccount
+		if broken == InvalidServiceAccount {
+			saName = name + "_astro_" + env + "_user"
+		}
+		saPath := fmt.Sprintf("serviceaccounts/%s/%s.yaml", env, serviceAccount)
+		r.Files[saPath] = serviceAccountFile(saName, name, env)
+
+		switch broken {
+		case InvalidMaskingOrder, InvalidMaskingDataType:
+			r.Invalid = append(r.Invalid, InvalidFile{Path: maskingPath, Kind: broken})
+		case InvalidServiceAccount:
+			r.Invalid = append(r.Invalid, InvalidFile{Path: saPath, Kind: broken})
+		}
+	}
+}
+
+func productFile(rng *rand.Rand, name, kind, group string) string {
+	return fmt.Sprintf("---\nname: %s\nkind: %s\nrover_group: %s\nwarehouses:\n- type: user\n  size: %s\n- type: service_account\n  size: %s\nservice_account:\n  dbt: true\ntags:\n  data_product: %s\n",
+		name, kind, group, warehouseSizes[rng.Intn(len(warehouseSizes))], warehouseSizes[rng.Intn(len(warehouseSizes))], name)
+}
+
+func maskingFile(name, group, serviceAccount, broken string) string {
+	datatype := "string"
+	if broken == InvalidMaskingDataType {
+		datatype = "number"
+	}
+	unmasked := fmt.Sprintf("  - strategy: UNMASKED\n    consumers:\n      - kind: consumer_group\n        name: %s\n", group)
+	hashed := fmt.Sprintf("  - strategy: HASH_SHA1\n    consumers:\n      - kind: service_account\n        name: %s\n", serviceAccount)
+	cases := unmasked + hashed
+	if broken == InvalidMaskingOrder {
+		cases = hashed + unmasked
+	}
+	return fmt.Sprintf("kind: MaskingPolicy\nname: %s_pii_string_policy\ndata_product: %s\ndatatype: %s\nmask: \"==MASKED==\"\ncases:\n%s",
+		name, name, datatype, cases)
+}
+
+func serviceAccountFile(saName, product, env string) string {
+	return fmt.Sprintf("name: %s\ndata_product: %s\nenvironment: %s\n", saName, product, env)
+}
+
+func groupFile(group, product string, envs []string) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "group_name: %s\napprovers:\n- %s-owner\nmembers:\n  users:\n  - %s-owner\nbackends:\n", group, product, product)
+	for _, env := range envs {
+		fmt.Fprintf(&sb, "- name: snowflake_rh%s\n  type: snowflake\n", env)
+	}
+	return sb.String()
+}
+
+// Paths returns the repository paths in lexical order
+func (r *Repo) Paths() []string {
+	paths := make([]string, 0, len(r.Files))
+	for path := range r.Files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// Write writes the repository below dir, creating directories as needed
+func (r *Repo) Write(dir string) error {
+	for _, path := range r.Paths() {
+		target := filepath.Join(dir, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(target), 0750); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", path, err)
+		}
+		if err := os.WriteFile(target, []byte(r.Files[path]), 0600); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+	return nil
+}
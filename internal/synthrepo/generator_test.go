@@ -0,0 +1,100 @@
+package synthrepo
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate_Layout(t *testing.T) {
+	repo, err := Generate(Options{Products: 4, Environments: []string{"dev", "prod"}, Seed: 1})
+	require.NoError(t, err)
+
+	// developers.yaml and group per product, product, masking and service account per environment
+	assert.Len(t, repo.Files, 4*(2+2*3))
+	assert.Empty(t, repo.Invalid)
+	assert.Contains(t, repo.Files, "dataproducts/source/product000/prod/product.yaml")
+	assert.Contains(t, repo.Files, "dataproducts/aggregate/product001/dev/pii_masking.yaml")
+	assert.Contains(t, repo.Files, "dataproducts/aggregate/product001/groups/dataverse-aggregate-product001.yaml")
+	assert.Contains(t, repo.Files, "serviceaccounts/prod/product003_astro_prod_appuser.yaml")
+	assert.Contains(t, repo.Files["dataproducts/source/product000/prod/product.yaml"], "kind: source-aligned")
+}
+
+func TestGenerate_DefaultEnvironments(t *testing.T) {
+	repo, err := Generate(Options{Products: 1})
+	require.NoError(t, err)
+
+	for _, env := range DefaultEnvironments {
+		assert.Contains(t, repo.Files, "dataproducts/source/product000/"+env+"/product.yaml")
+	}
+}
+
+func TestGenerate_Deterministic(t *testing.T) {
+	opts := Options{Products: 20, InvalidRatio: 0.3, Seed: 99}
+	first, err := Generate(opts)
+	require.NoError(t, err)
+	second, err := Generate(opts)
+	require.NoError(t, err)
+
+	assert.Equal(t, first, second)
+}
+
+func TestGenerate_InvalidFiles(t *testing.T) {
+	valid, err := Generate(Options{Products: 9, Seed: 3})
+	require.NoError(t, err)
+	repo, err := Generate(Options{Products: 9, InvalidRatio: 1.0 / 3, Seed: 3})
+	require.NoError(t, err)
+
+	require.Len(t, repo.Invalid, 3)
+	kinds := map[string]bool{}
+	for _, invalid := range repo.Invalid {
+		kinds[invalid.Kind] = true
+		assert.NotEqual(t, valid.Files[invalid.Path], repo.Files[invalid.Path], invalid.Path)
+	}
+	assert.Len(t, kinds, len(invalidKinds), "invalid kinds are applied round-robin")
+
+	changed := 0
+	for path, content := range repo.Files {
+		if valid.Files[path] != content {
+			changed++
+		}
+	}
+	assert.Equal(t, len(repo.Invalid), changed, "only the invalid files differ from the valid repository")
+}
+
+func TestGenerate_InvalidContent(t *testing.T) {
+	assert.True(t, strings.Index(maskingFile("p", "g", "sa", InvalidMaskingOrder), "HASH_SHA1") <
+		strings.Index(maskingFile("p", "g", "sa", InvalidMaskingOrder), "UNMASKED"))
+	assert.Contains(t, maskingFile("p", "g", "sa", InvalidMaskingDataType), "datatype: number")
+	assert.Contains(t, maskingFile("p", "g", "sa", ""), "name: p_pii_string_policy\ndata_product: p\ndatatype: string")
+}
+
+func TestGenerate_InvalidOptions(t *testing.T) {
+	for name, opts := range map[string]Options{
+		"negative products":   {Products: -1},
+		"ratio below zero":    {Products: 1, InvalidRatio: -0.1},
+		"ratio above one":     {Products: 1, InvalidRatio: 1.5},
+		"empty environment":   {Products: 1, Environments: []string{""}},
+		"environment slashes": {Products: 1, Environments: []string{"dev/eu"}},
+	} {
+		_, err := Generate(opts)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestRepo_Write(t *testing.T) {
+	repo, err := Generate(Options{Products: 2, Environments: []string{"dev"}, InvalidRatio: 0.5})
+	require.NoError(t, err)
+	dir := t.TempDir()
+
+	require.NoError(t, repo.Write(dir))
+	for _, path := range repo.Paths() {
+		content, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(path)))
+		require.NoError(t, err, path)
+		assert.Equal(t, repo.Files[path], string(content))
+	}
+}
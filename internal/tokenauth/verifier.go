@@ -0,0 +1,130 @@
+package tokenauth
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"sort"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"go.uber.org/zap"
+)
+
+// TokenHeader is the header GitLab sets to the secret token of a webhook
+const TokenHeader = "X-Gitlab-Token"
+
+// Rejection reasons
+const (
+	ReasonMissing = "missing_token"
+	ReasonInvalid = "invalid_token"
+)
+
+// Verifier rejects deliveries to an endpoint whose X-Gitlab-Token does not match the secret
+type Verifier struct {
+	endpoint string
+	secret   [sha256.Size]byte
+}
+
+// NewVerifier returns a verifier for endpoint, or nil when secret is empty
+func NewVerifier(endpoint, secret string) *Verifier {
+	if secret == "" {
+		return nil
+	}
+	return &Verifier{endpoint: endpoint, secret: sha256.Sum256([]byte(secret))}
+}
+
+// NewVerifierFromConfig returns the verifier of an endpoint, or nil when it has no secret
+func NewVerifierFromConfig(cfg config.WebhookConfig, endpoint string) *Verifier {
+	return NewVerifier(endpoint, cfg.SecretFor(endpoint))
+}
+
+// Check returns the rejection reason of a token, or "" when it matches. Hashing both
+// values first keeps the comparison constant-time regardless of the token length.
+func (v *Verifier) Check(token string) string {
+	if token == "" {
+		return ReasonMissing
+	}
+	hashed := sha256.Sum256([]byte(token))
+	if subtle.ConstantTimeCompare(hashed[:], v.secret[:]) != 1 {
+		return ReasonInvalid
+	}
+	return ""
+}
+
+// Middleware answers 401 to deliveries without the right token before they reach the
+// handler. A nil verifier lets every request through.
+func (v *Verifier) Middleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return v.verify(c)
+	}
+}
+
+// InstanceMiddleware answers 401 to deliveries whose token does not match the verifier of
+// the GitLab instance resolve picks for them, so the secret of one instance cannot be used
+// to send events for another. Instances without a verifier let every request through.
+func InstanceMiddleware(resolve func(c *fiber.Ctx) string, verifiers map[string]*Verifier) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return verifiers[resolve(c)].verify(c)
+	}
+}
+
+// verify calls the next handler when the delivery carries the secret of v
+func (v *Verifier) verify(c *fiber.Ctx) error {
+	if v == nil {
+		return c.Next()
+	}
+	reason := v.Check(c.Get(TokenHeader))
+	if reason == "" {
+		return c.Next()
+	}
+
+	recordRejection(v.endpoint, reason)
+	logging.Warn("Rejected webhook delivery",
+		zap.String("path", c.Path()),
+		zap.String("ip", c.IP()),
+		zap.String("reason", reason))
+	message := "Invalid webhook token"
+	if reason == ReasonMissing {
+		message = "Missing " + TokenHeader + " header"
+	}
+	return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": message})
+}
+
+// Rejection counts the deliveries rejected for one endpoint and reason
+type Rejection struct {
+	Endpoint string
+	Reason   string
+	Count    int64
+}
+
+var (
+	rejectionsMu sync.Mutex
+	rejections   = make(map[[2]string]int64)
+)
+
+func recordRejection(endpoint, reason string) {
+	rejectionsMu.Lock()
+	defer rejectionsMu.Unlock()
+	rejections[[2]string{endpoint, reason}]++
+}
+
+// Rejections returns the deliveries rejected since startup, sorted by endpoint and reason
+func Rejections() []Rejection {
+	rejectionsMu.Lock()
+	defer rejectionsMu.Unlock()
+
+	result := make([]Rejection, 0, len(rejections))
+	for key, count := range rejections {
+		result = append(result, Rejection{Endpoint: key[0], Reason: key[1], Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Endpoint != result[j].Endpoint {
+			return result[i].Endpoint < result[j].Endpoint
+		}
+		return result[i].Reason < result[j].Reason
+	})
+	return result
+}
@@ -0,0 +1,101 @@
+package tokenauth
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+)
+
+func newTestApp(v *Verifier) *fiber.App {
+	app := fiber.New()
+	app.Post("/webhook", v.Middleware(), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+	return app
+}
+
+func send(t *testing.T, app *fiber.App, token string) int {
+	req := httptest.NewRequest("POST", "/webhook", nil)
+	if token != "" {
+		req.Header.Set(TokenHeader, token)
+	}
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	return resp.StatusCode
+}
+
+func rejectionCount(endpoint, reason string) int64 {
+	for _, r := range Rejections() {
+		if r.Endpoint == endpoint && r.Reason == reason {
+			return r.Count
+		}
+	}
+	return 0
+}
+
+func TestVerifier_Check(t *testing.T) {
+	v := NewVerifier("review", "s3cret")
+	assert.Equal(t, "", v.Check("s3cret"))
+	assert.Equal(t, ReasonMissing, v.Check(""))
+	assert.Equal(t, ReasonInvalid, v.Check("s3cre"))
+	assert.Equal(t, ReasonInvalid, v.Check("s3cret-and-more"))
+}
+
+func TestVerifier_Middleware(t *testing.T) {
+	app := newTestApp(NewVerifier("middleware-test", "s3cret"))
+
+	assert.Equal(t, fiber.StatusOK, send(t, app, "s3cret"))
+	assert.Equal(t, fiber.StatusUnauthorized, send(t, app, ""))
+	assert.Equal(t, fiber.StatusUnauthorized, send(t, app, "wrong"))
+	assert.Equal(t, fiber.StatusUnauthorized, send(t, app, "wrong"))
+
+	assert.Equal(t, int64(1), rejectionCount("middleware-test", ReasonMissing))
+	assert.Equal(t, int64(2), rejectionCount("middleware-test", ReasonInvalid))
+}
+
+func TestVerifier_NilPassesThrough(t *testing.T) {
+	v := NewVerifier("review", "")
+	assert.Nil(t, v)
+	assert.Equal(t, fiber.StatusOK, send(t, newTestApp(v), ""))
+}
+
+func TestInstanceMiddleware(t *testing.T) {
+	instance := "default"
+	app := fiber.New()
+	app.Post("/webhook", InstanceMiddleware(func(c *fiber.Ctx) string { return instance }, map[string]*Verifier{
+		"default": NewVerifier("review", "default-secret"),
+		"onprem":  NewVerifier("review", "onprem-secret"),
+	}), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	assert.Equal(t, fiber.StatusOK, send(t, app, "default-secret"))
+	assert.Equal(t, fiber.StatusUnauthorized, send(t, app, "onprem-secret"))
+
+	instance = "onprem"
+	assert.Equal(t, fiber.StatusOK, send(t, app, "onprem-secret"))
+	assert.Equal(t, fiber.StatusUnauthorized, send(t, app, "default-secret"), "the secret of one instance is not valid for another")
+
+	instance = "unverified"
+	assert.Equal(t, fiber.StatusOK, send(t, app, ""), "instances without a verifier are not verified")
+}
+
+func TestNewVerifierFromConfig(t *testing.T) {
+	cfg := config.WebhookConfig{
+		Secret:          "shared",
+		EndpointSecrets: map[string]string{config.EndpointAutoRebase: "rebase"},
+	}
+
+	rebase := NewVerifierFromConfig(cfg, config.EndpointAutoRebase)
+	assert.Equal(t, "", rebase.Check("rebase"))
+	assert.Equal(t, ReasonInvalid, rebase.Check("shared"), "the endpoint override replaces the shared secret")
+
+	review := NewVerifierFromConfig(cfg, config.EndpointReview)
+	assert.Equal(t, "", review.Check("shared"))
+
+	assert.Nil(t, NewVerifierFromConfig(config.WebhookConfig{}, config.EndpointReview))
+}
@@ -0,0 +1,268 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"go.uber.org/zap"
+)
+
+// Export batching: spans are sent every exportInterval or once exportBatchSize are queued.
+// Spans ended while exportQueueSize are waiting are dropped rather than slowing requests.
+const (
+	exportInterval  = 5 * time.Second
+	exportBatchSize = 512
+	exportQueueSize = 2048
+	exportTimeout   = 10 * time.Second
+)
+
+// instrumentationScope names the instrumentation in exported spans
+const instrumentationScope = "github.com/redhat-data-and-ai/naysayer"
+
+// Span export counts since startup
+var (
+	spansExported atomic.Int64
+	spansDropped  atomic.Int64
+	spansFailed   atomic.Int64
+)
+
+// Stats returns the spans exported, dropped because the export queue was full and lost in
+// failed exports since startup
+func Stats() (exported, dropped, failed int64) {
+	return spansExported.Load(), spansDropped.Load(), spansFailed.Load()
+}
+
+// exporter sends ended spans to an OTLP/HTTP collector in the JSON encoding
+type exporter struct {
+	endpoint string
+	headers  map[string]string
+	service  string
+	http     *http.Client
+
+	queue    chan *Span
+	stopping chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+func newExporter(cfg config.TracingConfig) *exporter {
+	service := cfg.ServiceName
+	if service == "" {
+		service = "naysayer"
+	}
+	return &exporter{
+		endpoint: cfg.Endpoint,
+		headers:  cfg.Headers,
+		service:  service,
+		http:     &http.Client{Timeout: exportTimeout},
+		queue:    make(chan *Span, exportQueueSize),
+		stopping: make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// enqueue queues an ended span for export, dropping it when the queue is full
+func (e *exporter) enqueue(span *Span) {
+	select {
+	case e.queue <- span:
+	default:
+		spansDropped.Add(1)
+	}
+}
+
+func (e *exporter) start() {
+	go e.run()
+}
+
+// stop exports the queued spans and stops the exporter, giving up when ctx ends
+func (e *exporter) stop(ctx context.Context) error {
+	e.stopOnce.Do(func() { close(e.stopping) })
+	select {
+	case <-e.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (e *exporter) run() {
+	defer close(e.done)
+	ticker := time.NewTicker(exportInterval)
+	defer ticker.Stop()
+
+	batch := make([]*Span, 0, exportBatchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		e.export(batch)
+		batch = batch[:0]
+	}
+	for {
+		select {
+		case span := <-e.queue:
+			batch = append(batch, span)
+			if len(batch) >= exportBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-e.stopping:
+			for {
+				select {
+				case span := <-e.queue:
+					batch = append(batch, span)
+					if len(batch) >= exportBatchSize {
+						flush()
+					}
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+// export sends a batch of spans; failed batches are logged and counted, not retried
+func (e *exporter) export(spans []*Span) {
+	if err := e.post(spans); err != nil {
+		spansFailed.Add(int64(len(spans)))
+		logging.Warn("Failed to export trace spans", zap.Int("spans", len(spans)), zap.Error(err))
+		return
+	}
+	spansExported.Add(int64(len(spans)))
+}
+
+func (e *exporter) post(spans []*Span) error {
+	payload, err := json.Marshal(e.request(spans))
+	if err != nil {
+		return fmt.Errorf("failed to encode spans: %w", err)
+	}
+	req, err := http.NewRequest("POST", e.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create export request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range e.headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := e.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("export request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("collector answered %d: %s", resp.StatusCode, bytes.TrimSpace(body))
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+// OTLP/JSON trace export request, see opentelemetry-proto ExportTraceServiceRequest
+type (
+	otlpRequest struct {
+		ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+	}
+	otlpResourceSpans struct {
+		Resource   otlpResource     `json:"resource"`
+		ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+	}
+	otlpResource struct {
+		Attributes []otlpAttribute `json:"attributes"`
+	}
+	otlpScopeSpans struct {
+		Scope otlpScope  `json:"scope"`
+		Spans []otlpSpan `json:"spans"`
+	}
+	otlpScope struct {
+		Name string `json:"name"`
+	}
+	otlpSpan struct {
+		TraceID           string          `json:"traceId"`
+		SpanID            string          `json:"spanId"`
+		ParentSpanID      string          `json:"parentSpanId,omitempty"`
+		Name              string          `json:"name"`
+		Kind              SpanKind        `json:"kind"`
+		StartTimeUnixNano string          `json:"startTimeUnixNano"`
+		EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+		Attributes        []otlpAttribute `json:"attributes,omitempty"`
+		Status            *otlpStatus     `json:"status,omitempty"`
+	}
+	otlpStatus struct {
+		Code    int    `json:"code"` // 2 is STATUS_CODE_ERROR
+		Message string `json:"message,omitempty"`
+	}
+	otlpAttribute struct {
+		Key   string                 `json:"key"`
+		Value map[string]interface{} `json:"value"`
+	}
+)
+
+// otlpStatusError is the OTLP status code of failed spans
+const otlpStatusError = 2
+
+// request converts spans to an export request
+func (e *exporter) request(spans []*Span) otlpRequest {
+	converted := make([]otlpSpan, 0, len(spans))
+	for _, span := range spans {
+		converted = append(converted, convertSpan(span))
+	}
+	return otlpRequest{ResourceSpans: []otlpResourceSpans{{
+		Resource:   otlpResource{Attributes: convertAttributes([]Attribute{String("service.name", e.service)})},
+		ScopeSpans: []otlpScopeSpans{{Scope: otlpScope{Name: instrumentationScope}, Spans: converted}},
+	}}}
+}
+
+func convertSpan(span *Span) otlpSpan {
+	span.mu.Lock()
+	defer span.mu.Unlock()
+	converted := otlpSpan{
+		TraceID:           hex.EncodeToString(span.sc.TraceID[:]),
+		SpanID:            hex.EncodeToString(span.sc.SpanID[:]),
+		Name:              span.name,
+		Kind:              span.kind,
+		StartTimeUnixNano: strconv.FormatInt(span.start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(span.end.UnixNano(), 10),
+		Attributes:        convertAttributes(span.attrs),
+	}
+	if span.parent != [8]byte{} {
+		converted.ParentSpanID = hex.EncodeToString(span.parent[:])
+	}
+	if span.failed {
+		converted.Status = &otlpStatus{Code: otlpStatusError, Message: span.message}
+	}
+	return converted
+}
+
+// convertAttributes encodes attributes as OTLP AnyValues; 64-bit integers are strings in
+// the JSON encoding
+func convertAttributes(attrs []Attribute) []otlpAttribute {
+	converted := make([]otlpAttribute, 0, len(attrs))
+	for _, attr := range attrs {
+		var value map[string]interface{}
+		switch v := attr.Value.(type) {
+		case int64:
+			value = map[string]interface{}{"intValue": strconv.FormatInt(v, 10)}
+		case bool:
+			value = map[string]interface{}{"boolValue": v}
+		default:
+			value = map[string]interface{}{"stringValue": fmt.Sprint(v)}
+		}
+		converted = append(converted, otlpAttribute{Key: attr.Key, Value: value})
+	}
+	return converted
+}
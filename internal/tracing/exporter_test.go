@@ -0,0 +1,116 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+)
+
+// collector is an OTLP/HTTP endpoint recording the exported spans
+type collector struct {
+	*httptest.Server
+	mu      sync.Mutex
+	spans   []otlpSpan
+	service string
+	headers http.Header
+}
+
+func newCollector(t *testing.T, status int) *collector {
+	c := &collector{}
+	c.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req otlpRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.headers = r.Header
+		for _, resource := range req.ResourceSpans {
+			c.service = resource.Resource.Attributes[0].Value["stringValue"].(string)
+			for _, scope := range resource.ScopeSpans {
+				c.spans = append(c.spans, scope.Spans...)
+			}
+		}
+		w.WriteHeader(status)
+	}))
+	return c
+}
+
+func (c *collector) exported() []otlpSpan {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.spans
+}
+
+func TestExporter_SendsOTLPJSON(t *testing.T) {
+	server := newCollector(t, http.StatusOK)
+	defer server.Close()
+	exportedBefore, _, _ := Stats()
+
+	tracer := NewTracerFromConfig(config.TracingConfig{
+		Endpoint:    server.URL + "/v1/traces",
+		Headers:     map[string]string{"Authorization": "Bearer collector-token"},
+		ServiceName: "naysayer-test",
+		SampleRatio: 1,
+	})
+	ctx, root := tracer.Start(context.Background(), "POST /dataverse-product-config-review", KindServer)
+	_, child := tracer.Start(ctx, "GitLab GET", KindClient, Int("http.response.status_code", 404), Bool("cached", false))
+	child.SetError(errors.New("not found"))
+	child.End()
+	root.End()
+	assert.NoError(t, tracer.Shutdown(context.Background()))
+
+	spans := server.exported()
+	assert.Len(t, spans, 2)
+	assert.Equal(t, "naysayer-test", server.service)
+	assert.Equal(t, "Bearer collector-token", server.headers.Get("Authorization"))
+	assert.Equal(t, "application/json", server.headers.Get("Content-Type"))
+
+	exportedChild, exportedRoot := spans[0], spans[1]
+	assert.Equal(t, "GitLab GET", exportedChild.Name)
+	assert.Equal(t, KindClient, exportedChild.Kind)
+	assert.Equal(t, exportedRoot.TraceID, exportedChild.TraceID)
+	assert.Equal(t, exportedRoot.SpanID, exportedChild.ParentSpanID)
+	assert.Empty(t, exportedRoot.ParentSpanID)
+	assert.Len(t, exportedRoot.TraceID, 32)
+	assert.Equal(t, []otlpAttribute{
+		{Key: "http.response.status_code", Value: map[string]interface{}{"intValue": "404"}},
+		{Key: "cached", Value: map[string]interface{}{"boolValue": false}},
+	}, exportedChild.Attributes)
+	assert.Equal(t, &otlpStatus{Code: otlpStatusError, Message: "not found"}, exportedChild.Status)
+	assert.Nil(t, exportedRoot.Status)
+	assert.NotEqual(t, exportedRoot.StartTimeUnixNano, exportedRoot.EndTimeUnixNano)
+
+	exportedAfter, _, _ := Stats()
+	assert.Equal(t, int64(2), exportedAfter-exportedBefore)
+}
+
+func TestExporter_CountsFailuresAndSkipsUnsampledSpans(t *testing.T) {
+	server := newCollector(t, http.StatusServiceUnavailable)
+	defer server.Close()
+	_, _, failedBefore := Stats()
+
+	tracer := NewTracerFromConfig(config.TracingConfig{Endpoint: server.URL, SampleRatio: 1})
+	_, span := tracer.Start(context.Background(), "sampled", KindInternal)
+	span.End()
+	unsampled := Extract(context.Background(), "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00")
+	_, span = tracer.Start(unsampled, "unsampled", KindInternal)
+	span.End()
+	assert.NoError(t, tracer.Shutdown(context.Background()))
+
+	assert.Len(t, server.exported(), 1)
+	_, _, failedAfter := Stats()
+	assert.Equal(t, int64(1), failedAfter-failedBefore)
+}
+
+func TestNewTracerFromConfig_Disabled(t *testing.T) {
+	tracer := NewTracerFromConfig(config.TracingConfig{})
+	assert.Nil(t, tracer)
+	assert.NoError(t, tracer.Shutdown(context.Background()))
+}
@@ -0,0 +1,56 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+)
+
+// Middleware records a server span for every request, continuing the trace of a valid
+// traceparent header. The header is replaced with the span's own, so handlers running the
+// request later on the job queue continue the trace from it.
+func Middleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		tracer := Default()
+		if tracer == nil {
+			return c.Next()
+		}
+
+		ctx := Extract(c.UserContext(), c.Get(TraceParentHeader))
+		ctx, span := tracer.Start(ctx, c.Method()+" "+c.Path(), KindServer,
+			String("http.request.method", c.Method()),
+			String("url.path", c.Path()))
+		defer span.End()
+		if id := logging.CorrelationID(ctx); id != "" {
+			span.SetAttributes(String("naysayer.correlation_id", id))
+		}
+		c.Request().Header.Set(TraceParentHeader, span.SpanContext().TraceParent())
+		c.SetUserContext(ctx)
+
+		err := c.Next()
+		if route := c.Route(); route != nil && route.Path != "" && route.Path != "/" {
+			span.SetName(c.Method() + " " + route.Path)
+			span.SetAttributes(String("http.route", route.Path))
+		}
+		status := c.Response().StatusCode()
+		span.SetAttributes(Int("http.response.status_code", status))
+		if err != nil {
+			span.SetError(err)
+		} else if status >= 500 {
+			span.SetFailed(http.StatusText(status))
+		}
+		return err
+	}
+}
+
+// RequestContext returns ctx carrying the trace of the traceparent header of c, for
+// handlers running requests outside the middleware chain
+func RequestContext(ctx context.Context, c *fiber.Ctx) context.Context {
+	if SpanFromContext(ctx) != nil {
+		return ctx
+	}
+	return Extract(ctx, c.Get(TraceParentHeader))
+}
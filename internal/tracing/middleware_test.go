@@ -0,0 +1,86 @@
+package tracing
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+)
+
+func TestMiddleware_RecordsServerSpans(t *testing.T) {
+	server := newCollector(t, 200)
+	defer server.Close()
+	tracer := NewTracerFromConfig(config.TracingConfig{Endpoint: server.URL, SampleRatio: 1})
+	SetDefault(tracer)
+	defer SetDefault(nil)
+
+	var handlerSpan *Span
+	var forwarded string
+	app := fiber.New()
+	app.Use(logging.Middleware())
+	app.Use(Middleware())
+	app.Post("/projects/:id", func(c *fiber.Ctx) error {
+		handlerSpan = SpanFromContext(c.UserContext())
+		forwarded = c.Get(TraceParentHeader)
+		return c.SendStatus(fiber.StatusServiceUnavailable)
+	})
+
+	req := httptest.NewRequest("POST", "/projects/7", nil)
+	req.Header.Set(TraceParentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	req.Header.Set(logging.CorrelationHeader, "delivery-3")
+	_, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.NoError(t, tracer.Shutdown(context.Background()))
+
+	assert.NotNil(t, handlerSpan)
+	assert.Equal(t, handlerSpan.SpanContext().TraceParent(), forwarded, "queued handlers continue from the server span")
+
+	spans := server.exported()
+	assert.Len(t, spans, 1)
+	span := spans[0]
+	assert.Equal(t, "POST /projects/:id", span.Name)
+	assert.Equal(t, KindServer, span.Kind)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", span.TraceID)
+	assert.Equal(t, "00f067aa0ba902b7", span.ParentSpanID)
+	assert.Equal(t, &otlpStatus{Code: otlpStatusError, Message: "Service Unavailable"}, span.Status)
+
+	attributes := make(map[string]map[string]interface{})
+	for _, attr := range span.Attributes {
+		attributes[attr.Key] = attr.Value
+	}
+	assert.Equal(t, "503", attributes["http.response.status_code"]["intValue"])
+	assert.Equal(t, "/projects/:id", attributes["http.route"]["stringValue"])
+	assert.Equal(t, "delivery-3", attributes["naysayer.correlation_id"]["stringValue"])
+}
+
+func TestMiddleware_DisabledPassesThrough(t *testing.T) {
+	SetDefault(nil)
+	app := fiber.New()
+	app.Use(Middleware())
+	app.Get("/", func(c *fiber.Ctx) error {
+		assert.Nil(t, SpanFromContext(c.UserContext()))
+		assert.Empty(t, c.Get(TraceParentHeader))
+		return nil
+	})
+	_, err := app.Test(httptest.NewRequest("GET", "/", nil))
+	assert.NoError(t, err)
+}
+
+func TestRequestContext(t *testing.T) {
+	app := fiber.New()
+	app.Get("/", func(c *fiber.Ctx) error {
+		ctx := RequestContext(context.Background(), c)
+		_, span := newTracer(1).Start(ctx, "job", KindInternal)
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", span.SpanContext().TraceParent()[3:35])
+		return nil
+	})
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set(TraceParentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	_, err := app.Test(req)
+	assert.NoError(t, err)
+}
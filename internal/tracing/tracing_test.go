@@ -0,0 +1,105 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTraceParent(t *testing.T) {
+	sc, ok := ParseTraceParent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	assert.True(t, ok)
+	assert.True(t, sc.Sampled)
+	assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", sc.TraceParent())
+
+	sc, ok = ParseTraceParent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00")
+	assert.True(t, ok)
+	assert.False(t, sc.Sampled)
+
+	for _, invalid := range []string{
+		"",
+		"01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e473z-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+	} {
+		_, ok := ParseTraceParent(invalid)
+		assert.False(t, ok, invalid)
+	}
+}
+
+func TestTracer_StartContinuesTraces(t *testing.T) {
+	tracer := newTracer(1)
+
+	ctx, root := tracer.Start(context.Background(), "root", KindServer)
+	assert.True(t, root.SpanContext().IsValid())
+	assert.True(t, root.SpanContext().Sampled)
+	assert.Equal(t, [8]byte{}, root.parent)
+	assert.Same(t, root, SpanFromContext(ctx))
+
+	_, child := tracer.Start(ctx, "child", KindClient)
+	assert.Equal(t, root.SpanContext().TraceID, child.SpanContext().TraceID)
+	assert.Equal(t, root.SpanContext().SpanID, child.parent)
+	assert.NotEqual(t, root.SpanContext().SpanID, child.SpanContext().SpanID)
+
+	// Remote parents decide sampling for the whole trace
+	remote := Extract(context.Background(), "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00")
+	_, continued := tracer.Start(remote, "continued", KindServer)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", continued.SpanContext().TraceParent()[3:35])
+	assert.False(t, continued.SpanContext().Sampled)
+
+	header := http.Header{}
+	Inject(ctx, header)
+	assert.Equal(t, root.SpanContext().TraceParent(), header.Get(TraceParentHeader))
+}
+
+func TestTracer_SampleRatio(t *testing.T) {
+	never := newTracer(0)
+	_, span := never.Start(context.Background(), "unsampled", KindInternal)
+	assert.False(t, span.SpanContext().Sampled)
+	assert.True(t, span.SpanContext().IsValid(), "unsampled spans still propagate their trace")
+
+	half := newTracer(0.5)
+	sampled := 0
+	for i := 0; i < 1000; i++ {
+		if _, span := half.Start(context.Background(), "span", KindInternal); span.SpanContext().Sampled {
+			sampled++
+		}
+	}
+	assert.InDelta(t, 500, sampled, 100)
+}
+
+func TestSpan_Attributes(t *testing.T) {
+	_, span := newTracer(1).Start(context.Background(), "span", KindInternal, String("rule", "warehouse_rule"), Int("lines", 3))
+	span.SetAttributes(Int("lines", 4), Bool("cached", true))
+	span.SetError(nil)
+	assert.False(t, span.failed)
+	span.SetError(errors.New("boom"))
+	span.End()
+	span.End()
+
+	assert.Equal(t, []Attribute{String("rule", "warehouse_rule"), Int("lines", 4), Bool("cached", true)}, span.attrs)
+	assert.True(t, span.failed)
+	assert.Equal(t, "boom", span.message)
+}
+
+func TestDisabledTracingIsNoOp(t *testing.T) {
+	SetDefault(nil)
+	ctx, span := Start(context.Background(), "span", KindInternal, String("key", "value"))
+	assert.Nil(t, span)
+	assert.Equal(t, context.Background(), ctx)
+
+	span.SetName("renamed")
+	span.SetAttributes(Int("key", 1))
+	span.SetError(errors.New("ignored"))
+	span.End()
+	assert.False(t, span.SpanContext().IsValid())
+
+	header := http.Header{}
+	Inject(ctx, header)
+	assert.Empty(t, header.Get(TraceParentHeader))
+}
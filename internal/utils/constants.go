@@ -6,6 +6,37 @@ const (
 	DefaultActionAutoApprove  = "auto_approve"
 )
 
+// Parser Types - section parsers of file configurations
+const (
+	ParserTypeYAML = "yaml"
+	ParserTypeJSON = "json"
+	ParserTypeTOML = "toml"
+	ParserTypeText = "text"
+)
+
+// Deletion Actions - used by deletion policies (in addition to the default actions above)
+const (
+	DeletionActionBlock = "block"
+)
+
+// Rule Decisions - matched by decision policy conditions (manual_review is DefaultActionManualReview)
+const (
+	DecisionApprove = "approve"
+	DecisionWarn    = "warn"
+)
+
+// Rule Severities - used by rule severity overrides
+const (
+	SeverityBlocking = "blocking"
+	SeverityWarning  = "warning"
+)
+
+// Aggregation Policies - combine the file decisions of an MR into its decision
+const (
+	AggregationRequireAllApprove = "require_all_approve"
+	AggregationQuorum            = "quorum"
+)
+
 // MR States - used in webhook processing
 const (
 	MRStateOpened = "opened"
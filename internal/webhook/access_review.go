@@ -0,0 +1,148 @@
+package webhook
+
+import (
+	"bufio"
+	"bytes"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/redhat-data-and-ai/naysayer/internal/accessreview"
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/repoindex"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+	"go.uber.org/zap"
+)
+
+// AccessReviewHandler exports UNMASKED masking policy grants for access reviews
+type AccessReviewHandler struct {
+	config  *config.Config
+	index   *repoindex.Index
+	scanner *accessreview.Scanner
+	// refreshIndex takes a fresh snapshot per request when no shared, continuously updated index exists
+	refreshIndex bool
+}
+
+// NewAccessReviewHandler creates an access review handler using the shared repository index when enabled
+func NewAccessReviewHandler(cfg *config.Config) *AccessReviewHandler {
+	client := gitlab.NewClientWithConfig(cfg)
+	if index := repoindex.ForInstance(cfg.GitLab.Instance); index != nil {
+		return NewAccessReviewHandlerWithIndex(cfg, index, client, false)
+	}
+	return NewAccessReviewHandlerWithIndex(cfg, repoindex.NewIndex(client, store.NewMemoryStore()), client, true)
+}
+
+// NewAccessReviewHandlerWithIndex creates an access review handler with a custom index and file fetcher
+// This is primarily used for testing
+func NewAccessReviewHandlerWithIndex(cfg *config.Config, index *repoindex.Index, fetcher accessreview.FileFetcher, refreshIndex bool) *AccessReviewHandler {
+	return &AccessReviewHandler{
+		config:       cfg,
+		index:        index,
+		scanner:      accessreview.NewScanner(index, fetcher),
+		refreshIndex: refreshIndex,
+	}
+}
+
+// HandleUnmaskedGrants returns every UNMASKED grant on a branch as CSV or JSON.
+// Without per_page the full report is streamed; with per_page the report is paginated
+// and X-Next-Page is set when more grants follow.
+func (h *AccessReviewHandler) HandleUnmaskedGrants(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	projectID, err := strconv.Atoi(c.Query("project_id"))
+	if err != nil || projectID <= 0 {
+		return c.Status(400).JSON(fiber.Map{
+			"error": "project_id query parameter is required",
+		})
+	}
+	ref := c.Query("ref", "main")
+	format := c.Query("format", accessreview.FormatJSON)
+	if format != accessreview.FormatCSV && format != accessreview.FormatJSON {
+		return c.Status(400).JSON(fiber.Map{
+			"error": "format must be csv or json",
+		})
+	}
+	page := c.QueryInt("page", 1)
+	perPage := c.QueryInt("per_page", 0)
+	if page < 1 || perPage < 0 {
+		return c.Status(400).JSON(fiber.Map{
+			"error": "page must be >= 1 and per_page must be >= 0",
+		})
+	}
+
+	if h.refreshIndex {
+		if _, err := h.index.Refresh(ctx, projectID, ref); err != nil {
+			logging.Error("Access review index refresh failed",
+				zap.Int("project_id", projectID),
+				zap.String("ref", ref),
+				zap.Error(err))
+			return c.Status(502).JSON(fiber.Map{
+				"error": "failed to list repository: " + err.Error(),
+			})
+		}
+	}
+
+	c.Set("Content-Type", accessreview.ContentType(format))
+
+	if perPage > 0 {
+		return h.writePage(c, projectID, ref, format, page, perPage)
+	}
+
+	logging.Info("Streaming UNMASKED access review",
+		zap.Int("project_id", projectID),
+		zap.String("ref", ref),
+		zap.String("format", format))
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		out, _ := accessreview.NewWriter(format, w)
+		count := 0
+		err := h.scanner.Scan(ctx, projectID, ref, func(g accessreview.Grant) error {
+			count++
+			if err := out.Write(g); err != nil {
+				return err
+			}
+			return w.Flush()
+		})
+		if err != nil {
+			// Headers are already sent; the report is truncated
+			logging.Error("Access review export stopped",
+				zap.Int("project_id", projectID),
+				zap.String("ref", ref),
+				zap.Int("grants", count),
+				zap.Error(err))
+		}
+		_ = out.Close()
+		_ = w.Flush()
+	})
+	return nil
+}
+
+// writePage writes one page of grants with pagination headers
+func (h *AccessReviewHandler) writePage(c *fiber.Ctx, projectID int, ref, format string, page, perPage int) error {
+	ctx := c.UserContext()
+	grants, hasMore, err := h.scanner.Page(ctx, projectID, ref, (page-1)*perPage, perPage)
+	if err != nil {
+		logging.Error("Access review export failed", zap.Int("project_id", projectID), zap.String("ref", ref), zap.Error(err))
+		return c.Status(502).JSON(fiber.Map{
+			"error": "failed to build access review: " + err.Error(),
+		})
+	}
+
+	var buf bytes.Buffer
+	out, _ := accessreview.NewWriter(format, &buf)
+	for _, g := range grants {
+		if err := out.Write(g); err != nil {
+			return err
+		}
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+
+	c.Set("X-Page", strconv.Itoa(page))
+	c.Set("X-Per-Page", strconv.Itoa(perPage))
+	if hasMore {
+		c.Set("X-Next-Page", strconv.Itoa(page+1))
+	}
+	c.Set("Content-Type", accessreview.ContentType(format))
+	return c.Send(buf.Bytes())
+}
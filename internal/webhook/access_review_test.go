@@ -0,0 +1,107 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/accessreview"
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/repoindex"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+)
+
+// mockAccessReviewRepository serves a repository tree and file contents
+type mockAccessReviewRepository struct {
+	files map[string]string
+}
+
+func (m *mockAccessReviewRepository) ListRepositoryTree(ctx context.Context, projectID int, ref string) ([]gitlab.TreeEntry, error) {
+	entries := make([]gitlab.TreeEntry, 0, len(m.files))
+	for path := range m.files {
+		entries = append(entries, gitlab.TreeEntry{Type: "blob", Path: path})
+	}
+	return entries, nil
+}
+
+func (m *mockAccessReviewRepository) FetchFileContent(ctx context.Context, projectID int, filePath, ref string) (*gitlab.FileContent, error) {
+	content, ok := m.files[filePath]
+	if !ok {
+		return nil, fmt.Errorf("file not found: %s", filePath)
+	}
+	return &gitlab.FileContent{FilePath: filePath, Content: content}, nil
+}
+
+func newAccessReviewTestRepository() *mockAccessReviewRepository {
+	return &mockAccessReviewRepository{files: map[string]string{
+		"dataproducts/source/analytics/prod/pii_masking.yaml": `kind: MaskingPolicy
+name: analytics_pii_string_policy
+data_product: analytics
+datatype: string
+cases:
+  - strategy: UNMASKED
+    consumers:
+      - kind: consumer_group
+        name: dataverse-source-analytics
+      - kind: service_account
+        name: analytics_astro_prod_appuser
+`,
+	}}
+}
+
+func TestAccessReviewHandler_HandleUnmaskedGrants(t *testing.T) {
+	repo := newAccessReviewTestRepository()
+	handler := NewAccessReviewHandlerWithIndex(&config.Config{}, repoindex.NewIndex(repo, store.NewMemoryStore()), repo, true)
+
+	app := createTestApp()
+	app.Get("/api/v1/access-review/unmasked", handler.HandleUnmaskedGrants)
+
+	// Streamed JSON report
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/access-review/unmasked?project_id=1", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	var grants []accessreview.Grant
+	body, _ := io.ReadAll(resp.Body)
+	assert.NoError(t, json.Unmarshal(body, &grants))
+	assert.Len(t, grants, 2)
+	assert.Equal(t, "analytics", grants[0].DataProduct)
+	assert.Equal(t, "prod", grants[0].Environment)
+
+	// Paginated CSV report
+	resp, err = app.Test(httptest.NewRequest("GET", "/api/v1/access-review/unmasked?project_id=1&format=csv&per_page=1", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "text/csv", resp.Header.Get("Content-Type"))
+	assert.Equal(t, "2", resp.Header.Get("X-Next-Page"))
+	body, _ = io.ReadAll(resp.Body)
+	lines := strings.Split(strings.TrimSpace(string(body)), "\n")
+	assert.Len(t, lines, 2)
+	assert.Contains(t, lines[1], "dataverse-source-analytics")
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/api/v1/access-review/unmasked?project_id=1&format=csv&per_page=1&page=2", nil))
+	assert.NoError(t, err)
+	assert.Empty(t, resp.Header.Get("X-Next-Page"))
+	body, _ = io.ReadAll(resp.Body)
+	assert.Contains(t, string(body), "analytics_astro_prod_appuser")
+}
+
+func TestAccessReviewHandler_InvalidRequests(t *testing.T) {
+	repo := newAccessReviewTestRepository()
+	handler := NewAccessReviewHandlerWithIndex(&config.Config{}, repoindex.NewIndex(repo, store.NewMemoryStore()), repo, false)
+
+	app := createTestApp()
+	app.Get("/api/v1/access-review/unmasked", handler.HandleUnmaskedGrants)
+
+	for _, query := range []string{"", "?project_id=abc", "?project_id=1&format=xml", "?project_id=1&page=0"} {
+		resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/access-review/unmasked"+query, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 400, resp.StatusCode, query)
+	}
+}
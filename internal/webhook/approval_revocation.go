@@ -0,0 +1,89 @@
+package webhook
+
+import (
+	"context"
+	"sort"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"go.uber.org/zap"
+)
+
+// applyApprovalRevocation records why an approval no longer holds when new commits were
+// pushed to an MR naysayer approved. The manual review then revokes the approval and its
+// comment lists the rule results that regressed.
+func (h *DataProductConfigMrReviewHandler) applyApprovalRevocation(result *shared.RuleEvaluation, mrInfo *gitlab.MRInfo) {
+	if mrInfo.OldRev == "" || result.FinalDecision.Type == shared.Approve {
+		return
+	}
+	previous := h.previousExplanation(mrInfo)
+	if previous == nil || previous.Decision.Type != shared.Approve {
+		return
+	}
+
+	result.ApprovalRevocation = &shared.ApprovalRevocation{
+		PreviousCommit: mrInfo.OldRev,
+		Regressions:    RuleRegressions(previous, result),
+	}
+	logging.MRInfo(mrInfo.MRIID, "New commits revoke the previous approval",
+		zap.String("oldrev", mrInfo.OldRev),
+		zap.Int("regressions", len(result.ApprovalRevocation.Regressions)))
+}
+
+// resetStaleApproval revokes a previous approval after new commits to an MR that is not
+// evaluated, such as a draft
+func (h *DataProductConfigMrReviewHandler) resetStaleApproval(ctx context.Context, mrInfo *gitlab.MRInfo) {
+	if mrInfo.OldRev == "" {
+		return
+	}
+	if err := h.gitlabClient.ResetNaysayerApproval(ctx, mrInfo.ProjectID, mrInfo.MRIID); err != nil {
+		logging.MRWarn(mrInfo.MRIID, "Could not reset previous naysayer approval (may not have been approved)", zap.Error(err))
+	}
+}
+
+// RuleRegressions lists the rule results of an evaluation requiring manual review where the
+// previous evaluation of the MR did not, sorted by file
+func RuleRegressions(previous *Explanation, result *shared.RuleEvaluation) []shared.RuleRegression {
+	previousRules := make(map[string]map[string]RuleExplanation)
+	for _, file := range previous.Files {
+		rules := make(map[string]RuleExplanation, len(file.Rules))
+		for _, rule := range file.Rules {
+			if rule.Evaluated {
+				rules[rule.Rule] = rule
+			}
+		}
+		previousRules[file.Path] = rules
+	}
+
+	paths := make([]string, 0, len(result.FileValidations))
+	for path := range result.FileValidations {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var regressions []shared.RuleRegression
+	for _, path := range paths {
+		summary := result.FileValidations[path]
+		if summary == nil {
+			continue
+		}
+		for _, ruleResult := range summary.RuleResults {
+			if !ruleResult.WasEvaluated || ruleResult.Decision != shared.ManualReview {
+				continue
+			}
+			before, ran := previousRules[path][ruleResult.RuleName]
+			if ran && before.Decision == shared.ManualReview {
+				continue
+			}
+			regressions = append(regressions, shared.RuleRegression{
+				FilePath: path,
+				RuleName: ruleResult.RuleName,
+				Previous: before.Decision,
+				Current:  ruleResult.Decision,
+				Reason:   ruleResult.Reason,
+			})
+		}
+	}
+	return regressions
+}
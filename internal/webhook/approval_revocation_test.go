@@ -0,0 +1,99 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+)
+
+func revocationTestResult(decision shared.DecisionType, rules ...shared.LineValidationResult) *shared.RuleEvaluation {
+	return &shared.RuleEvaluation{
+		FinalDecision: shared.Decision{Type: decision, Reason: "warehouse size increased"},
+		FileValidations: map[string]*shared.FileValidationSummary{
+			"dataproducts/source/analytics/prod/product.yaml": {FileDecision: decision, RuleResults: rules},
+		},
+	}
+}
+
+func TestRuleRegressions(t *testing.T) {
+	previous := NewExplanation(revocationTestResult(shared.Approve,
+		shared.LineValidationResult{RuleName: "warehouse_rule", Decision: shared.Approve, Reason: "Warehouse unchanged", WasEvaluated: true},
+		shared.LineValidationResult{RuleName: "metadata_rule", Decision: shared.ManualReview, Reason: "Missing owner", WasEvaluated: true},
+	), &gitlab.MRInfo{ProjectID: 1, MRIID: 2}, time.Now())
+
+	result := revocationTestResult(shared.ManualReview,
+		shared.LineValidationResult{RuleName: "warehouse_rule", Decision: shared.ManualReview, Reason: "Warehouse size increased", WasEvaluated: true},
+		shared.LineValidationResult{RuleName: "metadata_rule", Decision: shared.ManualReview, Reason: "Missing owner", WasEvaluated: true},
+		shared.LineValidationResult{RuleName: "tag_rule", Decision: shared.ManualReview, Reason: "Unknown tag", WasEvaluated: true},
+		shared.LineValidationResult{RuleName: "toc_approval_rule", Decision: shared.ManualReview, Reason: "Skipped", WasEvaluated: false},
+	)
+
+	assert.Equal(t, []shared.RuleRegression{
+		{FilePath: "dataproducts/source/analytics/prod/product.yaml", RuleName: "warehouse_rule", Previous: shared.Approve, Current: shared.ManualReview, Reason: "Warehouse size increased"},
+		{FilePath: "dataproducts/source/analytics/prod/product.yaml", RuleName: "tag_rule", Current: shared.ManualReview, Reason: "Unknown tag"},
+	}, RuleRegressions(previous, result))
+}
+
+func TestApprovalRevocation_NewCommits(t *testing.T) {
+	ctx := context.Background()
+	client := &overrideGitLabClient{MockGitLabClient: &MockGitLabClient{}}
+	cfg := createTestConfig()
+	cfg.Comments.EnableMRComments = true
+	cfg.Comments.UpdateExistingComments = false
+	handler := &DataProductConfigMrReviewHandler{gitlabClient: client, config: cfg}
+	handler.SetStateStore(store.NewMemoryStore())
+
+	mrInfo := &gitlab.MRInfo{ProjectID: 7, MRIID: 3}
+	_, err := handler.applyDecision(ctx, revocationTestResult(shared.Approve,
+		shared.LineValidationResult{RuleName: "warehouse_rule", Decision: shared.Approve, Reason: "Warehouse unchanged", WasEvaluated: true},
+	), mrInfo)
+	assert.NoError(t, err)
+
+	// Re-evaluations without new commits do not explain a revocation
+	result := revocationTestResult(shared.ManualReview,
+		shared.LineValidationResult{RuleName: "warehouse_rule", Decision: shared.ManualReview, Reason: "Warehouse size increased", WasEvaluated: true},
+	)
+	handler.applyApprovalRevocation(result, mrInfo)
+	assert.Nil(t, result.ApprovalRevocation)
+
+	pushed := &gitlab.MRInfo{ProjectID: 7, MRIID: 3, OldRev: "0123456789abcdef"}
+	handler.applyApprovalRevocation(result, pushed)
+	assert.Equal(t, "0123456789abcdef", result.ApprovalRevocation.PreviousCommit)
+	assert.Len(t, result.ApprovalRevocation.Regressions, 1)
+
+	client.comments = nil
+	_, err = handler.applyDecision(ctx, result, pushed)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, client.resets, "the stale approval is reset")
+	assert.Len(t, client.comments, 1)
+	assert.Contains(t, client.comments[0], "🔄 **Approval revoked**: commits pushed after `01234567` changed the decision")
+	assert.Contains(t, client.comments[0], "• `dataproducts/source/analytics/prod/product.yaml` (`warehouse_rule`): approve → manual_review: Warehouse size increased")
+
+	// Only a previous approval can be revoked
+	result = revocationTestResult(shared.ManualReview)
+	handler.applyApprovalRevocation(result, pushed)
+	assert.Nil(t, result.ApprovalRevocation)
+}
+
+func TestBuildApprovalRevocationSection(t *testing.T) {
+	mb := NewMessageBuilder(createTestConfig())
+
+	assert.Empty(t, mb.BuildApprovalRevocationSection(revocationTestResult(shared.ManualReview)))
+
+	result := revocationTestResult(shared.ManualReview)
+	result.FinalDecision.Reason = "Merge settings violated"
+	result.ApprovalRevocation = &shared.ApprovalRevocation{PreviousCommit: "abc"}
+	assert.Equal(t, "\n🔄 **Approval revoked**: commits pushed after `abc` changed the decision\n• Merge settings violated\n",
+		mb.BuildApprovalRevocationSection(result))
+
+	result.ApprovalRevocation.Regressions = []shared.RuleRegression{
+		{FilePath: "product.yaml", RuleName: "tag_rule", Current: shared.ManualReview, Reason: "Unknown tag"},
+	}
+	assert.Contains(t, mb.BuildApprovalRevocationSection(result), "• `product.yaml` (`tag_rule`): not evaluated → manual_review: Unknown tag")
+}
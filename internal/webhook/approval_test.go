@@ -2,6 +2,7 @@ package webhook
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -62,6 +63,7 @@ func (m *MockRuleManagerForApproval) EvaluateAll(ctx *shared.MRContext) *shared.
 }
 
 func TestHandleApprovalWithComments_Success(t *testing.T) {
+	ctx := context.Background()
 	// Create test GitLab server that expects both comment and approval calls
 	var commentReceived, approvalReceived bool
 	gitlabServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -156,7 +158,7 @@ func TestHandleApprovalWithComments_Success(t *testing.T) {
 		State:     "opened",
 	}
 
-	err := handler.handleApprovalWithComments(result, mrInfo)
+	err := handler.handleApprovalWithComments(ctx, result, mrInfo)
 
 	assert.NoError(t, err)
 	assert.True(t, commentReceived, "Should have posted comment to GitLab")
@@ -164,6 +166,7 @@ func TestHandleApprovalWithComments_Success(t *testing.T) {
 }
 
 func TestHandleApprovalWithComments_CommentsDisabled(t *testing.T) {
+	ctx := context.Background()
 	// Create test GitLab server that should only receive approval call (no comment)
 	var commentReceived, approvalReceived bool
 	gitlabServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -214,7 +217,7 @@ func TestHandleApprovalWithComments_CommentsDisabled(t *testing.T) {
 		State:     "opened",
 	}
 
-	err := handler.handleApprovalWithComments(result, mrInfo)
+	err := handler.handleApprovalWithComments(ctx, result, mrInfo)
 
 	assert.NoError(t, err)
 	assert.False(t, commentReceived, "Should not have posted comment when disabled")
@@ -222,6 +225,7 @@ func TestHandleApprovalWithComments_CommentsDisabled(t *testing.T) {
 }
 
 func TestHandleApprovalWithComments_CommentFailsContinues(t *testing.T) {
+	ctx := context.Background()
 	// Create test GitLab server that fails comment but succeeds approval
 	var approvalReceived bool
 	gitlabServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -274,7 +278,7 @@ func TestHandleApprovalWithComments_CommentFailsContinues(t *testing.T) {
 		State:     "opened",
 	}
 
-	err := handler.handleApprovalWithComments(result, mrInfo)
+	err := handler.handleApprovalWithComments(ctx, result, mrInfo)
 
 	// Should succeed even if comment fails
 	assert.NoError(t, err)
@@ -282,6 +286,7 @@ func TestHandleApprovalWithComments_CommentFailsContinues(t *testing.T) {
 }
 
 func TestHandleApprovalWithComments_ApprovalFallback(t *testing.T) {
+	ctx := context.Background()
 	// Create test GitLab server that fails approval with message but succeeds simple approval
 	callCount := 0
 	gitlabServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -336,13 +341,14 @@ func TestHandleApprovalWithComments_ApprovalFallback(t *testing.T) {
 		State:     "opened",
 	}
 
-	err := handler.handleApprovalWithComments(result, mrInfo)
+	err := handler.handleApprovalWithComments(ctx, result, mrInfo)
 
 	assert.NoError(t, err)
 	assert.Equal(t, 2, callCount, "Should have made 2 approval attempts (with message, then fallback)")
 }
 
 func TestHandleApprovalWithComments_BothApprovalsFail(t *testing.T) {
+	ctx := context.Background()
 	// Create test GitLab server that fails both approval attempts
 	gitlabServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if strings.Contains(r.URL.Path, "/approve") {
@@ -388,7 +394,7 @@ func TestHandleApprovalWithComments_BothApprovalsFail(t *testing.T) {
 		State:     "opened",
 	}
 
-	err := handler.handleApprovalWithComments(result, mrInfo)
+	err := handler.handleApprovalWithComments(ctx, result, mrInfo)
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to approve MR (both with message and simple)")
@@ -399,21 +405,19 @@ func TestWebhookHandler_FullApprovalWorkflow(t *testing.T) {
 
 	// Create mock GitLab server for changes API (to avoid manual review due to API failure)
 	changesServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if strings.Contains(r.URL.Path, "/changes") {
+		if strings.Contains(r.URL.Path, "/diffs") {
 			// Return mock changes that should trigger approval
 			w.WriteHeader(200)
-			_, _ = w.Write([]byte(`{
-				"changes": [
-					{
-						"old_path": "dataproducts/agg/test/prod/product.yaml",
-						"new_path": "dataproducts/agg/test/prod/product.yaml",
-						"new_file": false,
-						"renamed_file": false,
-						"deleted_file": false,
-						"diff": "@@ -10,7 +10,7 @@\n warehouses:\n-  - name: old\n+  - name: new"
-					}
-				]
-			}`))
+			_, _ = w.Write([]byte(`[
+				{
+					"old_path": "dataproducts/agg/test/prod/product.yaml",
+					"new_path": "dataproducts/agg/test/prod/product.yaml",
+					"new_file": false,
+					"renamed_file": false,
+					"deleted_file": false,
+					"diff": "@@ -10,7 +10,7 @@\n warehouses:\n-  - name: old\n+  - name: new"
+				}
+			]`))
 		} else if strings.Contains(r.URL.Path, "/notes") {
 			// Mock comment creation
 			w.WriteHeader(201)
@@ -0,0 +1,89 @@
+package webhook
+
+import (
+	"sort"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/audit"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+)
+
+// recordDecision writes the applied review decision to the audit log
+func recordDecision(result *shared.RuleEvaluation, mrInfo *gitlab.MRInfo, approved bool, err error) {
+	if !audit.Enabled() {
+		return
+	}
+	event := audit.Event{
+		ID:           result.DecisionID,
+		Kind:         audit.KindDecision,
+		ProjectID:    mrInfo.ProjectID,
+		MRIID:        mrInfo.MRIID,
+		Title:        mrInfo.Title,
+		Author:       mrInfo.Author,
+		SourceBranch: mrInfo.SourceBranch,
+		TargetBranch: mrInfo.TargetBranch,
+		Rules:        auditRuleResults(result),
+		Decision:     string(result.FinalDecision.Type),
+		Reason:       result.FinalDecision.Reason,
+		SnapshotID:   result.SnapshotID,
+		Approved:     approved,
+		Outcome:      audit.OutcomeSucceeded,
+	}
+	if err != nil {
+		event.Outcome, event.Error = audit.OutcomeFailed, err.Error()
+	}
+	audit.Record(event)
+}
+
+// recordAction writes an auto-rebase, stale closure or override of an MR to the audit log
+func recordAction(kind string, projectID int, mr gitlab.MRDetails, reason string, err error) {
+	if !audit.Enabled() {
+		return
+	}
+	event := audit.Event{
+		Kind:         kind,
+		ProjectID:    projectID,
+		MRIID:        mr.IID,
+		Title:        mr.Title,
+		SourceBranch: mr.SourceBranch,
+		TargetBranch: mr.TargetBranch,
+		Reason:       reason,
+		Outcome:      audit.OutcomeSucceeded,
+	}
+	if mr.Author != nil {
+		event.Author = mr.Author.Username
+	}
+	if err != nil {
+		event.Outcome, event.Error = audit.OutcomeFailed, err.Error()
+	}
+	audit.Record(event)
+}
+
+// auditRuleResults lists the evaluated rule results per file, sorted by file
+func auditRuleResults(result *shared.RuleEvaluation) []audit.RuleResult {
+	files := make([]string, 0, len(result.FileValidations))
+	for file := range result.FileValidations {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	var results []audit.RuleResult
+	for _, file := range files {
+		summary := result.FileValidations[file]
+		if summary == nil {
+			continue
+		}
+		for _, ruleResult := range summary.RuleResults {
+			if !ruleResult.WasEvaluated {
+				continue
+			}
+			results = append(results, audit.RuleResult{
+				File:     file,
+				Rule:     ruleResult.RuleName,
+				Decision: string(ruleResult.Decision),
+				Reason:   ruleResult.Reason,
+			})
+		}
+	}
+	return results
+}
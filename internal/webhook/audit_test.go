@@ -0,0 +1,131 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/audit"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+)
+
+// auditCommentClient keeps the last posted decision comment
+type auditCommentClient struct {
+	MockGitLabClient
+	comment string
+}
+
+func (m *auditCommentClient) AddMRComment(ctx context.Context, projectID, mrIID int, comment string) error {
+	m.comment = comment
+	return nil
+}
+
+// captureAudit installs an audit logger writing to the returned buffer for the test
+func captureAudit(t *testing.T) *bytes.Buffer {
+	var buf bytes.Buffer
+	audit.SetDefault(audit.NewLogger(&buf))
+	t.Cleanup(func() { audit.SetDefault(nil) })
+	return &buf
+}
+
+// auditEvents decodes the JSON lines written to buf
+func auditEvents(t *testing.T, buf *bytes.Buffer) []audit.Event {
+	var events []audit.Event
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var event audit.Event
+		assert.NoError(t, json.Unmarshal([]byte(line), &event))
+		events = append(events, event)
+	}
+	return events
+}
+
+func TestApplyDecision_RecordsAuditEvent(t *testing.T) {
+	ctx := context.Background()
+	buf := captureAudit(t)
+	client := &auditCommentClient{}
+	cfg := createTestConfig()
+	cfg.Comments.EnableMRComments = true
+	handler := &DataProductConfigMrReviewHandler{gitlabClient: client, config: cfg}
+
+	result := &shared.RuleEvaluation{
+		FinalDecision: shared.Decision{Type: shared.Approve, Reason: "All rules passed"},
+		FileValidations: map[string]*shared.FileValidationSummary{
+			"dataproducts/source/sales/prod/product.yaml": {RuleResults: []shared.LineValidationResult{
+				{RuleName: "warehouse_rule", Decision: shared.Approve, Reason: "Warehouse decrease", WasEvaluated: true},
+				{RuleName: "skipped_rule", Decision: shared.Approve},
+			}},
+		},
+		DecisionID: "0123456789abcdef",
+		SnapshotID: "snap-1",
+	}
+	mrInfo := &gitlab.MRInfo{ProjectID: 42, MRIID: 7, Title: "Shrink warehouse", Author: "alice", SourceBranch: "shrink", TargetBranch: "main"}
+
+	approved, err := handler.applyDecision(ctx, result, mrInfo)
+	assert.NoError(t, err)
+	assert.True(t, approved)
+	assert.Contains(t, client.comment, "🔎 Decision ID: `0123456789abcdef`")
+
+	events := auditEvents(t, buf)
+	assert.Len(t, events, 1)
+	event := events[0]
+	assert.Equal(t, "0123456789abcdef", event.ID)
+	assert.Equal(t, audit.KindDecision, event.Kind)
+	assert.Equal(t, 42, event.ProjectID)
+	assert.Equal(t, 7, event.MRIID)
+	assert.Equal(t, "alice", event.Author)
+	assert.Equal(t, "approve", event.Decision)
+	assert.True(t, event.Approved)
+	assert.Equal(t, "snap-1", event.SnapshotID)
+	assert.Equal(t, audit.OutcomeSucceeded, event.Outcome)
+	assert.Equal(t, []audit.RuleResult{{
+		File: "dataproducts/source/sales/prod/product.yaml", Rule: "warehouse_rule", Decision: "approve", Reason: "Warehouse decrease",
+	}}, event.Rules)
+}
+
+func TestApplyDecision_NoAuditWhenDisabled(t *testing.T) {
+	ctx := context.Background()
+	client := &auditCommentClient{}
+	cfg := createTestConfig()
+	cfg.Comments.EnableMRComments = true
+	handler := &DataProductConfigMrReviewHandler{gitlabClient: client, config: cfg}
+
+	result := &shared.RuleEvaluation{FinalDecision: shared.Decision{Type: shared.ManualReview, Reason: "Warehouse increase"}}
+	approved, err := handler.applyDecision(ctx, result, &gitlab.MRInfo{ProjectID: 42, MRIID: 8})
+	assert.NoError(t, err)
+	assert.False(t, approved)
+	assert.NotContains(t, client.comment, "Decision ID")
+}
+
+func TestStaleMRCleanup_RecordsAuditEvents(t *testing.T) {
+	ctx := context.Background()
+	buf := captureAudit(t)
+	now := time.Now()
+	client := &MockStaleMRClient{openMRs: []gitlab.MRDetails{
+		{IID: 3, Title: "Old change", Author: &gitlab.MRUser{Username: "bob"}, UpdatedAt: now.AddDate(0, 0, -40).Format(time.RFC3339)},
+	}}
+	handler := NewStaleMRCleanupHandlerWithClient(createTestConfig(), client)
+
+	_, err := handler.processCleanup(ctx, &StaleMRCleanupPayload{ProjectID: 42, ClosureDays: 30, DryRun: true})
+	assert.NoError(t, err)
+	assert.Empty(t, auditEvents(t, buf), "dry runs are not audited")
+
+	_, err = handler.processCleanup(ctx, &StaleMRCleanupPayload{ProjectID: 42, ClosureDays: 30})
+	assert.NoError(t, err)
+	events := auditEvents(t, buf)
+	assert.Len(t, events, 1)
+	assert.Equal(t, audit.KindStaleClose, events[0].Kind)
+	assert.Equal(t, 3, events[0].MRIID)
+	assert.Equal(t, "bob", events[0].Author)
+	assert.Equal(t, "inactive for 40 days", events[0].Reason)
+	assert.Equal(t, audit.OutcomeSucceeded, events[0].Outcome)
+	assert.NotEmpty(t, events[0].ID)
+}
@@ -0,0 +1,117 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/audit"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"go.uber.org/zap"
+)
+
+// mrMerger merges MRs. The GitLab client implements it; without it MRs are never auto-merged.
+type mrMerger interface {
+	MergeMR(ctx context.Context, projectID, mrIID int, opts gitlab.MergeOptions) error
+}
+
+// autoMerge merges an MR naysayer just approved when it carries the auto-merge label and
+// passes the safety checks. Running pipelines are left to GitLab to merge once they succeed.
+// Overridden decisions are never auto-merged: only MRs passing all rules are.
+func (h *DataProductConfigMrReviewHandler) autoMerge(ctx context.Context, result *shared.RuleEvaluation, mrInfo *gitlab.MRInfo) {
+	if !h.config.AutoMerge.Enabled || result.FinalDecision.Summary == overrideSummary {
+		return
+	}
+	merger, ok := h.gitlabClient.(mrMerger)
+	if !ok {
+		return
+	}
+
+	details, err := h.gitlabClient.GetMRDetails(ctx, mrInfo.ProjectID, mrInfo.MRIID)
+	if err != nil || details == nil {
+		logging.FromContext(ctx).MRWarn(mrInfo.MRIID, "Failed to load MR details for auto-merge", zap.Error(err))
+		return
+	}
+	if !hasLabel(details.Labels, h.config.AutoMerge.Label) || details.MergeWhenPipelineSucceeds {
+		return
+	}
+
+	if reason := h.autoMergeBlocker(ctx, details, mrInfo); reason != "" {
+		logging.FromContext(ctx).MRInfo(mrInfo.MRIID, "Auto-merge on hold", zap.String("reason", reason))
+		h.postAutoMergeComment(ctx, mrInfo, fmt.Sprintf("⏸️ **Auto-merge on hold**: %s.", reason))
+		return
+	}
+
+	// Merge the commit naysayer reviewed, not a later push
+	whenPipelineSucceeds := details.Pipeline != nil && details.Pipeline.Status != "success"
+	err = merger.MergeMR(ctx, mrInfo.ProjectID, mrInfo.MRIID, gitlab.MergeOptions{
+		SHA:                       details.Sha,
+		MergeWhenPipelineSucceeds: whenPipelineSucceeds,
+	})
+	recordAction(audit.KindMerge, mrInfo.ProjectID, *details, "labeled "+h.config.AutoMerge.Label, err)
+	if err != nil {
+		logging.FromContext(ctx).MRWarn(mrInfo.MRIID, "Auto-merge failed", zap.Error(err))
+		h.postAutoMergeComment(ctx, mrInfo, "❌ **Auto-merge failed**: GitLab refused to merge this MR, please merge it manually.")
+		return
+	}
+
+	if whenPipelineSucceeds {
+		logging.FromContext(ctx).MRInfo(mrInfo.MRIID, "Set to merge when the pipeline succeeds")
+		h.postAutoMergeComment(ctx, mrInfo, "🚀 **Auto-merge**: this MR will be merged once its pipeline succeeds.")
+		return
+	}
+	logging.FromContext(ctx).MRInfo(mrInfo.MRIID, "Auto-merged")
+	h.postAutoMergeComment(ctx, mrInfo, "🚀 **Auto-merge**: this MR was merged.")
+}
+
+// autoMergeBlocker describes why an approved MR cannot be merged yet, or returns "" when
+// conflicts, draft status, the pipeline and the project approval rules all allow it
+func (h *DataProductConfigMrReviewHandler) autoMergeBlocker(ctx context.Context, details *gitlab.MRDetails, mrInfo *gitlab.MRInfo) string {
+	switch {
+	case details.State != "opened":
+		return fmt.Sprintf("the MR is %s", details.State)
+	case details.IsDraft():
+		return "the MR is a draft"
+	case details.HasConflicts || details.MergeStatus == "cannot_be_merged":
+		return "the MR has merge conflicts"
+	case details.Pipeline != nil && (details.Pipeline.Status == "failed" || details.Pipeline.Status == "canceled"):
+		return fmt.Sprintf("the pipeline %s", details.Pipeline.Status)
+	}
+
+	getter, ok := h.gitlabClient.(approvalsGetter)
+	if !ok {
+		return "approvals could not be checked"
+	}
+	approvals, err := getter.GetMRApprovals(ctx, mrInfo.ProjectID, mrInfo.MRIID)
+	if err != nil {
+		logging.MRWarn(mrInfo.MRIID, "Could not check MR approvals for auto-merge", zap.Error(err))
+		return "approvals could not be checked"
+	}
+	if approvals.ApprovalsLeft > 0 {
+		return fmt.Sprintf("%d more approval(s) required by the project approval rules", approvals.ApprovalsLeft)
+	}
+	return ""
+}
+
+// postAutoMergeComment keeps a single auto-merge status comment on the MR
+func (h *DataProductConfigMrReviewHandler) postAutoMergeComment(ctx context.Context, mrInfo *gitlab.MRInfo, message string) {
+	if !h.config.Comments.EnableMRComments {
+		return
+	}
+	comment := "<!-- naysayer-comment-id: auto-merge -->\n" + message + "\n"
+	if err := h.postComment(ctx, mrInfo, comment, "auto-merge"); err != nil {
+		logging.FromContext(ctx).MRError(mrInfo.MRIID, "Failed to add auto-merge comment", err)
+	}
+}
+
+// hasLabel reports whether labels contain label, ignoring case
+func hasLabel(labels []string, label string) bool {
+	for _, l := range labels {
+		if strings.EqualFold(l, label) {
+			return true
+		}
+	}
+	return false
+}
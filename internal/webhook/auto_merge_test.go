@@ -0,0 +1,167 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+)
+
+// mergeGitLabClient records merges of an MR with the given details and approvals left
+type mergeGitLabClient struct {
+	*MockGitLabClient
+	details       *gitlab.MRDetails
+	approvalsLeft int
+	mergeErr      error
+	merges        []gitlab.MergeOptions
+	comments      []string
+}
+
+func (m *mergeGitLabClient) GetMRDetails(ctx context.Context, projectID, mrIID int) (*gitlab.MRDetails, error) {
+	return m.details, nil
+}
+
+func (m *mergeGitLabClient) GetMRApprovals(ctx context.Context, projectID, mrIID int) (*gitlab.MRApprovals, error) {
+	return &gitlab.MRApprovals{ApprovalsLeft: m.approvalsLeft}, nil
+}
+
+func (m *mergeGitLabClient) MergeMR(ctx context.Context, projectID, mrIID int, opts gitlab.MergeOptions) error {
+	m.merges = append(m.merges, opts)
+	return m.mergeErr
+}
+
+func (m *mergeGitLabClient) AddMRComment(ctx context.Context, projectID, mrIID int, comment string) error {
+	m.comments = append(m.comments, comment)
+	return nil
+}
+
+func mergeableMR() *gitlab.MRDetails {
+	return &gitlab.MRDetails{
+		IID: 9, State: "opened", Sha: "abc123", Labels: []string{"AutoMerge"},
+		MergeStatus: "can_be_merged", Pipeline: &gitlab.MRPipeline{ID: 1, Status: "success"},
+	}
+}
+
+func newAutoMergeTestHandler(client *mergeGitLabClient) *DataProductConfigMrReviewHandler {
+	cfg := createTestConfig()
+	cfg.Comments.EnableMRComments = true
+	cfg.AutoMerge.Enabled = true
+	cfg.AutoMerge.Label = "automerge"
+	return &DataProductConfigMrReviewHandler{gitlabClient: client, config: cfg}
+}
+
+func approvedResult() *shared.RuleEvaluation {
+	return &shared.RuleEvaluation{FinalDecision: shared.Decision{Type: shared.Approve, Reason: "All rules passed"}}
+}
+
+func TestAutoMerge(t *testing.T) {
+	ctx := context.Background()
+	client := &mergeGitLabClient{MockGitLabClient: &MockGitLabClient{}, details: mergeableMR()}
+	handler := newAutoMergeTestHandler(client)
+	events := captureAudit(t)
+
+	approved, err := handler.applyDecision(ctx, approvedResult(), &gitlab.MRInfo{ProjectID: 4, MRIID: 9})
+	assert.NoError(t, err)
+	assert.True(t, approved)
+	assert.Equal(t, []gitlab.MergeOptions{{SHA: "abc123"}}, client.merges)
+	assert.Contains(t, client.comments, "<!-- naysayer-comment-id: auto-merge -->\n🚀 **Auto-merge**: this MR was merged.\n")
+
+	recorded := auditEvents(t, events)
+	if assert.NotEmpty(t, recorded) {
+		last := recorded[len(recorded)-1]
+		assert.Equal(t, "merge", last.Kind)
+		assert.Equal(t, "labeled automerge", last.Reason)
+	}
+}
+
+func TestAutoMerge_RunningPipeline(t *testing.T) {
+	ctx := context.Background()
+	details := mergeableMR()
+	details.Pipeline.Status = "running"
+	client := &mergeGitLabClient{MockGitLabClient: &MockGitLabClient{}, details: details}
+	handler := newAutoMergeTestHandler(client)
+
+	handler.autoMerge(ctx, approvedResult(), &gitlab.MRInfo{ProjectID: 4, MRIID: 9})
+	assert.Equal(t, []gitlab.MergeOptions{{SHA: "abc123", MergeWhenPipelineSucceeds: true}}, client.merges)
+	assert.Contains(t, client.comments[0], "will be merged once its pipeline succeeds")
+
+	// An MR already set to merge is left alone
+	details.MergeWhenPipelineSucceeds = true
+	handler.autoMerge(ctx, approvedResult(), &gitlab.MRInfo{ProjectID: 4, MRIID: 9})
+	assert.Len(t, client.merges, 1)
+}
+
+func TestAutoMerge_SafetyChecks(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name          string
+		modify        func(*gitlab.MRDetails)
+		approvalsLeft int
+		reason        string
+	}{
+		{name: "draft", modify: func(d *gitlab.MRDetails) { d.Draft = true }, reason: "the MR is a draft"},
+		{name: "conflicts", modify: func(d *gitlab.MRDetails) { d.HasConflicts = true }, reason: "the MR has merge conflicts"},
+		{name: "cannot be merged", modify: func(d *gitlab.MRDetails) { d.MergeStatus = "cannot_be_merged" }, reason: "the MR has merge conflicts"},
+		{name: "failed pipeline", modify: func(d *gitlab.MRDetails) { d.Pipeline.Status = "failed" }, reason: "the pipeline failed"},
+		{name: "approvals left", modify: func(d *gitlab.MRDetails) {}, approvalsLeft: 2, reason: "2 more approval(s) required by the project approval rules"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			details := mergeableMR()
+			tt.modify(details)
+			client := &mergeGitLabClient{MockGitLabClient: &MockGitLabClient{}, details: details, approvalsLeft: tt.approvalsLeft}
+			handler := newAutoMergeTestHandler(client)
+
+			handler.autoMerge(ctx, approvedResult(), &gitlab.MRInfo{ProjectID: 4, MRIID: 9})
+			assert.Empty(t, client.merges)
+			assert.Equal(t, []string{fmt.Sprintf("<!-- naysayer-comment-id: auto-merge -->\n⏸️ **Auto-merge on hold**: %s.\n", tt.reason)}, client.comments)
+		})
+	}
+}
+
+func TestAutoMerge_Skipped(t *testing.T) {
+	ctx := context.Background()
+	unlabeled := mergeableMR()
+	unlabeled.Labels = []string{"docs"}
+	overridden := approvedResult()
+	overridden.FinalDecision.Summary = overrideSummary
+
+	for name, tc := range map[string]struct {
+		details *gitlab.MRDetails
+		result  *shared.RuleEvaluation
+		enabled bool
+	}{
+		"disabled":   {details: mergeableMR(), result: approvedResult()},
+		"unlabeled":  {details: unlabeled, result: approvedResult(), enabled: true},
+		"overridden": {details: mergeableMR(), result: overridden, enabled: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			client := &mergeGitLabClient{MockGitLabClient: &MockGitLabClient{}, details: tc.details}
+			handler := newAutoMergeTestHandler(client)
+			handler.config.AutoMerge.Enabled = tc.enabled
+
+			handler.autoMerge(ctx, tc.result, &gitlab.MRInfo{ProjectID: 4, MRIID: 9})
+			assert.Empty(t, client.merges)
+			assert.Empty(t, client.comments)
+		})
+	}
+}
+
+func TestAutoMerge_Refused(t *testing.T) {
+	ctx := context.Background()
+	client := &mergeGitLabClient{MockGitLabClient: &MockGitLabClient{}, details: mergeableMR(), mergeErr: fmt.Errorf("merge MR failed with status 409")}
+	handler := newAutoMergeTestHandler(client)
+	events := captureAudit(t)
+
+	handler.autoMerge(ctx, approvedResult(), &gitlab.MRInfo{ProjectID: 4, MRIID: 9})
+	assert.Contains(t, client.comments[0], "❌ **Auto-merge failed**")
+	recorded := auditEvents(t, events)
+	if assert.Len(t, recorded, 1) {
+		assert.Equal(t, "failed", recorded[0].Outcome)
+	}
+}
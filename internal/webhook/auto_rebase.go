@@ -1,47 +1,53 @@
 package webhook
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	fiber "github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
 
+	"github.com/redhat-data-and-ai/naysayer/internal/audit"
 	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/eligibility"
 	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
 	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/notify"
+	"github.com/redhat-data-and-ai/naysayer/internal/repoindex"
+	"github.com/redhat-data-and-ai/naysayer/internal/stats"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
 )
 
 // AutoRebaseHandler handles auto-rebase requests (generic, reusable across repositories)
 type AutoRebaseHandler struct {
 	gitlabClient gitlab.GitLabClient
 	config       *config.Config
+	stateStore   store.Store     // Optional: records the last processed target-branch commit
+	stats        *stats.Recorder // Optional: comment statistics
+	notifier     notify.Sink     // Optional: notified of rebases failing with conflicts
+	hooks        []eligibility.Hook
+
+	localRebaseTimes store.Store // Last rebase of each MR when no state store is set
 }
 
 // FivetranTerraformRebaseHandler is an alias for backward compatibility
+//
 // Deprecated: Use AutoRebaseHandler instead
 type FivetranTerraformRebaseHandler = AutoRebaseHandler
 
 // NewAutoRebaseHandler creates a new auto-rebase handler
 func NewAutoRebaseHandler(cfg *config.Config) *AutoRebaseHandler {
 	// Use repository-specific token if configured, otherwise use main token
-	token := cfg.AutoRebase.RepositoryToken
-	if token == "" {
-		token = cfg.GitLab.Token
+	if cfg.AutoRebase.RepositoryToken == "" {
 		logging.Info("Using main GITLAB_TOKEN for auto-rebase")
 	} else {
 		logging.Info("Using repository-specific token for auto-rebase")
 	}
 
-	// Create a custom config with the appropriate token
-	gitlabConfig := config.GitLabConfig{
-		BaseURL:     cfg.GitLab.BaseURL,
-		Token:       token,
-		InsecureTLS: cfg.GitLab.InsecureTLS,
-		CACertPath:  cfg.GitLab.CACertPath,
-	}
-
-	gitlabClient := gitlab.NewClient(gitlabConfig)
+	gitlabClient := gitlab.NewClientForFunction(cfg, config.EndpointAutoRebase)
 	return NewAutoRebaseHandlerWithClient(cfg, gitlabClient)
 }
 
@@ -56,19 +62,44 @@ func NewAutoRebaseHandlerWithClient(cfg *config.Config, client gitlab.GitLabClie
 		zap.Bool("atlantis_comment_check_enabled", cfg.AutoRebase.CheckAtlantisComments),
 		zap.String("atlantis_check_status", atlantisCheckStatus),
 		zap.Bool("auto_rebase_enabled", cfg.AutoRebase.Enabled))
+	hooks, err := eligibility.HooksFromConfig(cfg.AutoRebase)
+	if err != nil {
+		logging.Error("Invalid auto-rebase eligibility hooks, running without them", zap.Error(err))
+	}
 	return &AutoRebaseHandler{
 		gitlabClient: client,
 		config:       cfg,
+		notifier:     notify.NewRoutedSinkFromConfig(cfg, notify.EventRebaseConflict),
+		hooks:        hooks,
+
+		localRebaseTimes: store.NewMemoryStore(),
 	}
 }
 
+// SetStateStore enables recording of processed target-branch commits for outage catch-up
+func (h *AutoRebaseHandler) SetStateStore(st store.Store) {
+	h.stateStore = st
+}
+
+// SetStatsRecorder enables recording of rebase comments for comment statistics
+func (h *AutoRebaseHandler) SetStatsRecorder(recorder *stats.Recorder) {
+	h.stats = recorder
+}
+
+// SetEligibilityHooks replaces the additional skip checks run for every rebase candidate
+func (h *AutoRebaseHandler) SetEligibilityHooks(hooks []eligibility.Hook) {
+	h.hooks = hooks
+}
+
 // NewFivetranTerraformRebaseHandler creates a new handler (backward compatibility)
+//
 // Deprecated: Use NewAutoRebaseHandler instead
 func NewFivetranTerraformRebaseHandler(cfg *config.Config) *AutoRebaseHandler {
 	return NewAutoRebaseHandler(cfg)
 }
 
 // NewFivetranTerraformRebaseHandlerWithClient creates a handler with a custom GitLab client (backward compatibility)
+//
 // Deprecated: Use NewAutoRebaseHandlerWithClient instead
 func NewFivetranTerraformRebaseHandlerWithClient(cfg *config.Config, client gitlab.GitLabClient) *AutoRebaseHandler {
 	return NewAutoRebaseHandlerWithClient(cfg, client)
@@ -81,7 +112,7 @@ func (h *AutoRebaseHandler) HandleWebhook(c *fiber.Ctx) error {
 	// Quick validation of content type
 	if !c.Is("json") {
 		contentType := c.Get("Content-Type")
-		logging.Warn("Invalid content type: %s", contentType)
+		logging.Warn("Invalid content type", zap.String("content_type", contentType))
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"error": fmt.Sprintf("Content-Type must be application/json, got: %s", contentType),
 		})
@@ -90,7 +121,7 @@ func (h *AutoRebaseHandler) HandleWebhook(c *fiber.Ctx) error {
 	// Parse webhook payload
 	var payload map[string]interface{}
 	if err := c.BodyParser(&payload); err != nil {
-		logging.Error("Failed to parse payload: %v", err)
+		logging.Error("Failed to parse payload", zap.Error(err))
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"error": fmt.Sprintf("Invalid JSON payload: %v", err),
 		})
@@ -98,7 +129,7 @@ func (h *AutoRebaseHandler) HandleWebhook(c *fiber.Ctx) error {
 
 	// Validate webhook payload structure
 	if err := h.validateWebhookPayload(payload); err != nil {
-		logging.Warn("Webhook validation failed: %v", err)
+		logging.Warn("Webhook validation failed", zap.Error(err))
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"error": fmt.Sprintf("Invalid webhook payload: %v", err),
 		})
@@ -115,44 +146,52 @@ func (h *AutoRebaseHandler) HandleWebhook(c *fiber.Ctx) error {
 
 	// Handle push events to main branch (rebase all open MRs)
 	if eventType == "push" {
-		// Extract branch reference
-		ref, ok := payload["ref"].(string)
-		if !ok {
-			logging.Warn("Missing ref in push payload")
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"error": "Missing ref in payload",
-			})
-		}
-
-		// Check if push is to main/master branch
-		targetBranch := strings.TrimPrefix(ref, "refs/heads/")
-		if targetBranch != "main" && targetBranch != "master" {
-			logging.Info("Ignoring push to non-main branch: %s", targetBranch)
-			return c.JSON(fiber.Map{
-				"webhook_response": "processed",
-				"status":           "skipped",
-				"reason":           fmt.Sprintf("Push to %s branch, only main/master triggers rebase", targetBranch),
-				"branch":           targetBranch,
-			})
-		}
-
-		return h.handlePushToMain(c, payload, targetBranch)
+		return h.handlePushEvent(c, payload)
 	}
 
 	// Unsupported event type
-	logging.Warn("Skipping unsupported event: %s", eventType)
+	logging.Warn("Skipping unsupported event", zap.String("event_type", eventType))
 	return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 		"error": fmt.Sprintf("Unsupported event type: %s. Only push events are supported.", eventType),
 	})
 }
 
+// handlePushEvent rebases open MRs after pushes to main/master and skips other branches
+func (h *AutoRebaseHandler) handlePushEvent(c *fiber.Ctx, payload map[string]interface{}) error {
+	// Extract branch reference
+	ref, ok := payload["ref"].(string)
+	if !ok {
+		logging.Warn("Missing ref in push payload")
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Missing ref in payload",
+		})
+	}
+
+	invalidatePushedFiles(payload)
+
+	// Check if push is to main/master branch
+	targetBranch := strings.TrimPrefix(ref, "refs/heads/")
+	if targetBranch != "main" && targetBranch != "master" {
+		logging.Info("Ignoring push to non-main branch", zap.String("branch", targetBranch))
+		return c.JSON(fiber.Map{
+			"webhook_response": "processed",
+			"status":           "skipped",
+			"reason":           fmt.Sprintf("Push to %s branch, only main/master triggers rebase", targetBranch),
+			"branch":           targetBranch,
+		})
+	}
+
+	return h.handlePushToMain(c, payload, targetBranch)
+}
+
 // handlePushToMain handles push events to main branch by rebasing all open MRs
 // targetBranch is already validated to be "main" or "master" by the caller
 func (h *AutoRebaseHandler) handlePushToMain(c *fiber.Ctx, payload map[string]interface{}, targetBranch string) error {
+	ctx := c.UserContext()
 	// Extract project ID
 	project, ok := payload["project"].(map[string]interface{})
 	if !ok {
-		logging.Error("Missing project information in push payload")
+		logging.FromContext(ctx).Error("Missing project information in push payload")
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"error": "Missing project information",
 		})
@@ -160,7 +199,7 @@ func (h *AutoRebaseHandler) handlePushToMain(c *fiber.Ctx, payload map[string]in
 
 	projectIDFloat, ok := project["id"].(float64)
 	if !ok {
-		logging.Error("Invalid project ID in push payload")
+		logging.FromContext(ctx).Error("Invalid project ID in push payload")
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"error": "Invalid project ID",
 		})
@@ -169,49 +208,124 @@ func (h *AutoRebaseHandler) handlePushToMain(c *fiber.Ctx, payload map[string]in
 	// Convert projectID to int once and reuse throughout
 	projectID := int(projectIDFloat)
 
-	logging.Info("Push to main branch detected, rebasing eligible open MRs",
+	// Keep the repository index in sync with the pushed branch
+	if idx := repoindex.ForInstance(h.config.GitLab.Instance); idx != nil {
+		if err := idx.ApplyPush(ctx, projectID, targetBranch, payload); err != nil {
+			logging.FromContext(ctx).Warn("Failed to update repository index",
+				zap.Int("project_id", projectID),
+				zap.String("branch", targetBranch),
+				zap.Error(err))
+		}
+	}
+
+	logging.FromContext(ctx).Info("Push to main branch detected, rebasing eligible open MRs",
 		zap.String("branch", targetBranch),
 		zap.Int("project_id", projectID))
 
-	// Get all open MRs with details (already filtered by created_after at API level)
-	allMRs, err := h.gitlabClient.ListOpenMRsWithDetails(projectID)
+	pass, err := h.RunRebasePass(ctx, projectID, targetBranch)
+	if errors.Is(err, gitlab.ErrArchived) {
+		logging.FromContext(ctx).Info("Skipping auto-rebase for archived project", zap.Int("project_id", projectID))
+		return c.JSON(fiber.Map{
+			"webhook_response": "processed",
+			"status":           "skipped",
+			"reason":           "Project is archived",
+			"project_id":       projectID,
+			"branch":           targetBranch,
+		})
+	}
 	if err != nil {
-		logging.Error("Failed to list open MRs: %v", err)
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error":      fmt.Sprintf("Failed to list open MRs: %v", err),
+			"error":      err.Error(),
 			"project_id": projectID,
 		})
 	}
 
+	// Remember the processed head so missed pushes can be detected later
+	if after, ok := payload["after"].(string); ok {
+		h.recordProcessedCommit(projectID, targetBranch, after)
+	}
+
+	// Build response
+	response := fiber.Map{
+		"webhook_response": "processed",
+		"status":           "completed",
+		"project_id":       projectID,
+		"branch":           targetBranch,
+		"total_mrs":        pass.TotalMRs,
+		"eligible_mrs":     pass.EligibleMRs,
+		"successful":       pass.Successful,
+		"failed":           pass.Failed,
+		"skipped":          len(pass.Skipped),
+		"skip_details":     pass.Skipped,
+	}
+
+	if pass.Failed > 0 {
+		response["failures"] = pass.Failures
+	}
+
+	return c.JSON(response)
+}
+
+// RebasePassResult summarizes one auto-rebase eligibility pass over a project's open MRs
+type RebasePassResult struct {
+	TotalMRs    int
+	EligibleMRs int
+	Successful  int
+	Failed      int
+	Skipped     []MRSkipInfo
+	Failures    []map[string]interface{}
+}
+
+// RunRebasePass lists open MRs targeting the project, filters eligible ones and rebases those behind the target branch.
+// Returns an error wrapping gitlab.ErrArchived without touching any MR when the project is archived.
+func (h *AutoRebaseHandler) RunRebasePass(ctx context.Context, projectID int, targetBranch string) (*RebasePassResult, error) {
+	// Archived projects are read-only: every rebase would fail with 403
+	if isProjectArchived(ctx, h.gitlabClient, projectID) {
+		return nil, fmt.Errorf("project %d: %w", projectID, gitlab.ErrArchived)
+	}
+
+	// Get all open MRs with details (already filtered by created_after at API level)
+	allMRs, err := h.gitlabClient.ListOpenMRsWithDetails(ctx, projectID)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to list open MRs", zap.Error(err))
+		return nil, fmt.Errorf("failed to list open MRs: %w", err)
+	}
+
 	// Filter MRs based on pipeline status
 	// Note: Date filtering is already done at API level via created_after parameter
-	filterResult := h.filterEligibleMRs(projectID, allMRs)
-	eligibleMRs := filterResult.Eligible
+	filterResult := h.filterEligibleMRs(ctx, projectID, allMRs)
+	eligibleMRs, cooling := h.applyRebaseCooldown(projectID, filterResult.Eligible, time.Now())
+	filterResult.Skipped = append(filterResult.Skipped, cooling...)
+	orderRebaseCandidates(h.config.AutoRebase, eligibleMRs)
 
 	if len(eligibleMRs) == 0 {
-		logging.Info("No eligible MRs found to rebase")
-		return c.JSON(fiber.Map{
-			"webhook_response": "processed",
-			"status":           "completed",
-			"project_id":       projectID,
-			"branch":           targetBranch,
-			"total_mrs":        len(allMRs),
-			"eligible_mrs":     0,
-			"successful":       0,
-			"failed":           0,
-			"skipped":          len(allMRs),
-			"skip_details":     filterResult.Skipped,
-		})
+		logging.FromContext(ctx).Info("No eligible MRs found to rebase")
+		return &RebasePassResult{
+			TotalMRs: len(allMRs),
+			Skipped:  filterResult.Skipped,
+			Failures: make([]map[string]interface{}, 0),
+		}, nil
 	}
 
-	logging.Info("Found %d eligible MRs to rebase out of %d total open MRs", len(eligibleMRs), len(allMRs))
+	logging.FromContext(ctx).Info("Found eligible MRs to rebase",
+		zap.Int("eligible", len(eligibleMRs)),
+		zap.Int("open", len(allMRs)))
 
 	// Rebase all eligible MRs
 	successCount := 0
 	failureCount := 0
 	failures := make([]map[string]interface{}, 0)
+	skipped := filterResult.Skipped
+	maxRebases := h.config.AutoRebase.MaxRebasesPerPush
+	attempted := 0
 
 	for _, mr := range eligibleMRs {
+		// Remaining MRs wait for the next pass instead of retriggering all pipelines at once
+		if maxRebases > 0 && attempted >= maxRebases {
+			skipped = append(skipped, MRSkipInfo{MRIID: mr.IID, Reason: "batch_limit"})
+			continue
+		}
+
 		// Determine source project ID (handles fork MRs)
 		sourceProjectID := mr.SourceProjectID
 		if sourceProjectID == 0 {
@@ -228,9 +342,9 @@ func (h *AutoRebaseHandler) handlePushToMain(c *fiber.Ctx, payload map[string]in
 			// 1) MR.sha = source branch HEAD, 2) get target branch SHA, 3) compare in target project.
 			if mr.Sha == "" {
 				// Fetch full MR details to get sha (list endpoint may not include it)
-				details, getErr := h.gitlabClient.GetMRDetails(projectID, mr.IID)
+				details, getErr := h.gitlabClient.GetMRDetails(ctx, projectID, mr.IID)
 				if getErr != nil || details.Sha == "" {
-					logging.Warn("Fork MR has no sha, skipping rebase",
+					logging.FromContext(ctx).Warn("Fork MR has no sha, skipping rebase",
 						zap.Int("mr_iid", mr.IID),
 						zap.Int("source_project_id", sourceProjectID),
 						zap.Error(getErr))
@@ -243,9 +357,9 @@ func (h *AutoRebaseHandler) handlePushToMain(c *fiber.Ctx, payload map[string]in
 				}
 				mr.Sha = details.Sha
 			}
-			targetBranchSHA, err := h.gitlabClient.GetBranchCommit(projectID, mr.TargetBranch)
+			targetBranchSHA, err := h.gitlabClient.GetBranchCommit(ctx, projectID, mr.TargetBranch)
 			if err != nil {
-				logging.Warn("Failed to get target branch commit for fork MR, skipping rebase",
+				logging.FromContext(ctx).Warn("Failed to get target branch commit for fork MR, skipping rebase",
 					zap.Int("mr_iid", mr.IID),
 					zap.String("target_branch", mr.TargetBranch),
 					zap.Error(err))
@@ -257,21 +371,21 @@ func (h *AutoRebaseHandler) handlePushToMain(c *fiber.Ctx, payload map[string]in
 				continue
 			}
 			var res *gitlab.CompareResult
-			res, err = h.gitlabClient.CompareCommits(projectID, mr.Sha, targetBranchSHA)
+			res, err = h.gitlabClient.CompareCommits(ctx, projectID, mr.Sha, targetBranchSHA)
 			if err == nil {
 				compareResult = res
 			}
 		} else {
 			// Same-project: compare by branch name
 			var res *gitlab.CompareResult
-			res, err = h.gitlabClient.CompareBranches(projectID, mr.SourceBranch, projectID, mr.TargetBranch)
+			res, err = h.gitlabClient.CompareBranches(ctx, projectID, mr.SourceBranch, projectID, mr.TargetBranch)
 			if err == nil {
 				compareResult = res
 			}
 		}
 
 		if err != nil {
-			logging.Warn("Failed to compare for MR, skipping rebase",
+			logging.FromContext(ctx).Warn("Failed to compare for MR, skipping rebase",
 				zap.Int("mr_iid", mr.IID),
 				zap.Int("source_project_id", sourceProjectID),
 				zap.String("source_branch", mr.SourceBranch),
@@ -288,7 +402,7 @@ func (h *AutoRebaseHandler) handlePushToMain(c *fiber.Ctx, payload map[string]in
 		}
 
 		behindByCompare := len(compareResult.Commits)
-		logging.Info("Evaluating MR for rebase",
+		logging.FromContext(ctx).Info("Evaluating MR for rebase",
 			zap.Int("mr_iid", mr.IID),
 			zap.Int("source_project_id", sourceProjectID),
 			zap.String("source_branch", mr.SourceBranch),
@@ -300,7 +414,7 @@ func (h *AutoRebaseHandler) handlePushToMain(c *fiber.Ctx, payload map[string]in
 		// AUTHORITATIVE CHECK: Use Compare API result to determine if rebase is needed
 		// If behind_by_compare == 0, source branch already contains all target branch commits
 		if behindByCompare == 0 {
-			logging.Info("Skipping rebase: source branch already contains target branch commits",
+			logging.FromContext(ctx).Info("Skipping rebase: source branch already contains target branch commits",
 				zap.Int("mr_iid", mr.IID),
 				zap.Int("source_project_id", sourceProjectID),
 				zap.String("source_branch", mr.SourceBranch),
@@ -310,7 +424,7 @@ func (h *AutoRebaseHandler) handlePushToMain(c *fiber.Ctx, payload map[string]in
 			continue
 		}
 
-		logging.Info("Rebase required: target branch has commits missing in source branch",
+		logging.FromContext(ctx).Info("Rebase required: target branch has commits missing in source branch",
 			zap.Int("mr_iid", mr.IID),
 			zap.Int("source_project_id", sourceProjectID),
 			zap.String("source_branch", mr.SourceBranch),
@@ -319,56 +433,105 @@ func (h *AutoRebaseHandler) handlePushToMain(c *fiber.Ctx, payload map[string]in
 			zap.Bool("is_fork_mr", isForkMR),
 			zap.Int("behind_by_compare", behindByCompare))
 
-		success, err := h.gitlabClient.RebaseMR(projectID, mr.IID)
+		attempted++
+		success, err := h.gitlabClient.RebaseMR(ctx, projectID, mr.IID)
+		outcome := RebaseOutcome(err)
+		if isForkRebasePermissionError(err) {
+			// The bot cannot push to the fork's source branch: skip the MR instead of failing
+			// the pass, and ask the author once to rebase manually
+			logging.FromContext(ctx).Info("Skipping rebase of fork MR without push access to its source branch",
+				zap.Int("mr_iid", mr.IID),
+				zap.Int("source_project_id", sourceProjectID),
+				zap.Error(err))
+			skipped = append(skipped, MRSkipInfo{MRIID: mr.IID, Reason: "fork_no_push_access"})
+			h.postForkRebaseComment(ctx, projectID, mr.IID)
+			continue
+		}
+		recordRebaseOutcome(outcome)
+		if err != nil || success {
+			recordAction(audit.KindRebase, projectID, mr, fmt.Sprintf("behind %s by %d commits", mr.TargetBranch, behindByCompare), err)
+		}
 		if err != nil {
-			logging.Warn("Failed to rebase MR", zap.Int("mr_iid", mr.IID), zap.Error(err))
+			logging.FromContext(ctx).Warn("Failed to rebase MR", zap.Int("mr_iid", mr.IID), zap.String("outcome", outcome), zap.Error(err))
+			switch outcome {
+			case RebaseOutcomeConflict:
+				h.notifyRebaseConflict(projectID, mr, err)
+				h.postRebaseConflictComment(ctx, projectID, mr, compareResult.Commits)
+			case RebaseOutcomeFailed:
+				h.postRebaseFailureComment(ctx, projectID, mr, err)
+			}
 			failureCount++
 			failures = append(failures, map[string]interface{}{
-				"mr_iid": mr.IID,
-				"error":  err.Error(),
+				"mr_iid":  mr.IID,
+				"error":   err.Error(),
+				"outcome": outcome,
 			})
-			// When rebase fails due to fork permissions (cannot push to source branch), comment on the MR so author knows to rebase manually
-			if isForkRebasePermissionError(err) {
-				forkComment := "🤖 **Auto-rebase attempted**\n\nThis merge request is from a fork. Automated rebase was attempted but cannot push to the fork's source branch (insufficient permissions). Please **rebase manually** to bring in the latest changes from the target branch.\n\n_This is an automated message._"
-				if commentErr := h.gitlabClient.AddMRComment(projectID, mr.IID, forkComment); commentErr != nil {
-					logging.Warn("Failed to add fork rebase comment to MR", zap.Int("mr_iid", mr.IID), zap.Error(commentErr))
-				}
-			}
 		} else if success {
-			logging.Info("Successfully rebased MR", zap.Int("mr_iid", mr.IID))
+			logging.FromContext(ctx).Info("Successfully rebased MR", zap.Int("mr_iid", mr.IID))
 			successCount++
-			commentBody := "🤖 **Automated Rebase**\n\nThis merge request has been automatically rebased with the latest changes from the target branch.\n\n_This is an automated action triggered by a push to the main branch._"
-			if commentErr := h.gitlabClient.AddMRComment(projectID, mr.IID, commentBody); commentErr != nil {
-				logging.Warn("Failed to add rebase comment to MR", zap.Int("mr_iid", mr.IID), zap.Error(commentErr))
+			h.recordRebase(projectID, mr.IID, time.Now())
+			commentBody := NewMessageBuilder(h.config).BuildRebaseComment(RebaseCommentData{
+				ProjectID:    projectID,
+				MRIID:        mr.IID,
+				Title:        mr.Title,
+				Author:       authorUsername(mr),
+				SourceBranch: mr.SourceBranch,
+				TargetBranch: mr.TargetBranch,
+				BehindBy:     behindByCompare,
+			})
+			if commentErr := h.gitlabClient.AddMRComment(ctx, projectID, mr.IID, commentBody); commentErr != nil {
+				logging.FromContext(ctx).Warn("Failed to add rebase comment to MR", zap.Int("mr_iid", mr.IID), zap.Error(commentErr))
+			} else {
+				h.stats.Record(stats.KindRebase, projectID, mr.IID)
 			}
 		}
 	}
 
-	// Build response
-	response := fiber.Map{
-		"webhook_response": "processed",
-		"status":           "completed",
-		"project_id":       projectID,
-		"branch":           targetBranch,
-		"total_mrs":        len(allMRs),
-		"eligible_mrs":     len(eligibleMRs),
-		"successful":       successCount,
-		"failed":           failureCount,
-		"skipped":          len(allMRs) - len(eligibleMRs),
-		"skip_details":     filterResult.Skipped,
-	}
-
-	if failureCount > 0 {
-		response["failures"] = failures
-	}
-
-	logging.Info("Rebase operation completed",
+	logging.FromContext(ctx).Info("Rebase operation completed",
 		zap.Int("total", len(allMRs)),
 		zap.Int("eligible", len(eligibleMRs)),
 		zap.Int("successful", successCount),
 		zap.Int("failed", failureCount))
 
-	return c.JSON(response)
+	return &RebasePassResult{
+		TotalMRs:    len(allMRs),
+		EligibleMRs: len(eligibleMRs),
+		Successful:  successCount,
+		Failed:      failureCount,
+		Skipped:     skipped,
+		Failures:    failures,
+	}, nil
+}
+
+// forkRebaseCommentMarker identifies the comment asking the author of a fork MR to rebase manually
+const forkRebaseCommentMarker = "🤖 **Auto-rebase attempted**"
+
+// postForkRebaseComment asks the author of a fork MR to rebase manually, unless an earlier
+// pass already did
+func (h *AutoRebaseHandler) postForkRebaseComment(ctx context.Context, projectID, mrIID int) {
+	if found, err := h.gitlabClient.FindCommentByPattern(ctx, projectID, mrIID, forkRebaseCommentMarker); err != nil || found {
+		return
+	}
+	forkComment := forkRebaseCommentMarker + "\n\nThis merge request is from a fork. Automated rebase was attempted but cannot push to the fork's source branch (insufficient permissions). Please **rebase manually** to bring in the latest changes from the target branch, or enable **Allow commits from members who can merge to the target branch** on the MR.\n\n_This is an automated message._"
+	if err := h.gitlabClient.AddMRComment(ctx, projectID, mrIID, forkComment); err != nil {
+		logging.FromContext(ctx).Warn("Failed to add fork rebase comment to MR", zap.Int("mr_iid", mrIID), zap.Error(err))
+		return
+	}
+	h.stats.Record(stats.KindRebase, projectID, mrIID)
+}
+
+// postRebaseConflictComment tells the author that the MR conflicts with the target branch
+// commits it is missing, unless an earlier pass already did
+func (h *AutoRebaseHandler) postRebaseConflictComment(ctx context.Context, projectID int, mr gitlab.MRDetails, missing []gitlab.CompareCommit) {
+	if found, err := h.gitlabClient.FindCommentByPattern(ctx, projectID, mr.IID, rebaseConflictCommentMarker); err != nil || found {
+		return
+	}
+	comment := NewMessageBuilder(h.config).BuildRebaseConflictComment(mr, missing)
+	if err := h.gitlabClient.AddMRComment(ctx, projectID, mr.IID, comment); err != nil {
+		logging.FromContext(ctx).Warn("Failed to add rebase conflict comment to MR", zap.Int("mr_iid", mr.IID), zap.Error(err))
+		return
+	}
+	h.stats.Record(stats.KindRebase, projectID, mr.IID)
 }
 
 // isForkRebasePermissionError returns true when the error indicates GitLab rejected rebase due to lack of push access to the source branch (e.g. fork MRs).
@@ -395,23 +558,46 @@ type MRFilterResult struct {
 	Skipped  []MRSkipInfo
 }
 
-// filterEligibleMRs filters MRs based on pipeline status, jobs, and optionally atlantis comments
+// filterEligibleMRs filters MRs based on pipeline status, jobs, optionally atlantis comments,
+// and the configured eligibility hooks
 // Returns both eligible MRs and detailed skip information
 // Note: MRs are already filtered by creation date at the API level (last 7 days)
-func (h *AutoRebaseHandler) filterEligibleMRs(projectID int, mrs []gitlab.MRDetails) MRFilterResult {
+func (h *AutoRebaseHandler) filterEligibleMRs(ctx context.Context, projectID int, mrs []gitlab.MRDetails) MRFilterResult {
 	result := MRFilterResult{
 		Eligible: make([]gitlab.MRDetails, 0),
 		Skipped:  make([]MRSkipInfo, 0),
 	}
 
 	for _, mr := range mrs {
+		// Authors exclude their MR with the opt-out label or description marker
+		if h.optedOut(mr) {
+			logging.FromContext(ctx).Info("Skipping MR opted out of auto-rebase", zap.Int("mr_iid", mr.IID))
+			result.Skipped = append(result.Skipped, MRSkipInfo{
+				MRIID:  mr.IID,
+				Reason: "opted_out",
+			})
+			continue
+		}
+
+		// Skip drafts when configured
+		if h.config.AutoRebase.SkipDrafts && mr.IsDraft() {
+			logging.FromContext(ctx).Info("Skipping draft MR", zap.Int("mr_iid", mr.IID))
+			result.Skipped = append(result.Skipped, MRSkipInfo{
+				MRIID:  mr.IID,
+				Reason: "draft",
+			})
+			continue
+		}
+
 		// Check pipeline status
 		if mr.Pipeline != nil {
 			status := strings.ToLower(mr.Pipeline.Status)
 
 			// Skip MRs with running or pending pipelines
 			if status == "running" || status == "pending" {
-				logging.Info("Skipping MR with %s pipeline", status, zap.Int("mr_iid", mr.IID))
+				logging.FromContext(ctx).Info("Skipping MR because of its pipeline status",
+					zap.String("pipeline_status", status),
+					zap.Int("mr_iid", mr.IID))
 				result.Skipped = append(result.Skipped, MRSkipInfo{
 					MRIID:      mr.IID,
 					Reason:     fmt.Sprintf("pipeline_%s", status),
@@ -427,9 +613,9 @@ func (h *AutoRebaseHandler) filterEligibleMRs(projectID int, mrs []gitlab.MRDeta
 			// For failed pipelines, check all jobs first
 			// If all jobs succeeded, optionally check atlantis comment for plan failures
 			if status == "failed" {
-				allJobsSucceeded, err := h.gitlabClient.AreAllPipelineJobsSucceeded(projectID, mr.Pipeline.ID)
+				allJobsSucceeded, err := h.gitlabClient.AreAllPipelineJobsSucceeded(ctx, projectID, mr.Pipeline.ID)
 				if err != nil {
-					logging.Warn("Failed to check pipeline jobs for MR, skipping", zap.Int("mr_iid", mr.IID), zap.Error(err))
+					logging.FromContext(ctx).Warn("Failed to check pipeline jobs for MR, skipping", zap.Int("mr_iid", mr.IID), zap.Error(err))
 					result.Skipped = append(result.Skipped, MRSkipInfo{
 						MRIID:      mr.IID,
 						Reason:     "failed_to_check_jobs",
@@ -439,7 +625,7 @@ func (h *AutoRebaseHandler) filterEligibleMRs(projectID int, mrs []gitlab.MRDeta
 				}
 
 				if !allJobsSucceeded {
-					logging.Info("Skipping MR with failed jobs", zap.Int("mr_iid", mr.IID))
+					logging.FromContext(ctx).Info("Skipping MR with failed jobs", zap.Int("mr_iid", mr.IID))
 					result.Skipped = append(result.Skipped, MRSkipInfo{
 						MRIID:      mr.IID,
 						Reason:     "pipeline_jobs_failed",
@@ -450,20 +636,20 @@ func (h *AutoRebaseHandler) filterEligibleMRs(projectID int, mrs []gitlab.MRDeta
 
 				// All jobs succeeded but pipeline is marked as failed
 				// Check if we should check atlantis comments (configurable)
-				logging.Info("Checking atlantis comment configuration",
+				logging.FromContext(ctx).Info("Checking atlantis comment configuration",
 					zap.Int("mr_iid", mr.IID),
 					zap.Bool("check_atlantis_enabled", h.config.AutoRebase.CheckAtlantisComments))
 
 				if h.config.AutoRebase.CheckAtlantisComments {
-					logging.Info("Atlantis comment check enabled, checking for atlantis comments", zap.Int("mr_iid", mr.IID))
+					logging.FromContext(ctx).Info("Atlantis comment check enabled, checking for atlantis comments", zap.Int("mr_iid", mr.IID))
 					// Check for atlantis comments
-					atlantisComment, err := h.gitlabClient.FindLatestAtlantisComment(projectID, mr.IID)
+					atlantisComment, err := h.gitlabClient.FindLatestAtlantisComment(ctx, projectID, mr.IID)
 					if err != nil {
-						logging.Warn("Failed to find atlantis comment", zap.Int("mr_iid", mr.IID), zap.Error(err))
+						logging.FromContext(ctx).Warn("Failed to find atlantis comment", zap.Int("mr_iid", mr.IID), zap.Error(err))
 					}
 					if err != nil || atlantisComment == nil {
 						// No atlantis comment found - skip rebase (safe default)
-						logging.Info("Skipping MR with failed pipeline (no atlantis comment found)", zap.Int("mr_iid", mr.IID))
+						logging.FromContext(ctx).Info("Skipping MR with failed pipeline (no atlantis comment found)", zap.Int("mr_iid", mr.IID))
 						result.Skipped = append(result.Skipped, MRSkipInfo{
 							MRIID:      mr.IID,
 							Reason:     "pipeline_failed_atlantis_comment_not_found",
@@ -472,16 +658,16 @@ func (h *AutoRebaseHandler) filterEligibleMRs(projectID int, mrs []gitlab.MRDeta
 						continue
 					}
 
-					logging.Info("Found atlantis comment, checking for plan failures", zap.Int("mr_iid", mr.IID))
+					logging.FromContext(ctx).Info("Found atlantis comment, checking for plan failures", zap.Int("mr_iid", mr.IID))
 					// Check atlantis comment to determine if it's a state lock (allow rebase) or plan error (skip rebase)
-					shouldSkip, skipReason := h.gitlabClient.CheckAtlantisCommentForPlanFailures(projectID, mr.IID)
-					logging.Info("Atlantis comment check result",
+					shouldSkip, skipReason := h.gitlabClient.CheckAtlantisCommentForPlanFailures(ctx, projectID, mr.IID)
+					logging.FromContext(ctx).Info("Atlantis comment check result",
 						zap.Int("mr_iid", mr.IID),
 						zap.Bool("should_skip", shouldSkip),
 						zap.String("skip_reason", skipReason))
 
 					if shouldSkip && skipReason != "atlantis_plan_locked" {
-						logging.Info("Skipping MR with failed pipeline due to plan error", zap.Int("mr_iid", mr.IID), zap.String("reason", skipReason))
+						logging.FromContext(ctx).Info("Skipping MR with failed pipeline due to plan error", zap.Int("mr_iid", mr.IID), zap.String("reason", skipReason))
 						result.Skipped = append(result.Skipped, MRSkipInfo{
 							MRIID:      mr.IID,
 							Reason:     fmt.Sprintf("pipeline_failed_%s", skipReason),
@@ -490,10 +676,10 @@ func (h *AutoRebaseHandler) filterEligibleMRs(projectID int, mrs []gitlab.MRDeta
 						continue
 					}
 					// If skipReason is "atlantis_plan_locked", we allow rebase (continue to eligible)
-					logging.Info("Allowing rebase (state lock detected or no plan failure)", zap.Int("mr_iid", mr.IID), zap.String("skip_reason", skipReason))
+					logging.FromContext(ctx).Info("Allowing rebase (state lock detected or no plan failure)", zap.Int("mr_iid", mr.IID), zap.String("skip_reason", skipReason))
 				} else {
 					// CheckAtlantisComments = false: Simple behavior - skip failed pipelines
-					logging.Info("Skipping MR with failed pipeline (atlantis check disabled)", zap.Int("mr_iid", mr.IID))
+					logging.FromContext(ctx).Info("Skipping MR with failed pipeline (atlantis check disabled)", zap.Int("mr_iid", mr.IID))
 					result.Skipped = append(result.Skipped, MRSkipInfo{
 						MRIID:      mr.IID,
 						Reason:     "pipeline_failed",
@@ -504,6 +690,20 @@ func (h *AutoRebaseHandler) filterEligibleMRs(projectID int, mrs []gitlab.MRDeta
 			}
 		}
 
+		// Deployment-specific checks (skip labels, freeze APIs, plugins)
+		if reason, err := eligibility.Check(h.hooks, eligibility.NewRequest(projectID, mr)); reason != "" {
+			if err != nil {
+				logging.FromContext(ctx).Warn("Eligibility hook failed for MR, skipping", zap.Int("mr_iid", mr.IID), zap.Error(err))
+			} else {
+				logging.FromContext(ctx).Info("Skipping MR rejected by eligibility hook", zap.Int("mr_iid", mr.IID), zap.String("reason", reason))
+			}
+			result.Skipped = append(result.Skipped, MRSkipInfo{
+				MRIID:  mr.IID,
+				Reason: reason,
+			})
+			continue
+		}
+
 		// MR is eligible
 		result.Eligible = append(result.Eligible, mr)
 	}
@@ -511,6 +711,19 @@ func (h *AutoRebaseHandler) filterEligibleMRs(projectID int, mrs []gitlab.MRDeta
 	return result
 }
 
+// optedOut reports whether the MR carries the opt-out label or its "[<label>]" marker in the
+// description. An empty opt-out label disables opting out.
+func (h *AutoRebaseHandler) optedOut(mr gitlab.MRDetails) bool {
+	label := h.config.AutoRebase.OptOutLabel
+	if label == "" {
+		return false
+	}
+	if mr.HasLabel(label) {
+		return true
+	}
+	return strings.Contains(strings.ToLower(mr.Description), "["+strings.ToLower(label)+"]")
+}
+
 // validateWebhookPayload performs validation on webhook payload
 func (h *AutoRebaseHandler) validateWebhookPayload(payload map[string]interface{}) error {
 	// Check for required top-level fields
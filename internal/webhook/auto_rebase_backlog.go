@@ -0,0 +1,274 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/notify"
+	"github.com/redhat-data-and-ai/naysayer/internal/projectfilter"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+	"go.uber.org/zap"
+)
+
+// processedCommitPrefix is the state store namespace for the last processed target-branch commit
+const processedCommitPrefix = "autorebase/last_processed/"
+
+// RebaseTarget identifies a project branch watched by the backlog processor
+type RebaseTarget struct {
+	ProjectID int
+	Branch    string
+}
+
+// ParseRebaseTargets parses "<project_id>[:<branch>]" entries; branch defaults to main
+func ParseRebaseTargets(entries []string) ([]RebaseTarget, error) {
+	targets := make([]RebaseTarget, 0, len(entries))
+	for _, entry := range entries {
+		idPart, branch, found := strings.Cut(strings.TrimSpace(entry), ":")
+		if !found || branch == "" {
+			branch = "main"
+		}
+		projectID, err := strconv.Atoi(idPart)
+		if err != nil || projectID <= 0 {
+			return nil, fmt.Errorf("invalid rebase target %q: expected <project_id>[:<branch>]", entry)
+		}
+		targets = append(targets, RebaseTarget{ProjectID: projectID, Branch: branch})
+	}
+	return targets, nil
+}
+
+// processedCommitKey returns the state store key for a project branch
+func processedCommitKey(projectID int, branch string) string {
+	return fmt.Sprintf("%s%d/%s", processedCommitPrefix, projectID, branch)
+}
+
+// recordProcessedCommit stores the target-branch head handled by a rebase pass
+func (h *AutoRebaseHandler) recordProcessedCommit(projectID int, branch, sha string) {
+	if h.stateStore == nil || sha == "" {
+		return
+	}
+	if err := h.stateStore.Put(processedCommitKey(projectID, branch), []byte(sha)); err != nil {
+		logging.Warn("Failed to record processed commit",
+			zap.Int("project_id", projectID),
+			zap.String("branch", branch),
+			zap.Error(err))
+	}
+}
+
+// BacklogProcessor catches up on push events missed while naysayer was down by comparing
+// the last processed target-branch commit against the current branch head
+type BacklogProcessor struct {
+	handler *AutoRebaseHandler
+	store   store.Store
+	targets []RebaseTarget
+	sink    notify.Sink // Optional: notified when archived projects are dropped
+
+	mu   sync.Mutex
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewBacklogProcessor creates a backlog processor; the handler records processed commits in st
+func NewBacklogProcessor(handler *AutoRebaseHandler, st store.Store, targets []RebaseTarget) *BacklogProcessor {
+	handler.SetStateStore(st)
+	return &BacklogProcessor{
+		handler: handler,
+		store:   st,
+		targets: targets,
+	}
+}
+
+// SetNotificationSink enables notifications when archived projects are dropped
+func (p *BacklogProcessor) SetNotificationSink(sink notify.Sink) {
+	p.sink = sink
+}
+
+// Check runs the rebase pass for one branch if its head moved since the last processed commit.
+// Returns true when a catch-up pass was run. Archived projects are dropped from the targets,
+// and projects the project filter excludes from auto-rebase are not checked.
+func (p *BacklogProcessor) Check(ctx context.Context, target RebaseTarget) (bool, error) {
+	if allowed, _ := projectfilter.Default().Check(ctx, p.handler.config.GitLab.Instance, config.EndpointAutoRebase, target.ProjectID, ""); !allowed {
+		return false, nil
+	}
+	if isProjectArchived(ctx, p.handler.gitlabClient, target.ProjectID) {
+		p.dropArchivedProject(target.ProjectID)
+		return false, nil
+	}
+
+	head, err := p.handler.gitlabClient.GetBranchCommit(ctx, target.ProjectID, target.Branch)
+	if err != nil {
+		return false, fmt.Errorf("failed to get head of %s for project %d: %w", target.Branch, target.ProjectID, err)
+	}
+
+	last, found, err := p.store.Get(processedCommitKey(target.ProjectID, target.Branch))
+	if err != nil {
+		return false, err
+	}
+	if found && string(last) == head {
+		return false, nil
+	}
+
+	logging.FromContext(ctx).Info("Branch moved without a processed push event, running catch-up rebase pass",
+		zap.String("branch", target.Branch),
+		zap.Int("project_id", target.ProjectID),
+		zap.String("last_processed", string(last)),
+		zap.String("head", head))
+
+	pass, err := p.handler.RunRebasePass(ctx, target.ProjectID, target.Branch)
+	if err != nil {
+		return true, err
+	}
+	p.handler.recordProcessedCommit(target.ProjectID, target.Branch, head)
+
+	logging.FromContext(ctx).Info("Catch-up rebase pass completed",
+		zap.Int("project_id", target.ProjectID),
+		zap.String("branch", target.Branch),
+		zap.Int("eligible", pass.EligibleMRs),
+		zap.Int("successful", pass.Successful),
+		zap.Int("failed", pass.Failed))
+	return true, nil
+}
+
+// CheckAll checks configured targets plus every branch previously seen in a push event
+func (p *BacklogProcessor) CheckAll(ctx context.Context) {
+	for _, target := range p.allTargets() {
+		if _, err := p.Check(ctx, target); err != nil {
+			logging.FromContext(ctx).Warn("Catch-up check failed",
+				zap.Int("project_id", target.ProjectID),
+				zap.String("branch", target.Branch),
+				zap.Error(err))
+		}
+	}
+}
+
+// dropArchivedProject removes every branch of an archived project from the configured targets
+// and the state store. A push event received after the project is unarchived records the branch again.
+func (p *BacklogProcessor) dropArchivedProject(projectID int) {
+	p.mu.Lock()
+	kept := make([]RebaseTarget, 0, len(p.targets))
+	for _, t := range p.targets {
+		if t.ProjectID != projectID {
+			kept = append(kept, t)
+		}
+	}
+	p.targets = kept
+	p.mu.Unlock()
+
+	prefix := fmt.Sprintf("%s%d/", processedCommitPrefix, projectID)
+	keys, err := p.store.Keys(prefix)
+	if err != nil {
+		logging.Warn("Failed to list recorded branches of archived project", zap.Int("project_id", projectID), zap.Error(err))
+	}
+	branches := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if err := p.store.Delete(key); err != nil {
+			logging.Warn("Failed to drop catch-up state of archived project",
+				zap.String("key", key),
+				zap.Int("project_id", projectID),
+				zap.Error(err))
+			continue
+		}
+		branches = append(branches, strings.TrimPrefix(key, prefix))
+	}
+
+	logging.Info("Project is archived, dropped it from auto-rebase catch-up", zap.Int("project_id", projectID))
+	if p.sink == nil {
+		return
+	}
+	err = p.sink.Notify(notify.Notification{
+		Event:     EventProjectArchived,
+		Severity:  notify.SeverityInfo,
+		Title:     "Archived project dropped from auto-rebase",
+		Message:   fmt.Sprintf("Project %d is archived and was removed from auto-rebase catch-up. Remove it from AUTO_REBASE_CATCHUP_PROJECTS and its webhooks, or unarchive it and push to re-enable.", projectID),
+		ProjectID: projectID,
+		Fields:    map[string]string{"branches": strings.Join(branches, ",")},
+		Timestamp: time.Now().UTC(),
+	})
+	if err != nil {
+		logging.Warn("Failed to send archived project notification", zap.Int("project_id", projectID), zap.Error(err))
+	}
+}
+
+// allTargets merges configured targets with branches recorded in the state store
+func (p *BacklogProcessor) allTargets() []RebaseTarget {
+	seen := make(map[string]bool)
+	targets := make([]RebaseTarget, 0, len(p.targets))
+	add := func(t RebaseTarget) {
+		key := processedCommitKey(t.ProjectID, t.Branch)
+		if !seen[key] {
+			seen[key] = true
+			targets = append(targets, t)
+		}
+	}
+
+	p.mu.Lock()
+	for _, t := range p.targets {
+		add(t)
+	}
+	p.mu.Unlock()
+
+	keys, err := p.store.Keys(processedCommitPrefix)
+	if err != nil {
+		return targets
+	}
+	for _, key := range keys {
+		idPart, branch, ok := strings.Cut(strings.TrimPrefix(key, processedCommitPrefix), "/")
+		if !ok {
+			continue
+		}
+		if projectID, err := strconv.Atoi(idPart); err == nil {
+			add(RebaseTarget{ProjectID: projectID, Branch: branch})
+		}
+	}
+	return targets
+}
+
+// Start runs an immediate check and then re-checks on every interval
+func (p *BacklogProcessor) Start(interval time.Duration) {
+	p.mu.Lock()
+	if p.stop != nil {
+		p.mu.Unlock()
+		return
+	}
+	p.stop = make(chan struct{})
+	stop := p.stop
+	p.mu.Unlock()
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		ctx := context.Background()
+		p.CheckAll(ctx)
+		if interval <= 0 {
+			return
+		}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				p.CheckAll(ctx)
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop halts the periodic catch-up loop
+func (p *BacklogProcessor) Stop() {
+	p.mu.Lock()
+	stop := p.stop
+	p.stop = nil
+	p.mu.Unlock()
+
+	if stop != nil {
+		close(stop)
+		p.wg.Wait()
+	}
+}
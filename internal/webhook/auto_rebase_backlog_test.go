@@ -0,0 +1,102 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+)
+
+func TestParseRebaseTargets(t *testing.T) {
+	targets, err := ParseRebaseTargets([]string{"123", "456:master", " 789:release/1.0 "})
+	assert.NoError(t, err)
+	assert.Equal(t, []RebaseTarget{
+		{ProjectID: 123, Branch: "main"},
+		{ProjectID: 456, Branch: "master"},
+		{ProjectID: 789, Branch: "release/1.0"},
+	}, targets)
+
+	_, err = ParseRebaseTargets([]string{"abc:main"})
+	assert.Error(t, err)
+	_, err = ParseRebaseTargets([]string{"-1"})
+	assert.Error(t, err)
+}
+
+func TestBacklogProcessor_Check_RunsPassWhenHeadMoved(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &MockRebaseGitLabClient{openMRs: []int{1, 2}}
+	handler := NewAutoRebaseHandlerWithClient(createTestConfig(), mockClient)
+	st := store.NewMemoryStore()
+	processor := NewBacklogProcessor(handler, st, nil)
+
+	target := RebaseTarget{ProjectID: 42, Branch: "main"}
+
+	// Nothing recorded yet: run the pass and remember the head
+	ran, err := processor.Check(ctx, target)
+	assert.NoError(t, err)
+	assert.True(t, ran)
+	assert.Len(t, mockClient.capturedRebaseMRs, 2)
+
+	recorded, found, _ := st.Get(processedCommitKey(42, "main"))
+	assert.True(t, found)
+	assert.Equal(t, "mock-main-sha", string(recorded))
+
+	// Head unchanged: no catch-up needed
+	ran, err = processor.Check(ctx, target)
+	assert.NoError(t, err)
+	assert.False(t, ran)
+	assert.Len(t, mockClient.capturedRebaseMRs, 2)
+
+	// A push was missed: recorded commit differs from head
+	_ = st.Put(processedCommitKey(42, "main"), []byte("older-sha"))
+	ran, err = processor.Check(ctx, target)
+	assert.NoError(t, err)
+	assert.True(t, ran)
+	assert.Len(t, mockClient.capturedRebaseMRs, 4)
+}
+
+func TestBacklogProcessor_AllTargetsIncludesRecordedBranches(t *testing.T) {
+	handler := NewAutoRebaseHandlerWithClient(createTestConfig(), &MockRebaseGitLabClient{})
+	st := store.NewMemoryStore()
+	_ = st.Put(processedCommitKey(7, "master"), []byte("sha"))
+	_ = st.Put(processedCommitKey(1, "main"), []byte("sha"))
+
+	processor := NewBacklogProcessor(handler, st, []RebaseTarget{{ProjectID: 1, Branch: "main"}})
+	assert.Equal(t, []RebaseTarget{
+		{ProjectID: 1, Branch: "main"},
+		{ProjectID: 7, Branch: "master"},
+	}, processor.allTargets())
+}
+
+func TestAutoRebaseHandler_RecordsProcessedCommitOnPush(t *testing.T) {
+	mockClient := &MockRebaseGitLabClient{}
+	handler := NewAutoRebaseHandlerWithClient(createTestConfig(), mockClient)
+	st := store.NewMemoryStore()
+	handler.SetStateStore(st)
+
+	app := createTestApp()
+	app.Post("/rebase", handler.HandleWebhook)
+
+	payload := map[string]interface{}{
+		"object_kind": "push",
+		"ref":         "refs/heads/main",
+		"after":       "new-head-sha",
+		"project":     map[string]interface{}{"id": 456},
+	}
+	payloadBytes, _ := json.Marshal(payload)
+	req := httptest.NewRequest("POST", "/rebase", bytes.NewReader(payloadBytes))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	recorded, found, _ := st.Get(processedCommitKey(456, "main"))
+	assert.True(t, found)
+	assert.Equal(t, "new-head-sha", string(recorded))
+}
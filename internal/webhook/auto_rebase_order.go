@@ -0,0 +1,122 @@
+package webhook
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+)
+
+// lastRebasedPrefix is the state store namespace for the last automated rebase of an MR
+const lastRebasedPrefix = "autorebase/last_rebased/"
+
+// lastRebasedKey returns the state store key for an MR
+func lastRebasedKey(projectID, mrIID int) string {
+	return fmt.Sprintf("%s%d/%d", lastRebasedPrefix, projectID, mrIID)
+}
+
+// rebaseTimes returns the store holding the last rebase of each MR: the state store when
+// configured, otherwise the handler's in-memory store
+func (h *AutoRebaseHandler) rebaseTimes() store.Store {
+	if h.stateStore != nil {
+		return h.stateStore
+	}
+	return h.localRebaseTimes
+}
+
+// recordRebase remembers when an MR was rebased, for the cool-down
+func (h *AutoRebaseHandler) recordRebase(projectID, mrIID int, at time.Time) {
+	if h.config.AutoRebase.CooldownMinutes <= 0 || h.rebaseTimes() == nil {
+		return
+	}
+	if err := store.PutJSON(h.rebaseTimes(), lastRebasedKey(projectID, mrIID), at); err != nil {
+		logging.Warn("Failed to record rebase time", zap.Int("mr_iid", mrIID), zap.Error(err))
+	}
+}
+
+// applyRebaseCooldown skips MRs rebased less than the cool-down ago
+func (h *AutoRebaseHandler) applyRebaseCooldown(projectID int, mrs []gitlab.MRDetails, now time.Time) ([]gitlab.MRDetails, []MRSkipInfo) {
+	cooldown := time.Duration(h.config.AutoRebase.CooldownMinutes) * time.Minute
+	if cooldown <= 0 || h.rebaseTimes() == nil {
+		return mrs, nil
+	}
+
+	eligible := make([]gitlab.MRDetails, 0, len(mrs))
+	var skipped []MRSkipInfo
+	for _, mr := range mrs {
+		var last time.Time
+		found, err := store.GetJSON(h.rebaseTimes(), lastRebasedKey(projectID, mr.IID), &last)
+		if err == nil && found && now.Sub(last) < cooldown {
+			logging.Info("Skipping MR rebased within the cool-down", zap.Int("mr_iid", mr.IID), zap.Time("last_rebased", last))
+			skipped = append(skipped, MRSkipInfo{MRIID: mr.IID, Reason: "cooldown"})
+			continue
+		}
+		eligible = append(eligible, mr)
+	}
+	return eligible, skipped
+}
+
+// orderRebaseCandidates sorts MRs in the configured rebase order, oldest first on ties, so the
+// MRs rebased first are those that matter most when MaxRebasesPerPush limits a pass
+func orderRebaseCandidates(cfg config.AutoRebaseConfig, mrs []gitlab.MRDetails) {
+	var rank func(mr gitlab.MRDetails) int
+	switch cfg.RebaseOrder() {
+	case config.RebaseOrderClosestToMerge:
+		rank = closeToMergeRank
+	case config.RebaseOrderLabelPriority:
+		rank = func(mr gitlab.MRDetails) int { return labelPriorityRank(cfg.PriorityLabels, mr) }
+	default:
+		rank = func(gitlab.MRDetails) int { return 0 }
+	}
+
+	sort.SliceStable(mrs, func(i, j int) bool {
+		if ri, rj := rank(mrs[i]), rank(mrs[j]); ri != rj {
+			return ri < rj
+		}
+		return createdBefore(mrs[i], mrs[j])
+	})
+}
+
+// closeToMergeRank ranks MRs set to merge when their pipeline succeeds first, then MRs with a
+// green pipeline, then MRs GitLab reports as mergeable, then by the commits they are behind
+func closeToMergeRank(mr gitlab.MRDetails) int {
+	rank := 0
+	if !mr.MergeWhenPipelineSucceeds {
+		rank += 4
+	}
+	if mr.Pipeline == nil || mr.Pipeline.Status != "success" {
+		rank += 2
+	}
+	if mr.MergeStatus != "can_be_merged" {
+		rank++
+	}
+	return rank*1000 + min(mr.BehindCommitsCount, 999)
+}
+
+// labelPriorityRank is the position of the first priority label an MR carries, after all
+// priority labels when it carries none
+func labelPriorityRank(priorityLabels []string, mr gitlab.MRDetails) int {
+	for i, label := range priorityLabels {
+		if mr.HasLabel(strings.TrimSpace(label)) {
+			return i
+		}
+	}
+	return len(priorityLabels)
+}
+
+// createdBefore compares MR creation times; unparseable times sort last
+func createdBefore(a, b gitlab.MRDetails) bool {
+	ta, errA := time.Parse(time.RFC3339, a.CreatedAt)
+	tb, errB := time.Parse(time.RFC3339, b.CreatedAt)
+	if errA != nil || errB != nil {
+		return errA == nil && errB != nil
+	}
+	return ta.Before(tb)
+}
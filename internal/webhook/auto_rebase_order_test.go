@@ -0,0 +1,120 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+)
+
+func rebaseCandidate(iid int, created time.Time) gitlab.MRDetails {
+	return gitlab.MRDetails{
+		IID:       iid,
+		CreatedAt: created.Format(time.RFC3339),
+		Pipeline:  &gitlab.MRPipeline{Status: "success"},
+	}
+}
+
+func iids(mrs []gitlab.MRDetails) []int {
+	result := make([]int, 0, len(mrs))
+	for _, mr := range mrs {
+		result = append(result, mr.IID)
+	}
+	return result
+}
+
+func TestOrderRebaseCandidates(t *testing.T) {
+	now := time.Now()
+	newest := rebaseCandidate(1, now.Add(-time.Hour))
+	oldest := rebaseCandidate(2, now.Add(-72*time.Hour))
+	oldest.Pipeline = &gitlab.MRPipeline{Status: "failed"}
+	mergeable := rebaseCandidate(3, now.Add(-24*time.Hour))
+	mergeable.MergeStatus = "can_be_merged"
+	mergeable.Labels = []string{"hotfix"}
+	scheduled := rebaseCandidate(4, now.Add(-2*time.Hour))
+	scheduled.MergeWhenPipelineSucceeds = true
+	scheduled.Labels = []string{"Release"}
+	unknownAge := gitlab.MRDetails{IID: 5}
+
+	tests := []struct {
+		name     string
+		cfg      config.AutoRebaseConfig
+		expected []int
+	}{
+		{"oldest first by default", config.AutoRebaseConfig{}, []int{2, 3, 4, 1, 5}},
+		{"unknown order falls back to oldest first", config.AutoRebaseConfig{Order: "random"}, []int{2, 3, 4, 1, 5}},
+		{"closest to merge", config.AutoRebaseConfig{Order: config.RebaseOrderClosestToMerge}, []int{4, 3, 1, 2, 5}},
+		{"label priority", config.AutoRebaseConfig{Order: config.RebaseOrderLabelPriority, PriorityLabels: []string{"release", "hotfix"}}, []int{4, 3, 2, 1, 5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mrs := []gitlab.MRDetails{newest, oldest, unknownAge, mergeable, scheduled}
+			orderRebaseCandidates(tt.cfg, mrs)
+			assert.Equal(t, tt.expected, iids(mrs))
+		})
+	}
+}
+
+func TestRunRebasePass_BatchLimit(t *testing.T) {
+	now := time.Now()
+	mockClient := &MockRebaseGitLabClient{
+		openMRDetails: []gitlab.MRDetails{
+			rebaseCandidate(10, now.Add(-time.Hour)),
+			rebaseCandidate(11, now.Add(-48*time.Hour)),
+			rebaseCandidate(12, now.Add(-24*time.Hour)),
+		},
+	}
+	cfg := createTestConfig()
+	cfg.AutoRebase.MaxRebasesPerPush = 2
+	handler := NewAutoRebaseHandlerWithClient(cfg, mockClient)
+
+	pass, err := handler.RunRebasePass(context.Background(), 456, "main")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, pass.Successful)
+	assert.Equal(t, []MRSkipInfo{{MRIID: 10, Reason: "batch_limit"}}, pass.Skipped)
+	if assert.Len(t, mockClient.capturedRebaseMRs, 2) {
+		assert.Equal(t, 11, mockClient.capturedRebaseMRs[0].mrIID)
+		assert.Equal(t, 12, mockClient.capturedRebaseMRs[1].mrIID)
+	}
+}
+
+func TestRunRebasePass_Cooldown(t *testing.T) {
+	now := time.Now()
+	mockClient := &MockRebaseGitLabClient{
+		openMRDetails: []gitlab.MRDetails{
+			rebaseCandidate(20, now.Add(-time.Hour)),
+			rebaseCandidate(21, now.Add(-2*time.Hour)),
+		},
+	}
+	cfg := createTestConfig()
+	cfg.AutoRebase.CooldownMinutes = 30
+	handler := NewAutoRebaseHandlerWithClient(cfg, mockClient)
+	stateStore := store.NewMemoryStore()
+	handler.SetStateStore(stateStore)
+
+	// MR 21 was rebased long ago and is rebased again
+	assert.NoError(t, store.PutJSON(stateStore, lastRebasedKey(456, 21), now.Add(-time.Hour)))
+
+	pass, err := handler.RunRebasePass(context.Background(), 456, "main")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, pass.Successful)
+	assert.Empty(t, pass.Skipped)
+
+	// The next push within the cool-down skips both
+	pass, err = handler.RunRebasePass(context.Background(), 456, "main")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, pass.Successful)
+	assert.Equal(t, []MRSkipInfo{{MRIID: 20, Reason: "cooldown"}, {MRIID: 21, Reason: "cooldown"}}, pass.Skipped)
+	assert.Len(t, mockClient.capturedRebaseMRs, 2)
+
+	// Without a state store the cool-down is kept in memory
+	handler = NewAutoRebaseHandlerWithClient(cfg, mockClient)
+	_, _ = handler.RunRebasePass(context.Background(), 456, "main")
+	pass, _ = handler.RunRebasePass(context.Background(), 456, "main")
+	assert.Len(t, pass.Skipped, 2)
+}
@@ -0,0 +1,88 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/stats"
+)
+
+// Final outcomes of the rebases triggered by auto-rebase
+const (
+	RebaseOutcomeRebased  = "rebased"  // GitLab finished the rebase without conflicts
+	RebaseOutcomeConflict = "conflict" // The MR conflicts with the target branch
+	RebaseOutcomeFailed   = "failed"   // GitLab reported an error running the rebase
+	RebaseOutcomeTimeout  = "timeout"  // The rebase did not finish within the verification time
+	RebaseOutcomeError    = "error"    // The rebase could not be triggered or verified
+)
+
+var (
+	rebaseOutcomesMu sync.Mutex
+	rebaseOutcomes   = make(map[string]int64)
+)
+
+// RebaseOutcome classifies the result of RebaseMR
+func RebaseOutcome(err error) string {
+	switch {
+	case err == nil:
+		return RebaseOutcomeRebased
+	case errors.Is(err, gitlab.ErrConflict):
+		return RebaseOutcomeConflict
+	case errors.Is(err, gitlab.ErrRebaseFailed):
+		return RebaseOutcomeFailed
+	case errors.Is(err, gitlab.ErrRebaseTimeout):
+		return RebaseOutcomeTimeout
+	default:
+		return RebaseOutcomeError
+	}
+}
+
+// recordRebaseOutcome counts the final outcome of a rebase
+func recordRebaseOutcome(outcome string) {
+	rebaseOutcomesMu.Lock()
+	defer rebaseOutcomesMu.Unlock()
+	rebaseOutcomes[outcome]++
+}
+
+// RebaseOutcomeCount is the number of rebases that ended with an outcome since startup
+type RebaseOutcomeCount struct {
+	Outcome string
+	Count   int64
+}
+
+// RebaseOutcomes returns the rebases triggered since startup per final outcome, sorted by outcome
+func RebaseOutcomes() []RebaseOutcomeCount {
+	rebaseOutcomesMu.Lock()
+	defer rebaseOutcomesMu.Unlock()
+
+	result := make([]RebaseOutcomeCount, 0, len(rebaseOutcomes))
+	for outcome, count := range rebaseOutcomes {
+		result = append(result, RebaseOutcomeCount{Outcome: outcome, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Outcome < result[j].Outcome })
+	return result
+}
+
+// postRebaseFailureComment tells the author that GitLab failed to run the rebase it accepted,
+// unless an earlier pass already did. Conflicts get the rebase conflict comment instead.
+func (h *AutoRebaseHandler) postRebaseFailureComment(ctx context.Context, projectID int, mr gitlab.MRDetails, rebaseErr error) {
+	var failure *gitlab.RebaseError
+	if !errors.As(rebaseErr, &failure) || errors.Is(rebaseErr, gitlab.ErrConflict) {
+		return
+	}
+	if found, err := h.gitlabClient.FindCommentByPattern(ctx, projectID, mr.IID, rebaseFailureCommentMarker); err != nil || found {
+		return
+	}
+	comment := NewMessageBuilder(h.config).BuildRebaseFailureComment(mr, failure.MergeError)
+	if err := h.gitlabClient.AddMRComment(ctx, projectID, mr.IID, comment); err != nil {
+		logging.FromContext(ctx).Warn("Failed to add rebase failure comment to MR", zap.Int("mr_iid", mr.IID), zap.Error(err))
+		return
+	}
+	h.stats.Record(stats.KindRebase, projectID, mr.IID)
+}
@@ -0,0 +1,77 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+)
+
+func TestRebaseOutcome(t *testing.T) {
+	assert.Equal(t, RebaseOutcomeRebased, RebaseOutcome(nil))
+	assert.Equal(t, RebaseOutcomeConflict, RebaseOutcome(fmt.Errorf("rebase: %w", gitlab.ErrConflict)))
+	assert.Equal(t, RebaseOutcomeConflict, RebaseOutcome(&gitlab.RebaseError{MergeError: "Rebase failed due to conflicts"}))
+	assert.Equal(t, RebaseOutcomeFailed, RebaseOutcome(&gitlab.RebaseError{MergeError: "Failed to push"}))
+	assert.Equal(t, RebaseOutcomeTimeout, RebaseOutcome(fmt.Errorf("verification: %w", gitlab.ErrRebaseTimeout)))
+	assert.Equal(t, RebaseOutcomeError, RebaseOutcome(errors.New("connection refused")))
+}
+
+func rebaseOutcomeCount(outcome string) int64 {
+	for _, count := range RebaseOutcomes() {
+		if count.Outcome == outcome {
+			return count.Count
+		}
+	}
+	return 0
+}
+
+func TestRunRebasePass_BackgroundFailurePostsComment(t *testing.T) {
+	mr := rebaseCandidate(88, time.Now().Add(-time.Hour))
+	mr.TargetBranch = "main"
+	mr.Author = &gitlab.MRUser{Username: "jdoe"}
+	mockClient := &MockRebaseGitLabClient{
+		openMRDetails: []gitlab.MRDetails{mr},
+		rebaseError:   &gitlab.RebaseError{MergeError: "Failed to push to source branch"},
+	}
+	handler := NewAutoRebaseHandlerWithClient(createTestConfig(), mockClient)
+	failedBefore := rebaseOutcomeCount(RebaseOutcomeFailed)
+
+	pass, err := handler.RunRebasePass(context.Background(), 456, "main")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, pass.Failed)
+	if assert.Len(t, pass.Failures, 1) {
+		assert.Equal(t, RebaseOutcomeFailed, pass.Failures[0]["outcome"])
+	}
+	if assert.Len(t, mockClient.capturedComments, 1) {
+		comment := mockClient.capturedComments[0]
+		assert.True(t, strings.HasPrefix(comment, rebaseFailureCommentMarker))
+		assert.Contains(t, comment, "@jdoe")
+		assert.Contains(t, comment, "> Failed to push to source branch")
+	}
+	assert.Equal(t, failedBefore+1, rebaseOutcomeCount(RebaseOutcomeFailed))
+
+	// The comment is posted once
+	_, _ = handler.RunRebasePass(context.Background(), 456, "main")
+	assert.Len(t, mockClient.capturedComments, 1)
+}
+
+func TestRunRebasePass_TimeoutDoesNotComment(t *testing.T) {
+	mockClient := &MockRebaseGitLabClient{
+		openMRDetails: []gitlab.MRDetails{rebaseCandidate(89, time.Now().Add(-time.Hour))},
+		rebaseError:   fmt.Errorf("rebase verification timeout: %w", gitlab.ErrRebaseTimeout),
+	}
+	handler := NewAutoRebaseHandlerWithClient(createTestConfig(), mockClient)
+
+	pass, err := handler.RunRebasePass(context.Background(), 456, "main")
+	assert.NoError(t, err)
+	if assert.Len(t, pass.Failures, 1) {
+		assert.Equal(t, RebaseOutcomeTimeout, pass.Failures[0]["outcome"])
+	}
+	assert.Empty(t, mockClient.capturedComments)
+}
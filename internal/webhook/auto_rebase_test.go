@@ -2,6 +2,7 @@ package webhook
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -15,6 +16,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/eligibility"
 	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
 )
 
@@ -34,7 +36,7 @@ type MockRebaseGitLabClient struct {
 	sourceProjectID int // Set to non-zero to simulate fork MR
 }
 
-func (m *MockRebaseGitLabClient) CompareBranches(sourceProjectID int, sourceBranch string, targetProjectID int, targetBranch string) (*gitlab.CompareResult, error) {
+func (m *MockRebaseGitLabClient) CompareBranches(ctx context.Context, sourceProjectID int, sourceBranch string, targetProjectID int, targetBranch string) (*gitlab.CompareResult, error) {
 	return &gitlab.CompareResult{
 		Commits: []gitlab.CompareCommit{
 			{ID: "abc123", ShortID: "abc123", Title: "Mock commit"},
@@ -42,11 +44,11 @@ func (m *MockRebaseGitLabClient) CompareBranches(sourceProjectID int, sourceBran
 	}, nil
 }
 
-func (m *MockRebaseGitLabClient) GetBranchCommit(projectID int, branch string) (string, error) {
+func (m *MockRebaseGitLabClient) GetBranchCommit(ctx context.Context, projectID int, branch string) (string, error) {
 	return "mock-main-sha", nil
 }
 
-func (m *MockRebaseGitLabClient) CompareCommits(projectID int, fromSHA, toSHA string) (*gitlab.CompareResult, error) {
+func (m *MockRebaseGitLabClient) CompareCommits(ctx context.Context, projectID int, fromSHA, toSHA string) (*gitlab.CompareResult, error) {
 	return &gitlab.CompareResult{
 		Commits: []gitlab.CompareCommit{
 			{ID: "abc123", ShortID: "abc123", Title: "Mock commit"},
@@ -54,7 +56,7 @@ func (m *MockRebaseGitLabClient) CompareCommits(projectID int, fromSHA, toSHA st
 	}, nil
 }
 
-func (m *MockRebaseGitLabClient) RebaseMR(projectID, mrIID int) (bool, error) {
+func (m *MockRebaseGitLabClient) RebaseMR(ctx context.Context, projectID, mrIID int) (bool, error) {
 	m.capturedRebaseMRs = append(m.capturedRebaseMRs, struct {
 		projectID int
 		mrIID     int
@@ -65,21 +67,21 @@ func (m *MockRebaseGitLabClient) RebaseMR(projectID, mrIID int) (bool, error) {
 	return true, nil
 }
 
-func (m *MockRebaseGitLabClient) AddMRComment(projectID, mrIID int, comment string) error {
+func (m *MockRebaseGitLabClient) AddMRComment(ctx context.Context, projectID, mrIID int, comment string) error {
 	m.capturedComments = append(m.capturedComments, comment)
 	return m.addCommentError
 }
 
 // Stub implementations for required interface methods
-func (m *MockRebaseGitLabClient) FetchFileContent(projectID int, filePath, ref string) (*gitlab.FileContent, error) {
+func (m *MockRebaseGitLabClient) FetchFileContent(ctx context.Context, projectID int, filePath, ref string) (*gitlab.FileContent, error) {
 	return nil, nil
 }
 
-func (m *MockRebaseGitLabClient) GetMRTargetBranch(projectID, mrIID int) (string, error) {
+func (m *MockRebaseGitLabClient) GetMRTargetBranch(ctx context.Context, projectID, mrIID int) (string, error) {
 	return "main", nil
 }
 
-func (m *MockRebaseGitLabClient) GetMRDetails(projectID, mrIID int) (*gitlab.MRDetails, error) {
+func (m *MockRebaseGitLabClient) GetMRDetails(ctx context.Context, projectID, mrIID int) (*gitlab.MRDetails, error) {
 	sourceProjectID := m.sourceProjectID
 	if sourceProjectID == 0 {
 		sourceProjectID = projectID
@@ -105,54 +107,54 @@ func (m *MockRebaseGitLabClient) GetMRDetails(projectID, mrIID int) (*gitlab.MRD
 	}, nil
 }
 
-func (m *MockRebaseGitLabClient) FetchMRChanges(projectID, mrIID int) ([]gitlab.FileChange, error) {
+func (m *MockRebaseGitLabClient) FetchMRChanges(ctx context.Context, projectID, mrIID int) ([]gitlab.FileChange, error) {
 	return []gitlab.FileChange{}, nil
 }
 
-func (m *MockRebaseGitLabClient) AddOrUpdateMRComment(projectID, mrIID int, commentBody, commentType string) error {
+func (m *MockRebaseGitLabClient) AddOrUpdateMRComment(ctx context.Context, projectID, mrIID int, commentBody, commentType string) error {
 	return nil
 }
 
-func (m *MockRebaseGitLabClient) ListMRComments(projectID, mrIID int) ([]gitlab.MRComment, error) {
+func (m *MockRebaseGitLabClient) ListMRComments(ctx context.Context, projectID, mrIID int) ([]gitlab.MRComment, error) {
 	return []gitlab.MRComment{}, nil
 }
 
-func (m *MockRebaseGitLabClient) UpdateMRComment(projectID, mrIID, commentID int, newBody string) error {
+func (m *MockRebaseGitLabClient) UpdateMRComment(ctx context.Context, projectID, mrIID, commentID int, newBody string) error {
 	return nil
 }
 
-func (m *MockRebaseGitLabClient) FindLatestNaysayerComment(projectID, mrIID int, commentType ...string) (*gitlab.MRComment, error) {
+func (m *MockRebaseGitLabClient) FindLatestNaysayerComment(ctx context.Context, projectID, mrIID int, commentType ...string) (*gitlab.MRComment, error) {
 	return nil, nil
 }
 
-func (m *MockRebaseGitLabClient) ApproveMR(projectID, mrIID int) error {
+func (m *MockRebaseGitLabClient) ApproveMR(ctx context.Context, projectID, mrIID int) error {
 	return nil
 }
 
-func (m *MockRebaseGitLabClient) ApproveMRWithMessage(projectID, mrIID int, message string) error {
+func (m *MockRebaseGitLabClient) ApproveMRWithMessage(ctx context.Context, projectID, mrIID int, message string) error {
 	return nil
 }
 
-func (m *MockRebaseGitLabClient) ResetNaysayerApproval(projectID, mrIID int) error {
+func (m *MockRebaseGitLabClient) ResetNaysayerApproval(ctx context.Context, projectID, mrIID int) error {
 	return nil
 }
 
-func (m *MockRebaseGitLabClient) GetCurrentBotUsername() (string, error) {
+func (m *MockRebaseGitLabClient) GetCurrentBotUsername(ctx context.Context) (string, error) {
 	return "naysayer-bot", nil
 }
 
-func (m *MockRebaseGitLabClient) IsNaysayerBotAuthor(author map[string]interface{}) bool {
+func (m *MockRebaseGitLabClient) IsNaysayerBotAuthor(ctx context.Context, author map[string]interface{}) bool {
 	return false
 }
 
-func (m *MockRebaseGitLabClient) ListOpenMRs(projectID int) ([]int, error) {
+func (m *MockRebaseGitLabClient) ListOpenMRs(ctx context.Context, projectID int) ([]int, error) {
 	if m.listOpenMRsError != nil {
 		return nil, m.listOpenMRsError
 	}
 	return m.openMRs, nil
 }
 
-func (m *MockRebaseGitLabClient) ListOpenMRsWithDetails(projectID int) ([]gitlab.MRDetails, error) {
+func (m *MockRebaseGitLabClient) ListOpenMRsWithDetails(ctx context.Context, projectID int) ([]gitlab.MRDetails, error) {
 	if m.listOpenMRsError != nil {
 		return nil, m.listOpenMRsError
 	}
@@ -166,7 +168,7 @@ func (m *MockRebaseGitLabClient) ListOpenMRsWithDetails(projectID int) ([]gitlab
 	details := make([]gitlab.MRDetails, 0, len(m.openMRs))
 	for _, mrIID := range m.openMRs {
 		// Call GetMRDetails for each (simulating N+1 calls)
-		mrDetail, err := m.GetMRDetails(projectID, mrIID)
+		mrDetail, err := m.GetMRDetails(ctx, projectID, mrIID)
 		if err != nil {
 			// Skip MRs that fail to fetch
 			continue
@@ -194,19 +196,19 @@ func (m *MockRebaseGitLabClient) ListOpenMRsWithDetails(projectID int) ([]gitlab
 }
 
 // ListAllOpenMRsWithDetails lists all open merge requests (mock implementation)
-func (m *MockRebaseGitLabClient) ListAllOpenMRsWithDetails(projectID int) ([]gitlab.MRDetails, error) {
+func (m *MockRebaseGitLabClient) ListAllOpenMRsWithDetails(ctx context.Context, projectID int) ([]gitlab.MRDetails, error) {
 	// For mock, return same as ListOpenMRsWithDetails
-	return m.ListOpenMRsWithDetails(projectID)
+	return m.ListOpenMRsWithDetails(ctx, projectID)
 }
 
 // CloseMR closes a merge request (mock implementation)
-func (m *MockRebaseGitLabClient) CloseMR(projectID, mrIID int) error {
+func (m *MockRebaseGitLabClient) CloseMR(ctx context.Context, projectID, mrIID int) error {
 	// Mock implementation - just return nil
 	return nil
 }
 
 // FindCommentByPattern checks if a comment with the pattern exists (mock implementation)
-func (m *MockRebaseGitLabClient) FindCommentByPattern(projectID, mrIID int, pattern string) (bool, error) {
+func (m *MockRebaseGitLabClient) FindCommentByPattern(ctx context.Context, projectID, mrIID int, pattern string) (bool, error) {
 	// Mock implementation - check captured comments
 	for _, comment := range m.capturedComments {
 		if strings.Contains(comment, pattern) {
@@ -216,26 +218,26 @@ func (m *MockRebaseGitLabClient) FindCommentByPattern(projectID, mrIID int, patt
 	return false, nil
 }
 
-func (m *MockRebaseGitLabClient) GetPipelineJobs(projectID, pipelineID int) ([]gitlab.PipelineJob, error) {
+func (m *MockRebaseGitLabClient) GetPipelineJobs(ctx context.Context, projectID, pipelineID int) ([]gitlab.PipelineJob, error) {
 	// Return empty jobs by default (all succeeded)
 	return []gitlab.PipelineJob{}, nil
 }
 
-func (m *MockRebaseGitLabClient) GetJobTrace(projectID, jobID int) (string, error) {
+func (m *MockRebaseGitLabClient) GetJobTrace(ctx context.Context, projectID, jobID int) (string, error) {
 	return "", nil
 }
 
-func (m *MockRebaseGitLabClient) FindLatestAtlantisComment(projectID, mrIID int) (*gitlab.MRComment, error) {
+func (m *MockRebaseGitLabClient) FindLatestAtlantisComment(ctx context.Context, projectID, mrIID int) (*gitlab.MRComment, error) {
 	// Return nil by default (no atlantis comment)
 	return nil, nil
 }
 
-func (m *MockRebaseGitLabClient) AreAllPipelineJobsSucceeded(projectID, pipelineID int) (bool, error) {
+func (m *MockRebaseGitLabClient) AreAllPipelineJobsSucceeded(ctx context.Context, projectID, pipelineID int) (bool, error) {
 	// Return true by default (all jobs succeeded)
 	return true, nil
 }
 
-func (m *MockRebaseGitLabClient) CheckAtlantisCommentForPlanFailures(projectID, mrIID int) (bool, string) {
+func (m *MockRebaseGitLabClient) CheckAtlantisCommentForPlanFailures(ctx context.Context, projectID, mrIID int) (bool, string) {
 	// Return true, "atlantis_comment_not_found" by default (no atlantis comment found, skip rebase)
 	// This matches the actual implementation behavior
 	return true, "atlantis_comment_not_found"
@@ -450,12 +452,85 @@ func TestAutoRebase_ForkPermissionErrorPostsComment(t *testing.T) {
 	var response map[string]interface{}
 	_ = json.Unmarshal(body, &response)
 
-	assert.Equal(t, float64(1), response["failed"])
+	// Fork MRs the bot cannot push to are skipped, not failed
+	assert.Equal(t, float64(0), response["failed"])
+	assert.Equal(t, float64(1), response["skipped"])
+	details := response["skip_details"].([]interface{})
+	if assert.Len(t, details, 1) {
+		assert.Equal(t, "fork_no_push_access", details[0].(map[string]interface{})["reason"])
+	}
 	// When rebase fails due to fork permission, we post a comment explaining manual rebase is needed
 	assert.Len(t, mockClient.capturedComments, 1)
 	assert.Contains(t, mockClient.capturedComments[0], "Auto-rebase attempted")
 	assert.Contains(t, mockClient.capturedComments[0], "rebase manually")
 	assert.Contains(t, mockClient.capturedComments[0], "fork")
+
+	// Later pushes do not repeat the comment
+	req = httptest.NewRequest("POST", "/rebase", bytes.NewReader(payloadBytes))
+	req.Header.Set("Content-Type", "application/json")
+	_, err = app.Test(req)
+	assert.NoError(t, err)
+	assert.Len(t, mockClient.capturedComments, 1)
+}
+
+func TestAutoRebase_ConflictPostsComment(t *testing.T) {
+	mockClient := &MockRebaseGitLabClient{
+		openMRDetails: []gitlab.MRDetails{{
+			IID:          77,
+			TargetBranch: "main",
+			CreatedAt:    time.Now().Add(-24 * time.Hour).Format(time.RFC3339),
+			Pipeline:     &gitlab.MRPipeline{Status: "success"},
+			Author:       &gitlab.MRUser{Username: "jdoe"},
+		}},
+		rebaseError: fmt.Errorf("rebase failed: conflicts detected or rebase could not complete: %w", gitlab.ErrConflict),
+	}
+	handler := NewAutoRebaseHandlerWithClient(createTestConfig(), mockClient)
+
+	app := createTestApp()
+	app.Post("/rebase", handler.HandleWebhook)
+	payloadBytes, _ := json.Marshal(map[string]interface{}{
+		"object_kind": "push",
+		"ref":         "refs/heads/main",
+		"project":     map[string]interface{}{"id": 456},
+	})
+	push := func() {
+		req := httptest.NewRequest("POST", "/rebase", bytes.NewReader(payloadBytes))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+	}
+
+	push()
+	if assert.Len(t, mockClient.capturedComments, 1) {
+		comment := mockClient.capturedComments[0]
+		assert.Contains(t, comment, "Auto-rebase blocked by conflicts")
+		assert.Contains(t, comment, "@jdoe")
+		assert.Contains(t, comment, "1 commit(s) behind `main`")
+		assert.Contains(t, comment, "- `abc123` Mock commit")
+	}
+
+	// Later pushes to main do not repeat the comment
+	push()
+	assert.Len(t, mockClient.capturedComments, 1)
+	assert.Len(t, mockClient.capturedRebaseMRs, 2)
+}
+
+func TestBuildRebaseConflictComment(t *testing.T) {
+	mb := NewMessageBuilder(createTestConfig())
+	missing := make([]gitlab.CompareCommit, 0, maxConflictCommits+2)
+	for i := 0; i < maxConflictCommits+2; i++ {
+		missing = append(missing, gitlab.CompareCommit{ID: fmt.Sprintf("%040d", i), Title: fmt.Sprintf("Change %d", i), AuthorName: "Jane"})
+	}
+
+	comment := mb.BuildRebaseConflictComment(gitlab.MRDetails{IID: 5, TargetBranch: "master"}, missing)
+	assert.True(t, strings.HasPrefix(comment, rebaseConflictCommentMarker))
+	assert.NotContains(t, comment, "@")
+	assert.Contains(t, comment, "22 commit(s) behind `master`")
+	assert.Contains(t, comment, "- `00000000` Change 0 (Jane)")
+	assert.Contains(t, comment, "Change 19")
+	assert.NotContains(t, comment, "Change 20")
+	assert.Contains(t, comment, "_...and 2 more_")
 }
 
 func TestIsForkRebasePermissionError(t *testing.T) {
@@ -668,6 +743,7 @@ func TestFivetranTerraformRebaseHandler_ValidateWebhookPayload(t *testing.T) {
 }
 
 func TestFivetranTerraformRebaseHandler_FilterEligibleMRs(t *testing.T) {
+	ctx := context.Background()
 	cfg := createTestConfig()
 	mockClient := &MockRebaseGitLabClient{}
 	handler := NewAutoRebaseHandlerWithClient(cfg, mockClient)
@@ -765,7 +841,7 @@ func TestFivetranTerraformRebaseHandler_FilterEligibleMRs(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Use a test project ID
-			result := handler.filterEligibleMRs(456, tt.mrs)
+			result := handler.filterEligibleMRs(ctx, 456, tt.mrs)
 			assert.Len(t, result.Eligible, len(tt.expectedIDs))
 
 			actualIDs := make([]int, len(result.Eligible))
@@ -778,6 +854,79 @@ func TestFivetranTerraformRebaseHandler_FilterEligibleMRs(t *testing.T) {
 	}
 }
 
+func TestAutoRebaseHandler_FilterEligibleMRs_EligibilityHooks(t *testing.T) {
+	ctx := context.Background()
+	cfg := createTestConfig()
+	cfg.AutoRebase.SkipLabels = []string{"needs-design-review=design_review_pending"}
+	handler := NewAutoRebaseHandlerWithClient(cfg, &MockRebaseGitLabClient{})
+
+	mrs := []gitlab.MRDetails{
+		{IID: 1, Pipeline: &gitlab.MRPipeline{Status: "success"}},
+		{IID: 2, Pipeline: &gitlab.MRPipeline{Status: "success"}, Labels: []string{"needs-design-review"}},
+		{IID: 3, TargetBranch: "release"},
+	}
+	handler.SetEligibilityHooks(append(handler.hooks, eligibility.NewPluginHook("freeze",
+		func(request map[string]interface{}) (bool, string, error) {
+			if request["target_branch"] == "release" {
+				return true, "release_freeze", nil
+			}
+			return false, "", nil
+		})))
+
+	result := handler.filterEligibleMRs(ctx, 456, mrs)
+	assert.Len(t, result.Eligible, 1)
+	assert.Equal(t, 1, result.Eligible[0].IID)
+	assert.Equal(t, []MRSkipInfo{
+		{MRIID: 2, Reason: "design_review_pending"},
+		{MRIID: 3, Reason: "release_freeze"},
+	}, result.Skipped)
+}
+
+func TestAutoRebaseHandler_FilterEligibleMRs_SkipDrafts(t *testing.T) {
+	ctx := context.Background()
+	mrs := []gitlab.MRDetails{
+		{IID: 1, Pipeline: &gitlab.MRPipeline{Status: "success"}},
+		{IID: 2, Pipeline: &gitlab.MRPipeline{Status: "success"}, Draft: true},
+	}
+
+	// Drafts are rebased by default
+	handler := NewAutoRebaseHandlerWithClient(createTestConfig(), &MockRebaseGitLabClient{})
+	assert.Len(t, handler.filterEligibleMRs(ctx, 456, mrs).Eligible, 2)
+
+	cfg := createTestConfig()
+	cfg.AutoRebase.SkipDrafts = true
+	handler = NewAutoRebaseHandlerWithClient(cfg, &MockRebaseGitLabClient{})
+	result := handler.filterEligibleMRs(ctx, 456, mrs)
+	assert.Len(t, result.Eligible, 1)
+	assert.Equal(t, 1, result.Eligible[0].IID)
+	assert.Equal(t, []MRSkipInfo{{MRIID: 2, Reason: "draft"}}, result.Skipped)
+}
+
+func TestAutoRebaseHandler_FilterEligibleMRs_OptOut(t *testing.T) {
+	ctx := context.Background()
+	mrs := []gitlab.MRDetails{
+		{IID: 1, Pipeline: &gitlab.MRPipeline{Status: "success"}},
+		{IID: 2, Pipeline: &gitlab.MRPipeline{Status: "success"}, Labels: []string{"No-Auto-Rebase"}},
+		{IID: 3, Pipeline: &gitlab.MRPipeline{Status: "running"}, Description: "Pinned to the old schema\n\n[no-auto-rebase]"},
+	}
+
+	cfg := createTestConfig()
+	cfg.AutoRebase.OptOutLabel = "no-auto-rebase"
+	handler := NewAutoRebaseHandlerWithClient(cfg, &MockRebaseGitLabClient{})
+	result := handler.filterEligibleMRs(ctx, 456, mrs)
+	assert.Len(t, result.Eligible, 1)
+	assert.Equal(t, 1, result.Eligible[0].IID)
+	assert.Equal(t, []MRSkipInfo{
+		{MRIID: 2, Reason: "opted_out"},
+		{MRIID: 3, Reason: "opted_out"},
+	}, result.Skipped)
+
+	// An empty opt-out label disables opting out
+	cfg.AutoRebase.OptOutLabel = ""
+	handler = NewAutoRebaseHandlerWithClient(cfg, &MockRebaseGitLabClient{})
+	assert.Len(t, handler.filterEligibleMRs(ctx, 456, mrs).Eligible, 2)
+}
+
 func TestFivetranTerraformRebaseHandler_HandleWebhook_WithFilteredMRs(t *testing.T) {
 	cfg := &config.Config{
 		GitLab: config.GitLabConfig{
@@ -1059,7 +1208,7 @@ type CustomCompareGitLabClient struct {
 	compareError      error
 }
 
-func (c *CustomCompareGitLabClient) CompareBranches(sourceProjectID int, sourceBranch string, targetProjectID int, targetBranch string) (*gitlab.CompareResult, error) {
+func (c *CustomCompareGitLabClient) CompareBranches(ctx context.Context, sourceProjectID int, sourceBranch string, targetProjectID int, targetBranch string) (*gitlab.CompareResult, error) {
 	if c.compareError != nil {
 		return nil, c.compareError
 	}
@@ -1130,7 +1279,7 @@ type MultiMRCompareClient struct {
 
 var multiMRCounter int
 
-func (c *MultiMRCompareClient) CompareBranches(sourceProjectID int, sourceBranch string, targetProjectID int, targetBranch string) (*gitlab.CompareResult, error) {
+func (c *MultiMRCompareClient) CompareBranches(ctx context.Context, sourceProjectID int, sourceBranch string, targetProjectID int, targetBranch string) (*gitlab.CompareResult, error) {
 	mrIID := c.openMRs[multiMRCounter%len(c.openMRs)]
 	multiMRCounter++
 	behindCount := c.behindCounts[mrIID]
@@ -1145,7 +1294,7 @@ func (c *MultiMRCompareClient) CompareBranches(sourceProjectID int, sourceBranch
 	return &gitlab.CompareResult{Commits: commits}, nil
 }
 
-func (c *MultiMRCompareClient) GetMRDetails(projectID, mrIID int) (*gitlab.MRDetails, error) {
+func (c *MultiMRCompareClient) GetMRDetails(ctx context.Context, projectID, mrIID int) (*gitlab.MRDetails, error) {
 	return &gitlab.MRDetails{
 		IID:                mrIID,
 		SourceBranch:       "feature-branch-" + strings.Repeat(string(rune('0'+mrIID)), 1),
@@ -1159,7 +1308,7 @@ func (c *MultiMRCompareClient) GetMRDetails(projectID, mrIID int) (*gitlab.MRDet
 	}, nil
 }
 
-func (c *MultiMRCompareClient) ListOpenMRsWithDetails(projectID int) ([]gitlab.MRDetails, error) {
+func (c *MultiMRCompareClient) ListOpenMRsWithDetails(ctx context.Context, projectID int) ([]gitlab.MRDetails, error) {
 	details := make([]gitlab.MRDetails, 0)
 	for _, mrIID := range c.openMRs {
 		details = append(details, gitlab.MRDetails{
@@ -1227,7 +1376,7 @@ type DirectionTrackingClient struct {
 	behindCount int
 }
 
-func (c *DirectionTrackingClient) GetMRDetails(projectID, mrIID int) (*gitlab.MRDetails, error) {
+func (c *DirectionTrackingClient) GetMRDetails(ctx context.Context, projectID, mrIID int) (*gitlab.MRDetails, error) {
 	return &gitlab.MRDetails{
 		IID:                mrIID,
 		SourceBranch:       "feature-branch",
@@ -1241,7 +1390,7 @@ func (c *DirectionTrackingClient) GetMRDetails(projectID, mrIID int) (*gitlab.MR
 	}, nil
 }
 
-func (c *DirectionTrackingClient) ListOpenMRsWithDetails(projectID int) ([]gitlab.MRDetails, error) {
+func (c *DirectionTrackingClient) ListOpenMRsWithDetails(ctx context.Context, projectID int) ([]gitlab.MRDetails, error) {
 	return []gitlab.MRDetails{
 		{
 			IID:                100,
@@ -1257,7 +1406,7 @@ func (c *DirectionTrackingClient) ListOpenMRsWithDetails(projectID int) ([]gitla
 	}, nil
 }
 
-func (c *DirectionTrackingClient) CompareBranches(sourceProjectID int, sourceBranch string, targetProjectID int, targetBranch string) (*gitlab.CompareResult, error) {
+func (c *DirectionTrackingClient) CompareBranches(ctx context.Context, sourceProjectID int, sourceBranch string, targetProjectID int, targetBranch string) (*gitlab.CompareResult, error) {
 	*c.capturedComparisons = append(*c.capturedComparisons, struct {
 		source string
 		target string
@@ -1415,7 +1564,7 @@ type ForkMRCompareClient struct {
 	behindCount             int
 }
 
-func (c *ForkMRCompareClient) CompareBranches(sourceProjectID int, sourceBranch string, targetProjectID int, targetBranch string) (*gitlab.CompareResult, error) {
+func (c *ForkMRCompareClient) CompareBranches(ctx context.Context, sourceProjectID int, sourceBranch string, targetProjectID int, targetBranch string) (*gitlab.CompareResult, error) {
 	c.capturedSourceProjectID = sourceProjectID
 	c.capturedTargetProjectID = targetProjectID
 	commits := make([]gitlab.CompareCommit, c.behindCount)
@@ -1426,7 +1575,7 @@ func (c *ForkMRCompareClient) CompareBranches(sourceProjectID int, sourceBranch
 }
 
 // CompareCommits is used for fork MR path; record projectID as target (upstream)
-func (c *ForkMRCompareClient) CompareCommits(projectID int, fromSHA, toSHA string) (*gitlab.CompareResult, error) {
+func (c *ForkMRCompareClient) CompareCommits(ctx context.Context, projectID int, fromSHA, toSHA string) (*gitlab.CompareResult, error) {
 	c.capturedTargetProjectID = projectID
 	c.capturedSourceProjectID = 456 // Fork path: source is fork project (set by test)
 	commits := make([]gitlab.CompareCommit, c.behindCount)
@@ -0,0 +1,298 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"github.com/redhat-data-and-ai/naysayer/internal/audit"
+	"github.com/redhat-data-and-ai/naysayer/internal/chatops"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/override"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/redhat-data-and-ai/naysayer/internal/utils"
+	"go.uber.org/zap"
+)
+
+// commandOutcome is the result of a ChatOps command, replied in the comment thread
+type commandOutcome struct {
+	decision string // Rule decision for recheck and override, otherwise rebased, explained, skipped or failed
+	reply    string
+	approved bool
+}
+
+// handleCommand runs a `/naysayer recheck|rebase|explain|override` command from an allowed commenter
+func (h *DataProductConfigMrReviewHandler) handleCommand(c *fiber.Ctx, payload map[string]interface{}, cmd *chatops.Command, parseErr error) error {
+	ctx := c.UserContext()
+	mrInfo, mr := noteMergeRequest(payload)
+	if mrInfo == nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error": "Missing merge request in note event",
+		})
+	}
+	attrs, _ := payload["object_attributes"].(map[string]interface{})
+	user, _ := payload["user"].(map[string]interface{})
+	noteID := payloadInt(attrs["id"])
+	userID := payloadInt(user["id"])
+	username, _ := user["username"].(string)
+
+	if parseErr != nil {
+		h.replyToNote(ctx, mrInfo, noteID, fmt.Sprintf("⚠️ @%s %v", username, parseErr))
+		return commandResponse(c, mrInfo, "", commandOutcome{decision: "rejected", reply: parseErr.Error()})
+	}
+
+	if !h.mayRunCommand(ctx, cmd, mrInfo, payloadInt(mr["author_id"]), userID, username) {
+		logging.FromContext(ctx).MRWarn(mrInfo.MRIID, "ChatOps command denied", zap.String("actor", username), zap.String("command", cmd.Name))
+		h.replyToNote(ctx, mrInfo, noteID, fmt.Sprintf("🚫 @%s %s", username, h.commandAccessHint(cmd)))
+		return commandResponse(c, mrInfo, cmd.Name, commandOutcome{decision: "denied", reply: "Not allowed to run naysayer commands"})
+	}
+
+	logging.FromContext(ctx).MRInfo(mrInfo.MRIID, "Running ChatOps command",
+		zap.Int("project_id", mrInfo.ProjectID),
+		zap.String("actor", username),
+		zap.String("command", cmd.Name))
+
+	var outcome commandOutcome
+	switch cmd.Name {
+	case chatops.Recheck:
+		outcome = h.recheck(ctx, mrInfo, username)
+	case chatops.Rebase:
+		outcome = h.rebase(ctx, mrInfo, username)
+	case chatops.Explain:
+		outcome = h.explain(mrInfo)
+	case chatops.Override:
+		outcome = h.overrideReview(ctx, mrInfo, username, cmd.Reason, noteID)
+	}
+	h.replyToNote(ctx, mrInfo, noteID, outcome.reply)
+	return commandResponse(c, mrInfo, cmd.Name, outcome)
+}
+
+// commandResponse answers the webhook delivery of a ChatOps command
+func commandResponse(c *fiber.Ctx, mrInfo *gitlab.MRInfo, command string, outcome commandOutcome) error {
+	return c.JSON(fiber.Map{
+		"webhook_response": "processed",
+		"event_type":       "note",
+		"command":          command,
+		"decision":         outcome.decision,
+		"reason":           outcome.reply,
+		"mr_approved":      outcome.approved,
+		"project_id":       mrInfo.ProjectID,
+		"mr_iid":           mrInfo.MRIID,
+	})
+}
+
+// overrideCommandEnabled reports whether `/naysayer override` has approvers configured
+func (h *DataProductConfigMrReviewHandler) overrideCommandEnabled() bool {
+	return h.config.ChatOps.Enabled && len(h.config.ChatOps.OverrideApprovers) > 0
+}
+
+// mayRunCommand checks the commenter may run cmd. Overrides are reserved to the override
+// approvers, whatever their project role.
+func (h *DataProductConfigMrReviewHandler) mayRunCommand(ctx context.Context, cmd *chatops.Command, mrInfo *gitlab.MRInfo, authorID, userID int, username string) bool {
+	if cmd.Name == chatops.Override {
+		return h.overrideCommandEnabled() && containsUser(h.config.ChatOps.OverrideApprovers, username)
+	}
+	return h.canRunCommand(ctx, mrInfo, authorID, userID, username)
+}
+
+// canRunCommand allows the MR author, the configured users and project members with the
+// configured role to run commands
+func (h *DataProductConfigMrReviewHandler) canRunCommand(ctx context.Context, mrInfo *gitlab.MRInfo, authorID, userID int, username string) bool {
+	cfg := h.config.ChatOps
+	if cfg.AllowAuthor && userID != 0 && userID == authorID {
+		return true
+	}
+	if containsUser(cfg.AllowedUsers, username) {
+		return true
+	}
+	return cfg.MinAccessLevel > 0 && h.hasAccessLevel(ctx, mrInfo, userID, cfg.MinAccessLevel)
+}
+
+// containsUser reports whether username is listed, ignoring case like GitLab does
+func containsUser(users []string, username string) bool {
+	for _, user := range users {
+		if strings.EqualFold(user, username) {
+			return true
+		}
+	}
+	return false
+}
+
+// commandAccessHint tells a denied commenter who may run cmd
+func (h *DataProductConfigMrReviewHandler) commandAccessHint(cmd *chatops.Command) string {
+	if cmd.Name == chatops.Override {
+		if !h.overrideCommandEnabled() {
+			return "no override approvers are configured on this instance."
+		}
+		return "only the configured override approvers can override a manual review."
+	}
+
+	var who []string
+	if h.config.ChatOps.AllowAuthor {
+		who = append(who, "the MR author")
+	}
+	if len(h.config.ChatOps.AllowedUsers) > 0 {
+		who = append(who, "allowlisted users")
+	}
+	if h.config.ChatOps.MinAccessLevel > 0 {
+		who = append(who, fmt.Sprintf("members with at least the %s role", accessLevelName(h.config.ChatOps.MinAccessLevel)))
+	}
+	if len(who) == 0 {
+		return "naysayer commands are not open to anyone on this instance."
+	}
+	return "naysayer commands can only be run by " + strings.Join(who, ", ") + "."
+}
+
+// openMR fetches an MR a command acts on, with a reply when it cannot be acted on
+func (h *DataProductConfigMrReviewHandler) openMR(ctx context.Context, mrInfo *gitlab.MRInfo) (*gitlab.MRDetails, *commandOutcome) {
+	details, err := h.gitlabClient.GetMRDetails(ctx, mrInfo.ProjectID, mrInfo.MRIID)
+	if err != nil || details == nil {
+		logging.FromContext(ctx).MRWarn(mrInfo.MRIID, "Failed to fetch MR for command", zap.Error(err))
+		return nil, &commandOutcome{decision: "failed", reply: "❌ Could not load this merge request, please try again later."}
+	}
+	if details.State != utils.MRStateOpened {
+		return nil, &commandOutcome{decision: "skipped", reply: fmt.Sprintf("ℹ️ This merge request is %s, commands only act on open merge requests.", details.State)}
+	}
+	return details, nil
+}
+
+// recheck evaluates the rules again and approves or requests review like a push would
+func (h *DataProductConfigMrReviewHandler) recheck(ctx context.Context, mrInfo *gitlab.MRInfo, username string) commandOutcome {
+	details, skipped := h.openMR(ctx, mrInfo)
+	if skipped != nil {
+		return *skipped
+	}
+	info := mrInfoFromDetails(mrInfo.ProjectID, mrInfo.MRIID, details)
+	if shared.IsDraftMR(&shared.MRContext{MRInfo: info}) && !h.config.Approval.ReviewDrafts {
+		return commandOutcome{decision: "skipped", reply: "ℹ️ Draft merge requests are reviewed once they are marked as ready."}
+	}
+
+	result, err := h.decide(ctx, info)
+	if err != nil {
+		logging.FromContext(ctx).MRError(info.MRIID, "Recheck failed", err)
+		return commandOutcome{decision: "failed", reply: "❌ The rules could not be evaluated, please try again later."}
+	}
+	approved, err := h.applyDecision(ctx, result, info)
+	if err != nil {
+		logging.FromContext(ctx).MRError(info.MRIID, "Failed to apply recheck decision", err)
+		return commandOutcome{decision: "failed", reply: "❌ The decision could not be applied, please try again later."}
+	}
+
+	verdict := "✅ approved"
+	if result.FinalDecision.Type != shared.Approve {
+		verdict = "⚠️ needs manual review"
+	}
+	return commandOutcome{
+		decision: string(result.FinalDecision.Type),
+		reply:    fmt.Sprintf("🔁 Rechecked at the request of @%s: %s. %s", username, verdict, result.FinalDecision.Reason),
+		approved: approved,
+	}
+}
+
+// rebase rebases the source branch onto the target branch
+func (h *DataProductConfigMrReviewHandler) rebase(ctx context.Context, mrInfo *gitlab.MRInfo, username string) commandOutcome {
+	details, skipped := h.openMR(ctx, mrInfo)
+	if skipped != nil {
+		return *skipped
+	}
+
+	success, err := h.gitlabClient.RebaseMR(ctx, mrInfo.ProjectID, mrInfo.MRIID)
+	if err != nil || success {
+		recordAction(audit.KindRebase, mrInfo.ProjectID, *details, "requested by @"+username, err)
+	}
+	switch {
+	case isForkRebasePermissionError(err):
+		return commandOutcome{decision: "failed", reply: "❌ naysayer cannot push to the source branch of this fork, please rebase manually."}
+	case err != nil:
+		logging.FromContext(ctx).MRWarn(mrInfo.MRIID, "Requested rebase failed", zap.Error(err))
+		return commandOutcome{decision: "failed", reply: "❌ The rebase failed, please rebase manually or try again later."}
+	case !success:
+		return commandOutcome{decision: "failed", reply: "❌ The rebase did not complete, please rebase manually or try again later."}
+	}
+	return commandOutcome{decision: "rebased", reply: fmt.Sprintf("✅ Rebased onto `%s` at the request of @%s.", details.TargetBranch, username)}
+}
+
+// explain replies with the rule breakdown of the latest evaluation
+func (h *DataProductConfigMrReviewHandler) explain(mrInfo *gitlab.MRInfo) commandOutcome {
+	noEvaluation := commandOutcome{decision: "skipped", reply: "ℹ️ No evaluation was recorded for this merge request since naysayer started. Comment `/naysayer recheck` to evaluate it."}
+	if h.explanations == nil {
+		return noEvaluation
+	}
+	data, found, err := h.explanations.Get(explanationKey(mrInfo.ProjectID, mrInfo.MRIID))
+	if err != nil {
+		logging.MRWarn(mrInfo.MRIID, "Failed to load decision explanation", zap.Error(err))
+		return commandOutcome{decision: "failed", reply: "❌ The latest decision could not be loaded, please try again later."}
+	}
+	if !found {
+		return noEvaluation
+	}
+	var explanation Explanation
+	if err := json.Unmarshal(data, &explanation); err != nil {
+		logging.MRWarn(mrInfo.MRIID, "Failed to decode decision explanation", zap.Error(err))
+		return commandOutcome{decision: "failed", reply: "❌ The latest decision could not be loaded, please try again later."}
+	}
+	return commandOutcome{decision: "explained", reply: NewMessageBuilder(h.config).BuildExplanationComment(&explanation)}
+}
+
+// overrideReview approves an MR that needs manual review on behalf of an override approver.
+// The override is kept until new commits are pushed or the MR is merged or closed.
+func (h *DataProductConfigMrReviewHandler) overrideReview(ctx context.Context, mrInfo *gitlab.MRInfo, username, reason string, noteID int) commandOutcome {
+	details, skipped := h.openMR(ctx, mrInfo)
+	if skipped != nil {
+		return *skipped
+	}
+
+	now := time.Now().UTC()
+	record := override.Override{
+		ProjectID: mrInfo.ProjectID,
+		MRIID:     mrInfo.MRIID,
+		Actor:     username,
+		Reason:    reason,
+		CreatedAt: now,
+		NoteID:    noteID,
+		SHA:       details.Sha,
+	}
+	// Record the override before evaluating so applyOverride approves the MR
+	if err := h.overrides.Save(record); err != nil {
+		logging.FromContext(ctx).MRError(mrInfo.MRIID, "Failed to save override", err)
+		return commandOutcome{decision: "failed", reply: "❌ The override could not be saved, please try again later."}
+	}
+
+	info := mrInfoFromDetails(mrInfo.ProjectID, mrInfo.MRIID, details)
+	result, err := h.decide(ctx, info)
+	approved := false
+	if err == nil {
+		approved, err = h.applyDecision(ctx, result, info)
+	}
+	recordAction(audit.KindOverride, mrInfo.ProjectID, *details, overrideReason(&record), err)
+	if err != nil {
+		if delErr := h.overrides.Delete(mrInfo.ProjectID, mrInfo.MRIID); delErr != nil {
+			logging.FromContext(ctx).MRWarn(mrInfo.MRIID, "Failed to remove override after failed approval", zap.Error(delErr))
+		}
+		logging.FromContext(ctx).MRError(mrInfo.MRIID, "Failed to apply override", err)
+		return commandOutcome{decision: "failed", reply: "❌ The override could not be applied, please try again later."}
+	}
+
+	logging.FromContext(ctx).MRInfo(mrInfo.MRIID, "Manual review overridden",
+		zap.Int("project_id", mrInfo.ProjectID),
+		zap.String("actor", username),
+		zap.String("reason", reason),
+		zap.String("sha", record.SHA),
+		zap.Bool("approved", approved))
+	if !approved {
+		return commandOutcome{
+			decision: string(result.FinalDecision.Type),
+			reply:    fmt.Sprintf("⚠️ The override from @%s is recorded, but naysayer did not approve this MR: %s", username, result.FinalDecision.Reason),
+		}
+	}
+	return commandOutcome{
+		decision: "override",
+		reply: fmt.Sprintf("✅ Manual review overridden by @%s on %s.\n\n**Reason:** %s\n\nThe override is revoked when new commits are pushed.",
+			username, now.Format("2006-01-02 15:04 MST"), reason),
+		approved: true,
+	}
+}
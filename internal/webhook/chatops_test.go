@@ -0,0 +1,195 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+)
+
+func newChatOpsTestHandler(client *overrideGitLabClient) *DataProductConfigMrReviewHandler {
+	cfg := createTestConfig()
+	cfg.ChatOps = config.ChatOpsConfig{Enabled: true, AllowAuthor: true, MinAccessLevel: gitlab.AccessLevelDeveloper}
+	handler := &DataProductConfigMrReviewHandler{gitlabClient: client, config: cfg}
+	handler.SetStateStore(store.NewMemoryStore())
+	return handler
+}
+
+// postCommand posts a note by user 7 (@commenter) on MR !123 authored by authorID
+func postCommand(t *testing.T, handler *DataProductConfigMrReviewHandler, note string, authorID int) map[string]interface{} {
+	app := createTestApp()
+	app.Post("/webhook", handler.HandleWebhook)
+
+	payload := map[string]interface{}{
+		"object_kind":       "note",
+		"project":           map[string]interface{}{"id": 456},
+		"user":              map[string]interface{}{"id": 7, "username": "commenter"},
+		"object_attributes": map[string]interface{}{"id": 99, "note": note, "noteable_type": "MergeRequest"},
+		"merge_request":     map[string]interface{}{"iid": 123, "state": "opened", "author_id": authorID},
+	}
+	body, _ := json.Marshal(payload)
+	req := httptest.NewRequest("POST", "/webhook", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	var response map[string]interface{}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+	return response
+}
+
+func openMRDetails() *gitlab.MRDetails {
+	return &gitlab.MRDetails{IID: 123, State: "opened", TargetBranch: "main", SourceBranch: "feature"}
+}
+
+func TestHandleCommand_Recheck(t *testing.T) {
+	client := &overrideGitLabClient{MockGitLabClient: &MockGitLabClient{}, details: openMRDetails()}
+	handler := newChatOpsTestHandler(client)
+
+	response := postCommand(t, handler, "/naysayer recheck", 7)
+	assert.Equal(t, "recheck", response["command"])
+	assert.Equal(t, string(shared.ManualReview), response["decision"])
+	assert.Equal(t, false, response["mr_approved"])
+	assert.Contains(t, client.comments[len(client.comments)-1], "🔁 Rechecked at the request of @commenter: ⚠️ needs manual review. MR contains no file changes")
+
+	// The recheck leaves an explanation behind
+	response = postCommand(t, handler, "/naysayer explain", 7)
+	assert.Equal(t, "explained", response["decision"])
+	assert.Contains(t, client.comments[len(client.comments)-1], "🔎 **Decision breakdown**")
+}
+
+func TestHandleCommand_Rebase(t *testing.T) {
+	client := &overrideGitLabClient{MockGitLabClient: &MockGitLabClient{}, details: openMRDetails()}
+	handler := newChatOpsTestHandler(client)
+	events := captureAudit(t)
+
+	response := postCommand(t, handler, "/naysayer rebase", 7)
+	assert.Equal(t, "rebased", response["decision"])
+	assert.Equal(t, []string{"✅ Rebased onto `main` at the request of @commenter."}, client.comments)
+	assert.Len(t, auditEvents(t, events), 1)
+}
+
+func TestHandleCommand_ClosedMR(t *testing.T) {
+	client := &overrideGitLabClient{MockGitLabClient: &MockGitLabClient{}, details: &gitlab.MRDetails{IID: 123, State: "merged"}}
+	handler := newChatOpsTestHandler(client)
+
+	response := postCommand(t, handler, "/naysayer rebase", 7)
+	assert.Equal(t, "skipped", response["decision"])
+	assert.Contains(t, client.comments[0], "This merge request is merged")
+}
+
+func TestHandleCommand_ExplainWithoutEvaluation(t *testing.T) {
+	client := &overrideGitLabClient{MockGitLabClient: &MockGitLabClient{}}
+	handler := newChatOpsTestHandler(client)
+
+	response := postCommand(t, handler, "/naysayer explain", 7)
+	assert.Equal(t, "skipped", response["decision"])
+	assert.Contains(t, client.comments[0], "Comment `/naysayer recheck` to evaluate it")
+}
+
+func TestHandleCommand_Allowlist(t *testing.T) {
+	tests := []struct {
+		name         string
+		allowAuthor  bool
+		allowedUsers []string
+		accessLevel  int
+		authorID     int
+		allowed      bool
+	}{
+		{name: "author", allowAuthor: true, authorID: 7, allowed: true},
+		{name: "author not allowed", authorID: 7},
+		{name: "allowlisted user", allowedUsers: []string{"Commenter"}, authorID: 8, allowed: true},
+		{name: "developer", accessLevel: gitlab.AccessLevelDeveloper, authorID: 8, allowed: true},
+		{name: "reporter", allowAuthor: true, accessLevel: 20, authorID: 8},
+		{name: "non member", allowAuthor: true, authorID: 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &overrideGitLabClient{MockGitLabClient: &MockGitLabClient{}, details: openMRDetails(), accessLevel: tt.accessLevel}
+			handler := newChatOpsTestHandler(client)
+			handler.config.ChatOps.AllowAuthor = tt.allowAuthor
+			handler.config.ChatOps.AllowedUsers = tt.allowedUsers
+
+			response := postCommand(t, handler, "/naysayer rebase", tt.authorID)
+			if tt.allowed {
+				assert.Equal(t, "rebased", response["decision"])
+				return
+			}
+			assert.Equal(t, "denied", response["decision"])
+			assert.Contains(t, client.comments[0], "🚫 @commenter naysayer commands can only be run by")
+		})
+	}
+}
+
+func TestHandleCommand_Unknown(t *testing.T) {
+	client := &overrideGitLabClient{MockGitLabClient: &MockGitLabClient{}}
+	handler := newChatOpsTestHandler(client)
+
+	response := postCommand(t, handler, "/naysayer merge", 7)
+	assert.Equal(t, "rejected", response["decision"])
+	assert.Contains(t, client.comments[0], "unknown naysayer command")
+}
+
+func TestHandleCommand_Disabled(t *testing.T) {
+	client := &overrideGitLabClient{MockGitLabClient: &MockGitLabClient{}, accessLevel: gitlab.AccessLevelMaintainer}
+	handler := newOverrideTestHandler(client)
+
+	// With only overrides enabled, other commands are ignored
+	response := postCommand(t, handler, "/naysayer recheck", 7)
+	assert.Equal(t, "skipped", response["decision"])
+	assert.Empty(t, client.comments)
+}
+
+func TestHandleCommand_Override(t *testing.T) {
+	details := openMRDetails()
+	details.Sha = "abc123"
+	client := &overrideGitLabClient{MockGitLabClient: &MockGitLabClient{}, details: details}
+	handler := newChatOpsTestHandler(client)
+	handler.config.ChatOps.OverrideApprovers = []string{"commenter"}
+	handler.SetStateStore(store.NewMemoryStore())
+	events := captureAudit(t)
+
+	response := postCommand(t, handler, "/naysayer override vendor contract approved by finance", 8)
+	assert.Equal(t, "override", response["decision"])
+	assert.Equal(t, true, response["mr_approved"])
+	assert.Equal(t, []string{"Manual review overridden by @commenter: vendor contract approved by finance"}, client.approvals)
+	assert.Contains(t, client.comments[len(client.comments)-1], "✅ Manual review overridden by @commenter on ")
+	assert.Contains(t, client.comments[len(client.comments)-1], "**Reason:** vendor contract approved by finance")
+
+	saved, err := handler.overrides.Get(456, 123)
+	assert.NoError(t, err)
+	assert.True(t, saved.Until.IsZero(), "command overrides do not expire")
+	assert.Equal(t, "abc123", saved.SHA)
+
+	kinds := map[string]string{}
+	for _, event := range auditEvents(t, events) {
+		kinds[event.Kind] = event.Reason
+	}
+	assert.Equal(t, "Manual review overridden by @commenter: vendor contract approved by finance", kinds["override"])
+	assert.Equal(t, kinds["override"], kinds["decision"])
+}
+
+func TestHandleCommand_OverrideDenied(t *testing.T) {
+	client := &overrideGitLabClient{MockGitLabClient: &MockGitLabClient{}, details: openMRDetails(), accessLevel: gitlab.AccessLevelOwner}
+	handler := newChatOpsTestHandler(client)
+
+	// Authors and project owners cannot override without being an override approver
+	response := postCommand(t, handler, "/naysayer override trust me", 7)
+	assert.Equal(t, "denied", response["decision"])
+	assert.Contains(t, client.comments[0], "no override approvers are configured")
+
+	handler.config.ChatOps.OverrideApprovers = []string{"senior"}
+	response = postCommand(t, handler, "/naysayer override trust me", 7)
+	assert.Equal(t, "denied", response["decision"])
+	assert.Contains(t, client.comments[1], "only the configured override approvers")
+	assert.Empty(t, client.approvals)
+}
@@ -0,0 +1,78 @@
+package webhook
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/stats"
+	"go.uber.org/zap"
+)
+
+// defaultStatsRange is the reporting range used when from is not given
+const defaultStatsRange = 30 * 24 * time.Hour
+
+// CommentStatsHandler reports naysayer comment and decision statistics
+type CommentStatsHandler struct {
+	recorder *stats.Recorder
+	now      func() time.Time
+}
+
+// NewCommentStatsHandler creates a statistics handler reading events from recorder
+func NewCommentStatsHandler(recorder *stats.Recorder) *CommentStatsHandler {
+	return &CommentStatsHandler{recorder: recorder, now: time.Now}
+}
+
+// parseStatsTime accepts RFC 3339 timestamps and YYYY-MM-DD dates (midnight UTC)
+func parseStatsTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q: expected RFC 3339 or YYYY-MM-DD", value)
+}
+
+// HandleCommentStats summarizes approvals, manual reviews, rebase and stale comments, median time
+// to first decision and auto-approval rate per project for [from, to). Defaults to the last 30 days.
+func (h *CommentStatsHandler) HandleCommentStats(c *fiber.Ctx) error {
+	projectID := c.QueryInt("project_id", 0)
+	if projectID < 0 {
+		return c.Status(400).JSON(fiber.Map{
+			"error": "project_id must be a positive integer",
+		})
+	}
+
+	to := h.now()
+	if value := c.Query("to"); value != "" {
+		parsed, err := parseStatsTime(value)
+		if err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+		}
+		to = parsed
+	}
+	from := to.Add(-defaultStatsRange)
+	if value := c.Query("from"); value != "" {
+		parsed, err := parseStatsTime(value)
+		if err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+		}
+		from = parsed
+	}
+	if !from.Before(to) {
+		return c.Status(400).JSON(fiber.Map{
+			"error": "from must be before to",
+		})
+	}
+
+	report, err := h.recorder.Summarize(projectID, from, to)
+	if err != nil {
+		logging.Error("Failed to summarize comment statistics", zap.Error(err))
+		return c.Status(500).JSON(fiber.Map{
+			"error": "failed to summarize comment statistics",
+		})
+	}
+	return c.JSON(report)
+}
@@ -0,0 +1,71 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/redhat-data-and-ai/naysayer/internal/stats"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+)
+
+func TestCommentStats_RecordsDecisionsAndReports(t *testing.T) {
+	ctx := context.Background()
+	recorder := stats.NewRecorder(store.NewMemoryStore())
+
+	cfg := createTestConfig()
+	handler := &DataProductConfigMrReviewHandler{config: cfg, gitlabClient: &MockGitLabClient{}}
+	handler.SetStatsRecorder(recorder)
+
+	createdAt := time.Now().Add(-2 * time.Hour).UTC().Format(time.RFC3339)
+	result := &shared.RuleEvaluation{FinalDecision: shared.Decision{Type: shared.Approve, Reason: "ok"}}
+	assert.NoError(t, handler.handleApprovalWithComments(ctx, result, &gitlab.MRInfo{ProjectID: 7, MRIID: 1, CreatedAt: createdAt}))
+	result = &shared.RuleEvaluation{FinalDecision: shared.Decision{Type: shared.ManualReview, Reason: "review"}}
+	assert.NoError(t, handler.handleManualReviewWithComments(ctx, result, &gitlab.MRInfo{ProjectID: 7, MRIID: 2, CreatedAt: createdAt}))
+	recorder.Record(stats.KindRebase, 8, 3)
+
+	app := createTestApp()
+	app.Get("/api/v1/stats/comments", NewCommentStatsHandler(recorder).HandleCommentStats)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/stats/comments", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	var report stats.Report
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&report))
+	assert.Len(t, report.Projects, 2)
+	assert.Equal(t, 1, report.Total.Approvals)
+	assert.Equal(t, 1, report.Total.ManualReviews)
+	assert.Equal(t, 1, report.Total.RebaseComments)
+	assert.Equal(t, 0.5, report.Total.AutoApprovalRate)
+	assert.Equal(t, 2, report.Total.FirstDecisions)
+	assert.InDelta(t, (2 * time.Hour).Seconds(), report.Total.MedianTimeToFirstDecisionSeconds, 60)
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/api/v1/stats/comments?project_id=8", nil))
+	assert.NoError(t, err)
+	report = stats.Report{}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&report))
+	assert.Len(t, report.Projects, 1)
+	assert.Equal(t, 8, report.Total.ProjectID)
+}
+
+func TestCommentStats_InvalidRange(t *testing.T) {
+	app := createTestApp()
+	app.Get("/api/v1/stats/comments", NewCommentStatsHandler(stats.NewRecorder(store.NewMemoryStore())).HandleCommentStats)
+
+	for _, query := range []string{"?from=yesterday", "?from=2024-03-02&to=2024-03-01", "?project_id=-1"} {
+		resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/stats/comments"+query, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 400, resp.StatusCode, query)
+	}
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/stats/comments?from=2024-03-01&to=2024-03-01T12:00:00Z", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+}
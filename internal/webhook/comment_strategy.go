@@ -0,0 +1,120 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"regexp"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"go.uber.org/zap"
+)
+
+// commentReplier replies in the thread of an existing comment. The GitLab client
+// implements it; without it the reply strategy edits the comment in place.
+type commentReplier interface {
+	ReplyToMRComment(ctx context.Context, projectID, mrIID, commentID int, body string) error
+}
+
+// decisionCommentTypes are the comment types carrying the review decision. With the
+// on-decision-change strategy they share a single comment.
+var decisionCommentTypes = []string{"approval", "manual-review"}
+
+// decisionIDFooter matches the decision ID footer, which differs on every evaluation
+var decisionIDFooter = regexp.MustCompile("\n<sub>🔎 Decision ID: `[0-9a-f]+`</sub>\n")
+
+// postComment adds a naysayer comment of commentType, or updates the existing one
+// following the comment update strategy of the project
+func (h *DataProductConfigMrReviewHandler) postComment(ctx context.Context, mrInfo *gitlab.MRInfo, body, commentType string) error {
+	if !h.config.Comments.UpdateExistingComments {
+		// Legacy behavior: always create new comment
+		return h.gitlabClient.AddMRComment(ctx, mrInfo.ProjectID, mrInfo.MRIID, body)
+	}
+
+	switch h.config.Comments.UpdateStrategyFor(mrInfo.ProjectID) {
+	case config.CommentStrategyReply:
+		return h.replyToComment(ctx, mrInfo, body, commentType)
+	case config.CommentStrategyOnDecisionChange:
+		return h.replaceOnDecisionChange(ctx, mrInfo, body, commentType)
+	default:
+		return h.gitlabClient.AddOrUpdateMRComment(ctx, mrInfo.ProjectID, mrInfo.MRIID, body, commentType)
+	}
+}
+
+// replyToComment replies in the thread of the existing comment instead of editing it.
+// Unchanged comments are not repeated; decision and correlation IDs alone do not count as
+// a change.
+func (h *DataProductConfigMrReviewHandler) replyToComment(ctx context.Context, mrInfo *gitlab.MRInfo, body, commentType string) error {
+	existing, err := h.gitlabClient.FindLatestNaysayerComment(ctx, mrInfo.ProjectID, mrInfo.MRIID, commentType)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return h.gitlabClient.AddMRComment(ctx, mrInfo.ProjectID, mrInfo.MRIID, body)
+	}
+	if decisionIDFooter.ReplaceAllString(gitlab.StripCorrelationID(existing.Body), "") == decisionIDFooter.ReplaceAllString(body, "") {
+		logging.FromContext(ctx).MRInfo(mrInfo.MRIID, "Comment unchanged, not replying", zap.String("comment_type", commentType))
+		return nil
+	}
+
+	replier, ok := h.gitlabClient.(commentReplier)
+	if !ok {
+		return h.gitlabClient.AddOrUpdateMRComment(ctx, mrInfo.ProjectID, mrInfo.MRIID, body, commentType)
+	}
+	if err := replier.ReplyToMRComment(ctx, mrInfo.ProjectID, mrInfo.MRIID, existing.ID, body); err != nil {
+		logging.FromContext(ctx).MRWarn(mrInfo.MRIID, "Failed to reply in comment thread, adding new comment", zap.Error(err))
+		return h.gitlabClient.AddMRComment(ctx, mrInfo.ProjectID, mrInfo.MRIID, body)
+	}
+	return nil
+}
+
+// replaceOnDecisionChange leaves the existing comment untouched while the decision stays
+// the same. Approval and manual review share one comment, which is replaced when the
+// decision flips; other comments are posted once.
+func (h *DataProductConfigMrReviewHandler) replaceOnDecisionChange(ctx context.Context, mrInfo *gitlab.MRInfo, body, commentType string) error {
+	commentTypes := []string{commentType}
+	for _, decisionType := range decisionCommentTypes {
+		if decisionType == commentType {
+			commentTypes = decisionCommentTypes
+			break
+		}
+	}
+
+	existing, existingType, err := h.latestComment(ctx, mrInfo, commentTypes)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return h.gitlabClient.AddMRComment(ctx, mrInfo.ProjectID, mrInfo.MRIID, body)
+	}
+	if existingType == commentType {
+		logging.FromContext(ctx).MRInfo(mrInfo.MRIID, "Decision unchanged, keeping existing comment", zap.String("comment_type", commentType))
+		return nil
+	}
+
+	if err := h.gitlabClient.UpdateMRComment(ctx, mrInfo.ProjectID, mrInfo.MRIID, existing.ID, body); err != nil {
+		if errors.Is(err, gitlab.ErrPermission) {
+			return h.gitlabClient.AddMRComment(ctx, mrInfo.ProjectID, mrInfo.MRIID, body)
+		}
+		return err
+	}
+	return nil
+}
+
+// latestComment returns the most recent naysayer comment of any of the types, and its type
+func (h *DataProductConfigMrReviewHandler) latestComment(ctx context.Context, mrInfo *gitlab.MRInfo, commentTypes []string) (*gitlab.MRComment, string, error) {
+	var latest *gitlab.MRComment
+	var latestType string
+	for _, commentType := range commentTypes {
+		comment, err := h.gitlabClient.FindLatestNaysayerComment(ctx, mrInfo.ProjectID, mrInfo.MRIID, commentType)
+		if err != nil {
+			return nil, "", err
+		}
+		// Note IDs increase monotonically, so the highest ID is the newest comment
+		if comment != nil && (latest == nil || comment.ID > latest.ID) {
+			latest, latestType = comment, commentType
+		}
+	}
+	return latest, latestType, nil
+}
@@ -0,0 +1,161 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/stretchr/testify/assert"
+)
+
+// commentRecordingClient keeps naysayer comments by type and records how they were posted
+type commentRecordingClient struct {
+	MockGitLabClient
+	existing map[string]*gitlab.MRComment
+	calls    []string
+}
+
+func (m *commentRecordingClient) FindLatestNaysayerComment(ctx context.Context, projectID, mrIID int, commentType ...string) (*gitlab.MRComment, error) {
+	return m.existing[commentType[0]], nil
+}
+
+func (m *commentRecordingClient) AddMRComment(ctx context.Context, projectID, mrIID int, comment string) error {
+	m.calls = append(m.calls, "add")
+	return nil
+}
+
+func (m *commentRecordingClient) AddOrUpdateMRComment(ctx context.Context, projectID, mrIID int, commentBody, commentType string) error {
+	m.calls = append(m.calls, "add-or-update:"+commentType)
+	return nil
+}
+
+func (m *commentRecordingClient) UpdateMRComment(ctx context.Context, projectID, mrIID, commentID int, newBody string) error {
+	m.calls = append(m.calls, "update")
+	return nil
+}
+
+func (m *commentRecordingClient) ReplyToMRComment(ctx context.Context, projectID, mrIID, commentID int, body string) error {
+	m.calls = append(m.calls, "reply")
+	return nil
+}
+
+func TestPostComment_Strategies(t *testing.T) {
+	ctx := context.Background()
+	approval := &gitlab.MRComment{ID: 10, Body: "approved"}
+	manualReview := &gitlab.MRComment{ID: 20, Body: "needs review"}
+
+	tests := []struct {
+		name        string
+		strategy    string
+		existing    map[string]*gitlab.MRComment
+		body        string
+		commentType string
+		expected    []string
+	}{
+		{
+			name:        "edit updates in place",
+			strategy:    config.CommentStrategyEdit,
+			existing:    map[string]*gitlab.MRComment{"approval": approval},
+			body:        "approved again",
+			commentType: "approval",
+			expected:    []string{"add-or-update:approval"},
+		},
+		{
+			name:        "reply in existing thread",
+			strategy:    config.CommentStrategyReply,
+			existing:    map[string]*gitlab.MRComment{"approval": approval},
+			body:        "approved again",
+			commentType: "approval",
+			expected:    []string{"reply"},
+		},
+		{
+			name:        "reply skips unchanged comment",
+			strategy:    config.CommentStrategyReply,
+			existing:    map[string]*gitlab.MRComment{"approval": approval},
+			body:        "approved",
+			commentType: "approval",
+			expected:    nil,
+		},
+		{
+			name:     "reply ignores decision ID changes",
+			strategy: config.CommentStrategyReply,
+			existing: map[string]*gitlab.MRComment{"approval": {
+				ID: 11, Body: "approved\n<sub>🔎 Decision ID: `0a1b`</sub>\n"}},
+			body:        "approved\n<sub>🔎 Decision ID: `2c3d`</sub>\n",
+			commentType: "approval",
+			expected:    nil,
+		},
+		{
+			name:        "reply creates first comment",
+			strategy:    config.CommentStrategyReply,
+			body:        "approved",
+			commentType: "approval",
+			expected:    []string{"add"},
+		},
+		{
+			name:        "decision unchanged keeps comment",
+			strategy:    config.CommentStrategyOnDecisionChange,
+			existing:    map[string]*gitlab.MRComment{"approval": approval},
+			body:        "approved with different details",
+			commentType: "approval",
+			expected:    nil,
+		},
+		{
+			name:        "decision change replaces latest decision comment",
+			strategy:    config.CommentStrategyOnDecisionChange,
+			existing:    map[string]*gitlab.MRComment{"approval": approval, "manual-review": manualReview},
+			body:        "approved",
+			commentType: "approval",
+			expected:    []string{"update"},
+		},
+		{
+			name:        "decision change creates first comment",
+			strategy:    config.CommentStrategyOnDecisionChange,
+			body:        "approved",
+			commentType: "approval",
+			expected:    []string{"add"},
+		},
+		{
+			name:        "other comments are posted once",
+			strategy:    config.CommentStrategyOnDecisionChange,
+			existing:    map[string]*gitlab.MRComment{"group-membership": {ID: 5, Body: "groups"}},
+			body:        "more groups",
+			commentType: "group-membership",
+			expected:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &commentRecordingClient{existing: tt.existing}
+			cfg := createTestConfig()
+			cfg.Comments.UpdateExistingComments = true
+			cfg.Comments.UpdateStrategy = tt.strategy
+			handler := &DataProductConfigMrReviewHandler{config: cfg, gitlabClient: client}
+
+			err := handler.postComment(ctx, &gitlab.MRInfo{ProjectID: 1, MRIID: 2}, tt.body, tt.commentType)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, client.calls)
+		})
+	}
+}
+
+func TestPostComment_ProjectOverrideAndLegacy(t *testing.T) {
+	ctx := context.Background()
+	client := &commentRecordingClient{existing: map[string]*gitlab.MRComment{"approval": {ID: 10, Body: "approved"}}}
+	cfg := createTestConfig()
+	cfg.Comments.UpdateExistingComments = true
+	cfg.Comments.ProjectUpdateStrategies = map[int]string{1: config.CommentStrategyReply}
+	handler := &DataProductConfigMrReviewHandler{config: cfg, gitlabClient: client}
+
+	assert.NoError(t, handler.postComment(ctx, &gitlab.MRInfo{ProjectID: 1, MRIID: 2}, "changed", "approval"))
+	assert.NoError(t, handler.postComment(ctx, &gitlab.MRInfo{ProjectID: 3, MRIID: 2}, "changed", "approval"))
+	assert.Equal(t, []string{"reply", "add-or-update:approval"}, client.calls)
+
+	client.calls = nil
+	cfg.Comments.UpdateExistingComments = false
+	assert.NoError(t, handler.postComment(ctx, &gitlab.MRInfo{ProjectID: 1, MRIID: 2}, "changed", "approval"))
+	assert.Equal(t, []string{"add"}, client.calls)
+}
@@ -0,0 +1,97 @@
+package webhook
+
+import (
+	"sort"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+)
+
+// ReviewCommentData is passed to the approval and manual review comment templates
+type ReviewCommentData struct {
+	MR       *gitlab.MRInfo
+	Decision shared.Decision
+	Summary  string        // Built-in analysis for the configured COMMENT_VERBOSITY
+	Coverage string        // Built-in line coverage report, empty unless COMMENT_COVERAGE_REPORT is set
+	Rules    []CommentRule // Every evaluated rule per file
+	Failures []CommentRule // Rules requiring manual review, and files no rule covers (empty Rule)
+	Warnings []CommentRule // Rule findings downgraded to warnings by a rule severity
+}
+
+// CommentRule is the outcome of one rule for one file
+type CommentRule struct {
+	File     string
+	Rule     string
+	Decision shared.DecisionType
+	Reason   string
+}
+
+// RebaseCommentData is passed to the automated rebase comment template
+type RebaseCommentData struct {
+	ProjectID    int
+	MRIID        int
+	Title        string
+	Author       string
+	SourceBranch string
+	TargetBranch string
+	BehindBy     int // Target branch commits missing in the source branch before the rebase
+}
+
+// StaleClosureCommentData is passed to the stale MR closure comment template
+type StaleClosureCommentData struct {
+	ProjectID    int
+	MRIID        int
+	Title        string
+	Author       string
+	DaysInactive int
+	ClosureDays  int
+}
+
+// newReviewCommentData flattens a rule evaluation for comment templates, sorted by file
+func (mb *MessageBuilder) newReviewCommentData(result *shared.RuleEvaluation, mrInfo *gitlab.MRInfo, summary string) ReviewCommentData {
+	data := ReviewCommentData{
+		MR:       mrInfo,
+		Decision: result.FinalDecision,
+		Summary:  summary,
+		Coverage: mb.BuildCoverageReport(result),
+		Rules:    []CommentRule{},
+		Failures: []CommentRule{},
+		Warnings: []CommentRule{},
+	}
+
+	paths := make([]string, 0, len(result.FileValidations))
+	for path := range result.FileValidations {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		validation := result.FileValidations[path]
+		if validation == nil {
+			continue
+		}
+		for _, ruleResult := range validation.RuleResults {
+			if !ruleResult.WasEvaluated {
+				continue
+			}
+			rule := CommentRule{File: path, Rule: ruleResult.RuleName, Decision: ruleResult.Decision, Reason: ruleResult.Reason}
+			data.Rules = append(data.Rules, rule)
+			switch rule.Decision {
+			case shared.ManualReview:
+				data.Failures = append(data.Failures, rule)
+			case shared.Warn:
+				data.Warnings = append(data.Warnings, rule)
+			}
+		}
+		if len(validation.UncoveredLines) > 0 {
+			data.Failures = append(data.Failures, CommentRule{File: path, Decision: shared.ManualReview, Reason: mb.getUncoveredReason(path)})
+		}
+	}
+	return data
+}
+
+func authorUsername(mr gitlab.MRDetails) string {
+	if mr.Author == nil {
+		return ""
+	}
+	return mr.Author.Username
+}
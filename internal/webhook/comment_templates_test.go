@@ -0,0 +1,102 @@
+package webhook
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/commenttmpl"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+)
+
+func useCommentTemplates(t *testing.T, files map[string]string) {
+	dir := t.TempDir()
+	for name, text := range files {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name+".md.tmpl"), []byte(text), 0o600))
+	}
+	set, err := commenttmpl.Load(dir)
+	assert.NoError(t, err)
+	commenttmpl.SetDefault(set)
+	t.Cleanup(func() { commenttmpl.SetDefault(nil) })
+}
+
+func templatedEvaluation() *shared.RuleEvaluation {
+	return &shared.RuleEvaluation{
+		FinalDecision: shared.Decision{Type: shared.ManualReview, Reason: "warehouse size increased"},
+		FileValidations: map[string]*shared.FileValidationSummary{
+			"dataproducts/source/sales/prod/product.yaml": {
+				RuleResults: []shared.LineValidationResult{
+					{RuleName: "warehouse_rule", Decision: shared.ManualReview, Reason: "Warehouse size increased", WasEvaluated: true},
+					{RuleName: "consumer_rule", Decision: shared.Approve, Reason: "No consumer changes", WasEvaluated: true},
+					{RuleName: "skipped_rule", Decision: shared.Approve, WasEvaluated: false},
+				},
+			},
+			"migrations/001.sql": {
+				UncoveredLines: []shared.LineRange{{StartLine: 1, EndLine: 3}},
+			},
+		},
+	}
+}
+
+func TestBuildManualReviewComment_Template(t *testing.T) {
+	useCommentTemplates(t, map[string]string{
+		commenttmpl.ManualReview: "Hi @{{.MR.Author}}, {{.Decision.Reason}}.\n{{range .Failures}}- {{.File}}{{if .Rule}} ({{.Rule}}){{end}}: {{.Reason}}\n{{end}}{{len .Rules}} rules ran",
+	})
+
+	comment := NewMessageBuilder(createTestConfig()).BuildManualReviewComment(templatedEvaluation(), &gitlab.MRInfo{MRIID: 4, Author: "alice"})
+	assert.True(t, strings.HasPrefix(comment, "<!-- naysayer-comment-id: manual-review -->\n"), "the comment stays trackable")
+	assert.Contains(t, comment, "Hi @alice, warehouse size increased.")
+	assert.Contains(t, comment, "- dataproducts/source/sales/prod/product.yaml (warehouse_rule): Warehouse size increased\n")
+	assert.Contains(t, comment, "- migrations/001.sql: No validation rules configured for SQL migrations\n")
+	assert.Contains(t, comment, "2 rules ran")
+	assert.NotContains(t, comment, "Manual review required")
+}
+
+func TestBuildApprovalComment_Template(t *testing.T) {
+	useCommentTemplates(t, map[string]string{commenttmpl.Approval: "Approved by the data team bot.\n\n{{.Summary}}"})
+
+	result := &shared.RuleEvaluation{FinalDecision: shared.Decision{Type: shared.Approve, Reason: "All rules passed"}, FileValidations: map[string]*shared.FileValidationSummary{}}
+	mb := NewMessageBuilder(createTestConfig())
+	comment := mb.BuildApprovalComment(result, &gitlab.MRInfo{})
+	assert.True(t, strings.HasPrefix(comment, "<!-- naysayer-comment-id: approval -->\nApproved by the data team bot.\n\n"))
+	assert.Contains(t, comment, mb.buildDetailedSummary(result), "the built-in analysis is available to templates")
+
+	// Without a manual review template the built-in comment is used
+	assert.Contains(t, mb.BuildManualReviewComment(templatedEvaluation(), &gitlab.MRInfo{}), "⚠️ **Manual review required**")
+}
+
+func TestBuildMaintenanceComments_Template(t *testing.T) {
+	mb := NewMessageBuilder(createTestConfig())
+	assert.Contains(t, mb.BuildRebaseComment(RebaseCommentData{}), "🤖 **Automated Rebase**")
+	assert.Contains(t, mb.BuildStaleClosureComment(StaleClosureCommentData{DaysInactive: 45}), "inactivity (45 days with no updates)")
+
+	useCommentTemplates(t, map[string]string{
+		commenttmpl.Rebase:       "Rebased {{.SourceBranch}} onto {{.TargetBranch}} ({{.BehindBy}} commits behind).",
+		commenttmpl.StaleClosure: "Closing !{{.MRIID}} after {{.DaysInactive}} idle days (limit {{.ClosureDays}}).",
+	})
+	assert.Equal(t, "Rebased feature onto main (3 commits behind).",
+		mb.BuildRebaseComment(RebaseCommentData{SourceBranch: "feature", TargetBranch: "main", BehindBy: 3}))
+	assert.Equal(t, "Closing !9 after 45 idle days (limit 30).",
+		mb.BuildStaleClosureComment(StaleClosureCommentData{MRIID: 9, DaysInactive: 45, ClosureDays: 30}))
+}
+
+func TestBuildComments_ProjectLocale(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.Comments.ProjectLocales = map[int]string{7: "de"}
+	mb := NewMessageBuilder(cfg)
+
+	comment := mb.BuildManualReviewComment(templatedEvaluation(), &gitlab.MRInfo{ProjectID: 7, MRIID: 4})
+	assert.True(t, strings.HasPrefix(comment, "<!-- naysayer-comment-id: manual-review -->\n⚠️ **Manuelle Prüfung erforderlich**"))
+	assert.Contains(t, comment, "**Grund:** warehouse size increased")
+	assert.Contains(t, comment, "- `dataproducts/source/sales/prod/product.yaml` (warehouse_rule): Warehouse size increased")
+
+	assert.Contains(t, mb.BuildManualReviewComment(templatedEvaluation(), &gitlab.MRInfo{ProjectID: 8}), "⚠️ **Manual review required**")
+	assert.Contains(t, mb.BuildStaleClosureComment(StaleClosureCommentData{ProjectID: 7, DaysInactive: 45}), "45 Tage ohne Aktualisierung")
+
+	cfg.Comments.Locale = "es"
+	assert.Contains(t, mb.BuildRebaseComment(RebaseCommentData{ProjectID: 8, TargetBranch: "main"}), "**Rebase automático**")
+}
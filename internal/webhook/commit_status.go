@@ -0,0 +1,52 @@
+package webhook
+
+import (
+	"context"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"go.uber.org/zap"
+)
+
+// maxCommitStatusDescription keeps descriptions within what GitLab shows next to the status
+const maxCommitStatusDescription = 140
+
+// commitStatusSetter reports a commit status on a commit. The GitLab client implements
+// it; without it no commit status is reported.
+type commitStatusSetter interface {
+	SetCommitStatus(ctx context.Context, projectID int, sha string, status gitlab.CommitStatus) error
+}
+
+// setCommitStatus reports the review state on the head commit of the MR so projects can
+// require it through merge checks. Failures are logged and never block the review.
+func (h *DataProductConfigMrReviewHandler) setCommitStatus(ctx context.Context, mrInfo *gitlab.MRInfo, state, description string) {
+	if !h.config.CommitStatus.Enabled {
+		return
+	}
+	client, ok := h.gitlabClient.(commitStatusSetter)
+	if !ok {
+		return
+	}
+
+	sha := mrInfo.HeadSHA
+	if sha == "" {
+		details, err := h.gitlabClient.GetMRDetails(ctx, mrInfo.ProjectID, mrInfo.MRIID)
+		if err != nil || details == nil || details.Sha == "" {
+			logging.MRWarn(mrInfo.MRIID, "Failed to resolve head commit for commit status", zap.Error(err))
+			return
+		}
+		sha = details.Sha
+	}
+
+	if runes := []rune(description); len(runes) > maxCommitStatusDescription {
+		description = string(runes[:maxCommitStatusDescription-1]) + "…"
+	}
+	status := gitlab.CommitStatus{
+		State:       state,
+		Name:        h.config.CommitStatus.Name,
+		Description: description,
+	}
+	if err := client.SetCommitStatus(ctx, mrInfo.ProjectID, sha, status); err != nil {
+		logging.MRWarn(mrInfo.MRIID, "Failed to set commit status",
+			zap.String("sha", sha), zap.String("state", state), zap.Error(err))
+	}
+}
@@ -0,0 +1,76 @@
+package webhook
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+)
+
+// statusGitLabClient records the commit statuses reported by naysayer
+type statusGitLabClient struct {
+	*MockGitLabClient
+	shas     []string
+	statuses []gitlab.CommitStatus
+}
+
+func (m *statusGitLabClient) GetMRDetails(ctx context.Context, projectID, mrIID int) (*gitlab.MRDetails, error) {
+	return &gitlab.MRDetails{IID: mrIID, State: "opened", Sha: "fromdetails"}, nil
+}
+
+func (m *statusGitLabClient) SetCommitStatus(ctx context.Context, projectID int, sha string, status gitlab.CommitStatus) error {
+	m.shas = append(m.shas, sha)
+	m.statuses = append(m.statuses, status)
+	return nil
+}
+
+func newCommitStatusTestHandler(client *statusGitLabClient) *DataProductConfigMrReviewHandler {
+	cfg := createTestConfig()
+	cfg.CommitStatus.Enabled = true
+	cfg.CommitStatus.Name = "naysayer/review"
+	return &DataProductConfigMrReviewHandler{gitlabClient: client, config: cfg}
+}
+
+func TestApplyDecision_CommitStatus(t *testing.T) {
+	client := &statusGitLabClient{MockGitLabClient: &MockGitLabClient{}}
+	handler := newCommitStatusTestHandler(client)
+	mrInfo := &gitlab.MRInfo{ProjectID: 4, MRIID: 9, HeadSHA: "abc123"}
+
+	approved, err := handler.applyDecision(context.Background(), &shared.RuleEvaluation{
+		FinalDecision: shared.Decision{Type: shared.Approve, Reason: "All rules passed"},
+	}, mrInfo)
+	assert.NoError(t, err)
+	assert.True(t, approved)
+
+	_, err = handler.applyDecision(context.Background(), &shared.RuleEvaluation{
+		FinalDecision: shared.Decision{Type: shared.ManualReview, Reason: "Warehouse increase"},
+	}, mrInfo)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"abc123", "abc123"}, client.shas)
+	assert.Equal(t, []gitlab.CommitStatus{
+		{State: gitlab.CommitStatusSuccess, Name: "naysayer/review", Description: "All rules passed"},
+		{State: gitlab.CommitStatusFailed, Name: "naysayer/review", Description: "Manual review required: Warehouse increase"},
+	}, client.statuses)
+}
+
+func TestSetCommitStatus(t *testing.T) {
+	ctx := context.Background()
+	client := &statusGitLabClient{MockGitLabClient: &MockGitLabClient{}}
+	handler := newCommitStatusTestHandler(client)
+
+	// Without a head commit in the event the MR details provide it
+	handler.setCommitStatus(ctx, &gitlab.MRInfo{ProjectID: 4, MRIID: 9}, gitlab.CommitStatusPending, strings.Repeat("x", 200))
+	if assert.Len(t, client.statuses, 1) {
+		assert.Equal(t, "fromdetails", client.shas[0])
+		assert.Len(t, []rune(client.statuses[0].Description), maxCommitStatusDescription)
+	}
+
+	handler.config.CommitStatus.Enabled = false
+	handler.setCommitStatus(ctx, &gitlab.MRInfo{ProjectID: 4, MRIID: 9, HeadSHA: "abc123"}, gitlab.CommitStatusPending, "Evaluating rules")
+	assert.Len(t, client.statuses, 1)
+}
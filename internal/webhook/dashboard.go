@@ -0,0 +1,199 @@
+package webhook
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"html/template"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/redhat-data-and-ai/naysayer/internal/audit"
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/history"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/tokenauth"
+	"go.uber.org/zap"
+)
+
+// Dashboard limits: rows per table and the window of the per-rule counts
+const (
+	dashboardRows       = 20
+	dashboardRuleWindow = 30 * 24 * time.Hour
+)
+
+//go:embed templates/dashboard.html
+var dashboardHTML string
+
+var dashboardTemplate = template.Must(template.New("dashboard").Funcs(template.FuncMap{
+	"time": func(t time.Time) string {
+		if t.IsZero() {
+			return "-"
+		}
+		return t.UTC().Format("2006-01-02 15:04 UTC")
+	},
+}).Parse(dashboardHTML))
+
+// dashboardCSP allows the inline stylesheet of the page and nothing else
+const dashboardCSP = "default-src 'none'; style-src 'unsafe-inline'; frame-ancestors 'none'"
+
+// DashboardSetting is one line of the configuration status
+type DashboardSetting struct {
+	Name  string
+	Value string
+}
+
+// dashboardData is rendered by the dashboard template
+type dashboardData struct {
+	GeneratedAt       time.Time
+	HistoryEnabled    bool
+	Decisions         []history.Decision
+	Rebases           []history.Action
+	Closures          []history.Action
+	RuleCounts        []history.RuleCount
+	RuleWindowDays    int
+	ScheduledRebases  []ScheduledRunStats
+	ScheduledCleanups []ScheduledRunStats
+	Settings          []DashboardSetting
+}
+
+// DashboardHandler serves a read-only HTML overview of recent decisions, rebase and
+// cleanup runs, per-rule outcomes and the configuration status
+type DashboardHandler struct {
+	config    *config.Config
+	decisions *history.Store
+	verifier  *tokenauth.Verifier
+	now       func() time.Time
+}
+
+// NewDashboardHandler creates a dashboard handler reading the decision history (nil when
+// disabled). Requests must carry the configured dashboard token.
+func NewDashboardHandler(cfg *config.Config, decisions *history.Store) *DashboardHandler {
+	return &DashboardHandler{
+		config:    cfg,
+		decisions: decisions,
+		verifier:  tokenauth.NewVerifier("dashboard", cfg.Dashboard.Token),
+		now:       time.Now,
+	}
+}
+
+// bearerToken returns the bearer token of the Authorization header
+func bearerToken(c *fiber.Ctx) string {
+	return strings.TrimPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+}
+
+// rejectAdminToken answers 401 to admin requests whose token does not match the dashboard
+// token and reports whether it did
+func rejectAdminToken(c *fiber.Ctx, verifier *tokenauth.Verifier, token string) (bool, error) {
+	reason := verifier.Check(token)
+	if reason == "" {
+		return false, nil
+	}
+	logging.Warn("Rejected admin request", zap.String("path", c.Path()), zap.String("ip", c.IP()), zap.String("reason", reason))
+	c.Set(fiber.HeaderWWWAuthenticate, `Bearer realm="naysayer"`)
+	return true, c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "dashboard token required"})
+}
+
+// HandleDashboard renders the dashboard for requests with the token as a bearer token or
+// the token query parameter
+func (h *DashboardHandler) HandleDashboard(c *fiber.Ctx) error {
+	if h.verifier == nil {
+		return c.Status(404).JSON(fiber.Map{"error": "dashboard is disabled"})
+	}
+	token := bearerToken(c)
+	if token == "" {
+		token = c.Query("token")
+	}
+	if rejected, err := rejectAdminToken(c, h.verifier, token); rejected {
+		return err
+	}
+
+	data, err := h.load()
+	if err != nil {
+		logging.Error("Failed to load dashboard", zap.Error(err))
+		return c.Status(500).JSON(fiber.Map{"error": "failed to load dashboard"})
+	}
+	var page bytes.Buffer
+	if err := dashboardTemplate.Execute(&page, data); err != nil {
+		logging.Error("Failed to render dashboard", zap.Error(err))
+		return c.Status(500).JSON(fiber.Map{"error": "failed to render dashboard"})
+	}
+
+	c.Set("Content-Security-Policy", dashboardCSP)
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	c.Type("html", "utf-8")
+	return c.Send(page.Bytes())
+}
+
+// load collects the dashboard data from the decision history and the scheduled run statistics
+func (h *DashboardHandler) load() (*dashboardData, error) {
+	now := h.now()
+	data := &dashboardData{
+		GeneratedAt:    now,
+		HistoryEnabled: h.decisions != nil,
+		RuleWindowDays: int(dashboardRuleWindow / (24 * time.Hour)),
+		Settings:       dashboardSettings(h.config),
+	}
+	for _, run := range ScheduledRuns() {
+		switch run.Kind {
+		case TaskAutoRebase:
+			data.ScheduledRebases = append(data.ScheduledRebases, run)
+		case TaskStaleMRCleanup:
+			data.ScheduledCleanups = append(data.ScheduledCleanups, run)
+		}
+	}
+	if h.decisions == nil {
+		return data, nil
+	}
+
+	var err error
+	if data.Decisions, err = h.decisions.Decisions(history.Filter{Limit: dashboardRows}); err != nil {
+		return nil, err
+	}
+	if data.Rebases, err = h.decisions.Actions(history.Filter{Kind: audit.KindRebase, Limit: dashboardRows}); err != nil {
+		return nil, err
+	}
+	if data.Closures, err = h.decisions.Actions(history.Filter{Kind: audit.KindStaleClose, Limit: dashboardRows}); err != nil {
+		return nil, err
+	}
+	if data.RuleCounts, err = h.decisions.RuleCounts(now.Add(-dashboardRuleWindow)); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// dashboardSettings summarizes which features are configured, without secrets
+func dashboardSettings(cfg *config.Config) []DashboardSetting {
+	enabled := func(on bool) string {
+		if on {
+			return "enabled"
+		}
+		return "disabled"
+	}
+	auditSink := cfg.Audit.Sink
+	if auditSink == "" {
+		auditSink = "disabled"
+	}
+	return []DashboardSetting{
+		{Name: "GitLab", Value: cfg.GitLab.BaseURL},
+		{Name: "Analysis mode", Value: cfg.AnalysisMode()},
+		{Name: "Webhook security", Value: cfg.WebhookSecurityMode()},
+		{Name: "GitHub", Value: enabled(cfg.GitHub.Enabled())},
+		{Name: "Auto-approval", Value: enabled(cfg.Approval.EnableAutoApproval)},
+		{Name: "Auto-rebase", Value: enabled(cfg.AutoRebase.Enabled)},
+		{Name: "Scheduled rebases", Value: strconv.Itoa(len(cfg.AutoRebase.Schedules))},
+		{Name: "Stale MR closure", Value: fmt.Sprintf("after %d days", cfg.StaleMR.ClosureDays)},
+		{Name: "Scheduled stale MR cleanups", Value: strconv.Itoa(len(cfg.StaleMR.Schedules))},
+		{Name: "Auto-merge", Value: enabled(cfg.AutoMerge.Enabled)},
+		{Name: "Commit status", Value: enabled(cfg.CommitStatus.Enabled)},
+		{Name: "ChatOps", Value: enabled(cfg.ChatOps.Enabled)},
+		{Name: "Overrides", Value: enabled(cfg.Override.Enabled)},
+		{Name: "Snapshots", Value: enabled(cfg.Snapshot.Enabled)},
+		{Name: "Decision history", Value: enabled(cfg.History.Enabled)},
+		{Name: "Audit log", Value: auditSink},
+		{Name: "Asynchronous processing", Value: enabled(cfg.Jobs.Enabled)},
+		{Name: "System hooks", Value: enabled(cfg.SystemHook.Enabled)},
+	}
+}
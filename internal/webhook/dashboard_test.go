@@ -0,0 +1,76 @@
+package webhook
+
+import (
+	"io"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/audit"
+	"github.com/redhat-data-and-ai/naysayer/internal/history"
+)
+
+func dashboardPage(t *testing.T, handler *DashboardHandler, target, authorization string) (int, string) {
+	app := createTestApp()
+	app.Get("/dashboard", handler.HandleDashboard)
+	req := httptest.NewRequest("GET", target, nil)
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(body)
+}
+
+func TestDashboardHandler(t *testing.T) {
+	store, err := history.Open(filepath.Join(t.TempDir(), "history.db"))
+	assert.NoError(t, err)
+	defer func() { _ = store.Close() }()
+
+	now := time.Now().UTC()
+	assert.NoError(t, store.Record(audit.Event{
+		ID: "d1", Timestamp: now, Kind: audit.KindDecision, ProjectID: 5, MRIID: 9,
+		Title: "Shrink <warehouse>", Decision: "approve", Approved: true, Outcome: audit.OutcomeSucceeded,
+		Rules: []audit.RuleResult{{File: "product.yaml", Rule: "warehouse_rule", Decision: "approve"}},
+	}))
+	assert.NoError(t, store.Record(audit.Event{ID: "r1", Timestamp: now, Kind: audit.KindRebase, ProjectID: 5, MRIID: 10, Title: "Add consumer"}))
+	assert.NoError(t, store.Record(audit.Event{ID: "c1", Timestamp: now, Kind: audit.KindStaleClose, ProjectID: 5, MRIID: 11, Title: "Abandoned change"}))
+
+	cfg := createTestConfig()
+	cfg.Dashboard.Token = "dashboard-secret"
+	handler := NewDashboardHandler(cfg, store)
+
+	status, _ := dashboardPage(t, handler, "/dashboard", "")
+	assert.Equal(t, 401, status)
+	status, _ = dashboardPage(t, handler, "/dashboard", "Bearer wrong")
+	assert.Equal(t, 401, status)
+
+	status, body := dashboardPage(t, handler, "/dashboard", "Bearer dashboard-secret")
+	assert.Equal(t, 200, status)
+	assert.Contains(t, body, "Shrink &lt;warehouse&gt;", "values are escaped")
+	assert.Contains(t, body, "<td>warehouse_rule</td>")
+	assert.Contains(t, body, "Add consumer")
+	assert.Contains(t, body, "Abandoned change")
+	assert.Contains(t, body, "<th>Decision history</th>")
+	assert.NotContains(t, body, "dashboard-secret", "the token is not rendered")
+
+	status, _ = dashboardPage(t, handler, "/dashboard?token=dashboard-secret", "")
+	assert.Equal(t, 200, status)
+}
+
+func TestDashboardHandler_WithoutHistory(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.Dashboard.Token = "dashboard-secret"
+
+	status, body := dashboardPage(t, NewDashboardHandler(cfg, nil), "/dashboard", "Bearer dashboard-secret")
+	assert.Equal(t, 200, status)
+	assert.Contains(t, body, "The decision history is disabled")
+	assert.Contains(t, body, "<th>Analysis mode</th>")
+
+	status, _ = dashboardPage(t, NewDashboardHandler(createTestConfig(), nil), "/dashboard", "Bearer dashboard-secret")
+	assert.Equal(t, 404, status, "the dashboard is disabled without a token")
+}
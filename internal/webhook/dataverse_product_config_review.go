@@ -1,15 +1,32 @@
 package webhook
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
 	fiber "github.com/gofiber/fiber/v2"
+	"github.com/redhat-data-and-ai/naysayer/internal/audit"
+	"github.com/redhat-data-and-ai/naysayer/internal/coalesce"
 	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/flapping"
 	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
 	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/mergepolicy"
+	"github.com/redhat-data-and-ai/naysayer/internal/notify"
+	"github.com/redhat-data-and-ai/naysayer/internal/onboarding"
+	"github.com/redhat-data-and-ai/naysayer/internal/override"
+	"github.com/redhat-data-and-ai/naysayer/internal/owners"
+	"github.com/redhat-data-and-ai/naysayer/internal/revert"
 	"github.com/redhat-data-and-ai/naysayer/internal/rules"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/group_membership"
 	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/warehouse"
+	"github.com/redhat-data-and-ai/naysayer/internal/snapshot"
+	"github.com/redhat-data-and-ai/naysayer/internal/stats"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
 	"github.com/redhat-data-and-ai/naysayer/internal/utils"
 	"go.uber.org/zap"
 )
@@ -19,11 +36,23 @@ type DataProductConfigMrReviewHandler struct {
 	gitlabClient gitlab.GitLabClient
 	ruleManager  shared.RuleManager
 	config       *config.Config
+	flapping     *flapping.Detector  // Optional: decision flapping detection
+	stats        *stats.Recorder     // Optional: comment statistics
+	snapshots    *snapshot.Store     // Optional: evaluation snapshots for replay
+	overrides    *override.Store     // Optional: approve-until decision overrides
+	onboarding   *onboarding.Checker // Optional: onboarding checklist for new data products
+	owners       *owners.Resolver    // Optional: routes manual reviews to the owners of changed paths
+	explanations store.Store         // Optional: latest evaluation of every open MR for /decisions
+	discussions  store.Store         // Optional: inline discussions opened on offending diff lines
+	notifier     notify.Sink         // Optional: notified of MRs newly requiring manual review
+	coalescer    *coalesce.Coalescer // Optional: one evaluation per MR at a time, latest event wins
+	// newRuleManager builds a rule manager for a custom client (used to capture snapshots)
+	newRuleManager func(gitlab.GitLabClient) (shared.RuleManager, error)
 }
 
 // NewDataProductConfigMrReviewHandler creates a new webhook handler
 func NewDataProductConfigMrReviewHandler(cfg *config.Config) *DataProductConfigMrReviewHandler {
-	gitlabClient := gitlab.NewClientWithConfig(cfg)
+	gitlabClient := gitlab.NewClientForFunction(cfg, config.EndpointReview)
 	return NewDataProductConfigMrReviewHandlerWithClient(cfg, gitlabClient)
 }
 
@@ -33,27 +62,273 @@ func NewDataProductConfigMrReviewHandlerWithClient(cfg *config.Config, client gi
 	// Create rule manager for dataverse product config
 	manager, err := rules.CreateSectionBasedDataverseManager(client)
 	if err != nil {
-		logging.Error("Failed to create section-based rule manager: %v", err)
+		logging.Error("Failed to create section-based rule manager", zap.Error(err))
 		panic(fmt.Sprintf("Critical error: cannot start without section-based validation: %v", err))
 	}
 
 	// Log security configuration (skip in tests if config is minimal)
 	if cfg.Webhook.AllowedIPs != nil {
-		logging.Info("Webhook security: %s", cfg.WebhookSecurityMode())
+		logging.Info("Webhook security", zap.String("mode", cfg.WebhookSecurityMode()))
 		if len(cfg.Webhook.AllowedIPs) > 0 {
-			logging.Info("IP restrictions enabled: %v", cfg.Webhook.AllowedIPs)
+			logging.Info("IP restrictions enabled", zap.Strings("allowed_ips", cfg.Webhook.AllowedIPs))
 		}
 	}
 
 	// Log comments configuration
-	logging.Info("MR Comments: %t (verbosity: %s)",
-		cfg.Comments.EnableMRComments, cfg.Comments.CommentVerbosity)
+	logging.Info("MR comments",
+		zap.Bool("enabled", cfg.Comments.EnableMRComments),
+		zap.String("verbosity", cfg.Comments.CommentVerbosity))
+
+	checker, err := onboarding.NewCheckerFromConfig(client, cfg.Onboarding)
+	if err != nil {
+		logging.Error("Onboarding checklist disabled", zap.Error(err))
+	}
 
 	return &DataProductConfigMrReviewHandler{
-		gitlabClient: client,
-		ruleManager:  manager,
-		config:       cfg,
+		gitlabClient:   client,
+		ruleManager:    manager,
+		config:         cfg,
+		onboarding:     checker,
+		owners:         owners.NewResolverFromConfig(client, cfg.Owners),
+		notifier:       notify.NewRoutedSinkFromConfig(cfg, notify.EventManualReview),
+		coalescer:      coalesce.NewFromConfig(cfg.Coalesce),
+		newRuleManager: rules.CreateSectionBasedDataverseManager,
+	}
+}
+
+// SetStatsRecorder enables recording of decisions for comment statistics
+func (h *DataProductConfigMrReviewHandler) SetStatsRecorder(recorder *stats.Recorder) {
+	h.stats = recorder
+}
+
+// SetSnapshotStore enables persisting the inputs of every evaluation for later replay
+func (h *DataProductConfigMrReviewHandler) SetSnapshotStore(snapshots *snapshot.Store) {
+	h.snapshots = snapshots
+	if h.newRuleManager == nil {
+		h.newRuleManager = rules.CreateSectionBasedDataverseManager
+	}
+}
+
+// SetStateStore enables decision explanations, inline discussions, decision flapping
+// detection and approve-until and `/naysayer override` overrides backed by the shared state store
+func (h *DataProductConfigMrReviewHandler) SetStateStore(st store.Store) {
+	h.explanations = st
+	if h.config.Comments.InlineDiscussions {
+		h.discussions = st
+	}
+	if h.config.Override.Enabled || h.overrideCommandEnabled() {
+		overrides, err := override.NewStoreFromConfig(h.config, st)
+		if err != nil {
+			logging.Error("Failed to open override directory", zap.String("dir", h.config.Override.Dir), zap.Error(err))
+			overrides = override.NewStore(st)
+		}
+		h.overrides = overrides
+	}
+	if h.config.Flapping.Threshold <= 0 {
+		return
+	}
+	h.flapping = flapping.NewDetector(st, notify.NewSinkFromConfig(h.config), h.config.Flapping)
+}
+
+// applyFlappingPolicy records the decision and, when auto-approval is frozen for a
+// flapping MR, turns an approval into a manual review
+func (h *DataProductConfigMrReviewHandler) applyFlappingPolicy(result *shared.RuleEvaluation, mrInfo *gitlab.MRInfo) {
+	if h.flapping == nil {
+		return
+	}
+
+	status, err := h.flapping.Record(mrInfo.ProjectID, mrInfo.MRIID, result.FinalDecision.Type)
+	if err != nil {
+		logging.MRWarn(mrInfo.MRIID, "Failed to record decision for flapping detection", zap.Error(err))
+		return
+	}
+	if status.Flapping {
+		logging.MRWarn(mrInfo.MRIID, "Decision flapping detected", zap.Int("flips", status.Flips), zap.Bool("frozen", status.Frozen))
+	}
+
+	if status.Frozen && result.FinalDecision.Type == shared.Approve {
+		result.FinalDecision = shared.Decision{
+			Type:    shared.ManualReview,
+			Reason:  fmt.Sprintf("Auto-approval frozen: the decision for this MR flipped %d times between approve and manual review - human review required", status.Flips),
+			Summary: "Decision flapping",
+			Details: result.FinalDecision.Reason,
+		}
+	}
+}
+
+// applyMergePolicy comments the merge setting changes an MR needs to comply with the
+// project policy and, when configured, turns an approval into a manual review until it does
+func (h *DataProductConfigMrReviewHandler) applyMergePolicy(ctx context.Context, result *shared.RuleEvaluation, mrInfo *gitlab.MRInfo) {
+	if !h.config.MergePolicy.Enabled {
+		return
+	}
+
+	violations, err := mergepolicy.NewChecker(h.gitlabClient, h.config.MergePolicy).Check(ctx, mrInfo.ProjectID, mrInfo.MRIID)
+	if err != nil {
+		logging.FromContext(ctx).MRWarn(mrInfo.MRIID, "Merge settings check failed", zap.Error(err))
+		return
+	}
+	h.postMergeSettingsComment(ctx, mrInfo, violations)
+
+	if len(violations) > 0 && h.config.MergePolicy.BlockApproval && result.FinalDecision.Type == shared.Approve {
+		result.FinalDecision = shared.Decision{
+			Type:    shared.ManualReview,
+			Reason:  mergepolicy.Reason(violations),
+			Summary: "Merge settings non-compliant",
+			Details: result.FinalDecision.Reason,
+		}
+	}
+}
+
+// applyDraftPolicy withholds the approval of draft MRs reviewed with REVIEW_DRAFT_MRS;
+// marking the MR as ready triggers the review that approves it
+func (h *DataProductConfigMrReviewHandler) applyDraftPolicy(result *shared.RuleEvaluation, mrInfo *gitlab.MRInfo) {
+	if result.FinalDecision.Type != shared.Approve || !shared.IsDraftMR(&shared.MRContext{MRInfo: mrInfo}) {
+		return
+	}
+	result.FinalDecision = shared.Decision{
+		Type:    shared.ManualReview,
+		Reason:  "Draft MR - approval withheld until the MR is marked as ready",
+		Summary: "📝 Draft MR",
+		Details: result.FinalDecision.Reason,
+	}
+}
+
+// approvalsGetter reads who approved an MR. The GitLab client implements it; without it
+// approval requirements cannot be verified and the MR needs manual review.
+type approvalsGetter interface {
+	GetMRApprovals(ctx context.Context, projectID, mrIID int) (*gitlab.MRApprovals, error)
+}
+
+// applyApprovalRequirements holds naysayer's approval until the human approvals required
+// by matching approval policies are given
+func (h *DataProductConfigMrReviewHandler) applyApprovalRequirements(ctx context.Context, result *shared.RuleEvaluation, mrInfo *gitlab.MRInfo) {
+	if len(result.ApprovalRequirements) == 0 || result.FinalDecision.Type != shared.Approve {
+		return
+	}
+
+	pending, err := h.pendingApprovals(ctx, result.ApprovalRequirements, mrInfo)
+	if err != nil {
+		logging.FromContext(ctx).MRWarn(mrInfo.MRIID, "Could not check MR approvals", zap.Error(err))
+		pending = []string{fmt.Sprintf("approvals could not be checked: %v", err)}
+	}
+	if len(pending) == 0 {
+		logging.FromContext(ctx).MRInfo(mrInfo.MRIID, "Approval policies satisfied", zap.Int("policies", len(result.ApprovalRequirements)))
+		return
+	}
+
+	result.FinalDecision = shared.Decision{
+		Type:    shared.ManualReview,
+		Reason:  "Waiting for approvals required by approval policies: " + strings.Join(pending, "; "),
+		Summary: "⏳ Waiting for required approvals",
+		Details: result.FinalDecision.Reason,
+	}
+}
+
+// groupMembersLister lists the members of a GitLab group. The GitLab client implements it;
+// without it approvers are matched as usernames only.
+type groupMembersLister interface {
+	ListGroupMembers(ctx context.Context, group string) ([]gitlab.MRUser, error)
+}
+
+// pendingApprovals describes the approval requirements not yet met by the MR approvals.
+// Naysayer's own approval does not count.
+func (h *DataProductConfigMrReviewHandler) pendingApprovals(ctx context.Context, requirements []shared.ApprovalRequirement, mrInfo *gitlab.MRInfo) ([]string, error) {
+	getter, ok := h.gitlabClient.(approvalsGetter)
+	if !ok {
+		return nil, fmt.Errorf("the GitLab client cannot read MR approvals")
+	}
+	approvals, err := getter.GetMRApprovals(ctx, mrInfo.ProjectID, mrInfo.MRIID)
+	if err != nil {
+		return nil, err
+	}
+	bot, _ := h.gitlabClient.GetCurrentBotUsername(ctx)
+
+	var pending []string
+	resolved := make(map[string][]string)
+	for _, requirement := range requirements {
+		allowed := make(map[string]bool, len(requirement.Approvers))
+		for _, approver := range requirement.Approvers {
+			usernames, err := h.approverUsernames(ctx, strings.TrimPrefix(approver, "@"), resolved)
+			if err != nil {
+				return nil, err
+			}
+			for _, username := range usernames {
+				allowed[strings.ToLower(username)] = true
+			}
+		}
+		given := 0
+		for _, approver := range approvals.ApprovedBy {
+			username := strings.ToLower(approver.Username)
+			if username == strings.ToLower(bot) || (len(allowed) > 0 && !allowed[username]) {
+				continue
+			}
+			given++
+		}
+		if given >= requirement.Approvals {
+			continue
+		}
+
+		status := fmt.Sprintf("'%s' has %d/%d approvals", requirement.Policy, given, requirement.Approvals)
+		if len(requirement.Approvers) > 0 {
+			status += fmt.Sprintf(" from %s", strings.Join(requirement.Approvers, ", "))
+		}
+		if requirement.Reason != "" {
+			status += " (" + requirement.Reason + ")"
+		}
+		pending = append(pending, status)
+	}
+	return pending, nil
+}
+
+// approverUsernames returns the usernames an approver entry stands for: the members of the
+// group of that path, or the entry itself when no such group exists. Results are kept in
+// resolved so entries shared by several requirements are looked up once.
+func (h *DataProductConfigMrReviewHandler) approverUsernames(ctx context.Context, approver string, resolved map[string][]string) ([]string, error) {
+	if usernames, ok := resolved[approver]; ok {
+		return usernames, nil
+	}
+	usernames := []string{approver}
+	if lister, ok := h.gitlabClient.(groupMembersLister); ok {
+		members, err := lister.ListGroupMembers(ctx, approver)
+		switch {
+		case errors.Is(err, gitlab.ErrNotFound):
+		case err != nil:
+			return nil, fmt.Errorf("failed to resolve approver group %s: %w", approver, err)
+		default:
+			usernames = make([]string, 0, len(members))
+			for _, member := range members {
+				usernames = append(usernames, member.Username)
+			}
+		}
+	}
+	resolved[approver] = usernames
+	return usernames, nil
+}
+
+// postMergeSettingsComment lists the toggle changes needed for compliance. Once the MR
+// complies, an earlier comment is updated instead of leaving stale instructions behind.
+func (h *DataProductConfigMrReviewHandler) postMergeSettingsComment(ctx context.Context, mrInfo *gitlab.MRInfo, violations []mergepolicy.Violation) {
+	if !h.config.Comments.EnableMRComments {
+		return
+	}
+
+	comment := NewMessageBuilder(h.config).BuildMergeSettingsComment(violations)
+	var err error
+	if len(violations) == 0 {
+		existing, findErr := h.gitlabClient.FindLatestNaysayerComment(ctx, mrInfo.ProjectID, mrInfo.MRIID, "merge-settings")
+		if findErr != nil || existing == nil {
+			return
+		}
+		err = h.gitlabClient.UpdateMRComment(ctx, mrInfo.ProjectID, mrInfo.MRIID, existing.ID, comment)
+	} else {
+		err = h.postComment(ctx, mrInfo, comment, "merge-settings")
+	}
+	if err != nil {
+		logging.FromContext(ctx).MRError(mrInfo.MRIID, "Failed to add merge settings comment", err)
+		return
 	}
+	logging.FromContext(ctx).MRInfo(mrInfo.MRIID, "Added merge settings comment", zap.Int("violations", len(violations)))
 }
 
 // HandleWebhook processes GitLab webhook requests with security validation
@@ -64,7 +339,7 @@ func (h *DataProductConfigMrReviewHandler) HandleWebhook(c *fiber.Ctx) error {
 	// Quick validation of content type
 	contentType := c.Get("Content-Type")
 	if !strings.Contains(contentType, "application/json") {
-		logging.Warn("Invalid content type: %s", contentType)
+		logging.Warn("Invalid content type", zap.String("content_type", contentType))
 		return c.Status(400).JSON(fiber.Map{
 			"error": "Content-Type must be application/json",
 		})
@@ -73,7 +348,7 @@ func (h *DataProductConfigMrReviewHandler) HandleWebhook(c *fiber.Ctx) error {
 	// Parse webhook payload
 	var payload map[string]interface{}
 	if err := c.BodyParser(&payload); err != nil {
-		logging.Error("Failed to parse payload: %v", err)
+		logging.Error("Failed to parse payload", zap.Error(err))
 		return c.Status(400).JSON(fiber.Map{
 			"error": "Invalid JSON payload",
 		})
@@ -81,12 +356,17 @@ func (h *DataProductConfigMrReviewHandler) HandleWebhook(c *fiber.Ctx) error {
 
 	// Validate webhook payload structure
 	if err := h.validateWebhookPayload(payload); err != nil {
-		logging.Warn("Webhook validation failed: %v", err)
+		logging.Warn("Webhook validation failed", zap.Error(err))
 		return c.Status(400).JSON(fiber.Map{
 			"error": "Invalid webhook payload: " + err.Error(),
 		})
 	}
 
+	// approve-until and ChatOps commands arrive as comment (note) events
+	if kind, _ := payload["object_kind"].(string); kind == "note" && (h.overrides != nil || h.config.ChatOps.Enabled) {
+		return h.handleNoteEvent(c, payload)
+	}
+
 	// Only support MR events
 	eventType, ok := payload["object_kind"].(string)
 	if !ok {
@@ -97,7 +377,7 @@ func (h *DataProductConfigMrReviewHandler) HandleWebhook(c *fiber.Ctx) error {
 	}
 
 	if eventType != "merge_request" {
-		logging.Warn("Skipping unsupported event: %s", eventType)
+		logging.Warn("Skipping unsupported event", zap.String("event_type", eventType))
 		return c.Status(400).JSON(fiber.Map{
 			"error": fmt.Sprintf("Unsupported event type: %s. Only merge_request events are supported.", eventType),
 		})
@@ -107,16 +387,33 @@ func (h *DataProductConfigMrReviewHandler) HandleWebhook(c *fiber.Ctx) error {
 }
 
 // evaluateRules evaluates all rules and returns a decision with optimized error handling
-func (h *DataProductConfigMrReviewHandler) evaluateRules(projectID, mrID int, mrInfo *gitlab.MRInfo) (*shared.RuleEvaluation, error) {
+func (h *DataProductConfigMrReviewHandler) evaluateRules(ctx context.Context, projectID, mrID int, mrInfo *gitlab.MRInfo) (*shared.RuleEvaluation, error) {
 	// Fetch MR changes from GitLab API with timeout handling
-	changes, err := h.gitlabClient.FetchMRChanges(projectID, mrID)
+	changes, err := h.gitlabClient.FetchMRChanges(ctx, projectID, mrID)
+	var tooLarge *gitlab.TooLargeError
+	if errors.As(err, &tooLarge) {
+		logging.FromContext(ctx).MRWarn(mrID, "MR too large to analyze", zap.String("reason", tooLarge.Reason))
+		return &shared.RuleEvaluation{
+			FinalDecision: shared.Decision{
+				Type:    shared.ManualReview,
+				Reason:  fmt.Sprintf("MR too large to analyze: %s", tooLarge.Reason),
+				Summary: "MR too large to analyze",
+			},
+			FileValidations: make(map[string]*shared.FileValidationSummary),
+			ExecutionTime:   0,
+		}, nil
+	}
 	if err != nil {
-		logging.MRError(mrID, "Failed to fetch MR changes", err)
+		logging.FromContext(ctx).MRError(mrID, "Failed to fetch MR changes", err)
+		reason := "Could not fetch MR changes from GitLab API"
+		if errors.Is(err, gitlab.ErrUnavailable) {
+			reason = "GitLab API is unavailable (circuit breaker open), the MR is re-evaluated on its next update"
+		}
 		// Return manual review decision if we can't fetch changes
 		return &shared.RuleEvaluation{
 			FinalDecision: shared.Decision{
 				Type:   shared.ManualReview,
-				Reason: "Could not fetch MR changes from GitLab API",
+				Reason: reason,
 			},
 			FileValidations: make(map[string]*shared.FileValidationSummary),
 			ExecutionTime:   0,
@@ -126,7 +423,7 @@ func (h *DataProductConfigMrReviewHandler) evaluateRules(projectID, mrID int, mr
 	// Validate MR has substantive changes
 	// Performance: O(1) for empty check, O(n) with early-exit for diff check
 	if len(changes) == 0 {
-		logging.MRWarn(mrID, "Empty MR detected - no file changes")
+		logging.FromContext(ctx).MRWarn(mrID, "Empty MR detected - no file changes")
 		return &shared.RuleEvaluation{
 			FinalDecision: shared.Decision{
 				Type:    shared.ManualReview,
@@ -147,7 +444,7 @@ func (h *DataProductConfigMrReviewHandler) evaluateRules(projectID, mrID int, mr
 	}
 
 	if !hasSubstantiveChange {
-		logging.MRWarn(mrID, "Net-zero changes detected",
+		logging.FromContext(ctx).MRWarn(mrID, "Net-zero changes detected",
 			zap.Int("file_count", len(changes)))
 		return &shared.RuleEvaluation{
 			FinalDecision: shared.Decision{
@@ -159,140 +456,459 @@ func (h *DataProductConfigMrReviewHandler) evaluateRules(projectID, mrID int, mr
 		}, nil
 	}
 
+	// Exact reverts of merged MRs skip rule evaluation to unblock incident recovery
+	if result := h.revertFastPath(ctx, mrInfo, changes); result != nil {
+		return result, nil
+	}
+
 	// Create MR context for rule evaluation
 	mrContext := &shared.MRContext{
 		ProjectID: projectID,
 		MRIID:     mrID,
 		Changes:   changes,
 		MRInfo:    mrInfo,
+		Ctx:       ctx,
 	}
 
 	// Log rule evaluation start
-	logging.MRInfo(mrID, "Starting rule evaluation", zap.Int("file_changes", len(changes)))
+	logging.FromContext(ctx).MRInfo(mrID, "Starting rule evaluation", zap.Int("file_changes", len(changes)))
 
-	// Evaluate all rules using the simple rule manager
-	result := h.ruleManager.EvaluateAll(mrContext)
+	// Evaluate all rules, recording what they read when snapshots are enabled
+	manager := h.ruleManager
+	var capture *snapshot.Capture
+	if h.snapshots != nil {
+		capture = snapshot.NewCapture(h.gitlabClient)
+		if captureManager, err := h.newRuleManager(capture); err != nil {
+			logging.FromContext(ctx).MRWarn(mrID, "Snapshot capture unavailable", zap.Error(err))
+			capture = nil
+		} else {
+			manager = captureManager
+		}
+	}
+	result := manager.EvaluateAll(mrContext)
+	if capture != nil {
+		snap, contents := capture.Snapshot(mrContext, result.FinalDecision, rules.RulesConfigPath)
+		if err := h.snapshots.Save(snap, contents); err != nil {
+			logging.FromContext(ctx).MRWarn(mrID, "Failed to save evaluation snapshot", zap.Error(err))
+		} else {
+			logging.FromContext(ctx).MRInfo(mrID, "Evaluation snapshot saved", zap.String("snapshot_id", snap.ID))
+			result.SnapshotID = snap.ID
+		}
+	}
 
 	// Log rule evaluation completion
-	logging.MRInfo(mrID, "Rule evaluation completed",
+	logging.FromContext(ctx).MRInfo(mrID, "Rule evaluation completed",
 		zap.String("decision", string(result.FinalDecision.Type)),
 		zap.Int("files_evaluated", result.TotalFiles))
+
+	// Give reviewers a digestible view of access changes
+	h.postGroupMembershipComment(ctx, mrInfo, changes)
+
+	// Show what warehouse size changes cost
+	h.applyWarehouseCostImpact(ctx, result, mrInfo, changes)
+
+	// Walk authors of new data products through the required artifacts
+	h.applyOnboardingChecklist(ctx, result, mrInfo, changes)
+
 	return result, nil
 }
 
+// postGroupMembershipComment comments the membership diff of changed groups/*.yaml files
+func (h *DataProductConfigMrReviewHandler) postGroupMembershipComment(ctx context.Context, mrInfo *gitlab.MRInfo, changes []gitlab.FileChange) {
+	if !h.config.Comments.EnableMRComments || mrInfo == nil {
+		return
+	}
+
+	sourceProjectID := 0
+	var groupChanges []GroupMembershipChange
+	for _, change := range changes {
+		if change.DeletedFile || !group_membership.IsGroupFile(change.NewPath) {
+			continue
+		}
+		if sourceProjectID == 0 {
+			sourceProjectID = h.sourceProjectID(ctx, mrInfo)
+		}
+
+		newContent, err := h.gitlabClient.FetchFileContent(ctx, sourceProjectID, change.NewPath, mrInfo.SourceBranch)
+		if err != nil || newContent == nil {
+			logging.FromContext(ctx).MRWarn(mrInfo.MRIID, "Could not fetch group file for membership diff", zap.String("file", change.NewPath), zap.Error(err))
+			continue
+		}
+
+		oldContent := ""
+		if !change.NewFile {
+			oldPath := change.OldPath
+			if oldPath == "" {
+				oldPath = change.NewPath
+			}
+			previous, err := h.gitlabClient.FetchFileContent(ctx, mrInfo.ProjectID, oldPath, mrInfo.TargetBranch)
+			if err != nil || previous == nil {
+				logging.FromContext(ctx).MRWarn(mrInfo.MRIID, "Could not fetch previous group file for membership diff", zap.String("file", oldPath), zap.Error(err))
+				continue
+			}
+			oldContent = previous.Content
+		}
+
+		diff, err := group_membership.ComputeDiff(oldContent, newContent.Content)
+		if err != nil {
+			logging.FromContext(ctx).MRWarn(mrInfo.MRIID, "Could not compute group membership diff", zap.String("file", change.NewPath), zap.Error(err))
+			continue
+		}
+		if diff.IsEmpty() {
+			continue
+		}
+		groupChanges = append(groupChanges, GroupMembershipChange{FilePath: change.NewPath, Diff: diff})
+	}
+
+	if len(groupChanges) == 0 {
+		return
+	}
+
+	comment := NewMessageBuilder(h.config).BuildGroupMembershipComment(groupChanges, h.config.Rules.GroupMembershipRule.ElevatedRoles)
+	if err := h.postComment(ctx, mrInfo, comment, "group-membership"); err != nil {
+		logging.FromContext(ctx).MRError(mrInfo.MRIID, "Failed to add group membership comment", err)
+		return
+	}
+	logging.FromContext(ctx).MRInfo(mrInfo.MRIID, "Added group membership comment", zap.Int("groups", len(groupChanges)))
+}
+
+// applyWarehouseCostImpact comments the estimated monthly cost of warehouse size changes and
+// turns an approval into a manual review when the increase exceeds the configured threshold.
+// Zero running hours per month disables the estimate.
+func (h *DataProductConfigMrReviewHandler) applyWarehouseCostImpact(ctx context.Context, result *shared.RuleEvaluation, mrInfo *gitlab.MRInfo, changes []gitlab.FileChange) {
+	cfg := h.config.Rules.WarehouseRule
+	if mrInfo == nil || cfg.HoursPerMonth <= 0 {
+		return
+	}
+	hasProductFile := false
+	for _, change := range changes {
+		hasProductFile = hasProductFile || (!change.DeletedFile && shared.IsDataProductFile(change.NewPath))
+	}
+	if !hasProductFile {
+		return
+	}
+
+	warehouseChanges, err := warehouse.NewAnalyzer(h.gitlabClient).AnalyzeChanges(ctx, mrInfo.ProjectID, mrInfo.MRIID, changes)
+	if err != nil {
+		logging.FromContext(ctx).MRWarn(mrInfo.MRIID, "Could not analyze warehouse changes for cost impact", zap.Error(err))
+		return
+	}
+	impacts := warehouse.NewCostModel(cfg).Estimate(warehouseChanges)
+	if len(impacts) == 0 {
+		return
+	}
+
+	_, totalCost := warehouse.TotalCostDelta(impacts)
+	logging.FromContext(ctx).MRInfo(mrInfo.MRIID, "Estimated warehouse cost impact", zap.Int("warehouses", len(impacts)), zap.Float64("monthly_cost_delta", totalCost))
+
+	if h.config.Comments.EnableMRComments {
+		comment := NewMessageBuilder(h.config).BuildWarehouseCostComment(impacts)
+		if err := h.postComment(ctx, mrInfo, comment, "warehouse-cost"); err != nil {
+			logging.FromContext(ctx).MRError(mrInfo.MRIID, "Failed to add warehouse cost comment", err)
+		}
+	}
+
+	if cfg.CostReviewThreshold > 0 && totalCost > cfg.CostReviewThreshold && result.FinalDecision.Type == shared.Approve {
+		result.FinalDecision = shared.Decision{
+			Type:    shared.ManualReview,
+			Reason:  fmt.Sprintf("Estimated warehouse cost increase of %.2f %s/month exceeds %.2f %s/month", totalCost, cfg.CostCurrency, cfg.CostReviewThreshold, cfg.CostCurrency),
+			Summary: "Warehouse cost threshold exceeded",
+			Details: result.FinalDecision.Reason,
+		}
+	}
+}
+
+// applyOnboardingChecklist comments an onboarding checklist on MRs that create data
+// products and, when configured, turns an approval into a manual review until it is complete
+func (h *DataProductConfigMrReviewHandler) applyOnboardingChecklist(ctx context.Context, result *shared.RuleEvaluation, mrInfo *gitlab.MRInfo, changes []gitlab.FileChange) {
+	if h.onboarding == nil || mrInfo == nil {
+		return
+	}
+
+	checklists := h.onboarding.Check(ctx, mrInfo.ProjectID, mrInfo.TargetBranch, changes)
+	if len(checklists) == 0 {
+		return
+	}
+
+	complete := true
+	for _, checklist := range checklists {
+		complete = complete && checklist.Complete()
+	}
+	logging.FromContext(ctx).MRInfo(mrInfo.MRIID, "New data products detected", zap.Int("products", len(checklists)), zap.Bool("complete", complete))
+
+	if h.config.Comments.EnableMRComments {
+		comment := NewMessageBuilder(h.config).BuildOnboardingComment(checklists)
+		if err := h.postComment(ctx, mrInfo, comment, "onboarding-checklist"); err != nil {
+			logging.FromContext(ctx).MRError(mrInfo.MRIID, "Failed to add onboarding checklist comment", err)
+		}
+	}
+
+	if !complete && h.config.Onboarding.BlockApproval && result.FinalDecision.Type == shared.Approve {
+		result.FinalDecision = shared.Decision{
+			Type:    shared.ManualReview,
+			Reason:  onboarding.Reason(checklists),
+			Summary: "Onboarding checklist incomplete",
+			Details: result.FinalDecision.Reason,
+		}
+	}
+}
+
+// revertFastPath approves an MR that exactly reverts a previously merged MR, even if the
+// reverted state would normally need review. It returns nil for any other MR.
+func (h *DataProductConfigMrReviewHandler) revertFastPath(ctx context.Context, mrInfo *gitlab.MRInfo, changes []gitlab.FileChange) *shared.RuleEvaluation {
+	if !h.config.Revert.FastPathEnabled || mrInfo == nil {
+		return nil
+	}
+
+	check, err := revert.NewChecker(h.gitlabClient).Check(ctx, mrInfo, changes)
+	if err != nil {
+		logging.FromContext(ctx).MRWarn(mrInfo.MRIID, "Revert check failed, evaluating rules", zap.Error(err))
+		return nil
+	}
+	if check == nil {
+		return nil
+	}
+	if !check.Exact {
+		logging.FromContext(ctx).MRInfo(mrInfo.MRIID, "Revert MR is not an exact revert, evaluating rules",
+			zap.Int("reverted_mr", check.RevertedIID), zap.String("reason", check.Reason))
+		return nil
+	}
+
+	logging.FromContext(ctx).MRInfo(mrInfo.MRIID, "Exact revert detected, approving", zap.Int("reverted_mr", check.RevertedIID))
+	return &shared.RuleEvaluation{
+		FinalDecision:   revert.Decision(check.RevertedIID),
+		FileValidations: make(map[string]*shared.FileValidationSummary),
+		TotalFiles:      len(changes),
+		ApprovedFiles:   len(changes),
+	}
+}
+
+// sourceProjectID returns the project holding the MR source branch (the fork for fork MRs)
+func (h *DataProductConfigMrReviewHandler) sourceProjectID(ctx context.Context, mrInfo *gitlab.MRInfo) int {
+	mrDetails, err := h.gitlabClient.GetMRDetails(ctx, mrInfo.ProjectID, mrInfo.MRIID)
+	if err != nil || mrDetails == nil || mrDetails.SourceProjectID == 0 {
+		return mrInfo.ProjectID
+	}
+	return mrDetails.SourceProjectID
+}
+
 // handleApprovalWithComments handles the approval process with meaningful comments and messages
-func (h *DataProductConfigMrReviewHandler) handleApprovalWithComments(result *shared.RuleEvaluation, mrInfo *gitlab.MRInfo) error {
+func (h *DataProductConfigMrReviewHandler) handleApprovalWithComments(ctx context.Context, result *shared.RuleEvaluation, mrInfo *gitlab.MRInfo) error {
 	messageBuilder := NewMessageBuilder(h.config)
 
 	// Add detailed comment to MR if enabled
 	if h.config.Comments.EnableMRComments {
-		comment := messageBuilder.BuildApprovalComment(result, mrInfo)
+		comment := messageBuilder.BuildApprovalComment(result, mrInfo) + messageBuilder.BuildExplanationSection(result, mrInfo) +
+			messageBuilder.BuildDecisionIDFooter(result)
 
-		logging.MRInfo(mrInfo.MRIID, "Adding/updating approval comment")
+		logging.FromContext(ctx).MRInfo(mrInfo.MRIID, "Adding/updating approval comment")
 
-		// Use smart comment handling (update existing or create new)
-		if h.config.Comments.UpdateExistingComments {
-			if err := h.gitlabClient.AddOrUpdateMRComment(mrInfo.ProjectID, mrInfo.MRIID, comment, "approval"); err != nil {
-				logging.MRError(mrInfo.MRIID, "Failed to add/update comment", err)
-				// Continue with approval even if comment fails - comment is nice-to-have
-			} else {
-				logging.MRInfo(mrInfo.MRIID, "Added/updated approval comment")
-			}
+		// Use smart comment handling (update existing or create new, per project strategy)
+		if err := h.postComment(ctx, mrInfo, comment, "approval"); err != nil {
+			logging.FromContext(ctx).MRError(mrInfo.MRIID, "Failed to add/update comment", err)
+			// Continue with approval even if comment fails - comment is nice-to-have
 		} else {
-			// Legacy behavior: always create new comment
-			if err := h.gitlabClient.AddMRComment(mrInfo.ProjectID, mrInfo.MRIID, comment); err != nil {
-				logging.MRError(mrInfo.MRIID, "Failed to add comment", err)
-				// Continue with approval even if comment fails - comment is nice-to-have
-			} else {
-				logging.MRInfo(mrInfo.MRIID, "Added approval comment")
-			}
+			logging.FromContext(ctx).MRInfo(mrInfo.MRIID, "Added/updated approval comment")
 		}
 	} else {
-		logging.MRInfo(mrInfo.MRIID, "Skipping comment (comments disabled)")
+		logging.FromContext(ctx).MRInfo(mrInfo.MRIID, "Skipping comment (comments disabled)")
 	}
 
 	// Approve the MR with message
 	approvalMessage := messageBuilder.BuildApprovalMessage(result)
-	logging.MRInfo(mrInfo.MRIID, "Approving MR with message", zap.String("message", approvalMessage))
+	logging.FromContext(ctx).MRInfo(mrInfo.MRIID, "Approving MR with message", zap.String("message", approvalMessage))
 
-	if err := h.gitlabClient.ApproveMRWithMessage(mrInfo.ProjectID, mrInfo.MRIID, approvalMessage); err != nil {
+	if err := h.gitlabClient.ApproveMRWithMessage(ctx, mrInfo.ProjectID, mrInfo.MRIID, approvalMessage); err != nil {
 		// Try fallback to simple approval if message approval fails
-		logging.MRWarn(mrInfo.MRIID, "Failed to approve with message, trying simple approval", zap.Error(err))
-		if fallbackErr := h.gitlabClient.ApproveMR(mrInfo.ProjectID, mrInfo.MRIID); fallbackErr != nil {
+		logging.FromContext(ctx).MRWarn(mrInfo.MRIID, "Failed to approve with message, trying simple approval", zap.Error(err))
+		if fallbackErr := h.gitlabClient.ApproveMR(ctx, mrInfo.ProjectID, mrInfo.MRIID); fallbackErr != nil {
 			return fmt.Errorf("failed to approve MR (both with message and simple): %w", fallbackErr)
 		}
-		logging.MRInfo(mrInfo.MRIID, "Auto-approved (fallback approval)")
+		logging.FromContext(ctx).MRInfo(mrInfo.MRIID, "Auto-approved (fallback approval)")
 	} else {
-		logging.MRInfo(mrInfo.MRIID, "Auto-approved", zap.String("message", approvalMessage))
+		logging.FromContext(ctx).MRInfo(mrInfo.MRIID, "Auto-approved", zap.String("message", approvalMessage))
 	}
 
+	h.stats.RecordDecision(stats.KindApproval, mrInfo.ProjectID, mrInfo.MRIID, mrInfo.CreatedAt)
+	h.stats.RecordDecisionLatency(mrInfo.ProjectID, mrInfo.MRIID, mrInfo.ReceivedAt)
 	return nil
 }
 
 // handleManualReviewWithComments handles manual review decisions with informational comments
-func (h *DataProductConfigMrReviewHandler) handleManualReviewWithComments(result *shared.RuleEvaluation, mrInfo *gitlab.MRInfo) error {
+func (h *DataProductConfigMrReviewHandler) handleManualReviewWithComments(ctx context.Context, result *shared.RuleEvaluation, mrInfo *gitlab.MRInfo) error {
 	messageBuilder := NewMessageBuilder(h.config)
 
 	// Reset any previous naysayer approval since manual review is now required
-	logging.MRInfo(mrInfo.MRIID, "Resetting any previous naysayer approval")
-	if err := h.gitlabClient.ResetNaysayerApproval(mrInfo.ProjectID, mrInfo.MRIID); err != nil {
+	logging.FromContext(ctx).MRInfo(mrInfo.MRIID, "Resetting any previous naysayer approval")
+	if err := h.gitlabClient.ResetNaysayerApproval(ctx, mrInfo.ProjectID, mrInfo.MRIID); err != nil {
 		// Log warning but continue - reset might fail if not previously approved by naysayer
-		logging.MRWarn(mrInfo.MRIID, "Could not reset previous naysayer approval (may not have been approved)", zap.Error(err))
+		logging.FromContext(ctx).MRWarn(mrInfo.MRIID, "Could not reset previous naysayer approval (may not have been approved)", zap.Error(err))
 	} else {
-		logging.MRInfo(mrInfo.MRIID, "Successfully reset previous naysayer approval")
+		logging.FromContext(ctx).MRInfo(mrInfo.MRIID, "Successfully reset previous naysayer approval")
 	}
 
+	// Route the review to the owners of the files needing it
+	reviewers := h.manualReviewers(ctx, result, mrInfo)
+	h.assignReviewers(ctx, mrInfo, reviewers)
+
 	// Add informational comment to MR if enabled
 	if h.config.Comments.EnableMRComments {
-		comment := messageBuilder.BuildManualReviewComment(result, mrInfo)
+		comment := messageBuilder.BuildManualReviewComment(result, mrInfo) + messageBuilder.BuildApprovalRevocationSection(result) +
+			messageBuilder.BuildReviewersSection(reviewers) + messageBuilder.BuildExplanationSection(result, mrInfo) +
+			messageBuilder.BuildDecisionIDFooter(result)
 
-		logging.MRInfo(mrInfo.MRIID, "Adding/updating manual review comment")
+		logging.FromContext(ctx).MRInfo(mrInfo.MRIID, "Adding/updating manual review comment")
 
-		// Use smart comment handling (update existing or create new)
-		if h.config.Comments.UpdateExistingComments {
-			if err := h.gitlabClient.AddOrUpdateMRComment(mrInfo.ProjectID, mrInfo.MRIID, comment, "manual-review"); err != nil {
-				logging.MRError(mrInfo.MRIID, "Failed to add/update manual review comment", err)
-				// Continue without error - comment is nice-to-have
-			} else {
-				logging.MRInfo(mrInfo.MRIID, "Added/updated manual review comment")
-			}
+		// Use smart comment handling (update existing or create new, per project strategy)
+		if err := h.postComment(ctx, mrInfo, comment, "manual-review"); err != nil {
+			logging.FromContext(ctx).MRError(mrInfo.MRIID, "Failed to add/update manual review comment", err)
+			// Continue without error - comment is nice-to-have
 		} else {
-			// Legacy behavior: always create new comment
-			if err := h.gitlabClient.AddMRComment(mrInfo.ProjectID, mrInfo.MRIID, comment); err != nil {
-				logging.MRError(mrInfo.MRIID, "Failed to add manual review comment", err)
-				// Continue without error - comment is nice-to-have
-			} else {
-				logging.MRInfo(mrInfo.MRIID, "Added manual review comment")
-			}
+			logging.FromContext(ctx).MRInfo(mrInfo.MRIID, "Added/updated manual review comment")
 		}
 	} else {
-		logging.MRInfo(mrInfo.MRIID, "Skipping manual review comment (comments disabled)")
+		logging.FromContext(ctx).MRInfo(mrInfo.MRIID, "Skipping manual review comment (comments disabled)")
 	}
 
+	h.stats.RecordDecision(stats.KindManualReview, mrInfo.ProjectID, mrInfo.MRIID, mrInfo.CreatedAt)
+	h.stats.RecordDecisionLatency(mrInfo.ProjectID, mrInfo.MRIID, mrInfo.ReceivedAt)
 	return nil
 }
 
+// reviewerAssigner adds MR reviewers. The GitLab client implements it; without it owners
+// are only mentioned.
+type reviewerAssigner interface {
+	GetUserByUsername(ctx context.Context, username string) (*gitlab.MRUser, error)
+	SetMRReviewers(ctx context.Context, projectID, mrIID int, reviewerIDs []int) error
+}
+
+// manualReviewers returns the reviewers policies ask for and the owners of the files
+// requiring manual review, or of all files when the review is required for the MR as a whole
+func (h *DataProductConfigMrReviewHandler) manualReviewers(ctx context.Context, result *shared.RuleEvaluation, mrInfo *gitlab.MRInfo) []string {
+	var reviewFiles, allFiles, reviewers []string
+	seen := make(map[string]bool)
+	for filePath, validation := range result.FileValidations {
+		allFiles = append(allFiles, filePath)
+		if validation != nil && validation.FileDecision == shared.ManualReview {
+			reviewFiles = append(reviewFiles, filePath)
+			for _, reviewer := range validation.Reviewers {
+				if !seen[reviewer] {
+					seen[reviewer] = true
+					reviewers = append(reviewers, reviewer)
+				}
+			}
+		}
+	}
+	sort.Strings(reviewers)
+	if h.owners == nil {
+		return reviewers
+	}
+	if len(reviewFiles) == 0 {
+		reviewFiles = allFiles
+	}
+	sort.Strings(reviewFiles)
+
+	owners, err := h.owners.Reviewers(ctx, mrInfo.ProjectID, mrInfo.TargetBranch, reviewFiles)
+	if err != nil {
+		logging.FromContext(ctx).MRWarn(mrInfo.MRIID, "Could not resolve owners of changed files", zap.Error(err))
+		return reviewers
+	}
+	for _, owner := range owners {
+		if !seen[owner] {
+			seen[owner] = true
+			reviewers = append(reviewers, owner)
+		}
+	}
+	return reviewers
+}
+
+// assignReviewers adds the owning users as MR reviewers, keeping existing reviewers.
+// Owners that are not users (groups) are skipped.
+func (h *DataProductConfigMrReviewHandler) assignReviewers(ctx context.Context, mrInfo *gitlab.MRInfo, reviewers []string) {
+	if len(reviewers) == 0 || !h.config.Owners.AssignReviewers {
+		return
+	}
+	assigner, ok := h.gitlabClient.(reviewerAssigner)
+	if !ok {
+		return
+	}
+	details, err := h.gitlabClient.GetMRDetails(ctx, mrInfo.ProjectID, mrInfo.MRIID)
+	if err != nil || details == nil {
+		logging.FromContext(ctx).MRWarn(mrInfo.MRIID, "Could not read current MR reviewers", zap.Error(err))
+		return
+	}
+
+	ids := make([]int, 0, len(details.Reviewers)+len(reviewers))
+	current := make(map[int]bool, len(details.Reviewers))
+	for _, reviewer := range details.Reviewers {
+		ids = append(ids, reviewer.ID)
+		current[reviewer.ID] = true
+	}
+	var added []string
+	for _, username := range reviewers {
+		user, err := assigner.GetUserByUsername(ctx, username)
+		if err != nil {
+			if !errors.Is(err, gitlab.ErrNotFound) {
+				logging.FromContext(ctx).MRWarn(mrInfo.MRIID, "Could not look up reviewer", zap.String("username", username), zap.Error(err))
+			}
+			continue
+		}
+		if current[user.ID] || (details.Author != nil && user.ID == details.Author.ID) {
+			continue
+		}
+		current[user.ID] = true
+		ids = append(ids, user.ID)
+		added = append(added, user.Username)
+	}
+	if len(added) == 0 {
+		return
+	}
+
+	if err := assigner.SetMRReviewers(ctx, mrInfo.ProjectID, mrInfo.MRIID, ids); err != nil {
+		logging.FromContext(ctx).MRError(mrInfo.MRIID, "Failed to assign reviewers", err)
+		return
+	}
+	logging.FromContext(ctx).MRInfo(mrInfo.MRIID, "Assigned reviewers", zap.Strings("reviewers", added))
+}
+
 // handleMergeRequestEvent handles traditional MR events (immediate processing)
 func (h *DataProductConfigMrReviewHandler) handleMergeRequestEvent(c *fiber.Ctx, payload map[string]interface{}) error {
+	ctx := c.UserContext()
 	// Extract MR information
 	mrInfo, err := gitlab.ExtractMRInfo(payload)
 	if err != nil {
-		logging.Error("Failed to extract MR info: %v", err)
+		logging.FromContext(ctx).Error("Failed to extract MR info", zap.Error(err))
 		return c.Status(400).JSON(fiber.Map{
 			"error": "Missing MR information: " + err.Error(),
 		})
 	}
+	mrInfo.ReceivedAt = c.Context().Time()
+	invalidateMRSourceFiles(payload)
 
-	logging.MRInfo(mrInfo.MRIID, "Processing MR event",
+	logging.FromContext(ctx).MRInfo(mrInfo.MRIID, "Processing MR event",
 		zap.Int("project_id", mrInfo.ProjectID),
 		zap.String("author", mrInfo.Author),
 		zap.String("state", mrInfo.State))
 
 	// Skip rule evaluation if MR is not open
 	if mrInfo.State != utils.MRStateOpened {
-		logging.MRInfo(mrInfo.MRIID, "Skipping rule evaluation for non-open MR",
+		logging.FromContext(ctx).MRInfo(mrInfo.MRIID, "Skipping rule evaluation for non-open MR",
 			zap.String("state", mrInfo.State))
 
+		// Decision history is no longer needed once the MR is merged or closed
+		h.clearExplanation(mrInfo)
+		h.clearInlineDiscussions(mrInfo)
+		if h.flapping != nil {
+			if err := h.flapping.Clear(mrInfo.ProjectID, mrInfo.MRIID); err != nil {
+				logging.FromContext(ctx).MRWarn(mrInfo.MRIID, "Failed to clear flapping history", zap.Error(err))
+			}
+		}
+		if h.overrides != nil {
+			if err := h.overrides.Delete(mrInfo.ProjectID, mrInfo.MRIID); err != nil {
+				logging.FromContext(ctx).MRWarn(mrInfo.MRIID, "Failed to clear override", zap.Error(err))
+			}
+		}
+
 		return c.JSON(fiber.Map{
 			"webhook_response": "processed",
 			"event_type":       "merge_request",
@@ -304,11 +920,14 @@ func (h *DataProductConfigMrReviewHandler) handleMergeRequestEvent(c *fiber.Ctx,
 		})
 	}
 
-	// Skip rule evaluation for draft MRs - no comments, no approval, no processing
+	// Skip rule evaluation for draft MRs - no comments, no approval, no processing - unless
+	// drafts are reviewed without approval
 	mrCtx := &shared.MRContext{MRInfo: mrInfo}
-	if shared.IsDraftMR(mrCtx) {
-		logging.MRInfo(mrInfo.MRIID, "Skipping rule evaluation for draft MR",
+	if shared.IsDraftMR(mrCtx) && !h.config.Approval.ReviewDrafts {
+		logging.FromContext(ctx).MRInfo(mrInfo.MRIID, "Skipping rule evaluation for draft MR",
 			zap.String("title", mrInfo.Title))
+		h.setCommitStatus(ctx, mrInfo, gitlab.CommitStatusPending, "Draft MR - reviewed once marked as ready")
+		h.resetStaleApproval(ctx, mrInfo)
 
 		return c.JSON(fiber.Map{
 			"webhook_response": "processed",
@@ -321,38 +940,42 @@ func (h *DataProductConfigMrReviewHandler) handleMergeRequestEvent(c *fiber.Ctx,
 		})
 	}
 
-	// Fast evaluation using rule manager
-	result, err := h.evaluateRules(mrInfo.ProjectID, mrInfo.MRIID, mrInfo)
-	if err != nil {
-		logging.MRError(mrInfo.MRIID, "Rule evaluation failed", err)
+	// Fast evaluation using rule manager, one event per MR at a time so rapid deliveries
+	// (push and MR update) do not race on comments and approvals
+	var result *shared.RuleEvaluation
+	var approved bool
+	var evalErr, applyErr error
+	evaluated := h.coalescer.Run(ctx, coalesce.Key(mrInfo.ProjectID, mrInfo.MRIID), func() {
+		h.setCommitStatus(ctx, mrInfo, gitlab.CommitStatusPending, "Evaluating rules")
+		result, evalErr = h.decide(ctx, mrInfo)
+		if evalErr != nil {
+			logging.FromContext(ctx).MRError(mrInfo.MRIID, "Rule evaluation failed", evalErr)
+			h.setCommitStatus(ctx, mrInfo, gitlab.CommitStatusFailed, "Rule evaluation failed")
+			return
+		}
+		approved, applyErr = h.applyDecision(ctx, result, mrInfo)
+	})
+	if !evaluated {
+		logging.FromContext(ctx).MRInfo(mrInfo.MRIID, "Skipping event superseded by a newer event of the MR")
+		return c.JSON(fiber.Map{
+			"webhook_response": "processed",
+			"event_type":       "merge_request",
+			"decision":         "coalesced",
+			"reason":           "A newer event of the MR is evaluated instead",
+			"mr_approved":      false,
+			"project_id":       mrInfo.ProjectID,
+			"mr_iid":           mrInfo.MRIID,
+		})
+	}
+	if evalErr != nil {
 		return c.Status(500).JSON(fiber.Map{
-			"error": "Rule evaluation failed: " + err.Error(),
+			"error": "Rule evaluation failed: " + evalErr.Error(),
 		})
 	}
-
-	// Log decision with execution time
-	logging.MRInfo(mrInfo.MRIID, "Decision",
-		zap.String("type", string(result.FinalDecision.Type)),
-		zap.String("reason", result.FinalDecision.Reason),
-		zap.Duration("execution_time", result.ExecutionTime))
-
-	// Handle approval with comments if decision is to approve
-	approved := false
-	if result.FinalDecision.Type == shared.Approve {
-		if err := h.handleApprovalWithComments(result, mrInfo); err != nil {
-			logging.MRError(mrInfo.MRIID, "Failed to approve", err)
-			return c.Status(500).JSON(fiber.Map{
-				"error": "Failed to approve MR: " + err.Error(),
-			})
-		}
-		approved = true
-	} else {
-		// Handle manual review with informational comments
-		if err := h.handleManualReviewWithComments(result, mrInfo); err != nil {
-			logging.MRError(mrInfo.MRIID, "Failed to add manual review comment", err)
-			// Continue - comment failure shouldn't block the webhook response
-		}
-		logging.MRInfo(mrInfo.MRIID, "Manual review required", zap.String("reason", result.FinalDecision.Reason))
+	if applyErr != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"error": "Failed to approve MR: " + applyErr.Error(),
+		})
 	}
 
 	// Return structured response for GitLab webhook
@@ -368,6 +991,79 @@ func (h *DataProductConfigMrReviewHandler) handleMergeRequestEvent(c *fiber.Ctx,
 	})
 }
 
+// decide evaluates the rules and applies the decision policies: flapping freezes, merge
+// settings and decision overrides
+func (h *DataProductConfigMrReviewHandler) decide(ctx context.Context, mrInfo *gitlab.MRInfo) (*shared.RuleEvaluation, error) {
+	result, err := h.evaluateRules(ctx, mrInfo.ProjectID, mrInfo.MRIID, mrInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	// Detect approve/manual-review flapping and honor auto-approval freezes
+	h.applyFlappingPolicy(result, mrInfo)
+
+	// Check squash/delete-source-branch/merge method settings
+	h.applyMergePolicy(ctx, result, mrInfo)
+
+	// Hold the approval until approval policies are satisfied
+	h.applyApprovalRequirements(ctx, result, mrInfo)
+
+	// Honor maintainer approve-until overrides
+	h.applyOverride(ctx, result, mrInfo)
+
+	// Never approve drafts, not even by override
+	h.applyDraftPolicy(result, mrInfo)
+
+	// Explain an approval that new commits revoke
+	h.applyApprovalRevocation(result, mrInfo)
+
+	// Identify the decision in the audit log and the MR comment
+	if audit.Enabled() {
+		result.DecisionID = audit.NewID()
+	}
+
+	// Log decision with execution time
+	logging.FromContext(ctx).MRInfo(mrInfo.MRIID, "Decision",
+		zap.String("type", string(result.FinalDecision.Type)),
+		zap.String("reason", result.FinalDecision.Reason),
+		zap.Duration("execution_time", result.ExecutionTime))
+	return result, nil
+}
+
+// applyDecision approves the MR or requests manual review with comments. Only a failed
+// approval is returned as an error; comment failures are logged.
+func (h *DataProductConfigMrReviewHandler) applyDecision(ctx context.Context, result *shared.RuleEvaluation, mrInfo *gitlab.MRInfo) (bool, error) {
+	repeated := h.repeatedDecision(result, mrInfo)
+	h.saveExplanation(result, mrInfo)
+	h.syncInlineDiscussions(ctx, result, mrInfo)
+
+	if result.FinalDecision.Type == shared.Approve {
+		if err := h.handleApprovalWithComments(ctx, result, mrInfo); err != nil {
+			logging.FromContext(ctx).MRError(mrInfo.MRIID, "Failed to approve", err)
+			recordDecision(result, mrInfo, false, err)
+			h.setCommitStatus(ctx, mrInfo, gitlab.CommitStatusFailed, "Approval failed")
+			return false, err
+		}
+		recordDecision(result, mrInfo, true, nil)
+		h.setCommitStatus(ctx, mrInfo, gitlab.CommitStatusSuccess, result.FinalDecision.Reason)
+		h.autoMerge(ctx, result, mrInfo)
+		return true, nil
+	}
+
+	// Handle manual review with informational comments
+	if err := h.handleManualReviewWithComments(ctx, result, mrInfo); err != nil {
+		logging.FromContext(ctx).MRError(mrInfo.MRIID, "Failed to add manual review comment", err)
+		// Continue - comment failure shouldn't block the webhook response
+	}
+	recordDecision(result, mrInfo, false, nil)
+	h.setCommitStatus(ctx, mrInfo, gitlab.CommitStatusFailed, "Manual review required: "+result.FinalDecision.Reason)
+	logging.FromContext(ctx).MRInfo(mrInfo.MRIID, "Manual review required", zap.String("reason", result.FinalDecision.Reason))
+	if !repeated {
+		h.notifyManualReview(result, mrInfo)
+	}
+	return false, nil
+}
+
 // validateWebhookPayload performs security validation on webhook payload
 func (h *DataProductConfigMrReviewHandler) validateWebhookPayload(payload map[string]interface{}) error {
 	// Check for required top-level fields
@@ -2,7 +2,9 @@ package webhook
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http/httptest"
 	"os"
@@ -14,6 +16,8 @@ import (
 
 	"github.com/redhat-data-and-ai/naysayer/internal/config"
 	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/onboarding"
+	"github.com/redhat-data-and-ai/naysayer/internal/owners"
 	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
 )
 
@@ -170,6 +174,74 @@ func TestWebhookHandler_HandleWebhook_Success(t *testing.T) {
 	assert.Contains(t, decision["reason"], "Could not fetch MR changes from GitLab API")
 }
 
+func TestWebhookHandler_HandleWebhook_DraftMR(t *testing.T) {
+	setupTestRulesFile(t)
+	payload := map[string]interface{}{
+		"object_kind": "merge_request",
+		"object_attributes": map[string]interface{}{
+			"iid":           123,
+			"title":         "Update warehouse configuration",
+			"source_branch": "feature/update",
+			"target_branch": "main",
+			"state":         "opened",
+			"draft":         true,
+		},
+		"project": map[string]interface{}{"id": 456},
+		"user":    map[string]interface{}{"username": "testuser"},
+	}
+	jsonData, _ := json.Marshal(payload)
+
+	send := func(cfg *config.Config) map[string]interface{} {
+		app := createTestApp()
+		app.Post("/webhook", NewDataProductConfigMrReviewHandler(cfg).HandleWebhook)
+		req := httptest.NewRequest("POST", "/webhook", bytes.NewReader(jsonData))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+		body, _ := io.ReadAll(resp.Body)
+		var response map[string]interface{}
+		_ = json.Unmarshal(body, &response)
+		return response
+	}
+
+	// Drafts are skipped by default, even without a draft title
+	response := send(createTestConfig())
+	assert.Equal(t, "skipped", response["decision"])
+
+	// Reviewed drafts are evaluated
+	cfg := createTestConfig()
+	cfg.Approval.ReviewDrafts = true
+	response = send(cfg)
+	decision, ok := response["decision"].(map[string]interface{})
+	assert.True(t, ok)
+	assert.Equal(t, "manual_review", decision["type"])
+	assert.Equal(t, false, response["mr_approved"])
+}
+
+// Test approvals of draft MRs are withheld
+func TestApplyDraftPolicy(t *testing.T) {
+	handler := &DataProductConfigMrReviewHandler{config: createTestConfig()}
+	approve := func() *shared.RuleEvaluation {
+		return &shared.RuleEvaluation{FinalDecision: shared.Decision{Type: shared.Approve, Reason: "All rules passed"}}
+	}
+
+	result := approve()
+	handler.applyDraftPolicy(result, &gitlab.MRInfo{MRIID: 1, Title: "Add warehouse", Draft: true})
+	assert.Equal(t, shared.ManualReview, result.FinalDecision.Type)
+	assert.Equal(t, "📝 Draft MR", result.FinalDecision.Summary)
+	assert.Contains(t, result.FinalDecision.Reason, "marked as ready")
+	assert.Equal(t, "All rules passed", result.FinalDecision.Details)
+
+	result = approve()
+	handler.applyDraftPolicy(result, &gitlab.MRInfo{MRIID: 1, Title: "Add warehouse"})
+	assert.Equal(t, shared.Approve, result.FinalDecision.Type, "ready MRs are approved")
+
+	result = &shared.RuleEvaluation{FinalDecision: shared.Decision{Type: shared.ManualReview, Reason: "Warehouse increase"}}
+	handler.applyDraftPolicy(result, &gitlab.MRInfo{MRIID: 1, Draft: true})
+	assert.Equal(t, "Warehouse increase", result.FinalDecision.Reason, "manual reviews keep their reason")
+}
+
 func TestWebhookHandler_HandleWebhook_InvalidContentType(t *testing.T) {
 	setupTestRulesFile(t)
 	cfg := createTestConfig()
@@ -447,121 +519,122 @@ type MockGitLabClient struct {
 	err     error
 }
 
-func (m *MockGitLabClient) FetchFileContent(projectID int, filePath, ref string) (*gitlab.FileContent, error) {
+func (m *MockGitLabClient) FetchFileContent(ctx context.Context, projectID int, filePath, ref string) (*gitlab.FileContent, error) {
 	return nil, nil
 }
 
-func (m *MockGitLabClient) GetMRTargetBranch(projectID, mrIID int) (string, error) {
+func (m *MockGitLabClient) GetMRTargetBranch(ctx context.Context, projectID, mrIID int) (string, error) {
 	return "main", nil
 }
 
-func (m *MockGitLabClient) GetMRDetails(projectID, mrIID int) (*gitlab.MRDetails, error) {
+func (m *MockGitLabClient) GetMRDetails(ctx context.Context, projectID, mrIID int) (*gitlab.MRDetails, error) {
 	return nil, nil
 }
 
-func (m *MockGitLabClient) FetchMRChanges(projectID, mrIID int) ([]gitlab.FileChange, error) {
+func (m *MockGitLabClient) FetchMRChanges(ctx context.Context, projectID, mrIID int) ([]gitlab.FileChange, error) {
 	return m.changes, m.err
 }
 
-func (m *MockGitLabClient) AddMRComment(projectID, mrIID int, comment string) error {
+func (m *MockGitLabClient) AddMRComment(ctx context.Context, projectID, mrIID int, comment string) error {
 	return nil
 }
 
-func (m *MockGitLabClient) AddOrUpdateMRComment(projectID, mrIID int, commentBody, commentType string) error {
+func (m *MockGitLabClient) AddOrUpdateMRComment(ctx context.Context, projectID, mrIID int, commentBody, commentType string) error {
 	return nil
 }
 
-func (m *MockGitLabClient) ListMRComments(projectID, mrIID int) ([]gitlab.MRComment, error) {
+func (m *MockGitLabClient) ListMRComments(ctx context.Context, projectID, mrIID int) ([]gitlab.MRComment, error) {
 	return nil, nil
 }
 
-func (m *MockGitLabClient) UpdateMRComment(projectID, mrIID, commentID int, newBody string) error {
+func (m *MockGitLabClient) UpdateMRComment(ctx context.Context, projectID, mrIID, commentID int, newBody string) error {
 	return nil
 }
 
-func (m *MockGitLabClient) FindLatestNaysayerComment(projectID, mrIID int, commentType ...string) (*gitlab.MRComment, error) {
+func (m *MockGitLabClient) FindLatestNaysayerComment(ctx context.Context, projectID, mrIID int, commentType ...string) (*gitlab.MRComment, error) {
 	return nil, nil
 }
 
-func (m *MockGitLabClient) ApproveMR(projectID, mrIID int) error {
+func (m *MockGitLabClient) ApproveMR(ctx context.Context, projectID, mrIID int) error {
 	return nil
 }
 
-func (m *MockGitLabClient) ApproveMRWithMessage(projectID, mrIID int, message string) error {
+func (m *MockGitLabClient) ApproveMRWithMessage(ctx context.Context, projectID, mrIID int, message string) error {
 	return nil
 }
 
-func (m *MockGitLabClient) ResetNaysayerApproval(projectID, mrIID int) error {
+func (m *MockGitLabClient) ResetNaysayerApproval(ctx context.Context, projectID, mrIID int) error {
 	return nil
 }
 
-func (m *MockGitLabClient) GetCurrentBotUsername() (string, error) {
+func (m *MockGitLabClient) GetCurrentBotUsername(ctx context.Context) (string, error) {
 	return "naysayer-bot", nil
 }
 
-func (m *MockGitLabClient) IsNaysayerBotAuthor(author map[string]interface{}) bool {
+func (m *MockGitLabClient) IsNaysayerBotAuthor(ctx context.Context, author map[string]interface{}) bool {
 	return false
 }
 
-func (m *MockGitLabClient) CompareBranches(sourceProjectID int, sourceBranch string, targetProjectID int, targetBranch string) (*gitlab.CompareResult, error) {
+func (m *MockGitLabClient) CompareBranches(ctx context.Context, sourceProjectID int, sourceBranch string, targetProjectID int, targetBranch string) (*gitlab.CompareResult, error) {
 	return &gitlab.CompareResult{Commits: []gitlab.CompareCommit{}}, nil
 }
-func (m *MockGitLabClient) GetBranchCommit(projectID int, branch string) (string, error) {
+func (m *MockGitLabClient) GetBranchCommit(ctx context.Context, projectID int, branch string) (string, error) {
 	return "mock-sha", nil
 }
-func (m *MockGitLabClient) CompareCommits(projectID int, fromSHA, toSHA string) (*gitlab.CompareResult, error) {
+func (m *MockGitLabClient) CompareCommits(ctx context.Context, projectID int, fromSHA, toSHA string) (*gitlab.CompareResult, error) {
 	return &gitlab.CompareResult{Commits: []gitlab.CompareCommit{}}, nil
 }
 
-func (m *MockGitLabClient) RebaseMR(projectID, mrIID int) (bool, error) {
+func (m *MockGitLabClient) RebaseMR(ctx context.Context, projectID, mrIID int) (bool, error) {
 	return true, nil
 }
 
-func (m *MockGitLabClient) ListOpenMRs(projectID int) ([]int, error) {
+func (m *MockGitLabClient) ListOpenMRs(ctx context.Context, projectID int) ([]int, error) {
 	return nil, nil
 }
 
-func (m *MockGitLabClient) ListOpenMRsWithDetails(projectID int) ([]gitlab.MRDetails, error) {
+func (m *MockGitLabClient) ListOpenMRsWithDetails(ctx context.Context, projectID int) ([]gitlab.MRDetails, error) {
 	// Returns MRs created in last 7 days (mocked as empty)
 	return []gitlab.MRDetails{}, nil
 }
 
-func (m *MockGitLabClient) ListAllOpenMRsWithDetails(projectID int) ([]gitlab.MRDetails, error) {
+func (m *MockGitLabClient) ListAllOpenMRsWithDetails(ctx context.Context, projectID int) ([]gitlab.MRDetails, error) {
 	// Returns ALL open MRs without date filter (mocked as empty)
 	// This is used by stale MR cleanup to find MRs older than 27-30 days
 	return []gitlab.MRDetails{}, nil
 }
 
-func (m *MockGitLabClient) CloseMR(projectID, mrIID int) error {
+func (m *MockGitLabClient) CloseMR(ctx context.Context, projectID, mrIID int) error {
 	return nil
 }
 
-func (m *MockGitLabClient) FindCommentByPattern(projectID, mrIID int, pattern string) (bool, error) {
+func (m *MockGitLabClient) FindCommentByPattern(ctx context.Context, projectID, mrIID int, pattern string) (bool, error) {
 	return false, nil
 }
 
-func (m *MockGitLabClient) GetPipelineJobs(projectID, pipelineID int) ([]gitlab.PipelineJob, error) {
+func (m *MockGitLabClient) GetPipelineJobs(ctx context.Context, projectID, pipelineID int) ([]gitlab.PipelineJob, error) {
 	return []gitlab.PipelineJob{}, nil
 }
 
-func (m *MockGitLabClient) GetJobTrace(projectID, jobID int) (string, error) {
+func (m *MockGitLabClient) GetJobTrace(ctx context.Context, projectID, jobID int) (string, error) {
 	return "", nil
 }
 
-func (m *MockGitLabClient) FindLatestAtlantisComment(projectID, mrIID int) (*gitlab.MRComment, error) {
+func (m *MockGitLabClient) FindLatestAtlantisComment(ctx context.Context, projectID, mrIID int) (*gitlab.MRComment, error) {
 	return nil, nil
 }
 
-func (m *MockGitLabClient) AreAllPipelineJobsSucceeded(projectID, pipelineID int) (bool, error) {
+func (m *MockGitLabClient) AreAllPipelineJobsSucceeded(ctx context.Context, projectID, pipelineID int) (bool, error) {
 	return true, nil
 }
 
-func (m *MockGitLabClient) CheckAtlantisCommentForPlanFailures(projectID, mrIID int) (bool, string) {
+func (m *MockGitLabClient) CheckAtlantisCommentForPlanFailures(ctx context.Context, projectID, mrIID int) (bool, string) {
 	return false, ""
 }
 
 // Test empty MR detection
 func TestEvaluateRules_EmptyMR(t *testing.T) {
+	ctx := context.Background()
 	setupTestRulesFile(t)
 	cfg := createTestConfig()
 
@@ -583,7 +656,7 @@ func TestEvaluateRules_EmptyMR(t *testing.T) {
 		State:        "opened",
 	}
 
-	result, err := handler.evaluateRules(456, 123, mrInfo)
+	result, err := handler.evaluateRules(ctx, 456, 123, mrInfo)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
@@ -594,6 +667,7 @@ func TestEvaluateRules_EmptyMR(t *testing.T) {
 
 // Test net-zero changes detection
 func TestEvaluateRules_NetZeroChanges(t *testing.T) {
+	ctx := context.Background()
 	setupTestRulesFile(t)
 	cfg := createTestConfig()
 
@@ -618,7 +692,7 @@ func TestEvaluateRules_NetZeroChanges(t *testing.T) {
 		State:        "opened",
 	}
 
-	result, err := handler.evaluateRules(456, 124, mrInfo)
+	result, err := handler.evaluateRules(ctx, 456, 124, mrInfo)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
@@ -626,3 +700,504 @@ func TestEvaluateRules_NetZeroChanges(t *testing.T) {
 	assert.Contains(t, result.FinalDecision.Reason, "no substantive changes")
 	assert.Equal(t, "Net-zero changes", result.FinalDecision.Summary)
 }
+
+func TestEvaluateRules_TooLargeMR(t *testing.T) {
+	ctx := context.Background()
+	setupTestRulesFile(t)
+	cfg := createTestConfig()
+
+	mockClient := &MockGitLabClient{
+		err: &gitlab.TooLargeError{Reason: "more than 2000 changed files"},
+	}
+
+	handler := NewDataProductConfigMrReviewHandlerWithClient(cfg, mockClient)
+
+	mrInfo := &gitlab.MRInfo{ProjectID: 456, MRIID: 125, State: "opened"}
+
+	result, err := handler.evaluateRules(ctx, 456, 125, mrInfo)
+
+	assert.NoError(t, err)
+	assert.Equal(t, shared.ManualReview, result.FinalDecision.Type)
+	assert.Equal(t, "MR too large to analyze: more than 2000 changed files", result.FinalDecision.Reason)
+	assert.Equal(t, "MR too large to analyze", result.FinalDecision.Summary)
+}
+
+func TestEvaluateRules_GitLabUnavailable(t *testing.T) {
+	ctx := context.Background()
+	setupTestRulesFile(t)
+	handler := NewDataProductConfigMrReviewHandlerWithClient(createTestConfig(), &MockGitLabClient{err: gitlab.ErrUnavailable})
+
+	result, err := handler.evaluateRules(ctx, 456, 126, &gitlab.MRInfo{ProjectID: 456, MRIID: 126, State: "opened"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, shared.ManualReview, result.FinalDecision.Type)
+	assert.Contains(t, result.FinalDecision.Reason, "GitLab API is unavailable")
+}
+
+// revertMockClient serves a merged original MR alongside the MR under review
+type revertMockClient struct {
+	MockGitLabClient
+	original []gitlab.FileChange
+}
+
+func (m *revertMockClient) FetchMRChanges(ctx context.Context, projectID, mrIID int) ([]gitlab.FileChange, error) {
+	if mrIID == 10 {
+		return m.original, nil
+	}
+	return m.changes, nil
+}
+
+func (m *revertMockClient) GetMRDetails(ctx context.Context, projectID, mrIID int) (*gitlab.MRDetails, error) {
+	return &gitlab.MRDetails{IID: mrIID, State: "merged"}, nil
+}
+
+// Test exact reverts are approved without rule evaluation
+func TestEvaluateRules_RevertFastPath(t *testing.T) {
+	ctx := context.Background()
+	setupTestRulesFile(t)
+	cfg := createTestConfig()
+	cfg.Revert.FastPathEnabled = true
+
+	path := "dataproducts/source/orders/prod/warehouse.yaml"
+	mockClient := &revertMockClient{
+		MockGitLabClient: MockGitLabClient{changes: []gitlab.FileChange{
+			{OldPath: path, NewPath: path, Diff: "@@ -1 +1 @@\n-size: LARGE\n+size: XSMALL\n"},
+		}},
+		original: []gitlab.FileChange{
+			{OldPath: path, NewPath: path, Diff: "@@ -1 +1 @@\n-size: XSMALL\n+size: LARGE\n"},
+		},
+	}
+	handler := NewDataProductConfigMrReviewHandlerWithClient(cfg, mockClient)
+
+	mrInfo := &gitlab.MRInfo{
+		ProjectID:   456,
+		MRIID:       11,
+		Title:       `Revert "Grow orders warehouse"`,
+		Description: "This reverts merge request !10",
+		State:       "opened",
+	}
+
+	result, err := handler.evaluateRules(ctx, 456, 11, mrInfo)
+	assert.NoError(t, err)
+	assert.Equal(t, shared.Approve, result.FinalDecision.Type)
+	assert.Contains(t, result.FinalDecision.Reason, "Revert of !10")
+	assert.Contains(t, NewMessageBuilder(cfg).BuildApprovalComment(result, mrInfo), "Revert of !10")
+	assert.Equal(t, "Auto-approved: Revert of !10 - exactly reverts its merged changes", NewMessageBuilder(cfg).BuildApprovalMessage(result))
+
+	// A revert that also changes something else goes through the rules
+	mockClient.changes[0].Diff = "@@ -1 +1 @@\n-size: LARGE\n+size: MEDIUM\n"
+	result, err = handler.evaluateRules(ctx, 456, 11, mrInfo)
+	assert.NoError(t, err)
+	assert.NotContains(t, result.FinalDecision.Reason, "Revert of")
+
+	// Disabled fast path
+	mockClient.changes[0].Diff = "@@ -1 +1 @@\n-size: LARGE\n+size: XSMALL\n"
+	cfg.Revert.FastPathEnabled = false
+	result, err = handler.evaluateRules(ctx, 456, 11, mrInfo)
+	assert.NoError(t, err)
+	assert.NotContains(t, result.FinalDecision.Reason, "Revert of")
+}
+
+// mergeSettingsMockClient returns MR merge settings and records merge settings comments
+type mergeSettingsMockClient struct {
+	MockGitLabClient
+	details  *gitlab.MRDetails
+	existing *gitlab.MRComment
+	comments []string
+	updated  []int
+}
+
+func (m *mergeSettingsMockClient) GetMRDetails(ctx context.Context, projectID, mrIID int) (*gitlab.MRDetails, error) {
+	return m.details, nil
+}
+
+func (m *mergeSettingsMockClient) AddOrUpdateMRComment(ctx context.Context, projectID, mrIID int, commentBody, commentType string) error {
+	m.comments = append(m.comments, commentBody)
+	return nil
+}
+
+func (m *mergeSettingsMockClient) FindLatestNaysayerComment(ctx context.Context, projectID, mrIID int, commentType ...string) (*gitlab.MRComment, error) {
+	return m.existing, nil
+}
+
+func (m *mergeSettingsMockClient) UpdateMRComment(ctx context.Context, projectID, mrIID, commentID int, newBody string) error {
+	m.updated = append(m.updated, commentID)
+	m.comments = append(m.comments, newBody)
+	return nil
+}
+
+// Test merge settings policy comments and blocking
+func TestApplyMergePolicy(t *testing.T) {
+	ctx := context.Background()
+	cfg := createTestConfig()
+	cfg.Comments = config.CommentsConfig{EnableMRComments: true, UpdateExistingComments: true}
+	cfg.MergePolicy = config.MergePolicyConfig{Enabled: true, RequireSquash: true, RequireDeleteSourceBranch: true}
+	client := &mergeSettingsMockClient{details: &gitlab.MRDetails{Squash: true}}
+	handler := &DataProductConfigMrReviewHandler{config: cfg, gitlabClient: client}
+	mrInfo := &gitlab.MRInfo{ProjectID: 1, MRIID: 2}
+
+	// Comment only
+	result := &shared.RuleEvaluation{FinalDecision: shared.Decision{Type: shared.Approve}}
+	handler.applyMergePolicy(ctx, result, mrInfo)
+	assert.Equal(t, shared.Approve, result.FinalDecision.Type)
+	assert.Len(t, client.comments, 1)
+	assert.Contains(t, client.comments[0], "<!-- naysayer-comment-id: merge-settings -->")
+	assert.Contains(t, client.comments[0], "Enable **Delete source branch when merge request is accepted**")
+	assert.NotContains(t, client.comments[0], "Squash")
+
+	// Blocking
+	cfg.MergePolicy.BlockApproval = true
+	handler.applyMergePolicy(ctx, result, mrInfo)
+	assert.Equal(t, shared.ManualReview, result.FinalDecision.Type)
+	assert.Contains(t, result.FinalDecision.Reason, "delete source branch")
+	assert.Contains(t, client.comments[1], "will not be auto-approved")
+
+	// Compliant: the earlier comment is updated, nothing new is posted
+	client.details = &gitlab.MRDetails{Squash: true, RemoveSourceBranch: true}
+	client.existing = &gitlab.MRComment{ID: 77}
+	result = &shared.RuleEvaluation{FinalDecision: shared.Decision{Type: shared.Approve}}
+	handler.applyMergePolicy(ctx, result, mrInfo)
+	assert.Equal(t, shared.Approve, result.FinalDecision.Type)
+	assert.Equal(t, []int{77}, client.updated)
+	assert.Contains(t, client.comments[2], "comply with project policy")
+
+	// Disabled
+	cfg.MergePolicy.Enabled = false
+	client.details = &gitlab.MRDetails{}
+	handler.applyMergePolicy(ctx, result, mrInfo)
+	assert.Equal(t, shared.Approve, result.FinalDecision.Type)
+	assert.Len(t, client.comments, 3)
+}
+
+// Test onboarding checklist comments and blocking for new data products
+func TestApplyOnboardingChecklist(t *testing.T) {
+	ctx := context.Background()
+	cfg := createTestConfig()
+	cfg.Comments = config.CommentsConfig{EnableMRComments: true, UpdateExistingComments: true}
+	cfg.Onboarding = config.OnboardingConfig{Enabled: true, BlockApproval: true}
+	client := &mergeSettingsMockClient{}
+	checker, err := onboarding.NewCheckerFromConfig(client, cfg.Onboarding)
+	assert.NoError(t, err)
+	handler := &DataProductConfigMrReviewHandler{config: cfg, gitlabClient: client, onboarding: checker}
+	mrInfo := &gitlab.MRInfo{ProjectID: 1, MRIID: 2, TargetBranch: "main"}
+
+	changes := []gitlab.FileChange{
+		{NewPath: "dataproducts/source/analytics/prod/product.yaml", NewFile: true, Diff: "+name: analytics"},
+	}
+
+	// Missing artifacts block approval
+	result := &shared.RuleEvaluation{FinalDecision: shared.Decision{Type: shared.Approve, Reason: "All files approved"}}
+	handler.applyOnboardingChecklist(ctx, result, mrInfo, changes)
+	assert.Equal(t, shared.ManualReview, result.FinalDecision.Type)
+	assert.Equal(t, "Onboarding checklist incomplete", result.FinalDecision.Summary)
+	assert.Contains(t, result.FinalDecision.Reason, "analytics: Masking policy (prod)")
+	assert.Len(t, client.comments, 1)
+	assert.Contains(t, client.comments[0], "<!-- naysayer-comment-id: onboarding-checklist -->")
+
+	// A later push completes the checklist
+	changes = append(changes,
+		gitlab.FileChange{NewPath: "dataproducts/source/analytics/prod/pii_masking.yaml", NewFile: true, Diff: "+x"},
+		gitlab.FileChange{NewPath: "dataproducts/source/analytics/groups/dataverse-source-analytics.yaml", NewFile: true, Diff: "+x"},
+	)
+	result = &shared.RuleEvaluation{FinalDecision: shared.Decision{Type: shared.Approve}}
+	handler.applyOnboardingChecklist(ctx, result, mrInfo, changes)
+	assert.Equal(t, shared.Approve, result.FinalDecision.Type)
+	assert.Contains(t, client.comments[1], "Onboarding checklist complete")
+
+	// Existing products get no checklist
+	result = &shared.RuleEvaluation{FinalDecision: shared.Decision{Type: shared.Approve}}
+	handler.applyOnboardingChecklist(context.Background(), result, mrInfo, []gitlab.FileChange{
+		{NewPath: "dataproducts/source/analytics/prod/product.yaml", Diff: "-a\n+b"},
+	})
+	assert.Equal(t, shared.Approve, result.FinalDecision.Type)
+	assert.Len(t, client.comments, 2)
+
+	// Disabled
+	handler.onboarding = nil
+	handler.applyOnboardingChecklist(context.Background(), result, mrInfo, changes[:1])
+	assert.Equal(t, shared.Approve, result.FinalDecision.Type)
+	assert.Len(t, client.comments, 2)
+}
+
+// warehouseCostMockClient serves product.yaml versions by branch
+type warehouseCostMockClient struct {
+	mergeSettingsMockClient
+	files map[string]string // ref -> product.yaml content
+}
+
+func (m *warehouseCostMockClient) FetchFileContent(ctx context.Context, projectID int, filePath, ref string) (*gitlab.FileContent, error) {
+	content, ok := m.files[ref]
+	if !ok {
+		return nil, gitlab.ErrNotFound
+	}
+	return &gitlab.FileContent{Content: content}, nil
+}
+
+// Test warehouse cost impact comments and the cost review threshold
+func TestApplyWarehouseCostImpact(t *testing.T) {
+	ctx := context.Background()
+	cfg := createTestConfig()
+	cfg.Comments = config.CommentsConfig{EnableMRComments: true, UpdateExistingComments: true}
+	cfg.Rules.WarehouseRule = config.WarehouseRuleConfig{HoursPerMonth: 100, CreditPrice: 2, CostCurrency: "USD", CostReviewThreshold: 1000}
+	client := &warehouseCostMockClient{
+		mergeSettingsMockClient: mergeSettingsMockClient{details: &gitlab.MRDetails{SourceBranch: "feature"}},
+		files: map[string]string{
+			"main":    "name: analytics\nwarehouses:\n- type: user\n  size: SMALL\n",
+			"feature": "name: analytics\nwarehouses:\n- type: user\n  size: MEDIUM\n",
+		},
+	}
+	handler := &DataProductConfigMrReviewHandler{config: cfg, gitlabClient: client}
+	mrInfo := &gitlab.MRInfo{ProjectID: 1, MRIID: 2, TargetBranch: "main", SourceBranch: "feature"}
+	changes := []gitlab.FileChange{{NewPath: "dataproducts/source/analytics/prod/product.yaml", Diff: "-  size: SMALL\n+  size: MEDIUM"}}
+
+	// Below the threshold: comment only
+	result := &shared.RuleEvaluation{FinalDecision: shared.Decision{Type: shared.Approve, Reason: "All files approved"}}
+	handler.applyWarehouseCostImpact(ctx, result, mrInfo, changes)
+	assert.Equal(t, shared.Approve, result.FinalDecision.Type)
+	assert.Len(t, client.comments, 1)
+	assert.Contains(t, client.comments[0], "<!-- naysayer-comment-id: warehouse-cost -->")
+	assert.Contains(t, client.comments[0], "| `dataproducts/source/analytics/prod/product.yaml` | user | SMALL → MEDIUM | +200 | +400.00 USD |")
+
+	// Above the threshold: manual review
+	client.files["feature"] = "name: analytics\nwarehouses:\n- type: user\n  size: XLARGE\n"
+	handler.applyWarehouseCostImpact(ctx, result, mrInfo, changes)
+	assert.Equal(t, shared.ManualReview, result.FinalDecision.Type)
+	assert.Equal(t, "Warehouse cost threshold exceeded", result.FinalDecision.Summary)
+	assert.Contains(t, result.FinalDecision.Reason, "2800.00 USD/month exceeds 1000.00 USD/month")
+	assert.Equal(t, "All files approved", result.FinalDecision.Details)
+	assert.Contains(t, client.comments[1], "manual review required")
+
+	// Other files: nothing to estimate
+	handler.applyWarehouseCostImpact(ctx, result, mrInfo, []gitlab.FileChange{{NewPath: "dataproducts/source/analytics/prod/pii_masking.yaml"}})
+	assert.Len(t, client.comments, 2)
+
+	// Disabled
+	cfg.Rules.WarehouseRule.HoursPerMonth = 0
+	handler.applyWarehouseCostImpact(ctx, result, mrInfo, changes)
+	assert.Len(t, client.comments, 2)
+}
+
+// approvalsMockClient returns the users who approved the MR
+type approvalsMockClient struct {
+	MockGitLabClient
+	approvedBy []gitlab.Approver
+	err        error
+}
+
+func (m *approvalsMockClient) GetMRApprovals(ctx context.Context, projectID, mrIID int) (*gitlab.MRApprovals, error) {
+	return &gitlab.MRApprovals{ApprovedBy: m.approvedBy}, m.err
+}
+
+// Test approval policies hold naysayer's approval until enough approvers approved
+func TestApplyApprovalRequirements(t *testing.T) {
+	ctx := context.Background()
+	client := &approvalsMockClient{}
+	handler := &DataProductConfigMrReviewHandler{config: createTestConfig(), gitlabClient: client}
+	mrInfo := &gitlab.MRInfo{ProjectID: 1, MRIID: 2}
+	approvedResult := func() *shared.RuleEvaluation {
+		return &shared.RuleEvaluation{
+			FinalDecision: shared.Decision{Type: shared.Approve, Reason: "Changes requiring review are covered by approval policies: large_warehouse_increase"},
+			ApprovalRequirements: []shared.ApprovalRequirement{
+				{Policy: "large_warehouse_increase", Approvals: 2, Approvers: []string{"@alice", "@bob"}, Reason: "budget owners"},
+			},
+		}
+	}
+
+	// Only one listed approver approved; other approvals do not count
+	client.approvedBy = []gitlab.Approver{{ID: 1, Username: "Alice"}, {ID: 3, Username: "carol"}}
+	result := approvedResult()
+	handler.applyApprovalRequirements(ctx, result, mrInfo)
+	assert.Equal(t, shared.ManualReview, result.FinalDecision.Type)
+	assert.Equal(t, "⏳ Waiting for required approvals", result.FinalDecision.Summary)
+	assert.Equal(t, "Waiting for approvals required by approval policies: 'large_warehouse_increase' has 1/2 approvals from @alice, @bob (budget owners)", result.FinalDecision.Reason)
+	assert.Contains(t, result.FinalDecision.Details, "covered by approval policies")
+
+	// Both approved
+	client.approvedBy = append(client.approvedBy, gitlab.Approver{ID: 2, Username: "bob"})
+	result = approvedResult()
+	handler.applyApprovalRequirements(ctx, result, mrInfo)
+	assert.Equal(t, shared.Approve, result.FinalDecision.Type)
+
+	// Approvals cannot be read
+	client.err = errors.New("403 Forbidden")
+	result = approvedResult()
+	handler.applyApprovalRequirements(ctx, result, mrInfo)
+	assert.Equal(t, shared.ManualReview, result.FinalDecision.Type)
+	assert.Contains(t, result.FinalDecision.Reason, "approvals could not be checked: 403 Forbidden")
+
+	// Manual reviews are left alone
+	result = approvedResult()
+	result.FinalDecision = shared.Decision{Type: shared.ManualReview, Reason: "uncovered"}
+	handler.applyApprovalRequirements(ctx, result, mrInfo)
+	assert.Equal(t, "uncovered", result.FinalDecision.Reason)
+}
+
+// groupApprovalsMockClient also serves group members
+type groupApprovalsMockClient struct {
+	approvalsMockClient
+	groups  map[string][]gitlab.MRUser
+	lookups []string
+}
+
+func (m *groupApprovalsMockClient) ListGroupMembers(ctx context.Context, group string) ([]gitlab.MRUser, error) {
+	m.lookups = append(m.lookups, group)
+	members, ok := m.groups[group]
+	if !ok {
+		return nil, gitlab.ErrNotFound
+	}
+	return members, nil
+}
+
+// Test approvals of group members count for approvers naming a group
+func TestApplyApprovalRequirements_GroupApprovers(t *testing.T) {
+	ctx := context.Background()
+	client := &groupApprovalsMockClient{groups: map[string][]gitlab.MRUser{
+		"data/platform-team": {{ID: 4, Username: "dana"}, {ID: 5, Username: "Erin"}},
+	}}
+	handler := &DataProductConfigMrReviewHandler{config: createTestConfig(), gitlabClient: client}
+	mrInfo := &gitlab.MRInfo{ProjectID: 1, MRIID: 2}
+	result := func() *shared.RuleEvaluation {
+		return &shared.RuleEvaluation{
+			FinalDecision: shared.Decision{Type: shared.Approve},
+			ApprovalRequirements: []shared.ApprovalRequirement{
+				{Policy: "platform", Approvals: 2, Approvers: []string{"@data/platform-team", "@frank"}},
+				{Policy: "platform_lead", Approvals: 1, Approvers: []string{"@data/platform-team"}},
+			},
+		}
+	}
+
+	client.approvedBy = []gitlab.Approver{{ID: 5, Username: "erin"}, {ID: 6, Username: "frank"}}
+	approved := result()
+	handler.applyApprovalRequirements(ctx, approved, mrInfo)
+	assert.Equal(t, shared.Approve, approved.FinalDecision.Type)
+	assert.Equal(t, []string{"data/platform-team", "frank"}, client.lookups, "each approver entry is resolved once")
+
+	// Members of other groups do not count
+	client.approvedBy = []gitlab.Approver{{ID: 6, Username: "frank"}, {ID: 7, Username: "grace"}}
+	pending := result()
+	handler.applyApprovalRequirements(ctx, pending, mrInfo)
+	assert.Equal(t, shared.ManualReview, pending.FinalDecision.Type)
+	assert.Contains(t, pending.FinalDecision.Reason, "'platform' has 1/2 approvals from @data/platform-team, @frank")
+}
+
+// Test naysayer's own approval does not count towards approval policies
+func TestApplyApprovalRequirements_IgnoresBotApproval(t *testing.T) {
+	ctx := context.Background()
+	client := &approvalsMockClient{}
+	handler := &DataProductConfigMrReviewHandler{config: createTestConfig(), gitlabClient: client}
+	bot, _ := client.GetCurrentBotUsername(ctx)
+	client.approvedBy = []gitlab.Approver{{ID: 9, Username: bot}}
+
+	result := &shared.RuleEvaluation{
+		FinalDecision:        shared.Decision{Type: shared.Approve},
+		ApprovalRequirements: []shared.ApprovalRequirement{{Policy: "any_reviewer", Approvals: 1}},
+	}
+	handler.applyApprovalRequirements(ctx, result, &gitlab.MRInfo{ProjectID: 1, MRIID: 2})
+	assert.Equal(t, shared.ManualReview, result.FinalDecision.Type)
+	assert.Contains(t, result.FinalDecision.Reason, "'any_reviewer' has 0/1 approvals")
+}
+
+// ownersMockClient serves an owners file and records reviewer assignments
+type ownersMockClient struct {
+	mergeSettingsMockClient
+	ownersFile string
+	users      map[string]int
+	assigned   [][]int
+}
+
+func (m *ownersMockClient) FetchFileContent(ctx context.Context, projectID int, filePath, ref string) (*gitlab.FileContent, error) {
+	if filePath != "owners.yaml" || ref != "main" {
+		return nil, gitlab.ErrNotFound
+	}
+	return &gitlab.FileContent{Content: m.ownersFile}, nil
+}
+
+func (m *ownersMockClient) GetUserByUsername(ctx context.Context, username string) (*gitlab.MRUser, error) {
+	id, ok := m.users[username]
+	if !ok {
+		return nil, gitlab.ErrNotFound
+	}
+	return &gitlab.MRUser{ID: id, Username: username}, nil
+}
+
+func (m *ownersMockClient) SetMRReviewers(ctx context.Context, projectID, mrIID int, reviewerIDs []int) error {
+	m.assigned = append(m.assigned, reviewerIDs)
+	return nil
+}
+
+// Test manual reviews mention and assign the owners of the files needing review
+func TestHandleManualReview_RoutesToOwners(t *testing.T) {
+	ctx := context.Background()
+	cfg := createTestConfig()
+	cfg.Comments = config.CommentsConfig{EnableMRComments: true, UpdateExistingComments: true}
+	cfg.Owners = config.OwnersConfig{Enabled: true, Files: []string{"OWNERS", "owners.yaml"}, AssignReviewers: true}
+	client := &ownersMockClient{
+		mergeSettingsMockClient: mergeSettingsMockClient{details: &gitlab.MRDetails{
+			Author:    &gitlab.MRUser{ID: 3, Username: "carol"},
+			Reviewers: []gitlab.MRUser{{ID: 9, Username: "dave"}},
+		}},
+		ownersFile: "owners:\n" +
+			"  - path: \"dataproducts/source/analytics/**\"\n    owners: [\"@alice\", \"@carol\", \"@data-platform\"]\n" +
+			"  - path: \"docs/**\"\n    owners: [\"@writers\"]\n",
+		users: map[string]int{"alice": 5, "carol": 3},
+	}
+	handler := &DataProductConfigMrReviewHandler{config: cfg, gitlabClient: client, owners: owners.NewResolverFromConfig(client, cfg.Owners)}
+	mrInfo := &gitlab.MRInfo{ProjectID: 1, MRIID: 2, TargetBranch: "main"}
+
+	result := &shared.RuleEvaluation{
+		FinalDecision: shared.Decision{Type: shared.ManualReview, Reason: "Warehouse changes require manual review"},
+		FileValidations: map[string]*shared.FileValidationSummary{
+			"dataproducts/source/analytics/prod/product.yaml": {FileDecision: shared.ManualReview},
+			"docs/README.md": {FileDecision: shared.Approve},
+		},
+	}
+	assert.NoError(t, handler.handleManualReviewWithComments(ctx, result, mrInfo))
+
+	assert.Len(t, client.comments, 1)
+	assert.Contains(t, client.comments[0], "👀 **Reviewers:** @alice @carol @data-platform")
+	assert.NotContains(t, client.comments[0], "@writers", "approved files are not routed")
+	assert.Equal(t, [][]int{{9, 5}}, client.assigned, "existing reviewers are kept, the author and groups are skipped")
+
+	// Without assignment, owners are only mentioned
+	cfg.Owners.AssignReviewers = false
+	assert.NoError(t, handler.handleManualReviewWithComments(ctx, result, mrInfo))
+	assert.Len(t, client.assigned, 1)
+	assert.Contains(t, client.comments[1], "**Reviewers:**")
+
+	// Disabled
+	handler.owners = nil
+	assert.NoError(t, handler.handleManualReviewWithComments(ctx, result, mrInfo))
+	assert.NotContains(t, client.comments[2], "**Reviewers:**")
+}
+
+// Test reviewers asked for by a deletion policy are mentioned and assigned with the owners
+func TestHandleManualReview_RoutesToPolicyReviewers(t *testing.T) {
+	ctx := context.Background()
+	cfg := createTestConfig()
+	cfg.Comments = config.CommentsConfig{EnableMRComments: true, UpdateExistingComments: true}
+	cfg.Owners = config.OwnersConfig{Enabled: true, Files: []string{"owners.yaml"}, AssignReviewers: true}
+	client := &ownersMockClient{
+		mergeSettingsMockClient: mergeSettingsMockClient{details: &gitlab.MRDetails{Author: &gitlab.MRUser{ID: 3, Username: "carol"}}},
+		ownersFile:              "owners:\n  - path: \"dataproducts/**\"\n    owners: [\"@alice\"]\n",
+		users:                   map[string]int{"alice": 5, "erin": 7},
+	}
+	handler := &DataProductConfigMrReviewHandler{config: cfg, gitlabClient: client, owners: owners.NewResolverFromConfig(client, cfg.Owners)}
+	mrInfo := &gitlab.MRInfo{ProjectID: 1, MRIID: 2, TargetBranch: "main"}
+
+	result := &shared.RuleEvaluation{
+		FinalDecision: shared.Decision{Type: shared.ManualReview, Reason: "Deletion blocked by policy: dataproducts/source/x/prod/product.yaml"},
+		FileValidations: map[string]*shared.FileValidationSummary{
+			"dataproducts/source/x/prod/product.yaml": {FileDecision: shared.ManualReview, Reviewers: []string{"erin"}},
+			"docs/README.md": {FileDecision: shared.Approve, Reviewers: []string{"frank"}},
+		},
+	}
+	assert.NoError(t, handler.handleManualReviewWithComments(ctx, result, mrInfo))
+	assert.Contains(t, client.comments[0], "👀 **Reviewers:** @erin @alice")
+	assert.NotContains(t, client.comments[0], "@frank", "reviewers of approved files are not routed")
+	assert.Equal(t, [][]int{{7, 5}}, client.assigned)
+
+	// Policy reviewers are routed without an owners resolver
+	handler.owners = nil
+	assert.NoError(t, handler.handleManualReviewWithComments(ctx, result, mrInfo))
+	assert.Contains(t, client.comments[1], "👀 **Reviewers:** @erin\n")
+}
@@ -0,0 +1,51 @@
+package webhook
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+)
+
+func TestApplyFlappingPolicy_FreezesAutoApproval(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.Flapping = config.FlappingConfig{Threshold: 2, WindowMinutes: 60, FreezeAutoApproval: true}
+	handler := &DataProductConfigMrReviewHandler{config: cfg}
+	handler.SetStateStore(store.NewMemoryStore())
+	assert.NotNil(t, handler.flapping)
+
+	mrInfo := &gitlab.MRInfo{ProjectID: 456, MRIID: 123}
+	evaluate := func(decision shared.DecisionType) *shared.RuleEvaluation {
+		result := &shared.RuleEvaluation{FinalDecision: shared.Decision{Type: decision, Reason: "rules said so"}}
+		handler.applyFlappingPolicy(result, mrInfo)
+		return result
+	}
+
+	assert.Equal(t, shared.Approve, evaluate(shared.Approve).FinalDecision.Type)
+	assert.Equal(t, shared.ManualReview, evaluate(shared.ManualReview).FinalDecision.Type)
+	assert.Equal(t, shared.Approve, evaluate(shared.Approve).FinalDecision.Type)
+	evaluate(shared.ManualReview) // third flip exceeds the threshold and freezes
+
+	result := evaluate(shared.Approve)
+	assert.Equal(t, shared.ManualReview, result.FinalDecision.Type)
+	assert.Equal(t, "Decision flapping", result.FinalDecision.Summary)
+	assert.Contains(t, result.FinalDecision.Reason, "Auto-approval frozen")
+	assert.Equal(t, "rules said so", result.FinalDecision.Details)
+}
+
+func TestSetStateStore_FlappingDisabled(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.Flapping.Threshold = 0
+	handler := &DataProductConfigMrReviewHandler{config: cfg}
+	handler.SetStateStore(store.NewMemoryStore())
+	assert.Nil(t, handler.flapping)
+
+	// No detector: decisions pass through untouched
+	result := &shared.RuleEvaluation{FinalDecision: shared.Decision{Type: shared.Approve}}
+	handler.applyFlappingPolicy(result, &gitlab.MRInfo{ProjectID: 1, MRIID: 2})
+	assert.Equal(t, shared.Approve, result.FinalDecision.Type)
+}
@@ -0,0 +1,97 @@
+package webhook
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/depgraph"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/repoindex"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+	"go.uber.org/zap"
+)
+
+// Dependency graph output formats
+const (
+	graphFormatJSON = "json"
+	graphFormatDOT  = "dot"
+)
+
+// DependencyGraphHandler exposes the data product dependency graph built from product.yaml consumers
+type DependencyGraphHandler struct {
+	config  *config.Config
+	index   *repoindex.Index
+	builder *depgraph.Builder
+	// refreshIndex takes a fresh snapshot per request when no shared, continuously updated index exists
+	refreshIndex bool
+}
+
+// NewDependencyGraphHandler creates a dependency graph handler using the shared repository index when enabled
+func NewDependencyGraphHandler(cfg *config.Config) *DependencyGraphHandler {
+	client := gitlab.NewClientWithConfig(cfg)
+	if index := repoindex.ForInstance(cfg.GitLab.Instance); index != nil {
+		return NewDependencyGraphHandlerWithIndex(cfg, index, client, false)
+	}
+	return NewDependencyGraphHandlerWithIndex(cfg, repoindex.NewIndex(client, store.NewMemoryStore()), client, true)
+}
+
+// NewDependencyGraphHandlerWithIndex creates a dependency graph handler with a custom index and file fetcher
+// This is primarily used for testing
+func NewDependencyGraphHandlerWithIndex(cfg *config.Config, index *repoindex.Index, fetcher depgraph.FileFetcher, refreshIndex bool) *DependencyGraphHandler {
+	return &DependencyGraphHandler{
+		config:       cfg,
+		index:        index,
+		builder:      depgraph.NewBuilder(index, fetcher),
+		refreshIndex: refreshIndex,
+	}
+}
+
+// HandleDependencyGraph returns the dependency graph of a branch as JSON or Graphviz DOT,
+// optionally limited to the consumer edges of one environment
+func (h *DependencyGraphHandler) HandleDependencyGraph(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	projectID, err := strconv.Atoi(c.Query("project_id"))
+	if err != nil || projectID <= 0 {
+		return c.Status(400).JSON(fiber.Map{
+			"error": "project_id query parameter is required",
+		})
+	}
+	ref := c.Query("ref", "main")
+	format := c.Query("format", graphFormatJSON)
+	if format != graphFormatJSON && format != graphFormatDOT {
+		return c.Status(400).JSON(fiber.Map{
+			"error": "format must be json or dot",
+		})
+	}
+
+	if h.refreshIndex {
+		if _, err := h.index.Refresh(ctx, projectID, ref); err != nil {
+			logging.Error("Dependency graph index refresh failed",
+				zap.Int("project_id", projectID),
+				zap.String("ref", ref),
+				zap.Error(err))
+			return c.Status(502).JSON(fiber.Map{
+				"error": "failed to list repository: " + err.Error(),
+			})
+		}
+	}
+
+	graph, err := h.builder.Build(ctx, projectID, ref)
+	if err != nil {
+		logging.Error("Dependency graph build failed", zap.Int("project_id", projectID), zap.String("ref", ref), zap.Error(err))
+		return c.Status(502).JSON(fiber.Map{
+			"error": "failed to build dependency graph: " + err.Error(),
+		})
+	}
+	if env := c.Query("environment"); env != "" {
+		graph = graph.Environment(env)
+	}
+
+	if format == graphFormatDOT {
+		c.Set("Content-Type", "text/vnd.graphviz; charset=utf-8")
+		return c.SendString(graph.DOT())
+	}
+	return c.JSON(graph)
+}
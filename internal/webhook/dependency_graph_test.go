@@ -0,0 +1,74 @@
+package webhook
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/repoindex"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+)
+
+func newDependencyGraphTestHandler() *DependencyGraphHandler {
+	repo := &mockAccessReviewRepository{files: map[string]string{
+		"dataproducts/source/orders/prod/product.yaml": `name: orders
+data_product_db:
+  - presentation_schemas:
+      - name: marts
+        consumers:
+          - name: analytics
+            kind: data_product
+`,
+		"dataproducts/source/orders/dev/product.yaml": `name: orders
+data_product_db:
+  - presentation_schemas:
+      - name: marts
+        consumers:
+          - name: sandbox-tools
+            kind: data_product
+`,
+	}}
+	return NewDependencyGraphHandlerWithIndex(&config.Config{}, repoindex.NewIndex(repo, store.NewMemoryStore()), repo, true)
+}
+
+func TestDependencyGraphHandler_JSONAndDOT(t *testing.T) {
+	app := createTestApp()
+	app.Get("/api/v1/dependency-graph", newDependencyGraphTestHandler().HandleDependencyGraph)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/dependency-graph?project_id=1", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	var graph struct {
+		Nodes []string `json:"nodes"`
+		Edges []struct {
+			Consumer string `json:"consumer"`
+			Producer string `json:"producer"`
+		} `json:"edges"`
+	}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&graph))
+	assert.Equal(t, []string{"analytics", "orders", "sandbox-tools"}, graph.Nodes)
+	assert.Len(t, graph.Edges, 2)
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/api/v1/dependency-graph?project_id=1&format=dot&environment=prod", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Contains(t, resp.Header.Get("Content-Type"), "text/vnd.graphviz")
+	body, _ := io.ReadAll(resp.Body)
+	assert.Contains(t, string(body), `"analytics" -> "orders" [label="prod"];`)
+	assert.NotContains(t, string(body), `"sandbox-tools" -> "orders"`)
+}
+
+func TestDependencyGraphHandler_InvalidRequest(t *testing.T) {
+	app := createTestApp()
+	app.Get("/api/v1/dependency-graph", newDependencyGraphTestHandler().HandleDependencyGraph)
+
+	for _, query := range []string{"", "?project_id=abc", "?project_id=1&format=svg"} {
+		resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/dependency-graph"+query, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 400, resp.StatusCode, query)
+	}
+}
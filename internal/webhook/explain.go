@@ -0,0 +1,226 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/redhat-data-and-ai/naysayer/internal/digest"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+	"go.uber.org/zap"
+)
+
+// explanationPrefix namespaces the latest evaluation of every open MR in the state store
+const explanationPrefix = "explanations/"
+
+// Explanation is the most recent rule evaluation of an MR, for authors diagnosing a decision
+type Explanation struct {
+	ProjectID            int                          `json:"project_id"`
+	MRIID                int                          `json:"mr_iid"`
+	Title                string                       `json:"title,omitempty"`
+	Author               string                       `json:"author,omitempty"`
+	EvaluatedAt          time.Time                    `json:"evaluated_at"`
+	DecisionID           string                       `json:"decision_id,omitempty"`
+	Decision             shared.Decision              `json:"decision"`
+	Files                []FileExplanation            `json:"files"`
+	UncoveredFiles       []string                     `json:"uncovered_files"` // Files with changed lines no rule covers
+	TotalFiles           int                          `json:"total_files"`
+	ApprovedFiles        int                          `json:"approved_files"`
+	ReviewFiles          int                          `json:"review_files"`
+	ApprovalRequirements []shared.ApprovalRequirement `json:"approval_requirements,omitempty"`
+	ExecutionTime        string                       `json:"execution_time"`
+}
+
+// FileExplanation lists the rules that covered a file and what they decided
+type FileExplanation struct {
+	Path           string              `json:"path"`
+	Decision       shared.DecisionType `json:"decision"`
+	CoveredLines   []shared.LineRange  `json:"covered_lines"`
+	UncoveredLines []shared.LineRange  `json:"uncovered_lines"`
+	Rules          []RuleExplanation   `json:"rules"`
+}
+
+// RuleExplanation is the decision of one rule for the lines it covered in a file
+type RuleExplanation struct {
+	Rule      string              `json:"rule"`
+	Decision  shared.DecisionType `json:"decision"`
+	Reason    string              `json:"reason"`
+	Lines     []shared.LineRange  `json:"lines"`
+	Evaluated bool                `json:"evaluated"` // False when the rule was skipped
+}
+
+// NewExplanation summarizes a rule evaluation of an MR
+func NewExplanation(result *shared.RuleEvaluation, mrInfo *gitlab.MRInfo, evaluatedAt time.Time) *Explanation {
+	explanation := &Explanation{
+		ProjectID:            mrInfo.ProjectID,
+		MRIID:                mrInfo.MRIID,
+		Title:                mrInfo.Title,
+		Author:               mrInfo.Author,
+		EvaluatedAt:          evaluatedAt,
+		DecisionID:           result.DecisionID,
+		Decision:             result.FinalDecision,
+		Files:                []FileExplanation{},
+		UncoveredFiles:       []string{},
+		TotalFiles:           result.TotalFiles,
+		ApprovedFiles:        result.ApprovedFiles,
+		ReviewFiles:          result.ReviewFiles,
+		ApprovalRequirements: result.ApprovalRequirements,
+		ExecutionTime:        result.ExecutionTime.String(),
+	}
+
+	paths := make([]string, 0, len(result.FileValidations))
+	for path := range result.FileValidations {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		summary := result.FileValidations[path]
+		if summary == nil {
+			continue
+		}
+		file := FileExplanation{
+			Path:           path,
+			Decision:       summary.FileDecision,
+			CoveredLines:   summary.CoveredLines,
+			UncoveredLines: summary.UncoveredLines,
+			Rules:          make([]RuleExplanation, 0, len(summary.RuleResults)),
+		}
+		for _, ruleResult := range summary.RuleResults {
+			file.Rules = append(file.Rules, RuleExplanation{
+				Rule:      ruleResult.RuleName,
+				Decision:  ruleResult.Decision,
+				Reason:    ruleResult.Reason,
+				Lines:     ruleResult.LineRanges,
+				Evaluated: ruleResult.WasEvaluated,
+			})
+		}
+		explanation.Files = append(explanation.Files, file)
+		if len(summary.UncoveredLines) > 0 {
+			explanation.UncoveredFiles = append(explanation.UncoveredFiles, path)
+		}
+	}
+	return explanation
+}
+
+func explanationKey(projectID, mrIID int) string {
+	return fmt.Sprintf("%s%d/%d", explanationPrefix, projectID, mrIID)
+}
+
+// saveExplanation keeps the evaluation as the latest explanation of the MR
+func (h *DataProductConfigMrReviewHandler) saveExplanation(result *shared.RuleEvaluation, mrInfo *gitlab.MRInfo) {
+	if h.explanations == nil {
+		return
+	}
+	data, err := json.Marshal(NewExplanation(result, mrInfo, time.Now().UTC()))
+	if err == nil {
+		err = h.explanations.Put(explanationKey(mrInfo.ProjectID, mrInfo.MRIID), data)
+	}
+	if err != nil {
+		logging.MRWarn(mrInfo.MRIID, "Failed to save decision explanation", zap.Error(err))
+	}
+}
+
+// previousExplanation returns the latest saved evaluation of the MR, nil when there is none
+func (h *DataProductConfigMrReviewHandler) previousExplanation(mrInfo *gitlab.MRInfo) *Explanation {
+	if h.explanations == nil {
+		return nil
+	}
+	data, found, err := h.explanations.Get(explanationKey(mrInfo.ProjectID, mrInfo.MRIID))
+	if err != nil || !found {
+		return nil
+	}
+	var previous Explanation
+	if err := json.Unmarshal(data, &previous); err != nil {
+		return nil
+	}
+	return &previous
+}
+
+// PendingManualReviews lists the open MRs whose latest explanation requires manual review,
+// for the email digest
+func PendingManualReviews(st store.Store) digest.Source {
+	return func() ([]digest.PendingReview, error) {
+		keys, err := st.Keys(explanationPrefix)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list decision explanations: %w", err)
+		}
+		var reviews []digest.PendingReview
+		for _, key := range keys {
+			data, found, err := st.Get(key)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load decision explanation %s: %w", key, err)
+			}
+			var explanation Explanation
+			if !found || json.Unmarshal(data, &explanation) != nil || explanation.Decision.Type != shared.ManualReview {
+				continue
+			}
+			review := digest.PendingReview{
+				ProjectID:   explanation.ProjectID,
+				MRIID:       explanation.MRIID,
+				Title:       explanation.Title,
+				Author:      explanation.Author,
+				Reason:      explanation.Decision.Reason,
+				EvaluatedAt: explanation.EvaluatedAt,
+			}
+			for _, file := range explanation.Files {
+				review.Files = append(review.Files, file.Path)
+			}
+			reviews = append(reviews, review)
+		}
+		return reviews, nil
+	}
+}
+
+// clearExplanation drops the explanation of a merged or closed MR
+func (h *DataProductConfigMrReviewHandler) clearExplanation(mrInfo *gitlab.MRInfo) {
+	if h.explanations == nil {
+		return
+	}
+	if err := h.explanations.Delete(explanationKey(mrInfo.ProjectID, mrInfo.MRIID)); err != nil {
+		logging.MRWarn(mrInfo.MRIID, "Failed to clear decision explanation", zap.Error(err))
+	}
+}
+
+// ExplainHandler serves the latest rule evaluation of open MRs
+type ExplainHandler struct {
+	store store.Store
+}
+
+// NewExplainHandler creates an explain handler reading explanations from the state store
+func NewExplainHandler(st store.Store) *ExplainHandler {
+	return &ExplainHandler{store: st}
+}
+
+// HandleExplain returns the most recent rule evaluation of /decisions/:project_id/:mr_iid
+func (h *ExplainHandler) HandleExplain(c *fiber.Ctx) error {
+	projectID, projectErr := strconv.Atoi(c.Params("project_id"))
+	mrIID, mrErr := strconv.Atoi(c.Params("mr_iid"))
+	if projectErr != nil || mrErr != nil || projectID <= 0 || mrIID <= 0 {
+		return c.Status(400).JSON(fiber.Map{"error": "project_id and mr_iid must be positive integers"})
+	}
+
+	data, found, err := h.store.Get(explanationKey(projectID, mrIID))
+	if err != nil {
+		logging.Error("Failed to load decision explanation", zap.Error(err))
+		return c.Status(500).JSON(fiber.Map{"error": "failed to load decision explanation"})
+	}
+	if !found {
+		return c.Status(404).JSON(fiber.Map{
+			"error": fmt.Sprintf("no evaluation recorded for MR !%d of project %d since naysayer started", mrIID, projectID),
+		})
+	}
+
+	var explanation Explanation
+	if err := json.Unmarshal(data, &explanation); err != nil {
+		logging.Error("Failed to decode decision explanation", zap.Error(err))
+		return c.Status(500).JSON(fiber.Map{"error": "failed to load decision explanation"})
+	}
+	return c.JSON(explanation)
+}
@@ -0,0 +1,116 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+)
+
+func TestNewExplanation(t *testing.T) {
+	result := &shared.RuleEvaluation{
+		FinalDecision: shared.Decision{Type: shared.ManualReview, Reason: "Uncovered changes"},
+		FileValidations: map[string]*shared.FileValidationSummary{
+			"product.yaml": {
+				FileDecision: shared.Approve,
+				CoveredLines: []shared.LineRange{{StartLine: 1, EndLine: 10, FilePath: "product.yaml"}},
+				RuleResults: []shared.LineValidationResult{{
+					RuleName: "warehouse_rule", Decision: shared.Approve, Reason: "Warehouse decrease", WasEvaluated: true,
+					LineRanges: []shared.LineRange{{StartLine: 3, EndLine: 6, FilePath: "product.yaml"}},
+				}},
+			},
+			"pipeline.sh": {
+				FileDecision:   shared.ManualReview,
+				UncoveredLines: []shared.LineRange{{StartLine: 1, EndLine: 4, FilePath: "pipeline.sh"}},
+			},
+		},
+		TotalFiles: 2, ApprovedFiles: 1, ReviewFiles: 1,
+		ExecutionTime: 3 * time.Millisecond,
+		DecisionID:    "abc",
+	}
+	evaluatedAt := time.Date(2024, 5, 6, 10, 0, 0, 0, time.UTC)
+
+	explanation := NewExplanation(result, &gitlab.MRInfo{ProjectID: 1, MRIID: 2}, evaluatedAt)
+	assert.Equal(t, "abc", explanation.DecisionID)
+	assert.Equal(t, evaluatedAt, explanation.EvaluatedAt)
+	assert.Equal(t, []string{"pipeline.sh"}, explanation.UncoveredFiles)
+	assert.Len(t, explanation.Files, 2)
+	assert.Equal(t, "pipeline.sh", explanation.Files[0].Path, "files are sorted")
+	assert.Empty(t, explanation.Files[0].Rules)
+	assert.Equal(t, "warehouse_rule", explanation.Files[1].Rules[0].Rule)
+	assert.Equal(t, 3, explanation.Files[1].Rules[0].Lines[0].StartLine)
+	assert.Equal(t, "3ms", explanation.ExecutionTime)
+}
+
+func TestExplainHandler(t *testing.T) {
+	st := store.NewMemoryStore()
+	handler := &DataProductConfigMrReviewHandler{gitlabClient: &MockGitLabClient{}, config: createTestConfig()}
+	handler.SetStateStore(st)
+
+	mrInfo := &gitlab.MRInfo{ProjectID: 7, MRIID: 3}
+	_, err := handler.applyDecision(context.Background(), &shared.RuleEvaluation{
+		FinalDecision:   shared.Decision{Type: shared.Approve, Reason: "All rules passed"},
+		FileValidations: map[string]*shared.FileValidationSummary{},
+	}, mrInfo)
+	assert.NoError(t, err)
+
+	app := createTestApp()
+	app.Get("/decisions/:project_id/:mr_iid", NewExplainHandler(st).HandleExplain)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/decisions/7/3", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	var explanation Explanation
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&explanation))
+	assert.Equal(t, shared.Approve, explanation.Decision.Type)
+	assert.Equal(t, "All rules passed", explanation.Decision.Reason)
+	assert.Equal(t, 7, explanation.ProjectID)
+
+	for path, status := range map[string]int{
+		"/decisions/7/4":  404,
+		"/decisions/x/3":  400,
+		"/decisions/7/-3": 400,
+	} {
+		resp, err := app.Test(httptest.NewRequest("GET", path, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, status, resp.StatusCode, path)
+	}
+
+	// Merged and closed MRs drop their explanation
+	handler.clearExplanation(mrInfo)
+	resp, err = app.Test(httptest.NewRequest("GET", "/decisions/7/3", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 404, resp.StatusCode)
+}
+
+func TestPendingManualReviews(t *testing.T) {
+	st := store.NewMemoryStore()
+	handler := &DataProductConfigMrReviewHandler{gitlabClient: &MockGitLabClient{}, config: createTestConfig()}
+	handler.SetStateStore(st)
+
+	for iid, decision := range map[int]shared.DecisionType{1: shared.ManualReview, 2: shared.Approve} {
+		_, err := handler.applyDecision(context.Background(), &shared.RuleEvaluation{
+			FinalDecision: shared.Decision{Type: decision, Reason: "warehouse size increased"},
+			FileValidations: map[string]*shared.FileValidationSummary{
+				"dataproducts/source/analytics/prod/product.yaml": {FileDecision: decision},
+			},
+		}, &gitlab.MRInfo{ProjectID: 7, MRIID: iid, Title: "Grow warehouse", Author: "alice"})
+		assert.NoError(t, err)
+	}
+
+	reviews, err := PendingManualReviews(st)()
+	assert.NoError(t, err)
+	assert.Len(t, reviews, 1, "approved MRs are not pending")
+	assert.Equal(t, 1, reviews[0].MRIID)
+	assert.Equal(t, "Grow warehouse", reviews[0].Title)
+	assert.Equal(t, "alice", reviews[0].Author)
+	assert.Equal(t, "warehouse size increased", reviews[0].Reason)
+	assert.Equal(t, []string{"dataproducts/source/analytics/prod/product.yaml"}, reviews[0].Files)
+}
@@ -0,0 +1,45 @@
+package webhook
+
+import (
+	"strings"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"go.uber.org/zap"
+)
+
+// invalidatePushedFiles drops cached file contents of the branch a push event updated
+func invalidatePushedFiles(payload map[string]interface{}) {
+	projectID, _ := payload["project_id"].(float64)
+	ref, _ := payload["ref"].(string)
+	if projectID == 0 || !strings.HasPrefix(ref, "refs/heads/") {
+		return
+	}
+	invalidateFileCache(int(projectID), strings.TrimPrefix(ref, "refs/heads/"))
+}
+
+// invalidateMRSourceFiles drops cached file contents of the source branch when a merge
+// request event reports new commits (GitLab sets oldrev only for pushes to the source branch)
+func invalidateMRSourceFiles(payload map[string]interface{}) {
+	attrs, _ := payload["object_attributes"].(map[string]interface{})
+	oldrev, _ := attrs["oldrev"].(string)
+	sourceProjectID, _ := attrs["source_project_id"].(float64)
+	sourceBranch, _ := attrs["source_branch"].(string)
+	if oldrev == "" || sourceProjectID == 0 || sourceBranch == "" {
+		return
+	}
+	invalidateFileCache(int(sourceProjectID), sourceBranch)
+}
+
+func invalidateFileCache(projectID int, branch string) {
+	cache := gitlab.DefaultFileCache()
+	if cache == nil {
+		return
+	}
+	if dropped := cache.Invalidate(projectID, branch); dropped > 0 {
+		logging.Info("Dropped cached files after a push",
+			zap.Int("files", dropped),
+			zap.Int("project_id", projectID),
+			zap.String("branch", branch))
+	}
+}
@@ -0,0 +1,67 @@
+package webhook
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/stats"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+)
+
+func TestInvalidatePushedFiles(t *testing.T) {
+	cache := gitlab.NewFileCache(10, time.Hour)
+	gitlab.SetDefaultFileCache(cache)
+	defer gitlab.SetDefaultFileCache(nil)
+
+	cache.Put(1, "main", "a.yaml", &gitlab.FileContent{})
+	cache.Put(1, "v1.0", "a.yaml", &gitlab.FileContent{})
+
+	invalidatePushedFiles(map[string]interface{}{"project_id": float64(1), "ref": "refs/tags/v1.0"})
+	assert.Equal(t, 2, cache.Len())
+
+	invalidatePushedFiles(map[string]interface{}{"project_id": float64(1), "ref": "refs/heads/main"})
+	assert.Equal(t, 1, cache.Len())
+}
+
+func TestInvalidateMRSourceFiles(t *testing.T) {
+	cache := gitlab.NewFileCache(10, time.Hour)
+	gitlab.SetDefaultFileCache(cache)
+	defer gitlab.SetDefaultFileCache(nil)
+
+	cache.Put(9, "feature", "a.yaml", &gitlab.FileContent{})
+	attrs := map[string]interface{}{"source_project_id": float64(9), "source_branch": "feature"}
+
+	// Title or label updates carry no oldrev
+	invalidateMRSourceFiles(map[string]interface{}{"object_attributes": attrs})
+	assert.Equal(t, 1, cache.Len())
+
+	attrs["oldrev"] = "abc123"
+	invalidateMRSourceFiles(map[string]interface{}{"object_attributes": attrs})
+	assert.Equal(t, 0, cache.Len())
+}
+
+func TestMetricsHandler_ReportsFileCache(t *testing.T) {
+	cache := gitlab.NewFileCache(10, time.Hour)
+	gitlab.SetDefaultFileCache(cache)
+	defer gitlab.SetDefaultFileCache(nil)
+
+	cache.Put(1, "main", "a.yaml", &gitlab.FileContent{})
+	_, _ = cache.Get(1, "main", "a.yaml")
+	_, _ = cache.Get(1, "main", "b.yaml")
+
+	app := createTestApp()
+	app.Get("/metrics", NewMetricsHandler(stats.NewRecorder(store.NewMemoryStore()), config.SLOConfig{WindowMinutes: 60}).HandleMetrics)
+	resp, err := app.Test(httptest.NewRequest("GET", "/metrics", nil))
+	assert.NoError(t, err)
+
+	body, _ := io.ReadAll(resp.Body)
+	assert.Contains(t, string(body), `naysayer_gitlab_file_cache_requests_total{result="hit"} 1`)
+	assert.Contains(t, string(body), `naysayer_gitlab_file_cache_requests_total{result="miss"} 1`)
+	assert.Contains(t, string(body), "naysayer_gitlab_file_cache_entries 1")
+}
@@ -0,0 +1,130 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+
+	fiber "github.com/gofiber/fiber/v2"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/scm"
+	"github.com/redhat-data-and-ai/naysayer/internal/scm/github"
+	"go.uber.org/zap"
+)
+
+// GitHubHandler serves GitHub webhook deliveries. Pull request and push events are
+// translated into GitLab payloads and handled by the review and auto-rebase handlers,
+// backed by the GitHub API client.
+type GitHubHandler struct {
+	review *DataProductConfigMrReviewHandler
+	rebase *AutoRebaseHandler
+	secret string
+}
+
+// NewGitHubHandler creates a GitHub webhook handler using the GitHub API client
+func NewGitHubHandler(cfg *config.Config) (*GitHubHandler, error) {
+	client, err := scm.NewClient(cfg, scm.ProviderGitHub)
+	if err != nil {
+		return nil, err
+	}
+	return NewGitHubHandlerWithClient(cfg, client), nil
+}
+
+// NewGitHubHandlerWithClient creates a GitHub webhook handler with a custom client
+// This is primarily used for testing with mock clients
+func NewGitHubHandlerWithClient(cfg *config.Config, client gitlab.GitLabClient) *GitHubHandler {
+	return &GitHubHandler{
+		review: NewDataProductConfigMrReviewHandlerWithClient(cfg, client),
+		rebase: NewAutoRebaseHandlerWithClient(cfg, client),
+		secret: cfg.GitHub.WebhookSecret,
+	}
+}
+
+// ReviewHandler returns the pull request review handler, e.g. to attach the state store
+func (h *GitHubHandler) ReviewHandler() *DataProductConfigMrReviewHandler {
+	return h.review
+}
+
+// RebaseHandler returns the auto-rebase handler, e.g. to attach the state store
+func (h *GitHubHandler) RebaseHandler() *AutoRebaseHandler {
+	return h.rebase
+}
+
+// HandleReview reviews pull requests like GitLab merge requests
+func (h *GitHubHandler) HandleReview(c *fiber.Ctx) error {
+	event, done, err := h.parse(c, github.EventPullRequest)
+	if done {
+		return err
+	}
+
+	action, _ := event["action"].(string)
+	if !github.ReviewActions[action] {
+		return c.JSON(fiber.Map{
+			"webhook_response": "processed",
+			"event_type":       github.EventPullRequest,
+			"decision":         "skipped",
+			"reason":           fmt.Sprintf("Pull request action '%s' does not change the review", action),
+			"mr_approved":      false,
+		})
+	}
+
+	payload, err := github.MergeRequestPayload(event)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid webhook payload: " + err.Error(),
+		})
+	}
+	return h.review.handleMergeRequestEvent(c, payload)
+}
+
+// HandleAutoRebase updates open pull requests after pushes to main/master
+func (h *GitHubHandler) HandleAutoRebase(c *fiber.Ctx) error {
+	event, done, err := h.parse(c, github.EventPush)
+	if done {
+		return err
+	}
+
+	payload, err := github.PushPayload(event)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid webhook payload: " + err.Error(),
+		})
+	}
+	return h.rebase.handlePushEvent(c, payload)
+}
+
+// parse verifies and decodes a delivery of eventType. When done is true the response
+// has been written (pings, rejected deliveries) and err is the handler result.
+func (h *GitHubHandler) parse(c *fiber.Ctx, eventType string) (event map[string]interface{}, done bool, err error) {
+	if !c.Is("json") {
+		return nil, true, c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Content-Type must be application/json",
+		})
+	}
+
+	if h.secret != "" && !github.VerifySignature(h.secret, c.Body(), c.Get("X-Hub-Signature-256")) {
+		logging.Warn("Rejected GitHub delivery", zap.String("path", c.Path()))
+		return nil, true, c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "Invalid webhook signature",
+		})
+	}
+
+	switch delivered := c.Get("X-GitHub-Event"); delivered {
+	case github.EventPing:
+		return nil, true, c.JSON(fiber.Map{"webhook_response": "pong"})
+	case eventType:
+	default:
+		return nil, true, c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": fmt.Sprintf("Unsupported event type: %s. Only %s events are supported.", delivered, eventType),
+		})
+	}
+
+	if err := json.Unmarshal(c.Body(), &event); err != nil {
+		return nil, true, c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid JSON payload: " + err.Error(),
+		})
+	}
+	return event, false, nil
+}
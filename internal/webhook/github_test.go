@@ -0,0 +1,129 @@
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// sendGitHubDelivery posts a GitHub delivery, signed when secret is set, and returns the status and JSON body
+func sendGitHubDelivery(t *testing.T, handler *GitHubHandler, route, event, secret string, payload map[string]interface{}) (int, map[string]interface{}) {
+	app := createTestApp()
+	app.Post("/github/review", handler.HandleReview)
+	app.Post("/github/rebase", handler.HandleAutoRebase)
+
+	body, _ := json.Marshal(payload)
+	req := httptest.NewRequest("POST", route, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-GitHub-Event", event)
+	if secret != "" {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(body)
+		req.Header.Set("X-Hub-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	respBody, _ := io.ReadAll(resp.Body)
+	var response map[string]interface{}
+	_ = json.Unmarshal(respBody, &response)
+	return resp.StatusCode, response
+}
+
+func githubPullRequestEvent(action string) map[string]interface{} {
+	return map[string]interface{}{
+		"action": action,
+		"pull_request": map[string]interface{}{
+			"number": 123,
+			"title":  "Update warehouse configuration",
+			"state":  "open",
+			"head":   map[string]interface{}{"ref": "feature/update", "sha": "abc123"},
+			"base":   map[string]interface{}{"ref": "main"},
+			"user":   map[string]interface{}{"login": "testuser"},
+		},
+		"repository": map[string]interface{}{"id": 456},
+	}
+}
+
+func TestGitHubHandler_Ping(t *testing.T) {
+	setupTestRulesFile(t)
+	handler := NewGitHubHandlerWithClient(createTestConfig(), &MockGitLabClient{})
+
+	status, response := sendGitHubDelivery(t, handler, "/github/review", "ping", "", map[string]interface{}{"zen": "Keep it simple."})
+	assert.Equal(t, 200, status)
+	assert.Equal(t, "pong", response["webhook_response"])
+}
+
+func TestGitHubHandler_InvalidSignature(t *testing.T) {
+	setupTestRulesFile(t)
+	cfg := createTestConfig()
+	cfg.GitHub.WebhookSecret = "secret"
+	handler := NewGitHubHandlerWithClient(cfg, &MockGitLabClient{})
+
+	status, response := sendGitHubDelivery(t, handler, "/github/review", "pull_request", "wrong", githubPullRequestEvent("opened"))
+	assert.Equal(t, 401, status)
+	assert.Equal(t, "Invalid webhook signature", response["error"])
+
+	status, _ = sendGitHubDelivery(t, handler, "/github/review", "ping", "secret", map[string]interface{}{})
+	assert.Equal(t, 200, status)
+}
+
+func TestGitHubHandler_UnsupportedEvent(t *testing.T) {
+	setupTestRulesFile(t)
+	handler := NewGitHubHandlerWithClient(createTestConfig(), &MockGitLabClient{})
+
+	status, response := sendGitHubDelivery(t, handler, "/github/review", "issues", "", map[string]interface{}{})
+	assert.Equal(t, 400, status)
+	assert.Contains(t, response["error"], "Unsupported event type: issues")
+
+	status, _ = sendGitHubDelivery(t, handler, "/github/rebase", "pull_request", "", githubPullRequestEvent("opened"))
+	assert.Equal(t, 400, status)
+}
+
+func TestGitHubHandler_HandleReview_SkippedAction(t *testing.T) {
+	setupTestRulesFile(t)
+	handler := NewGitHubHandlerWithClient(createTestConfig(), &MockGitLabClient{})
+
+	status, response := sendGitHubDelivery(t, handler, "/github/review", "pull_request", "", githubPullRequestEvent("labeled"))
+	assert.Equal(t, 200, status)
+	assert.Equal(t, "skipped", response["decision"])
+	assert.Contains(t, response["reason"], "labeled")
+}
+
+func TestGitHubHandler_HandleReview_PullRequest(t *testing.T) {
+	setupTestRulesFile(t)
+	cfg := createTestConfig()
+	cfg.GitHub.WebhookSecret = "secret"
+	handler := NewGitHubHandlerWithClient(cfg, &MockGitLabClient{err: errors.New("API unavailable")})
+
+	status, response := sendGitHubDelivery(t, handler, "/github/review", "pull_request", "secret", githubPullRequestEvent("synchronize"))
+	assert.Equal(t, 200, status)
+	assert.Equal(t, "processed", response["webhook_response"])
+
+	decision := response["decision"].(map[string]interface{})
+	assert.Equal(t, "manual_review", decision["type"])
+	assert.Contains(t, decision["reason"], "Could not fetch MR changes")
+}
+
+func TestGitHubHandler_HandleAutoRebase_PushToNonMainBranch(t *testing.T) {
+	setupTestRulesFile(t)
+	mockClient := &MockRebaseGitLabClient{openMRs: []int{123}}
+	handler := NewGitHubHandlerWithClient(createTestConfig(), mockClient)
+
+	status, response := sendGitHubDelivery(t, handler, "/github/rebase", "push", "", map[string]interface{}{
+		"ref":        "refs/heads/feature-branch",
+		"after":      "abc123",
+		"repository": map[string]interface{}{"id": 456},
+	})
+	assert.Equal(t, 200, status)
+	assert.Equal(t, "skipped", response["status"])
+	assert.Len(t, mockClient.capturedRebaseMRs, 0)
+}
@@ -1,16 +1,56 @@
 package webhook
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
 )
 
+// Token states reported by /readyz
+const (
+	TokenValid    = "valid"
+	TokenExpiring = "expiring" // Valid, but expires within GITLAB_TOKEN_EXPIRY_WARNING_DAYS
+	TokenExpired  = "expired"
+	TokenRevoked  = "revoked"
+	TokenInvalid  = "invalid" // GitLab rejects the token
+	TokenUnknown  = "unknown" // GitLab could not be asked, e.g. during an outage
+)
+
+// tokenCheckTTL is how long /readyz reuses token checks, so frequent probes do not load GitLab
+const tokenCheckTTL = 30 * time.Second
+
+// tokenCheckTimeout bounds the token checks of one /readyz request
+const tokenCheckTimeout = 5 * time.Second
+
+// TokenCheck is the state of one GitLab token reported by /readyz
+type TokenCheck struct {
+	Name      string `json:"name"` // Function or instance using the token
+	Status    string `json:"status"`
+	ExpiresAt string `json:"expires_at,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// tokenProbe checks the token of a naysayer function or additional GitLab instance
+type tokenProbe struct {
+	name   string
+	client *gitlab.Client
+}
+
 // HealthHandler handles health check requests
 type HealthHandler struct {
 	config    *config.Config
 	startTime time.Time
+
+	probes    []tokenProbe
+	tokenMu   sync.Mutex
+	checks    []TokenCheck
+	checkedAt time.Time
 }
 
 // NewHealthHandler creates a new health handler
@@ -18,7 +58,35 @@ func NewHealthHandler(cfg *config.Config) *HealthHandler {
 	return &HealthHandler{
 		config:    cfg,
 		startTime: time.Now(),
+		probes:    newTokenProbes(cfg),
+	}
+}
+
+// newTokenProbes returns a probe per distinct token: the tokens of the naysayer functions
+// and of the additional GitLab instances
+func newTokenProbes(cfg *config.Config) []tokenProbe {
+	var probes []tokenProbe
+	seen := make(map[string]bool)
+	add := func(name string, gitlabConfig config.GitLabConfig) {
+		key := gitlabConfig.BaseURL + "\x00" + gitlabConfig.Token + "\x00" + gitlabConfig.TokenFile + "\x00" + gitlabConfig.TokenSource + "\x00" + gitlabConfig.OAuth.ClientID
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		probes = append(probes, tokenProbe{name: name, client: gitlab.NewClient(gitlabConfig)})
+	}
+
+	if cfg.HasGitLabToken() {
+		for _, function := range []string{config.EndpointReview, config.EndpointAutoRebase, config.EndpointStaleMRCleanup} {
+			add(function, cfg.GitLabFor(function))
+		}
+	}
+	for _, instance := range cfg.GitLab.Instances {
+		if instanceCfg, ok := cfg.ForGitLabInstance(instance.Name); ok && instanceCfg.HasGitLabToken() {
+			add("instance/"+instance.Name, instanceCfg.GitLab)
+		}
 	}
+	return probes
 }
 
 // HandleHealth returns comprehensive health status
@@ -60,3 +128,87 @@ func (h *HealthHandler) HandleReady(c *fiber.Ctx) error {
 
 	return c.JSON(ready)
 }
+
+// HandleReadyz returns readiness including the state of every GitLab token: the service is
+// not ready while GitLab rejects a token or a token has expired or been revoked, so a
+// rotation that went wrong is noticed before webhooks fail. Tokens expiring soon and
+// tokens that could not be checked are reported without failing readiness.
+func (h *HealthHandler) HandleReadyz(c *fiber.Ctx) error {
+	ready := fiber.Map{
+		"ready":     true,
+		"service":   "naysayer-webhook",
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+	}
+
+	if !h.config.HasGitLabToken() {
+		ready["ready"] = false
+		ready["reason"] = "GitLab token not configured"
+		return c.Status(503).JSON(ready)
+	}
+
+	checks := h.tokenChecks(c.UserContext())
+	ready["tokens"] = checks
+	for _, check := range checks {
+		switch check.Status {
+		case TokenInvalid, TokenExpired, TokenRevoked:
+			ready["ready"] = false
+			ready["reason"] = fmt.Sprintf("GitLab token of %s is %s", check.Name, check.Status)
+			return c.Status(503).JSON(ready)
+		}
+	}
+	return c.JSON(ready)
+}
+
+// tokenChecks returns the state of every token, checking them again once tokenCheckTTL elapsed
+func (h *HealthHandler) tokenChecks(ctx context.Context) []TokenCheck {
+	h.tokenMu.Lock()
+	defer h.tokenMu.Unlock()
+	if h.checks != nil && time.Since(h.checkedAt) < tokenCheckTTL {
+		return h.checks
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, tokenCheckTimeout)
+	defer cancel()
+
+	checks := make([]TokenCheck, len(h.probes))
+	var wg sync.WaitGroup
+	for i, probe := range h.probes {
+		wg.Add(1)
+		go func(i int, probe tokenProbe) {
+			defer wg.Done()
+			info, err := probe.client.TokenInfo(ctx)
+			checks[i] = classifyToken(probe.name, info, err, time.Now(), h.config.GitLab.TokenExpiryWarningDays)
+		}(i, probe)
+	}
+	wg.Wait()
+
+	h.checks, h.checkedAt = checks, time.Now()
+	return checks
+}
+
+// classifyToken returns the state of a token from the result of TokenInfo. GitLab expires
+// tokens at midnight UTC at the start of their expiry date.
+func classifyToken(name string, info *gitlab.TokenInfo, err error, now time.Time, warningDays int) TokenCheck {
+	check := TokenCheck{Name: name, Status: TokenValid}
+	switch {
+	case errors.Is(err, gitlab.ErrPermission):
+		check.Status, check.Error = TokenInvalid, err.Error()
+		return check
+	case err != nil:
+		check.Status, check.Error = TokenUnknown, err.Error()
+		return check
+	case info.Revoked:
+		check.Status = TokenRevoked
+		return check
+	}
+
+	check.ExpiresAt = info.ExpiresAt
+	expiresAt, parseErr := time.Parse("2006-01-02", info.ExpiresAt)
+	switch {
+	case !info.Active || (parseErr == nil && !now.Before(expiresAt)):
+		check.Status = TokenExpired
+	case parseErr == nil && now.Add(time.Duration(warningDays)*24*time.Hour).After(expiresAt):
+		check.Status = TokenExpiring
+	}
+	return check
+}
@@ -2,12 +2,15 @@ package webhook
 
 import (
 	"encoding/json"
+	"errors"
 	"io"
+	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -343,3 +346,79 @@ func TestHealthHandler_HTTPMethods(t *testing.T) {
 		})
 	}
 }
+
+func TestClassifyToken(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		info     *gitlab.TokenInfo
+		err      error
+		expected string
+	}{
+		{"valid", &gitlab.TokenInfo{Active: true, ExpiresAt: "2027-01-01"}, nil, TokenValid},
+		{"no expiry", &gitlab.TokenInfo{Active: true}, nil, TokenValid},
+		{"expiring", &gitlab.TokenInfo{Active: true, ExpiresAt: "2026-10-20"}, nil, TokenExpiring},
+		{"expired today", &gitlab.TokenInfo{Active: true, ExpiresAt: "2026-10-15"}, nil, TokenExpired},
+		{"inactive", &gitlab.TokenInfo{Active: false}, nil, TokenExpired},
+		{"revoked", &gitlab.TokenInfo{Revoked: true}, nil, TokenRevoked},
+		{"rejected", nil, &gitlab.APIError{StatusCode: 401, Message: "401 Unauthorized"}, TokenInvalid},
+		{"unreachable", nil, errors.New("connection refused"), TokenUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := classifyToken("review", tt.info, tt.err, now, 7)
+			assert.Equal(t, tt.expected, check.Status)
+			assert.Equal(t, "review", check.Name)
+		})
+	}
+}
+
+func TestHealthHandler_HandleReadyz(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Header.Get("Authorization") {
+		case "Bearer valid-token":
+			_, _ = w.Write([]byte(`{"name":"naysayer","active":true,"revoked":false}`))
+		case "Bearer revoked-token":
+			_, _ = w.Write([]byte(`{"name":"naysayer-cleanup","active":false,"revoked":true}`))
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+
+	readyz := func(cfg *config.Config) (int, map[string]interface{}) {
+		app := createTestApp()
+		app.Get("/readyz", NewHealthHandler(cfg).HandleReadyz)
+		resp, err := app.Test(httptest.NewRequest("GET", "/readyz", nil))
+		assert.NoError(t, err)
+		body, _ := io.ReadAll(resp.Body)
+		var result map[string]interface{}
+		_ = json.Unmarshal(body, &result)
+		return resp.StatusCode, result
+	}
+
+	status, result := readyz(&config.Config{GitLab: config.GitLabConfig{BaseURL: server.URL, Token: "valid-token"}})
+	assert.Equal(t, 200, status)
+	assert.Equal(t, true, result["ready"])
+	assert.Len(t, result["tokens"], 1, "functions sharing a token are checked once")
+
+	status, result = readyz(&config.Config{GitLab: config.GitLabConfig{
+		BaseURL:            server.URL,
+		Token:              "valid-token",
+		GitlabStaleMRToken: "revoked-token",
+	}})
+	assert.Equal(t, 503, status)
+	assert.Equal(t, false, result["ready"])
+	assert.Equal(t, "GitLab token of stale-mr-cleanup is revoked", result["reason"])
+
+	status, result = readyz(&config.Config{GitLab: config.GitLabConfig{
+		BaseURL:   server.URL,
+		Token:     "valid-token",
+		Instances: []config.GitLabInstance{{Name: "onprem", BaseURL: server.URL, Token: "expired-token"}},
+	}})
+	assert.Equal(t, 503, status)
+	assert.Equal(t, "GitLab token of instance/onprem is invalid", result["reason"])
+
+	status, _ = readyz(&config.Config{})
+	assert.Equal(t, 503, status)
+}
@@ -0,0 +1,116 @@
+package webhook
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/redhat-data-and-ai/naysayer/internal/audit"
+	"github.com/redhat-data-and-ai/naysayer/internal/history"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"go.uber.org/zap"
+)
+
+// HistoryHandler serves the persistent decision history
+type HistoryHandler struct {
+	store *history.Store
+}
+
+// NewHistoryHandler creates a history handler; a nil store answers 404
+func NewHistoryHandler(store *history.Store) *HistoryHandler {
+	return &HistoryHandler{store: store}
+}
+
+// HandleListDecisions lists decisions, newest first, filtered by project_id, mr_iid and since
+func (h *HistoryHandler) HandleListDecisions(c *fiber.Ctx) error {
+	if h.store == nil {
+		return c.Status(404).JSON(fiber.Map{"error": "decision history is disabled"})
+	}
+	filter, err := historyFilter(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	decisions, err := h.store.Decisions(filter)
+	if err != nil {
+		logging.Error("Failed to list decisions", zap.Error(err))
+		return c.Status(500).JSON(fiber.Map{"error": "failed to list decisions"})
+	}
+	return c.JSON(fiber.Map{"decisions": decisions})
+}
+
+// HandleGetDecision returns one decision with its rule outcomes. The id "latest" with
+// project_id and mr_iid returns the newest decision of the MR.
+func (h *HistoryHandler) HandleGetDecision(c *fiber.Ctx) error {
+	if h.store == nil {
+		return c.Status(404).JSON(fiber.Map{"error": "decision history is disabled"})
+	}
+
+	var decision *history.Decision
+	var err error
+	if id := c.Params("id"); id == "latest" {
+		projectID, mrIID := c.QueryInt("project_id", 0), c.QueryInt("mr_iid", 0)
+		if projectID <= 0 || mrIID <= 0 {
+			return c.Status(400).JSON(fiber.Map{"error": "project_id and mr_iid are required for the latest decision"})
+		}
+		decision, err = h.store.LatestDecision(projectID, mrIID)
+		if err == nil && decision == nil {
+			err = history.ErrNotFound
+		}
+	} else {
+		decision, err = h.store.Decision(id)
+	}
+	if errors.Is(err, history.ErrNotFound) {
+		return c.Status(404).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err != nil {
+		logging.Error("Failed to load decision", zap.Error(err))
+		return c.Status(500).JSON(fiber.Map{"error": "failed to load decision"})
+	}
+	return c.JSON(decision)
+}
+
+// HandleListActions lists auto-rebases, stale MR closures, overrides and auto-merges, newest first, filtered by
+// project_id, mr_iid, kind and since
+func (h *HistoryHandler) HandleListActions(c *fiber.Ctx) error {
+	if h.store == nil {
+		return c.Status(404).JSON(fiber.Map{"error": "decision history is disabled"})
+	}
+	filter, err := historyFilter(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	filter.Kind = c.Query("kind")
+	switch filter.Kind {
+	case "", audit.KindRebase, audit.KindStaleClose, audit.KindOverride, audit.KindMerge:
+	default:
+		return c.Status(400).JSON(fiber.Map{"error": "kind must be " + audit.KindRebase + ", " + audit.KindStaleClose + ", " + audit.KindOverride + " or " + audit.KindMerge})
+	}
+
+	actions, err := h.store.Actions(filter)
+	if err != nil {
+		logging.Error("Failed to list actions", zap.Error(err))
+		return c.Status(500).JSON(fiber.Map{"error": "failed to list actions"})
+	}
+	return c.JSON(fiber.Map{"actions": actions})
+}
+
+// historyFilter parses the project_id, mr_iid, since (RFC 3339) and limit query parameters
+func historyFilter(c *fiber.Ctx) (history.Filter, error) {
+	filter := history.Filter{
+		ProjectID: c.QueryInt("project_id", 0),
+		MRIID:     c.QueryInt("mr_iid", 0),
+		Limit:     c.QueryInt("limit", 0),
+	}
+	if filter.ProjectID < 0 || filter.MRIID < 0 || filter.Limit < 0 || (filter.MRIID > 0 && filter.ProjectID == 0) {
+		return filter, errors.New("project_id, mr_iid and limit must be positive integers; mr_iid requires project_id")
+	}
+	if since := c.Query("since"); since != "" {
+		t, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			return filter, errors.New("since must be an RFC 3339 timestamp")
+		}
+		filter.Since = t
+	}
+	return filter, nil
+}
@@ -0,0 +1,92 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/audit"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/history"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+)
+
+func TestHistoryHandler(t *testing.T) {
+	store, err := history.Open(filepath.Join(t.TempDir(), "history.db"))
+	assert.NoError(t, err)
+	defer func() { _ = store.Close() }()
+	audit.SetDefault(store)
+	t.Cleanup(func() { audit.SetDefault(nil) })
+
+	// Decisions and actions reach the store through the audit recorder
+	handler := &DataProductConfigMrReviewHandler{gitlabClient: &MockGitLabClient{}, config: createTestConfig()}
+	result := &shared.RuleEvaluation{
+		FinalDecision: shared.Decision{Type: shared.Approve, Reason: "All rules passed"},
+		DecisionID:    "d1",
+		FileValidations: map[string]*shared.FileValidationSummary{
+			"product.yaml": {RuleResults: []shared.LineValidationResult{
+				{RuleName: "warehouse_rule", Decision: shared.Approve, WasEvaluated: true},
+			}},
+		},
+	}
+	_, err = handler.applyDecision(context.Background(), result, &gitlab.MRInfo{ProjectID: 5, MRIID: 9})
+	assert.NoError(t, err)
+	recordAction(audit.KindRebase, 5, gitlab.MRDetails{IID: 9}, "behind main by 1 commits", nil)
+
+	app := createTestApp()
+	historyHandler := NewHistoryHandler(store)
+	app.Get("/api/v1/decisions", historyHandler.HandleListDecisions)
+	app.Get("/api/v1/decisions/:id", historyHandler.HandleGetDecision)
+	app.Get("/api/v1/actions", historyHandler.HandleListActions)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/decisions?project_id=5&mr_iid=9", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	var list struct {
+		Decisions []history.Decision `json:"decisions"`
+	}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&list))
+	assert.Len(t, list.Decisions, 1)
+	assert.Equal(t, "d1", list.Decisions[0].ID)
+	assert.True(t, list.Decisions[0].Approved)
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/api/v1/decisions/latest?project_id=5&mr_iid=9", nil))
+	assert.NoError(t, err)
+	var decision history.Decision
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&decision))
+	assert.Equal(t, "d1", decision.ID)
+	assert.Len(t, decision.Rules, 1)
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/api/v1/actions?project_id=5&kind=rebase", nil))
+	assert.NoError(t, err)
+	var actions struct {
+		Actions []history.Action `json:"actions"`
+	}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&actions))
+	assert.Len(t, actions.Actions, 1)
+
+	for path, status := range map[string]int{
+		"/api/v1/decisions/unknown":                      404,
+		"/api/v1/decisions/latest?project_id=5":          400,
+		"/api/v1/decisions/latest?project_id=5&mr_iid=1": 404,
+		"/api/v1/decisions?mr_iid=9":                     400,
+		"/api/v1/decisions?since=yesterday":              400,
+		"/api/v1/actions?kind=deploy":                    400,
+	} {
+		resp, err := app.Test(httptest.NewRequest("GET", path, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, status, resp.StatusCode, path)
+	}
+}
+
+func TestHistoryHandler_Disabled(t *testing.T) {
+	app := createTestApp()
+	app.Get("/api/v1/decisions", NewHistoryHandler(nil).HandleListDecisions)
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/decisions", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 404, resp.StatusCode)
+}
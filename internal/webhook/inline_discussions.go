@@ -0,0 +1,168 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+	"go.uber.org/zap"
+)
+
+// discussionsPrefix namespaces the inline discussions opened on every open MR
+const discussionsPrefix = "discussions/"
+
+// diffDiscussioner opens and resolves discussions on diff lines. The GitLab client
+// implements it; without it no inline discussions are opened.
+type diffDiscussioner interface {
+	CreateMRDiscussion(ctx context.Context, projectID, mrIID int, body string, position gitlab.DiffPosition) (string, error)
+	ResolveMRDiscussion(ctx context.Context, projectID, mrIID int, discussionID string, resolved bool) error
+}
+
+// inlineFinding is a failed rule anchored to the first line it reported
+type inlineFinding struct {
+	key    string // Identifies the finding across evaluations
+	path   string
+	line   int
+	rule   string
+	reason string
+}
+
+// inlineFindings lists the rules that required manual review for specific lines, one per
+// file, rule and reason, sorted by file and line
+func inlineFindings(result *shared.RuleEvaluation) []inlineFinding {
+	seen := map[string]bool{}
+	var findings []inlineFinding
+	for path, summary := range result.FileValidations {
+		if summary == nil {
+			continue
+		}
+		for _, ruleResult := range summary.RuleResults {
+			if !ruleResult.WasEvaluated || ruleResult.Decision != shared.ManualReview || len(ruleResult.LineRanges) == 0 {
+				continue
+			}
+			key := fmt.Sprintf("%s|%s|%s", path, ruleResult.RuleName, ruleResult.Reason)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			findings = append(findings, inlineFinding{
+				key:    key,
+				path:   path,
+				line:   ruleResult.LineRanges[0].StartLine,
+				rule:   ruleResult.RuleName,
+				reason: ruleResult.Reason,
+			})
+		}
+	}
+	sort.Slice(findings, func(i, j int) bool {
+		if findings[i].path != findings[j].path {
+			return findings[i].path < findings[j].path
+		}
+		return findings[i].line < findings[j].line
+	})
+	return findings
+}
+
+func discussionsKey(projectID, mrIID int) string {
+	return fmt.Sprintf("%s%d/%d", discussionsPrefix, projectID, mrIID)
+}
+
+// syncInlineDiscussions opens a discussion on the diff line of every new finding and
+// resolves the discussions of findings a later push fixed
+func (h *DataProductConfigMrReviewHandler) syncInlineDiscussions(ctx context.Context, result *shared.RuleEvaluation, mrInfo *gitlab.MRInfo) {
+	if h.discussions == nil || !h.config.Comments.EnableMRComments {
+		return
+	}
+	client, ok := h.gitlabClient.(diffDiscussioner)
+	if !ok {
+		return
+	}
+
+	key := discussionsKey(mrInfo.ProjectID, mrInfo.MRIID)
+	open := map[string]string{} // Finding key -> discussion ID
+	if _, err := store.GetJSON(h.discussions, key, &open); err != nil {
+		logging.FromContext(ctx).MRWarn(mrInfo.MRIID, "Failed to load inline discussions", zap.Error(err))
+		return
+	}
+
+	changed := false
+	findings := inlineFindings(result)
+	current := make(map[string]bool, len(findings))
+	for _, finding := range findings {
+		current[finding.key] = true
+	}
+
+	for findingKey, discussionID := range open {
+		if current[findingKey] {
+			continue
+		}
+		if err := client.ResolveMRDiscussion(ctx, mrInfo.ProjectID, mrInfo.MRIID, discussionID, true); err != nil {
+			// Discussions deleted by hand no longer need resolving
+			if !errors.Is(err, gitlab.ErrNotFound) {
+				logging.FromContext(ctx).MRWarn(mrInfo.MRIID, "Failed to resolve inline discussion", zap.String("discussion_id", discussionID), zap.Error(err))
+				continue
+			}
+		}
+		delete(open, findingKey)
+		changed = true
+	}
+
+	var refs *gitlab.DiffRefs
+	for _, finding := range findings {
+		if _, exists := open[finding.key]; exists {
+			continue
+		}
+		if refs == nil {
+			details, err := h.gitlabClient.GetMRDetails(ctx, mrInfo.ProjectID, mrInfo.MRIID)
+			if err != nil || details == nil || details.DiffRefs == nil {
+				logging.FromContext(ctx).MRWarn(mrInfo.MRIID, "Failed to load diff refs for inline discussions", zap.Error(err))
+				break
+			}
+			refs = details.DiffRefs
+		}
+
+		body := fmt.Sprintf("🚫 **%s**: %s\n\nNaysayer resolves this thread once a push fixes it.", finding.rule, finding.reason)
+		discussionID, err := client.CreateMRDiscussion(ctx, mrInfo.ProjectID, mrInfo.MRIID, body, gitlab.DiffPosition{
+			DiffRefs: *refs,
+			OldPath:  finding.path,
+			NewPath:  finding.path,
+			NewLine:  finding.line,
+		})
+		if err != nil {
+			// Lines outside the diff cannot carry a discussion; the decision comment still lists them
+			logging.FromContext(ctx).MRWarn(mrInfo.MRIID, "Failed to open inline discussion",
+				zap.String("file", finding.path), zap.Int("line", finding.line), zap.Error(err))
+			continue
+		}
+		open[finding.key] = discussionID
+		changed = true
+	}
+	if !changed {
+		return
+	}
+
+	var err error
+	if len(open) == 0 {
+		err = h.discussions.Delete(key)
+	} else {
+		err = store.PutJSON(h.discussions, key, open)
+	}
+	if err != nil {
+		logging.FromContext(ctx).MRWarn(mrInfo.MRIID, "Failed to save inline discussions", zap.Error(err))
+	}
+}
+
+// clearInlineDiscussions forgets the discussions of a merged or closed MR
+func (h *DataProductConfigMrReviewHandler) clearInlineDiscussions(mrInfo *gitlab.MRInfo) {
+	if h.discussions == nil {
+		return
+	}
+	if err := h.discussions.Delete(discussionsKey(mrInfo.ProjectID, mrInfo.MRIID)); err != nil {
+		logging.MRWarn(mrInfo.MRIID, "Failed to clear inline discussions", zap.Error(err))
+	}
+}
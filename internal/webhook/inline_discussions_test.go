@@ -0,0 +1,132 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+)
+
+// discussionGitLabClient records diff discussions and rejects lines outside the diff
+type discussionGitLabClient struct {
+	*MockGitLabClient
+	positions []gitlab.DiffPosition
+	bodies    []string
+	resolved  []string
+}
+
+func (m *discussionGitLabClient) GetMRDetails(ctx context.Context, projectID, mrIID int) (*gitlab.MRDetails, error) {
+	return &gitlab.MRDetails{IID: mrIID, State: "opened", DiffRefs: &gitlab.DiffRefs{BaseSHA: "base", HeadSHA: "head", StartSHA: "start"}}, nil
+}
+
+func (m *discussionGitLabClient) CreateMRDiscussion(ctx context.Context, projectID, mrIID int, body string, position gitlab.DiffPosition) (string, error) {
+	if position.NewLine > 100 {
+		return "", fmt.Errorf("create discussion failed with status 400: line_code can't be blank")
+	}
+	m.positions = append(m.positions, position)
+	m.bodies = append(m.bodies, body)
+	return fmt.Sprintf("d%d", len(m.positions)), nil
+}
+
+func (m *discussionGitLabClient) ResolveMRDiscussion(ctx context.Context, projectID, mrIID int, discussionID string, resolved bool) error {
+	m.resolved = append(m.resolved, discussionID)
+	return nil
+}
+
+func manualReviewResult(line int) *shared.RuleEvaluation {
+	return &shared.RuleEvaluation{
+		FinalDecision: shared.Decision{Type: shared.ManualReview, Reason: "Masking policy naming error"},
+		FileValidations: map[string]*shared.FileValidationSummary{
+			"policies/masking.yaml": {
+				FileDecision: shared.ManualReview,
+				RuleResults: []shared.LineValidationResult{
+					{RuleName: "masking_policy_rule", Decision: shared.ManualReview, Reason: "Policy name must end with _mask", WasEvaluated: true,
+						LineRanges: []shared.LineRange{{StartLine: line, EndLine: line}}},
+					{RuleName: "metadata_rule", Decision: shared.Approve, WasEvaluated: true,
+						LineRanges: []shared.LineRange{{StartLine: 1, EndLine: 2}}},
+				},
+			},
+		},
+	}
+}
+
+func TestSyncInlineDiscussions(t *testing.T) {
+	ctx := context.Background()
+	client := &discussionGitLabClient{MockGitLabClient: &MockGitLabClient{}}
+	cfg := createTestConfig()
+	cfg.Comments.EnableMRComments = true
+	cfg.Comments.InlineDiscussions = true
+	handler := &DataProductConfigMrReviewHandler{gitlabClient: client, config: cfg}
+	st := store.NewMemoryStore()
+	handler.SetStateStore(st)
+	mrInfo := &gitlab.MRInfo{ProjectID: 4, MRIID: 9}
+
+	handler.syncInlineDiscussions(ctx, manualReviewResult(3), mrInfo)
+	if assert.Len(t, client.positions, 1) {
+		assert.Equal(t, gitlab.DiffPosition{
+			DiffRefs: gitlab.DiffRefs{BaseSHA: "base", HeadSHA: "head", StartSHA: "start"},
+			OldPath:  "policies/masking.yaml", NewPath: "policies/masking.yaml", NewLine: 3,
+		}, client.positions[0])
+		assert.Contains(t, client.bodies[0], "🚫 **masking_policy_rule**: Policy name must end with _mask")
+	}
+
+	// The same finding on a later push keeps its discussion, even when its line moved
+	handler.syncInlineDiscussions(ctx, manualReviewResult(5), mrInfo)
+	assert.Len(t, client.positions, 1)
+	assert.Empty(t, client.resolved)
+
+	// A push fixing the finding resolves the discussion
+	handler.syncInlineDiscussions(ctx, &shared.RuleEvaluation{FinalDecision: shared.Decision{Type: shared.Approve}}, mrInfo)
+	assert.Equal(t, []string{"d1"}, client.resolved)
+	_, found, err := st.Get(discussionsKey(4, 9))
+	assert.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestSyncInlineDiscussions_LineOutsideDiff(t *testing.T) {
+	ctx := context.Background()
+	client := &discussionGitLabClient{MockGitLabClient: &MockGitLabClient{}}
+	cfg := createTestConfig()
+	cfg.Comments.EnableMRComments = true
+	cfg.Comments.InlineDiscussions = true
+	handler := &DataProductConfigMrReviewHandler{gitlabClient: client, config: cfg}
+	handler.SetStateStore(store.NewMemoryStore())
+
+	handler.syncInlineDiscussions(ctx, manualReviewResult(300), &gitlab.MRInfo{ProjectID: 4, MRIID: 9})
+	assert.Empty(t, client.positions)
+}
+
+func TestSyncInlineDiscussions_Disabled(t *testing.T) {
+	ctx := context.Background()
+	client := &discussionGitLabClient{MockGitLabClient: &MockGitLabClient{}}
+	handler := &DataProductConfigMrReviewHandler{gitlabClient: client, config: createTestConfig()}
+	handler.SetStateStore(store.NewMemoryStore())
+
+	handler.syncInlineDiscussions(ctx, manualReviewResult(3), &gitlab.MRInfo{ProjectID: 4, MRIID: 9})
+	assert.Empty(t, client.positions)
+}
+
+func TestInlineFindings(t *testing.T) {
+	result := manualReviewResult(3)
+	result.FileValidations["a.yaml"] = &shared.FileValidationSummary{RuleResults: []shared.LineValidationResult{
+		{RuleName: "warehouse_rule", Decision: shared.ManualReview, Reason: "Warehouse increase", WasEvaluated: true,
+			LineRanges: []shared.LineRange{{StartLine: 4, EndLine: 4}, {StartLine: 9, EndLine: 9}}},
+		{RuleName: "warehouse_rule", Decision: shared.ManualReview, Reason: "Warehouse increase", WasEvaluated: true,
+			LineRanges: []shared.LineRange{{StartLine: 12, EndLine: 12}}},
+		{RuleName: "toc_approval_rule", Decision: shared.ManualReview, Reason: "TOC approval required",
+			LineRanges: []shared.LineRange{{StartLine: 1, EndLine: 1}}},
+		{RuleName: "deletion_policy", Decision: shared.ManualReview, Reason: "File deleted", WasEvaluated: true},
+	}}
+
+	findings := inlineFindings(result)
+	if assert.Len(t, findings, 2) {
+		assert.Equal(t, "a.yaml", findings[0].path)
+		assert.Equal(t, 4, findings[0].line)
+		assert.Equal(t, "masking_policy_rule", findings[1].rule)
+	}
+}
@@ -0,0 +1,60 @@
+package webhook
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/tokenauth"
+	"go.uber.org/zap"
+)
+
+// LogLevelHandler reads and changes the log level at runtime, e.g. to debug a live
+// incident without a restart
+type LogLevelHandler struct {
+	verifier *tokenauth.Verifier
+}
+
+// NewLogLevelHandler creates a log level handler. Changes must carry the dashboard token
+// as a bearer token; without a configured token the level cannot be changed.
+func NewLogLevelHandler(cfg *config.Config) *LogLevelHandler {
+	return &LogLevelHandler{verifier: tokenauth.NewVerifier("log-level", cfg.Dashboard.Token)}
+}
+
+// logLevelRequest is the body of a log level change
+type logLevelRequest struct {
+	Level string `json:"level"`
+}
+
+// HandleGet returns the current log level
+func (h *LogLevelHandler) HandleGet(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{"level": logging.CurrentLevel().String()})
+}
+
+// HandlePut changes the log level; the change lasts until the next restart, which applies
+// LOG_LEVEL again
+func (h *LogLevelHandler) HandlePut(c *fiber.Ctx) error {
+	if h.verifier == nil {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "log level changes require DASHBOARD_TOKEN"})
+	}
+	if rejected, err := rejectAdminToken(c, h.verifier, bearerToken(c)); rejected {
+		return err
+	}
+
+	var req logLevelRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+	previous := logging.CurrentLevel()
+	if err := logging.SetLevel(req.Level); err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	current := logging.CurrentLevel()
+	logging.FromContext(c.UserContext()).Warn("Log level changed",
+		zap.Stringer("previous", previous),
+		zap.Stringer("current", current))
+	return c.JSON(fiber.Map{"level": current.String(), "previous": previous.String()})
+}
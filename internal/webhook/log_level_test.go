@@ -0,0 +1,76 @@
+package webhook
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+)
+
+func TestLogLevelHandler(t *testing.T) {
+	original := logging.CurrentLevel()
+	defer func() { _ = logging.SetLevel(original.String()) }()
+	assert.NoError(t, logging.SetLevel("info"))
+
+	cfg := createTestConfig()
+	cfg.Dashboard.Token = "admin-secret"
+	handler := NewLogLevelHandler(cfg)
+	app := createTestApp()
+	app.Get("/api/v1/log-level", handler.HandleGet)
+	app.Put("/api/v1/log-level", handler.HandlePut)
+
+	token := "admin-secret"
+	put := func(body string) (int, map[string]string) {
+		req := httptest.NewRequest("PUT", "/api/v1/log-level", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		var decoded map[string]string
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&decoded))
+		return resp.StatusCode, decoded
+	}
+
+	status, body := put(`{"level":"debug"}`)
+	assert.Equal(t, 200, status)
+	assert.Equal(t, map[string]string{"level": "debug", "previous": "info"}, body)
+	assert.Equal(t, logging.DEBUG, logging.CurrentLevel())
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/log-level", nil))
+	assert.NoError(t, err)
+	var current map[string]string
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&current))
+	assert.Equal(t, "debug", current["level"])
+
+	status, body = put(`{"level":"verbose"}`)
+	assert.Equal(t, 400, status)
+	assert.Contains(t, body["error"], "unknown log level")
+	assert.Equal(t, logging.DEBUG, logging.CurrentLevel(), "an invalid level keeps the current one")
+
+	status, _ = put(`not json`)
+	assert.Equal(t, 400, status)
+
+	// Changes need the dashboard token
+	for _, token = range []string{"", "wrong"} {
+		status, body = put(`{"level":"error"}`)
+		assert.Equal(t, 401, status, "token %q", token)
+		assert.Equal(t, "dashboard token required", body["error"])
+	}
+	assert.Equal(t, logging.DEBUG, logging.CurrentLevel())
+
+	// Without a dashboard token the level cannot be changed
+	token = ""
+	app.Put("/api/v1/log-level-open", NewLogLevelHandler(createTestConfig()).HandlePut)
+	req := httptest.NewRequest("PUT", "/api/v1/log-level-open", strings.NewReader(`{"level":"error"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err = app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, 403, resp.StatusCode)
+	assert.Equal(t, logging.DEBUG, logging.CurrentLevel())
+}
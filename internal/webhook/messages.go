@@ -3,11 +3,19 @@ package webhook
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/redhat-data-and-ai/naysayer/internal/commenttmpl"
 	"github.com/redhat-data-and-ai/naysayer/internal/config"
 	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/mergepolicy"
+	"github.com/redhat-data-and-ai/naysayer/internal/onboarding"
+	"github.com/redhat-data-and-ai/naysayer/internal/revert"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/group_membership"
 	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/warehouse"
 )
 
 // MessageBuilder handles creation of MR comments and approval messages
@@ -27,19 +35,34 @@ func (mb *MessageBuilder) BuildApprovalComment(result *shared.RuleEvaluation, mr
 	// Hidden identifier for comment tracking
 	comment.WriteString("<!-- naysayer-comment-id: approval -->\n")
 
-	// Header
-	comment.WriteString("✅ **Auto-approved**\n\n")
+	if revert.IsDecision(result.FinalDecision) {
+		comment.WriteString("✅ **Auto-approved**\n\n")
+		comment.WriteString(fmt.Sprintf("↩️ **%s**\n\n%s.\n", result.FinalDecision.Reason, result.FinalDecision.Details))
+		return comment.String()
+	}
 
 	// Analysis results based on verbosity
+	var summary string
 	switch mb.config.Comments.CommentVerbosity {
 	case "basic":
-		comment.WriteString(mb.buildBasicSummary(result))
+		summary = mb.buildBasicSummary(result)
 	case "debug":
-		comment.WriteString(mb.buildDebugSummary(result, mrInfo))
+		summary = mb.buildDebugSummary(result, mrInfo)
 	default: // "detailed"
-		comment.WriteString(mb.buildDetailedSummary(result))
+		summary = mb.buildDetailedSummary(result)
 	}
 
+	// A customized or translated template replaces the header, analysis and coverage report
+	if body, ok := commenttmpl.Default().Render(mb.config.Comments.LocaleFor(mrInfo.ProjectID), commenttmpl.Approval, mb.newReviewCommentData(result, mrInfo, summary)); ok {
+		comment.WriteString(body)
+		return comment.String()
+	}
+
+	comment.WriteString("✅ **Auto-approved**\n\n")
+	comment.WriteString(mb.BuildWarningsSection(result))
+	comment.WriteString(summary)
+	comment.WriteString(mb.BuildCoverageReport(result))
+
 	return comment.String()
 }
 
@@ -50,21 +73,491 @@ func (mb *MessageBuilder) BuildManualReviewComment(result *shared.RuleEvaluation
 	// Hidden identifier for comment tracking
 	comment.WriteString("<!-- naysayer-comment-id: manual-review -->\n")
 
-	// Header
-	comment.WriteString("⚠️ **Manual review required**\n\n")
-
 	// Analysis results based on verbosity
+	var summary string
 	switch mb.config.Comments.CommentVerbosity {
 	case "basic":
-		comment.WriteString(mb.buildBasicManualReviewSummary(result))
+		summary = mb.buildBasicManualReviewSummary(result)
 	case "debug":
-		comment.WriteString(mb.buildDebugManualReviewSummary(result, mrInfo))
+		summary = mb.buildDebugManualReviewSummary(result, mrInfo)
 	default: // "detailed"
-		comment.WriteString(mb.buildDetailedManualReviewSummary(result))
+		summary = mb.buildDetailedManualReviewSummary(result)
+	}
+
+	// A customized or translated template replaces the header, analysis and coverage report
+	if body, ok := commenttmpl.Default().Render(mb.config.Comments.LocaleFor(mrInfo.ProjectID), commenttmpl.ManualReview, mb.newReviewCommentData(result, mrInfo, summary)); ok {
+		comment.WriteString(body)
+		return comment.String()
+	}
+
+	comment.WriteString("⚠️ **Manual review required**\n\n")
+	comment.WriteString(summary)
+	comment.WriteString(mb.BuildCoverageReport(result))
+	return comment.String()
+}
+
+// BuildRebaseComment creates the comment posted after an automated rebase
+func (mb *MessageBuilder) BuildRebaseComment(data RebaseCommentData) string {
+	if body, ok := commenttmpl.Default().Render(mb.config.Comments.LocaleFor(data.ProjectID), commenttmpl.Rebase, data); ok {
+		return body
+	}
+	return "🤖 **Automated Rebase**\n\nThis merge request has been automatically rebased with the latest changes from the target branch.\n\n_This is an automated action triggered by a push to the main branch._"
+}
+
+// rebaseConflictCommentMarker identifies the comment asking an author to resolve rebase conflicts
+const rebaseConflictCommentMarker = "⚠️ **Auto-rebase blocked by conflicts**"
+
+// maxConflictCommits limits the target branch commits listed in a rebase conflict comment
+const maxConflictCommits = 20
+
+// BuildRebaseConflictComment asks the author to rebase an MR that conflicts with the target
+// branch, listing the target branch commits the MR is missing
+func (mb *MessageBuilder) BuildRebaseConflictComment(mr gitlab.MRDetails, missing []gitlab.CompareCommit) string {
+	var b strings.Builder
+	b.WriteString(rebaseConflictCommentMarker + "\n\n")
+	if author := authorUsername(mr); author != "" {
+		b.WriteString("@" + author + " ")
+	}
+	fmt.Fprintf(&b, "This merge request is %d commit(s) behind `%s` and could not be rebased automatically because it conflicts with them. Please rebase onto `%s` and resolve the conflicts; automated rebases resume once the MR is up to date.\n",
+		len(missing), mr.TargetBranch, mr.TargetBranch)
+
+	if len(missing) > 0 {
+		fmt.Fprintf(&b, "\n**Commits on `%s` missing in this MR:**\n", mr.TargetBranch)
+		for i, commit := range missing {
+			if i == maxConflictCommits {
+				fmt.Fprintf(&b, "- _...and %d more_\n", len(missing)-maxConflictCommits)
+				break
+			}
+			id := commit.ShortID
+			if id == "" {
+				id = shortSHA(commit.ID)
+			}
+			fmt.Fprintf(&b, "- `%s` %s", id, commit.Title)
+			if commit.AuthorName != "" {
+				fmt.Fprintf(&b, " (%s)", commit.AuthorName)
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	b.WriteString("\n_This is an automated message, posted once per merge request._")
+	return b.String()
+}
+
+// rebaseFailureCommentMarker identifies the comment reporting a rebase GitLab failed to run
+const rebaseFailureCommentMarker = "❌ **Auto-rebase failed**"
+
+// BuildRebaseFailureComment tells the author that GitLab reported an error running the
+// automated rebase of an MR
+func (mb *MessageBuilder) BuildRebaseFailureComment(mr gitlab.MRDetails, mergeError string) string {
+	var b strings.Builder
+	b.WriteString(rebaseFailureCommentMarker + "\n\n")
+	if author := authorUsername(mr); author != "" {
+		b.WriteString("@" + author + " ")
+	}
+	fmt.Fprintf(&b, "GitLab accepted the automated rebase of this merge request onto `%s` but could not complete it:\n\n> %s\n\nPlease rebase manually; automated rebases are retried on the next push to `%s`.\n",
+		mr.TargetBranch, mergeError, mr.TargetBranch)
+	b.WriteString("\n_This is an automated message, posted once per merge request._")
+	return b.String()
+}
+
+// BuildStaleClosureComment creates the comment posted when closing a stale MR
+func (mb *MessageBuilder) BuildStaleClosureComment(data StaleClosureCommentData) string {
+	if body, ok := commenttmpl.Default().Render(mb.config.Comments.LocaleFor(data.ProjectID), commenttmpl.StaleClosure, data); ok {
+		return body
+	}
+	return fmt.Sprintf(`**Automated Closure - Stale Merge Request**
+
+This merge request has been automatically closed due to inactivity (%d days with no updates).
+
+If you still want to merge this change, please:
+1. Reopen this MR
+2. Rebase with the latest changes
+3. Address any conflicts or review comments
+
+_This is an automated action performed by the stale MR cleanup process._`, data.DaysInactive)
+}
+
+// BuildReviewersSection mentions the owners asked to review, empty without owners
+func (mb *MessageBuilder) BuildReviewersSection(reviewers []string) string {
+	if len(reviewers) == 0 {
+		return ""
+	}
+	mentions := make([]string, 0, len(reviewers))
+	for _, reviewer := range reviewers {
+		mentions = append(mentions, "@"+reviewer)
+	}
+	return fmt.Sprintf("\n👀 **Reviewers:** %s\n", strings.Join(mentions, " "))
+}
+
+// BuildApprovalRevocationSection explains an approval revoked by new commits, listing the rule
+// results that regressed. It is empty when no approval was revoked.
+func (mb *MessageBuilder) BuildApprovalRevocationSection(result *shared.RuleEvaluation) string {
+	revocation := result.ApprovalRevocation
+	if revocation == nil {
+		return ""
+	}
+	var section strings.Builder
+	section.WriteString("\n🔄 **Approval revoked**: ")
+	if revocation.PreviousCommit != "" {
+		section.WriteString(fmt.Sprintf("commits pushed after `%s` changed the decision\n", shortSHA(revocation.PreviousCommit)))
+	} else {
+		section.WriteString("new commits changed the decision\n")
+	}
+	if len(revocation.Regressions) == 0 {
+		section.WriteString(fmt.Sprintf("• %s\n", result.FinalDecision.Reason))
+		return section.String()
+	}
+	for _, regression := range revocation.Regressions {
+		previous := string(regression.Previous)
+		if previous == "" {
+			previous = "not evaluated"
+		}
+		section.WriteString(fmt.Sprintf("• `%s` (`%s`): %s → %s: %s\n", regression.FilePath, regression.RuleName, previous, regression.Current, regression.Reason))
+	}
+	return section.String()
+}
+
+// shortSHA abbreviates a commit SHA for comments
+func shortSHA(sha string) string {
+	if len(sha) > 8 {
+		return sha[:8]
+	}
+	return sha
+}
+
+// BuildWarningsSection lists the rule findings downgraded to warnings, empty without warnings
+func (mb *MessageBuilder) BuildWarningsSection(result *shared.RuleEvaluation) string {
+	warnings := result.Warnings()
+	if len(warnings) == 0 {
+		return ""
+	}
+	var section strings.Builder
+	section.WriteString("⚠️ **Warnings** (not blocking):\n")
+	for _, warning := range warnings {
+		section.WriteString(fmt.Sprintf("• `%s` (`%s`): %s\n", warning.FilePath, warning.RuleName, warning.Reason))
+	}
+	section.WriteString("\n")
+	return section.String()
+}
+
+// BuildDecisionIDFooter references the audit log entry of the decision, empty without one
+func (mb *MessageBuilder) BuildDecisionIDFooter(result *shared.RuleEvaluation) string {
+	if result.DecisionID == "" {
+		return ""
+	}
+	return fmt.Sprintf("\n<sub>🔎 Decision ID: `%s`</sub>\n", result.DecisionID)
+}
+
+// BuildExplanationComment lays out the rule breakdown of a decision for `/naysayer explain`
+func (mb *MessageBuilder) BuildExplanationComment(explanation *Explanation) string {
+	var comment strings.Builder
+
+	decision := "✅ **Approve**"
+	if explanation.Decision.Type != shared.Approve {
+		decision = "⚠️ **Manual review**"
+	}
+	comment.WriteString("🔎 **Decision breakdown**\n\n")
+	comment.WriteString(fmt.Sprintf("%s: %s\n\n", decision, explanation.Decision.Reason))
+	comment.WriteString(fmt.Sprintf("Evaluated %s", explanation.EvaluatedAt.UTC().Format("2006-01-02 15:04 MST")))
+	if explanation.DecisionID != "" {
+		comment.WriteString(fmt.Sprintf(", decision ID `%s`", explanation.DecisionID))
+	}
+	comment.WriteString(fmt.Sprintf(" (%d files: %d approved, %d need review)\n", explanation.TotalFiles, explanation.ApprovedFiles, explanation.ReviewFiles))
+
+	comment.WriteString(buildExplanationFiles(explanation))
+	return comment.String()
+}
+
+// BuildExplanationSection collapses the rule breakdown of a decision into the decision
+// comment, so authors read it with their access to the MR rather than through the admin
+// /decisions endpoint
+func (mb *MessageBuilder) BuildExplanationSection(result *shared.RuleEvaluation, mrInfo *gitlab.MRInfo) string {
+	if len(result.FileValidations) == 0 {
+		return ""
+	}
+	explanation := NewExplanation(result, mrInfo, time.Now().UTC())
+
+	var section strings.Builder
+	section.WriteString("\n<details>\n")
+	section.WriteString(fmt.Sprintf("<summary>🔎 Decision breakdown: %d files, %d approved, %d need review</summary>\n",
+		explanation.TotalFiles, explanation.ApprovedFiles, explanation.ReviewFiles))
+	section.WriteString(buildExplanationFiles(explanation))
+	section.WriteString("\n</details>\n")
+	return section.String()
+}
+
+// buildExplanationFiles tables the rules that decided every file and lists uncovered files
+func buildExplanationFiles(explanation *Explanation) string {
+	var table strings.Builder
+	if len(explanation.Files) > 0 {
+		table.WriteString("\n| File | Decision | Rules |\n|---|---|---|\n")
+		for i, file := range explanation.Files {
+			if i == maxCoverageReportFiles {
+				table.WriteString(fmt.Sprintf("\n_…and %d more files._\n", len(explanation.Files)-maxCoverageReportFiles))
+				break
+			}
+			var rules []string
+			for _, rule := range file.Rules {
+				if !rule.Evaluated {
+					continue
+				}
+				entry := fmt.Sprintf("`%s`: %s", rule.Rule, rule.Decision)
+				if rule.Reason != "" {
+					entry += " (" + rule.Reason + ")"
+				}
+				rules = append(rules, entry)
+			}
+			if len(rules) == 0 {
+				rules = append(rules, "no rule covers the changed lines")
+			}
+			table.WriteString(fmt.Sprintf("| `%s` | %s | %s |\n", file.Path, file.Decision, strings.Join(rules, "<br>")))
+		}
+	}
+
+	if len(explanation.UncoveredFiles) > 0 {
+		table.WriteString(fmt.Sprintf("\n⚠️ **Uncovered changes:** %s\n", strings.Join(explanation.UncoveredFiles, ", ")))
+	}
+	return table.String()
+}
+
+// maxCoverageReportFiles caps the files listed in the coverage report and the decision
+// breakdown to keep comments within GitLab's size limit
+const maxCoverageReportFiles = 100
+
+// BuildCoverageReport breaks down which rule validated which lines of every file and which
+// lines no rule covered, collapsed per file. It is empty when coverage reports are disabled.
+func (mb *MessageBuilder) BuildCoverageReport(result *shared.RuleEvaluation) string {
+	if !mb.config.Comments.CoverageReport || len(result.FileValidations) == 0 {
+		return ""
 	}
+
+	paths := make([]string, 0, len(result.FileValidations))
+	uncoveredFiles := 0
+	for path, summary := range result.FileValidations {
+		if summary == nil {
+			continue
+		}
+		paths = append(paths, path)
+		if len(summary.UncoveredLines) > 0 {
+			uncoveredFiles++
+		}
+	}
+	sort.Strings(paths)
+
+	var report strings.Builder
+	report.WriteString("\n<details>\n")
+	report.WriteString(fmt.Sprintf("<summary>📐 Line coverage: %d files, %d fully covered, %d with uncovered lines</summary>\n\n",
+		len(paths), len(paths)-uncoveredFiles, uncoveredFiles))
+
+	for i, path := range paths {
+		if i == maxCoverageReportFiles {
+			report.WriteString(fmt.Sprintf("_…and %d more files._\n\n", len(paths)-maxCoverageReportFiles))
+			break
+		}
+		report.WriteString(mb.buildFileCoverage(path, result.FileValidations[path]))
+	}
+	report.WriteString("</details>\n")
+	return report.String()
+}
+
+// coverageRow is one line range of a file in the coverage report
+type coverageRow struct {
+	lines shared.LineRange
+	rule  string
+	text  string
+}
+
+// buildFileCoverage renders the collapsed line coverage of one file
+func (mb *MessageBuilder) buildFileCoverage(path string, summary *shared.FileValidationSummary) string {
+	var rows []coverageRow
+	for _, ruleResult := range summary.RuleResults {
+		if !ruleResult.WasEvaluated {
+			continue
+		}
+		text := string(ruleResult.Decision)
+		if ruleResult.Reason != "" {
+			text += ": " + ruleResult.Reason
+		}
+		for _, lines := range ruleResult.LineRanges {
+			rows = append(rows, coverageRow{lines: lines, rule: fmt.Sprintf("`%s`", ruleResult.RuleName), text: text})
+		}
+	}
+	for _, lines := range summary.UncoveredLines {
+		rows = append(rows, coverageRow{lines: lines, rule: "⚠️ _uncovered_", text: "no rule validated these lines"})
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].lines.StartLine < rows[j].lines.StartLine
+	})
+
+	icon := "✅"
+	if summary.FileDecision != shared.Approve {
+		icon = "⚠️"
+	}
+	var file strings.Builder
+	file.WriteString("<details>\n")
+	file.WriteString(fmt.Sprintf("<summary>%s <code>%s</code>: %d lines covered, %d uncovered</summary>\n\n",
+		icon, path, countLines(summary.CoveredLines), countLines(summary.UncoveredLines)))
+	if len(rows) == 0 {
+		file.WriteString("No changed lines were evaluated.\n\n")
+	} else {
+		file.WriteString("| Lines | Rule | Result |\n|---|---|---|\n")
+		for _, row := range rows {
+			file.WriteString(fmt.Sprintf("| %s | %s | %s |\n", formatLineRange(row.lines), row.rule, row.text))
+		}
+		file.WriteString("\n")
+	}
+	file.WriteString("</details>\n\n")
+	return file.String()
+}
+
+// countLines totals the lines of the ranges
+func countLines(ranges []shared.LineRange) int {
+	total := 0
+	for _, r := range ranges {
+		total += r.EndLine - r.StartLine + 1
+	}
+	return total
+}
+
+// formatLineRange shows a range as "3-6", or "3" for a single line
+func formatLineRange(r shared.LineRange) string {
+	if r.StartLine == r.EndLine {
+		return strconv.Itoa(r.StartLine)
+	}
+	return fmt.Sprintf("%d-%d", r.StartLine, r.EndLine)
+}
+
+// GroupMembershipChange pairs a changed group file with its membership diff
+type GroupMembershipChange struct {
+	FilePath string
+	Diff     *group_membership.MembershipDiff
+}
+
+// BuildGroupMembershipComment creates a comment summarizing group membership changes
+func (mb *MessageBuilder) BuildGroupMembershipComment(changes []GroupMembershipChange, elevatedRoles []string) string {
+	var comment strings.Builder
+
+	// Hidden identifier for comment tracking
+	comment.WriteString("<!-- naysayer-comment-id: group-membership -->\n")
+
+	// Header
+	comment.WriteString("👥 **Group membership changes**\n\n")
+
+	var grants []string
+	for _, change := range changes {
+		comment.WriteString(change.Diff.FormatMarkdown(change.FilePath))
+		grants = append(grants, change.Diff.ElevatedGrants(elevatedRoles)...)
+	}
+
+	if len(grants) > 0 {
+		comment.WriteString(fmt.Sprintf("⚠️ **Elevated roles granted:** %s - manual review required\n", strings.Join(grants, ", ")))
+	}
+
 	return comment.String()
 }
 
+// BuildMergeSettingsComment lists the merge setting changes an MR needs to comply with the project policy
+func (mb *MessageBuilder) BuildMergeSettingsComment(violations []mergepolicy.Violation) string {
+	var comment strings.Builder
+
+	// Hidden identifier for comment tracking
+	comment.WriteString("<!-- naysayer-comment-id: merge-settings -->\n")
+
+	if len(violations) == 0 {
+		comment.WriteString("✅ **Merge settings comply with project policy**\n")
+		return comment.String()
+	}
+
+	comment.WriteString("🔀 **Merge settings need changes**\n\n")
+	comment.WriteString("Consistent merge settings keep the changelog tooling working. Please:\n")
+	for _, v := range violations {
+		comment.WriteString(fmt.Sprintf("- %s\n", v.Fix))
+	}
+	if mb.config.MergePolicy.BlockApproval {
+		comment.WriteString("\nThis MR will not be auto-approved until the settings comply.\n")
+	}
+
+	return comment.String()
+}
+
+// BuildOnboardingComment creates the onboarding checklist of new data products. Items are
+// ticked off as the missing files are pushed.
+func (mb *MessageBuilder) BuildOnboardingComment(checklists []onboarding.Checklist) string {
+	var comment strings.Builder
+
+	// Hidden identifier for comment tracking
+	comment.WriteString("<!-- naysayer-comment-id: onboarding-checklist -->\n")
+
+	complete := true
+	for _, checklist := range checklists {
+		complete = complete && checklist.Complete()
+	}
+	if complete {
+		comment.WriteString("✅ **Onboarding checklist complete**\n")
+	} else {
+		comment.WriteString("🆕 **New data product onboarding checklist**\n\n")
+		comment.WriteString("This MR creates a new data product. Please add the missing artifacts:\n")
+	}
+
+	for _, checklist := range checklists {
+		done := len(checklist.Items) - len(checklist.Missing())
+		comment.WriteString(fmt.Sprintf("\n**%s** (`%s`, %s) - %d/%d\n",
+			checklist.Product.Name, checklist.Product.Dir, checklist.Product.Kind, done, len(checklist.Items)))
+		for _, item := range checklist.Items {
+			box := " "
+			if item.Done {
+				box = "x"
+			}
+			comment.WriteString(fmt.Sprintf("- [%s] %s: `%s`\n", box, item.Name, item.Path))
+		}
+	}
+
+	if !complete && mb.config.Onboarding.BlockApproval {
+		comment.WriteString("\nThis MR will not be auto-approved until the checklist is complete.\n")
+	}
+
+	return comment.String()
+}
+
+// BuildWarehouseCostComment creates the estimated monthly cost impact table of warehouse size changes
+func (mb *MessageBuilder) BuildWarehouseCostComment(impacts []warehouse.CostImpact) string {
+	var comment strings.Builder
+	cfg := mb.config.Rules.WarehouseRule
+
+	// Hidden identifier for comment tracking
+	comment.WriteString("<!-- naysayer-comment-id: warehouse-cost -->\n")
+
+	comment.WriteString("💰 **Warehouse cost impact (estimate)**\n\n")
+	comment.WriteString("| File | Warehouse | Size | Credits/month | Cost/month |\n")
+	comment.WriteString("|------|-----------|------|---------------|------------|\n")
+	for _, impact := range impacts {
+		credits, cost := fmt.Sprintf("%+.0f", impact.CreditsDelta), fmt.Sprintf("%+.2f %s", impact.CostDelta, cfg.CostCurrency)
+		if impact.UnknownSize {
+			credits, cost = "unknown", "unknown"
+		}
+		comment.WriteString(fmt.Sprintf("| `%s` | %s | %s → %s | %s | %s |\n",
+			impact.FilePath, impact.WarehouseType, sizeLabel(impact.FromSize), sizeLabel(impact.ToSize), credits, cost))
+	}
+	totalCredits, totalCost := warehouse.TotalCostDelta(impacts)
+	comment.WriteString(fmt.Sprintf("| **Total** | | | **%+.0f** | **%+.2f %s** |\n\n", totalCredits, totalCost, cfg.CostCurrency))
+
+	comment.WriteString(fmt.Sprintf("_Assumes %.0f running hours per month at %.2f %s per credit._\n", cfg.HoursPerMonth, cfg.CreditPrice, cfg.CostCurrency))
+	if cfg.CostReviewThreshold > 0 && totalCost > cfg.CostReviewThreshold {
+		comment.WriteString(fmt.Sprintf("\n⚠️ **Estimated increase exceeds %.2f %s/month** - manual review required\n", cfg.CostReviewThreshold, cfg.CostCurrency))
+	}
+
+	return comment.String()
+}
+
+// sizeLabel shows a missing warehouse size as a dash
+func sizeLabel(size string) string {
+	if size == "" {
+		return "-"
+	}
+	return size
+}
+
 // buildBasicSummary creates a basic approval summary
 func (mb *MessageBuilder) buildBasicSummary(result *shared.RuleEvaluation) string {
 	var summary strings.Builder
@@ -173,6 +666,11 @@ func (mb *MessageBuilder) buildRulesSummary(fileValidations map[string]*shared.F
 						ruleMessages[ruleKey] = fmt.Sprintf("✅ %s", ruleResult.Reason)
 					}
 				}
+			case shared.Warn:
+				// Warnings override approvals but not manual reviews
+				if message, exists := ruleMessages[ruleKey]; !exists || !strings.HasPrefix(message, "🚫") {
+					ruleMessages[ruleKey] = fmt.Sprintf("⚠️ %s", ruleResult.Reason)
+				}
 			case shared.ManualReview:
 				// Manual review messages always override, use actual reason
 				ruleMessages[ruleKey] = fmt.Sprintf("🚫 %s", ruleResult.Reason)
@@ -231,6 +729,10 @@ func (mb *MessageBuilder) formatRuleName(ruleName string) string {
 		"toc_approval_rule":         "TOC approval check",
 		"metadata_rule":             "Metadata validated",
 		"dataproduct_consumer_rule": "Consumer access changes validated",
+		"consumer_cycle_rule":       "Consumer dependency cycles checked",
+		"deletion_policy":           "File deletion policy applied",
+		"group_membership_rule":     "Group membership changes validated",
+		"repo_settings_rule":        "Review requirements checked",
 	}
 
 	if friendly, ok := friendlyNames[ruleName]; ok {
@@ -336,6 +838,8 @@ func (mb *MessageBuilder) buildDetailedRulesSummary(fileValidations map[string]*
 		switch result.Decision {
 		case shared.Approve:
 			summary.WriteString(fmt.Sprintf("• ✅ **%s**\n", friendlyName))
+		case shared.Warn:
+			summary.WriteString(fmt.Sprintf("• ⚠️ **%s**: %s\n", friendlyName, result.Reason))
 		case shared.ManualReview:
 			summary.WriteString(fmt.Sprintf("• 🚫 **%s**: %s\n", friendlyName, result.Reason))
 		}
@@ -413,6 +917,10 @@ func (mb *MessageBuilder) buildDetailedFilesSummary(result *shared.RuleEvaluatio
 func (mb *MessageBuilder) BuildApprovalMessage(result *shared.RuleEvaluation) string {
 	// Analyze the results to create a meaningful short message
 	switch {
+	case result.FinalDecision.Summary == overrideSummary:
+		return result.FinalDecision.Reason
+	case revert.IsDecision(result.FinalDecision):
+		return "Auto-approved: " + result.FinalDecision.Reason
 	case mb.hasWarehouseChanges(result):
 		return "Auto-approved: Warehouse changes are safe (decreases only)"
 	case mb.isAutomatedUser(result):
@@ -1,12 +1,16 @@
 package webhook
 
 import (
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/redhat-data-and-ai/naysayer/internal/config"
 	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/onboarding"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/group_membership"
 	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/warehouse"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -235,3 +239,194 @@ func TestBuildManualReviewComment(t *testing.T) {
 	assert.Contains(t, comment, "warehouse size increase detected")
 	assert.Contains(t, comment, "**What was checked:**")
 }
+
+func TestBuildGroupMembershipComment(t *testing.T) {
+	builder := NewMessageBuilder(&config.Config{})
+
+	diff, err := group_membership.ComputeDiff(
+		"group_name: analytics\napprovers:\n- alice\nmembers:\n  users:\n  - alice\n",
+		"group_name: analytics\napprovers:\n- alice\n- bob\nmembers:\n  users:\n  - alice\n  - bob\n",
+	)
+	assert.NoError(t, err)
+
+	comment := builder.BuildGroupMembershipComment([]GroupMembershipChange{
+		{FilePath: "dataproducts/source/x/groups/analytics.yaml", Diff: diff},
+	}, []string{group_membership.RoleApprover})
+
+	assert.Contains(t, comment, "<!-- naysayer-comment-id: group-membership -->")
+	assert.Contains(t, comment, "**Group membership changes**")
+	assert.Contains(t, comment, "➕ `bob` — approver, member")
+	assert.Contains(t, comment, "Elevated roles granted:** bob (approver)")
+}
+
+func TestBuildOnboardingComment(t *testing.T) {
+	builder := NewMessageBuilder(&config.Config{Onboarding: config.OnboardingConfig{BlockApproval: true}})
+	checklist := onboarding.Checklist{
+		Product: onboarding.Product{Kind: "source", Name: "analytics", Dir: "dataproducts/source/analytics"},
+		Items: []onboarding.ItemStatus{
+			{Name: "Product definition (prod)", Path: "dataproducts/source/analytics/prod/product.yaml", Done: true},
+			{Name: "Consumer group", Path: "dataproducts/source/analytics/groups/*.yaml"},
+		},
+	}
+
+	comment := builder.BuildOnboardingComment([]onboarding.Checklist{checklist})
+	assert.Contains(t, comment, "<!-- naysayer-comment-id: onboarding-checklist -->")
+	assert.Contains(t, comment, "**analytics** (`dataproducts/source/analytics`, source) - 1/2")
+	assert.Contains(t, comment, "- [x] Product definition (prod): `dataproducts/source/analytics/prod/product.yaml`")
+	assert.Contains(t, comment, "- [ ] Consumer group: `dataproducts/source/analytics/groups/*.yaml`")
+	assert.Contains(t, comment, "will not be auto-approved")
+
+	checklist.Items[1].Done = true
+	comment = builder.BuildOnboardingComment([]onboarding.Checklist{checklist})
+	assert.Contains(t, comment, "Onboarding checklist complete")
+	assert.Contains(t, comment, "2/2")
+	assert.NotContains(t, comment, "will not be auto-approved")
+}
+
+func TestBuildWarehouseCostComment(t *testing.T) {
+	builder := NewMessageBuilder(&config.Config{Rules: config.RulesConfig{WarehouseRule: config.WarehouseRuleConfig{
+		HoursPerMonth: 730, CreditPrice: 3, CostCurrency: "USD", CostReviewThreshold: 5000,
+	}}})
+
+	comment := builder.BuildWarehouseCostComment([]warehouse.CostImpact{
+		{FilePath: "dataproducts/source/a/prod/product.yaml", WarehouseType: "user", FromSize: "SMALL", ToSize: "LARGE", CreditsDelta: 4380, CostDelta: 13140},
+		{FilePath: "dataproducts/source/a/prod/product.yaml", WarehouseType: "service_account", FromSize: "XSMALL", UnknownSize: true},
+	})
+
+	assert.Contains(t, comment, "<!-- naysayer-comment-id: warehouse-cost -->")
+	assert.Contains(t, comment, "| `dataproducts/source/a/prod/product.yaml` | user | SMALL → LARGE | +4380 | +13140.00 USD |")
+	assert.Contains(t, comment, "| service_account | XSMALL → - | unknown | unknown |")
+	assert.Contains(t, comment, "| **Total** | | | **+4380** | **+13140.00 USD** |")
+	assert.Contains(t, comment, "_Assumes 730 running hours per month at 3.00 USD per credit._")
+	assert.Contains(t, comment, "Estimated increase exceeds 5000.00 USD/month")
+}
+
+func TestBuildReviewersSection(t *testing.T) {
+	builder := NewMessageBuilder(&config.Config{})
+	assert.Equal(t, "\n👀 **Reviewers:** @alice @data-platform/analytics\n", builder.BuildReviewersSection([]string{"alice", "data-platform/analytics"}))
+	assert.Empty(t, builder.BuildReviewersSection(nil))
+}
+
+func TestBuildExplanationComment(t *testing.T) {
+	builder := NewMessageBuilder(&config.Config{})
+	comment := builder.BuildExplanationComment(&Explanation{
+		EvaluatedAt: time.Date(2024, 5, 6, 10, 0, 0, 0, time.UTC),
+		DecisionID:  "abc",
+		Decision:    shared.Decision{Type: shared.ManualReview, Reason: "Uncovered changes"},
+		Files: []FileExplanation{
+			{Path: "pipeline.sh", Decision: shared.ManualReview},
+			{Path: "product.yaml", Decision: shared.Approve, Rules: []RuleExplanation{
+				{Rule: "warehouse_rule", Decision: shared.Approve, Reason: "Warehouse decrease", Evaluated: true},
+				{Rule: "metadata_rule", Decision: shared.Approve},
+			}},
+		},
+		UncoveredFiles: []string{"pipeline.sh"},
+		TotalFiles:     2, ApprovedFiles: 1, ReviewFiles: 1,
+	})
+
+	assert.Contains(t, comment, "⚠️ **Manual review**: Uncovered changes")
+	assert.Contains(t, comment, "Evaluated 2024-05-06 10:00 UTC, decision ID `abc` (2 files: 1 approved, 1 need review)")
+	assert.Contains(t, comment, "| `pipeline.sh` | manual_review | no rule covers the changed lines |")
+	assert.Contains(t, comment, "| `product.yaml` | approve | `warehouse_rule`: approve (Warehouse decrease) |")
+	assert.NotContains(t, comment, "metadata_rule", "skipped rules are left out")
+	assert.Contains(t, comment, "**Uncovered changes:** pipeline.sh")
+}
+
+func TestBuildExplanationSection(t *testing.T) {
+	builder := NewMessageBuilder(&config.Config{})
+	result := &shared.RuleEvaluation{
+		FinalDecision: shared.Decision{Type: shared.ManualReview, Reason: "Uncovered changes"},
+		FileValidations: map[string]*shared.FileValidationSummary{
+			"pipeline.sh": {FilePath: "pipeline.sh", FileDecision: shared.ManualReview,
+				UncoveredLines: []shared.LineRange{{StartLine: 1, EndLine: 3}}},
+			"product.yaml": {FilePath: "product.yaml", FileDecision: shared.Approve, RuleResults: []shared.LineValidationResult{
+				{RuleName: "warehouse_rule", Decision: shared.Approve, Reason: "Warehouse decrease", WasEvaluated: true,
+					LineRanges: []shared.LineRange{{StartLine: 1, EndLine: 4}}},
+			}},
+		},
+		TotalFiles: 2, ApprovedFiles: 1, ReviewFiles: 1,
+	}
+
+	section := builder.BuildExplanationSection(result, &gitlab.MRInfo{ProjectID: 1, MRIID: 2})
+	assert.True(t, strings.HasPrefix(section, "\n<details>\n<summary>🔎 Decision breakdown: 2 files, 1 approved, 1 need review</summary>\n"), section)
+	assert.Contains(t, section, "| `pipeline.sh` | manual_review | no rule covers the changed lines |")
+	assert.Contains(t, section, "| `product.yaml` | approve | `warehouse_rule`: approve (Warehouse decrease) |")
+	assert.Contains(t, section, "**Uncovered changes:** pipeline.sh")
+	assert.True(t, strings.HasSuffix(section, "</details>\n"))
+
+	assert.Empty(t, builder.BuildExplanationSection(&shared.RuleEvaluation{}, &gitlab.MRInfo{}), "nothing to break down")
+}
+
+func TestBuildApprovalComment_Warnings(t *testing.T) {
+	result := &shared.RuleEvaluation{
+		FinalDecision: shared.Decision{Type: shared.Approve, Reason: "All files passed validation", Summary: "✅ Auto-approved with warnings"},
+		FileValidations: map[string]*shared.FileValidationSummary{
+			"dataproducts/source/orders/dev/product.yaml": {
+				FileDecision: shared.Approve,
+				RuleResults: []shared.LineValidationResult{
+					{RuleName: "naming_rule", Decision: shared.Warn, Reason: "naming style deviation", WasEvaluated: true,
+						LineRanges: []shared.LineRange{{StartLine: 1, EndLine: 2}}},
+					{RuleName: "warehouse_rule", Decision: shared.Approve, Reason: "Warehouse decrease", WasEvaluated: true,
+						LineRanges: []shared.LineRange{{StartLine: 3, EndLine: 6}}},
+				},
+			},
+		},
+		TotalFiles:    1,
+		ApprovedFiles: 1,
+	}
+
+	builder := NewMessageBuilder(&config.Config{Comments: config.CommentsConfig{CommentVerbosity: "basic"}})
+	comment := builder.BuildApprovalComment(result, &gitlab.MRInfo{ProjectID: 123})
+	assert.Contains(t, comment, "✅ **Auto-approved**\n\n⚠️ **Warnings** (not blocking):\n• `dataproducts/source/orders/dev/product.yaml` (`naming_rule`): naming style deviation\n")
+	assert.Contains(t, comment, "• ⚠️ naming style deviation\n")
+	assert.Contains(t, comment, "• ✅ Warehouse decrease\n")
+
+	data := builder.newReviewCommentData(result, &gitlab.MRInfo{}, "")
+	assert.Empty(t, data.Failures, "warnings are not failures")
+	if assert.Len(t, data.Warnings, 1) {
+		assert.Equal(t, "naming_rule", data.Warnings[0].Rule)
+	}
+
+	result.FileValidations["dataproducts/source/orders/dev/product.yaml"].RuleResults[0].Decision = shared.Approve
+	assert.Empty(t, builder.BuildWarningsSection(result))
+}
+
+func TestBuildCoverageReport(t *testing.T) {
+	result := &shared.RuleEvaluation{
+		FinalDecision: shared.Decision{Type: shared.ManualReview, Reason: "Uncovered changes"},
+		FileValidations: map[string]*shared.FileValidationSummary{
+			"product.yaml": {
+				FileDecision:   shared.ManualReview,
+				CoveredLines:   []shared.LineRange{{StartLine: 1, EndLine: 6}},
+				UncoveredLines: []shared.LineRange{{StartLine: 8, EndLine: 8}},
+				RuleResults: []shared.LineValidationResult{
+					{RuleName: "warehouse_rule", Decision: shared.Approve, Reason: "Warehouse decrease", WasEvaluated: true,
+						LineRanges: []shared.LineRange{{StartLine: 3, EndLine: 6}}},
+					{RuleName: "metadata_rule", Decision: shared.Approve, WasEvaluated: true,
+						LineRanges: []shared.LineRange{{StartLine: 1, EndLine: 2}}},
+					{RuleName: "toc_approval_rule", Decision: shared.Approve,
+						LineRanges: []shared.LineRange{{StartLine: 1, EndLine: 6}}},
+				},
+			},
+			"README.md": {
+				FileDecision: shared.Approve,
+				CoveredLines: []shared.LineRange{{StartLine: 1, EndLine: 4}},
+			},
+		},
+	}
+
+	disabled := NewMessageBuilder(&config.Config{})
+	assert.Empty(t, disabled.BuildCoverageReport(result))
+
+	builder := NewMessageBuilder(&config.Config{Comments: config.CommentsConfig{CommentVerbosity: "detailed", CoverageReport: true}})
+	report := builder.BuildCoverageReport(result)
+	assert.Contains(t, report, "<summary>📐 Line coverage: 2 files, 1 fully covered, 1 with uncovered lines</summary>")
+	assert.Contains(t, report, "<summary>✅ <code>README.md</code>: 4 lines covered, 0 uncovered</summary>\n\nNo changed lines were evaluated.")
+	assert.Contains(t, report, "<summary>⚠️ <code>product.yaml</code>: 6 lines covered, 1 uncovered</summary>")
+	assert.Contains(t, report, "| 1-2 | `metadata_rule` | approve |\n| 3-6 | `warehouse_rule` | approve: Warehouse decrease |\n| 8 | ⚠️ _uncovered_ | no rule validated these lines |\n")
+	assert.NotContains(t, report, "toc_approval_rule", "skipped rules are left out")
+	assert.Less(t, strings.Index(report, "README.md"), strings.Index(report, "product.yaml"), "files are sorted")
+
+	comment := builder.BuildManualReviewComment(result, &gitlab.MRInfo{})
+	assert.True(t, strings.HasSuffix(comment, report))
+}
@@ -0,0 +1,220 @@
+package webhook
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/redhat-data-and-ai/naysayer/internal/coalesce"
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/idempotency"
+	"github.com/redhat-data-and-ai/naysayer/internal/instances"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/projectfilter"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/warehouse"
+	"github.com/redhat-data-and-ai/naysayer/internal/stats"
+	"github.com/redhat-data-and-ai/naysayer/internal/tokenauth"
+	"github.com/redhat-data-and-ai/naysayer/internal/tracing"
+	"go.uber.org/zap"
+)
+
+// MetricsHandler exposes time-to-decision SLO compliance in the Prometheus text format
+type MetricsHandler struct {
+	recorder *stats.Recorder
+	window   time.Duration
+	now      func() time.Time
+}
+
+// NewMetricsHandler creates a metrics handler reporting over the SLO window
+func NewMetricsHandler(recorder *stats.Recorder, cfg config.SLOConfig) *MetricsHandler {
+	window := time.Duration(cfg.WindowMinutes) * time.Minute
+	if window <= 0 {
+		window = time.Hour
+	}
+	return &MetricsHandler{recorder: recorder, window: window, now: time.Now}
+}
+
+// sloMetric is one gauge written per project and objective
+type sloMetric struct {
+	name  string
+	help  string
+	value func(status *stats.SLOStatus) float64
+}
+
+var sloMetrics = []sloMetric{
+	{"naysayer_slo_events", "Decisions in the SLO window", func(s *stats.SLOStatus) float64 { return float64(s.Events) }},
+	{"naysayer_slo_compliance_ratio", "Share of decisions within the SLO threshold", func(s *stats.SLOStatus) float64 { return s.CompliancePercent / 100 }},
+	{"naysayer_slo_p95_seconds", "95th percentile latency in the SLO window", func(s *stats.SLOStatus) float64 { return s.P95Seconds }},
+	{"naysayer_slo_burn_rate", "Error budget burn rate in the SLO window", func(s *stats.SLOStatus) float64 { return s.BurnRate }},
+	{"naysayer_slo_threshold_seconds", "SLO latency threshold", func(s *stats.SLOStatus) float64 { return s.ThresholdSeconds }},
+	{"naysayer_slo_target_ratio", "SLO target share of decisions within the threshold", func(s *stats.SLOStatus) float64 { return s.TargetPercent / 100 }},
+}
+
+// HandleMetrics writes SLO gauges per project and objective for the last SLO window and
+// the webhook deliveries rejected since startup
+func (h *MetricsHandler) HandleMetrics(c *fiber.Ctx) error {
+	now := h.now()
+	report, err := h.recorder.Summarize(0, now.Add(-h.window), now)
+	if err != nil {
+		logging.Error("Failed to summarize SLO metrics", zap.Error(err))
+		return c.Status(500).SendString("failed to summarize SLO metrics\n")
+	}
+
+	var b strings.Builder
+	for _, metric := range sloMetrics {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s gauge\n", metric.name, metric.help, metric.name)
+		for _, project := range report.Projects {
+			for _, objective := range []struct {
+				name   string
+				status *stats.SLOStatus
+			}{
+				{stats.ObjectiveDecisionLatency, project.DecisionLatencySLO},
+				{stats.ObjectiveFirstDecision, project.FirstDecisionSLO},
+			} {
+				if objective.status == nil {
+					continue
+				}
+				fmt.Fprintf(&b, "%s{project_id=\"%d\",objective=\"%s\"} %g\n",
+					metric.name, project.ProjectID, objective.name, metric.value(objective.status))
+			}
+		}
+	}
+
+	fmt.Fprintf(&b, "# HELP naysayer_webhook_rejected_total Webhook deliveries rejected for a missing or invalid X-Gitlab-Token\n# TYPE naysayer_webhook_rejected_total counter\n")
+	for _, rejection := range tokenauth.Rejections() {
+		fmt.Fprintf(&b, "naysayer_webhook_rejected_total{endpoint=\"%s\",reason=\"%s\"} %d\n",
+			rejection.Endpoint, rejection.Reason, rejection.Count)
+	}
+
+	fmt.Fprintf(&b, "# HELP naysayer_fork_visibility_failures_total Fork MRs whose source project the bot could not read\n# TYPE naysayer_fork_visibility_failures_total counter\n")
+	fmt.Fprintf(&b, "naysayer_fork_visibility_failures_total %d\n", warehouse.ForkVisibilityFailures())
+
+	fmt.Fprintf(&b, "# HELP naysayer_gitlab_token_reloads_total Rotated GitLab tokens picked up from token files and sources\n# TYPE naysayer_gitlab_token_reloads_total counter\n")
+	fmt.Fprintf(&b, "naysayer_gitlab_token_reloads_total %d\n", gitlab.TokenReloads())
+
+	writeFileCacheMetrics(&b)
+	writeCoalesceMetrics(&b)
+	writeIdempotencyMetrics(&b)
+	writeScheduledRunMetrics(&b)
+	writeRebaseOutcomeMetrics(&b)
+	writeRuleFailureMetrics(&b)
+	writeProjectFilterMetrics(&b)
+	writeInstanceMetrics(&b)
+	writeTracingMetrics(&b)
+
+	c.Set(fiber.HeaderContentType, "text/plain; version=0.0.4; charset=utf-8")
+	return c.SendString(b.String())
+}
+
+// writeFileCacheMetrics writes the GitLab file cache lookups and size when the cache is enabled
+func writeFileCacheMetrics(b *strings.Builder) {
+	cache := gitlab.DefaultFileCache()
+	if cache == nil {
+		return
+	}
+	hits, misses := cache.Stats()
+	fmt.Fprintf(b, "# HELP naysayer_gitlab_file_cache_requests_total GitLab file content lookups served from the cache (hit) or the API (miss)\n# TYPE naysayer_gitlab_file_cache_requests_total counter\n")
+	fmt.Fprintf(b, "naysayer_gitlab_file_cache_requests_total{result=\"hit\"} %d\n", hits)
+	fmt.Fprintf(b, "naysayer_gitlab_file_cache_requests_total{result=\"miss\"} %d\n", misses)
+	fmt.Fprintf(b, "# HELP naysayer_gitlab_file_cache_entries Files held in the GitLab file cache\n# TYPE naysayer_gitlab_file_cache_entries gauge\n")
+	fmt.Fprintf(b, "naysayer_gitlab_file_cache_entries %d\n", cache.Len())
+}
+
+// writeCoalesceMetrics writes the merge request events evaluated or skipped for a newer
+// event of the same MR since startup
+func writeCoalesceMetrics(b *strings.Builder) {
+	evaluated, coalesced, serialized := coalesce.Stats()
+	fmt.Fprintf(b, "# HELP naysayer_mr_events_total Merge request events evaluated or coalesced into a newer event of the same MR\n# TYPE naysayer_mr_events_total counter\n")
+	fmt.Fprintf(b, "naysayer_mr_events_total{result=\"evaluated\"} %d\n", evaluated)
+	fmt.Fprintf(b, "naysayer_mr_events_total{result=\"coalesced\"} %d\n", coalesced)
+	fmt.Fprintf(b, "# HELP naysayer_mr_events_serialized_total Merge request events that waited for a running evaluation of the same MR\n# TYPE naysayer_mr_events_serialized_total counter\n")
+	fmt.Fprintf(b, "naysayer_mr_events_serialized_total %d\n", serialized)
+}
+
+// writeIdempotencyMetrics writes the repeated webhook deliveries answered without processing
+// them since startup
+func writeIdempotencyMetrics(b *strings.Builder) {
+	duplicates, inFlight := idempotency.Stats()
+	fmt.Fprintf(b, "# HELP naysayer_webhook_duplicates_total Repeated webhook deliveries answered with the original result (stored) or while the original was processed (in_flight)\n# TYPE naysayer_webhook_duplicates_total counter\n")
+	fmt.Fprintf(b, "naysayer_webhook_duplicates_total{state=\"stored\"} %d\n", duplicates)
+	fmt.Fprintf(b, "naysayer_webhook_duplicates_total{state=\"in_flight\"} %d\n", inFlight)
+}
+
+// writeInstanceMetrics writes the webhook deliveries routed per GitLab instance; only
+// reported when additional instances are configured
+func writeInstanceMetrics(b *strings.Builder) {
+	counts := instances.Deliveries()
+	if len(counts) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "# HELP naysayer_gitlab_instance_deliveries_total Webhook deliveries routed to each GitLab instance\n# TYPE naysayer_gitlab_instance_deliveries_total counter\n")
+	for _, count := range counts {
+		fmt.Fprintf(b, "naysayer_gitlab_instance_deliveries_total{instance=\"%s\"} %d\n", count.Instance, count.Count)
+	}
+}
+
+// writeTracingMetrics writes the trace spans exported since startup; only reported when
+// tracing is enabled
+func writeTracingMetrics(b *strings.Builder) {
+	if tracing.Default() == nil {
+		return
+	}
+	exported, dropped, failed := tracing.Stats()
+	fmt.Fprintf(b, "# HELP naysayer_trace_spans_total Trace spans exported, dropped because the export queue was full, or lost in failed exports\n# TYPE naysayer_trace_spans_total counter\n")
+	fmt.Fprintf(b, "naysayer_trace_spans_total{result=\"exported\"} %d\n", exported)
+	fmt.Fprintf(b, "naysayer_trace_spans_total{result=\"dropped\"} %d\n", dropped)
+	fmt.Fprintf(b, "naysayer_trace_spans_total{result=\"failed\"} %d\n", failed)
+}
+
+// writeProjectFilterMetrics writes the events and runs skipped for projects a subsystem does not handle
+func writeProjectFilterMetrics(b *strings.Builder) {
+	fmt.Fprintf(b, "# HELP naysayer_project_filter_skips_total Webhook deliveries and background runs skipped by PROJECT_ALLOWLIST, PROJECT_DENYLIST and PROJECT_SUBSYSTEMS\n# TYPE naysayer_project_filter_skips_total counter\n")
+	for _, skip := range projectfilter.Skips() {
+		fmt.Fprintf(b, "naysayer_project_filter_skips_total{subsystem=\"%s\",reason=\"%s\"} %d\n", skip.Subsystem, skip.Reason, skip.Count)
+	}
+}
+
+// writeRebaseOutcomeMetrics writes the final outcomes of the rebases triggered since startup
+func writeRebaseOutcomeMetrics(b *strings.Builder) {
+	fmt.Fprintf(b, "# HELP naysayer_rebase_outcomes_total Automated rebases by final outcome (rebased, conflict, failed, timeout, error)\n# TYPE naysayer_rebase_outcomes_total counter\n")
+	for _, outcome := range RebaseOutcomes() {
+		fmt.Fprintf(b, "naysayer_rebase_outcomes_total{outcome=\"%s\"} %d\n", outcome.Outcome, outcome.Count)
+	}
+}
+
+// writeRuleFailureMetrics writes the rule validations that panicked or timed out since startup
+func writeRuleFailureMetrics(b *strings.Builder) {
+	fmt.Fprintf(b, "# HELP naysayer_rule_errors_total Rule validations that panicked or timed out and required manual review\n# TYPE naysayer_rule_errors_total counter\n")
+	for _, failure := range rules.RuleFailures() {
+		fmt.Fprintf(b, "naysayer_rule_errors_total{rule=\"%s\",kind=\"%s\"} %d\n", failure.Rule, failure.Kind, failure.Count)
+	}
+}
+
+// writeScheduledRunMetrics writes the scheduled auto-rebase and stale MR cleanup runs since startup
+func writeScheduledRunMetrics(b *strings.Builder) {
+	runs := ScheduledRuns()
+	fmt.Fprintf(b, "# HELP naysayer_scheduled_runs_total Scheduled maintenance runs by task and status\n# TYPE naysayer_scheduled_runs_total counter\n")
+	for _, run := range runs {
+		for _, status := range []string{RunCompleted, RunSkipped, RunFailed} {
+			if count, ok := run.Runs[status]; ok {
+				fmt.Fprintf(b, "naysayer_scheduled_runs_total{task=\"%s\",project_id=\"%d\",status=\"%s\"} %d\n", run.Kind, run.ProjectID, status, count)
+			}
+		}
+	}
+	fmt.Fprintf(b, "# HELP naysayer_scheduled_mrs_total MRs rebased, closed or failed by scheduled maintenance runs\n# TYPE naysayer_scheduled_mrs_total counter\n")
+	for _, run := range runs {
+		for _, outcome := range []struct {
+			name  string
+			count int64
+		}{{"rebased", run.Rebased}, {"closed", run.Closed}, {"failed", run.Failed}} {
+			fmt.Fprintf(b, "naysayer_scheduled_mrs_total{task=\"%s\",project_id=\"%d\",outcome=\"%s\"} %d\n", run.Kind, run.ProjectID, outcome.name, outcome.count)
+		}
+	}
+	fmt.Fprintf(b, "# HELP naysayer_scheduled_last_run_timestamp_seconds End of the last scheduled maintenance run\n# TYPE naysayer_scheduled_last_run_timestamp_seconds gauge\n")
+	for _, run := range runs {
+		fmt.Fprintf(b, "naysayer_scheduled_last_run_timestamp_seconds{task=\"%s\",project_id=\"%d\"} %d\n", run.Kind, run.ProjectID, run.LastRun.Unix())
+	}
+}
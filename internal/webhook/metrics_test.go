@@ -0,0 +1,70 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/redhat-data-and-ai/naysayer/internal/stats"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+	"github.com/redhat-data-and-ai/naysayer/internal/tokenauth"
+)
+
+func TestMetricsHandler_ReportsDecisionLatencySLO(t *testing.T) {
+	ctx := context.Background()
+	sloConfig := config.SLOConfig{DecisionLatencySeconds: 30, FirstDecisionSeconds: 300, TargetPercent: 95, WindowMinutes: 60}
+	recorder := stats.NewRecorder(store.NewMemoryStore())
+	recorder.SetObjectives(stats.ObjectivesFromConfig(sloConfig))
+
+	handler := &DataProductConfigMrReviewHandler{config: createTestConfig(), gitlabClient: &MockGitLabClient{}}
+	handler.SetStatsRecorder(recorder)
+	result := &shared.RuleEvaluation{FinalDecision: shared.Decision{Type: shared.Approve, Reason: "ok"}}
+	mrInfo := &gitlab.MRInfo{ProjectID: 7, MRIID: 1, ReceivedAt: time.Now().Add(-time.Second)}
+	assert.NoError(t, handler.handleApprovalWithComments(ctx, result, mrInfo))
+
+	app := createTestApp()
+	app.Get("/metrics", NewMetricsHandler(recorder, sloConfig).HandleMetrics)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/metrics", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Contains(t, resp.Header.Get("Content-Type"), "text/plain")
+
+	body, _ := io.ReadAll(resp.Body)
+	assert.Contains(t, string(body), "# TYPE naysayer_slo_compliance_ratio gauge")
+	assert.Contains(t, string(body), `naysayer_slo_events{project_id="7",objective="decision_latency"} 1`)
+	assert.Contains(t, string(body), `naysayer_slo_compliance_ratio{project_id="7",objective="decision_latency"} 1`)
+	assert.Contains(t, string(body), `naysayer_slo_target_ratio{project_id="7",objective="decision_latency"} 0.95`)
+}
+
+func TestMetricsHandler_ReportsWebhookRejections(t *testing.T) {
+	app := createTestApp()
+	app.Post("/stale-mr-cleanup", tokenauth.NewVerifier("metrics-test", "secret").Middleware(), func(c *fiber.Ctx) error {
+		return c.SendStatus(200)
+	})
+	app.Get("/metrics", NewMetricsHandler(stats.NewRecorder(store.NewMemoryStore()), config.SLOConfig{WindowMinutes: 60}).HandleMetrics)
+
+	resp, err := app.Test(httptest.NewRequest("POST", "/stale-mr-cleanup", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 401, resp.StatusCode)
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/metrics", nil))
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	assert.Contains(t, string(body), "# TYPE naysayer_webhook_rejected_total counter")
+	assert.Contains(t, string(body), `naysayer_webhook_rejected_total{endpoint="metrics-test",reason="missing_token"} 1`)
+	assert.Contains(t, string(body), "# TYPE naysayer_fork_visibility_failures_total counter")
+	assert.Contains(t, string(body), "# TYPE naysayer_mr_events_total counter")
+	assert.Contains(t, string(body), `naysayer_mr_events_total{result="coalesced"}`)
+	assert.Contains(t, string(body), "# TYPE naysayer_mr_events_serialized_total counter")
+	assert.Contains(t, string(body), `naysayer_webhook_duplicates_total{state="stored"}`)
+	assert.Contains(t, string(body), "# TYPE naysayer_rule_errors_total counter")
+}
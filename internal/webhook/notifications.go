@@ -0,0 +1,97 @@
+package webhook
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/notify"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"go.uber.org/zap"
+)
+
+// repeatedDecision reports whether the latest saved evaluation of the MR reached the same
+// decision for the same reason, so re-evaluations of an unchanged MR are not notified again
+func (h *DataProductConfigMrReviewHandler) repeatedDecision(result *shared.RuleEvaluation, mrInfo *gitlab.MRInfo) bool {
+	if h.notifier == nil {
+		return false
+	}
+	previous := h.previousExplanation(mrInfo)
+	if previous == nil {
+		return false
+	}
+	return previous.Decision.Type == result.FinalDecision.Type && previous.Decision.Reason == result.FinalDecision.Reason
+}
+
+// notifyManualReview tells the channels routed for manual reviews that the MR needs a human
+func (h *DataProductConfigMrReviewHandler) notifyManualReview(result *shared.RuleEvaluation, mrInfo *gitlab.MRInfo) {
+	if h.notifier == nil {
+		return
+	}
+	err := h.notifier.Notify(notify.Notification{
+		Event:     notify.EventManualReview,
+		Severity:  notify.SeverityInfo,
+		Title:     fmt.Sprintf("Manual review required: %s", mrInfo.Title),
+		Message:   result.FinalDecision.Reason,
+		ProjectID: mrInfo.ProjectID,
+		MRIID:     mrInfo.MRIID,
+		Fields: map[string]string{
+			"author":        mrInfo.Author,
+			"source_branch": mrInfo.SourceBranch,
+			"target_branch": mrInfo.TargetBranch,
+		},
+	})
+	if err != nil {
+		logging.MRWarn(mrInfo.MRIID, "Failed to send manual review notification", zap.Error(err))
+	}
+}
+
+// notifyRebaseConflict tells the channels routed for rebase conflicts that the MR must be
+// rebased by hand
+func (h *AutoRebaseHandler) notifyRebaseConflict(projectID int, mr gitlab.MRDetails, rebaseErr error) {
+	if h.notifier == nil {
+		return
+	}
+	err := h.notifier.Notify(notify.Notification{
+		Event:     notify.EventRebaseConflict,
+		Severity:  notify.SeverityWarning,
+		Title:     fmt.Sprintf("Auto-rebase failed: %s", mr.Title),
+		Message:   fmt.Sprintf("!%d could not be rebased onto %s: %v", mr.IID, mr.TargetBranch, rebaseErr),
+		ProjectID: projectID,
+		MRIID:     mr.IID,
+		Fields: map[string]string{
+			"author":        authorUsername(mr),
+			"source_branch": mr.SourceBranch,
+			"target_branch": mr.TargetBranch,
+		},
+	})
+	if err != nil {
+		logging.Warn("Failed to send rebase conflict notification", zap.Int("mr_iid", mr.IID), zap.Error(err))
+	}
+}
+
+// notifyStaleClosures sends one notification listing the MRs a cleanup run closed
+func (h *StaleMRCleanupHandler) notifyStaleClosures(projectID int, closed []gitlab.MRDetails) {
+	if h.notifier == nil || len(closed) == 0 {
+		return
+	}
+	lines := make([]string, 0, len(closed))
+	iids := make([]string, 0, len(closed))
+	for _, mr := range closed {
+		lines = append(lines, fmt.Sprintf("!%d %s", mr.IID, mr.Title))
+		iids = append(iids, strconv.Itoa(mr.IID))
+	}
+	err := h.notifier.Notify(notify.Notification{
+		Event:     notify.EventStaleMRClosed,
+		Severity:  notify.SeverityInfo,
+		Title:     fmt.Sprintf("Closed %d stale MRs in project %d", len(closed), projectID),
+		Message:   strings.Join(lines, "\n"),
+		ProjectID: projectID,
+		Fields:    map[string]string{"mr_iids": strings.Join(iids, ",")},
+	})
+	if err != nil {
+		logging.Warn("Failed to send stale MR closure notification", zap.Int("project_id", projectID), zap.Error(err))
+	}
+}
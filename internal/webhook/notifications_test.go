@@ -0,0 +1,114 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/notify"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+)
+
+func TestNotifiers_OnlyForRoutedEvents(t *testing.T) {
+	cfg := createTestConfig()
+	assert.Nil(t, NewAutoRebaseHandlerWithClient(cfg, &MockRebaseGitLabClient{}).notifier)
+	assert.Nil(t, NewStaleMRCleanupHandlerWithClient(cfg, &MockStaleMRClient{}).notifier)
+
+	cfg.Notify.Routes = map[string][]string{notify.EventStaleMRClosed: {"team"}}
+	assert.NotNil(t, NewStaleMRCleanupHandlerWithClient(cfg, &MockStaleMRClient{}).notifier)
+	assert.Nil(t, NewAutoRebaseHandlerWithClient(cfg, &MockRebaseGitLabClient{}).notifier)
+}
+
+func TestApplyDecision_NotifiesNewManualReviews(t *testing.T) {
+	sink := &recordingSink{}
+	handler := &DataProductConfigMrReviewHandler{gitlabClient: &MockGitLabClient{}, config: createTestConfig(), notifier: sink}
+	handler.SetStateStore(store.NewMemoryStore())
+
+	mrInfo := &gitlab.MRInfo{ProjectID: 7, MRIID: 3, Title: "Grow warehouse", Author: "alice"}
+	review := func(reason string) {
+		_, err := handler.applyDecision(context.Background(), &shared.RuleEvaluation{
+			FinalDecision:   shared.Decision{Type: shared.ManualReview, Reason: reason},
+			FileValidations: map[string]*shared.FileValidationSummary{},
+		}, mrInfo)
+		assert.NoError(t, err)
+	}
+
+	review("warehouse size increased")
+	assert.Len(t, sink.notifications, 1)
+	n := sink.notifications[0]
+	assert.Equal(t, notify.EventManualReview, n.Event)
+	assert.Equal(t, "Manual review required: Grow warehouse", n.Title)
+	assert.Equal(t, "warehouse size increased", n.Message)
+	assert.Equal(t, 7, n.ProjectID)
+	assert.Equal(t, 3, n.MRIID)
+	assert.Equal(t, "alice", n.Fields["author"])
+
+	review("warehouse size increased")
+	assert.Len(t, sink.notifications, 1, "an unchanged decision is not notified again")
+
+	review("uncovered changes")
+	assert.Len(t, sink.notifications, 2, "a new reason is notified")
+}
+
+func TestAutoRebase_NotifiesConflicts(t *testing.T) {
+	sink := &recordingSink{}
+	handler := NewAutoRebaseHandlerWithClient(&config.Config{}, &MockRebaseGitLabClient{
+		openMRs:     []int{123},
+		rebaseError: fmt.Errorf("rebase failed: conflicts detected: %w", gitlab.ErrConflict),
+	})
+	handler.notifier = sink
+
+	app := createTestApp()
+	app.Post("/rebase", handler.HandleWebhook)
+	payload, _ := json.Marshal(map[string]interface{}{
+		"object_kind": "push",
+		"ref":         "refs/heads/main",
+		"project":     map[string]interface{}{"id": 456},
+	})
+	req := httptest.NewRequest("POST", "/rebase", bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	assert.Len(t, sink.notifications, 1)
+	assert.Equal(t, notify.EventRebaseConflict, sink.notifications[0].Event)
+	assert.Equal(t, 456, sink.notifications[0].ProjectID)
+	assert.Equal(t, 123, sink.notifications[0].MRIID)
+}
+
+func TestStaleMRCleanup_NotifiesClosures(t *testing.T) {
+	stale := time.Now().AddDate(0, 0, -60).Format(time.RFC3339)
+	mockClient := &MockStaleMRClient{
+		openMRs: []gitlab.MRDetails{
+			{IID: 1, Title: "Abandoned change", UpdatedAt: stale},
+			{IID: 2, Title: "Forgotten fix", UpdatedAt: stale},
+			{IID: 3, Title: "Active", UpdatedAt: time.Now().Format(time.RFC3339)},
+		},
+	}
+	sink := &recordingSink{}
+	handler := NewStaleMRCleanupHandlerWithClient(createStaleMRTestConfig(), mockClient)
+	handler.notifier = sink
+
+	_, err := handler.processCleanup(context.Background(), &StaleMRCleanupPayload{ProjectID: 123, ClosureDays: 30, DryRun: true})
+	assert.NoError(t, err)
+	assert.Empty(t, sink.notifications, "dry runs close nothing")
+
+	_, err = handler.processCleanup(context.Background(), &StaleMRCleanupPayload{ProjectID: 123, ClosureDays: 30})
+	assert.NoError(t, err)
+	assert.Len(t, sink.notifications, 1, "one notification per run")
+	n := sink.notifications[0]
+	assert.Equal(t, notify.EventStaleMRClosed, n.Event)
+	assert.Equal(t, "Closed 2 stale MRs in project 123", n.Title)
+	assert.Equal(t, "!1 Abandoned change\n!2 Forgotten fix", n.Message)
+	assert.Equal(t, "1,2", n.Fields["mr_iids"])
+}
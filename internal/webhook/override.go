@@ -0,0 +1,479 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"github.com/redhat-data-and-ai/naysayer/internal/chatops"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/override"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/redhat-data-and-ai/naysayer/internal/stats"
+	"github.com/redhat-data-and-ai/naysayer/internal/utils"
+	"go.uber.org/zap"
+)
+
+// memberAccessChecker looks up the project role of a user. The GitLab client implements
+// it; without it every override is denied.
+type memberAccessChecker interface {
+	GetMemberAccessLevel(ctx context.Context, projectID, userID int) (int, error)
+}
+
+// payloadInt reads a numeric webhook field
+func payloadInt(value interface{}) int {
+	switch v := value.(type) {
+	case float64:
+		return int(v)
+	case int:
+		return v
+	case string:
+		n, _ := strconv.Atoi(v)
+		return n
+	}
+	return 0
+}
+
+// noteSkipped responds to a note event that needs no action
+func noteSkipped(c *fiber.Ctx, reason string) error {
+	return c.JSON(fiber.Map{
+		"webhook_response": "processed",
+		"event_type":       "note",
+		"decision":         "skipped",
+		"reason":           reason,
+		"mr_approved":      false,
+	})
+}
+
+// noteMergeRequest reads the merge request a note event was posted on, nil when missing
+func noteMergeRequest(payload map[string]interface{}) (*gitlab.MRInfo, map[string]interface{}) {
+	mr, _ := payload["merge_request"].(map[string]interface{})
+	if mr == nil {
+		return nil, nil
+	}
+	projectID := 0
+	if project, ok := payload["project"].(map[string]interface{}); ok {
+		projectID = payloadInt(project["id"])
+	}
+	if projectID == 0 {
+		projectID = payloadInt(payload["project_id"])
+	}
+	mrInfo := &gitlab.MRInfo{ProjectID: projectID, MRIID: payloadInt(mr["iid"])}
+	mrInfo.State, _ = mr["state"].(string)
+	mrInfo.CreatedAt, _ = mr["created_at"].(string)
+	return mrInfo, mr
+}
+
+// handleNoteEvent applies `/naysayer approve-until` commands and, when ChatOps is
+// enabled, recheck, rebase and explain commands posted on merge requests
+func (h *DataProductConfigMrReviewHandler) handleNoteEvent(c *fiber.Ctx, payload map[string]interface{}) error {
+	ctx := c.UserContext()
+	attrs, _ := payload["object_attributes"].(map[string]interface{})
+	if attrs == nil || attrs["noteable_type"] != "MergeRequest" {
+		return noteSkipped(c, "Not a merge request comment")
+	}
+	user, _ := payload["user"].(map[string]interface{})
+	if user == nil || h.gitlabClient.IsNaysayerBotAuthor(ctx, user) {
+		return noteSkipped(c, "Comment by naysayer")
+	}
+
+	body, _ := attrs["note"].(string)
+	if h.config.ChatOps.Enabled {
+		if cmd, err := chatops.ParseCommand(body); cmd != nil || err != nil {
+			return h.handleCommand(c, payload, cmd, err)
+		}
+	}
+	if !h.config.Override.Enabled {
+		return noteSkipped(c, "No naysayer command")
+	}
+
+	now := time.Now()
+	cmd, err := override.ParseCommand(body, now, h.config.Override.MaxDays)
+	if cmd == nil && err == nil {
+		return noteSkipped(c, "No naysayer command")
+	}
+
+	mrInfo, mr := noteMergeRequest(payload)
+	if mrInfo == nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error": "Missing merge request in note event",
+		})
+	}
+	noteID := payloadInt(attrs["id"])
+	userID := payloadInt(user["id"])
+	username, _ := user["username"].(string)
+
+	if err != nil {
+		h.replyToNote(ctx, mrInfo, noteID, fmt.Sprintf("⚠️ @%s could not apply the override: %v", username, err))
+		return c.JSON(fiber.Map{
+			"webhook_response": "processed",
+			"event_type":       "note",
+			"decision":         "rejected",
+			"reason":           err.Error(),
+			"mr_approved":      false,
+			"project_id":       mrInfo.ProjectID,
+			"mr_iid":           mrInfo.MRIID,
+		})
+	}
+
+	if mrInfo.State != utils.MRStateOpened {
+		return noteSkipped(c, fmt.Sprintf("MR state is '%s', only open MRs can be overridden", mrInfo.State))
+	}
+
+	if !h.canOverride(ctx, mrInfo, userID) {
+		logging.FromContext(ctx).MRWarn(mrInfo.MRIID, "Override denied", zap.String("actor", username), zap.Int("project_id", mrInfo.ProjectID))
+		h.replyToNote(ctx, mrInfo, noteID, fmt.Sprintf("🚫 @%s overrides require at least the %s role on this project.",
+			username, accessLevelName(h.config.Override.MinAccessLevel)))
+		return c.JSON(fiber.Map{
+			"webhook_response": "processed",
+			"event_type":       "note",
+			"decision":         "denied",
+			"reason":           "Insufficient project role",
+			"mr_approved":      false,
+			"project_id":       mrInfo.ProjectID,
+			"mr_iid":           mrInfo.MRIID,
+		})
+	}
+
+	sha := ""
+	if lastCommit, ok := mr["last_commit"].(map[string]interface{}); ok {
+		sha, _ = lastCommit["id"].(string)
+	}
+	record := override.Override{
+		ProjectID: mrInfo.ProjectID,
+		MRIID:     mrInfo.MRIID,
+		Actor:     username,
+		Reason:    cmd.Reason,
+		Until:     cmd.Until,
+		CreatedAt: now.UTC(),
+		NoteID:    noteID,
+		SHA:       sha,
+	}
+
+	// Record the override before approving so the expiry job always finds it
+	if err := h.overrides.Save(record); err != nil {
+		logging.FromContext(ctx).MRError(mrInfo.MRIID, "Failed to save override", err)
+		return c.Status(500).JSON(fiber.Map{
+			"error": "Failed to save override: " + err.Error(),
+		})
+	}
+
+	message := overrideReason(&record)
+	if err := h.gitlabClient.ApproveMRWithMessage(ctx, mrInfo.ProjectID, mrInfo.MRIID, message); err != nil {
+		logging.FromContext(ctx).MRWarn(mrInfo.MRIID, "Failed to approve with message, trying simple approval", zap.Error(err))
+		if fallbackErr := h.gitlabClient.ApproveMR(ctx, mrInfo.ProjectID, mrInfo.MRIID); fallbackErr != nil {
+			if delErr := h.overrides.Delete(mrInfo.ProjectID, mrInfo.MRIID); delErr != nil {
+				logging.FromContext(ctx).MRWarn(mrInfo.MRIID, "Failed to remove override after failed approval", zap.Error(delErr))
+			}
+			logging.FromContext(ctx).MRError(mrInfo.MRIID, "Failed to approve override", fallbackErr)
+			return c.Status(500).JSON(fiber.Map{
+				"error": "Failed to approve MR: " + fallbackErr.Error(),
+			})
+		}
+	}
+
+	h.replyToNote(ctx, mrInfo, noteID, fmt.Sprintf("✅ Approved by override from @%s until %s.\n\n**Reason:** %s\n\nNaysayer re-reviews this MR when the override expires or new commits are pushed.",
+		username, formatOverrideUntil(record.Until), record.Reason))
+	h.stats.RecordDecision(stats.KindApproval, mrInfo.ProjectID, mrInfo.MRIID, mrInfo.CreatedAt)
+	logging.FromContext(ctx).MRInfo(mrInfo.MRIID, "Override granted",
+		zap.Int("project_id", mrInfo.ProjectID),
+		zap.String("actor", username),
+		zap.Time("until", record.Until),
+		zap.String("reason", record.Reason),
+		zap.String("sha", record.SHA))
+
+	return c.JSON(fiber.Map{
+		"webhook_response": "processed",
+		"event_type":       "note",
+		"decision":         "override",
+		"reason":           message,
+		"until":            record.Until.Format(time.RFC3339),
+		"mr_approved":      true,
+		"project_id":       mrInfo.ProjectID,
+		"mr_iid":           mrInfo.MRIID,
+	})
+}
+
+// canOverride checks the commenter holds the configured minimum project role. Failed
+// lookups deny the override.
+func (h *DataProductConfigMrReviewHandler) canOverride(ctx context.Context, mrInfo *gitlab.MRInfo, userID int) bool {
+	return h.hasAccessLevel(ctx, mrInfo, userID, h.config.Override.MinAccessLevel)
+}
+
+// hasAccessLevel checks a user holds at least minLevel on the project of the MR. Failed
+// lookups count as no access.
+func (h *DataProductConfigMrReviewHandler) hasAccessLevel(ctx context.Context, mrInfo *gitlab.MRInfo, userID, minLevel int) bool {
+	checker, ok := h.gitlabClient.(memberAccessChecker)
+	if !ok || userID == 0 {
+		return false
+	}
+	level, err := checker.GetMemberAccessLevel(ctx, mrInfo.ProjectID, userID)
+	if err != nil {
+		if !errors.Is(err, gitlab.ErrNotFound) {
+			logging.MRWarn(mrInfo.MRIID, "Failed to look up commenter role", zap.Error(err))
+		}
+		return false
+	}
+	return level >= minLevel
+}
+
+// replyToNote answers a command in its comment thread, or with a new comment when the
+// client cannot reply in threads
+func (h *DataProductConfigMrReviewHandler) replyToNote(ctx context.Context, mrInfo *gitlab.MRInfo, noteID int, body string) {
+	if replier, ok := h.gitlabClient.(commentReplier); ok && noteID > 0 {
+		err := replier.ReplyToMRComment(ctx, mrInfo.ProjectID, mrInfo.MRIID, noteID, body)
+		if err == nil {
+			return
+		}
+		logging.FromContext(ctx).MRWarn(mrInfo.MRIID, "Failed to reply in comment thread, adding new comment", zap.Error(err))
+	}
+	if err := h.gitlabClient.AddMRComment(ctx, mrInfo.ProjectID, mrInfo.MRIID, body); err != nil {
+		logging.FromContext(ctx).MRError(mrInfo.MRIID, "Failed to add override comment", err)
+	}
+}
+
+// applyOverride turns a manual review into an approval while a maintainer override is
+// active. Overrides are granted for a specific MR head: new commits revoke them, and the
+// manual review stands while the current head cannot be looked up.
+func (h *DataProductConfigMrReviewHandler) applyOverride(ctx context.Context, result *shared.RuleEvaluation, mrInfo *gitlab.MRInfo) {
+	if h.overrides == nil || result.FinalDecision.Type != shared.ManualReview {
+		return
+	}
+
+	o, err := h.overrides.Get(mrInfo.ProjectID, mrInfo.MRIID)
+	if err != nil {
+		logging.FromContext(ctx).MRWarn(mrInfo.MRIID, "Failed to read override", zap.Error(err))
+		return
+	}
+	if o == nil || o.Expired(time.Now()) {
+		return
+	}
+
+	if o.SHA != "" {
+		details, err := h.gitlabClient.GetMRDetails(ctx, mrInfo.ProjectID, mrInfo.MRIID)
+		if err != nil || details == nil || details.Sha == "" {
+			logging.FromContext(ctx).MRWarn(mrInfo.MRIID, "Skipping override, the MR head could not be verified",
+				zap.String("actor", o.Actor),
+				zap.String("sha", o.SHA),
+				zap.Error(err))
+			return
+		}
+		if details.Sha != o.SHA {
+			if err := h.overrides.Delete(mrInfo.ProjectID, mrInfo.MRIID); err != nil {
+				logging.FromContext(ctx).MRWarn(mrInfo.MRIID, "Failed to remove revoked override", zap.Error(err))
+			}
+			logging.FromContext(ctx).MRInfo(mrInfo.MRIID, "Override revoked by new commits", zap.String("actor", o.Actor))
+			command := "/naysayer approve-until"
+			if o.Until.IsZero() {
+				command = "/naysayer override"
+			}
+			comment := fmt.Sprintf("🔄 The override from @%s was revoked because new commits were pushed. A new `%s` command is needed to approve this MR without review.", o.Actor, command)
+			if err := h.gitlabClient.AddMRComment(ctx, mrInfo.ProjectID, mrInfo.MRIID, comment); err != nil {
+				logging.FromContext(ctx).MRError(mrInfo.MRIID, "Failed to add override revocation comment", err)
+			}
+			return
+		}
+	}
+
+	result.FinalDecision = shared.Decision{
+		Type:    shared.Approve,
+		Reason:  overrideReason(o),
+		Summary: overrideSummary,
+		Details: result.FinalDecision.Reason,
+	}
+}
+
+// overrideSummary marks decisions turned into an approval by an override
+const overrideSummary = "Decision override"
+
+// overrideReason describes an override for approval messages and decisions
+func overrideReason(o *override.Override) string {
+	if o.Until.IsZero() {
+		return fmt.Sprintf("Manual review overridden by @%s: %s", o.Actor, o.Reason)
+	}
+	return fmt.Sprintf("Approved by override from @%s until %s: %s", o.Actor, formatOverrideUntil(o.Until), o.Reason)
+}
+
+// formatOverrideUntil shows midnight expiries as a date and others as a timestamp
+func formatOverrideUntil(until time.Time) string {
+	until = until.UTC()
+	if until.Equal(until.Truncate(24 * time.Hour)) {
+		return until.Format("2006-01-02")
+	}
+	return until.Format(time.RFC3339)
+}
+
+// accessLevelName names a GitLab access level for comments
+func accessLevelName(level int) string {
+	switch {
+	case level >= gitlab.AccessLevelOwner:
+		return "Owner"
+	case level >= gitlab.AccessLevelMaintainer:
+		return "Maintainer"
+	case level >= gitlab.AccessLevelDeveloper:
+		return "Developer"
+	}
+	return fmt.Sprintf("access level %d", level)
+}
+
+// mrInfoFromDetails describes an MR fetched from the API like a webhook would
+func mrInfoFromDetails(projectID, mrIID int, details *gitlab.MRDetails) *gitlab.MRInfo {
+	mrInfo := &gitlab.MRInfo{
+		ProjectID:    projectID,
+		MRIID:        mrIID,
+		Title:        details.Title,
+		SourceBranch: details.SourceBranch,
+		TargetBranch: details.TargetBranch,
+		State:        details.State,
+		CreatedAt:    details.CreatedAt,
+		ReceivedAt:   time.Now(),
+		Draft:        details.IsDraft(),
+		HeadSHA:      details.Sha,
+	}
+	if details.Author != nil {
+		mrInfo.Author = details.Author.Username
+	}
+	return mrInfo
+}
+
+// reReview evaluates an MR again outside of a webhook, e.g. after its override expired
+func (h *DataProductConfigMrReviewHandler) reReview(ctx context.Context, projectID, mrIID int) {
+	details, err := h.gitlabClient.GetMRDetails(ctx, projectID, mrIID)
+	if err != nil || details == nil {
+		logging.FromContext(ctx).MRWarn(mrIID, "Failed to fetch MR for re-review", zap.Int("project_id", projectID), zap.Error(err))
+		return
+	}
+	if details.State != utils.MRStateOpened {
+		return
+	}
+
+	mrInfo := mrInfoFromDetails(projectID, mrIID, details)
+
+	// Draft MRs are not evaluated unless reviewed without approval, so only the override
+	// approval is withdrawn
+	if shared.IsDraftMR(&shared.MRContext{MRInfo: mrInfo}) && !h.config.Approval.ReviewDrafts {
+		if err := h.gitlabClient.ResetNaysayerApproval(ctx, projectID, mrIID); err != nil {
+			logging.FromContext(ctx).MRWarn(mrIID, "Failed to reset approval of draft MR", zap.Error(err))
+		}
+		return
+	}
+
+	result, err := h.decide(ctx, mrInfo)
+	if err != nil {
+		// Fail safe: without a decision the override approval must not stay in place
+		logging.FromContext(ctx).MRError(mrIID, "Re-review failed, resetting approval", err)
+		if err := h.gitlabClient.ResetNaysayerApproval(ctx, projectID, mrIID); err != nil {
+			logging.FromContext(ctx).MRWarn(mrIID, "Failed to reset approval", zap.Error(err))
+		}
+		return
+	}
+	if _, err := h.applyDecision(ctx, result, mrInfo); err != nil {
+		logging.FromContext(ctx).MRError(mrIID, "Failed to apply re-review decision", err)
+	}
+}
+
+// OverrideExpirer periodically removes expired overrides and re-reviews their MRs, which
+// withdraws the approval unless the rules now approve the MR on their own
+type OverrideExpirer struct {
+	handler *DataProductConfigMrReviewHandler
+	now     func() time.Time
+
+	mu   sync.Mutex
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewOverrideExpirer creates an expirer for the overrides of handler. It returns nil when
+// overrides are disabled.
+func NewOverrideExpirer(handler *DataProductConfigMrReviewHandler) *OverrideExpirer {
+	if handler == nil || handler.overrides == nil {
+		return nil
+	}
+	return &OverrideExpirer{handler: handler, now: time.Now}
+}
+
+// Check handles every override that has expired
+func (e *OverrideExpirer) Check(ctx context.Context) {
+	if e == nil {
+		return
+	}
+	h := e.handler
+	expired, err := h.overrides.Expired(e.now())
+	if err != nil {
+		logging.FromContext(ctx).Warn("Failed to list expired overrides", zap.Error(err))
+		return
+	}
+
+	for _, o := range expired {
+		if err := h.overrides.Delete(o.ProjectID, o.MRIID); err != nil {
+			logging.FromContext(ctx).MRWarn(o.MRIID, "Failed to remove expired override", zap.Error(err))
+			continue
+		}
+		logging.FromContext(ctx).MRInfo(o.MRIID, "Override expired",
+			zap.Int("project_id", o.ProjectID),
+			zap.String("actor", o.Actor),
+			zap.Time("until", o.Until))
+
+		comment := fmt.Sprintf("⏰ The override from @%s expired on %s. Naysayer is reviewing this MR again.", o.Actor, formatOverrideUntil(o.Until))
+		if err := h.gitlabClient.AddMRComment(ctx, o.ProjectID, o.MRIID, comment); err != nil {
+			logging.FromContext(ctx).MRError(o.MRIID, "Failed to add override expiry comment", err)
+		}
+		h.reReview(ctx, o.ProjectID, o.MRIID)
+	}
+}
+
+// Start runs Check immediately and then every interval until Stop is called
+func (e *OverrideExpirer) Start(interval time.Duration) {
+	if e == nil {
+		return
+	}
+	e.mu.Lock()
+	if e.stop != nil {
+		e.mu.Unlock()
+		return
+	}
+	e.stop = make(chan struct{})
+	stop := e.stop
+	e.mu.Unlock()
+
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+		ctx := context.Background()
+		e.Check(ctx)
+		if interval <= 0 {
+			return
+		}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				e.Check(ctx)
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop halts the periodic expiry check
+func (e *OverrideExpirer) Stop() {
+	if e == nil {
+		return
+	}
+	e.mu.Lock()
+	stop := e.stop
+	e.stop = nil
+	e.mu.Unlock()
+
+	if stop != nil {
+		close(stop)
+		e.wg.Wait()
+	}
+}
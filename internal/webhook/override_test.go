@@ -0,0 +1,242 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/override"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+)
+
+// overrideGitLabClient records approvals and comments and reports member roles
+type overrideGitLabClient struct {
+	*MockGitLabClient
+	accessLevel int
+	details     *gitlab.MRDetails
+	detailsErr  error
+	approvals   []string
+	comments    []string
+	resets      int
+}
+
+func (m *overrideGitLabClient) GetMemberAccessLevel(ctx context.Context, projectID, userID int) (int, error) {
+	if m.accessLevel == 0 {
+		return 0, gitlab.ErrNotFound
+	}
+	return m.accessLevel, nil
+}
+
+func (m *overrideGitLabClient) GetMRDetails(ctx context.Context, projectID, mrIID int) (*gitlab.MRDetails, error) {
+	return m.details, m.detailsErr
+}
+
+func (m *overrideGitLabClient) ApproveMRWithMessage(ctx context.Context, projectID, mrIID int, message string) error {
+	m.approvals = append(m.approvals, message)
+	return nil
+}
+
+func (m *overrideGitLabClient) AddMRComment(ctx context.Context, projectID, mrIID int, comment string) error {
+	m.comments = append(m.comments, comment)
+	return nil
+}
+
+func (m *overrideGitLabClient) ResetNaysayerApproval(ctx context.Context, projectID, mrIID int) error {
+	m.resets++
+	return nil
+}
+
+func newOverrideTestHandler(client *overrideGitLabClient) *DataProductConfigMrReviewHandler {
+	cfg := createTestConfig()
+	cfg.Override = config.OverrideConfig{Enabled: true, MaxDays: 30, MinAccessLevel: gitlab.AccessLevelMaintainer}
+	handler := &DataProductConfigMrReviewHandler{gitlabClient: client, config: cfg}
+	handler.SetStateStore(store.NewMemoryStore())
+	return handler
+}
+
+func postNote(t *testing.T, handler *DataProductConfigMrReviewHandler, note string) map[string]interface{} {
+	app := createTestApp()
+	app.Post("/webhook", handler.HandleWebhook)
+
+	payload := map[string]interface{}{
+		"object_kind":       "note",
+		"project":           map[string]interface{}{"id": 456},
+		"user":              map[string]interface{}{"id": 7, "username": "maintainer"},
+		"object_attributes": map[string]interface{}{"id": 99, "note": note, "noteable_type": "MergeRequest"},
+		"merge_request": map[string]interface{}{
+			"iid":         123,
+			"state":       "opened",
+			"last_commit": map[string]interface{}{"id": "abc123"},
+		},
+	}
+	body, _ := json.Marshal(payload)
+	req := httptest.NewRequest("POST", "/webhook", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	var response map[string]interface{}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+	return response
+}
+
+func TestHandleNoteEvent_ApproveUntil(t *testing.T) {
+	client := &overrideGitLabClient{MockGitLabClient: &MockGitLabClient{}, accessLevel: gitlab.AccessLevelMaintainer}
+	handler := newOverrideTestHandler(client)
+	until := time.Now().UTC().AddDate(0, 0, 7).Format("2006-01-02")
+
+	response := postNote(t, handler, `/naysayer approve-until `+until+` reason:"migration window"`)
+	assert.Equal(t, "override", response["decision"])
+	assert.Equal(t, true, response["mr_approved"])
+	assert.Equal(t, []string{"Approved by override from @maintainer until " + until + ": migration window"}, client.approvals)
+	assert.Len(t, client.comments, 1)
+	assert.Contains(t, client.comments[0], "✅ Approved by override from @maintainer")
+
+	saved, err := handler.overrides.Get(456, 123)
+	assert.NoError(t, err)
+	assert.NotNil(t, saved)
+	assert.Equal(t, "maintainer", saved.Actor)
+	assert.Equal(t, "migration window", saved.Reason)
+	assert.Equal(t, "abc123", saved.SHA)
+	assert.Equal(t, 99, saved.NoteID)
+}
+
+func TestHandleNoteEvent_DeniesInsufficientRole(t *testing.T) {
+	client := &overrideGitLabClient{MockGitLabClient: &MockGitLabClient{}, accessLevel: gitlab.AccessLevelDeveloper}
+	handler := newOverrideTestHandler(client)
+	until := time.Now().UTC().AddDate(0, 0, 7).Format("2006-01-02")
+
+	response := postNote(t, handler, `/naysayer approve-until `+until+` reason:"migration window"`)
+	assert.Equal(t, "denied", response["decision"])
+	assert.Empty(t, client.approvals)
+	assert.Contains(t, client.comments[0], "at least the Maintainer role")
+
+	saved, _ := handler.overrides.Get(456, 123)
+	assert.Nil(t, saved)
+}
+
+func TestHandleNoteEvent_RejectsInvalidCommand(t *testing.T) {
+	client := &overrideGitLabClient{MockGitLabClient: &MockGitLabClient{}, accessLevel: gitlab.AccessLevelMaintainer}
+	handler := newOverrideTestHandler(client)
+
+	response := postNote(t, handler, `/naysayer approve-until 2020-01-01 reason:"too late"`)
+	assert.Equal(t, "rejected", response["decision"])
+	assert.Empty(t, client.approvals)
+	assert.Contains(t, client.comments[0], "is in the past")
+}
+
+func TestHandleNoteEvent_IgnoresOtherComments(t *testing.T) {
+	client := &overrideGitLabClient{MockGitLabClient: &MockGitLabClient{}, accessLevel: gitlab.AccessLevelMaintainer}
+	handler := newOverrideTestHandler(client)
+
+	response := postNote(t, handler, "Looks good to me")
+	assert.Equal(t, "skipped", response["decision"])
+	assert.Empty(t, client.approvals)
+	assert.Empty(t, client.comments)
+}
+
+func TestApplyOverride(t *testing.T) {
+	ctx := context.Background()
+	client := &overrideGitLabClient{MockGitLabClient: &MockGitLabClient{}, details: &gitlab.MRDetails{Sha: "abc123"}}
+	handler := newOverrideTestHandler(client)
+	mrInfo := &gitlab.MRInfo{ProjectID: 456, MRIID: 123}
+	assert.NoError(t, handler.overrides.Save(override.Override{
+		ProjectID: 456, MRIID: 123, Actor: "maintainer", Reason: "migration window",
+		Until: time.Now().Add(24 * time.Hour), SHA: "abc123",
+	}))
+
+	result := &shared.RuleEvaluation{FinalDecision: shared.Decision{Type: shared.ManualReview, Reason: "warehouse changed"}}
+	handler.applyOverride(ctx, result, mrInfo)
+	assert.Equal(t, shared.Approve, result.FinalDecision.Type)
+	assert.Equal(t, "Decision override", result.FinalDecision.Summary)
+	assert.Contains(t, result.FinalDecision.Reason, "Approved by override from @maintainer")
+	assert.Equal(t, "warehouse changed", result.FinalDecision.Details)
+
+	// The manual review stands while the MR head cannot be verified
+	client.detailsErr = errors.New("gitlab unavailable")
+	result = &shared.RuleEvaluation{FinalDecision: shared.Decision{Type: shared.ManualReview, Reason: "warehouse changed"}}
+	handler.applyOverride(ctx, result, mrInfo)
+	assert.Equal(t, shared.ManualReview, result.FinalDecision.Type)
+	saved, _ := handler.overrides.Get(456, 123)
+	assert.NotNil(t, saved, "the override is kept for the next evaluation")
+	client.detailsErr = nil
+
+	// New commits revoke the override
+	client.details.Sha = "def456"
+	result = &shared.RuleEvaluation{FinalDecision: shared.Decision{Type: shared.ManualReview, Reason: "warehouse changed"}}
+	handler.applyOverride(ctx, result, mrInfo)
+	assert.Equal(t, shared.ManualReview, result.FinalDecision.Type)
+	assert.Contains(t, client.comments[0], "was revoked because new commits were pushed")
+	saved, _ = handler.overrides.Get(456, 123)
+	assert.Nil(t, saved)
+}
+
+func TestOverrideExpirer_Check(t *testing.T) {
+	ctx := context.Background()
+	client := &overrideGitLabClient{
+		MockGitLabClient: &MockGitLabClient{},
+		details:          &gitlab.MRDetails{IID: 123, Title: "Draft: migrate warehouses", State: "opened"},
+	}
+	handler := newOverrideTestHandler(client)
+	expirer := NewOverrideExpirer(handler)
+	now := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	expirer.now = func() time.Time { return now }
+
+	assert.NoError(t, handler.overrides.Save(override.Override{ProjectID: 456, MRIID: 123, Actor: "maintainer", Until: now}))
+	assert.NoError(t, handler.overrides.Save(override.Override{ProjectID: 456, MRIID: 124, Actor: "maintainer", Until: now.Add(time.Hour)}))
+
+	expirer.Check(ctx)
+	assert.Len(t, client.comments, 1)
+	assert.Contains(t, client.comments[0], "expired on 2026-07-01")
+	assert.Equal(t, 1, client.resets, "the approval of the draft MR is withdrawn")
+
+	expired, _ := handler.overrides.Get(456, 123)
+	assert.Nil(t, expired)
+	active, _ := handler.overrides.Get(456, 124)
+	assert.NotNil(t, active)
+}
+
+func TestOverrideExpirer_ExpiresOverridesAfterRestart(t *testing.T) {
+	ctx := context.Background()
+	client := &overrideGitLabClient{
+		MockGitLabClient: &MockGitLabClient{},
+		details:          &gitlab.MRDetails{IID: 123, Title: "Draft: migrate warehouses", State: "opened"},
+	}
+	dir := t.TempDir()
+	now := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	before := &DataProductConfigMrReviewHandler{gitlabClient: client, config: createTestConfig()}
+	before.config.Override = config.OverrideConfig{Enabled: true, MaxDays: 30, Dir: dir}
+	before.SetStateStore(store.NewMemoryStore())
+	assert.NoError(t, before.overrides.Save(override.Override{ProjectID: 456, MRIID: 123, Actor: "maintainer", Until: now}))
+
+	// The restarted process starts with an empty state store
+	after := &DataProductConfigMrReviewHandler{gitlabClient: client, config: createTestConfig()}
+	after.config.Override = before.config.Override
+	after.SetStateStore(store.NewMemoryStore())
+	expirer := NewOverrideExpirer(after)
+	expirer.now = func() time.Time { return now }
+
+	expirer.Check(ctx)
+	assert.Len(t, client.comments, 1)
+	assert.Contains(t, client.comments[0], "expired on 2026-07-01")
+	assert.Equal(t, 1, client.resets, "the approval granted before the restart is withdrawn")
+	expired, _ := after.overrides.Get(456, 123)
+	assert.Nil(t, expired)
+}
+
+func TestNewOverrideExpirer_Disabled(t *testing.T) {
+	handler := &DataProductConfigMrReviewHandler{config: createTestConfig()}
+	handler.SetStateStore(store.NewMemoryStore())
+	assert.Nil(t, NewOverrideExpirer(handler))
+}
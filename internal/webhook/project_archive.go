@@ -0,0 +1,32 @@
+package webhook
+
+import (
+	"context"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"go.uber.org/zap"
+)
+
+// EventProjectArchived is the notification event sent when an archived project is dropped
+// from auto-rebase catch-up
+const EventProjectArchived = "project_archived"
+
+// projectGetter is implemented by GitLab clients that can read project settings
+type projectGetter interface {
+	GetProject(ctx context.Context, projectID int) (*gitlab.Project, error)
+}
+
+// isProjectArchived reports whether a project is archived. Clients without project lookups
+// and failed lookups count as not archived, so the caller proceeds and reports its own errors.
+func isProjectArchived(ctx context.Context, client gitlab.GitLabClient, projectID int) bool {
+	getter, ok := client.(projectGetter)
+	if !ok {
+		return false
+	}
+	project, err := getter.GetProject(ctx, projectID)
+	if err != nil {
+		logging.Warn("Failed to look up project", zap.Int("project_id", projectID), zap.Error(err))
+		return false
+	}
+	return project.Archived
+}
@@ -0,0 +1,150 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/notify"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+)
+
+// archivedRebaseClient is a rebase mock that reports its projects as archived
+type archivedRebaseClient struct {
+	*MockRebaseGitLabClient
+	archived  bool
+	lookupErr error
+}
+
+func (m *archivedRebaseClient) GetProject(ctx context.Context, projectID int) (*gitlab.Project, error) {
+	if m.lookupErr != nil {
+		return nil, m.lookupErr
+	}
+	return &gitlab.Project{ID: projectID, Archived: m.archived}, nil
+}
+
+// archivedStaleMRClient is a stale MR cleanup mock that reports its projects as archived
+type archivedStaleMRClient struct {
+	*MockStaleMRClient
+}
+
+func (m *archivedStaleMRClient) GetProject(ctx context.Context, projectID int) (*gitlab.Project, error) {
+	return &gitlab.Project{ID: projectID, Archived: true}, nil
+}
+
+// recordingSink captures notifications
+type recordingSink struct {
+	notifications []notify.Notification
+}
+
+func (s *recordingSink) Notify(n notify.Notification) error {
+	s.notifications = append(s.notifications, n)
+	return nil
+}
+
+func TestIsProjectArchived(t *testing.T) {
+	assert.False(t, isProjectArchived(context.Background(), &MockRebaseGitLabClient{}, 1), "clients without project lookups are not archived")
+	assert.False(t, isProjectArchived(context.Background(), &archivedRebaseClient{MockRebaseGitLabClient: &MockRebaseGitLabClient{}}, 1))
+	assert.True(t, isProjectArchived(context.Background(), &archivedRebaseClient{MockRebaseGitLabClient: &MockRebaseGitLabClient{}, archived: true}, 1))
+	assert.False(t, isProjectArchived(context.Background(), &archivedRebaseClient{
+		MockRebaseGitLabClient: &MockRebaseGitLabClient{},
+		archived:               true,
+		lookupErr:              errors.New("boom"),
+	}, 1), "failed lookups are not archived")
+}
+
+func TestAutoRebaseHandler_SkipsArchivedProject(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &archivedRebaseClient{MockRebaseGitLabClient: &MockRebaseGitLabClient{openMRs: []int{1, 2}}, archived: true}
+	handler := NewAutoRebaseHandlerWithClient(createTestConfig(), mockClient)
+	st := store.NewMemoryStore()
+	handler.SetStateStore(st)
+
+	_, err := handler.RunRebasePass(ctx, 456, "main")
+	assert.True(t, errors.Is(err, gitlab.ErrArchived))
+
+	app := createTestApp()
+	app.Post("/rebase", handler.HandleWebhook)
+
+	payloadBytes, _ := json.Marshal(map[string]interface{}{
+		"object_kind": "push",
+		"ref":         "refs/heads/main",
+		"after":       "new-head-sha",
+		"project":     map[string]interface{}{"id": 456},
+	})
+	req := httptest.NewRequest("POST", "/rebase", bytes.NewReader(payloadBytes))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	var body map[string]interface{}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, "skipped", body["status"])
+	assert.Equal(t, "Project is archived", body["reason"])
+
+	assert.Empty(t, mockClient.capturedRebaseMRs)
+	_, found, _ := st.Get(processedCommitKey(456, "main"))
+	assert.False(t, found, "archived projects are not recorded for catch-up")
+}
+
+func TestStaleMRCleanupHandler_SkipsArchivedProject(t *testing.T) {
+	mockClient := &archivedStaleMRClient{MockStaleMRClient: &MockStaleMRClient{
+		openMRs: []gitlab.MRDetails{{IID: 1, UpdatedAt: "2020-01-01T00:00:00Z"}},
+	}}
+	handler := NewStaleMRCleanupHandlerWithClient(createStaleMRTestConfig(), mockClient)
+
+	app := fiber.New()
+	app.Post("/stale-mr-cleanup", handler.HandleWebhook)
+
+	payloadBytes, _ := json.Marshal(map[string]interface{}{"project_id": 123})
+	req := httptest.NewRequest("POST", "/stale-mr-cleanup", bytes.NewReader(payloadBytes))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	var response StaleMRCleanupResponse
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+	assert.Equal(t, "skipped", response.Status)
+	assert.Equal(t, "Project is archived", response.Reason)
+	assert.Empty(t, mockClient.closedMRs)
+	assert.Empty(t, mockClient.addedComments)
+}
+
+func TestBacklogProcessor_DropsArchivedProject(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &archivedRebaseClient{MockRebaseGitLabClient: &MockRebaseGitLabClient{openMRs: []int{1}}, archived: true}
+	handler := NewAutoRebaseHandlerWithClient(createTestConfig(), mockClient)
+	st := store.NewMemoryStore()
+	_ = st.Put(processedCommitKey(42, "main"), []byte("older-sha"))
+	_ = st.Put(processedCommitKey(42, "release"), []byte("older-sha"))
+	_ = st.Put(processedCommitKey(7, "main"), []byte("sha"))
+
+	processor := NewBacklogProcessor(handler, st, []RebaseTarget{{ProjectID: 42, Branch: "main"}})
+	sink := &recordingSink{}
+	processor.SetNotificationSink(sink)
+
+	ran, err := processor.Check(ctx, RebaseTarget{ProjectID: 42, Branch: "main"})
+	assert.NoError(t, err)
+	assert.False(t, ran)
+	assert.Empty(t, mockClient.capturedRebaseMRs)
+
+	assert.Equal(t, []RebaseTarget{{ProjectID: 7, Branch: "main"}}, processor.allTargets())
+
+	if assert.Len(t, sink.notifications, 1) {
+		n := sink.notifications[0]
+		assert.Equal(t, EventProjectArchived, n.Event)
+		assert.Equal(t, 42, n.ProjectID)
+		assert.Equal(t, "main,release", n.Fields["branches"])
+	}
+}
@@ -0,0 +1,88 @@
+package webhook
+
+import (
+	"sort"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"go.uber.org/zap"
+)
+
+// RuleCatalogEntry describes a registered rule and where rules.yaml enables it
+type RuleCatalogEntry struct {
+	Name        string                     `json:"name"`
+	Description string                     `json:"description"`
+	Version     string                     `json:"version"`
+	Category    string                     `json:"category"`
+	Sections    []string                   `json:"sections"` // "file/section" entries enabling the rule
+	Schedule    *shared.RuleScheduleStatus `json:"schedule,omitempty"`
+}
+
+// RulesCatalogHandler lists the available rules and their activation status
+type RulesCatalogHandler struct {
+	configPath string
+	now        func() time.Time
+}
+
+// NewRulesCatalogHandler creates a catalog handler reading the section rule configuration
+func NewRulesCatalogHandler() *RulesCatalogHandler {
+	return &RulesCatalogHandler{configPath: rules.RulesConfigPath, now: time.Now}
+}
+
+// HandleCatalog lists the registered rules sorted by name, with the sections enabling each
+// rule and, for scheduled rules, whether the rule is currently active
+func (h *RulesCatalogHandler) HandleCatalog(c *fiber.Ctx) error {
+	ruleConfig, err := config.LoadRuleConfig(h.configPath)
+	if err != nil {
+		logging.Error("Failed to load rule configuration for the rules catalog", zap.Error(err))
+		return c.Status(500).JSON(fiber.Map{
+			"error": "failed to load rule configuration",
+		})
+	}
+
+	sections := make(map[string][]string)
+	for _, file := range ruleConfig.Files {
+		if !file.Enabled {
+			continue
+		}
+		for _, section := range file.Sections {
+			for _, ruleConfig := range section.RuleConfigs {
+				if ruleConfig.Enabled {
+					sections[ruleConfig.Name] = append(sections[ruleConfig.Name], file.Name+"/"+section.Name)
+				}
+			}
+		}
+	}
+	schedules := make(map[string]shared.RuleScheduleStatus)
+	for _, status := range rules.RuleScheduleStatuses(ruleConfig.RuleSchedules, h.now()) {
+		schedules[status.Rule] = status
+	}
+
+	catalog := make([]RuleCatalogEntry, 0)
+	for name, info := range rules.ListAvailableRules() {
+		entry := RuleCatalogEntry{
+			Name:        name,
+			Description: info.Description,
+			Version:     info.Version,
+			Category:    info.Category,
+			Sections:    sections[name],
+		}
+		if entry.Sections == nil {
+			entry.Sections = []string{}
+		}
+		if status, ok := schedules[name]; ok {
+			entry.Schedule = &status
+		}
+		catalog = append(catalog, entry)
+	}
+	sort.Slice(catalog, func(i, j int) bool { return catalog[i].Name < catalog[j].Name })
+
+	return c.JSON(fiber.Map{
+		"rules": catalog,
+		"count": len(catalog),
+	})
+}
@@ -0,0 +1,90 @@
+package webhook
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const rulesCatalogTestConfig = `enabled: true
+files:
+  - name: product_configs
+    path: "**/"
+    filename: "product.{yaml,yml}"
+    parser_type: yaml
+    enabled: true
+    default_action: manual_review
+    sections:
+      - name: warehouses
+        yaml_path: warehouses
+        auto_approve: false
+        rule_configs:
+          - name: warehouse_rule
+            enabled: true
+          - name: metadata_rule
+            enabled: false
+rule_schedules:
+  - rule: warehouse_rule
+    windows:
+      - from: "2026-12-01"
+        to: "2026-12-14"
+`
+
+func TestRulesCatalog_ListsRulesWithSchedules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(rulesCatalogTestConfig), 0o600))
+
+	handler := NewRulesCatalogHandler()
+	handler.configPath = path
+	handler.now = func() time.Time { return time.Date(2026, 12, 3, 12, 0, 0, 0, time.UTC) }
+
+	app := createTestApp()
+	app.Get("/api/v1/rules", handler.HandleCatalog)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/rules", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	var body struct {
+		Rules []RuleCatalogEntry `json:"rules"`
+		Count int                `json:"count"`
+	}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, len(body.Rules), body.Count)
+
+	entries := make(map[string]RuleCatalogEntry)
+	for _, entry := range body.Rules {
+		entries[entry.Name] = entry
+	}
+	warehouse, ok := entries["warehouse_rule"]
+	if assert.True(t, ok) {
+		assert.Equal(t, []string{"product_configs/warehouses"}, warehouse.Sections)
+		if assert.NotNil(t, warehouse.Schedule) {
+			assert.True(t, warehouse.Schedule.Active)
+			assert.Equal(t, "active during window 2026-12-01 - 2026-12-14", warehouse.Schedule.Reason)
+		}
+	}
+	for name, entry := range entries {
+		if name != "warehouse_rule" {
+			assert.Nil(t, entry.Schedule, name)
+			assert.Empty(t, entry.Sections, "disabled rules are not listed as enabled in a section")
+		}
+	}
+}
+
+func TestRulesCatalog_InvalidConfig(t *testing.T) {
+	handler := NewRulesCatalogHandler()
+	handler.configPath = filepath.Join(t.TempDir(), "missing.yaml")
+
+	app := createTestApp()
+	app.Get("/api/v1/rules", handler.HandleCatalog)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/rules", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 500, resp.StatusCode)
+}
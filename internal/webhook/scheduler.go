@@ -0,0 +1,333 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/cron"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/projectfilter"
+	"go.uber.org/zap"
+)
+
+// Scheduled task kinds
+const (
+	TaskAutoRebase     = "auto_rebase"
+	TaskStaleMRCleanup = "stale_mr_cleanup"
+)
+
+// taskSubsystems maps task kinds to the subsystems the project filter enables per project
+var taskSubsystems = map[string]string{
+	TaskAutoRebase:     config.EndpointAutoRebase,
+	TaskStaleMRCleanup: config.EndpointStaleMRCleanup,
+}
+
+// Scheduled run statuses
+const (
+	RunCompleted = "completed"
+	RunSkipped   = "skipped"
+	RunFailed    = "failed"
+)
+
+// ScheduledTask is a maintenance pass run for one project on a cron schedule
+type ScheduledTask struct {
+	Kind      string
+	ProjectID int
+	Branch    string // Target branch of auto-rebase passes
+	Schedule  *cron.Schedule
+}
+
+// ParseScheduledTasks parses "<project_id>[:<branch>]=<cron>" entries of the given kind.
+// Stale MR cleanups take no branch.
+func ParseScheduledTasks(kind string, entries []string) ([]ScheduledTask, error) {
+	tasks := make([]ScheduledTask, 0, len(entries))
+	for _, entry := range entries {
+		targetPart, expr, found := strings.Cut(entry, "=")
+		if !found || strings.TrimSpace(expr) == "" {
+			return nil, fmt.Errorf("invalid %s schedule %q: expected <project_id>[:<branch>]=<cron>", kind, entry)
+		}
+		schedule, err := cron.Parse(strings.TrimSpace(expr))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s schedule %q: %w", kind, entry, err)
+		}
+
+		task := ScheduledTask{Kind: kind, Schedule: schedule}
+		switch kind {
+		case TaskAutoRebase:
+			targets, err := ParseRebaseTargets([]string{targetPart})
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s schedule %q: %w", kind, entry, err)
+			}
+			task.ProjectID, task.Branch = targets[0].ProjectID, targets[0].Branch
+		case TaskStaleMRCleanup:
+			projectID, err := strconv.Atoi(strings.TrimSpace(targetPart))
+			if err != nil || projectID <= 0 {
+				return nil, fmt.Errorf("invalid %s schedule %q: expected <project_id>=<cron>", kind, entry)
+			}
+			task.ProjectID = projectID
+		default:
+			return nil, fmt.Errorf("unknown scheduled task kind %q", kind)
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
+
+// ScheduledRunSummary summarizes one scheduled run
+type ScheduledRunSummary struct {
+	Kind      string
+	ProjectID int
+	Branch    string
+	Status    string
+	StartedAt time.Time
+	Duration  time.Duration
+	TotalMRs  int
+	Eligible  int // MRs eligible for rebasing
+	Rebased   int
+	Closed    int
+	Failed    int
+	Reason    string // Why the run was skipped or failed
+}
+
+// ScheduledRunStats aggregates the scheduled runs of one task kind and project since startup
+type ScheduledRunStats struct {
+	Kind      string
+	ProjectID int
+	Runs      map[string]int64 // By status
+	Rebased   int64
+	Closed    int64
+	Failed    int64
+	LastRun   time.Time
+}
+
+var (
+	scheduledRunsMu sync.Mutex
+	scheduledRuns   = make(map[[2]string]*ScheduledRunStats)
+)
+
+// recordScheduledRun adds a run summary to the statistics exported as metrics
+func recordScheduledRun(summary ScheduledRunSummary) {
+	scheduledRunsMu.Lock()
+	defer scheduledRunsMu.Unlock()
+
+	key := [2]string{summary.Kind, strconv.Itoa(summary.ProjectID)}
+	runStats, ok := scheduledRuns[key]
+	if !ok {
+		runStats = &ScheduledRunStats{Kind: summary.Kind, ProjectID: summary.ProjectID, Runs: make(map[string]int64)}
+		scheduledRuns[key] = runStats
+	}
+	runStats.Runs[summary.Status]++
+	runStats.Rebased += int64(summary.Rebased)
+	runStats.Closed += int64(summary.Closed)
+	runStats.Failed += int64(summary.Failed)
+	runStats.LastRun = summary.StartedAt.Add(summary.Duration)
+}
+
+// ScheduledRuns returns the scheduled run statistics since startup, sorted by kind and project
+func ScheduledRuns() []ScheduledRunStats {
+	scheduledRunsMu.Lock()
+	defer scheduledRunsMu.Unlock()
+
+	result := make([]ScheduledRunStats, 0, len(scheduledRuns))
+	for _, runStats := range scheduledRuns {
+		runs := make(map[string]int64, len(runStats.Runs))
+		for status, count := range runStats.Runs {
+			runs[status] = count
+		}
+		copied := *runStats
+		copied.Runs = runs
+		result = append(result, copied)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Kind != result[j].Kind {
+			return result[i].Kind < result[j].Kind
+		}
+		return result[i].ProjectID < result[j].ProjectID
+	})
+	return result
+}
+
+// Scheduler runs auto-rebase passes and stale MR cleanups on cron schedules, for
+// projects whose pushes or cleanups are not delivered as webhooks
+type Scheduler struct {
+	rebase  *AutoRebaseHandler
+	cleanup *StaleMRCleanupHandler
+	tasks   []ScheduledTask
+	now     func() time.Time
+
+	mu   sync.Mutex
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewScheduler creates a scheduler; runs of a kind whose handler is nil are skipped
+func NewScheduler(rebase *AutoRebaseHandler, cleanup *StaleMRCleanupHandler, tasks []ScheduledTask) *Scheduler {
+	return &Scheduler{
+		rebase:  rebase,
+		cleanup: cleanup,
+		tasks:   tasks,
+		now:     func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// RunDue runs every task whose schedule matches the minute of t
+func (s *Scheduler) RunDue(ctx context.Context, t time.Time) []ScheduledRunSummary {
+	var summaries []ScheduledRunSummary
+	for _, task := range s.tasks {
+		if task.Schedule.Matches(t) {
+			summaries = append(summaries, s.Run(ctx, task))
+		}
+	}
+	return summaries
+}
+
+// Run runs one task, logs its summary and records it for the metrics endpoint
+func (s *Scheduler) Run(ctx context.Context, task ScheduledTask) ScheduledRunSummary {
+	summary := ScheduledRunSummary{Kind: task.Kind, ProjectID: task.ProjectID, Branch: task.Branch, StartedAt: s.now()}
+	allowed, reason := true, ""
+	if subsystem, ok := taskSubsystems[task.Kind]; ok {
+		allowed, reason = projectfilter.Default().Check(ctx, "", subsystem, task.ProjectID, "")
+	}
+	switch {
+	case !allowed:
+		summary.Status, summary.Reason = RunSkipped, reason
+	case task.Kind == TaskAutoRebase:
+		s.runRebase(ctx, task, &summary)
+	case task.Kind == TaskStaleMRCleanup:
+		s.runCleanup(ctx, task, &summary)
+	default:
+		summary.Status, summary.Reason = RunSkipped, fmt.Sprintf("unknown task kind %q", task.Kind)
+	}
+	summary.Duration = s.now().Sub(summary.StartedAt)
+
+	recordScheduledRun(summary)
+	switch summary.Status {
+	case RunFailed:
+		logging.Error("Scheduled run failed",
+			zap.String("kind", summary.Kind),
+			zap.Int("project_id", summary.ProjectID),
+			zap.Duration("duration", summary.Duration),
+			zap.String("reason", summary.Reason))
+	case RunSkipped:
+		logging.Info("Scheduled run skipped",
+			zap.String("kind", summary.Kind),
+			zap.Int("project_id", summary.ProjectID),
+			zap.String("reason", summary.Reason))
+	default:
+		logging.Info("Scheduled run completed",
+			zap.String("kind", summary.Kind),
+			zap.Int("project_id", summary.ProjectID),
+			zap.Duration("duration", summary.Duration),
+			zap.Int("total", summary.TotalMRs),
+			zap.Int("eligible", summary.Eligible),
+			zap.Int("rebased", summary.Rebased),
+			zap.Int("closed", summary.Closed),
+			zap.Int("failed", summary.Failed))
+	}
+	return summary
+}
+
+// runRebase runs the auto-rebase eligibility pass for the task's branch
+func (s *Scheduler) runRebase(ctx context.Context, task ScheduledTask, summary *ScheduledRunSummary) {
+	if s.rebase == nil {
+		summary.Status, summary.Reason = RunSkipped, "auto-rebase is not configured"
+		return
+	}
+	pass, err := s.rebase.RunRebasePass(ctx, task.ProjectID, task.Branch)
+	if errors.Is(err, gitlab.ErrArchived) {
+		summary.Status, summary.Reason = RunSkipped, "project is archived"
+		return
+	}
+	if err != nil {
+		summary.Status, summary.Reason = RunFailed, err.Error()
+		return
+	}
+	summary.Status = RunCompleted
+	summary.TotalMRs, summary.Eligible = pass.TotalMRs, pass.EligibleMRs
+	summary.Rebased, summary.Failed = pass.Successful, pass.Failed
+}
+
+// runCleanup closes the project's stale MRs with the configured closure threshold
+func (s *Scheduler) runCleanup(ctx context.Context, task ScheduledTask, summary *ScheduledRunSummary) {
+	if s.cleanup == nil {
+		summary.Status, summary.Reason = RunSkipped, "stale MR cleanup is not configured"
+		return
+	}
+	response, err := s.cleanup.processCleanup(ctx, &StaleMRCleanupPayload{
+		ProjectID:        task.ProjectID,
+		ClosureDays:      s.cleanup.config.StaleMR.ClosureDays,
+		BranchDays:       s.cleanup.config.StaleMR.BranchDays,
+		DraftClosureDays: s.cleanup.config.StaleMR.DraftClosureDays,
+	})
+	if err != nil {
+		summary.Status, summary.Reason = RunFailed, err.Error()
+		return
+	}
+	summary.Status, summary.Reason = response.Status, response.Reason
+	summary.TotalMRs, summary.Closed, summary.Failed = response.TotalMRs, response.Closed, response.Failed
+}
+
+// nextRun returns the earliest scheduled minute after t, or the zero time when no task follows
+func (s *Scheduler) nextRun(t time.Time) time.Time {
+	var next time.Time
+	for _, task := range s.tasks {
+		if candidate := task.Schedule.Next(t); !candidate.IsZero() && (next.IsZero() || candidate.Before(next)) {
+			next = candidate
+		}
+	}
+	return next
+}
+
+// Start runs due tasks at every scheduled minute until Stop is called
+func (s *Scheduler) Start() {
+	s.mu.Lock()
+	if s.stop != nil || len(s.tasks) == 0 {
+		s.mu.Unlock()
+		return
+	}
+	s.stop = make(chan struct{})
+	stop := s.stop
+	s.mu.Unlock()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ctx := context.Background()
+		for {
+			now := s.now()
+			next := s.nextRun(now)
+			if next.IsZero() {
+				return
+			}
+			timer := time.NewTimer(next.Sub(now))
+			select {
+			case <-timer.C:
+				s.RunDue(ctx, next)
+			case <-stop:
+				timer.Stop()
+				return
+			}
+		}
+	}()
+}
+
+// Stop halts the scheduler and waits for a running task to finish
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	stop := s.stop
+	s.stop = nil
+	s.mu.Unlock()
+
+	if stop != nil {
+		close(stop)
+		s.wg.Wait()
+	}
+}
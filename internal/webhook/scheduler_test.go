@@ -0,0 +1,157 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/projectfilter"
+	"github.com/redhat-data-and-ai/naysayer/internal/stats"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+)
+
+func TestParseScheduledTasks(t *testing.T) {
+	tasks, err := ParseScheduledTasks(TaskAutoRebase, []string{"123=0,30 * * * *", "456:master=@hourly"})
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 2)
+	assert.Equal(t, 123, tasks[0].ProjectID)
+	assert.Equal(t, "main", tasks[0].Branch)
+	assert.Equal(t, "0,30 * * * *", tasks[0].Schedule.String())
+	assert.Equal(t, "master", tasks[1].Branch)
+
+	tasks, err = ParseScheduledTasks(TaskStaleMRCleanup, []string{"789=0 3 * * 1-5"})
+	assert.NoError(t, err)
+	assert.Equal(t, 789, tasks[0].ProjectID)
+
+	for _, invalid := range []struct{ kind, entry string }{
+		{TaskAutoRebase, "123"},
+		{TaskAutoRebase, "abc=@daily"},
+		{TaskAutoRebase, "123=61 * * * *"},
+		{TaskStaleMRCleanup, "123:main=@daily"},
+		{"unknown", "123=@daily"},
+	} {
+		_, err := ParseScheduledTasks(invalid.kind, []string{invalid.entry})
+		assert.Error(t, err, invalid.entry)
+	}
+}
+
+func TestScheduler_RunDue(t *testing.T) {
+	ctx := context.Background()
+	rebaseClient := &MockRebaseGitLabClient{openMRs: []int{1, 2}}
+	now := time.Now()
+	staleClient := &MockStaleMRClient{openMRs: []gitlab.MRDetails{
+		{IID: 7, UpdatedAt: now.AddDate(0, 0, -40).Format(time.RFC3339)},
+		{IID: 8, UpdatedAt: now.AddDate(0, 0, -2).Format(time.RFC3339)},
+	}}
+	cfg := createTestConfig()
+	cfg.StaleMR.ClosureDays = 30
+
+	rebaseTasks, _ := ParseScheduledTasks(TaskAutoRebase, []string{"9101=*/30 * * * *"})
+	cleanupTasks, _ := ParseScheduledTasks(TaskStaleMRCleanup, []string{"9101=0 3 * * *"})
+	scheduler := NewScheduler(
+		NewAutoRebaseHandlerWithClient(cfg, rebaseClient),
+		NewStaleMRCleanupHandlerWithClient(cfg, staleClient),
+		append(rebaseTasks, cleanupTasks...))
+
+	// 03:15 matches no schedule
+	assert.Empty(t, scheduler.RunDue(ctx, time.Date(2024, 5, 6, 3, 15, 0, 0, time.UTC)))
+
+	summaries := scheduler.RunDue(ctx, time.Date(2024, 5, 6, 3, 0, 0, 0, time.UTC))
+	assert.Len(t, summaries, 2)
+	assert.Equal(t, TaskAutoRebase, summaries[0].Kind)
+	assert.Equal(t, RunCompleted, summaries[0].Status)
+	assert.Equal(t, 2, summaries[0].Rebased)
+	assert.Len(t, rebaseClient.capturedRebaseMRs, 2)
+
+	assert.Equal(t, TaskStaleMRCleanup, summaries[1].Kind)
+	assert.Equal(t, RunCompleted, summaries[1].Status)
+	assert.Equal(t, 2, summaries[1].TotalMRs)
+	assert.Equal(t, 1, summaries[1].Closed)
+	assert.Equal(t, []int{7}, staleClient.closedMRs)
+
+	var recorded []ScheduledRunStats
+	for _, run := range ScheduledRuns() {
+		if run.ProjectID == 9101 {
+			recorded = append(recorded, run)
+		}
+	}
+	assert.Len(t, recorded, 2)
+	assert.Equal(t, int64(1), recorded[0].Runs[RunCompleted])
+	assert.Equal(t, int64(2), recorded[0].Rebased)
+	assert.Equal(t, int64(1), recorded[1].Closed)
+}
+
+func TestScheduler_SkipsUnconfiguredAndArchived(t *testing.T) {
+	ctx := context.Background()
+	cfg := createTestConfig()
+	rebaseTasks, _ := ParseScheduledTasks(TaskAutoRebase, []string{"9102=@hourly"})
+	cleanupTasks, _ := ParseScheduledTasks(TaskStaleMRCleanup, []string{"9102=@hourly"})
+
+	staleClient := &archivedStaleMRClient{MockStaleMRClient: &MockStaleMRClient{}}
+	scheduler := NewScheduler(nil, NewStaleMRCleanupHandlerWithClient(cfg, staleClient),
+		append(rebaseTasks, cleanupTasks...))
+
+	summaries := scheduler.RunDue(ctx, time.Date(2024, 5, 6, 4, 0, 0, 0, time.UTC))
+	assert.Len(t, summaries, 2)
+	assert.Equal(t, RunSkipped, summaries[0].Status)
+	assert.Equal(t, "auto-rebase is not configured", summaries[0].Reason)
+	assert.Equal(t, RunSkipped, summaries[1].Status)
+	assert.Equal(t, "Project is archived", summaries[1].Reason)
+
+	assert.Equal(t, RunSkipped, NewScheduler(nil, nil, cleanupTasks).Run(ctx, cleanupTasks[0]).Status)
+}
+
+func TestScheduler_SkipsFilteredProjects(t *testing.T) {
+	ctx := context.Background()
+	filter, err := projectfilter.NewFilter(config.ProjectFilterConfig{Subsystems: []string{"9103=review,auto-rebase"}}, nil)
+	assert.NoError(t, err)
+	projectfilter.SetDefault(filter)
+	defer projectfilter.SetDefault(nil)
+
+	rebaseClient := &MockRebaseGitLabClient{openMRs: []int{1}}
+	staleClient := &MockStaleMRClient{}
+	cfg := createTestConfig()
+	rebaseTasks, _ := ParseScheduledTasks(TaskAutoRebase, []string{"9103=@hourly"})
+	cleanupTasks, _ := ParseScheduledTasks(TaskStaleMRCleanup, []string{"9103=@hourly"})
+	scheduler := NewScheduler(NewAutoRebaseHandlerWithClient(cfg, rebaseClient), NewStaleMRCleanupHandlerWithClient(cfg, staleClient),
+		append(rebaseTasks, cleanupTasks...))
+
+	summaries := scheduler.RunDue(ctx, time.Date(2024, 5, 6, 5, 0, 0, 0, time.UTC))
+	assert.Len(t, summaries, 2)
+	assert.Equal(t, RunCompleted, summaries[0].Status)
+	assert.Equal(t, RunSkipped, summaries[1].Status)
+	assert.Equal(t, projectfilter.ReasonSubsystemDisabled, summaries[1].Reason)
+}
+
+func TestScheduler_NextRun(t *testing.T) {
+	tasks, _ := ParseScheduledTasks(TaskAutoRebase, []string{"1=0 3 * * *", "2=*/20 * * * *"})
+	scheduler := NewScheduler(nil, nil, tasks)
+	from := time.Date(2024, 5, 6, 2, 45, 30, 0, time.UTC)
+	assert.Equal(t, time.Date(2024, 5, 6, 3, 0, 0, 0, time.UTC), scheduler.nextRun(from))
+
+	assert.True(t, NewScheduler(nil, nil, nil).nextRun(from).IsZero())
+}
+
+func TestMetricsHandler_ReportsScheduledRuns(t *testing.T) {
+	recordScheduledRun(ScheduledRunSummary{
+		Kind: TaskStaleMRCleanup, ProjectID: 9103, Status: RunCompleted, Closed: 3,
+		StartedAt: time.Unix(1700000000, 0), Duration: 2 * time.Second,
+	})
+
+	app := createTestApp()
+	app.Get("/metrics", NewMetricsHandler(stats.NewRecorder(store.NewMemoryStore()), config.SLOConfig{WindowMinutes: 60}).HandleMetrics)
+	resp, err := app.Test(httptest.NewRequest("GET", "/metrics", nil))
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+
+	assert.Contains(t, string(body), "# TYPE naysayer_scheduled_runs_total counter")
+	assert.Contains(t, string(body), `naysayer_scheduled_runs_total{task="stale_mr_cleanup",project_id="9103",status="completed"} 1`)
+	assert.Contains(t, string(body), `naysayer_scheduled_mrs_total{task="stale_mr_cleanup",project_id="9103",outcome="closed"} 3`)
+	assert.Contains(t, string(body), `naysayer_scheduled_last_run_timestamp_seconds{task="stale_mr_cleanup",project_id="9103"} 1700000002`)
+}
@@ -0,0 +1,94 @@
+package webhook
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/redhat-data-and-ai/naysayer/internal/snapshot"
+	"go.uber.org/zap"
+)
+
+// SnapshotHandler lists stored evaluation snapshots and replays them
+type SnapshotHandler struct {
+	snapshots  *snapshot.Store
+	newManager func(gitlab.GitLabClient) (shared.RuleManager, error)
+}
+
+// NewSnapshotHandler creates a snapshot handler replaying with the dataverse rule manager
+func NewSnapshotHandler(snapshots *snapshot.Store) *SnapshotHandler {
+	return &SnapshotHandler{snapshots: snapshots, newManager: rules.CreateSectionBasedDataverseManager}
+}
+
+// ReplayResult compares a replayed evaluation with the recorded decision
+type ReplayResult struct {
+	SnapshotID string          `json:"snapshot_id"`
+	Recorded   shared.Decision `json:"recorded_decision"`
+	Replayed   shared.Decision `json:"replayed_decision"`
+	// Matches is true when the replay reached the recorded decision type and reason
+	Matches bool `json:"matches"`
+	// RulesConfigChanged is true when rules.yaml differs from the one the evaluation ran with
+	RulesConfigChanged bool                   `json:"rules_config_changed"`
+	Evaluation         *shared.RuleEvaluation `json:"evaluation"`
+}
+
+// HandleList lists snapshots, newest first, filtered by project_id and mr_iid
+func (h *SnapshotHandler) HandleList(c *fiber.Ctx) error {
+	if h.snapshots == nil {
+		return c.Status(404).JSON(fiber.Map{"error": "evaluation snapshots are disabled"})
+	}
+	projectID := c.QueryInt("project_id", 0)
+	mrIID := c.QueryInt("mr_iid", 0)
+	if projectID < 0 || mrIID < 0 || (mrIID > 0 && projectID == 0) {
+		return c.Status(400).JSON(fiber.Map{
+			"error": "project_id and mr_iid must be positive integers; mr_iid requires project_id",
+		})
+	}
+
+	summaries, err := h.snapshots.List(projectID, mrIID)
+	if err != nil {
+		logging.Error("Failed to list snapshots", zap.Error(err))
+		return c.Status(500).JSON(fiber.Map{"error": "failed to list snapshots"})
+	}
+	return c.JSON(fiber.Map{"snapshots": summaries})
+}
+
+// HandleGet returns the manifest of one snapshot (file contents are referenced by blob address)
+func (h *SnapshotHandler) HandleGet(c *fiber.Ctx) error {
+	if h.snapshots == nil {
+		return c.Status(404).JSON(fiber.Map{"error": "evaluation snapshots are disabled"})
+	}
+	snap, err := h.snapshots.Load(c.Params("id"))
+	if err != nil {
+		return c.Status(404).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(snap)
+}
+
+// HandleReplay re-runs the rules against the snapshot's inputs and compares the decisions
+func (h *SnapshotHandler) HandleReplay(c *fiber.Ctx) error {
+	if h.snapshots == nil {
+		return c.Status(404).JSON(fiber.Map{"error": "evaluation snapshots are disabled"})
+	}
+	snap, err := h.snapshots.Load(c.Params("id"))
+	if err != nil {
+		return c.Status(404).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	result, err := h.snapshots.Replay(snap, h.newManager)
+	if err != nil {
+		logging.Error("Replay of snapshot failed", zap.String("snapshot_id", snap.ID), zap.Error(err))
+		return c.Status(500).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	current := snapshot.RulesConfigSHA256(rules.RulesConfigPath)
+	return c.JSON(ReplayResult{
+		SnapshotID:         snap.ID,
+		Recorded:           snap.Decision,
+		Replayed:           result.FinalDecision,
+		Matches:            result.FinalDecision.Type == snap.Decision.Type && result.FinalDecision.Reason == snap.Decision.Reason,
+		RulesConfigChanged: snap.RulesConfigSHA256 != "" && current != snap.RulesConfigSHA256,
+		Evaluation:         result,
+	})
+}
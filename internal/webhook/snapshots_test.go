@@ -0,0 +1,97 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/redhat-data-and-ai/naysayer/internal/snapshot"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+)
+
+// targetBranchManager decides on the MR target branch the client reports
+type targetBranchManager struct {
+	client gitlab.GitLabClient
+}
+
+func (m *targetBranchManager) AddRule(rule shared.Rule) {}
+
+func (m *targetBranchManager) EvaluateAll(mrCtx *shared.MRContext) *shared.RuleEvaluation {
+	ctx := mrCtx.Context()
+	branch, _ := m.client.GetMRTargetBranch(ctx, mrCtx.ProjectID, mrCtx.MRIID)
+	_, _ = m.client.FetchFileContent(ctx, mrCtx.ProjectID, "product.yaml", branch)
+	return &shared.RuleEvaluation{
+		FinalDecision:   shared.Decision{Type: shared.Approve, Reason: "target " + branch},
+		FileValidations: make(map[string]*shared.FileValidationSummary),
+	}
+}
+
+func TestSnapshots_CaptureListAndReplay(t *testing.T) {
+	ctx := context.Background()
+	snapshots, err := snapshot.NewStore(store.NewMemoryStore(), "", 0, 0)
+	assert.NoError(t, err)
+	newManager := func(client gitlab.GitLabClient) (shared.RuleManager, error) {
+		return &targetBranchManager{client: client}, nil
+	}
+
+	handler := &DataProductConfigMrReviewHandler{
+		config:       createTestConfig(),
+		gitlabClient: &MockGitLabClient{changes: []gitlab.FileChange{{NewPath: "product.yaml", Diff: "+x"}}},
+	}
+	handler.SetSnapshotStore(snapshots)
+	handler.newRuleManager = newManager
+
+	result, err := handler.evaluateRules(ctx, 7, 3, &gitlab.MRInfo{ProjectID: 7, MRIID: 3})
+	assert.NoError(t, err)
+	assert.Equal(t, "target main", result.FinalDecision.Reason)
+
+	app := createTestApp()
+	snapshotHandler := NewSnapshotHandler(snapshots)
+	snapshotHandler.newManager = newManager
+	app.Get("/api/v1/snapshots", snapshotHandler.HandleList)
+	app.Get("/api/v1/snapshots/:id", snapshotHandler.HandleGet)
+	app.Post("/api/v1/snapshots/:id/replay", snapshotHandler.HandleReplay)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/snapshots?project_id=7&mr_iid=3", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	var list struct {
+		Snapshots []snapshot.Summary `json:"snapshots"`
+	}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&list))
+	assert.Len(t, list.Snapshots, 1)
+	id := list.Snapshots[0].ID
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/api/v1/snapshots/"+id, nil))
+	assert.NoError(t, err)
+	var snap snapshot.Snapshot
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&snap))
+	assert.Len(t, snap.Changes, 1)
+	assert.Len(t, snap.TargetBranches, 1)
+
+	resp, err = app.Test(httptest.NewRequest("POST", "/api/v1/snapshots/"+id+"/replay", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	var replay ReplayResult
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&replay))
+	assert.True(t, replay.Matches)
+	assert.Equal(t, "target main", replay.Replayed.Reason)
+
+	resp, err = app.Test(httptest.NewRequest("POST", "/api/v1/snapshots/7-3-1/replay", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 404, resp.StatusCode)
+}
+
+func TestSnapshots_Disabled(t *testing.T) {
+	app := createTestApp()
+	app.Get("/api/v1/snapshots", NewSnapshotHandler(nil).HandleList)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/snapshots", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 404, resp.StatusCode)
+}
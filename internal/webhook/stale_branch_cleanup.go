@@ -0,0 +1,130 @@
+package webhook
+
+import (
+	"context"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"go.uber.org/zap"
+)
+
+// branchManager is implemented by clients that can list and delete repository branches
+type branchManager interface {
+	ListBranches(ctx context.Context, projectID int) ([]gitlab.Branch, error)
+	DeleteBranch(ctx context.Context, projectID int, branch string) error
+}
+
+// StaleBranchReport lists branches without open MR whose last commit is older than the threshold
+type StaleBranchReport struct {
+	BranchDays    int           `json:"branch_days"`
+	Deleting      bool          `json:"deleting"` // False when only reporting (dry run or STALE_BRANCH_DELETE off)
+	TotalBranches int           `json:"total_branches"`
+	Stale         []StaleBranch `json:"stale"`
+	Deleted       int           `json:"deleted"`
+	Failed        int           `json:"failed"`
+	Reason        string        `json:"reason,omitempty"` // Why the branch cleanup was skipped
+}
+
+// StaleBranch is a branch reported by the stale branch cleanup
+type StaleBranch struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"` // "merged" or "abandoned"
+	LastCommit string `json:"last_commit"`
+	AgeDays    int    `json:"age_days"`
+	Deleted    bool   `json:"deleted"`
+	Error      string `json:"error,omitempty"`
+}
+
+// cleanupStaleBranches reports stale branches and deletes them unless reporting only.
+// Default, protected and configured protected branches, as well as branches used by
+// open MRs (as source or target), are never touched.
+func (h *StaleMRCleanupHandler) cleanupStaleBranches(ctx context.Context, payload *StaleMRCleanupPayload, openMRs []gitlab.MRDetails, now time.Time) *StaleBranchReport {
+	report := &StaleBranchReport{
+		BranchDays: payload.BranchDays,
+		Deleting:   h.config.StaleMR.BranchDelete && !payload.DryRun,
+		Stale:      []StaleBranch{},
+	}
+
+	manager, ok := h.client.(branchManager)
+	if !ok {
+		report.Reason = "Branch listing is not supported by the client"
+		return report
+	}
+
+	branches, err := manager.ListBranches(ctx, payload.ProjectID)
+	if err != nil {
+		logging.Error("Failed to list branches", zap.Int("project_id", payload.ProjectID), zap.Error(err))
+		report.Reason = "Failed to list branches"
+		return report
+	}
+	report.TotalBranches = len(branches)
+
+	inUse := make(map[string]bool)
+	for _, mr := range openMRs {
+		// Source branches of fork MRs live in the fork
+		if mr.SourceProjectID == 0 || mr.SourceProjectID == payload.ProjectID {
+			inUse[mr.SourceBranch] = true
+		}
+		inUse[mr.TargetBranch] = true
+	}
+
+	for _, branch := range branches {
+		if branch.Default || branch.Protected || inUse[branch.Name] || h.isProtectedBranch(branch.Name) {
+			continue
+		}
+
+		committedAt, err := time.Parse(time.RFC3339, branch.Commit.CommittedDate)
+		if err != nil {
+			logging.Warn("Failed to parse committed_date of branch", zap.String("branch", branch.Name), zap.Error(err))
+			continue
+		}
+		ageDays := int(now.Sub(committedAt).Hours() / 24)
+		if ageDays < payload.BranchDays {
+			continue
+		}
+
+		stale := StaleBranch{
+			Name:       branch.Name,
+			Status:     "abandoned",
+			LastCommit: branch.Commit.ID,
+			AgeDays:    ageDays,
+		}
+		if branch.Merged {
+			stale.Status = "merged"
+		}
+
+		if !report.Deleting {
+			logging.Info("[REPORT] Stale branch",
+				zap.String("status", stale.Status),
+				zap.String("branch", branch.Name),
+				zap.Int("project_id", payload.ProjectID),
+				zap.Int("age_days", ageDays))
+		} else if err := manager.DeleteBranch(ctx, payload.ProjectID, branch.Name); err != nil {
+			logging.Error("Failed to delete stale branch", zap.String("branch", branch.Name), zap.Error(err))
+			stale.Error = err.Error()
+			report.Failed++
+		} else {
+			stale.Deleted = true
+			report.Deleted++
+		}
+		report.Stale = append(report.Stale, stale)
+	}
+
+	sort.Slice(report.Stale, func(i, j int) bool {
+		return report.Stale[i].AgeDays > report.Stale[j].AgeDays
+	})
+	return report
+}
+
+// isProtectedBranch reports whether a branch matches STALE_BRANCH_PROTECTED
+func (h *StaleMRCleanupHandler) isProtectedBranch(name string) bool {
+	for _, pattern := range h.config.StaleMR.ProtectedBranches {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,199 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+)
+
+// MockStaleBranchClient adds branch listing and deletion to MockStaleMRClient
+type MockStaleBranchClient struct {
+	MockStaleMRClient
+	branches       []gitlab.Branch
+	deleted        []string
+	listError      error
+	deleteFailures map[string]bool
+}
+
+func (m *MockStaleBranchClient) ListBranches(ctx context.Context, projectID int) ([]gitlab.Branch, error) {
+	return m.branches, m.listError
+}
+
+func (m *MockStaleBranchClient) DeleteBranch(ctx context.Context, projectID int, branch string) error {
+	if m.deleteFailures[branch] {
+		return errors.New("delete branch failed with status 403")
+	}
+	m.deleted = append(m.deleted, branch)
+	return nil
+}
+
+func staleBranch(name string, ageDays int, merged bool) gitlab.Branch {
+	return gitlab.Branch{
+		Name:   name,
+		Merged: merged,
+		Commit: gitlab.BranchCommit{
+			ID:            name + "-sha",
+			CommittedDate: time.Now().AddDate(0, 0, -ageDays).Format(time.RFC3339),
+		},
+	}
+}
+
+func newStaleBranchClient() *MockStaleBranchClient {
+	main := staleBranch("main", 400, false)
+	main.Default = true
+	locked := staleBranch("locked", 400, false)
+	locked.Protected = true
+	return &MockStaleBranchClient{
+		MockStaleMRClient: MockStaleMRClient{
+			openMRs: []gitlab.MRDetails{
+				{IID: 1, SourceBranch: "feature/open", TargetBranch: "release-train", UpdatedAt: time.Now().Format(time.RFC3339)},
+				{IID: 2, SourceBranch: "feature/fork", SourceProjectID: 999, TargetBranch: "main", UpdatedAt: time.Now().Format(time.RFC3339)},
+			},
+		},
+		branches: []gitlab.Branch{
+			main,
+			locked,
+			staleBranch("release/1.0", 400, false),
+			staleBranch("release-train", 400, false),
+			staleBranch("feature/open", 400, false),
+			staleBranch("feature/fork", 200, false),
+			staleBranch("feature/merged", 120, true),
+			staleBranch("feature/recent", 10, false),
+		},
+	}
+}
+
+func runStaleBranchCleanup(t *testing.T, handler *StaleMRCleanupHandler, payload map[string]interface{}) StaleMRCleanupResponse {
+	app := fiber.New()
+	app.Post("/stale-mr-cleanup", handler.HandleWebhook)
+
+	payloadBytes, _ := json.Marshal(payload)
+	req := httptest.NewRequest("POST", "/stale-mr-cleanup", bytes.NewBuffer(payloadBytes))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	var response StaleMRCleanupResponse
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+	return response
+}
+
+func TestStaleBranchCleanup_ReportOnlyByDefault(t *testing.T) {
+	cfg := createStaleMRTestConfig()
+	cfg.StaleMR.BranchDays = 90
+	cfg.StaleMR.ProtectedBranches = []string{"main", "release/*"}
+	mockClient := newStaleBranchClient()
+	handler := NewStaleMRCleanupHandlerWithClient(cfg, mockClient)
+
+	response := runStaleBranchCleanup(t, handler, map[string]interface{}{
+		"project_id": 123,
+		"branches":   true,
+	})
+
+	assert.NotNil(t, response.Branches)
+	assert.False(t, response.Branches.Deleting)
+	assert.Equal(t, 90, response.Branches.BranchDays)
+	assert.Equal(t, 8, response.Branches.TotalBranches)
+	assert.Empty(t, mockClient.deleted)
+
+	// Default, protected, pattern-protected and open MR branches are skipped;
+	// the fork MR's source branch lives in the fork
+	assert.Len(t, response.Branches.Stale, 2)
+	assert.Equal(t, "feature/fork", response.Branches.Stale[0].Name)
+	assert.Equal(t, "abandoned", response.Branches.Stale[0].Status)
+	assert.Equal(t, "feature/merged", response.Branches.Stale[1].Name)
+	assert.Equal(t, "merged", response.Branches.Stale[1].Status)
+	assert.Equal(t, "feature/merged-sha", response.Branches.Stale[1].LastCommit)
+}
+
+func TestStaleBranchCleanup_Delete(t *testing.T) {
+	cfg := createStaleMRTestConfig()
+	cfg.StaleMR.BranchDays = 90
+	cfg.StaleMR.BranchDelete = true
+	cfg.StaleMR.ProtectedBranches = []string{"main", "release/*"}
+	mockClient := newStaleBranchClient()
+	mockClient.deleteFailures = map[string]bool{"feature/fork": true}
+	handler := NewStaleMRCleanupHandlerWithClient(cfg, mockClient)
+
+	response := runStaleBranchCleanup(t, handler, map[string]interface{}{
+		"project_id":  123,
+		"branches":    true,
+		"branch_days": 100,
+	})
+
+	assert.True(t, response.Branches.Deleting)
+	assert.Equal(t, 100, response.Branches.BranchDays)
+	assert.Equal(t, []string{"feature/merged"}, mockClient.deleted)
+	assert.Equal(t, 1, response.Branches.Deleted)
+	assert.Equal(t, 1, response.Branches.Failed)
+	assert.Contains(t, response.Branches.Stale[0].Error, "403")
+	assert.True(t, response.Branches.Stale[1].Deleted)
+}
+
+func TestStaleBranchCleanup_DryRunOverridesDelete(t *testing.T) {
+	cfg := createStaleMRTestConfig()
+	cfg.StaleMR.BranchDays = 90
+	cfg.StaleMR.BranchDelete = true
+	mockClient := newStaleBranchClient()
+	handler := NewStaleMRCleanupHandlerWithClient(cfg, mockClient)
+
+	response := runStaleBranchCleanup(t, handler, map[string]interface{}{
+		"project_id": 123,
+		"branches":   true,
+		"dry_run":    true,
+	})
+
+	assert.False(t, response.Branches.Deleting)
+	assert.NotEmpty(t, response.Branches.Stale)
+	assert.Empty(t, mockClient.deleted)
+}
+
+func TestStaleBranchCleanup_NotRequested(t *testing.T) {
+	cfg := createStaleMRTestConfig()
+	mockClient := newStaleBranchClient()
+	handler := NewStaleMRCleanupHandlerWithClient(cfg, mockClient)
+
+	response := runStaleBranchCleanup(t, handler, map[string]interface{}{"project_id": 123})
+
+	assert.Nil(t, response.Branches)
+	assert.Empty(t, mockClient.deleted)
+}
+
+func TestStaleBranchCleanup_Unsupported(t *testing.T) {
+	cfg := createStaleMRTestConfig()
+	handler := NewStaleMRCleanupHandlerWithClient(cfg, &MockStaleMRClient{})
+
+	response := runStaleBranchCleanup(t, handler, map[string]interface{}{
+		"project_id": 123,
+		"branches":   true,
+	})
+
+	assert.Equal(t, "Branch listing is not supported by the client", response.Branches.Reason)
+	assert.Empty(t, response.Branches.Stale)
+}
+
+func TestStaleBranchCleanup_ListError(t *testing.T) {
+	cfg := createStaleMRTestConfig()
+	mockClient := newStaleBranchClient()
+	mockClient.listError = errors.New("list branches failed with status 500")
+	handler := NewStaleMRCleanupHandlerWithClient(cfg, mockClient)
+
+	response := runStaleBranchCleanup(t, handler, map[string]interface{}{
+		"project_id": 123,
+		"branches":   true,
+	})
+
+	assert.Equal(t, "completed", response.Status)
+	assert.Equal(t, "Failed to list branches", response.Branches.Reason)
+}
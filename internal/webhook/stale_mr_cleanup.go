@@ -1,19 +1,27 @@
 package webhook
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/redhat-data-and-ai/naysayer/internal/audit"
 	"github.com/redhat-data-and-ai/naysayer/internal/config"
 	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
 	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/notify"
+	"github.com/redhat-data-and-ai/naysayer/internal/stats"
+	"go.uber.org/zap"
 )
 
 // StaleMRCleanupHandler handles stale MR cleanup requests
 type StaleMRCleanupHandler struct {
-	config *config.Config
-	client gitlab.GitLabClient
+	config   *config.Config
+	client   gitlab.GitLabClient
+	stats    *stats.Recorder // Optional: comment statistics
+	notifier notify.Sink     // Optional: notified of the MRs each run closed
 }
 
 // StaleMRCleanupPayload represents the payload for stale MR cleanup webhook
@@ -21,47 +29,55 @@ type StaleMRCleanupPayload struct {
 	ProjectID   int  `json:"project_id"`   // Required: GitLab project ID
 	ClosureDays int  `json:"closure_days"` // Optional: Override default closure threshold
 	DryRun      bool `json:"dry_run"`      // Optional: Test mode (no actual changes)
+	Branches    bool `json:"branches"`     // Optional: Also clean up stale branches without open MR
+	BranchDays  int  `json:"branch_days"`  // Optional: Override default branch staleness threshold
+
+	DraftClosureDays int `json:"draft_closure_days"` // Optional: Override default closure threshold of draft MRs
 }
 
 // StaleMRCleanupResponse represents the response from stale MR cleanup
 type StaleMRCleanupResponse struct {
-	WebhookResponse string `json:"webhook_response"`
-	Status          string `json:"status"`
-	ProjectID       int    `json:"project_id"`
-	ClosureDays     int    `json:"closure_days"`
-	DryRun          bool   `json:"dry_run"`
-	TotalMRs        int    `json:"total_mrs"`
-	Closed          int    `json:"closed"`
-	Failed          int    `json:"failed"`
+	WebhookResponse  string `json:"webhook_response"`
+	Status           string `json:"status"`
+	ProjectID        int    `json:"project_id"`
+	ClosureDays      int    `json:"closure_days"`
+	DraftClosureDays int    `json:"draft_closure_days,omitempty"` // Closure threshold of draft MRs, when different
+	DryRun           bool   `json:"dry_run"`
+	TotalMRs         int    `json:"total_mrs"`
+	Closed           int    `json:"closed"`
+	Exempted         int    `json:"exempted"` // Stale MRs kept open by an exemption label
+	Failed           int    `json:"failed"`
+	Reason           string `json:"reason,omitempty"` // Why the cleanup was skipped
+
+	Branches *StaleBranchReport `json:"branches,omitempty"` // Set when branch cleanup was requested
 }
 
 // NewStaleMRCleanupHandler creates a new stale MR cleanup handler
 func NewStaleMRCleanupHandler(cfg *config.Config) *StaleMRCleanupHandler {
-	clientCfg := cfg.GitLab
-	if clientCfg.GitlabStaleMRToken != "" {
-		// Use the dedicated token for this handler's client
-		clientCfg.Token = clientCfg.GitlabStaleMRToken
-	}
-
-	return &StaleMRCleanupHandler{
-		config: cfg,
-		client: gitlab.NewClient(clientCfg),
-	}
+	return NewStaleMRCleanupHandlerWithClient(cfg, gitlab.NewClientForFunction(cfg, config.EndpointStaleMRCleanup))
 }
 
 // NewStaleMRCleanupHandlerWithClient creates a handler with a custom GitLab client (for testing)
 func NewStaleMRCleanupHandlerWithClient(cfg *config.Config, client gitlab.GitLabClient) *StaleMRCleanupHandler {
 	return &StaleMRCleanupHandler{
-		config: cfg,
-		client: client,
+		config:   cfg,
+		client:   client,
+		notifier: notify.NewRoutedSinkFromConfig(cfg, notify.EventStaleMRClosed),
 	}
 }
 
+// SetStatsRecorder enables recording of closure comments for comment statistics
+func (h *StaleMRCleanupHandler) SetStatsRecorder(recorder *stats.Recorder) {
+	h.stats = recorder
+}
+
 // HandleWebhook processes stale MR cleanup webhook requests
 func (h *StaleMRCleanupHandler) HandleWebhook(c *fiber.Ctx) error {
+	ctx := c.UserContext()
 	// Validate content type
 	if c.Get("Content-Type") != "application/json" {
-		logging.Warn("Invalid content type for stale MR cleanup: %s", c.Get("Content-Type"))
+		logging.FromContext(ctx).Warn("Invalid content type for stale MR cleanup",
+			zap.String("content_type", c.Get("Content-Type")))
 		return c.Status(400).JSON(fiber.Map{
 			"error": "Content-Type must be application/json",
 		})
@@ -70,7 +86,7 @@ func (h *StaleMRCleanupHandler) HandleWebhook(c *fiber.Ctx) error {
 	// Parse payload
 	var payload StaleMRCleanupPayload
 	if err := c.BodyParser(&payload); err != nil {
-		logging.Warn("Failed to parse stale MR cleanup payload: %v", err)
+		logging.FromContext(ctx).Warn("Failed to parse stale MR cleanup payload", zap.Error(err))
 		return c.Status(400).JSON(fiber.Map{
 			"error": "Invalid JSON payload",
 		})
@@ -78,7 +94,7 @@ func (h *StaleMRCleanupHandler) HandleWebhook(c *fiber.Ctx) error {
 
 	// Validate payload
 	if err := h.validatePayload(&payload); err != nil {
-		logging.Warn("Invalid stale MR cleanup payload: %v", err)
+		logging.FromContext(ctx).Warn("Invalid stale MR cleanup payload", zap.Error(err))
 		return c.Status(400).JSON(fiber.Map{
 			"error": err.Error(),
 		})
@@ -88,21 +104,32 @@ func (h *StaleMRCleanupHandler) HandleWebhook(c *fiber.Ctx) error {
 	if payload.ClosureDays == 0 {
 		payload.ClosureDays = h.config.StaleMR.ClosureDays
 	}
+	if payload.BranchDays == 0 {
+		payload.BranchDays = h.config.StaleMR.BranchDays
+	}
+	if payload.DraftClosureDays == 0 {
+		payload.DraftClosureDays = h.config.StaleMR.DraftClosureDays
+	}
 
-	logging.Info("Starting stale MR cleanup for project %d (closure: %d days, dry_run: %t)",
-		payload.ProjectID, payload.ClosureDays, payload.DryRun)
+	logging.FromContext(ctx).Info("Starting stale MR cleanup",
+		zap.Int("project_id", payload.ProjectID),
+		zap.Int("closure_days", payload.ClosureDays),
+		zap.Bool("dry_run", payload.DryRun))
 
 	// Process cleanup
-	response, err := h.processCleanup(&payload)
+	response, err := h.processCleanup(ctx, &payload)
 	if err != nil {
-		logging.Error("Stale MR cleanup failed for project %d: %v", payload.ProjectID, err)
+		logging.FromContext(ctx).Error("Stale MR cleanup failed", zap.Int("project_id", payload.ProjectID), zap.Error(err))
 		return c.Status(500).JSON(fiber.Map{
 			"error": "Internal server error during cleanup",
 		})
 	}
 
-	logging.Info("Stale MR cleanup completed for project %d: %d closed, %d failed",
-		payload.ProjectID, response.Closed, response.Failed)
+	logging.FromContext(ctx).Info("Stale MR cleanup completed",
+		zap.Int("project_id", payload.ProjectID),
+		zap.Int("closed", response.Closed),
+		zap.Int("exempted", response.Exempted),
+		zap.Int("failed", response.Failed))
 
 	return c.JSON(response)
 }
@@ -117,13 +144,34 @@ func (h *StaleMRCleanupHandler) validatePayload(payload *StaleMRCleanupPayload)
 		return fmt.Errorf("closure_days must be >= 0")
 	}
 
+	if payload.BranchDays < 0 {
+		return fmt.Errorf("branch_days must be >= 0")
+	}
+
+	if payload.DraftClosureDays < 0 {
+		return fmt.Errorf("draft_closure_days must be >= 0")
+	}
+
 	return nil
 }
 
 // processCleanup processes the stale MR cleanup workflow
-func (h *StaleMRCleanupHandler) processCleanup(payload *StaleMRCleanupPayload) (*StaleMRCleanupResponse, error) {
+func (h *StaleMRCleanupHandler) processCleanup(ctx context.Context, payload *StaleMRCleanupPayload) (*StaleMRCleanupResponse, error) {
+	// Archived projects are read-only: closing MRs would fail with 403
+	if isProjectArchived(ctx, h.client, payload.ProjectID) {
+		logging.FromContext(ctx).Info("Skipping stale MR cleanup for archived project", zap.Int("project_id", payload.ProjectID))
+		return &StaleMRCleanupResponse{
+			WebhookResponse: "processed",
+			Status:          "skipped",
+			ProjectID:       payload.ProjectID,
+			ClosureDays:     payload.ClosureDays,
+			DryRun:          payload.DryRun,
+			Reason:          "Project is archived",
+		}, nil
+	}
+
 	// Fetch all open MRs
-	mrs, err := h.client.ListAllOpenMRsWithDetails(payload.ProjectID)
+	mrs, err := h.client.ListAllOpenMRsWithDetails(ctx, payload.ProjectID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list open MRs: %w", err)
 	}
@@ -138,61 +186,106 @@ func (h *StaleMRCleanupHandler) processCleanup(payload *StaleMRCleanupPayload) (
 		Closed:          0,
 		Failed:          0,
 	}
+	if payload.DraftClosureDays > 0 && payload.DraftClosureDays != payload.ClosureDays {
+		response.DraftClosureDays = payload.DraftClosureDays
+	}
 
 	now := time.Now()
+	var closed []gitlab.MRDetails
 
 	// Process each MR
 	for _, mr := range mrs {
 		// Parse UpdatedAt timestamp
 		updatedAt, err := time.Parse(time.RFC3339, mr.UpdatedAt)
 		if err != nil {
-			logging.Warn("Failed to parse updated_at for MR !%d: %v", mr.IID, err)
+			logging.FromContext(ctx).Warn("Failed to parse updated_at of MR", zap.Int("mr_iid", mr.IID), zap.Error(err))
 			response.Failed++
 			continue
 		}
 
 		daysSinceUpdate := int(now.Sub(updatedAt).Hours() / 24)
 
-		// Close if >= threshold
-		if daysSinceUpdate >= payload.ClosureDays {
-			if err := h.closeStaleMR(payload.ProjectID, mr.IID, payload.ClosureDays, daysSinceUpdate, payload.DryRun); err != nil {
-				logging.Error("Failed to close MR !%d: %v", mr.IID, err)
+		// Drafts may have their own threshold
+		closureDays := payload.ClosureDays
+		if mr.IsDraft() && payload.DraftClosureDays > 0 {
+			closureDays = payload.DraftClosureDays
+		}
+
+		// Close if >= threshold, unless an exemption label keeps the MR open
+		if daysSinceUpdate >= closureDays {
+			if label := h.exemptionLabel(mr); label != "" {
+				response.Exempted++
+				logging.FromContext(ctx).Info("Keeping stale MR open, exempt by label",
+					zap.Int("mr_iid", mr.IID),
+					zap.Int("days_inactive", daysSinceUpdate),
+					zap.String("label", label))
+				continue
+			}
+			err := h.closeStaleMR(ctx, payload.ProjectID, mr, closureDays, daysSinceUpdate, payload.DryRun)
+			if !payload.DryRun {
+				recordAction(audit.KindStaleClose, payload.ProjectID, mr, fmt.Sprintf("inactive for %d days", daysSinceUpdate), err)
+			}
+			if err != nil {
+				logging.FromContext(ctx).Error("Failed to close MR", zap.Int("mr_iid", mr.IID), zap.Error(err))
 				response.Failed++
 			} else {
 				response.Closed++
-				logging.Info("Closed stale MR !%d (inactive for %d days)", mr.IID, daysSinceUpdate)
+				if !payload.DryRun {
+					closed = append(closed, mr)
+				}
+				logging.FromContext(ctx).Info("Closed stale MR",
+					zap.Int("mr_iid", mr.IID),
+					zap.Int("days_inactive", daysSinceUpdate))
 			}
 		}
 	}
 
-	return response, nil
-}
+	h.notifyStaleClosures(payload.ProjectID, closed)
 
-// closeStaleMR adds a closure comment and closes a stale MR
-func (h *StaleMRCleanupHandler) closeStaleMR(projectID, mrIID, closureDays, daysSinceUpdate int, dryRun bool) error {
-	comment := fmt.Sprintf(`**Automated Closure - Stale Merge Request**
+	// Uses the MRs open before this run, so branches of MRs closed just now are kept until the next run
+	if payload.Branches {
+		response.Branches = h.cleanupStaleBranches(ctx, payload, mrs, now)
+	}
 
-This merge request has been automatically closed due to inactivity (%d days with no updates).
+	return response, nil
+}
 
-If you still want to merge this change, please:
-1. Reopen this MR
-2. Rebase with the latest changes
-3. Address any conflicts or review comments
+// exemptionLabel returns the first label of the MR that exempts it from closure, or ""
+func (h *StaleMRCleanupHandler) exemptionLabel(mr gitlab.MRDetails) string {
+	for _, label := range mr.Labels {
+		for _, exempt := range h.config.StaleMR.ExemptLabels {
+			if strings.EqualFold(label, exempt) {
+				return label
+			}
+		}
+	}
+	return ""
+}
 
-_This is an automated action performed by the stale MR cleanup process._`, daysSinceUpdate)
+// closeStaleMR adds a closure comment and closes a stale MR
+func (h *StaleMRCleanupHandler) closeStaleMR(ctx context.Context, projectID int, mr gitlab.MRDetails, closureDays, daysSinceUpdate int, dryRun bool) error {
+	comment := NewMessageBuilder(h.config).BuildStaleClosureComment(StaleClosureCommentData{
+		ProjectID:    projectID,
+		MRIID:        mr.IID,
+		Title:        mr.Title,
+		Author:       authorUsername(mr),
+		DaysInactive: daysSinceUpdate,
+		ClosureDays:  closureDays,
+	})
 
 	if dryRun {
-		logging.Info("[DRY RUN] Would close MR !%d", mrIID)
+		logging.FromContext(ctx).Info("[DRY RUN] Would close MR", zap.Int("mr_iid", mr.IID))
 		return nil
 	}
 
 	// Add closure comment first
-	if err := h.client.AddMRComment(projectID, mrIID, comment); err != nil {
+	if err := h.client.AddMRComment(ctx, projectID, mr.IID, comment); err != nil {
 		return fmt.Errorf("failed to add closure comment: %w", err)
 	}
+	h.stats.Record(stats.KindStale, projectID, mr.IID)
 
 	// Close the MR
-	if err := h.client.CloseMR(projectID, mrIID); err != nil {
+	if err := h.client.CloseMR(ctx, projectID, mr.IID); err != nil {
 		return fmt.Errorf("failed to close MR: %w", err)
 	}
 
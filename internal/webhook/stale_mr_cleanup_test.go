@@ -2,6 +2,7 @@ package webhook
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http/httptest"
@@ -27,14 +28,14 @@ type MockStaleMRClient struct {
 	findPatternError     error
 }
 
-func (m *MockStaleMRClient) ListAllOpenMRsWithDetails(projectID int) ([]gitlab.MRDetails, error) {
+func (m *MockStaleMRClient) ListAllOpenMRsWithDetails(ctx context.Context, projectID int) ([]gitlab.MRDetails, error) {
 	if m.listMRsError != nil {
 		return nil, m.listMRsError
 	}
 	return m.openMRs, nil
 }
 
-func (m *MockStaleMRClient) CloseMR(projectID, mrIID int) error {
+func (m *MockStaleMRClient) CloseMR(ctx context.Context, projectID, mrIID int) error {
 	if m.closeMRError != nil {
 		return m.closeMRError
 	}
@@ -42,7 +43,7 @@ func (m *MockStaleMRClient) CloseMR(projectID, mrIID int) error {
 	return nil
 }
 
-func (m *MockStaleMRClient) AddMRComment(projectID, mrIID int, comment string) error {
+func (m *MockStaleMRClient) AddMRComment(ctx context.Context, projectID, mrIID int, comment string) error {
 	if m.addCommentError != nil {
 		return m.addCommentError
 	}
@@ -50,7 +51,7 @@ func (m *MockStaleMRClient) AddMRComment(projectID, mrIID int, comment string) e
 	return nil
 }
 
-func (m *MockStaleMRClient) FindCommentByPattern(projectID, mrIID int, pattern string) (bool, error) {
+func (m *MockStaleMRClient) FindCommentByPattern(ctx context.Context, projectID, mrIID int, pattern string) (bool, error) {
 	if m.findPatternError != nil {
 		return false, m.findPatternError
 	}
@@ -61,73 +62,79 @@ func (m *MockStaleMRClient) FindCommentByPattern(projectID, mrIID int, pattern s
 }
 
 // Stub methods to satisfy GitLabClient interface
-func (m *MockStaleMRClient) FetchFileContent(projectID int, filePath, ref string) (*gitlab.FileContent, error) {
+func (m *MockStaleMRClient) FetchFileContent(ctx context.Context, projectID int, filePath, ref string) (*gitlab.FileContent, error) {
 	return nil, nil
 }
-func (m *MockStaleMRClient) GetMRTargetBranch(projectID, mrIID int) (string, error) { return "", nil }
-func (m *MockStaleMRClient) GetMRDetails(projectID, mrIID int) (*gitlab.MRDetails, error) {
+func (m *MockStaleMRClient) GetMRTargetBranch(ctx context.Context, projectID, mrIID int) (string, error) {
+	return "", nil
+}
+func (m *MockStaleMRClient) GetMRDetails(ctx context.Context, projectID, mrIID int) (*gitlab.MRDetails, error) {
 	return nil, nil
 }
-func (m *MockStaleMRClient) FetchMRChanges(projectID, mrIID int) ([]gitlab.FileChange, error) {
+func (m *MockStaleMRClient) FetchMRChanges(ctx context.Context, projectID, mrIID int) ([]gitlab.FileChange, error) {
 	return nil, nil
 }
-func (m *MockStaleMRClient) AddOrUpdateMRComment(projectID, mrIID int, commentBody, commentType string) error {
+func (m *MockStaleMRClient) AddOrUpdateMRComment(ctx context.Context, projectID, mrIID int, commentBody, commentType string) error {
 	return nil
 }
-func (m *MockStaleMRClient) ListMRComments(projectID, mrIID int) ([]gitlab.MRComment, error) {
+func (m *MockStaleMRClient) ListMRComments(ctx context.Context, projectID, mrIID int) ([]gitlab.MRComment, error) {
 	return nil, nil
 }
-func (m *MockStaleMRClient) UpdateMRComment(projectID, mrIID, commentID int, newBody string) error {
+func (m *MockStaleMRClient) UpdateMRComment(ctx context.Context, projectID, mrIID, commentID int, newBody string) error {
 	return nil
 }
-func (m *MockStaleMRClient) FindLatestNaysayerComment(projectID, mrIID int, commentType ...string) (*gitlab.MRComment, error) {
+func (m *MockStaleMRClient) FindLatestNaysayerComment(ctx context.Context, projectID, mrIID int, commentType ...string) (*gitlab.MRComment, error) {
 	return nil, nil
 }
-func (m *MockStaleMRClient) ApproveMR(projectID, mrIID int) error { return nil }
-func (m *MockStaleMRClient) ApproveMRWithMessage(projectID, mrIID int, message string) error {
+func (m *MockStaleMRClient) ApproveMR(ctx context.Context, projectID, mrIID int) error { return nil }
+func (m *MockStaleMRClient) ApproveMRWithMessage(ctx context.Context, projectID, mrIID int, message string) error {
+	return nil
+}
+func (m *MockStaleMRClient) ResetNaysayerApproval(ctx context.Context, projectID, mrIID int) error {
 	return nil
 }
-func (m *MockStaleMRClient) ResetNaysayerApproval(projectID, mrIID int) error { return nil }
-func (m *MockStaleMRClient) GetCurrentBotUsername() (string, error)           { return "naysayer-bot", nil }
-func (m *MockStaleMRClient) IsNaysayerBotAuthor(author map[string]interface{}) bool {
+func (m *MockStaleMRClient) GetCurrentBotUsername(ctx context.Context) (string, error) {
+	return "naysayer-bot", nil
+}
+func (m *MockStaleMRClient) IsNaysayerBotAuthor(ctx context.Context, author map[string]interface{}) bool {
 	return false
 }
-func (m *MockStaleMRClient) CompareBranches(sourceProjectID int, sourceBranch string, targetProjectID int, targetBranch string) (*gitlab.CompareResult, error) {
+func (m *MockStaleMRClient) CompareBranches(ctx context.Context, sourceProjectID int, sourceBranch string, targetProjectID int, targetBranch string) (*gitlab.CompareResult, error) {
 	return &gitlab.CompareResult{Commits: []gitlab.CompareCommit{}}, nil
 }
-func (m *MockStaleMRClient) GetBranchCommit(projectID int, branch string) (string, error) {
+func (m *MockStaleMRClient) GetBranchCommit(ctx context.Context, projectID int, branch string) (string, error) {
 	return "mock-sha", nil
 }
-func (m *MockStaleMRClient) CompareCommits(projectID int, fromSHA, toSHA string) (*gitlab.CompareResult, error) {
+func (m *MockStaleMRClient) CompareCommits(ctx context.Context, projectID int, fromSHA, toSHA string) (*gitlab.CompareResult, error) {
 	return &gitlab.CompareResult{Commits: []gitlab.CompareCommit{}}, nil
 }
-func (m *MockStaleMRClient) RebaseMR(projectID, mrIID int) (bool, error) {
+func (m *MockStaleMRClient) RebaseMR(ctx context.Context, projectID, mrIID int) (bool, error) {
 	return true, nil
 }
-func (m *MockStaleMRClient) ListOpenMRs(projectID int) ([]int, error) {
+func (m *MockStaleMRClient) ListOpenMRs(ctx context.Context, projectID int) ([]int, error) {
 	return nil, nil
 }
-func (m *MockStaleMRClient) ListOpenMRsWithDetails(projectID int) ([]gitlab.MRDetails, error) {
+func (m *MockStaleMRClient) ListOpenMRsWithDetails(ctx context.Context, projectID int) ([]gitlab.MRDetails, error) {
 	return nil, nil
 }
 
-func (m *MockStaleMRClient) GetPipelineJobs(projectID, pipelineID int) ([]gitlab.PipelineJob, error) {
+func (m *MockStaleMRClient) GetPipelineJobs(ctx context.Context, projectID, pipelineID int) ([]gitlab.PipelineJob, error) {
 	return []gitlab.PipelineJob{}, nil
 }
 
-func (m *MockStaleMRClient) GetJobTrace(projectID, jobID int) (string, error) {
+func (m *MockStaleMRClient) GetJobTrace(ctx context.Context, projectID, jobID int) (string, error) {
 	return "", nil
 }
 
-func (m *MockStaleMRClient) FindLatestAtlantisComment(projectID, mrIID int) (*gitlab.MRComment, error) {
+func (m *MockStaleMRClient) FindLatestAtlantisComment(ctx context.Context, projectID, mrIID int) (*gitlab.MRComment, error) {
 	return nil, nil
 }
 
-func (m *MockStaleMRClient) AreAllPipelineJobsSucceeded(projectID, pipelineID int) (bool, error) {
+func (m *MockStaleMRClient) AreAllPipelineJobsSucceeded(ctx context.Context, projectID, pipelineID int) (bool, error) {
 	return true, nil
 }
 
-func (m *MockStaleMRClient) CheckAtlantisCommentForPlanFailures(projectID, mrIID int) (bool, string) {
+func (m *MockStaleMRClient) CheckAtlantisCommentForPlanFailures(ctx context.Context, projectID, mrIID int) (bool, string) {
 	return false, ""
 }
 
@@ -250,6 +257,75 @@ func TestStaleMRCleanupHandler_HandleWebhook_DryRun(t *testing.T) {
 	assert.Equal(t, 0, len(mockClient.addedComments))
 }
 
+func TestStaleMRCleanupHandler_ExemptLabels(t *testing.T) {
+	ctx := context.Background()
+	cfg := createStaleMRTestConfig()
+	cfg.StaleMR.ExemptLabels = []string{"keep-open", "pinned"}
+
+	stale := time.Now().AddDate(0, 0, -60).Format(time.RFC3339)
+	mockClient := &MockStaleMRClient{
+		openMRs: []gitlab.MRDetails{
+			{IID: 1, UpdatedAt: stale, Labels: []string{"infra", "Keep-Open"}},
+			{IID: 2, UpdatedAt: stale, Labels: []string{"pinned"}},
+			{IID: 3, UpdatedAt: stale, Labels: []string{"infra"}},
+			{IID: 4, UpdatedAt: time.Now().Format(time.RFC3339), Labels: []string{"pinned"}},
+		},
+	}
+	handler := NewStaleMRCleanupHandlerWithClient(cfg, mockClient)
+
+	response, err := handler.processCleanup(ctx, &StaleMRCleanupPayload{ProjectID: 123, ClosureDays: 30})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, response.Closed)
+	assert.Equal(t, 2, response.Exempted, "only stale MRs count as exempted")
+	assert.Equal(t, []int{3}, mockClient.closedMRs)
+
+	// Without exemption labels every stale MR is closed
+	cfg.StaleMR.ExemptLabels = nil
+	mockClient.closedMRs = nil
+	response, err = handler.processCleanup(ctx, &StaleMRCleanupPayload{ProjectID: 123, ClosureDays: 30})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, response.Closed)
+	assert.Equal(t, 0, response.Exempted)
+}
+
+func TestStaleMRCleanupHandler_DraftClosureDays(t *testing.T) {
+	ctx := context.Background()
+	cfg := createStaleMRTestConfig()
+	cfg.StaleMR.DraftClosureDays = 90
+
+	now := time.Now()
+	mockClient := &MockStaleMRClient{
+		openMRs: []gitlab.MRDetails{
+			{IID: 1, UpdatedAt: now.AddDate(0, 0, -60).Format(time.RFC3339), Draft: true},          // draft under 90 days - keep
+			{IID: 2, UpdatedAt: now.AddDate(0, 0, -95).Format(time.RFC3339), WorkInProgress: true}, // draft over 90 days - close
+			{IID: 3, UpdatedAt: now.AddDate(0, 0, -60).Format(time.RFC3339)},                       // ready over 30 days - close
+		},
+	}
+	handler := NewStaleMRCleanupHandlerWithClient(cfg, mockClient)
+
+	app := fiber.New()
+	app.Post("/stale-mr-cleanup", handler.HandleWebhook)
+	req := httptest.NewRequest("POST", "/stale-mr-cleanup", bytes.NewBufferString(`{"project_id": 123}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+
+	var response StaleMRCleanupResponse
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+	assert.Equal(t, 30, response.ClosureDays)
+	assert.Equal(t, 90, response.DraftClosureDays)
+	assert.Equal(t, 2, response.Closed)
+	assert.Equal(t, []int{2, 3}, mockClient.closedMRs)
+
+	// The payload overrides the draft threshold
+	mockClient.closedMRs = nil
+	response2, err := handler.processCleanup(ctx, &StaleMRCleanupPayload{ProjectID: 123, ClosureDays: 30, DraftClosureDays: 50})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, response2.Closed)
+
+	assert.Error(t, handler.validatePayload(&StaleMRCleanupPayload{ProjectID: 123, DraftClosureDays: -1}))
+}
+
 func TestStaleMRCleanupHandler_HandleWebhook_InvalidContentType(t *testing.T) {
 	cfg := createStaleMRTestConfig()
 	handler := NewStaleMRCleanupHandler(cfg)
@@ -0,0 +1,131 @@
+package webhook
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/registry"
+	"go.uber.org/zap"
+)
+
+// System hook events that change the projects naysayer reviews
+const (
+	systemHookProjectCreate   = "project_create"
+	systemHookProjectDestroy  = "project_destroy"
+	systemHookProjectRename   = "project_rename"
+	systemHookProjectTransfer = "project_transfer"
+)
+
+// SystemHookHandler onboards projects from GitLab system hook events and reviews the merge
+// requests of onboarded projects, so new repositories need no webhook of their own
+type SystemHookHandler struct {
+	registry *registry.Registry
+	review   fiber.Handler // Reviews merge request events of onboarded projects
+	now      func() time.Time
+}
+
+// NewSystemHookHandler creates a system hook handler keeping onboarded projects in reg and
+// passing their merge request events to review
+func NewSystemHookHandler(reg *registry.Registry, review fiber.Handler) *SystemHookHandler {
+	return &SystemHookHandler{registry: reg, review: review, now: time.Now}
+}
+
+// systemHookEvent is the part of a system hook event needed to route it. Project events
+// carry the project at the top level, merge request events in project.
+type systemHookEvent struct {
+	EventName         string `json:"event_name"`
+	ObjectKind        string `json:"object_kind"`
+	ProjectID         int    `json:"project_id"`
+	PathWithNamespace string `json:"path_with_namespace"`
+	Project           struct {
+		ID                int    `json:"id"`
+		PathWithNamespace string `json:"path_with_namespace"`
+	} `json:"project"`
+}
+
+// HandleWebhook processes GitLab system hook events
+func (h *SystemHookHandler) HandleWebhook(c *fiber.Ctx) error {
+	var event systemHookEvent
+	if err := json.Unmarshal(c.Body(), &event); err != nil {
+		logging.Warn("Failed to parse system hook payload", zap.Error(err))
+		return c.Status(400).JSON(fiber.Map{
+			"error": "Invalid JSON payload",
+		})
+	}
+
+	if event.ObjectKind == "merge_request" {
+		return h.handleMergeRequest(c, event)
+	}
+
+	switch event.EventName {
+	case systemHookProjectCreate, systemHookProjectRename, systemHookProjectTransfer:
+		registered, err := h.registry.Sync(event.ProjectID, event.PathWithNamespace, h.now())
+		if err != nil {
+			logging.Error("Failed to update project registry for project", zap.Int("project_id", event.ProjectID), zap.Error(err))
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to update project registry"})
+		}
+		if registered {
+			logging.Info("Onboarded project",
+				zap.String("path", event.PathWithNamespace),
+				zap.Int("project_id", event.ProjectID),
+				zap.String("event", event.EventName))
+		}
+		return systemHookResponse(c, event.EventName, event.ProjectID, registered)
+	case systemHookProjectDestroy:
+		if err := h.registry.Remove(event.ProjectID); err != nil {
+			logging.Error("Failed to remove project from registry", zap.Int("project_id", event.ProjectID), zap.Error(err))
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to update project registry"})
+		}
+		return systemHookResponse(c, event.EventName, event.ProjectID, false)
+	default:
+		return c.JSON(fiber.Map{
+			"webhook_response": "processed",
+			"event_type":       "system_hook",
+			"event":            event.EventName,
+			"decision":         "skipped",
+			"reason":           "event does not concern onboarded projects",
+		})
+	}
+}
+
+// handleMergeRequest reviews merge requests of projects matching the project patterns.
+// Projects created before naysayer received system hooks are onboarded on their first MR.
+func (h *SystemHookHandler) handleMergeRequest(c *fiber.Ctx, event systemHookEvent) error {
+	registered, err := h.registry.Sync(event.Project.ID, event.Project.PathWithNamespace, h.now())
+	if err != nil {
+		logging.Error("Failed to update project registry for project", zap.Int("project_id", event.Project.ID), zap.Error(err))
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to update project registry"})
+	}
+	if !registered {
+		return c.JSON(fiber.Map{
+			"webhook_response": "processed",
+			"event_type":       "merge_request",
+			"decision":         "skipped",
+			"reason":           "project is not onboarded",
+			"project_id":       event.Project.ID,
+		})
+	}
+	return h.review(c)
+}
+
+func systemHookResponse(c *fiber.Ctx, eventName string, projectID int, registered bool) error {
+	return c.JSON(fiber.Map{
+		"webhook_response": "processed",
+		"event_type":       "system_hook",
+		"event":            eventName,
+		"project_id":       projectID,
+		"registered":       registered,
+	})
+}
+
+// HandleListProjects answers GET /api/v1/projects with the onboarded projects
+func (h *SystemHookHandler) HandleListProjects(c *fiber.Ctx) error {
+	projects, err := h.registry.List()
+	if err != nil {
+		logging.Error("Failed to list onboarded projects", zap.Error(err))
+		return c.Status(500).JSON(fiber.Map{"error": "failed to list projects"})
+	}
+	return c.JSON(fiber.Map{"projects": projects})
+}
@@ -0,0 +1,112 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/registry"
+	"github.com/redhat-data-and-ai/naysayer/internal/store"
+)
+
+func newSystemHookTestApp(reviewed *int) (*fiber.App, *registry.Registry) {
+	reg := registry.NewRegistry(store.NewMemoryStore(), []string{"dataverse/*"})
+	handler := NewSystemHookHandler(reg, func(c *fiber.Ctx) error {
+		*reviewed++
+		return c.JSON(fiber.Map{"decision": "reviewed"})
+	})
+	handler.now = func() time.Time { return time.Date(2024, 6, 20, 12, 0, 0, 0, time.UTC) }
+
+	app := createTestApp()
+	app.Post("/system-hook", handler.HandleWebhook)
+	app.Get("/api/v1/projects", handler.HandleListProjects)
+	return app, reg
+}
+
+func postSystemHook(t *testing.T, app *fiber.App, payload map[string]interface{}) map[string]interface{} {
+	body, _ := json.Marshal(payload)
+	req := httptest.NewRequest("POST", "/system-hook", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Gitlab-Event", "System Hook")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	var response map[string]interface{}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+	return response
+}
+
+func TestSystemHookHandler_ProjectEvents(t *testing.T) {
+	reviewed := 0
+	app, reg := newSystemHookTestApp(&reviewed)
+
+	response := postSystemHook(t, app, map[string]interface{}{
+		"event_name": "project_create", "project_id": 74, "path_with_namespace": "dataverse/dataverse-marts",
+	})
+	assert.Equal(t, true, response["registered"])
+	response = postSystemHook(t, app, map[string]interface{}{
+		"event_name": "project_create", "project_id": 75, "path_with_namespace": "jsmith/sandbox",
+	})
+	assert.Equal(t, false, response["registered"])
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/projects", nil))
+	assert.NoError(t, err)
+	var listed struct {
+		Projects []registry.Project `json:"projects"`
+	}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&listed))
+	if assert.Len(t, listed.Projects, 1) {
+		assert.Equal(t, "dataverse/dataverse-marts", listed.Projects[0].Path)
+	}
+
+	// Transferring a project out of the patterns offboards it
+	postSystemHook(t, app, map[string]interface{}{
+		"event_name": "project_transfer", "project_id": 74, "path_with_namespace": "archive/dataverse-marts",
+		"old_path_with_namespace": "dataverse/dataverse-marts",
+	})
+	project, err := reg.Get(74)
+	assert.NoError(t, err)
+	assert.Nil(t, project)
+
+	postSystemHook(t, app, map[string]interface{}{
+		"event_name": "project_rename", "project_id": 74, "path_with_namespace": "dataverse/marts",
+	})
+	postSystemHook(t, app, map[string]interface{}{"event_name": "project_destroy", "project_id": 74, "path_with_namespace": "dataverse/marts"})
+	project, err = reg.Get(74)
+	assert.NoError(t, err)
+	assert.Nil(t, project)
+
+	response = postSystemHook(t, app, map[string]interface{}{"event_name": "user_create", "user_id": 41})
+	assert.Equal(t, "skipped", response["decision"])
+	assert.Zero(t, reviewed)
+}
+
+func TestSystemHookHandler_MergeRequests(t *testing.T) {
+	reviewed := 0
+	app, reg := newSystemHookTestApp(&reviewed)
+
+	// MRs of projects created before system hooks were set up onboard the project
+	response := postSystemHook(t, app, map[string]interface{}{
+		"object_kind": "merge_request",
+		"project":     map[string]interface{}{"id": 80, "path_with_namespace": "dataverse/dataverse-config"},
+	})
+	assert.Equal(t, "reviewed", response["decision"])
+	assert.Equal(t, 1, reviewed)
+	project, err := reg.Get(80)
+	assert.NoError(t, err)
+	assert.NotNil(t, project)
+
+	response = postSystemHook(t, app, map[string]interface{}{
+		"object_kind": "merge_request",
+		"project":     map[string]interface{}{"id": 81, "path_with_namespace": "jsmith/sandbox"},
+	})
+	assert.Equal(t, "skipped", response["decision"])
+	assert.Equal(t, "project is not onboarded", response["reason"])
+	assert.Equal(t, 1, reviewed)
+}